@@ -0,0 +1,22 @@
+// Command fx runs the modular server composed with uber/fx instead of the hand-written
+// startup and shutdown of the serve command
+package main
+
+import (
+	"log"
+
+	"clean-arch-gin/internal/di/fxapp"
+	"clean-arch-gin/internal/infrastructure/config"
+
+	"github.com/joho/godotenv"
+	"go.uber.org/fx"
+)
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using system environment variables")
+	}
+
+	// Runs until SIGINT or SIGTERM, then stops the hooks in reverse order
+	fx.New(fxapp.Options(config.NewConfig())).Run()
+}
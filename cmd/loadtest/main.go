@@ -0,0 +1,346 @@
+// Command loadtest drives the user API with a weighted mix of create/get/list requests
+// and reports throughput and latency percentiles per operation.
+//
+// By default it starts the user module in-process (in-memory or SQLite repository),
+// so middleware and serialization costs can be profiled with -cpuprofile/-memprofile
+// or live through -pprof. Point -target at a running server to load test it instead.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	_ "net/http/pprof"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userRepositories "clean-arch-gin/internal/adapters/user/repositories"
+	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/interceptor"
+	"clean-arch-gin/internal/modules"
+	userModule "clean-arch-gin/internal/modules/user"
+
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	target := flag.String("target", "", "base URL of a running server; empty starts an in-process server")
+	store := flag.String("store", "memory", "repository for the in-process server: memory or sqlite")
+	duration := flag.Duration("duration", 10*time.Second, "how long to generate load")
+	concurrency := flag.Int("concurrency", runtime.NumCPU()*4, "number of concurrent workers")
+	mix := flag.String("mix", "create=1,get=4,list=2", "weighted operation mix")
+	seed := flag.Int("seed", 100, "users created before the run so reads have data")
+	cpuProfile := flag.String("cpuprofile", "", "write a CPU profile to this file")
+	memProfile := flag.String("memprofile", "", "write a heap profile to this file")
+	pprofAddr := flag.String("pprof", "", "serve net/http/pprof on this address while running (e.g. :6060)")
+	flag.Parse()
+
+	ops, err := parseMix(*mix)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	baseURL := *target
+	if baseURL == "" {
+		server, err := startServer(*store)
+		if err != nil {
+			log.Fatal("Failed to start in-process server:", err)
+		}
+		defer server.Close()
+		baseURL = server.URL
+	}
+	baseURL = strings.TrimRight(baseURL, "/") + "/api/v1/users"
+
+	if *pprofAddr != "" {
+		go func() {
+			log.Printf("pprof listening on %s", *pprofAddr)
+			log.Println(http.ListenAndServe(*pprofAddr, nil))
+		}()
+	}
+
+	client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{MaxIdleConnsPerHost: *concurrency},
+	}
+	lt := &loadTest{client: client, baseURL: baseURL}
+
+	for i := 0; i < *seed; i++ {
+		if _, err := lt.create(); err != nil {
+			log.Fatal("Failed to seed users:", err)
+		}
+	}
+
+	if *cpuProfile != "" {
+		f, err := os.Create(*cpuProfile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		if err := pprof.StartCPUProfile(f); err != nil {
+			log.Fatal(err)
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	log.Printf("Running %s against %s with %d workers (mix: %s)", *duration, baseURL, *concurrency, *mix)
+	results := lt.run(ops, *concurrency, *duration)
+	report(results, *duration)
+
+	if *memProfile != "" {
+		f, err := os.Create(*memProfile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// startServer runs the user module in-process exactly as main.go mounts it
+func startServer(store string) (*httptest.Server, error) {
+	gin.SetMode(gin.ReleaseMode)
+
+	var module modules.Module
+	switch store {
+	case "memory":
+		module = userModule.NewUserModuleWithRepository(nil, userRepositories.NewUserRepositoryMemory())
+	case "sqlite":
+		cfg := config.NewConfig()
+		cfg.DB.Driver = "sqlite"
+		cfg.DB.Name = "file:loadtest?mode=memory&cache=shared"
+		cfg.DB.LogLevel = "silent"
+
+		db, err := database.NewConnection(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if err := database.AutoMigrate(db, &models.UserModel{}); err != nil {
+			return nil, err
+		}
+		module = userModule.NewUserModule(db, nil, nil, nil, nil, nil, nil, nil, userModule.AccountMail{}, nil, userModule.TextMessages{}, nil, nil, 0, nil, nil, nil, interceptor.Stack{}, userModule.Pools{})
+	default:
+		return nil, fmt.Errorf("unknown store %q", store)
+	}
+
+	registry := modules.NewModuleRegistry()
+	registry.Register(module)
+	if err := registry.InitializeAll(); err != nil {
+		return nil, err
+	}
+
+	r := gin.New()
+	r.Use(gin.Recovery())
+	registry.RegisterAllRoutes(r.Group("/api/v1"))
+	return httptest.NewServer(r), nil
+}
+
+// operation is one weighted request type in the mix
+type operation struct {
+	name   string
+	weight int
+}
+
+// parseMix parses "create=1,get=4,list=2" into weighted operations
+func parseMix(mix string) ([]operation, error) {
+	var ops []operation
+	for _, part := range strings.Split(mix, ",") {
+		name, weightStr, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid mix entry %q", part)
+		}
+		weight, err := strconv.Atoi(weightStr)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight in mix entry %q", part)
+		}
+		switch name {
+		case "create", "get", "list":
+		default:
+			return nil, fmt.Errorf("unknown operation %q", name)
+		}
+		ops = append(ops, operation{name: name, weight: weight})
+	}
+	return ops, nil
+}
+
+// loadTest issues requests against the user API
+type loadTest struct {
+	client  *http.Client
+	baseURL string
+	seq     uint64
+	maxID   uint64
+}
+
+// result records every sample for one operation
+type result struct {
+	latencies []time.Duration
+	errors    int
+}
+
+// run executes the weighted mix with the given number of workers until duration elapses
+func (lt *loadTest) run(ops []operation, concurrency int, duration time.Duration) map[string]*result {
+	deadline := time.Now().Add(duration)
+	perWorker := make([]map[string]*result, concurrency)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(int64(w) + time.Now().UnixNano()))
+			results := make(map[string]*result)
+			perWorker[w] = results
+
+			for time.Now().Before(deadline) {
+				op := pick(ops, rng)
+				start := time.Now()
+				err := lt.do(op, rng)
+				elapsed := time.Since(start)
+
+				res, ok := results[op]
+				if !ok {
+					res = &result{}
+					results[op] = res
+				}
+				res.latencies = append(res.latencies, elapsed)
+				if err != nil {
+					res.errors++
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	merged := make(map[string]*result)
+	for _, results := range perWorker {
+		for op, res := range results {
+			m, ok := merged[op]
+			if !ok {
+				m = &result{}
+				merged[op] = m
+			}
+			m.latencies = append(m.latencies, res.latencies...)
+			m.errors += res.errors
+		}
+	}
+	return merged
+}
+
+// pick selects an operation according to its weight
+func pick(ops []operation, rng *rand.Rand) string {
+	total := 0
+	for _, op := range ops {
+		total += op.weight
+	}
+	n := rng.Intn(total)
+	for _, op := range ops {
+		if n < op.weight {
+			return op.name
+		}
+		n -= op.weight
+	}
+	return ops[len(ops)-1].name
+}
+
+// do performs a single operation
+func (lt *loadTest) do(op string, rng *rand.Rand) error {
+	switch op {
+	case "create":
+		_, err := lt.create()
+		return err
+	case "get":
+		maxID := atomic.LoadUint64(&lt.maxID)
+		if maxID == 0 {
+			maxID = 1
+		}
+		return lt.expect(http.MethodGet, fmt.Sprintf("%s/%d", lt.baseURL, rng.Int63n(int64(maxID))+1), nil, http.StatusOK)
+	default:
+		return lt.expect(http.MethodGet, lt.baseURL+"?limit=20&offset=0", nil, http.StatusOK)
+	}
+}
+
+// create registers a new unique user and tracks the highest ID seen
+func (lt *loadTest) create() (uint64, error) {
+	n := atomic.AddUint64(&lt.seq, 1)
+	body := fmt.Sprintf(`{"email":"load%d-%d@example.com","name":"Load %d","password":"password123"}`, time.Now().UnixNano(), n, n)
+	if err := lt.expect(http.MethodPost, lt.baseURL, []byte(body), http.StatusCreated); err != nil {
+		return 0, err
+	}
+	for {
+		maxID := atomic.LoadUint64(&lt.maxID)
+		if n <= maxID || atomic.CompareAndSwapUint64(&lt.maxID, maxID, n) {
+			return n, nil
+		}
+	}
+}
+
+// expect sends a request and checks the status code, draining the body so connections are reused
+func (lt *loadTest) expect(method, url string, body []byte, status int) error {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := lt.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != status {
+		return fmt.Errorf("%s %s: status %d, want %d", method, url, resp.StatusCode, status)
+	}
+	return nil
+}
+
+// report prints throughput and latency percentiles per operation
+func report(results map[string]*result, duration time.Duration) {
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("\n%-8s %10s %10s %8s %10s %10s %10s %10s\n", "op", "requests", "req/s", "errors", "p50", "p95", "p99", "max")
+	for _, name := range names {
+		res := results[name]
+		sort.Slice(res.latencies, func(i, j int) bool { return res.latencies[i] < res.latencies[j] })
+		fmt.Printf("%-8s %10d %10.1f %8d %10s %10s %10s %10s\n",
+			name,
+			len(res.latencies),
+			float64(len(res.latencies))/duration.Seconds(),
+			res.errors,
+			percentile(res.latencies, 0.50),
+			percentile(res.latencies, 0.95),
+			percentile(res.latencies, 0.99),
+			percentile(res.latencies, 1),
+		)
+	}
+}
+
+// percentile returns the p-th percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(float64(len(sorted)-1) * p)
+	return sorted[idx].Round(time.Microsecond)
+}
@@ -0,0 +1,247 @@
+// Command scaffold generates a feature module laid out like the existing ones: the domain
+// entity and ports, the use case, the HTTP controller and the module wiring.
+//
+// Flags choose the repository style (GORM, GORM Gen or in-memory), whether creating and
+// reading go through CQRS command and query handlers, and whether gRPC and GraphQL adapters
+// are generated. Existing files are never overwritten unless -force is given:
+//
+//	go run ./cmd/scaffold -name coupon -repo gen -cqrs -grpc
+package main
+
+import (
+	"bytes"
+	"embed"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Repository styles offered by -repo
+const (
+	repoGORM   = "gorm"
+	repoGen    = "gen"
+	repoMemory = "memory"
+)
+
+// namePattern restricts module names to one lower-case word, which is also the Go package name
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// reservedNames would collide with the identifiers of the generated code
+var reservedNames = map[string]bool{
+	"ctx": true, "err": true, "id": true, "name": true, "req": true, "resp": true, "page": true,
+	"dtos": true, "ids": true, "query": true, "cmd": true, "uc": true, "db": true, "rg": true,
+	"manage": true, "models": true, "params": true, "responses": true, "status": true,
+	"codes": true, "commands": true, "context": true, "controllers": true, "database": true,
+	"entities": true, "gin": true, "gorm": true, "grpc": true, "middleware": true, "modules": true,
+	"openapi": true, "queries": true, "repositories": true, "sort": true, "strings": true, "sync": true,
+	"time": true, "timestamppb": true, "usecases": true,
+}
+
+// Module is the data every template is rendered with
+type Module struct {
+	// Name is the singular lower-case name, e.g. coupon; it names the packages and files
+	Name string
+	// Plural names the routes, table and permission object, e.g. coupons
+	Plural string
+	// Title and PluralTitle are the exported forms, e.g. Coupon and Coupons
+	Title       string
+	PluralTitle string
+	Repo        string
+	CQRS        bool
+	GRPC        bool
+	GraphQL     bool
+}
+
+// UsesDB reports whether the module stores its records in the database
+func (m Module) UsesDB() bool {
+	return m.Repo != repoMemory
+}
+
+// file is one generated file; when reports whether the options ask for it
+type file struct {
+	template string
+	path     string
+	when     func(Module) bool
+}
+
+// always is the condition of the files every module gets
+func always(Module) bool { return true }
+
+// files lists the generated files; paths are templates rendered with the Module
+var files = []file{
+	{"entity.go.tmpl", "internal/domain/{{.Name}}/entities/{{.Name}}.go", always},
+	{"domain_repository.go.tmpl", "internal/domain/{{.Name}}/repositories/{{.Name}}_repository.go", always},
+	{"domain_usecase.go.tmpl", "internal/domain/{{.Name}}/usecases/{{.Name}}_usecase.go", always},
+	{"usecase_impl.go.tmpl", "internal/adapters/{{.Name}}/usecases/{{.Name}}_usecase_impl.go", always},
+	{"controller.go.tmpl", "internal/adapters/{{.Name}}/controllers/{{.Name}}_controller.go", always},
+	{"module.go.tmpl", "internal/modules/{{.Name}}/{{.Name}}_module.go", always},
+
+	{"model.go.tmpl", "internal/adapters/shared/models/{{.Name}}_model.go", Module.UsesDB},
+	{"repository_gorm.go.tmpl", "internal/adapters/{{.Name}}/repositories/{{.Name}}_repository.go", Module.UsesDB},
+	{"repository_gen.go.tmpl", "internal/adapters/{{.Name}}/repositories/{{.Name}}_repository_gen.go",
+		func(m Module) bool { return m.Repo == repoGen }},
+	{"repository_memory.go.tmpl", "internal/adapters/{{.Name}}/repositories/{{.Name}}_repository_memory.go",
+		func(m Module) bool { return m.Repo == repoMemory }},
+
+	{"command.go.tmpl", "internal/application/{{.Name}}/commands/create_{{.Name}}_command.go",
+		func(m Module) bool { return m.CQRS }},
+	{"query.go.tmpl", "internal/application/{{.Name}}/queries/get_{{.Name}}_query.go",
+		func(m Module) bool { return m.CQRS }},
+
+	{"service.proto.tmpl", "api/proto/{{.Name}}/v1/{{.Name}}.proto", func(m Module) bool { return m.GRPC }},
+	{"service_http.yaml.tmpl", "api/proto/{{.Name}}/v1/{{.Name}}_http.yaml", func(m Module) bool { return m.GRPC }},
+	{"grpc_server.go.tmpl", "internal/adapters/{{.Name}}/grpc/{{.Name}}_grpc_server.go", func(m Module) bool { return m.GRPC }},
+
+	{"schema.graphqls.tmpl", "internal/adapters/graph/{{.Name}}.graphqls", func(m Module) bool { return m.GraphQL }},
+}
+
+func main() {
+	name := flag.String("name", "", "singular lower-case module name, e.g. coupon (required)")
+	plural := flag.String("plural", "", "plural used for routes and the table; defaults to the name with an s")
+	repo := flag.String("repo", repoGORM, "repository style: gorm, gen or memory")
+	cqrs := flag.Bool("cqrs", false, "create and read through CQRS command and query handlers")
+	grpc := flag.Bool("grpc", false, "generate the gRPC service definition and server adapter")
+	graphql := flag.Bool("graphql", false, "generate a GraphQL schema extension for the module")
+	root := flag.String("root", ".", "repository root the files are written under")
+	force := flag.Bool("force", false, "overwrite files that already exist")
+	flag.Parse()
+
+	m, err := newModule(*name, *plural, *repo, *cqrs, *grpc, *graphql)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	templates, err := template.ParseFS(templateFS, "templates/*.tmpl")
+	if err != nil {
+		log.Fatal("Failed to parse templates:", err)
+	}
+
+	written, err := generate(templates, m, *root, *force)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, path := range written {
+		fmt.Println("created", path)
+	}
+	printNextSteps(m)
+}
+
+// newModule validates the flags and derives the names used by the templates
+func newModule(name, plural, repo string, cqrs, grpc, graphql bool) (Module, error) {
+	if plural == "" {
+		plural = name + "s"
+	}
+	for flagName, value := range map[string]string{"-name": name, "-plural": plural} {
+		if !namePattern.MatchString(value) || token.IsKeyword(value) || reservedNames[value] {
+			return Module{}, fmt.Errorf("%s must be one lower-case word usable as a Go identifier, got %q", flagName, value)
+		}
+	}
+	if plural == name {
+		return Module{}, fmt.Errorf("-plural must differ from -name")
+	}
+	switch repo {
+	case repoGORM, repoGen, repoMemory:
+	default:
+		return Module{}, fmt.Errorf("-repo must be gorm, gen or memory, got %q", repo)
+	}
+
+	return Module{
+		Name:        name,
+		Plural:      plural,
+		Title:       strings.ToUpper(name[:1]) + name[1:],
+		PluralTitle: strings.ToUpper(plural[:1]) + plural[1:],
+		Repo:        repo,
+		CQRS:        cqrs,
+		GRPC:        grpc,
+		GraphQL:     graphql,
+	}, nil
+}
+
+// generate renders every file the module asks for under root and returns their paths
+// Nothing is written when any of them exists and force is false
+func generate(templates *template.Template, m Module, root string, force bool) ([]string, error) {
+	rendered := make(map[string][]byte)
+	var paths []string
+	for _, f := range files {
+		if !f.when(m) {
+			continue
+		}
+		var path strings.Builder
+		if err := template.Must(template.New(f.template).Parse(f.path)).Execute(&path, m); err != nil {
+			return nil, err
+		}
+		content, err := render(templates, f.template, path.String(), m)
+		if err != nil {
+			return nil, err
+		}
+		target := filepath.Join(root, filepath.FromSlash(path.String()))
+		if _, err := os.Stat(target); err == nil && !force {
+			return nil, fmt.Errorf("%s already exists; use -force to overwrite it", target)
+		}
+		rendered[target] = content
+		paths = append(paths, target)
+	}
+
+	for _, path := range paths {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, rendered[path], 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// render renders one template, formatting Go sources as gofmt does
+func render(templates *template.Template, name, path string, m Module) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, m); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", path, err)
+	}
+	if !strings.HasSuffix(path, ".go") {
+		return buf.Bytes(), nil
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated %s is not valid Go: %w", path, err)
+	}
+	return formatted, nil
+}
+
+// printNextSteps lists what is left to do by hand, in order
+func printNextSteps(m Module) {
+	steps := []string{
+		fmt.Sprintf("Register %s.New%sModule in app.NewModuleRegistry", m.Name, m.Title),
+		"Run just mocks to generate the mocks of the new domain interfaces",
+	}
+	if m.Repo == repoGen {
+		steps = append(steps,
+			"Run just gen-query; the module declares its model in Queries()",
+			fmt.Sprintf("Remove the build constraint of %s_repository_gen.go and use New%sRepositoryGen in the module",
+				m.Name, m.Title))
+	}
+	if m.GRPC {
+		steps = append(steps, "Run just proto to generate the gRPC stubs the server adapter uses")
+	}
+	if m.GraphQL {
+		steps = append(steps, fmt.Sprintf(
+			"Run just gql and fill in the %s resolvers with the use case added to graph.Resolver", m.Name))
+	}
+	steps = append(steps, fmt.Sprintf("Grant the %s manage permission to the roles that may change %s", m.Plural, m.Plural))
+
+	fmt.Println("\nNext steps:")
+	for i, step := range steps {
+		fmt.Printf("  %d. %s\n", i+1, step)
+	}
+}
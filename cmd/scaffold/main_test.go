@@ -0,0 +1,163 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+// parseTemplates parses the embedded templates like main does
+func parseTemplates(t *testing.T) *template.Template {
+	t.Helper()
+	templates, err := template.ParseFS(templateFS, "templates/*.tmpl")
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+	return templates
+}
+
+func TestGenerateWritesTheFilesOfEveryOption(t *testing.T) {
+	templates := parseTemplates(t)
+
+	tests := []struct {
+		name    string
+		repo    string
+		cqrs    bool
+		grpc    bool
+		graphql bool
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "gorm", repo: repoGORM,
+			want: []string{
+				"internal/domain/coupon/entities/coupon.go",
+				"internal/modules/coupon/coupon_module.go",
+				"internal/adapters/shared/models/coupon_model.go",
+				"internal/adapters/coupon/repositories/coupon_repository.go",
+			},
+			notWant: []string{
+				"internal/adapters/coupon/repositories/coupon_repository_gen.go",
+				"internal/application/coupon/commands/create_coupon_command.go",
+			},
+		},
+		{
+			name: "gen with every adapter", repo: repoGen, cqrs: true, grpc: true, graphql: true,
+			want: []string{
+				"internal/adapters/coupon/repositories/coupon_repository_gen.go",
+				"internal/application/coupon/commands/create_coupon_command.go",
+				"internal/application/coupon/queries/get_coupon_query.go",
+				"api/proto/coupon/v1/coupon.proto",
+				"api/proto/coupon/v1/coupon_http.yaml",
+				"internal/adapters/coupon/grpc/coupon_grpc_server.go",
+				"internal/adapters/graph/coupon.graphqls",
+			},
+		},
+		{
+			name: "memory", repo: repoMemory,
+			want: []string{"internal/adapters/coupon/repositories/coupon_repository_memory.go"},
+			notWant: []string{
+				"internal/adapters/shared/models/coupon_model.go",
+				"internal/adapters/coupon/repositories/coupon_repository.go",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := newModule("coupon", "", tt.repo, tt.cqrs, tt.grpc, tt.graphql)
+			if err != nil {
+				t.Fatalf("newModule() error = %v", err)
+			}
+			root := t.TempDir()
+			written, err := generate(templates, m, root, false)
+			if err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+
+			for _, path := range tt.want {
+				if _, err := os.Stat(filepath.Join(root, path)); err != nil {
+					t.Errorf("%s was not generated", path)
+				}
+			}
+			for _, path := range tt.notWant {
+				if _, err := os.Stat(filepath.Join(root, path)); err == nil {
+					t.Errorf("%s was generated", path)
+				}
+			}
+			for _, path := range written {
+				if !strings.HasSuffix(path, ".go") {
+					continue
+				}
+				if _, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.AllErrors); err != nil {
+					t.Errorf("generated %s does not parse: %v", path, err)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateRefusesToOverwrite(t *testing.T) {
+	templates := parseTemplates(t)
+	m, err := newModule("coupon", "", repoGORM, false, false, false)
+	if err != nil {
+		t.Fatalf("newModule() error = %v", err)
+	}
+	root := t.TempDir()
+	entity := filepath.Join(root, "internal/domain/coupon/entities/coupon.go")
+	if err := os.MkdirAll(filepath.Dir(entity), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(entity, []byte("package entities\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := generate(templates, m, root, false); err == nil {
+		t.Fatal("generate() over an existing file succeeded, want an error")
+	}
+	if _, err := os.Stat(filepath.Join(root, "internal/modules/coupon/coupon_module.go")); err == nil {
+		t.Error("generate() wrote files although one already existed")
+	}
+
+	if _, err := generate(templates, m, root, true); err != nil {
+		t.Fatalf("generate() with force error = %v", err)
+	}
+	content, err := os.ReadFile(entity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "type Coupon struct") {
+		t.Error("generate() with force did not overwrite the existing file")
+	}
+}
+
+func TestNewModule(t *testing.T) {
+	m, err := newModule("category", "categories", repoGen, true, false, false)
+	if err != nil {
+		t.Fatalf("newModule() error = %v", err)
+	}
+	if m.Title != "Category" || m.PluralTitle != "Categories" || !m.UsesDB() {
+		t.Errorf("newModule() = %+v", m)
+	}
+
+	invalid := []struct {
+		name, plural, repo string
+	}{
+		{"", "", repoGORM},
+		{"Coupon", "", repoGORM},
+		{"gift_card", "", repoGORM},
+		{"type", "", repoGORM},
+		{"status", "", repoGORM},
+		{"coupon", "coupon", repoGORM},
+		{"coupon", "", "sql"},
+	}
+	for _, tt := range invalid {
+		if _, err := newModule(tt.name, tt.plural, tt.repo, false, false, false); err == nil {
+			t.Errorf("newModule(%q, %q, %q) succeeded, want an error", tt.name, tt.plural, tt.repo)
+		}
+	}
+}
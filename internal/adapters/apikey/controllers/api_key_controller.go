@@ -0,0 +1,239 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	apikeyEntities "clean-arch-gin/internal/domain/apikey/entities"
+	apikeyUsecases "clean-arch-gin/internal/domain/apikey/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateKeyRequest represents the request for creating an API key
+type CreateKeyRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// SetLimitsRequest represents the request for changing the limits of an API key; 0 is
+// unlimited
+type SetLimitsRequest struct {
+	RatePerMinute int   `json:"rate_per_minute" binding:"min=0"`
+	MonthlyQuota  int64 `json:"monthly_quota" binding:"min=0"`
+}
+
+// LimitsDTO represents the limits of an API key in API responses; 0 is unlimited
+type LimitsDTO struct {
+	RatePerMinute int   `json:"rate_per_minute"`
+	MonthlyQuota  int64 `json:"monthly_quota"`
+}
+
+// APIKeyDTO represents an API key in API responses
+type APIKeyDTO struct {
+	// ID is the key's public ID
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Display is the start of the key, to tell keys apart
+	Display string `json:"display"`
+	// Key is only returned when the key is created
+	Key        string     `json:"key,omitempty"`
+	Limits     LimitsDTO  `json:"limits"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// APIKeyListResponse is the response of ListKeys
+type APIKeyListResponse struct {
+	Keys []APIKeyDTO `json:"keys"`
+}
+
+// UsageResponse is the use of an API key in the current month
+type UsageResponse struct {
+	// KeyID is the key's public ID
+	KeyID string `json:"key_id"`
+	// Period is the month, as YYYY-MM in UTC
+	Period string    `json:"period"`
+	Used   int64     `json:"used"`
+	Limits LimitsDTO `json:"limits"`
+	// Remaining is left out when the key has no monthly quota
+	Remaining *int64    `json:"remaining,omitempty"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+// toAPIKeyDTO converts domain entity to DTO without the key
+func toAPIKeyDTO(key *apikeyEntities.APIKey) APIKeyDTO {
+	return APIKeyDTO{
+		ID:         key.PublicID,
+		Name:       key.Name,
+		Display:    key.Display,
+		Limits:     toLimitsDTO(key.Limits),
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
+
+// toLimitsDTO converts domain limits to DTO
+func toLimitsDTO(limits apikeyEntities.Limits) LimitsDTO {
+	return LimitsDTO{RatePerMinute: limits.RatePerMinute, MonthlyQuota: limits.MonthlyQuota}
+}
+
+// APIKeyController handles HTTP requests for API keys
+type APIKeyController struct {
+	apiKeyUseCase apikeyUsecases.APIKeyUseCase
+}
+
+// NewAPIKeyController creates a new API key controller
+func NewAPIKeyController(apiKeyUseCase apikeyUsecases.APIKeyUseCase) *APIKeyController {
+	return &APIKeyController{
+		apiKeyUseCase: apiKeyUseCase,
+	}
+}
+
+// CreateKey creates a key for the current user and returns it once; a request made with an
+// API key cannot create others
+func (kc *APIKeyController) CreateKey(c *gin.Context) {
+	if _, ok := middleware.APIKeyID(c); ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "API keys cannot create API keys"})
+		return
+	}
+	ownerID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req CreateKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	key, secret, err := kc.apiKeyUseCase.CreateKey(c.Request.Context(), ownerID, req.Name)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	dto := toAPIKeyDTO(key)
+	dto.Key = secret
+	middleware.AllowSensitiveFields(c, "key")
+	c.JSON(http.StatusCreated, dto)
+}
+
+// ListKeys retrieves the current user's keys
+func (kc *APIKeyController) ListKeys(c *gin.Context) {
+	ownerID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	keys, err := kc.apiKeyUseCase.ListKeys(c.Request.Context(), ownerID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	dtos := make([]APIKeyDTO, len(keys))
+	for i, key := range keys {
+		dtos[i] = toAPIKeyDTO(key)
+	}
+	c.JSON(http.StatusOK, APIKeyListResponse{Keys: dtos})
+}
+
+// RevokeKey revokes one of the current user's keys
+func (kc *APIKeyController) RevokeKey(c *gin.Context) {
+	ownerID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key ID"})
+		return
+	}
+
+	if err := kc.apiKeyUseCase.RevokeKey(c.Request.Context(), ownerID, id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetUsage retrieves the month's use of one of the current user's keys
+func (kc *APIKeyController) GetUsage(c *gin.Context) {
+	ownerID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key ID"})
+		return
+	}
+
+	usage, err := kc.apiKeyUseCase.GetUsage(c.Request.Context(), ownerID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response := UsageResponse{
+		KeyID:    usage.KeyPublicID,
+		Period:   usage.Period,
+		Used:     usage.Used,
+		Limits:   toLimitsDTO(usage.Limits),
+		ResetsAt: usage.ResetsAt,
+	}
+	if usage.Limits.MonthlyQuota > 0 {
+		remaining := max(usage.Limits.MonthlyQuota-usage.Used, 0)
+		response.Remaining = &remaining
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// SetLimits changes the limits of any key
+func (kc *APIKeyController) SetLimits(c *gin.Context) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key ID"})
+		return
+	}
+
+	var req SetLimitsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	key, err := kc.apiKeyUseCase.SetLimits(c.Request.Context(), id, apikeyEntities.Limits{
+		RatePerMinute: req.RatePerMinute,
+		MonthlyQuota:  req.MonthlyQuota,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAPIKeyDTO(key))
+}
+
+// respondError maps API key domain errors to HTTP responses
+func respondError(c *gin.Context, err error) {
+	switch err {
+	case apikeyEntities.ErrAPIKeyNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case apikeyEntities.ErrInvalidKeyName, apikeyEntities.ErrInvalidLimits:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	apikeyEntities "clean-arch-gin/internal/domain/apikey/entities"
+	apikeyRepositories "clean-arch-gin/internal/domain/apikey/repositories"
+
+	"gorm.io/gorm"
+)
+
+// apiKeyRepository implements APIKeyRepository using GORM
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *gorm.DB) apikeyRepositories.APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// Create stores a key and assigns its ID
+func (r *apiKeyRepository) Create(ctx context.Context, key *apikeyEntities.APIKey) error {
+	model := models.NewAPIKeyModelFromEntity(key)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+	key.ID = model.ID
+	return nil
+}
+
+// GetByID retrieves a key by ID
+func (r *apiKeyRepository) GetByID(ctx context.Context, id uint) (*apikeyEntities.APIKey, error) {
+	return r.first(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// GetByPublicID retrieves a key by public ID
+func (r *apiKeyRepository) GetByPublicID(ctx context.Context, publicID string) (*apikeyEntities.APIKey, error) {
+	return r.first(r.db.WithContext(ctx).Where("public_id = ?", publicID))
+}
+
+// GetByHash retrieves a key, revoked or not, by its hash
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*apikeyEntities.APIKey, error) {
+	return r.first(r.db.WithContext(ctx).Where("key_hash = ?", keyHash))
+}
+
+// ListByOwner retrieves the owner's keys, newest first
+func (r *apiKeyRepository) ListByOwner(ctx context.Context, ownerID uint) ([]*apikeyEntities.APIKey, error) {
+	var keyModels []models.APIKeyModel
+	if err := r.db.WithContext(ctx).Where("owner_id = ?", ownerID).Order("id DESC").Find(&keyModels).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make([]*apikeyEntities.APIKey, len(keyModels))
+	for i := range keyModels {
+		keys[i] = keyModels[i].ToDomainEntity()
+	}
+	return keys, nil
+}
+
+// Update saves the limits and revocation of a key
+func (r *apiKeyRepository) Update(ctx context.Context, key *apikeyEntities.APIKey) error {
+	return r.db.WithContext(ctx).Model(&models.APIKeyModel{}).Where("id = ?", key.ID).Updates(map[string]interface{}{
+		"rate_per_minute": key.Limits.RatePerMinute,
+		"monthly_quota":   key.Limits.MonthlyQuota,
+		"revoked_at":      key.RevokedAt,
+	}).Error
+}
+
+// Touch records the last use of a key
+func (r *apiKeyRepository) Touch(ctx context.Context, id uint, usedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.APIKeyModel{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
+
+// first retrieves the first key matching query
+func (r *apiKeyRepository) first(query *gorm.DB) (*apikeyEntities.APIKey, error) {
+	var model models.APIKeyModel
+	if err := query.First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apikeyEntities.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomainEntity(), nil
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apikeyEntities "clean-arch-gin/internal/domain/apikey/entities"
+	apikeyRepositories "clean-arch-gin/internal/domain/apikey/repositories"
+)
+
+// usageCounterMemory implements UsageCounter in process memory, so each replica counts its
+// own requests and the counts are lost on restart; suited to single instances and local runs
+type usageCounterMemory struct {
+	mu      sync.Mutex
+	minutes map[uint]windowCount
+	months  map[uint]periodCount
+}
+
+// windowCount is the requests of a key in the minute starting at start
+type windowCount struct {
+	start time.Time
+	used  int
+}
+
+// periodCount is the requests of a key in a month
+type periodCount struct {
+	period string
+	used   int64
+}
+
+// NewUsageCounterMemory creates a usage counter counting in memory
+func NewUsageCounterMemory() apikeyRepositories.UsageCounter {
+	return &usageCounterMemory{
+		minutes: make(map[uint]windowCount),
+		months:  make(map[uint]periodCount),
+	}
+}
+
+// Take counts a request of keyID at now unless it is over limits
+func (c *usageCounterMemory) Take(ctx context.Context, keyID uint, limits apikeyEntities.Limits, now time.Time) (apikeyEntities.Allowance, error) {
+	windowStart, windowEnd := apikeyEntities.RateWindow(now)
+	period, periodEnd := apikeyEntities.QuotaPeriod(now)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	minute := c.minutes[keyID]
+	if !minute.start.Equal(windowStart) {
+		minute = windowCount{start: windowStart}
+	}
+	month := c.months[keyID]
+	if month.period != period {
+		month = periodCount{period: period}
+	}
+
+	allowance := apikeyEntities.Allowance{
+		Limits:     limits,
+		RateUsed:   minute.used,
+		RateReset:  windowEnd,
+		QuotaUsed:  month.used,
+		QuotaReset: periodEnd,
+	}
+	if (limits.RatePerMinute > 0 && minute.used >= limits.RatePerMinute) ||
+		(limits.MonthlyQuota > 0 && month.used >= limits.MonthlyQuota) {
+		return allowance, nil
+	}
+
+	minute.used++
+	month.used++
+	c.minutes[keyID] = minute
+	c.months[keyID] = month
+	allowance.Allowed = true
+	allowance.RateUsed = minute.used
+	allowance.QuotaUsed = month.used
+	return allowance, nil
+}
+
+// MonthlyUsage returns the requests keyID made in the month of now
+func (c *usageCounterMemory) MonthlyUsage(ctx context.Context, keyID uint, now time.Time) (int64, error) {
+	period, _ := apikeyEntities.QuotaPeriod(now)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if month := c.months[keyID]; month.period == period {
+		return month.used, nil
+	}
+	return 0, nil
+}
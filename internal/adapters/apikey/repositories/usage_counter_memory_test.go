@@ -0,0 +1,92 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/adapters/apikey/repositories"
+	apikeyEntities "clean-arch-gin/internal/domain/apikey/entities"
+)
+
+func TestUsageCounterMemoryLimits(t *testing.T) {
+	// start is the beginning of a minute in the middle of a month
+	start := time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		limits apikeyEntities.Limits
+		// at are the offsets from start of the requests, allowed as in want
+		at                []time.Duration
+		want              []bool
+		wantQuotaExceeded bool
+		wantMonthlyUsage  int64
+	}{
+		{
+			name:             "unlimited",
+			at:               []time.Duration{0, 0, 0},
+			want:             []bool{true, true, true},
+			wantMonthlyUsage: 3,
+		},
+		{
+			name:             "rate limited within the minute",
+			limits:           apikeyEntities.Limits{RatePerMinute: 2},
+			at:               []time.Duration{0, 10 * time.Second, 59 * time.Second},
+			want:             []bool{true, true, false},
+			wantMonthlyUsage: 2,
+		},
+		{
+			name:             "rate resets with the next minute",
+			limits:           apikeyEntities.Limits{RatePerMinute: 1},
+			at:               []time.Duration{0, 30 * time.Second, time.Minute},
+			want:             []bool{true, false, true},
+			wantMonthlyUsage: 2,
+		},
+		{
+			name:              "monthly quota spent",
+			limits:            apikeyEntities.Limits{RatePerMinute: 10, MonthlyQuota: 2},
+			at:                []time.Duration{0, time.Hour, 48 * time.Hour},
+			want:              []bool{true, true, false},
+			wantQuotaExceeded: true,
+			wantMonthlyUsage:  2,
+		},
+		{
+			name:             "quota resets with the next month",
+			limits:           apikeyEntities.Limits{MonthlyQuota: 1},
+			at:               []time.Duration{0, 17 * 24 * time.Hour},
+			want:             []bool{true, true},
+			wantMonthlyUsage: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			counter := repositories.NewUsageCounterMemory()
+			var last apikeyEntities.Allowance
+			for i, offset := range tt.at {
+				allowance, err := counter.Take(ctx, 1, tt.limits, start.Add(offset))
+				if err != nil {
+					t.Fatalf("Take() error = %v", err)
+				}
+				if allowance.Allowed != tt.want[i] {
+					t.Errorf("request %d allowed = %v, want %v", i, allowance.Allowed, tt.want[i])
+				}
+				last = allowance
+			}
+			if last.QuotaExceeded() != tt.wantQuotaExceeded {
+				t.Errorf("QuotaExceeded() = %v, want %v", last.QuotaExceeded(), tt.wantQuotaExceeded)
+			}
+
+			used, err := counter.MonthlyUsage(ctx, 1, start.Add(tt.at[len(tt.at)-1]))
+			if err != nil {
+				t.Fatalf("MonthlyUsage() error = %v", err)
+			}
+			if used != tt.wantMonthlyUsage {
+				t.Errorf("MonthlyUsage() = %d, want %d", used, tt.wantMonthlyUsage)
+			}
+			if other, _ := counter.MonthlyUsage(ctx, 2, start); other != 0 {
+				t.Errorf("MonthlyUsage() of another key = %d, want 0", other)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	apikeyEntities "clean-arch-gin/internal/domain/apikey/entities"
+	apikeyRepositories "clean-arch-gin/internal/domain/apikey/repositories"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// monthRetention is how long a month's count outlives the month, for the usage endpoint to
+// still read it around the reset
+const monthRetention = 24 * time.Hour
+
+// takeScript counts a request in the minute and month counters unless either is at its
+// limit, 0 being unlimited, and returns whether it did with both counts, atomically
+var takeScript = redis.NewScript(`
+local rate, quota = tonumber(ARGV[1]), tonumber(ARGV[2])
+local minute = tonumber(redis.call("GET", KEYS[1]) or "0")
+local month = tonumber(redis.call("GET", KEYS[2]) or "0")
+if (rate > 0 and minute >= rate) or (quota > 0 and month >= quota) then
+	return {0, minute, month}
+end
+minute = redis.call("INCR", KEYS[1])
+if minute == 1 then
+	redis.call("PEXPIREAT", KEYS[1], ARGV[3])
+end
+month = redis.call("INCR", KEYS[2])
+if month == 1 then
+	redis.call("PEXPIREAT", KEYS[2], ARGV[4])
+end
+return {1, minute, month}
+`)
+
+// usageCounterRedis implements UsageCounter on Redis, so every replica shares the counts
+// The requests of a key are counted under key:<id>:minute:<unix minute>, expiring with the
+// minute, and key:<id>:month:<YYYY-MM>, expiring a day after the month
+type usageCounterRedis struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewUsageCounterRedis creates a usage counter storing keys under prefix
+func NewUsageCounterRedis(client redis.UniversalClient, prefix string) apikeyRepositories.UsageCounter {
+	return &usageCounterRedis{client: client, prefix: prefix}
+}
+
+// Take counts a request of keyID at now unless it is over limits
+func (c *usageCounterRedis) Take(ctx context.Context, keyID uint, limits apikeyEntities.Limits, now time.Time) (apikeyEntities.Allowance, error) {
+	windowStart, windowEnd := apikeyEntities.RateWindow(now)
+	period, periodEnd := apikeyEntities.QuotaPeriod(now)
+
+	counts, err := takeScript.Run(ctx, c.client,
+		[]string{c.minuteKey(keyID, windowStart), c.monthKey(keyID, period)},
+		limits.RatePerMinute, limits.MonthlyQuota,
+		windowEnd.UnixMilli(), periodEnd.Add(monthRetention).UnixMilli()).Int64Slice()
+	if err != nil {
+		return apikeyEntities.Allowance{}, err
+	}
+	return apikeyEntities.Allowance{
+		Allowed:    counts[0] == 1,
+		Limits:     limits,
+		RateUsed:   int(counts[1]),
+		RateReset:  windowEnd,
+		QuotaUsed:  counts[2],
+		QuotaReset: periodEnd,
+	}, nil
+}
+
+// MonthlyUsage returns the requests keyID made in the month of now
+func (c *usageCounterRedis) MonthlyUsage(ctx context.Context, keyID uint, now time.Time) (int64, error) {
+	period, _ := apikeyEntities.QuotaPeriod(now)
+	used, err := c.client.Get(ctx, c.monthKey(keyID, period)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return used, err
+}
+
+// minuteKey is the key counting the requests of keyID in the minute starting at start
+func (c *usageCounterRedis) minuteKey(keyID uint, start time.Time) string {
+	return c.prefix + "key:" + strconv.FormatUint(uint64(keyID), 10) + ":minute:" + strconv.FormatInt(start.Unix()/60, 10)
+}
+
+// monthKey is the key counting the requests of keyID in period
+func (c *usageCounterRedis) monthKey(keyID uint, period string) string {
+	return c.prefix + "key:" + strconv.FormatUint(uint64(keyID), 10) + ":month:" + period
+}
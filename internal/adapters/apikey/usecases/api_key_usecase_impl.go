@@ -0,0 +1,175 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+
+	apikeyEntities "clean-arch-gin/internal/domain/apikey/entities"
+	apikeyRepositories "clean-arch-gin/internal/domain/apikey/repositories"
+	apikeyUsecases "clean-arch-gin/internal/domain/apikey/usecases"
+)
+
+// touchInterval is how often the last use of a key is written, so busy keys do not write
+// on every request
+const touchInterval = time.Minute
+
+// apiKeyUseCase implements the APIKeyUseCase interface
+type apiKeyUseCase struct {
+	keyRepo  apikeyRepositories.APIKeyRepository
+	counter  apikeyRepositories.UsageCounter
+	defaults apikeyEntities.Limits
+}
+
+// NewAPIKeyUseCase creates a new API key use case issuing keys with defaults as their limits
+// and counting their requests with counter
+func NewAPIKeyUseCase(keyRepo apikeyRepositories.APIKeyRepository, counter apikeyRepositories.UsageCounter,
+	defaults apikeyEntities.Limits) apikeyUsecases.APIKeyUseCase {
+	return &apiKeyUseCase{
+		keyRepo:  keyRepo,
+		counter:  counter,
+		defaults: defaults,
+	}
+}
+
+// CreateKey generates a key and stores its hash
+func (uc *apiKeyUseCase) CreateKey(ctx context.Context, ownerID uint, name string) (*apikeyEntities.APIKey, string, error) {
+	secret, err := newAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	key, err := apikeyEntities.NewAPIKey(ownerID, name, secret[:apikeyEntities.DisplayLength], hashAPIKey(secret), uc.defaults)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := uc.keyRepo.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+	return key, secret, nil
+}
+
+// ListKeys retrieves the owner's keys
+func (uc *apiKeyUseCase) ListKeys(ctx context.Context, ownerID uint) ([]*apikeyEntities.APIKey, error) {
+	return uc.keyRepo.ListByOwner(ctx, ownerID)
+}
+
+// GetKeyByPublicID retrieves a key by its public ID
+func (uc *apiKeyUseCase) GetKeyByPublicID(ctx context.Context, publicID string) (*apikeyEntities.APIKey, error) {
+	return uc.keyRepo.GetByPublicID(ctx, publicID)
+}
+
+// RevokeKey revokes one of the owner's keys; revoking twice is harmless
+func (uc *apiKeyUseCase) RevokeKey(ctx context.Context, ownerID, id uint) error {
+	key, err := uc.ownedKey(ctx, ownerID, id)
+	if err != nil {
+		return err
+	}
+	if key.IsRevoked() {
+		return nil
+	}
+	key.Revoke()
+	return uc.keyRepo.Update(ctx, key)
+}
+
+// GetUsage reads the month's count of one of the owner's keys
+func (uc *apiKeyUseCase) GetUsage(ctx context.Context, ownerID, id uint) (*apikeyEntities.Usage, error) {
+	key, err := uc.ownedKey(ctx, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	used, err := uc.counter.MonthlyUsage(ctx, key.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	period, resetsAt := apikeyEntities.QuotaPeriod(now)
+	return &apikeyEntities.Usage{
+		KeyID:       key.ID,
+		KeyPublicID: key.PublicID,
+		Period:      period,
+		Used:        used,
+		Limits:      key.Limits,
+		ResetsAt:    resetsAt,
+	}, nil
+}
+
+// SetLimits validates and saves new limits, applied from the key's next request
+func (uc *apiKeyUseCase) SetLimits(ctx context.Context, id uint, limits apikeyEntities.Limits) (*apikeyEntities.APIKey, error) {
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
+	key, err := uc.keyRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	key.Limits = limits
+	if err := uc.keyRepo.Update(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Authenticate looks the key up by its hash
+func (uc *apiKeyUseCase) Authenticate(ctx context.Context, secret string) (*apikeyEntities.APIKey, error) {
+	if !strings.HasPrefix(secret, apikeyEntities.KeyPrefix) {
+		return nil, apikeyEntities.ErrInvalidAPIKey
+	}
+	key, err := uc.keyRepo.GetByHash(ctx, hashAPIKey(secret))
+	if err == apikeyEntities.ErrAPIKeyNotFound {
+		return nil, apikeyEntities.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key.IsRevoked() {
+		return nil, apikeyEntities.ErrInvalidAPIKey
+	}
+
+	// The request is authenticated either way; a failed write only loses the last-used time
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= touchInterval {
+		if err := uc.keyRepo.Touch(ctx, key.ID, now); err != nil {
+			log.Printf("failed to record the use of API key %d: %v", key.ID, err)
+		} else {
+			key.LastUsedAt = &now
+		}
+	}
+	return key, nil
+}
+
+// Consume takes a request from the key's minute and month
+func (uc *apiKeyUseCase) Consume(ctx context.Context, key *apikeyEntities.APIKey) (apikeyEntities.Allowance, error) {
+	return uc.counter.Take(ctx, key.ID, key.Limits, time.Now())
+}
+
+// ownedKey retrieves a key of ownerID; the keys of others are reported as not found
+func (uc *apiKeyUseCase) ownedKey(ctx context.Context, ownerID, id uint) (*apikeyEntities.APIKey, error) {
+	key, err := uc.keyRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.OwnerID != ownerID {
+		return nil, apikeyEntities.ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+// newAPIKey generates a random key
+func newAPIKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return apikeyEntities.KeyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashAPIKey derives the stored form of a key
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
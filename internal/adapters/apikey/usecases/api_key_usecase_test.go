@@ -0,0 +1,170 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	apikeyRepositories "clean-arch-gin/internal/adapters/apikey/repositories"
+	"clean-arch-gin/internal/adapters/apikey/usecases"
+	"clean-arch-gin/internal/adapters/shared/models"
+	apikeyEntities "clean-arch-gin/internal/domain/apikey/entities"
+	apikeyUsecases "clean-arch-gin/internal/domain/apikey/usecases"
+	"clean-arch-gin/internal/domain/shared/publicid"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/testutil/repotest"
+)
+
+// newAPIKeyUseCase creates a use case on SQLite counting in memory and issuing keys with defaults
+func newAPIKeyUseCase(t *testing.T, defaults apikeyEntities.Limits) apikeyUsecases.APIKeyUseCase {
+	t.Helper()
+	db := repotest.OpenSQLite(t)
+	if err := database.AutoMigrate(db, &models.APIKeyModel{}); err != nil {
+		t.Fatalf("migrate API keys: %v", err)
+	}
+	return usecases.NewAPIKeyUseCase(apikeyRepositories.NewAPIKeyRepository(db), apikeyRepositories.NewUsageCounterMemory(), defaults)
+}
+
+func TestAPIKeysAreAddressedByPublicID(t *testing.T) {
+	ctx := context.Background()
+	uc := newAPIKeyUseCase(t, apikeyEntities.Limits{})
+	key, secret, err := uc.CreateKey(ctx, 1, "ci")
+	if err != nil {
+		t.Fatalf("CreateKey() error = %v", err)
+	}
+	if !publicid.Valid(key.PublicID) {
+		t.Fatalf("PublicID = %q, want a public ID", key.PublicID)
+	}
+
+	found, err := uc.GetKeyByPublicID(ctx, key.PublicID)
+	if err != nil || found.ID != key.ID {
+		t.Fatalf("GetKeyByPublicID() = %+v, %v; want key %d", found, err, key.ID)
+	}
+	if _, err := uc.GetKeyByPublicID(ctx, publicid.New()); err != apikeyEntities.ErrAPIKeyNotFound {
+		t.Errorf("GetKeyByPublicID() of an unknown key error = %v, want %v", err, apikeyEntities.ErrAPIKeyNotFound)
+	}
+	authenticated, err := uc.Authenticate(ctx, secret)
+	if err != nil || authenticated.PublicID != key.PublicID {
+		t.Errorf("Authenticate() = %+v, %v; want key %s", authenticated, err, key.PublicID)
+	}
+	usage, err := uc.GetUsage(ctx, 1, key.ID)
+	if err != nil || usage.KeyPublicID != key.PublicID {
+		t.Errorf("GetUsage() = %+v, %v; want key %s", usage, err, key.PublicID)
+	}
+}
+
+func TestAPIKeyOwnership(t *testing.T) {
+	tests := []struct {
+		name    string
+		call    func(ctx context.Context, uc apikeyUsecases.APIKeyUseCase, id uint) error
+		wantErr error
+	}{
+		{"owner revokes", func(ctx context.Context, uc apikeyUsecases.APIKeyUseCase, id uint) error {
+			return uc.RevokeKey(ctx, 1, id)
+		}, nil},
+		{"another user revokes", func(ctx context.Context, uc apikeyUsecases.APIKeyUseCase, id uint) error {
+			return uc.RevokeKey(ctx, 2, id)
+		}, apikeyEntities.ErrAPIKeyNotFound},
+		{"another user reads the usage", func(ctx context.Context, uc apikeyUsecases.APIKeyUseCase, id uint) error {
+			_, err := uc.GetUsage(ctx, 2, id)
+			return err
+		}, apikeyEntities.ErrAPIKeyNotFound},
+		{"negative limits", func(ctx context.Context, uc apikeyUsecases.APIKeyUseCase, id uint) error {
+			_, err := uc.SetLimits(ctx, id, apikeyEntities.Limits{RatePerMinute: -1})
+			return err
+		}, apikeyEntities.ErrInvalidLimits},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			uc := newAPIKeyUseCase(t, apikeyEntities.Limits{})
+			key, _, err := uc.CreateKey(ctx, 1, "ci")
+			if err != nil {
+				t.Fatalf("CreateKey() error = %v", err)
+			}
+			if err := tt.call(ctx, uc, key.ID); err != tt.wantErr {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRevokedKeysDoNotAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	uc := newAPIKeyUseCase(t, apikeyEntities.Limits{})
+	key, secret, err := uc.CreateKey(ctx, 1, "ci")
+	if err != nil {
+		t.Fatalf("CreateKey() error = %v", err)
+	}
+	if err := uc.RevokeKey(ctx, 1, key.ID); err != nil {
+		t.Fatalf("RevokeKey() error = %v", err)
+	}
+
+	for _, presented := range []string{secret, "ak_unknown", "not-a-key"} {
+		if _, err := uc.Authenticate(ctx, presented); err != apikeyEntities.ErrInvalidAPIKey {
+			t.Errorf("Authenticate(%q) error = %v, want %v", presented, err, apikeyEntities.ErrInvalidAPIKey)
+		}
+	}
+}
+
+func TestConsumeSpendsTheMonthlyQuota(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults apikeyEntities.Limits
+		// limits replace the defaults of the key when set
+		limits        *apikeyEntities.Limits
+		requests      int
+		wantAllowed   int
+		wantUsed      int64
+		wantRemaining int64
+	}{
+		{"unlimited", apikeyEntities.Limits{}, nil, 5, 5, 5, -1},
+		{"default quota", apikeyEntities.Limits{MonthlyQuota: 3}, nil, 5, 3, 3, 0},
+		{"quota raised by an admin", apikeyEntities.Limits{MonthlyQuota: 3}, &apikeyEntities.Limits{MonthlyQuota: 4}, 5, 4, 4, 0},
+		{"quota left", apikeyEntities.Limits{MonthlyQuota: 10}, nil, 4, 4, 4, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			uc := newAPIKeyUseCase(t, tt.defaults)
+			key, _, err := uc.CreateKey(ctx, 1, "ci")
+			if err != nil {
+				t.Fatalf("CreateKey() error = %v", err)
+			}
+			if tt.limits != nil {
+				if key, err = uc.SetLimits(ctx, key.ID, *tt.limits); err != nil {
+					t.Fatalf("SetLimits() error = %v", err)
+				}
+			}
+
+			allowed := 0
+			var last apikeyEntities.Allowance
+			for i := 0; i < tt.requests; i++ {
+				if last, err = uc.Consume(ctx, key); err != nil {
+					t.Fatalf("Consume() error = %v", err)
+				}
+				if last.Allowed {
+					allowed++
+				}
+			}
+			if allowed != tt.wantAllowed {
+				t.Errorf("allowed %d requests, want %d", allowed, tt.wantAllowed)
+			}
+			if last.QuotaRemaining() != tt.wantRemaining {
+				t.Errorf("QuotaRemaining() = %d, want %d", last.QuotaRemaining(), tt.wantRemaining)
+			}
+			if spent := allowed < tt.requests; last.QuotaExceeded() != spent {
+				t.Errorf("QuotaExceeded() = %v, want %v", last.QuotaExceeded(), spent)
+			}
+
+			usage, err := uc.GetUsage(ctx, 1, key.ID)
+			if err != nil {
+				t.Fatalf("GetUsage() error = %v", err)
+			}
+			if usage.Used != tt.wantUsed {
+				t.Errorf("GetUsage() used = %d, want %d", usage.Used, tt.wantUsed)
+			}
+		})
+	}
+}
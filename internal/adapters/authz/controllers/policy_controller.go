@@ -0,0 +1,203 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	authzEntities "clean-arch-gin/internal/domain/authz/entities"
+	authzUsecases "clean-arch-gin/internal/domain/authz/usecases"
+	"clean-arch-gin/internal/domain/shared/authz"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PolicyRequest represents a policy to grant or revoke
+type PolicyRequest struct {
+	// Subject is a role such as support, or a user such as user:42
+	Subject string `json:"subject" binding:"required,max=100"`
+	// Object is a resource such as users/42; a trailing * matches by prefix
+	Object string `json:"object" binding:"required,max=100"`
+	// Action is a verb such as read, or * for any
+	Action string `json:"action" binding:"required,max=100"`
+}
+
+// RoleAssignmentRequest represents a role to assign or unassign
+type RoleAssignmentRequest struct {
+	// Subject is a user such as user:42, or a role inheriting Role
+	Subject string `json:"subject" binding:"required,max=100"`
+	Role    string `json:"role" binding:"required,max=100"`
+}
+
+// PolicyDTO represents a policy in API responses
+type PolicyDTO struct {
+	Subject string `json:"subject"`
+	Object  string `json:"object"`
+	Action  string `json:"action"`
+}
+
+// RoleAssignmentDTO represents a role assignment in API responses
+type RoleAssignmentDTO struct {
+	Subject string `json:"subject"`
+	Role    string `json:"role"`
+}
+
+// PolicyListResponse represents the whole policy
+type PolicyListResponse struct {
+	Policies []PolicyDTO         `json:"policies"`
+	Roles    []RoleAssignmentDTO `json:"roles"`
+}
+
+// CheckResponse reports the decision on a request
+type CheckResponse struct {
+	PolicyDTO
+	Allowed bool `json:"allowed"`
+}
+
+// PolicyController handles HTTP requests for managing authorization policies
+type PolicyController struct {
+	policyUseCase authzUsecases.PolicyUseCase
+}
+
+// NewPolicyController creates a new policy controller
+func NewPolicyController(policyUseCase authzUsecases.PolicyUseCase) *PolicyController {
+	return &PolicyController{
+		policyUseCase: policyUseCase,
+	}
+}
+
+// ListPolicies retrieves every policy and role assignment
+func (pc *PolicyController) ListPolicies(c *gin.Context) {
+	policies, err := pc.policyUseCase.ListPolicies()
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+	assignments, err := pc.policyUseCase.ListRoleAssignments()
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+
+	response := PolicyListResponse{
+		Policies: make([]PolicyDTO, len(policies)),
+		Roles:    make([]RoleAssignmentDTO, len(assignments)),
+	}
+	for i, policy := range policies {
+		response.Policies[i] = toPolicyDTO(policy)
+	}
+	for i, assignment := range assignments {
+		response.Roles[i] = toRoleAssignmentDTO(assignment)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GrantPolicy adds a policy
+func (pc *PolicyController) GrantPolicy(c *gin.Context) {
+	var req PolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := pc.policyUseCase.GrantPolicy(middleware.CurrentActor(c), req.Subject, req.Object, req.Action)
+	if err != nil {
+		respondPolicyError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toPolicyDTO(policy))
+}
+
+// RevokePolicy removes the policy in the body
+func (pc *PolicyController) RevokePolicy(c *gin.Context) {
+	var req PolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := pc.policyUseCase.RevokePolicy(middleware.CurrentActor(c), req.Subject, req.Object, req.Action); err != nil {
+		respondPolicyError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AssignRole adds a role assignment
+func (pc *PolicyController) AssignRole(c *gin.Context) {
+	var req RoleAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	assignment, err := pc.policyUseCase.AssignRole(middleware.CurrentActor(c), req.Subject, req.Role)
+	if err != nil {
+		respondPolicyError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toRoleAssignmentDTO(assignment))
+}
+
+// UnassignRole removes the role assignment in the body
+func (pc *PolicyController) UnassignRole(c *gin.Context) {
+	var req RoleAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := pc.policyUseCase.UnassignRole(middleware.CurrentActor(c), req.Subject, req.Role); err != nil {
+		respondPolicyError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Check decides ?subject=&object=&action= against the policy
+func (pc *PolicyController) Check(c *gin.Context) {
+	req := PolicyDTO{Subject: c.Query("subject"), Object: c.Query("object"), Action: c.Query("action")}
+	allowed, err := pc.policyUseCase.Check(req.Subject, req.Object, req.Action)
+	if err != nil {
+		respondPolicyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, CheckResponse{PolicyDTO: req, Allowed: allowed})
+}
+
+// toPolicyDTO converts domain entity to DTO
+func toPolicyDTO(policy *authzEntities.Policy) PolicyDTO {
+	return PolicyDTO{
+		Subject: policy.Subject,
+		Object:  policy.Object,
+		Action:  policy.Action,
+	}
+}
+
+// toRoleAssignmentDTO converts domain entity to DTO
+func toRoleAssignmentDTO(assignment *authzEntities.RoleAssignment) RoleAssignmentDTO {
+	return RoleAssignmentDTO{
+		Subject: assignment.Subject,
+		Role:    assignment.Role,
+	}
+}
+
+// respondPolicyError maps policy errors: a missing rule is 404, a duplicate 409, a denied
+// or protected change 403 and any other domain error a bad request
+func respondPolicyError(c *gin.Context, err error) {
+	var domainErr sharedEntities.DomainError
+	switch {
+	case err == authzEntities.ErrPolicyNotFound || err == authzEntities.ErrRoleAssignmentNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err == authzEntities.ErrPolicyExists || err == authzEntities.ErrRoleAssignmentExists:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case err == authz.ErrForbidden || err == authzEntities.ErrProtectedPolicy:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
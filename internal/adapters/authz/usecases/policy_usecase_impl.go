@@ -0,0 +1,115 @@
+package usecases
+
+import (
+	authzEntities "clean-arch-gin/internal/domain/authz/entities"
+	authzRepositories "clean-arch-gin/internal/domain/authz/repositories"
+	authzUsecases "clean-arch-gin/internal/domain/authz/usecases"
+	"clean-arch-gin/internal/domain/shared/authz"
+)
+
+// writeAction is the action on policies/<subject> needed to change a subject's rules
+const writeAction = "write"
+
+// policyUseCase implements the PolicyUseCase interface
+type policyUseCase struct {
+	policyRepo authzRepositories.PolicyRepository
+	authorizer authz.Authorizer
+}
+
+// NewPolicyUseCase creates a new policy management use case checking actors with authorizer
+func NewPolicyUseCase(policyRepo authzRepositories.PolicyRepository, authorizer authz.Authorizer) authzUsecases.PolicyUseCase {
+	return &policyUseCase{
+		policyRepo: policyRepo,
+		authorizer: authorizer,
+	}
+}
+
+// ListPolicies retrieves every policy
+func (uc *policyUseCase) ListPolicies() ([]*authzEntities.Policy, error) {
+	return uc.policyRepo.ListPolicies()
+}
+
+// GrantPolicy allows subject action on object
+func (uc *policyUseCase) GrantPolicy(actor authz.Actor, subject, object, action string) (*authzEntities.Policy, error) {
+	policy, err := authzEntities.NewPolicy(subject, object, action)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorizeSubject(actor, policy.Subject); err != nil {
+		return nil, err
+	}
+	if err := uc.policyRepo.AddPolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// RevokePolicy removes a policy; the admin grant is kept so the policies stay manageable
+func (uc *policyUseCase) RevokePolicy(actor authz.Actor, subject, object, action string) error {
+	policy, err := authzEntities.NewPolicy(subject, object, action)
+	if err != nil {
+		return err
+	}
+	if policy.IsProtected() {
+		return authzEntities.ErrProtectedPolicy
+	}
+	if err := uc.authorizeSubject(actor, policy.Subject); err != nil {
+		return err
+	}
+	return uc.policyRepo.RemovePolicy(policy)
+}
+
+// ListRoleAssignments retrieves every role assignment
+func (uc *policyUseCase) ListRoleAssignments() ([]*authzEntities.RoleAssignment, error) {
+	return uc.policyRepo.ListRoleAssignments()
+}
+
+// AssignRole makes subject inherit role
+// Granting a role hands out its permissions, so the actor must be allowed to manage both
+func (uc *policyUseCase) AssignRole(actor authz.Actor, subject, role string) (*authzEntities.RoleAssignment, error) {
+	assignment, err := authzEntities.NewRoleAssignment(subject, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorizeAssignment(actor, assignment); err != nil {
+		return nil, err
+	}
+	if err := uc.policyRepo.AddRoleAssignment(assignment); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// UnassignRole removes a role assignment
+func (uc *policyUseCase) UnassignRole(actor authz.Actor, subject, role string) error {
+	assignment, err := authzEntities.NewRoleAssignment(subject, role)
+	if err != nil {
+		return err
+	}
+	if err := uc.authorizeAssignment(actor, assignment); err != nil {
+		return err
+	}
+	return uc.policyRepo.RemoveRoleAssignment(assignment)
+}
+
+// Check reports whether subject may perform action on object
+func (uc *policyUseCase) Check(subject, object, action string) (bool, error) {
+	policy, err := authzEntities.NewPolicy(subject, object, action)
+	if err != nil {
+		return false, err
+	}
+	return uc.authorizer.Enforce(authz.Actor{Roles: []string{policy.Subject}}, policy.Object, policy.Action)
+}
+
+// authorizeSubject requires the write action on policies/<subject>
+func (uc *policyUseCase) authorizeSubject(actor authz.Actor, subject string) error {
+	return authz.Authorize(uc.authorizer, actor, "policies/"+subject, writeAction)
+}
+
+// authorizeAssignment requires the write action on the policies of the subject and the role
+func (uc *policyUseCase) authorizeAssignment(actor authz.Actor, assignment *authzEntities.RoleAssignment) error {
+	if err := uc.authorizeSubject(actor, assignment.Subject); err != nil {
+		return err
+	}
+	return uc.authorizeSubject(actor, assignment.Role)
+}
@@ -1,10 +1,13 @@
+// Package controllers holds the user controller of the legacy flat router
+//
+// Deprecated: use the user module's controllers in internal/adapters/user/controllers
 package controllers
 
 import (
 	"net/http"
-	"strconv"
 	"time"
 
+	"clean-arch-gin/internal/adapters/shared/params"
 	userEntities "clean-arch-gin/internal/domain/user/entities"
 	userUsecases "clean-arch-gin/internal/domain/user/usecases"
 
@@ -45,6 +48,15 @@ func toDTO(user *userEntities.User) UserDTO {
 	}
 }
 
+// toDTOs converts a list of domain entities to DTOs
+func toDTOs(users []*userEntities.User) []UserDTO {
+	dtos := make([]UserDTO, len(users))
+	for i, user := range users {
+		dtos[i] = toDTO(user)
+	}
+	return dtos
+}
+
 // UserController handles HTTP requests for user operations
 type UserController struct {
 	userUseCase userUsecases.UserUseCase
@@ -60,9 +72,9 @@ func NewUserController(userUseCase userUsecases.UserUseCase) *UserController {
 // CreateUser creates a new user
 func (uc *UserController) CreateUser(c *gin.Context) {
 	var req struct {
-		Email    string `json:"email" binding:"required"`
-		Name     string `json:"name" binding:"required"`
-		Password string `json:"password" binding:"required"`
+		Email    string `json:"email" binding:"required,email,max=255"`
+		Name     string `json:"name" binding:"required,max=255"`
+		Password string `json:"password" binding:"required,max=72"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -70,30 +82,33 @@ func (uc *UserController) CreateUser(c *gin.Context) {
 		return
 	}
 
-	user, err := uc.userUseCase.CreateUser(req.Email, req.Name, req.Password)
+	user, err := uc.userUseCase.CreateUser(c.Request.Context(), req.Email, req.Name, req.Password)
 	if err != nil {
 		// Handle domain errors appropriately
 		if err == userEntities.ErrEmailExists {
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
+		if err == userEntities.ErrPasswordTooLong {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, user)
+	c.JSON(http.StatusCreated, toDTO(user))
 }
 
 // GetUser retrieves a user by ID
 func (uc *UserController) GetUser(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	id, err := params.ParseID(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
 
-	user, err := uc.userUseCase.GetUser(uint(id))
+	user, err := uc.userUseCase.GetUser(c.Request.Context(), id)
 	if err != nil {
 		if err == userEntities.ErrUserNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -103,52 +118,42 @@ func (uc *UserController) GetUser(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	c.JSON(http.StatusOK, toDTO(user))
 }
 
 // GetUsers retrieves all users with pagination
 func (uc *UserController) GetUsers(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "10")
-	offsetStr := c.DefaultQuery("offset", "0")
-
-	limit, err := strconv.Atoi(limitStr)
+	page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
-		return
-	}
-
-	offset, err := strconv.Atoi(offsetStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	users, err := uc.userUseCase.GetUsers(limit, offset)
+	users, err := uc.userUseCase.GetUsers(c.Request.Context(), page.Limit, page.Offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"users":  users,
-		"limit":  limit,
-		"offset": offset,
+		"users":  toDTOs(users),
+		"limit":  page.Limit,
+		"offset": page.Offset,
 		"count":  len(users),
 	})
 }
 
 // UpdateUser updates user information
 func (uc *UserController) UpdateUser(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	id, err := params.ParseID(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
 
 	var req struct {
-		Email string `json:"email"`
-		Name  string `json:"name"`
+		Email string `json:"email" binding:"omitempty,email,max=255"`
+		Name  string `json:"name" binding:"max=255"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -156,29 +161,32 @@ func (uc *UserController) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	user, err := uc.userUseCase.UpdateUser(uint(id), req.Email, req.Name)
+	user, err := uc.userUseCase.UpdateUser(c.Request.Context(), id, req.Email, req.Name)
 	if err != nil {
 		if err == userEntities.ErrUserNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err == userEntities.ErrEmailExists {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	c.JSON(http.StatusOK, toDTO(user))
 }
 
 // DeleteUser soft deletes a user
 func (uc *UserController) DeleteUser(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	id, err := params.ParseID(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
 
-	err = uc.userUseCase.DeleteUser(uint(id))
+	err = uc.userUseCase.DeleteUser(c.Request.Context(), id)
 	if err != nil {
 		if err == userEntities.ErrUserNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
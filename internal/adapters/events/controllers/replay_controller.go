@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"clean-arch-gin/internal/adapters/events/tasks"
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TaskEnqueuer queues background work, e.g. a *taskqueue.Queue
+type TaskEnqueuer interface {
+	Enqueue(ctx context.Context, taskType string, payload interface{}, opts ...taskqueue.EnqueueOption) (*taskqueue.Task, error)
+}
+
+// ReplayRequest selects the recorded events to replay and where they go: "bus" for every
+// subscriber of the event bus or the name of a projection. Reset rebuilds a projection from
+// scratch and cannot be combined with a filter
+type ReplayRequest struct {
+	Target    string     `json:"target" binding:"required"`
+	Types     []string   `json:"types"`
+	Aggregate string     `json:"aggregate"`
+	From      *time.Time `json:"from"`
+	To        *time.Time `json:"to"`
+	Reset     bool       `json:"reset"`
+}
+
+// ProjectionDTO describes a projection that can be rebuilt
+type ProjectionDTO struct {
+	Name       string   `json:"name"`
+	Events     []string `json:"events"`
+	Resettable bool     `json:"resettable"`
+}
+
+// ProjectionsResponse lists the projections that can be rebuilt, by name
+type ProjectionsResponse struct {
+	Projections []ProjectionDTO `json:"projections"`
+}
+
+// ReplayController handles replay requests
+type ReplayController struct {
+	projections map[string]events.Projection
+	// queue runs the replays; without one replays are unavailable
+	queue TaskEnqueuer
+}
+
+// NewReplayController creates a new replay controller for the projections, by name
+func NewReplayController(projections map[string]events.Projection) *ReplayController {
+	return &ReplayController{projections: projections}
+}
+
+// SetQueue enables replays on queue
+func (rc *ReplayController) SetQueue(queue TaskEnqueuer) {
+	rc.queue = queue
+}
+
+// ListProjections lists the projections that can be rebuilt with the events they are built from
+func (rc *ReplayController) ListProjections(c *gin.Context) {
+	projections := make([]ProjectionDTO, 0, len(rc.projections))
+	for _, projection := range rc.projections {
+		projections = append(projections, ProjectionDTO{
+			Name:       projection.Name,
+			Events:     projection.Events,
+			Resettable: projection.Reset != nil,
+		})
+	}
+	sort.Slice(projections, func(i, j int) bool { return projections[i].Name < projections[j].Name })
+	c.JSON(http.StatusOK, ProjectionsResponse{Projections: projections})
+}
+
+// Replay queues a replay and responds 202 with a job ID whose progress the jobs API reports;
+// the job's result is how many events were replayed. A replay is not retried, since the
+// handlers have seen the events it got through before failing
+func (rc *ReplayController) Replay(c *gin.Context) {
+	requesterID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req ReplayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Reject an unknown target or a bad selection now rather than in a dead task
+	projection, ok := rc.projections[req.Target]
+	if !ok && req.Target != tasks.TargetBus {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tasks.ErrUnknownTarget.Error()})
+		return
+	}
+	if req.From != nil && req.To != nil && !req.From.Before(*req.To) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+		return
+	}
+	if req.Reset {
+		if projection.Reset == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Only projections with a reset can be reset"})
+			return
+		}
+		if len(req.Types) > 0 || req.Aggregate != "" || req.From != nil || req.To != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "A reset replays every event; it cannot be filtered"})
+			return
+		}
+	}
+	if rc.queue == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Replays are not available"})
+		return
+	}
+
+	task, err := rc.queue.Enqueue(c.Request.Context(), tasks.ReplayTaskType, tasks.ReplayPayload{
+		Target:    req.Target,
+		Types:     req.Types,
+		Aggregate: req.Aggregate,
+		From:      req.From,
+		To:        req.To,
+		Reset:     req.Reset,
+	}, taskqueue.Owner(strconv.FormatUint(uint64(requesterID), 10)), taskqueue.MaxAttempts(1))
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+	responses.JobAccepted(c, task)
+}
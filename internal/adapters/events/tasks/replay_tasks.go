@@ -0,0 +1,113 @@
+// Package tasks holds the task queue handlers of the events module
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/infrastructure/outbox"
+	"clean-arch-gin/internal/infrastructure/retry"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+
+	"gorm.io/gorm"
+)
+
+// ReplayTaskType is the task type of a replay of recorded events
+const ReplayTaskType = "events.replay"
+
+// TargetBus replays the events through the event bus, to every subscriber
+const TargetBus = "bus"
+
+// Phases reported by replay jobs while they run
+const (
+	PhaseResetting = "resetting"
+	PhaseReplaying = "replaying"
+)
+
+// ErrUnknownTarget is returned for a target that is neither the bus nor a projection
+var ErrUnknownTarget = errors.New("unknown replay target")
+
+// ReplayPayload is a queued replay: where the events go and which of them are replayed
+type ReplayPayload struct {
+	// Target is TargetBus or the name of a projection
+	Target    string     `json:"target"`
+	Types     []string   `json:"types,omitempty"`
+	Aggregate string     `json:"aggregate,omitempty"`
+	From      *time.Time `json:"from,omitempty"`
+	To        *time.Time `json:"to,omitempty"`
+	// Reset clears the projection before its events are replayed into it
+	Reset bool `json:"reset,omitempty"`
+}
+
+// ReplayResult is the result of a replay task
+type ReplayResult struct {
+	Target   string `json:"target"`
+	Replayed int    `json:"replayed"`
+}
+
+// NewReplayHandler runs queued replays of the events recorded in the outbox on db: through
+// publisher, or into one of projections, by name, reset first when asked. A projection only
+// gets the events it is built from. Unknown targets fail permanently
+func NewReplayHandler(db *gorm.DB, publisher events.EventPublisher, projections map[string]events.Projection) taskqueue.Handler {
+	return func(ctx context.Context, task *taskqueue.Task) error {
+		var payload ReplayPayload
+		if err := task.Decode(&payload); err != nil {
+			return retry.Permanent(err)
+		}
+		filter := outbox.Filter{Types: payload.Types, Aggregate: payload.Aggregate, From: payload.From, To: payload.To}
+
+		var handle events.EventHandler
+		switch projection, ok := projections[payload.Target]; {
+		case payload.Target == TargetBus:
+			handle = func(ctx context.Context, event events.DomainEvent) {
+				if err := publisher.Publish(ctx, event); err != nil {
+					log.Printf("events: failed to replay %s: %v", event.EventName(), err)
+				}
+			}
+		case ok:
+			filter.Types = projected(projection, payload.Types)
+			if len(filter.Types) == 0 {
+				return taskqueue.SetResult(ctx, ReplayResult{Target: payload.Target})
+			}
+			if payload.Reset && projection.Reset != nil {
+				taskqueue.ReportPhase(ctx, PhaseResetting)
+				if err := projection.Reset(ctx); err != nil {
+					return fmt.Errorf("failed to reset %s: %w", projection.Name, err)
+				}
+			}
+			handle = projection.Handle
+		default:
+			return retry.Permanent(fmt.Errorf("%w: %s", ErrUnknownTarget, payload.Target))
+		}
+
+		taskqueue.ReportPhase(ctx, PhaseReplaying)
+		replayed, err := outbox.ReplayEvents(ctx, db, filter, handle, func(done, total int) {
+			taskqueue.ReportItems(ctx, done, total)
+		})
+		if err != nil {
+			return err
+		}
+		return taskqueue.SetResult(ctx, ReplayResult{Target: payload.Target, Replayed: replayed})
+	}
+}
+
+// projected narrows types to the events projection is built from; no types selects all of them
+func projected(projection events.Projection, types []string) []string {
+	if len(types) == 0 {
+		return projection.Events
+	}
+	var selected []string
+	for _, name := range types {
+		for _, event := range projection.Events {
+			if name == event {
+				selected = append(selected, name)
+				break
+			}
+		}
+	}
+	return selected
+}
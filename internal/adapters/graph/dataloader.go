@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"sync"
+	"time"
+)
+
+// batchFunc loads the values for a set of keys; keys missing from the result resolve to the zero value
+type batchFunc[K comparable, V any] func(keys []K) (map[K]V, error)
+
+// batch collects the keys requested during one wait window
+type batch[K comparable, V any] struct {
+	keys    []K
+	results map[K]V
+	err     error
+	done    chan struct{}
+}
+
+// Loader coalesces the Load calls issued by sibling resolvers into one batchFunc call
+// A loader lives for a single request, so its memoized results never go stale
+type Loader[K comparable, V any] struct {
+	fetch    batchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	current *batch[K, V]
+	loaded  map[K]*batch[K, V]
+}
+
+// NewLoader creates a loader that dispatches after wait or once maxBatch keys are queued
+func NewLoader[K comparable, V any](fetch batchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		loaded:   make(map[K]*batch[K, V]),
+	}
+}
+
+// Load returns the value for key, waiting for the batch it joined to be fetched
+func (l *Loader[K, V]) Load(key K) (V, error) {
+	l.mu.Lock()
+	b, ok := l.loaded[key]
+	if !ok {
+		b = l.enqueue(key)
+	}
+	l.mu.Unlock()
+
+	<-b.done
+	return b.results[key], b.err
+}
+
+// enqueue adds key to the current batch, starting a new one if needed; l.mu must be held
+func (l *Loader[K, V]) enqueue(key K) *batch[K, V] {
+	if l.current == nil {
+		b := &batch[K, V]{done: make(chan struct{})}
+		l.current = b
+		time.AfterFunc(l.wait, func() { l.dispatch(b) })
+	}
+
+	b := l.current
+	b.keys = append(b.keys, key)
+	l.loaded[key] = b
+
+	if l.maxBatch > 0 && len(b.keys) >= l.maxBatch {
+		l.current = nil
+		go l.run(b)
+	}
+	return b
+}
+
+// dispatch runs the batch when its wait window ends unless it was already dispatched for size
+func (l *Loader[K, V]) dispatch(b *batch[K, V]) {
+	l.mu.Lock()
+	if l.current != b {
+		l.mu.Unlock()
+		return
+	}
+	l.current = nil
+	l.mu.Unlock()
+
+	l.run(b)
+}
+
+// run fetches the batch and releases every waiting Load call
+func (l *Loader[K, V]) run(b *batch[K, V]) {
+	b.results, b.err = l.fetch(b.keys)
+	close(b.done)
+}
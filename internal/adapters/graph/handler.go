@@ -0,0 +1,69 @@
+package graph
+
+import (
+	"net/http"
+
+	"clean-arch-gin/internal/adapters/shared/params"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+const (
+	// DefaultMaxDepth bounds selection nesting (e.g. users > orders > user > orders > items)
+	DefaultMaxDepth = 6
+	// DefaultMaxComplexity bounds the estimated cost of an operation
+	DefaultMaxComplexity = 1000
+)
+
+// Options tunes the GraphQL handler; zero values use the defaults
+type Options struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// NewHandler creates the GraphQL HTTP handler with batching loaders and query limits
+func NewHandler(userRepo userRepositories.UserRepository, orderRepo orderRepositories.OrderRepository, opts Options) http.Handler {
+	if opts.MaxDepth == 0 {
+		opts.MaxDepth = DefaultMaxDepth
+	}
+	if opts.MaxComplexity == 0 {
+		opts.MaxComplexity = DefaultMaxComplexity
+	}
+
+	cfg := Config{Resolvers: NewResolver(userRepo, orderRepo)}
+	cfg.Complexity.Query.Users = func(childComplexity int, limit *int, offset *int) int {
+		return pageSize(limit) * childComplexity
+	}
+	cfg.Complexity.Query.UserOrders = func(childComplexity int, userID uint, limit *int, offset *int) int {
+		return pageSize(limit) * childComplexity
+	}
+	cfg.Complexity.User.Orders = func(childComplexity int) int {
+		// Unbounded list: assume a typical order history size
+		return 10 * childComplexity
+	}
+
+	srv := handler.New(NewExecutableSchema(cfg))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+	srv.Use(DepthLimit{Max: opts.MaxDepth})
+	srv.Use(extension.FixedComplexityLimit(opts.MaxComplexity))
+
+	return LoaderMiddleware(userRepo, orderRepo, srv)
+}
+
+// pageSize returns the effective size of a paginated list for complexity estimates
+func pageSize(limit *int) int {
+	if limit == nil {
+		return params.DefaultLimit
+	}
+	page, err := params.NewPagination(*limit, 0)
+	if err != nil {
+		return params.DefaultLimit
+	}
+	return page.Limit
+}
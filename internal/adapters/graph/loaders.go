@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+)
+
+const (
+	// loaderWait is how long a loader waits for sibling resolvers before querying
+	loaderWait = 2 * time.Millisecond
+	// loaderMaxBatch bounds the size of a single IN (...) query
+	loaderMaxBatch = 100
+)
+
+type loadersKey struct{}
+
+// Loaders holds the per-request batch loaders used to avoid N+1 queries
+type Loaders struct {
+	UserByID       *Loader[uint, *userEntities.User]
+	OrdersByUserID *Loader[uint, []*orderEntities.Order]
+}
+
+// NewLoaders creates a fresh set of loaders backed by the repositories
+// Batches are queried with ctx, so they stay within the request's tenant
+func NewLoaders(ctx context.Context, userRepo userRepositories.UserRepository, orderRepo orderRepositories.OrderRepository) *Loaders {
+	return &Loaders{
+		UserByID: NewLoader(func(ids []uint) (map[uint]*userEntities.User, error) {
+			users, err := userRepo.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[uint]*userEntities.User, len(users))
+			for _, user := range users {
+				byID[user.ID] = user
+			}
+			return byID, nil
+		}, loaderWait, loaderMaxBatch),
+
+		OrdersByUserID: NewLoader(func(userIDs []uint) (map[uint][]*orderEntities.Order, error) {
+			orders, err := orderRepo.GetByUserIDs(ctx, userIDs)
+			if err != nil {
+				return nil, err
+			}
+			byUser := make(map[uint][]*orderEntities.Order, len(userIDs))
+			for _, order := range orders {
+				byUser[order.UserID] = append(byUser[order.UserID], order)
+			}
+			return byUser, nil
+		}, loaderWait, loaderMaxBatch),
+	}
+}
+
+// LoaderMiddleware attaches new loaders to every request context
+func LoaderMiddleware(userRepo userRepositories.UserRepository, orderRepo orderRepositories.OrderRepository, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), loadersKey{}, NewLoaders(r.Context(), userRepo, orderRepo))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// loadersFrom returns the loaders attached by LoaderMiddleware
+func loadersFrom(ctx context.Context) *Loaders {
+	return ctx.Value(loadersKey{}).(*Loaders)
+}
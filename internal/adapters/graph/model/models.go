@@ -0,0 +1,123 @@
+// Package model holds the GraphQL types bound by gqlgen and their conversions from domain entities
+package model
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// User is the GraphQL user type; passwords are never exposed
+type User struct {
+	ID        uint      `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Order is the GraphQL order type; the owning user is resolved through a loader
+type Order struct {
+	ID          uint         `json:"id"`
+	UserID      uint         `json:"-"`
+	Status      OrderStatus  `json:"status"`
+	TotalAmount float64      `json:"totalAmount"`
+	Items       []*OrderItem `json:"items"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+}
+
+// OrderItem is the GraphQL order item type
+type OrderItem struct {
+	ID        uint    `json:"id"`
+	ProductID uint    `json:"productId"`
+	Quantity  int     `json:"quantity"`
+	Price     float64 `json:"price"`
+}
+
+// NewUser converts a domain user
+func NewUser(user *userEntities.User) *User {
+	return &User{
+		ID:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+// NewUsers converts a list of domain users
+func NewUsers(users []*userEntities.User) []*User {
+	result := make([]*User, len(users))
+	for i, user := range users {
+		result[i] = NewUser(user)
+	}
+	return result
+}
+
+// NewOrder converts a domain order with its items
+func NewOrder(order *orderEntities.Order) *Order {
+	items := make([]*OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = &OrderItem{
+			ID:        item.ID,
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+		}
+	}
+
+	return &Order{
+		ID:          order.ID,
+		UserID:      order.UserID,
+		Status:      OrderStatus(strings.ToUpper(string(order.Status))),
+		TotalAmount: order.TotalAmount,
+		Items:       items,
+		CreatedAt:   order.CreatedAt,
+		UpdatedAt:   order.UpdatedAt,
+	}
+}
+
+// NewOrders converts a list of domain orders
+func NewOrders(orders []*orderEntities.Order) []*Order {
+	result := make([]*Order, len(orders))
+	for i, order := range orders {
+		result[i] = NewOrder(order)
+	}
+	return result
+}
+
+// MarshalID writes numeric primary keys as GraphQL ID strings
+func MarshalID(id uint) graphql.Marshaler {
+	return graphql.WriterFunc(func(w io.Writer) {
+		_, _ = io.WriteString(w, strconv.Quote(strconv.FormatUint(uint64(id), 10)))
+	})
+}
+
+// UnmarshalID parses a GraphQL ID into a numeric primary key
+func UnmarshalID(v interface{}) (uint, error) {
+	var raw string
+	switch v := v.(type) {
+	case string:
+		raw = v
+	case int64:
+		raw = strconv.FormatInt(v, 10)
+	case int:
+		raw = strconv.Itoa(v)
+	default:
+		return 0, fmt.Errorf("%T is not a valid ID", v)
+	}
+
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%q is not a valid ID", raw)
+	}
+	return uint(id), nil
+}
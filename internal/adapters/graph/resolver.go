@@ -0,0 +1,44 @@
+// Package graph serves the GraphQL API that aggregates users and orders
+// Resolvers are backed by the CQRS query handlers; nested fields go through
+// per-request batch loaders so list queries issue one query per level
+package graph
+
+//go:generate go run -mod=mod github.com/99designs/gqlgen generate
+
+import (
+	"clean-arch-gin/internal/adapters/shared/params"
+	orderQueries "clean-arch-gin/internal/application/order/queries"
+	userQueries "clean-arch-gin/internal/application/user/queries"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+)
+
+// Resolver is the root resolver holding the query handlers
+type Resolver struct {
+	getUser       *userQueries.GetUserQueryHandler
+	getUsers      *userQueries.GetUsersQueryHandler
+	getOrder      *orderQueries.GetOrderQueryHandler
+	getUserOrders *orderQueries.GetUserOrdersQueryHandler
+}
+
+// NewResolver creates the root resolver on top of the repositories
+func NewResolver(userRepo userRepositories.UserRepository, orderRepo orderRepositories.OrderRepository) *Resolver {
+	return &Resolver{
+		getUser:       userQueries.NewGetUserQueryHandler(userRepo),
+		getUsers:      userQueries.NewGetUsersQueryHandler(userRepo),
+		getOrder:      orderQueries.NewGetOrderQueryHandler(orderRepo),
+		getUserOrders: orderQueries.NewGetUserOrdersQueryHandler(orderRepo),
+	}
+}
+
+// pagination validates the limit/offset arguments like the REST and gRPC surfaces
+func pagination(limit, offset *int) (params.Pagination, error) {
+	l, o := 0, 0
+	if limit != nil {
+		l = *limit
+	}
+	if offset != nil {
+		o = *offset
+	}
+	return params.NewPagination(l, o)
+}
@@ -0,0 +1,98 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.40
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/graph/model"
+	orderQueries "clean-arch-gin/internal/application/order/queries"
+	userQueries "clean-arch-gin/internal/application/user/queries"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// User is the resolver for the user field.
+func (r *orderResolver) User(ctx context.Context, obj *model.Order) (*model.User, error) {
+	user, err := loadersFrom(ctx).UserByID.Load(obj.UserID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return model.NewUser(user), nil
+}
+
+// User is the resolver for the user field.
+func (r *queryResolver) User(ctx context.Context, id uint) (*model.User, error) {
+	user, err := r.getUser.Handle(ctx, userQueries.GetUserQuery{UserID: id})
+	if err == userEntities.ErrUserNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.NewUser(user), nil
+}
+
+// Users is the resolver for the users field.
+func (r *queryResolver) Users(ctx context.Context, limit *int, offset *int) ([]*model.User, error) {
+	page, err := pagination(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := r.getUsers.Handle(ctx, userQueries.GetUsersQuery{Limit: page.Limit, Offset: page.Offset})
+	if err != nil {
+		return nil, err
+	}
+	return model.NewUsers(users), nil
+}
+
+// Order is the resolver for the order field.
+func (r *queryResolver) Order(ctx context.Context, id uint) (*model.Order, error) {
+	order, err := r.getOrder.Handle(ctx, orderQueries.GetOrderQuery{OrderID: id})
+	if err == orderEntities.ErrOrderNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return model.NewOrder(order), nil
+}
+
+// UserOrders is the resolver for the userOrders field.
+func (r *queryResolver) UserOrders(ctx context.Context, userID uint, limit *int, offset *int) ([]*model.Order, error) {
+	page, err := pagination(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := r.getUserOrders.Handle(ctx, orderQueries.GetUserOrdersQuery{UserID: userID, Limit: page.Limit, Offset: page.Offset})
+	if err != nil {
+		return nil, err
+	}
+	return model.NewOrders(orders), nil
+}
+
+// Orders is the resolver for the orders field.
+func (r *userResolver) Orders(ctx context.Context, obj *model.User) ([]*model.Order, error) {
+	orders, err := loadersFrom(ctx).OrdersByUserID.Load(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewOrders(orders), nil
+}
+
+// Order returns OrderResolver implementation.
+func (r *Resolver) Order() OrderResolver { return &orderResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// User returns UserResolver implementation.
+func (r *Resolver) User() UserResolver { return &userResolver{r} }
+
+type orderResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type userResolver struct{ *Resolver }
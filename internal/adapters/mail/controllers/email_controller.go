@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	mailEntities "clean-arch-gin/internal/domain/mail/entities"
+	mailRepositories "clean-arch-gin/internal/domain/mail/repositories"
+	mailUsecases "clean-arch-gin/internal/domain/mail/usecases"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmailDTO represents the delivery status of an outbound email; bodies are never returned
+// since account emails carry secrets
+type EmailDTO struct {
+	ID        uint   `json:"id"`
+	Recipient string `json:"recipient"`
+	Subject   string `json:"subject"`
+	Status    string `json:"status"`
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error,omitempty"`
+	// TaskID is the task sending the email, inspected and requeued at /api/v1/tasks once it failed
+	TaskID    uint       `json:"task_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+}
+
+// EmailListResponse represents a page of outbound emails
+type EmailListResponse struct {
+	Emails []EmailDTO `json:"emails"`
+	Total  int64      `json:"total"`
+	Limit  int        `json:"limit"`
+	Offset int        `json:"offset"`
+}
+
+// toEmailDTO converts domain entity to DTO
+func toEmailDTO(email *mailEntities.Email) EmailDTO {
+	return EmailDTO{
+		ID:        email.ID,
+		Recipient: email.Recipient,
+		Subject:   email.Subject,
+		Status:    string(email.Status),
+		Attempts:  email.Attempts,
+		LastError: email.LastError,
+		TaskID:    email.TaskID,
+		CreatedAt: email.CreatedAt,
+		UpdatedAt: email.UpdatedAt,
+		SentAt:    email.SentAt,
+	}
+}
+
+// EmailController handles HTTP requests looking up the delivery status of outbound emails
+type EmailController struct {
+	emailUseCase mailUsecases.EmailUseCase
+}
+
+// NewEmailController creates a new email controller
+func NewEmailController(emailUseCase mailUsecases.EmailUseCase) *EmailController {
+	return &EmailController{
+		emailUseCase: emailUseCase,
+	}
+}
+
+// ListEmails lists the outbound emails, newest first, optionally those to one recipient
+// or in one status
+func (ec *EmailController) ListEmails(c *gin.Context) {
+	page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := mailRepositories.EmailFilter{
+		Recipient: c.Query("recipient"),
+		Status:    mailEntities.EmailStatus(c.Query("status")),
+	}
+	emails, total, err := ec.emailUseCase.ListEmails(c.Request.Context(), filter, page.Limit, page.Offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	dtos := make([]EmailDTO, len(emails))
+	for i, email := range emails {
+		dtos[i] = toEmailDTO(email)
+	}
+	c.JSON(http.StatusOK, EmailListResponse{Emails: dtos, Total: total, Limit: page.Limit, Offset: page.Offset})
+}
+
+// GetEmail returns the delivery status of an outbound email
+func (ec *EmailController) GetEmail(c *gin.Context) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid email ID"})
+		return
+	}
+
+	email, err := ec.emailUseCase.GetEmail(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toEmailDTO(email))
+}
+
+// respondError maps mail errors: an unknown email is 404 and a domain error a bad request
+func respondError(c *gin.Context, err error) {
+	var domainErr sharedEntities.DomainError
+	switch {
+	case errors.Is(err, mailEntities.ErrEmailNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
@@ -0,0 +1,36 @@
+// Package jobs holds the scheduled jobs of the mail module
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	mailEntities "clean-arch-gin/internal/domain/mail/entities"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+
+	"gorm.io/gorm"
+)
+
+// SentEmailRetention is how long the delivery status of sent emails is kept for support
+const SentEmailRetention = 90 * 24 * time.Hour
+
+// NewPurgeSentJob deletes the emails of every tenant sent more than retention ago, nightly
+// Failed emails are kept until their task is requeued or discarded
+func NewPurgeSentJob(db *gorm.DB, retention time.Duration) scheduler.Job {
+	return scheduler.Job{
+		Name:     "purge-sent",
+		Schedule: "45 3 * * *",
+		Timeout:  10 * time.Minute,
+		Run: func(ctx context.Context) error {
+			result := db.WithContext(ctx).
+				Where("status = ? AND sent_at < ?", string(mailEntities.EmailStatusSent), time.Now().Add(-retention)).
+				Delete(&models.OutboundEmailModel{})
+			if result.RowsAffected > 0 {
+				log.Printf("mail: purged %d emails sent more than %s ago", result.RowsAffected, retention)
+			}
+			return result.Error
+		},
+	}
+}
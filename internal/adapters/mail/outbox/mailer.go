@@ -0,0 +1,71 @@
+// Package outbox sends mail through the durable task queue
+package outbox
+
+import (
+	"context"
+	"errors"
+
+	"clean-arch-gin/internal/adapters/mail/tasks"
+	mailEntities "clean-arch-gin/internal/domain/mail/entities"
+	mailRepositories "clean-arch-gin/internal/domain/mail/repositories"
+	"clean-arch-gin/internal/domain/shared/mail"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+
+	"gorm.io/gorm"
+)
+
+// ErrQueueUnavailable is returned by Send before the task queue is set
+var ErrQueueUnavailable = errors.New("mail queue unavailable")
+
+// Mailer queues messages instead of sending them: each recipient gets an outbound email
+// and a task sending it, written in the transaction of the context or in their own, so
+// mail is only queued when the work mailing it commits. Workers send the emails
+type Mailer struct {
+	db          *gorm.DB
+	emailRepo   mailRepositories.EmailRepository
+	maxAttempts int
+	// queue runs the send tasks; without one nothing is sent
+	queue *taskqueue.Queue
+}
+
+var _ mail.Mailer = (*Mailer)(nil)
+
+// NewMailer creates a mailer queueing emails on db, tried maxAttempts times; zero uses the
+// queue's default
+func NewMailer(db *gorm.DB, emailRepo mailRepositories.EmailRepository, maxAttempts int) *Mailer {
+	return &Mailer{db: db, emailRepo: emailRepo, maxAttempts: maxAttempts}
+}
+
+// SetQueue enables sending on queue
+func (m *Mailer) SetQueue(queue *taskqueue.Queue) {
+	m.queue = queue
+}
+
+// Send queues msg, an email per recipient
+func (m *Mailer) Send(ctx context.Context, msg mail.Message) error {
+	if m.queue == nil {
+		return ErrQueueUnavailable
+	}
+	if len(msg.To) == 0 {
+		return errors.New("mail has no recipients")
+	}
+
+	return database.Transactional(m.db)(ctx, "mail.send", func(ctx context.Context) error {
+		tx, _ := database.TxFromContext(ctx)
+		for _, email := range mailEntities.NewEmails(msg) {
+			if err := m.emailRepo.Create(ctx, email); err != nil {
+				return err
+			}
+			task, err := m.queue.EnqueueTx(tx.WithContext(ctx), tasks.SendTaskType, tasks.SendPayload{EmailID: email.ID},
+				taskqueue.MaxAttempts(m.maxAttempts))
+			if err != nil {
+				return err
+			}
+			if err := m.emailRepo.SetTask(ctx, email.ID, task.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
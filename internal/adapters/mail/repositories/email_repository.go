@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	mailEntities "clean-arch-gin/internal/domain/mail/entities"
+	mailRepositories "clean-arch-gin/internal/domain/mail/repositories"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// emailRepository implements EmailRepository interface using GORM
+type emailRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailRepository creates a new outbound email repository
+func NewEmailRepository(db *gorm.DB) mailRepositories.EmailRepository {
+	return &emailRepository{db: db}
+}
+
+// Create stores an email
+func (r *emailRepository) Create(ctx context.Context, email *mailEntities.Email) error {
+	emailModel := models.NewOutboundEmailModelFromEntity(email)
+	if err := database.Conn(ctx, r.db).Create(emailModel).Error; err != nil {
+		return err
+	}
+	email.ID = emailModel.ID
+	return nil
+}
+
+// SetTask records the task sending an email
+func (r *emailRepository) SetTask(ctx context.Context, id, taskID uint) error {
+	return database.Conn(ctx, r.db).Model(&models.OutboundEmailModel{}).Where("id = ?", id).Update("task_id", taskID).Error
+}
+
+// Get retrieves an email by ID
+func (r *emailRepository) Get(ctx context.Context, id uint) (*mailEntities.Email, error) {
+	var emailModel models.OutboundEmailModel
+	if err := database.Conn(ctx, r.db).First(&emailModel, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, mailEntities.ErrEmailNotFound
+		}
+		return nil, err
+	}
+	return emailModel.ToDomainEntity(), nil
+}
+
+// List retrieves the emails matching filter, newest first
+func (r *emailRepository) List(ctx context.Context, filter mailRepositories.EmailFilter, limit, offset int) ([]*mailEntities.Email, int64, error) {
+	query := database.Conn(ctx, r.db).Model(&models.OutboundEmailModel{})
+	if filter.Recipient != "" {
+		query = query.Where("recipient_hash = ?", models.EmailIndex(filter.Recipient))
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var emailModels []models.OutboundEmailModel
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&emailModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	emails := make([]*mailEntities.Email, len(emailModels))
+	for i := range emailModels {
+		emails[i] = emailModels[i].ToDomainEntity()
+	}
+	return emails, total, nil
+}
+
+// Update saves the delivery state and body of an email
+// Through the model, so the body goes through its encrypting serializer
+func (r *emailRepository) Update(ctx context.Context, email *mailEntities.Email) error {
+	emailModel := models.NewOutboundEmailModelFromEntity(email)
+	result := database.Conn(ctx, r.db).Model(emailModel).
+		Select("status", "attempts", "last_error", "text", "html", "sent_at", "updated_at").
+		Updates(emailModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return mailEntities.ErrEmailNotFound
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Package tasks holds the task queue handlers of the mail module
+package tasks
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	mailEntities "clean-arch-gin/internal/domain/mail/entities"
+	mailRepositories "clean-arch-gin/internal/domain/mail/repositories"
+	"clean-arch-gin/internal/domain/shared/mail"
+	"clean-arch-gin/internal/infrastructure/retry"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+)
+
+// SendTaskType is the task type sending an outbound email
+const SendTaskType = "mail.send"
+
+// SendPayload names the outbound email a task sends; the message itself stays in its row
+type SendPayload struct {
+	EmailID uint `json:"email_id"`
+}
+
+// NewSendHandler sends queued emails with sender, recording every attempt on the email
+// Failed sends are retried with the queue's backoff; the email fails with the task
+func NewSendHandler(emailRepo mailRepositories.EmailRepository, sender mail.Mailer) taskqueue.Handler {
+	return func(ctx context.Context, task *taskqueue.Task) error {
+		var payload SendPayload
+		if err := task.Decode(&payload); err != nil {
+			return retry.Permanent(err)
+		}
+		email, err := emailRepo.Get(ctx, payload.EmailID)
+		if err != nil {
+			if errors.Is(err, mailEntities.ErrEmailNotFound) {
+				return retry.Permanent(err)
+			}
+			return err
+		}
+		if email.Status == mailEntities.EmailStatusSent {
+			return nil
+		}
+
+		if sendErr := sender.Send(ctx, email.Message()); sendErr != nil {
+			email.MarkFailed(task.Attempts, sendErr, retry.IsPermanent(sendErr) || task.Attempts >= task.MaxAttempts)
+			if err := emailRepo.Update(ctx, email); err != nil {
+				log.Printf("mail: failed to record the failed attempt of email %d: %v", email.ID, err)
+			}
+			return sendErr
+		}
+		// The email is out: failing now would send it again
+		email.MarkSent(task.Attempts)
+		if err := emailRepo.Update(ctx, email); err != nil {
+			log.Printf("mail: failed to record email %d as sent: %v", email.ID, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+
+	mailEntities "clean-arch-gin/internal/domain/mail/entities"
+	mailRepositories "clean-arch-gin/internal/domain/mail/repositories"
+	mailUsecases "clean-arch-gin/internal/domain/mail/usecases"
+)
+
+// emailUseCase implements the EmailUseCase interface
+type emailUseCase struct {
+	emailRepo mailRepositories.EmailRepository
+}
+
+// NewEmailUseCase creates a new outbound email use case
+func NewEmailUseCase(emailRepo mailRepositories.EmailRepository) mailUsecases.EmailUseCase {
+	return &emailUseCase{
+		emailRepo: emailRepo,
+	}
+}
+
+// GetEmail retrieves an email by ID
+func (uc *emailUseCase) GetEmail(ctx context.Context, id uint) (*mailEntities.Email, error) {
+	return uc.emailRepo.Get(ctx, id)
+}
+
+// ListEmails retrieves the emails matching filter
+func (uc *emailUseCase) ListEmails(ctx context.Context, filter mailRepositories.EmailFilter, limit, offset int) ([]*mailEntities.Email, int64, error) {
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, 0, mailEntities.ErrInvalidEmailStatus
+	}
+	return uc.emailRepo.List(ctx, filter, limit, offset)
+}
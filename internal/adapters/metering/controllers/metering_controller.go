@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/responses"
+	meteringEntities "clean-arch-gin/internal/domain/metering/entities"
+	meteringUsecases "clean-arch-gin/internal/domain/metering/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dateLayout is the layout of the days bounding a usage summary
+const dateLayout = "2006-01-02"
+
+// UsageTotalDTO represents the usage of a metric in API responses
+type UsageTotalDTO struct {
+	// APIKeyID is left out for the usage of requests made without an API key
+	APIKeyID uint   `json:"api_key_id,omitempty"`
+	Metric   string `json:"metric"`
+	Quantity int64  `json:"quantity"`
+}
+
+// UsageResponse is the usage of the tenant from From until To
+type UsageResponse struct {
+	From   time.Time       `json:"from"`
+	To     time.Time       `json:"to"`
+	Totals []UsageTotalDTO `json:"totals"`
+}
+
+// MeteringController handles HTTP requests for usage metering
+type MeteringController struct {
+	meteringUseCase meteringUsecases.MeteringUseCase
+}
+
+// NewMeteringController creates a new metering controller
+func NewMeteringController(meteringUseCase meteringUsecases.MeteringUseCase) *MeteringController {
+	return &MeteringController{
+		meteringUseCase: meteringUseCase,
+	}
+}
+
+// GetUsage sums the usage of the tenant between the from and to days, both included, by
+// API key and metric; the current month by default. Usage is counted a few seconds late
+func (mc *MeteringController) GetUsage(c *gin.Context) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := now
+	for name, day := range map[string]*time.Time{"from": &from, "to": &to} {
+		raw := c.Query(name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(dateLayout, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": name + " must be a date as YYYY-MM-DD"})
+			return
+		}
+		*day = parsed
+	}
+	// The to day is included
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+
+	var apiKeyID *uint
+	if raw := c.Query("api_key_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key ID"})
+			return
+		}
+		keyID := uint(id)
+		apiKeyID = &keyID
+	}
+
+	summary, err := mc.meteringUseCase.GetUsage(c.Request.Context(), from, to, apiKeyID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	totals := make([]UsageTotalDTO, len(summary.Totals))
+	for i, total := range summary.Totals {
+		totals[i] = UsageTotalDTO{APIKeyID: total.APIKeyID, Metric: total.Metric, Quantity: total.Quantity}
+	}
+	c.JSON(http.StatusOK, UsageResponse{From: summary.From, To: summary.To, Totals: totals})
+}
+
+// respondError maps metering domain errors to HTTP responses
+func respondError(c *gin.Context, err error) {
+	switch err {
+	case meteringEntities.ErrInvalidUsageRange:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
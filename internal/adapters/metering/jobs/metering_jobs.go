@@ -0,0 +1,28 @@
+// Package jobs holds the scheduled jobs of the metering module
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	meteringUsecases "clean-arch-gin/internal/domain/metering/usecases"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+)
+
+// NewReportUsageJob reports the usage of the periods that ended for billing, hourly, a few
+// minutes past the hour so every replica has written its counts
+func NewReportUsageJob(metering meteringUsecases.MeteringUseCase) scheduler.Job {
+	return scheduler.Job{
+		Name:     "report-usage",
+		Schedule: "10 * * * *",
+		Timeout:  5 * time.Minute,
+		Run: func(ctx context.Context) error {
+			reported, err := metering.ReportUsage(ctx, time.Now())
+			if reported > 0 {
+				log.Printf("metering: reported the usage of %d tenant periods", reported)
+			}
+			return err
+		},
+	}
+}
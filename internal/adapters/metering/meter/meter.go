@@ -0,0 +1,119 @@
+// Package meter counts billable operations in memory and adds the counts to the usage
+// records periodically, so counting never waits on the database
+package meter
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	meteringEntities "clean-arch-gin/internal/domain/metering/entities"
+	meteringRepositories "clean-arch-gin/internal/domain/metering/repositories"
+	"clean-arch-gin/internal/domain/shared/metering"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
+)
+
+// flushTimeout bounds the last flush, made after the loop of Run is cancelled
+const flushTimeout = 10 * time.Second
+
+// counterKey identifies the usage record a count adds to
+type counterKey struct {
+	tenantID    uint
+	apiKeyID    uint
+	metric      string
+	periodStart time.Time
+}
+
+// BufferedMeter implements metering.Meter, buffering counts until Flush writes them
+type BufferedMeter struct {
+	usage meteringRepositories.UsageRepository
+
+	mu     sync.Mutex
+	counts map[counterKey]int64
+}
+
+var _ metering.Meter = (*BufferedMeter)(nil)
+
+// NewBufferedMeter creates a meter adding its counts to usage
+func NewBufferedMeter(usage meteringRepositories.UsageRepository) *BufferedMeter {
+	return &BufferedMeter{usage: usage, counts: make(map[counterKey]int64)}
+}
+
+// Record counts quantity of metric for the tenant of ctx, the default one when it acts for
+// none, and its API key if any
+func (m *BufferedMeter) Record(ctx context.Context, metric string, quantity int64) {
+	if quantity <= 0 {
+		return
+	}
+	tenantID, ok := tenancy.FromContext(ctx)
+	if !ok {
+		tenantID = tenantEntities.DefaultTenantID
+	}
+	apiKeyID, _ := metering.APIKeyFromContext(ctx)
+	key := counterKey{
+		tenantID:    tenantID,
+		apiKeyID:    apiKeyID,
+		metric:      metric,
+		periodStart: meteringEntities.PeriodStart(time.Now()),
+	}
+
+	m.mu.Lock()
+	m.counts[key] += quantity
+	m.mu.Unlock()
+}
+
+// Flush adds the buffered counts to the usage records; counts that could not be written are
+// kept for the next flush
+func (m *BufferedMeter) Flush(ctx context.Context) error {
+	m.mu.Lock()
+	counts := m.counts
+	m.counts = make(map[counterKey]int64)
+	m.mu.Unlock()
+	if len(counts) == 0 {
+		return nil
+	}
+
+	records := make([]*meteringEntities.UsageRecord, 0, len(counts))
+	for key, quantity := range counts {
+		records = append(records, &meteringEntities.UsageRecord{
+			TenantID:    key.tenantID,
+			APIKeyID:    key.apiKeyID,
+			Metric:      key.metric,
+			PeriodStart: key.periodStart,
+			Quantity:    quantity,
+		})
+	}
+	// The counts are written without a tenant so each record keeps its own
+	if err := m.usage.Add(tenancy.WithoutTenant(ctx), records); err != nil {
+		m.mu.Lock()
+		for key, quantity := range counts {
+			m.counts[key] += quantity
+		}
+		m.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Run flushes every interval until ctx is cancelled, then flushes one last time
+func (m *BufferedMeter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+			defer cancel()
+			if err := m.Flush(flushCtx); err != nil {
+				log.Printf("metering: failed to write the last usage counts: %v", err)
+			}
+			return
+		case <-ticker.C:
+			if err := m.Flush(ctx); err != nil {
+				log.Printf("metering: failed to write usage counts: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package meter
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"clean-arch-gin/internal/domain/shared/metering"
+	"clean-arch-gin/internal/domain/shared/storage"
+)
+
+// meteredStorage counts the bytes written to a storage as MetricStorageBytes
+type meteredStorage struct {
+	storage.Storage
+	meter metering.Meter
+}
+
+// meteredMultipartStorage also counts the bytes of uploaded parts, keeping the multipart
+// uploads of the storage available
+type meteredMultipartStorage struct {
+	meteredStorage
+	multipart storage.MultipartStorage
+}
+
+// Storage wraps files so the bytes written to it are counted by meter for the tenant and API
+// key of the write; a MultipartStorage stays one
+func Storage(files storage.Storage, meter metering.Meter) storage.Storage {
+	metered := meteredStorage{Storage: files, meter: meter}
+	if multipart, ok := files.(storage.MultipartStorage); ok {
+		return &meteredMultipartStorage{meteredStorage: metered, multipart: multipart}
+	}
+	return &metered
+}
+
+// Put writes body and counts its bytes once written; files are passed through as they are,
+// since storages stream them rather than reading them into memory, and sized beforehand
+func (s *meteredStorage) Put(ctx context.Context, key, contentType string, body io.Reader) error {
+	if file, ok := body.(*os.File); ok {
+		size, err := remainingSize(file)
+		if err != nil {
+			return err
+		}
+		if err := s.Storage.Put(ctx, key, contentType, file); err != nil {
+			return err
+		}
+		s.meter.Record(ctx, metering.MetricStorageBytes, size)
+		return nil
+	}
+
+	counted := &countingReader{reader: body}
+	if err := s.Storage.Put(ctx, key, contentType, counted); err != nil {
+		return err
+	}
+	s.meter.Record(ctx, metering.MetricStorageBytes, counted.n)
+	return nil
+}
+
+// PartSize returns the part size of the storage
+func (s *meteredMultipartStorage) PartSize() int {
+	return s.multipart.PartSize()
+}
+
+// CreateUpload starts an upload
+func (s *meteredMultipartStorage) CreateUpload(ctx context.Context, key, contentType string) (string, error) {
+	return s.multipart.CreateUpload(ctx, key, contentType)
+}
+
+// UploadPart uploads a part and counts its bytes once uploaded
+func (s *meteredMultipartStorage) UploadPart(ctx context.Context, key, uploadID string, number int, body []byte) (storage.Part, error) {
+	part, err := s.multipart.UploadPart(ctx, key, uploadID, number, body)
+	if err != nil {
+		return part, err
+	}
+	s.meter.Record(ctx, metering.MetricStorageBytes, int64(len(body)))
+	return part, nil
+}
+
+// ListParts lists the uploaded parts
+func (s *meteredMultipartStorage) ListParts(ctx context.Context, key, uploadID string) ([]storage.Part, error) {
+	return s.multipart.ListParts(ctx, key, uploadID)
+}
+
+// CompleteUpload assembles the parts
+func (s *meteredMultipartStorage) CompleteUpload(ctx context.Context, key, uploadID string, parts []storage.Part) error {
+	return s.multipart.CompleteUpload(ctx, key, uploadID, parts)
+}
+
+// AbortUpload discards the upload
+func (s *meteredMultipartStorage) AbortUpload(ctx context.Context, key, uploadID string) error {
+	return s.multipart.AbortUpload(ctx, key, uploadID)
+}
+
+// remainingSize is how many bytes are left to read from file
+func remainingSize(file *os.File) (int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	offset, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size() - offset, nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	reader io.Reader
+	n      int64
+}
+
+// Read reads from the underlying reader
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
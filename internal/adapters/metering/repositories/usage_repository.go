@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	meteringEntities "clean-arch-gin/internal/domain/metering/entities"
+	meteringRepositories "clean-arch-gin/internal/domain/metering/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// periodKey is the unique index identifying the row a usage record adds to
+var periodKey = []clause.Column{{Name: "tenant_id"}, {Name: "api_key_id"}, {Name: "metric"}, {Name: "period_start"}}
+
+// usageRepository implements UsageRepository using GORM
+type usageRepository struct {
+	db *gorm.DB
+}
+
+// NewUsageRepository creates a new usage repository
+func NewUsageRepository(db *gorm.DB) meteringRepositories.UsageRepository {
+	return &usageRepository{db: db}
+}
+
+// Add upserts every record, incrementing the quantity of the rows already there, in one
+// transaction
+func (r *usageRepository) Add(ctx context.Context, records []*meteringEntities.UsageRecord) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, record := range records {
+			model := models.NewUsageRecordModelFromEntity(record)
+			model.ID = 0
+			err := tx.Clauses(clause.OnConflict{
+				Columns: periodKey,
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"quantity":   gorm.Expr("usage_records.quantity + ?", record.Quantity),
+					"updated_at": time.Now(),
+				}),
+			}).Create(model).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListUnreported returns the records with usage to report of periods starting before before
+func (r *usageRepository) ListUnreported(ctx context.Context, before time.Time, limit int) ([]*meteringEntities.UsageRecord, error) {
+	var recordModels []models.UsageRecordModel
+	err := r.db.WithContext(ctx).
+		Where("period_start < ? AND quantity > reported", before).
+		Order("tenant_id, period_start, id").
+		Limit(limit).
+		Find(&recordModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*meteringEntities.UsageRecord, len(recordModels))
+	for i := range recordModels {
+		records[i] = recordModels[i].ToDomainEntity()
+	}
+	return records, nil
+}
+
+// MarkReported adds quantity to the reported usage of a record
+func (r *usageRepository) MarkReported(ctx context.Context, id uint, quantity int64) error {
+	return r.db.WithContext(ctx).Model(&models.UsageRecordModel{}).Where("id = ?", id).
+		UpdateColumn("reported", gorm.Expr("reported + ?", quantity)).Error
+}
+
+// Totals sums the usage of the tenant of ctx by API key and metric
+func (r *usageRepository) Totals(ctx context.Context, from, to time.Time, apiKeyID *uint) ([]meteringEntities.UsageTotal, error) {
+	query := r.db.WithContext(ctx).Model(&models.UsageRecordModel{}).
+		Select("api_key_id, metric, SUM(quantity) AS quantity").
+		Where("period_start >= ? AND period_start < ?", from, to)
+	if apiKeyID != nil {
+		query = query.Where("api_key_id = ?", *apiKeyID)
+	}
+
+	var rows []struct {
+		APIKeyID uint
+		Metric   string
+		Quantity int64
+	}
+	if err := query.Group("api_key_id, metric").Order("api_key_id, metric").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	totals := make([]meteringEntities.UsageTotal, len(rows))
+	for i, row := range rows {
+		totals[i] = meteringEntities.UsageTotal{APIKeyID: row.APIKeyID, Metric: row.Metric, Quantity: row.Quantity}
+	}
+	return totals, nil
+}
@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	meteringEntities "clean-arch-gin/internal/domain/metering/entities"
+	meteringEvents "clean-arch-gin/internal/domain/metering/events"
+	meteringRepositories "clean-arch-gin/internal/domain/metering/repositories"
+	meteringUsecases "clean-arch-gin/internal/domain/metering/usecases"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
+)
+
+const (
+	// reportDelay is how long after a period ends it is reported, so the counts replicas
+	// still buffer for it are in
+	reportDelay = 5 * time.Minute
+	// reportBatchSize is how many records are read at a time to be reported
+	reportBatchSize = 500
+	// maxUsageRange bounds the range of a usage summary
+	maxUsageRange = 366 * 24 * time.Hour
+)
+
+// meteringUseCase implements the MeteringUseCase interface
+type meteringUseCase struct {
+	usage     meteringRepositories.UsageRepository
+	publisher events.EventPublisher
+}
+
+// NewMeteringUseCase creates a new metering use case reporting usage on publisher
+// Reports are published for the default tenant, whose webhook endpoints are the platform
+// operator's, since they cover every tenant
+func NewMeteringUseCase(usage meteringRepositories.UsageRepository, publisher events.EventPublisher) meteringUsecases.MeteringUseCase {
+	return &meteringUseCase{
+		usage:     usage,
+		publisher: publisher,
+	}
+}
+
+// ReportUsage publishes the unreported usage of each tenant and period, marking it
+// reported once published; a failure leaves the rest for the next run
+func (uc *meteringUseCase) ReportUsage(ctx context.Context, now time.Time) (int, error) {
+	before := meteringEntities.PeriodStart(now.Add(-reportDelay))
+	publishCtx := tenancy.NewContext(ctx, tenantEntities.DefaultTenantID)
+
+	published := 0
+	for {
+		records, err := uc.usage.ListUnreported(ctx, before, reportBatchSize)
+		if err != nil {
+			return published, err
+		}
+
+		// Records come by tenant and period; a group cut by the batch is reported in two
+		// events, which add up
+		for start := 0; start < len(records); {
+			end := start + 1
+			for end < len(records) && records[end].TenantID == records[start].TenantID &&
+				records[end].PeriodStart.Equal(records[start].PeriodStart) {
+				end++
+			}
+			if err := uc.report(ctx, publishCtx, records[start:end]); err != nil {
+				return published, err
+			}
+			published++
+			start = end
+		}
+
+		if len(records) < reportBatchSize {
+			return published, nil
+		}
+	}
+}
+
+// GetUsage sums the usage of the tenant of ctx over whole periods
+func (uc *meteringUseCase) GetUsage(ctx context.Context, from, to time.Time, apiKeyID *uint) (*meteringEntities.UsageSummary, error) {
+	from, to = meteringEntities.PeriodStart(from), meteringEntities.PeriodStart(to)
+	if !to.After(from) || to.Sub(from) > maxUsageRange {
+		return nil, meteringEntities.ErrInvalidUsageRange
+	}
+	totals, err := uc.usage.Totals(ctx, from, to, apiKeyID)
+	if err != nil {
+		return nil, err
+	}
+	return &meteringEntities.UsageSummary{From: from, To: to, Totals: totals}, nil
+}
+
+// report publishes the usage of records, all of one tenant and period, and marks it reported
+func (uc *meteringUseCase) report(ctx, publishCtx context.Context, records []*meteringEntities.UsageRecord) error {
+	items := make([]meteringEvents.UsageItem, len(records))
+	for i, record := range records {
+		items[i] = meteringEvents.UsageItem{APIKeyID: record.APIKeyID, Metric: record.Metric, Quantity: record.Unreported()}
+	}
+	event := meteringEvents.NewUsageReportedEvent(records[0].TenantID, records[0].PeriodStart, items)
+	if err := uc.publisher.Publish(publishCtx, event); err != nil {
+		return err
+	}
+	for i, record := range records {
+		if err := uc.usage.MarkReported(ctx, record.ID, items[i].Quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
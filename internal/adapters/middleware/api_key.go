@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	apikeyEntities "clean-arch-gin/internal/domain/apikey/entities"
+	"clean-arch-gin/internal/domain/shared/metering"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries the API key of a request
+const APIKeyHeader = "X-API-Key"
+
+// apiKeyIDKey is the context key set by APIKeyAuth
+const apiKeyIDKey = "apiKeyID"
+
+// APIKeyAuthenticator resolves API keys and counts their requests against their limits
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*apikeyEntities.APIKey, error)
+	Consume(ctx context.Context, key *apikeyEntities.APIKey) (apikeyEntities.Allowance, error)
+}
+
+// APIKeyAuth authenticates requests carrying an X-API-Key as the key's owner for RequireAuth,
+// and counts them against the key's limits; it runs in front of every route. A rejected key
+// is remembered for RequireAuth to report, like SessionAuth does, but a key over its rate
+// limit or monthly quota gets 429 with Retry-After whatever the route. Allowed requests
+// report what is left in the X-RateLimit-* and X-Quota-* headers, the limits of unlimited
+// keys being left out, and their work is billed to the key through the request context.
+// When the counter fails the request goes through uncounted, since the API must not depend
+// on it
+func APIKeyAuth(keys APIKeyAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := strings.TrimSpace(c.GetHeader(APIKeyHeader))
+		if secret == "" {
+			c.Next()
+			return
+		}
+		ctx := c.Request.Context()
+
+		key, err := keys.Authenticate(ctx, secret)
+		if err != nil {
+			c.Set(authErrorKey, err)
+			c.Next()
+			return
+		}
+
+		allowance, err := keys.Consume(ctx, key)
+		if err != nil {
+			log.Printf("api keys: failed to count a request of key %d: %v", key.ID, err)
+		} else {
+			setAllowanceHeaders(c, allowance)
+			if !allowance.Allowed {
+				reset, message := allowance.RateReset, "API key rate limit exceeded, try again later"
+				if allowance.QuotaExceeded() {
+					reset, message = allowance.QuotaReset, "API key monthly quota exceeded"
+				}
+				c.Header("Retry-After", strconv.Itoa(max(int(math.Ceil(time.Until(reset).Seconds())), 1)))
+				c.JSON(http.StatusTooManyRequests, gin.H{"error": message})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Set("userID", key.OwnerID)
+		c.Set(apiKeyIDKey, key.ID)
+		c.Request = c.Request.WithContext(metering.WithAPIKey(ctx, key.ID))
+
+		c.Next()
+	}
+}
+
+// setAllowanceHeaders reports the limits of the key and what is left of them, with their
+// resets as Unix times
+func setAllowanceHeaders(c *gin.Context, allowance apikeyEntities.Allowance) {
+	if allowance.Limits.RatePerMinute > 0 {
+		c.Header("X-RateLimit-Limit", strconv.Itoa(allowance.Limits.RatePerMinute))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(allowance.RateRemaining()))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(allowance.RateReset.Unix(), 10))
+	}
+	if allowance.Limits.MonthlyQuota > 0 {
+		c.Header("X-Quota-Limit", strconv.FormatInt(allowance.Limits.MonthlyQuota, 10))
+		c.Header("X-Quota-Remaining", strconv.FormatInt(allowance.QuotaRemaining(), 10))
+		c.Header("X-Quota-Reset", strconv.FormatInt(allowance.QuotaReset.Unix(), 10))
+	}
+}
+
+// APIKeyID returns the ID of the API key the request was authenticated with, if any
+func APIKeyID(c *gin.Context) (uint, bool) {
+	id, ok := c.Get(apiKeyIDKey)
+	if !ok {
+		return 0, false
+	}
+	keyID, ok := id.(uint)
+	return keyID, ok
+}
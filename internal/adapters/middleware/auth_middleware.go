@@ -1,11 +1,48 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"log"
 	"net/http"
+	"strings"
+
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/domain/shared/authz"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Context keys set by SessionAuth, APIKeyAuth and UseAuthorizer
+const (
+	sessionIDKey  = "sessionID"
+	authErrorKey  = "authError"
+	authorizerKey = "authorizer"
+	// roleResolverKey holds the RoleResolver given to SessionAuth, rolesKey the roles it
+	// resolved for the request
+	roleResolverKey = "roleResolver"
+	rolesKey        = "roles"
+	// accountCheckerKey holds the AccountChecker given to SessionAuth
+	accountCheckerKey = "accountChecker"
+)
+
+// SessionAuthenticator resolves a bearer token to its server-side session in the tenant of ctx
+type SessionAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*userEntities.Session, error)
+}
+
+// RoleResolver looks up the roles a user acts with, such as the role stored on their account
+type RoleResolver interface {
+	Roles(ctx context.Context, userID uint) ([]string, error)
+}
+
+// AccountChecker returns why a user may not act, such as a suspended account, or nil
+type AccountChecker interface {
+	CheckAccount(ctx context.Context, userID uint) error
+}
+
 // AuthMiddleware provides authentication and authorization middleware
 type AuthMiddleware struct {
 	// Add any dependencies like JWT service, user service, etc.
@@ -19,53 +56,42 @@ func NewAuthMiddleware(jwtSecret string) *AuthMiddleware {
 	}
 }
 
-// RequireAuth middleware that requires user authentication
+// RequireAuth requires the request to be authenticated with a session token resolved by
+// SessionAuth or an API key resolved by APIKeyAuth, for a user whose account the
+// AccountChecker given to SessionAuth lets act
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Placeholder implementation
-		// In real implementation, you would:
-		// 1. Extract JWT token from Authorization header
-		// 2. Validate the token
-		// 3. Extract user information from token
-		// 4. Set user context
-
-		token := c.GetHeader("Authorization")
-		if token == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Authorization header required",
-			})
-			c.Abort()
+		_, hasSession := SessionID(c)
+		_, hasAPIKey := APIKeyID(c)
+		if hasSession || hasAPIKey {
+			if !checkAccount(c) {
+				c.Abort()
+				return
+			}
+			c.Next()
 			return
 		}
-
-		// Placeholder: In real implementation, validate JWT token
-		if token != "Bearer valid-token" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid token",
-			})
+		if err, ok := c.Get(authErrorKey); ok {
+			respondAuthError(c, err.(error))
 			c.Abort()
 			return
 		}
 
-		// Set user in context (placeholder)
-		c.Set("userID", uint(1))
-		c.Set("email", "user@example.com")
-
-		c.Next()
+		// A token nothing resolved, e.g. without a session store, is as good as none
+		message := "Authorization header required"
+		if c.GetHeader("Authorization") != "" {
+			message = "Invalid token"
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": message})
+		c.Abort()
 	}
 }
 
-// RequireRole middleware that requires specific user role
+// RequireRole middleware that requires the authenticated user to hold role
+// It must run after RequireAuth
 func (m *AuthMiddleware) RequireRole(role string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Placeholder implementation
-		// In real implementation, you would:
-		// 1. Get user from context (set by RequireAuth)
-		// 2. Check if user has required role
-		// 3. Allow or deny access
-
-		userRole := c.GetHeader("X-User-Role") // Placeholder
-		if userRole != role {
+		if !HasRole(c, role) {
 			c.JSON(http.StatusForbidden, gin.H{
 				"error": "Insufficient permissions",
 			})
@@ -77,19 +103,175 @@ func (m *AuthMiddleware) RequireRole(role string) gin.HandlerFunc {
 	}
 }
 
-// OptionalAuth middleware that optionally extracts user info if token is present
-func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
+// RequirePermission middleware that requires the policy to allow the user action on object
+// It must run after RequireAuth. Without an authorizer installed by UseAuthorizer only the
+// admin role passes
+func (m *AuthMiddleware) RequirePermission(object, action string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		token := c.GetHeader("Authorization")
-		if token != "" {
-			// Validate token and set user context if valid
-			// But don't abort if invalid, just continue without user context
-			if token == "Bearer valid-token" {
-				c.Set("userID", uint(1))
-				c.Set("email", "user@example.com")
+		if err := Authorize(c, object, action); err != nil {
+			if err == authz.ErrForbidden {
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			} else {
+				responses.InternalError(c, err)
 			}
+			c.Abort()
+			return
 		}
 
 		c.Next()
 	}
 }
+
+// Authorize returns authz.ErrForbidden unless the policy allows the user action on object,
+// for handlers deciding what to serve by permission; without an authorizer installed by
+// UseAuthorizer only the admin role is allowed
+func Authorize(c *gin.Context, object, action string) error {
+	value, ok := c.Get(authorizerKey)
+	if !ok {
+		if !HasRole(c, "admin") {
+			return authz.ErrForbidden
+		}
+		return nil
+	}
+	return authz.Authorize(value.(authz.Authorizer), CurrentActor(c), object, action)
+}
+
+// OptionalAuth lets requests through with or without credentials; the user resolved by
+// SessionAuth or APIKeyAuth, if any, is in the context
+func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+	}
+}
+
+// SessionAuth resolves bearer tokens issued at login to their session and sets the user
+// and session in the context for RequireAuth and OptionalAuth; it never aborts, so it can
+// run in front of every route. A rejected token is remembered for RequireAuth to report
+// roles, when not nil, looks up the roles of the authenticated user, however they were
+// authenticated, the first time HasRole or CurrentActor needs them, and accounts, when not
+// nil, turns away users RequireAuth must not let act, e.g. suspended ones
+func SessionAuth(sessions SessionAuthenticator, roles RoleResolver, accounts AccountChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if roles != nil {
+			c.Set(roleResolverKey, roles)
+		}
+		if accounts != nil {
+			c.Set(accountCheckerKey, accounts)
+		}
+
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			c.Next()
+			return
+		}
+
+		session, err := sessions.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			c.Set(authErrorKey, err)
+			c.Next()
+			return
+		}
+		c.Set("userID", session.UserID)
+		c.Set(sessionIDKey, session.ID)
+
+		c.Next()
+	}
+}
+
+// checkAccount lets the authenticated user through unless the AccountChecker given to
+// SessionAuth turns their account away, responding 403 for a suspended account or one
+// awaiting a password reset
+func checkAccount(c *gin.Context) bool {
+	accounts, ok := c.Get(accountCheckerKey)
+	userID, authenticated := UserID(c)
+	if !ok || !authenticated {
+		return true
+	}
+	switch err := accounts.(AccountChecker).CheckAccount(c.Request.Context(), userID); err {
+	case nil:
+		return true
+	case userEntities.ErrUserSuspended, userEntities.ErrPasswordResetRequired:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		respondAuthError(c, err)
+	}
+	return false
+}
+
+// respondAuthError rejects a token SessionAuth or a key APIKeyAuth could not resolve
+func respondAuthError(c *gin.Context, err error) {
+	var domainErr sharedEntities.DomainError
+	if errors.As(err, &domainErr) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": domainErr.Error()})
+		return
+	}
+	responses.InternalError(c, err)
+}
+
+// UseAuthorizer makes authorizer decide RequirePermission; it runs in front of every route
+func UseAuthorizer(authorizer authz.Authorizer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(authorizerKey, authorizer)
+		c.Next()
+	}
+}
+
+// CurrentActor returns the authenticated user with the roles they act with, for policy
+// checks; the authorizer resolves the roles granted to the user's own subject
+func CurrentActor(c *gin.Context) authz.Actor {
+	actor := authz.Actor{}
+	actor.ID, _ = UserID(c)
+	actor.Roles = userRoles(c)
+	return actor
+}
+
+// userRoles returns the roles of the authenticated user, looked up once per request through
+// the RoleResolver given to SessionAuth; a failed lookup is logged and grants no role
+func userRoles(c *gin.Context) []string {
+	if roles, ok := c.Get(rolesKey); ok {
+		return roles.([]string)
+	}
+	var roles []string
+	userID, authenticated := UserID(c)
+	resolver, ok := c.Get(roleResolverKey)
+	if authenticated && ok {
+		var err error
+		if roles, err = resolver.(RoleResolver).Roles(c.Request.Context(), userID); err != nil {
+			log.Printf("failed to look up the roles of user %d: %v", userID, err)
+			roles = nil
+		}
+	}
+	c.Set(rolesKey, roles)
+	return roles
+}
+
+// SessionID returns the ID of the session the request was authenticated with, if any
+func SessionID(c *gin.Context) (uint, bool) {
+	id, ok := c.Get(sessionIDKey)
+	if !ok {
+		return 0, false
+	}
+	sessionID, ok := id.(uint)
+	return sessionID, ok
+}
+
+// UserID returns the authenticated user's ID set by RequireAuth or OptionalAuth
+func UserID(c *gin.Context) (uint, bool) {
+	id, ok := c.Get("userID")
+	if !ok {
+		return 0, false
+	}
+	userID, ok := id.(uint)
+	return userID, ok
+}
+
+// HasRole reports whether the authenticated user has role, for handlers that serve several
+// roles differently
+func HasRole(c *gin.Context, role string) bool {
+	for _, held := range userRoles(c) {
+		if held == role {
+			return true
+		}
+	}
+	return false
+}
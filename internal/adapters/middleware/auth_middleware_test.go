@@ -0,0 +1,91 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	apikeyEntities "clean-arch-gin/internal/domain/apikey/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	"clean-arch-gin/internal/testutil/httptestutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeSessions resolves "token" to a session of user 1 and checks accounts against accounts
+type fakeSessions struct {
+	accounts map[uint]error
+}
+
+// Authenticate resolves "token" and rejects any other
+func (s fakeSessions) Authenticate(_ context.Context, token string) (*userEntities.Session, error) {
+	if token != "token" {
+		return nil, userEntities.ErrInvalidToken
+	}
+	return &userEntities.Session{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
+
+// CheckAccount returns the error set for the user
+func (s fakeSessions) CheckAccount(_ context.Context, userID uint) error {
+	return s.accounts[userID]
+}
+
+// fakeAPIKeys resolves "key" to a key of user 2 with no limits
+type fakeAPIKeys struct{}
+
+// Authenticate resolves "key" and rejects any other
+func (fakeAPIKeys) Authenticate(_ context.Context, key string) (*apikeyEntities.APIKey, error) {
+	if key != "key" {
+		return nil, userEntities.ErrInvalidToken
+	}
+	return &apikeyEntities.APIKey{ID: 3, OwnerID: 2}, nil
+}
+
+// Consume allows every request
+func (fakeAPIKeys) Consume(context.Context, *apikeyEntities.APIKey) (apikeyEntities.Allowance, error) {
+	return apikeyEntities.Allowance{Allowed: true}, nil
+}
+
+func TestRequireAuthChecksAccounts(t *testing.T) {
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		accounts   map[uint]error
+		wantStatus int
+		wantError  string
+	}{
+		{"no credentials", nil, nil, http.StatusUnauthorized, "Authorization header required"},
+		{"unknown token", map[string]string{"Authorization": "Bearer other"}, nil, http.StatusUnauthorized, userEntities.ErrInvalidToken.Error()},
+		{"active session", map[string]string{"Authorization": "Bearer token"}, nil, http.StatusOK, ""},
+		{"suspended session user", map[string]string{"Authorization": "Bearer token"},
+			map[uint]error{1: userEntities.ErrUserSuspended}, http.StatusForbidden, userEntities.ErrUserSuspended.Error()},
+		{"session user awaiting a password reset", map[string]string{"Authorization": "Bearer token"},
+			map[uint]error{1: userEntities.ErrPasswordResetRequired}, http.StatusForbidden, userEntities.ErrPasswordResetRequired.Error()},
+		{"deleted session user", map[string]string{"Authorization": "Bearer token"},
+			map[uint]error{1: userEntities.ErrInvalidToken}, http.StatusUnauthorized, userEntities.ErrInvalidToken.Error()},
+		{"active API key owner", map[string]string{middleware.APIKeyHeader: "key"}, nil, http.StatusOK, ""},
+		{"suspended API key owner", map[string]string{middleware.APIKeyHeader: "key"},
+			map[uint]error{2: userEntities.ErrUserSuspended}, http.StatusForbidden, userEntities.ErrUserSuspended.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			sessions := fakeSessions{accounts: tt.accounts}
+			r := gin.New()
+			r.Use(middleware.SessionAuth(sessions, nil, sessions), middleware.APIKeyAuth(fakeAPIKeys{}))
+			r.GET("/private", middleware.NewAuthMiddleware("").RequireAuth(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			rec := httptestutil.Do(t, r, httptestutil.Request{Method: http.MethodGet, Path: "/private", Headers: tt.headers})
+			if tt.wantError == "" {
+				httptestutil.AssertStatus(t, rec, tt.wantStatus)
+				return
+			}
+			httptestutil.AssertError(t, rec, tt.wantStatus, tt.wantError)
+		})
+	}
+}
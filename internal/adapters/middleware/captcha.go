@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/domain/shared/captcha"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CaptchaHeader carries the response token of the challenge the client solved
+const CaptchaHeader = "X-Captcha-Token"
+
+// RequireCaptcha rejects requests whose CaptchaHeader token verifier does not accept, for
+// public endpoints open to automated abuse such as registration
+// Rejected tokens get 400; a provider that cannot be reached fails the request rather than
+// letting it through. A nil verifier, as configured in development and tests, lets every
+// request through
+func RequireCaptcha(verifier captcha.Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if verifier == nil {
+			c.Next()
+			return
+		}
+
+		if err := verifier.Verify(c.Request.Context(), c.GetHeader(CaptchaHeader), c.ClientIP()); err != nil {
+			if errors.Is(err, captcha.ErrFailed) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": captcha.ErrFailed.Error()})
+			} else {
+				responses.InternalError(c, err)
+			}
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
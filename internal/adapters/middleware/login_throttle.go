@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/tenancy"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxThrottledBody bounds how much of a request body is read for the account it names
+const maxThrottledBody = 64 << 10
+
+// attemptSucceededKey is the context key set by AttemptSucceeded
+const attemptSucceededKey = "throttledAttemptSucceeded"
+
+// AttemptLimiter records attempts per key and tells how long a key must wait
+type AttemptLimiter interface {
+	Attempt(ctx context.Context, key string) (time.Duration, error)
+	Reset(ctx context.Context, key string) error
+}
+
+// LoginThrottle slows down credential guessing on sign-in and password recovery routes by
+// limiting the attempts per client IP and per account
+type LoginThrottle struct {
+	ip      AttemptLimiter
+	account AttemptLimiter
+}
+
+// NewLoginThrottle creates a throttle counting attempts per IP on ip and per account on account
+func NewLoginThrottle(ip, account AttemptLimiter) *LoginThrottle {
+	return &LoginThrottle{ip: ip, account: account}
+}
+
+// Limit throttles the attempts of a route, named scope in the limiter keys; the account is
+// the email of the JSON body within the tenant of the request
+// Throttled attempts get 429 with Retry-After. An attempt the handler reports through
+// AttemptSucceeded, such as a sign-in with the right password, forgets the account's failures
+// but not the IP's, so an attacker cannot clear them with an account of their own; any other
+// response, e.g. the 202 of a password recovery request, counts against the account.
+// When the limiter fails the request goes through, since sign-in must not depend on it.
+// A nil throttle lets everything through
+func (t *LoginThrottle) Limit(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if t == nil {
+			c.Next()
+			return
+		}
+		ctx := c.Request.Context()
+
+		ipKey := scope + ":ip:" + c.ClientIP()
+		accountKey := ""
+		if email := requestEmail(c); email != "" {
+			tenantID, _ := tenancy.FromContext(ctx)
+			accountKey = scope + ":account:" + strconv.FormatUint(uint64(tenantID), 10) + ":" + email
+		}
+		if !t.attempt(c, t.ip, ipKey) || (accountKey != "" && !t.attempt(c, t.account, accountKey)) {
+			c.Abort()
+			return
+		}
+
+		c.Next()
+
+		if accountKey != "" && c.GetBool(attemptSucceededKey) {
+			if err := t.account.Reset(ctx, accountKey); err != nil {
+				log.Printf("login throttle: failed to reset the attempts of an account: %v", err)
+			}
+		}
+	}
+}
+
+// AttemptSucceeded tells the LoginThrottle of the route that the credentials of the request
+// were right, so the failures of its account are forgotten
+func AttemptSucceeded(c *gin.Context) {
+	c.Set(attemptSucceededKey, true)
+}
+
+// attempt records an attempt of key on limiter, responding 429 and returning false when
+// it must wait
+func (t *LoginThrottle) attempt(c *gin.Context, limiter AttemptLimiter, key string) bool {
+	wait, err := limiter.Attempt(c.Request.Context(), key)
+	if err != nil {
+		log.Printf("login throttle: failed to record an attempt: %v", err)
+		return true
+	}
+	if wait > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many attempts, try again later"})
+		return false
+	}
+	return true
+}
+
+// requestEmail returns the normalized email field of a JSON body, leaving the body for
+// the handler to read
+func requestEmail(c *gin.Context) string {
+	if c.Request.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxThrottledBody))
+	if err != nil {
+		return ""
+	}
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+
+	var payload struct {
+		Email string `json:"email"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(payload.Email))
+}
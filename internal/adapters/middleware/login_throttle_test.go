@@ -0,0 +1,96 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/infrastructure/throttle"
+	"clean-arch-gin/internal/testutil/httptestutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// accountLimit is how many attempts the test throttle lets an account make
+const accountLimit = 3
+
+// newThrottledRouter serves POST /attempt behind a throttle allowing accountLimit attempts
+// per account; the handler answers status and reports success when succeed is set
+func newThrottledRouter(status int, succeed bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	ip := throttle.NewMemoryLimiter(throttle.Options{Limit: 100, Window: time.Minute})
+	account := throttle.NewMemoryLimiter(throttle.Options{Limit: accountLimit, Window: time.Minute})
+
+	r := gin.New()
+	r.POST("/attempt", middleware.NewLoginThrottle(ip, account).Limit("test"), func(c *gin.Context) {
+		if succeed {
+			middleware.AttemptSucceeded(c)
+		}
+		c.Status(status)
+	})
+	return r
+}
+
+func TestLoginThrottleCountsAccountAttempts(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		succeed  bool
+		attempts int
+		// wantStatus is the status of the attempt after attempts
+		wantStatus int
+	}{
+		{"failed sign-ins", http.StatusUnauthorized, false, accountLimit, http.StatusTooManyRequests},
+		{"password reset requests", http.StatusAccepted, false, accountLimit, http.StatusTooManyRequests},
+		{"successful responses not reported", http.StatusOK, false, accountLimit, http.StatusTooManyRequests},
+		{"successful sign-ins", http.StatusCreated, true, accountLimit * 2, http.StatusCreated},
+		{"under the limit", http.StatusAccepted, false, accountLimit - 1, http.StatusAccepted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newThrottledRouter(tt.status, tt.succeed)
+			body := map[string]string{"email": "alice@example.com"}
+			for i := 0; i < tt.attempts; i++ {
+				httptestutil.AssertStatus(t, httptestutil.DoJSON(t, r, http.MethodPost, "/attempt", body), tt.status)
+			}
+
+			rec := httptestutil.DoJSON(t, r, http.MethodPost, "/attempt", body)
+			httptestutil.AssertStatus(t, rec, tt.wantStatus)
+			if tt.wantStatus == http.StatusTooManyRequests && rec.Header().Get("Retry-After") == "" {
+				t.Error("throttled attempt has no Retry-After")
+			}
+
+			// Other accounts are counted apart
+			other := map[string]string{"email": "bob@example.com"}
+			httptestutil.AssertStatus(t, httptestutil.DoJSON(t, r, http.MethodPost, "/attempt", other), tt.status)
+		})
+	}
+}
+
+func TestLoginThrottleSuccessForgetsFailures(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	account := throttle.NewMemoryLimiter(throttle.Options{Limit: accountLimit, Window: time.Minute})
+	ip := throttle.NewMemoryLimiter(throttle.Options{Limit: 100, Window: time.Minute})
+
+	r := gin.New()
+	r.POST("/login", middleware.NewLoginThrottle(ip, account).Limit("login"), func(c *gin.Context) {
+		if c.Query("password") == "right" {
+			middleware.AttemptSucceeded(c)
+			c.Status(http.StatusCreated)
+			return
+		}
+		c.Status(http.StatusUnauthorized)
+	})
+	body := map[string]string{"email": "alice@example.com"}
+
+	for i := 0; i < accountLimit-1; i++ {
+		httptestutil.AssertStatus(t, httptestutil.DoJSON(t, r, http.MethodPost, "/login", body), http.StatusUnauthorized)
+	}
+	httptestutil.AssertStatus(t, httptestutil.DoJSON(t, r, http.MethodPost, "/login?password=right", body), http.StatusCreated)
+	for i := 0; i < accountLimit; i++ {
+		httptestutil.AssertStatus(t, httptestutil.DoJSON(t, r, http.MethodPost, "/login", body), http.StatusUnauthorized)
+	}
+	httptestutil.AssertStatus(t, httptestutil.DoJSON(t, r, http.MethodPost, "/login", body), http.StatusTooManyRequests)
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"clean-arch-gin/internal/domain/shared/metering"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MeterRequests counts the authenticated requests served as MetricRequests, for the tenant
+// and API key of the request; it runs in front of every route, behind the middleware
+// resolving them. Requests refused for being over a limit are not counted
+func MeterRequests(meter metering.Meter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.Writer.Status() == http.StatusTooManyRequests {
+			return
+		}
+		if _, ok := UserID(c); !ok {
+			return
+		}
+		meter.Record(c.Request.Context(), metering.MetricRequests, 1)
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/publicid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PublicIDResolver finds the internal ID of the resource a public ID names
+type PublicIDResolver func(ctx context.Context, publicID string) (uint, error)
+
+// ResolvePublicID lets routes address resources by public ID only: the public ID in the
+// param route parameter is replaced with the internal ID the controllers parse. Malformed
+// IDs, sequential ones included, get 400 and unknown ones 404. Routes without the parameter
+// pass through
+func ResolvePublicID(param string, resolve PublicIDResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for i := range c.Params {
+			if c.Params[i].Key != param {
+				continue
+			}
+			if !publicid.Valid(c.Params[i].Value) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": params.ErrInvalidID.Error()})
+				c.Abort()
+				return
+			}
+
+			id, err := resolve(c.Request.Context(), publicid.Normalize(c.Params[i].Value))
+			if err != nil {
+				var domainErr sharedEntities.DomainError
+				if errors.As(err, &domainErr) {
+					c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				} else {
+					responses.InternalError(c, err)
+				}
+				c.Abort()
+				return
+			}
+			c.Params[i].Value = strconv.FormatUint(uint64(id), 10)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,212 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// allowedSensitiveKey holds the sensitive fields a handler reveals on purpose
+	allowedSensitiveKey = "sensitiveFieldsAllowed"
+	// skipSensitiveKey is set on requests whose response is not audited
+	skipSensitiveKey = "sensitiveFieldsSkipped"
+)
+
+// SensitiveFields name the fields a response must not carry, such as password hashes,
+// secrets, tokens and keys. A JSON key is sensitive when, lowercased and without underscores
+// and dashes, it ends with one of them, so password also covers new_password, PasswordHash
+// is covered by hash and private_key by key
+var SensitiveFields = []string{"password", "secret", "token", "hash", "key"}
+
+// AllowSensitiveFields lets the response to c carry the given JSON keys, for handlers that
+// reveal a secret on purpose, e.g. the token of a new session or a webhook secret once when
+// the endpoint is registered
+func AllowSensitiveFields(c *gin.Context, keys ...string) {
+	allowed, _ := c.Get(allowedSensitiveKey)
+	set, _ := allowed.(map[string]bool)
+	if set == nil {
+		set = make(map[string]bool, len(keys))
+		c.Set(allowedSensitiveKey, set)
+	}
+	for _, key := range keys {
+		set[key] = true
+	}
+}
+
+// SkipSensitiveFieldAudit leaves the response to c unaudited, for documents describing
+// fields rather than carrying their values, such as the OpenAPI document
+func SkipSensitiveFieldAudit(c *gin.Context) {
+	c.Set(skipSensitiveKey, true)
+}
+
+// GuardSensitiveFields audits the JSON responses of every route and replaces one carrying a
+// sensitive field the handler did not allow with a 500 naming the fields, so an entity or
+// model serialized in place of its DTO fails instead of leaking. Responses are buffered,
+// except those of other content types such as streams and downloads
+func GuardSensitiveFields() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writer := &auditingWriter{ResponseWriter: c.Writer, status: c.Writer.Status()}
+		c.Writer = writer
+		defer func() { c.Writer = writer.ResponseWriter }()
+
+		c.Next()
+
+		if !writer.buffered {
+			if !writer.decided && writer.status != writer.ResponseWriter.Status() {
+				writer.ResponseWriter.WriteHeader(writer.status)
+				writer.ResponseWriter.WriteHeaderNow()
+			}
+			return
+		}
+		var exposed []string
+		if !c.GetBool(skipSensitiveKey) {
+			allowed, _ := c.Get(allowedSensitiveKey)
+			set, _ := allowed.(map[string]bool)
+			exposed = ExposedSensitiveFields(writer.body.Bytes(), set)
+		}
+		if len(exposed) > 0 {
+			log.Printf("%s %s exposes sensitive fields: %s", c.Request.Method, c.FullPath(), strings.Join(exposed, ", "))
+			writer.ResponseWriter.Header().Del("Content-Length")
+			writer.ResponseWriter.WriteHeader(http.StatusInternalServerError)
+			body, _ := json.Marshal(gin.H{"error": "Response exposes sensitive fields: " + strings.Join(exposed, ", ")})
+			writer.ResponseWriter.Write(body)
+			return
+		}
+		writer.ResponseWriter.WriteHeader(writer.status)
+		writer.ResponseWriter.Write(writer.body.Bytes())
+	}
+}
+
+// ExposedSensitiveFields returns the paths of the sensitive keys in the JSON document body,
+// e.g. users[0].password, other than those in allowed, sorted; a body that is not JSON
+// exposes nothing
+func ExposedSensitiveFields(body []byte, allowed map[string]bool) []string {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil
+	}
+	var exposed []string
+	walkSensitive(doc, "", allowed, &exposed)
+	sort.Strings(exposed)
+	return exposed
+}
+
+// walkSensitive collects the paths of the sensitive keys in value, found at path
+func walkSensitive(value interface{}, path string, allowed map[string]bool, exposed *[]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if isSensitive(key) && !allowed[key] {
+				*exposed = append(*exposed, childPath)
+				continue
+			}
+			walkSensitive(child, childPath, allowed, exposed)
+		}
+	case []interface{}:
+		for i, child := range v {
+			walkSensitive(child, path+"["+strconv.Itoa(i)+"]", allowed, exposed)
+		}
+	}
+}
+
+// isSensitive reports whether a JSON key names a sensitive field
+func isSensitive(key string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	for _, field := range SensitiveFields {
+		if strings.HasSuffix(normalized, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// auditingWriter holds back JSON responses until the handler is done so they can be audited,
+// and passes the others through; whether a response is buffered is decided by its content
+// type when the handler starts writing it
+type auditingWriter struct {
+	gin.ResponseWriter
+	status   int
+	body     bytes.Buffer
+	decided  bool
+	buffered bool
+}
+
+// decide buffers JSON responses and writes the status of the others
+func (w *auditingWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	mediaType, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	w.buffered = mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+	if !w.buffered {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+}
+
+// WriteHeader records the status, written once the response is
+func (w *auditingWriter) WriteHeader(code int) {
+	if code > 0 && !w.decided {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow writes the status of a response passed through
+func (w *auditingWriter) WriteHeaderNow() {
+	w.decide()
+	if !w.buffered {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// Write buffers or passes through data
+func (w *auditingWriter) Write(data []byte) (int, error) {
+	w.decide()
+	if w.buffered {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString buffers or passes through s
+func (w *auditingWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends what was written of a response passed through
+func (w *auditingWriter) Flush() {
+	w.decide()
+	if !w.buffered {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// Status returns the status of the response
+func (w *auditingWriter) Status() int {
+	return w.status
+}
+
+// Size returns how many bytes of the body were written
+func (w *auditingWriter) Size() int {
+	if w.buffered {
+		return w.body.Len()
+	}
+	return w.ResponseWriter.Size()
+}
+
+// Written reports whether the response was started
+func (w *auditingWriter) Written() bool {
+	return w.decided
+}
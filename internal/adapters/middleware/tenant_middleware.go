@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TenantHeader names the tenant of a request by slug, ahead of its subdomain
+const TenantHeader = "X-Tenant"
+
+// tenantIDKey is the context key set by ResolveTenant
+const tenantIDKey = "tenantID"
+
+// TenantResolver finds the tenant a request names by slug
+type TenantResolver interface {
+	ResolveTenant(ctx context.Context, slug string) (*tenantEntities.Tenant, error)
+}
+
+// ResolveTenant scopes each request to a tenant so repositories only see its data
+// The tenant is named by the X-Tenant header or, with a baseDomain such as example.com,
+// by the subdomain of the host (acme.example.com); requests naming none act for the
+// default tenant without a lookup. Unknown tenants get 404 and suspended ones 403. It must
+// run in front of SessionAuth so sessions are looked up within the tenant
+func ResolveTenant(tenants TenantResolver, baseDomain string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := tenantEntities.DefaultTenantID
+		if slug := tenantSlug(c.Request, baseDomain); slug != "" && slug != tenantEntities.DefaultSlug {
+			tenant, err := tenants.ResolveTenant(c.Request.Context(), strings.ToLower(slug))
+			if err != nil {
+				respondTenantError(c, err)
+				c.Abort()
+				return
+			}
+			tenantID = tenant.ID
+		}
+
+		c.Set(tenantIDKey, tenantID)
+		c.Request = c.Request.WithContext(tenancy.NewContext(c.Request.Context(), tenantID))
+		c.Next()
+	}
+}
+
+// tenantSlug returns the slug the request names, "" for none
+func tenantSlug(r *http.Request, baseDomain string) string {
+	if slug := strings.TrimSpace(r.Header.Get(TenantHeader)); slug != "" {
+		return slug
+	}
+	if baseDomain == "" {
+		return ""
+	}
+
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	subdomain, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(baseDomain))
+	if !ok || strings.Contains(subdomain, ".") {
+		return ""
+	}
+	return subdomain
+}
+
+// respondTenantError rejects a request naming a tenant that cannot be served
+func respondTenantError(c *gin.Context, err error) {
+	switch err {
+	case tenantEntities.ErrTenantNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case tenantEntities.ErrTenantSuspended:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
+
+// TenantID returns the tenant the request was scoped to by ResolveTenant
+func TenantID(c *gin.Context) (uint, bool) {
+	id, ok := c.Get(tenantIDKey)
+	if !ok {
+		return 0, false
+	}
+	tenantID, ok := id.(uint)
+	return tenantID, ok
+}
+
+// RequireDefaultTenant limits a route to requests of the default tenant, for operations
+// spanning every tenant such as managing the tenants themselves
+func RequireDefaultTenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if tenantID, ok := TenantID(c); ok && tenantID != tenantEntities.DefaultTenantID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "only available to the default tenant"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
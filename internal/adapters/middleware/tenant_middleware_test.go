@@ -0,0 +1,80 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
+	"clean-arch-gin/internal/testutil/httptestutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeTenants knows acme as tenant 2 and frozen as a suspended tenant
+type fakeTenants struct{}
+
+// ResolveTenant resolves the known slugs
+func (fakeTenants) ResolveTenant(_ context.Context, slug string) (*tenantEntities.Tenant, error) {
+	switch slug {
+	case "acme":
+		return &tenantEntities.Tenant{ID: 2, Slug: slug}, nil
+	case "frozen":
+		return nil, tenantEntities.ErrTenantSuspended
+	default:
+		return nil, tenantEntities.ErrTenantNotFound
+	}
+}
+
+func TestTenantScoping(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		host       string
+		header     string
+		wantStatus int
+		wantTenant string
+	}{
+		{"no tenant named", "/scoped", "example.com", "", http.StatusOK, "1"},
+		{"default slug", "/scoped", "", "default", http.StatusOK, "1"},
+		{"header", "/scoped", "", "acme", http.StatusOK, "2"},
+		{"header in upper case", "/scoped", "", "ACME", http.StatusOK, "2"},
+		{"subdomain", "/scoped", "acme.example.com:8080", "", http.StatusOK, "2"},
+		{"header ahead of subdomain", "/scoped", "other.example.com", "acme", http.StatusOK, "2"},
+		{"nested subdomain", "/scoped", "a.acme.example.com", "", http.StatusOK, "1"},
+		{"unknown tenant", "/scoped", "", "nobody", http.StatusNotFound, ""},
+		{"suspended tenant", "/scoped", "", "frozen", http.StatusForbidden, ""},
+		{"default-only route, default tenant", "/default-only", "example.com", "", http.StatusOK, "1"},
+		{"default-only route, other tenant", "/default-only", "", "acme", http.StatusForbidden, ""},
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ResolveTenant(fakeTenants{}, "example.com"))
+	scoped := func(c *gin.Context) {
+		tenantID, _ := tenancy.FromContext(c.Request.Context())
+		c.String(http.StatusOK, strconv.FormatUint(uint64(tenantID), 10))
+	}
+	r.GET("/scoped", scoped)
+	r.GET("/default-only", middleware.RequireDefaultTenant(), scoped)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := map[string]string{}
+			if tt.header != "" {
+				headers[middleware.TenantHeader] = tt.header
+			}
+			if tt.host != "" {
+				headers["Host"] = tt.host
+			}
+			rec := httptestutil.Do(t, r, httptestutil.Request{Method: http.MethodGet, Path: tt.path, Headers: headers})
+			httptestutil.AssertStatus(t, rec, tt.wantStatus)
+			if tt.wantTenant != "" && rec.Body.String() != tt.wantTenant {
+				t.Errorf("tenant = %s, want %s", rec.Body.String(), tt.wantTenant)
+			}
+		})
+	}
+}
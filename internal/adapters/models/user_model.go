@@ -1,3 +1,6 @@
+// Package models holds the user model of the legacy flat router
+//
+// Deprecated: use the shared models in internal/adapters/shared/models
 package models
 
 import (
@@ -12,6 +15,7 @@ import (
 // This is infrastructure layer concern - contains GORM tags and database-specific logic
 type UserModel struct {
 	ID        uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	PublicID  *string        `gorm:"size:36;uniqueIndex" json:"public_id"`
 	Email     string         `gorm:"uniqueIndex;not null;size:255" json:"email"`
 	Name      string         `gorm:"not null;size:255" json:"name"`
 	Password  string         `gorm:"not null;size:255" json:"-"` // Excluded from JSON
@@ -33,8 +37,14 @@ func (u *UserModel) ToDomainEntity() *userEntities.User {
 		deletedAt = &u.DeletedAt.Time
 	}
 
+	var publicID string
+	if u.PublicID != nil {
+		publicID = *u.PublicID
+	}
+
 	return &userEntities.User{
 		ID:        u.ID,
+		PublicID:  publicID,
 		Email:     u.Email,
 		Name:      u.Name,
 		Password:  u.Password,
@@ -56,6 +66,9 @@ func NewUserModelFromEntity(user *userEntities.User) *UserModel {
 		UpdatedAt: user.UpdatedAt,
 	}
 
+	if user.PublicID != "" {
+		userModel.PublicID = &user.PublicID
+	}
 	if user.DeletedAt != nil {
 		userModel.DeletedAt = gorm.DeletedAt{
 			Time:  *user.DeletedAt,
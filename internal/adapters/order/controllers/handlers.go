@@ -0,0 +1,49 @@
+package controllers
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=handlers.go -destination=../../../mocks/order_handlers_mock.go -package=mocks
+
+import "github.com/gin-gonic/gin"
+
+// OrderHandlers are the handlers the order routes are registered against
+// OrderController implements them on the order and pricing use cases; routes take the
+// interface so they can be registered against fakes
+type OrderHandlers interface {
+	CreateOrder(c *gin.Context)
+	QuoteOrder(c *gin.Context)
+	GetOrder(c *gin.Context)
+	ConfirmOrder(c *gin.Context)
+	CancelOrder(c *gin.Context)
+	// StreamOrderEvents streams an order's status transitions as Server-Sent Events
+	StreamOrderEvents(c *gin.Context)
+	CreateShipment(c *gin.Context)
+	ListShipments(c *gin.Context)
+}
+
+// ReturnHandlers are the handlers of the routes of returns (RMAs)
+type ReturnHandlers interface {
+	RequestReturn(c *gin.Context)
+	ListReturns(c *gin.Context)
+	GetReturn(c *gin.Context)
+	ApproveReturn(c *gin.Context)
+	RejectReturn(c *gin.Context)
+	ReceiveReturn(c *gin.Context)
+	RefundReturn(c *gin.Context)
+}
+
+// InventoryHandlers are the handlers of the stock management routes
+type InventoryHandlers interface {
+	GetStock(c *gin.Context)
+	SetStock(c *gin.Context)
+}
+
+// InvoiceHandlers are the handlers of the invoice routes
+type InvoiceHandlers interface {
+	GetInvoicePDF(c *gin.Context)
+}
+
+var (
+	_ OrderHandlers     = (*OrderController)(nil)
+	_ ReturnHandlers    = (*ReturnController)(nil)
+	_ InventoryHandlers = (*InventoryController)(nil)
+	_ InvoiceHandlers   = (*InvoiceController)(nil)
+)
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/params"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderUsecases "clean-arch-gin/internal/domain/order/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StockLevelDTO represents the stock of a product in API responses
+type StockLevelDTO struct {
+	ProductID uint      `json:"product_id"`
+	OnHand    int       `json:"on_hand"`
+	Reserved  int       `json:"reserved"`
+	Available int       `json:"available"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SetStockRequest represents the request payload for setting the stock on hand
+type SetStockRequest struct {
+	OnHand *int `json:"on_hand" binding:"required"`
+}
+
+// toStockLevelDTO converts domain entity to DTO
+func toStockLevelDTO(level *orderEntities.StockLevel) StockLevelDTO {
+	return StockLevelDTO{
+		ProductID: level.ProductID,
+		OnHand:    level.OnHand,
+		Reserved:  level.Reserved,
+		Available: level.Available(),
+		UpdatedAt: level.UpdatedAt,
+	}
+}
+
+// InventoryController handles HTTP requests for managing stock levels
+type InventoryController struct {
+	inventoryUseCase orderUsecases.InventoryUseCase
+}
+
+// NewInventoryController creates a new inventory controller
+func NewInventoryController(inventoryUseCase orderUsecases.InventoryUseCase) *InventoryController {
+	return &InventoryController{
+		inventoryUseCase: inventoryUseCase,
+	}
+}
+
+// GetStock retrieves the stock level of the :productId product
+func (ic *InventoryController) GetStock(c *gin.Context) {
+	productID, err := params.ParseID(c.Param("productId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	level, err := ic.inventoryUseCase.GetStock(c.Request.Context(), productID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toStockLevelDTO(level))
+}
+
+// SetStock sets the units on hand of the :productId product
+func (ic *InventoryController) SetStock(c *gin.Context) {
+	productID, err := params.ParseID(c.Param("productId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+	var req SetStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	level, err := ic.inventoryUseCase.SetStock(c.Request.Context(), productID, *req.OnHand)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toStockLevelDTO(level))
+}
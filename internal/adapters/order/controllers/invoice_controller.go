@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+
+	orderUsecases "clean-arch-gin/internal/domain/order/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InvoiceController handles HTTP requests for order invoices
+type InvoiceController struct {
+	invoiceUseCase orderUsecases.InvoiceUseCase
+	// orderUseCase loads the orders whose invoices customers download
+	orderUseCase orderUsecases.OrderUseCase
+}
+
+// NewInvoiceController creates a new invoice controller, checking with orderUseCase that
+// customers only download the invoices of their own orders
+func NewInvoiceController(invoiceUseCase orderUsecases.InvoiceUseCase, orderUseCase orderUsecases.OrderUseCase) *InvoiceController {
+	return &InvoiceController{invoiceUseCase: invoiceUseCase, orderUseCase: orderUseCase}
+}
+
+// GetInvoicePDF downloads the order's invoice as a PDF, for the user who placed it or staff
+// allowed to read invoices
+// The PDF is rendered in full before responding, so a failed rendering still gets an error response
+func (ic *InvoiceController) GetInvoicePDF(c *gin.Context) {
+	order, ok := visibleOrder(c, ic.orderUseCase, "invoices", "read")
+	if !ok {
+		return
+	}
+
+	var pdf bytes.Buffer
+	invoice, err := ic.invoiceUseCase.RenderInvoicePDF(c.Request.Context(), order.ID, &pdf)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="`+invoice.Number()+`.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", pdf.Bytes())
+}
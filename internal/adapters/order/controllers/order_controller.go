@@ -0,0 +1,310 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/order/streams"
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderUsecases "clean-arch-gin/internal/domain/order/usecases"
+	"clean-arch-gin/internal/domain/shared/authz"
+	"clean-arch-gin/internal/domain/shared/money"
+	"clean-arch-gin/internal/domain/shared/payments"
+	"clean-arch-gin/internal/domain/shared/pricing"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// keepAliveInterval is how often an idle event stream sends a comment to keep proxies from closing it
+const keepAliveInterval = 15 * time.Second
+
+// OrderItemDTO represents an order item in API responses
+type OrderItemDTO struct {
+	ID        uint    `json:"id"`
+	ProductID uint    `json:"product_id"`
+	Quantity  int     `json:"quantity"`
+	Price     float64 `json:"price"`
+}
+
+// OrderDTO represents the order data transfer object for API responses
+// ID is the order's public ID, which routes take in place of the internal one. ExchangeRate is
+// set when the amounts were converted from the order's currency for display
+type OrderDTO struct {
+	ID             string         `json:"id"`
+	UserID         uint           `json:"user_id"`
+	Status         string         `json:"status"`
+	TotalAmount    float64        `json:"total_amount"`
+	TaxAmount      float64        `json:"tax_amount"`
+	ShippingAmount float64        `json:"shipping_amount"`
+	Currency       string         `json:"currency"`
+	ExchangeRate   float64        `json:"exchange_rate,omitempty"`
+	Items          []OrderItemDTO `json:"items"`
+	// Transitions lists the status transitions the order may take next, e.g. confirm and cancel
+	Transitions []string  `json:"transitions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// toDTO converts domain entity to DTO
+func toDTO(order *orderEntities.Order) OrderDTO {
+	items := make([]OrderItemDTO, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = OrderItemDTO{
+			ID:        item.ID,
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+		}
+	}
+
+	return OrderDTO{
+		ID:             order.PublicID,
+		UserID:         order.UserID,
+		Status:         string(order.Status),
+		TotalAmount:    order.TotalAmount,
+		TaxAmount:      order.TaxAmount,
+		ShippingAmount: order.ShippingAmount,
+		Currency:       string(order.Currency),
+		Items:          items,
+		Transitions:    order.AvailableTransitions(),
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
+	}
+}
+
+// OrderController handles HTTP requests for order operations
+type OrderController struct {
+	orderUseCase   orderUsecases.OrderUseCase
+	pricingUseCase orderUsecases.PricingUseCase
+	statusStream   *streams.StatusStream
+}
+
+// NewOrderController creates a new order controller
+func NewOrderController(orderUseCase orderUsecases.OrderUseCase, pricingUseCase orderUsecases.PricingUseCase,
+	statusStream *streams.StatusStream) *OrderController {
+	return &OrderController{
+		orderUseCase:   orderUseCase,
+		pricingUseCase: pricingUseCase,
+		statusStream:   statusStream,
+	}
+}
+
+// CreateOrder places an order for the authenticated user, priced with tax and shipping
+func (oc *OrderController) CreateOrder(c *gin.Context) {
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req CheckoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, breakdown, err := oc.orderUseCase.CreateOrder(c.Request.Context(), userID, req.destination(), req.orderItems())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateOrderResponse{Order: toDTO(order), Pricing: toPriceBreakdownDTO(breakdown)})
+}
+
+// GetOrder retrieves an order by ID, with its amounts in the ?currency asked for
+// Only the user who placed it and staff allowed to read orders see it
+func (oc *OrderController) GetOrder(c *gin.Context) {
+	currency, ok := displayCurrency(c)
+	if !ok {
+		return
+	}
+
+	order, ok := visibleOrder(c, oc.orderUseCase, "orders", "read")
+	if !ok {
+		return
+	}
+
+	dto := toDTO(order)
+	if currency != "" && currency != order.Currency {
+		rate, err := oc.pricingUseCase.ExchangeRate(c.Request.Context(), order.Currency, currency)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		dto.convert(rate, currency)
+	}
+	c.JSON(http.StatusOK, dto)
+}
+
+// ConfirmOrder confirms a pending order, for the user who placed it or staff allowed to
+// manage orders
+func (oc *OrderController) ConfirmOrder(c *gin.Context) {
+	oc.transition(c, oc.orderUseCase.ConfirmOrder)
+}
+
+// CancelOrder cancels an order, for the user who placed it or staff allowed to manage orders
+func (oc *OrderController) CancelOrder(c *gin.Context) {
+	oc.transition(c, oc.orderUseCase.CancelOrder)
+}
+
+// StreamOrderEvents streams the order's status transitions as Server-Sent Events
+// Clients resume after a disconnect by sending the Last-Event-ID header (browsers'
+// EventSource does this automatically) or the lastEventId query parameter. Only the user who
+// placed the order and staff allowed to read orders follow it
+func (oc *OrderController) StreamOrderEvents(c *gin.Context) {
+	lastEventID, err := parseLastEventID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+		return
+	}
+
+	order, ok := visibleOrder(c, oc.orderUseCase, "orders", "read")
+	if !ok {
+		return
+	}
+
+	// Subscribe before writing headers so no transition is missed in between
+	backlog, live, cancel := oc.statusStream.Subscribe(order.ID, lastEventID)
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for _, event := range backlog {
+		writeStatusEvent(c, event)
+		if isFinal(event.To) {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-live:
+			if !ok {
+				// Dropped for lagging; the client reconnects with its last event ID
+				return false
+			}
+			writeStatusEvent(c, event)
+			return !isFinal(event.To)
+		case <-keepAlive.C:
+			_, _ = io.WriteString(w, ": keep-alive\n\n")
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+// transition runs a status change use case on an order the user may manage and responds
+// with the updated order
+func (oc *OrderController) transition(c *gin.Context, apply func(ctx context.Context, id uint) (*orderEntities.Order, error)) {
+	order, ok := visibleOrder(c, oc.orderUseCase, "orders", "manage")
+	if !ok {
+		return
+	}
+
+	order, err := apply(c.Request.Context(), order.ID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toDTO(order))
+}
+
+// visibleOrder loads the :id order if the authenticated user placed it or the policy allows
+// them action on object, e.g. orders, responding otherwise; others' orders are not found, so
+// their IDs reveal nothing
+func visibleOrder(c *gin.Context, orders orderUsecases.OrderUseCase, object, action string) (*orderEntities.Order, bool) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return nil, false
+	}
+	order, err := orders.GetOrder(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+
+	if userID, ok := middleware.UserID(c); ok && userID == order.UserID {
+		return order, true
+	}
+	switch err := middleware.Authorize(c, object, action); err {
+	case nil:
+		return order, true
+	case authz.ErrForbidden:
+		respondError(c, orderEntities.ErrOrderNotFound)
+	default:
+		responses.InternalError(c, err)
+	}
+	return nil, false
+}
+
+// respondError maps order, pricing, shipment, return, invoice and inventory domain errors to HTTP responses
+func respondError(c *gin.Context, err error) {
+	switch err {
+	case orderEntities.ErrOrderNotFound, orderEntities.ErrStockLevelNotFound, orderEntities.ErrReturnNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case orderEntities.ErrInvalidOrderStatusTransition, orderEntities.ErrCannotCancelDeliveredOrder, orderEntities.ErrInsufficientStock:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case orderEntities.ErrShipmentExceedsOrder, orderEntities.ErrOrderNotReturnable, orderEntities.ErrReturnExceedsOrder,
+		orderEntities.ErrInvalidReturnStatusTransition, orderEntities.ErrOrderNotInvoiceable:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case orderEntities.ErrInvalidUserID, orderEntities.ErrEmptyOrder,
+		orderEntities.ErrInvalidStockLevel, orderEntities.ErrTrackingNumberRequired, orderEntities.ErrEmptyShipment,
+		orderEntities.ErrInvalidShipmentQuantity, orderEntities.ErrShipmentItemNotInOrder, orderEntities.ErrReturnReasonRequired,
+		orderEntities.ErrEmptyReturn, orderEntities.ErrInvalidReturnQuantity, orderEntities.ErrReturnItemNotInOrder,
+		orderEntities.ErrReturnNoteRequired, orderEntities.ErrInvalidOrderSearch, orderEntities.ErrInvalidOrderSearchStatus:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		if errors.Is(err, payments.ErrDeclined) || errors.Is(err, pricing.ErrUnsupportedDestination) ||
+			errors.Is(err, money.ErrUnsupportedCurrency) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		responses.InternalError(c, err)
+	}
+}
+
+// parseLastEventID reads the resume position from the header or query string
+func parseLastEventID(c *gin.Context) (uint64, error) {
+	raw := c.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = c.Query("lastEventId")
+	}
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(raw, 10, 64)
+}
+
+// writeStatusEvent renders one transition as an SSE "status" event
+func writeStatusEvent(c *gin.Context, event streams.StatusEvent) {
+	c.Render(-1, sse.Event{
+		Id:    strconv.FormatUint(event.ID, 10),
+		Event: "status",
+		Data:  event,
+	})
+}
+
+// isFinal reports whether no further transitions can follow
+func isFinal(status string) bool {
+	return status == string(orderEntities.OrderStatusDelivered) || status == string(orderEntities.OrderStatusCancelled)
+}
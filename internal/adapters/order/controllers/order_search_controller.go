@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"net/http"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/params"
+	orderQueries "clean-arch-gin/internal/application/order/queries"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrderSearchHitDTO represents an order matching a search
+type OrderSearchHitDTO struct {
+	Order OrderDTO `json:"order"`
+	Score float64  `json:"score"`
+}
+
+// OrderSearchResponse represents a page of search results, most relevant first
+type OrderSearchResponse struct {
+	Query  string              `json:"query"`
+	Hits   []OrderSearchHitDTO `json:"hits"`
+	Total  int64               `json:"total"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+}
+
+// OrderSearchController handles HTTP requests searching the orders of the authenticated user
+type OrderSearchController struct {
+	searchHandler *orderQueries.SearchOrdersQueryHandler
+}
+
+// NewOrderSearchController creates a new order search controller
+func NewOrderSearchController(searchHandler *orderQueries.SearchOrdersQueryHandler) *OrderSearchController {
+	return &OrderSearchController{
+		searchHandler: searchHandler,
+	}
+}
+
+// SearchOrders retrieves a page of the authenticated user's orders whose public ID has words
+// starting with those of ?q=, in ?status= when given
+func (sc *OrderSearchController) SearchOrders(c *gin.Context) {
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+	page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := c.Query("q")
+	result, err := sc.searchHandler.Handle(c.Request.Context(), orderQueries.SearchOrdersQuery{
+		Query:  query,
+		UserID: userID,
+		Status: orderEntities.OrderStatus(c.Query("status")),
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	hits := make([]OrderSearchHitDTO, len(result.Hits))
+	for i, hit := range result.Hits {
+		hits[i] = OrderSearchHitDTO{Order: toDTO(hit.Order), Score: hit.Score}
+	}
+	c.JSON(http.StatusOK, OrderSearchResponse{
+		Query:  query,
+		Hits:   hits,
+		Total:  result.Total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+}
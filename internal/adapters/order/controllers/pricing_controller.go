@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	"clean-arch-gin/internal/domain/shared/money"
+	"clean-arch-gin/internal/domain/shared/pricing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DestinationRequest is where an order ships to
+type DestinationRequest struct {
+	// Country is the ISO 3166-1 alpha-2 code, e.g. "US"
+	Country string `json:"country" binding:"required,len=2,alpha"`
+	// Region is the ISO 3166-2 subdivision code without the country, e.g. "CA"
+	Region     string `json:"region" binding:"omitempty,max=3,alphanum"`
+	PostalCode string `json:"postal_code" binding:"max=16"`
+}
+
+// CheckoutItemRequest is a product to order at a unit price
+type CheckoutItemRequest struct {
+	ProductID uint    `json:"product_id" binding:"required"`
+	Quantity  int     `json:"quantity" binding:"required,min=1"`
+	Price     float64 `json:"price" binding:"min=0"`
+}
+
+// CheckoutRequest represents the request payload for pricing a cart and for creating an order
+type CheckoutRequest struct {
+	Destination DestinationRequest    `json:"destination" binding:"required"`
+	Items       []CheckoutItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// destination converts the request's destination for pricing
+func (r CheckoutRequest) destination() pricing.Destination {
+	return pricing.Destination{
+		Country:    strings.ToUpper(r.Destination.Country),
+		Region:     strings.ToUpper(r.Destination.Region),
+		PostalCode: r.Destination.PostalCode,
+	}
+}
+
+// orderItems converts the request's items to order items
+func (r CheckoutRequest) orderItems() []*orderEntities.OrderItem {
+	items := make([]*orderEntities.OrderItem, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = &orderEntities.OrderItem{ProductID: item.ProductID, Quantity: item.Quantity, Price: item.Price}
+	}
+	return items
+}
+
+// PriceLineDTO represents an item of a price breakdown in API responses
+type PriceLineDTO struct {
+	ProductID uint    `json:"product_id"`
+	Quantity  int     `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+	Amount    float64 `json:"amount"`
+	Tax       float64 `json:"tax"`
+}
+
+// PriceBreakdownDTO represents the itemised price of an order in API responses
+// ExchangeRate is set when the amounts were converted from the base currency for display
+type PriceBreakdownDTO struct {
+	Lines          []PriceLineDTO `json:"lines"`
+	Subtotal       float64        `json:"subtotal"`
+	TaxLabel       string         `json:"tax_label,omitempty"`
+	Tax            float64        `json:"tax"`
+	ShippingMethod string         `json:"shipping_method"`
+	Shipping       float64        `json:"shipping"`
+	Total          float64        `json:"total"`
+	Currency       string         `json:"currency"`
+	ExchangeRate   float64        `json:"exchange_rate,omitempty"`
+}
+
+// CreateOrderResponse is the response of CreateOrder
+type CreateOrderResponse struct {
+	Order   OrderDTO          `json:"order"`
+	Pricing PriceBreakdownDTO `json:"pricing"`
+}
+
+// toPriceBreakdownDTO converts domain entity to DTO
+func toPriceBreakdownDTO(breakdown *orderEntities.PriceBreakdown) PriceBreakdownDTO {
+	lines := make([]PriceLineDTO, len(breakdown.Lines))
+	for i, line := range breakdown.Lines {
+		lines[i] = PriceLineDTO{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+			Amount:    line.Amount,
+			Tax:       line.Tax,
+		}
+	}
+
+	return PriceBreakdownDTO{
+		Lines:          lines,
+		Subtotal:       breakdown.Subtotal,
+		TaxLabel:       breakdown.TaxLabel,
+		Tax:            breakdown.Tax,
+		ShippingMethod: breakdown.ShippingMethod,
+		Shipping:       breakdown.Shipping,
+		Total:          breakdown.Total,
+		Currency:       string(breakdown.Currency),
+	}
+}
+
+// convert shows the breakdown's amounts in currency, at rate from its own
+func (b *PriceBreakdownDTO) convert(rate float64, currency money.Currency) {
+	for i := range b.Lines {
+		b.Lines[i].UnitPrice = money.New(b.Lines[i].UnitPrice*rate, currency).Amount
+		b.Lines[i].Amount = money.New(b.Lines[i].Amount*rate, currency).Amount
+		b.Lines[i].Tax = money.New(b.Lines[i].Tax*rate, currency).Amount
+	}
+	b.Subtotal = money.New(b.Subtotal*rate, currency).Amount
+	b.Tax = money.New(b.Tax*rate, currency).Amount
+	b.Shipping = money.New(b.Shipping*rate, currency).Amount
+	b.Total = money.New(b.Total*rate, currency).Amount
+	b.Currency = string(currency)
+	b.ExchangeRate = rate
+}
+
+// convert shows the order's amounts in currency, at rate from its own
+func (o *OrderDTO) convert(rate float64, currency money.Currency) {
+	for i := range o.Items {
+		o.Items[i].Price = money.New(o.Items[i].Price*rate, currency).Amount
+	}
+	o.TotalAmount = money.New(o.TotalAmount*rate, currency).Amount
+	o.TaxAmount = money.New(o.TaxAmount*rate, currency).Amount
+	o.ShippingAmount = money.New(o.ShippingAmount*rate, currency).Amount
+	o.Currency = string(currency)
+	o.ExchangeRate = rate
+}
+
+// displayCurrency reads the ?currency amounts are asked for in, "" for their own, responding
+// when it is invalid
+func displayCurrency(c *gin.Context) (money.Currency, bool) {
+	raw := c.Query("currency")
+	if raw == "" {
+		return "", true
+	}
+	currency, err := money.ParseCurrency(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return "", false
+	}
+	return currency, true
+}
+
+// QuoteOrder prices a cart at checkout, before the order is placed, with its amounts in the
+// ?currency asked for
+func (oc *OrderController) QuoteOrder(c *gin.Context) {
+	currency, ok := displayCurrency(c)
+	if !ok {
+		return
+	}
+	var req CheckoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	breakdown, err := oc.pricingUseCase.PriceItems(c.Request.Context(), req.destination(), req.orderItems())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	dto := toPriceBreakdownDTO(breakdown)
+	if currency != "" && currency != breakdown.Currency {
+		rate, err := oc.pricingUseCase.ExchangeRate(c.Request.Context(), breakdown.Currency, currency)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		dto.convert(rate, currency)
+	}
+	c.JSON(http.StatusOK, dto)
+}
@@ -0,0 +1,216 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/params"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderUsecases "clean-arch-gin/internal/domain/order/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReturnItemDTO represents the units of an order item in a return
+type ReturnItemDTO struct {
+	OrderItemID uint    `json:"order_item_id"`
+	ProductID   uint    `json:"product_id"`
+	Quantity    int     `json:"quantity"`
+	Price       float64 `json:"price"`
+}
+
+// ReturnDTO represents a return (RMA) in API responses
+type ReturnDTO struct {
+	ID              uint            `json:"id"`
+	RMANumber       string          `json:"rma_number"`
+	OrderID         uint            `json:"order_id"`
+	Status          string          `json:"status"`
+	Reason          string          `json:"reason"`
+	Items           []ReturnItemDTO `json:"items"`
+	RefundAmount    float64         `json:"refund_amount"`
+	RefundReference string          `json:"refund_reference,omitempty"`
+	Note            string          `json:"note,omitempty"`
+	// Transitions lists the status transitions the return may take next, e.g. approve and reject
+	Transitions []string  `json:"transitions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ReturnListResponse is the response of ListReturns
+type ReturnListResponse struct {
+	Returns []ReturnDTO `json:"returns"`
+}
+
+// RequestReturnItem is the units of an order item to return
+type RequestReturnItem struct {
+	OrderItemID uint `json:"order_item_id" binding:"required"`
+	Quantity    int  `json:"quantity" binding:"required,min=1"`
+}
+
+// RequestReturnRequest represents the request payload for returning items of a delivered order
+type RequestReturnRequest struct {
+	Reason string              `json:"reason" binding:"required,max=1000"`
+	Items  []RequestReturnItem `json:"items" binding:"required,min=1,dive"`
+}
+
+// ReturnDecisionRequest represents the request payload for approving or rejecting a return;
+// rejections require a note
+type ReturnDecisionRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+// toReturnDTO converts domain entity to DTO
+func toReturnDTO(ret *orderEntities.Return) ReturnDTO {
+	items := make([]ReturnItemDTO, len(ret.Items))
+	for i, item := range ret.Items {
+		items[i] = ReturnItemDTO{
+			OrderItemID: item.OrderItemID,
+			ProductID:   item.ProductID,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+		}
+	}
+
+	return ReturnDTO{
+		ID:              ret.ID,
+		RMANumber:       ret.RMANumber(),
+		OrderID:         ret.OrderID,
+		Status:          string(ret.Status),
+		Reason:          ret.Reason,
+		Items:           items,
+		RefundAmount:    ret.RefundAmount,
+		RefundReference: ret.RefundReference,
+		Note:            ret.Note,
+		Transitions:     ret.AvailableTransitions(),
+		CreatedAt:       ret.CreatedAt,
+		UpdatedAt:       ret.UpdatedAt,
+	}
+}
+
+// ReturnController handles HTTP requests for returns of orders
+type ReturnController struct {
+	returnUseCase orderUsecases.ReturnUseCase
+	// orderUseCase loads the orders whose returns customers open and follow
+	orderUseCase orderUsecases.OrderUseCase
+}
+
+// NewReturnController creates a new return controller, checking with orderUseCase that
+// customers only open and read the returns of their own orders
+func NewReturnController(returnUseCase orderUsecases.ReturnUseCase, orderUseCase orderUsecases.OrderUseCase) *ReturnController {
+	return &ReturnController{
+		returnUseCase: returnUseCase,
+		orderUseCase:  orderUseCase,
+	}
+}
+
+// RequestReturn opens a return of some of the :id order's items, for the user who placed it
+// or staff allowed to manage returns
+func (rc *ReturnController) RequestReturn(c *gin.Context) {
+	order, ok := visibleOrder(c, rc.orderUseCase, "returns", "manage")
+	if !ok {
+		return
+	}
+	var req RequestReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	quantities := make(map[uint]int, len(req.Items))
+	for _, item := range req.Items {
+		quantities[item.OrderItemID] += item.Quantity
+	}
+
+	ret, err := rc.returnUseCase.RequestReturn(c.Request.Context(), order.ID, req.Reason, quantities)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toReturnDTO(ret))
+}
+
+// ListReturns lists the :id order's returns, for the user who placed it or staff allowed to
+// manage returns
+func (rc *ReturnController) ListReturns(c *gin.Context) {
+	order, ok := visibleOrder(c, rc.orderUseCase, "returns", "manage")
+	if !ok {
+		return
+	}
+
+	returns, err := rc.returnUseCase.ListReturns(c.Request.Context(), order.ID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	result := make([]ReturnDTO, len(returns))
+	for i, ret := range returns {
+		result[i] = toReturnDTO(ret)
+	}
+	c.JSON(http.StatusOK, ReturnListResponse{Returns: result})
+}
+
+// GetReturn retrieves the :returnId return of the :id order, for the user who placed it or
+// staff allowed to manage returns
+func (rc *ReturnController) GetReturn(c *gin.Context) {
+	if _, ok := visibleOrder(c, rc.orderUseCase, "returns", "manage"); !ok {
+		return
+	}
+	rc.respond(c, rc.returnUseCase.GetReturn)
+}
+
+// ApproveReturn accepts the :returnId return
+func (rc *ReturnController) ApproveReturn(c *gin.Context) {
+	rc.decide(c, rc.returnUseCase.ApproveReturn)
+}
+
+// RejectReturn refuses the :returnId return with a note
+func (rc *ReturnController) RejectReturn(c *gin.Context) {
+	rc.decide(c, rc.returnUseCase.RejectReturn)
+}
+
+// ReceiveReturn records the :returnId return's goods are back in stock
+func (rc *ReturnController) ReceiveReturn(c *gin.Context) {
+	rc.respond(c, rc.returnUseCase.ReceiveReturn)
+}
+
+// RefundReturn refunds the :returnId return through the payment gateway
+func (rc *ReturnController) RefundReturn(c *gin.Context) {
+	rc.respond(c, rc.returnUseCase.RefundReturn)
+}
+
+// decide runs an approval or rejection with the note of the request's optional body
+func (rc *ReturnController) decide(c *gin.Context, apply func(ctx context.Context, orderID, id uint, note string) (*orderEntities.Return, error)) {
+	var req ReturnDecisionRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	rc.respond(c, func(ctx context.Context, orderID, id uint) (*orderEntities.Return, error) {
+		return apply(ctx, orderID, id, req.Note)
+	})
+}
+
+// respond runs a use case on the :returnId return of the :id order and responds with the return
+func (rc *ReturnController) respond(c *gin.Context, apply func(ctx context.Context, orderID, id uint) (*orderEntities.Return, error)) {
+	orderID, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return
+	}
+	id, err := params.ParseID(c.Param("returnId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid return ID"})
+		return
+	}
+
+	ret, err := apply(c.Request.Context(), orderID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toReturnDTO(ret))
+}
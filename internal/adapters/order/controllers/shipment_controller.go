@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/params"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ShipmentItemDTO represents the units of an order item in a shipment
+type ShipmentItemDTO struct {
+	OrderItemID uint `json:"order_item_id" binding:"required"`
+	Quantity    int  `json:"quantity" binding:"required,min=1"`
+}
+
+// ShipmentDTO represents a shipment in API responses
+type ShipmentDTO struct {
+	ID             uint              `json:"id"`
+	OrderID        uint              `json:"order_id"`
+	TrackingNumber string            `json:"tracking_number"`
+	Carrier        string            `json:"carrier,omitempty"`
+	Items          []ShipmentItemDTO `json:"items"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+// CreateShipmentRequest represents the request payload for shipping order items
+type CreateShipmentRequest struct {
+	TrackingNumber string            `json:"tracking_number" binding:"required,max=100"`
+	Carrier        string            `json:"carrier" binding:"max=100"`
+	Items          []ShipmentItemDTO `json:"items" binding:"required,min=1,dive"`
+}
+
+// CreateShipmentResponse is the shipment created together with the order's new status
+type CreateShipmentResponse struct {
+	Shipment ShipmentDTO `json:"shipment"`
+	Order    OrderDTO    `json:"order"`
+}
+
+// ShipmentListResponse is the response of ListShipments
+type ShipmentListResponse struct {
+	Shipments []ShipmentDTO `json:"shipments"`
+}
+
+// toShipmentDTO converts domain entity to DTO
+func toShipmentDTO(shipment *orderEntities.Shipment) ShipmentDTO {
+	items := make([]ShipmentItemDTO, len(shipment.Items))
+	for i, item := range shipment.Items {
+		items[i] = ShipmentItemDTO{
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	return ShipmentDTO{
+		ID:             shipment.ID,
+		OrderID:        shipment.OrderID,
+		TrackingNumber: shipment.TrackingNumber,
+		Carrier:        shipment.Carrier,
+		Items:          items,
+		CreatedAt:      shipment.CreatedAt,
+	}
+}
+
+// CreateShipment ships some or all of the order's items
+func (oc *OrderController) CreateShipment(c *gin.Context) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return
+	}
+	var req CreateShipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	quantities := make(map[uint]int, len(req.Items))
+	for _, item := range req.Items {
+		quantities[item.OrderItemID] += item.Quantity
+	}
+
+	shipment, order, err := oc.orderUseCase.ShipOrder(c.Request.Context(), id, req.TrackingNumber, req.Carrier, quantities)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateShipmentResponse{
+		Shipment: toShipmentDTO(shipment),
+		Order:    toDTO(order),
+	})
+}
+
+// ListShipments lists the order's shipments with their tracking numbers, for the user who
+// placed it or staff allowed to read orders
+func (oc *OrderController) ListShipments(c *gin.Context) {
+	order, ok := visibleOrder(c, oc.orderUseCase, "orders", "read")
+	if !ok {
+		return
+	}
+
+	shipments, err := oc.orderUseCase.ListShipments(c.Request.Context(), order.ID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	result := make([]ShipmentDTO, len(shipments))
+	for i, shipment := range shipments {
+		result[i] = toShipmentDTO(shipment)
+	}
+	c.JSON(http.StatusOK, ShipmentListResponse{Shipments: result})
+}
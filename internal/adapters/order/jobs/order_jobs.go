@@ -0,0 +1,111 @@
+// Package jobs holds the scheduled jobs of the order module
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	orderUsecases "clean-arch-gin/internal/domain/order/usecases"
+	"clean-arch-gin/internal/domain/shared/softdelete"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// PurgeDeletedAfter is how long soft-deleted orders are kept before they are purged
+	PurgeDeletedAfter = 90 * 24 * time.Hour
+	// StaleOrderAfter is how long an order may stay pending before it is cancelled
+	StaleOrderAfter = 24 * time.Hour
+	// ReservationTTL is how long confirmed orders hold their stock unpaid by default
+	ReservationTTL = 30 * time.Minute
+	// batchSize is how many orders are cancelled or assigned a public ID per batch
+	batchSize = 100
+)
+
+// NewPurgeDeletedJob permanently deletes orders, and their items, shipments and returns, soft-deleted more than
+// retention ago, nightly. Their invoices are kept as accounting records
+func NewPurgeDeletedJob(orders softdelete.Purger, retention time.Duration) scheduler.Job {
+	return scheduler.Job{
+		Name:     "purge-deleted",
+		Schedule: "30 3 * * *",
+		Timeout:  10 * time.Minute,
+		Run: func(ctx context.Context) error {
+			purged, err := orders.PurgeOlderThan(ctx, retention)
+			if purged > 0 {
+				log.Printf("orders: purged %d orders deleted more than %s ago", purged, retention)
+			}
+			return err
+		},
+	}
+}
+
+// NewCancelStaleJob cancels orders left pending for longer than staleAfter, every 10 minutes
+// Cancellations go through the use case so OrderStatusChangedEvents are published
+func NewCancelStaleJob(orderUseCase orderUsecases.OrderUseCase, staleAfter time.Duration) scheduler.Job {
+	return scheduler.Job{
+		Name:     "cancel-stale",
+		Schedule: "*/10 * * * *",
+		Timeout:  5 * time.Minute,
+		Run: func(ctx context.Context) error {
+			before := time.Now().Add(-staleAfter)
+			total := 0
+			for ctx.Err() == nil {
+				cancelled, err := orderUseCase.CancelStaleOrders(ctx, before, batchSize)
+				total += cancelled
+				if err != nil {
+					return err
+				}
+				if cancelled == 0 {
+					break
+				}
+			}
+			if total > 0 {
+				log.Printf("orders: cancelled %d orders pending since before %s", total, before.Format(time.RFC3339))
+			}
+			return ctx.Err()
+		},
+	}
+}
+
+// NewCancelUnpaidJob cancels confirmed orders whose stock reservation expired unpaid, every
+// minute, so their stock can be sold again
+// Cancellations go through the use case so OrderStatusChangedEvents are published
+func NewCancelUnpaidJob(orderUseCase orderUsecases.OrderUseCase) scheduler.Job {
+	return scheduler.Job{
+		Name:     "cancel-unpaid",
+		Schedule: "* * * * *",
+		Timeout:  time.Minute,
+		Run: func(ctx context.Context) error {
+			now := time.Now()
+			total := 0
+			for ctx.Err() == nil {
+				cancelled, err := orderUseCase.CancelUnpaidOrders(ctx, now, batchSize)
+				total += cancelled
+				if err != nil {
+					return err
+				}
+				if cancelled == 0 {
+					break
+				}
+			}
+			if total > 0 {
+				log.Printf("orders: cancelled %d orders whose stock reservation expired", total)
+			}
+			return ctx.Err()
+		},
+	}
+}
+
+// BackfillPublicIDs gives a public ID to the orders placed before public IDs existed, so
+// they can be addressed in routes again
+func BackfillPublicIDs(ctx context.Context, db *gorm.DB) error {
+	assigned, err := database.AssignPublicIDs(ctx, db, &models.OrderModel{}, batchSize)
+	if assigned > 0 {
+		log.Printf("orders: assigned a public ID to %d orders", assigned)
+	}
+	return err
+}
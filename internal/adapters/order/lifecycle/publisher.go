@@ -0,0 +1,57 @@
+// Package lifecycle announces order milestones, such as confirmation and shipping, to
+// external systems through events carrying the whole order
+package lifecycle
+
+import (
+	"context"
+	"log"
+
+	orderEvents "clean-arch-gin/internal/domain/order/events"
+	orderUsecases "clean-arch-gin/internal/domain/order/usecases"
+	"clean-arch-gin/internal/domain/shared/events"
+)
+
+// Publisher turns order status changes into OrderLifecycleEvents for the milestones it is
+// enabled for. Webhook endpoints subscribe to the events by name, e.g. a warehouse system
+// to order.confirmed only
+type Publisher struct {
+	orders    orderUsecases.OrderUseCase
+	publisher events.EventPublisher
+	enabled   map[string]bool
+}
+
+// NewPublisher creates a publisher of the lifecycle events named in eventNames
+func NewPublisher(orders orderUsecases.OrderUseCase, publisher events.EventPublisher, eventNames []string) *Publisher {
+	enabled := make(map[string]bool, len(eventNames))
+	for _, name := range eventNames {
+		enabled[name] = true
+	}
+	return &Publisher{orders: orders, publisher: publisher, enabled: enabled}
+}
+
+// Subscribe listens for order status changes and returns the unsubscribe function
+func (p *Publisher) Subscribe(subscriber events.EventSubscriber) func() {
+	return subscriber.Subscribe(orderEvents.OrderStatusChangedEventName, p.HandleEvent)
+}
+
+// HandleEvent publishes the lifecycle event of a status change, with the order loaded in the
+// tenant of ctx. Failures are logged: the transition is already committed
+func (p *Publisher) HandleEvent(ctx context.Context, event events.DomainEvent) {
+	changed, ok := event.(orderEvents.OrderStatusChangedEvent)
+	if !ok {
+		return
+	}
+	name, ok := orderEvents.LifecycleEventNames[changed.To]
+	if !ok || !p.enabled[name] {
+		return
+	}
+
+	order, err := p.orders.GetOrder(ctx, changed.OrderID)
+	if err != nil {
+		log.Printf("failed to load order %d for %s: %v", changed.OrderID, name, err)
+		return
+	}
+	if err := p.publisher.Publish(ctx, orderEvents.NewOrderLifecycleEvent(name, order)); err != nil {
+		log.Printf("failed to publish %s for order %d: %v", name, changed.OrderID, err)
+	}
+}
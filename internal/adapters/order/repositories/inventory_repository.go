@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// inventoryRepository implements InventoryRepository using GORM
+// Stock rows are read with SELECT ... FOR UPDATE in product order, so concurrent
+// reservations of the same products queue up instead of deadlocking, and every change is
+// also guarded in its UPDATE, so databases without row locks cannot oversell either
+type inventoryRepository struct {
+	db *gorm.DB
+}
+
+// NewInventoryRepository creates a new inventory repository
+func NewInventoryRepository(db *gorm.DB) orderRepositories.InventoryRepository {
+	return &inventoryRepository{db: db}
+}
+
+// GetStock retrieves the stock level of a product
+func (r *inventoryRepository) GetStock(ctx context.Context, productID uint) (*orderEntities.StockLevel, error) {
+	var level models.StockLevelModel
+	err := r.db.WithContext(ctx).Where("product_id = ?", productID).First(&level).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, orderEntities.ErrStockLevelNotFound
+		}
+		return nil, err
+	}
+	return level.ToDomainEntity(), nil
+}
+
+// SetOnHand creates or updates the stock level of a product
+func (r *inventoryRepository) SetOnHand(ctx context.Context, productID uint, onHand int) (*orderEntities.StockLevel, error) {
+	if onHand < 0 {
+		return nil, orderEntities.ErrInvalidStockLevel
+	}
+
+	var level models.StockLevelModel
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("product_id = ?", productID).First(&level).Error
+		if err == gorm.ErrRecordNotFound {
+			level = models.StockLevelModel{ProductID: productID, OnHand: onHand}
+			return tx.Create(&level).Error
+		}
+		if err != nil {
+			return err
+		}
+		if onHand < level.Reserved {
+			return orderEntities.ErrInvalidStockLevel
+		}
+		level.OnHand = onHand
+		return tx.Model(&level).Update("on_hand", onHand).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return level.ToDomainEntity(), nil
+}
+
+// Reserve holds the tracked products' quantities for the order in one transaction
+func (r *inventoryRepository) Reserve(ctx context.Context, orderID uint, quantities map[uint]int, expiresAt time.Time) error {
+	productIDs := make([]uint, 0, len(quantities))
+	for productID := range quantities {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Slice(productIDs, func(i, j int) bool { return productIDs[i] < productIDs[j] })
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var levels []models.StockLevelModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id IN ?", productIDs).Order("product_id").
+			Find(&levels).Error
+		if err != nil {
+			return err
+		}
+
+		for _, level := range levels {
+			quantity := quantities[level.ProductID]
+			if level.OnHand-level.Reserved < quantity {
+				return orderEntities.ErrInsufficientStock
+			}
+			result := tx.Model(&models.StockLevelModel{}).
+				Where("id = ? AND on_hand - reserved >= ?", level.ID, quantity).
+				UpdateColumn("reserved", gorm.Expr("reserved + ?", quantity))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return orderEntities.ErrInsufficientStock
+			}
+			reservation := models.StockReservationModel{
+				OrderID:   orderID,
+				ProductID: level.ProductID,
+				Quantity:  quantity,
+				Status:    string(orderEntities.ReservationActive),
+				ExpiresAt: expiresAt,
+			}
+			if err := tx.Create(&reservation).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Release gives the order's active reservations back to the stock
+func (r *inventoryRepository) Release(ctx context.Context, orderID uint) error {
+	return r.settle(ctx, orderID, orderEntities.ReservationReleased)
+}
+
+// Commit takes the order's active reservations out of the stock on hand
+func (r *inventoryRepository) Commit(ctx context.Context, orderID uint) error {
+	return r.settle(ctx, orderID, orderEntities.ReservationCommitted)
+}
+
+// settle moves the order's active reservations to status, giving their units back to the
+// stock when released and taking them out of the stock on hand when committed
+// A reservation is only settled once, even by concurrent callers
+func (r *inventoryRepository) settle(ctx context.Context, orderID uint, status orderEntities.ReservationStatus) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var reservations []models.StockReservationModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("order_id = ? AND status = ?", orderID, string(orderEntities.ReservationActive)).
+			Order("product_id").Find(&reservations).Error
+		if err != nil {
+			return err
+		}
+
+		for _, reservation := range reservations {
+			result := tx.Model(&models.StockReservationModel{}).
+				Where("id = ? AND status = ?", reservation.ID, string(orderEntities.ReservationActive)).
+				Update("status", string(status))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+
+			updates := map[string]interface{}{"reserved": gorm.Expr("reserved - ?", reservation.Quantity)}
+			if status == orderEntities.ReservationCommitted {
+				updates["on_hand"] = gorm.Expr("on_hand - ?", reservation.Quantity)
+			}
+			err := tx.Model(&models.StockLevelModel{}).Where("product_id = ?", reservation.ProductID).
+				UpdateColumns(updates).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Restock adds the units to the stock on hand of the tracked products
+func (r *inventoryRepository) Restock(ctx context.Context, quantities map[uint]int) error {
+	productIDs := make([]uint, 0, len(quantities))
+	for productID := range quantities {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Slice(productIDs, func(i, j int) bool { return productIDs[i] < productIDs[j] })
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, productID := range productIDs {
+			err := tx.Model(&models.StockLevelModel{}).Where("product_id = ?", productID).
+				UpdateColumn("on_hand", gorm.Expr("on_hand + ?", quantities[productID])).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListExpiredOrderIDs retrieves the orders holding active reservations past their expiry
+func (r *inventoryRepository) ListExpiredOrderIDs(ctx context.Context, now time.Time, limit int) ([]uint, error) {
+	var orderIDs []uint
+	err := r.db.WithContext(ctx).Model(&models.StockReservationModel{}).
+		Where("status = ? AND expires_at <= ?", string(orderEntities.ReservationActive), now).
+		Distinct("order_id").Order("order_id").Limit(limit).
+		Pluck("order_id", &orderIDs).Error
+	return orderIDs, err
+}
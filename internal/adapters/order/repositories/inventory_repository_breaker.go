@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// inventoryRepositoryBreaker guards an InventoryRepository with a circuit breaker
+type inventoryRepositoryBreaker struct {
+	repo orderRepositories.InventoryRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewInventoryRepositoryWithBreaker wraps repo so calls go through cb
+func NewInventoryRepositoryWithBreaker(repo orderRepositories.InventoryRepository, cb *breaker.CircuitBreaker) orderRepositories.InventoryRepository {
+	return &inventoryRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// GetStock retrieves a stock level through the breaker
+func (r *inventoryRepositoryBreaker) GetStock(ctx context.Context, productID uint) (level *orderEntities.StockLevel, err error) {
+	err = r.cb.Execute(func() error {
+		level, err = r.repo.GetStock(ctx, productID)
+		return err
+	})
+	return level, err
+}
+
+// SetOnHand updates a stock level through the breaker
+func (r *inventoryRepositoryBreaker) SetOnHand(ctx context.Context, productID uint, onHand int) (level *orderEntities.StockLevel, err error) {
+	err = r.cb.Execute(func() error {
+		level, err = r.repo.SetOnHand(ctx, productID, onHand)
+		return err
+	})
+	return level, err
+}
+
+// Reserve holds stock through the breaker
+func (r *inventoryRepositoryBreaker) Reserve(ctx context.Context, orderID uint, quantities map[uint]int, expiresAt time.Time) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Reserve(ctx, orderID, quantities, expiresAt)
+	})
+}
+
+// Release gives stock back through the breaker
+func (r *inventoryRepositoryBreaker) Release(ctx context.Context, orderID uint) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Release(ctx, orderID)
+	})
+}
+
+// Commit takes stock out through the breaker
+func (r *inventoryRepositoryBreaker) Commit(ctx context.Context, orderID uint) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Commit(ctx, orderID)
+	})
+}
+
+// Restock puts units back on hand through the breaker
+func (r *inventoryRepositoryBreaker) Restock(ctx context.Context, quantities map[uint]int) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Restock(ctx, quantities)
+	})
+}
+
+// ListExpiredOrderIDs lists orders with expired reservations through the breaker
+func (r *inventoryRepositoryBreaker) ListExpiredOrderIDs(ctx context.Context, now time.Time, limit int) (orderIDs []uint, err error) {
+	err = r.cb.Execute(func() error {
+		orderIDs, err = r.repo.ListExpiredOrderIDs(ctx, now, limit)
+		return err
+	})
+	return orderIDs, err
+}
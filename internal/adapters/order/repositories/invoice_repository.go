@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// invoiceRepository implements InvoiceRepository using GORM
+// Invoice numbers come from a counter row per tenant, locked for the rest of the transaction
+// that takes a number: it serialises invoicing within a tenant like an advisory lock would,
+// on MySQL and SQLite alike, and a rolled back transaction gives its number back
+type invoiceRepository struct {
+	db *gorm.DB
+}
+
+// NewInvoiceRepository creates a new invoice repository
+func NewInvoiceRepository(db *gorm.DB) orderRepositories.InvoiceRepository {
+	return &invoiceRepository{db: db}
+}
+
+// Create numbers and stores the invoice, or returns the order's invoice when it has one
+func (r *invoiceRepository) Create(ctx context.Context, invoice *orderEntities.Invoice) (*orderEntities.Invoice, error) {
+	var existing *orderEntities.Invoice
+	model := models.NewInvoiceModelFromEntity(invoice)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var order models.OrderModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "tenant_id").First(&order, invoice.OrderID).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return orderEntities.ErrOrderNotFound
+			}
+			return err
+		}
+
+		// The order's lock orders concurrent invoicing of the same order
+		found, err := findInvoice(tx, invoice.OrderID)
+		if err == nil {
+			existing = found
+			return nil
+		}
+		if err != orderEntities.ErrInvoiceNotFound {
+			return err
+		}
+
+		sequence := models.InvoiceSequenceModel{TenantID: order.TenantID, Next: 1}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&sequence).Error; err != nil {
+			return err
+		}
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("tenant_id = ?", order.TenantID).First(&sequence).Error
+		if err != nil {
+			return err
+		}
+
+		model.TenantID = order.TenantID
+		model.Sequence = sequence.Next
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		return tx.Model(&sequence).Update("next", sequence.Next+1).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	return model.ToDomainEntity(), nil
+}
+
+// GetByOrderID retrieves the order's invoice with its lines
+func (r *invoiceRepository) GetByOrderID(ctx context.Context, orderID uint) (*orderEntities.Invoice, error) {
+	return findInvoice(r.db.WithContext(ctx), orderID)
+}
+
+// findInvoice loads the order's invoice with its lines in order
+func findInvoice(db *gorm.DB, orderID uint) (*orderEntities.Invoice, error) {
+	var model models.InvoiceModel
+	err := db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Where("order_id = ?", orderID).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, orderEntities.ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomainEntity(), nil
+}
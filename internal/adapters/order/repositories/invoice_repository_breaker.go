@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// invoiceRepositoryBreaker guards an InvoiceRepository with a circuit breaker
+type invoiceRepositoryBreaker struct {
+	repo orderRepositories.InvoiceRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewInvoiceRepositoryWithBreaker wraps repo so calls go through cb
+func NewInvoiceRepositoryWithBreaker(repo orderRepositories.InvoiceRepository, cb *breaker.CircuitBreaker) orderRepositories.InvoiceRepository {
+	return &invoiceRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Create stores an invoice through the breaker
+func (r *invoiceRepositoryBreaker) Create(ctx context.Context, invoice *orderEntities.Invoice) (created *orderEntities.Invoice, err error) {
+	err = r.cb.Execute(func() error {
+		created, err = r.repo.Create(ctx, invoice)
+		return err
+	})
+	return created, err
+}
+
+// GetByOrderID retrieves an order's invoice through the breaker
+func (r *invoiceRepositoryBreaker) GetByOrderID(ctx context.Context, orderID uint) (invoice *orderEntities.Invoice, err error) {
+	err = r.cb.Execute(func() error {
+		invoice, err = r.repo.GetByOrderID(ctx, orderID)
+		return err
+	})
+	return invoice, err
+}
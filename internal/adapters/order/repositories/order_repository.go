@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	"clean-arch-gin/internal/domain/shared/pagination"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// purgeBatchSize is how many orders are purged per transaction
+const purgeBatchSize = 100
+
+// orderRepository implements OrderRepository interface using GORM
+type orderRepository struct {
+	db *gorm.DB
+}
+
+// NewOrderRepository creates a new order repository
+func NewOrderRepository(db *gorm.DB) orderRepositories.OrderRepository {
+	return &orderRepository{db: db}
+}
+
+// Create creates an order and its items in one transaction
+func (r *orderRepository) Create(ctx context.Context, order *orderEntities.Order) error {
+	orderModel := models.NewOrderModelFromEntity(order)
+	if err := r.db.WithContext(ctx).Create(orderModel).Error; err != nil {
+		return err
+	}
+
+	order.ID = orderModel.ID
+	for i, item := range orderModel.Items {
+		order.Items[i].ID = item.ID
+		order.Items[i].OrderID = orderModel.ID
+	}
+	return nil
+}
+
+// GetByID retrieves an order with its items
+func (r *orderRepository) GetByID(ctx context.Context, id uint) (*orderEntities.Order, error) {
+	var orderModel models.OrderModel
+	err := r.db.WithContext(ctx).Preload("Items").First(&orderModel, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, orderEntities.ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return orderModel.ToDomainEntity(), nil
+}
+
+// GetByPublicID retrieves an order with its items by its public ID
+func (r *orderRepository) GetByPublicID(ctx context.Context, publicID string) (*orderEntities.Order, error) {
+	var orderModel models.OrderModel
+	err := r.db.WithContext(ctx).Preload("Items").Where("public_id = ?", publicID).First(&orderModel).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, orderEntities.ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return orderModel.ToDomainEntity(), nil
+}
+
+// GetByUserID retrieves a user's orders, newest first, with pagination
+func (r *orderRepository) GetByUserID(ctx context.Context, userID uint, limit, offset int) ([]*orderEntities.Order, error) {
+	var orderModels []models.OrderModel
+	err := r.db.WithContext(ctx).Preload("Items").
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&orderModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return toOrderEntities(orderModels), nil
+}
+
+// ListAfter retrieves the orders after cursor in created_at, id order
+func (r *orderRepository) ListAfter(ctx context.Context, cursor pagination.Cursor, limit int) ([]*orderEntities.Order, error) {
+	var orderModels []models.OrderModel
+	err := database.PageAfter(r.db.WithContext(ctx).Preload("Items"), cursor, limit).Find(&orderModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return toOrderEntities(orderModels), nil
+}
+
+// GetByUserIDs retrieves the orders of all given users in a single query
+func (r *orderRepository) GetByUserIDs(ctx context.Context, userIDs []uint) ([]*orderEntities.Order, error) {
+	if len(userIDs) == 0 {
+		return []*orderEntities.Order{}, nil
+	}
+
+	var orderModels []models.OrderModel
+	err := r.db.WithContext(ctx).Preload("Items").
+		Where("user_id IN ?", userIDs).
+		Order("created_at DESC, id DESC").
+		Find(&orderModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return toOrderEntities(orderModels), nil
+}
+
+// GetPendingCreatedBefore retrieves the oldest pending orders created before the given time
+func (r *orderRepository) GetPendingCreatedBefore(ctx context.Context, before time.Time, limit int) ([]*orderEntities.Order, error) {
+	var orderModels []models.OrderModel
+	err := r.db.WithContext(ctx).Preload("Items").
+		Where("status = ? AND created_at < ?", string(orderEntities.OrderStatusPending), before).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&orderModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return toOrderEntities(orderModels), nil
+}
+
+// Update saves the order and replaces its items
+func (r *orderRepository) Update(ctx context.Context, order *orderEntities.Order) error {
+	orderModel := models.NewOrderModelFromEntity(order)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("order_id = ?", order.ID).Delete(&models.OrderItemModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(orderModel).Error
+	})
+}
+
+// Delete soft deletes an order by ID
+func (r *orderRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.OrderModel{}, id).Error
+}
+
+// PurgeOlderThan permanently deletes the orders soft deleted more than age ago with their
+// dependent rows, a batch at a time
+func (r *orderRepository) PurgeOlderThan(ctx context.Context, age time.Duration) (int64, error) {
+	return database.PurgeSoftDeleted(ctx, r.db, &models.OrderModel{}, time.Now().Add(-age), purgeBatchSize, purgeOrderDependents)
+}
+
+// toOrderEntities converts GORM models to domain entities
+func toOrderEntities(orderModels []models.OrderModel) []*orderEntities.Order {
+	orders := make([]*orderEntities.Order, len(orderModels))
+	for i := range orderModels {
+		orders[i] = orderModels[i].ToDomainEntity()
+	}
+	return orders
+}
+
+// purgeOrderDependents hard deletes the items, shipments and returns of the orders with ids
+// Their invoices are kept as accounting records
+func purgeOrderDependents(tx *gorm.DB, ids []uint) error {
+	shipmentIDs := tx.Model(&models.ShipmentModel{}).Select("id").Where("order_id IN ?", ids)
+	if err := tx.Where("shipment_id IN (?)", shipmentIDs).Delete(&models.ShipmentItemModel{}).Error; err != nil {
+		return err
+	}
+	returnIDs := tx.Model(&models.ReturnModel{}).Select("id").Where("order_id IN ?", ids)
+	if err := tx.Where("return_id IN (?)", returnIDs).Delete(&models.ReturnItemModel{}).Error; err != nil {
+		return err
+	}
+	for _, dependent := range []interface{}{&models.ShipmentModel{}, &models.ReturnModel{}, &models.OrderItemModel{}} {
+		if err := tx.Where("order_id IN ?", ids).Delete(dependent).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
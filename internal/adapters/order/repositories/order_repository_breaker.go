@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	"clean-arch-gin/internal/domain/shared/pagination"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// orderRepositoryBreaker guards an OrderRepository with a circuit breaker
+// While the breaker is open calls fail fast with a *breaker.UnavailableError
+type orderRepositoryBreaker struct {
+	repo orderRepositories.OrderRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewOrderRepositoryWithBreaker wraps repo so calls go through cb
+func NewOrderRepositoryWithBreaker(repo orderRepositories.OrderRepository, cb *breaker.CircuitBreaker) orderRepositories.OrderRepository {
+	return &orderRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Create creates a new order through the breaker
+func (r *orderRepositoryBreaker) Create(ctx context.Context, order *orderEntities.Order) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Create(ctx, order)
+	})
+}
+
+// GetByID retrieves an order by ID through the breaker
+func (r *orderRepositoryBreaker) GetByID(ctx context.Context, id uint) (order *orderEntities.Order, err error) {
+	err = r.cb.Execute(func() error {
+		order, err = r.repo.GetByID(ctx, id)
+		return err
+	})
+	return order, err
+}
+
+// GetByPublicID retrieves an order by public ID through the breaker
+func (r *orderRepositoryBreaker) GetByPublicID(ctx context.Context, publicID string) (order *orderEntities.Order, err error) {
+	err = r.cb.Execute(func() error {
+		order, err = r.repo.GetByPublicID(ctx, publicID)
+		return err
+	})
+	return order, err
+}
+
+// GetByUserID retrieves a user's orders through the breaker
+func (r *orderRepositoryBreaker) GetByUserID(ctx context.Context, userID uint, limit, offset int) (orders []*orderEntities.Order, err error) {
+	err = r.cb.Execute(func() error {
+		orders, err = r.repo.GetByUserID(ctx, userID, limit, offset)
+		return err
+	})
+	return orders, err
+}
+
+// ListAfter retrieves a page of orders after a cursor through the breaker
+func (r *orderRepositoryBreaker) ListAfter(ctx context.Context, cursor pagination.Cursor, limit int) (orders []*orderEntities.Order, err error) {
+	err = r.cb.Execute(func() error {
+		orders, err = r.repo.ListAfter(ctx, cursor, limit)
+		return err
+	})
+	return orders, err
+}
+
+// GetByUserIDs retrieves the orders of several users through the breaker
+func (r *orderRepositoryBreaker) GetByUserIDs(ctx context.Context, userIDs []uint) (orders []*orderEntities.Order, err error) {
+	err = r.cb.Execute(func() error {
+		orders, err = r.repo.GetByUserIDs(ctx, userIDs)
+		return err
+	})
+	return orders, err
+}
+
+// GetPendingCreatedBefore retrieves stale pending orders through the breaker
+func (r *orderRepositoryBreaker) GetPendingCreatedBefore(ctx context.Context, before time.Time, limit int) (orders []*orderEntities.Order, err error) {
+	err = r.cb.Execute(func() error {
+		orders, err = r.repo.GetPendingCreatedBefore(ctx, before, limit)
+		return err
+	})
+	return orders, err
+}
+
+// Update updates an order through the breaker
+func (r *orderRepositoryBreaker) Update(ctx context.Context, order *orderEntities.Order) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Update(ctx, order)
+	})
+}
+
+// Delete deletes an order through the breaker
+func (r *orderRepositoryBreaker) Delete(ctx context.Context, id uint) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Delete(ctx, id)
+	})
+}
+
+// PurgeOlderThan purges long soft-deleted orders through the breaker
+func (r *orderRepositoryBreaker) PurgeOlderThan(ctx context.Context, age time.Duration) (purged int64, err error) {
+	err = r.cb.Execute(func() error {
+		purged, err = r.repo.PurgeOlderThan(ctx, age)
+		return err
+	})
+	return purged, err
+}
@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	"clean-arch-gin/internal/domain/shared/pagination"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/database/query"
+
+	"gorm.io/gorm"
+)
+
+// orderRepositoryGen implements OrderRepository using GORM Gen
+// Orders are loaded with their items through the generated Preload of the Items relation
+type orderRepositoryGen struct {
+	db    *gorm.DB
+	query *query.Query
+}
+
+// NewOrderRepositoryGen creates a new order repository using GORM Gen
+func NewOrderRepositoryGen(db *gorm.DB) orderRepositories.OrderRepository {
+	return &orderRepositoryGen{
+		db:    db,
+		query: query.Use(db),
+	}
+}
+
+// Create creates an order and its items in one statement using GORM Gen
+func (r *orderRepositoryGen) Create(ctx context.Context, order *orderEntities.Order) error {
+	orderModel := models.NewOrderModelFromEntity(order)
+	if err := r.query.OrderModel.WithContext(ctx).Create(orderModel); err != nil {
+		return err
+	}
+
+	order.ID = orderModel.ID
+	for i, item := range orderModel.Items {
+		order.Items[i].ID = item.ID
+		order.Items[i].OrderID = orderModel.ID
+	}
+	return nil
+}
+
+// GetByID retrieves an order with its items using GORM Gen
+func (r *orderRepositoryGen) GetByID(ctx context.Context, id uint) (*orderEntities.Order, error) {
+	o := r.query.OrderModel.WithContext(ctx)
+
+	orderModel, err := o.Preload(o.Items()).Where(o.ID().Eq(id)).First()
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, orderEntities.ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return orderModel.ToDomainEntity(), nil
+}
+
+// GetByPublicID retrieves an order with its items by its public ID using GORM Gen
+func (r *orderRepositoryGen) GetByPublicID(ctx context.Context, publicID string) (*orderEntities.Order, error) {
+	o := r.query.OrderModel.WithContext(ctx)
+
+	orderModel, err := o.Preload(o.Items()).Where(o.PublicID().Eq(publicID)).First()
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, orderEntities.ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return orderModel.ToDomainEntity(), nil
+}
+
+// GetByUserID retrieves a user's orders, newest first, with pagination using GORM Gen
+func (r *orderRepositoryGen) GetByUserID(ctx context.Context, userID uint, limit, offset int) ([]*orderEntities.Order, error) {
+	o := r.query.OrderModel.WithContext(ctx)
+
+	orderModels, err := o.Preload(o.Items()).
+		Where(o.UserID().Eq(userID)).
+		Order(o.CreatedAt().Desc(), o.ID().Desc()).
+		Limit(limit).Offset(offset).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	return toOrderEntitiesGen(orderModels), nil
+}
+
+// ListAfter retrieves the orders after cursor in created_at, id order using GORM Gen
+func (r *orderRepositoryGen) ListAfter(ctx context.Context, cursor pagination.Cursor, limit int) ([]*orderEntities.Order, error) {
+	o := r.query.OrderModel.WithContext(ctx)
+	if !cursor.IsZero() {
+		o = o.Where(query.Or(
+			o.CreatedAt().Gt(cursor.CreatedAt),
+			query.And(o.CreatedAt().Eq(cursor.CreatedAt), o.ID().Gt(cursor.ID)),
+		))
+	}
+
+	orderModels, err := o.Preload(o.Items()).
+		Order(o.CreatedAt().Asc(), o.ID().Asc()).
+		Limit(limit).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	return toOrderEntitiesGen(orderModels), nil
+}
+
+// GetByUserIDs retrieves the orders of all given users in a single query using GORM Gen
+func (r *orderRepositoryGen) GetByUserIDs(ctx context.Context, userIDs []uint) ([]*orderEntities.Order, error) {
+	if len(userIDs) == 0 {
+		return []*orderEntities.Order{}, nil
+	}
+
+	o := r.query.OrderModel.WithContext(ctx)
+	values := make([]interface{}, len(userIDs))
+	for i, id := range userIDs {
+		values[i] = id
+	}
+
+	orderModels, err := o.Preload(o.Items()).
+		Where(o.UserID().In(values...)).
+		Order(o.CreatedAt().Desc(), o.ID().Desc()).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	return toOrderEntitiesGen(orderModels), nil
+}
+
+// GetPendingCreatedBefore retrieves the oldest pending orders created before the given
+// time using GORM Gen
+func (r *orderRepositoryGen) GetPendingCreatedBefore(ctx context.Context, before time.Time, limit int) ([]*orderEntities.Order, error) {
+	o := r.query.OrderModel.WithContext(ctx)
+
+	orderModels, err := o.Preload(o.Items()).
+		Where(o.Status().Eq(string(orderEntities.OrderStatusPending)), o.CreatedAt().Lt(before)).
+		Order(o.CreatedAt().Asc(), o.ID().Asc()).
+		Limit(limit).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	return toOrderEntitiesGen(orderModels), nil
+}
+
+// Update saves the order and replaces its items in one transaction using GORM Gen
+func (r *orderRepositoryGen) Update(ctx context.Context, order *orderEntities.Order) error {
+	orderModel := models.NewOrderModelFromEntity(order)
+	return r.query.WithContext(ctx).Transaction(func(tx *query.Query) error {
+		i := tx.OrderItemModel.WithContext(ctx)
+		if _, err := i.Where(i.OrderID().Eq(order.ID)).Delete(); err != nil {
+			return err
+		}
+		return tx.OrderModel.WithContext(ctx).Save(orderModel)
+	})
+}
+
+// Delete soft deletes an order by ID using GORM Gen
+func (r *orderRepositoryGen) Delete(ctx context.Context, id uint) error {
+	o := r.query.OrderModel.WithContext(ctx)
+	_, err := o.Where(o.ID().Eq(id)).Delete()
+	return err
+}
+
+// PurgeOlderThan permanently deletes the orders soft deleted more than age ago with their
+// dependent rows, a batch at a time
+func (r *orderRepositoryGen) PurgeOlderThan(ctx context.Context, age time.Duration) (int64, error) {
+	return database.PurgeSoftDeleted(ctx, r.db, &models.OrderModel{}, time.Now().Add(-age), purgeBatchSize, purgeOrderDependents)
+}
+
+// toOrderEntitiesGen converts generated query results to domain entities
+func toOrderEntitiesGen(orderModels []*models.OrderModel) []*orderEntities.Order {
+	orders := make([]*orderEntities.Order, len(orderModels))
+	for i, model := range orderModels {
+		orders[i] = model.ToDomainEntity()
+	}
+	return orders
+}
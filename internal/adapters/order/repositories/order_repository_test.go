@@ -0,0 +1,165 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/adapters/order/repositories"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	"clean-arch-gin/internal/testutil/factory"
+	"clean-arch-gin/internal/testutil/repotest"
+
+	"gorm.io/gorm"
+)
+
+// orderRepositoryImplementations are the GORM and GORM Gen order repositories, which must agree
+var orderRepositoryImplementations = []struct {
+	name string
+	new  func(db *gorm.DB) orderRepositories.OrderRepository
+}{
+	{"gorm", repositories.NewOrderRepository},
+	{"gorm_gen", repositories.NewOrderRepositoryGen},
+}
+
+// runOrderRepositoryTest runs fn against every implementation, each on its own database
+func runOrderRepositoryTest(t *testing.T, fn func(t *testing.T, db *gorm.DB, repo orderRepositories.OrderRepository)) {
+	for _, impl := range orderRepositoryImplementations {
+		t.Run(impl.name, func(t *testing.T) {
+			db := repotest.OpenSQLite(t)
+			fn(t, db, impl.new(db))
+		})
+	}
+}
+
+// mustCreateOrder persists a generated order and fails the test on error
+func mustCreateOrder(t *testing.T, repo orderRepositories.OrderRepository, opts ...factory.OrderOption) *orderEntities.Order {
+	t.Helper()
+	order := factory.Order(opts...)
+	if err := repo.Create(context.Background(), order); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	return order
+}
+
+func TestOrderRepositoryCreateStoresItems(t *testing.T) {
+	runOrderRepositoryTest(t, func(t *testing.T, db *gorm.DB, repo orderRepositories.OrderRepository) {
+		owner := factory.CreateUser(t, db)
+		order := mustCreateOrder(t, repo,
+			factory.WithOrderUserID(owner.ID),
+			factory.WithItems(
+				factory.OrderItem(factory.WithQuantity(3), factory.WithPrice(2.5)),
+				factory.OrderItem(factory.WithProductID(42), factory.WithPrice(4)),
+			))
+		if order.ID == 0 || order.Items[0].ID == 0 || order.Items[1].OrderID != order.ID {
+			t.Fatalf("Create left order %d with items %+v, want IDs assigned", order.ID, order.Items)
+		}
+
+		found, err := repo.GetByID(context.Background(), order.ID)
+		if err != nil {
+			t.Fatalf("GetByID returned error: %v", err)
+		}
+		if found.UserID != owner.ID || len(found.Items) != 2 {
+			t.Fatalf("GetByID = user %d with %d items, want user %d with 2 items", found.UserID, len(found.Items), owner.ID)
+		}
+		if found.TotalAmount != 11.5 {
+			t.Errorf("TotalAmount = %v, want 11.5", found.TotalAmount)
+		}
+		if found.Status != orderEntities.OrderStatusPending {
+			t.Errorf("Status = %q, want %q", found.Status, orderEntities.OrderStatusPending)
+		}
+
+		byPublicID, err := repo.GetByPublicID(context.Background(), order.PublicID)
+		if err != nil || byPublicID.ID != order.ID {
+			t.Errorf("GetByPublicID = %v, %v; want order %d", byPublicID, err, order.ID)
+		}
+	})
+}
+
+func TestOrderRepositoryMissingOrder(t *testing.T) {
+	runOrderRepositoryTest(t, func(t *testing.T, db *gorm.DB, repo orderRepositories.OrderRepository) {
+		if _, err := repo.GetByID(context.Background(), 4242); err != orderEntities.ErrOrderNotFound {
+			t.Errorf("GetByID error = %v, want %v", err, orderEntities.ErrOrderNotFound)
+		}
+		if _, err := repo.GetByPublicID(context.Background(), "01ARZ3NDEKTSV4RRFFQ69G5FAV"); err != orderEntities.ErrOrderNotFound {
+			t.Errorf("GetByPublicID error = %v, want %v", err, orderEntities.ErrOrderNotFound)
+		}
+	})
+}
+
+func TestOrderRepositoryGetByUserID(t *testing.T) {
+	runOrderRepositoryTest(t, func(t *testing.T, db *gorm.DB, repo orderRepositories.OrderRepository) {
+		alice, bob := factory.CreateUser(t, db), factory.CreateUser(t, db)
+		first := mustCreateOrder(t, repo, factory.WithOrderUserID(alice.ID))
+		mustCreateOrder(t, repo, factory.WithOrderUserID(bob.ID))
+		second := mustCreateOrder(t, repo, factory.WithOrderUserID(alice.ID))
+
+		orders, err := repo.GetByUserID(context.Background(), alice.ID, 10, 0)
+		if err != nil {
+			t.Fatalf("GetByUserID returned error: %v", err)
+		}
+		if len(orders) != 2 || orders[0].ID != second.ID || orders[1].ID != first.ID {
+			t.Fatalf("GetByUserID returned %d orders, want orders %d then %d", len(orders), second.ID, first.ID)
+		}
+
+		page, err := repo.GetByUserID(context.Background(), alice.ID, 1, 1)
+		if err != nil {
+			t.Fatalf("GetByUserID returned error: %v", err)
+		}
+		if len(page) != 1 || page[0].ID != first.ID {
+			t.Errorf("GetByUserID(1, 1) returned %d orders, want order %d", len(page), first.ID)
+		}
+	})
+}
+
+func TestOrderRepositoryGetPendingCreatedBefore(t *testing.T) {
+	runOrderRepositoryTest(t, func(t *testing.T, db *gorm.DB, repo orderRepositories.OrderRepository) {
+		owner := factory.CreateUser(t, db)
+		pending := mustCreateOrder(t, repo, factory.WithOrderUserID(owner.ID))
+		mustCreateOrder(t, repo, factory.WithOrderUserID(owner.ID), factory.WithStatus(orderEntities.OrderStatusConfirmed))
+		mustCreateOrder(t, repo, factory.WithOrderUserID(owner.ID), factory.WithStatus(orderEntities.OrderStatusCancelled))
+
+		orders, err := repo.GetPendingCreatedBefore(context.Background(), time.Now().Add(time.Minute), 10)
+		if err != nil {
+			t.Fatalf("GetPendingCreatedBefore returned error: %v", err)
+		}
+		if len(orders) != 1 || orders[0].ID != pending.ID {
+			t.Errorf("GetPendingCreatedBefore returned %d orders, want only order %d", len(orders), pending.ID)
+		}
+
+		orders, err = repo.GetPendingCreatedBefore(context.Background(), time.Now().Add(-time.Hour), 10)
+		if err != nil {
+			t.Fatalf("GetPendingCreatedBefore returned error: %v", err)
+		}
+		if len(orders) != 0 {
+			t.Errorf("GetPendingCreatedBefore an hour ago returned %d orders, want none", len(orders))
+		}
+	})
+}
+
+func TestOrderRepositoryUpdateReplacesItems(t *testing.T) {
+	runOrderRepositoryTest(t, func(t *testing.T, db *gorm.DB, repo orderRepositories.OrderRepository) {
+		owner := factory.CreateUser(t, db)
+		order := mustCreateOrder(t, repo, factory.WithOrderUserID(owner.ID))
+
+		if err := order.Confirm(); err != nil {
+			t.Fatalf("Confirm returned error: %v", err)
+		}
+		factory.WithItems(factory.OrderItem(factory.WithProductID(7), factory.WithQuantity(2), factory.WithPrice(5)))(order)
+		if err := repo.Update(context.Background(), order); err != nil {
+			t.Fatalf("Update returned error: %v", err)
+		}
+
+		found, err := repo.GetByID(context.Background(), order.ID)
+		if err != nil {
+			t.Fatalf("GetByID returned error: %v", err)
+		}
+		if found.Status != orderEntities.OrderStatusConfirmed {
+			t.Errorf("Status = %q, want %q", found.Status, orderEntities.OrderStatusConfirmed)
+		}
+		if len(found.Items) != 1 || found.Items[0].ProductID != 7 || found.TotalAmount != 10 {
+			t.Errorf("after Update got %d items totalling %v, want product 7 totalling 10", len(found.Items), found.TotalAmount)
+		}
+	})
+}
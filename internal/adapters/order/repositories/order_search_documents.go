@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/domain/shared/search"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// OrderSearchIndex names the search index of orders
+const OrderSearchIndex = "orders"
+
+// OrderSearchMapping types the fields of order documents; public IDs are matched by their
+// words, and the owner and status filter
+var OrderSearchMapping = search.Mapping{
+	"public_id":   search.FieldText,
+	"user_id":     search.FieldLong,
+	"status":      search.FieldKeyword,
+	"currency":    search.FieldKeyword,
+	"product_ids": search.FieldLong,
+	"created_at":  search.FieldDate,
+}
+
+// documentBatchSize is how many rows a reindex reads at a time
+const documentBatchSize = 500
+
+// orderSearchDocuments implements search.DocumentSource on the orders table
+type orderSearchDocuments struct {
+	db *gorm.DB
+}
+
+// NewOrderSearchIndex describes the search index of orders, read from db
+func NewOrderSearchIndex(db *gorm.DB) search.Index {
+	return search.Index{
+		Name:    OrderSearchIndex,
+		Mapping: OrderSearchMapping,
+		Source:  &orderSearchDocuments{db: db},
+	}
+}
+
+// Document returns the document of the order id, nil once deleted
+func (d *orderSearchDocuments) Document(ctx context.Context, id uint) (*search.Document, error) {
+	var orderModel models.OrderModel
+	if err := database.Conn(ctx, d.db).Preload("Items").First(&orderModel, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	doc := orderDocument(&orderModel)
+	return &doc, nil
+}
+
+// Documents yields the documents of every order, in ID order
+func (d *orderSearchDocuments) Documents(ctx context.Context, yield func(search.Document) error) error {
+	var batch []models.OrderModel
+	return database.Conn(ctx, d.db).Preload("Items").Order("id").FindInBatches(&batch, documentBatchSize, func(tx *gorm.DB, _ int) error {
+		for i := range batch {
+			if err := yield(orderDocument(&batch[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+// orderDocument is the document of an order
+func orderDocument(orderModel *models.OrderModel) search.Document {
+	productIDs := make([]uint, len(orderModel.Items))
+	for i, item := range orderModel.Items {
+		productIDs[i] = item.ProductID
+	}
+	publicID := ""
+	if orderModel.PublicID != nil {
+		publicID = *orderModel.PublicID
+	}
+	return search.Document{
+		ID:       strconv.FormatUint(uint64(orderModel.ID), 10),
+		TenantID: orderModel.TenantID,
+		Fields: map[string]interface{}{
+			"public_id":   publicID,
+			"user_id":     orderModel.UserID,
+			"status":      orderModel.Status,
+			"currency":    orderModel.Currency,
+			"product_ids": productIDs,
+			"created_at":  orderModel.CreatedAt,
+		},
+	}
+}
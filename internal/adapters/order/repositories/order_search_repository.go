@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// orderSearchRepository implements OrderSearchRepository on the orders table
+type orderSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewOrderSearchRepository creates a new order search repository matching terms against
+// the words of public IDs, which are either one word (ULIDs) or split by hyphens (UUIDs);
+// every match is as relevant, so the orders come newest first
+func NewOrderSearchRepository(db *gorm.DB) orderRepositories.OrderSearchRepository {
+	return &orderSearchRepository{db: db}
+}
+
+// Search retrieves a page of the orders matching criteria
+func (r *orderSearchRepository) Search(ctx context.Context, criteria orderEntities.OrderSearch, limit, offset int) ([]*orderEntities.OrderSearchHit, int64, error) {
+	query := database.Conn(ctx, r.db).Model(&models.OrderModel{}).Where("user_id = ?", criteria.UserID)
+	if criteria.Status != "" {
+		query = query.Where("status = ?", string(criteria.Status))
+	}
+	for _, term := range criteria.Terms {
+		query = query.Where("LOWER(public_id) LIKE ? OR LOWER(public_id) LIKE ?", term+"%", "%-"+term+"%")
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var orderModels []models.OrderModel
+	err := query.Preload("Items").Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&orderModels).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	hits := make([]*orderEntities.OrderSearchHit, len(orderModels))
+	for i := range orderModels {
+		hits[i] = &orderEntities.OrderSearchHit{Order: orderModels[i].ToDomainEntity(), Score: 1}
+	}
+	return hits, total, nil
+}
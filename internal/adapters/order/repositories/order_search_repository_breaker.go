@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// orderSearchRepositoryBreaker guards an OrderSearchRepository with a circuit breaker
+type orderSearchRepositoryBreaker struct {
+	repo orderRepositories.OrderSearchRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewOrderSearchRepositoryWithBreaker wraps repo so calls go through cb
+func NewOrderSearchRepositoryWithBreaker(repo orderRepositories.OrderSearchRepository, cb *breaker.CircuitBreaker) orderRepositories.OrderSearchRepository {
+	return &orderSearchRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Search searches orders through the breaker
+func (r *orderSearchRepositoryBreaker) Search(ctx context.Context, criteria orderEntities.OrderSearch, limit, offset int) (hits []*orderEntities.OrderSearchHit, total int64, err error) {
+	err = r.cb.Execute(func() error {
+		hits, total, err = r.repo.Search(ctx, criteria, limit, offset)
+		return err
+	})
+	return hits, total, err
+}
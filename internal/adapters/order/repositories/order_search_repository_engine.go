@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	"clean-arch-gin/internal/domain/shared/search"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// orderSearchRepositoryEngine searches orders in the search index, falling back to the
+// database while the engine is unavailable
+type orderSearchRepositoryEngine struct {
+	engine   search.SearchRepository
+	db       *gorm.DB
+	fallback orderRepositories.OrderSearchRepository
+}
+
+// NewOrderSearchRepositoryWithEngine searches orders in engine's orders index and reads the
+// hits from db, or searches through fallback while engine is unavailable
+func NewOrderSearchRepositoryWithEngine(engine search.SearchRepository, db *gorm.DB,
+	fallback orderRepositories.OrderSearchRepository) orderRepositories.OrderSearchRepository {
+	return &orderSearchRepositoryEngine{engine: engine, db: db, fallback: fallback}
+}
+
+// Search retrieves a page of the orders matching criteria; hits on orders deleted since
+// they were indexed are left out
+func (r *orderSearchRepositoryEngine) Search(ctx context.Context, criteria orderEntities.OrderSearch, limit, offset int) ([]*orderEntities.OrderSearchHit, int64, error) {
+	filters := map[string]interface{}{"user_id": criteria.UserID}
+	if criteria.Status != "" {
+		filters["status"] = string(criteria.Status)
+	}
+	results, total, err := r.engine.Search(ctx, search.Query{
+		Index:   OrderSearchIndex,
+		Terms:   criteria.Terms,
+		Filters: filters,
+		Limit:   limit,
+		Offset:  offset,
+	})
+	if errors.Is(err, search.ErrUnavailable) {
+		log.Printf("search: searching orders in the database: %v", err)
+		return r.fallback.Search(ctx, criteria, limit, offset)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]uint, 0, len(results))
+	for _, result := range results {
+		if id, err := strconv.ParseUint(result.ID, 10, 64); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+	var orderModels []models.OrderModel
+	if len(ids) > 0 {
+		if err := database.Conn(ctx, r.db).Preload("Items").Where("id IN ?", ids).Find(&orderModels).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+	byID := make(map[string]*orderEntities.Order, len(orderModels))
+	for i := range orderModels {
+		byID[strconv.FormatUint(uint64(orderModels[i].ID), 10)] = orderModels[i].ToDomainEntity()
+	}
+	hits := make([]*orderEntities.OrderSearchHit, 0, len(results))
+	for _, result := range results {
+		if order, ok := byID[result.ID]; ok {
+			hits = append(hits, &orderEntities.OrderSearchHit{Order: order, Score: result.Score})
+		}
+	}
+	return hits, total, nil
+}
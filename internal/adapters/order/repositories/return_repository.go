@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// returnRepository implements ReturnRepository using GORM
+// Returns of an order are created under a lock on its row, like its shipments
+type returnRepository struct {
+	db *gorm.DB
+}
+
+// NewReturnRepository creates a new return repository
+func NewReturnRepository(db *gorm.DB) orderRepositories.ReturnRepository {
+	return &returnRepository{db: db}
+}
+
+// Create stores the return when the order's units not returned yet still cover it
+func (r *returnRepository) Create(ctx context.Context, ret *orderEntities.Return) error {
+	model := models.NewReturnModelFromEntity(ret)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var order models.OrderModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items").First(&order, ret.OrderID).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return orderEntities.ErrOrderNotFound
+			}
+			return err
+		}
+
+		returned, err := listReturns(tx, ret.OrderID)
+		if err != nil {
+			return err
+		}
+		remaining := order.ToDomainEntity().Returnable(returned)
+		for _, item := range ret.Items {
+			if item.Quantity > remaining[item.OrderItemID] {
+				return orderEntities.ErrReturnExceedsOrder
+			}
+		}
+		return tx.Create(model).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	ret.ID = model.ID
+	for i, item := range model.Items {
+		ret.Items[i].ID = item.ID
+		ret.Items[i].ReturnID = model.ID
+	}
+	return nil
+}
+
+// GetByID retrieves a return with its items
+func (r *returnRepository) GetByID(ctx context.Context, id uint) (*orderEntities.Return, error) {
+	var model models.ReturnModel
+	err := r.db.WithContext(ctx).Preload("Items").First(&model, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, orderEntities.ErrReturnNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomainEntity(), nil
+}
+
+// ListByOrderID retrieves the order's returns with their items
+func (r *returnRepository) ListByOrderID(ctx context.Context, orderID uint) ([]*orderEntities.Return, error) {
+	return listReturns(r.db.WithContext(ctx), orderID)
+}
+
+// Update saves the return's status, note and refund reference while it is in status from;
+// its items never change
+func (r *returnRepository) Update(ctx context.Context, ret *orderEntities.Return, from orderEntities.ReturnStatus) error {
+	result := r.db.WithContext(ctx).Model(&models.ReturnModel{}).
+		Where("id = ? AND status = ?", ret.ID, string(from)).
+		Updates(map[string]interface{}{
+			"status":           string(ret.Status),
+			"note":             ret.Note,
+			"refund_reference": ret.RefundReference,
+			"updated_at":       ret.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return orderEntities.ErrInvalidReturnStatusTransition
+	}
+	return nil
+}
+
+// listReturns loads the order's returns, oldest first
+func listReturns(db *gorm.DB, orderID uint) ([]*orderEntities.Return, error) {
+	var returns []models.ReturnModel
+	if err := db.Preload("Items").Where("order_id = ?", orderID).Order("id").Find(&returns).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*orderEntities.Return, len(returns))
+	for i := range returns {
+		result[i] = returns[i].ToDomainEntity()
+	}
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// returnRepositoryBreaker guards a ReturnRepository with a circuit breaker
+type returnRepositoryBreaker struct {
+	repo orderRepositories.ReturnRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewReturnRepositoryWithBreaker wraps repo so calls go through cb
+func NewReturnRepositoryWithBreaker(repo orderRepositories.ReturnRepository, cb *breaker.CircuitBreaker) orderRepositories.ReturnRepository {
+	return &returnRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Create stores a return through the breaker
+func (r *returnRepositoryBreaker) Create(ctx context.Context, ret *orderEntities.Return) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Create(ctx, ret)
+	})
+}
+
+// GetByID retrieves a return through the breaker
+func (r *returnRepositoryBreaker) GetByID(ctx context.Context, id uint) (ret *orderEntities.Return, err error) {
+	err = r.cb.Execute(func() error {
+		ret, err = r.repo.GetByID(ctx, id)
+		return err
+	})
+	return ret, err
+}
+
+// ListByOrderID lists an order's returns through the breaker
+func (r *returnRepositoryBreaker) ListByOrderID(ctx context.Context, orderID uint) (returns []*orderEntities.Return, err error) {
+	err = r.cb.Execute(func() error {
+		returns, err = r.repo.ListByOrderID(ctx, orderID)
+		return err
+	})
+	return returns, err
+}
+
+// Update saves a return through the breaker
+func (r *returnRepositoryBreaker) Update(ctx context.Context, ret *orderEntities.Return, from orderEntities.ReturnStatus) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Update(ctx, ret, from)
+	})
+}
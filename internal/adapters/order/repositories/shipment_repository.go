@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// shipmentRepository implements ShipmentRepository using GORM
+// Shipments of an order are created under a lock on its row, so the units already shipped
+// read while checking a new shipment cannot change before it is stored
+type shipmentRepository struct {
+	db *gorm.DB
+}
+
+// NewShipmentRepository creates a new shipment repository
+func NewShipmentRepository(db *gorm.DB) orderRepositories.ShipmentRepository {
+	return &shipmentRepository{db: db}
+}
+
+// Create stores the shipment when the order's unshipped units still cover it
+func (r *shipmentRepository) Create(ctx context.Context, shipment *orderEntities.Shipment) error {
+	model := models.NewShipmentModelFromEntity(shipment)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var order models.OrderModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items").First(&order, shipment.OrderID).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return orderEntities.ErrOrderNotFound
+			}
+			return err
+		}
+
+		var shipped []models.ShipmentModel
+		if err := tx.Preload("Items").Where("order_id = ?", shipment.OrderID).Find(&shipped).Error; err != nil {
+			return err
+		}
+		prior := make([]*orderEntities.Shipment, len(shipped))
+		for i := range shipped {
+			prior[i] = shipped[i].ToDomainEntity()
+		}
+
+		remaining := order.ToDomainEntity().Unshipped(prior)
+		for orderItemID, quantity := range shipment.Quantities() {
+			if quantity > remaining[orderItemID] {
+				return orderEntities.ErrShipmentExceedsOrder
+			}
+		}
+		return tx.Create(model).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	*shipment = *model.ToDomainEntity()
+	return nil
+}
+
+// ListByOrderID retrieves the order's shipments with their items
+func (r *shipmentRepository) ListByOrderID(ctx context.Context, orderID uint) ([]*orderEntities.Shipment, error) {
+	var shipments []models.ShipmentModel
+	err := r.db.WithContext(ctx).Preload("Items").Where("order_id = ?", orderID).Order("id").Find(&shipments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*orderEntities.Shipment, len(shipments))
+	for i := range shipments {
+		result[i] = shipments[i].ToDomainEntity()
+	}
+	return result, nil
+}
+
+// Delete removes a shipment and its items
+func (r *shipmentRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("shipment_id = ?", id).Delete(&models.ShipmentItemModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.ShipmentModel{}, id).Error
+	})
+}
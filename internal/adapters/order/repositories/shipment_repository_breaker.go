@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// shipmentRepositoryBreaker guards a ShipmentRepository with a circuit breaker
+type shipmentRepositoryBreaker struct {
+	repo orderRepositories.ShipmentRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewShipmentRepositoryWithBreaker wraps repo so calls go through cb
+func NewShipmentRepositoryWithBreaker(repo orderRepositories.ShipmentRepository, cb *breaker.CircuitBreaker) orderRepositories.ShipmentRepository {
+	return &shipmentRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Create stores a shipment through the breaker
+func (r *shipmentRepositoryBreaker) Create(ctx context.Context, shipment *orderEntities.Shipment) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Create(ctx, shipment)
+	})
+}
+
+// ListByOrderID lists an order's shipments through the breaker
+func (r *shipmentRepositoryBreaker) ListByOrderID(ctx context.Context, orderID uint) (shipments []*orderEntities.Shipment, err error) {
+	err = r.cb.Execute(func() error {
+		shipments, err = r.repo.ListByOrderID(ctx, orderID)
+		return err
+	})
+	return shipments, err
+}
+
+// Delete removes a shipment through the breaker
+func (r *shipmentRepositoryBreaker) Delete(ctx context.Context, id uint) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Delete(ctx, id)
+	})
+}
@@ -0,0 +1,167 @@
+// Package streams fans domain events out to long-lived client connections such as SSE
+package streams
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	orderEvents "clean-arch-gin/internal/domain/order/events"
+	"clean-arch-gin/internal/domain/shared/events"
+)
+
+const (
+	// historySize is how many transitions are kept per order for Last-Event-ID resume
+	historySize = 50
+	// maxTrackedOrders bounds memory; the least recently changed orders are evicted first
+	maxTrackedOrders = 10_000
+	// subscriberBuffer is how many events a slow subscriber may lag before it is dropped
+	subscriberBuffer = 16
+)
+
+// StatusEvent is one order status transition as delivered to stream clients
+type StatusEvent struct {
+	ID         uint64    `json:"id"`
+	OrderID    uint      `json:"order_id"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// StatusStream keeps a short history of status transitions per order and fans new ones
+// out to subscribers. It is fed by the event bus, so it only sees transitions made by
+// this process
+type StatusStream struct {
+	mu          sync.Mutex
+	seq         uint64
+	history     map[uint][]StatusEvent
+	order       []uint
+	subscribers map[uint]map[chan StatusEvent]struct{}
+	closed      bool
+}
+
+// NewStatusStream creates a stream subscribed to order status changes on the bus
+// The returned function unsubscribes it
+func NewStatusStream(subscriber events.EventSubscriber) (*StatusStream, func()) {
+	s := &StatusStream{
+		// Seed IDs from the clock so they keep increasing across restarts and a stale
+		// Last-Event-ID never hides new events
+		seq:         uint64(time.Now().UnixNano()),
+		history:     make(map[uint][]StatusEvent),
+		subscribers: make(map[uint]map[chan StatusEvent]struct{}),
+	}
+	unsubscribe := subscriber.Subscribe(orderEvents.OrderStatusChangedEventName, s.handle)
+	return s, unsubscribe
+}
+
+// Subscribe returns the transitions of orderID after lastEventID and a channel of live ones
+// The backlog and the channel never overlap or leave gaps. The channel is closed when the
+// subscriber falls too far behind; clients then reconnect with their last event ID
+func (s *StatusStream) Subscribe(orderID uint, lastEventID uint64) ([]StatusEvent, <-chan StatusEvent, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var backlog []StatusEvent
+	for _, event := range s.history[orderID] {
+		if event.ID > lastEventID {
+			backlog = append(backlog, event)
+		}
+	}
+
+	ch := make(chan StatusEvent, subscriberBuffer)
+	if s.closed {
+		close(ch)
+		return backlog, ch, func() {}
+	}
+	if s.subscribers[orderID] == nil {
+		s.subscribers[orderID] = make(map[chan StatusEvent]struct{})
+	}
+	s.subscribers[orderID][ch] = struct{}{}
+
+	cancel := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.remove(orderID, ch)
+	}
+	return backlog, ch, cancel
+}
+
+// Close ends every live subscription so streaming handlers return during shutdown
+// Clients reconnect with their last event ID, typically to another instance; later
+// subscriptions receive their backlog and an already closed channel
+func (s *StatusStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for orderID, subscribers := range s.subscribers {
+		for ch := range subscribers {
+			s.remove(orderID, ch)
+		}
+	}
+}
+
+// handle records an OrderStatusChangedEvent and delivers it to subscribers
+func (s *StatusStream) handle(_ context.Context, domainEvent events.DomainEvent) {
+	changed, ok := domainEvent.(orderEvents.OrderStatusChangedEvent)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	event := StatusEvent{
+		ID:         s.seq,
+		OrderID:    changed.OrderID,
+		From:       string(changed.From),
+		To:         string(changed.To),
+		OccurredAt: changed.OccurredOn(),
+	}
+	s.record(event)
+
+	for ch := range s.subscribers[event.OrderID] {
+		select {
+		case ch <- event:
+		default:
+			s.remove(event.OrderID, ch)
+		}
+	}
+}
+
+// record appends to the order's history and evicts old orders; s.mu must be held
+func (s *StatusStream) record(event StatusEvent) {
+	if _, tracked := s.history[event.OrderID]; tracked {
+		for i, id := range s.order {
+			if id == event.OrderID {
+				s.order = append(s.order[:i], s.order[i+1:]...)
+				break
+			}
+		}
+	}
+	s.order = append(s.order, event.OrderID)
+
+	history := append(s.history[event.OrderID], event)
+	if len(history) > historySize {
+		history = history[len(history)-historySize:]
+	}
+	s.history[event.OrderID] = history
+
+	for len(s.order) > maxTrackedOrders {
+		delete(s.history, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// remove closes and forgets a subscriber channel; s.mu must be held
+func (s *StatusStream) remove(orderID uint, ch chan StatusEvent) {
+	if _, ok := s.subscribers[orderID][ch]; !ok {
+		return
+	}
+	delete(s.subscribers[orderID], ch)
+	if len(s.subscribers[orderID]) == 0 {
+		delete(s.subscribers, orderID)
+	}
+	close(ch)
+}
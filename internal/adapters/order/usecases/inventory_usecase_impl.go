@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	orderUsecases "clean-arch-gin/internal/domain/order/usecases"
+)
+
+// inventoryUseCase implements the InventoryUseCase interface
+type inventoryUseCase struct {
+	inventoryRepo orderRepositories.InventoryRepository
+}
+
+// NewInventoryUseCase creates a new inventory use case
+func NewInventoryUseCase(inventoryRepo orderRepositories.InventoryRepository) orderUsecases.InventoryUseCase {
+	return &inventoryUseCase{
+		inventoryRepo: inventoryRepo,
+	}
+}
+
+// GetStock retrieves the stock level of a product
+func (uc *inventoryUseCase) GetStock(ctx context.Context, productID uint) (*orderEntities.StockLevel, error) {
+	return uc.inventoryRepo.GetStock(ctx, productID)
+}
+
+// SetStock sets the units on hand of a product, tracking its stock from now on
+func (uc *inventoryUseCase) SetStock(ctx context.Context, productID uint, onHand int) (*orderEntities.StockLevel, error) {
+	if onHand < 0 {
+		return nil, orderEntities.ErrInvalidStockLevel
+	}
+	return uc.inventoryRepo.SetOnHand(ctx, productID, onHand)
+}
@@ -0,0 +1,131 @@
+package usecases
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	orderUsecases "clean-arch-gin/internal/domain/order/usecases"
+	"clean-arch-gin/internal/domain/shared/documents"
+	"clean-arch-gin/internal/domain/shared/locks"
+	"clean-arch-gin/internal/domain/shared/money"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+)
+
+const (
+	// numberingLockPrefix starts the names of the locks each tenant's invoices are numbered under
+	numberingLockPrefix = "invoices:numbering"
+	// numberingLockTTL is how long a replica that died numbering an invoice holds up the others
+	numberingLockTTL = 15 * time.Second
+)
+
+// invoiceUseCase implements the InvoiceUseCase interface
+type invoiceUseCase struct {
+	orderRepo   orderRepositories.OrderRepository
+	invoiceRepo orderRepositories.InvoiceRepository
+	pdf         documents.PDFGenerator
+	locks       locks.LockManager
+}
+
+// NewInvoiceUseCase creates a new invoice use case rendering invoices with pdf
+// With lockManager invoices are numbered one at a time across replicas, ahead of the
+// repository's own serialisation, so replicas wait on the lock rather than on database rows
+func NewInvoiceUseCase(orderRepo orderRepositories.OrderRepository, invoiceRepo orderRepositories.InvoiceRepository,
+	pdf documents.PDFGenerator, lockManager locks.LockManager) orderUsecases.InvoiceUseCase {
+	return &invoiceUseCase{
+		orderRepo:   orderRepo,
+		invoiceRepo: invoiceRepo,
+		pdf:         pdf,
+		locks:       lockManager,
+	}
+}
+
+// IssueInvoice returns the order's invoice, issuing it first when the order has none
+func (uc *invoiceUseCase) IssueInvoice(ctx context.Context, orderID uint) (*orderEntities.Invoice, error) {
+	invoice, err := uc.invoiceRepo.GetByOrderID(ctx, orderID)
+	if err != orderEntities.ErrInvoiceNotFound {
+		return invoice, err
+	}
+
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	invoice, err = orderEntities.NewInvoice(order)
+	if err != nil {
+		return nil, err
+	}
+	var issued *orderEntities.Invoice
+	err = locks.WithLock(ctx, uc.locks, numberingLockKey(ctx), numberingLockTTL, func(ctx context.Context) error {
+		issued, err = uc.invoiceRepo.Create(ctx, invoice)
+		return err
+	})
+	return issued, err
+}
+
+// RenderInvoicePDF renders the order's invoice
+func (uc *invoiceUseCase) RenderInvoicePDF(ctx context.Context, orderID uint, w io.Writer) (*orderEntities.Invoice, error) {
+	invoice, err := uc.IssueInvoice(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.pdf.GeneratePDF(w, invoiceDocument(invoice)); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// numberingLockKey names the lock the invoices of ctx's tenant are numbered under, since each
+// tenant has its own sequence; work acting across tenants shares one lock
+func numberingLockKey(ctx context.Context) string {
+	tenantID, ok := tenancy.FromContext(ctx)
+	if !ok {
+		return numberingLockPrefix
+	}
+	return numberingLockPrefix + ":" + strconv.FormatUint(uint64(tenantID), 10)
+}
+
+// invoiceDocument lays out the invoice for printing
+func invoiceDocument(invoice *orderEntities.Invoice) documents.Document {
+	rows := make([][]string, len(invoice.Lines))
+	for i, line := range invoice.Lines {
+		rows[i] = []string{
+			"#" + strconv.FormatUint(uint64(line.ProductID), 10),
+			strconv.Itoa(line.Quantity),
+			formatAmount(line.UnitPrice, invoice.Currency),
+			formatAmount(line.Amount, invoice.Currency),
+		}
+	}
+
+	return documents.Document{
+		Title: "Invoice " + invoice.Number(),
+		Header: []string{
+			"Invoice number: " + invoice.Number(),
+			"Issued: " + invoice.IssuedAt.Format("2006-01-02"),
+			"Order: #" + strconv.FormatUint(uint64(invoice.OrderID), 10),
+			"Customer: #" + strconv.FormatUint(uint64(invoice.UserID), 10),
+			"Currency: " + string(invoice.Currency),
+		},
+		Columns: []documents.Column{
+			{Title: "Product", Width: 40},
+			{Title: "Quantity", Width: 10, AlignRight: true},
+			{Title: "Unit price", Width: 16, AlignRight: true},
+			{Title: "Amount", Width: 16, AlignRight: true},
+		},
+		Rows: rows,
+		Footer: []string{
+			"Subtotal: " + formatAmount(invoice.Subtotal(), invoice.Currency),
+			"Tax: " + formatAmount(invoice.TaxAmount, invoice.Currency),
+			"Shipping: " + formatAmount(invoice.ShippingAmount, invoice.Currency),
+			"Total: " + formatAmount(invoice.Total, invoice.Currency) + " " + string(invoice.Currency),
+		},
+	}
+}
+
+// formatAmount prints a price with the decimals of its currency
+func formatAmount(amount float64, currency money.Currency) string {
+	return strconv.FormatFloat(amount, 'f', currency.Decimals(), 64)
+}
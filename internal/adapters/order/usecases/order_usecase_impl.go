@@ -0,0 +1,251 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderEvents "clean-arch-gin/internal/domain/order/events"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	orderUsecases "clean-arch-gin/internal/domain/order/usecases"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/pricing"
+)
+
+// orderUseCase implements the OrderUseCase interface
+type orderUseCase struct {
+	orderRepo    orderRepositories.OrderRepository
+	shipmentRepo orderRepositories.ShipmentRepository
+	// inventoryRepo holds the stock of confirmed orders; nil tracks no stock
+	inventoryRepo orderRepositories.InventoryRepository
+	pricing       orderUsecases.PricingUseCase
+	publisher     events.EventPublisher
+	// reservationTTL is how long confirmed orders hold their stock unpaid
+	reservationTTL time.Duration
+}
+
+// NewOrderUseCase creates a new order use case pricing new orders with pricing and reserving
+// the stock of confirmed orders in inventoryRepo for reservationTTL; inventoryRepo may be nil
+func NewOrderUseCase(orderRepo orderRepositories.OrderRepository, shipmentRepo orderRepositories.ShipmentRepository,
+	inventoryRepo orderRepositories.InventoryRepository, pricing orderUsecases.PricingUseCase, publisher events.EventPublisher,
+	reservationTTL time.Duration) orderUsecases.OrderUseCase {
+	return &orderUseCase{
+		orderRepo:      orderRepo,
+		shipmentRepo:   shipmentRepo,
+		inventoryRepo:  inventoryRepo,
+		pricing:        pricing,
+		publisher:      publisher,
+		reservationTTL: reservationTTL,
+	}
+}
+
+// CreateOrder places a pending order for the items, priced with tax and shipping to
+// destination, and publishes OrderPlacedEvent
+func (uc *orderUseCase) CreateOrder(ctx context.Context, userID uint, destination pricing.Destination,
+	items []*orderEntities.OrderItem) (*orderEntities.Order, *orderEntities.PriceBreakdown, error) {
+	order, err := orderEntities.NewOrder(userID, items)
+	if err != nil {
+		return nil, nil, err
+	}
+	breakdown, err := uc.pricing.PriceItems(ctx, destination, order.Items)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := order.ApplyPricing(breakdown); err != nil {
+		return nil, nil, err
+	}
+	if err := uc.orderRepo.Create(ctx, order); err != nil {
+		return nil, nil, err
+	}
+	if err := uc.publisher.Publish(ctx, orderEvents.NewOrderPlacedEvent(order)); err != nil {
+		log.Printf("failed to publish %s for order %d: %v", orderEvents.OrderPlacedEventName, order.ID, err)
+	}
+	return order, breakdown, nil
+}
+
+// GetOrder retrieves an order by ID
+func (uc *orderUseCase) GetOrder(ctx context.Context, id uint) (*orderEntities.Order, error) {
+	return uc.orderRepo.GetByID(ctx, id)
+}
+
+// GetOrderByPublicID retrieves an order by its public ID
+func (uc *orderUseCase) GetOrderByPublicID(ctx context.Context, publicID string) (*orderEntities.Order, error) {
+	return uc.orderRepo.GetByPublicID(ctx, publicID)
+}
+
+// ConfirmOrder moves a pending order to confirmed, reserving its stock
+func (uc *orderUseCase) ConfirmOrder(ctx context.Context, id uint) (*orderEntities.Order, error) {
+	return uc.transition(ctx, id, func(o *orderEntities.Order) error {
+		if err := o.Confirm(); err != nil {
+			return err
+		}
+		if uc.inventoryRepo == nil {
+			return nil
+		}
+		return uc.inventoryRepo.Reserve(ctx, o.ID, o.Quantities(), time.Now().Add(uc.reservationTTL))
+	})
+}
+
+// CancelOrder cancels an order that has not been delivered
+func (uc *orderUseCase) CancelOrder(ctx context.Context, id uint) (*orderEntities.Order, error) {
+	return uc.transition(ctx, id, (*orderEntities.Order).Cancel)
+}
+
+// ShipOrder records a shipment and moves the order on as its shipments cover its units
+// The shipment is removed again when the order cannot be saved
+func (uc *orderUseCase) ShipOrder(ctx context.Context, id uint, trackingNumber, carrier string, quantities map[uint]int) (*orderEntities.Shipment, *orderEntities.Order, error) {
+	order, err := uc.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	shipment, err := orderEntities.NewShipment(order.ID, trackingNumber, carrier, quantities)
+	if err != nil {
+		return nil, nil, err
+	}
+	shipped, err := uc.shipmentRepo.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := order.AddShipment(shipped, shipment); err != nil {
+		return nil, nil, err
+	}
+
+	if err := uc.shipmentRepo.Create(ctx, shipment); err != nil {
+		return nil, nil, err
+	}
+	if err := uc.orderRepo.Update(ctx, order); err != nil {
+		if deleteErr := uc.shipmentRepo.Delete(ctx, shipment.ID); deleteErr != nil {
+			log.Printf("failed to remove shipment %d of order %d: %v", shipment.ID, order.ID, deleteErr)
+		}
+		return nil, nil, err
+	}
+	uc.transitioned(ctx, order)
+
+	return shipment, order, nil
+}
+
+// ListShipments retrieves the shipments of an order
+func (uc *orderUseCase) ListShipments(ctx context.Context, id uint) ([]*orderEntities.Shipment, error) {
+	if _, err := uc.orderRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.shipmentRepo.ListByOrderID(ctx, id)
+}
+
+// CancelStaleOrders cancels the oldest orders still pending since before
+// An order confirmed or cancelled meanwhile is skipped rather than failing the batch
+func (uc *orderUseCase) CancelStaleOrders(ctx context.Context, before time.Time, limit int) (int, error) {
+	orders, err := uc.orderRepo.GetPendingCreatedBefore(ctx, before, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	cancelled := 0
+	for _, order := range orders {
+		_, err := uc.transition(ctx, order.ID, func(o *orderEntities.Order) error {
+			if o.Status != orderEntities.OrderStatusPending {
+				return orderEntities.ErrInvalidOrderStatusTransition
+			}
+			return o.Cancel()
+		})
+		switch {
+		case err == nil:
+			cancelled++
+		case err == orderEntities.ErrInvalidOrderStatusTransition || err == orderEntities.ErrOrderNotFound:
+		default:
+			return cancelled, err
+		}
+	}
+	return cancelled, nil
+}
+
+// CancelUnpaidOrders cancels confirmed orders whose stock reservation expired, releasing it
+// The reservation of an order that moved on meanwhile is settled as its status requires
+// instead, so it is not picked again
+func (uc *orderUseCase) CancelUnpaidOrders(ctx context.Context, now time.Time, limit int) (int, error) {
+	if uc.inventoryRepo == nil {
+		return 0, nil
+	}
+	orderIDs, err := uc.inventoryRepo.ListExpiredOrderIDs(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	cancelled := 0
+	for _, id := range orderIDs {
+		order, err := uc.orderRepo.GetByID(ctx, id)
+		switch {
+		case err == orderEntities.ErrOrderNotFound:
+			err = uc.inventoryRepo.Release(ctx, id)
+		case err != nil:
+		case order.Status == orderEntities.OrderStatusConfirmed:
+			if _, err = uc.transition(ctx, id, (*orderEntities.Order).Cancel); err == nil {
+				cancelled++
+			}
+		default:
+			err = uc.settleStock(ctx, order)
+		}
+		if err != nil {
+			return cancelled, err
+		}
+	}
+	return cancelled, nil
+}
+
+// transition applies a status change, persists it, settles the order's stock reservation
+// and publishes the events the order raised
+// When persisting fails after apply reserved stock, the reservation is released again
+func (uc *orderUseCase) transition(ctx context.Context, id uint, apply func(*orderEntities.Order) error) (*orderEntities.Order, error) {
+	order, err := uc.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(order); err != nil {
+		return nil, err
+	}
+
+	if err := uc.orderRepo.Update(ctx, order); err != nil {
+		if uc.inventoryRepo != nil && order.Status == orderEntities.OrderStatusConfirmed {
+			if releaseErr := uc.inventoryRepo.Release(ctx, order.ID); releaseErr != nil {
+				log.Printf("failed to release the stock of order %d: %v", order.ID, releaseErr)
+			}
+		}
+		return nil, err
+	}
+	uc.transitioned(ctx, order)
+
+	return order, nil
+}
+
+// transitioned settles the stock reservation of an order just saved in a new status and
+// publishes the events it raised
+// The transition is committed, so failures are logged rather than failing the request
+func (uc *orderUseCase) transitioned(ctx context.Context, order *orderEntities.Order) {
+	// A reservation left active expires and is settled by CancelUnpaidOrders
+	if err := uc.settleStock(ctx, order); err != nil {
+		log.Printf("failed to settle the stock of order %d: %v", order.ID, err)
+	}
+
+	for _, event := range order.PullEvents() {
+		if err := uc.publisher.Publish(ctx, event); err != nil {
+			log.Printf("failed to publish %s for order %d: %v", event.EventName(), order.ID, err)
+		}
+	}
+}
+
+// settleStock gives the stock of a cancelled order back and takes that of an order shipped
+// in part or in full out of the stock on hand
+func (uc *orderUseCase) settleStock(ctx context.Context, order *orderEntities.Order) error {
+	if uc.inventoryRepo == nil {
+		return nil
+	}
+	switch order.Status {
+	case orderEntities.OrderStatusCancelled:
+		return uc.inventoryRepo.Release(ctx, order.ID)
+	case orderEntities.OrderStatusPartiallyShipped, orderEntities.OrderStatusShipped, orderEntities.OrderStatusDelivered:
+		return uc.inventoryRepo.Commit(ctx, order.ID)
+	}
+	return nil
+}
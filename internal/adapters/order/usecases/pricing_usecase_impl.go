@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderUsecases "clean-arch-gin/internal/domain/order/usecases"
+	"clean-arch-gin/internal/domain/shared/money"
+	"clean-arch-gin/internal/domain/shared/pricing"
+)
+
+// pricingUseCase implements the PricingUseCase interface
+type pricingUseCase struct {
+	tax        pricing.TaxCalculator
+	shipping   pricing.ShippingStrategy
+	currencies money.BaseCurrencies
+	rates      money.ExchangeRateProvider
+}
+
+// NewPricingUseCase creates a new pricing use case pricing in the base currency of each
+// tenant from currencies and converting amounts for display at the rates of rates
+func NewPricingUseCase(tax pricing.TaxCalculator, shipping pricing.ShippingStrategy, currencies money.BaseCurrencies,
+	rates money.ExchangeRateProvider) orderUsecases.PricingUseCase {
+	return &pricingUseCase{
+		tax:        tax,
+		shipping:   shipping,
+		currencies: currencies,
+		rates:      rates,
+	}
+}
+
+// PriceItems taxes each item, then adds shipping, in the tenant's base currency
+func (uc *pricingUseCase) PriceItems(ctx context.Context, destination pricing.Destination, items []*orderEntities.OrderItem) (*orderEntities.PriceBreakdown, error) {
+	if len(items) == 0 {
+		return nil, orderEntities.ErrEmptyOrder
+	}
+	currency, err := uc.currencies.BaseCurrency(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]pricing.Line, len(items))
+	for i, item := range items {
+		lines[i] = pricing.Line{ProductID: item.ProductID, Quantity: item.Quantity, UnitPrice: item.Price}
+	}
+
+	tax, err := uc.tax.CalculateTax(ctx, destination, lines)
+	if err != nil {
+		return nil, err
+	}
+	if len(tax.Amounts) != len(lines) {
+		return nil, fmt.Errorf("tax calculator returned %d amounts for %d lines", len(tax.Amounts), len(lines))
+	}
+	shipping, err := uc.shipping.ShippingRate(ctx, destination, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	priced := make([]*orderEntities.PriceLine, len(lines))
+	for i, line := range lines {
+		priced[i] = &orderEntities.PriceLine{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+			Amount:    line.Amount(),
+			Tax:       tax.Amounts[i],
+		}
+	}
+	return orderEntities.NewPriceBreakdown(priced, tax.Label, shipping, currency), nil
+}
+
+// ExchangeRate quotes the rate to display amounts in another currency
+func (uc *pricingUseCase) ExchangeRate(ctx context.Context, from, to money.Currency) (float64, error) {
+	return uc.rates.Rate(ctx, from, to)
+}
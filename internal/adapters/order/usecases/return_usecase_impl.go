@@ -0,0 +1,155 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+	orderUsecases "clean-arch-gin/internal/domain/order/usecases"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/payments"
+)
+
+// returnUseCase implements the ReturnUseCase interface
+type returnUseCase struct {
+	orderRepo  orderRepositories.OrderRepository
+	returnRepo orderRepositories.ReturnRepository
+	// inventoryRepo takes received goods back; nil tracks no stock
+	inventoryRepo orderRepositories.InventoryRepository
+	refunds       payments.Gateway
+	publisher     events.EventPublisher
+}
+
+// NewReturnUseCase creates a new return use case refunding through refunds; inventoryRepo may be nil
+func NewReturnUseCase(orderRepo orderRepositories.OrderRepository, returnRepo orderRepositories.ReturnRepository,
+	inventoryRepo orderRepositories.InventoryRepository, refunds payments.Gateway, publisher events.EventPublisher) orderUsecases.ReturnUseCase {
+	return &returnUseCase{
+		orderRepo:     orderRepo,
+		returnRepo:    returnRepo,
+		inventoryRepo: inventoryRepo,
+		refunds:       refunds,
+		publisher:     publisher,
+	}
+}
+
+// RequestReturn opens a return of a delivered order
+func (uc *returnUseCase) RequestReturn(ctx context.Context, orderID uint, reason string, quantities map[uint]int) (*orderEntities.Return, error) {
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	returned, err := uc.returnRepo.ListByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := orderEntities.NewReturn(order, returned, reason, quantities)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.returnRepo.Create(ctx, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetReturn retrieves a return of the order
+func (uc *returnUseCase) GetReturn(ctx context.Context, orderID, id uint) (*orderEntities.Return, error) {
+	ret, err := uc.returnRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ret.OrderID != orderID {
+		return nil, orderEntities.ErrReturnNotFound
+	}
+	return ret, nil
+}
+
+// ListReturns retrieves the returns of an order
+func (uc *returnUseCase) ListReturns(ctx context.Context, orderID uint) ([]*orderEntities.Return, error) {
+	if _, err := uc.orderRepo.GetByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return uc.returnRepo.ListByOrderID(ctx, orderID)
+}
+
+// ApproveReturn accepts a requested return
+func (uc *returnUseCase) ApproveReturn(ctx context.Context, orderID, id uint, note string) (*orderEntities.Return, error) {
+	return uc.transition(ctx, orderID, id, func(r *orderEntities.Return) error {
+		return r.Approve(note)
+	})
+}
+
+// RejectReturn refuses a requested return
+func (uc *returnUseCase) RejectReturn(ctx context.Context, orderID, id uint, note string) (*orderEntities.Return, error) {
+	return uc.transition(ctx, orderID, id, func(r *orderEntities.Return) error {
+		return r.Reject(note)
+	})
+}
+
+// ReceiveReturn records an approved return's goods are back and restocks them
+// The return is saved first, so a failed restock is logged instead of receiving it twice
+func (uc *returnUseCase) ReceiveReturn(ctx context.Context, orderID, id uint) (*orderEntities.Return, error) {
+	ret, err := uc.transition(ctx, orderID, id, (*orderEntities.Return).Receive)
+	if err != nil {
+		return nil, err
+	}
+	if uc.inventoryRepo != nil {
+		if err := uc.inventoryRepo.Restock(ctx, ret.Quantities()); err != nil {
+			log.Printf("failed to restock return %d of order %d: %v", ret.ID, ret.OrderID, err)
+		}
+	}
+	return ret, nil
+}
+
+// RefundReturn refunds a received return in the currency the order was paid in
+// The refund is keyed by the return, so when saving the return fails after the gateway paid,
+// retrying gets the same refund back from the gateway instead of paying again
+func (uc *returnUseCase) RefundReturn(ctx context.Context, orderID, id uint) (*orderEntities.Return, error) {
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.transition(ctx, orderID, id, func(r *orderEntities.Return) error {
+		if !r.CanRefund() {
+			return orderEntities.ErrInvalidReturnStatusTransition
+		}
+		reference, err := uc.refunds.Refund(ctx, payments.Refund{
+			OrderID:        r.OrderID,
+			Amount:         r.RefundAmount,
+			Currency:       order.Currency,
+			IdempotencyKey: "return-" + strconv.FormatUint(uint64(r.ID), 10),
+			Reason:         r.Reason,
+		})
+		if err != nil {
+			return err
+		}
+		return r.Refunded(reference)
+	})
+}
+
+// transition applies a status change to a return of the order, persists it unless another
+// transition got there first and publishes the events the return raised
+func (uc *returnUseCase) transition(ctx context.Context, orderID, id uint, apply func(*orderEntities.Return) error) (*orderEntities.Return, error) {
+	ret, err := uc.GetReturn(ctx, orderID, id)
+	if err != nil {
+		return nil, err
+	}
+	from := ret.Status
+	if err := apply(ret); err != nil {
+		return nil, err
+	}
+	if err := uc.returnRepo.Update(ctx, ret, from); err != nil {
+		return nil, err
+	}
+
+	// The transition is committed; a failed publish must not fail the request
+	for _, event := range ret.PullEvents() {
+		if err := uc.publisher.Publish(ctx, event); err != nil {
+			log.Printf("failed to publish %s for return %d: %v", event.EventName(), ret.ID, err)
+		}
+	}
+	return ret, nil
+}
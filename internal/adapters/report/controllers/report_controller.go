@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/report/tasks"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	reportEntities "clean-arch-gin/internal/domain/report/entities"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/reports"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TaskEnqueuer queues background work, e.g. a *taskqueue.Queue
+type TaskEnqueuer interface {
+	Enqueue(ctx context.Context, taskType string, payload interface{}, opts ...taskqueue.EnqueueOption) (*taskqueue.Task, error)
+}
+
+// RequestReportRequest names the report to generate, its parameters and the file format
+type RequestReportRequest struct {
+	Type   string                `json:"type" binding:"required"`
+	Params map[string]string     `json:"params"`
+	Format reportEntities.Format `json:"format" binding:"required,oneof=csv pdf"`
+}
+
+// ReportTypeDTO describes a report that can be requested
+type ReportTypeDTO struct {
+	Type        string            `json:"type"`
+	Description string            `json:"description"`
+	Params      map[string]string `json:"params"`
+}
+
+// ReportTypesResponse lists the reports that can be requested, by type
+type ReportTypesResponse struct {
+	Types []ReportTypeDTO `json:"types"`
+}
+
+// ReportController handles report requests
+type ReportController struct {
+	generators map[string]reports.Generator
+	// queue runs the reports; without one reports are unavailable
+	queue TaskEnqueuer
+}
+
+// NewReportController creates a new report controller for the reports of generators, by type
+func NewReportController(generators map[string]reports.Generator) *ReportController {
+	return &ReportController{generators: generators}
+}
+
+// SetQueue enables reports on queue
+func (rc *ReportController) SetQueue(queue TaskEnqueuer) {
+	rc.queue = queue
+}
+
+// ListTypes lists the reports that can be requested with their parameters
+func (rc *ReportController) ListTypes(c *gin.Context) {
+	types := make([]ReportTypeDTO, 0, len(rc.generators))
+	for _, generator := range rc.generators {
+		types = append(types, ReportTypeDTO{
+			Type:        generator.Type(),
+			Description: generator.Description(),
+			Params:      generator.Params(),
+		})
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Type < types[j].Type })
+	c.JSON(http.StatusOK, ReportTypesResponse{Types: types})
+}
+
+// RequestReport queues a report and responds 202 with a job ID whose progress the jobs API
+// reports. Once the job succeeds the requester is notified with a signed download URL, which
+// is also the job's result
+func (rc *ReportController) RequestReport(c *gin.Context) {
+	requesterID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req RequestReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Reject an unknown report or bad parameters now rather than in a dead task
+	generator, ok := rc.generators[req.Type]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": reportEntities.ErrUnknownReportType.Error()})
+		return
+	}
+	if err := generator.Validate(req.Params); err != nil {
+		var domainErr sharedEntities.DomainError
+		if errors.As(err, &domainErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		responses.InternalError(c, err)
+		return
+	}
+	if rc.queue == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Reports are not available"})
+		return
+	}
+
+	task, err := rc.queue.Enqueue(c.Request.Context(), tasks.GenerateTaskType, tasks.GeneratePayload{
+		Type:        req.Type,
+		Params:      req.Params,
+		Format:      req.Format,
+		RequesterID: requesterID,
+	}, taskqueue.Owner(strconv.FormatUint(uint64(requesterID), 10)))
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+	responses.JobAccepted(c, task)
+}
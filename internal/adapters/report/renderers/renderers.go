@@ -0,0 +1,75 @@
+// Package renderers writes generated reports as CSV or PDF files
+package renderers
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	reportEntities "clean-arch-gin/internal/domain/report/entities"
+	"clean-arch-gin/internal/domain/shared/documents"
+)
+
+// Renderer writes report documents to files
+type Renderer struct {
+	pdf documents.PDFGenerator
+}
+
+// NewRenderer creates a renderer writing PDF files with pdf
+func NewRenderer(pdf documents.PDFGenerator) *Renderer {
+	return &Renderer{pdf: pdf}
+}
+
+// Render writes doc to w in format
+func (r *Renderer) Render(w io.Writer, format reportEntities.Format, doc documents.Document) error {
+	switch format {
+	case reportEntities.FormatCSV:
+		return writeCSV(w, doc)
+	case reportEntities.FormatPDF:
+		return r.pdf.GeneratePDF(w, doc)
+	default:
+		return reportEntities.ErrUnsupportedFormat
+	}
+}
+
+// writeCSV writes the table of doc under a header row of its column titles
+// The title, header and footer lines have no place in a table and are left out
+func writeCSV(w io.Writer, doc documents.Document) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(doc.Columns))
+	for i, column := range doc.Columns {
+		header[i] = column.Title
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, row := range doc.Rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			record[i] = escapeFormula(cell)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// escapeFormula stops spreadsheets from evaluating text such as =HYPERLINK(...) by
+// prefixing cells that start like a formula with a quote; negative numbers are left alone
+func escapeFormula(cell string) string {
+	if cell == "" || !strings.ContainsRune("=+-@\t\r", rune(cell[0])) || isNumber(cell) {
+		return cell
+	}
+	return "'" + cell
+}
+
+// isNumber reports whether cell is a plain signed decimal such as -1.5
+func isNumber(cell string) bool {
+	digits := strings.TrimLeft(cell, "+-")
+	if digits == "" || len(cell)-len(digits) > 1 {
+		return false
+	}
+	return strings.Trim(digits, "0123456789.") == "" && strings.Count(digits, ".") <= 1 && digits != "."
+}
@@ -0,0 +1,144 @@
+// Package tasks holds the task queue handlers of the report module
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"clean-arch-gin/internal/adapters/report/renderers"
+	reportEntities "clean-arch-gin/internal/domain/report/entities"
+	reportEvents "clean-arch-gin/internal/domain/report/events"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/reports"
+	"clean-arch-gin/internal/domain/shared/storage"
+	"clean-arch-gin/internal/infrastructure/retry"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+)
+
+// GenerateTaskType is the task type of a report generated in the background
+const GenerateTaskType = "reports.generate"
+
+// generateShare is the share of a job's progress spent generating the report; the rest is
+// rendering and storing it
+const generateShare = 90
+
+// Phases reported by report jobs while they run
+const (
+	PhaseGenerating = "generating"
+	PhaseRendering  = "rendering"
+	PhaseStoring    = "storing"
+)
+
+// GeneratePayload is a queued report request
+type GeneratePayload struct {
+	Type        string                `json:"type"`
+	Params      reports.Params        `json:"params,omitempty"`
+	Format      reportEntities.Format `json:"format"`
+	RequesterID uint                  `json:"requester_id"`
+}
+
+// GenerateResult is the result of a report task
+type GenerateResult struct {
+	Type        string                `json:"type"`
+	Format      reportEntities.Format `json:"format"`
+	Rows        int                   `json:"rows"`
+	DownloadURL string                `json:"download_url"`
+	ExpiresAt   time.Time             `json:"expires_at"`
+}
+
+// NewGenerateHandler runs queued reports: the generator of the report's type builds it, it is
+// rendered to a temporary file, stored in files, and a ReportReadyEvent carrying a signed
+// download URL is published on publisher, when it is not nil, for the requester to be
+// notified; the URL is also kept as the task's result. Unknown types, invalid parameters and
+// formats fail permanently
+// A retry rewrites the same key, so a run that failed after storing the file is harmless
+func NewGenerateHandler(generators map[string]reports.Generator, renderer *renderers.Renderer, files storage.Storage,
+	publisher events.EventPublisher) taskqueue.Handler {
+	return func(ctx context.Context, task *taskqueue.Task) error {
+		var payload GeneratePayload
+		if err := task.Decode(&payload); err != nil {
+			return retry.Permanent(err)
+		}
+		generator, ok := generators[payload.Type]
+		if !ok {
+			return retry.Permanent(reportEntities.ErrUnknownReportType)
+		}
+		if !payload.Format.Valid() {
+			return retry.Permanent(reportEntities.ErrUnsupportedFormat)
+		}
+		if err := generator.Validate(payload.Params); err != nil {
+			return retry.Permanent(err)
+		}
+
+		taskqueue.ReportPhase(ctx, PhaseGenerating)
+		doc, err := generator.Generate(ctx, payload.Params, func(percent int) {
+			taskqueue.ReportProgress(ctx, min(max(percent, 0), 100)*generateShare/100)
+		})
+		if err != nil {
+			var domainErr sharedEntities.DomainError
+			if errors.As(err, &domainErr) {
+				return retry.Permanent(err)
+			}
+			return err
+		}
+
+		tmp, err := os.CreateTemp("", "report-*")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+
+		taskqueue.ReportPhase(ctx, PhaseRendering)
+		if err := renderer.Render(tmp, payload.Format, doc); err != nil {
+			return err
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+
+		key := fmt.Sprintf("reports/%s-%d.%s", payload.Type, task.ID, payload.Format)
+		taskqueue.ReportPhase(ctx, PhaseStoring)
+		if err := files.Put(ctx, key, payload.Format.ContentType(), tmp); err != nil {
+			return err
+		}
+		expiresAt := time.Now().Add(reportEntities.ReportURLTTL).Truncate(time.Second)
+		url, err := files.SignedURL(key, expiresAt)
+		if err != nil {
+			return err
+		}
+
+		report := reportEntities.Report{
+			Type:        payload.Type,
+			Format:      payload.Format,
+			Rows:        len(doc.Rows),
+			DownloadURL: url,
+			ExpiresAt:   expiresAt,
+		}
+		publishReady(ctx, publisher, task.ID, payload, report)
+		return taskqueue.SetResult(ctx, GenerateResult{
+			Type:        report.Type,
+			Format:      report.Format,
+			Rows:        report.Rows,
+			DownloadURL: report.DownloadURL,
+			ExpiresAt:   report.ExpiresAt,
+		})
+	}
+}
+
+// publishReady announces the report so the requester is told where to download it
+// A lost event does not fail the report: the URL is also the task's result
+func publishReady(ctx context.Context, publisher events.EventPublisher, taskID uint, payload GeneratePayload, report reportEntities.Report) {
+	if publisher == nil || payload.RequesterID == 0 {
+		return
+	}
+	if err := publisher.Publish(ctx, reportEvents.NewReportReadyEvent(taskID, payload.RequesterID, report)); err != nil {
+		log.Printf("failed to publish %s for job %d: %v", reportEvents.ReportReadyEventName, taskID, err)
+	}
+}
@@ -1,13 +1,25 @@
+// Package repositories holds the user repository of the legacy flat router
+//
+// Deprecated: use the user module's repositories in internal/adapters/user/repositories
 package repositories
 
 import (
+	"context"
+	"time"
+
 	"clean-arch-gin/internal/adapters/models"
+	"clean-arch-gin/internal/domain/shared/pagination"
+	"clean-arch-gin/internal/domain/shared/publicid"
 	userEntities "clean-arch-gin/internal/domain/user/entities"
 	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/database"
 
 	"gorm.io/gorm"
 )
 
+// purgeBatchSize is how many users are purged per transaction
+const purgeBatchSize = 500
+
 // userRepository implements UserRepository interface using traditional GORM
 type userRepository struct {
 	db *gorm.DB
@@ -18,10 +30,13 @@ func NewUserRepository(db *gorm.DB) userRepositories.UserRepository {
 	return &userRepository{db: db}
 }
 
-// Create creates a new user in the database
-func (r *userRepository) Create(user *userEntities.User) error {
+// Create creates a new user in the database; an email already taken is ErrEmailExists
+func (r *userRepository) Create(ctx context.Context, user *userEntities.User) error {
 	userModel := models.NewUserModelFromEntity(user)
-	if err := r.db.Create(userModel).Error; err != nil {
+	if err := r.db.WithContext(ctx).Create(userModel).Error; err != nil {
+		if database.IsDuplicateKey(r.db, err) {
+			return userEntities.ErrEmailExists
+		}
 		return err
 	}
 	user.ID = userModel.ID
@@ -29,9 +44,41 @@ func (r *userRepository) Create(user *userEntities.User) error {
 }
 
 // GetByID retrieves a user by ID
-func (r *userRepository) GetByID(id uint) (*userEntities.User, error) {
+func (r *userRepository) GetByID(ctx context.Context, id uint) (*userEntities.User, error) {
 	var userModel models.UserModel
-	err := r.db.First(&userModel, id).Error
+	err := r.db.WithContext(ctx).First(&userModel, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, userEntities.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return userModel.ToDomainEntity(), nil
+}
+
+// GetByIDs retrieves the users with the given IDs in a single query
+func (r *userRepository) GetByIDs(ctx context.Context, ids []uint) ([]*userEntities.User, error) {
+	if len(ids) == 0 {
+		return []*userEntities.User{}, nil
+	}
+
+	var userModels []models.UserModel
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&userModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*userEntities.User, len(userModels))
+	for i, model := range userModels {
+		users[i] = model.ToDomainEntity()
+	}
+	return users, nil
+}
+
+// GetByPublicID retrieves a user, deleted or not, by public ID
+func (r *userRepository) GetByPublicID(ctx context.Context, publicID string) (*userEntities.User, error) {
+	var userModel models.UserModel
+	err := r.db.WithContext(ctx).Unscoped().Where("public_id = ?", publicID).First(&userModel).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, userEntities.ErrUserNotFound
@@ -42,9 +89,9 @@ func (r *userRepository) GetByID(id uint) (*userEntities.User, error) {
 }
 
 // GetByEmail retrieves a user by email
-func (r *userRepository) GetByEmail(email string) (*userEntities.User, error) {
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*userEntities.User, error) {
 	var userModel models.UserModel
-	err := r.db.Where("email = ?", email).First(&userModel).Error
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&userModel).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, userEntities.ErrUserNotFound
@@ -55,9 +102,24 @@ func (r *userRepository) GetByEmail(email string) (*userEntities.User, error) {
 }
 
 // GetAll retrieves all users with pagination
-func (r *userRepository) GetAll(limit, offset int) ([]*userEntities.User, error) {
+func (r *userRepository) GetAll(ctx context.Context, limit, offset int) ([]*userEntities.User, error) {
+	var userModels []models.UserModel
+	err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&userModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*userEntities.User, len(userModels))
+	for i, model := range userModels {
+		users[i] = model.ToDomainEntity()
+	}
+	return users, nil
+}
+
+// ListAfter retrieves the users after cursor in created_at, id order
+func (r *userRepository) ListAfter(ctx context.Context, cursor pagination.Cursor, limit int) ([]*userEntities.User, error) {
 	var userModels []models.UserModel
-	err := r.db.Limit(limit).Offset(offset).Find(&userModels).Error
+	err := database.PageAfter(r.db.WithContext(ctx), cursor, limit).Find(&userModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -69,28 +131,143 @@ func (r *userRepository) GetAll(limit, offset int) ([]*userEntities.User, error)
 	return users, nil
 }
 
-// Update updates an existing user
-func (r *userRepository) Update(user *userEntities.User) error {
+// Update updates an existing user; an email already taken is ErrEmailExists
+func (r *userRepository) Update(ctx context.Context, user *userEntities.User) error {
 	userModel := models.NewUserModelFromEntity(user)
-	return r.db.Save(userModel).Error
+	if err := r.db.WithContext(ctx).Save(userModel).Error; err != nil {
+		if database.IsDuplicateKey(r.db, err) {
+			return userEntities.ErrEmailExists
+		}
+		return err
+	}
+	return nil
 }
 
 // Delete soft deletes a user by ID
-func (r *userRepository) Delete(id uint) error {
-	return r.db.Delete(&models.UserModel{}, id).Error
+func (r *userRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.UserModel{}, id).Error
 }
 
 // Count returns the total number of users
-func (r *userRepository) Count() (int64, error) {
+func (r *userRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.UserModel{}).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&models.UserModel{}).Count(&count).Error
 	return count, err
 }
 
+// CreateInBatches inserts the users size at a time, one statement per batch
+func (r *userRepository) CreateInBatches(ctx context.Context, users []*userEntities.User, size int) error {
+	return database.WriteInBatches(len(users), size, func(from, to int) error {
+		userModels := make([]*models.UserModel, 0, to-from)
+		for _, user := range users[from:to] {
+			userModels = append(userModels, models.NewUserModelFromEntity(user))
+		}
+		if err := r.db.WithContext(ctx).CreateInBatches(userModels, len(userModels)).Error; err != nil {
+			return err
+		}
+		for i, userModel := range userModels {
+			users[from+i].ID = userModel.ID
+		}
+		return nil
+	}, func(i int) error {
+		return r.Create(ctx, users[i])
+	})
+}
+
+// UpdateInBatches saves the users size at a time, one transaction per batch
+func (r *userRepository) UpdateInBatches(ctx context.Context, users []*userEntities.User, size int) error {
+	return database.WriteInBatches(len(users), size, func(from, to int) error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, user := range users[from:to] {
+				if err := tx.Save(models.NewUserModelFromEntity(user)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}, func(i int) error {
+		return r.Update(ctx, users[i])
+	})
+}
+
+// Upsert inserts or updates the user keyed by email in one statement, then reads back the
+// stored row since MySQL does not return the ID of an updated one
+func (r *userRepository) Upsert(ctx context.Context, user *userEntities.User) (bool, error) {
+	if user.PublicID == "" {
+		user.PublicID = publicid.New()
+	}
+	userModel := models.NewUserModelFromEntity(user)
+	userModel.ID = 0
+
+	var stored models.UserModel
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(database.OnConflictUpdate([]string{"email"}, []string{"name", "password", "updated_at"})).Create(userModel).Error
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("email = ?", userModel.Email).First(&stored).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	created := stored.PublicID != nil && *stored.PublicID == user.PublicID
+	user.ID = stored.ID
+	if stored.PublicID != nil {
+		user.PublicID = *stored.PublicID
+	}
+	user.CreatedAt = stored.CreatedAt
+	return created, nil
+}
+
+// GetByIDIncludingDeleted retrieves a user by ID, soft deleted or not
+func (r *userRepository) GetByIDIncludingDeleted(ctx context.Context, id uint) (*userEntities.User, error) {
+	var userModel models.UserModel
+	err := r.db.WithContext(ctx).Unscoped().First(&userModel, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, userEntities.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return userModel.ToDomainEntity(), nil
+}
+
+// Restore clears the deletion time of a soft-deleted user
+func (r *userRepository) Restore(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&models.UserModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return userEntities.ErrUserNotFound
+	}
+	return nil
+}
+
+// Purge permanently deletes a user; this layout stores nothing else per user
+func (r *userRepository) Purge(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Delete(&models.UserModel{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return userEntities.ErrUserNotFound
+	}
+	return nil
+}
+
+// PurgeOlderThan permanently deletes the users soft deleted more than age ago
+func (r *userRepository) PurgeOlderThan(ctx context.Context, age time.Duration) (int64, error) {
+	return database.PurgeSoftDeleted(ctx, r.db, &models.UserModel{}, time.Now().Add(-age), purgeBatchSize, nil)
+}
+
 // GetUsersByEmailDomain gets users by email domain (traditional implementation)
-func (r *userRepository) GetUsersByEmailDomain(domain string) ([]*userEntities.User, error) {
+func (r *userRepository) GetUsersByEmailDomain(ctx context.Context, domain string) ([]*userEntities.User, error) {
 	var userModels []models.UserModel
-	err := r.db.Where("email LIKE ?", "%"+domain).Find(&userModels).Error
+	err := r.db.WithContext(ctx).Where("email LIKE ?", "%"+domain).Find(&userModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -103,9 +280,9 @@ func (r *userRepository) GetUsersByEmailDomain(domain string) ([]*userEntities.U
 }
 
 // GetActiveUsers gets all non-deleted users (traditional implementation)
-func (r *userRepository) GetActiveUsers() ([]*userEntities.User, error) {
+func (r *userRepository) GetActiveUsers(ctx context.Context) ([]*userEntities.User, error) {
 	var userModels []models.UserModel
-	err := r.db.Where("deleted_at IS NULL").Find(&userModels).Error
+	err := r.db.WithContext(ctx).Where("deleted_at IS NULL").Find(&userModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -118,9 +295,9 @@ func (r *userRepository) GetActiveUsers() ([]*userEntities.User, error) {
 }
 
 // GetUsersWithFilters gets users with complex filtering (traditional implementation)
-func (r *userRepository) GetUsersWithFilters(limit, offset int, email, name string) ([]*userEntities.User, error) {
+func (r *userRepository) GetUsersWithFilters(ctx context.Context, limit, offset int, email, name string) ([]*userEntities.User, error) {
 	var userModels []models.UserModel
-	query := r.db.Model(&models.UserModel{})
+	query := r.db.WithContext(ctx).Model(&models.UserModel{})
 
 	if email != "" {
 		query = query.Where("email LIKE ?", "%"+email+"%")
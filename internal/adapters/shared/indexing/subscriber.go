@@ -0,0 +1,89 @@
+// Package indexing keeps the documents of search indexes up to date from domain events
+package indexing
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/search"
+)
+
+// Target maps an event to the IDs of the records it changed; none means it changed nothing
+// indexed
+type Target func(event events.DomainEvent) []uint
+
+// Subscriber reindexes the records the events of its index change, reading them from the
+// index's source and deleting those that are gone. Failures are logged: a stale document
+// never fails the operation that published the event, and is fixed by its next change or a
+// reindex
+type Subscriber struct {
+	indexer search.Indexer
+	index   search.Index
+
+	mu      sync.RWMutex
+	targets map[string]Target
+}
+
+// NewSubscriber creates a subscriber keeping index up to date through indexer
+func NewSubscriber(indexer search.Indexer, index search.Index) *Subscriber {
+	return &Subscriber{
+		indexer: indexer,
+		index:   index,
+		targets: make(map[string]Target),
+	}
+}
+
+// Register sets the target of an event, replacing any previous one
+// Call it before Subscribe; events registered later are not subscribed to
+func (s *Subscriber) Register(eventName string, target Target) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.targets[eventName] = target
+}
+
+// Subscribe listens for every event with a target and returns the unsubscribe function
+func (s *Subscriber) Subscribe(subscriber events.EventSubscriber) func() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	unsubscribes := make([]func(), 0, len(s.targets))
+	for eventName := range s.targets {
+		unsubscribes = append(unsubscribes, subscriber.Subscribe(eventName, s.HandleEvent))
+	}
+	return func() {
+		for _, unsubscribe := range unsubscribes {
+			unsubscribe()
+		}
+	}
+}
+
+// HandleEvent reindexes the records targeted by event in the tenant of ctx
+func (s *Subscriber) HandleEvent(ctx context.Context, event events.DomainEvent) {
+	s.mu.RLock()
+	target, ok := s.targets[event.EventName()]
+	s.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	for _, id := range target(event) {
+		if err := s.Reindex(ctx, id); err != nil {
+			log.Printf("search: failed to reindex %s %d after %s: %v", s.index.Name, id, event.EventName(), err)
+		}
+	}
+}
+
+// Reindex indexes the record id as it is now, or deletes its document when it is gone
+func (s *Subscriber) Reindex(ctx context.Context, id uint) error {
+	doc, err := s.index.Source.Document(ctx, id)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		return s.indexer.Delete(ctx, s.index.Name, strconv.FormatUint(uint64(id), 10))
+	}
+	return s.indexer.Index(ctx, s.index.Name, *doc)
+}
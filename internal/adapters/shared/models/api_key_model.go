@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	apikeyEntities "clean-arch-gin/internal/domain/apikey/entities"
+)
+
+// APIKeyModel represents the GORM model of API keys
+// The key hash index serves the lookup on every request made with a key; revoked keys are
+// kept so their owners still see them listed
+type APIKeyModel struct {
+	ID uint `gorm:"primaryKey;autoIncrement"`
+	// PublicID is the key's identifier in routes; nil on rows written before it existed until
+	// the module assigns one at migration
+	PublicID      *string   `gorm:"size:36;uniqueIndex" json:"public_id"`
+	TenantID      uint      `gorm:"not null;default:1;index"`
+	OwnerID       uint      `gorm:"not null;index"`
+	Name          string    `gorm:"not null;size:100"`
+	Display       string    `gorm:"not null;size:16"`
+	KeyHash       string    `gorm:"not null;size:64;uniqueIndex"`
+	RatePerMinute int       `gorm:"not null;default:0"`
+	MonthlyQuota  int64     `gorm:"not null;default:0"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	LastUsedAt    *time.Time
+	RevokedAt     *time.Time
+}
+
+// TableName sets the table name for GORM
+func (APIKeyModel) TableName() string {
+	return "api_keys"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *APIKeyModel) ToDomainEntity() *apikeyEntities.APIKey {
+	return &apikeyEntities.APIKey{
+		ID:       m.ID,
+		PublicID: fromPublicID(m.PublicID),
+		OwnerID:  m.OwnerID,
+		Name:     m.Name,
+		Display:  m.Display,
+		KeyHash:  m.KeyHash,
+		Limits: apikeyEntities.Limits{
+			RatePerMinute: m.RatePerMinute,
+			MonthlyQuota:  m.MonthlyQuota,
+		},
+		CreatedAt:  m.CreatedAt,
+		LastUsedAt: m.LastUsedAt,
+		RevokedAt:  m.RevokedAt,
+	}
+}
+
+// NewAPIKeyModelFromEntity creates GORM model from domain entity
+func NewAPIKeyModelFromEntity(key *apikeyEntities.APIKey) *APIKeyModel {
+	return &APIKeyModel{
+		ID:            key.ID,
+		PublicID:      toPublicID(key.PublicID),
+		OwnerID:       key.OwnerID,
+		Name:          key.Name,
+		Display:       key.Display,
+		KeyHash:       key.KeyHash,
+		RatePerMinute: key.Limits.RatePerMinute,
+		MonthlyQuota:  key.Limits.MonthlyQuota,
+		CreatedAt:     key.CreatedAt,
+		LastUsedAt:    key.LastUsedAt,
+		RevokedAt:     key.RevokedAt,
+	}
+}
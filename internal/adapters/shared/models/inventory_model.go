@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+)
+
+// StockLevelModel represents the GORM model of product stock levels
+// Rows are locked while reservations change them; a product has one per tenant
+type StockLevelModel struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	TenantID  uint      `gorm:"not null;default:1;uniqueIndex:idx_stock_levels_tenant_product,priority:1"`
+	ProductID uint      `gorm:"not null;uniqueIndex:idx_stock_levels_tenant_product,priority:2"`
+	OnHand    int       `gorm:"not null;default:0"`
+	Reserved  int       `gorm:"not null;default:0"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName sets the table name for GORM
+func (StockLevelModel) TableName() string {
+	return "stock_levels"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *StockLevelModel) ToDomainEntity() *orderEntities.StockLevel {
+	return &orderEntities.StockLevel{
+		ProductID: m.ProductID,
+		OnHand:    m.OnHand,
+		Reserved:  m.Reserved,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// StockReservationModel represents the GORM model of the units held for orders
+// An order reserves each product once, so a repeated confirmation fails on the unique index
+type StockReservationModel struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	TenantID  uint      `gorm:"not null;default:1;index"`
+	OrderID   uint      `gorm:"not null;uniqueIndex:idx_stock_reservations_order_product,priority:1"`
+	ProductID uint      `gorm:"not null;uniqueIndex:idx_stock_reservations_order_product,priority:2"`
+	Quantity  int       `gorm:"not null"`
+	Status    string    `gorm:"not null;size:16;index:idx_stock_reservations_status_expiry,priority:1"`
+	ExpiresAt time.Time `gorm:"not null;index:idx_stock_reservations_status_expiry,priority:2"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName sets the table name for GORM
+func (StockReservationModel) TableName() string {
+	return "stock_reservations"
+}
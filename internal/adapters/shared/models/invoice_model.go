@@ -0,0 +1,110 @@
+package models
+
+import (
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	"clean-arch-gin/internal/domain/shared/money"
+)
+
+// InvoiceModel represents the GORM model for invoices
+type InvoiceModel struct {
+	ID             uint               `gorm:"primaryKey;autoIncrement"`
+	TenantID       uint               `gorm:"not null;default:1;uniqueIndex:idx_invoices_tenant_sequence,priority:1"`
+	Sequence       uint               `gorm:"not null;uniqueIndex:idx_invoices_tenant_sequence,priority:2"`
+	OrderID        uint               `gorm:"not null;uniqueIndex"`
+	UserID         uint               `gorm:"not null;index"`
+	Total          float64            `gorm:"not null"`
+	TaxAmount      float64            `gorm:"not null;default:0"`
+	ShippingAmount float64            `gorm:"not null;default:0"`
+	Currency       string             `gorm:"not null;size:3;default:USD"`
+	Lines          []InvoiceLineModel `gorm:"foreignKey:InvoiceID;constraint:OnDelete:CASCADE"`
+	IssuedAt       time.Time          `gorm:"not null"`
+}
+
+// TableName sets the table name for GORM
+func (InvoiceModel) TableName() string {
+	return "invoices"
+}
+
+// InvoiceLineModel represents the GORM model for invoice lines
+type InvoiceLineModel struct {
+	ID        uint    `gorm:"primaryKey;autoIncrement"`
+	TenantID  uint    `gorm:"not null;default:1;index"`
+	InvoiceID uint    `gorm:"not null;index"`
+	ProductID uint    `gorm:"not null"`
+	Quantity  int     `gorm:"not null"`
+	UnitPrice float64 `gorm:"not null"`
+	Amount    float64 `gorm:"not null"`
+}
+
+// TableName sets the table name for GORM
+func (InvoiceLineModel) TableName() string {
+	return "invoice_lines"
+}
+
+// InvoiceSequenceModel is the next invoice number of a tenant
+// The row is locked while an invoice takes its number, so numbers are never skipped nor reused
+type InvoiceSequenceModel struct {
+	ID       uint `gorm:"primaryKey;autoIncrement"`
+	TenantID uint `gorm:"not null;default:1;uniqueIndex"`
+	Next     uint `gorm:"not null;default:1"`
+}
+
+// TableName sets the table name for GORM
+func (InvoiceSequenceModel) TableName() string {
+	return "invoice_sequences"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *InvoiceModel) ToDomainEntity() *orderEntities.Invoice {
+	lines := make([]*orderEntities.InvoiceLine, len(m.Lines))
+	for i, line := range m.Lines {
+		lines[i] = &orderEntities.InvoiceLine{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+			Amount:    line.Amount,
+		}
+	}
+
+	return &orderEntities.Invoice{
+		ID:             m.ID,
+		Sequence:       m.Sequence,
+		OrderID:        m.OrderID,
+		UserID:         m.UserID,
+		Lines:          lines,
+		Total:          m.Total,
+		TaxAmount:      m.TaxAmount,
+		ShippingAmount: m.ShippingAmount,
+		Currency:       money.Currency(m.Currency),
+		IssuedAt:       m.IssuedAt,
+	}
+}
+
+// NewInvoiceModelFromEntity creates GORM model from domain entity
+func NewInvoiceModelFromEntity(invoice *orderEntities.Invoice) *InvoiceModel {
+	lines := make([]InvoiceLineModel, len(invoice.Lines))
+	for i, line := range invoice.Lines {
+		lines[i] = InvoiceLineModel{
+			InvoiceID: invoice.ID,
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+			Amount:    line.Amount,
+		}
+	}
+
+	return &InvoiceModel{
+		ID:             invoice.ID,
+		Sequence:       invoice.Sequence,
+		OrderID:        invoice.OrderID,
+		UserID:         invoice.UserID,
+		Total:          invoice.Total,
+		TaxAmount:      invoice.TaxAmount,
+		ShippingAmount: invoice.ShippingAmount,
+		Currency:       string(invoice.Currency),
+		Lines:          lines,
+		IssuedAt:       invoice.IssuedAt,
+	}
+}
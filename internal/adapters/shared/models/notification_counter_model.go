@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// NotificationCounterModel keeps the counts of a user's inbox, one row per user, changed in
+// the transaction of every notification written so reading them needs no aggregate
+type NotificationCounterModel struct {
+	UserID    uint      `gorm:"primaryKey;autoIncrement:false"`
+	TenantID  uint      `gorm:"not null;default:1;index"`
+	Total     int64     `gorm:"not null;default:0"`
+	Unread    int64     `gorm:"not null;default:0"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName sets the table name for GORM
+func (NotificationCounterModel) TableName() string {
+	return "notification_counters"
+}
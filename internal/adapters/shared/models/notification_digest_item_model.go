@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// NotificationDigestItemModel represents the GORM model for the notifications waiting for
+// a user's digest; rows are deleted once the digest is sent
+type NotificationDigestItemModel struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	TenantID  uint      `gorm:"not null;default:1;index"`
+	UserID    uint      `gorm:"not null;index"`
+	Type      string    `gorm:"not null;size:128"`
+	Title     string    `gorm:"not null;size:255"`
+	Body      string    `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName sets the table name for GORM
+func (NotificationDigestItemModel) TableName() string {
+	return "notification_digest_items"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *NotificationDigestItemModel) ToDomainEntity() *userEntities.DigestItem {
+	return &userEntities.DigestItem{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Type:      m.Type,
+		Title:     m.Title,
+		Body:      m.Body,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// NewNotificationDigestItemModelFromEntity creates GORM model from domain entity
+func NewNotificationDigestItemModelFromEntity(item *userEntities.DigestItem) *NotificationDigestItemModel {
+	return &NotificationDigestItemModel{
+		ID:        item.ID,
+		UserID:    item.UserID,
+		Type:      item.Type,
+		Title:     item.Title,
+		Body:      item.Body,
+		CreatedAt: item.CreatedAt,
+	}
+}
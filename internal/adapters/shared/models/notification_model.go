@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// NotificationModel represents the GORM model for user notifications
+// The composite indexes serve the unread notifications of a user and the pages of their inbox
+type NotificationModel struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement;index:idx_notifications_user_created,priority:3"`
+	TenantID  uint       `gorm:"not null;default:1;index"`
+	UserID    uint       `gorm:"not null;index:idx_notifications_user,priority:1;index:idx_notifications_user_created,priority:1"`
+	Type      string     `gorm:"not null;size:128"`
+	Title     string     `gorm:"not null;size:255"`
+	Body      string     `gorm:"type:text"`
+	Data      string     `gorm:"type:text"`
+	ReadAt    *time.Time `gorm:"index:idx_notifications_user,priority:2"`
+	CreatedAt time.Time  `gorm:"autoCreateTime;index:idx_notifications_user_created,priority:2"`
+}
+
+// TableName sets the table name for GORM
+func (NotificationModel) TableName() string {
+	return "notifications"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *NotificationModel) ToDomainEntity() *userEntities.Notification {
+	var data map[string]interface{}
+	if m.Data != "" {
+		// Data is always written by NewNotificationModelFromEntity, so it decodes
+		_ = json.Unmarshal([]byte(m.Data), &data)
+	}
+
+	return &userEntities.Notification{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Type:      m.Type,
+		Title:     m.Title,
+		Body:      m.Body,
+		Data:      data,
+		ReadAt:    m.ReadAt,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// NewNotificationModelFromEntity creates GORM model from domain entity
+func NewNotificationModelFromEntity(notification *userEntities.Notification) (*NotificationModel, error) {
+	model := &NotificationModel{
+		ID:        notification.ID,
+		UserID:    notification.UserID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+	if len(notification.Data) > 0 {
+		data, err := json.Marshal(notification.Data)
+		if err != nil {
+			return nil, err
+		}
+		model.Data = string(data)
+	}
+	return model, nil
+}
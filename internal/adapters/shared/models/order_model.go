@@ -0,0 +1,121 @@
+package models
+
+import (
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	"clean-arch-gin/internal/domain/shared/money"
+
+	"gorm.io/gorm"
+)
+
+// OrderModel represents the GORM model for orders
+type OrderModel struct {
+	ID             uint             `gorm:"primaryKey;autoIncrement;index:idx_orders_created_at_id,priority:2" json:"id"`
+	PublicID       *string          `gorm:"size:36;uniqueIndex" json:"public_id"`
+	TenantID       uint             `gorm:"not null;default:1;index" json:"tenant_id"`
+	UserID         uint             `gorm:"not null;index" json:"user_id"`
+	Status         string           `gorm:"not null;size:32;index" json:"status"`
+	TaxAmount      float64          `gorm:"not null;default:0" json:"tax_amount"`
+	ShippingAmount float64          `gorm:"not null;default:0" json:"shipping_amount"`
+	TotalAmount    float64          `gorm:"not null" json:"total_amount"`
+	Currency       string           `gorm:"not null;size:3;default:USD" json:"currency"`
+	Items          []OrderItemModel `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"items"`
+	CreatedAt      time.Time        `gorm:"autoCreateTime;index:idx_orders_created_at_id,priority:1" json:"created_at"`
+	UpdatedAt      time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt   `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// TableName sets the table name for GORM
+func (OrderModel) TableName() string {
+	return "orders"
+}
+
+// OrderItemModel represents the GORM model for order items
+type OrderItemModel struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID  uint      `gorm:"not null;default:1;index" json:"tenant_id"`
+	OrderID   uint      `gorm:"not null;index" json:"order_id"`
+	ProductID uint      `gorm:"not null" json:"product_id"`
+	Quantity  int       `gorm:"not null" json:"quantity"`
+	Price     float64   `gorm:"not null" json:"price"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName sets the table name for GORM
+func (OrderItemModel) TableName() string {
+	return "order_items"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (o *OrderModel) ToDomainEntity() *orderEntities.Order {
+	var deletedAt *time.Time
+	if o.DeletedAt.Valid {
+		deletedAt = &o.DeletedAt.Time
+	}
+
+	items := make([]*orderEntities.OrderItem, len(o.Items))
+	for i, item := range o.Items {
+		items[i] = &orderEntities.OrderItem{
+			ID:        item.ID,
+			OrderID:   item.OrderID,
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+			CreatedAt: item.CreatedAt,
+		}
+	}
+
+	return &orderEntities.Order{
+		ID:             o.ID,
+		PublicID:       fromPublicID(o.PublicID),
+		UserID:         o.UserID,
+		Status:         orderEntities.OrderStatus(o.Status),
+		TaxAmount:      o.TaxAmount,
+		ShippingAmount: o.ShippingAmount,
+		TotalAmount:    o.TotalAmount,
+		Currency:       money.Currency(o.Currency),
+		Items:          items,
+		CreatedAt:      o.CreatedAt,
+		UpdatedAt:      o.UpdatedAt,
+		DeletedAt:      deletedAt,
+	}
+}
+
+// NewOrderModelFromEntity creates GORM model from domain entity
+func NewOrderModelFromEntity(order *orderEntities.Order) *OrderModel {
+	items := make([]OrderItemModel, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = OrderItemModel{
+			ID:        item.ID,
+			OrderID:   order.ID,
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+			CreatedAt: item.CreatedAt,
+		}
+	}
+
+	orderModel := &OrderModel{
+		ID:             order.ID,
+		PublicID:       toPublicID(order.PublicID),
+		UserID:         order.UserID,
+		Status:         string(order.Status),
+		TaxAmount:      order.TaxAmount,
+		ShippingAmount: order.ShippingAmount,
+		TotalAmount:    order.TotalAmount,
+		Currency:       string(order.Currency),
+		Items:          items,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
+	}
+
+	if order.DeletedAt != nil {
+		orderModel.DeletedAt = gorm.DeletedAt{
+			Time:  *order.DeletedAt,
+			Valid: true,
+		}
+	}
+
+	return orderModel
+}
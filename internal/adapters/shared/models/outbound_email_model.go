@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	mailEntities "clean-arch-gin/internal/domain/mail/entities"
+)
+
+// OutboundEmailModel represents the GORM model of the emails sent through the task queue
+// The recipient and body are encrypted at rest like users' emails; recipients are looked
+// up by their blind index, and bodies are emptied once sent
+type OutboundEmailModel struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement"`
+	TenantID      uint      `gorm:"not null;default:1;index"`
+	TaskID        uint      `gorm:"index"`
+	Recipient     string    `gorm:"not null;size:1024;serializer:encrypted"`
+	RecipientHash string    `gorm:"not null;size:80;index"`
+	Subject       string    `gorm:"not null;size:998"`
+	Text          string    `gorm:"type:text;serializer:encrypted"`
+	HTML          string    `gorm:"type:text;serializer:encrypted"`
+	Status        string    `gorm:"not null;size:32;index"`
+	Attempts      int       `gorm:"not null;default:0"`
+	LastError     string    `gorm:"size:1024"`
+	CreatedAt     time.Time `gorm:"autoCreateTime;index"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+	SentAt        *time.Time
+}
+
+// TableName sets the table name for GORM
+func (OutboundEmailModel) TableName() string {
+	return "outbound_emails"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *OutboundEmailModel) ToDomainEntity() *mailEntities.Email {
+	return &mailEntities.Email{
+		ID:        m.ID,
+		TaskID:    m.TaskID,
+		Recipient: m.Recipient,
+		Subject:   m.Subject,
+		Text:      m.Text,
+		HTML:      m.HTML,
+		Status:    mailEntities.EmailStatus(m.Status),
+		Attempts:  m.Attempts,
+		LastError: m.LastError,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		SentAt:    m.SentAt,
+	}
+}
+
+// NewOutboundEmailModelFromEntity creates GORM model from domain entity
+func NewOutboundEmailModelFromEntity(email *mailEntities.Email) *OutboundEmailModel {
+	return &OutboundEmailModel{
+		ID:            email.ID,
+		TaskID:        email.TaskID,
+		Recipient:     email.Recipient,
+		RecipientHash: EmailIndex(email.Recipient),
+		Subject:       email.Subject,
+		Text:          email.Text,
+		HTML:          email.HTML,
+		Status:        string(email.Status),
+		Attempts:      email.Attempts,
+		LastError:     email.LastError,
+		CreatedAt:     email.CreatedAt,
+		UpdatedAt:     email.UpdatedAt,
+		SentAt:        email.SentAt,
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+// toPublicID stores an entity's public ID, NULL when it has none yet so the unique index
+// allows any number of them
+func toPublicID(publicID string) *string {
+	if publicID == "" {
+		return nil
+	}
+	return &publicID
+}
+
+// fromPublicID reads a stored public ID, empty when none was assigned yet
+func fromPublicID(publicID *string) string {
+	if publicID == nil {
+		return ""
+	}
+	return *publicID
+}
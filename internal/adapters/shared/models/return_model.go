@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+)
+
+// ReturnModel represents the GORM model for order returns (RMAs)
+type ReturnModel struct {
+	ID              uint              `gorm:"primaryKey;autoIncrement"`
+	TenantID        uint              `gorm:"not null;default:1;index"`
+	OrderID         uint              `gorm:"not null;index"`
+	UserID          uint              `gorm:"not null;index"`
+	Status          string            `gorm:"not null;size:32;index"`
+	Reason          string            `gorm:"not null;size:1000"`
+	RefundAmount    float64           `gorm:"not null"`
+	RefundReference string            `gorm:"size:255"`
+	Note            string            `gorm:"size:1000"`
+	Items           []ReturnItemModel `gorm:"foreignKey:ReturnID;constraint:OnDelete:CASCADE"`
+	CreatedAt       time.Time         `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time         `gorm:"autoUpdateTime"`
+}
+
+// TableName sets the table name for GORM
+func (ReturnModel) TableName() string {
+	return "order_returns"
+}
+
+// ReturnItemModel represents the GORM model for the order items in a return
+type ReturnItemModel struct {
+	ID          uint    `gorm:"primaryKey;autoIncrement"`
+	TenantID    uint    `gorm:"not null;default:1;index"`
+	ReturnID    uint    `gorm:"not null;index"`
+	OrderItemID uint    `gorm:"not null;index"`
+	ProductID   uint    `gorm:"not null"`
+	Quantity    int     `gorm:"not null"`
+	Price       float64 `gorm:"not null"`
+}
+
+// TableName sets the table name for GORM
+func (ReturnItemModel) TableName() string {
+	return "order_return_items"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *ReturnModel) ToDomainEntity() *orderEntities.Return {
+	items := make([]*orderEntities.ReturnItem, len(m.Items))
+	for i, item := range m.Items {
+		items[i] = &orderEntities.ReturnItem{
+			ID:          item.ID,
+			ReturnID:    item.ReturnID,
+			OrderItemID: item.OrderItemID,
+			ProductID:   item.ProductID,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+		}
+	}
+
+	return &orderEntities.Return{
+		ID:              m.ID,
+		OrderID:         m.OrderID,
+		UserID:          m.UserID,
+		Status:          orderEntities.ReturnStatus(m.Status),
+		Reason:          m.Reason,
+		Items:           items,
+		RefundAmount:    m.RefundAmount,
+		RefundReference: m.RefundReference,
+		Note:            m.Note,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// NewReturnModelFromEntity creates GORM model from domain entity
+func NewReturnModelFromEntity(r *orderEntities.Return) *ReturnModel {
+	items := make([]ReturnItemModel, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = ReturnItemModel{
+			ID:          item.ID,
+			ReturnID:    r.ID,
+			OrderItemID: item.OrderItemID,
+			ProductID:   item.ProductID,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+		}
+	}
+
+	return &ReturnModel{
+		ID:              r.ID,
+		OrderID:         r.OrderID,
+		UserID:          r.UserID,
+		Status:          string(r.Status),
+		Reason:          r.Reason,
+		RefundAmount:    r.RefundAmount,
+		RefundReference: r.RefundReference,
+		Note:            r.Note,
+		Items:           items,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+	}
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+)
+
+// ShipmentModel represents the GORM model for order shipments
+type ShipmentModel struct {
+	ID             uint                `gorm:"primaryKey;autoIncrement"`
+	TenantID       uint                `gorm:"not null;default:1;index"`
+	OrderID        uint                `gorm:"not null;index"`
+	TrackingNumber string              `gorm:"not null;size:100"`
+	Carrier        string              `gorm:"size:100"`
+	Items          []ShipmentItemModel `gorm:"foreignKey:ShipmentID;constraint:OnDelete:CASCADE"`
+	CreatedAt      time.Time           `gorm:"autoCreateTime"`
+}
+
+// TableName sets the table name for GORM
+func (ShipmentModel) TableName() string {
+	return "shipments"
+}
+
+// ShipmentItemModel represents the GORM model for the order items in a shipment
+type ShipmentItemModel struct {
+	ID          uint `gorm:"primaryKey;autoIncrement"`
+	TenantID    uint `gorm:"not null;default:1;index"`
+	ShipmentID  uint `gorm:"not null;index"`
+	OrderItemID uint `gorm:"not null;index"`
+	Quantity    int  `gorm:"not null"`
+}
+
+// TableName sets the table name for GORM
+func (ShipmentItemModel) TableName() string {
+	return "shipment_items"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *ShipmentModel) ToDomainEntity() *orderEntities.Shipment {
+	items := make([]*orderEntities.ShipmentItem, len(m.Items))
+	for i, item := range m.Items {
+		items[i] = &orderEntities.ShipmentItem{
+			ID:          item.ID,
+			ShipmentID:  item.ShipmentID,
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	return &orderEntities.Shipment{
+		ID:             m.ID,
+		OrderID:        m.OrderID,
+		TrackingNumber: m.TrackingNumber,
+		Carrier:        m.Carrier,
+		Items:          items,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// NewShipmentModelFromEntity creates GORM model from domain entity
+func NewShipmentModelFromEntity(shipment *orderEntities.Shipment) *ShipmentModel {
+	items := make([]ShipmentItemModel, len(shipment.Items))
+	for i, item := range shipment.Items {
+		items[i] = ShipmentItemModel{
+			ID:          item.ID,
+			ShipmentID:  shipment.ID,
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	return &ShipmentModel{
+		ID:             shipment.ID,
+		OrderID:        shipment.OrderID,
+		TrackingNumber: shipment.TrackingNumber,
+		Carrier:        shipment.Carrier,
+		Items:          items,
+		CreatedAt:      shipment.CreatedAt,
+	}
+}
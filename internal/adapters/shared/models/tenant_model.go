@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/money"
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
+)
+
+// TenantModel represents the GORM model for tenants
+// Tenants are not themselves tenant-owned, so the table has no tenant_id
+type TenantModel struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Slug         string    `gorm:"uniqueIndex;not null;size:63" json:"slug"`
+	Name         string    `gorm:"not null;size:255" json:"name"`
+	Status       string    `gorm:"not null;size:32;default:active" json:"status"`
+	BaseCurrency string    `gorm:"not null;size:3;default:USD" json:"base_currency"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName sets the table name for GORM
+func (TenantModel) TableName() string {
+	return "tenants"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (t *TenantModel) ToDomainEntity() *tenantEntities.Tenant {
+	return &tenantEntities.Tenant{
+		ID:           t.ID,
+		Slug:         t.Slug,
+		Name:         t.Name,
+		Status:       tenantEntities.Status(t.Status),
+		BaseCurrency: money.Currency(t.BaseCurrency),
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+	}
+}
+
+// NewTenantModelFromEntity creates GORM model from domain entity
+func NewTenantModelFromEntity(tenant *tenantEntities.Tenant) *TenantModel {
+	return &TenantModel{
+		ID:           tenant.ID,
+		Slug:         tenant.Slug,
+		Name:         tenant.Name,
+		Status:       string(tenant.Status),
+		BaseCurrency: string(tenant.BaseCurrency),
+		CreatedAt:    tenant.CreatedAt,
+		UpdatedAt:    tenant.UpdatedAt,
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	meteringEntities "clean-arch-gin/internal/domain/metering/entities"
+)
+
+// UsageRecordModel represents the GORM model of usage records
+// Replicas add their counts to the row of a tenant, API key (0 for none), metric and period,
+// which the unique index identifies
+type UsageRecordModel struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	TenantID    uint      `gorm:"not null;default:1;uniqueIndex:idx_usage_records_period,priority:1"`
+	APIKeyID    uint      `gorm:"not null;default:0;uniqueIndex:idx_usage_records_period,priority:2"`
+	Metric      string    `gorm:"not null;size:64;uniqueIndex:idx_usage_records_period,priority:3"`
+	PeriodStart time.Time `gorm:"not null;uniqueIndex:idx_usage_records_period,priority:4;index"`
+	Quantity    int64     `gorm:"not null;default:0"`
+	Reported    int64     `gorm:"not null;default:0"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName sets the table name for GORM
+func (UsageRecordModel) TableName() string {
+	return "usage_records"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *UsageRecordModel) ToDomainEntity() *meteringEntities.UsageRecord {
+	return &meteringEntities.UsageRecord{
+		ID:          m.ID,
+		TenantID:    m.TenantID,
+		APIKeyID:    m.APIKeyID,
+		Metric:      m.Metric,
+		PeriodStart: m.PeriodStart,
+		Quantity:    m.Quantity,
+		Reported:    m.Reported,
+	}
+}
+
+// NewUsageRecordModelFromEntity creates GORM model from domain entity
+func NewUsageRecordModelFromEntity(record *meteringEntities.UsageRecord) *UsageRecordModel {
+	return &UsageRecordModel{
+		ID:          record.ID,
+		TenantID:    record.TenantID,
+		APIKeyID:    record.APIKeyID,
+		Metric:      record.Metric,
+		PeriodStart: record.PeriodStart,
+		Quantity:    record.Quantity,
+		Reported:    record.Reported,
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// UserAccountTokenModel represents the GORM model of the tokens mailed for password resets
+// and email verification
+// The email is encrypted at rest like the user's; rows are purged once expired
+type UserAccountTokenModel struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	TenantID  uint      `gorm:"not null;default:1;index"`
+	UserID    uint      `gorm:"not null;index"`
+	Purpose   string    `gorm:"not null;size:32"`
+	TokenHash string    `gorm:"not null;size:64;uniqueIndex"`
+	Email     string    `gorm:"not null;size:1024;serializer:encrypted"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	UsedAt    *time.Time
+}
+
+// TableName sets the table name for GORM
+func (UserAccountTokenModel) TableName() string {
+	return "user_account_tokens"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *UserAccountTokenModel) ToDomainEntity() *userEntities.AccountToken {
+	return &userEntities.AccountToken{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Purpose:   userEntities.AccountTokenPurpose(m.Purpose),
+		TokenHash: m.TokenHash,
+		Email:     m.Email,
+		CreatedAt: m.CreatedAt,
+		ExpiresAt: m.ExpiresAt,
+		UsedAt:    m.UsedAt,
+	}
+}
+
+// NewUserAccountTokenModelFromEntity creates GORM model from domain entity
+func NewUserAccountTokenModelFromEntity(token *userEntities.AccountToken) *UserAccountTokenModel {
+	return &UserAccountTokenModel{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		Purpose:   string(token.Purpose),
+		TokenHash: token.TokenHash,
+		Email:     token.Email,
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+		UsedAt:    token.UsedAt,
+	}
+}
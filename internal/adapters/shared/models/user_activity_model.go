@@ -0,0 +1,72 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// UserActivityModel represents the GORM model of user activity feeds
+// Rows are only ever inserted; the index serves the feed of one user
+type UserActivityModel struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	TenantID   uint      `gorm:"not null;default:1;index"`
+	UserID     uint      `gorm:"not null;index:idx_user_activities_user,priority:1"`
+	Type       string    `gorm:"not null;size:128"`
+	Summary    string    `gorm:"size:255"`
+	Subject    string    `gorm:"size:255"`
+	IPAddress  string    `gorm:"size:45"`
+	UserAgent  string    `gorm:"size:512"`
+	Metadata   string    `gorm:"type:text"`
+	OccurredAt time.Time `gorm:"not null;index:idx_user_activities_user,priority:2"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName sets the table name for GORM
+func (UserActivityModel) TableName() string {
+	return "user_activities"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *UserActivityModel) ToDomainEntity() *userEntities.Activity {
+	var metadata map[string]interface{}
+	if m.Metadata != "" {
+		// Metadata is always written by NewUserActivityModelFromEntity, so it decodes
+		_ = json.Unmarshal([]byte(m.Metadata), &metadata)
+	}
+
+	return &userEntities.Activity{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		Type:       m.Type,
+		Summary:    m.Summary,
+		Subject:    m.Subject,
+		IPAddress:  m.IPAddress,
+		UserAgent:  m.UserAgent,
+		Metadata:   metadata,
+		OccurredAt: m.OccurredAt,
+	}
+}
+
+// NewUserActivityModelFromEntity creates GORM model from domain entity
+func NewUserActivityModelFromEntity(activity *userEntities.Activity) (*UserActivityModel, error) {
+	model := &UserActivityModel{
+		ID:         activity.ID,
+		UserID:     activity.UserID,
+		Type:       activity.Type,
+		Summary:    activity.Summary,
+		Subject:    activity.Subject,
+		IPAddress:  activity.IPAddress,
+		UserAgent:  activity.UserAgent,
+		OccurredAt: activity.OccurredAt,
+	}
+	if len(activity.Metadata) > 0 {
+		metadata, err := json.Marshal(activity.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		model.Metadata = string(metadata)
+	}
+	return model, nil
+}
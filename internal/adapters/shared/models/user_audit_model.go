@@ -0,0 +1,65 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// UserAuditModel represents the GORM model of the admin audit log
+// Rows are only ever inserted; the index serves the log of one account
+type UserAuditModel struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement"`
+	TenantID     uint      `gorm:"not null;default:1;index"`
+	ActorID      uint      `gorm:"not null;index"`
+	TargetUserID uint      `gorm:"not null;index:idx_user_audit_target,priority:1"`
+	Action       string    `gorm:"not null;size:64"`
+	Changes      string    `gorm:"type:text"`
+	Reason       string    `gorm:"size:512"`
+	CreatedAt    time.Time `gorm:"autoCreateTime;index:idx_user_audit_target,priority:2"`
+}
+
+// TableName sets the table name for GORM
+func (UserAuditModel) TableName() string {
+	return "user_audit_logs"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *UserAuditModel) ToDomainEntity() *userEntities.AuditEntry {
+	changes := map[string]userEntities.AuditChange{}
+	if m.Changes != "" {
+		// Changes is always written by NewUserAuditModelFromEntity, so it decodes
+		_ = json.Unmarshal([]byte(m.Changes), &changes)
+	}
+
+	return &userEntities.AuditEntry{
+		ID:           m.ID,
+		ActorID:      m.ActorID,
+		TargetUserID: m.TargetUserID,
+		Action:       userEntities.AuditAction(m.Action),
+		Changes:      changes,
+		Reason:       m.Reason,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// NewUserAuditModelFromEntity creates GORM model from domain entity
+func NewUserAuditModelFromEntity(entry *userEntities.AuditEntry) (*UserAuditModel, error) {
+	model := &UserAuditModel{
+		ID:           entry.ID,
+		ActorID:      entry.ActorID,
+		TargetUserID: entry.TargetUserID,
+		Action:       string(entry.Action),
+		Reason:       entry.Reason,
+		CreatedAt:    entry.CreatedAt,
+	}
+	if len(entry.Changes) > 0 {
+		changes, err := json.Marshal(entry.Changes)
+		if err != nil {
+			return nil, err
+		}
+		model.Changes = string(changes)
+	}
+	return model, nil
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// UserAuthEventModel represents the GORM model of the authentication audit trail
+// Rows are only ever inserted; the indexes serve the events of one user and of all users
+type UserAuthEventModel struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	TenantID   uint      `gorm:"not null;default:1;index"`
+	UserID     uint      `gorm:"not null;index:idx_user_auth_events_user,priority:1"`
+	SessionID  uint      `gorm:"not null;default:0"`
+	Type       string    `gorm:"not null;size:64"`
+	Reason     string    `gorm:"size:64"`
+	IPAddress  string    `gorm:"size:45"`
+	UserAgent  string    `gorm:"size:512"`
+	OccurredAt time.Time `gorm:"not null;index:idx_user_auth_events_user,priority:2;index"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName sets the table name for GORM
+func (UserAuthEventModel) TableName() string {
+	return "user_auth_events"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *UserAuthEventModel) ToDomainEntity() *userEntities.AuthEvent {
+	return &userEntities.AuthEvent{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		SessionID:  m.SessionID,
+		Type:       userEntities.AuthEventType(m.Type),
+		Reason:     m.Reason,
+		IPAddress:  m.IPAddress,
+		UserAgent:  m.UserAgent,
+		OccurredAt: m.OccurredAt,
+	}
+}
+
+// NewUserAuthEventModelFromEntity creates GORM model from domain entity
+func NewUserAuthEventModelFromEntity(event *userEntities.AuthEvent) *UserAuthEventModel {
+	return &UserAuthEventModel{
+		ID:         event.ID,
+		UserID:     event.UserID,
+		SessionID:  event.SessionID,
+		Type:       string(event.Type),
+		Reason:     event.Reason,
+		IPAddress:  event.IPAddress,
+		UserAgent:  event.UserAgent,
+		OccurredAt: event.OccurredAt,
+	}
+}
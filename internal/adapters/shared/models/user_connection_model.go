@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// UserConnectionModel represents the GORM model of users following each other
+// A user follows another at most once; the second index serves the followers of a user
+type UserConnectionModel struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	TenantID   uint      `gorm:"not null;default:1;index"`
+	FollowerID uint      `gorm:"not null;uniqueIndex:idx_user_connections_pair,priority:1"`
+	FolloweeID uint      `gorm:"not null;uniqueIndex:idx_user_connections_pair,priority:2;index:idx_user_connections_followee"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName sets the table name for GORM
+func (UserConnectionModel) TableName() string {
+	return "user_connections"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *UserConnectionModel) ToDomainEntity() *userEntities.Connection {
+	return &userEntities.Connection{
+		ID:         m.ID,
+		FollowerID: m.FollowerID,
+		FolloweeID: m.FolloweeID,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+// NewUserConnectionModelFromEntity creates GORM model from domain entity
+func NewUserConnectionModelFromEntity(connection *userEntities.Connection) *UserConnectionModel {
+	return &UserConnectionModel{
+		ID:         connection.ID,
+		FollowerID: connection.FollowerID,
+		FolloweeID: connection.FolloweeID,
+		CreatedAt:  connection.CreatedAt,
+	}
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// UserDeviceModel represents the GORM model of the devices users get push notifications on
+// Tokens are unique across tenants, so a token registered again moves to its new user
+type UserDeviceModel struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	TenantID  uint      `gorm:"not null;default:1;index"`
+	UserID    uint      `gorm:"not null;index"`
+	Token     string    `gorm:"not null;size:512;uniqueIndex"`
+	Platform  string    `gorm:"not null;size:16"`
+	Name      string    `gorm:"size:255"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName sets the table name for GORM
+func (UserDeviceModel) TableName() string {
+	return "user_devices"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *UserDeviceModel) ToDomainEntity() *userEntities.Device {
+	return &userEntities.Device{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Token:     m.Token,
+		Platform:  userEntities.DevicePlatform(m.Platform),
+		Name:      m.Name,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// NewUserDeviceModelFromEntity creates GORM model from domain entity
+func NewUserDeviceModelFromEntity(device *userEntities.Device) *UserDeviceModel {
+	return &UserDeviceModel{
+		ID:        device.ID,
+		UserID:    device.UserID,
+		Token:     device.Token,
+		Platform:  string(device.Platform),
+		Name:      device.Name,
+		CreatedAt: device.CreatedAt,
+		UpdatedAt: device.UpdatedAt,
+	}
+}
+
+// UserDeviceUpsertColumns are overwritten when a registered token exists
+var UserDeviceUpsertColumns = []string{"tenant_id", "user_id", "platform", "name", "updated_at"}
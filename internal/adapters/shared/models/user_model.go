@@ -3,21 +3,47 @@ package models
 import (
 	"time"
 
+	"clean-arch-gin/internal/domain/shared/publicid"
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
 	userEntities "clean-arch-gin/internal/domain/user/entities"
+	"clean-arch-gin/internal/infrastructure/fieldcrypt"
 
 	"gorm.io/gorm"
 )
 
 // UserModel represents the GORM model for users
 // This is infrastructure layer concern - contains GORM tags and database-specific logic
+// Emails are unique within a tenant; email and name are encrypted at rest (see fieldcrypt)
 type UserModel struct {
-	ID        uint           `gorm:"primaryKey;autoIncrement" json:"id"`
-	Email     string         `gorm:"uniqueIndex;not null;size:255" json:"email"`
-	Name      string         `gorm:"not null;size:255" json:"name"`
-	Password  string         `gorm:"not null;size:255" json:"-"` // Excluded from JSON
-	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID uint `gorm:"primaryKey;autoIncrement;index:idx_users_created_at_id,priority:2" json:"id"`
+	// PublicID is the user's identifier in routes; nil on rows written before it existed until
+	// the user module's migration assigns one
+	PublicID *string `gorm:"size:36;uniqueIndex" json:"public_id"`
+	TenantID uint    `gorm:"not null;default:1;uniqueIndex:idx_users_tenant_email_hash,priority:1" json:"tenant_id"`
+	Email    string  `gorm:"not null;size:1024;serializer:encrypted" json:"email"`
+	// EmailHash is the blind index of Email, looked up and kept unique in its place since
+	// encrypted emails cannot be compared; nil on rows written before it existed until the
+	// user module's migration rewrites them
+	EmailHash             *string    `gorm:"size:80;uniqueIndex:idx_users_tenant_email_hash,priority:2" json:"-"`
+	Name                  string     `gorm:"not null;size:1024;serializer:encrypted" json:"name"`
+	Password              string     `gorm:"not null;size:255" json:"-"` // Excluded from JSON
+	AvatarURL             string     `gorm:"size:512" json:"avatar_url,omitempty"`
+	Role                  string     `gorm:"not null;size:32;default:user" json:"role"`
+	Status                string     `gorm:"not null;size:32;default:active" json:"status"`
+	PasswordResetRequired bool       `gorm:"not null;default:false" json:"password_reset_required"`
+	EmailVerifiedAt       *time.Time `json:"email_verified_at,omitempty"`
+	// EmailUndeliverableAt is set from bounce and complaint webhooks across tenants, looked
+	// up by EmailHash
+	EmailUndeliverableAt     *time.Time `json:"email_undeliverable_at,omitempty"`
+	EmailUndeliverableReason string     `gorm:"size:255" json:"email_undeliverable_reason,omitempty"`
+	Phone                    string     `gorm:"size:1024;serializer:encrypted" json:"phone,omitempty"`
+	// PhoneHash is the blind index of Phone, looked up by the replies to texts across
+	// tenants; nil without a phone
+	PhoneHash     *string        `gorm:"size:80;index" json:"-"`
+	SMSOptedOutAt *time.Time     `json:"sms_opted_out_at,omitempty"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime;index:idx_users_created_at_id,priority:1" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
 // TableName sets the table name for GORM
@@ -34,26 +60,49 @@ func (u *UserModel) ToDomainEntity() *userEntities.User {
 	}
 
 	return &userEntities.User{
-		ID:        u.ID,
-		Email:     u.Email,
-		Name:      u.Name,
-		Password:  u.Password,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
-		DeletedAt: deletedAt,
+		ID:                       u.ID,
+		PublicID:                 fromPublicID(u.PublicID),
+		Email:                    u.Email,
+		Name:                     u.Name,
+		Password:                 u.Password,
+		AvatarURL:                u.AvatarURL,
+		Role:                     userEntities.Role(u.Role),
+		Status:                   userEntities.Status(u.Status),
+		PasswordResetRequired:    u.PasswordResetRequired,
+		EmailVerifiedAt:          u.EmailVerifiedAt,
+		EmailUndeliverableAt:     u.EmailUndeliverableAt,
+		EmailUndeliverableReason: u.EmailUndeliverableReason,
+		Phone:                    u.Phone,
+		SMSOptedOutAt:            u.SMSOptedOutAt,
+		CreatedAt:                u.CreatedAt,
+		UpdatedAt:                u.UpdatedAt,
+		DeletedAt:                deletedAt,
 	}
 }
 
 // NewUserModelFromEntity creates GORM model from domain entity
 // This maintains clean architecture boundaries
 func NewUserModelFromEntity(user *userEntities.User) *UserModel {
+	emailHash := EmailIndex(user.Email)
 	userModel := &UserModel{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		Password:  user.Password,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:                       user.ID,
+		PublicID:                 toPublicID(user.PublicID),
+		Email:                    user.Email,
+		EmailHash:                &emailHash,
+		Name:                     user.Name,
+		Password:                 user.Password,
+		AvatarURL:                user.AvatarURL,
+		Role:                     string(user.Role),
+		Status:                   string(user.Status),
+		PasswordResetRequired:    user.PasswordResetRequired,
+		EmailVerifiedAt:          user.EmailVerifiedAt,
+		EmailUndeliverableAt:     user.EmailUndeliverableAt,
+		EmailUndeliverableReason: user.EmailUndeliverableReason,
+		Phone:                    user.Phone,
+		PhoneHash:                PhoneIndex(user.Phone),
+		SMSOptedOutAt:            user.SMSOptedOutAt,
+		CreatedAt:                user.CreatedAt,
+		UpdatedAt:                user.UpdatedAt,
 	}
 
 	if user.DeletedAt != nil {
@@ -65,3 +114,39 @@ func NewUserModelFromEntity(user *userEntities.User) *UserModel {
 
 	return userModel
 }
+
+// UserUpsertKey is the unique index users are upserted by, the blind index of their email
+// within their tenant
+var UserUpsertKey = []string{"tenant_id", "email_hash"}
+
+// UserUpsertColumns are overwritten when an upserted user exists; its email, public ID,
+// tenant and creation and deletion times are kept
+var UserUpsertColumns = []string{"name", "password", "avatar_url", "role", "status", "password_reset_required", "updated_at"}
+
+// NewUserUpsertModel creates the GORM model a user is upserted with
+// The key must be complete, so the tenant is the default one unless the tenant scope sets
+// the context's, and a user without a public ID gets one so an insert can be told from an
+// update by whether it was kept
+func NewUserUpsertModel(user *userEntities.User) *UserModel {
+	if user.PublicID == "" {
+		user.PublicID = publicid.New()
+	}
+	userModel := NewUserModelFromEntity(user)
+	userModel.ID = 0
+	userModel.TenantID = tenantEntities.DefaultTenantID
+	return userModel
+}
+
+// EmailIndex returns the blind index users are looked up by email with
+func EmailIndex(email string) string {
+	return fieldcrypt.BlindIndex(email)
+}
+
+// PhoneIndex returns the blind index users are looked up by phone with, nil for no phone
+func PhoneIndex(phone string) *string {
+	if phone == "" {
+		return nil
+	}
+	index := fieldcrypt.BlindIndex(phone)
+	return &index
+}
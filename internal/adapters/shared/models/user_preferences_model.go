@@ -0,0 +1,103 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// UserPreferencesModel represents the GORM model for user preferences, one row per user
+// Notification opt-ins are columns so senders can filter on them; toggled notification
+// types and custom keys are JSON objects
+// Quiet hours are empty when the user set none
+type UserPreferencesModel struct {
+	UserID            uint      `gorm:"primaryKey;autoIncrement:false"`
+	TenantID          uint      `gorm:"not null;default:1;index"`
+	Locale            string    `gorm:"not null;size:35"`
+	Timezone          string    `gorm:"not null;size:64"`
+	NotifyEmail       bool      `gorm:"not null"`
+	NotifyPush        bool      `gorm:"not null"`
+	NotifySMS         bool      `gorm:"not null"`
+	NotifyMarketing   bool      `gorm:"not null"`
+	NotificationTypes string    `gorm:"type:text"`
+	QuietHoursStart   string    `gorm:"not null;default:'';size:5"`
+	QuietHoursEnd     string    `gorm:"not null;default:'';size:5"`
+	Custom            string    `gorm:"type:text"`
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName sets the table name for GORM
+func (UserPreferencesModel) TableName() string {
+	return "user_preferences"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *UserPreferencesModel) ToDomainEntity() (*userEntities.UserPreferences, error) {
+	custom := map[string]json.RawMessage{}
+	if m.Custom != "" {
+		if err := json.Unmarshal([]byte(m.Custom), &custom); err != nil {
+			return nil, err
+		}
+	}
+	types := map[string]bool{}
+	if m.NotificationTypes != "" {
+		if err := json.Unmarshal([]byte(m.NotificationTypes), &types); err != nil {
+			return nil, err
+		}
+	}
+	var quiet *userEntities.QuietHours
+	if m.QuietHoursStart != "" && m.QuietHoursEnd != "" {
+		quiet = &userEntities.QuietHours{Start: m.QuietHoursStart, End: m.QuietHoursEnd}
+	}
+
+	return &userEntities.UserPreferences{
+		UserID:   m.UserID,
+		Locale:   m.Locale,
+		Timezone: m.Timezone,
+		Notifications: map[string]bool{
+			userEntities.NotifyEmail:     m.NotifyEmail,
+			userEntities.NotifyPush:      m.NotifyPush,
+			userEntities.NotifySMS:       m.NotifySMS,
+			userEntities.NotifyMarketing: m.NotifyMarketing,
+		},
+		NotificationTypes: types,
+		QuietHours:        quiet,
+		Custom:            custom,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+	}, nil
+}
+
+// NewUserPreferencesModelFromEntity creates GORM model from domain entity
+func NewUserPreferencesModelFromEntity(prefs *userEntities.UserPreferences) (*UserPreferencesModel, error) {
+	custom, err := json.Marshal(prefs.Custom)
+	if err != nil {
+		return nil, err
+	}
+	types, err := json.Marshal(prefs.NotificationTypes)
+	if err != nil {
+		return nil, err
+	}
+	var quiet userEntities.QuietHours
+	if prefs.QuietHours != nil {
+		quiet = *prefs.QuietHours
+	}
+
+	return &UserPreferencesModel{
+		UserID:            prefs.UserID,
+		Locale:            prefs.Locale,
+		Timezone:          prefs.Timezone,
+		NotifyEmail:       prefs.WantsNotification(userEntities.NotifyEmail),
+		NotifyPush:        prefs.WantsNotification(userEntities.NotifyPush),
+		NotifySMS:         prefs.WantsNotification(userEntities.NotifySMS),
+		NotifyMarketing:   prefs.WantsNotification(userEntities.NotifyMarketing),
+		NotificationTypes: string(types),
+		QuietHoursStart:   quiet.Start,
+		QuietHoursEnd:     quiet.End,
+		Custom:            string(custom),
+		CreatedAt:         prefs.CreatedAt,
+		UpdatedAt:         prefs.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// UserSessionModel represents the GORM model of server-side sessions
+// The token hash index serves the lookup on every authenticated request; rows are kept
+// revoked until they expire so a revoked token is told apart from an unknown one
+type UserSessionModel struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement"`
+	TenantID   uint       `gorm:"not null;default:1;index"`
+	UserID     uint       `gorm:"not null;index"`
+	TokenHash  string     `gorm:"not null;size:64;uniqueIndex"`
+	UserAgent  string     `gorm:"size:512"`
+	IPAddress  string     `gorm:"size:45"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime"`
+	LastSeenAt time.Time  `gorm:"not null"`
+	ExpiresAt  time.Time  `gorm:"not null;index"`
+	RevokedAt  *time.Time `gorm:"index"`
+}
+
+// TableName sets the table name for GORM
+func (UserSessionModel) TableName() string {
+	return "user_sessions"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (m *UserSessionModel) ToDomainEntity() *userEntities.Session {
+	return &userEntities.Session{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		TokenHash:  m.TokenHash,
+		UserAgent:  m.UserAgent,
+		IPAddress:  m.IPAddress,
+		CreatedAt:  m.CreatedAt,
+		LastSeenAt: m.LastSeenAt,
+		ExpiresAt:  m.ExpiresAt,
+		RevokedAt:  m.RevokedAt,
+	}
+}
+
+// NewUserSessionModelFromEntity creates GORM model from domain entity
+func NewUserSessionModelFromEntity(session *userEntities.Session) *UserSessionModel {
+	return &UserSessionModel{
+		ID:         session.ID,
+		UserID:     session.UserID,
+		TokenHash:  session.TokenHash,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+		RevokedAt:  session.RevokedAt,
+	}
+}
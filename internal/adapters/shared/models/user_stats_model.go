@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// UserDailyStatsModel is the per-day rollup of user counts kept by the users.stats-rollup job
+// There is a row per tenant and day
+type UserDailyStatsModel struct {
+	TenantID     uint      `gorm:"primaryKey;autoIncrement:false;default:1" json:"tenant_id"`
+	Date         string    `gorm:"primaryKey;size:10" json:"date"` // YYYY-MM-DD in UTC
+	TotalUsers   int64     `gorm:"not null" json:"total_users"`
+	NewUsers     int64     `gorm:"not null" json:"new_users"`
+	DeletedUsers int64     `gorm:"not null" json:"deleted_users"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName sets the table name for GORM
+func (UserDailyStatsModel) TableName() string {
+	return "user_daily_stats"
+}
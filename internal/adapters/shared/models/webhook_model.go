@@ -0,0 +1,177 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	webhookEntities "clean-arch-gin/internal/domain/webhook/entities"
+)
+
+// WebhookEndpointModel represents the GORM model for webhook endpoints
+// Event types and retry intervals are stored as comma-separated lists
+type WebhookEndpointModel struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID      uint      `gorm:"not null;default:1;index" json:"tenant_id"`
+	URL           string    `gorm:"not null;size:2048" json:"url"`
+	Secret        string    `gorm:"not null;size:255" json:"-"`
+	EventTypes    string    `gorm:"size:1024" json:"event_types"`
+	RetrySchedule string    `gorm:"size:255" json:"retry_schedule"`
+	Format        string    `gorm:"not null;size:32;default:json" json:"format"`
+	Active        bool      `gorm:"not null;default:true" json:"active"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName sets the table name for GORM
+func (WebhookEndpointModel) TableName() string {
+	return "webhook_endpoints"
+}
+
+// WebhookDeliveryModel represents the GORM model for webhook deliveries
+type WebhookDeliveryModel struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID      uint      `gorm:"not null;default:1;index" json:"tenant_id"`
+	EndpointID    uint      `gorm:"not null;index" json:"endpoint_id"`
+	EventID       string    `gorm:"not null;size:64" json:"event_id"`
+	EventName     string    `gorm:"not null;size:128" json:"event_name"`
+	Payload       []byte    `gorm:"not null" json:"payload"`
+	Status        string    `gorm:"not null;size:32;index:idx_webhook_deliveries_due,priority:1" json:"status"`
+	Attempts      int       `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time `gorm:"not null;index:idx_webhook_deliveries_due,priority:2" json:"next_attempt_at"`
+	LastError     string    `gorm:"size:1024" json:"last_error"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName sets the table name for GORM
+func (WebhookDeliveryModel) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookDeliveryAttemptModel represents the GORM model for the delivery attempt log
+type WebhookDeliveryAttemptModel struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID    uint      `gorm:"not null;default:1;index" json:"tenant_id"`
+	DeliveryID  uint      `gorm:"not null;index" json:"delivery_id"`
+	Number      int       `gorm:"not null" json:"number"`
+	StatusCode  int       `gorm:"not null;default:0" json:"status_code"`
+	Error       string    `gorm:"size:1024" json:"error"`
+	DurationMs  int64     `gorm:"not null;default:0" json:"duration_ms"`
+	AttemptedAt time.Time `gorm:"not null" json:"attempted_at"`
+}
+
+// TableName sets the table name for GORM
+func (WebhookDeliveryAttemptModel) TableName() string {
+	return "webhook_delivery_attempts"
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (e *WebhookEndpointModel) ToDomainEntity() *webhookEntities.Endpoint {
+	var eventTypes []string
+	if e.EventTypes != "" {
+		eventTypes = strings.Split(e.EventTypes, ",")
+	}
+
+	var retrySchedule []time.Duration
+	if e.RetrySchedule != "" {
+		for _, raw := range strings.Split(e.RetrySchedule, ",") {
+			if wait, err := time.ParseDuration(raw); err == nil {
+				retrySchedule = append(retrySchedule, wait)
+			}
+		}
+	}
+
+	return &webhookEntities.Endpoint{
+		ID:            e.ID,
+		URL:           e.URL,
+		Secret:        e.Secret,
+		EventTypes:    eventTypes,
+		RetrySchedule: retrySchedule,
+		Format:        webhookEntities.PayloadFormat(e.Format),
+		Active:        e.Active,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
+
+// NewWebhookEndpointModelFromEntity creates GORM model from domain entity
+func NewWebhookEndpointModelFromEntity(endpoint *webhookEntities.Endpoint) *WebhookEndpointModel {
+	retrySchedule := make([]string, len(endpoint.RetrySchedule))
+	for i, wait := range endpoint.RetrySchedule {
+		retrySchedule[i] = wait.String()
+	}
+
+	return &WebhookEndpointModel{
+		ID:            endpoint.ID,
+		URL:           endpoint.URL,
+		Secret:        endpoint.Secret,
+		EventTypes:    strings.Join(endpoint.EventTypes, ","),
+		RetrySchedule: strings.Join(retrySchedule, ","),
+		Format:        string(endpoint.Format),
+		Active:        endpoint.Active,
+		CreatedAt:     endpoint.CreatedAt,
+		UpdatedAt:     endpoint.UpdatedAt,
+	}
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (d *WebhookDeliveryModel) ToDomainEntity() *webhookEntities.Delivery {
+	return &webhookEntities.Delivery{
+		ID:            d.ID,
+		TenantID:      d.TenantID,
+		EndpointID:    d.EndpointID,
+		EventID:       d.EventID,
+		EventName:     d.EventName,
+		Payload:       d.Payload,
+		Status:        webhookEntities.DeliveryStatus(d.Status),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		LastError:     d.LastError,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
+
+// NewWebhookDeliveryModelFromEntity creates GORM model from domain entity
+func NewWebhookDeliveryModelFromEntity(delivery *webhookEntities.Delivery) *WebhookDeliveryModel {
+	return &WebhookDeliveryModel{
+		ID:            delivery.ID,
+		TenantID:      delivery.TenantID,
+		EndpointID:    delivery.EndpointID,
+		EventID:       delivery.EventID,
+		EventName:     delivery.EventName,
+		Payload:       delivery.Payload,
+		Status:        string(delivery.Status),
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		LastError:     delivery.LastError,
+		CreatedAt:     delivery.CreatedAt,
+		UpdatedAt:     delivery.UpdatedAt,
+	}
+}
+
+// ToDomainEntity converts GORM model to domain entity
+func (a *WebhookDeliveryAttemptModel) ToDomainEntity() *webhookEntities.DeliveryAttempt {
+	return &webhookEntities.DeliveryAttempt{
+		ID:          a.ID,
+		DeliveryID:  a.DeliveryID,
+		Number:      a.Number,
+		StatusCode:  a.StatusCode,
+		Error:       a.Error,
+		Duration:    time.Duration(a.DurationMs) * time.Millisecond,
+		AttemptedAt: a.AttemptedAt,
+	}
+}
+
+// NewWebhookDeliveryAttemptModelFromEntity creates GORM model from domain entity
+func NewWebhookDeliveryAttemptModelFromEntity(attempt *webhookEntities.DeliveryAttempt) *WebhookDeliveryAttemptModel {
+	return &WebhookDeliveryAttemptModel{
+		ID:          attempt.ID,
+		DeliveryID:  attempt.DeliveryID,
+		Number:      attempt.Number,
+		StatusCode:  attempt.StatusCode,
+		Error:       attempt.Error,
+		DurationMs:  attempt.Duration.Milliseconds(),
+		AttemptedAt: attempt.AttemptedAt,
+	}
+}
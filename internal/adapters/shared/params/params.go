@@ -0,0 +1,86 @@
+// Package params parses and validates request parameters shared by all controllers
+// The functions are pure so they can be exercised directly by fuzz targets
+package params
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultLimit is used when the limit query parameter is omitted
+	DefaultLimit = 10
+	// MaxLimit caps page sizes so a single request cannot load an entire table
+	MaxLimit = 100
+	// MaxOffset bounds offsets to keep pathological values away from the database
+	MaxOffset = 1_000_000
+)
+
+// Parameter errors; controllers return their messages with HTTP 400
+var (
+	ErrInvalidID     = errors.New("Invalid ID")
+	ErrInvalidLimit  = errors.New("Invalid limit parameter")
+	ErrInvalidOffset = errors.New("Invalid offset parameter")
+)
+
+// Pagination holds validated limit/offset values
+type Pagination struct {
+	Limit  int
+	Offset int
+}
+
+// ParsePagination parses limit and offset query values
+// Empty values fall back to defaults, limits above MaxLimit are clamped,
+// and negative or non-numeric values are rejected
+func ParsePagination(limitStr, offsetStr string) (Pagination, error) {
+	p := Pagination{Limit: DefaultLimit}
+
+	if limitStr = strings.TrimSpace(limitStr); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			return Pagination{}, ErrInvalidLimit
+		}
+		if limit > MaxLimit {
+			limit = MaxLimit
+		}
+		p.Limit = limit
+	}
+
+	if offsetStr = strings.TrimSpace(offsetStr); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 || offset > MaxOffset {
+			return Pagination{}, ErrInvalidOffset
+		}
+		p.Offset = offset
+	}
+
+	return p, nil
+}
+
+// ParseID parses a positive numeric path ID that fits the uint32 primary key columns
+func ParseID(idStr string) (uint, error) {
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		return 0, ErrInvalidID
+	}
+	return uint(id), nil
+}
+
+// NewPagination validates numeric limit/offset values from typed transports such as gRPC
+// A zero limit means "unset" and falls back to DefaultLimit
+func NewPagination(limit, offset int) (Pagination, error) {
+	if limit < 0 {
+		return Pagination{}, ErrInvalidLimit
+	}
+	if offset < 0 || offset > MaxOffset {
+		return Pagination{}, ErrInvalidOffset
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	return Pagination{Limit: limit, Offset: offset}, nil
+}
@@ -0,0 +1,66 @@
+package params
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func FuzzParsePagination(f *testing.F) {
+	seeds := []struct{ limit, offset string }{
+		{"", ""},
+		{"10", "0"},
+		{" 25 ", " 50 "},
+		{"0", "1000000"},
+		{"101", "1000001"},
+		{"-1", "-1"},
+		{"abc", "1e3"},
+		{"9223372036854775807", "9223372036854775808"},
+		{"\x00", " "},
+	}
+	for _, seed := range seeds {
+		f.Add(seed.limit, seed.offset)
+	}
+
+	f.Fuzz(func(t *testing.T, limitStr, offsetStr string) {
+		p, err := ParsePagination(limitStr, offsetStr)
+		if err != nil {
+			if err != ErrInvalidLimit && err != ErrInvalidOffset {
+				t.Fatalf("ParsePagination(%q, %q) error = %v, want a parameter error", limitStr, offsetStr, err)
+			}
+			if p != (Pagination{}) {
+				t.Fatalf("ParsePagination(%q, %q) = %+v with an error, want the zero value", limitStr, offsetStr, p)
+			}
+			return
+		}
+		if p.Limit < 0 || p.Limit > MaxLimit {
+			t.Fatalf("ParsePagination(%q, %q) limit = %d, want within [0, %d]", limitStr, offsetStr, p.Limit, MaxLimit)
+		}
+		if p.Offset < 0 || p.Offset > MaxOffset {
+			t.Fatalf("ParsePagination(%q, %q) offset = %d, want within [0, %d]", limitStr, offsetStr, p.Offset, MaxOffset)
+		}
+	})
+}
+
+func FuzzParseID(f *testing.F) {
+	for _, seed := range []string{"1", "42", "007", "0", "-1", "4294967295", "4294967296", "18446744073709551616", "1.5", " 1", "+1", ""} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, idStr string) {
+		id, err := ParseID(idStr)
+		if err != nil {
+			if err != ErrInvalidID || id != 0 {
+				t.Fatalf("ParseID(%q) = %d, %v; want 0, %v", idStr, id, err, ErrInvalidID)
+			}
+			return
+		}
+		if id == 0 || id > math.MaxUint32 {
+			t.Fatalf("ParseID(%q) = %d, want within [1, %d]", idStr, id, uint64(math.MaxUint32))
+		}
+		// A valid ID survives a round trip through its canonical form
+		if again, err := ParseID(strconv.FormatUint(uint64(id), 10)); err != nil || again != id {
+			t.Fatalf("ParseID(%q) = %d, but its canonical form parses to %d, %v", idStr, id, again, err)
+		}
+	})
+}
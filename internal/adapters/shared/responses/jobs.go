@@ -0,0 +1,27 @@
+package responses
+
+import (
+	"net/http"
+	"strconv"
+
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobsPath is where the status of a queued job is polled, as GET <JobsPath>/:id
+const JobsPath = "/api/v1/jobs"
+
+// JobAcceptedResponse is returned when a long operation is queued instead of run inline
+type JobAcceptedResponse struct {
+	JobID     uint             `json:"job_id"`
+	Status    taskqueue.Status `json:"status"`
+	StatusURL string           `json:"status_url"`
+}
+
+// JobAccepted responds 202 with the job's ID and its status URL, also set as Location
+func JobAccepted(c *gin.Context, task *taskqueue.Task) {
+	statusURL := JobsPath + "/" + strconv.FormatUint(uint64(task.ID), 10)
+	c.Header("Location", statusURL)
+	c.JSON(http.StatusAccepted, JobAcceptedResponse{JobID: task.ID, Status: task.Status, StatusURL: statusURL})
+}
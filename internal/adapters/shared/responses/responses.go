@@ -0,0 +1,30 @@
+// Package responses holds HTTP error responses shared by the controllers
+package responses
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"clean-arch-gin/internal/infrastructure/breaker"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InternalError responds to an error no domain rule explains
+// A dependency rejected by its circuit breaker is a temporary 503 with Retry-After;
+// anything else is a 500
+func InternalError(c *gin.Context, err error) {
+	var unavailable *breaker.UnavailableError
+	if errors.As(err, &unavailable) {
+		seconds := int(math.Ceil(unavailable.RetryAfter.Seconds()))
+		if seconds < 1 {
+			seconds = 1
+		}
+		c.Header("Retry-After", strconv.Itoa(seconds))
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
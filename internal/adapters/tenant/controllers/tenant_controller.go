@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
+	tenantUsecases "clean-arch-gin/internal/domain/tenant/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateTenantRequest represents the request for creating a tenant
+type CreateTenantRequest struct {
+	// Slug names the tenant in the X-Tenant header and as its subdomain, e.g. acme
+	Slug string `json:"slug" binding:"required,max=63"`
+	Name string `json:"name" binding:"required,max=255"`
+	// BaseCurrency is the ISO 4217 currency of the tenant's prices and orders; USD by default
+	BaseCurrency string `json:"base_currency" binding:"omitempty,len=3"`
+}
+
+// UpdateTenantRequest renames, suspends or reactivates a tenant or changes its base currency;
+// omitted fields are kept
+type UpdateTenantRequest struct {
+	Name         *string                `json:"name" binding:"omitempty,max=255"`
+	Status       *tenantEntities.Status `json:"status" binding:"omitempty,oneof=active suspended"`
+	BaseCurrency *string                `json:"base_currency" binding:"omitempty,len=3"`
+}
+
+// TenantDTO represents a tenant in API responses
+type TenantDTO struct {
+	ID           uint                  `json:"id"`
+	Slug         string                `json:"slug"`
+	Name         string                `json:"name"`
+	Status       tenantEntities.Status `json:"status"`
+	BaseCurrency string                `json:"base_currency"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+}
+
+// TenantListResponse is the paginated response of ListTenants
+type TenantListResponse struct {
+	Tenants []TenantDTO `json:"tenants"`
+	Total   int64       `json:"total"`
+	Limit   int         `json:"limit"`
+	Offset  int         `json:"offset"`
+}
+
+// TenantController handles HTTP requests for tenant administration
+type TenantController struct {
+	tenantUseCase tenantUsecases.TenantUseCase
+}
+
+// NewTenantController creates a new tenant controller
+func NewTenantController(tenantUseCase tenantUsecases.TenantUseCase) *TenantController {
+	return &TenantController{
+		tenantUseCase: tenantUseCase,
+	}
+}
+
+// CreateTenant creates a new tenant
+func (tc *TenantController) CreateTenant(c *gin.Context) {
+	var req CreateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tenant, err := tc.tenantUseCase.CreateTenant(c.Request.Context(), req.Slug, req.Name, req.BaseCurrency)
+	if err != nil {
+		respondTenantError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toTenantDTO(tenant))
+}
+
+// ListTenants retrieves a page of tenants
+func (tc *TenantController) ListTenants(c *gin.Context) {
+	page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tenants, total, err := tc.tenantUseCase.ListTenants(c.Request.Context(), page.Limit, page.Offset)
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+
+	dtos := make([]TenantDTO, len(tenants))
+	for i, tenant := range tenants {
+		dtos[i] = toTenantDTO(tenant)
+	}
+	c.JSON(http.StatusOK, TenantListResponse{
+		Tenants: dtos,
+		Total:   total,
+		Limit:   page.Limit,
+		Offset:  page.Offset,
+	})
+}
+
+// GetTenant retrieves a tenant by ID
+func (tc *TenantController) GetTenant(c *gin.Context) {
+	id, ok := tenantID(c)
+	if !ok {
+		return
+	}
+
+	tenant, err := tc.tenantUseCase.GetTenant(c.Request.Context(), id)
+	if err != nil {
+		respondTenantError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toTenantDTO(tenant))
+}
+
+// UpdateTenant renames, suspends or reactivates a tenant or changes its base currency
+func (tc *TenantController) UpdateTenant(c *gin.Context) {
+	id, ok := tenantID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tenant, err := tc.tenantUseCase.UpdateTenant(c.Request.Context(), id, req.Name, req.Status, req.BaseCurrency)
+	if err != nil {
+		respondTenantError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toTenantDTO(tenant))
+}
+
+// DeleteTenant deletes a tenant
+func (tc *TenantController) DeleteTenant(c *gin.Context) {
+	id, ok := tenantID(c)
+	if !ok {
+		return
+	}
+
+	if err := tc.tenantUseCase.DeleteTenant(c.Request.Context(), id); err != nil {
+		respondTenantError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// tenantID returns the :id tenant, responding when it is invalid
+func tenantID(c *gin.Context) (uint, bool) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tenant ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// toTenantDTO converts domain entity to DTO
+func toTenantDTO(tenant *tenantEntities.Tenant) TenantDTO {
+	return TenantDTO{
+		ID:           tenant.ID,
+		Slug:         tenant.Slug,
+		Name:         tenant.Name,
+		Status:       tenant.Status,
+		BaseCurrency: string(tenant.BaseCurrency),
+		CreatedAt:    tenant.CreatedAt,
+		UpdatedAt:    tenant.UpdatedAt,
+	}
+}
+
+// respondTenantError maps tenant errors: a missing tenant is 404, a taken slug 409, a
+// change to the default tenant 403 and any other domain error a bad request
+func respondTenantError(c *gin.Context, err error) {
+	var domainErr sharedEntities.DomainError
+	switch {
+	case err == tenantEntities.ErrTenantNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err == tenantEntities.ErrSlugExists:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case err == tenantEntities.ErrDefaultTenant:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
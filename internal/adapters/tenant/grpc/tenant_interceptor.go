@@ -0,0 +1,50 @@
+// Package grpc scopes gRPC calls to the tenant they name
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TenantMetadata names the tenant of a call by slug, like the X-Tenant header over HTTP
+const TenantMetadata = "x-tenant"
+
+// TenantResolver finds the tenant a call names by slug
+type TenantResolver interface {
+	ResolveTenant(ctx context.Context, slug string) (*tenantEntities.Tenant, error)
+}
+
+// TenantInterceptor mirrors middleware.ResolveTenant for gRPC calls
+// Calls naming no tenant act for the default one; unknown tenants are NotFound and
+// suspended ones PermissionDenied
+func TenantInterceptor(tenants TenantResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		tenantID := tenantEntities.DefaultTenantID
+		md, _ := metadata.FromIncomingContext(ctx)
+		if slugs := md.Get(TenantMetadata); len(slugs) > 0 {
+			slug := strings.ToLower(strings.TrimSpace(slugs[0]))
+			if slug != "" && slug != tenantEntities.DefaultSlug {
+				tenant, err := tenants.ResolveTenant(ctx, slug)
+				switch err {
+				case nil:
+					tenantID = tenant.ID
+				case tenantEntities.ErrTenantNotFound:
+					return nil, status.Error(codes.NotFound, err.Error())
+				case tenantEntities.ErrTenantSuspended:
+					return nil, status.Error(codes.PermissionDenied, err.Error())
+				default:
+					return nil, status.Error(codes.Internal, err.Error())
+				}
+			}
+		}
+		return handler(tenancy.NewContext(ctx, tenantID), req)
+	}
+}
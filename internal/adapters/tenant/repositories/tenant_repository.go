@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
+	tenantRepositories "clean-arch-gin/internal/domain/tenant/repositories"
+
+	"gorm.io/gorm"
+)
+
+// tenantRepository implements TenantRepository interface using GORM
+type tenantRepository struct {
+	db *gorm.DB
+}
+
+// NewTenantRepository creates a new tenant repository
+func NewTenantRepository(db *gorm.DB) tenantRepositories.TenantRepository {
+	return &tenantRepository{db: db}
+}
+
+// Create creates a new tenant in the database
+func (r *tenantRepository) Create(ctx context.Context, tenant *tenantEntities.Tenant) error {
+	tenantModel := models.NewTenantModelFromEntity(tenant)
+	if err := r.db.WithContext(ctx).Create(tenantModel).Error; err != nil {
+		return err
+	}
+	tenant.ID = tenantModel.ID
+	return nil
+}
+
+// GetByID retrieves a tenant by ID
+func (r *tenantRepository) GetByID(ctx context.Context, id uint) (*tenantEntities.Tenant, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+// GetBySlug retrieves a tenant by slug
+func (r *tenantRepository) GetBySlug(ctx context.Context, slug string) (*tenantEntities.Tenant, error) {
+	return r.first(ctx, "slug = ?", slug)
+}
+
+// List retrieves a page of tenants ordered by ID
+func (r *tenantRepository) List(ctx context.Context, limit, offset int) ([]*tenantEntities.Tenant, error) {
+	var tenantModels []models.TenantModel
+	if err := r.db.WithContext(ctx).Order("id").Limit(limit).Offset(offset).Find(&tenantModels).Error; err != nil {
+		return nil, err
+	}
+
+	tenants := make([]*tenantEntities.Tenant, len(tenantModels))
+	for i := range tenantModels {
+		tenants[i] = tenantModels[i].ToDomainEntity()
+	}
+	return tenants, nil
+}
+
+// Count returns the number of tenants
+func (r *tenantRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.TenantModel{}).Count(&count).Error
+	return count, err
+}
+
+// Update updates an existing tenant
+func (r *tenantRepository) Update(ctx context.Context, tenant *tenantEntities.Tenant) error {
+	return r.db.WithContext(ctx).Save(models.NewTenantModelFromEntity(tenant)).Error
+}
+
+// Delete deletes a tenant
+func (r *tenantRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.TenantModel{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return tenantEntities.ErrTenantNotFound
+	}
+	return nil
+}
+
+// first retrieves the tenant matching query
+func (r *tenantRepository) first(ctx context.Context, query string, args ...interface{}) (*tenantEntities.Tenant, error) {
+	var tenantModel models.TenantModel
+	if err := r.db.WithContext(ctx).Where(query, args...).First(&tenantModel).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, tenantEntities.ErrTenantNotFound
+		}
+		return nil, err
+	}
+	return tenantModel.ToDomainEntity(), nil
+}
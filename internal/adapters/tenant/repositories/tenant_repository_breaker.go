@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
+	tenantRepositories "clean-arch-gin/internal/domain/tenant/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// tenantRepositoryBreaker guards a TenantRepository with a circuit breaker
+type tenantRepositoryBreaker struct {
+	repo tenantRepositories.TenantRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewTenantRepositoryWithBreaker wraps repo so calls go through cb
+func NewTenantRepositoryWithBreaker(repo tenantRepositories.TenantRepository, cb *breaker.CircuitBreaker) tenantRepositories.TenantRepository {
+	return &tenantRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Create creates a tenant through the breaker
+func (r *tenantRepositoryBreaker) Create(ctx context.Context, tenant *tenantEntities.Tenant) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Create(ctx, tenant)
+	})
+}
+
+// GetByID retrieves a tenant through the breaker
+func (r *tenantRepositoryBreaker) GetByID(ctx context.Context, id uint) (tenant *tenantEntities.Tenant, err error) {
+	err = r.cb.Execute(func() error {
+		tenant, err = r.repo.GetByID(ctx, id)
+		return err
+	})
+	return tenant, err
+}
+
+// GetBySlug retrieves a tenant through the breaker
+func (r *tenantRepositoryBreaker) GetBySlug(ctx context.Context, slug string) (tenant *tenantEntities.Tenant, err error) {
+	err = r.cb.Execute(func() error {
+		tenant, err = r.repo.GetBySlug(ctx, slug)
+		return err
+	})
+	return tenant, err
+}
+
+// List retrieves a page of tenants through the breaker
+func (r *tenantRepositoryBreaker) List(ctx context.Context, limit, offset int) (tenants []*tenantEntities.Tenant, err error) {
+	err = r.cb.Execute(func() error {
+		tenants, err = r.repo.List(ctx, limit, offset)
+		return err
+	})
+	return tenants, err
+}
+
+// Count counts tenants through the breaker
+func (r *tenantRepositoryBreaker) Count(ctx context.Context) (count int64, err error) {
+	err = r.cb.Execute(func() error {
+		count, err = r.repo.Count(ctx)
+		return err
+	})
+	return count, err
+}
+
+// Update updates a tenant through the breaker
+func (r *tenantRepositoryBreaker) Update(ctx context.Context, tenant *tenantEntities.Tenant) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Update(ctx, tenant)
+	})
+}
+
+// Delete deletes a tenant through the breaker
+func (r *tenantRepositoryBreaker) Delete(ctx context.Context, id uint) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Delete(ctx, id)
+	})
+}
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/shared/money"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
+	tenantRepositories "clean-arch-gin/internal/domain/tenant/repositories"
+	tenantUsecases "clean-arch-gin/internal/domain/tenant/usecases"
+)
+
+// tenantUseCase implements the TenantUseCase interface
+type tenantUseCase struct {
+	tenantRepo tenantRepositories.TenantRepository
+}
+
+// NewTenantUseCase creates a new tenant use case
+func NewTenantUseCase(tenantRepo tenantRepositories.TenantRepository) tenantUsecases.TenantUseCase {
+	return &tenantUseCase{
+		tenantRepo: tenantRepo,
+	}
+}
+
+// CreateTenant validates and stores a new tenant with a unique slug
+func (uc *tenantUseCase) CreateTenant(ctx context.Context, slug, name, baseCurrency string) (*tenantEntities.Tenant, error) {
+	tenant, err := tenantEntities.NewTenant(slug, name, baseCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = uc.tenantRepo.GetBySlug(ctx, tenant.Slug)
+	if err == nil {
+		return nil, tenantEntities.ErrSlugExists
+	}
+	if err != tenantEntities.ErrTenantNotFound {
+		return nil, err
+	}
+
+	if err := uc.tenantRepo.Create(ctx, tenant); err != nil {
+		return nil, err
+	}
+	return tenant, nil
+}
+
+// GetTenant retrieves a tenant by ID
+func (uc *tenantUseCase) GetTenant(ctx context.Context, id uint) (*tenantEntities.Tenant, error) {
+	return uc.tenantRepo.GetByID(ctx, id)
+}
+
+// ListTenants retrieves a page of tenants and their total count
+func (uc *tenantUseCase) ListTenants(ctx context.Context, limit, offset int) ([]*tenantEntities.Tenant, int64, error) {
+	tenants, err := uc.tenantRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := uc.tenantRepo.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return tenants, total, nil
+}
+
+// UpdateTenant renames the tenant and sets its status and base currency
+func (uc *tenantUseCase) UpdateTenant(ctx context.Context, id uint, name *string, status *tenantEntities.Status,
+	baseCurrency *string) (*tenantEntities.Tenant, error) {
+	tenant, err := uc.tenantRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if name != nil {
+		if err := tenant.Rename(*name); err != nil {
+			return nil, err
+		}
+	}
+	if status != nil {
+		if err := tenant.SetStatus(*status); err != nil {
+			return nil, err
+		}
+	}
+	if baseCurrency != nil {
+		if err := tenant.SetBaseCurrency(*baseCurrency); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.tenantRepo.Update(ctx, tenant); err != nil {
+		return nil, err
+	}
+	return tenant, nil
+}
+
+// DeleteTenant deletes a tenant other than the default one
+func (uc *tenantUseCase) DeleteTenant(ctx context.Context, id uint) error {
+	if id == tenantEntities.DefaultTenantID {
+		return tenantEntities.ErrDefaultTenant
+	}
+	return uc.tenantRepo.Delete(ctx, id)
+}
+
+// ResolveTenant finds an active tenant by slug
+func (uc *tenantUseCase) ResolveTenant(ctx context.Context, slug string) (*tenantEntities.Tenant, error) {
+	tenant, err := uc.tenantRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.IsSuspended() {
+		return nil, tenantEntities.ErrTenantSuspended
+	}
+	return tenant, nil
+}
+
+// BaseCurrency looks up the base currency of the tenant ctx acts for
+func (uc *tenantUseCase) BaseCurrency(ctx context.Context) (money.Currency, error) {
+	tenantID, ok := tenancy.FromContext(ctx)
+	if !ok {
+		tenantID = tenantEntities.DefaultTenantID
+	}
+	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	return tenant.BaseCurrency, nil
+}
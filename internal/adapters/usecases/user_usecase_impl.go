@@ -1,6 +1,11 @@
+// Package usecases holds the user use case of the legacy flat router
+//
+// Deprecated: use the user module's use cases in internal/adapters/user/usecases
 package usecases
 
 import (
+	"context"
+
 	userEntities "clean-arch-gin/internal/domain/user/entities"
 	userRepositories "clean-arch-gin/internal/domain/user/repositories"
 	userUsecases "clean-arch-gin/internal/domain/user/usecases"
@@ -19,18 +24,14 @@ func NewUserUseCase(userRepo userRepositories.UserRepository) userUsecases.UserU
 }
 
 // CreateUser creates a new user
-func (uc *userUseCase) CreateUser(email, name, password string) (*userEntities.User, error) {
+func (uc *userUseCase) CreateUser(ctx context.Context, email, name, password string) (*userEntities.User, error) {
 	// Business logic validation
 	if email == "" || name == "" || password == "" {
 		return nil, userEntities.ErrInvalidEmail
 	}
 
 	// Check if user already exists
-	_, err := uc.userRepo.GetByEmail(email)
-	if err == nil {
-		return nil, userEntities.ErrEmailExists
-	}
-	if err != userEntities.ErrUserNotFound {
+	if err := userRepositories.CheckEmailAvailable(ctx, uc.userRepo, nil, email); err != nil {
 		return nil, err
 	}
 
@@ -41,7 +42,7 @@ func (uc *userUseCase) CreateUser(email, name, password string) (*userEntities.U
 	}
 
 	// Persist user
-	if err := uc.userRepo.Create(user); err != nil {
+	if err := uc.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
@@ -49,25 +50,33 @@ func (uc *userUseCase) CreateUser(email, name, password string) (*userEntities.U
 }
 
 // GetUser retrieves a user by ID
-func (uc *userUseCase) GetUser(id uint) (*userEntities.User, error) {
-	return uc.userRepo.GetByID(id)
+func (uc *userUseCase) GetUser(ctx context.Context, id uint) (*userEntities.User, error) {
+	return uc.userRepo.GetByID(ctx, id)
+}
+
+// GetUserByPublicID retrieves a user by public ID
+func (uc *userUseCase) GetUserByPublicID(ctx context.Context, publicID string) (*userEntities.User, error) {
+	return uc.userRepo.GetByPublicID(ctx, publicID)
 }
 
 // GetUsers retrieves all users with pagination
-func (uc *userUseCase) GetUsers(limit, offset int) ([]*userEntities.User, error) {
-	return uc.userRepo.GetAll(limit, offset)
+func (uc *userUseCase) GetUsers(ctx context.Context, limit, offset int) ([]*userEntities.User, error) {
+	return uc.userRepo.GetAll(ctx, limit, offset)
 }
 
-// UpdateUser updates user information
-func (uc *userUseCase) UpdateUser(id uint, email, name string) (*userEntities.User, error) {
-	user, err := uc.userRepo.GetByID(id)
+// UpdateUser updates user information; an email taken by another user is rejected
+func (uc *userUseCase) UpdateUser(ctx context.Context, id uint, email, name string) (*userEntities.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := userRepositories.CheckEmailAvailable(ctx, uc.userRepo, user, email); err != nil {
+		return nil, err
+	}
 
 	user.UpdateInfo(name, email)
 
-	if err := uc.userRepo.Update(user); err != nil {
+	if err := uc.userRepo.Update(ctx, user); err != nil {
 		return nil, err
 	}
 
@@ -75,6 +84,6 @@ func (uc *userUseCase) UpdateUser(id uint, email, name string) (*userEntities.Us
 }
 
 // DeleteUser soft deletes a user
-func (uc *userUseCase) DeleteUser(id uint) error {
-	return uc.userRepo.Delete(id)
+func (uc *userUseCase) DeleteUser(ctx context.Context, id uint) error {
+	return uc.userRepo.Delete(ctx, id)
 }
@@ -0,0 +1,39 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"clean-arch-gin/internal/adapters/repositories"
+	"clean-arch-gin/internal/adapters/usecases"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	"clean-arch-gin/internal/testutil/repotest"
+)
+
+func TestUserUseCaseEmailConflicts(t *testing.T) {
+	ctx := context.Background()
+	uc := usecases.NewUserUseCase(repositories.NewUserRepository(repotest.OpenSQLite(t)))
+
+	alice, err := uc.CreateUser(ctx, "alice@example.com", "Alice", "password123")
+	if err != nil {
+		t.Fatalf("CreateUser returned error: %v", err)
+	}
+	if _, err := uc.CreateUser(ctx, "bob@example.com", "Bob", "password123"); err != nil {
+		t.Fatalf("CreateUser returned error: %v", err)
+	}
+
+	if _, err := uc.CreateUser(ctx, "alice@example.com", "Another Alice", "password123"); err != userEntities.ErrEmailExists {
+		t.Errorf("CreateUser(taken email) error = %v, want %v", err, userEntities.ErrEmailExists)
+	}
+	if _, err := uc.UpdateUser(ctx, alice.ID, "bob@example.com", "Alice"); err != userEntities.ErrEmailExists {
+		t.Errorf("UpdateUser(another user's email) error = %v, want %v", err, userEntities.ErrEmailExists)
+	}
+
+	updated, err := uc.UpdateUser(ctx, alice.ID, "alice@example.com", "Alice Smith")
+	if err != nil {
+		t.Fatalf("UpdateUser(own unchanged email) returned error: %v", err)
+	}
+	if updated.Name != "Alice Smith" {
+		t.Errorf("UpdateUser(own unchanged email) name = %q, want %q", updated.Name, "Alice Smith")
+	}
+}
@@ -0,0 +1,140 @@
+// Package activity turns domain events into entries of the users' activity feeds
+package activity
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	orderEvents "clean-arch-gin/internal/domain/order/events"
+	"clean-arch-gin/internal/domain/shared/events"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userEvents "clean-arch-gin/internal/domain/user/events"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// Mapper turns an event into the activities to record; none means nothing is recorded
+type Mapper func(event events.DomainEvent) []*userEntities.Activity
+
+// Recorder subscribes to domain events and records the activities their mapper produces.
+// Failures are logged: a lost activity never fails the operation that published the event
+type Recorder struct {
+	activities userUsecases.ActivityUseCase
+
+	mu      sync.RWMutex
+	mappers map[string]Mapper
+}
+
+// NewRecorder creates a recorder with the mappers of the built-in events
+func NewRecorder(activities userUsecases.ActivityUseCase) *Recorder {
+	r := &Recorder{
+		activities: activities,
+		mappers:    make(map[string]Mapper),
+	}
+	r.Register(userEvents.UserLoggedInEventName, mapLoggedIn)
+	r.Register(userEvents.UserProfileUpdatedEventName, mapProfileUpdated)
+	r.Register(orderEvents.OrderStatusChangedEventName, mapOrderStatusChanged)
+	return r
+}
+
+// Register sets the mapper of an event, replacing any previous one
+// Call it before Subscribe; events registered later are not subscribed to
+func (r *Recorder) Register(eventName string, mapper Mapper) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mappers[eventName] = mapper
+}
+
+// Subscribe listens for every event with a mapper and returns the unsubscribe function
+func (r *Recorder) Subscribe(subscriber events.EventSubscriber) func() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	unsubscribes := make([]func(), 0, len(r.mappers))
+	for eventName := range r.mappers {
+		unsubscribes = append(unsubscribes, subscriber.Subscribe(eventName, r.HandleEvent))
+	}
+	return func() {
+		for _, unsubscribe := range unsubscribes {
+			unsubscribe()
+		}
+	}
+}
+
+// HandleEvent records the activities mapped from event in the tenant of ctx
+func (r *Recorder) HandleEvent(ctx context.Context, event events.DomainEvent) {
+	r.mu.RLock()
+	mapper, ok := r.mappers[event.EventName()]
+	r.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	for _, activity := range mapper(event) {
+		if subject, ok := event.(events.SubjectProvider); ok && activity.Subject == "" {
+			activity.Subject = subject.EventSubject()
+		}
+		if err := r.activities.Record(ctx, activity); err != nil {
+			log.Printf("activity: failed to record %s for user %d: %v", event.EventName(), activity.UserID, err)
+		}
+	}
+}
+
+// newActivity creates the activity of event, or nil for an event without a user
+func newActivity(event events.DomainEvent, userID uint, summary string) *userEntities.Activity {
+	activity, err := userEntities.NewActivity(userID, event.EventName(), summary, event.OccurredOn())
+	if err != nil {
+		return nil
+	}
+	return activity
+}
+
+// mapLoggedIn records a sign-in with where it came from
+func mapLoggedIn(event events.DomainEvent) []*userEntities.Activity {
+	loggedIn, ok := event.(userEvents.UserLoggedInEvent)
+	if !ok {
+		return nil
+	}
+	activity := newActivity(event, loggedIn.UserID, "Signed in")
+	if activity == nil {
+		return nil
+	}
+	activity.IPAddress = loggedIn.IPAddress
+	activity.UserAgent = loggedIn.UserAgent
+	return []*userEntities.Activity{activity}
+}
+
+// mapProfileUpdated records which profile fields changed
+func mapProfileUpdated(event events.DomainEvent) []*userEntities.Activity {
+	updated, ok := event.(userEvents.UserProfileUpdatedEvent)
+	if !ok || len(updated.Fields) == 0 {
+		return nil
+	}
+	activity := newActivity(event, updated.UserID, "Updated "+strings.Join(updated.Fields, ", "))
+	if activity == nil {
+		return nil
+	}
+	activity.Metadata = map[string]interface{}{"fields": updated.Fields}
+	return []*userEntities.Activity{activity}
+}
+
+// mapOrderStatusChanged records an order moving to a new status for the order's owner
+func mapOrderStatusChanged(event events.DomainEvent) []*userEntities.Activity {
+	changed, ok := event.(orderEvents.OrderStatusChangedEvent)
+	if !ok {
+		return nil
+	}
+	summary := "Order #" + strconv.FormatUint(uint64(changed.OrderID), 10) + " " + string(changed.To)
+	activity := newActivity(event, changed.UserID, summary)
+	if activity == nil {
+		return nil
+	}
+	activity.Metadata = map[string]interface{}{
+		"order_id": changed.OrderID,
+		"from":     changed.From,
+		"to":       changed.To,
+	}
+	return []*userEntities.Activity{activity}
+}
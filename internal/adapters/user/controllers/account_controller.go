@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"clean-arch-gin/internal/adapters/shared/responses"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordResetRequest asks for a password reset link
+type PasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email,max=255"`
+}
+
+// ResetPasswordRequest sets a new password with the token of a reset link
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,max=72"`
+}
+
+// VerifyEmailRequest confirms an email with the token of a verification link
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// AccountController handles HTTP requests for password resets and email verification
+type AccountController struct {
+	accountUseCase userUsecases.AccountUseCase
+}
+
+// NewAccountController creates a new account controller
+func NewAccountController(accountUseCase userUsecases.AccountUseCase) *AccountController {
+	return &AccountController{
+		accountUseCase: accountUseCase,
+	}
+}
+
+// RequestPasswordReset mails a reset link; the response is the same whether or not the
+// email belongs to an account
+func (ac *AccountController) RequestPasswordReset(c *gin.Context) {
+	var req PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := ac.accountUseCase.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "If the email belongs to an account, a reset link is on its way"})
+}
+
+// ResetPassword sets a new password with the token of a reset link
+func (ac *AccountController) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := ac.accountUseCase.ResetPassword(clientContext(c), req.Token, req.Password); err != nil {
+		respondAccountError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SendVerification mails the authenticated user a link confirming their email
+func (ac *AccountController) SendVerification(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := ac.accountUseCase.SendVerification(c.Request.Context(), userID); err != nil {
+		respondAccountError(c, err)
+		return
+	}
+	c.Status(http.StatusAccepted)
+}
+
+// VerifyEmail confirms an email with the token of a verification link
+func (ac *AccountController) VerifyEmail(c *gin.Context) {
+	var req VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := ac.accountUseCase.VerifyEmail(c.Request.Context(), req.Token)
+	if err != nil {
+		respondAccountError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toDTO(user))
+}
+
+// respondAccountError maps account errors: a suspended account is 403, an unknown user 404
+// and any other domain error, such as a bad token, a bad request
+func respondAccountError(c *gin.Context, err error) {
+	var domainErr sharedEntities.DomainError
+	switch {
+	case err == userEntities.ErrUserSuspended:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case err == userEntities.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
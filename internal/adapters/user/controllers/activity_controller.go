@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActivityDTO represents an activity as admins see it
+type ActivityDTO struct {
+	ID         uint                   `json:"id"`
+	UserID     uint                   `json:"user_id"`
+	Type       string                 `json:"type"`
+	Summary    string                 `json:"summary"`
+	Subject    string                 `json:"subject,omitempty"`
+	IPAddress  string                 `json:"ip_address,omitempty"`
+	UserAgent  string                 `json:"user_agent,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// OwnActivityDTO represents an activity in the user's own feed
+// The IP address is reduced to its network, so a leaked session does not reveal where the
+// user has been
+type OwnActivityDTO struct {
+	ID         uint                   `json:"id"`
+	Type       string                 `json:"type"`
+	Summary    string                 `json:"summary"`
+	Subject    string                 `json:"subject,omitempty"`
+	IPNetwork  string                 `json:"ip_network,omitempty"`
+	UserAgent  string                 `json:"user_agent,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// ActivityListResponse represents a page of a user's activity as admins see it
+type ActivityListResponse struct {
+	Activities []ActivityDTO `json:"activities"`
+	Total      int64         `json:"total"`
+	Limit      int           `json:"limit"`
+	Offset     int           `json:"offset"`
+}
+
+// OwnActivityListResponse represents a page of the user's own activity
+type OwnActivityListResponse struct {
+	Activities []OwnActivityDTO `json:"activities"`
+	Total      int64            `json:"total"`
+	Limit      int              `json:"limit"`
+	Offset     int              `json:"offset"`
+}
+
+// toActivityDTO converts domain entity to the admin DTO
+func toActivityDTO(activity *userEntities.Activity) ActivityDTO {
+	return ActivityDTO{
+		ID:         activity.ID,
+		UserID:     activity.UserID,
+		Type:       activity.Type,
+		Summary:    activity.Summary,
+		Subject:    activity.Subject,
+		IPAddress:  activity.IPAddress,
+		UserAgent:  activity.UserAgent,
+		Metadata:   activity.Metadata,
+		OccurredAt: activity.OccurredAt,
+	}
+}
+
+// toOwnActivityDTO converts domain entity to the self-view DTO
+func toOwnActivityDTO(activity *userEntities.Activity) OwnActivityDTO {
+	return OwnActivityDTO{
+		ID:         activity.ID,
+		Type:       activity.Type,
+		Summary:    activity.Summary,
+		Subject:    activity.Subject,
+		IPNetwork:  activity.MaskedIP(),
+		UserAgent:  activity.UserAgent,
+		Metadata:   activity.Metadata,
+		OccurredAt: activity.OccurredAt,
+	}
+}
+
+// ActivityController handles HTTP requests for user activity feeds
+type ActivityController struct {
+	activityUseCase userUsecases.ActivityUseCase
+}
+
+// NewActivityController creates a new activity controller
+func NewActivityController(activityUseCase userUsecases.ActivityUseCase) *ActivityController {
+	return &ActivityController{
+		activityUseCase: activityUseCase,
+	}
+}
+
+// GetOwnActivity retrieves a page of the authenticated user's activity, most recent first
+func (ac *ActivityController) GetOwnActivity(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	activities, total, page, ok := ac.listActivity(c, userID)
+	if !ok {
+		return
+	}
+
+	dtos := make([]OwnActivityDTO, len(activities))
+	for i, activity := range activities {
+		dtos[i] = toOwnActivityDTO(activity)
+	}
+	c.JSON(http.StatusOK, OwnActivityListResponse{
+		Activities: dtos,
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+	})
+}
+
+// GetUserActivity retrieves a page of the :id user's activity for admins, most recent first
+func (ac *ActivityController) GetUserActivity(c *gin.Context) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	activities, total, page, ok := ac.listActivity(c, id)
+	if !ok {
+		return
+	}
+
+	dtos := make([]ActivityDTO, len(activities))
+	for i, activity := range activities {
+		dtos[i] = toActivityDTO(activity)
+	}
+	c.JSON(http.StatusOK, ActivityListResponse{
+		Activities: dtos,
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+	})
+}
+
+// listActivity reads the requested page of userID's activity, responding on failure
+func (ac *ActivityController) listActivity(c *gin.Context, userID uint) ([]*userEntities.Activity, int64, params.Pagination, bool) {
+	page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, 0, page, false
+	}
+
+	activities, total, err := ac.activityUseCase.ListActivity(c.Request.Context(), userID, page.Limit, page.Offset)
+	if err != nil {
+		responses.InternalError(c, err)
+		return nil, 0, page, false
+	}
+	return activities, total, page, true
+}
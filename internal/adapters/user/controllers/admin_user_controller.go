@@ -0,0 +1,329 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/domain/shared/authz"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminUserDTO is a user as admins see it, with the account's role and status
+type AdminUserDTO struct {
+	UserDTO
+	Role                  userEntities.Role   `json:"role"`
+	Status                userEntities.Status `json:"status"`
+	PasswordResetRequired bool                `json:"password_reset_required"`
+	// EmailUndeliverableReason is the bounce or complaint the email was marked undeliverable for
+	EmailUndeliverableReason string `json:"email_undeliverable_reason,omitempty"`
+	// Phone is where the user is texted, SMSOptedOut set once it replied STOP
+	Phone       string `json:"phone,omitempty"`
+	SMSOptedOut bool   `json:"sms_opted_out"`
+}
+
+// AdminActionRequest carries the reason recorded in the audit log
+type AdminActionRequest struct {
+	Reason string `json:"reason" binding:"max=512"`
+}
+
+// UpdateStatusRequest suspends or reactivates an account
+type UpdateStatusRequest struct {
+	Status userEntities.Status `json:"status" binding:"required,oneof=active suspended"`
+	Reason string              `json:"reason" binding:"max=512"`
+}
+
+// UpdateRoleRequest assigns a role
+type UpdateRoleRequest struct {
+	Role   userEntities.Role `json:"role" binding:"required,oneof=user admin"`
+	Reason string            `json:"reason" binding:"max=512"`
+}
+
+// AuditEntryDTO represents an audit log entry in API responses
+type AuditEntryDTO struct {
+	ID           uint                                `json:"id"`
+	ActorID      uint                                `json:"actor_id"`
+	TargetUserID uint                                `json:"target_user_id"`
+	Action       userEntities.AuditAction            `json:"action"`
+	Changes      map[string]userEntities.AuditChange `json:"changes,omitempty"`
+	Reason       string                              `json:"reason,omitempty"`
+	CreatedAt    time.Time                           `json:"created_at"`
+}
+
+// AuditLogResponse represents a page of a user's audit log
+type AuditLogResponse struct {
+	Entries []AuditEntryDTO `json:"entries"`
+	Total   int64           `json:"total"`
+	Limit   int             `json:"limit"`
+	Offset  int             `json:"offset"`
+}
+
+// toAdminUserDTO converts domain entity to the admin DTO
+func toAdminUserDTO(user *userEntities.User) AdminUserDTO {
+	return AdminUserDTO{
+		UserDTO:                  toDTO(user),
+		Role:                     user.Role,
+		Status:                   user.Status,
+		PasswordResetRequired:    user.PasswordResetRequired,
+		EmailUndeliverableReason: user.EmailUndeliverableReason,
+		Phone:                    user.Phone,
+		SMSOptedOut:              user.SMSOptedOutAt != nil,
+	}
+}
+
+// toAuditEntryDTO converts domain entity to DTO
+func toAuditEntryDTO(entry *userEntities.AuditEntry) AuditEntryDTO {
+	return AuditEntryDTO{
+		ID:           entry.ID,
+		ActorID:      entry.ActorID,
+		TargetUserID: entry.TargetUserID,
+		Action:       entry.Action,
+		Changes:      entry.Changes,
+		Reason:       entry.Reason,
+		CreatedAt:    entry.CreatedAt,
+	}
+}
+
+// AdminUserController handles the account management requests of admins
+// The routes are restricted to admins by the auth middleware; the acting admin is taken
+// from the auth context and recorded in the audit log
+type AdminUserController struct {
+	adminUseCase userUsecases.AdminUserUseCase
+}
+
+// NewAdminUserController creates a new admin user controller
+func NewAdminUserController(adminUseCase userUsecases.AdminUserUseCase) *AdminUserController {
+	return &AdminUserController{
+		adminUseCase: adminUseCase,
+	}
+}
+
+// UpdateUser changes a user's name or email
+func (ac *AdminUserController) UpdateUser(c *gin.Context) {
+	actor, id, ok := adminTarget(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := ac.adminUseCase.UpdateUser(c.Request.Context(), actor, id, req.Email, req.Name)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAdminUserDTO(user))
+}
+
+// DeleteUser soft deletes a user; the optional body carries the reason
+func (ac *AdminUserController) DeleteUser(c *gin.Context) {
+	actor, id, ok := adminTarget(c)
+	if !ok {
+		return
+	}
+
+	var req AdminActionRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	if err := ac.adminUseCase.DeleteUser(c.Request.Context(), actor, id, req.Reason); err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreUser undeletes a soft-deleted user; the optional body carries the reason
+func (ac *AdminUserController) RestoreUser(c *gin.Context) {
+	actor, id, ok := adminTarget(c)
+	if !ok {
+		return
+	}
+
+	var req AdminActionRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	user, err := ac.adminUseCase.RestoreUser(c.Request.Context(), actor, id, req.Reason)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAdminUserDTO(user))
+}
+
+// PurgeUser permanently deletes a user and its data; the optional body carries the reason
+func (ac *AdminUserController) PurgeUser(c *gin.Context) {
+	actor, id, ok := adminTarget(c)
+	if !ok {
+		return
+	}
+
+	var req AdminActionRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	if err := ac.adminUseCase.PurgeUser(c.Request.Context(), actor, id, req.Reason); err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateStatus suspends or reactivates an account
+func (ac *AdminUserController) UpdateStatus(c *gin.Context) {
+	actor, id, ok := adminTarget(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := ac.adminUseCase.SetStatus(c.Request.Context(), actor, id, req.Status, req.Reason)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAdminUserDTO(user))
+}
+
+// UpdateRole assigns a role
+func (ac *AdminUserController) UpdateRole(c *gin.Context) {
+	actor, id, ok := adminTarget(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := ac.adminUseCase.SetRole(c.Request.Context(), actor, id, req.Role, req.Reason)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAdminUserDTO(user))
+}
+
+// ForcePasswordReset makes the user change their password; the optional body carries the reason
+func (ac *AdminUserController) ForcePasswordReset(c *gin.Context) {
+	actor, id, ok := adminTarget(c)
+	if !ok {
+		return
+	}
+
+	var req AdminActionRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	user, err := ac.adminUseCase.ForcePasswordReset(c.Request.Context(), actor, id, req.Reason)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAdminUserDTO(user))
+}
+
+// GetAuditLog lists the admin actions on a user, newest first
+func (ac *AdminUserController) GetAuditLog(c *gin.Context) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, total, err := ac.adminUseCase.AuditLog(c.Request.Context(), id, page.Limit, page.Offset)
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+
+	dtos := make([]AuditEntryDTO, len(entries))
+	for i, entry := range entries {
+		dtos[i] = toAuditEntryDTO(entry)
+	}
+	c.JSON(http.StatusOK, AuditLogResponse{
+		Entries: dtos,
+		Total:   total,
+		Limit:   page.Limit,
+		Offset:  page.Offset,
+	})
+}
+
+// adminTarget returns the acting admin and the :id user, responding when either is missing
+func adminTarget(c *gin.Context) (authz.Actor, uint, bool) {
+	if _, ok := currentUserID(c); !ok {
+		return authz.Actor{}, 0, false
+	}
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return authz.Actor{}, 0, false
+	}
+	return middleware.CurrentActor(c), id, true
+}
+
+// bindOptionalJSON binds a JSON body if there is one, responding 400 when it is invalid
+func bindOptionalJSON(c *gin.Context, req interface{}) bool {
+	if c.Request.ContentLength == 0 {
+		return true
+	}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+// respondAdminError maps admin errors: a missing user is 404, a taken email or restoring a
+// user that is not deleted 409, acting on one's own account or against the policy 403 and
+// any other domain error a bad request
+func respondAdminError(c *gin.Context, err error) {
+	var domainErr sharedEntities.DomainError
+	switch {
+	case err == userEntities.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err == userEntities.ErrEmailExists || err == userEntities.ErrUserNotDeleted:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case err == userEntities.ErrSelfModeration || err == authz.ErrForbidden:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
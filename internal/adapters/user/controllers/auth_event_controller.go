@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthEventDTO represents an authentication event as admins see it
+type AuthEventDTO struct {
+	ID         uint      `json:"id"`
+	UserID     uint      `json:"user_id,omitempty"`
+	SessionID  uint      `json:"session_id,omitempty"`
+	Type       string    `json:"type"`
+	Reason     string    `json:"reason,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OwnAuthEventDTO represents an authentication event in the user's recent activity
+// As in the activity feed, the IP address is reduced to its network
+type OwnAuthEventDTO struct {
+	ID         uint      `json:"id"`
+	Type       string    `json:"type"`
+	Reason     string    `json:"reason,omitempty"`
+	IPNetwork  string    `json:"ip_network,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// AuthEventListResponse represents a page of authentication events as admins see it
+type AuthEventListResponse struct {
+	Events []AuthEventDTO `json:"events"`
+	Total  int64          `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// OwnAuthEventListResponse represents a page of the user's own authentication events
+type OwnAuthEventListResponse struct {
+	Events []OwnAuthEventDTO `json:"events"`
+	Total  int64             `json:"total"`
+	Limit  int               `json:"limit"`
+	Offset int               `json:"offset"`
+}
+
+// toAuthEventDTO converts domain entity to the admin DTO
+func toAuthEventDTO(event *userEntities.AuthEvent) AuthEventDTO {
+	return AuthEventDTO{
+		ID:         event.ID,
+		UserID:     event.UserID,
+		SessionID:  event.SessionID,
+		Type:       string(event.Type),
+		Reason:     event.Reason,
+		IPAddress:  event.IPAddress,
+		UserAgent:  event.UserAgent,
+		OccurredAt: event.OccurredAt,
+	}
+}
+
+// toOwnAuthEventDTO converts domain entity to the self-view DTO
+func toOwnAuthEventDTO(event *userEntities.AuthEvent) OwnAuthEventDTO {
+	return OwnAuthEventDTO{
+		ID:         event.ID,
+		Type:       string(event.Type),
+		Reason:     event.Reason,
+		IPNetwork:  event.MaskedIP(),
+		UserAgent:  event.UserAgent,
+		OccurredAt: event.OccurredAt,
+	}
+}
+
+// AuthEventController handles HTTP requests for the authentication audit trail
+type AuthEventController struct {
+	authEventUseCase userUsecases.AuthEventUseCase
+}
+
+// NewAuthEventController creates a new authentication audit trail controller
+func NewAuthEventController(authEventUseCase userUsecases.AuthEventUseCase) *AuthEventController {
+	return &AuthEventController{
+		authEventUseCase: authEventUseCase,
+	}
+}
+
+// GetOwnAuthEvents retrieves a page of the authenticated user's sign-ins and sign-outs,
+// most recent first, filtered by type and since
+func (ac *AuthEventController) GetOwnAuthEvents(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	filter, ok := parseAuthEventFilter(c)
+	if !ok {
+		return
+	}
+	filter.UserID = userID
+
+	events, total, page, ok := ac.listAuthEvents(c, filter)
+	if !ok {
+		return
+	}
+
+	dtos := make([]OwnAuthEventDTO, len(events))
+	for i, event := range events {
+		dtos[i] = toOwnAuthEventDTO(event)
+	}
+	c.JSON(http.StatusOK, OwnAuthEventListResponse{
+		Events: dtos,
+		Total:  total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+}
+
+// GetAuthEvents retrieves a page of the tenant's authentication events for admins, most
+// recent first, filtered by user_id, type and since; failed sign-ins to unknown accounts
+// have no user
+func (ac *AuthEventController) GetAuthEvents(c *gin.Context) {
+	filter, ok := parseAuthEventFilter(c)
+	if !ok {
+		return
+	}
+	if raw := c.Query("user_id"); raw != "" {
+		userID, err := params.ParseID(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+			return
+		}
+		filter.UserID = userID
+	}
+
+	events, total, page, ok := ac.listAuthEvents(c, filter)
+	if !ok {
+		return
+	}
+
+	dtos := make([]AuthEventDTO, len(events))
+	for i, event := range events {
+		dtos[i] = toAuthEventDTO(event)
+	}
+	c.JSON(http.StatusOK, AuthEventListResponse{
+		Events: dtos,
+		Total:  total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+}
+
+// parseAuthEventFilter reads the type and since (RFC 3339) filters, responding 400 when
+// one is invalid
+func parseAuthEventFilter(c *gin.Context) (userEntities.AuthEventFilter, bool) {
+	var filter userEntities.AuthEventFilter
+	if raw := c.Query("type"); raw != "" {
+		eventType, err := userEntities.ParseAuthEventType(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return filter, false
+		}
+		filter.Type = eventType
+	}
+	if raw := c.Query("since"); raw != "" {
+		since, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 time"})
+			return filter, false
+		}
+		filter.Since = since
+	}
+	return filter, true
+}
+
+// listAuthEvents reads the requested page of the events matching filter, responding on failure
+func (ac *AuthEventController) listAuthEvents(c *gin.Context, filter userEntities.AuthEventFilter) ([]*userEntities.AuthEvent, int64, params.Pagination, bool) {
+	page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, 0, page, false
+	}
+
+	events, total, err := ac.authEventUseCase.ListAuthEvents(c.Request.Context(), filter, page.Limit, page.Offset)
+	if err != nil {
+		responses.InternalError(c, err)
+		return nil, 0, page, false
+	}
+	return events, total, page, true
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"clean-arch-gin/internal/adapters/shared/responses"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxAvatarUploadBytes caps the multipart request: the image plus room for the form encoding
+const maxAvatarUploadBytes = userEntities.MaxAvatarBytes + 64<<10
+
+// AvatarController handles HTTP requests for profile pictures
+type AvatarController struct {
+	avatarUseCase userUsecases.AvatarUseCase
+}
+
+// NewAvatarController creates a new avatar controller
+func NewAvatarController(avatarUseCase userUsecases.AvatarUseCase) *AvatarController {
+	return &AvatarController{
+		avatarUseCase: avatarUseCase,
+	}
+}
+
+// UploadAvatar replaces the authenticated user's avatar with the multipart "avatar" image
+// JPEG, PNG and GIF images are accepted and stored as square JPEGs; the response is the
+// user with the new avatar_url
+func (ac *AvatarController) UploadAvatar(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxAvatarUploadBytes)
+	fileHeader, err := c.FormFile("avatar")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": userEntities.ErrAvatarTooLarge.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "An image upload in the \"avatar\" field is required"})
+		return
+	}
+	if fileHeader.Size > userEntities.MaxAvatarBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": userEntities.ErrAvatarTooLarge.Error()})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	user, err := ac.avatarUseCase.UploadAvatar(c.Request.Context(), userID, file)
+	if err != nil {
+		respondAvatarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toDTO(user))
+}
+
+// respondAvatarError maps avatar errors: a missing user is 404, an oversized image 413 and
+// any other domain error an unusable image
+func respondAvatarError(c *gin.Context, err error) {
+	var domainErr sharedEntities.DomainError
+	switch {
+	case err == userEntities.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err == userEntities.ErrAvatarTooLarge:
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
@@ -0,0 +1,246 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConnectionDTO represents a user as other users see it on connection lists and timelines;
+// the email stays private
+type ConnectionDTO struct {
+	// ID is the user's public ID
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+// ConnectionCountsDTO represents how many users follow a user and how many it follows
+type ConnectionCountsDTO struct {
+	Followers int64 `json:"followers"`
+	Following int64 `json:"following"`
+}
+
+// ConnectionListResponse represents a page of one side of a user's connections
+type ConnectionListResponse struct {
+	Type   userEntities.ConnectionType `json:"type"`
+	Users  []ConnectionDTO             `json:"users"`
+	Counts ConnectionCountsDTO         `json:"counts"`
+	Limit  int                         `json:"limit"`
+	Offset int                         `json:"offset"`
+}
+
+// FollowStatusResponse represents whether the authenticated user follows a user
+type FollowStatusResponse struct {
+	Following bool `json:"following"`
+}
+
+// TimelineEntryDTO represents an activity of a followed user
+type TimelineEntryDTO struct {
+	ID         uint                   `json:"id"`
+	User       ConnectionDTO          `json:"user"`
+	Type       string                 `json:"type"`
+	Summary    string                 `json:"summary"`
+	Subject    string                 `json:"subject,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// TimelineResponse represents a page of a timeline
+type TimelineResponse struct {
+	Entries []TimelineEntryDTO `json:"entries"`
+	Total   int64              `json:"total"`
+	Limit   int                `json:"limit"`
+	Offset  int                `json:"offset"`
+}
+
+// toConnectionDTO converts domain entity to the DTO other users see
+func toConnectionDTO(user *userEntities.User) ConnectionDTO {
+	return ConnectionDTO{
+		ID:        user.PublicID,
+		Name:      user.Name,
+		AvatarURL: user.AvatarURL,
+	}
+}
+
+// toTimelineEntryDTO converts domain entity to DTO
+func toTimelineEntryDTO(entry *userEntities.TimelineEntry) TimelineEntryDTO {
+	return TimelineEntryDTO{
+		ID:         entry.Activity.ID,
+		User:       toConnectionDTO(entry.User),
+		Type:       entry.Activity.Type,
+		Summary:    entry.Activity.Summary,
+		Subject:    entry.Activity.Subject,
+		Metadata:   entry.Activity.Metadata,
+		OccurredAt: entry.Activity.OccurredAt,
+	}
+}
+
+// ConnectionController handles HTTP requests for users following each other and their
+// timelines
+type ConnectionController struct {
+	connectionUseCase userUsecases.ConnectionUseCase
+}
+
+// NewConnectionController creates a new connection controller
+func NewConnectionController(connectionUseCase userUsecases.ConnectionUseCase) *ConnectionController {
+	return &ConnectionController{
+		connectionUseCase: connectionUseCase,
+	}
+}
+
+// Follow makes the authenticated user follow the :id user
+func (cc *ConnectionController) Follow(c *gin.Context) {
+	followerID, followeeID, ok := followPair(c)
+	if !ok {
+		return
+	}
+	if err := cc.connectionUseCase.Follow(c.Request.Context(), followerID, followeeID); err != nil {
+		respondConnectionError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Unfollow makes the authenticated user stop following the :id user
+func (cc *ConnectionController) Unfollow(c *gin.Context) {
+	followerID, followeeID, ok := followPair(c)
+	if !ok {
+		return
+	}
+	if err := cc.connectionUseCase.Unfollow(c.Request.Context(), followerID, followeeID); err != nil {
+		respondConnectionError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetFollowStatus tells whether the authenticated user follows the :id user
+func (cc *ConnectionController) GetFollowStatus(c *gin.Context) {
+	followerID, followeeID, ok := followPair(c)
+	if !ok {
+		return
+	}
+	following, err := cc.connectionUseCase.IsFollowing(c.Request.Context(), followerID, followeeID)
+	if err != nil {
+		respondConnectionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, FollowStatusResponse{Following: following})
+}
+
+// ListConnections retrieves a page of the users following the :id user, or with
+// ?type=following the users it follows, most recently connected first
+func (cc *ConnectionController) ListConnections(c *gin.Context) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	side := userEntities.ConnectionType(c.DefaultQuery("type", string(userEntities.ConnectionFollowers)))
+
+	users, counts, err := cc.connectionUseCase.ListConnections(c.Request.Context(), id, side, page.Limit, page.Offset)
+	if err != nil {
+		respondConnectionError(c, err)
+		return
+	}
+
+	dtos := make([]ConnectionDTO, len(users))
+	for i, user := range users {
+		dtos[i] = toConnectionDTO(user)
+	}
+	c.JSON(http.StatusOK, ConnectionListResponse{
+		Type:   side,
+		Users:  dtos,
+		Counts: ConnectionCountsDTO{Followers: counts.Followers, Following: counts.Following},
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+}
+
+// GetOwnTimeline retrieves a page of the activity of the users the authenticated user
+// follows, most recent first
+func (cc *ConnectionController) GetOwnTimeline(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	cc.respondTimeline(c, userID)
+}
+
+// GetUserTimeline retrieves a page of the activity of the users the :id user follows, most
+// recent first; it shows nothing the followed users do not share with every follower
+func (cc *ConnectionController) GetUserTimeline(c *gin.Context) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	cc.respondTimeline(c, id)
+}
+
+// respondTimeline responds with the requested page of userID's timeline
+func (cc *ConnectionController) respondTimeline(c *gin.Context, userID uint) {
+	page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, total, err := cc.connectionUseCase.GetTimeline(c.Request.Context(), userID, page.Limit, page.Offset)
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+
+	dtos := make([]TimelineEntryDTO, len(entries))
+	for i, entry := range entries {
+		dtos[i] = toTimelineEntryDTO(entry)
+	}
+	c.JSON(http.StatusOK, TimelineResponse{
+		Entries: dtos,
+		Total:   total,
+		Limit:   page.Limit,
+		Offset:  page.Offset,
+	})
+}
+
+// followPair reads the authenticated user and the :id user, responding on failure
+func followPair(c *gin.Context) (uint, uint, bool) {
+	followerID, ok := currentUserID(c)
+	if !ok {
+		return 0, 0, false
+	}
+	followeeID, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return 0, 0, false
+	}
+	return followerID, followeeID, true
+}
+
+// respondConnectionError maps connection errors to HTTP responses
+func respondConnectionError(c *gin.Context, err error) {
+	var domainErr sharedEntities.DomainError
+	switch {
+	case errors.Is(err, userEntities.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
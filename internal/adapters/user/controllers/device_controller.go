@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeviceDTO represents a device the user gets push notifications on
+type DeviceDTO struct {
+	ID        uint                        `json:"id"`
+	Token     string                      `json:"token"`
+	Platform  userEntities.DevicePlatform `json:"platform"`
+	Name      string                      `json:"name,omitempty"`
+	CreatedAt time.Time                   `json:"created_at"`
+	UpdatedAt time.Time                   `json:"updated_at"`
+}
+
+// RegisterDeviceRequest registers the push token the provider issued an app install
+type RegisterDeviceRequest struct {
+	Token    string                      `json:"token" binding:"required,max=512"`
+	Platform userEntities.DevicePlatform `json:"platform" binding:"required,oneof=android ios web"`
+	Name     string                      `json:"name" binding:"max=255"`
+}
+
+// DeviceListResponse represents the user's devices
+type DeviceListResponse struct {
+	Devices []DeviceDTO `json:"devices"`
+}
+
+// toDeviceDTO converts domain entity to DTO
+func toDeviceDTO(device *userEntities.Device) DeviceDTO {
+	return DeviceDTO{
+		ID:        device.ID,
+		Token:     device.Token,
+		Platform:  device.Platform,
+		Name:      device.Name,
+		CreatedAt: device.CreatedAt,
+		UpdatedAt: device.UpdatedAt,
+	}
+}
+
+// DeviceController handles HTTP requests for the devices users get push notifications on
+type DeviceController struct {
+	deviceUseCase userUsecases.DeviceUseCase
+}
+
+// NewDeviceController creates a new device controller
+func NewDeviceController(deviceUseCase userUsecases.DeviceUseCase) *DeviceController {
+	return &DeviceController{
+		deviceUseCase: deviceUseCase,
+	}
+}
+
+// RegisterDevice registers a push token of the authenticated user; apps register it on
+// every start, as providers rotate tokens
+func (dc *DeviceController) RegisterDevice(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req RegisterDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	device, err := dc.deviceUseCase.RegisterDevice(c.Request.Context(), userID, req.Token, req.Platform, req.Name)
+	if err != nil {
+		respondDeviceError(c, err)
+		return
+	}
+	middleware.AllowSensitiveFields(c, "token")
+	c.JSON(http.StatusCreated, toDeviceDTO(device))
+}
+
+// ListDevices lists the authenticated user's devices
+func (dc *DeviceController) ListDevices(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	devices, err := dc.deviceUseCase.ListDevices(c.Request.Context(), userID)
+	if err != nil {
+		respondDeviceError(c, err)
+		return
+	}
+	dtos := make([]DeviceDTO, len(devices))
+	for i, device := range devices {
+		dtos[i] = toDeviceDTO(device)
+	}
+	middleware.AllowSensitiveFields(c, "token")
+	c.JSON(http.StatusOK, DeviceListResponse{Devices: dtos})
+}
+
+// RemoveDevice stops push notifications to one of the authenticated user's devices, e.g.
+// when signing out of the app
+func (dc *DeviceController) RemoveDevice(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid device ID"})
+		return
+	}
+
+	if err := dc.deviceUseCase.RemoveDevice(c.Request.Context(), userID, id); err != nil {
+		respondDeviceError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// respondDeviceError maps device errors: an unknown device is 404 and any other domain
+// error a bad request
+func respondDeviceError(c *gin.Context, err error) {
+	var domainErr sharedEntities.DomainError
+	switch {
+	case err == userEntities.ErrDeviceNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/domain/shared/mail"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxFeedbackBody bounds a webhook request; providers batch events well below it
+const maxFeedbackBody = 1 << 20
+
+// EmailFeedbackController handles the bounce and complaint webhooks of mail providers
+type EmailFeedbackController struct {
+	deliverabilityUseCase userUsecases.DeliverabilityUseCase
+	// sources reads the webhook of each provider, by the name in its route
+	sources map[string]mail.FeedbackSource
+}
+
+// NewEmailFeedbackController creates a new email feedback controller for the webhooks of
+// sources, keyed by provider name
+func NewEmailFeedbackController(deliverabilityUseCase userUsecases.DeliverabilityUseCase, sources map[string]mail.FeedbackSource) *EmailFeedbackController {
+	return &EmailFeedbackController{
+		deliverabilityUseCase: deliverabilityUseCase,
+		sources:               sources,
+	}
+}
+
+// Receive marks the emails a provider's webhook reports bouncing or complained about
+// undeliverable; requests that are not authentic get 401, and failures 500 so the provider
+// retries them
+func (fc *EmailFeedbackController) Receive(c *gin.Context) {
+	source, ok := fc.sources[c.Param("provider")]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown mail provider"})
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxFeedbackBody))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "webhook body is too large"})
+		return
+	}
+
+	feedback, err := source.Parse(c.Request.Context(), c.Request.Header, body)
+	if err != nil {
+		if errors.Is(err, mail.ErrInvalidWebhook) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		responses.InternalError(c, err)
+		return
+	}
+	if err := fc.deliverabilityUseCase.RecordFeedback(c.Request.Context(), feedback); err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,23 @@
+package controllers
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=handlers.go -destination=../../../mocks/user_handlers_mock.go -package=mocks
+
+import "github.com/gin-gonic/gin"
+
+// UserHandlers are the handlers the user routes are registered against
+// UserController implements them on the user use case; routes take the interface so they
+// can be registered against fakes
+type UserHandlers interface {
+	CreateUser(c *gin.Context)
+	GetUser(c *gin.Context)
+	GetUsers(c *gin.Context)
+	UpdateUser(c *gin.Context)
+	DeleteUser(c *gin.Context)
+	// GetCurrentUser and UpdateCurrentUser act on the authenticated user
+	GetCurrentUser(c *gin.Context)
+	UpdateCurrentUser(c *gin.Context)
+	// RequireActiveAccount returns the middleware turning suspended users away
+	RequireActiveAccount() gin.HandlerFunc
+}
+
+var _ UserHandlers = (*UserController)(nil)
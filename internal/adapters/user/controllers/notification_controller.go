@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/domain/shared/pagination"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationDTO represents a notification in API responses
+type NotificationDTO struct {
+	ID        uint                   `json:"id"`
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Body      string                 `json:"body,omitempty"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Read      bool                   `json:"read"`
+	ReadAt    *time.Time             `json:"read_at,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// NotificationListResponse represents a page of notifications with the inbox counts
+type NotificationListResponse struct {
+	Notifications []NotificationDTO `json:"notifications"`
+	Total         int64             `json:"total"`
+	Unread        int64             `json:"unread"`
+	Limit         int               `json:"limit"`
+	// NextCursor requests the next page; it is omitted on the last one
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// UnreadCountResponse reports how many notifications are unread
+type UnreadCountResponse struct {
+	Unread int64 `json:"unread"`
+}
+
+// MarkAllReadResponse reports how many notifications were marked as read
+type MarkAllReadResponse struct {
+	Marked int64 `json:"marked"`
+}
+
+// toNotificationDTO converts domain entity to DTO
+func toNotificationDTO(notification *userEntities.Notification) NotificationDTO {
+	return NotificationDTO{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Data:      notification.Data,
+		Read:      notification.IsRead(),
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+// NotificationController handles HTTP requests for the authenticated user's notifications
+type NotificationController struct {
+	notificationUseCase userUsecases.NotificationUseCase
+}
+
+// NewNotificationController creates a new notification controller
+func NewNotificationController(notificationUseCase userUsecases.NotificationUseCase) *NotificationController {
+	return &NotificationController{
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+// GetNotifications retrieves a page of the user's notifications, newest first
+// ?unread=true lists only unread ones; the total and unread counts cover the whole inbox
+// The next page is requested with ?cursor= set to the next_cursor of the previous one, so
+// notifications arriving meanwhile do not shift the pages
+func (nc *NotificationController) GetNotifications(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	page, err := params.ParsePagination(c.Query("limit"), "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	cursor, err := pagination.Decode(c.Query("cursor"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	notifications, counts, err := nc.notificationUseCase.ListNotifications(c.Request.Context(), userID, c.Query("unread") == "true", cursor, page.Limit)
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+
+	dtos := make([]NotificationDTO, len(notifications))
+	for i, notification := range notifications {
+		dtos[i] = toNotificationDTO(notification)
+	}
+	response := NotificationListResponse{
+		Notifications: dtos,
+		Total:         counts.Total,
+		Unread:        counts.Unread,
+		Limit:         page.Limit,
+	}
+	if len(notifications) > 0 && len(notifications) == page.Limit {
+		last := notifications[len(notifications)-1]
+		response.NextCursor = pagination.After(last.CreatedAt, last.ID).Encode()
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetUnreadCount reports how many of the user's notifications are unread, e.g. for a badge;
+// the count is kept up to date rather than counted
+func (nc *NotificationController) GetUnreadCount(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	unread, err := nc.notificationUseCase.UnreadCount(c.Request.Context(), userID)
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, UnreadCountResponse{Unread: unread})
+}
+
+// MarkAsRead marks one of the user's notifications as read
+func (nc *NotificationController) MarkAsRead(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	notification, err := nc.notificationUseCase.MarkAsRead(c.Request.Context(), userID, id)
+	if err != nil {
+		respondNotificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toNotificationDTO(notification))
+}
+
+// MarkAllAsRead marks all the user's notifications as read
+func (nc *NotificationController) MarkAllAsRead(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	marked, err := nc.notificationUseCase.MarkAllAsRead(c.Request.Context(), userID)
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, MarkAllReadResponse{Marked: marked})
+}
+
+// DeleteNotification deletes one of the user's notifications
+func (nc *NotificationController) DeleteNotification(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	if err := nc.notificationUseCase.DeleteNotification(c.Request.Context(), userID, id); err != nil {
+		respondNotificationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondNotificationError maps notification errors to HTTP responses
+func respondNotificationError(c *gin.Context, err error) {
+	if err == userEntities.ErrNotificationNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	responses.InternalError(c, err)
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/domain/shared/templates"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationTemplateDTO describes a notification template
+type NotificationTemplateDTO struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Variables   map[string]string      `json:"variables"`
+	Channels    []templates.Channel    `json:"channels"`
+	Example     map[string]interface{} `json:"example"`
+	Locales     []string               `json:"locales"`
+}
+
+// NotificationTemplateListResponse lists the notification templates
+type NotificationTemplateListResponse struct {
+	Templates []NotificationTemplateDTO `json:"templates"`
+}
+
+// PreviewTemplateRequest renders a template; without variables the template's example is
+// rendered, without a channel every channel it has a variant for, and without a locale the
+// default one
+type PreviewTemplateRequest struct {
+	Channel   templates.Channel      `json:"channel,omitempty"`
+	Locale    string                 `json:"locale,omitempty"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// RenderedContentDTO represents a template rendered for one channel
+type RenderedContentDTO struct {
+	Channel templates.Channel `json:"channel"`
+	Title   string            `json:"title,omitempty"`
+	Body    string            `json:"body"`
+	HTML    string            `json:"html,omitempty"`
+}
+
+// PreviewTemplateResponse represents the renderings of a template
+type PreviewTemplateResponse struct {
+	Template string               `json:"template"`
+	Contents []RenderedContentDTO `json:"contents"`
+}
+
+// NotificationTemplateController handles HTTP requests previewing notification templates
+type NotificationTemplateController struct {
+	engine templates.Engine
+}
+
+// NewNotificationTemplateController creates a new notification template controller
+func NewNotificationTemplateController(engine templates.Engine) *NotificationTemplateController {
+	return &NotificationTemplateController{
+		engine: engine,
+	}
+}
+
+// ListTemplates lists the notification templates
+func (tc *NotificationTemplateController) ListTemplates(c *gin.Context) {
+	described := tc.engine.Templates()
+	dtos := make([]NotificationTemplateDTO, len(described))
+	for i, tmpl := range described {
+		dtos[i] = NotificationTemplateDTO{
+			Name:        tmpl.Name,
+			Description: tmpl.Description,
+			Variables:   tmpl.Variables,
+			Channels:    tmpl.Channels,
+			Example:     tmpl.Example,
+			Locales:     tmpl.Locales,
+		}
+	}
+	c.JSON(http.StatusOK, NotificationTemplateListResponse{Templates: dtos})
+}
+
+// PreviewTemplate renders a notification template without sending anything
+func (tc *NotificationTemplateController) PreviewTemplate(c *gin.Context) {
+	var req PreviewTemplateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	tmpl, ok := tc.engine.Template(c.Param("name"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": templates.ErrUnknownTemplate.Error()})
+		return
+	}
+	vars := req.Variables
+	if vars == nil {
+		vars = tmpl.Example
+	}
+	channels := tmpl.Channels
+	if req.Channel != "" {
+		channels = []templates.Channel{req.Channel}
+	}
+
+	response := PreviewTemplateResponse{Template: tmpl.Name, Contents: make([]RenderedContentDTO, 0, len(channels))}
+	for _, channel := range channels {
+		content, err := tc.engine.Render(tmpl.Name, channel, req.Locale, vars)
+		if err != nil {
+			respondTemplateError(c, err)
+			return
+		}
+		response.Contents = append(response.Contents, RenderedContentDTO{
+			Channel: content.Channel,
+			Title:   content.Title,
+			Body:    content.Body,
+			HTML:    content.HTML,
+		})
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// respondTemplateError maps rendering errors: variables that do not match the template and
+// channels it has no variant for are bad requests, and so are variables it fails to render
+// with, such as a date that is not one
+func respondTemplateError(c *gin.Context, err error) {
+	var varsErr *templates.VariablesError
+	switch {
+	case errors.As(err, &varsErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "missing": varsErr.Missing, "unknown": varsErr.Unknown})
+	case errors.Is(err, templates.ErrUnknownTemplate):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, templates.ErrNoVariant), errors.Is(err, templates.ErrRender):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/responses"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QuietHoursDTO is the daily period, in the user's timezone, without interrupting
+// notifications; times are HH:MM
+type QuietHoursDTO struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// PreferencesDTO represents a user's preferences in API responses
+type PreferencesDTO struct {
+	Locale            string                     `json:"locale"`
+	Timezone          string                     `json:"timezone"`
+	Notifications     map[string]bool            `json:"notifications"`
+	NotificationTypes map[string]bool            `json:"notification_types"`
+	QuietHours        *QuietHoursDTO             `json:"quiet_hours"`
+	Custom            map[string]json.RawMessage `json:"custom"`
+	// UpdatedAt is omitted while the user still has the defaults
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdatePreferencesRequest is a partial update; omitted fields keep their value
+// Quiet hours with empty bounds are removed
+type UpdatePreferencesRequest struct {
+	Locale            *string                    `json:"locale"`
+	Timezone          *string                    `json:"timezone"`
+	Notifications     map[string]bool            `json:"notifications"`
+	NotificationTypes map[string]bool            `json:"notification_types"`
+	QuietHours        *QuietHoursDTO             `json:"quiet_hours"`
+	Custom            map[string]json.RawMessage `json:"custom"`
+}
+
+// toPreferencesDTO converts domain entity to DTO
+func toPreferencesDTO(prefs *userEntities.UserPreferences) PreferencesDTO {
+	dto := PreferencesDTO{
+		Locale:            prefs.Locale,
+		Timezone:          prefs.Timezone,
+		Notifications:     prefs.Notifications,
+		NotificationTypes: prefs.NotificationTypes,
+		Custom:            prefs.Custom,
+	}
+	if prefs.QuietHours != nil {
+		dto.QuietHours = &QuietHoursDTO{Start: prefs.QuietHours.Start, End: prefs.QuietHours.End}
+	}
+	if !prefs.UpdatedAt.IsZero() {
+		updatedAt := prefs.UpdatedAt
+		dto.UpdatedAt = &updatedAt
+	}
+	return dto
+}
+
+// PreferencesController handles HTTP requests for the authenticated user's preferences
+type PreferencesController struct {
+	preferencesUseCase userUsecases.PreferencesUseCase
+}
+
+// NewPreferencesController creates a new preferences controller
+func NewPreferencesController(preferencesUseCase userUsecases.PreferencesUseCase) *PreferencesController {
+	return &PreferencesController{
+		preferencesUseCase: preferencesUseCase,
+	}
+}
+
+// GetPreferences retrieves the authenticated user's preferences
+func (pc *PreferencesController) GetPreferences(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	prefs, err := pc.preferencesUseCase.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		respondPreferencesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toPreferencesDTO(prefs))
+}
+
+// UpdatePreferences validates and applies a partial update to the authenticated user's preferences
+// Custom keys must be namespaced, e.g. ui.theme, and are removed by setting them to null
+// Notification types are toggled by name, e.g. order.status_changed
+func (pc *PreferencesController) UpdatePreferences(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	update := userEntities.PreferencesUpdate{
+		Locale:            req.Locale,
+		Timezone:          req.Timezone,
+		Notifications:     req.Notifications,
+		NotificationTypes: req.NotificationTypes,
+		Custom:            req.Custom,
+	}
+	if req.QuietHours != nil {
+		update.QuietHours = &userEntities.QuietHours{Start: req.QuietHours.Start, End: req.QuietHours.End}
+	}
+	prefs, err := pc.preferencesUseCase.UpdatePreferences(c.Request.Context(), userID, update)
+	if err != nil {
+		respondPreferencesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toPreferencesDTO(prefs))
+}
+
+// respondPreferencesError maps preference errors: a missing user is 404 and any other
+// domain error is a schema violation
+func respondPreferencesError(c *gin.Context, err error) {
+	var domainErr sharedEntities.DomainError
+	switch {
+	case err == userEntities.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
@@ -0,0 +1,230 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/domain/shared/clientinfo"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoginRequest represents the request payload for signing in
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// SessionDTO represents a signed-in device in the user's session list
+// As in the activity feed, the IP address is reduced to its network
+type SessionDTO struct {
+	ID         uint      `json:"id"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	IPNetwork  string    `json:"ip_network,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	// Current marks the session the request was made with
+	Current bool `json:"current"`
+}
+
+// LoginResponse represents a started session with its bearer token
+type LoginResponse struct {
+	Token     string     `json:"token"`
+	TokenType string     `json:"token_type"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	Session   SessionDTO `json:"session"`
+}
+
+// SessionListResponse represents the user's active sessions
+type SessionListResponse struct {
+	Sessions []SessionDTO `json:"sessions"`
+}
+
+// RevokeSessionsResponse reports how many sessions were signed out
+type RevokeSessionsResponse struct {
+	Revoked int64 `json:"revoked"`
+}
+
+// toSessionDTO converts domain entity to DTO, flagging the session with currentID
+func toSessionDTO(session *userEntities.Session, currentID uint) SessionDTO {
+	return SessionDTO{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IPNetwork:  userEntities.MaskIP(session.IPAddress),
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+		Current:    session.ID == currentID,
+	}
+}
+
+// SessionController handles HTTP requests for signing in and managing sessions
+type SessionController struct {
+	sessionUseCase userUsecases.SessionUseCase
+}
+
+// NewSessionController creates a new session controller
+func NewSessionController(sessionUseCase userUsecases.SessionUseCase) *SessionController {
+	return &SessionController{
+		sessionUseCase: sessionUseCase,
+	}
+}
+
+// Login verifies the credentials and starts a session for the requesting device
+func (sc *SessionController) Login(c *gin.Context) {
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, token, err := sc.sessionUseCase.Login(c.Request.Context(), req.Email, req.Password, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		respondSessionError(c, err)
+		return
+	}
+
+	middleware.AttemptSucceeded(c)
+	middleware.AllowSensitiveFields(c, "token")
+	c.JSON(http.StatusCreated, LoginResponse{
+		Token:     token,
+		TokenType: "Bearer",
+		ExpiresAt: session.ExpiresAt,
+		Session:   toSessionDTO(session, session.ID),
+	})
+}
+
+// Logout revokes the session the request was made with
+func (sc *SessionController) Logout(c *gin.Context) {
+	userID, sessionID, ok := currentSession(c)
+	if !ok {
+		return
+	}
+
+	if err := sc.sessionUseCase.Logout(clientContext(c), userID, sessionID); err != nil {
+		respondSessionError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListSessions retrieves the authenticated user's active sessions
+func (sc *SessionController) ListSessions(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := sc.sessionUseCase.ListSessions(c.Request.Context(), userID)
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+
+	currentID, _ := middleware.SessionID(c)
+	dtos := make([]SessionDTO, len(sessions))
+	for i, session := range sessions {
+		dtos[i] = toSessionDTO(session, currentID)
+	}
+	c.JSON(http.StatusOK, SessionListResponse{Sessions: dtos})
+}
+
+// RevokeSession signs out one of the authenticated user's sessions
+func (sc *SessionController) RevokeSession(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	if err := sc.sessionUseCase.RevokeSession(clientContext(c), userID, id); err != nil {
+		respondSessionError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeAllSessions signs out all of the authenticated user's sessions, except the current
+// one with keep_current=true
+func (sc *SessionController) RevokeAllSessions(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	keepCurrent := false
+	if raw := c.Query("keep_current"); raw != "" {
+		var err error
+		if keepCurrent, err = strconv.ParseBool(raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "keep_current must be a boolean"})
+			return
+		}
+	}
+
+	var exceptID uint
+	if keepCurrent {
+		exceptID, _ = middleware.SessionID(c)
+	}
+	revoked, err := sc.sessionUseCase.RevokeAllSessions(clientContext(c), userID, exceptID)
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, RevokeSessionsResponse{Revoked: revoked})
+}
+
+// currentSession returns the user and the session the request was authenticated with,
+// responding 400 when it was not made with a session token
+func currentSession(c *gin.Context) (uint, uint, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return 0, 0, false
+	}
+	sessionID, ok := middleware.SessionID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Request is not authenticated with a session token"})
+		return 0, 0, false
+	}
+	return userID, sessionID, true
+}
+
+// clientContext returns the request context carrying the client's IP address and user
+// agent, for the sign-outs recorded in the audit trail
+func clientContext(c *gin.Context) context.Context {
+	return clientinfo.NewContext(c.Request.Context(), clientinfo.Info{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+}
+
+// respondSessionError maps session errors: bad credentials are 401, a suspended account or
+// one awaiting a password reset 403, an unknown session 404 and any other domain error a bad
+// request
+func respondSessionError(c *gin.Context, err error) {
+	var domainErr sharedEntities.DomainError
+	switch {
+	case err == userEntities.ErrInvalidCredentials:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case err == userEntities.ErrUserSuspended, err == userEntities.ErrPasswordResetRequired:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case err == userEntities.ErrSessionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
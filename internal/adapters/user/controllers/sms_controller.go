@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"clean-arch-gin/internal/adapters/shared/responses"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/sms"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxSMSWebhookBody bounds a webhook request; a reply is a single short form
+const maxSMSWebhookBody = 64 << 10
+
+// emptyTwiML answers a reply without texting anything back; providers confirm opt-outs
+// themselves
+const emptyTwiML = `<?xml version="1.0" encoding="UTF-8"?><Response></Response>`
+
+// PhoneDTO is the phone a user is texted at
+type PhoneDTO struct {
+	// Phone is in E.164 format, e.g. +14155550123; empty when none is set
+	Phone string `json:"phone"`
+	// SMSOptedOut is set once the phone replied STOP; nothing is texted to it until it
+	// replies START
+	SMSOptedOut bool `json:"sms_opted_out"`
+}
+
+// SetPhoneRequest sets the phone; an empty phone removes it
+type SetPhoneRequest struct {
+	Phone string `json:"phone" binding:"max=32"`
+}
+
+// toPhoneDTO converts domain entity to DTO
+func toPhoneDTO(user *userEntities.User) PhoneDTO {
+	return PhoneDTO{
+		Phone:       user.Phone,
+		SMSOptedOut: user.SMSOptedOutAt != nil,
+	}
+}
+
+// SMSController handles the phones users are texted at and the replies of SMS providers
+type SMSController struct {
+	userUseCase userUsecases.UserUseCase
+	smsUseCase  userUsecases.SMSUseCase
+	// sources reads the webhook of each provider, by the name in its route
+	sources map[string]sms.ReplySource
+}
+
+// NewSMSController creates a new SMS controller for the webhooks of sources, keyed by
+// provider name
+func NewSMSController(userUseCase userUsecases.UserUseCase, smsUseCase userUsecases.SMSUseCase, sources map[string]sms.ReplySource) *SMSController {
+	return &SMSController{
+		userUseCase: userUseCase,
+		smsUseCase:  smsUseCase,
+		sources:     sources,
+	}
+}
+
+// GetPhone returns the authenticated user's phone
+func (sc *SMSController) GetPhone(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := sc.userUseCase.GetUser(c.Request.Context(), userID)
+	if err != nil {
+		respondPhoneError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toPhoneDTO(user))
+}
+
+// SetPhone sets the authenticated user's phone, which must include its country code
+func (sc *SMSController) SetPhone(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req SetPhoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := sc.smsUseCase.SetPhone(c.Request.Context(), userID, req.Phone)
+	if err != nil {
+		respondPhoneError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toPhoneDTO(user))
+}
+
+// ReceiveReplies opts the phones replying STOP to a provider's texts out and those
+// replying START back in; requests that are not authentic get 401, and failures 500 so the
+// provider retries them
+func (sc *SMSController) ReceiveReplies(c *gin.Context) {
+	source, ok := sc.sources[c.Param("provider")]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown sms provider"})
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSMSWebhookBody))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "webhook body is too large"})
+		return
+	}
+
+	replies, err := source.Parse(c.Request.Context(), requestURL(c.Request), c.Request.Header, body)
+	if err != nil {
+		if errors.Is(err, sms.ErrInvalidWebhook) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		responses.InternalError(c, err)
+		return
+	}
+	if err := sc.smsUseCase.RecordReplies(c.Request.Context(), replies); err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+	c.Data(http.StatusOK, "text/xml; charset=utf-8", []byte(emptyTwiML))
+}
+
+// requestURL rebuilds the URL a request was made to, taking the scheme a proxy terminating
+// TLS forwards
+func requestURL(req *http.Request) string {
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := req.Header.Get("X-Forwarded-Proto"); forwarded != "" {
+		scheme = forwarded
+	}
+	return scheme + "://" + req.Host + req.URL.RequestURI()
+}
+
+// respondPhoneError maps phone errors: a missing user is 404 and any other domain error an
+// invalid phone
+func respondPhoneError(c *gin.Context, err error) {
+	var domainErr sharedEntities.DomainError
+	switch {
+	case err == userEntities.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
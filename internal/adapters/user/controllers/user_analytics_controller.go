@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/responses"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsRangeDTO is the days a report covers, both included
+type AnalyticsRangeDTO struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// UserTotalsDTO counts the users as they are now
+type UserTotalsDTO struct {
+	Live     int64            `json:"live"`
+	Deleted  int64            `json:"deleted"`
+	ByStatus map[string]int64 `json:"by_status"`
+	ByRole   map[string]int64 `json:"by_role"`
+}
+
+// DailySignupsDTO is the users created and deleted on a day
+type DailySignupsDTO struct {
+	Date      string `json:"date"`
+	Signups   int64  `json:"signups"`
+	Deletions int64  `json:"deletions"`
+}
+
+// UserStatsResponse holds the user totals and the signups of every day of the range
+type UserStatsResponse struct {
+	Range  AnalyticsRangeDTO `json:"range"`
+	Totals UserTotalsDTO     `json:"totals"`
+	// Signups and Deletions sum the days of the range
+	Signups   int64             `json:"signups"`
+	Deletions int64             `json:"deletions"`
+	Days      []DailySignupsDTO `json:"days"`
+}
+
+// DailyActivityDTO is the sign-ins of a day
+type DailyActivityDTO struct {
+	Date          string `json:"date"`
+	ActiveUsers   int64  `json:"active_users"`
+	SignIns       int64  `json:"sign_ins"`
+	FailedSignIns int64  `json:"failed_sign_ins"`
+}
+
+// UserActivityResponse holds the sign-ins of every day of the range
+type UserActivityResponse struct {
+	Range AnalyticsRangeDTO  `json:"range"`
+	Days  []DailyActivityDTO `json:"days"`
+}
+
+// RetentionCohortDTO is the users who signed up in a week and how many of them signed in
+// during each week since
+type RetentionCohortDTO struct {
+	Week     string    `json:"week"`
+	Users    int64     `json:"users"`
+	Retained []int64   `json:"retained"`
+	Rates    []float64 `json:"rates"`
+}
+
+// UserReportResponse holds the weekly retention cohorts of the users who signed up in the range
+type UserReportResponse struct {
+	Range   AnalyticsRangeDTO    `json:"range"`
+	Weeks   int                  `json:"weeks"`
+	Cohorts []RetentionCohortDTO `json:"cohorts"`
+}
+
+// toAnalyticsRangeDTO converts the range, whose end is excluded, to the days it covers
+func toAnalyticsRangeDTO(r userEntities.AnalyticsRange) AnalyticsRangeDTO {
+	return AnalyticsRangeDTO{
+		From: r.From.Format(userEntities.AnalyticsDateLayout),
+		To:   r.To.AddDate(0, 0, -1).Format(userEntities.AnalyticsDateLayout),
+	}
+}
+
+// UserAnalyticsController handles HTTP requests for the admin analytics of users
+type UserAnalyticsController struct {
+	analyticsUseCase userUsecases.UserAnalyticsUseCase
+}
+
+// NewUserAnalyticsController creates a new user analytics controller
+func NewUserAnalyticsController(analyticsUseCase userUsecases.UserAnalyticsUseCase) *UserAnalyticsController {
+	return &UserAnalyticsController{
+		analyticsUseCase: analyticsUseCase,
+	}
+}
+
+// GetStats retrieves the user totals, active and deleted, by status and by role, and the
+// signups and deletions of every day from ?from= through ?to=, the last 30 days by default
+func (ac *UserAnalyticsController) GetStats(c *gin.Context) {
+	r, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+	stats, err := ac.analyticsUseCase.GetStats(c.Request.Context(), r)
+	if err != nil {
+		respondAnalyticsError(c, err)
+		return
+	}
+
+	resp := UserStatsResponse{
+		Range: toAnalyticsRangeDTO(stats.Range),
+		Totals: UserTotalsDTO{
+			Live:     stats.Totals.Live,
+			Deleted:  stats.Totals.Deleted,
+			ByStatus: make(map[string]int64, len(stats.Totals.ByStatus)),
+			ByRole:   make(map[string]int64, len(stats.Totals.ByRole)),
+		},
+		Days: make([]DailySignupsDTO, len(stats.Days)),
+	}
+	for status, count := range stats.Totals.ByStatus {
+		resp.Totals.ByStatus[string(status)] = count
+	}
+	for role, count := range stats.Totals.ByRole {
+		resp.Totals.ByRole[string(role)] = count
+	}
+	for i, day := range stats.Days {
+		resp.Days[i] = DailySignupsDTO{Date: day.Date, Signups: day.Signups, Deletions: day.Deletions}
+		resp.Signups += day.Signups
+		resp.Deletions += day.Deletions
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetActivity retrieves how many users signed in, how often and how many sign-ins failed
+// on every day from ?from= through ?to=
+func (ac *UserAnalyticsController) GetActivity(c *gin.Context) {
+	r, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+	days, err := ac.analyticsUseCase.GetActivity(c.Request.Context(), r)
+	if err != nil {
+		respondAnalyticsError(c, err)
+		return
+	}
+
+	dtos := make([]DailyActivityDTO, len(days))
+	for i, day := range days {
+		dtos[i] = DailyActivityDTO{
+			Date:          day.Date,
+			ActiveUsers:   day.ActiveUsers,
+			SignIns:       day.SignIns,
+			FailedSignIns: day.FailedSignIns,
+		}
+	}
+	c.JSON(http.StatusOK, UserActivityResponse{Range: toAnalyticsRangeDTO(r), Days: dtos})
+}
+
+// GetReports retrieves the retention cohorts of the users who signed up from ?from= through
+// ?to=, by week, with how many of them signed in during each of the ?weeks= weeks since
+func (ac *UserAnalyticsController) GetReports(c *gin.Context) {
+	r, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+	weeks := userEntities.DefaultRetentionWeeks
+	if raw := c.Query("weeks"); raw != "" {
+		var err error
+		if weeks, err = strconv.Atoi(raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": userEntities.ErrInvalidRetentionWeeks.Error()})
+			return
+		}
+	}
+	cohorts, err := ac.analyticsUseCase.GetRetention(c.Request.Context(), r, weeks)
+	if err != nil {
+		respondAnalyticsError(c, err)
+		return
+	}
+
+	dtos := make([]RetentionCohortDTO, len(cohorts))
+	for i, cohort := range cohorts {
+		dtos[i] = RetentionCohortDTO{
+			Week:     cohort.Week,
+			Users:    cohort.Users,
+			Retained: cohort.Retained,
+			Rates:    cohort.Rates(),
+		}
+	}
+	c.JSON(http.StatusOK, UserReportResponse{Range: toAnalyticsRangeDTO(r), Weeks: weeks, Cohorts: dtos})
+}
+
+// parseAnalyticsRange reads the ?from= and ?to= days, responding 400 when they are invalid
+func parseAnalyticsRange(c *gin.Context) (userEntities.AnalyticsRange, bool) {
+	var from, to time.Time
+	for _, param := range []struct {
+		name string
+		day  *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		raw := c.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		day, err := time.Parse(userEntities.AnalyticsDateLayout, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": param.name + " must be a date as YYYY-MM-DD"})
+			return userEntities.AnalyticsRange{}, false
+		}
+		*param.day = day
+	}
+	r, err := userEntities.NewAnalyticsRange(from, to)
+	if err != nil {
+		respondAnalyticsError(c, err)
+		return r, false
+	}
+	return r, true
+}
+
+// respondAnalyticsError maps analytics errors: domain errors are bad requests
+func respondAnalyticsError(c *gin.Context, err error) {
+	var domainErr sharedEntities.DomainError
+	if errors.As(err, &domainErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	responses.InternalError(c, err)
+}
@@ -2,14 +2,78 @@ package controllers
 
 import (
 	"net/http"
-	"strconv"
+	"time"
 
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/domain/shared/authz"
 	userEntities "clean-arch-gin/internal/domain/user/entities"
 	userUsecases "clean-arch-gin/internal/domain/user/usecases"
 
 	"github.com/gin-gonic/gin"
 )
 
+// UserDTO represents the user data transfer object for API responses
+type UserDTO struct {
+	// ID is the user's public ID, which routes take in place of the internal one
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+	// EmailVerified is set once the user confirmed their email through a mailed link
+	EmailVerified bool `json:"email_verified"`
+	// EmailUndeliverable is set once mail to the email bounced for good or was reported as
+	// spam; nothing is mailed to it until it changes
+	EmailUndeliverable bool      `json:"email_undeliverable"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// CreateUserRequest represents the request for creating a user
+type CreateUserRequest struct {
+	Email    string `json:"email" binding:"required,email,max=255"`
+	Name     string `json:"name" binding:"required,max=255"`
+	Password string `json:"password" binding:"required,max=72"`
+}
+
+// UpdateUserRequest represents the request for updating a user
+type UpdateUserRequest struct {
+	Email string `json:"email" binding:"omitempty,email,max=255"`
+	Name  string `json:"name" binding:"max=255"`
+}
+
+// UserListResponse represents a page of users
+type UserListResponse struct {
+	Users  []UserDTO `json:"users"`
+	Limit  int       `json:"limit"`
+	Offset int       `json:"offset"`
+	Count  int       `json:"count"`
+}
+
+// toDTO converts domain entity to DTO
+func toDTO(user *userEntities.User) UserDTO {
+	return UserDTO{
+		ID:                 user.PublicID,
+		Email:              user.Email,
+		Name:               user.Name,
+		AvatarURL:          user.AvatarURL,
+		EmailVerified:      user.IsEmailVerified(),
+		EmailUndeliverable: !user.IsEmailDeliverable(),
+		CreatedAt:          user.CreatedAt,
+		UpdatedAt:          user.UpdatedAt,
+	}
+}
+
+// toDTOs converts a list of domain entities to DTOs
+func toDTOs(users []*userEntities.User) []UserDTO {
+	dtos := make([]UserDTO, len(users))
+	for i, user := range users {
+		dtos[i] = toDTO(user)
+	}
+	return dtos
+}
+
 // UserController handles HTTP requests for user operations
 type UserController struct {
 	userUseCase userUsecases.UserUseCase
@@ -24,134 +88,213 @@ func NewUserController(userUseCase userUsecases.UserUseCase) *UserController {
 
 // CreateUser creates a new user
 func (uc *UserController) CreateUser(c *gin.Context) {
-	var req struct {
-		Email    string `json:"email" binding:"required"`
-		Name     string `json:"name" binding:"required"`
-		Password string `json:"password" binding:"required"`
-	}
+	var req CreateUserRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	user, err := uc.userUseCase.CreateUser(req.Email, req.Name, req.Password)
+	user, err := uc.userUseCase.CreateUser(c.Request.Context(), req.Email, req.Name, req.Password)
 	if err != nil {
 		// Handle domain errors appropriately
 		if err == userEntities.ErrEmailExists {
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if err == userEntities.ErrPasswordTooLong {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		responses.InternalError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, user)
+	c.JSON(http.StatusCreated, toDTO(user))
+}
+
+// RequireActiveAccount rejects the requests of suspended users with 403
+// It runs after the auth middleware; an unknown user is left to the handler
+func (uc *UserController) RequireActiveAccount() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := middleware.UserID(c)
+		if !ok {
+			c.Next()
+			return
+		}
+		user, err := uc.userUseCase.GetUser(c.Request.Context(), id)
+		if err != nil && err != userEntities.ErrUserNotFound {
+			responses.InternalError(c, err)
+			c.Abort()
+			return
+		}
+		if err == nil && user.IsSuspended() {
+			c.JSON(http.StatusForbidden, gin.H{"error": userEntities.ErrUserSuspended.Error()})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
 }
 
 // GetUser retrieves a user by ID
 func (uc *UserController) GetUser(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	id, err := params.ParseID(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
 
-	user, err := uc.userUseCase.GetUser(uint(id))
+	uc.getUser(c, id)
+}
+
+// GetCurrentUser retrieves the authenticated user's own record
+func (uc *UserController) GetCurrentUser(c *gin.Context) {
+	id, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	uc.getUser(c, id)
+}
+
+// getUser responds with the user of the given ID
+func (uc *UserController) getUser(c *gin.Context, id uint) {
+	user, err := uc.userUseCase.GetUser(c.Request.Context(), id)
 	if err != nil {
 		if err == userEntities.ErrUserNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		responses.InternalError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	c.JSON(http.StatusOK, toDTO(user))
 }
 
 // GetUsers retrieves all users with pagination
 func (uc *UserController) GetUsers(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "10")
-	offsetStr := c.DefaultQuery("offset", "0")
-
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
-		return
-	}
-
-	offset, err := strconv.Atoi(offsetStr)
+	page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	users, err := uc.userUseCase.GetUsers(limit, offset)
+	users, err := uc.userUseCase.GetUsers(c.Request.Context(), page.Limit, page.Offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		responses.InternalError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"users":  users,
-		"limit":  limit,
-		"offset": offset,
-		"count":  len(users),
+	c.JSON(http.StatusOK, UserListResponse{
+		Users:  toDTOs(users),
+		Limit:  page.Limit,
+		Offset: page.Offset,
+		Count:  len(users),
 	})
 }
 
-// UpdateUser updates user information
+// UpdateUser updates user information; users change their own record, others need the
+// users manage permission
 func (uc *UserController) UpdateUser(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+	id, ok := modifiableUserID(c)
+	if !ok {
 		return
 	}
 
-	var req struct {
-		Email string `json:"email"`
-		Name  string `json:"name"`
+	uc.updateUser(c, id)
+}
+
+// UpdateCurrentUser updates the authenticated user's own record
+// The user is taken from the auth context only, so no request can target another user
+func (uc *UserController) UpdateCurrentUser(c *gin.Context) {
+	id, ok := currentUserID(c)
+	if !ok {
+		return
 	}
 
+	uc.updateUser(c, id)
+}
+
+// updateUser applies the request body to the user of the given ID
+func (uc *UserController) updateUser(c *gin.Context, id uint) {
+	var req UpdateUserRequest
+
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	user, err := uc.userUseCase.UpdateUser(uint(id), req.Email, req.Name)
+	user, err := uc.userUseCase.UpdateUser(c.Request.Context(), id, req.Email, req.Name)
 	if err != nil {
 		if err == userEntities.ErrUserNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if err == userEntities.ErrEmailExists {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		responses.InternalError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	c.JSON(http.StatusOK, toDTO(user))
 }
 
 // DeleteUser soft deletes a user
 func (uc *UserController) DeleteUser(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+	id, ok := modifiableUserID(c)
+	if !ok {
 		return
 	}
 
-	err = uc.userUseCase.DeleteUser(uint(id))
+	err := uc.userUseCase.DeleteUser(c.Request.Context(), id)
 	if err != nil {
 		if err == userEntities.ErrUserNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		responses.InternalError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// modifiableUserID returns the :id user if it is the authenticated user or the policy allows
+// them to manage users, responding otherwise
+func modifiableUserID(c *gin.Context) (uint, bool) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return 0, false
+	}
+	userID, ok := currentUserID(c)
+	if !ok {
+		return 0, false
+	}
+	if userID == id {
+		return id, true
+	}
+	switch err := middleware.Authorize(c, "users", "manage"); err {
+	case nil:
+		return id, true
+	case authz.ErrForbidden:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+	return 0, false
+}
+
+// currentUserID returns the ID set by the auth middleware, responding 401 without one
+func currentUserID(c *gin.Context) (uint, bool) {
+	id, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return 0, false
+	}
+	return id, true
+}
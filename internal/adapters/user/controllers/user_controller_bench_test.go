@@ -0,0 +1,115 @@
+package controllers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"clean-arch-gin/internal/adapters/user/controllers"
+	userRepositoryAdapters "clean-arch-gin/internal/adapters/user/repositories"
+	"clean-arch-gin/internal/adapters/user/usecases"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+	"clean-arch-gin/internal/testutil/repotest"
+
+	"github.com/gin-gonic/gin"
+)
+
+// benchRepositories are the stores every user HTTP benchmark runs against
+var benchRepositories = []struct {
+	name string
+	new  func(b *testing.B) userRepositories.UserRepository
+}{
+	{"memory", func(b *testing.B) userRepositories.UserRepository {
+		return userRepositoryAdapters.NewUserRepositoryMemory()
+	}},
+	{"sqlite", func(b *testing.B) userRepositories.UserRepository {
+		return userRepositoryAdapters.NewUserRepository(repotest.OpenSQLite(b))
+	}},
+}
+
+// newBenchRouter serves the public user routes on top of repo
+func newBenchRouter(repo userRepositories.UserRepository) (*gin.Engine, userUsecases.UserUseCase) {
+	gin.SetMode(gin.TestMode)
+	uc := usecases.NewUserUseCase(repo, nil)
+	controller := controllers.NewUserController(uc)
+
+	r := gin.New()
+	users := r.Group("/api/v1/users")
+	users.POST("", controller.CreateUser)
+	users.GET("/:id", controller.GetUser)
+	users.GET("", controller.GetUsers)
+	return r, uc
+}
+
+// serveBench issues one request and fails the benchmark unless it answers with want
+func serveBench(b *testing.B, r http.Handler, method, path, body string, want int) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != want {
+		b.Fatalf("%s %s = %d, want %d: %s", method, path, w.Code, want, w.Body.String())
+	}
+}
+
+// seedUsers creates n users through the use case and returns their IDs
+func seedUsers(b *testing.B, uc userUsecases.UserUseCase, n int) []uint {
+	b.Helper()
+	ids := make([]uint, n)
+	for i := range ids {
+		user, err := uc.CreateUser(context.Background(), fmt.Sprintf("seed%d@example.com", i), "Seed", "password123")
+		if err != nil {
+			b.Fatalf("failed to seed user: %v", err)
+		}
+		ids[i] = user.ID
+	}
+	return ids
+}
+
+func BenchmarkCreateUserHTTP(b *testing.B) {
+	for _, repo := range benchRepositories {
+		b.Run(repo.name, func(b *testing.B) {
+			r, _ := newBenchRouter(repo.new(b))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				body := fmt.Sprintf(`{"email":"bench%d@example.com","name":"Bench","password":"password123"}`, i)
+				serveBench(b, r, http.MethodPost, "/api/v1/users", body, http.StatusCreated)
+			}
+		})
+	}
+}
+
+func BenchmarkGetUserHTTP(b *testing.B) {
+	for _, repo := range benchRepositories {
+		b.Run(repo.name, func(b *testing.B) {
+			r, uc := newBenchRouter(repo.new(b))
+			ids := seedUsers(b, uc, 100)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				serveBench(b, r, http.MethodGet, fmt.Sprintf("/api/v1/users/%d", ids[i%len(ids)]), "", http.StatusOK)
+			}
+		})
+	}
+}
+
+func BenchmarkGetUsersHTTP(b *testing.B) {
+	for _, repo := range benchRepositories {
+		b.Run(repo.name, func(b *testing.B) {
+			r, uc := newBenchRouter(repo.new(b))
+			seedUsers(b, uc, 100)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				serveBench(b, r, http.MethodGet, fmt.Sprintf("/api/v1/users?limit=20&offset=%d", (i*20)%100), "", http.StatusOK)
+			}
+		})
+	}
+}
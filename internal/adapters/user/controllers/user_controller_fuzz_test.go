@@ -0,0 +1,70 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"clean-arch-gin/internal/adapters/user/controllers"
+	userRepositoryAdapters "clean-arch-gin/internal/adapters/user/repositories"
+)
+
+// FuzzCreateUserBinding posts arbitrary bodies to POST /users; binding must answer 400 for
+// anything invalid and never panic, fail or store a field longer than the request allows
+func FuzzCreateUserBinding(f *testing.F) {
+	seeds := []string{
+		`{"email":"alice@example.com","name":"Alice","password":"password123"}`,
+		`{"email":"alice@example.com","name":"Alice"}`,
+		`{"email":"not-an-email","name":"Alice","password":"password123"}`,
+		`{"email":"alice@example.com","name":"` + strings.Repeat("a", 256) + `","password":"password123"}`,
+		`{"email":1,"name":null,"password":[]}`,
+		`{"email":"alice@example.com","name":"Ålice ☃","password":"\u0000"}`,
+		`[]`,
+		`{`,
+		``,
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	repo := userRepositoryAdapters.NewUserRepositoryMemory()
+	r, _ := newBenchRouter(repo)
+
+	f.Fuzz(func(t *testing.T, body string) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		switch w.Code {
+		case http.StatusCreated, http.StatusBadRequest, http.StatusConflict:
+		default:
+			t.Fatalf("POST /users with %q = %d, want 201, 400 or 409: %s", body, w.Code, w.Body.String())
+		}
+		if w.Code != http.StatusCreated {
+			return
+		}
+
+		var sent controllers.CreateUserRequest
+		if err := json.Unmarshal([]byte(body), &sent); err != nil {
+			t.Fatalf("POST /users accepted %q, which does not decode: %v", body, err)
+		}
+		user, err := repo.GetByEmail(context.Background(), sent.Email)
+		if err != nil {
+			t.Fatalf("POST /users with %q did not store the user: %v", body, err)
+		}
+		if user.Name == "" || sent.Password == "" {
+			t.Fatalf("POST /users with %q accepted an empty required field", body)
+		}
+		if n := utf8.RuneCountInString(user.Email); n > 255 {
+			t.Fatalf("POST /users with %q stored an email of %d characters", body, n)
+		}
+		if n := utf8.RuneCountInString(user.Name); n > 255 {
+			t.Fatalf("POST /users with %q stored a name of %d characters", body, n)
+		}
+	})
+}
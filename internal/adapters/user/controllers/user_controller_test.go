@@ -0,0 +1,241 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/user/controllers"
+	userRepositoryAdapters "clean-arch-gin/internal/adapters/user/repositories"
+	"clean-arch-gin/internal/adapters/user/usecases"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+	"clean-arch-gin/internal/testutil/factory"
+	"clean-arch-gin/internal/testutil/httptestutil"
+	"clean-arch-gin/internal/testutil/repotest"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// userAPI is the user routes served on SQLite behind the session auth middleware
+type userAPI struct {
+	db         *gorm.DB
+	router     *gin.Engine
+	controller *controllers.UserController
+	sessions   userUsecases.SessionUseCase
+}
+
+// newUserAPI mounts the user routes the way the user module does, with numeric IDs in paths
+func newUserAPI(t *testing.T) *userAPI {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db := repotest.OpenSQLite(t)
+	userRepo := userRepositoryAdapters.NewUserRepository(db)
+	sessions := usecases.NewSessionUseCase(userRepo, userRepositoryAdapters.NewSessionRepository(db),
+		userRepositoryAdapters.NewAuthEventRepository(db), nil, nil, time.Hour)
+	controller := controllers.NewUserController(usecases.NewUserUseCase(userRepo, nil))
+	auth := middleware.NewAuthMiddleware("")
+
+	r := gin.New()
+	r.Use(middleware.SessionAuth(sessions, sessions, sessions))
+	users := r.Group("/api/v1/users")
+	users.POST("", controller.CreateUser)
+	users.GET("/:id", controller.GetUser)
+	users.GET("", controller.GetUsers)
+	me := users.Group("/me", auth.RequireAuth(), controller.RequireActiveAccount())
+	me.GET("", controller.GetCurrentUser)
+	me.PUT("", controller.UpdateCurrentUser)
+	owned := users.Group("/:id", auth.RequireAuth(), controller.RequireActiveAccount())
+	owned.PUT("", controller.UpdateUser)
+	owned.DELETE("", controller.DeleteUser)
+	return &userAPI{db: db, router: r, controller: controller, sessions: sessions}
+}
+
+// signIn persists a user built from opts and returns it with the token of a new session
+func (api *userAPI) signIn(t *testing.T, opts ...factory.UserOption) (*userEntities.User, string) {
+	t.Helper()
+	user := factory.CreateUser(t, api.db, opts...)
+	return user, httptestutil.SeedSession(t, api.sessions, user.Email, "password123")
+}
+
+func TestCreateUser(t *testing.T) {
+	api := newUserAPI(t)
+
+	rec := httptestutil.DoJSON(t, api.router, http.MethodPost, "/api/v1/users",
+		controllers.CreateUserRequest{Email: "alice@example.com", Name: "Alice", Password: "password123"})
+	httptestutil.AssertStatus(t, rec, http.StatusCreated)
+	body := httptestutil.AssertJSONFields(t, rec, "id", "email", "name", "created_at")
+	if _, ok := body["password"]; ok {
+		t.Error("response exposes the password")
+	}
+	if body["email"] != "alice@example.com" || body["name"] != "Alice" {
+		t.Errorf("response = %v, want alice@example.com named Alice", body)
+	}
+
+	rec = httptestutil.DoJSON(t, api.router, http.MethodPost, "/api/v1/users",
+		controllers.CreateUserRequest{Email: "alice@example.com", Name: "Alice", Password: "password123"})
+	httptestutil.AssertError(t, rec, http.StatusConflict, userEntities.ErrEmailExists.Error())
+
+	rec = httptestutil.DoJSON(t, api.router, http.MethodPost, "/api/v1/users", `{"email":"not-an-email"}`)
+	httptestutil.AssertStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestGetUser(t *testing.T) {
+	api := newUserAPI(t)
+	user := factory.CreateUser(t, api.db, factory.WithName("Bob"))
+
+	rec := httptestutil.DoJSON(t, api.router, http.MethodGet, "/api/v1/users/"+itoa(user.ID), nil)
+	httptestutil.AssertStatus(t, rec, http.StatusOK)
+	var got controllers.UserDTO
+	httptestutil.DecodeJSON(t, rec, &got)
+	if got.ID != user.PublicID || got.Name != "Bob" {
+		t.Errorf("GET user = %+v, want public ID %q named Bob", got, user.PublicID)
+	}
+
+	rec = httptestutil.DoJSON(t, api.router, http.MethodGet, "/api/v1/users/"+itoa(user.ID+1000), nil)
+	httptestutil.AssertError(t, rec, http.StatusNotFound, userEntities.ErrUserNotFound.Error())
+
+	rec = httptestutil.DoJSON(t, api.router, http.MethodGet, "/api/v1/users/abc", nil)
+	httptestutil.AssertError(t, rec, http.StatusBadRequest, "Invalid user ID")
+}
+
+func TestGetUsersPaginates(t *testing.T) {
+	api := newUserAPI(t)
+	for i := 0; i < 3; i++ {
+		factory.CreateUser(t, api.db)
+	}
+
+	rec := httptestutil.DoJSON(t, api.router, http.MethodGet, "/api/v1/users?limit=2&offset=1", nil)
+	httptestutil.AssertStatus(t, rec, http.StatusOK)
+	var got controllers.UserListResponse
+	httptestutil.DecodeJSON(t, rec, &got)
+	if got.Count != 2 || got.Limit != 2 || got.Offset != 1 || len(got.Users) != 2 {
+		t.Errorf("GET users = %+v, want 2 users from offset 1", got)
+	}
+
+	rec = httptestutil.DoJSON(t, api.router, http.MethodGet, "/api/v1/users?limit=-1", nil)
+	httptestutil.AssertError(t, rec, http.StatusBadRequest, "Invalid limit parameter")
+}
+
+func TestCurrentUserRequiresSession(t *testing.T) {
+	api := newUserAPI(t)
+	user, token := api.signIn(t)
+
+	rec := httptestutil.DoJSON(t, api.router, http.MethodGet, "/api/v1/users/me", nil)
+	httptestutil.AssertError(t, rec, http.StatusUnauthorized, "Authorization header required")
+
+	rec = httptestutil.DoAuthenticatedJSON(t, api.router, "not-a-session", http.MethodGet, "/api/v1/users/me", nil)
+	httptestutil.AssertStatus(t, rec, http.StatusUnauthorized)
+
+	rec = httptestutil.DoAuthenticatedJSON(t, api.router, token, http.MethodGet, "/api/v1/users/me", nil)
+	httptestutil.AssertStatus(t, rec, http.StatusOK)
+	if body := httptestutil.AssertJSONFields(t, rec, "id", "email"); body["email"] != user.Email {
+		t.Errorf("GET me email = %v, want %s", body["email"], user.Email)
+	}
+}
+
+func TestUpdateCurrentUser(t *testing.T) {
+	api := newUserAPI(t)
+	user, token := api.signIn(t)
+
+	rec := httptestutil.DoAuthenticatedJSON(t, api.router, token, http.MethodPut, "/api/v1/users/me",
+		controllers.UpdateUserRequest{Name: "Renamed"})
+	httptestutil.AssertStatus(t, rec, http.StatusOK)
+	if body := httptestutil.AssertJSONFields(t, rec, "name"); body["name"] != "Renamed" || body["email"] != user.Email {
+		t.Errorf("PUT me = %v, want the name changed and the email kept", body)
+	}
+
+	other := factory.CreateUser(t, api.db)
+	rec = httptestutil.DoAuthenticatedJSON(t, api.router, token, http.MethodPut, "/api/v1/users/me",
+		controllers.UpdateUserRequest{Email: other.Email})
+	httptestutil.AssertError(t, rec, http.StatusConflict, userEntities.ErrEmailExists.Error())
+}
+
+func TestModifyUserRequiresOwnerOrAdmin(t *testing.T) {
+	admin := func(u *userEntities.User) { u.Role = userEntities.RoleAdmin }
+
+	tests := []struct {
+		name       string
+		method     string
+		actor      []factory.UserOption
+		self       bool
+		signedIn   bool
+		wantStatus int
+	}{
+		{"update without a session", http.MethodPut, nil, false, false, http.StatusUnauthorized},
+		{"update another user", http.MethodPut, nil, false, true, http.StatusForbidden},
+		{"update self", http.MethodPut, nil, true, true, http.StatusOK},
+		{"admin updates another user", http.MethodPut, []factory.UserOption{admin}, false, true, http.StatusOK},
+		{"delete without a session", http.MethodDelete, nil, false, false, http.StatusUnauthorized},
+		{"delete another user", http.MethodDelete, nil, false, true, http.StatusForbidden},
+		{"delete self", http.MethodDelete, nil, true, true, http.StatusNoContent},
+		{"admin deletes another user", http.MethodDelete, []factory.UserOption{admin}, false, true, http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newUserAPI(t)
+			actor, token := api.signIn(t, tt.actor...)
+			target := factory.CreateUser(t, api.db)
+			if tt.self {
+				target = actor
+			}
+
+			var body interface{}
+			if tt.method == http.MethodPut {
+				body = controllers.UpdateUserRequest{Name: "Renamed"}
+			}
+			path := "/api/v1/users/" + itoa(target.ID)
+			var rec *httptest.ResponseRecorder
+			if tt.signedIn {
+				rec = httptestutil.DoAuthenticatedJSON(t, api.router, token, tt.method, path, body)
+			} else {
+				rec = httptestutil.DoJSON(t, api.router, tt.method, path, body)
+			}
+			httptestutil.AssertStatus(t, rec, tt.wantStatus)
+
+			stored, err := userRepositoryAdapters.NewUserRepository(api.db).GetByID(context.Background(), target.ID)
+			changed := err != nil || stored.Name == "Renamed"
+			if wantChanged := tt.wantStatus < http.StatusBadRequest; changed != wantChanged {
+				t.Errorf("user changed = %v, want %v", changed, wantChanged)
+			}
+		})
+	}
+}
+
+func TestRequireActiveAccountRejectsSuspendedUsers(t *testing.T) {
+	api := newUserAPI(t)
+	// Suspended users cannot sign in, so the account is suspended after the session starts
+	user, token := api.signIn(t)
+	factory.Suspended()(user)
+	if err := userRepositoryAdapters.NewUserRepository(api.db).Update(context.Background(), user); err != nil {
+		t.Fatalf("failed to suspend user: %v", err)
+	}
+
+	rec := httptestutil.DoAuthenticatedJSON(t, api.router, token, http.MethodGet, "/api/v1/users/me", nil)
+	httptestutil.AssertError(t, rec, http.StatusForbidden, userEntities.ErrUserSuspended.Error())
+}
+
+func TestGetCurrentUserHandler(t *testing.T) {
+	api := newUserAPI(t)
+	user := factory.CreateUser(t, api.db)
+
+	c, rec := httptestutil.NewAuthenticatedContext(t, http.MethodGet, "/api/v1/users/me", nil, user.ID)
+	api.controller.GetCurrentUser(c)
+	httptestutil.AssertStatus(t, rec, http.StatusOK)
+
+	c, rec = httptestutil.NewContext(t, http.MethodGet, "/api/v1/users/me", nil)
+	api.controller.GetCurrentUser(c)
+	httptestutil.AssertStatus(t, rec, http.StatusUnauthorized)
+}
+
+// itoa formats a numeric user ID for a path
+func itoa(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/adapters/user/exporters"
+	"clean-arch-gin/internal/adapters/user/tasks"
+	"clean-arch-gin/internal/application/user/queries"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportUsersRequest selects the users to export and the file format
+type ExportUsersRequest struct {
+	Format  exporters.Format     `json:"format" binding:"required,oneof=csv json xlsx"`
+	Filters queries.ExportFilter `json:"filters"`
+}
+
+// UserExportController handles bulk user exports
+type UserExportController struct {
+	// queue runs the exports; without one exports are unavailable
+	queue TaskEnqueuer
+}
+
+// NewUserExportController creates a new user export controller
+func NewUserExportController() *UserExportController {
+	return &UserExportController{}
+}
+
+// SetQueue enables exports on queue
+func (ec *UserExportController) SetQueue(queue TaskEnqueuer) {
+	ec.queue = queue
+}
+
+// ExportUsers queues an export of the users matching the filters and responds 202 with a
+// job ID. Once the job succeeds the requester is notified with a signed download URL, which
+// is also the job's result
+func (ec *UserExportController) ExportUsers(c *gin.Context) {
+	requesterID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req ExportUsersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Reject a bad filter now rather than in a dead task
+	if err := req.Filters.Validate(); err != nil {
+		var domainErr sharedEntities.DomainError
+		if errors.As(err, &domainErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		responses.InternalError(c, err)
+		return
+	}
+	if ec.queue == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Exports are not available"})
+		return
+	}
+
+	task, err := ec.queue.Enqueue(c.Request.Context(), tasks.ExportTaskType, tasks.ExportPayload{
+		Format:      req.Format,
+		Filter:      req.Filters,
+		RequesterID: requesterID,
+	}, taskqueue.Owner(strconv.FormatUint(uint64(requesterID), 10)))
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+	responses.JobAccepted(c, task)
+}
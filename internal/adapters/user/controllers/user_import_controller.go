@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/adapters/user/importers"
+	"clean-arch-gin/internal/adapters/user/tasks"
+	"clean-arch-gin/internal/application/user/commands"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxImportUploadBytes caps the size of an uploaded import file
+const MaxImportUploadBytes = 32 << 20
+
+// TaskEnqueuer queues background work, e.g. a *taskqueue.Queue
+type TaskEnqueuer interface {
+	Enqueue(ctx context.Context, taskType string, payload interface{}, opts ...taskqueue.EnqueueOption) (*taskqueue.Task, error)
+}
+
+// UserImportController handles bulk user imports
+type UserImportController struct {
+	importHandler *commands.ImportUsersCommandHandler
+	// queue runs async imports; without one every import runs inline
+	queue TaskEnqueuer
+}
+
+// NewUserImportController creates a new user import controller
+func NewUserImportController(importHandler *commands.ImportUsersCommandHandler) *UserImportController {
+	return &UserImportController{
+		importHandler: importHandler,
+	}
+}
+
+// SetQueue enables async imports on queue
+func (ic *UserImportController) SetQueue(queue TaskEnqueuer) {
+	ic.queue = queue
+}
+
+// ImportUsers creates users from a multipart CSV or XLSX upload and returns a row-level report
+// The "file" field's header row must name the email, name and password columns; the optional
+// "format" field (csv or xlsx) overrides the file extension. Rows are validated one by one and
+// inserted in batches, and a failing row never aborts the import
+// With ?async=true the file is queued instead and 202 returns a job ID; the report is the
+// job's result once it succeeds
+func (ic *UserImportController) ImportUsers(c *gin.Context) {
+	batchSize := 0
+	if raw := c.Query("batch_size"); raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil || size < 1 || size > commands.MaxImportBatchSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "batch_size must be between 1 and " + strconv.Itoa(commands.MaxImportBatchSize)})
+			return
+		}
+		batchSize = size
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxImportUploadBytes)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file upload in the \"file\" field is required"})
+		return
+	}
+
+	format := importers.Format(c.PostForm("format"))
+	if format == "" {
+		format, err = importers.FormatFromFilename(fileHeader.Filename)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	if c.Query("async") == "true" {
+		ic.enqueueImport(c, format, batchSize, file)
+		return
+	}
+
+	rows, err := importers.NewReader(format, file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ic.importHandler.Handle(c.Request.Context(), commands.ImportUsersCommand{Rows: rows, BatchSize: batchSize})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "report": result})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// enqueueImport queues the upload as a users.import task and responds 202 with its job ID
+func (ic *UserImportController) enqueueImport(c *gin.Context, format importers.Format, batchSize int, file io.Reader) {
+	if ic.queue == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Async imports are not available"})
+		return
+	}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Reject an unreadable file or header now rather than in a dead task
+	rows, err := importers.NewReader(format, bytes.NewReader(content))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if closer, ok := rows.(io.Closer); ok {
+		closer.Close()
+	}
+
+	var opts []taskqueue.EnqueueOption
+	if userID, ok := middleware.UserID(c); ok {
+		opts = append(opts, taskqueue.Owner(strconv.FormatUint(uint64(userID), 10)))
+	}
+	task, err := ic.queue.Enqueue(c.Request.Context(), tasks.ImportTaskType, tasks.ImportPayload{
+		Format:    format,
+		BatchSize: batchSize,
+		File:      content,
+	}, opts...)
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+	responses.JobAccepted(c, task)
+}
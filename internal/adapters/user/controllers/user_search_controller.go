@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"errors"
+	"html"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Markers wrapped around the matched part of a highlighted field
+const (
+	highlightStart = "<mark>"
+	highlightEnd   = "</mark>"
+)
+
+// UserSearchHitDTO represents a user matching a search
+type UserSearchHitDTO struct {
+	User  UserDTO `json:"user"`
+	Score float64 `json:"score"`
+	// Highlights maps the fields that matched, name and email, to their HTML-escaped
+	// text with the matched word prefixes in <mark>
+	Highlights map[string]string `json:"highlights"`
+}
+
+// UserSearchResponse represents a page of search results, most relevant first
+type UserSearchResponse struct {
+	Query  string             `json:"query"`
+	Hits   []UserSearchHitDTO `json:"hits"`
+	Total  int64              `json:"total"`
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+}
+
+// UserSearchController handles HTTP requests searching users
+type UserSearchController struct {
+	searchUseCase userUsecases.UserSearchUseCase
+}
+
+// NewUserSearchController creates a new user search controller
+func NewUserSearchController(searchUseCase userUsecases.UserSearchUseCase) *UserSearchController {
+	return &UserSearchController{
+		searchUseCase: searchUseCase,
+	}
+}
+
+// SearchUsers retrieves a page of the users whose name or email has words starting with
+// those of ?q=, e.g. "ali smi" finds Alice Smith
+func (sc *UserSearchController) SearchUsers(c *gin.Context) {
+	page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := c.Query("q")
+	hits, total, terms, err := sc.searchUseCase.SearchUsers(c.Request.Context(), query, page.Limit, page.Offset)
+	if err != nil {
+		if errors.Is(err, userEntities.ErrInvalidSearchQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		responses.InternalError(c, err)
+		return
+	}
+
+	dtos := make([]UserSearchHitDTO, len(hits))
+	for i, hit := range hits {
+		dtos[i] = UserSearchHitDTO{
+			User:       toDTO(hit.User),
+			Score:      hit.Score,
+			Highlights: make(map[string]string),
+		}
+		for field, text := range map[string]string{"name": hit.User.Name, "email": hit.User.Email} {
+			if highlighted, ok := highlight(text, terms); ok {
+				dtos[i].Highlights[field] = highlighted
+			}
+		}
+	}
+	c.JSON(http.StatusOK, UserSearchResponse{
+		Query:  query,
+		Hits:   dtos,
+		Total:  total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+}
+
+// highlight HTML-escapes text and marks the longest term starting each of its words, and
+// reports whether any was marked
+func highlight(text string, terms []string) (string, bool) {
+	var b strings.Builder
+	marked := false
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			j := i
+			for j < len(runes) && !isWordRune(runes[j]) {
+				j++
+			}
+			b.WriteString(html.EscapeString(string(runes[i:j])))
+			i = j
+			continue
+		}
+		j := i
+		for j < len(runes) && isWordRune(runes[j]) {
+			j++
+		}
+		word := runes[i:j]
+		matched := 0
+		for _, term := range terms {
+			n := len([]rune(term))
+			if n > matched && n <= len(word) && strings.ToLower(string(word[:n])) == term {
+				matched = n
+			}
+		}
+		if matched > 0 {
+			marked = true
+			b.WriteString(highlightStart + html.EscapeString(string(word[:matched])) + highlightEnd)
+		}
+		b.WriteString(html.EscapeString(string(word[matched:])))
+		i = j
+	}
+	return b.String(), marked
+}
+
+// isWordRune reports whether r belongs to a word searches match, as userEntities.SearchWords
+// splits them
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,52 @@
+package exporters
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// csvWriter streams users as CSV rows under a header row
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter writes the header and returns a writer for the rows
+func NewCSVWriter(w io.Writer) (Writer, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+	return &csvWriter{w: cw}, nil
+}
+
+// Write appends a user's row
+func (c *csvWriter) Write(user *userEntities.User) error {
+	row := record(user)
+	for i, cell := range row {
+		row[i] = escapeFormula(cell)
+	}
+	return c.w.Write(row)
+}
+
+// Flush writes out the buffered rows
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// Close flushes the buffered rows
+func (c *csvWriter) Close() error {
+	return c.Flush()
+}
+
+// escapeFormula stops spreadsheets from evaluating user-supplied text such as
+// =HYPERLINK(...) by prefixing cells that start like a formula with a quote
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
@@ -0,0 +1,105 @@
+// Package exporters writes exported users as CSV, JSON or XLSX files
+package exporters
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"clean-arch-gin/internal/application/user/queries"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// Format identifies an export's file type
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+	FormatXLSX Format = "xlsx"
+)
+
+// ErrUnsupportedFormat is returned for anything other than CSV, JSON and XLSX
+var ErrUnsupportedFormat = errors.New("format must be csv, json or xlsx")
+
+// ErrNotResumable is returned by ResumeWriter for XLSX, which is only written out on Close
+var ErrNotResumable = errors.New("only csv and json exports can be resumed")
+
+// Writer writes users to a file; Close completes the file and must be called once
+type Writer interface {
+	queries.ExportRowWriter
+	// Flush writes out the rows written so far, where the format allows
+	Flush() error
+	Close() error
+}
+
+// NewWriter starts a file of the format on w
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w)
+	case FormatJSON:
+		return NewJSONWriter(w), nil
+	case FormatXLSX:
+		return NewXLSXWriter(w)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// ResumeWriter continues a file of the format on w after rows written earlier, e.g. by a run
+// that was cut short, without writing its start again; XLSX cannot be resumed
+func ResumeWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatJSON:
+		return &jsonWriter{w: w, started: true}, nil
+	case FormatXLSX:
+		return nil, ErrNotResumable
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// Resumable reports whether files of the format are written as they go, so that a file cut
+// short can be continued with ResumeWriter
+func (f Format) Resumable() bool {
+	return f == FormatCSV || f == FormatJSON
+}
+
+// Valid reports whether the format is supported
+func (f Format) Valid() bool {
+	return f == FormatCSV || f == FormatJSON || f == FormatXLSX
+}
+
+// ContentType is the media type of files of the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv"
+	case FormatJSON:
+		return "application/json"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// header names the exported columns; passwords are never exported
+var header = []string{"id", "email", "name", "role", "status", "created_at"}
+
+// record returns the exported columns of a user, in header order
+func record(user *userEntities.User) []string {
+	return []string{
+		strconv.FormatUint(uint64(user.ID), 10),
+		user.Email,
+		user.Name,
+		string(user.Role),
+		string(user.Status),
+		user.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,71 @@
+package exporters
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// exportedUser is a user as written to JSON exports
+type exportedUser struct {
+	ID        uint                `json:"id"`
+	Email     string              `json:"email"`
+	Name      string              `json:"name"`
+	Role      userEntities.Role   `json:"role"`
+	Status    userEntities.Status `json:"status"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+// jsonWriter streams users as the elements of a JSON array, one per line
+type jsonWriter struct {
+	w       io.Writer
+	started bool
+}
+
+// NewJSONWriter returns a writer for a JSON array of users
+func NewJSONWriter(w io.Writer) Writer {
+	return &jsonWriter{w: w}
+}
+
+// Write appends a user to the array
+func (j *jsonWriter) Write(user *userEntities.User) error {
+	element, err := json.Marshal(exportedUser{
+		ID:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		Role:      user.Role,
+		Status:    user.Status,
+		CreatedAt: user.CreatedAt.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	if !j.started {
+		separator = "[\n"
+		j.started = true
+	}
+	if _, err := io.WriteString(j.w, separator); err != nil {
+		return err
+	}
+	_, err = j.w.Write(element)
+	return err
+}
+
+// Flush does nothing; elements are written unbuffered
+func (j *jsonWriter) Flush() error {
+	return nil
+}
+
+// Close ends the array; an export without users is []
+func (j *jsonWriter) Close() error {
+	end := "\n]\n"
+	if !j.started {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.w, end)
+	return err
+}
@@ -0,0 +1,75 @@
+package exporters
+
+import (
+	"io"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// exportSheet names the worksheet of XLSX exports
+const exportSheet = "Users"
+
+// xlsxWriter streams users into a worksheet, written out as a workbook on Close
+type xlsxWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+// NewXLSXWriter creates a workbook with the header row
+// Rows are streamed, so excelize spills large sheets to temporary files
+func NewXLSXWriter(w io.Writer) (Writer, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName(file.GetSheetName(0), exportSheet); err != nil {
+		file.Close()
+		return nil, err
+	}
+	stream, err := file.NewStreamWriter(exportSheet)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	x := &xlsxWriter{w: w, file: file, stream: stream, row: 1}
+	if err := x.writeRow(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return x, nil
+}
+
+// Write appends a user's row; cells are strings so nothing is evaluated as a formula
+func (x *xlsxWriter) Write(user *userEntities.User) error {
+	return x.writeRow(record(user))
+}
+
+// Flush does nothing; the workbook is only written on Close
+func (x *xlsxWriter) Flush() error {
+	return nil
+}
+
+// Close completes the sheet and writes the workbook
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.w)
+}
+
+// writeRow writes cells to the next row
+func (x *xlsxWriter) writeRow(cells []string) error {
+	values := make([]interface{}, len(cells))
+	for i, cell := range cells {
+		values[i] = cell
+	}
+	axis, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	x.row++
+	return x.stream.SetRow(axis, values)
+}
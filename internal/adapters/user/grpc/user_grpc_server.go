@@ -0,0 +1,178 @@
+// Package grpc adapts the user use cases to the generated gRPC UserService
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+
+	"clean-arch-gin/internal/adapters/shared/params"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+	userv1 "clean-arch-gin/internal/gen/proto/user/v1"
+	"clean-arch-gin/internal/infrastructure/breaker"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxFieldLength mirrors the max=255 binding used by the HTTP controller
+const maxFieldLength = 255
+
+// UserGRPCServer handles gRPC requests for user operations
+type UserGRPCServer struct {
+	userv1.UnimplementedUserServiceServer
+	userUseCase userUsecases.UserUseCase
+}
+
+// NewUserGRPCServer creates a new user gRPC server
+func NewUserGRPCServer(userUseCase userUsecases.UserUseCase) *UserGRPCServer {
+	return &UserGRPCServer{
+		userUseCase: userUseCase,
+	}
+}
+
+// CreateUser creates a new user
+func (s *UserGRPCServer) CreateUser(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.CreateUserResponse, error) {
+	if err := validateEmail(req.GetEmail(), true); err != nil {
+		return nil, err
+	}
+	if err := validateField("name", req.GetName(), true); err != nil {
+		return nil, err
+	}
+	if err := validateField("password", req.GetPassword(), true); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userUseCase.CreateUser(ctx, req.GetEmail(), req.GetName(), req.GetPassword())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &userv1.CreateUserResponse{User: toProto(user)}, nil
+}
+
+// GetUser retrieves a user by ID
+func (s *UserGRPCServer) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.GetUserResponse, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	user, err := s.userUseCase.GetUser(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &userv1.GetUserResponse{User: toProto(user)}, nil
+}
+
+// ListUsers retrieves all users with pagination
+func (s *UserGRPCServer) ListUsers(ctx context.Context, req *userv1.ListUsersRequest) (*userv1.ListUsersResponse, error) {
+	page, err := params.NewPagination(int(req.GetLimit()), int(req.GetOffset()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	users, err := s.userUseCase.GetUsers(ctx, page.Limit, page.Offset)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &userv1.ListUsersResponse{
+		Users:  make([]*userv1.User, len(users)),
+		Limit:  int32(page.Limit),
+		Offset: int32(page.Offset),
+		Count:  int32(len(users)),
+	}
+	for i, user := range users {
+		resp.Users[i] = toProto(user)
+	}
+	return resp, nil
+}
+
+// UpdateUser updates user information
+func (s *UserGRPCServer) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest) (*userv1.UpdateUserResponse, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+	if err := validateEmail(req.GetEmail(), false); err != nil {
+		return nil, err
+	}
+	if err := validateField("name", req.GetName(), false); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userUseCase.UpdateUser(ctx, uint(req.GetId()), req.GetEmail(), req.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &userv1.UpdateUserResponse{User: toProto(user)}, nil
+}
+
+// DeleteUser soft deletes a user
+func (s *UserGRPCServer) DeleteUser(ctx context.Context, req *userv1.DeleteUserRequest) (*userv1.DeleteUserResponse, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid user ID")
+	}
+
+	if err := s.userUseCase.DeleteUser(ctx, uint(req.GetId())); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &userv1.DeleteUserResponse{}, nil
+}
+
+// toProto converts domain entity to its protobuf message
+func toProto(user *userEntities.User) *userv1.User {
+	return &userv1.User{
+		Id:        uint32(user.ID),
+		Email:     user.Email,
+		Name:      user.Name,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+}
+
+// toStatus maps domain errors to gRPC status codes the same way the controller maps them to HTTP
+func toStatus(err error) error {
+	switch err {
+	case userEntities.ErrUserNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case userEntities.ErrEmailExists:
+		return status.Error(codes.AlreadyExists, err.Error())
+	case userEntities.ErrPasswordTooLong:
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		if breaker.IsUnavailable(err) {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// validateField applies the required/max=255 rules of the HTTP bindings
+func validateField(name, value string, required bool) error {
+	if required && value == "" {
+		return status.Errorf(codes.InvalidArgument, "%s is required", name)
+	}
+	if len(value) > maxFieldLength {
+		return status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", name, maxFieldLength)
+	}
+	return nil
+}
+
+// validateEmail applies the email binding rule on top of validateField
+func validateEmail(email string, required bool) error {
+	if err := validateField("email", email, required); err != nil {
+		return err
+	}
+	if email == "" {
+		return nil
+	}
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("%q is not a valid email", email))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package importers
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+
+	"clean-arch-gin/internal/application/user/commands"
+)
+
+// csvReader streams rows from a CSV file with a header row
+type csvReader struct {
+	r    *csv.Reader
+	cols columns
+}
+
+// NewCSVReader reads the header and returns a reader for the remaining rows
+func NewCSVReader(r io.Reader) (commands.ImportRowReader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, ErrMissingColumn
+	}
+	if err != nil {
+		return nil, err
+	}
+	cols, err := parseHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	return &csvReader{r: cr, cols: cols}, nil
+}
+
+// Next returns the next non-blank row
+func (c *csvReader) Next() (commands.ImportRow, error) {
+	for {
+		record, err := c.r.Read()
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return commands.ImportRow{}, &commands.ImportRowError{Line: parseErr.StartLine, Message: parseErr.Err.Error()}
+		}
+		if err != nil {
+			return commands.ImportRow{}, err
+		}
+		if blank(record) {
+			continue
+		}
+		line, _ := c.r.FieldPos(0)
+		return c.cols.row(line, record), nil
+	}
+}
@@ -0,0 +1,98 @@
+package importers
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"clean-arch-gin/internal/application/user/commands"
+)
+
+// Format identifies an upload's file type
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ErrMissingColumn is returned when the header row lacks a required column
+var ErrMissingColumn = errors.New("header must contain email, name and password columns")
+
+// ErrUnsupportedFormat is returned for anything other than CSV and XLSX
+var ErrUnsupportedFormat = errors.New("file must be CSV or XLSX")
+
+// FormatFromFilename picks the format from a file extension
+func FormatFromFilename(filename string) (Format, error) {
+	lower := strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(lower, ".csv"):
+		return FormatCSV, nil
+	case strings.HasSuffix(lower, ".xlsx"):
+		return FormatXLSX, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// NewReader opens a streaming row reader for the format
+func NewReader(format Format, r io.Reader) (commands.ImportRowReader, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVReader(r)
+	case FormatXLSX:
+		return NewXLSXReader(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// columns maps the required fields to their position in a row
+type columns struct {
+	email, name, password int
+}
+
+// parseHeader locates the columns by case-insensitive name, in any order
+func parseHeader(header []string) (columns, error) {
+	cols := columns{email: -1, name: -1, password: -1}
+	for i, cell := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cell, "\ufeff"))) {
+		case "email":
+			cols.email = i
+		case "name":
+			cols.name = i
+		case "password":
+			cols.password = i
+		}
+	}
+	if cols.email < 0 || cols.name < 0 || cols.password < 0 {
+		return cols, ErrMissingColumn
+	}
+	return cols, nil
+}
+
+// row builds an ImportRow from the cells of one record
+func (c columns) row(line int, record []string) commands.ImportRow {
+	cell := func(i int) string {
+		if i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	return commands.ImportRow{
+		Line:     line,
+		Email:    strings.ToLower(cell(c.email)),
+		Name:     cell(c.name),
+		Password: cell(c.password),
+	}
+}
+
+// blank reports whether every cell of a record is empty
+func blank(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package importers
+
+import (
+	"io"
+
+	"clean-arch-gin/internal/application/user/commands"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxReader streams rows from the first sheet of a workbook with a header row
+type xlsxReader struct {
+	file *excelize.File
+	rows *excelize.Rows
+	cols columns
+	line int
+	done bool
+}
+
+// NewXLSXReader opens the workbook and reads the header of its first sheet
+// Large sheets are spilled to temporary files by excelize rather than held in memory
+func NewXLSXReader(r io.Reader) (commands.ImportRowReader, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := file.Rows(file.GetSheetName(0))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if !rows.Next() {
+		rows.Close()
+		file.Close()
+		return nil, ErrMissingColumn
+	}
+	header, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		file.Close()
+		return nil, err
+	}
+	cols, err := parseHeader(header)
+	if err != nil {
+		rows.Close()
+		file.Close()
+		return nil, err
+	}
+	return &xlsxReader{file: file, rows: rows, cols: cols, line: 1}, nil
+}
+
+// Next returns the next non-blank row and releases the workbook after the last one
+func (x *xlsxReader) Next() (commands.ImportRow, error) {
+	if x.done {
+		return commands.ImportRow{}, io.EOF
+	}
+	for x.rows.Next() {
+		x.line++
+		record, err := x.rows.Columns()
+		if err != nil {
+			return commands.ImportRow{}, &commands.ImportRowError{Line: x.line, Message: err.Error()}
+		}
+		if blank(record) {
+			continue
+		}
+		return x.cols.row(x.line, record), nil
+	}
+
+	err := x.rows.Error()
+	x.Close()
+	if err != nil {
+		return commands.ImportRow{}, err
+	}
+	return commands.ImportRow{}, io.EOF
+}
+
+// Close releases the workbook of a reader that is not read to the end
+func (x *xlsxReader) Close() error {
+	if x.done {
+		return nil
+	}
+	x.done = true
+	x.rows.Close()
+	return x.file.Close()
+}
@@ -0,0 +1,288 @@
+// Package jobs holds the scheduled jobs of the user module
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/domain/shared/softdelete"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/fieldcrypt"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// PurgeDeletedAfter is how long soft-deleted users are kept before they are purged
+	PurgeDeletedAfter = 30 * 24 * time.Hour
+	// AuthEventRetention is how long the authentication audit trail is kept
+	AuthEventRetention = 90 * 24 * time.Hour
+	// statsDateLayout formats the rollup day
+	statsDateLayout = "2006-01-02"
+	// rewriteBatchSize is how many users are read at a time to be rewritten
+	rewriteBatchSize = 200
+)
+
+// NewPurgeDeletedJob permanently deletes users soft-deleted more than retention ago, with
+// their dependent rows, nightly
+func NewPurgeDeletedJob(users softdelete.Purger, retention time.Duration) scheduler.Job {
+	return scheduler.Job{
+		Name:     "purge-deleted",
+		Schedule: "0 3 * * *",
+		Timeout:  10 * time.Minute,
+		Run: func(ctx context.Context) error {
+			purged, err := users.PurgeOlderThan(ctx, retention)
+			if purged > 0 {
+				log.Printf("users: purged %d users deleted more than %s ago", purged, retention)
+			}
+			return err
+		},
+	}
+}
+
+// NewPurgeSessionsJob deletes the database sessions that expired, revoked or not, hourly
+// Revoked sessions are kept until then so their tokens are reported as revoked
+func NewPurgeSessionsJob(db *gorm.DB) scheduler.Job {
+	return scheduler.Job{
+		Name:     "purge-sessions",
+		Schedule: "0 * * * *",
+		Timeout:  5 * time.Minute,
+		Run: func(ctx context.Context) error {
+			result := db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&models.UserSessionModel{})
+			if result.RowsAffected > 0 {
+				log.Printf("users: purged %d expired sessions", result.RowsAffected)
+			}
+			return result.Error
+		},
+	}
+}
+
+// NewPurgeAccountTokensJob deletes the password reset and email verification tokens that
+// expired, used or not, hourly
+func NewPurgeAccountTokensJob(db *gorm.DB) scheduler.Job {
+	return scheduler.Job{
+		Name:     "purge-account-tokens",
+		Schedule: "30 * * * *",
+		Timeout:  5 * time.Minute,
+		Run: func(ctx context.Context) error {
+			result := db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&models.UserAccountTokenModel{})
+			if result.RowsAffected > 0 {
+				log.Printf("users: purged %d expired account tokens", result.RowsAffected)
+			}
+			return result.Error
+		},
+	}
+}
+
+// Digester mails the users their pending notification digests
+type Digester interface {
+	// SendDigests returns how many digests were sent
+	SendDigests(ctx context.Context) (int, error)
+}
+
+// NewDigestJob mails the users of every tenant the digest of their low-priority
+// notifications, daily at 08:00 UTC
+func NewDigestJob(digests Digester) scheduler.Job {
+	return scheduler.Job{
+		Name:     "notification-digest",
+		Schedule: "0 8 * * *",
+		Timeout:  15 * time.Minute,
+		Run: func(ctx context.Context) error {
+			sent, err := digests.SendDigests(ctx)
+			if sent > 0 {
+				log.Printf("users: sent %d notification digests", sent)
+			}
+			return err
+		},
+	}
+}
+
+// NewStatsRollupJob refreshes the user_daily_stats rows of today and yesterday (UTC)
+// every 15 minutes; yesterday is recomputed so late changes around midnight are counted
+func NewStatsRollupJob(db *gorm.DB) scheduler.Job {
+	return scheduler.Job{
+		Name:     "stats-rollup",
+		Schedule: "*/15 * * * *",
+		Timeout:  2 * time.Minute,
+		Run: func(ctx context.Context) error {
+			today := time.Now().UTC().Truncate(24 * time.Hour)
+			for _, day := range []time.Time{today.AddDate(0, 0, -1), today} {
+				if err := rollupDay(ctx, db, day); err != nil {
+					return fmt.Errorf("failed to roll up %s: %w", day.Format(statsDateLayout), err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// rollupDay computes and upserts the stats of every tenant for the day starting at start
+// Tenants without users that day are left without a row
+func rollupDay(ctx context.Context, db *gorm.DB, start time.Time) error {
+	end := start.AddDate(0, 0, 1)
+	date := start.Format(statsDateLayout)
+	byTenant := make(map[uint]*models.UserDailyStatsModel)
+	count := func(set func(*models.UserDailyStatsModel, int64), query string, args ...interface{}) error {
+		var rows []struct {
+			TenantID uint
+			Count    int64
+		}
+		if err := db.WithContext(ctx).Unscoped().Model(&models.UserModel{}).
+			Select("tenant_id, COUNT(*) AS count").Where(query, args...).Group("tenant_id").
+			Scan(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			stats, ok := byTenant[row.TenantID]
+			if !ok {
+				stats = &models.UserDailyStatsModel{TenantID: row.TenantID, Date: date}
+				byTenant[row.TenantID] = stats
+			}
+			set(stats, row.Count)
+		}
+		return nil
+	}
+
+	if err := count(func(s *models.UserDailyStatsModel, n int64) { s.TotalUsers = n },
+		"created_at < ? AND (deleted_at IS NULL OR deleted_at >= ?)", end, end); err != nil {
+		return err
+	}
+	if err := count(func(s *models.UserDailyStatsModel, n int64) { s.NewUsers = n },
+		"created_at >= ? AND created_at < ?", start, end); err != nil {
+		return err
+	}
+	if err := count(func(s *models.UserDailyStatsModel, n int64) { s.DeletedUsers = n },
+		"deleted_at >= ? AND deleted_at < ?", start, end); err != nil {
+		return err
+	}
+	if len(byTenant) == 0 {
+		return nil
+	}
+
+	stats := make([]*models.UserDailyStatsModel, 0, len(byTenant))
+	for _, s := range byTenant {
+		stats = append(stats, s)
+	}
+	return db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&stats).Error
+}
+
+// NewReencryptJob rewrites, hourly, the users whose email or name were not encrypted with
+// the active key: after a key rotation, after encryption is turned on, or decrypting them
+// once it is turned off. Retired keys can be removed from the keyring once a run succeeds
+// without re-encrypting anyone
+func NewReencryptJob(db *gorm.DB) scheduler.Job {
+	return scheduler.Job{
+		Name:     "reencrypt-pii",
+		Schedule: "30 * * * *",
+		Timeout:  30 * time.Minute,
+		Run: func(ctx context.Context) error {
+			keyring := fieldcrypt.Current()
+			if !keyring.Enabled() {
+				return nil
+			}
+			emailCond, emailArg := keyring.StaleCiphertext("email")
+			nameCond, nameArg := keyring.StaleCiphertext("name")
+			rewritten, err := rewriteUsers(ctx, db, emailCond+" OR "+nameCond, emailArg, nameArg)
+			switch {
+			case rewritten == 0:
+			case keyring.ActiveKeyID() == "":
+				log.Printf("users: decrypted %d users", rewritten)
+			default:
+				log.Printf("users: re-encrypted %d users with key %q", rewritten, keyring.ActiveKeyID())
+			}
+			return err
+		},
+	}
+}
+
+// BackfillEmailIndex computes the blind index of the users missing one or indexed with
+// another index key, so they can be looked up by email again
+func BackfillEmailIndex(ctx context.Context, db *gorm.DB) error {
+	cond, arg := fieldcrypt.Current().StaleIndex("email_hash")
+	rewritten, err := rewriteUsers(ctx, db, cond, arg)
+	if rewritten > 0 {
+		log.Printf("users: indexed the email of %d users", rewritten)
+	}
+	return err
+}
+
+// BackfillPublicIDs gives a public ID to the users created before public IDs existed, so
+// they can be addressed in routes again
+func BackfillPublicIDs(ctx context.Context, db *gorm.DB) error {
+	assigned, err := database.AssignPublicIDs(ctx, db, &models.UserModel{}, rewriteBatchSize)
+	if assigned > 0 {
+		log.Printf("users: assigned a public ID to %d users", assigned)
+	}
+	return err
+}
+
+// BackfillNotificationCounters counts the inboxes of the users notified before their counts
+// were kept, so the unread counts include their older notifications
+func BackfillNotificationCounters(ctx context.Context, db *gorm.DB) error {
+	result := db.WithContext(ctx).Exec(`INSERT INTO notification_counters (user_id, tenant_id, total, unread, updated_at)
+		SELECT user_id, MIN(tenant_id), COUNT(*), SUM(CASE WHEN read_at IS NULL THEN 1 ELSE 0 END), ?
+		FROM notifications
+		WHERE user_id NOT IN (SELECT user_id FROM notification_counters)
+		GROUP BY user_id`, time.Now())
+	if result.RowsAffected > 0 {
+		log.Printf("users: counted the notifications of %d users", result.RowsAffected)
+	}
+	return result.Error
+}
+
+// rewriteUsers writes back the email, name and email index of every user, deleted or not,
+// matching the condition, which encrypts them with the active key
+// Users are rewritten in ID order without touching updated_at, a batch at a time in a
+// transaction holding their rows so concurrent updates are not overwritten
+func rewriteUsers(ctx context.Context, db *gorm.DB, cond string, args ...interface{}) (int64, error) {
+	var rewritten int64
+	var afterID uint
+	for {
+		var users []models.UserModel
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id > ?", afterID).
+				Where(cond, args...).Order("id").Limit(rewriteBatchSize).Find(&users).Error; err != nil {
+				return err
+			}
+			for i := range users {
+				user := &users[i]
+				emailHash := models.EmailIndex(user.Email)
+				user.EmailHash = &emailHash
+				if err := tx.Unscoped().Model(user).Select("email", "name", "email_hash").UpdateColumns(user).Error; err != nil {
+					return fmt.Errorf("failed to rewrite user %d: %w", user.ID, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return rewritten, err
+		}
+		rewritten += int64(len(users))
+		if len(users) < rewriteBatchSize {
+			return rewritten, nil
+		}
+		afterID = users[len(users)-1].ID
+	}
+}
+
+// NewPurgeAuthEventsJob deletes the authentication events of every tenant that occurred
+// more than retention ago, nightly
+func NewPurgeAuthEventsJob(db *gorm.DB, retention time.Duration) scheduler.Job {
+	return scheduler.Job{
+		Name:     "purge-auth-events",
+		Schedule: "30 3 * * *",
+		Timeout:  10 * time.Minute,
+		Run: func(ctx context.Context) error {
+			result := db.WithContext(ctx).Where("occurred_at < ?", time.Now().Add(-retention)).Delete(&models.UserAuthEventModel{})
+			if result.RowsAffected > 0 {
+				log.Printf("users: purged %d auth events older than %s", result.RowsAffected, retention)
+			}
+			return result.Error
+		},
+	}
+}
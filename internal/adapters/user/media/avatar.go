@@ -0,0 +1,131 @@
+// Package media prepares uploaded profile pictures for storage
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// avatarQuality is the JPEG quality avatars are encoded at
+const avatarQuality = 85
+
+// decoders decode the accepted formats by sniffed content type, never by the client's claim
+var decoders = map[string]func(io.Reader) (image.Image, error){
+	"image/jpeg": jpeg.Decode,
+	"image/png":  png.Decode,
+	"image/gif":  gif.Decode,
+}
+
+// configDecoders read image dimensions without decoding the pixels
+var configDecoders = map[string]func(io.Reader) (image.Config, error){
+	"image/jpeg": jpeg.DecodeConfig,
+	"image/png":  png.DecodeConfig,
+	"image/gif":  gif.DecodeConfig,
+}
+
+// ProcessAvatar validates an uploaded image and returns it as a square JPEG of
+// userEntities.AvatarSize pixels, center-cropped and flattened onto white
+// Files over userEntities.MaxAvatarBytes, formats other than JPEG, PNG and GIF, and images
+// whose dimensions exceed userEntities.MaxAvatarPixels are rejected before decoding
+func ProcessAvatar(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, userEntities.MaxAvatarBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > userEntities.MaxAvatarBytes {
+		return nil, userEntities.ErrAvatarTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	decode, ok := decoders[contentType]
+	if !ok {
+		return nil, userEntities.ErrUnsupportedAvatarType
+	}
+	config, err := configDecoders[contentType](bytes.NewReader(data))
+	if err != nil || config.Width < 1 || config.Height < 1 {
+		return nil, userEntities.ErrInvalidAvatar
+	}
+	if config.Width*config.Height > userEntities.MaxAvatarPixels {
+		return nil, userEntities.ErrAvatarTooLarge
+	}
+	src, err := decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, userEntities.ErrInvalidAvatar
+	}
+
+	avatar := resize(flatten(src, cropSquare(src)), userEntities.AvatarSize)
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, avatar, &jpeg.Options{Quality: avatarQuality}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// cropSquare returns the centered square of the image
+func cropSquare(src image.Image) image.Rectangle {
+	b := src.Bounds()
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+	x := b.Min.X + (b.Dx()-side)/2
+	y := b.Min.Y + (b.Dy()-side)/2
+	return image.Rect(x, y, x+side, y+side)
+}
+
+// flatten copies the crop of src onto white, since JPEG has no transparency
+func flatten(src image.Image, crop image.Rectangle) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, crop.Dx(), crop.Dy()))
+	draw.Draw(dst, dst.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), src, crop.Min, draw.Over)
+	return dst
+}
+
+// resize scales a square image to size x size, averaging the source pixels each target
+// pixel covers when shrinking and repeating the nearest one when enlarging
+func resize(src *image.RGBA, size int) *image.RGBA {
+	n := src.Bounds().Dx()
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for dy := 0; dy < size; dy++ {
+		y0, y1 := span(dy, n, size)
+		for dx := 0; dx < size; dx++ {
+			x0, x1 := span(dx, n, size)
+			var r, g, b, a, count uint32
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					i := src.PixOffset(x, y)
+					r += uint32(src.Pix[i])
+					g += uint32(src.Pix[i+1])
+					b += uint32(src.Pix[i+2])
+					a += uint32(src.Pix[i+3])
+					count++
+				}
+			}
+			i := dst.PixOffset(dx, dy)
+			dst.Pix[i] = uint8(r / count)
+			dst.Pix[i+1] = uint8(g / count)
+			dst.Pix[i+2] = uint8(b / count)
+			dst.Pix[i+3] = uint8(a / count)
+		}
+	}
+	return dst
+}
+
+// span is the range of the n source pixels that target pixel d of size covers
+func span(d, n, size int) (int, int) {
+	start := d * n / size
+	end := (d + 1) * n / size
+	if end <= start {
+		end = start + 1
+	}
+	return start, end
+}
@@ -0,0 +1,155 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"clean-arch-gin/internal/domain/shared/mail"
+	"clean-arch-gin/internal/domain/shared/templates"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// DigestTemplate is the template of the digest emails
+const DigestTemplate = "digest"
+
+// Digest collects the low-priority notifications of users and mails each of them a summary
+// when sent, e.g. daily
+type Digest struct {
+	items       userRepositories.DigestRepository
+	users       userUsecases.UserUseCase
+	preferences userUsecases.PreferencesUseCase
+	templates   templates.Engine
+	mailer      mail.Mailer
+}
+
+// NewDigest creates a digest keeping items in the repository and mailing them with mailer,
+// rendered from the digest template of engine
+func NewDigest(items userRepositories.DigestRepository, users userUsecases.UserUseCase, preferences userUsecases.PreferencesUseCase, engine templates.Engine, mailer mail.Mailer) *Digest {
+	return &Digest{
+		items:       items,
+		users:       users,
+		preferences: preferences,
+		templates:   engine,
+		mailer:      mailer,
+	}
+}
+
+// Add keeps the in-app content of a notification for the user's next digest
+func (d *Digest) Add(ctx context.Context, userID uint, notificationType string, content *templates.Content) error {
+	item, err := userEntities.NewDigestItem(userID, notificationType, content.Title, content.Body)
+	if err != nil {
+		return err
+	}
+	return d.items.Add(ctx, item)
+}
+
+// SendDigests mails every user with pending items their digest and returns how many were
+// sent; ctx acts across tenants
+// A user failing keeps their items for the next run without holding back the others; the
+// failures are returned together
+func (d *Digest) SendDigests(ctx context.Context) (int, error) {
+	userIDs, err := d.items.PendingUsers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, userID := range userIDs {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+		mailed, err := d.send(ctx, userID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("digest of user %d: %w", userID, err))
+			continue
+		}
+		if mailed {
+			sent++
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+// send mails the user their pending items and deletes them; the items of users who are gone,
+// cannot be mailed or opted out of email since they were added are deleted unsent
+func (d *Digest) send(ctx context.Context, userID uint) (bool, error) {
+	items, err := d.items.ListByUser(ctx, userID)
+	if err != nil || len(items) == 0 {
+		return false, err
+	}
+	lastID := items[len(items)-1].ID
+
+	to, err := d.recipient(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if to == nil {
+		log.Printf("notifications: dropping the digest of user %d, who cannot be mailed", userID)
+		return false, d.items.DeleteThrough(ctx, userID, lastID)
+	}
+
+	listed := items
+	if len(listed) > userEntities.MaxDigestItems {
+		listed = listed[len(listed)-userEntities.MaxDigestItems:]
+	}
+	variables := make([]interface{}, len(listed))
+	for i, item := range listed {
+		variables[i] = map[string]interface{}{
+			"title":      item.Title,
+			"body":       item.Body,
+			"created_at": item.CreatedAt,
+		}
+	}
+	content, err := d.templates.Render(DigestTemplate, templates.ChannelEmail, to.locale, map[string]interface{}{
+		"count": len(items),
+		"items": variables,
+		"more":  len(items) - len(listed),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err := d.mailer.Send(ctx, mail.Message{
+		To:      []string{to.email},
+		Subject: content.Title,
+		Text:    content.Body,
+		HTML:    content.HTML,
+	}); err != nil {
+		return false, err
+	}
+	return true, d.items.DeleteThrough(ctx, userID, lastID)
+}
+
+// recipient is where and in which locale a digest is mailed
+type recipient struct {
+	email  string
+	locale string
+}
+
+// recipient returns where to mail the user's digest, or nil unless the user still exists,
+// has a deliverable email and wants email
+func (d *Digest) recipient(ctx context.Context, userID uint) (*recipient, error) {
+	user, err := d.users.GetUser(ctx, userID)
+	if errors.Is(err, userEntities.ErrUserNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsEmailDeliverable() {
+		return nil, nil
+	}
+	prefs, err := d.preferences.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !prefs.WantsNotification(userEntities.NotifyEmail) {
+		return nil, nil
+	}
+	return &recipient{email: user.Email, locale: prefs.Locale}, nil
+}
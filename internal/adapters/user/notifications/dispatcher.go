@@ -0,0 +1,375 @@
+// Package notifications turns domain events into in-app notifications for users, rendered
+// from notification templates
+package notifications
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderEvents "clean-arch-gin/internal/domain/order/events"
+	reportEvents "clean-arch-gin/internal/domain/report/events"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/templates"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+	"clean-arch-gin/internal/infrastructure/workerpool"
+)
+
+// Names of the templates of the built-in notifications
+const (
+	OrderStatusChangedTemplate  = "order_status_changed"
+	ReturnStatusChangedTemplate = "return_status_changed"
+	ReportReadyTemplate         = "report_ready"
+)
+
+// Priority is how urgently a message reaches the user
+type Priority int
+
+// Priorities of messages
+const (
+	// PriorityNormal messages are delivered on every channel right away
+	PriorityNormal Priority = iota
+	// PriorityLow messages are recorded in the inbox right away but mailed in the user's
+	// next digest instead of being delivered on the other channels
+	PriorityLow
+	// PriorityCritical messages, e.g. security alerts, reach the user whatever their
+	// preferences; see DefaultPolicy
+	PriorityCritical
+)
+
+// Message is the notification for one user: the template to render with its variables,
+// and the data kept with the notification for clients
+type Message struct {
+	UserID    uint
+	Template  string
+	Variables map[string]interface{}
+	Data      map[string]interface{}
+	Priority  Priority
+}
+
+// Channel delivers the notifications rendered for it outside the app, e.g. as texts
+type Channel interface {
+	// Channel is the template variant the channel delivers
+	Channel() templates.Channel
+	// Deliver sends content to the user, unless they cannot be reached on the channel; the
+	// dispatcher already checked their preferences
+	Deliver(ctx context.Context, userID uint, notificationType string, content *templates.Content) error
+}
+
+// Renderer turns an event into the messages to deliver; none means nobody is notified
+type Renderer func(event events.DomainEvent) []Message
+
+// Dispatcher subscribes to domain events and records a notification for every message
+// their renderer produces, rendering its template's in-app variant, then delivers it on
+// each channel its template has a variant for, or adds it to the digest when it has low
+// priority. The policy decides from the user's preferences which of these channels the
+// message reaches. Failures are logged: a lost notification never fails the operation that
+// published the event
+type Dispatcher struct {
+	notifications userUsecases.NotificationUseCase
+	preferences   userUsecases.PreferencesUseCase
+	templates     templates.Engine
+	channels      []Channel
+
+	mu        sync.RWMutex
+	renderers map[string]Renderer
+	policy    Policy
+	digest    *Digest
+	pool      *workerpool.Pool
+}
+
+// NewDispatcher creates a dispatcher with the renderers of the built-in events and the
+// default policy, rendering messages with engine and delivering them on channels besides
+// the inbox; preferences may be nil, applying everyone the defaults
+func NewDispatcher(notifications userUsecases.NotificationUseCase, preferences userUsecases.PreferencesUseCase, engine templates.Engine, channels ...Channel) *Dispatcher {
+	d := &Dispatcher{
+		notifications: notifications,
+		preferences:   preferences,
+		templates:     engine,
+		channels:      channels,
+		renderers:     make(map[string]Renderer),
+		policy:        DefaultPolicy,
+	}
+	d.Register(orderEvents.OrderStatusChangedEventName, renderOrderStatusChanged)
+	d.Register(orderEvents.ReturnStatusChangedEventName, renderReturnStatusChanged)
+	d.Register(reportEvents.ReportReadyEventName, renderReportReady)
+	return d
+}
+
+// Register sets the renderer of an event, replacing any previous one
+// Call it before Subscribe; events registered later are not subscribed to
+func (d *Dispatcher) Register(eventName string, renderer Renderer) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.renderers[eventName] = renderer
+}
+
+// SetPolicy replaces the policy deciding which channels a message reaches
+func (d *Dispatcher) SetPolicy(policy Policy) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.policy = policy
+}
+
+// SetDigest collects the low-priority messages in digest; without one they only reach the
+// inbox
+func (d *Dispatcher) SetDigest(digest *Digest) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.digest = digest
+}
+
+// SetPool delivers messages on the channels outside the app on pool's workers, so the
+// publisher only waits for the inbox; without one they are delivered on its goroutine.
+// Messages the pool rejects are logged and only reach the inbox
+func (d *Dispatcher) SetPool(pool *workerpool.Pool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pool = pool
+}
+
+// Subscribe listens for every event with a renderer and returns the unsubscribe function
+func (d *Dispatcher) Subscribe(subscriber events.EventSubscriber) func() {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	unsubscribes := make([]func(), 0, len(d.renderers))
+	for eventName := range d.renderers {
+		unsubscribes = append(unsubscribes, subscriber.Subscribe(eventName, d.HandleEvent))
+	}
+	return func() {
+		for _, unsubscribe := range unsubscribes {
+			unsubscribe()
+		}
+	}
+}
+
+// HandleEvent records the notifications rendered from event in the tenant of ctx
+func (d *Dispatcher) HandleEvent(ctx context.Context, event events.DomainEvent) {
+	d.mu.RLock()
+	renderer, ok := d.renderers[event.EventName()]
+	d.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	d.Dispatch(ctx, event.EventName(), renderer(event)...)
+}
+
+// Dispatch records the messages as notifications of notificationType in the tenant of ctx
+// and delivers them on the other channels, or adds those with low priority to the digest,
+// where the policy allows
+func (d *Dispatcher) Dispatch(ctx context.Context, notificationType string, messages ...Message) {
+	d.mu.RLock()
+	policy, digest, pool := d.policy, d.digest, d.pool
+	d.mu.RUnlock()
+
+	for _, message := range messages {
+		// allowed outlives the iteration when delivered on the pool
+		message := message
+		prefs := d.preferencesOf(ctx, message.UserID)
+		now := time.Now()
+		allowed := func(channel templates.Channel) bool {
+			return policy(prefs, channel, notificationType, message, now)
+		}
+
+		content, err := d.templates.Render(message.Template, templates.ChannelInApp, prefs.Locale, message.Variables)
+		if err != nil {
+			log.Printf("notifications: failed to render %s for user %d: %v", message.Template, message.UserID, err)
+			continue
+		}
+		if allowed(templates.ChannelInApp) {
+			if _, err := d.notifications.Notify(ctx, message.UserID, notificationType, content.Title, content.Body, message.Data); err != nil {
+				log.Printf("notifications: failed to notify user %d of %s: %v", message.UserID, notificationType, err)
+			}
+		}
+		if message.Priority != PriorityLow {
+			d.deliverOn(ctx, pool, notificationType, message, prefs.Locale, allowed)
+			continue
+		}
+		if digest != nil && allowed(templates.ChannelEmail) {
+			if err := digest.Add(ctx, message.UserID, notificationType, content); err != nil {
+				log.Printf("notifications: failed to add %s to the digest of user %d: %v", notificationType, message.UserID, err)
+			}
+		}
+	}
+}
+
+// preferencesOf returns the user's preferences; the defaults stand in when they cannot be
+// loaded, so a failing store does not silence notifications
+func (d *Dispatcher) preferencesOf(ctx context.Context, userID uint) *userEntities.UserPreferences {
+	if d.preferences == nil {
+		return userEntities.NewDefaultPreferences(userID)
+	}
+	prefs, err := d.preferences.GetPreferences(ctx, userID)
+	if err != nil {
+		log.Printf("notifications: failed to load the preferences of user %d, applying the defaults: %v", userID, err)
+		return userEntities.NewDefaultPreferences(userID)
+	}
+	return prefs
+}
+
+// deliverOn delivers the message on pool, outliving the publisher's context, or right away
+// without a pool
+func (d *Dispatcher) deliverOn(ctx context.Context, pool *workerpool.Pool, notificationType string, message Message, locale string, allowed func(templates.Channel) bool) {
+	if pool == nil || len(d.channels) == 0 {
+		d.deliver(ctx, notificationType, message, locale, allowed)
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	err := pool.Submit(ctx, func() {
+		d.deliver(ctx, notificationType, message, locale, allowed)
+	})
+	if err != nil {
+		log.Printf("notifications: dropped %s to user %d outside the inbox: %v", notificationType, message.UserID, err)
+	}
+}
+
+// deliver renders the message in locale for each channel its template has a variant for and
+// allowed accepts, and delivers it there
+func (d *Dispatcher) deliver(ctx context.Context, notificationType string, message Message, locale string, allowed func(templates.Channel) bool) {
+	if len(d.channels) == 0 {
+		return
+	}
+	template, ok := d.templates.Template(message.Template)
+	if !ok {
+		return
+	}
+	for _, channel := range d.channels {
+		if !hasVariant(template, channel.Channel()) || !allowed(channel.Channel()) {
+			continue
+		}
+		content, err := d.templates.Render(message.Template, channel.Channel(), locale, message.Variables)
+		if err != nil {
+			log.Printf("notifications: failed to render %s for %s to user %d: %v", message.Template, channel.Channel(), message.UserID, err)
+			continue
+		}
+		if err := channel.Deliver(ctx, message.UserID, notificationType, content); err != nil {
+			log.Printf("notifications: failed to deliver %s by %s to user %d: %v", notificationType, channel.Channel(), message.UserID, err)
+		}
+	}
+}
+
+// hasVariant reports whether template can be rendered for channel
+func hasVariant(template templates.Template, channel templates.Channel) bool {
+	for _, variant := range template.Channels {
+		if variant == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// orderStatusBodies describes each status an order can move to
+var orderStatusBodies = map[orderEntities.OrderStatus]string{
+	orderEntities.OrderStatusConfirmed:        "has been confirmed",
+	orderEntities.OrderStatusPartiallyShipped: "has partly shipped; the rest will follow",
+	orderEntities.OrderStatusShipped:          "is on its way",
+	orderEntities.OrderStatusDelivered:        "has been delivered",
+	orderEntities.OrderStatusCancelled:        "has been cancelled",
+}
+
+// renderOrderStatusChanged tells the order's owner about the new status
+func renderOrderStatusChanged(event events.DomainEvent) []Message {
+	changed, ok := event.(orderEvents.OrderStatusChangedEvent)
+	if !ok || changed.UserID == 0 {
+		return nil
+	}
+	text, ok := orderStatusBodies[changed.To]
+	if !ok {
+		return nil
+	}
+
+	priority := PriorityNormal
+	if changed.To == orderEntities.OrderStatusConfirmed {
+		// Customers expect the orders they placed to be confirmed
+		priority = PriorityLow
+	}
+	return []Message{{
+		UserID:   changed.UserID,
+		Template: OrderStatusChangedTemplate,
+		Priority: priority,
+		Variables: map[string]interface{}{
+			"order_number": orderNumber(changed.OrderID),
+			"status":       string(changed.To),
+			"status_text":  text,
+		},
+		Data: map[string]interface{}{
+			"order_id": changed.OrderID,
+			"from":     changed.From,
+			"to":       changed.To,
+		},
+	}}
+}
+
+// returnStatusBodies describes each status a return can move to
+var returnStatusBodies = map[orderEntities.ReturnStatus]string{
+	orderEntities.ReturnStatusApproved: "has been approved; please send the items back",
+	orderEntities.ReturnStatusRejected: "has been rejected",
+	orderEntities.ReturnStatusReceived: "has been received; your refund is on its way",
+	orderEntities.ReturnStatusRefunded: "has been refunded",
+}
+
+// renderReturnStatusChanged tells the customer about the new status of their return
+func renderReturnStatusChanged(event events.DomainEvent) []Message {
+	changed, ok := event.(orderEvents.ReturnStatusChangedEvent)
+	if !ok || changed.UserID == 0 {
+		return nil
+	}
+	text, ok := returnStatusBodies[changed.To]
+	if !ok {
+		return nil
+	}
+
+	return []Message{{
+		UserID:   changed.UserID,
+		Template: ReturnStatusChangedTemplate,
+		Variables: map[string]interface{}{
+			"rma_number":   orderEntities.FormatRMANumber(changed.ReturnID),
+			"order_number": orderNumber(changed.OrderID),
+			"status":       string(changed.To),
+			"status_text":  text,
+		},
+		Data: map[string]interface{}{
+			"return_id": changed.ReturnID,
+			"order_id":  changed.OrderID,
+			"from":      changed.From,
+			"to":        changed.To,
+		},
+	}}
+}
+
+// renderReportReady tells the admin who requested a report where to download it
+func renderReportReady(event events.DomainEvent) []Message {
+	ready, ok := event.(reportEvents.ReportReadyEvent)
+	if !ok || ready.RequesterID == 0 {
+		return nil
+	}
+
+	return []Message{{
+		UserID:   ready.RequesterID,
+		Template: ReportReadyTemplate,
+		Variables: map[string]interface{}{
+			"type":         ready.Report.Type,
+			"rows":         ready.Report.Rows,
+			"format":       string(ready.Report.Format),
+			"download_url": ready.Report.DownloadURL,
+			"expires_at":   ready.Report.ExpiresAt,
+		},
+		Data: map[string]interface{}{
+			"job_id":       ready.JobID,
+			"type":         ready.Report.Type,
+			"download_url": ready.Report.DownloadURL,
+			"expires_at":   ready.Report.ExpiresAt,
+		},
+	}}
+}
+
+// orderNumber is the number customers know an order by
+func orderNumber(orderID uint) string {
+	return "#" + strconv.FormatUint(uint64(orderID), 10)
+}
@@ -0,0 +1,44 @@
+package notifications
+
+import (
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/templates"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// Policy decides whether a message of notificationType reaches the user on channel, given
+// their preferences, at now
+type Policy func(prefs *userEntities.UserPreferences, channel templates.Channel, notificationType string, message Message, now time.Time) bool
+
+// channelOptIns maps the channels outside the app to the opt-in they require
+var channelOptIns = map[templates.Channel]string{
+	templates.ChannelEmail: userEntities.NotifyEmail,
+	templates.ChannelPush:  userEntities.NotifyPush,
+	templates.ChannelSMS:   userEntities.NotifySMS,
+}
+
+// interruptingChannels are held back during quiet hours; the inbox and email wait to be read
+var interruptingChannels = map[templates.Channel]bool{
+	templates.ChannelPush: true,
+	templates.ChannelSMS:  true,
+}
+
+// DefaultPolicy honors the user's preferences: a type toggled off reaches no channel, the
+// inbox included, channels outside the app need their opt-in, and push and texts are not
+// sent during quiet hours
+// Critical messages, e.g. security alerts, ignore toggles, quiet hours and opt-ins, except
+// the opt-in to texts, which is the consent texting requires
+func DefaultPolicy(prefs *userEntities.UserPreferences, channel templates.Channel, notificationType string, message Message, now time.Time) bool {
+	optIn, outside := channelOptIns[channel]
+	if message.Priority == PriorityCritical {
+		return channel != templates.ChannelSMS || prefs.WantsNotification(optIn)
+	}
+	if !prefs.WantsNotificationType(notificationType) {
+		return false
+	}
+	if outside && !prefs.WantsNotification(optIn) {
+		return false
+	}
+	return !interruptingChannels[channel] || !prefs.InQuietHours(now)
+}
@@ -0,0 +1,61 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"clean-arch-gin/internal/domain/shared/push"
+	"clean-arch-gin/internal/domain/shared/templates"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// PushChannel sends notifications to every device of their users
+type PushChannel struct {
+	devices userUsecases.DeviceUseCase
+	sender  push.Sender
+}
+
+var _ Channel = (*PushChannel)(nil)
+
+// NewPushChannel creates a channel pushing through sender, forgetting the devices whose
+// tokens it no longer accepts
+func NewPushChannel(devices userUsecases.DeviceUseCase, sender push.Sender) *PushChannel {
+	return &PushChannel{
+		devices: devices,
+		sender:  sender,
+	}
+}
+
+// Channel returns the push channel
+func (c *PushChannel) Channel() templates.Channel {
+	return templates.ChannelPush
+}
+
+// Deliver pushes content to each of the user's devices; the notification type reaches the app in the "type" data key
+// A device failing does not keep the others from getting it; the failures are returned
+// together
+func (c *PushChannel) Deliver(ctx context.Context, userID uint, notificationType string, content *templates.Content) error {
+	devices, err := c.devices.ListDevices(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	msg := push.Message{
+		Title: content.Title,
+		Body:  content.Body,
+		Data:  map[string]string{"type": notificationType},
+	}
+	var errs []error
+	for _, device := range devices {
+		err := c.sender.Send(ctx, device.Token, msg)
+		if errors.Is(err, push.ErrInvalidToken) {
+			log.Printf("notifications: forgetting device %d of user %d: %v", device.ID, userID, err)
+			err = c.devices.ForgetToken(ctx, device.Token)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,55 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"clean-arch-gin/internal/domain/shared/sms"
+	"clean-arch-gin/internal/domain/shared/templates"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// SMSChannel texts notifications to the users who set a phone that did not opt out
+type SMSChannel struct {
+	users      userUsecases.UserUseCase
+	smsUseCase userUsecases.SMSUseCase
+	sender     sms.Sender
+}
+
+var _ Channel = (*SMSChannel)(nil)
+
+// NewSMSChannel creates a channel texting through sender, recording the phones it refuses
+// to text as opted out with smsUseCase
+func NewSMSChannel(users userUsecases.UserUseCase, smsUseCase userUsecases.SMSUseCase, sender sms.Sender) *SMSChannel {
+	return &SMSChannel{
+		users:      users,
+		smsUseCase: smsUseCase,
+		sender:     sender,
+	}
+}
+
+// Channel returns the SMS channel
+func (c *SMSChannel) Channel() templates.Channel {
+	return templates.ChannelSMS
+}
+
+// Deliver texts the body of content to the user's phone when it did not opt out
+// A phone the provider reports opted out, e.g. by a STOP reply the webhook missed, is
+// recorded so it is not texted again
+func (c *SMSChannel) Deliver(ctx context.Context, userID uint, notificationType string, content *templates.Content) error {
+	user, err := c.users.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.CanBeTexted() {
+		return nil
+	}
+
+	err = c.sender.Send(ctx, user.Phone, content.Body)
+	if errors.Is(err, sms.ErrOptedOut) {
+		log.Printf("notifications: phone of user %d opted out of texts", userID)
+		return c.smsUseCase.OptOut(ctx, user.Phone)
+	}
+	return err
+}
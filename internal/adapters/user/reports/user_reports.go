@@ -0,0 +1,272 @@
+// Package reports holds the reports the user module contributes to the report module, built
+// from the user analytics
+package reports
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/documents"
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/reports"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// Types of the user reports
+const (
+	SignupsReportType   = "users.signups"
+	ActivityReportType  = "users.activity"
+	RetentionReportType = "users.retention"
+)
+
+// rangeParams describes the from and to parameters every user report takes
+var rangeParams = map[string]string{
+	"from": "First day, YYYY-MM-DD (default 30 days before to)",
+	"to":   "Last day, YYYY-MM-DD (default today, UTC)",
+}
+
+// NewGenerators creates the user reports on the analytics
+func NewGenerators(analytics userUsecases.UserAnalyticsUseCase) []reports.Generator {
+	return []reports.Generator{
+		&signupsReport{analytics: analytics},
+		&activityReport{analytics: analytics},
+		&retentionReport{analytics: analytics},
+	}
+}
+
+// signupsReport tabulates the users created and deleted each day, under the user totals
+type signupsReport struct {
+	analytics userUsecases.UserAnalyticsUseCase
+}
+
+// Type returns the report type
+func (r *signupsReport) Type() string { return SignupsReportType }
+
+// Description describes the report
+func (r *signupsReport) Description() string {
+	return "Users created and deleted on each day, with the totals by status and role"
+}
+
+// Params describes the parameters of the report
+func (r *signupsReport) Params() map[string]string { return rangeParams }
+
+// Validate rejects invalid days
+func (r *signupsReport) Validate(params reports.Params) error {
+	_, err := parseRange(params)
+	return err
+}
+
+// Generate builds the report for the tenant of ctx
+func (r *signupsReport) Generate(ctx context.Context, params reports.Params, progress reports.Progress) (documents.Document, error) {
+	rng, err := parseRange(params)
+	if err != nil {
+		return documents.Document{}, err
+	}
+	stats, err := r.analytics.GetStats(ctx, rng)
+	if err != nil {
+		return documents.Document{}, err
+	}
+	progress(100)
+
+	var signups, deletions int64
+	rows := make([][]string, len(stats.Days))
+	for i, day := range stats.Days {
+		rows[i] = []string{day.Date, count(day.Signups), count(day.Deletions)}
+		signups += day.Signups
+		deletions += day.Deletions
+	}
+	header := []string{
+		rangeLine(rng),
+		fmt.Sprintf("Live users: %d, deleted: %d", stats.Totals.Live, stats.Totals.Deleted),
+	}
+	var breakdown []string
+	for status, n := range stats.Totals.ByStatus {
+		breakdown = append(breakdown, fmt.Sprintf("Status %s: %d", status, n))
+	}
+	for role, n := range stats.Totals.ByRole {
+		breakdown = append(breakdown, fmt.Sprintf("Role %s: %d", role, n))
+	}
+	sort.Strings(breakdown)
+	header = append(header, breakdown...)
+	return documents.Document{
+		Title:  "User signups",
+		Header: header,
+		Columns: []documents.Column{
+			{Title: "Date", Width: 12},
+			{Title: "Signups", Width: 12, AlignRight: true},
+			{Title: "Deletions", Width: 12, AlignRight: true},
+		},
+		Rows:   rows,
+		Footer: []string{fmt.Sprintf("Total: %d signups, %d deletions", signups, deletions)},
+	}, nil
+}
+
+// activityReport tabulates the sign-ins of each day
+type activityReport struct {
+	analytics userUsecases.UserAnalyticsUseCase
+}
+
+// Type returns the report type
+func (r *activityReport) Type() string { return ActivityReportType }
+
+// Description describes the report
+func (r *activityReport) Description() string {
+	return "Users signing in, their sign-ins and the failed ones on each day"
+}
+
+// Params describes the parameters of the report
+func (r *activityReport) Params() map[string]string { return rangeParams }
+
+// Validate rejects invalid days
+func (r *activityReport) Validate(params reports.Params) error {
+	_, err := parseRange(params)
+	return err
+}
+
+// Generate builds the report for the tenant of ctx
+func (r *activityReport) Generate(ctx context.Context, params reports.Params, progress reports.Progress) (documents.Document, error) {
+	rng, err := parseRange(params)
+	if err != nil {
+		return documents.Document{}, err
+	}
+	days, err := r.analytics.GetActivity(ctx, rng)
+	if err != nil {
+		return documents.Document{}, err
+	}
+	progress(100)
+
+	rows := make([][]string, len(days))
+	for i, day := range days {
+		rows[i] = []string{day.Date, count(day.ActiveUsers), count(day.SignIns), count(day.FailedSignIns)}
+	}
+	return documents.Document{
+		Title:  "User activity",
+		Header: []string{rangeLine(rng)},
+		Columns: []documents.Column{
+			{Title: "Date", Width: 12},
+			{Title: "Active users", Width: 14, AlignRight: true},
+			{Title: "Sign-ins", Width: 12, AlignRight: true},
+			{Title: "Failed sign-ins", Width: 17, AlignRight: true},
+		},
+		Rows: rows,
+	}, nil
+}
+
+// retentionReport tabulates the weekly cohorts of the users who signed up in the range, with
+// the share of each signing in during every week since
+type retentionReport struct {
+	analytics userUsecases.UserAnalyticsUseCase
+}
+
+// Type returns the report type
+func (r *retentionReport) Type() string { return RetentionReportType }
+
+// Description describes the report
+func (r *retentionReport) Description() string {
+	return "Weekly cohorts of the users who signed up, with the share of them signing in during each week since"
+}
+
+// Params describes the parameters of the report
+func (r *retentionReport) Params() map[string]string {
+	params := map[string]string{"weeks": "Weeks to follow each cohort for (default 8, max 12)"}
+	for name, description := range rangeParams {
+		params[name] = description
+	}
+	return params
+}
+
+// Validate rejects invalid days and numbers of weeks
+func (r *retentionReport) Validate(params reports.Params) error {
+	if _, err := parseRange(params); err != nil {
+		return err
+	}
+	_, err := parseWeeks(params)
+	return err
+}
+
+// Generate builds the report for the tenant of ctx
+func (r *retentionReport) Generate(ctx context.Context, params reports.Params, progress reports.Progress) (documents.Document, error) {
+	rng, err := parseRange(params)
+	if err != nil {
+		return documents.Document{}, err
+	}
+	weeks, err := parseWeeks(params)
+	if err != nil {
+		return documents.Document{}, err
+	}
+	cohorts, err := r.analytics.GetRetention(ctx, rng, weeks)
+	if err != nil {
+		return documents.Document{}, err
+	}
+	progress(100)
+
+	columns := []documents.Column{
+		{Title: "Cohort", Width: 12},
+		{Title: "Users", Width: 8, AlignRight: true},
+	}
+	for week := 0; week < weeks; week++ {
+		columns = append(columns, documents.Column{Title: "W" + strconv.Itoa(week), Width: 5, AlignRight: true})
+	}
+	rows := make([][]string, len(cohorts))
+	for i, cohort := range cohorts {
+		rows[i] = []string{cohort.Week, count(cohort.Users)}
+		for _, rate := range cohort.Rates() {
+			rows[i] = append(rows[i], strconv.Itoa(int(rate*100+0.5))+"%")
+		}
+		// Weeks yet to come are left blank
+		for len(rows[i]) < len(columns) {
+			rows[i] = append(rows[i], "")
+		}
+	}
+	return documents.Document{
+		Title:   "User retention",
+		Header:  []string{rangeLine(rng), "Share of each weekly cohort signing in during its Nth week, W0 being the signup week"},
+		Columns: columns,
+		Rows:    rows,
+	}, nil
+}
+
+// parseRange reads the from and to days of params
+func parseRange(params reports.Params) (userEntities.AnalyticsRange, error) {
+	var from, to time.Time
+	for name, day := range map[string]*time.Time{"from": &from, "to": &to} {
+		raw := params[name]
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(userEntities.AnalyticsDateLayout, raw)
+		if err != nil {
+			return userEntities.AnalyticsRange{}, sharedEntities.DomainError{Message: name + " must be a date as YYYY-MM-DD"}
+		}
+		*day = parsed
+	}
+	return userEntities.NewAnalyticsRange(from, to)
+}
+
+// parseWeeks reads the number of weeks of params
+func parseWeeks(params reports.Params) (int, error) {
+	raw := params["weeks"]
+	if raw == "" {
+		return userEntities.DefaultRetentionWeeks, nil
+	}
+	weeks, err := strconv.Atoi(raw)
+	if err != nil || weeks < 1 || weeks > userEntities.MaxRetentionWeeks {
+		return 0, userEntities.ErrInvalidRetentionWeeks
+	}
+	return weeks, nil
+}
+
+// rangeLine describes the days a report covers
+func rangeLine(rng userEntities.AnalyticsRange) string {
+	return "From " + rng.From.Format(userEntities.AnalyticsDateLayout) +
+		" through " + rng.To.AddDate(0, 0, -1).Format(userEntities.AnalyticsDateLayout)
+}
+
+// count prints a count
+func count(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
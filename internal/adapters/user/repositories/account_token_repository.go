@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+
+	"gorm.io/gorm"
+)
+
+// accountTokenRepository implements AccountTokenRepository using GORM
+type accountTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountTokenRepository creates a new database account token repository
+func NewAccountTokenRepository(db *gorm.DB) userRepositories.AccountTokenRepository {
+	return &accountTokenRepository{db: db}
+}
+
+// Create stores a token and assigns its ID
+func (r *accountTokenRepository) Create(ctx context.Context, token *userEntities.AccountToken) error {
+	model := models.NewUserAccountTokenModelFromEntity(token)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+	token.ID = model.ID
+	return nil
+}
+
+// Consume marks a redeemable token used; the conditional update lets only one of concurrent
+// redemptions through
+func (r *accountTokenRepository) Consume(ctx context.Context, purpose userEntities.AccountTokenPurpose, tokenHash string) (*userEntities.AccountToken, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&models.UserAccountTokenModel{}).
+		Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenHash, string(purpose), now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, userEntities.ErrInvalidAccountToken
+	}
+
+	var model models.UserAccountTokenModel
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&model).Error; err != nil {
+		return nil, err
+	}
+	return model.ToDomainEntity(), nil
+}
+
+// RevokeAll marks the user's unused tokens of purpose used
+func (r *accountTokenRepository) RevokeAll(ctx context.Context, userID uint, purpose userEntities.AccountTokenPurpose) error {
+	return r.db.WithContext(ctx).Model(&models.UserAccountTokenModel{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, string(purpose)).
+		Update("used_at", time.Now()).Error
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// accountTokenRepositoryBreaker guards an AccountTokenRepository with a circuit breaker
+type accountTokenRepositoryBreaker struct {
+	repo userRepositories.AccountTokenRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewAccountTokenRepositoryWithBreaker wraps repo so calls go through cb
+func NewAccountTokenRepositoryWithBreaker(repo userRepositories.AccountTokenRepository, cb *breaker.CircuitBreaker) userRepositories.AccountTokenRepository {
+	return &accountTokenRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Create stores a token through the breaker
+func (r *accountTokenRepositoryBreaker) Create(ctx context.Context, token *userEntities.AccountToken) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Create(ctx, token)
+	})
+}
+
+// Consume redeems a token through the breaker
+func (r *accountTokenRepositoryBreaker) Consume(ctx context.Context, purpose userEntities.AccountTokenPurpose, tokenHash string) (token *userEntities.AccountToken, err error) {
+	err = r.cb.Execute(func() error {
+		token, err = r.repo.Consume(ctx, purpose, tokenHash)
+		return err
+	})
+	return token, err
+}
+
+// RevokeAll revokes the user's tokens through the breaker
+func (r *accountTokenRepositoryBreaker) RevokeAll(ctx context.Context, userID uint, purpose userEntities.AccountTokenPurpose) error {
+	return r.cb.Execute(func() error {
+		return r.repo.RevokeAll(ctx, userID, purpose)
+	})
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+
+	"gorm.io/gorm"
+)
+
+// activityRepository implements ActivityRepository using GORM
+type activityRepository struct {
+	db *gorm.DB
+}
+
+// NewActivityRepository creates a new user activity repository
+func NewActivityRepository(db *gorm.DB) userRepositories.ActivityRepository {
+	return &activityRepository{db: db}
+}
+
+// Create appends an activity and assigns its ID
+func (r *activityRepository) Create(ctx context.Context, activity *userEntities.Activity) error {
+	model, err := models.NewUserActivityModelFromEntity(activity)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+	activity.ID = model.ID
+	return nil
+}
+
+// ListByUser retrieves a page of the user's activities, most recent first
+func (r *activityRepository) ListByUser(ctx context.Context, userID uint, limit, offset int) ([]*userEntities.Activity, error) {
+	var activityModels []models.UserActivityModel
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).
+		Order("occurred_at DESC, id DESC").Limit(limit).Offset(offset).
+		Find(&activityModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	activities := make([]*userEntities.Activity, len(activityModels))
+	for i := range activityModels {
+		activities[i] = activityModels[i].ToDomainEntity()
+	}
+	return activities, nil
+}
+
+// CountByUser counts the user's activities
+func (r *activityRepository) CountByUser(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.UserActivityModel{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// ListFollowedBy retrieves a page of the activities of the types by the users the follower
+// follows, most recent first
+func (r *activityRepository) ListFollowedBy(ctx context.Context, followerID uint, types []string, limit, offset int) ([]*userEntities.Activity, error) {
+	var activityModels []models.UserActivityModel
+	err := r.followedBy(ctx, followerID, types).
+		Order("occurred_at DESC, id DESC").Limit(limit).Offset(offset).
+		Find(&activityModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	activities := make([]*userEntities.Activity, len(activityModels))
+	for i := range activityModels {
+		activities[i] = activityModels[i].ToDomainEntity()
+	}
+	return activities, nil
+}
+
+// CountFollowedBy counts the activities of the types by the users the follower follows
+func (r *activityRepository) CountFollowedBy(ctx context.Context, followerID uint, types []string) (int64, error) {
+	var count int64
+	err := r.followedBy(ctx, followerID, types).Count(&count).Error
+	return count, err
+}
+
+// followedBy selects the activities of the types by the users the follower follows
+func (r *activityRepository) followedBy(ctx context.Context, followerID uint, types []string) *gorm.DB {
+	followees := r.db.Model(&models.UserConnectionModel{}).Select("followee_id").Where("follower_id = ?", followerID)
+	return r.db.WithContext(ctx).Model(&models.UserActivityModel{}).
+		Where("user_id IN (?) AND type IN ?", followees, types)
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// activityRepositoryBreaker guards an ActivityRepository with a circuit breaker
+type activityRepositoryBreaker struct {
+	repo userRepositories.ActivityRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewActivityRepositoryWithBreaker wraps repo so calls go through cb
+func NewActivityRepositoryWithBreaker(repo userRepositories.ActivityRepository, cb *breaker.CircuitBreaker) userRepositories.ActivityRepository {
+	return &activityRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Create appends an activity through the breaker
+func (r *activityRepositoryBreaker) Create(ctx context.Context, activity *userEntities.Activity) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Create(ctx, activity)
+	})
+}
+
+// ListByUser retrieves a page of activities through the breaker
+func (r *activityRepositoryBreaker) ListByUser(ctx context.Context, userID uint, limit, offset int) (activities []*userEntities.Activity, err error) {
+	err = r.cb.Execute(func() error {
+		activities, err = r.repo.ListByUser(ctx, userID, limit, offset)
+		return err
+	})
+	return activities, err
+}
+
+// CountByUser counts activities through the breaker
+func (r *activityRepositoryBreaker) CountByUser(ctx context.Context, userID uint) (count int64, err error) {
+	err = r.cb.Execute(func() error {
+		count, err = r.repo.CountByUser(ctx, userID)
+		return err
+	})
+	return count, err
+}
+
+// ListFollowedBy retrieves a page of followed users' activities through the breaker
+func (r *activityRepositoryBreaker) ListFollowedBy(ctx context.Context, followerID uint, types []string, limit, offset int) (activities []*userEntities.Activity, err error) {
+	err = r.cb.Execute(func() error {
+		activities, err = r.repo.ListFollowedBy(ctx, followerID, types, limit, offset)
+		return err
+	})
+	return activities, err
+}
+
+// CountFollowedBy counts followed users' activities through the breaker
+func (r *activityRepositoryBreaker) CountFollowedBy(ctx context.Context, followerID uint, types []string) (count int64, err error) {
+	err = r.cb.Execute(func() error {
+		count, err = r.repo.CountFollowedBy(ctx, followerID, types)
+		return err
+	})
+	return count, err
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// auditRepository implements AuditRepository using GORM
+type auditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new admin audit log repository
+func NewAuditRepository(db *gorm.DB) userRepositories.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+// Create appends an entry and assigns its ID, in the transaction ctx runs in, if any, so the
+// entry commits with the change it records
+func (r *auditRepository) Create(ctx context.Context, entry *userEntities.AuditEntry) error {
+	model, err := models.NewUserAuditModelFromEntity(entry)
+	if err != nil {
+		return err
+	}
+	if err := database.Conn(ctx, r.db).Create(model).Error; err != nil {
+		return err
+	}
+	entry.ID = model.ID
+	entry.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// ListByTarget retrieves a page of the actions on a user, newest first
+func (r *auditRepository) ListByTarget(ctx context.Context, userID uint, limit, offset int) ([]*userEntities.AuditEntry, error) {
+	var auditModels []models.UserAuditModel
+	err := r.db.WithContext(ctx).Where("target_user_id = ?", userID).
+		Order("id DESC").Limit(limit).Offset(offset).
+		Find(&auditModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*userEntities.AuditEntry, len(auditModels))
+	for i := range auditModels {
+		entries[i] = auditModels[i].ToDomainEntity()
+	}
+	return entries, nil
+}
+
+// CountByTarget counts the actions on a user
+func (r *auditRepository) CountByTarget(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.UserAuditModel{}).Where("target_user_id = ?", userID).Count(&count).Error
+	return count, err
+}
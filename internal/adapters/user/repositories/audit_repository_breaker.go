@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// auditRepositoryBreaker guards an AuditRepository with a circuit breaker
+type auditRepositoryBreaker struct {
+	repo userRepositories.AuditRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewAuditRepositoryWithBreaker wraps repo so calls go through cb
+func NewAuditRepositoryWithBreaker(repo userRepositories.AuditRepository, cb *breaker.CircuitBreaker) userRepositories.AuditRepository {
+	return &auditRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Create appends an entry through the breaker
+func (r *auditRepositoryBreaker) Create(ctx context.Context, entry *userEntities.AuditEntry) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Create(ctx, entry)
+	})
+}
+
+// ListByTarget retrieves a page of entries through the breaker
+func (r *auditRepositoryBreaker) ListByTarget(ctx context.Context, userID uint, limit, offset int) (entries []*userEntities.AuditEntry, err error) {
+	err = r.cb.Execute(func() error {
+		entries, err = r.repo.ListByTarget(ctx, userID, limit, offset)
+		return err
+	})
+	return entries, err
+}
+
+// CountByTarget counts entries through the breaker
+func (r *auditRepositoryBreaker) CountByTarget(ctx context.Context, userID uint) (count int64, err error) {
+	err = r.cb.Execute(func() error {
+		count, err = r.repo.CountByTarget(ctx, userID)
+		return err
+	})
+	return count, err
+}
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+
+	"gorm.io/gorm"
+)
+
+// authEventRepository implements AuthEventRepository using GORM
+type authEventRepository struct {
+	db *gorm.DB
+}
+
+// NewAuthEventRepository creates a new authentication audit trail repository
+func NewAuthEventRepository(db *gorm.DB) userRepositories.AuthEventRepository {
+	return &authEventRepository{db: db}
+}
+
+// Create appends an event and assigns its ID
+func (r *authEventRepository) Create(ctx context.Context, event *userEntities.AuthEvent) error {
+	model := models.NewUserAuthEventModelFromEntity(event)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+	event.ID = model.ID
+	return nil
+}
+
+// List retrieves a page of the events matching filter, most recent first
+func (r *authEventRepository) List(ctx context.Context, filter userEntities.AuthEventFilter, limit, offset int) ([]*userEntities.AuthEvent, error) {
+	var eventModels []models.UserAuthEventModel
+	err := r.filtered(ctx, filter).
+		Order("occurred_at DESC, id DESC").Limit(limit).Offset(offset).
+		Find(&eventModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*userEntities.AuthEvent, len(eventModels))
+	for i := range eventModels {
+		events[i] = eventModels[i].ToDomainEntity()
+	}
+	return events, nil
+}
+
+// Count counts the events matching filter
+func (r *authEventRepository) Count(ctx context.Context, filter userEntities.AuthEventFilter) (int64, error) {
+	var count int64
+	err := r.filtered(ctx, filter).Count(&count).Error
+	return count, err
+}
+
+// filtered returns a query of the events matching filter
+func (r *authEventRepository) filtered(ctx context.Context, filter userEntities.AuthEventFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.UserAuthEventModel{})
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", string(filter.Type))
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("occurred_at >= ?", filter.Since)
+	}
+	return query
+}
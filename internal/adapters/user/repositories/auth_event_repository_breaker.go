@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// authEventRepositoryBreaker guards an AuthEventRepository with a circuit breaker
+type authEventRepositoryBreaker struct {
+	repo userRepositories.AuthEventRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewAuthEventRepositoryWithBreaker wraps repo so calls go through cb
+func NewAuthEventRepositoryWithBreaker(repo userRepositories.AuthEventRepository, cb *breaker.CircuitBreaker) userRepositories.AuthEventRepository {
+	return &authEventRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Create appends an event through the breaker
+func (r *authEventRepositoryBreaker) Create(ctx context.Context, event *userEntities.AuthEvent) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Create(ctx, event)
+	})
+}
+
+// List retrieves a page of events through the breaker
+func (r *authEventRepositoryBreaker) List(ctx context.Context, filter userEntities.AuthEventFilter, limit, offset int) (events []*userEntities.AuthEvent, err error) {
+	err = r.cb.Execute(func() error {
+		events, err = r.repo.List(ctx, filter, limit, offset)
+		return err
+	})
+	return events, err
+}
+
+// Count counts events through the breaker
+func (r *authEventRepositoryBreaker) Count(ctx context.Context, filter userEntities.AuthEventFilter) (count int64, err error) {
+	err = r.cb.Execute(func() error {
+		count, err = r.repo.Count(ctx, filter)
+		return err
+	})
+	return count, err
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// connectionRepository implements ConnectionRepository using GORM
+type connectionRepository struct {
+	db *gorm.DB
+}
+
+// NewConnectionRepository creates a new user connection repository
+func NewConnectionRepository(db *gorm.DB) userRepositories.ConnectionRepository {
+	return &connectionRepository{db: db}
+}
+
+// Create stores a connection, assigning its ID when it is new
+func (r *connectionRepository) Create(ctx context.Context, connection *userEntities.Connection) error {
+	model := models.NewUserConnectionModelFromEntity(connection)
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model).Error; err != nil {
+		return err
+	}
+	connection.ID = model.ID
+	return nil
+}
+
+// Delete removes the connection of follower following followee
+func (r *connectionRepository) Delete(ctx context.Context, followerID, followeeID uint) (bool, error) {
+	result := r.db.WithContext(ctx).Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+		Delete(&models.UserConnectionModel{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Exists reports whether follower follows followee
+func (r *connectionRepository) Exists(ctx context.Context, followerID, followeeID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.UserConnectionModel{}).
+		Where("follower_id = ? AND followee_id = ?", followerID, followeeID).Count(&count).Error
+	return count > 0, err
+}
+
+// Counts counts the live users following the user and followed by it
+func (r *connectionRepository) Counts(ctx context.Context, userID uint) (userEntities.ConnectionCounts, error) {
+	var counts userEntities.ConnectionCounts
+	if err := r.connected(ctx, userID, userEntities.ConnectionFollowers).Count(&counts.Followers).Error; err != nil {
+		return counts, err
+	}
+	err := r.connected(ctx, userID, userEntities.ConnectionFollowing).Count(&counts.Following).Error
+	return counts, err
+}
+
+// List retrieves a page of the live users on the side of the user's connections, most
+// recently connected first
+func (r *connectionRepository) List(ctx context.Context, userID uint, side userEntities.ConnectionType, limit, offset int) ([]*userEntities.User, error) {
+	var userModels []models.UserModel
+	err := r.connected(ctx, userID, side).
+		Order("user_connections.created_at DESC, user_connections.id DESC").Limit(limit).Offset(offset).
+		Find(&userModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*userEntities.User, len(userModels))
+	for i := range userModels {
+		users[i] = userModels[i].ToDomainEntity()
+	}
+	return users, nil
+}
+
+// connected selects the users on the side of the user's connections; soft deleted users are
+// left out by the users scope
+func (r *connectionRepository) connected(ctx context.Context, userID uint, side userEntities.ConnectionType) *gorm.DB {
+	// The connected user is on the other end of the user's connections
+	own, other := "followee_id", "follower_id"
+	if side == userEntities.ConnectionFollowing {
+		own, other = other, own
+	}
+	return r.db.WithContext(ctx).Model(&models.UserModel{}).
+		Joins("JOIN user_connections ON user_connections."+other+" = users.id AND user_connections.tenant_id = users.tenant_id").
+		Where("user_connections."+own+" = ?", userID)
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// connectionRepositoryBreaker guards a ConnectionRepository with a circuit breaker
+type connectionRepositoryBreaker struct {
+	repo userRepositories.ConnectionRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewConnectionRepositoryWithBreaker wraps repo so calls go through cb
+func NewConnectionRepositoryWithBreaker(repo userRepositories.ConnectionRepository, cb *breaker.CircuitBreaker) userRepositories.ConnectionRepository {
+	return &connectionRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Create stores a connection through the breaker
+func (r *connectionRepositoryBreaker) Create(ctx context.Context, connection *userEntities.Connection) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Create(ctx, connection)
+	})
+}
+
+// Delete removes a connection through the breaker
+func (r *connectionRepositoryBreaker) Delete(ctx context.Context, followerID, followeeID uint) (deleted bool, err error) {
+	err = r.cb.Execute(func() error {
+		deleted, err = r.repo.Delete(ctx, followerID, followeeID)
+		return err
+	})
+	return deleted, err
+}
+
+// Exists looks a connection up through the breaker
+func (r *connectionRepositoryBreaker) Exists(ctx context.Context, followerID, followeeID uint) (exists bool, err error) {
+	err = r.cb.Execute(func() error {
+		exists, err = r.repo.Exists(ctx, followerID, followeeID)
+		return err
+	})
+	return exists, err
+}
+
+// Counts counts connections through the breaker
+func (r *connectionRepositoryBreaker) Counts(ctx context.Context, userID uint) (counts userEntities.ConnectionCounts, err error) {
+	err = r.cb.Execute(func() error {
+		counts, err = r.repo.Counts(ctx, userID)
+		return err
+	})
+	return counts, err
+}
+
+// List retrieves a page of connected users through the breaker
+func (r *connectionRepositoryBreaker) List(ctx context.Context, userID uint, side userEntities.ConnectionType, limit, offset int) (users []*userEntities.User, err error) {
+	err = r.cb.Execute(func() error {
+		users, err = r.repo.List(ctx, userID, side, limit, offset)
+		return err
+	})
+	return users, err
+}
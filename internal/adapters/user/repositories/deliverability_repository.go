@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+
+	"gorm.io/gorm"
+)
+
+// deliverabilityRepository implements DeliverabilityRepository using GORM
+type deliverabilityRepository struct {
+	db *gorm.DB
+}
+
+// NewDeliverabilityRepository creates a new database deliverability repository
+func NewDeliverabilityRepository(db *gorm.DB) userRepositories.DeliverabilityRepository {
+	return &deliverabilityRepository{db: db}
+}
+
+// MarkUndeliverable looks the users up by the blind index of email, since emails may be
+// encrypted, and flags those not flagged yet in one transaction
+func (r *deliverabilityRepository) MarkUndeliverable(ctx context.Context, email, reason string, at time.Time) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.UserModel{}).
+			Where("email_hash = ? AND email_undeliverable_at IS NULL", models.EmailIndex(email)).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		return tx.Model(&models.UserModel{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"email_undeliverable_at":     at,
+			"email_undeliverable_reason": reason,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
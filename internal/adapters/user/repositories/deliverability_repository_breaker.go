@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// deliverabilityRepositoryBreaker guards a DeliverabilityRepository with a circuit breaker
+type deliverabilityRepositoryBreaker struct {
+	repo userRepositories.DeliverabilityRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewDeliverabilityRepositoryWithBreaker wraps repo so calls go through cb
+func NewDeliverabilityRepositoryWithBreaker(repo userRepositories.DeliverabilityRepository, cb *breaker.CircuitBreaker) userRepositories.DeliverabilityRepository {
+	return &deliverabilityRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// MarkUndeliverable flags the users with email through the breaker
+func (r *deliverabilityRepositoryBreaker) MarkUndeliverable(ctx context.Context, email, reason string, at time.Time) (ids []uint, err error) {
+	err = r.cb.Execute(func() error {
+		ids, err = r.repo.MarkUndeliverable(ctx, email, reason, at)
+		return err
+	})
+	return ids, err
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// deviceRepository implements DeviceRepository using GORM
+type deviceRepository struct {
+	db *gorm.DB
+}
+
+// NewDeviceRepository creates a new database device repository
+func NewDeviceRepository(db *gorm.DB) userRepositories.DeviceRepository {
+	return &deviceRepository{db: db}
+}
+
+// Register upserts the device by token and prunes the user's devices in one transaction
+func (r *deviceRepository) Register(ctx context.Context, device *userEntities.Device, keep int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		model := models.NewUserDeviceModelFromEntity(device)
+		err := tx.Clauses(database.OnConflictUpdate([]string{"token"}, models.UserDeviceUpsertColumns)).Create(model).Error
+		if err != nil {
+			return err
+		}
+		// The insert's ID is not reported for an update on every database
+		var stored models.UserDeviceModel
+		if err := tx.First(&stored, "token = ?", device.Token).Error; err != nil {
+			return err
+		}
+		*device = *stored.ToDomainEntity()
+
+		var stale []uint
+		err = tx.Model(&models.UserDeviceModel{}).Where("user_id = ?", device.UserID).
+			Order("updated_at DESC").Order("id DESC").Offset(keep).Pluck("id", &stale).Error
+		if err != nil || len(stale) == 0 {
+			return err
+		}
+		return tx.Where("id IN ?", stale).Delete(&models.UserDeviceModel{}).Error
+	})
+}
+
+// ListByUser retrieves the user's devices
+func (r *deviceRepository) ListByUser(ctx context.Context, userID uint) ([]*userEntities.Device, error) {
+	var deviceModels []models.UserDeviceModel
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).
+		Order("updated_at DESC").Order("id DESC").Find(&deviceModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]*userEntities.Device, len(deviceModels))
+	for i := range deviceModels {
+		devices[i] = deviceModels[i].ToDomainEntity()
+	}
+	return devices, nil
+}
+
+// Delete removes one of the user's devices
+func (r *deviceRepository) Delete(ctx context.Context, userID, id uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.UserDeviceModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return userEntities.ErrDeviceNotFound
+	}
+	return nil
+}
+
+// DeleteByToken removes the device with token
+func (r *deviceRepository) DeleteByToken(ctx context.Context, token string) error {
+	return r.db.WithContext(ctx).Where("token = ?", token).Delete(&models.UserDeviceModel{}).Error
+}
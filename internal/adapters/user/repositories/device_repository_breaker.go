@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// deviceRepositoryBreaker guards a DeviceRepository with a circuit breaker
+type deviceRepositoryBreaker struct {
+	repo userRepositories.DeviceRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewDeviceRepositoryWithBreaker wraps repo so calls go through cb
+func NewDeviceRepositoryWithBreaker(repo userRepositories.DeviceRepository, cb *breaker.CircuitBreaker) userRepositories.DeviceRepository {
+	return &deviceRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Register stores the device through the breaker
+func (r *deviceRepositoryBreaker) Register(ctx context.Context, device *userEntities.Device, keep int) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Register(ctx, device, keep)
+	})
+}
+
+// ListByUser lists the user's devices through the breaker
+func (r *deviceRepositoryBreaker) ListByUser(ctx context.Context, userID uint) (devices []*userEntities.Device, err error) {
+	err = r.cb.Execute(func() error {
+		devices, err = r.repo.ListByUser(ctx, userID)
+		return err
+	})
+	return devices, err
+}
+
+// Delete removes one of the user's devices through the breaker
+func (r *deviceRepositoryBreaker) Delete(ctx context.Context, userID, id uint) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Delete(ctx, userID, id)
+	})
+}
+
+// DeleteByToken removes the device with token through the breaker
+func (r *deviceRepositoryBreaker) DeleteByToken(ctx context.Context, token string) error {
+	return r.cb.Execute(func() error {
+		return r.repo.DeleteByToken(ctx, token)
+	})
+}
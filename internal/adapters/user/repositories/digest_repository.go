@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+
+	"gorm.io/gorm"
+)
+
+// digestRepository implements DigestRepository using GORM
+type digestRepository struct {
+	db *gorm.DB
+}
+
+// NewDigestRepository creates a new notification digest repository
+func NewDigestRepository(db *gorm.DB) userRepositories.DigestRepository {
+	return &digestRepository{db: db}
+}
+
+// Add stores a pending item and assigns its ID
+func (r *digestRepository) Add(ctx context.Context, item *userEntities.DigestItem) error {
+	model := models.NewNotificationDigestItemModelFromEntity(item)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+	item.ID = model.ID
+	item.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// PendingUsers returns the distinct users with pending items
+func (r *digestRepository) PendingUsers(ctx context.Context) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.WithContext(ctx).Model(&models.NotificationDigestItemModel{}).
+		Distinct("user_id").Order("user_id").Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// ListByUser retrieves a user's pending items, oldest first
+func (r *digestRepository) ListByUser(ctx context.Context, userID uint) ([]*userEntities.DigestItem, error) {
+	var itemModels []models.NotificationDigestItemModel
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&itemModels).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]*userEntities.DigestItem, len(itemModels))
+	for i := range itemModels {
+		items[i] = itemModels[i].ToDomainEntity()
+	}
+	return items, nil
+}
+
+// DeleteThrough deletes a user's items with an ID up to lastID
+func (r *digestRepository) DeleteThrough(ctx context.Context, userID, lastID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ? AND id <= ?", userID, lastID).
+		Delete(&models.NotificationDigestItemModel{}).Error
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// digestRepositoryBreaker guards a DigestRepository with a circuit breaker
+type digestRepositoryBreaker struct {
+	repo userRepositories.DigestRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewDigestRepositoryWithBreaker wraps repo so calls go through cb
+func NewDigestRepositoryWithBreaker(repo userRepositories.DigestRepository, cb *breaker.CircuitBreaker) userRepositories.DigestRepository {
+	return &digestRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Add stores a pending item through the breaker
+func (r *digestRepositoryBreaker) Add(ctx context.Context, item *userEntities.DigestItem) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Add(ctx, item)
+	})
+}
+
+// PendingUsers returns the users with pending items through the breaker
+func (r *digestRepositoryBreaker) PendingUsers(ctx context.Context) (userIDs []uint, err error) {
+	err = r.cb.Execute(func() error {
+		userIDs, err = r.repo.PendingUsers(ctx)
+		return err
+	})
+	return userIDs, err
+}
+
+// ListByUser retrieves a user's pending items through the breaker
+func (r *digestRepositoryBreaker) ListByUser(ctx context.Context, userID uint) (items []*userEntities.DigestItem, err error) {
+	err = r.cb.Execute(func() error {
+		items, err = r.repo.ListByUser(ctx, userID)
+		return err
+	})
+	return items, err
+}
+
+// DeleteThrough deletes a user's sent items through the breaker
+func (r *digestRepositoryBreaker) DeleteThrough(ctx context.Context, userID, lastID uint) error {
+	return r.cb.Execute(func() error {
+		return r.repo.DeleteThrough(ctx, userID, lastID)
+	})
+}
@@ -0,0 +1,15 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"testing"
+
+	"clean-arch-gin/internal/testutil/integration"
+)
+
+// TestIntegrationUserRepository runs the user repository suite and contract against every
+// container-backed database; run it with just test-integration
+func TestIntegrationUserRepository(t *testing.T) {
+	integration.RunUserRepositoryConformance(t)
+}
@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/domain/shared/pagination"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// notificationRepository implements NotificationRepository using GORM
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new user notification repository
+func NewNotificationRepository(db *gorm.DB) userRepositories.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// Create stores a notification, assigns its ID and counts it as unread
+func (r *notificationRepository) Create(ctx context.Context, notification *userEntities.Notification) error {
+	model, err := models.NewNotificationModelFromEntity(notification)
+	if err != nil {
+		return err
+	}
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		return adjustCounts(tx, model.UserID, 1, 1)
+	})
+	if err != nil {
+		return err
+	}
+	notification.ID = model.ID
+	notification.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// ListByUser retrieves the page of a user's notifications before cursor, newest first
+func (r *notificationRepository) ListByUser(ctx context.Context, userID uint, unreadOnly bool, cursor pagination.Cursor, limit int) ([]*userEntities.Notification, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notificationModels []models.NotificationModel
+	if err := database.PageBefore(query, cursor, limit).Find(&notificationModels).Error; err != nil {
+		return nil, err
+	}
+
+	notifications := make([]*userEntities.Notification, len(notificationModels))
+	for i := range notificationModels {
+		notifications[i] = notificationModels[i].ToDomainEntity()
+	}
+	return notifications, nil
+}
+
+// CountByUser reads the counts kept for a user's inbox; a user never notified has none
+func (r *notificationRepository) CountByUser(ctx context.Context, userID uint) (userEntities.NotificationCounts, error) {
+	var counter models.NotificationCounterModel
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Limit(1).Find(&counter).Error
+	if err != nil {
+		return userEntities.NotificationCounts{}, err
+	}
+	return userEntities.NotificationCounts{Total: counter.Total, Unread: counter.Unread}, nil
+}
+
+// MarkAsRead sets the read time of a user's notification; reading it again keeps the first time
+func (r *notificationRepository) MarkAsRead(ctx context.Context, userID, id uint) (*userEntities.Notification, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.NotificationModel{}).
+			Where("id = ? AND user_id = ? AND read_at IS NULL", id, userID).
+			Update("read_at", time.Now())
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return adjustCounts(tx, userID, 0, -1)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var model models.NotificationModel
+	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, userEntities.ErrNotificationNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomainEntity(), nil
+}
+
+// MarkAllAsRead sets the read time of every unread notification of a user
+func (r *notificationRepository) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	var marked int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.NotificationModel{}).
+			Where("user_id = ? AND read_at IS NULL", userID).
+			Update("read_at", time.Now())
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		marked = result.RowsAffected
+		return adjustCounts(tx, userID, 0, -marked)
+	})
+	return marked, err
+}
+
+// Delete permanently deletes a user's notification
+func (r *notificationRepository) Delete(ctx context.Context, userID, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var model models.NotificationModel
+		err := tx.Select("id", "read_at").Where("id = ? AND user_id = ?", id, userID).First(&model).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return userEntities.ErrNotificationNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&models.NotificationModel{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return userEntities.ErrNotificationNotFound
+		}
+		var unread int64
+		if model.ReadAt == nil {
+			unread = -1
+		}
+		return adjustCounts(tx, userID, -1, unread)
+	})
+}
+
+// adjustCounts adds total and unread to the counts of a user's inbox in tx, creating them
+// for the first notification
+// Increments are done by the database so concurrent changes are all counted
+func adjustCounts(tx *gorm.DB, userID uint, total, unread int64) error {
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.NotificationCounterModel{UserID: userID}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Model(&models.NotificationCounterModel{}).Where("user_id = ?", userID).Updates(map[string]interface{}{
+		"total":  gorm.Expr("total + ?", total),
+		"unread": gorm.Expr("unread + ?", unread),
+	}).Error
+}
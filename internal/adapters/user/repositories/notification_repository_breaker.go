@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/shared/pagination"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// notificationRepositoryBreaker guards a NotificationRepository with a circuit breaker
+type notificationRepositoryBreaker struct {
+	repo userRepositories.NotificationRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewNotificationRepositoryWithBreaker wraps repo so calls go through cb
+func NewNotificationRepositoryWithBreaker(repo userRepositories.NotificationRepository, cb *breaker.CircuitBreaker) userRepositories.NotificationRepository {
+	return &notificationRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Create stores a notification through the breaker
+func (r *notificationRepositoryBreaker) Create(ctx context.Context, notification *userEntities.Notification) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Create(ctx, notification)
+	})
+}
+
+// ListByUser retrieves a page of notifications through the breaker
+func (r *notificationRepositoryBreaker) ListByUser(ctx context.Context, userID uint, unreadOnly bool, cursor pagination.Cursor, limit int) (notifications []*userEntities.Notification, err error) {
+	err = r.cb.Execute(func() error {
+		notifications, err = r.repo.ListByUser(ctx, userID, unreadOnly, cursor, limit)
+		return err
+	})
+	return notifications, err
+}
+
+// CountByUser counts notifications through the breaker
+func (r *notificationRepositoryBreaker) CountByUser(ctx context.Context, userID uint) (counts userEntities.NotificationCounts, err error) {
+	err = r.cb.Execute(func() error {
+		counts, err = r.repo.CountByUser(ctx, userID)
+		return err
+	})
+	return counts, err
+}
+
+// MarkAsRead marks a notification as read through the breaker
+func (r *notificationRepositoryBreaker) MarkAsRead(ctx context.Context, userID, id uint) (notification *userEntities.Notification, err error) {
+	err = r.cb.Execute(func() error {
+		notification, err = r.repo.MarkAsRead(ctx, userID, id)
+		return err
+	})
+	return notification, err
+}
+
+// MarkAllAsRead marks every notification of a user as read through the breaker
+func (r *notificationRepositoryBreaker) MarkAllAsRead(ctx context.Context, userID uint) (marked int64, err error) {
+	err = r.cb.Execute(func() error {
+		marked, err = r.repo.MarkAllAsRead(ctx, userID)
+		return err
+	})
+	return marked, err
+}
+
+// Delete deletes a notification through the breaker
+func (r *notificationRepositoryBreaker) Delete(ctx context.Context, userID, id uint) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Delete(ctx, userID, id)
+	})
+}
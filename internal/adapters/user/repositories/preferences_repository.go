@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// preferencesRepository implements PreferencesRepository using GORM
+type preferencesRepository struct {
+	db *gorm.DB
+}
+
+// NewPreferencesRepository creates a new user preferences repository
+func NewPreferencesRepository(db *gorm.DB) userRepositories.PreferencesRepository {
+	return &preferencesRepository{db: db}
+}
+
+// Get retrieves the preferences of a user
+func (r *preferencesRepository) Get(ctx context.Context, userID uint) (*userEntities.UserPreferences, error) {
+	var model models.UserPreferencesModel
+	if err := r.db.WithContext(ctx).First(&model, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, userEntities.ErrUserPreferencesNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomainEntity()
+}
+
+// Save inserts the preferences or replaces the stored ones, keeping their creation time
+func (r *preferencesRepository) Save(ctx context.Context, prefs *userEntities.UserPreferences) error {
+	model, err := models.NewUserPreferencesModelFromEntity(prefs)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"locale", "timezone", "notify_email", "notify_push", "notify_sms", "notify_marketing",
+			"notification_types", "quiet_hours_start", "quiet_hours_end", "custom", "updated_at",
+		}),
+	}).Create(model).Error
+	if err != nil {
+		return err
+	}
+	prefs.UpdatedAt = model.UpdatedAt
+	if prefs.CreatedAt.IsZero() {
+		prefs.CreatedAt = model.CreatedAt
+	}
+	return nil
+}
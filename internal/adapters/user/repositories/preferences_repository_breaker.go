@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// preferencesRepositoryBreaker guards a PreferencesRepository with a circuit breaker
+type preferencesRepositoryBreaker struct {
+	repo userRepositories.PreferencesRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewPreferencesRepositoryWithBreaker wraps repo so calls go through cb
+func NewPreferencesRepositoryWithBreaker(repo userRepositories.PreferencesRepository, cb *breaker.CircuitBreaker) userRepositories.PreferencesRepository {
+	return &preferencesRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Get retrieves the preferences of a user through the breaker
+func (r *preferencesRepositoryBreaker) Get(ctx context.Context, userID uint) (prefs *userEntities.UserPreferences, err error) {
+	err = r.cb.Execute(func() error {
+		prefs, err = r.repo.Get(ctx, userID)
+		return err
+	})
+	return prefs, err
+}
+
+// Save saves the preferences of a user through the breaker
+func (r *preferencesRepositoryBreaker) Save(ctx context.Context, prefs *userEntities.UserPreferences) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Save(ctx, prefs)
+	})
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+
+	"gorm.io/gorm"
+)
+
+// sessionRepository implements SessionRepository using GORM
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository creates a new database session repository
+func NewSessionRepository(db *gorm.DB) userRepositories.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// Create stores a session and assigns its ID
+func (r *sessionRepository) Create(ctx context.Context, session *userEntities.Session) error {
+	model := models.NewUserSessionModelFromEntity(session)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+	session.ID = model.ID
+	return nil
+}
+
+// GetByTokenHash retrieves a session, revoked or not, by its token hash
+func (r *sessionRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*userEntities.Session, error) {
+	var model models.UserSessionModel
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, userEntities.ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomainEntity(), nil
+}
+
+// ListByUser retrieves the user's active sessions, most recently used first
+func (r *sessionRepository) ListByUser(ctx context.Context, userID uint) ([]*userEntities.Session, error) {
+	var sessionModels []models.UserSessionModel
+	err := r.active(ctx, userID).Order("last_seen_at DESC, id DESC").Find(&sessionModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*userEntities.Session, len(sessionModels))
+	for i := range sessionModels {
+		sessions[i] = sessionModels[i].ToDomainEntity()
+	}
+	return sessions, nil
+}
+
+// Touch records the last use of a session
+func (r *sessionRepository) Touch(ctx context.Context, id uint, seenAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.UserSessionModel{}).Where("id = ?", id).Update("last_seen_at", seenAt).Error
+}
+
+// Revoke signs out one of the user's active sessions
+func (r *sessionRepository) Revoke(ctx context.Context, userID, id uint) error {
+	result := r.active(ctx, userID).Where("id = ?", id).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return userEntities.ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeAll signs out the user's active sessions except exceptID
+func (r *sessionRepository) RevokeAll(ctx context.Context, userID, exceptID uint) (int64, error) {
+	result := r.active(ctx, userID).Where("id <> ?", exceptID).Update("revoked_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+// active scopes a query to the user's sessions that are neither revoked nor expired
+func (r *sessionRepository) active(ctx context.Context, userID uint) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&models.UserSessionModel{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now())
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// sessionRepositoryBreaker guards a SessionRepository with a circuit breaker
+type sessionRepositoryBreaker struct {
+	repo userRepositories.SessionRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewSessionRepositoryWithBreaker wraps repo so calls go through cb
+func NewSessionRepositoryWithBreaker(repo userRepositories.SessionRepository, cb *breaker.CircuitBreaker) userRepositories.SessionRepository {
+	return &sessionRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Create stores a session through the breaker
+func (r *sessionRepositoryBreaker) Create(ctx context.Context, session *userEntities.Session) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Create(ctx, session)
+	})
+}
+
+// GetByTokenHash retrieves a session through the breaker
+func (r *sessionRepositoryBreaker) GetByTokenHash(ctx context.Context, tokenHash string) (session *userEntities.Session, err error) {
+	err = r.cb.Execute(func() error {
+		session, err = r.repo.GetByTokenHash(ctx, tokenHash)
+		return err
+	})
+	return session, err
+}
+
+// ListByUser retrieves the active sessions through the breaker
+func (r *sessionRepositoryBreaker) ListByUser(ctx context.Context, userID uint) (sessions []*userEntities.Session, err error) {
+	err = r.cb.Execute(func() error {
+		sessions, err = r.repo.ListByUser(ctx, userID)
+		return err
+	})
+	return sessions, err
+}
+
+// Touch records the last use of a session through the breaker
+func (r *sessionRepositoryBreaker) Touch(ctx context.Context, id uint, seenAt time.Time) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Touch(ctx, id, seenAt)
+	})
+}
+
+// Revoke signs out a session through the breaker
+func (r *sessionRepositoryBreaker) Revoke(ctx context.Context, userID, id uint) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Revoke(ctx, userID, id)
+	})
+}
+
+// RevokeAll signs out the sessions through the breaker
+func (r *sessionRepositoryBreaker) RevokeAll(ctx context.Context, userID, exceptID uint) (revoked int64, err error) {
+	err = r.cb.Execute(func() error {
+		revoked, err = r.repo.RevokeAll(ctx, userID, exceptID)
+		return err
+	})
+	return revoked, err
+}
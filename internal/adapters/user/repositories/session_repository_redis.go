@@ -0,0 +1,214 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// sessionRepositoryRedis implements SessionRepository on Redis
+// Each session is a JSON value under session:<id> with a token:<hash> index, both expiring
+// with the session, and user:<id> holds the set of a user's session IDs. Revoking deletes
+// the keys, so revoked sessions are reported as not found. Keys of a tenant's sessions
+// are namespaced under tenant:<id>:
+type sessionRepositoryRedis struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewSessionRepositoryRedis creates a session repository storing keys under prefix
+func NewSessionRepositoryRedis(client redis.UniversalClient, prefix string) userRepositories.SessionRepository {
+	return &sessionRepositoryRedis{client: client, prefix: prefix}
+}
+
+// Create stores a session and assigns its ID from the sequence key
+func (r *sessionRepositoryRedis) Create(ctx context.Context, session *userEntities.Session) error {
+	id, err := r.client.Incr(ctx, r.prefix+"seq").Result()
+	if err != nil {
+		return err
+	}
+	session.ID = uint(id)
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(session.ExpiresAt)
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, r.sessionKey(ctx, session.ID), data, ttl)
+		pipe.Set(ctx, r.tokenKey(ctx, session.TokenHash), session.ID, ttl)
+		pipe.SAdd(ctx, r.userKey(ctx, session.UserID), session.ID)
+		return nil
+	})
+	return err
+}
+
+// GetByTokenHash retrieves a session through the token index
+func (r *sessionRepositoryRedis) GetByTokenHash(ctx context.Context, tokenHash string) (*userEntities.Session, error) {
+	id, err := r.client.Get(ctx, r.tokenKey(ctx, tokenHash)).Uint64()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, userEntities.ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return r.get(ctx, uint(id))
+}
+
+// ListByUser retrieves the user's live sessions, dropping the IDs of expired ones from the set
+func (r *sessionRepositoryRedis) ListByUser(ctx context.Context, userID uint) ([]*userEntities.Session, error) {
+	ids, err := r.client.SMembers(ctx, r.userKey(ctx, userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []*userEntities.Session{}, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = r.prefix + "session:" + id
+	}
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*userEntities.Session, 0, len(values))
+	var stale []interface{}
+	now := time.Now()
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			stale = append(stale, ids[i])
+			continue
+		}
+		var session userEntities.Session
+		if err := json.Unmarshal([]byte(data), &session); err != nil {
+			return nil, err
+		}
+		if session.IsRevoked() || session.IsExpired(now) {
+			continue
+		}
+		sessions = append(sessions, &session)
+	}
+	if len(stale) > 0 {
+		if err := r.client.SRem(ctx, r.userKey(ctx, userID), stale...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].LastSeenAt.Equal(sessions[j].LastSeenAt) {
+			return sessions[i].ID > sessions[j].ID
+		}
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+	return sessions, nil
+}
+
+// Touch records the last use of a session, keeping its expiry
+func (r *sessionRepositoryRedis) Touch(ctx context.Context, id uint, seenAt time.Time) error {
+	session, err := r.get(ctx, id)
+	if err != nil {
+		if err == userEntities.ErrSessionNotFound {
+			return nil
+		}
+		return err
+	}
+	session.LastSeenAt = seenAt
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return r.client.SetArgs(ctx, r.sessionKey(ctx, id), data, redis.SetArgs{Mode: "XX", KeepTTL: true}).Err()
+}
+
+// Revoke signs out one of the user's sessions by deleting its keys
+func (r *sessionRepositoryRedis) Revoke(ctx context.Context, userID, id uint) error {
+	session, err := r.get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID || session.IsRevoked() || session.IsExpired(time.Now()) {
+		return userEntities.ErrSessionNotFound
+	}
+	return r.delete(ctx, session)
+}
+
+// RevokeAll signs out the user's live sessions except exceptID
+func (r *sessionRepositoryRedis) RevokeAll(ctx context.Context, userID, exceptID uint) (int64, error) {
+	sessions, err := r.ListByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var revoked int64
+	for _, session := range sessions {
+		if session.ID == exceptID {
+			continue
+		}
+		if err := r.delete(ctx, session); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+// get loads the session stored under id
+func (r *sessionRepositoryRedis) get(ctx context.Context, id uint) (*userEntities.Session, error) {
+	data, err := r.client.Get(ctx, r.sessionKey(ctx, id)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, userEntities.ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	var session userEntities.Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// delete removes the keys of session
+func (r *sessionRepositoryRedis) delete(ctx context.Context, session *userEntities.Session) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, r.sessionKey(ctx, session.ID), r.tokenKey(ctx, session.TokenHash))
+		pipe.SRem(ctx, r.userKey(ctx, session.UserID), session.ID)
+		return nil
+	})
+	return err
+}
+
+// keyPrefix namespaces the keys of the context's tenant, so a token only resolves
+// within the tenant it was issued for
+func (r *sessionRepositoryRedis) keyPrefix(ctx context.Context) string {
+	if tenantID, ok := tenancy.FromContext(ctx); ok {
+		return r.prefix + "tenant:" + strconv.FormatUint(uint64(tenantID), 10) + ":"
+	}
+	return r.prefix
+}
+
+func (r *sessionRepositoryRedis) sessionKey(ctx context.Context, id uint) string {
+	return r.keyPrefix(ctx) + "session:" + strconv.FormatUint(uint64(id), 10)
+}
+
+func (r *sessionRepositoryRedis) tokenKey(ctx context.Context, tokenHash string) string {
+	return r.keyPrefix(ctx) + "token:" + tokenHash
+}
+
+func (r *sessionRepositoryRedis) userKey(ctx context.Context, userID uint) string {
+	return r.keyPrefix(ctx) + "user:" + strconv.FormatUint(uint64(userID), 10)
+}
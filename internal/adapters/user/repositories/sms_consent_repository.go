@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+
+	"gorm.io/gorm"
+)
+
+// smsConsentRepository implements SMSConsentRepository using GORM
+type smsConsentRepository struct {
+	db *gorm.DB
+}
+
+// NewSMSConsentRepository creates a new database SMS consent repository
+func NewSMSConsentRepository(db *gorm.DB) userRepositories.SMSConsentRepository {
+	return &smsConsentRepository{db: db}
+}
+
+// SetOptedOut looks the users up by the blind index of phone, since phones are encrypted,
+// and changes those whose consent differs in one transaction
+func (r *smsConsentRepository) SetOptedOut(ctx context.Context, phone string, at *time.Time) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.UserModel{}).Where("phone_hash = ?", models.PhoneIndex(phone))
+		if at != nil {
+			query = query.Where("sms_opted_out_at IS NULL")
+		} else {
+			query = query.Where("sms_opted_out_at IS NOT NULL")
+		}
+		if err := query.Pluck("id", &ids).Error; err != nil || len(ids) == 0 {
+			return err
+		}
+		return tx.Model(&models.UserModel{}).Where("id IN ?", ids).Update("sms_opted_out_at", at).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// smsConsentRepositoryBreaker guards an SMSConsentRepository with a circuit breaker
+type smsConsentRepositoryBreaker struct {
+	repo userRepositories.SMSConsentRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewSMSConsentRepositoryWithBreaker wraps repo so calls go through cb
+func NewSMSConsentRepositoryWithBreaker(repo userRepositories.SMSConsentRepository, cb *breaker.CircuitBreaker) userRepositories.SMSConsentRepository {
+	return &smsConsentRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// SetOptedOut changes the consent of the users with phone through the breaker
+func (r *smsConsentRepositoryBreaker) SetOptedOut(ctx context.Context, phone string, at *time.Time) (ids []uint, err error) {
+	err = r.cb.Execute(func() error {
+		ids, err = r.repo.SetOptedOut(ctx, phone, at)
+		return err
+	})
+	return ids, err
+}
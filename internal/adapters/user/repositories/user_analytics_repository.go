@@ -0,0 +1,219 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// userAnalyticsRepository implements UserAnalyticsRepository with grouped GORM queries
+// Users are read unscoped, since deleted users count too, and sign-ins from the
+// authentication audit trail
+type userAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+// NewUserAnalyticsRepository creates a new user analytics repository
+func NewUserAnalyticsRepository(db *gorm.DB) userRepositories.UserAnalyticsRepository {
+	return &userAnalyticsRepository{db: db}
+}
+
+// Totals counts the users by status, role and whether they are deleted in one query
+func (r *userAnalyticsRepository) Totals(ctx context.Context) (*userEntities.UserTotals, error) {
+	var rows []struct {
+		Status    string
+		Role      string
+		IsDeleted bool
+		Count     int64
+	}
+	err := database.Conn(ctx, r.db).Unscoped().Model(&models.UserModel{}).
+		Select("status, role, CASE WHEN deleted_at IS NULL THEN 0 ELSE 1 END AS is_deleted, COUNT(*) AS count").
+		Group("status, role, is_deleted").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := &userEntities.UserTotals{
+		ByStatus: make(map[userEntities.Status]int64),
+		ByRole:   make(map[userEntities.Role]int64),
+	}
+	for _, row := range rows {
+		if row.IsDeleted {
+			totals.Deleted += row.Count
+			continue
+		}
+		totals.Live += row.Count
+		totals.ByStatus[userEntities.Status(row.Status)] += row.Count
+		totals.ByRole[userEntities.Role(row.Role)] += row.Count
+	}
+	return totals, nil
+}
+
+// dailyCount is a count of rows grouped by day
+type dailyCount struct {
+	Day   string
+	Count int64
+}
+
+// countByDay counts the users whose column falls in r by day
+func (r *userAnalyticsRepository) countByDay(ctx context.Context, column string, rng userEntities.AnalyticsRange) ([]dailyCount, error) {
+	day := database.DateBucket(r.db, column, database.PeriodDay)
+	var rows []dailyCount
+	err := database.Conn(ctx, r.db).Unscoped().Model(&models.UserModel{}).
+		Select(day+" AS day, COUNT(*) AS count").
+		Where(column+" >= ? AND "+column+" < ?", rng.From, rng.To).
+		Group("day").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// Signups counts the users created and deleted by day, one grouped query each
+func (r *userAnalyticsRepository) Signups(ctx context.Context, rng userEntities.AnalyticsRange) ([]userEntities.DailySignups, error) {
+	created, err := r.countByDay(ctx, "created_at", rng)
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := r.countByDay(ctx, "deleted_at", rng)
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[string]*userEntities.DailySignups)
+	day := func(date string) *userEntities.DailySignups {
+		if _, ok := byDay[date]; !ok {
+			byDay[date] = &userEntities.DailySignups{Date: date}
+		}
+		return byDay[date]
+	}
+	for _, row := range created {
+		day(row.Day).Signups = row.Count
+	}
+	for _, row := range deleted {
+		day(row.Day).Deletions = row.Count
+	}
+
+	days := make([]userEntities.DailySignups, 0, len(byDay))
+	for _, date := range rng.Days() {
+		if signups, ok := byDay[date]; ok {
+			days = append(days, *signups)
+		}
+	}
+	return days, nil
+}
+
+// Activity counts the sign-ins of every day in one query over the audit trail
+func (r *userAnalyticsRepository) Activity(ctx context.Context, rng userEntities.AnalyticsRange) ([]userEntities.DailyActivity, error) {
+	succeeded, failed := string(userEntities.AuthLoginSucceeded), string(userEntities.AuthLoginFailed)
+	var rows []struct {
+		Day           string
+		ActiveUsers   int64
+		SignIns       int64
+		FailedSignIns int64
+	}
+	err := database.Conn(ctx, r.db).Model(&models.UserAuthEventModel{}).
+		Select(database.DateBucket(r.db, "occurred_at", database.PeriodDay)+" AS day, "+
+			"COUNT(DISTINCT CASE WHEN type = ? THEN user_id END) AS active_users, "+
+			"SUM(CASE WHEN type = ? THEN 1 ELSE 0 END) AS sign_ins, "+
+			"SUM(CASE WHEN type = ? THEN 1 ELSE 0 END) AS failed_sign_ins", succeeded, succeeded, failed).
+		Where("type IN ? AND occurred_at >= ? AND occurred_at < ?", []string{succeeded, failed}, rng.From, rng.To).
+		Group("day").Order("day").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]userEntities.DailyActivity, len(rows))
+	for i, row := range rows {
+		days[i] = userEntities.DailyActivity{
+			Date:          row.Day,
+			ActiveUsers:   row.ActiveUsers,
+			SignIns:       row.SignIns,
+			FailedSignIns: row.FailedSignIns,
+		}
+	}
+	return days, nil
+}
+
+// Retention sizes the weekly cohorts in one query and counts their users signing in by
+// week in another, joining the users to their sign-ins
+func (r *userAnalyticsRepository) Retention(ctx context.Context, rng userEntities.AnalyticsRange, weeks int, until time.Time) ([]userEntities.RetentionCohort, error) {
+	signupWeek := database.DateBucket(r.db, "users.created_at", database.PeriodWeek)
+	var sizes []struct {
+		Week string
+		Size int64
+	}
+	err := database.Conn(ctx, r.db).Unscoped().Model(&models.UserModel{}).
+		Select(signupWeek+" AS week, COUNT(*) AS size").
+		Where("users.created_at >= ? AND users.created_at < ?", rng.From, rng.To).
+		Group("week").Order("week").
+		Scan(&sizes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var active []struct {
+		Week       string
+		ActiveWeek string
+		Retained   int64
+	}
+	err = database.Conn(ctx, r.db).Unscoped().Model(&models.UserModel{}).
+		Select(signupWeek+" AS week, "+
+			database.DateBucket(r.db, "user_auth_events.occurred_at", database.PeriodWeek)+" AS active_week, "+
+			"COUNT(DISTINCT users.id) AS retained").
+		Joins("JOIN user_auth_events ON user_auth_events.user_id = users.id AND user_auth_events.tenant_id = users.tenant_id").
+		Where("users.created_at >= ? AND users.created_at < ?", rng.From, rng.To).
+		Where("user_auth_events.type = ? AND user_auth_events.occurred_at >= ? AND user_auth_events.occurred_at < ?",
+			string(userEntities.AuthLoginSucceeded), rng.From, until).
+		Group("week, active_week").
+		Scan(&active).Error
+	if err != nil {
+		return nil, err
+	}
+
+	cohorts := make([]userEntities.RetentionCohort, len(sizes))
+	byWeek := make(map[string]*userEntities.RetentionCohort, len(sizes))
+	for i, size := range sizes {
+		// The weeks that started by until, the current one included
+		elapsed, err := weeksBetween(size.Week, until.Format(userEntities.AnalyticsDateLayout))
+		if err != nil {
+			return nil, err
+		}
+		elapsed = min(max(elapsed+1, 0), weeks)
+		cohorts[i] = userEntities.RetentionCohort{Week: size.Week, Users: size.Size, Retained: make([]int64, elapsed)}
+		byWeek[size.Week] = &cohorts[i]
+	}
+	for _, row := range active {
+		cohort, ok := byWeek[row.Week]
+		if !ok {
+			continue
+		}
+		week, err := weeksBetween(row.Week, row.ActiveWeek)
+		if err != nil {
+			return nil, err
+		}
+		if week >= 0 && week < len(cohort.Retained) {
+			cohort.Retained[week] = row.Retained
+		}
+	}
+	return cohorts, nil
+}
+
+// weeksBetween is how many whole weeks pass from the day from to the day to
+func weeksBetween(from, to string) (int, error) {
+	start, err := time.Parse(userEntities.AnalyticsDateLayout, from)
+	if err != nil {
+		return 0, err
+	}
+	end, err := time.Parse(userEntities.AnalyticsDateLayout, to)
+	if err != nil {
+		return 0, err
+	}
+	return int(end.Sub(start).Hours()) / (24 * 7), nil
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// userAnalyticsRepositoryBreaker guards a UserAnalyticsRepository with a circuit breaker
+type userAnalyticsRepositoryBreaker struct {
+	repo userRepositories.UserAnalyticsRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewUserAnalyticsRepositoryWithBreaker wraps repo so calls go through cb
+func NewUserAnalyticsRepositoryWithBreaker(repo userRepositories.UserAnalyticsRepository, cb *breaker.CircuitBreaker) userRepositories.UserAnalyticsRepository {
+	return &userAnalyticsRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Totals counts users through the breaker
+func (r *userAnalyticsRepositoryBreaker) Totals(ctx context.Context) (totals *userEntities.UserTotals, err error) {
+	err = r.cb.Execute(func() error {
+		totals, err = r.repo.Totals(ctx)
+		return err
+	})
+	return totals, err
+}
+
+// Signups counts signups by day through the breaker
+func (r *userAnalyticsRepositoryBreaker) Signups(ctx context.Context, rng userEntities.AnalyticsRange) (days []userEntities.DailySignups, err error) {
+	err = r.cb.Execute(func() error {
+		days, err = r.repo.Signups(ctx, rng)
+		return err
+	})
+	return days, err
+}
+
+// Activity counts sign-ins by day through the breaker
+func (r *userAnalyticsRepositoryBreaker) Activity(ctx context.Context, rng userEntities.AnalyticsRange) (days []userEntities.DailyActivity, err error) {
+	err = r.cb.Execute(func() error {
+		days, err = r.repo.Activity(ctx, rng)
+		return err
+	})
+	return days, err
+}
+
+// Retention builds the cohorts through the breaker
+func (r *userAnalyticsRepositoryBreaker) Retention(ctx context.Context, rng userEntities.AnalyticsRange, weeks int, until time.Time) (cohorts []userEntities.RetentionCohort, err error) {
+	err = r.cb.Execute(func() error {
+		cohorts, err = r.repo.Retention(ctx, rng, weeks, until)
+		return err
+	})
+	return cohorts, err
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/application/user/queries"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	"clean-arch-gin/internal/infrastructure/fieldcrypt"
+
+	"gorm.io/gorm"
+)
+
+// userExportStore implements UserExportSource using GORM
+type userExportStore struct {
+	db *gorm.DB
+}
+
+// NewUserExportStore creates a new source for bulk user exports
+func NewUserExportStore(db *gorm.DB) queries.UserExportSource {
+	return &userExportStore{db: db}
+}
+
+// Count counts the users matching the filter
+func (s *userExportStore) Count(ctx context.Context, filter queries.ExportFilter) (int64, error) {
+	if searchesEncrypted(filter) {
+		userModels, err := filterDecrypted(s.filtered(ctx, filter), matchesSearch(filter.Email, filter.Name), 0, 0, -1)
+		return int64(len(userModels)), err
+	}
+
+	var count int64
+	err := s.filtered(ctx, filter).Count(&count).Error
+	return count, err
+}
+
+// NextBatch reads the next page of matching users by ID
+func (s *userExportStore) NextBatch(ctx context.Context, filter queries.ExportFilter, afterID uint, limit int) ([]*userEntities.User, error) {
+	if searchesEncrypted(filter) {
+		userModels, err := filterDecrypted(s.filtered(ctx, filter), matchesSearch(filter.Email, filter.Name), afterID, 0, limit)
+		if err != nil {
+			return nil, err
+		}
+		return toUserEntities(userModels), nil
+	}
+
+	var userModels []models.UserModel
+	err := s.filtered(ctx, filter).Where("id > ?", afterID).Order("id").Limit(limit).Find(&userModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*userEntities.User, len(userModels))
+	for i := range userModels {
+		users[i] = userModels[i].ToDomainEntity()
+	}
+	return users, nil
+}
+
+// filtered applies the filter to a query of non-deleted users
+// The email and name substrings are left to filterDecrypted when those columns are encrypted
+func (s *userExportStore) filtered(ctx context.Context, filter queries.ExportFilter) *gorm.DB {
+	query := s.db.WithContext(ctx).Model(&models.UserModel{})
+	if filter.Email != "" && !fieldcrypt.Enabled() {
+		query = query.Where("email LIKE ?", "%"+filter.Email+"%")
+	}
+	if filter.Name != "" && !fieldcrypt.Enabled() {
+		query = query.Where("name LIKE ?", "%"+filter.Name+"%")
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", string(filter.Role))
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	return query
+}
+
+// searchesEncrypted reports whether the filter searches encrypted columns
+func searchesEncrypted(filter queries.ExportFilter) bool {
+	return fieldcrypt.Enabled() && (filter.Email != "" || filter.Name != "")
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/application/user/commands"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// userImportStore implements UserImportStore using GORM
+type userImportStore struct {
+	db *gorm.DB
+}
+
+// NewUserImportStore creates a new store for bulk user imports
+func NewUserImportStore(db *gorm.DB) commands.UserImportStore {
+	return &userImportStore{db: db}
+}
+
+// ExistingEmails looks up the emails by blind index in a single query
+// Soft-deleted users are included because the unique index still covers them
+func (s *userImportStore) ExistingEmails(ctx context.Context, emails []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(emails) == 0 {
+		return existing, nil
+	}
+
+	byHash := make(map[string][]string, len(emails))
+	hashes := make([]string, 0, len(emails))
+	for _, email := range emails {
+		hash := models.EmailIndex(email)
+		if _, ok := byHash[hash]; !ok {
+			hashes = append(hashes, hash)
+		}
+		byHash[hash] = append(byHash[hash], email)
+	}
+
+	var found []string
+	err := s.db.WithContext(ctx).Unscoped().Model(&models.UserModel{}).Where("email_hash IN ?", hashes).Pluck("email_hash", &found).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, hash := range found {
+		for _, email := range byHash[hash] {
+			existing[email] = true
+		}
+	}
+	return existing, nil
+}
+
+// CreateBatch inserts the users in one statement; when that fails they are retried one at a
+// time so only the users that cannot be inserted are reported, by a *BatchError
+func (s *userImportStore) CreateBatch(ctx context.Context, users []*userEntities.User) error {
+	userModels := make([]*models.UserModel, len(users))
+	for i, user := range users {
+		userModels[i] = models.NewUserModelFromEntity(user)
+	}
+
+	err := database.WriteInBatches(len(userModels), len(userModels), func(from, to int) error {
+		return s.db.WithContext(ctx).CreateInBatches(userModels[from:to], to-from).Error
+	}, func(i int) error {
+		return s.db.WithContext(ctx).Create(userModels[i]).Error
+	})
+
+	for i, userModel := range userModels {
+		users[i].ID = userModel.ID
+	}
+	return err
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import "clean-arch-gin/internal/adapters/shared/models"
+
+// UserQueries are the custom user queries GORM Gen generates onto the user model; the
+// comment of every method is its SQL template. Raw SQL skips the tenant scope, so the
+// tenant is a parameter
+type UserQueries interface {
+	// SELECT * FROM @@table WHERE tenant_id = @tenantID AND status = @status AND deleted_at IS NULL
+	FindByStatus(tenantID uint, status string) ([]*models.UserModel, error)
+	// SELECT COUNT(*) FROM @@table WHERE tenant_id = @tenantID AND role = @role AND deleted_at IS NULL
+	CountByRole(tenantID uint, role string) (int64, error)
+	// SELECT * FROM @@table WHERE tenant_id = @tenantID AND created_at >= @since AND deleted_at IS NULL ORDER BY created_at DESC LIMIT @limit
+	FindCreatedSince(tenantID uint, since string, limit int) ([]*models.UserModel, error)
+}
@@ -1,13 +1,22 @@
 package repositories
 
 import (
+	"context"
+	"time"
+
 	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/domain/shared/pagination"
 	userEntities "clean-arch-gin/internal/domain/user/entities"
 	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/fieldcrypt"
 
 	"gorm.io/gorm"
 )
 
+// purgeBatchSize is how many users are purged per transaction
+const purgeBatchSize = 500
+
 // userRepository implements UserRepository interface using traditional GORM
 type userRepository struct {
 	db *gorm.DB
@@ -18,10 +27,13 @@ func NewUserRepository(db *gorm.DB) userRepositories.UserRepository {
 	return &userRepository{db: db}
 }
 
-// Create creates a new user in the database
-func (r *userRepository) Create(user *userEntities.User) error {
+// Create creates a new user in the database; an email already taken is ErrEmailExists
+func (r *userRepository) Create(ctx context.Context, user *userEntities.User) error {
 	userModel := models.NewUserModelFromEntity(user)
-	if err := r.db.Create(userModel).Error; err != nil {
+	if err := r.db.WithContext(ctx).Create(userModel).Error; err != nil {
+		if database.IsDuplicateKey(r.db, err) {
+			return userEntities.ErrEmailExists
+		}
 		return err
 	}
 	user.ID = userModel.ID
@@ -29,9 +41,42 @@ func (r *userRepository) Create(user *userEntities.User) error {
 }
 
 // GetByID retrieves a user by ID
-func (r *userRepository) GetByID(id uint) (*userEntities.User, error) {
+func (r *userRepository) GetByID(ctx context.Context, id uint) (*userEntities.User, error) {
 	var userModel models.UserModel
-	err := r.db.First(&userModel, id).Error
+	err := r.db.WithContext(ctx).First(&userModel, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, userEntities.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return userModel.ToDomainEntity(), nil
+}
+
+// GetByIDs retrieves the users with the given IDs in a single query
+// Missing IDs are skipped; callers batching lookups match results by ID
+func (r *userRepository) GetByIDs(ctx context.Context, ids []uint) ([]*userEntities.User, error) {
+	if len(ids) == 0 {
+		return []*userEntities.User{}, nil
+	}
+
+	var userModels []models.UserModel
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&userModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*userEntities.User, len(userModels))
+	for i, model := range userModels {
+		users[i] = model.ToDomainEntity()
+	}
+	return users, nil
+}
+
+// GetByPublicID retrieves a user, deleted or not, by public ID
+func (r *userRepository) GetByPublicID(ctx context.Context, publicID string) (*userEntities.User, error) {
+	var userModel models.UserModel
+	err := r.db.WithContext(ctx).Unscoped().Where("public_id = ?", publicID).First(&userModel).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, userEntities.ErrUserNotFound
@@ -42,9 +87,9 @@ func (r *userRepository) GetByID(id uint) (*userEntities.User, error) {
 }
 
 // GetByEmail retrieves a user by email
-func (r *userRepository) GetByEmail(email string) (*userEntities.User, error) {
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*userEntities.User, error) {
 	var userModel models.UserModel
-	err := r.db.Where("email = ?", email).First(&userModel).Error
+	err := r.db.WithContext(ctx).Where("email_hash = ?", models.EmailIndex(email)).First(&userModel).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, userEntities.ErrUserNotFound
@@ -55,9 +100,24 @@ func (r *userRepository) GetByEmail(email string) (*userEntities.User, error) {
 }
 
 // GetAll retrieves all users with pagination
-func (r *userRepository) GetAll(limit, offset int) ([]*userEntities.User, error) {
+func (r *userRepository) GetAll(ctx context.Context, limit, offset int) ([]*userEntities.User, error) {
+	var userModels []models.UserModel
+	err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&userModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*userEntities.User, len(userModels))
+	for i, model := range userModels {
+		users[i] = model.ToDomainEntity()
+	}
+	return users, nil
+}
+
+// ListAfter retrieves the users after cursor in created_at, id order
+func (r *userRepository) ListAfter(ctx context.Context, cursor pagination.Cursor, limit int) ([]*userEntities.User, error) {
 	var userModels []models.UserModel
-	err := r.db.Limit(limit).Offset(offset).Find(&userModels).Error
+	err := database.PageAfter(r.db.WithContext(ctx), cursor, limit).Find(&userModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -69,28 +129,138 @@ func (r *userRepository) GetAll(limit, offset int) ([]*userEntities.User, error)
 	return users, nil
 }
 
-// Update updates an existing user
-func (r *userRepository) Update(user *userEntities.User) error {
+// Update updates an existing user; an email already taken is ErrEmailExists
+// Like the other writes of an account it joins the transaction ctx runs in, if any
+func (r *userRepository) Update(ctx context.Context, user *userEntities.User) error {
 	userModel := models.NewUserModelFromEntity(user)
-	return r.db.Save(userModel).Error
+	if err := database.Conn(ctx, r.db).Save(userModel).Error; err != nil {
+		if database.IsDuplicateKey(r.db, err) {
+			return userEntities.ErrEmailExists
+		}
+		return err
+	}
+	return nil
 }
 
 // Delete soft deletes a user by ID
-func (r *userRepository) Delete(id uint) error {
-	return r.db.Delete(&models.UserModel{}, id).Error
+func (r *userRepository) Delete(ctx context.Context, id uint) error {
+	return database.Conn(ctx, r.db).Delete(&models.UserModel{}, id).Error
 }
 
 // Count returns the total number of users
-func (r *userRepository) Count() (int64, error) {
+func (r *userRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.UserModel{}).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&models.UserModel{}).Count(&count).Error
 	return count, err
 }
 
+// CreateInBatches inserts the users size at a time, one statement per batch
+func (r *userRepository) CreateInBatches(ctx context.Context, users []*userEntities.User, size int) error {
+	return database.WriteInBatches(len(users), size, func(from, to int) error {
+		userModels := make([]*models.UserModel, 0, to-from)
+		for _, user := range users[from:to] {
+			userModels = append(userModels, models.NewUserModelFromEntity(user))
+		}
+		if err := r.db.WithContext(ctx).CreateInBatches(userModels, len(userModels)).Error; err != nil {
+			return err
+		}
+		for i, userModel := range userModels {
+			users[from+i].ID = userModel.ID
+		}
+		return nil
+	}, func(i int) error {
+		return r.Create(ctx, users[i])
+	})
+}
+
+// UpdateInBatches saves the users size at a time, one transaction per batch
+func (r *userRepository) UpdateInBatches(ctx context.Context, users []*userEntities.User, size int) error {
+	return database.WriteInBatches(len(users), size, func(from, to int) error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, user := range users[from:to] {
+				if err := tx.Save(models.NewUserModelFromEntity(user)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}, func(i int) error {
+		return r.Update(ctx, users[i])
+	})
+}
+
+// Upsert inserts or updates the user keyed by email in one statement, then reads back the
+// stored row since MySQL does not return the ID of an updated one
+func (r *userRepository) Upsert(ctx context.Context, user *userEntities.User) (bool, error) {
+	userModel := models.NewUserUpsertModel(user)
+	var stored models.UserModel
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(database.OnConflictUpdate(models.UserUpsertKey, models.UserUpsertColumns)).Create(userModel).Error
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("tenant_id = ? AND email_hash = ?", userModel.TenantID, userModel.EmailHash).First(&stored).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	created := stored.PublicID != nil && *stored.PublicID == user.PublicID
+	user.ID = stored.ID
+	if stored.PublicID != nil {
+		user.PublicID = *stored.PublicID
+	}
+	user.CreatedAt = stored.CreatedAt
+	return created, nil
+}
+
+// GetByIDIncludingDeleted retrieves a user by ID, soft deleted or not
+func (r *userRepository) GetByIDIncludingDeleted(ctx context.Context, id uint) (*userEntities.User, error) {
+	var userModel models.UserModel
+	err := r.db.WithContext(ctx).Unscoped().First(&userModel, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, userEntities.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return userModel.ToDomainEntity(), nil
+}
+
+// Restore clears the deletion time of a soft-deleted user
+func (r *userRepository) Restore(ctx context.Context, id uint) error {
+	result := database.Conn(ctx, r.db).Unscoped().Model(&models.UserModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return userEntities.ErrUserNotFound
+	}
+	return nil
+}
+
+// Purge permanently deletes a user and its dependent rows in one transaction
+func (r *userRepository) Purge(ctx context.Context, id uint) error {
+	return purgeUser(database.Conn(ctx, r.db), id)
+}
+
+// PurgeOlderThan permanently deletes the users soft deleted more than age ago with their
+// dependent rows, a batch at a time
+func (r *userRepository) PurgeOlderThan(ctx context.Context, age time.Duration) (int64, error) {
+	return database.PurgeSoftDeleted(ctx, r.db, &models.UserModel{}, time.Now().Add(-age), purgeBatchSize, purgeUserDependents)
+}
+
 // GetUsersByEmailDomain gets users by email domain (traditional implementation)
-func (r *userRepository) GetUsersByEmailDomain(domain string) ([]*userEntities.User, error) {
+func (r *userRepository) GetUsersByEmailDomain(ctx context.Context, domain string) ([]*userEntities.User, error) {
 	var userModels []models.UserModel
-	err := r.db.Where("email LIKE ?", "%"+domain).Find(&userModels).Error
+	var err error
+	if fieldcrypt.Enabled() {
+		userModels, err = filterDecrypted(r.db.WithContext(ctx).Model(&models.UserModel{}), hasEmailDomain(domain), 0, 0, -1)
+	} else {
+		err = r.db.WithContext(ctx).Where("email LIKE ?", "%"+domain).Find(&userModels).Error
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -103,9 +273,9 @@ func (r *userRepository) GetUsersByEmailDomain(domain string) ([]*userEntities.U
 }
 
 // GetActiveUsers gets all non-deleted users (traditional implementation)
-func (r *userRepository) GetActiveUsers() ([]*userEntities.User, error) {
+func (r *userRepository) GetActiveUsers(ctx context.Context) ([]*userEntities.User, error) {
 	var userModels []models.UserModel
-	err := r.db.Where("deleted_at IS NULL").Find(&userModels).Error
+	err := r.db.WithContext(ctx).Where("deleted_at IS NULL").Find(&userModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -118,18 +288,22 @@ func (r *userRepository) GetActiveUsers() ([]*userEntities.User, error) {
 }
 
 // GetUsersWithFilters gets users with complex filtering (traditional implementation)
-func (r *userRepository) GetUsersWithFilters(limit, offset int, email, name string) ([]*userEntities.User, error) {
+func (r *userRepository) GetUsersWithFilters(ctx context.Context, limit, offset int, email, name string) ([]*userEntities.User, error) {
 	var userModels []models.UserModel
-	query := r.db.Model(&models.UserModel{})
+	query := r.db.WithContext(ctx).Model(&models.UserModel{})
 
-	if email != "" {
-		query = query.Where("email LIKE ?", "%"+email+"%")
-	}
-	if name != "" {
-		query = query.Where("name LIKE ?", "%"+name+"%")
+	var err error
+	if fieldcrypt.Enabled() && (email != "" || name != "") {
+		userModels, err = filterDecrypted(query, matchesSearch(email, name), 0, offset, limit)
+	} else {
+		if email != "" {
+			query = query.Where("email LIKE ?", "%"+email+"%")
+		}
+		if name != "" {
+			query = query.Where("name LIKE ?", "%"+name+"%")
+		}
+		err = query.Limit(limit).Offset(offset).Find(&userModels).Error
 	}
-
-	err := query.Limit(limit).Offset(offset).Find(&userModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -140,3 +314,60 @@ func (r *userRepository) GetUsersWithFilters(limit, offset int, email, name stri
 	}
 	return users, nil
 }
+
+// purgeUser hard deletes a user with its dependent rows in one transaction (see purgeUserDependents)
+func purgeUser(db *gorm.DB, id uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := purgeUserDependents(tx, []uint{id}); err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Delete(&models.UserModel{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return userEntities.ErrUserNotFound
+		}
+		return nil
+	})
+}
+
+// purgeUserDependents hard deletes the orders, order items, shipments, returns, notifications, preferences,
+// activity, sessions, auth events, account tokens, devices and connections of the users with ids. The tables have no foreign keys to users, so the
+// dependents are deleted here; the admin audit log is kept as the record of the purge and invoices as
+// accounting records
+func purgeUserDependents(tx *gorm.DB, ids []uint) error {
+	orderIDs := tx.Unscoped().Model(&models.OrderModel{}).Select("id").Where("user_id IN ?", ids)
+	if err := purgeOrderDependents(tx, orderIDs); err != nil {
+		return err
+	}
+	for _, dependent := range []interface{}{
+		&models.OrderModel{}, &models.NotificationModel{}, &models.UserPreferencesModel{}, &models.UserActivityModel{},
+		&models.UserSessionModel{}, &models.UserAuthEventModel{}, &models.UserAccountTokenModel{}, &models.UserDeviceModel{},
+		&models.NotificationDigestItemModel{}, &models.NotificationCounterModel{},
+	} {
+		if err := tx.Unscoped().Where("user_id IN ?", ids).Delete(dependent).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Where("follower_id IN ? OR followee_id IN ?", ids, ids).Delete(&models.UserConnectionModel{}).Error
+}
+
+// purgeOrderDependents hard deletes the items, shipments and returns of the orders selected by orderIDs
+func purgeOrderDependents(tx *gorm.DB, orderIDs *gorm.DB) error {
+	shipmentIDs := tx.Model(&models.ShipmentModel{}).Select("id").Where("order_id IN (?)", orderIDs)
+	if err := tx.Where("shipment_id IN (?)", shipmentIDs).Delete(&models.ShipmentItemModel{}).Error; err != nil {
+		return err
+	}
+	returnIDs := tx.Model(&models.ReturnModel{}).Select("id").Where("order_id IN (?)", orderIDs)
+	if err := tx.Where("return_id IN (?)", returnIDs).Delete(&models.ReturnItemModel{}).Error; err != nil {
+		return err
+	}
+	for _, dependent := range []interface{}{&models.ShipmentModel{}, &models.ReturnModel{}, &models.OrderItemModel{}} {
+		if err := tx.Where("order_id IN (?)", orderIDs).Delete(dependent).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/pagination"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// userRepositoryBreaker guards a UserRepository with a circuit breaker
+// While the breaker is open calls fail fast with a *breaker.UnavailableError
+type userRepositoryBreaker struct {
+	repo userRepositories.UserRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewUserRepositoryWithBreaker wraps repo so calls go through cb
+func NewUserRepositoryWithBreaker(repo userRepositories.UserRepository, cb *breaker.CircuitBreaker) userRepositories.UserRepository {
+	return &userRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Create creates a new user through the breaker
+func (r *userRepositoryBreaker) Create(ctx context.Context, user *userEntities.User) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Create(ctx, user)
+	})
+}
+
+// GetByID retrieves a user by ID through the breaker
+func (r *userRepositoryBreaker) GetByID(ctx context.Context, id uint) (user *userEntities.User, err error) {
+	err = r.cb.Execute(func() error {
+		user, err = r.repo.GetByID(ctx, id)
+		return err
+	})
+	return user, err
+}
+
+// GetByIDs retrieves users by IDs through the breaker
+func (r *userRepositoryBreaker) GetByIDs(ctx context.Context, ids []uint) (users []*userEntities.User, err error) {
+	err = r.cb.Execute(func() error {
+		users, err = r.repo.GetByIDs(ctx, ids)
+		return err
+	})
+	return users, err
+}
+
+// GetByPublicID retrieves a user by public ID through the breaker
+func (r *userRepositoryBreaker) GetByPublicID(ctx context.Context, publicID string) (user *userEntities.User, err error) {
+	err = r.cb.Execute(func() error {
+		user, err = r.repo.GetByPublicID(ctx, publicID)
+		return err
+	})
+	return user, err
+}
+
+// GetByEmail retrieves a user by email through the breaker
+func (r *userRepositoryBreaker) GetByEmail(ctx context.Context, email string) (user *userEntities.User, err error) {
+	err = r.cb.Execute(func() error {
+		user, err = r.repo.GetByEmail(ctx, email)
+		return err
+	})
+	return user, err
+}
+
+// GetAll retrieves a page of users through the breaker
+func (r *userRepositoryBreaker) GetAll(ctx context.Context, limit, offset int) (users []*userEntities.User, err error) {
+	err = r.cb.Execute(func() error {
+		users, err = r.repo.GetAll(ctx, limit, offset)
+		return err
+	})
+	return users, err
+}
+
+// ListAfter retrieves a page of users after a cursor through the breaker
+func (r *userRepositoryBreaker) ListAfter(ctx context.Context, cursor pagination.Cursor, limit int) (users []*userEntities.User, err error) {
+	err = r.cb.Execute(func() error {
+		users, err = r.repo.ListAfter(ctx, cursor, limit)
+		return err
+	})
+	return users, err
+}
+
+// Update updates a user through the breaker
+func (r *userRepositoryBreaker) Update(ctx context.Context, user *userEntities.User) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Update(ctx, user)
+	})
+}
+
+// Delete soft deletes a user through the breaker
+func (r *userRepositoryBreaker) Delete(ctx context.Context, id uint) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Delete(ctx, id)
+	})
+}
+
+// Count counts users through the breaker
+func (r *userRepositoryBreaker) Count(ctx context.Context) (count int64, err error) {
+	err = r.cb.Execute(func() error {
+		count, err = r.repo.Count(ctx)
+		return err
+	})
+	return count, err
+}
+
+// CreateInBatches inserts users in batches through the breaker
+func (r *userRepositoryBreaker) CreateInBatches(ctx context.Context, users []*userEntities.User, size int) error {
+	return r.cb.Execute(func() error {
+		return r.repo.CreateInBatches(ctx, users, size)
+	})
+}
+
+// UpdateInBatches updates users in batches through the breaker
+func (r *userRepositoryBreaker) UpdateInBatches(ctx context.Context, users []*userEntities.User, size int) error {
+	return r.cb.Execute(func() error {
+		return r.repo.UpdateInBatches(ctx, users, size)
+	})
+}
+
+// Upsert inserts or updates a user through the breaker
+func (r *userRepositoryBreaker) Upsert(ctx context.Context, user *userEntities.User) (created bool, err error) {
+	err = r.cb.Execute(func() error {
+		created, err = r.repo.Upsert(ctx, user)
+		return err
+	})
+	return created, err
+}
+
+// GetByIDIncludingDeleted retrieves a user, soft deleted or not, through the breaker
+func (r *userRepositoryBreaker) GetByIDIncludingDeleted(ctx context.Context, id uint) (user *userEntities.User, err error) {
+	err = r.cb.Execute(func() error {
+		user, err = r.repo.GetByIDIncludingDeleted(ctx, id)
+		return err
+	})
+	return user, err
+}
+
+// Restore undeletes a user through the breaker
+func (r *userRepositoryBreaker) Restore(ctx context.Context, id uint) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Restore(ctx, id)
+	})
+}
+
+// Purge permanently deletes a user through the breaker
+func (r *userRepositoryBreaker) Purge(ctx context.Context, id uint) error {
+	return r.cb.Execute(func() error {
+		return r.repo.Purge(ctx, id)
+	})
+}
+
+// PurgeOlderThan purges long soft-deleted users through the breaker
+func (r *userRepositoryBreaker) PurgeOlderThan(ctx context.Context, age time.Duration) (purged int64, err error) {
+	err = r.cb.Execute(func() error {
+		purged, err = r.repo.PurgeOlderThan(ctx, age)
+		return err
+	})
+	return purged, err
+}
+
+// GetUsersByEmailDomain retrieves users by email domain through the breaker
+func (r *userRepositoryBreaker) GetUsersByEmailDomain(ctx context.Context, domain string) (users []*userEntities.User, err error) {
+	err = r.cb.Execute(func() error {
+		users, err = r.repo.GetUsersByEmailDomain(ctx, domain)
+		return err
+	})
+	return users, err
+}
+
+// GetActiveUsers retrieves active users through the breaker
+func (r *userRepositoryBreaker) GetActiveUsers(ctx context.Context) (users []*userEntities.User, err error) {
+	err = r.cb.Execute(func() error {
+		users, err = r.repo.GetActiveUsers(ctx)
+		return err
+	})
+	return users, err
+}
+
+// GetUsersWithFilters searches users through the breaker
+func (r *userRepositoryBreaker) GetUsersWithFilters(ctx context.Context, limit, offset int, email, name string) (users []*userEntities.User, err error) {
+	err = r.cb.Execute(func() error {
+		users, err = r.repo.GetUsersWithFilters(ctx, limit, offset, email, name)
+		return err
+	})
+	return users, err
+}
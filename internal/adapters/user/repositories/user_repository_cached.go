@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"strconv"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userEvents "clean-arch-gin/internal/domain/user/events"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/querycache"
+)
+
+// UserCachePolicies configures the reads of public profiles a cached user repository serves
+// from the cache
+type UserCachePolicies struct {
+	GetByID       querycache.Policy
+	GetByPublicID querycache.Policy
+}
+
+// DefaultUserCachePolicies caches users by ID and by public ID for the cache's TTL
+var DefaultUserCachePolicies = UserCachePolicies{
+	GetByID: querycache.Policy{
+		Key:           "users:id:{id}",
+		InvalidatedBy: []string{userEvents.UserProfileUpdatedEventName, userEvents.UserEmailUndeliverableEventName, userEvents.UserSMSConsentChangedEventName},
+	},
+	GetByPublicID: querycache.Policy{
+		Key:           "users:public_id:{public_id}",
+		InvalidatedBy: []string{userEvents.UserProfileUpdatedEventName, userEvents.UserEmailUndeliverableEventName, userEvents.UserSMSConsentChangedEventName},
+	},
+}
+
+// Policies returns the policies of cached user reads, for subscribing to their invalidation
+// events
+func (p UserCachePolicies) Policies() []querycache.Policy {
+	return []querycache.Policy{p.GetByID, p.GetByPublicID}
+}
+
+// userRepositoryCached serves the reads of policies from a cache, invalidating a user's
+// entries when it writes to the user
+// Other methods go straight to repo, so lists and credential lookups by email stay fresh
+type userRepositoryCached struct {
+	userRepositories.UserRepository
+	cache    *querycache.Cache
+	policies UserCachePolicies
+}
+
+// NewUserRepositoryWithCache wraps repo so the reads of policies go through cache
+// Writes made elsewhere are only seen once the entries expire, or once an invalidation event
+// is published when cache.InvalidateOn is subscribed to policies.Policies()
+func NewUserRepositoryWithCache(repo userRepositories.UserRepository, cache *querycache.Cache, policies UserCachePolicies) userRepositories.UserRepository {
+	return &userRepositoryCached{UserRepository: repo, cache: cache, policies: policies}
+}
+
+// GetByID retrieves a user by ID through the cache
+func (r *userRepositoryCached) GetByID(ctx context.Context, id uint) (*userEntities.User, error) {
+	var user *userEntities.User
+	err := r.cache.Fetch(ctx, r.policies.GetByID, querycache.Params{"id": id}, &user, func() (string, error) {
+		var err error
+		user, err = r.UserRepository.GetByID(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return userCacheSubject(user.ID), nil
+	})
+	return user, err
+}
+
+// GetByPublicID retrieves a user by public ID through the cache
+func (r *userRepositoryCached) GetByPublicID(ctx context.Context, publicID string) (*userEntities.User, error) {
+	var user *userEntities.User
+	err := r.cache.Fetch(ctx, r.policies.GetByPublicID, querycache.Params{"public_id": publicID}, &user, func() (string, error) {
+		var err error
+		user, err = r.UserRepository.GetByPublicID(ctx, publicID)
+		if err != nil {
+			return "", err
+		}
+		return userCacheSubject(user.ID), nil
+	})
+	return user, err
+}
+
+// Update updates a user and invalidates its entries
+func (r *userRepositoryCached) Update(ctx context.Context, user *userEntities.User) error {
+	defer r.cache.Invalidate(ctx, userCacheSubject(user.ID))
+	return r.UserRepository.Update(ctx, user)
+}
+
+// Delete soft deletes a user and invalidates its entries
+func (r *userRepositoryCached) Delete(ctx context.Context, id uint) error {
+	defer r.cache.Invalidate(ctx, userCacheSubject(id))
+	return r.UserRepository.Delete(ctx, id)
+}
+
+// UpdateInBatches updates users in batches and invalidates the entries of every one of them,
+// whether its batch failed or not
+func (r *userRepositoryCached) UpdateInBatches(ctx context.Context, users []*userEntities.User, size int) error {
+	err := r.UserRepository.UpdateInBatches(ctx, users, size)
+	subjects := make([]string, len(users))
+	for i, user := range users {
+		subjects[i] = userCacheSubject(user.ID)
+	}
+	r.cache.Invalidate(ctx, subjects...)
+	return err
+}
+
+// Upsert inserts or updates a user and invalidates its entries
+func (r *userRepositoryCached) Upsert(ctx context.Context, user *userEntities.User) (bool, error) {
+	created, err := r.UserRepository.Upsert(ctx, user)
+	if err == nil && !created {
+		r.cache.Invalidate(ctx, userCacheSubject(user.ID))
+	}
+	return created, err
+}
+
+// Restore undeletes a user and invalidates its entries
+func (r *userRepositoryCached) Restore(ctx context.Context, id uint) error {
+	defer r.cache.Invalidate(ctx, userCacheSubject(id))
+	return r.UserRepository.Restore(ctx, id)
+}
+
+// Purge permanently deletes a user and invalidates its entries
+func (r *userRepositoryCached) Purge(ctx context.Context, id uint) error {
+	defer r.cache.Invalidate(ctx, userCacheSubject(id))
+	return r.UserRepository.Purge(ctx, id)
+}
+
+// userCacheSubject tags the entries of a user, matching the subject of events about it
+func userCacheSubject(id uint) string {
+	return "users/" + strconv.FormatUint(uint64(id), 10)
+}
@@ -0,0 +1,35 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"clean-arch-gin/internal/adapters/user/repositories"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/testutil/repotest"
+
+	"gorm.io/gorm"
+)
+
+// sqliteUserRepositories are the implementations checked on SQLite, which needs no Docker
+var sqliteUserRepositories = []repotest.UserRepositoryImplementation{
+	{Name: "gorm", New: repositories.NewUserRepository},
+	{Name: "gorm_gen", New: repositories.NewUserRepositoryGen},
+	{Name: "memory", New: func(*gorm.DB) userRepositories.UserRepository {
+		return repositories.NewUserRepositoryMemory()
+	}},
+}
+
+// TestUserRepositorySuite runs the conformance suite against every implementation
+func TestUserRepositorySuite(t *testing.T) {
+	db := repotest.OpenSQLite(t)
+	for _, impl := range sqliteUserRepositories {
+		t.Run(impl.Name, func(t *testing.T) {
+			repotest.RunUserRepositorySuite(t, db, impl.New)
+		})
+	}
+}
+
+// TestUserRepositoryContract checks that the GORM, GORM Gen and in-memory repositories agree
+func TestUserRepositoryContract(t *testing.T) {
+	repotest.RunUserRepositoryContract(t, repotest.OpenSQLite(t), sqliteUserRepositories)
+}
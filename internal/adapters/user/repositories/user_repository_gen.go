@@ -1,10 +1,16 @@
 package repositories
 
 import (
+	"context"
+	"time"
+
 	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/domain/shared/pagination"
 	userEntities "clean-arch-gin/internal/domain/user/entities"
 	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/database"
 	"clean-arch-gin/internal/infrastructure/database/query"
+	"clean-arch-gin/internal/infrastructure/fieldcrypt"
 
 	"gorm.io/gorm"
 )
@@ -24,13 +30,17 @@ func NewUserRepositoryGen(db *gorm.DB) userRepositories.UserRepository {
 	}
 }
 
-// Create creates a new user in the database using GORM Gen
-func (r *userRepositoryGen) Create(user *userEntities.User) error {
+// Create creates a new user in the database using GORM Gen; an email already taken is
+// ErrEmailExists
+func (r *userRepositoryGen) Create(ctx context.Context, user *userEntities.User) error {
 	userModel := models.NewUserModelFromEntity(user)
 
 	// Use GORM Gen's type-safe Create method
-	err := r.query.UserModel.Create(userModel)
+	err := r.query.UserModel.WithContext(ctx).Create(userModel)
 	if err != nil {
+		if database.IsDuplicateKey(r.db, err) {
+			return userEntities.ErrEmailExists
+		}
 		return err
 	}
 
@@ -40,8 +50,8 @@ func (r *userRepositoryGen) Create(user *userEntities.User) error {
 }
 
 // GetByID retrieves a user by ID using GORM Gen
-func (r *userRepositoryGen) GetByID(id uint) (*userEntities.User, error) {
-	u := r.query.UserModel
+func (r *userRepositoryGen) GetByID(ctx context.Context, id uint) (*userEntities.User, error) {
+	u := r.query.UserModel.WithContext(ctx)
 
 	// Type-safe query with GORM Gen (using placeholder for now)
 	userModel, err := u.Where(u.ID().Eq(id)).First()
@@ -55,12 +65,52 @@ func (r *userRepositoryGen) GetByID(id uint) (*userEntities.User, error) {
 	return userModel.ToDomainEntity(), nil
 }
 
+// GetByIDs retrieves the users with the given IDs in a single query using GORM Gen
+func (r *userRepositoryGen) GetByIDs(ctx context.Context, ids []uint) ([]*userEntities.User, error) {
+	if len(ids) == 0 {
+		return []*userEntities.User{}, nil
+	}
+
+	u := r.query.UserModel.WithContext(ctx)
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+
+	userModels, err := u.Where(u.ID().In(values...)).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*userEntities.User, len(userModels))
+	for i, model := range userModels {
+		users[i] = model.ToDomainEntity()
+	}
+	return users, nil
+}
+
+// GetByPublicID retrieves a user, soft deleted or not, by public ID using GORM Gen
+func (r *userRepositoryGen) GetByPublicID(ctx context.Context, publicID string) (*userEntities.User, error) {
+	u := r.query.UserModel.WithContext(ctx)
+
+	userModel, err := u.Unscoped().Where(u.PublicID().Eq(publicID)).First()
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, userEntities.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return userModel.ToDomainEntity(), nil
+}
+
 // GetByEmail retrieves a user by email using GORM Gen
-func (r *userRepositoryGen) GetByEmail(email string) (*userEntities.User, error) {
-	u := r.query.UserModel
+func (r *userRepositoryGen) GetByEmail(ctx context.Context, email string) (*userEntities.User, error) {
+	u := r.query.UserModel.WithContext(ctx)
 
-	// Type-safe query with GORM Gen (using placeholder for now)
-	userModel, err := u.Where(u.Email().Eq(email)).First()
+	// Type-safe query with GORM Gen (using placeholder for now); encrypted emails are
+	// looked up by their blind index
+	userModel, err := u.Where(u.EmailHash().Eq(models.EmailIndex(email))).First()
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, userEntities.ErrUserNotFound
@@ -72,8 +122,8 @@ func (r *userRepositoryGen) GetByEmail(email string) (*userEntities.User, error)
 }
 
 // GetAll retrieves all users with pagination using GORM Gen
-func (r *userRepositoryGen) GetAll(limit, offset int) ([]*userEntities.User, error) {
-	u := r.query.UserModel
+func (r *userRepositoryGen) GetAll(ctx context.Context, limit, offset int) ([]*userEntities.User, error) {
+	u := r.query.UserModel.WithContext(ctx)
 
 	// Type-safe pagination query with GORM Gen
 	userModels, err := u.Limit(limit).Offset(offset).Find()
@@ -90,19 +140,45 @@ func (r *userRepositoryGen) GetAll(limit, offset int) ([]*userEntities.User, err
 	return users, nil
 }
 
-// Update updates an existing user using GORM Gen
-func (r *userRepositoryGen) Update(user *userEntities.User) error {
+// ListAfter retrieves the users after cursor in created_at, id order using GORM Gen
+func (r *userRepositoryGen) ListAfter(ctx context.Context, cursor pagination.Cursor, limit int) ([]*userEntities.User, error) {
+	u := r.query.UserModel.WithContext(ctx)
+	if !cursor.IsZero() {
+		u = u.Where(query.Or(
+			u.CreatedAt().Gt(cursor.CreatedAt),
+			query.And(u.CreatedAt().Eq(cursor.CreatedAt), u.ID().Gt(cursor.ID)),
+		))
+	}
+
+	userModels, err := u.Order(u.CreatedAt().Asc(), u.ID().Asc()).Limit(limit).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*userEntities.User, len(userModels))
+	for i, model := range userModels {
+		users[i] = model.ToDomainEntity()
+	}
+	return users, nil
+}
+
+// Update updates an existing user using GORM Gen; an email already taken is ErrEmailExists
+func (r *userRepositoryGen) Update(ctx context.Context, user *userEntities.User) error {
 	userModel := models.NewUserModelFromEntity(user)
-	u := r.query.UserModel
+	u := r.query.UserModel.WithContext(ctx)
 
-	// Type-safe update with GORM Gen
-	_, err := u.Where(u.ID().Eq(user.ID)).Updates(userModel)
+	// Type-safe update with GORM Gen; selecting every column also writes cleared fields,
+	// such as a password reset flag set back to false
+	_, err := u.Where(u.ID().Eq(user.ID)).Select(u.ALL()).Updates(userModel)
+	if database.IsDuplicateKey(r.db, err) {
+		return userEntities.ErrEmailExists
+	}
 	return err
 }
 
 // Delete soft deletes a user by ID using GORM Gen
-func (r *userRepositoryGen) Delete(id uint) error {
-	u := r.query.UserModel
+func (r *userRepositoryGen) Delete(ctx context.Context, id uint) error {
+	u := r.query.UserModel.WithContext(ctx)
 
 	// Type-safe soft delete with GORM Gen
 	_, err := u.Where(u.ID().Eq(id)).Delete()
@@ -110,18 +186,132 @@ func (r *userRepositoryGen) Delete(id uint) error {
 }
 
 // Count returns the total number of users using GORM Gen
-func (r *userRepositoryGen) Count() (int64, error) {
-	u := r.query.UserModel
+func (r *userRepositoryGen) Count(ctx context.Context) (int64, error) {
+	u := r.query.UserModel.WithContext(ctx)
 
 	// Type-safe count query with GORM Gen
 	return u.Count()
 }
 
+// CreateInBatches inserts the users size at a time, one statement per batch, using GORM Gen
+func (r *userRepositoryGen) CreateInBatches(ctx context.Context, users []*userEntities.User, size int) error {
+	return database.WriteInBatches(len(users), size, func(from, to int) error {
+		userModels := make([]*models.UserModel, 0, to-from)
+		for _, user := range users[from:to] {
+			userModels = append(userModels, models.NewUserModelFromEntity(user))
+		}
+		if err := r.query.UserModel.WithContext(ctx).CreateInBatches(userModels, len(userModels)); err != nil {
+			return err
+		}
+		for i, userModel := range userModels {
+			users[from+i].ID = userModel.ID
+		}
+		return nil
+	}, func(i int) error {
+		return r.Create(ctx, users[i])
+	})
+}
+
+// UpdateInBatches updates the users size at a time, one transaction per batch, using GORM Gen
+func (r *userRepositoryGen) UpdateInBatches(ctx context.Context, users []*userEntities.User, size int) error {
+	return database.WriteInBatches(len(users), size, func(from, to int) error {
+		return r.query.WithContext(ctx).Transaction(func(tx *query.Query) error {
+			for _, user := range users[from:to] {
+				u := tx.UserModel.WithContext(ctx)
+				if _, err := u.Where(u.ID().Eq(user.ID)).Select(u.ALL()).Updates(models.NewUserModelFromEntity(user)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}, func(i int) error {
+		return r.Update(ctx, users[i])
+	})
+}
+
+// Upsert inserts or updates the user keyed by email in one statement using GORM Gen, then
+// reads back the stored row since MySQL does not return the ID of an updated one
+func (r *userRepositoryGen) Upsert(ctx context.Context, user *userEntities.User) (bool, error) {
+	userModel := models.NewUserUpsertModel(user)
+	var stored *models.UserModel
+	err := r.query.WithContext(ctx).Transaction(func(tx *query.Query) error {
+		u := tx.UserModel.WithContext(ctx)
+		err := u.Clauses(database.OnConflictUpdate(models.UserUpsertKey, models.UserUpsertColumns)).Upsert(userModel)
+		if err != nil {
+			return err
+		}
+		u = tx.UserModel.WithContext(ctx)
+		stored, err = u.Unscoped().Where(u.TenantID().Eq(userModel.TenantID), u.EmailHash().Eq(*userModel.EmailHash)).First()
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	created := stored.PublicID != nil && *stored.PublicID == user.PublicID
+	user.ID = stored.ID
+	if stored.PublicID != nil {
+		user.PublicID = *stored.PublicID
+	}
+	user.CreatedAt = stored.CreatedAt
+	return created, nil
+}
+
+// GetByIDIncludingDeleted retrieves a user by ID, soft deleted or not, using GORM Gen
+func (r *userRepositoryGen) GetByIDIncludingDeleted(ctx context.Context, id uint) (*userEntities.User, error) {
+	u := r.query.UserModel.WithContext(ctx)
+
+	// Unscoped lifts the soft-delete condition
+	userModel, err := u.Unscoped().Where(u.ID().Eq(id)).First()
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, userEntities.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return userModel.ToDomainEntity(), nil
+}
+
+// Restore clears the deletion time of a soft-deleted user using GORM Gen
+func (r *userRepositoryGen) Restore(ctx context.Context, id uint) error {
+	u := r.query.UserModel.WithContext(ctx)
+
+	updated, err := u.Unscoped().Where(u.ID().Eq(id), u.DeletedAt().IsNotNull()).Update(u.DeletedAt(), nil)
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return userEntities.ErrUserNotFound
+	}
+	return nil
+}
+
+// Purge permanently deletes a user and its dependent rows in one transaction
+func (r *userRepositoryGen) Purge(ctx context.Context, id uint) error {
+	return purgeUser(database.Conn(ctx, r.db), id)
+}
+
+// PurgeOlderThan permanently deletes the users soft deleted more than age ago with their
+// dependent rows, a batch at a time
+func (r *userRepositoryGen) PurgeOlderThan(ctx context.Context, age time.Duration) (int64, error) {
+	return database.PurgeSoftDeleted(ctx, r.db, &models.UserModel{}, time.Now().Add(-age), purgeBatchSize, purgeUserDependents)
+}
+
 // Advanced query methods using GORM Gen custom methods
 
 // GetUsersByEmailDomain gets users by email domain using generated method
-func (r *userRepositoryGen) GetUsersByEmailDomain(domain string) ([]*userEntities.User, error) {
-	u := r.query.UserModel
+func (r *userRepositoryGen) GetUsersByEmailDomain(ctx context.Context, domain string) ([]*userEntities.User, error) {
+	if fieldcrypt.Enabled() {
+		// Encrypted emails are matched once decrypted
+		userModels, err := filterDecrypted(database.Conn(ctx, r.db).Model(&models.UserModel{}), hasEmailDomain(domain), 0, 0, -1)
+		if err != nil {
+			return nil, err
+		}
+		return toUserEntities(userModels), nil
+	}
+
+	u := r.query.UserModel.WithContext(ctx)
 
 	// Use GORM Gen's powerful query builder
 	userModels, err := u.Where(u.Email().Like("%" + domain)).Find()
@@ -139,8 +329,8 @@ func (r *userRepositoryGen) GetUsersByEmailDomain(domain string) ([]*userEntitie
 }
 
 // GetActiveUsers gets all non-deleted users using GORM Gen
-func (r *userRepositoryGen) GetActiveUsers() ([]*userEntities.User, error) {
-	u := r.query.UserModel
+func (r *userRepositoryGen) GetActiveUsers(ctx context.Context) ([]*userEntities.User, error) {
+	u := r.query.UserModel.WithContext(ctx)
 
 	// Type-safe query for active users
 	userModels, err := u.Where(u.DeletedAt().IsNull()).Find()
@@ -158,8 +348,17 @@ func (r *userRepositoryGen) GetActiveUsers() ([]*userEntities.User, error) {
 }
 
 // GetUsersWithFilters gets users with complex filtering using GORM Gen
-func (r *userRepositoryGen) GetUsersWithFilters(limit, offset int, email, name string) ([]*userEntities.User, error) {
-	u := r.query.UserModel
+func (r *userRepositoryGen) GetUsersWithFilters(ctx context.Context, limit, offset int, email, name string) ([]*userEntities.User, error) {
+	if fieldcrypt.Enabled() && (email != "" || name != "") {
+		// Encrypted emails and names are matched once decrypted
+		userModels, err := filterDecrypted(database.Conn(ctx, r.db).Model(&models.UserModel{}), matchesSearch(email, name), 0, offset, limit)
+		if err != nil {
+			return nil, err
+		}
+		return toUserEntities(userModels), nil
+	}
+
+	u := r.query.UserModel.WithContext(ctx)
 	query := u.Select(u.ALL())
 
 	// Build dynamic query with GORM Gen
@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/pagination"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/interceptor"
+)
+
+// userRepositoryIntercepted runs the calls of a UserRepository through an interceptor, e.g.
+// logging and metrics; each call is the operation UserRepository.<method>
+type userRepositoryIntercepted struct {
+	repo        userRepositories.UserRepository
+	interceptor interceptor.Interceptor
+}
+
+// NewUserRepositoryWithInterceptor wraps repo so calls go through ic, or returns repo when ic
+// is nil
+func NewUserRepositoryWithInterceptor(repo userRepositories.UserRepository, ic interceptor.Interceptor) userRepositories.UserRepository {
+	if ic == nil {
+		return repo
+	}
+	return &userRepositoryIntercepted{repo: repo, interceptor: ic}
+}
+
+// Create creates a new user through the interceptor
+func (r *userRepositoryIntercepted) Create(ctx context.Context, user *userEntities.User) error {
+	return interceptor.Run(ctx, r.interceptor, "UserRepository.Create", func(ctx context.Context) error {
+		return r.repo.Create(ctx, user)
+	})
+}
+
+// GetByID retrieves a user by ID through the interceptor
+func (r *userRepositoryIntercepted) GetByID(ctx context.Context, id uint) (user *userEntities.User, err error) {
+	err = interceptor.Run(ctx, r.interceptor, "UserRepository.GetByID", func(ctx context.Context) error {
+		user, err = r.repo.GetByID(ctx, id)
+		return err
+	})
+	return user, err
+}
+
+// GetByPublicID retrieves a user by public ID through the interceptor
+func (r *userRepositoryIntercepted) GetByPublicID(ctx context.Context, publicID string) (user *userEntities.User, err error) {
+	err = interceptor.Run(ctx, r.interceptor, "UserRepository.GetByPublicID", func(ctx context.Context) error {
+		user, err = r.repo.GetByPublicID(ctx, publicID)
+		return err
+	})
+	return user, err
+}
+
+// GetByIDs retrieves users by IDs through the interceptor
+func (r *userRepositoryIntercepted) GetByIDs(ctx context.Context, ids []uint) (users []*userEntities.User, err error) {
+	err = interceptor.Run(ctx, r.interceptor, "UserRepository.GetByIDs", func(ctx context.Context) error {
+		users, err = r.repo.GetByIDs(ctx, ids)
+		return err
+	})
+	return users, err
+}
+
+// GetByEmail retrieves a user by email through the interceptor
+func (r *userRepositoryIntercepted) GetByEmail(ctx context.Context, email string) (user *userEntities.User, err error) {
+	err = interceptor.Run(ctx, r.interceptor, "UserRepository.GetByEmail", func(ctx context.Context) error {
+		user, err = r.repo.GetByEmail(ctx, email)
+		return err
+	})
+	return user, err
+}
+
+// GetAll retrieves a page of users through the interceptor
+func (r *userRepositoryIntercepted) GetAll(ctx context.Context, limit, offset int) (users []*userEntities.User, err error) {
+	err = interceptor.Run(ctx, r.interceptor, "UserRepository.GetAll", func(ctx context.Context) error {
+		users, err = r.repo.GetAll(ctx, limit, offset)
+		return err
+	})
+	return users, err
+}
+
+// ListAfter retrieves a page of users after a cursor through the interceptor
+func (r *userRepositoryIntercepted) ListAfter(ctx context.Context, cursor pagination.Cursor, limit int) (users []*userEntities.User, err error) {
+	err = interceptor.Run(ctx, r.interceptor, "UserRepository.ListAfter", func(ctx context.Context) error {
+		users, err = r.repo.ListAfter(ctx, cursor, limit)
+		return err
+	})
+	return users, err
+}
+
+// Update updates a user through the interceptor
+func (r *userRepositoryIntercepted) Update(ctx context.Context, user *userEntities.User) error {
+	return interceptor.Run(ctx, r.interceptor, "UserRepository.Update", func(ctx context.Context) error {
+		return r.repo.Update(ctx, user)
+	})
+}
+
+// Delete soft deletes a user through the interceptor
+func (r *userRepositoryIntercepted) Delete(ctx context.Context, id uint) error {
+	return interceptor.Run(ctx, r.interceptor, "UserRepository.Delete", func(ctx context.Context) error {
+		return r.repo.Delete(ctx, id)
+	})
+}
+
+// Count counts users through the interceptor
+func (r *userRepositoryIntercepted) Count(ctx context.Context) (count int64, err error) {
+	err = interceptor.Run(ctx, r.interceptor, "UserRepository.Count", func(ctx context.Context) error {
+		count, err = r.repo.Count(ctx)
+		return err
+	})
+	return count, err
+}
+
+// CreateInBatches inserts users in batches through the interceptor
+func (r *userRepositoryIntercepted) CreateInBatches(ctx context.Context, users []*userEntities.User, size int) error {
+	return interceptor.Run(ctx, r.interceptor, "UserRepository.CreateInBatches", func(ctx context.Context) error {
+		return r.repo.CreateInBatches(ctx, users, size)
+	})
+}
+
+// UpdateInBatches updates users in batches through the interceptor
+func (r *userRepositoryIntercepted) UpdateInBatches(ctx context.Context, users []*userEntities.User, size int) error {
+	return interceptor.Run(ctx, r.interceptor, "UserRepository.UpdateInBatches", func(ctx context.Context) error {
+		return r.repo.UpdateInBatches(ctx, users, size)
+	})
+}
+
+// Upsert inserts or updates a user through the interceptor
+func (r *userRepositoryIntercepted) Upsert(ctx context.Context, user *userEntities.User) (created bool, err error) {
+	err = interceptor.Run(ctx, r.interceptor, "UserRepository.Upsert", func(ctx context.Context) error {
+		created, err = r.repo.Upsert(ctx, user)
+		return err
+	})
+	return created, err
+}
+
+// GetByIDIncludingDeleted retrieves a user by ID, deleted or not, through the interceptor
+func (r *userRepositoryIntercepted) GetByIDIncludingDeleted(ctx context.Context, id uint) (user *userEntities.User, err error) {
+	err = interceptor.Run(ctx, r.interceptor, "UserRepository.GetByIDIncludingDeleted", func(ctx context.Context) error {
+		user, err = r.repo.GetByIDIncludingDeleted(ctx, id)
+		return err
+	})
+	return user, err
+}
+
+// Restore undeletes a user through the interceptor
+func (r *userRepositoryIntercepted) Restore(ctx context.Context, id uint) error {
+	return interceptor.Run(ctx, r.interceptor, "UserRepository.Restore", func(ctx context.Context) error {
+		return r.repo.Restore(ctx, id)
+	})
+}
+
+// Purge permanently deletes a user through the interceptor
+func (r *userRepositoryIntercepted) Purge(ctx context.Context, id uint) error {
+	return interceptor.Run(ctx, r.interceptor, "UserRepository.Purge", func(ctx context.Context) error {
+		return r.repo.Purge(ctx, id)
+	})
+}
+
+// PurgeOlderThan purges long soft-deleted users through the interceptor
+func (r *userRepositoryIntercepted) PurgeOlderThan(ctx context.Context, age time.Duration) (purged int64, err error) {
+	err = interceptor.Run(ctx, r.interceptor, "UserRepository.PurgeOlderThan", func(ctx context.Context) error {
+		purged, err = r.repo.PurgeOlderThan(ctx, age)
+		return err
+	})
+	return purged, err
+}
+
+// GetUsersByEmailDomain retrieves users by email domain through the interceptor
+func (r *userRepositoryIntercepted) GetUsersByEmailDomain(ctx context.Context, domain string) (users []*userEntities.User, err error) {
+	err = interceptor.Run(ctx, r.interceptor, "UserRepository.GetUsersByEmailDomain", func(ctx context.Context) error {
+		users, err = r.repo.GetUsersByEmailDomain(ctx, domain)
+		return err
+	})
+	return users, err
+}
+
+// GetActiveUsers retrieves the active users through the interceptor
+func (r *userRepositoryIntercepted) GetActiveUsers(ctx context.Context) (users []*userEntities.User, err error) {
+	err = interceptor.Run(ctx, r.interceptor, "UserRepository.GetActiveUsers", func(ctx context.Context) error {
+		users, err = r.repo.GetActiveUsers(ctx)
+		return err
+	})
+	return users, err
+}
+
+// GetUsersWithFilters retrieves a filtered page of users through the interceptor
+func (r *userRepositoryIntercepted) GetUsersWithFilters(ctx context.Context, limit, offset int, email, name string) (users []*userEntities.User, err error) {
+	err = interceptor.Run(ctx, r.interceptor, "UserRepository.GetUsersWithFilters", func(ctx context.Context) error {
+		users, err = r.repo.GetUsersWithFilters(ctx, limit, offset, email, name)
+		return err
+	})
+	return users, err
+}
@@ -0,0 +1,376 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/pagination"
+	"clean-arch-gin/internal/domain/shared/softdelete"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+)
+
+// userRepositoryMemory implements UserRepository in memory
+// It mirrors the soft-delete (including softdelete scopes) and filtering semantics of the database implementations,
+// which makes it suitable for benchmarks, local runs and tests without a database.
+// Like the tenant scope of the database, a context acting for a tenant only sees its users
+type userRepositoryMemory struct {
+	mu    sync.RWMutex
+	users map[uint]*userEntities.User
+	// tenants holds the tenant of every user by ID
+	tenants map[uint]uint
+	nextID  uint
+}
+
+// NewUserRepositoryMemory creates a new in-memory user repository
+func NewUserRepositoryMemory() userRepositories.UserRepository {
+	return &userRepositoryMemory{
+		users:   make(map[uint]*userEntities.User),
+		tenants: make(map[uint]uint),
+		nextID:  1,
+	}
+}
+
+// Create stores a new user, enforcing email uniqueness within the tenant like the database
+// index does
+func (r *userRepositoryMemory) Create(ctx context.Context, user *userEntities.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenantID, ok := tenancy.FromContext(ctx)
+	if !ok {
+		tenantID = tenantEntities.DefaultTenantID
+	}
+	for id, existing := range r.users {
+		if existing.Email == user.Email && r.tenants[id] == tenantID {
+			return userEntities.ErrEmailExists
+		}
+	}
+
+	user.ID = r.nextID
+	r.nextID++
+	r.users[user.ID] = copyUser(user)
+	r.tenants[user.ID] = tenantID
+	return nil
+}
+
+// GetByID retrieves a non-deleted user by ID
+func (r *userRepositoryMemory) GetByID(ctx context.Context, id uint) (*userEntities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.get(ctx, id)
+	if !ok || !softdelete.FromContext(ctx).Sees(user.DeletedAt) {
+		return nil, userEntities.ErrUserNotFound
+	}
+	return copyUser(user), nil
+}
+
+// GetByIDs retrieves the non-deleted users with the given IDs, skipping missing ones
+func (r *userRepositoryMemory) GetByIDs(ctx context.Context, ids []uint) ([]*userEntities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]*userEntities.User, 0, len(ids))
+	for _, id := range ids {
+		if user, ok := r.get(ctx, id); ok && softdelete.FromContext(ctx).Sees(user.DeletedAt) {
+			users = append(users, copyUser(user))
+		}
+	}
+	return users, nil
+}
+
+// GetByPublicID retrieves a user, deleted or not, by public ID
+func (r *userRepositoryMemory) GetByPublicID(ctx context.Context, publicID string) (*userEntities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for id, user := range r.users {
+		if user.PublicID == publicID && r.visible(ctx, id) {
+			return copyUser(user), nil
+		}
+	}
+	return nil, userEntities.ErrUserNotFound
+}
+
+// GetByEmail retrieves a non-deleted user by email
+func (r *userRepositoryMemory) GetByEmail(ctx context.Context, email string) (*userEntities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for id, user := range r.users {
+		if user.Email == email && softdelete.FromContext(ctx).Sees(user.DeletedAt) && r.visible(ctx, id) {
+			return copyUser(user), nil
+		}
+	}
+	return nil, userEntities.ErrUserNotFound
+}
+
+// GetAll retrieves non-deleted users ordered by ID with pagination
+func (r *userRepositoryMemory) GetAll(ctx context.Context, limit, offset int) ([]*userEntities.User, error) {
+	return r.find(ctx, limit, offset, func(*userEntities.User) bool { return true }), nil
+}
+
+// ListAfter retrieves the users after cursor ordered by creation time, then ID
+func (r *userRepositoryMemory) ListAfter(ctx context.Context, cursor pagination.Cursor, limit int) ([]*userEntities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	scope := softdelete.FromContext(ctx)
+	matched := make([]*userEntities.User, 0, len(r.users))
+	for id, user := range r.users {
+		if scope.Sees(user.DeletedAt) && r.visible(ctx, id) && isAfter(user, cursor) {
+			matched = append(matched, user)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return isAfter(matched[j], pagination.After(matched[i].CreatedAt, matched[i].ID))
+	})
+	if limit >= 0 && limit < len(matched) {
+		matched = matched[:limit]
+	}
+
+	users := make([]*userEntities.User, len(matched))
+	for i, user := range matched {
+		users[i] = copyUser(user)
+	}
+	return users, nil
+}
+
+// Update replaces a stored user, enforcing email uniqueness within its tenant like the
+// database index does
+func (r *userRepositoryMemory) Update(ctx context.Context, user *userEntities.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.get(ctx, user.ID); !ok {
+		return userEntities.ErrUserNotFound
+	}
+	for id, existing := range r.users {
+		if id != user.ID && existing.Email == user.Email && r.tenants[id] == r.tenants[user.ID] {
+			return userEntities.ErrEmailExists
+		}
+	}
+	r.users[user.ID] = copyUser(user)
+	return nil
+}
+
+// Delete soft deletes a user by ID; deleting a missing user is a no-op
+func (r *userRepositoryMemory) Delete(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user, ok := r.get(ctx, id); ok && !user.IsDeleted() {
+		now := time.Now()
+		user.DeletedAt = &now
+	}
+	return nil
+}
+
+// Count returns the number of non-deleted users
+func (r *userRepositoryMemory) Count(ctx context.Context) (int64, error) {
+	return int64(len(r.find(ctx, -1, 0, func(*userEntities.User) bool { return true }))), nil
+}
+
+// CreateInBatches stores the users one at a time; there are no statements to batch in memory
+func (r *userRepositoryMemory) CreateInBatches(ctx context.Context, users []*userEntities.User, size int) error {
+	return eachUser(users, func(user *userEntities.User) error { return r.Create(ctx, user) })
+}
+
+// UpdateInBatches replaces the users one at a time
+func (r *userRepositoryMemory) UpdateInBatches(ctx context.Context, users []*userEntities.User, size int) error {
+	return eachUser(users, func(user *userEntities.User) error { return r.Update(ctx, user) })
+}
+
+// Upsert stores the user or overwrites the tenant's user with its email, deleted or not,
+// keeping that one's ID, public ID and creation and deletion times
+func (r *userRepositoryMemory) Upsert(ctx context.Context, user *userEntities.User) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenantID, ok := tenancy.FromContext(ctx)
+	if !ok {
+		tenantID = tenantEntities.DefaultTenantID
+	}
+	for id, existing := range r.users {
+		if existing.Email == user.Email && r.tenants[id] == tenantID {
+			user.ID, user.PublicID, user.CreatedAt = existing.ID, existing.PublicID, existing.CreatedAt
+			updated := copyUser(user)
+			updated.DeletedAt = existing.DeletedAt
+			r.users[id] = updated
+			return false, nil
+		}
+	}
+
+	user.ID = r.nextID
+	r.nextID++
+	r.users[user.ID] = copyUser(user)
+	r.tenants[user.ID] = tenantID
+	return true, nil
+}
+
+// GetByIDIncludingDeleted retrieves a user by ID, soft deleted or not
+func (r *userRepositoryMemory) GetByIDIncludingDeleted(ctx context.Context, id uint) (*userEntities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.get(ctx, id)
+	if !ok {
+		return nil, userEntities.ErrUserNotFound
+	}
+	return copyUser(user), nil
+}
+
+// Restore clears the deletion time of a soft-deleted user
+func (r *userRepositoryMemory) Restore(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.get(ctx, id)
+	if !ok || !user.IsDeleted() {
+		return userEntities.ErrUserNotFound
+	}
+	user.Activate()
+	return nil
+}
+
+// PurgeOlderThan removes the users soft deleted more than age ago
+func (r *userRepositoryMemory) PurgeOlderThan(ctx context.Context, age time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	before := time.Now().Add(-age)
+	var purged int64
+	for id, user := range r.users {
+		if user.DeletedAt != nil && user.DeletedAt.Before(before) && r.visible(ctx, id) {
+			delete(r.users, id)
+			delete(r.tenants, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// Purge removes a user; there are no dependent records in memory
+func (r *userRepositoryMemory) Purge(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.get(ctx, id); !ok {
+		return userEntities.ErrUserNotFound
+	}
+	delete(r.users, id)
+	delete(r.tenants, id)
+	return nil
+}
+
+// GetUsersByEmailDomain gets users whose email ends with the domain
+func (r *userRepositoryMemory) GetUsersByEmailDomain(ctx context.Context, domain string) ([]*userEntities.User, error) {
+	return r.find(ctx, -1, 0, func(u *userEntities.User) bool {
+		return strings.HasSuffix(strings.ToLower(u.Email), strings.ToLower(domain))
+	}), nil
+}
+
+// GetActiveUsers gets all non-deleted users
+func (r *userRepositoryMemory) GetActiveUsers(ctx context.Context) ([]*userEntities.User, error) {
+	return r.find(ctx, -1, 0, func(*userEntities.User) bool { return true }), nil
+}
+
+// GetUsersWithFilters gets users whose email and name contain the filters (case-insensitive)
+func (r *userRepositoryMemory) GetUsersWithFilters(ctx context.Context, limit, offset int, email, name string) ([]*userEntities.User, error) {
+	return r.find(ctx, limit, offset, func(u *userEntities.User) bool {
+		return containsFold(u.Email, email) && containsFold(u.Name, name)
+	}), nil
+}
+
+// find returns copies of matching users in the context's soft delete scope, non-deleted ones
+// by default, ordered by ID
+// A negative limit returns every match, like GORM's Limit(-1)
+func (r *userRepositoryMemory) find(ctx context.Context, limit, offset int, match func(*userEntities.User) bool) []*userEntities.User {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	scope := softdelete.FromContext(ctx)
+	matched := make([]*userEntities.User, 0, len(r.users))
+	for id, user := range r.users {
+		if scope.Sees(user.DeletedAt) && r.visible(ctx, id) && match(user) {
+			matched = append(matched, user)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+
+	if offset > len(matched) {
+		offset = len(matched)
+	}
+	if offset > 0 {
+		matched = matched[offset:]
+	}
+	if limit >= 0 && limit < len(matched) {
+		matched = matched[:limit]
+	}
+
+	users := make([]*userEntities.User, len(matched))
+	for i, user := range matched {
+		users[i] = copyUser(user)
+	}
+	return users
+}
+
+// get returns the stored user with id when the context's tenant may see it
+func (r *userRepositoryMemory) get(ctx context.Context, id uint) (*userEntities.User, bool) {
+	user, ok := r.users[id]
+	if !ok || !r.visible(ctx, id) {
+		return nil, false
+	}
+	return user, true
+}
+
+// visible reports whether the user with id belongs to the context's tenant; a context
+// without a tenant sees every user
+func (r *userRepositoryMemory) visible(ctx context.Context, id uint) bool {
+	tenantID, ok := tenancy.FromContext(ctx)
+	return !ok || r.tenants[id] == tenantID
+}
+
+// copyUser prevents callers from mutating stored users through shared pointers
+func copyUser(user *userEntities.User) *userEntities.User {
+	copied := *user
+	if user.DeletedAt != nil {
+		deletedAt := *user.DeletedAt
+		copied.DeletedAt = &deletedAt
+	}
+	return &copied
+}
+
+// eachUser applies write to every user and reports the ones it failed for like a batch write
+func eachUser(users []*userEntities.User, write func(*userEntities.User) error) error {
+	var failed []sharedEntities.RecordError
+	for i, user := range users {
+		if err := write(user); err != nil {
+			failed = append(failed, sharedEntities.RecordError{Index: i, Err: err})
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return &sharedEntities.BatchError{Total: len(users), Records: failed}
+}
+
+// isAfter reports whether user comes after cursor in created_at, id order
+func isAfter(user *userEntities.User, cursor pagination.Cursor) bool {
+	if !user.CreatedAt.Equal(cursor.CreatedAt) {
+		return user.CreatedAt.After(cursor.CreatedAt)
+	}
+	return user.ID > cursor.ID
+}
+
+// containsFold reports whether substr is within s, ignoring case; an empty filter matches everything
+func containsFold(s, substr string) bool {
+	return substr == "" || strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"strings"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+
+	"gorm.io/gorm"
+)
+
+// decryptedScanBatch is how many users are decrypted at a time by filterDecrypted
+const decryptedScanBatch = 500
+
+// filterDecrypted returns the users of query, in ID order after afterID, that match once
+// decrypted, skipping offset matches and keeping up to limit (all when negative)
+// Encrypted columns cannot be searched with LIKE, so searches on them read every
+// candidate of the tenant; filter on plaintext columns in query to narrow them
+func filterDecrypted(query *gorm.DB, match func(*models.UserModel) bool, afterID uint, offset, limit int) ([]models.UserModel, error) {
+	var matched []models.UserModel
+	for limit < 0 || len(matched) < limit {
+		var batch []models.UserModel
+		if err := query.Session(&gorm.Session{}).Where("id > ?", afterID).Order("id").Limit(decryptedScanBatch).Find(&batch).Error; err != nil {
+			return nil, err
+		}
+		for i := range batch {
+			if !match(&batch[i]) {
+				continue
+			}
+			if offset > 0 {
+				offset--
+				continue
+			}
+			if limit >= 0 && len(matched) == limit {
+				break
+			}
+			matched = append(matched, batch[i])
+		}
+		if len(batch) < decryptedScanBatch {
+			break
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+	return matched, nil
+}
+
+// matchesSearch matches users whose email and name contain the given substrings, case
+// insensitively like the LIKE searches on plaintext columns
+func matchesSearch(email, name string) func(*models.UserModel) bool {
+	return func(user *models.UserModel) bool {
+		return containsFold(user.Email, email) && containsFold(user.Name, name)
+	}
+}
+
+// hasEmailDomain matches users whose email ends with domain
+func hasEmailDomain(domain string) func(*models.UserModel) bool {
+	return func(user *models.UserModel) bool {
+		return strings.HasSuffix(strings.ToLower(user.Email), strings.ToLower(domain))
+	}
+}
+
+// toUserEntities converts the users read by filterDecrypted to domain entities
+func toUserEntities(userModels []models.UserModel) []*userEntities.User {
+	users := make([]*userEntities.User, len(userModels))
+	for i := range userModels {
+		users[i] = userModels[i].ToDomainEntity()
+	}
+	return users
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/domain/shared/search"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// UserSearchIndex names the search index of users
+const UserSearchIndex = "users"
+
+// UserSearchMapping types the fields of user documents; emails are matched by their words,
+// as the database search splits them, and filtered by the whole address
+var UserSearchMapping = search.Mapping{
+	"name":        search.FieldText,
+	"email_words": search.FieldText,
+	"email":       search.FieldKeyword,
+	"role":        search.FieldKeyword,
+	"status":      search.FieldKeyword,
+	"created_at":  search.FieldDate,
+}
+
+// documentBatchSize is how many rows a reindex reads at a time
+const documentBatchSize = 500
+
+// userSearchDocuments implements search.DocumentSource on the users table, decrypting names
+// and emails, which the index holds in plaintext
+type userSearchDocuments struct {
+	db *gorm.DB
+}
+
+// NewUserSearchIndex describes the search index of users, read from db
+func NewUserSearchIndex(db *gorm.DB) search.Index {
+	return search.Index{
+		Name:    UserSearchIndex,
+		Mapping: UserSearchMapping,
+		Source:  &userSearchDocuments{db: db},
+	}
+}
+
+// Document returns the document of the user id, nil once deleted
+func (d *userSearchDocuments) Document(ctx context.Context, id uint) (*search.Document, error) {
+	var userModel models.UserModel
+	if err := database.Conn(ctx, d.db).First(&userModel, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	doc := userDocument(&userModel)
+	return &doc, nil
+}
+
+// Documents yields the documents of every user, in ID order
+func (d *userSearchDocuments) Documents(ctx context.Context, yield func(search.Document) error) error {
+	var batch []models.UserModel
+	return database.Conn(ctx, d.db).Order("id").FindInBatches(&batch, documentBatchSize, func(tx *gorm.DB, _ int) error {
+		for i := range batch {
+			if err := yield(userDocument(&batch[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+// userDocument is the document of a user
+func userDocument(userModel *models.UserModel) search.Document {
+	return search.Document{
+		ID:       strconv.FormatUint(uint64(userModel.ID), 10),
+		TenantID: userModel.TenantID,
+		Fields: map[string]interface{}{
+			"name":        userModel.Name,
+			"email_words": strings.Join(userEntities.SearchWords(userModel.Email), " "),
+			"email":       strings.ToLower(userModel.Email),
+			"role":        userModel.Role,
+			"status":      userModel.Status,
+			"created_at":  userModel.CreatedAt,
+		},
+	}
+}
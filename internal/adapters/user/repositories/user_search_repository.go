@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/fieldcrypt"
+
+	"gorm.io/gorm"
+)
+
+// UserSearchColumns are the columns users are searched by, in the order of their full-text
+// index
+var UserSearchColumns = []string{"name", "email"}
+
+// Relevance of a term matching a word of a user, for the searches scored by scoreSearch
+const (
+	nameMatchScore  = 2
+	emailMatchScore = 1
+	wholeWordBonus  = 1
+)
+
+// userSearchRow is a user read with its relevance
+type userSearchRow struct {
+	models.UserModel `gorm:"embedded"`
+	SearchScore      float64
+}
+
+// userSearchRepository implements UserSearchRepository using the database's full-text search
+type userSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewUserSearchRepository creates a new user search repository
+// MySQL and Postgres search through the full-text index of UserSearchColumns; SQLite, and
+// any database once the columns are encrypted, scan the tenant's users and rank them here
+func NewUserSearchRepository(db *gorm.DB) userRepositories.UserSearchRepository {
+	return &userSearchRepository{db: db}
+}
+
+// Search retrieves a page of the users matching every term, most relevant first
+func (r *userSearchRepository) Search(ctx context.Context, terms []string, limit, offset int) ([]*userEntities.UserSearchHit, int64, error) {
+	if fieldcrypt.Enabled() || !database.HasFullText(r.db) {
+		return r.scan(ctx, terms, limit, offset)
+	}
+
+	query, score := database.MatchFullText(database.Conn(ctx, r.db).Model(&models.UserModel{}), UserSearchColumns, terms)
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []userSearchRow
+	err := query.Select("users.*, ? AS search_score", score).
+		Order("search_score DESC").Order("id").Limit(limit).Offset(offset).Find(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]*userEntities.UserSearchHit, len(rows))
+	for i := range rows {
+		hits[i] = &userEntities.UserSearchHit{User: rows[i].ToDomainEntity(), Score: rows[i].SearchScore}
+	}
+	return hits, total, nil
+}
+
+// scan ranks every matching user of the tenant, reading plaintext columns narrowed by LIKE
+// and encrypted ones once decrypted
+func (r *userSearchRepository) scan(ctx context.Context, terms []string, limit, offset int) ([]*userEntities.UserSearchHit, int64, error) {
+	query := database.Conn(ctx, r.db).Model(&models.UserModel{})
+	if !fieldcrypt.Enabled() {
+		for _, term := range terms {
+			query = query.Where("LOWER(name) LIKE ? OR LOWER(email) LIKE ?", "%"+term+"%", "%"+term+"%")
+		}
+	}
+	scores := make(map[uint]float64)
+	userModels, err := filterDecrypted(query, func(user *models.UserModel) bool {
+		score, ok := scoreSearch(user.Name, user.Email, terms)
+		scores[user.ID] = score
+		return ok
+	}, 0, 0, -1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sort.SliceStable(userModels, func(i, j int) bool {
+		return scores[userModels[i].ID] > scores[userModels[j].ID]
+	})
+	total := int64(len(userModels))
+	if offset >= len(userModels) {
+		return []*userEntities.UserSearchHit{}, total, nil
+	}
+	userModels = userModels[offset:]
+	if limit >= 0 && limit < len(userModels) {
+		userModels = userModels[:limit]
+	}
+	hits := make([]*userEntities.UserSearchHit, len(userModels))
+	for i := range userModels {
+		hits[i] = &userEntities.UserSearchHit{User: userModels[i].ToDomainEntity(), Score: scores[userModels[i].ID]}
+	}
+	return hits, total, nil
+}
+
+// scoreSearch scores a user against terms and reports whether every term starts a word of
+// their name or email; names weigh more than emails, and whole words more than prefixes
+func scoreSearch(name, email string, terms []string) (float64, bool) {
+	nameWords, emailWords := userEntities.SearchWords(name), userEntities.SearchWords(email)
+	var score float64
+	for _, term := range terms {
+		nameScore := wordScore(nameWords, term, nameMatchScore)
+		emailScore := wordScore(emailWords, term, emailMatchScore)
+		if nameScore == 0 && emailScore == 0 {
+			return 0, false
+		}
+		score += nameScore + emailScore
+	}
+	return score, true
+}
+
+// wordScore is weight, plus the whole word bonus, for the best word term starts, or 0
+func wordScore(words []string, term string, weight float64) float64 {
+	var best float64
+	for _, word := range words {
+		word = strings.ToLower(word)
+		switch {
+		case word == term:
+			return weight + wholeWordBonus
+		case strings.HasPrefix(word, term):
+			best = weight
+		}
+	}
+	return best
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+)
+
+// userSearchRepositoryBreaker guards a UserSearchRepository with a circuit breaker
+type userSearchRepositoryBreaker struct {
+	repo userRepositories.UserSearchRepository
+	cb   *breaker.CircuitBreaker
+}
+
+// NewUserSearchRepositoryWithBreaker wraps repo so calls go through cb
+func NewUserSearchRepositoryWithBreaker(repo userRepositories.UserSearchRepository, cb *breaker.CircuitBreaker) userRepositories.UserSearchRepository {
+	return &userSearchRepositoryBreaker{repo: repo, cb: cb}
+}
+
+// Search searches users through the breaker
+func (r *userSearchRepositoryBreaker) Search(ctx context.Context, terms []string, limit, offset int) (hits []*userEntities.UserSearchHit, total int64, err error) {
+	err = r.cb.Execute(func() error {
+		hits, total, err = r.repo.Search(ctx, terms, limit, offset)
+		return err
+	})
+	return hits, total, err
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+
+	"clean-arch-gin/internal/domain/shared/search"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+)
+
+// userSearchRepositoryEngine searches users in the search index, falling back to the
+// database while the engine is unavailable
+type userSearchRepositoryEngine struct {
+	engine   search.SearchRepository
+	userRepo userRepositories.UserRepository
+	fallback userRepositories.UserSearchRepository
+}
+
+// NewUserSearchRepositoryWithEngine searches users in engine's users index and reads the
+// hits from userRepo, or searches through fallback while engine is unavailable
+func NewUserSearchRepositoryWithEngine(engine search.SearchRepository, userRepo userRepositories.UserRepository,
+	fallback userRepositories.UserSearchRepository) userRepositories.UserSearchRepository {
+	return &userSearchRepositoryEngine{engine: engine, userRepo: userRepo, fallback: fallback}
+}
+
+// Search retrieves a page of the users matching every term, most relevant first; hits on
+// users deleted since they were indexed are left out
+func (r *userSearchRepositoryEngine) Search(ctx context.Context, terms []string, limit, offset int) ([]*userEntities.UserSearchHit, int64, error) {
+	results, total, err := r.engine.Search(ctx, search.Query{
+		Index:  UserSearchIndex,
+		Terms:  terms,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if errors.Is(err, search.ErrUnavailable) {
+		log.Printf("search: searching users in the database: %v", err)
+		return r.fallback.Search(ctx, terms, limit, offset)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]uint, 0, len(results))
+	for _, result := range results {
+		id, err := strconv.ParseUint(result.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, uint(id))
+	}
+	users, err := r.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[string]*userEntities.User, len(users))
+	for _, user := range users {
+		byID[strconv.FormatUint(uint64(user.ID), 10)] = user
+	}
+	hits := make([]*userEntities.UserSearchHit, 0, len(results))
+	for _, result := range results {
+		if user, ok := byID[result.ID]; ok {
+			hits = append(hits, &userEntities.UserSearchHit{User: user, Score: result.Score})
+		}
+	}
+	return hits, total, nil
+}
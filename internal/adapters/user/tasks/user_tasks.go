@@ -0,0 +1,358 @@
+// Package tasks holds the task queue handlers of the user module
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"clean-arch-gin/internal/adapters/user/exporters"
+	"clean-arch-gin/internal/adapters/user/importers"
+	"clean-arch-gin/internal/adapters/user/notifications"
+	"clean-arch-gin/internal/application/user/commands"
+	"clean-arch-gin/internal/application/user/queries"
+	"clean-arch-gin/internal/domain/shared/storage"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	"clean-arch-gin/internal/infrastructure/retry"
+	infraStorage "clean-arch-gin/internal/infrastructure/storage"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+	"clean-arch-gin/internal/infrastructure/workerpool"
+)
+
+const (
+	// ImportTaskType is the task type of a background bulk import
+	ImportTaskType = "users.import"
+	// ExportTaskType is the task type of a background bulk export
+	ExportTaskType = "users.export"
+	// ExportReadyNotification is the notification type telling the requester an export is ready
+	ExportReadyNotification = "users.export_ready"
+	// ExportReadyTemplate is the notification template of ready exports
+	ExportReadyTemplate = "export_ready"
+	// ExportURLTTL is how long the download URL of an export works
+	ExportURLTTL = 24 * time.Hour
+)
+
+// Phases reported by imports and exports while they run
+const (
+	ImportPhaseCounting  = "counting"
+	ImportPhaseImporting = "importing"
+	ExportPhaseWriting   = "writing"
+	ExportPhaseStoring   = "storing"
+)
+
+// ImportPayload is an uploaded import file queued for processing
+// The file travels in the task row so any replica's worker can run the import
+type ImportPayload struct {
+	Format    importers.Format `json:"format"`
+	BatchSize int              `json:"batch_size,omitempty"`
+	File      []byte           `json:"file"`
+}
+
+// NewImportHandler runs queued imports, reporting progress after each batch and keeping
+// the row-level report as the task's result
+// The rows are counted in a first pass so progress is a true percentage. A file that
+// cannot be read fails permanently, since retrying would read the same bytes
+func NewImportHandler(importHandler *commands.ImportUsersCommandHandler) taskqueue.Handler {
+	return func(ctx context.Context, task *taskqueue.Task) error {
+		var payload ImportPayload
+		if err := task.Decode(&payload); err != nil {
+			return retry.Permanent(err)
+		}
+
+		taskqueue.ReportPhase(ctx, ImportPhaseCounting)
+		total, err := countRows(payload)
+		if err != nil {
+			return retry.Permanent(err)
+		}
+		rows, err := importers.NewReader(payload.Format, bytes.NewReader(payload.File))
+		if err != nil {
+			return retry.Permanent(err)
+		}
+
+		taskqueue.ReportPhase(ctx, ImportPhaseImporting)
+		result, err := importHandler.Handle(ctx, commands.ImportUsersCommand{
+			Rows:      rows,
+			BatchSize: payload.BatchSize,
+			Progress: func(processed int) {
+				taskqueue.ReportItems(ctx, processed, total)
+			},
+		})
+		if err != nil {
+			return retry.Permanent(err)
+		}
+		return taskqueue.SetResult(ctx, result)
+	}
+}
+
+// countRows reads the file once to count its data rows, including malformed ones
+func countRows(payload ImportPayload) (int, error) {
+	rows, err := importers.NewReader(payload.Format, bytes.NewReader(payload.File))
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for {
+		_, err := rows.Next()
+		if err == io.EOF {
+			return total, nil
+		}
+		var rowErr *commands.ImportRowError
+		if err != nil && !errors.As(err, &rowErr) {
+			return 0, err
+		}
+		total++
+	}
+}
+
+// ExportPayload is a queued export of the users matching a filter
+type ExportPayload struct {
+	Format      exporters.Format     `json:"format"`
+	Filter      queries.ExportFilter `json:"filter"`
+	RequesterID uint                 `json:"requester_id"`
+}
+
+// ExportResult is the result of an export task
+type ExportResult struct {
+	Total       int              `json:"total"`
+	Format      exporters.Format `json:"format"`
+	DownloadURL string           `json:"download_url"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+}
+
+// Notifier records templated notifications; implemented by the notification dispatcher
+type Notifier interface {
+	Dispatch(ctx context.Context, notificationType string, messages ...notifications.Message)
+}
+
+// NewExportHandler runs queued exports: the file is stored in files, and the requester is
+// notified with a signed download URL that is also kept as the task's result. Invalid
+// filters and formats fail permanently
+// CSV and JSON exports to a MultipartStorage are streamed into a multipart upload, so no
+// more than a part is held in memory and nothing is written to disk; after each part the
+// upload and the last user in it are saved as the task's checkpoint, so a retry, even on
+// another worker after a restart, continues the upload instead of starting over. Other
+// exports are written to a temporary file first; a retry rewrites the same key, so a run
+// that failed after storing the file is harmless
+// With a pool, exports run on its workers, so no more than its size run at once whatever
+// the number of task workers; one the pool turns away fails and is retried with backoff
+func NewExportHandler(exportHandler *queries.ExportUsersQueryHandler, files storage.Storage,
+	notifier Notifier, pool *workerpool.Pool) taskqueue.Handler {
+	export := exportTask(exportHandler, files, notifier)
+	if pool == nil {
+		return export
+	}
+	return func(ctx context.Context, task *taskqueue.Task) error {
+		err := pool.Do(ctx, func(ctx context.Context) error {
+			return export(ctx, task)
+		})
+		if errors.Is(err, workerpool.ErrRejected) || errors.Is(err, workerpool.ErrClosed) {
+			return fmt.Errorf("export postponed: %w", err)
+		}
+		return err
+	}
+}
+
+// exportTask runs one export
+func exportTask(exportHandler *queries.ExportUsersQueryHandler, files storage.Storage,
+	notifier Notifier) taskqueue.Handler {
+	return func(ctx context.Context, task *taskqueue.Task) error {
+		var payload ExportPayload
+		if err := task.Decode(&payload); err != nil {
+			return retry.Permanent(err)
+		}
+		if !payload.Format.Valid() {
+			return retry.Permanent(exporters.ErrUnsupportedFormat)
+		}
+		if err := payload.Filter.Validate(); err != nil {
+			return retry.Permanent(err)
+		}
+
+		key := fmt.Sprintf("exports/users-%d.%s", task.ID, payload.Format)
+		var total int
+		var err error
+		if multipart, ok := files.(storage.MultipartStorage); ok && payload.Format.Resumable() {
+			total, err = streamExport(ctx, exportHandler, task, payload, multipart, key)
+		} else {
+			total, err = storeExport(ctx, exportHandler, payload, files, key)
+		}
+		if err != nil {
+			return err
+		}
+		expiresAt := time.Now().Add(ExportURLTTL).Truncate(time.Second)
+		url, err := files.SignedURL(key, expiresAt)
+		if err != nil {
+			return err
+		}
+
+		result := ExportResult{Total: total, Format: payload.Format, DownloadURL: url, ExpiresAt: expiresAt}
+		notifyExportReady(ctx, notifier, task.ID, payload, result)
+		return taskqueue.SetResult(ctx, result)
+	}
+}
+
+// storeExport writes the export to a temporary file stored under key
+func storeExport(ctx context.Context, exportHandler *queries.ExportUsersQueryHandler, payload ExportPayload,
+	files storage.Storage, key string) (int, error) {
+	tmp, err := os.CreateTemp("", "users-export-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	rows, err := exporters.NewWriter(payload.Format, tmp)
+	if err != nil {
+		return 0, retry.Permanent(err)
+	}
+	total, err := writeExport(ctx, exportHandler, payload, rows, exportCheckpoint{})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	taskqueue.ReportPhase(ctx, ExportPhaseStoring)
+	return total, files.Put(ctx, key, payload.Format.ContentType(), tmp)
+}
+
+// exportCheckpoint is how far a streamed export got: its upload, and the users in the parts
+// uploaded so far
+type exportCheckpoint struct {
+	Upload infraStorage.UploadState `json:"upload"`
+	// AfterID is the last user written, Written how many were
+	AfterID uint `json:"after_id"`
+	Written int  `json:"written"`
+}
+
+// streamExport streams the export into a multipart upload under key, resuming the upload of
+// the task's checkpoint when it is still there. The upload is aborted when the export fails
+// for good
+func streamExport(ctx context.Context, exportHandler *queries.ExportUsersQueryHandler, task *taskqueue.Task,
+	payload ExportPayload, files storage.MultipartStorage, key string) (int, error) {
+	var checkpoint exportCheckpoint
+	resumed, err := task.DecodeCheckpoint(&checkpoint)
+	if err != nil {
+		log.Printf("users export %d: ignoring an unreadable checkpoint: %v", task.ID, err)
+		resumed = false
+	}
+
+	var upload *infraStorage.MultipartWriter
+	var rows exporters.Writer
+	if resumed {
+		upload, err = infraStorage.ResumeMultipartWriter(ctx, files, checkpoint.Upload)
+		if errors.Is(err, storage.ErrUploadNotFound) {
+			log.Printf("users export %d: starting over: %v", task.ID, err)
+			if err := files.AbortUpload(ctx, checkpoint.Upload.Key, checkpoint.Upload.UploadID); err != nil {
+				log.Printf("users export %d: failed to abort the upload: %v", task.ID, err)
+			}
+			resumed, checkpoint = false, exportCheckpoint{}
+		} else if err != nil {
+			return 0, err
+		}
+	}
+	if resumed {
+		rows, err = exporters.ResumeWriter(payload.Format, upload)
+	} else {
+		upload, err = infraStorage.NewMultipartWriter(ctx, files, key, payload.Format.ContentType())
+		if err != nil {
+			return 0, err
+		}
+		rows, err = exporters.NewWriter(payload.Format, upload)
+	}
+	if err != nil {
+		return 0, retry.Permanent(err)
+	}
+
+	total, err := writeExport(ctx, exportHandler, payload, &checkpointedRows{
+		Writer:     rows,
+		ctx:        ctx,
+		upload:     upload,
+		checkpoint: checkpoint,
+	}, checkpoint)
+	if err == nil {
+		taskqueue.ReportPhase(ctx, ExportPhaseStoring)
+		err = upload.Complete(ctx)
+	}
+	if err != nil && retry.IsPermanent(err) {
+		if abortErr := upload.Abort(context.WithoutCancel(ctx)); abortErr != nil {
+			log.Printf("users export %d: failed to abort the upload: %v", task.ID, abortErr)
+		}
+	}
+	return total, err
+}
+
+// checkpointedRows uploads a part whenever a row fills one, then saves the task's checkpoint
+// so a retry resumes after that row
+type checkpointedRows struct {
+	exporters.Writer
+	ctx        context.Context
+	upload     *infraStorage.MultipartWriter
+	checkpoint exportCheckpoint
+}
+
+// Write writes the user's row and uploads the part it completes
+func (r *checkpointedRows) Write(user *userEntities.User) error {
+	if err := r.Writer.Write(user); err != nil {
+		return err
+	}
+	r.checkpoint.AfterID = user.ID
+	r.checkpoint.Written++
+	// The row must be in the upload's buffer before a part ends after it
+	if err := r.Writer.Flush(); err != nil {
+		return err
+	}
+	uploaded, err := r.upload.FlushPart(r.ctx)
+	if err != nil || !uploaded {
+		return err
+	}
+	r.checkpoint.Upload = r.upload.State()
+	return taskqueue.SaveCheckpoint(r.ctx, r.checkpoint)
+}
+
+// writeExport writes the matching users after those of checkpoint to rows, and closes them
+func writeExport(ctx context.Context, exportHandler *queries.ExportUsersQueryHandler, payload ExportPayload,
+	rows exporters.Writer, checkpoint exportCheckpoint) (int, error) {
+	taskqueue.ReportPhase(ctx, ExportPhaseWriting)
+	total, err := exportHandler.Handle(ctx, queries.ExportUsersQuery{
+		Filter:  payload.Filter,
+		Rows:    rows,
+		AfterID: checkpoint.AfterID,
+		Written: checkpoint.Written,
+		Progress: func(written, total int) {
+			taskqueue.ReportItems(ctx, written, total)
+		},
+	})
+	if closeErr := rows.Close(); err == nil {
+		err = closeErr
+	}
+	return total, err
+}
+
+// notifyExportReady tells the requester where to download the export
+// A lost notification does not fail the export: the URL is also the task's result
+func notifyExportReady(ctx context.Context, notifier Notifier, taskID uint, payload ExportPayload, result ExportResult) {
+	if notifier == nil || payload.RequesterID == 0 {
+		return
+	}
+	notifier.Dispatch(ctx, ExportReadyNotification, notifications.Message{
+		UserID:   payload.RequesterID,
+		Template: ExportReadyTemplate,
+		Variables: map[string]interface{}{
+			"total":        result.Total,
+			"format":       string(payload.Format),
+			"download_url": result.DownloadURL,
+			"expires_at":   result.ExpiresAt,
+		},
+		Data: map[string]interface{}{
+			"job_id":       taskID,
+			"download_url": result.DownloadURL,
+			"expires_at":   result.ExpiresAt,
+		},
+	})
+}
@@ -0,0 +1,226 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/clientinfo"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/mail"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// Paths of the front end pages the mailed links open
+const (
+	PasswordResetPath = "/reset-password"
+	VerifyEmailPath   = "/verify-email"
+)
+
+// AccountLinks configures the links mailed by the account flows
+type AccountLinks struct {
+	// BaseURL is the front end; links open its pages with the token in the token query
+	// parameter, e.g. <BaseURL>/reset-password?token=...
+	BaseURL          string
+	PasswordResetTTL time.Duration
+	VerificationTTL  time.Duration
+}
+
+// accountUseCase implements the AccountUseCase interface
+type accountUseCase struct {
+	userRepo  userRepositories.UserRepository
+	tokenRepo userRepositories.AccountTokenRepository
+	// sessionRepo signs users out on a password reset; nil leaves the sessions
+	sessionRepo userRepositories.SessionRepository
+	// authEventRepo keeps the authentication audit trail; nil records nothing
+	authEventRepo userRepositories.AuthEventRepository
+	// preferencesRepo holds the locale links are mailed in; nil mails them in the default one
+	preferencesRepo userRepositories.PreferencesRepository
+	publisher       events.EventPublisher
+	mailer          mail.Mailer
+	templates       mail.Renderer
+	links           AccountLinks
+}
+
+// NewAccountUseCase creates a new account use case mailing links with mailer, rendered
+// from templates in the locale users prefer in preferencesRepo. Password resets sign out the
+// sessions in sessionRepo and are recorded in authEventRepo; changes are published on
+// publisher. sessionRepo, authEventRepo, preferencesRepo and publisher may be nil
+func NewAccountUseCase(userRepo userRepositories.UserRepository, tokenRepo userRepositories.AccountTokenRepository,
+	sessionRepo userRepositories.SessionRepository, authEventRepo userRepositories.AuthEventRepository,
+	preferencesRepo userRepositories.PreferencesRepository, publisher events.EventPublisher,
+	mailer mail.Mailer, templates mail.Renderer, links AccountLinks) userUsecases.AccountUseCase {
+	return &accountUseCase{
+		userRepo:        userRepo,
+		tokenRepo:       tokenRepo,
+		sessionRepo:     sessionRepo,
+		authEventRepo:   authEventRepo,
+		preferencesRepo: preferencesRepo,
+		publisher:       publisher,
+		mailer:          mailer,
+		templates:       templates,
+		links:           links,
+	}
+}
+
+// RequestPasswordReset mails a reset link to an active account
+func (uc *accountUseCase) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if err == userEntities.ErrUserNotFound {
+			return nil
+		}
+		return err
+	}
+	if user.IsSuspended() || !user.IsEmailDeliverable() {
+		return nil
+	}
+	return uc.mailLink(ctx, user, userEntities.TokenPasswordReset, mail.TemplatePasswordReset, PasswordResetPath, uc.links.PasswordResetTTL)
+}
+
+// ResetPassword redeems a reset token
+func (uc *accountUseCase) ResetPassword(ctx context.Context, token, password string) error {
+	if password == "" {
+		return userEntities.ErrInvalidPassword
+	}
+	user, err := uc.redeem(ctx, userEntities.TokenPasswordReset, token)
+	if err != nil {
+		return err
+	}
+	if user.IsSuspended() {
+		return userEntities.ErrUserSuspended
+	}
+
+	if err := user.ChangePassword(password); err != nil {
+		return err
+	}
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	if err := uc.tokenRepo.RevokeAll(ctx, user.ID, userEntities.TokenPasswordReset); err != nil {
+		log.Printf("failed to revoke the password reset tokens of user %d: %v", user.ID, err)
+	}
+	if uc.sessionRepo != nil {
+		if _, err := uc.sessionRepo.RevokeAll(ctx, user.ID, 0); err != nil {
+			log.Printf("failed to revoke the sessions of user %d after a password reset: %v", user.ID, err)
+		}
+	}
+	uc.record(ctx, user.ID)
+	publishProfileUpdated(ctx, uc.publisher, user, []string{"password"})
+	return nil
+}
+
+// SendVerification mails a verification link for the user's current email
+func (uc *accountUseCase) SendVerification(ctx context.Context, userID uint) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.IsEmailVerified() {
+		return nil
+	}
+	if !user.IsEmailDeliverable() {
+		return userEntities.ErrEmailUndeliverable
+	}
+	return uc.mailLink(ctx, user, userEntities.TokenEmailVerification, mail.TemplateEmailVerification, VerifyEmailPath, uc.links.VerificationTTL)
+}
+
+// VerifyEmail redeems a verification token
+func (uc *accountUseCase) VerifyEmail(ctx context.Context, token string) (*userEntities.User, error) {
+	user, err := uc.redeem(ctx, userEntities.TokenEmailVerification, token)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsEmailVerified() {
+		return user, nil
+	}
+
+	user.VerifyEmail()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	publishProfileUpdated(ctx, uc.publisher, user, []string{"email_verified"})
+	return user, nil
+}
+
+// mailLink stores a new token of purpose for the user and mails them the link to path
+// carrying it, rendered with template
+func (uc *accountUseCase) mailLink(ctx context.Context, user *userEntities.User, purpose userEntities.AccountTokenPurpose,
+	template, path string, ttl time.Duration) error {
+	secret, err := newSessionToken()
+	if err != nil {
+		return err
+	}
+	token := userEntities.NewAccountToken(user.ID, purpose, hashSessionToken(secret), user.Email, ttl)
+	if err := uc.tokenRepo.Create(ctx, token); err != nil {
+		return err
+	}
+
+	msg, err := uc.templates.Render(template, uc.locale(ctx, user.ID), mail.LinkData{
+		Name:      user.Name,
+		URL:       strings.TrimSuffix(uc.links.BaseURL, "/") + path + "?token=" + url.QueryEscape(secret),
+		ExpiresAt: token.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+	msg.To = []string{user.Email}
+	return uc.mailer.Send(ctx, *msg)
+}
+
+// locale is the locale the user prefers; the default one when they set none or it cannot be
+// loaded, which should not keep them from resetting their password
+func (uc *accountUseCase) locale(ctx context.Context, userID uint) string {
+	if uc.preferencesRepo == nil {
+		return userEntities.DefaultLocale
+	}
+	prefs, err := uc.preferencesRepo.Get(ctx, userID)
+	if err != nil {
+		if err != userEntities.ErrUserPreferencesNotFound {
+			log.Printf("failed to load the locale of user %d, mailing in %s: %v", userID, userEntities.DefaultLocale, err)
+		}
+		return userEntities.DefaultLocale
+	}
+	return prefs.Locale
+}
+
+// redeem consumes a token of purpose and returns its user; a verification token also
+// fails once the user's email changed
+func (uc *accountUseCase) redeem(ctx context.Context, purpose userEntities.AccountTokenPurpose, secret string) (*userEntities.User, error) {
+	if secret == "" {
+		return nil, userEntities.ErrInvalidAccountToken
+	}
+	token, err := uc.tokenRepo.Consume(ctx, purpose, hashSessionToken(secret))
+	if err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		if err == userEntities.ErrUserNotFound {
+			return nil, userEntities.ErrInvalidAccountToken
+		}
+		return nil, err
+	}
+	if purpose == userEntities.TokenEmailVerification && !strings.EqualFold(user.Email, token.Email) {
+		return nil, userEntities.ErrInvalidAccountToken
+	}
+	return user, nil
+}
+
+// record adds the password change of a reset to the authentication audit trail; a failure
+// is logged since the password is already changed
+func (uc *accountUseCase) record(ctx context.Context, userID uint) {
+	if uc.authEventRepo == nil {
+		return
+	}
+	info := clientinfo.FromContext(ctx)
+	event := userEntities.NewAuthEvent(userID, userEntities.AuthPasswordChanged, info.IPAddress, info.UserAgent)
+	event.Reason = "reset"
+	if err := uc.authEventRepo.Create(ctx, event); err != nil {
+		log.Printf("failed to record auth event %s for user %d: %v", event.Type, userID, err)
+	}
+}
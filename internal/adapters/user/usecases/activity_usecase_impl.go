@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// activityUseCase implements the ActivityUseCase interface
+type activityUseCase struct {
+	activityRepo userRepositories.ActivityRepository
+}
+
+// NewActivityUseCase creates a new user activity use case
+func NewActivityUseCase(activityRepo userRepositories.ActivityRepository) userUsecases.ActivityUseCase {
+	return &activityUseCase{
+		activityRepo: activityRepo,
+	}
+}
+
+// Record validates and appends an activity
+func (uc *activityUseCase) Record(ctx context.Context, activity *userEntities.Activity) error {
+	if activity.UserID == 0 || activity.Type == "" {
+		return userEntities.ErrInvalidActivity
+	}
+	return uc.activityRepo.Create(ctx, activity)
+}
+
+// ListActivity retrieves a page of the user's activities with their total
+// The feed outlives the account, so it is readable for deleted users too
+func (uc *activityUseCase) ListActivity(ctx context.Context, userID uint, limit, offset int) ([]*userEntities.Activity, int64, error) {
+	activities, err := uc.activityRepo.ListByUser(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := uc.activityRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return activities, total, nil
+}
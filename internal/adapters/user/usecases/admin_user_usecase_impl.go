@@ -0,0 +1,236 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"clean-arch-gin/internal/domain/shared/authz"
+	"clean-arch-gin/internal/domain/shared/storage"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+	"clean-arch-gin/internal/infrastructure/interceptor"
+)
+
+// adminUserUseCase implements the AdminUserUseCase interface
+type adminUserUseCase struct {
+	userRepo  userRepositories.UserRepository
+	auditRepo userRepositories.AuditRepository
+	// uploads holds the avatars removed on purge; nil without storage
+	uploads    storage.Storage
+	authorizer authz.Authorizer
+	// transaction writes a change and its audit entry as one; nil writes them in turn
+	transaction interceptor.Interceptor
+}
+
+// NewAdminUserUseCase creates a new admin user management use case
+// Purging a user also removes its avatar from uploads, which may be nil; actions are checked
+// against authorizer, and all denied when it is nil. Every change is written with its audit
+// entry through transaction, e.g. database.Transactional, which may be nil
+func NewAdminUserUseCase(userRepo userRepositories.UserRepository, auditRepo userRepositories.AuditRepository, uploads storage.Storage,
+	authorizer authz.Authorizer, transaction interceptor.Interceptor) userUsecases.AdminUserUseCase {
+	return &adminUserUseCase{
+		userRepo:    userRepo,
+		auditRepo:   auditRepo,
+		uploads:     uploads,
+		authorizer:  authorizer,
+		transaction: transaction,
+	}
+}
+
+// UpdateUser changes a user's name or email; an email taken by another user is rejected
+func (uc *adminUserUseCase) UpdateUser(ctx context.Context, actor authz.Actor, id uint, email, name string) (*userEntities.User, error) {
+	if err := uc.authorize(actor, id, "update"); err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := userRepositories.CheckEmailAvailable(ctx, uc.userRepo, user, email); err != nil {
+		return nil, err
+	}
+
+	entry := userEntities.NewAuditEntry(actor.ID, id, userEntities.AuditUserUpdated, "")
+	oldEmail, oldName := user.Email, user.Name
+	user.UpdateInfo(name, email)
+	entry.Change("email", oldEmail, user.Email)
+	entry.Change("name", oldName, user.Name)
+
+	return uc.save(ctx, user, entry)
+}
+
+// DeleteUser soft deletes another user's account
+func (uc *adminUserUseCase) DeleteUser(ctx context.Context, actor authz.Actor, id uint, reason string) error {
+	if err := uc.authorize(actor, id, "delete"); err != nil {
+		return err
+	}
+	if actor.ID == id {
+		return userEntities.ErrSelfModeration
+	}
+	if _, err := uc.userRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return uc.audited(ctx, "delete", userEntities.NewAuditEntry(actor.ID, id, userEntities.AuditUserDeleted, reason), func(ctx context.Context) error {
+		return uc.userRepo.Delete(ctx, id)
+	})
+}
+
+// RestoreUser undeletes a soft-deleted user
+func (uc *adminUserUseCase) RestoreUser(ctx context.Context, actor authz.Actor, id uint, reason string) (*userEntities.User, error) {
+	if err := uc.authorize(actor, id, "restore"); err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.GetByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsDeleted() {
+		return nil, userEntities.ErrUserNotDeleted
+	}
+
+	user.Activate()
+	err = uc.audited(ctx, "restore", userEntities.NewAuditEntry(actor.ID, id, userEntities.AuditUserRestored, reason), func(ctx context.Context) error {
+		return uc.userRepo.Restore(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// PurgeUser permanently deletes another user's account and data
+// The avatar is removed once the rows are gone; a leftover file is logged rather than
+// failing a purge that already happened
+func (uc *adminUserUseCase) PurgeUser(ctx context.Context, actor authz.Actor, id uint, reason string) error {
+	if err := uc.authorize(actor, id, "purge"); err != nil {
+		return err
+	}
+	if actor.ID == id {
+		return userEntities.ErrSelfModeration
+	}
+	user, err := uc.userRepo.GetByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = uc.audited(ctx, "purge", userEntities.NewAuditEntry(actor.ID, id, userEntities.AuditUserPurged, reason), func(ctx context.Context) error {
+		return uc.userRepo.Purge(ctx, id)
+	})
+	if err != nil {
+		return err
+	}
+
+	if uc.uploads != nil && user.AvatarURL != "" {
+		if err := uc.uploads.Delete(ctx, userEntities.AvatarKey(id)); err != nil {
+			log.Printf("failed to delete the avatar of purged user %d: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// SetStatus suspends or reactivates another user's account
+func (uc *adminUserUseCase) SetStatus(ctx context.Context, actor authz.Actor, id uint, status userEntities.Status, reason string) (*userEntities.User, error) {
+	if err := uc.authorize(actor, id, "status"); err != nil {
+		return nil, err
+	}
+	if actor.ID == id {
+		return nil, userEntities.ErrSelfModeration
+	}
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := userEntities.NewAuditEntry(actor.ID, id, userEntities.AuditStatusChanged, reason)
+	oldStatus := user.Status
+	if err := user.SetStatus(status); err != nil {
+		return nil, err
+	}
+	entry.Change("status", string(oldStatus), string(user.Status))
+
+	return uc.save(ctx, user, entry)
+}
+
+// SetRole assigns a role; admins cannot demote themselves, so one admin always remains
+func (uc *adminUserUseCase) SetRole(ctx context.Context, actor authz.Actor, id uint, role userEntities.Role, reason string) (*userEntities.User, error) {
+	if err := uc.authorize(actor, id, "role"); err != nil {
+		return nil, err
+	}
+	if actor.ID == id && role != userEntities.RoleAdmin {
+		return nil, userEntities.ErrSelfModeration
+	}
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := userEntities.NewAuditEntry(actor.ID, id, userEntities.AuditRoleChanged, reason)
+	oldRole := user.Role
+	if err := user.SetRole(role); err != nil {
+		return nil, err
+	}
+	entry.Change("role", string(oldRole), string(user.Role))
+
+	return uc.save(ctx, user, entry)
+}
+
+// ForcePasswordReset flags the user to change their password
+func (uc *adminUserUseCase) ForcePasswordReset(ctx context.Context, actor authz.Actor, id uint, reason string) (*userEntities.User, error) {
+	if err := uc.authorize(actor, id, "password-reset"); err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := userEntities.NewAuditEntry(actor.ID, id, userEntities.AuditPasswordResetForced, reason)
+	entry.Change("password_reset_required", user.PasswordResetRequired, true)
+	user.RequirePasswordReset()
+
+	return uc.save(ctx, user, entry)
+}
+
+// AuditLog retrieves a page of the actions on a user and their total
+// The log outlives the account, so it is readable for deleted users too
+func (uc *adminUserUseCase) AuditLog(ctx context.Context, id uint, limit, offset int) ([]*userEntities.AuditEntry, int64, error) {
+	entries, err := uc.auditRepo.ListByTarget(ctx, id, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := uc.auditRepo.CountByTarget(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// authorize requires the policy to allow actor action on users/<id>, so rules can limit
+// what is done to particular accounts
+func (uc *adminUserUseCase) authorize(actor authz.Actor, id uint, action string) error {
+	return authz.Authorize(uc.authorizer, actor, "users/"+strconv.FormatUint(uint64(id), 10), action)
+}
+
+// save persists the changed user and records the action in one transaction
+// An action that changed nothing is still recorded, since the attempt matters to auditors
+func (uc *adminUserUseCase) save(ctx context.Context, user *userEntities.User, entry *userEntities.AuditEntry) (*userEntities.User, error) {
+	err := uc.audited(ctx, "update", entry, func(ctx context.Context) error {
+		return uc.userRepo.Update(ctx, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// audited runs write and records entry through the transaction, so a change is never left
+// without its audit entry nor an entry without its change
+func (uc *adminUserUseCase) audited(ctx context.Context, op string, entry *userEntities.AuditEntry, write func(ctx context.Context) error) error {
+	return interceptor.Run(ctx, uc.transaction, "AdminUserUseCase."+op, func(ctx context.Context) error {
+		if err := write(ctx); err != nil {
+			return err
+		}
+		return uc.auditRepo.Create(ctx, entry)
+	})
+}
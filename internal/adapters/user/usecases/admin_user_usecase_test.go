@@ -0,0 +1,85 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userRepositoryAdapters "clean-arch-gin/internal/adapters/user/repositories"
+	"clean-arch-gin/internal/adapters/user/usecases"
+	"clean-arch-gin/internal/domain/shared/authz"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/testutil/factory"
+	"clean-arch-gin/internal/testutil/repotest"
+)
+
+// allowAll lets every actor do everything
+type allowAll struct{}
+
+// Enforce allows the action
+func (allowAll) Enforce(authz.Actor, string, string) (bool, error) {
+	return true, nil
+}
+
+// failingAudit fails every entry it is given, after the change it records was written
+type failingAudit struct {
+	userRepositories.AuditRepository
+}
+
+// errAuditDown is the error of failingAudit
+var errAuditDown = errors.New("audit log down")
+
+// Create fails
+func (failingAudit) Create(context.Context, *userEntities.AuditEntry) error {
+	return errAuditDown
+}
+
+func TestAdminChangesCommitWithTheirAuditEntry(t *testing.T) {
+	tests := []struct {
+		name      string
+		auditFail bool
+		wantErr   error
+		wantState userEntities.Status
+		wantAudit int64
+	}{
+		{"audit recorded", false, nil, userEntities.StatusSuspended, 1},
+		{"audit failed", true, errAuditDown, userEntities.StatusActive, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := repotest.OpenSQLite(t)
+			if err := database.AutoMigrate(db, &models.UserAuditModel{}); err != nil {
+				t.Fatalf("failed to migrate the audit log: %v", err)
+			}
+			userRepo := userRepositoryAdapters.NewUserRepository(db)
+			auditRepo := userRepositoryAdapters.NewAuditRepository(db)
+			var writeAudit userRepositories.AuditRepository = auditRepo
+			if tt.auditFail {
+				writeAudit = failingAudit{auditRepo}
+			}
+			uc := usecases.NewAdminUserUseCase(userRepo, writeAudit, nil, allowAll{}, database.Transactional(db))
+			admin := factory.CreateUser(t, db)
+			user := factory.CreateUser(t, db)
+
+			_, err := uc.SetStatus(context.Background(), authz.Actor{ID: admin.ID}, user.ID, userEntities.StatusSuspended, "abuse")
+			if err != tt.wantErr {
+				t.Fatalf("SetStatus() error = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, err := userRepo.GetByID(context.Background(), user.ID)
+			if err != nil {
+				t.Fatalf("GetByID returned error: %v", err)
+			}
+			if stored.Status != tt.wantState {
+				t.Errorf("status = %s, want %s", stored.Status, tt.wantState)
+			}
+			if count, err := auditRepo.CountByTarget(context.Background(), user.ID); err != nil || count != tt.wantAudit {
+				t.Errorf("CountByTarget() = %d, %v, want %d", count, err, tt.wantAudit)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// authEventUseCase implements the AuthEventUseCase interface
+type authEventUseCase struct {
+	authEventRepo userRepositories.AuthEventRepository
+}
+
+// NewAuthEventUseCase creates a new authentication audit trail use case
+func NewAuthEventUseCase(authEventRepo userRepositories.AuthEventRepository) userUsecases.AuthEventUseCase {
+	return &authEventUseCase{
+		authEventRepo: authEventRepo,
+	}
+}
+
+// ListAuthEvents retrieves a page of the events matching filter with their total
+func (uc *authEventUseCase) ListAuthEvents(ctx context.Context, filter userEntities.AuthEventFilter, limit, offset int) ([]*userEntities.AuthEvent, int64, error) {
+	events, err := uc.authEventRepo.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := uc.authEventRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"clean-arch-gin/internal/adapters/user/media"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/storage"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// avatarUseCase implements the AvatarUseCase interface
+type avatarUseCase struct {
+	userRepo  userRepositories.UserRepository
+	store     storage.Storage
+	publisher events.EventPublisher
+}
+
+// NewAvatarUseCase creates a new avatar use case storing the images in store and publishing
+// the change on publisher, which may be nil
+func NewAvatarUseCase(userRepo userRepositories.UserRepository, store storage.Storage, publisher events.EventPublisher) userUsecases.AvatarUseCase {
+	return &avatarUseCase{
+		userRepo:  userRepo,
+		store:     store,
+		publisher: publisher,
+	}
+}
+
+// UploadAvatar replaces the user's avatar with the processed image
+// Every user has one avatar file, overwritten on upload; the URL carries a hash of the
+// content so caches fetch the new picture
+func (uc *avatarUseCase) UploadAvatar(ctx context.Context, userID uint, image io.Reader) (*userEntities.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	avatar, err := media.ProcessAvatar(image)
+	if err != nil {
+		return nil, err
+	}
+	key := userEntities.AvatarKey(userID)
+	if err := uc.store.Put(ctx, key, "image/jpeg", bytes.NewReader(avatar)); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(avatar)
+	user.SetAvatar(uc.store.URL(key) + "?v=" + hex.EncodeToString(sum[:8]))
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	publishProfileUpdated(ctx, uc.publisher, user, []string{"avatar"})
+	return user, nil
+}
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// connectionUseCase implements the ConnectionUseCase interface
+type connectionUseCase struct {
+	connectionRepo userRepositories.ConnectionRepository
+	activityRepo   userRepositories.ActivityRepository
+	userRepo       userRepositories.UserRepository
+	timelineTypes  []string
+}
+
+// NewConnectionUseCase creates a new user connection use case
+// Only activities of timelineTypes are shared with followers; the others, e.g. sign-ins,
+// stay in their user's own feed
+func NewConnectionUseCase(connectionRepo userRepositories.ConnectionRepository, activityRepo userRepositories.ActivityRepository,
+	userRepo userRepositories.UserRepository, timelineTypes []string) userUsecases.ConnectionUseCase {
+	return &connectionUseCase{
+		connectionRepo: connectionRepo,
+		activityRepo:   activityRepo,
+		userRepo:       userRepo,
+		timelineTypes:  timelineTypes,
+	}
+}
+
+// Follow makes the follower follow the followee, which must not be deleted
+func (uc *connectionUseCase) Follow(ctx context.Context, followerID, followeeID uint) error {
+	connection, err := userEntities.NewConnection(followerID, followeeID)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.userRepo.GetByID(ctx, followeeID); err != nil {
+		return err
+	}
+	return uc.connectionRepo.Create(ctx, connection)
+}
+
+// Unfollow makes the follower stop following the followee
+func (uc *connectionUseCase) Unfollow(ctx context.Context, followerID, followeeID uint) error {
+	_, err := uc.connectionRepo.Delete(ctx, followerID, followeeID)
+	return err
+}
+
+// IsFollowing reports whether the follower follows the followee
+func (uc *connectionUseCase) IsFollowing(ctx context.Context, followerID, followeeID uint) (bool, error) {
+	return uc.connectionRepo.Exists(ctx, followerID, followeeID)
+}
+
+// ListConnections retrieves a page of the user's connections with the user's counts
+func (uc *connectionUseCase) ListConnections(ctx context.Context, userID uint, side userEntities.ConnectionType, limit, offset int) ([]*userEntities.User, userEntities.ConnectionCounts, error) {
+	if !side.Valid() {
+		return nil, userEntities.ConnectionCounts{}, userEntities.ErrInvalidConnectionType
+	}
+	users, err := uc.connectionRepo.List(ctx, userID, side, limit, offset)
+	if err != nil {
+		return nil, userEntities.ConnectionCounts{}, err
+	}
+	counts, err := uc.connectionRepo.Counts(ctx, userID)
+	if err != nil {
+		return nil, userEntities.ConnectionCounts{}, err
+	}
+	return users, counts, nil
+}
+
+// GetTimeline retrieves a page of the shared activity of the users the user follows with
+// its total
+// Activities of users deleted since are left out of the page, not of the total, so pages
+// stay aligned
+func (uc *connectionUseCase) GetTimeline(ctx context.Context, userID uint, limit, offset int) ([]*userEntities.TimelineEntry, int64, error) {
+	activities, err := uc.activityRepo.ListFollowedBy(ctx, userID, uc.timelineTypes, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := uc.activityRepo.CountFollowedBy(ctx, userID, uc.timelineTypes)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(activities) == 0 {
+		return []*userEntities.TimelineEntry{}, total, nil
+	}
+
+	ids := make([]uint, 0, len(activities))
+	seen := make(map[uint]bool, len(activities))
+	for _, activity := range activities {
+		if !seen[activity.UserID] {
+			seen[activity.UserID] = true
+			ids = append(ids, activity.UserID)
+		}
+	}
+	users, err := uc.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[uint]*userEntities.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+
+	entries := make([]*userEntities.TimelineEntry, 0, len(activities))
+	for _, activity := range activities {
+		if user, ok := byID[activity.UserID]; ok {
+			entries = append(entries, &userEntities.TimelineEntry{Activity: activity, User: user})
+		}
+	}
+	return entries, total, nil
+}
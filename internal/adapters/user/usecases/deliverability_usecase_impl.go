@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/mail"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	userEvents "clean-arch-gin/internal/domain/user/events"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// maxUndeliverableReason is the size of the stored reason, longer provider details are cut
+const maxUndeliverableReason = 255
+
+// deliverabilityUseCase implements the DeliverabilityUseCase interface
+type deliverabilityUseCase struct {
+	repo      userRepositories.DeliverabilityRepository
+	publisher events.EventPublisher
+}
+
+// NewDeliverabilityUseCase creates a new deliverability use case publishing the users it
+// marks on publisher, which may be nil
+func NewDeliverabilityUseCase(repo userRepositories.DeliverabilityRepository, publisher events.EventPublisher) userUsecases.DeliverabilityUseCase {
+	return &deliverabilityUseCase{
+		repo:      repo,
+		publisher: publisher,
+	}
+}
+
+// RecordFeedback marks the feedback's emails across tenants; the webhook the feedback came
+// from names no tenant, and the same address may sign up with several
+func (uc *deliverabilityUseCase) RecordFeedback(ctx context.Context, feedback []mail.Feedback) error {
+	ctx = tenancy.WithoutTenant(ctx)
+	for _, item := range feedback {
+		email := strings.TrimSpace(item.Email)
+		if email == "" {
+			continue
+		}
+		reason := undeliverableReason(item)
+		at := time.Now()
+		ids, err := uc.repo.MarkUndeliverable(ctx, email, reason, at)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			uc.publish(ctx, userEvents.NewUserEmailUndeliverableEvent(id, reason, at))
+		}
+	}
+	return nil
+}
+
+// publish publishes event, logging failures since the user is already marked
+func (uc *deliverabilityUseCase) publish(ctx context.Context, event userEvents.UserEmailUndeliverableEvent) {
+	if uc.publisher == nil {
+		return
+	}
+	if err := uc.publisher.Publish(ctx, event); err != nil {
+		log.Printf("failed to publish %s for user %d: %v", event.EventName(), event.UserID, err)
+	}
+}
+
+// undeliverableReason is the kind of feedback followed by the provider's detail, e.g.
+// "bounce: 550 5.1.1 user unknown", cut to fit the stored reason
+func undeliverableReason(feedback mail.Feedback) string {
+	reason := string(feedback.Kind)
+	if detail := strings.TrimSpace(feedback.Detail); detail != "" {
+		reason += ": " + detail
+	}
+	if len(reason) <= maxUndeliverableReason {
+		return reason
+	}
+	reason = reason[:maxUndeliverableReason]
+	for !utf8.ValidString(reason) {
+		reason = reason[:len(reason)-1]
+	}
+	return reason
+}
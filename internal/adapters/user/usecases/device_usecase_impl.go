@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// deviceUseCase implements the DeviceUseCase interface
+type deviceUseCase struct {
+	deviceRepo userRepositories.DeviceRepository
+}
+
+// NewDeviceUseCase creates a new device use case
+func NewDeviceUseCase(deviceRepo userRepositories.DeviceRepository) userUsecases.DeviceUseCase {
+	return &deviceUseCase{
+		deviceRepo: deviceRepo,
+	}
+}
+
+// RegisterDevice validates and stores the device
+func (uc *deviceUseCase) RegisterDevice(ctx context.Context, userID uint, token string, platform userEntities.DevicePlatform, name string) (*userEntities.Device, error) {
+	device, err := userEntities.NewDevice(userID, token, platform, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.deviceRepo.Register(ctx, device, userEntities.MaxDevices); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// ListDevices retrieves the user's devices
+func (uc *deviceUseCase) ListDevices(ctx context.Context, userID uint) ([]*userEntities.Device, error) {
+	return uc.deviceRepo.ListByUser(ctx, userID)
+}
+
+// RemoveDevice deletes one of the user's devices
+func (uc *deviceUseCase) RemoveDevice(ctx context.Context, userID, id uint) error {
+	return uc.deviceRepo.Delete(ctx, userID, id)
+}
+
+// ForgetToken deletes the device with token
+func (uc *deviceUseCase) ForgetToken(ctx context.Context, token string) error {
+	return uc.deviceRepo.DeleteByToken(ctx, token)
+}
@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/shared/pagination"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// notificationUseCase implements the NotificationUseCase interface
+type notificationUseCase struct {
+	notificationRepo userRepositories.NotificationRepository
+}
+
+// NewNotificationUseCase creates a new user notification use case
+func NewNotificationUseCase(notificationRepo userRepositories.NotificationRepository) userUsecases.NotificationUseCase {
+	return &notificationUseCase{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// Notify creates an unread notification in the user's inbox
+func (uc *notificationUseCase) Notify(ctx context.Context, userID uint, notificationType, title, body string, data map[string]interface{}) (*userEntities.Notification, error) {
+	notification, err := userEntities.NewNotification(userID, notificationType, title, body, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.notificationRepo.Create(ctx, notification); err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+// ListNotifications retrieves a page of notifications with the inbox counts
+func (uc *notificationUseCase) ListNotifications(ctx context.Context, userID uint, unreadOnly bool, cursor pagination.Cursor, limit int) ([]*userEntities.Notification, userEntities.NotificationCounts, error) {
+	notifications, err := uc.notificationRepo.ListByUser(ctx, userID, unreadOnly, cursor, limit)
+	if err != nil {
+		return nil, userEntities.NotificationCounts{}, err
+	}
+	counts, err := uc.notificationRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, userEntities.NotificationCounts{}, err
+	}
+	return notifications, counts, nil
+}
+
+// UnreadCount counts the user's unread notifications
+func (uc *notificationUseCase) UnreadCount(ctx context.Context, userID uint) (int64, error) {
+	counts, err := uc.notificationRepo.CountByUser(ctx, userID)
+	return counts.Unread, err
+}
+
+// MarkAsRead marks one of the user's notifications as read
+func (uc *notificationUseCase) MarkAsRead(ctx context.Context, userID, id uint) (*userEntities.Notification, error) {
+	return uc.notificationRepo.MarkAsRead(ctx, userID, id)
+}
+
+// MarkAllAsRead marks all the user's notifications as read
+func (uc *notificationUseCase) MarkAllAsRead(ctx context.Context, userID uint) (int64, error) {
+	return uc.notificationRepo.MarkAllAsRead(ctx, userID)
+}
+
+// DeleteNotification deletes one of the user's notifications
+func (uc *notificationUseCase) DeleteNotification(ctx context.Context, userID, id uint) error {
+	return uc.notificationRepo.Delete(ctx, userID, id)
+}
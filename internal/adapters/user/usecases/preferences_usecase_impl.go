@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// preferencesUseCase implements the PreferencesUseCase interface
+type preferencesUseCase struct {
+	userRepo        userRepositories.UserRepository
+	preferencesRepo userRepositories.PreferencesRepository
+}
+
+// NewPreferencesUseCase creates a new user preferences use case
+func NewPreferencesUseCase(userRepo userRepositories.UserRepository, preferencesRepo userRepositories.PreferencesRepository) userUsecases.PreferencesUseCase {
+	return &preferencesUseCase{
+		userRepo:        userRepo,
+		preferencesRepo: preferencesRepo,
+	}
+}
+
+// GetPreferences retrieves a user's preferences, falling back to the defaults
+func (uc *preferencesUseCase) GetPreferences(ctx context.Context, userID uint) (*userEntities.UserPreferences, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return uc.load(ctx, userID)
+}
+
+// UpdatePreferences applies a validated partial update and saves the result
+func (uc *preferencesUseCase) UpdatePreferences(ctx context.Context, userID uint, update userEntities.PreferencesUpdate) (*userEntities.UserPreferences, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	prefs, err := uc.load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := prefs.Apply(update); err != nil {
+		return nil, err
+	}
+
+	if err := uc.preferencesRepo.Save(ctx, prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// load returns the stored preferences or the defaults for a user who saved none
+func (uc *preferencesUseCase) load(ctx context.Context, userID uint) (*userEntities.UserPreferences, error) {
+	prefs, err := uc.preferencesRepo.Get(ctx, userID)
+	if err == userEntities.ErrUserPreferencesNotFound {
+		return userEntities.NewDefaultPreferences(userID), nil
+	}
+	return prefs, err
+}
@@ -0,0 +1,286 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/clientinfo"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/passwords"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	"clean-arch-gin/internal/domain/shared/tokens"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userEvents "clean-arch-gin/internal/domain/user/events"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// touchInterval limits how often a session's last use is written, since Authenticate runs
+// on every request
+const touchInterval = time.Minute
+
+// sessionUseCase implements the SessionUseCase interface
+type sessionUseCase struct {
+	userRepo    userRepositories.UserRepository
+	sessionRepo userRepositories.SessionRepository
+	// authEventRepo keeps the authentication audit trail; nil records nothing
+	authEventRepo userRepositories.AuthEventRepository
+	publisher     events.EventPublisher
+	// signer signs the tokens as JWTs; nil issues opaque tokens
+	signer tokens.Signer
+	ttl    time.Duration
+}
+
+// NewSessionUseCase creates a new session use case issuing tokens valid for ttl, signed
+// as JWTs by signer, recording sign-ins and sign-outs in authEventRepo and publishing
+// sign-ins on publisher; authEventRepo, signer and publisher may be nil
+func NewSessionUseCase(userRepo userRepositories.UserRepository, sessionRepo userRepositories.SessionRepository,
+	authEventRepo userRepositories.AuthEventRepository, publisher events.EventPublisher, signer tokens.Signer, ttl time.Duration) userUsecases.SessionUseCase {
+	return &sessionUseCase{
+		userRepo:      userRepo,
+		sessionRepo:   sessionRepo,
+		authEventRepo: authEventRepo,
+		publisher:     publisher,
+		signer:        signer,
+		ttl:           ttl,
+	}
+}
+
+// Login verifies the credentials and starts a session
+// Unknown emails and wrong passwords fail alike so the response does not reveal accounts;
+// suspended accounts and those awaiting a password reset are told so once the password matches
+func (uc *sessionUseCase) Login(ctx context.Context, email, password, userAgent, ipAddress string) (*userEntities.Session, string, error) {
+	failed := func(userID uint, reason string) {
+		event := userEntities.NewAuthEvent(userID, userEntities.AuthLoginFailed, ipAddress, userAgent)
+		event.Reason = reason
+		uc.record(ctx, event)
+	}
+
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if err == userEntities.ErrUserNotFound {
+			// Hash anyway so unknown emails take as long as wrong passwords
+			passwords.VerifyNone(password)
+			failed(0, userEntities.AuthFailureInvalidCredentials)
+			return nil, "", userEntities.ErrInvalidCredentials
+		}
+		return nil, "", err
+	}
+	ok, rehashed := user.VerifyPassword(password)
+	if !ok {
+		failed(user.ID, userEntities.AuthFailureInvalidCredentials)
+		return nil, "", userEntities.ErrInvalidCredentials
+	}
+	if user.IsSuspended() {
+		failed(user.ID, userEntities.AuthFailureSuspended)
+		return nil, "", userEntities.ErrUserSuspended
+	}
+	if user.PasswordResetRequired {
+		failed(user.ID, userEntities.AuthFailurePasswordResetRequired)
+		return nil, "", userEntities.ErrPasswordResetRequired
+	}
+	if rehashed {
+		// A plain-text or weaker hash is replaced; the sign-in goes on if saving it fails
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			log.Printf("failed to rehash the password of user %d: %v", user.ID, err)
+		}
+	}
+
+	secret, err := newSessionToken()
+	if err != nil {
+		return nil, "", err
+	}
+	session := userEntities.NewSession(user.ID, hashSessionToken(secret), userAgent, ipAddress, uc.ttl)
+	if err := uc.sessionRepo.Create(ctx, session); err != nil {
+		return nil, "", err
+	}
+	token, err := uc.sign(ctx, session, secret)
+	if err != nil {
+		return nil, "", err
+	}
+	event := userEntities.NewAuthEvent(user.ID, userEntities.AuthLoginSucceeded, ipAddress, userAgent)
+	event.SessionID = session.ID
+	uc.record(ctx, event)
+
+	if uc.publisher != nil {
+		if err := uc.publisher.Publish(ctx, userEvents.NewUserLoggedInEvent(user.ID, ipAddress, userAgent)); err != nil {
+			log.Printf("failed to publish %s for user %d: %v", userEvents.UserLoggedInEventName, user.ID, err)
+		}
+	}
+	return session, token, nil
+}
+
+// Authenticate resolves a bearer token to its active session
+func (uc *sessionUseCase) Authenticate(ctx context.Context, token string) (*userEntities.Session, error) {
+	secret, err := uc.verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	session, err := uc.sessionRepo.GetByTokenHash(ctx, hashSessionToken(secret))
+	if err != nil {
+		if err == userEntities.ErrSessionNotFound {
+			return nil, userEntities.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if session.IsRevoked() {
+		return nil, userEntities.ErrSessionRevoked
+	}
+	if session.IsExpired(now) {
+		return nil, userEntities.ErrSessionExpired
+	}
+
+	// The request is authenticated either way; a failed write only loses the last-seen time
+	if now.Sub(session.LastSeenAt) >= touchInterval {
+		if err := uc.sessionRepo.Touch(ctx, session.ID, now); err != nil {
+			log.Printf("failed to record the use of session %d: %v", session.ID, err)
+		} else {
+			session.LastSeenAt = now
+		}
+	}
+	return session, nil
+}
+
+// Roles returns the role stored on the user's account, read on every call so a change of
+// role applies to the sessions already started
+func (uc *sessionUseCase) Roles(ctx context.Context, userID uint) ([]string, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err == userEntities.ErrUserNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if user.Role == "" {
+		return nil, nil
+	}
+	return []string{string(user.Role)}, nil
+}
+
+// CheckAccount returns why the user may not act, read on every call so suspending an account
+// or requiring a password reset applies to the sessions and API keys already in use; the
+// credentials of a user that no longer exists are ErrInvalidToken
+func (uc *sessionUseCase) CheckAccount(ctx context.Context, userID uint) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err == userEntities.ErrUserNotFound {
+			return userEntities.ErrInvalidToken
+		}
+		return err
+	}
+	return user.CheckUsable()
+}
+
+// ListSessions retrieves the user's active sessions
+func (uc *sessionUseCase) ListSessions(ctx context.Context, userID uint) ([]*userEntities.Session, error) {
+	return uc.sessionRepo.ListByUser(ctx, userID)
+}
+
+// Logout revokes the session the user signs out of
+func (uc *sessionUseCase) Logout(ctx context.Context, userID, sessionID uint) error {
+	return uc.revoke(ctx, userID, sessionID, userEntities.AuthLogout)
+}
+
+// RevokeSession signs out one of the user's sessions
+func (uc *sessionUseCase) RevokeSession(ctx context.Context, userID, id uint) error {
+	return uc.revoke(ctx, userID, id, userEntities.AuthSessionRevoked)
+}
+
+// RevokeAllSessions signs out the user's sessions except exceptID
+func (uc *sessionUseCase) RevokeAllSessions(ctx context.Context, userID, exceptID uint) (int64, error) {
+	revoked, err := uc.sessionRepo.RevokeAll(ctx, userID, exceptID)
+	if err == nil && revoked > 0 {
+		uc.record(ctx, uc.clientEvent(ctx, userID, userEntities.AuthSessionsRevoked))
+	}
+	return revoked, err
+}
+
+// revoke revokes one of the user's sessions, recording it as eventType
+func (uc *sessionUseCase) revoke(ctx context.Context, userID, id uint, eventType userEntities.AuthEventType) error {
+	if err := uc.sessionRepo.Revoke(ctx, userID, id); err != nil {
+		return err
+	}
+	event := uc.clientEvent(ctx, userID, eventType)
+	event.SessionID = id
+	uc.record(ctx, event)
+	return nil
+}
+
+// clientEvent creates an event of the user from the client ctx was made on behalf of
+func (uc *sessionUseCase) clientEvent(ctx context.Context, userID uint, eventType userEntities.AuthEventType) *userEntities.AuthEvent {
+	client := clientinfo.FromContext(ctx)
+	return userEntities.NewAuthEvent(userID, eventType, client.IPAddress, client.UserAgent)
+}
+
+// record appends an event to the audit trail
+// Signing in and out goes on when it fails, so a trail outage does not lock users out
+func (uc *sessionUseCase) record(ctx context.Context, event *userEntities.AuthEvent) {
+	if uc.authEventRepo == nil {
+		return
+	}
+	if err := uc.authEventRepo.Create(ctx, event); err != nil {
+		log.Printf("failed to record auth event %s for user %d: %v", event.Type, event.UserID, err)
+	}
+}
+
+// sign returns the bearer token of a session: secret itself, or a JWT carrying it as its ID
+func (uc *sessionUseCase) sign(ctx context.Context, session *userEntities.Session, secret string) (string, error) {
+	if uc.signer == nil {
+		return secret, nil
+	}
+	tenantID, _ := tenancy.FromContext(ctx)
+	return uc.signer.Sign(ctx, tokens.Claims{
+		Subject:   strconv.FormatUint(uint64(session.UserID), 10),
+		TenantID:  tenantID,
+		ID:        secret,
+		IssuedAt:  session.CreatedAt,
+		ExpiresAt: session.ExpiresAt,
+	})
+}
+
+// verify returns the session secret a bearer token carries
+// A JWT must verify and be issued in the tenant of ctx; the session is still looked up,
+// so revoking it takes effect before the token expires
+func (uc *sessionUseCase) verify(ctx context.Context, token string) (string, error) {
+	if token == "" {
+		return "", userEntities.ErrInvalidToken
+	}
+	if uc.signer == nil {
+		return token, nil
+	}
+	claims, err := uc.signer.Verify(ctx, token)
+	if err != nil {
+		if errors.Is(err, tokens.ErrInvalid) {
+			return "", userEntities.ErrInvalidToken
+		}
+		return "", err
+	}
+	if tenantID, _ := tenancy.FromContext(ctx); claims.TenantID != tenantID || claims.ID == "" {
+		return "", userEntities.ErrInvalidToken
+	}
+	return claims.ID, nil
+}
+
+// newSessionToken generates a random bearer token
+func newSessionToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashSessionToken derives the stored form of a token
+func hashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,104 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	userRepositoryAdapters "clean-arch-gin/internal/adapters/user/repositories"
+	"clean-arch-gin/internal/adapters/user/usecases"
+	"clean-arch-gin/internal/domain/shared/passwords"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	"clean-arch-gin/internal/testutil/factory"
+	"clean-arch-gin/internal/testutil/repotest"
+)
+
+func TestLoginPasswords(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         factory.UserOption
+		password     string
+		wantErr      error
+		wantRehashed bool
+		// typeHash signs in with the stored hash instead of password
+		typeHash bool
+	}{
+		{"hashed password", factory.WithPassword("password123"), "password123", nil, false, false},
+		{"wrong password", factory.WithPassword("password123"), "password124", userEntities.ErrInvalidCredentials, false, false},
+		{"plain-text row", factory.WithPlainTextPassword("password123"), "password123", nil, true, false},
+		{"plain-text row, wrong password", factory.WithPlainTextPassword("password123"), "password124", userEntities.ErrInvalidCredentials, false, false},
+		{"hash typed as the password", factory.WithPassword("password123"), "", userEntities.ErrInvalidCredentials, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := repotest.OpenSQLite(t)
+			userRepo := userRepositoryAdapters.NewUserRepository(db)
+			uc := usecases.NewSessionUseCase(userRepo, userRepositoryAdapters.NewSessionRepository(db), nil, nil, nil, time.Hour)
+			user := factory.CreateUser(t, db, tt.user)
+			password := tt.password
+			if tt.typeHash {
+				password = user.Password
+			}
+
+			_, token, err := uc.Login(context.Background(), user.Email, password, "test", "127.0.0.1")
+			if err != tt.wantErr {
+				t.Fatalf("Login() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && token == "" {
+				t.Error("Login() returned an empty token")
+			}
+
+			stored, err := userRepo.GetByID(context.Background(), user.ID)
+			if err != nil {
+				t.Fatalf("GetByID returned error: %v", err)
+			}
+			if rehashed := stored.Password != user.Password; rehashed != tt.wantRehashed {
+				t.Errorf("password rehashed = %v, want %v", rehashed, tt.wantRehashed)
+			}
+			if tt.wantRehashed {
+				if !passwords.IsHash(stored.Password) {
+					t.Errorf("stored password = %q, want a bcrypt hash", stored.Password)
+				}
+				if _, _, err := uc.Login(context.Background(), user.Email, tt.password, "test", "127.0.0.1"); err != nil {
+					t.Errorf("Login() after the rehash returned error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoginUnknownEmail(t *testing.T) {
+	db := repotest.OpenSQLite(t)
+	uc := usecases.NewSessionUseCase(userRepositoryAdapters.NewUserRepository(db), userRepositoryAdapters.NewSessionRepository(db), nil, nil, nil, time.Hour)
+
+	if _, _, err := uc.Login(context.Background(), "nobody@example.com", "password123", "test", "127.0.0.1"); err != userEntities.ErrInvalidCredentials {
+		t.Errorf("Login(unknown email) error = %v, want %v", err, userEntities.ErrInvalidCredentials)
+	}
+}
+
+func TestLoginRefusesUnusableAccounts(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    factory.UserOption
+		wantErr error
+	}{
+		{"suspended", factory.Suspended(), userEntities.ErrUserSuspended},
+		{"password reset required", func(u *userEntities.User) { u.RequirePasswordReset() }, userEntities.ErrPasswordResetRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := repotest.OpenSQLite(t)
+			uc := usecases.NewSessionUseCase(userRepositoryAdapters.NewUserRepository(db), userRepositoryAdapters.NewSessionRepository(db), nil, nil, nil, time.Hour)
+			user := factory.CreateUser(t, db, tt.user)
+
+			if _, _, err := uc.Login(context.Background(), user.Email, "password123", "test", "127.0.0.1"); err != tt.wantErr {
+				t.Errorf("Login() error = %v, want %v", err, tt.wantErr)
+			}
+			if err := uc.CheckAccount(context.Background(), user.ID); err != tt.wantErr {
+				t.Errorf("CheckAccount() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
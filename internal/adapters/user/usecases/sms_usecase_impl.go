@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/sms"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userEvents "clean-arch-gin/internal/domain/user/events"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// smsUseCase implements the SMSUseCase interface
+type smsUseCase struct {
+	userRepo    userRepositories.UserRepository
+	consentRepo userRepositories.SMSConsentRepository
+	publisher   events.EventPublisher
+}
+
+// NewSMSUseCase creates a new SMS use case publishing the changes on publisher, which may
+// be nil
+func NewSMSUseCase(userRepo userRepositories.UserRepository, consentRepo userRepositories.SMSConsentRepository, publisher events.EventPublisher) userUsecases.SMSUseCase {
+	return &smsUseCase{
+		userRepo:    userRepo,
+		consentRepo: consentRepo,
+		publisher:   publisher,
+	}
+}
+
+// SetPhone saves the user's new phone; setting the same one again changes nothing
+func (uc *smsUseCase) SetPhone(ctx context.Context, userID uint, phone string) (*userEntities.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	previous := user.Phone
+	if err := user.SetPhone(phone); err != nil {
+		return nil, err
+	}
+	if user.Phone == previous {
+		return user, nil
+	}
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	publishProfileUpdated(ctx, uc.publisher, user, []string{"phone"})
+	return user, nil
+}
+
+// RecordReplies changes the consent of the replies' phones across tenants; the webhook the
+// replies came from names no tenant, and the same phone may sign up with several
+func (uc *smsUseCase) RecordReplies(ctx context.Context, replies []sms.Reply) error {
+	ctx = tenancy.WithoutTenant(ctx)
+	for _, reply := range replies {
+		phone, err := userEntities.NormalizePhone(reply.From)
+		if err != nil {
+			continue
+		}
+		switch reply.Keyword {
+		case sms.KeywordOptOut:
+			err = uc.setOptedOut(ctx, phone, true)
+		case sms.KeywordOptIn:
+			err = uc.setOptedOut(ctx, phone, false)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OptOut opts phone out across tenants, like a STOP reply from it
+func (uc *smsUseCase) OptOut(ctx context.Context, phone string) error {
+	phone, err := userEntities.NormalizePhone(phone)
+	if err != nil {
+		return err
+	}
+	return uc.setOptedOut(tenancy.WithoutTenant(ctx), phone, true)
+}
+
+// setOptedOut records the consent of phone and publishes it for each user it changed
+func (uc *smsUseCase) setOptedOut(ctx context.Context, phone string, optedOut bool) error {
+	now := time.Now()
+	var at *time.Time
+	if optedOut {
+		at = &now
+	}
+	ids, err := uc.consentRepo.SetOptedOut(ctx, phone, at)
+	if err != nil {
+		return err
+	}
+	if uc.publisher == nil {
+		return nil
+	}
+	for _, id := range ids {
+		event := userEvents.NewUserSMSConsentChangedEvent(id, optedOut, now)
+		if err := uc.publisher.Publish(ctx, event); err != nil {
+			log.Printf("failed to publish %s for user %d: %v", event.EventName(), id, err)
+		}
+	}
+	return nil
+}
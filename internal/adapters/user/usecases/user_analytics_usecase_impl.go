@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// userAnalyticsUseCase implements the UserAnalyticsUseCase interface
+type userAnalyticsUseCase struct {
+	analyticsRepo userRepositories.UserAnalyticsRepository
+}
+
+// NewUserAnalyticsUseCase creates a new user analytics use case
+func NewUserAnalyticsUseCase(analyticsRepo userRepositories.UserAnalyticsRepository) userUsecases.UserAnalyticsUseCase {
+	return &userAnalyticsUseCase{
+		analyticsRepo: analyticsRepo,
+	}
+}
+
+// GetStats counts the users and fills the days without signups or deletions with zeros
+func (uc *userAnalyticsUseCase) GetStats(ctx context.Context, r userEntities.AnalyticsRange) (*userEntities.UserStats, error) {
+	totals, err := uc.analyticsRepo.Totals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signups, err := uc.analyticsRepo.Signups(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[string]userEntities.DailySignups, len(signups))
+	for _, day := range signups {
+		byDay[day.Date] = day
+	}
+	days := r.Days()
+	stats := &userEntities.UserStats{Totals: *totals, Range: r, Days: make([]userEntities.DailySignups, len(days))}
+	for i, date := range days {
+		stats.Days[i] = userEntities.DailySignups{Date: date}
+		if day, ok := byDay[date]; ok {
+			stats.Days[i] = day
+		}
+	}
+	return stats, nil
+}
+
+// GetActivity counts the sign-ins and fills the days without any with zeros
+func (uc *userAnalyticsUseCase) GetActivity(ctx context.Context, r userEntities.AnalyticsRange) ([]userEntities.DailyActivity, error) {
+	activity, err := uc.analyticsRepo.Activity(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[string]userEntities.DailyActivity, len(activity))
+	for _, day := range activity {
+		byDay[day.Date] = day
+	}
+	days := r.Days()
+	filled := make([]userEntities.DailyActivity, len(days))
+	for i, date := range days {
+		filled[i] = userEntities.DailyActivity{Date: date}
+		if day, ok := byDay[date]; ok {
+			filled[i] = day
+		}
+	}
+	return filled, nil
+}
+
+// GetRetention validates weeks and builds the cohorts as of now
+func (uc *userAnalyticsUseCase) GetRetention(ctx context.Context, r userEntities.AnalyticsRange, weeks int) ([]userEntities.RetentionCohort, error) {
+	if weeks == 0 {
+		weeks = userEntities.DefaultRetentionWeeks
+	}
+	if weeks < 1 || weeks > userEntities.MaxRetentionWeeks {
+		return nil, userEntities.ErrInvalidRetentionWeeks
+	}
+	return uc.analyticsRepo.Retention(ctx, r, weeks, time.Now().UTC())
+}
@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+)
+
+// userSearchUseCase implements the UserSearchUseCase interface
+type userSearchUseCase struct {
+	searchRepo userRepositories.UserSearchRepository
+}
+
+// NewUserSearchUseCase creates a new user search use case
+func NewUserSearchUseCase(searchRepo userRepositories.UserSearchRepository) userUsecases.UserSearchUseCase {
+	return &userSearchUseCase{
+		searchRepo: searchRepo,
+	}
+}
+
+// SearchUsers splits the query into terms and retrieves a page of the users matching them
+func (uc *userSearchUseCase) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*userEntities.UserSearchHit, int64, []string, error) {
+	terms, err := userEntities.SearchTerms(query)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	hits, total, err := uc.searchRepo.Search(ctx, terms, limit, offset)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return hits, total, terms, nil
+}
@@ -0,0 +1,87 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	userRepositoryAdapters "clean-arch-gin/internal/adapters/user/repositories"
+	"clean-arch-gin/internal/adapters/user/usecases"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+	"clean-arch-gin/internal/testutil/repotest"
+)
+
+// benchRepositories are the stores every user use case benchmark runs against
+var benchRepositories = []struct {
+	name string
+	new  func(b *testing.B) userRepositories.UserRepository
+}{
+	{"memory", func(b *testing.B) userRepositories.UserRepository {
+		return userRepositoryAdapters.NewUserRepositoryMemory()
+	}},
+	{"sqlite", func(b *testing.B) userRepositories.UserRepository {
+		return userRepositoryAdapters.NewUserRepository(repotest.OpenSQLite(b))
+	}},
+}
+
+// seedUsers creates n users through the use case and returns their IDs
+func seedUsers(b *testing.B, uc userUsecases.UserUseCase, n int) []uint {
+	b.Helper()
+	ids := make([]uint, n)
+	for i := range ids {
+		user, err := uc.CreateUser(context.Background(), fmt.Sprintf("seed%d@example.com", i), "Seed", "password123")
+		if err != nil {
+			b.Fatalf("failed to seed user: %v", err)
+		}
+		ids[i] = user.ID
+	}
+	return ids
+}
+
+func BenchmarkCreateUser(b *testing.B) {
+	for _, repo := range benchRepositories {
+		b.Run(repo.name, func(b *testing.B) {
+			uc := usecases.NewUserUseCase(repo.new(b), nil)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := uc.CreateUser(context.Background(), fmt.Sprintf("bench%d@example.com", i), "Bench", "password123"); err != nil {
+					b.Fatalf("CreateUser returned error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetUser(b *testing.B) {
+	for _, repo := range benchRepositories {
+		b.Run(repo.name, func(b *testing.B) {
+			uc := usecases.NewUserUseCase(repo.new(b), nil)
+			ids := seedUsers(b, uc, 100)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := uc.GetUser(context.Background(), ids[i%len(ids)]); err != nil {
+					b.Fatalf("GetUser returned error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetUsers(b *testing.B) {
+	for _, repo := range benchRepositories {
+		b.Run(repo.name, func(b *testing.B) {
+			uc := usecases.NewUserUseCase(repo.new(b), nil)
+			seedUsers(b, uc, 100)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := uc.GetUsers(context.Background(), 20, (i*20)%100); err != nil {
+					b.Fatalf("GetUsers returned error: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -1,7 +1,12 @@
 package usecases
 
 import (
+	"context"
+	"log"
+
+	"clean-arch-gin/internal/domain/shared/events"
 	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userEvents "clean-arch-gin/internal/domain/user/events"
 	userRepositories "clean-arch-gin/internal/domain/user/repositories"
 	userUsecases "clean-arch-gin/internal/domain/user/usecases"
 )
@@ -9,28 +14,28 @@ import (
 // userUseCase implements the UserUseCase interface
 type userUseCase struct {
 	userRepo userRepositories.UserRepository
+	// publisher is nil when nothing listens for user events
+	publisher events.EventPublisher
 }
 
-// NewUserUseCase creates a new user use case
-func NewUserUseCase(userRepo userRepositories.UserRepository) userUsecases.UserUseCase {
+// NewUserUseCase creates a new user use case publishing sign-ups and profile changes on publisher,
+// which may be nil
+func NewUserUseCase(userRepo userRepositories.UserRepository, publisher events.EventPublisher) userUsecases.UserUseCase {
 	return &userUseCase{
-		userRepo: userRepo,
+		userRepo:  userRepo,
+		publisher: publisher,
 	}
 }
 
 // CreateUser creates a new user
-func (uc *userUseCase) CreateUser(email, name, password string) (*userEntities.User, error) {
+func (uc *userUseCase) CreateUser(ctx context.Context, email, name, password string) (*userEntities.User, error) {
 	// Business logic validation
 	if email == "" || name == "" || password == "" {
 		return nil, userEntities.ErrInvalidEmail
 	}
 
 	// Check if user already exists
-	_, err := uc.userRepo.GetByEmail(email)
-	if err == nil {
-		return nil, userEntities.ErrEmailExists
-	}
-	if err != userEntities.ErrUserNotFound {
+	if err := userRepositories.CheckEmailAvailable(ctx, uc.userRepo, nil, email); err != nil {
 		return nil, err
 	}
 
@@ -41,40 +46,74 @@ func (uc *userUseCase) CreateUser(email, name, password string) (*userEntities.U
 	}
 
 	// Persist user
-	if err := uc.userRepo.Create(user); err != nil {
+	if err := uc.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
+	if uc.publisher != nil {
+		if err := uc.publisher.Publish(ctx, userEvents.NewUserRegisteredEvent(user)); err != nil {
+			log.Printf("failed to publish %s for user %d: %v", userEvents.UserRegisteredEventName, user.ID, err)
+		}
+	}
 	return user, nil
 }
 
 // GetUser retrieves a user by ID
-func (uc *userUseCase) GetUser(id uint) (*userEntities.User, error) {
-	return uc.userRepo.GetByID(id)
+func (uc *userUseCase) GetUser(ctx context.Context, id uint) (*userEntities.User, error) {
+	return uc.userRepo.GetByID(ctx, id)
+}
+
+// GetUserByPublicID retrieves a user by public ID
+func (uc *userUseCase) GetUserByPublicID(ctx context.Context, publicID string) (*userEntities.User, error) {
+	return uc.userRepo.GetByPublicID(ctx, publicID)
 }
 
 // GetUsers retrieves all users with pagination
-func (uc *userUseCase) GetUsers(limit, offset int) ([]*userEntities.User, error) {
-	return uc.userRepo.GetAll(limit, offset)
+func (uc *userUseCase) GetUsers(ctx context.Context, limit, offset int) ([]*userEntities.User, error) {
+	return uc.userRepo.GetAll(ctx, limit, offset)
 }
 
-// UpdateUser updates user information
-func (uc *userUseCase) UpdateUser(id uint, email, name string) (*userEntities.User, error) {
-	user, err := uc.userRepo.GetByID(id)
+// UpdateUser updates user information; an email taken by another user is rejected
+func (uc *userUseCase) UpdateUser(ctx context.Context, id uint, email, name string) (*userEntities.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := userRepositories.CheckEmailAvailable(ctx, uc.userRepo, user, email); err != nil {
+		return nil, err
+	}
 
+	oldEmail, oldName := user.Email, user.Name
 	user.UpdateInfo(name, email)
 
-	if err := uc.userRepo.Update(user); err != nil {
+	if err := uc.userRepo.Update(ctx, user); err != nil {
 		return nil, err
 	}
 
+	var fields []string
+	if user.Email != oldEmail {
+		fields = append(fields, "email")
+	}
+	if user.Name != oldName {
+		fields = append(fields, "name")
+	}
+	publishProfileUpdated(ctx, uc.publisher, user, fields)
+
 	return user, nil
 }
 
 // DeleteUser soft deletes a user
-func (uc *userUseCase) DeleteUser(id uint) error {
-	return uc.userRepo.Delete(id)
+func (uc *userUseCase) DeleteUser(ctx context.Context, id uint) error {
+	return uc.userRepo.Delete(ctx, id)
+}
+
+// publishProfileUpdated publishes the change of fields, if any, once it is saved
+// The change is committed; a failed publish must not fail the request
+func publishProfileUpdated(ctx context.Context, publisher events.EventPublisher, user *userEntities.User, fields []string) {
+	if publisher == nil || len(fields) == 0 {
+		return
+	}
+	if err := publisher.Publish(ctx, userEvents.NewUserProfileUpdatedEvent(user, fields)); err != nil {
+		log.Printf("failed to publish %s for user %d: %v", userEvents.UserProfileUpdatedEventName, user.ID, err)
+	}
 }
@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"context"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+	"clean-arch-gin/internal/infrastructure/interceptor"
+)
+
+// userUseCaseIntercepted runs the calls of a UserUseCase through an interceptor, e.g.
+// logging, metrics and a transaction; each call is the operation UserUseCase.<method>
+type userUseCaseIntercepted struct {
+	useCase     userUsecases.UserUseCase
+	interceptor interceptor.Interceptor
+}
+
+// NewUserUseCaseWithInterceptor wraps useCase so calls go through ic, or returns useCase when
+// ic is nil
+func NewUserUseCaseWithInterceptor(useCase userUsecases.UserUseCase, ic interceptor.Interceptor) userUsecases.UserUseCase {
+	if ic == nil {
+		return useCase
+	}
+	return &userUseCaseIntercepted{useCase: useCase, interceptor: ic}
+}
+
+// CreateUser creates a new user through the interceptor
+func (uc *userUseCaseIntercepted) CreateUser(ctx context.Context, email, name, password string) (user *userEntities.User, err error) {
+	err = interceptor.Run(ctx, uc.interceptor, "UserUseCase.CreateUser", func(ctx context.Context) error {
+		user, err = uc.useCase.CreateUser(ctx, email, name, password)
+		return err
+	})
+	return user, err
+}
+
+// GetUser retrieves a user by ID through the interceptor
+func (uc *userUseCaseIntercepted) GetUser(ctx context.Context, id uint) (user *userEntities.User, err error) {
+	err = interceptor.Run(ctx, uc.interceptor, "UserUseCase.GetUser", func(ctx context.Context) error {
+		user, err = uc.useCase.GetUser(ctx, id)
+		return err
+	})
+	return user, err
+}
+
+// GetUserByPublicID retrieves a user by public ID through the interceptor
+func (uc *userUseCaseIntercepted) GetUserByPublicID(ctx context.Context, publicID string) (user *userEntities.User, err error) {
+	err = interceptor.Run(ctx, uc.interceptor, "UserUseCase.GetUserByPublicID", func(ctx context.Context) error {
+		user, err = uc.useCase.GetUserByPublicID(ctx, publicID)
+		return err
+	})
+	return user, err
+}
+
+// GetUsers retrieves a page of users through the interceptor
+func (uc *userUseCaseIntercepted) GetUsers(ctx context.Context, limit, offset int) (users []*userEntities.User, err error) {
+	err = interceptor.Run(ctx, uc.interceptor, "UserUseCase.GetUsers", func(ctx context.Context) error {
+		users, err = uc.useCase.GetUsers(ctx, limit, offset)
+		return err
+	})
+	return users, err
+}
+
+// UpdateUser updates a user through the interceptor
+func (uc *userUseCaseIntercepted) UpdateUser(ctx context.Context, id uint, email, name string) (user *userEntities.User, err error) {
+	err = interceptor.Run(ctx, uc.interceptor, "UserUseCase.UpdateUser", func(ctx context.Context) error {
+		user, err = uc.useCase.UpdateUser(ctx, id, email, name)
+		return err
+	})
+	return user, err
+}
+
+// DeleteUser deletes a user through the interceptor
+func (uc *userUseCaseIntercepted) DeleteUser(ctx context.Context, id uint) error {
+	return interceptor.Run(ctx, uc.interceptor, "UserUseCase.DeleteUser", func(ctx context.Context) error {
+		return uc.useCase.DeleteUser(ctx, id)
+	})
+}
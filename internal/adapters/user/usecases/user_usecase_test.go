@@ -0,0 +1,122 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	userRepositoryAdapters "clean-arch-gin/internal/adapters/user/repositories"
+	"clean-arch-gin/internal/adapters/user/usecases"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userUsecases "clean-arch-gin/internal/domain/user/usecases"
+	"clean-arch-gin/internal/testutil/repotest"
+)
+
+// userRepositoryImplementations are the implementations the email conflict rules are checked against
+var userRepositoryImplementations = []struct {
+	name string
+	new  func(t *testing.T) userRepositories.UserRepository
+}{
+	{"gorm", func(t *testing.T) userRepositories.UserRepository {
+		return userRepositoryAdapters.NewUserRepository(repotest.OpenSQLite(t))
+	}},
+	{"gorm_gen", func(t *testing.T) userRepositories.UserRepository {
+		return userRepositoryAdapters.NewUserRepositoryGen(repotest.OpenSQLite(t))
+	}},
+	{"memory", func(t *testing.T) userRepositories.UserRepository {
+		return userRepositoryAdapters.NewUserRepositoryMemory()
+	}},
+}
+
+// staleEmailLookup finds no user by email, like an availability check that ran just before a
+// concurrent request took the email, so only the repository's unique index can catch it
+type staleEmailLookup struct {
+	userRepositories.UserRepository
+}
+
+// GetByEmail reports every email as free
+func (staleEmailLookup) GetByEmail(context.Context, string) (*userEntities.User, error) {
+	return nil, userEntities.ErrUserNotFound
+}
+
+// mustCreateUser creates a user through the use case and fails the test on error
+func mustCreateUser(t *testing.T, uc userUsecases.UserUseCase, email, name string) *userEntities.User {
+	t.Helper()
+	user, err := uc.CreateUser(context.Background(), email, name, "password123")
+	if err != nil {
+		t.Fatalf("CreateUser(%s) returned error: %v", email, err)
+	}
+	return user
+}
+
+func TestCreateUserRejectsTakenEmail(t *testing.T) {
+	for _, impl := range userRepositoryImplementations {
+		t.Run(impl.name, func(t *testing.T) {
+			uc := usecases.NewUserUseCase(impl.new(t), nil)
+			mustCreateUser(t, uc, "alice@example.com", "Alice")
+
+			if _, err := uc.CreateUser(context.Background(), "alice@example.com", "Another Alice", "password123"); err != userEntities.ErrEmailExists {
+				t.Errorf("CreateUser(taken email) error = %v, want %v", err, userEntities.ErrEmailExists)
+			}
+		})
+	}
+}
+
+func TestUpdateUserEmailAvailability(t *testing.T) {
+	tests := []struct {
+		name    string
+		email   string
+		wantErr error
+	}{
+		{"another user's email", "bob@example.com", userEntities.ErrEmailExists},
+		{"own unchanged email", "alice@example.com", nil},
+		{"no email", "", nil},
+		{"free email", "alice.smith@example.com", nil},
+	}
+
+	for _, impl := range userRepositoryImplementations {
+		t.Run(impl.name, func(t *testing.T) {
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					uc := usecases.NewUserUseCase(impl.new(t), nil)
+					alice := mustCreateUser(t, uc, "alice@example.com", "Alice")
+					mustCreateUser(t, uc, "bob@example.com", "Bob")
+
+					updated, err := uc.UpdateUser(context.Background(), alice.ID, tt.email, "Alice Smith")
+					if err != tt.wantErr {
+						t.Fatalf("UpdateUser(%q) error = %v, want %v", tt.email, err, tt.wantErr)
+					}
+					if err != nil {
+						return
+					}
+					wantEmail := tt.email
+					if wantEmail == "" {
+						wantEmail = "alice@example.com"
+					}
+					if updated.Email != wantEmail || updated.Name != "Alice Smith" {
+						t.Errorf("UpdateUser(%q) = %s %q, want %s %q", tt.email, updated.Email, updated.Name, wantEmail, "Alice Smith")
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestDuplicateEmailRaceIsErrEmailExists(t *testing.T) {
+	for _, impl := range userRepositoryImplementations {
+		t.Run(impl.name, func(t *testing.T) {
+			repo := impl.new(t)
+			uc := usecases.NewUserUseCase(repo, nil)
+			alice := mustCreateUser(t, uc, "alice@example.com", "Alice")
+			mustCreateUser(t, uc, "bob@example.com", "Bob")
+
+			racing := usecases.NewUserUseCase(staleEmailLookup{repo}, nil)
+			if _, err := racing.CreateUser(context.Background(), "bob@example.com", "Another Bob", "password123"); err != userEntities.ErrEmailExists {
+				t.Errorf("CreateUser(taken email) error = %v, want %v", err, userEntities.ErrEmailExists)
+			}
+			if _, err := racing.UpdateUser(context.Background(), alice.ID, "bob@example.com", "Alice"); err != userEntities.ErrEmailExists {
+				t.Errorf("UpdateUser(taken email) error = %v, want %v", err, userEntities.ErrEmailExists)
+			}
+		})
+	}
+}
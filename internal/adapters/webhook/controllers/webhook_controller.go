@@ -0,0 +1,275 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	webhookEntities "clean-arch-gin/internal/domain/webhook/entities"
+	webhookUsecases "clean-arch-gin/internal/domain/webhook/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterEndpointRequest represents the request for registering a webhook endpoint
+type RegisterEndpointRequest struct {
+	URL string `json:"url" binding:"required,url,max=2048"`
+	// Secret signs deliveries; one is generated when empty
+	Secret string `json:"secret" binding:"max=255"`
+	// EventTypes filters the delivered events; empty delivers every event
+	EventTypes []string `json:"event_types" binding:"max=32,dive,max=128"`
+	// RetrySchedule overrides the default waits between retries, e.g. ["30s", "5m"]
+	RetrySchedule []string `json:"retry_schedule" binding:"max=16"`
+	// Format is "json" (default) or "cloudevents" for CloudEvents 1.0 structured JSON
+	Format string `json:"format" binding:"omitempty,oneof=json cloudevents"`
+}
+
+// EndpointDTO represents a webhook endpoint in API responses
+type EndpointDTO struct {
+	ID  uint   `json:"id"`
+	URL string `json:"url"`
+	// Secret is only returned when the endpoint is registered
+	Secret        string    `json:"secret,omitempty"`
+	EventTypes    []string  `json:"event_types"`
+	RetrySchedule []string  `json:"retry_schedule"`
+	Format        string    `json:"format"`
+	Active        bool      `json:"active"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// DeliveryDTO represents a webhook delivery in API responses
+type DeliveryDTO struct {
+	ID         uint   `json:"id"`
+	EndpointID uint   `json:"endpoint_id"`
+	EventID    string `json:"event_id"`
+	EventName  string `json:"event_name"`
+	Status     string `json:"status"`
+	Attempts   int    `json:"attempts"`
+	// NextAttemptAt is only set while the delivery is pending
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// DeliveryAttemptDTO represents one logged delivery attempt in API responses
+type DeliveryAttemptDTO struct {
+	Number      int       `json:"number"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	DurationMs  int64     `json:"duration_ms"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+// DeliveryDetailDTO represents a delivery with its payload and attempt log
+type DeliveryDetailDTO struct {
+	DeliveryDTO
+	Payload    json.RawMessage      `json:"payload"`
+	AttemptLog []DeliveryAttemptDTO `json:"attempt_log"`
+}
+
+// DeliveryListResponse is the paginated response of ListDeliveries
+type DeliveryListResponse struct {
+	Deliveries []DeliveryDTO `json:"deliveries"`
+	Limit      int           `json:"limit"`
+	Offset     int           `json:"offset"`
+	Count      int           `json:"count"`
+}
+
+// toEndpointDTO converts domain entity to DTO without its secret
+func toEndpointDTO(endpoint *webhookEntities.Endpoint) EndpointDTO {
+	eventTypes := endpoint.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	retrySchedule := make([]string, len(endpoint.RetrySchedule))
+	for i, wait := range endpoint.RetrySchedule {
+		retrySchedule[i] = wait.String()
+	}
+
+	return EndpointDTO{
+		ID:            endpoint.ID,
+		URL:           endpoint.URL,
+		EventTypes:    eventTypes,
+		RetrySchedule: retrySchedule,
+		Format:        string(endpoint.Format),
+		Active:        endpoint.Active,
+		CreatedAt:     endpoint.CreatedAt,
+	}
+}
+
+// toDeliveryDTO converts domain entity to DTO
+func toDeliveryDTO(delivery *webhookEntities.Delivery) DeliveryDTO {
+	dto := DeliveryDTO{
+		ID:         delivery.ID,
+		EndpointID: delivery.EndpointID,
+		EventID:    delivery.EventID,
+		EventName:  delivery.EventName,
+		Status:     string(delivery.Status),
+		Attempts:   delivery.Attempts,
+		LastError:  delivery.LastError,
+		CreatedAt:  delivery.CreatedAt,
+		UpdatedAt:  delivery.UpdatedAt,
+	}
+	if delivery.Status == webhookEntities.DeliveryStatusPending {
+		next := delivery.NextAttemptAt
+		dto.NextAttemptAt = &next
+	}
+	return dto
+}
+
+// WebhookController handles HTTP requests for webhook administration
+type WebhookController struct {
+	webhookUseCase webhookUsecases.WebhookUseCase
+}
+
+// NewWebhookController creates a new webhook controller
+func NewWebhookController(webhookUseCase webhookUsecases.WebhookUseCase) *WebhookController {
+	return &WebhookController{
+		webhookUseCase: webhookUseCase,
+	}
+}
+
+// RegisterEndpoint registers a new webhook endpoint and returns its signing secret once
+func (wc *WebhookController) RegisterEndpoint(c *gin.Context) {
+	var req RegisterEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	retrySchedule := make([]time.Duration, len(req.RetrySchedule))
+	for i, raw := range req.RetrySchedule {
+		wait, err := time.ParseDuration(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid retry interval: " + raw})
+			return
+		}
+		retrySchedule[i] = wait
+	}
+
+	endpoint, err := wc.webhookUseCase.RegisterEndpoint(c.Request.Context(), req.URL, req.Secret, req.EventTypes, retrySchedule, webhookEntities.PayloadFormat(req.Format))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	dto := toEndpointDTO(endpoint)
+	dto.Secret = endpoint.Secret
+	middleware.AllowSensitiveFields(c, "secret")
+	c.JSON(http.StatusCreated, dto)
+}
+
+// ListEndpoints retrieves every webhook endpoint
+func (wc *WebhookController) ListEndpoints(c *gin.Context) {
+	endpoints, err := wc.webhookUseCase.ListEndpoints(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	dtos := make([]EndpointDTO, len(endpoints))
+	for i, endpoint := range endpoints {
+		dtos[i] = toEndpointDTO(endpoint)
+	}
+	c.JSON(http.StatusOK, gin.H{"endpoints": dtos})
+}
+
+// ListDeliveries retrieves an endpoint's deliveries with pagination
+func (wc *WebhookController) ListDeliveries(c *gin.Context) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid endpoint ID"})
+		return
+	}
+
+	page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	deliveries, err := wc.webhookUseCase.ListDeliveries(c.Request.Context(), id, page.Limit, page.Offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	dtos := make([]DeliveryDTO, len(deliveries))
+	for i, delivery := range deliveries {
+		dtos[i] = toDeliveryDTO(delivery)
+	}
+	c.JSON(http.StatusOK, DeliveryListResponse{
+		Deliveries: dtos,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		Count:      len(dtos),
+	})
+}
+
+// GetDelivery retrieves a delivery with its payload and attempt log
+func (wc *WebhookController) GetDelivery(c *gin.Context) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID"})
+		return
+	}
+
+	delivery, attempts, err := wc.webhookUseCase.GetDelivery(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	attemptLog := make([]DeliveryAttemptDTO, len(attempts))
+	for i, attempt := range attempts {
+		attemptLog[i] = DeliveryAttemptDTO{
+			Number:      attempt.Number,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			DurationMs:  attempt.Duration.Milliseconds(),
+			AttemptedAt: attempt.AttemptedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, DeliveryDetailDTO{
+		DeliveryDTO: toDeliveryDTO(delivery),
+		Payload:     json.RawMessage(delivery.Payload),
+		AttemptLog:  attemptLog,
+	})
+}
+
+// Redeliver sends a succeeded or failed delivery again
+func (wc *WebhookController) Redeliver(c *gin.Context) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID"})
+		return
+	}
+
+	delivery, err := wc.webhookUseCase.Redeliver(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toDeliveryDTO(delivery))
+}
+
+// respondError maps webhook domain errors to HTTP responses
+func respondError(c *gin.Context, err error) {
+	switch err {
+	case webhookEntities.ErrEndpointNotFound, webhookEntities.ErrDeliveryNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case webhookEntities.ErrInvalidEndpointURL, webhookEntities.ErrEmptyEndpointSecret,
+		webhookEntities.ErrInvalidRetrySchedule, webhookEntities.ErrInvalidPayloadFormat:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case webhookEntities.ErrDeliveryPending:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
@@ -0,0 +1,406 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	webhookEntities "clean-arch-gin/internal/domain/webhook/entities"
+	webhookRepositories "clean-arch-gin/internal/domain/webhook/repositories"
+	"clean-arch-gin/internal/infrastructure/breaker"
+	"clean-arch-gin/internal/infrastructure/cloudevents"
+	"clean-arch-gin/internal/infrastructure/retry"
+	"clean-arch-gin/internal/infrastructure/workerpool"
+)
+
+// DefaultRetrySchedule is the wait before each retry when an endpoint has no schedule of its own
+// A delivery is attempted once plus once per entry before it is marked failed
+var DefaultRetrySchedule = []time.Duration{
+	time.Minute, 5 * time.Minute, 30 * time.Minute, 2 * time.Hour, 6 * time.Hour,
+}
+
+// Defaults applied to zero Options fields
+const (
+	defaultTimeout          = 10 * time.Second
+	defaultPollInterval     = 5 * time.Second
+	defaultBatchSize        = 50
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = time.Minute
+
+	// maxErrorLength matches the size of the error columns
+	maxErrorLength = 1024
+	// maxResponseBody is how much of a response is read before the connection is reused
+	maxResponseBody = 64 << 10
+)
+
+// Options configures a Dispatcher
+type Options struct {
+	// RetrySchedule is the default wait before each retry (DefaultRetrySchedule)
+	RetrySchedule []time.Duration
+	// Timeout bounds each HTTP request (10s)
+	Timeout time.Duration
+	// PollInterval is how often due retries are picked up when nothing wakes the dispatcher (5s)
+	PollInterval time.Duration
+	// BatchSize is how many due deliveries are loaded at once (50)
+	BatchSize int
+	// FailureThreshold is how many consecutive failures open an endpoint's circuit (5)
+	FailureThreshold int
+	// OpenTimeout is how long an open circuit waits before letting a probe through (1m)
+	OpenTimeout time.Duration
+	// StoreRetry retries writes of delivery state that fail transiently (retry.DefaultPolicy)
+	// Losing the write after a successful send would deliver the event again
+	StoreRetry retry.Policy
+	// CloudEvents sets the source and type prefix of payloads for PayloadFormatCloudEvents endpoints
+	CloudEvents cloudevents.Formatter
+	// Pool, when set, sends the deliveries of a batch concurrently on its workers, in no
+	// particular order; nil sends them one at a time on the Run loop
+	Pool *workerpool.Pool
+}
+
+// Payload is the JSON body sent to PayloadFormatJSON endpoints
+type Payload struct {
+	ID         string      `json:"id"`
+	Event      string      `json:"event"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// Dispatcher turns domain events into deliveries and sends them to subscribed endpoints
+// Deliveries are persisted before they are sent, so pending ones survive restarts
+type Dispatcher struct {
+	repo   webhookRepositories.WebhookRepository
+	client *http.Client
+	opts   Options
+
+	mu       sync.Mutex
+	breakers map[uint]*breaker.CircuitBreaker
+	wake     chan struct{}
+}
+
+// NewDispatcher creates a dispatcher; zero Options fields use the defaults
+func NewDispatcher(repo webhookRepositories.WebhookRepository, opts Options) *Dispatcher {
+	if opts.RetrySchedule == nil {
+		opts.RetrySchedule = DefaultRetrySchedule
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = defaultFailureThreshold
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = defaultOpenTimeout
+	}
+
+	return &Dispatcher{
+		repo:     repo,
+		client:   &http.Client{Timeout: opts.Timeout},
+		opts:     opts,
+		breakers: make(map[uint]*breaker.CircuitBreaker),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// HandleEvent records a delivery of event for every endpoint of the tenant of ctx subscribed to it
+// It is an events.EventHandler: sending happens on the Run loop, never on the publisher's goroutine
+// Replayed events were delivered when they happened and are skipped
+func (d *Dispatcher) HandleEvent(ctx context.Context, event events.DomainEvent) {
+	if events.IsReplay(ctx) {
+		return
+	}
+	endpoints, err := d.repo.ListEndpoints(ctx)
+	if err != nil {
+		log.Printf("webhooks: failed to list endpoints for %s: %v", event.EventName(), err)
+		return
+	}
+
+	// Every format is encoded at most once and all deliveries share the event ID
+	eventID := cloudevents.NewID()
+	payloads := make(map[webhookEntities.PayloadFormat][]byte)
+	for _, endpoint := range endpoints {
+		if !endpoint.Subscribes(event.EventName()) {
+			continue
+		}
+
+		payload, ok := payloads[endpoint.Format]
+		if !ok {
+			payload, err = d.encode(event, eventID, endpoint.Format)
+			if err != nil {
+				log.Printf("webhooks: failed to encode %s as %s: %v", event.EventName(), endpoint.Format, err)
+				continue
+			}
+			payloads[endpoint.Format] = payload
+		}
+
+		delivery := webhookEntities.NewDelivery(endpoint.ID, eventID, event.EventName(), payload)
+		if err := d.store(func() error { return d.repo.CreateDelivery(ctx, delivery) }); err != nil {
+			log.Printf("webhooks: failed to record %s delivery to endpoint %d: %v", event.EventName(), endpoint.ID, err)
+		}
+	}
+
+	if len(payloads) > 0 {
+		d.Wake()
+	}
+}
+
+// encode renders event in an endpoint's payload format
+func (d *Dispatcher) encode(event events.DomainEvent, eventID string, format webhookEntities.PayloadFormat) ([]byte, error) {
+	if format == webhookEntities.PayloadFormatCloudEvents {
+		return d.opts.CloudEvents.Marshal(event, eventID)
+	}
+	return json.Marshal(Payload{
+		ID:         eventID,
+		Event:      event.EventName(),
+		OccurredAt: event.OccurredOn(),
+		Data:       event.EventData(),
+	})
+}
+
+// Wake makes Run check for due deliveries now instead of at the next poll
+func (d *Dispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends due deliveries until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DispatchDue(ctx); err != nil {
+			log.Printf("webhooks: failed to load due deliveries: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// DispatchDue attempts every delivery that is due now and returns how many were processed
+// Deliveries of every tenant are loaded, and each is sent within its own tenant
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	processed := 0
+	for ctx.Err() == nil {
+		due, err := d.repo.ListDueDeliveries(ctx, time.Now(), d.opts.BatchSize)
+		if err != nil {
+			return processed, err
+		}
+		sent, full := d.deliverAll(ctx, due)
+		processed += sent
+		if full || len(due) < d.opts.BatchSize {
+			break
+		}
+	}
+	return processed, nil
+}
+
+// deliverAll attempts the deliveries, on the pool when there is one, and returns once every
+// attempt is over, so the next batch never loads a delivery still being sent. It returns how
+// many were attempted and whether the pool turned some away; those stay due for the next poll
+func (d *Dispatcher) deliverAll(ctx context.Context, due []*webhookEntities.Delivery) (int, bool) {
+	pool := d.opts.Pool
+	var inFlight sync.WaitGroup
+	defer inFlight.Wait()
+
+	processed := 0
+	for _, delivery := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		deliveryCtx := tenancy.NewContext(ctx, delivery.TenantID)
+		if pool == nil {
+			d.deliver(deliveryCtx, delivery)
+			processed++
+			continue
+		}
+
+		delivery := delivery
+		inFlight.Add(1)
+		err := pool.Submit(ctx, func() {
+			defer inFlight.Done()
+			// Deliveries still queued at shutdown are left for the next poll
+			if ctx.Err() == nil {
+				d.deliver(deliveryCtx, delivery)
+			}
+		})
+		if err != nil {
+			inFlight.Done()
+			if !errors.Is(err, ctx.Err()) {
+				log.Printf("webhooks: %d due deliveries left for the next poll: %v", len(due)-processed, err)
+			}
+			return processed, true
+		}
+		processed++
+	}
+	return processed, false
+}
+
+// deliver makes one attempt at a delivery and schedules what happens next
+func (d *Dispatcher) deliver(ctx context.Context, delivery *webhookEntities.Delivery) {
+	endpoint, err := d.repo.GetEndpoint(ctx, delivery.EndpointID)
+	switch {
+	case err == webhookEntities.ErrEndpointNotFound || (err == nil && !endpoint.Active):
+		delivery.MarkFailed("endpoint no longer active")
+		d.save(ctx, delivery)
+		return
+	case err != nil:
+		log.Printf("webhooks: failed to load endpoint %d: %v", delivery.EndpointID, err)
+		return
+	}
+
+	// An open circuit postpones the delivery without using up an attempt
+	done, err := d.breakerFor(endpoint.ID).Allow()
+	if err != nil {
+		retryAt := time.Now().Add(d.opts.OpenTimeout)
+		var unavailable *breaker.UnavailableError
+		if errors.As(err, &unavailable) {
+			retryAt = time.Now().Add(unavailable.RetryAfter)
+		}
+		delivery.MarkRetry("circuit open: endpoint is failing", retryAt)
+		d.save(ctx, delivery)
+		return
+	}
+
+	// A delivery in flight at shutdown runs to completion within the client timeout
+	// instead of being logged as a failed attempt
+	attempt := d.send(context.WithoutCancel(ctx), endpoint, delivery)
+	attempt.Number = delivery.RecordAttempt()
+	if err := d.store(func() error { return d.repo.CreateAttempt(ctx, attempt) }); err != nil {
+		log.Printf("webhooks: failed to log attempt %d of delivery %d: %v", attempt.Number, delivery.ID, err)
+	}
+
+	done(attempt.Succeeded())
+	if attempt.Succeeded() {
+		delivery.MarkSucceeded()
+		d.save(ctx, delivery)
+		return
+	}
+
+	reason := attempt.Error
+	if reason == "" {
+		reason = fmt.Sprintf("endpoint responded with status %d", attempt.StatusCode)
+	}
+
+	schedule := endpoint.RetrySchedule
+	if len(schedule) == 0 {
+		schedule = d.opts.RetrySchedule
+	}
+	if delivery.Attempts > len(schedule) {
+		log.Printf("webhooks: delivery %d of %s to endpoint %d failed after %d attempts: %s",
+			delivery.ID, delivery.EventName, endpoint.ID, delivery.Attempts, reason)
+		delivery.MarkFailed(reason)
+	} else {
+		delivery.MarkRetry(reason, time.Now().Add(schedule[delivery.Attempts-1]))
+	}
+	d.save(ctx, delivery)
+}
+
+// send POSTs the signed payload and reports the outcome as an attempt
+func (d *Dispatcher) send(ctx context.Context, endpoint *webhookEntities.Endpoint, delivery *webhookEntities.Delivery) *webhookEntities.DeliveryAttempt {
+	started := time.Now()
+	attempt := &webhookEntities.DeliveryAttempt{DeliveryID: delivery.ID, AttemptedAt: started}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = truncate(err.Error())
+		return attempt
+	}
+	req.Header.Set("Content-Type", contentType(endpoint.Format))
+	req.Header.Set("User-Agent", "clean-arch-gin-webhooks/1.0")
+	req.Header.Set(EventHeader, delivery.EventName)
+	req.Header.Set(EventIDHeader, delivery.EventID)
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, started, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	attempt.Duration = time.Since(started)
+	if err != nil {
+		attempt.Error = truncate(err.Error())
+		return attempt
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+	resp.Body.Close()
+
+	attempt.StatusCode = resp.StatusCode
+	return attempt
+}
+
+// save persists a delivery, logging failures; the delivery is retried from its stored state
+func (d *Dispatcher) save(ctx context.Context, delivery *webhookEntities.Delivery) {
+	if err := d.store(func() error { return d.repo.UpdateDelivery(ctx, delivery) }); err != nil {
+		log.Printf("webhooks: failed to save delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// store runs a repository write, retrying failures other than domain errors
+func (d *Dispatcher) store(write func() error) error {
+	policy := d.opts.StoreRetry
+	policy.Retryable = isTransientStoreError
+	return retry.Do(context.Background(), policy, func(context.Context) error {
+		return write()
+	})
+}
+
+// isTransientStoreError reports whether a failed write may succeed when repeated
+// Domain errors and an open database circuit will not change within a retry
+func isTransientStoreError(err error) bool {
+	var domainErr sharedEntities.DomainError
+	return !errors.As(err, &domainErr) && !breaker.IsUnavailable(err)
+}
+
+// breakerFor returns the circuit breaker of an endpoint, creating it on first use
+func (d *Dispatcher) breakerFor(endpointID uint) *breaker.CircuitBreaker {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	b, ok := d.breakers[endpointID]
+	if !ok {
+		b = breaker.New(breaker.Settings{
+			Name:        "webhook-endpoint-" + strconv.FormatUint(uint64(endpointID), 10),
+			Timeout:     d.opts.OpenTimeout,
+			ReadyToTrip: breaker.ConsecutiveFailures(uint32(d.opts.FailureThreshold)),
+		})
+		d.breakers[endpointID] = b
+	}
+	return b
+}
+
+// contentType returns the media type of a payload format
+func contentType(format webhookEntities.PayloadFormat) string {
+	if format == webhookEntities.PayloadFormatCloudEvents {
+		return cloudevents.ContentType
+	}
+	return "application/json"
+}
+
+// truncate shortens an error message to fit its column
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength]
+	}
+	return s
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	webhookEntities "clean-arch-gin/internal/domain/webhook/entities"
+	webhookRepositories "clean-arch-gin/internal/domain/webhook/repositories"
+
+	"gorm.io/gorm"
+)
+
+// webhookRepository implements WebhookRepository interface using GORM
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *gorm.DB) webhookRepositories.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// CreateEndpoint creates a webhook endpoint
+func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *webhookEntities.Endpoint) error {
+	endpointModel := models.NewWebhookEndpointModelFromEntity(endpoint)
+	if err := r.db.WithContext(ctx).Create(endpointModel).Error; err != nil {
+		return err
+	}
+	endpoint.ID = endpointModel.ID
+	return nil
+}
+
+// GetEndpoint retrieves a webhook endpoint by ID
+func (r *webhookRepository) GetEndpoint(ctx context.Context, id uint) (*webhookEntities.Endpoint, error) {
+	var endpointModel models.WebhookEndpointModel
+	if err := r.db.WithContext(ctx).First(&endpointModel, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, webhookEntities.ErrEndpointNotFound
+		}
+		return nil, err
+	}
+	return endpointModel.ToDomainEntity(), nil
+}
+
+// ListEndpoints retrieves every webhook endpoint ordered by ID
+func (r *webhookRepository) ListEndpoints(ctx context.Context) ([]*webhookEntities.Endpoint, error) {
+	var endpointModels []models.WebhookEndpointModel
+	if err := r.db.WithContext(ctx).Order("id").Find(&endpointModels).Error; err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]*webhookEntities.Endpoint, len(endpointModels))
+	for i := range endpointModels {
+		endpoints[i] = endpointModels[i].ToDomainEntity()
+	}
+	return endpoints, nil
+}
+
+// CreateDelivery creates a webhook delivery
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *webhookEntities.Delivery) error {
+	deliveryModel := models.NewWebhookDeliveryModelFromEntity(delivery)
+	if err := r.db.WithContext(ctx).Create(deliveryModel).Error; err != nil {
+		return err
+	}
+	delivery.ID = deliveryModel.ID
+	delivery.TenantID = deliveryModel.TenantID
+	return nil
+}
+
+// GetDelivery retrieves a webhook delivery by ID
+func (r *webhookRepository) GetDelivery(ctx context.Context, id uint) (*webhookEntities.Delivery, error) {
+	var deliveryModel models.WebhookDeliveryModel
+	if err := r.db.WithContext(ctx).First(&deliveryModel, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, webhookEntities.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return deliveryModel.ToDomainEntity(), nil
+}
+
+// ListDeliveries retrieves an endpoint's deliveries, newest first, with pagination
+func (r *webhookRepository) ListDeliveries(ctx context.Context, endpointID uint, limit, offset int) ([]*webhookEntities.Delivery, error) {
+	var deliveryModels []models.WebhookDeliveryModel
+	err := r.db.WithContext(ctx).Where("endpoint_id = ?", endpointID).
+		Order("id DESC").
+		Limit(limit).Offset(offset).
+		Find(&deliveryModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return toDeliveryEntities(deliveryModels), nil
+}
+
+// ListDueDeliveries retrieves pending deliveries due at now, oldest first
+func (r *webhookRepository) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*webhookEntities.Delivery, error) {
+	var deliveryModels []models.WebhookDeliveryModel
+	err := r.db.WithContext(ctx).Where("status = ? AND next_attempt_at <= ?", string(webhookEntities.DeliveryStatusPending), now).
+		Order("next_attempt_at, id").
+		Limit(limit).
+		Find(&deliveryModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return toDeliveryEntities(deliveryModels), nil
+}
+
+// UpdateDelivery saves a webhook delivery
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *webhookEntities.Delivery) error {
+	return r.db.WithContext(ctx).Save(models.NewWebhookDeliveryModelFromEntity(delivery)).Error
+}
+
+// CreateAttempt appends an entry to the delivery attempt log
+func (r *webhookRepository) CreateAttempt(ctx context.Context, attempt *webhookEntities.DeliveryAttempt) error {
+	attemptModel := models.NewWebhookDeliveryAttemptModelFromEntity(attempt)
+	if err := r.db.WithContext(ctx).Create(attemptModel).Error; err != nil {
+		return err
+	}
+	attempt.ID = attemptModel.ID
+	return nil
+}
+
+// ListAttempts retrieves a delivery's attempts, oldest first
+func (r *webhookRepository) ListAttempts(ctx context.Context, deliveryID uint) ([]*webhookEntities.DeliveryAttempt, error) {
+	var attemptModels []models.WebhookDeliveryAttemptModel
+	if err := r.db.WithContext(ctx).Where("delivery_id = ?", deliveryID).Order("attempted_at, id").Find(&attemptModels).Error; err != nil {
+		return nil, err
+	}
+
+	attempts := make([]*webhookEntities.DeliveryAttempt, len(attemptModels))
+	for i := range attemptModels {
+		attempts[i] = attemptModels[i].ToDomainEntity()
+	}
+	return attempts, nil
+}
+
+// toDeliveryEntities converts GORM models to domain entities
+func toDeliveryEntities(deliveryModels []models.WebhookDeliveryModel) []*webhookEntities.Delivery {
+	deliveries := make([]*webhookEntities.Delivery, len(deliveryModels))
+	for i := range deliveryModels {
+		deliveries[i] = deliveryModels[i].ToDomainEntity()
+	}
+	return deliveries
+}
@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"clean-arch-gin/internal/adapters/webhook/delivery"
+	webhookEntities "clean-arch-gin/internal/domain/webhook/entities"
+	webhookRepositories "clean-arch-gin/internal/domain/webhook/repositories"
+	webhookUsecases "clean-arch-gin/internal/domain/webhook/usecases"
+)
+
+// secretPrefix marks generated signing secrets so they are recognisable in config and logs
+const secretPrefix = "whsec_"
+
+// webhookUseCase implements the WebhookUseCase interface
+type webhookUseCase struct {
+	webhookRepo webhookRepositories.WebhookRepository
+	dispatcher  *delivery.Dispatcher
+}
+
+// NewWebhookUseCase creates a new webhook use case
+func NewWebhookUseCase(webhookRepo webhookRepositories.WebhookRepository, dispatcher *delivery.Dispatcher) webhookUsecases.WebhookUseCase {
+	return &webhookUseCase{
+		webhookRepo: webhookRepo,
+		dispatcher:  dispatcher,
+	}
+}
+
+// RegisterEndpoint validates and stores a new endpoint
+func (uc *webhookUseCase) RegisterEndpoint(ctx context.Context, url, secret string, eventTypes []string, retrySchedule []time.Duration, format webhookEntities.PayloadFormat) (*webhookEntities.Endpoint, error) {
+	if secret == "" {
+		secret = generateSecret()
+	}
+
+	endpoint, err := webhookEntities.NewEndpoint(url, secret, eventTypes, retrySchedule, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.webhookRepo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// ListEndpoints retrieves every endpoint
+func (uc *webhookUseCase) ListEndpoints(ctx context.Context) ([]*webhookEntities.Endpoint, error) {
+	return uc.webhookRepo.ListEndpoints(ctx)
+}
+
+// ListDeliveries retrieves an endpoint's deliveries, newest first
+func (uc *webhookUseCase) ListDeliveries(ctx context.Context, endpointID uint, limit, offset int) ([]*webhookEntities.Delivery, error) {
+	if _, err := uc.webhookRepo.GetEndpoint(ctx, endpointID); err != nil {
+		return nil, err
+	}
+	return uc.webhookRepo.ListDeliveries(ctx, endpointID, limit, offset)
+}
+
+// GetDelivery retrieves a delivery with its attempt log
+func (uc *webhookUseCase) GetDelivery(ctx context.Context, id uint) (*webhookEntities.Delivery, []*webhookEntities.DeliveryAttempt, error) {
+	d, err := uc.webhookRepo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	attempts, err := uc.webhookRepo.ListAttempts(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return d, attempts, nil
+}
+
+// Redeliver makes a finished delivery pending again and wakes the dispatcher
+func (uc *webhookUseCase) Redeliver(ctx context.Context, id uint) (*webhookEntities.Delivery, error) {
+	d, err := uc.webhookRepo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Redeliver(); err != nil {
+		return nil, err
+	}
+	if err := uc.webhookRepo.UpdateDelivery(ctx, d); err != nil {
+		return nil, err
+	}
+
+	uc.dispatcher.Wake()
+	return d, nil
+}
+
+// generateSecret returns a random signing secret
+func generateSecret() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return secretPrefix + hex.EncodeToString(b)
+}
@@ -0,0 +1,1281 @@
+// Package app composes the modular application so the CLI and test servers share one setup
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"strings"
+	"time"
+
+	apikeyRepositories "clean-arch-gin/internal/adapters/apikey/repositories"
+	"clean-arch-gin/internal/adapters/graph"
+	"clean-arch-gin/internal/adapters/metering/meter"
+	"clean-arch-gin/internal/adapters/middleware"
+	orderRepositories "clean-arch-gin/internal/adapters/order/repositories"
+	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	tenantRepositories "clean-arch-gin/internal/adapters/tenant/repositories"
+	tenantUsecases "clean-arch-gin/internal/adapters/tenant/usecases"
+	userRepositories "clean-arch-gin/internal/adapters/user/repositories"
+	userUsecases "clean-arch-gin/internal/adapters/user/usecases"
+	"clean-arch-gin/internal/adapters/webhook/delivery"
+	apikeyEntities "clean-arch-gin/internal/domain/apikey/entities"
+	apikeyDomainRepositories "clean-arch-gin/internal/domain/apikey/repositories"
+	orderEvents "clean-arch-gin/internal/domain/order/events"
+	"clean-arch-gin/internal/domain/shared/captcha"
+	"clean-arch-gin/internal/domain/shared/documents"
+	"clean-arch-gin/internal/domain/shared/locks"
+	"clean-arch-gin/internal/domain/shared/mail"
+	"clean-arch-gin/internal/domain/shared/metering"
+	"clean-arch-gin/internal/domain/shared/money"
+	"clean-arch-gin/internal/domain/shared/payments"
+	"clean-arch-gin/internal/domain/shared/pricing"
+	"clean-arch-gin/internal/domain/shared/push"
+	"clean-arch-gin/internal/domain/shared/search"
+	"clean-arch-gin/internal/domain/shared/sms"
+	sharedStorage "clean-arch-gin/internal/domain/shared/storage"
+	"clean-arch-gin/internal/domain/shared/tokens"
+	userDomainRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/authz"
+	"clean-arch-gin/internal/infrastructure/breaker"
+	"clean-arch-gin/internal/infrastructure/cache"
+	captchaVerifiers "clean-arch-gin/internal/infrastructure/captcha"
+	"clean-arch-gin/internal/infrastructure/cloudevents"
+	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/eventbus"
+	"clean-arch-gin/internal/infrastructure/exchangerates"
+	"clean-arch-gin/internal/infrastructure/fieldcrypt"
+	"clean-arch-gin/internal/infrastructure/gateway"
+	"clean-arch-gin/internal/infrastructure/grpcserver"
+	"clean-arch-gin/internal/infrastructure/health"
+	"clean-arch-gin/internal/infrastructure/i18n"
+	"clean-arch-gin/internal/infrastructure/interceptor"
+	"clean-arch-gin/internal/infrastructure/jwt"
+	"clean-arch-gin/internal/infrastructure/kafka"
+	"clean-arch-gin/internal/infrastructure/leader"
+	lockManagers "clean-arch-gin/internal/infrastructure/locks"
+	mailers "clean-arch-gin/internal/infrastructure/mail"
+	"clean-arch-gin/internal/infrastructure/metrics"
+	"clean-arch-gin/internal/infrastructure/outbox"
+	paymentGateways "clean-arch-gin/internal/infrastructure/payments"
+	"clean-arch-gin/internal/infrastructure/pdf"
+	pricingStrategies "clean-arch-gin/internal/infrastructure/pricing"
+	pushSenders "clean-arch-gin/internal/infrastructure/push"
+	"clean-arch-gin/internal/infrastructure/querycache"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+	searchEngines "clean-arch-gin/internal/infrastructure/search"
+	"clean-arch-gin/internal/infrastructure/sigv4"
+	smsSenders "clean-arch-gin/internal/infrastructure/sms"
+	"clean-arch-gin/internal/infrastructure/storage"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+	templateEngines "clean-arch-gin/internal/infrastructure/templates"
+	"clean-arch-gin/internal/infrastructure/throttle"
+	"clean-arch-gin/internal/infrastructure/urlsign"
+	"clean-arch-gin/internal/infrastructure/workerpool"
+	"clean-arch-gin/internal/modules"
+	apikeyModule "clean-arch-gin/internal/modules/apikey"
+	authzModule "clean-arch-gin/internal/modules/authz"
+	eventsModule "clean-arch-gin/internal/modules/events"
+	keysModule "clean-arch-gin/internal/modules/keys"
+	mailModule "clean-arch-gin/internal/modules/mail"
+	meteringModule "clean-arch-gin/internal/modules/metering"
+	orderModule "clean-arch-gin/internal/modules/order"
+	reportModule "clean-arch-gin/internal/modules/report"
+	tenantModule "clean-arch-gin/internal/modules/tenant"
+	userModule "clean-arch-gin/internal/modules/user"
+	webhookModule "clean-arch-gin/internal/modules/webhook"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	// apiPrefix is the path prefix of the versioned module routes
+	apiPrefix = "/api/v1"
+	// GraphQLPath serves the GraphQL endpoint
+	GraphQLPath = "/graphql"
+	// DatabaseCheck names the connection pool health check
+	DatabaseCheck = "database"
+	// RedisCheck names the health check of the shared Redis client
+	RedisCheck = "redis"
+	// JobsPath lists the scheduled jobs on the admin listener; JobsPath/:id reports the
+	// status of a job started by a long operation on either listener
+	JobsPath = apiPrefix + "/jobs"
+	// SchedulerLeaderKey names the lease held by the replica running scheduled jobs
+	SchedulerLeaderKey = "scheduler"
+	// MigrationsLockKey names the lock held by the replica migrating the schema
+	MigrationsLockKey = "migrations"
+	// migrationsLockTTL is how long a replica that died migrating holds up the others
+	migrationsLockTTL = 30 * time.Second
+	// PprofPrefix serves the runtime profiles on the admin listener
+	PprofPrefix = "/debug/pprof"
+	// JWKSPath publishes the public keys verifying the session tokens
+	JWKSPath = "/.well-known/jwks.json"
+)
+
+// NewModuleRegistry creates the registry with every feature module registered
+// Modules publish and subscribe to domain events through the shared bus, their
+// repositories share one database circuit breaker, uploads go to the configured storage
+// and sessions to the configured session store, their tokens are signed by keys when it
+// is not nil, sign-in attempts are throttled and API key requests counted on the configured
+// backends, registration is guarded by the configured CAPTCHA, profile reads are served from
+// queryCache when it is not nil, the user use case and repository are decorated by
+// decorators, permissions are decided by the policy stored in the database, and requests,
+// orders and stored bytes are metered for billing when metering is enabled, and invoices are
+// numbered under lockManager when it is not nil. The tenant
+// module comes first so every request is scoped to its tenant before any other module sees
+// it, and the report module last so it generates the reports every other module contributes,
+// followed, with the outbox on, by the events module replaying the recorded events into the
+// projections they contribute
+func NewModuleRegistry(cfg *config.Config, db *gorm.DB, bus *eventbus.Bus, keys *jwt.KeySet, queryCache *querycache.Cache,
+	decorators interceptor.Stack, redisClient redis.UniversalClient, lockManager locks.LockManager) (*modules.ModuleRegistry, error) {
+	registry := modules.NewModuleRegistry()
+	dbBreaker := database.NewCircuitBreaker(cfg.Breaker.Database)
+	// Every domain event goes to the outbox as well, to be relayed to Kafka
+	if cfg.Outbox.Enabled {
+		outbox.Subscribe(bus, outbox.NewWriter(db, CloudEventsFormatter(cfg), cfg.Outbox.Topic))
+	}
+	sessions, err := NewSessionRepository(cfg, db, dbBreaker, redisClient)
+	if err != nil {
+		return nil, err
+	}
+	enforcer, err := authz.NewEnforcer(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register feature modules
+	registry.Register(tenantModule.NewTenantModule(db, cfg.Tenancy.BaseDomain, dbBreaker))
+	registry.Register(authzModule.NewAuthzModule(enforcer, cfg.Authz.ReloadInterval))
+	var signer tokens.Signer
+	if keys != nil {
+		registry.Register(keysModule.NewKeysModule(keys, cfg.JWT.ReloadInterval))
+		signer = keys
+	}
+	throttle, err := NewLoginThrottle(cfg, redisClient)
+	if err != nil {
+		return nil, err
+	}
+	captchaVerifier, err := NewCaptchaVerifier(cfg)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := NewMailer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// Mail goes through the task queue; the module sends it with the provider's mailer
+	var mailer mail.Mailer
+	if sender != nil {
+		outbound := mailModule.NewMailModule(db, sender, cfg.Mail.MaxAttempts)
+		registry.Register(outbound)
+		mailer = outbound.Mailer()
+	}
+	catalog, err := i18n.NewCatalog()
+	if err != nil {
+		return nil, err
+	}
+	accountMail, err := NewAccountMail(cfg, mailer, catalog)
+	if err != nil {
+		return nil, err
+	}
+	notificationTemplates, err := templateEngines.NewEngine(catalog)
+	if err != nil {
+		return nil, err
+	}
+	textMessages, err := NewTextMessages(cfg)
+	if err != nil {
+		return nil, err
+	}
+	pushSender, err := NewPushSender(cfg)
+	if err != nil {
+		return nil, err
+	}
+	searchEngine, err := NewSearchEngine(cfg)
+	if err != nil {
+		return nil, err
+	}
+	files, err := NewFileStorage(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var uploads sharedStorage.Storage = NewUploadStorage(cfg)
+	// Metering counts the bytes written to storage, and registers after the modules
+	// resolving the tenant and API key of requests
+	var usageMetering *meteringModule.MeteringModule
+	if cfg.Metering.Enabled {
+		usageMetering = meteringModule.NewMeteringModule(db, bus, cfg.Metering.FlushInterval, map[string]string{
+			orderEvents.OrderPlacedEventName: metering.MetricOrdersCreated,
+		})
+		uploads = meter.Storage(uploads, usageMetering.Meter())
+		files = meter.Storage(files, usageMetering.Meter())
+	}
+	notificationPool, err := NewWorkerPool("notifications", cfg.Pools.Notifications)
+	if err != nil {
+		return nil, err
+	}
+	exportPool, err := NewWorkerPool("exports", cfg.Pools.Exports)
+	if err != nil {
+		return nil, err
+	}
+	registry.Register(userModule.NewUserModule(db, bus, uploads, files,
+		sessions, signer, throttle, captchaVerifier, accountMail, notificationTemplates, textMessages, pushSender, searchEngine,
+		cfg.Sessions.TTL, enforcer, dbBreaker, queryCache, decorators, userModule.Pools{
+			Notifications: notificationPool,
+			Exports:       exportPool,
+		}))
+	apiKeyCounter, err := NewAPIKeyCounter(cfg, redisClient)
+	if err != nil {
+		return nil, err
+	}
+	if apiKeyCounter != nil {
+		registry.Register(apikeyModule.NewAPIKeyModule(db, apiKeyCounter, apikeyEntities.Limits{
+			RatePerMinute: cfg.APIKeys.RateLimit,
+			MonthlyQuota:  int64(cfg.APIKeys.MonthlyQuota),
+		}))
+	}
+	if usageMetering != nil {
+		registry.Register(usageMetering)
+	}
+	refunds, err := NewPaymentGateway(cfg)
+	if err != nil {
+		return nil, err
+	}
+	pdfGenerator, err := NewPDFGenerator(cfg)
+	if err != nil {
+		return nil, err
+	}
+	tax, err := NewTaxCalculator(cfg)
+	if err != nil {
+		return nil, err
+	}
+	shipping, err := NewShippingStrategy(cfg)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := NewExchangeRateProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	lifecycleEvents, err := OrderLifecycleEvents(cfg)
+	if err != nil {
+		return nil, err
+	}
+	registry.Register(orderModule.NewOrderModule(db, bus, refunds, pdfGenerator, orderModule.Pricing{
+		Tax:        tax,
+		Shipping:   shipping,
+		Currencies: NewBaseCurrencies(db, dbBreaker),
+		Rates:      rates,
+	}, lifecycleEvents, cfg.Orders.ReservationTTL, searchEngine, dbBreaker, lockManager))
+	webhookPool, err := NewWorkerPool("webhooks", cfg.Pools.Webhooks)
+	if err != nil {
+		return nil, err
+	}
+	registry.Register(webhookModule.NewWebhookModule(db, bus, delivery.Options{
+		FailureThreshold: cfg.Breaker.Webhook.Failures,
+		OpenTimeout:      cfg.Breaker.Webhook.OpenTimeout,
+		CloudEvents:      CloudEventsFormatter(cfg),
+		Pool:             webhookPool,
+	}))
+	registry.Register(reportModule.NewReportModule(registry.ReportGenerators(), files, pdfGenerator, bus))
+	if cfg.Outbox.Enabled {
+		registry.Register(eventsModule.NewEventsModule(db, bus, registry.Projections()))
+	}
+	// registry.Register(productModule.NewProductModule(db))
+	// registry.Register(paymentModule.NewPaymentModule(db))
+	// registry.Register(inventoryModule.NewInventoryModule(db))
+
+	return registry, nil
+}
+
+// NewRedisClient creates the Redis client shared by every backend configured to use Redis;
+// it returns nil when none is, so Redis is neither required nor health checked. The cleanup
+// closes the client
+func NewRedisClient(cfg *config.Config) (redis.UniversalClient, func(), error) {
+	backends := []string{cfg.Sessions.Backend, cfg.LoginThrottle.Backend, cfg.APIKeys.Backend, cfg.QueryCache.Backend, cfg.Leader.Backend,
+		cfg.Locks.Backend}
+	used := false
+	for _, backend := range backends {
+		used = used || backend == "redis"
+	}
+	if !used {
+		return nil, func() {}, nil
+	}
+
+	settings := cfg.Redis
+	client, err := cache.NewClient(cache.Options{
+		Mode:         settings.Mode,
+		Addrs:        settings.Addrs,
+		MasterName:   settings.MasterName,
+		Username:     settings.Username,
+		Password:     settings.Password,
+		DB:           settings.DB,
+		PoolSize:     settings.PoolSize,
+		MinIdleConns: settings.MinIdleConns,
+		DialTimeout:  settings.DialTimeout,
+		ReadTimeout:  settings.ReadTimeout,
+		WriteTimeout: settings.WriteTimeout,
+		TLS:          settings.TLS,
+		Instrument:   settings.Instrument,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, func() {
+		if err := client.Close(); err != nil {
+			log.Printf("Failed to close Redis client: %v", err)
+		}
+	}, nil
+}
+
+// errNoRedis reports a backend configured to use Redis without the shared client
+func errNoRedis(backend string) error {
+	return fmt.Errorf("the redis %s backend needs the Redis client (see NewRedisClient)", backend)
+}
+
+// NewSessionRepository creates the store of login sessions on the configured backend,
+// database calls going through dbBreaker; it returns nil when sessions are off
+func NewSessionRepository(cfg *config.Config, db *gorm.DB, dbBreaker *breaker.CircuitBreaker, redisClient redis.UniversalClient) (userDomainRepositories.SessionRepository, error) {
+	switch cfg.Sessions.Backend {
+	case "none":
+		return nil, nil
+	case "redis":
+		if redisClient == nil {
+			return nil, errNoRedis("session")
+		}
+		return userRepositories.NewSessionRepositoryRedis(redisClient, "clean-arch-gin:sessions:"), nil
+	case "", "database":
+		return userRepositories.NewSessionRepositoryWithBreaker(userRepositories.NewSessionRepository(db), dbBreaker), nil
+	default:
+		return nil, fmt.Errorf("unsupported session backend: %s", cfg.Sessions.Backend)
+	}
+}
+
+// NewLoginThrottle creates the throttle of sign-in attempts on the configured backend; it
+// returns nil when throttling is off
+func NewLoginThrottle(cfg *config.Config, redisClient redis.UniversalClient) (*middleware.LoginThrottle, error) {
+	settings := cfg.LoginThrottle
+	ipOpts := throttle.Options{Limit: settings.IPLimit, Window: settings.Window}
+	accountOpts := throttle.Options{
+		Limit:        settings.AccountLimit,
+		Window:       settings.Window,
+		FreeAttempts: settings.AccountFreeAttempts,
+		BaseDelay:    settings.AccountDelay,
+	}
+
+	switch settings.Backend {
+	case "none":
+		return nil, nil
+	case "redis":
+		if redisClient == nil {
+			return nil, errNoRedis("login throttle")
+		}
+		return middleware.NewLoginThrottle(
+			throttle.NewRedisLimiter(redisClient, "clean-arch-gin:throttle:", ipOpts),
+			throttle.NewRedisLimiter(redisClient, "clean-arch-gin:throttle:", accountOpts),
+		), nil
+	case "", "memory":
+		return middleware.NewLoginThrottle(throttle.NewMemoryLimiter(ipOpts), throttle.NewMemoryLimiter(accountOpts)), nil
+	default:
+		return nil, fmt.Errorf("unsupported login throttle backend: %s", settings.Backend)
+	}
+}
+
+// NewAPIKeyCounter creates the counter of API key requests on the configured backend; it
+// returns nil when API keys are off
+func NewAPIKeyCounter(cfg *config.Config, redisClient redis.UniversalClient) (apikeyDomainRepositories.UsageCounter, error) {
+	switch cfg.APIKeys.Backend {
+	case "none":
+		return nil, nil
+	case "", "memory":
+		return apikeyRepositories.NewUsageCounterMemory(), nil
+	case "redis":
+		if redisClient == nil {
+			return nil, errNoRedis("API key counter")
+		}
+		return apikeyRepositories.NewUsageCounterRedis(redisClient, "clean-arch-gin:apikeys:"), nil
+	default:
+		return nil, fmt.Errorf("unsupported API key counter backend: %s", cfg.APIKeys.Backend)
+	}
+}
+
+// NewQueryCache creates the read-through cache of repository reads on the configured
+// backend; it returns nil when caching is off
+func NewQueryCache(cfg *config.Config, redisClient redis.UniversalClient) (*querycache.Cache, error) {
+	switch cfg.QueryCache.Backend {
+	case "", "none":
+		return nil, nil
+	case "memory":
+		return querycache.New(querycache.NewMemoryStore(), cfg.QueryCache.TTL), nil
+	case "redis":
+		if redisClient == nil {
+			return nil, errNoRedis("query cache")
+		}
+		return querycache.New(querycache.NewRedisStore(redisClient, "clean-arch-gin:cache:"), cfg.QueryCache.TTL), nil
+	default:
+		return nil, fmt.Errorf("unsupported query cache backend: %s", cfg.QueryCache.Backend)
+	}
+}
+
+// NewDecorators stacks the configured decorators: use case calls go through logging, metrics
+// and a transaction on db, repository calls through logging and metrics
+func NewDecorators(cfg *config.Config, db *gorm.DB, logger *log.Logger) interceptor.Stack {
+	var logging, measuring, transactions interceptor.Interceptor
+	if cfg.Decorators.Logging {
+		logging = interceptor.Logging(logger)
+	}
+	if cfg.Decorators.Metrics {
+		measuring = interceptor.Metrics()
+	}
+	if cfg.Decorators.Transactions {
+		transactions = database.Transactional(db)
+	}
+	return interceptor.Stack{
+		UseCases:     interceptor.Chain(logging, measuring, transactions),
+		Repositories: interceptor.Chain(logging, measuring),
+	}
+}
+
+// NewCaptchaVerifier creates the verifier of the configured CAPTCHA provider; it returns
+// nil when no challenge is required
+func NewCaptchaVerifier(cfg *config.Config) (captcha.Verifier, error) {
+	switch cfg.Captcha.Provider {
+	case "", "none":
+		return nil, nil
+	case "recaptcha":
+		return captchaVerifiers.NewReCAPTCHA(cfg.Captcha.Secret, cfg.Captcha.MinScore), nil
+	case "hcaptcha":
+		return captchaVerifiers.NewHCaptcha(cfg.Captcha.Secret, cfg.Captcha.MinScore), nil
+	default:
+		return nil, fmt.Errorf("unsupported captcha provider: %s", cfg.Captcha.Provider)
+	}
+}
+
+// NewMailer creates the mailer of the configured provider; it returns nil when mail is off
+func NewMailer(cfg *config.Config) (mail.Mailer, error) {
+	switch cfg.Mail.Provider {
+	case "", "none":
+		return nil, nil
+	case "log":
+		return mailers.NewLog(), nil
+	case "smtp":
+		return mailers.NewSMTP(mailers.SMTPConfig{
+			Host:     cfg.Mail.SMTP.Host,
+			Port:     cfg.Mail.SMTP.Port,
+			Username: cfg.Mail.SMTP.Username,
+			Password: cfg.Mail.SMTP.Password,
+			TLS:      cfg.Mail.SMTP.TLS,
+			PoolSize: cfg.Mail.SMTP.PoolSize,
+			Timeout:  cfg.Mail.SMTP.Timeout,
+		}, cfg.Mail.From)
+	case "sendgrid":
+		return mailers.NewSendGrid(cfg.Mail.SendGrid.APIKey, cfg.Mail.From)
+	case "ses":
+		return mailers.NewSES(mailers.SESConfig{
+			Region: cfg.Mail.SES.Region,
+			Credentials: mailers.AWSCredentials{
+				AccessKeyID:     cfg.Mail.SES.AccessKeyID,
+				SecretAccessKey: cfg.Mail.SES.SecretAccessKey,
+				SessionToken:    cfg.Mail.SES.SessionToken,
+			},
+			ConfigurationSet: cfg.Mail.SES.ConfigurationSet,
+		}, cfg.Mail.From)
+	default:
+		return nil, fmt.Errorf("unsupported mail provider: %s", cfg.Mail.Provider)
+	}
+}
+
+// NewMailFeedback creates the sources of the configured bounce and complaint webhooks, by
+// provider name; they are independent of the provider sending, e.g. SES relaying SMTP
+func NewMailFeedback(cfg *config.Config) (map[string]mail.FeedbackSource, error) {
+	sources := make(map[string]mail.FeedbackSource)
+	if key := cfg.Mail.SendGrid.WebhookVerificationKey; key != "" {
+		source, err := mailers.NewSendGridEvents(key)
+		if err != nil {
+			return nil, err
+		}
+		sources["sendgrid"] = source
+	}
+	if len(cfg.Mail.SES.TopicARNs) > 0 {
+		source, err := mailers.NewSESFeedback(cfg.Mail.SES.TopicARNs)
+		if err != nil {
+			return nil, err
+		}
+		sources["ses"] = source
+	}
+	return sources, nil
+}
+
+// NewAccountMail configures the user module's password reset and email verification flows
+// with mailer and the embedded templates, translated with catalog, and its bounce and
+// complaint webhooks; without a mailer the flows are off
+func NewAccountMail(cfg *config.Config, mailer mail.Mailer, catalog *i18n.Catalog) (userModule.AccountMail, error) {
+	feedback, err := NewMailFeedback(cfg)
+	if err != nil {
+		return userModule.AccountMail{}, err
+	}
+	accountMail := userModule.AccountMail{Feedback: feedback}
+	if mailer == nil {
+		return accountMail, nil
+	}
+	templates, err := mailers.NewTemplates(catalog)
+	if err != nil {
+		return userModule.AccountMail{}, err
+	}
+	accountMail.Mailer = mailer
+	accountMail.Templates = templates
+	accountMail.Links = userUsecases.AccountLinks{
+		BaseURL:          cfg.Mail.LinkURL,
+		PasswordResetTTL: cfg.Mail.PasswordResetTTL,
+		VerificationTTL:  cfg.Mail.VerificationTTL,
+	}
+	return accountMail, nil
+}
+
+// NewTextMessages configures the user module's texted notifications with the configured
+// provider, whose replies opt phones out and back in; without one nothing is texted
+func NewTextMessages(cfg *config.Config) (userModule.TextMessages, error) {
+	switch cfg.SMS.Provider {
+	case "", "none":
+		return userModule.TextMessages{}, nil
+	case "log":
+		return userModule.TextMessages{Sender: smsSenders.NewLog()}, nil
+	case "twilio":
+		twilio, err := smsSenders.NewTwilio(smsSenders.TwilioConfig{
+			AccountSID: cfg.SMS.Twilio.AccountSID,
+			AuthToken:  cfg.SMS.Twilio.AuthToken,
+			From:       cfg.SMS.Twilio.From,
+			WebhookURL: cfg.SMS.Twilio.WebhookURL,
+		})
+		if err != nil {
+			return userModule.TextMessages{}, err
+		}
+		return userModule.TextMessages{
+			Sender:  twilio,
+			Replies: map[string]sms.ReplySource{"twilio": twilio},
+		}, nil
+	default:
+		return userModule.TextMessages{}, fmt.Errorf("unsupported sms provider: %s", cfg.SMS.Provider)
+	}
+}
+
+// NewPushSender creates the sender of the configured push provider; it returns nil when push
+// notifications are off
+func NewPushSender(cfg *config.Config) (push.Sender, error) {
+	switch cfg.Push.Provider {
+	case "", "none":
+		return nil, nil
+	case "log":
+		return pushSenders.NewLog(), nil
+	case "fcm":
+		credentials, err := os.ReadFile(cfg.Push.FCM.CredentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read FCM credentials: %w", err)
+		}
+		return pushSenders.NewFCM(credentials, cfg.Push.FCM.ProjectID)
+	default:
+		return nil, fmt.Errorf("unsupported push provider: %s", cfg.Push.Provider)
+	}
+}
+
+// NewSearchClient creates the client of the configured search engine; it returns nil when
+// search is off
+func NewSearchClient(cfg *config.Config) (*searchEngines.Client, error) {
+	switch cfg.Search.Provider {
+	case "", "none":
+		return nil, nil
+	case "elasticsearch", "opensearch":
+		return searchEngines.New(searchEngines.Config{
+			URL:         cfg.Search.URL,
+			Username:    cfg.Search.Username,
+			Password:    cfg.Search.Password,
+			IndexPrefix: cfg.Search.IndexPrefix,
+			Timeout:     cfg.Search.Timeout,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported search provider: %s", cfg.Search.Provider)
+	}
+}
+
+// NewSearchEngine creates the configured search engine behind its circuit breaker; it
+// returns nil when search is off, leaving searches to the database
+func NewSearchEngine(cfg *config.Config) (search.Engine, error) {
+	client, err := NewSearchClient(cfg)
+	if err != nil || client == nil {
+		return nil, err
+	}
+	return searchEngines.NewEngineWithBreaker(client, searchEngines.NewCircuitBreaker(cfg.Breaker.Search)), nil
+}
+
+// EnsureSearchIndexes creates the search indexes of the modules and adds new fields to their
+// mappings; an unreachable engine is logged, searches falling back to the database
+func EnsureSearchIndexes(ctx context.Context, cfg *config.Config, registry *modules.ModuleRegistry) error {
+	client, err := NewSearchClient(cfg)
+	if err != nil || client == nil {
+		return err
+	}
+	err = client.EnsureIndexes(ctx, registry.SearchIndexes())
+	if errors.Is(err, search.ErrUnavailable) {
+		log.Printf("⚠️ Search engine unavailable, searching the database until it is back: %v", err)
+		return nil
+	}
+	return err
+}
+
+// NewPaymentGateway creates the gateway refunds are issued through
+func NewPaymentGateway(cfg *config.Config) (payments.Gateway, error) {
+	switch cfg.Payments.Gateway {
+	case "", "manual":
+		return paymentGateways.NewManual(), nil
+	default:
+		return nil, fmt.Errorf("unsupported payment gateway: %s", cfg.Payments.Gateway)
+	}
+}
+
+// NewTaxCalculator creates the calculator orders are taxed with
+func NewTaxCalculator(cfg *config.Config) (pricing.TaxCalculator, error) {
+	switch cfg.Pricing.TaxCalculator {
+	case "", "flat":
+		return pricingStrategies.NewFlatRate(cfg.Pricing.TaxRate), nil
+	case "region":
+		rates, err := pricingStrategies.ParseRates(cfg.Pricing.TaxRegionRates)
+		if err != nil {
+			return nil, err
+		}
+		return pricingStrategies.NewRegionTable(rates, cfg.Pricing.TaxRate), nil
+	case "api":
+		if cfg.Pricing.TaxAPIURL == "" {
+			return nil, fmt.Errorf("TAX_API_URL is required by the api tax calculator")
+		}
+		return pricingStrategies.NewAPI(cfg.Pricing.TaxAPIURL, cfg.Pricing.TaxAPIKey), nil
+	default:
+		return nil, fmt.Errorf("unsupported tax calculator: %s", cfg.Pricing.TaxCalculator)
+	}
+}
+
+// NewShippingStrategy creates the strategy pricing the shipping of orders
+func NewShippingStrategy(cfg *config.Config) (pricing.ShippingStrategy, error) {
+	var strategy pricing.ShippingStrategy
+	switch cfg.Pricing.Shipping {
+	case "", "flat":
+		strategy = pricingStrategies.NewFlatShipping(cfg.Pricing.ShippingRate)
+	case "per_item":
+		strategy = pricingStrategies.NewPerItemShipping(cfg.Pricing.ShippingRate, cfg.Pricing.ShippingPerItem)
+	default:
+		return nil, fmt.Errorf("unsupported shipping strategy: %s", cfg.Pricing.Shipping)
+	}
+	if cfg.Pricing.FreeShippingOver > 0 {
+		strategy = pricingStrategies.NewFreeShippingOver(cfg.Pricing.FreeShippingOver, strategy)
+	}
+	return strategy, nil
+}
+
+// NewBaseCurrencies looks up the base currency of each tenant, which orders are priced in
+func NewBaseCurrencies(db *gorm.DB, dbBreaker *breaker.CircuitBreaker) money.BaseCurrencies {
+	tenantRepo := tenantRepositories.NewTenantRepository(db)
+	if dbBreaker != nil {
+		tenantRepo = tenantRepositories.NewTenantRepositoryWithBreaker(tenantRepo, dbBreaker)
+	}
+	return tenantUsecases.NewTenantUseCase(tenantRepo)
+}
+
+// NewExchangeRateProvider creates the provider amounts are converted for display with
+func NewExchangeRateProvider(cfg *config.Config) (money.ExchangeRateProvider, error) {
+	switch cfg.Currency.ExchangeRates {
+	case "", "none":
+		return exchangerates.None{}, nil
+	case "ecb":
+		return exchangerates.NewCached(exchangerates.NewECB(exchangerates.ECBURL), cfg.Currency.RatesTTL), nil
+	case "openexchangerates":
+		if cfg.Currency.OpenExchangeRatesAppID == "" {
+			return nil, fmt.Errorf("OPENEXCHANGERATES_APP_ID is required by the openexchangerates provider")
+		}
+		source := exchangerates.NewOpenExchangeRates(exchangerates.OpenExchangeRatesURL, cfg.Currency.OpenExchangeRatesAppID)
+		return exchangerates.NewCached(source, cfg.Currency.RatesTTL), nil
+	default:
+		return nil, fmt.Errorf("unsupported exchange rate provider: %s", cfg.Currency.ExchangeRates)
+	}
+}
+
+// OrderLifecycleEvents parses the order lifecycle events published for webhooks
+func OrderLifecycleEvents(cfg *config.Config) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(cfg.Orders.WebhookEvents, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		known := false
+		for _, lifecycleEvent := range orderEvents.LifecycleEventNames {
+			known = known || lifecycleEvent == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unsupported order webhook event: %s", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// NewPDFGenerator creates the generator invoices are rendered with
+func NewPDFGenerator(cfg *config.Config) (documents.PDFGenerator, error) {
+	switch cfg.Documents.PDFGenerator {
+	case "", "builtin":
+		return pdf.NewGenerator(), nil
+	default:
+		return nil, fmt.Errorf("unsupported PDF generator: %s", cfg.Documents.PDFGenerator)
+	}
+}
+
+// NewKeySet creates the keys signing the session tokens as JWTs with the configured
+// algorithm; they are loaded when the keys module migrates
+func NewKeySet(cfg *config.Config, db *gorm.DB) (*jwt.KeySet, error) {
+	return jwt.NewKeySet(db, jwt.Options{
+		Algorithm:        cfg.JWT.Algorithm,
+		Issuer:           cfg.JWT.Issuer,
+		RotationInterval: cfg.JWT.RotationInterval,
+		TokenTTL:         cfg.Sessions.TTL,
+		ReloadInterval:   cfg.JWT.ReloadInterval,
+	})
+}
+
+// MountJWKS publishes the public keys of keys at JWKSPath for services verifying the
+// session tokens; HS256 keys are never published
+func MountJWKS(r *gin.Engine, keys *jwt.KeySet) {
+	r.GET(JWKSPath, func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.Data(http.StatusOK, "application/json", keys.JWKS())
+	})
+}
+
+// CloudEventsFormatter builds the formatter used wherever domain events leave the process
+func CloudEventsFormatter(cfg *config.Config) cloudevents.Formatter {
+	return cloudevents.Formatter{
+		Source:     cfg.Events.Source,
+		TypePrefix: cfg.Events.TypePrefix,
+	}
+}
+
+// NewHealthChecker checks the database connection, Redis when redisClient is not nil and every
+// module implementing modules.HealthChecker
+func NewHealthChecker(cfg *config.Config, db *gorm.DB, registry *modules.ModuleRegistry, redisClient redis.UniversalClient) *health.Checker {
+	checker := health.NewChecker(cfg.Health.Timeout)
+	checker.Register(DatabaseCheck, health.DatabaseCheck(db))
+	if redisClient != nil {
+		checker.Register(RedisCheck, cache.HealthCheck(redisClient))
+	}
+	registry.RegisterHealthChecks(checker)
+	return checker
+}
+
+// Setup initializes all modules and runs their migrations
+// With lockManager, replicas starting together migrate one after the other rather than
+// racing on the same tables
+func Setup(db *gorm.DB, registry *modules.ModuleRegistry, lockManager locks.LockManager) error {
+	// Initialize all modules
+	if err := registry.InitializeAll(); err != nil {
+		return err
+	}
+
+	return locks.WithLock(context.Background(), lockManager, MigrationsLockKey, migrationsLockTTL, func(ctx context.Context) error {
+		// Run database migrations for all modules
+		if err := registry.MigrateAll(db.WithContext(ctx)); err != nil {
+			return err
+		}
+
+		// Migrate shared models (used across multiple domains), the scheduler's job runs, the
+		// task queue and the outbox
+		return database.AutoMigrate(db.WithContext(ctx), &models.UserModel{}, &scheduler.JobRunModel{}, &scheduler.JobRunHistoryModel{}, &leader.LeaseModel{},
+			&taskqueue.TaskModel{}, &taskqueue.TaskAttemptModel{}, &outbox.MessageModel{})
+	})
+}
+
+// NewKeyring creates the keyring encrypting personal data columns from the configured keys
+// Without keys nothing is encrypted
+func NewKeyring(cfg *config.Config) (*fieldcrypt.Keyring, error) {
+	keys, err := fieldcrypt.ParseKeys(cfg.Encryption.Keys)
+	if err != nil {
+		return nil, err
+	}
+	indexKey, err := base64.StdEncoding.DecodeString(cfg.Encryption.IndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PII_INDEX_KEY: %w", err)
+	}
+	return fieldcrypt.NewKeyring(keys, cfg.Encryption.ActiveKey, indexKey)
+}
+
+// NewLeaderElector creates the elector of the replica running scheduled jobs on the
+// configured backend; it returns nil when leader election is off
+func NewLeaderElector(cfg *config.Config, db *gorm.DB, redisClient redis.UniversalClient) (*leader.Elector, error) {
+	var lock leader.Lock
+	switch cfg.Leader.Backend {
+	case "", "none":
+		return nil, nil
+	case "redis":
+		if redisClient == nil {
+			return nil, errNoRedis("leader election")
+		}
+		lock = leader.NewRedisLock(redisClient, "clean-arch-gin:leader:")
+	case "database":
+		lock = leader.NewDatabaseLock(db)
+	default:
+		return nil, fmt.Errorf("unsupported leader election backend: %s", cfg.Leader.Backend)
+	}
+
+	return leader.NewElector(lock, leader.Options{
+		Key: SchedulerLeaderKey,
+		ID:  cfg.Leader.ID,
+		TTL: cfg.Leader.LeaseTTL,
+	}), nil
+}
+
+// NewLockManager creates the locks shared by the replicas on the configured backend; it
+// returns nil when locking is off
+func NewLockManager(cfg *config.Config, db *gorm.DB, redisClient redis.UniversalClient) (locks.LockManager, error) {
+	switch cfg.Locks.Backend {
+	case "", "none":
+		return nil, nil
+	case "redis":
+		if redisClient == nil {
+			return nil, errNoRedis("locks")
+		}
+		return lockManagers.NewRedisLockManager(redisClient, "clean-arch-gin:locks:"), nil
+	case "postgres":
+		if cfg.DB.Driver != "postgres" {
+			return nil, fmt.Errorf("postgres locks need a postgres database, not %s", cfg.DB.Driver)
+		}
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		return lockManagers.NewPostgresLockManager(sqlDB), nil
+	default:
+		return nil, fmt.Errorf("unsupported lock backend: %s", cfg.Locks.Backend)
+	}
+}
+
+// NewTaskQueue creates the durable task queue with the handlers of every module
+// implementing modules.TaskProcessor
+func NewTaskQueue(cfg *config.Config, db *gorm.DB, registry *modules.ModuleRegistry) (*taskqueue.Queue, error) {
+	workerID := cfg.Leader.ID
+	if workerID == "" {
+		workerID = leader.DefaultID()
+	}
+	q := taskqueue.New(db, taskqueue.Options{
+		Workers:      cfg.Tasks.Workers,
+		PollInterval: cfg.Tasks.PollInterval,
+		LockTimeout:  cfg.Tasks.LockTimeout,
+		MaxAttempts:  cfg.Tasks.MaxAttempts,
+		WorkerID:     workerID,
+	})
+	if err := registry.RegisterTaskHandlers(q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// NewKafkaConsumer creates the consumer group running the topic handlers of every module
+// implementing modules.TopicConsumer; it returns nil when no Kafka brokers are configured
+func NewKafkaConsumer(cfg *config.Config, registry *modules.ModuleRegistry) (*kafka.Consumer, error) {
+	if len(cfg.Kafka.Brokers) == 0 {
+		return nil, nil
+	}
+	consumer, err := kafka.New(kafka.Options{
+		Brokers:        cfg.Kafka.Brokers,
+		GroupID:        cfg.Kafka.GroupID,
+		ClientID:       kafkaClientID(cfg),
+		Version:        cfg.Kafka.Version,
+		Commit:         cfg.Kafka.Commit,
+		CommitInterval: cfg.Kafka.CommitInterval,
+		Oldest:         cfg.Kafka.Oldest,
+		RetryDelays:    cfg.Kafka.RetryDelays,
+		HandlerTimeout: cfg.Kafka.HandlerTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := registry.RegisterTopicHandlers(consumer); err != nil {
+		return nil, err
+	}
+	return consumer, nil
+}
+
+// NewWorkerPool creates the worker pool named name from settings; it returns nil when its
+// size is 0
+func NewWorkerPool(name string, settings config.PoolSettings) (*workerpool.Pool, error) {
+	if settings.Size <= 0 {
+		return nil, nil
+	}
+	policy, err := workerpool.ParsePolicy(settings.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("%s pool: %w", name, err)
+	}
+	return workerpool.New(name, workerpool.Options{
+		Size:       settings.Size,
+		QueueDepth: settings.QueueDepth,
+		Policy:     policy,
+	}), nil
+}
+
+// NewOutboxRelay creates the relay publishing the outbox to OUTBOX_TOPIC on the Kafka brokers;
+// it returns nil when the outbox is off. With lockManager replicas relay one at a time
+func NewOutboxRelay(cfg *config.Config, db *gorm.DB, lockManager locks.LockManager) (*outbox.Relay, error) {
+	if !cfg.Outbox.Enabled {
+		return nil, nil
+	}
+	if len(cfg.Kafka.Brokers) == 0 {
+		return nil, errors.New("the outbox needs KAFKA_BROKERS to publish to")
+	}
+	sink := outbox.NewKafkaSink(kafka.Options{
+		Brokers:  cfg.Kafka.Brokers,
+		ClientID: kafkaClientID(cfg),
+		Version:  cfg.Kafka.Version,
+	})
+	return outbox.New(db, sink, outbox.Options{
+		BatchSize:    cfg.Outbox.BatchSize,
+		PollInterval: cfg.Outbox.PollInterval,
+		MaxAttempts:  cfg.Outbox.MaxAttempts,
+		Locks:        lockManager,
+	}), nil
+}
+
+// kafkaClientID identifies this replica to the Kafka brokers: KAFKA_CLIENT_ID, else the
+// replica's leader election ID
+func kafkaClientID(cfg *config.Config) string {
+	if cfg.Kafka.ClientID != "" {
+		return cfg.Kafka.ClientID
+	}
+	if cfg.Leader.ID != "" {
+		return cfg.Leader.ID
+	}
+	return leader.DefaultID()
+}
+
+// NewScheduler creates the scheduler with the jobs of every module implementing modules.Scheduled,
+// plus purging the task queue's succeeded tasks, the job run history and, with the outbox on,
+// its published messages
+// Every run is persisted so the admin listing and run history survive restarts and are the
+// same on every replica. With an elector only its leader runs the jobs, and with lockManager a
+// job never runs on two replicas at once
+func NewScheduler(cfg *config.Config, db *gorm.DB, registry *modules.ModuleRegistry, elector *leader.Elector,
+	queue *taskqueue.Queue, lockManager locks.LockManager) (*scheduler.Scheduler, error) {
+	opts := scheduler.Options{DefaultTimeout: cfg.Scheduler.JobTimeout, Locks: lockManager}
+	if elector != nil {
+		opts.Leader = elector
+	}
+	store := scheduler.NewGormStore(db)
+	s := scheduler.New(store, opts)
+	if err := registry.RegisterScheduledJobs(s); err != nil {
+		return nil, err
+	}
+
+	err := s.Register(scheduler.Job{
+		Name:     "scheduler.purge-runs",
+		Schedule: "30 4 * * *",
+		Run: func(ctx context.Context) error {
+			purged, err := store.PurgeRuns(ctx, time.Now().Add(-cfg.Scheduler.HistoryRetention))
+			if purged > 0 {
+				log.Printf("scheduler: purged %d job runs", purged)
+			}
+			return err
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Register(scheduler.Job{
+		Name:     "tasks.purge-finished",
+		Schedule: "15 4 * * *",
+		Run: func(ctx context.Context) error {
+			purged, err := queue.PurgeFinished(ctx, time.Now().Add(-cfg.Tasks.Retention))
+			if purged > 0 {
+				log.Printf("tasks: purged %d succeeded tasks", purged)
+			}
+			return err
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Outbox.Enabled {
+		err := s.Register(scheduler.Job{
+			Name:     "outbox.purge-published",
+			Schedule: "45 4 * * *",
+			Run: func(ctx context.Context) error {
+				purged, err := outbox.PurgePublished(ctx, db, time.Now().Add(-cfg.Outbox.Retention))
+				if purged > 0 {
+					log.Printf("outbox: purged %d published messages", purged)
+				}
+				return err
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// NewRouter builds the public HTTP router with the OpenAPI document and all public module routes
+// Health endpoints and admin routes are added with MountHealth and MountAdminRoutes, or served
+// on the internal port by NewAdminRouter
+func NewRouter(registry *modules.ModuleRegistry, middleware ...gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware...)
+	r.Use(registry.AuthMiddlewares()...)
+
+	// OpenAPI document generated from the registered routes
+	r.GET(OpenAPIPath, openAPIHandler(r, registry))
+
+	// API versioning with modular routes
+	v1 := r.Group(apiPrefix)
+	{
+		// Register all module routes automatically
+		registry.RegisterAllRoutes(v1)
+	}
+
+	// Future API versions can be added here
+	// v2 := r.Group("/api/v2")
+	// {
+	//     // Register v2 routes with different module configurations
+	//     // Each domain can evolve independently
+	// }
+
+	return r
+}
+
+// Sub-paths of STORAGE_DOWNLOAD_URL under which the signed downloads of each storage are served
+const (
+	uploadDownloadsPath = "/uploads"
+	fileDownloadsPath   = "/files"
+)
+
+// NewURLSigner creates the signer of the storage download URLs
+func NewURLSigner(cfg *config.Config) *urlsign.Signer {
+	return urlsign.New([]byte(cfg.Storage.SigningKey))
+}
+
+// NewUploadStorage creates the storage of public uploads such as avatars, also downloadable
+// through signed URLs
+func NewUploadStorage(cfg *config.Config) *storage.Local {
+	return storage.NewLocal(cfg.Storage.Dir, cfg.Storage.BaseURL, downloadURL(cfg, uploadDownloadsPath), NewURLSigner(cfg))
+}
+
+// NewFileStorage creates the storage of private files such as exports, downloadable only
+// through signed URLs: a local directory, or an S3 bucket exports are streamed into
+func NewFileStorage(cfg *config.Config) (sharedStorage.Storage, error) {
+	switch cfg.Storage.FilesBackend {
+	case "", "local":
+		return newLocalFileStorage(cfg), nil
+	case "s3":
+		return storage.NewS3(storage.S3Config{
+			Bucket: cfg.Storage.S3.Bucket,
+			Region: cfg.Storage.S3.Region,
+			Credentials: sigv4.Credentials{
+				AccessKeyID:     cfg.Storage.S3.AccessKeyID,
+				SecretAccessKey: cfg.Storage.S3.SecretAccessKey,
+				SessionToken:    cfg.Storage.S3.SessionToken,
+			},
+			Endpoint: cfg.Storage.S3.Endpoint,
+			PartSize: cfg.Storage.S3.PartSize,
+			Timeout:  cfg.Storage.S3.Timeout,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported files storage backend: %s", cfg.Storage.FilesBackend)
+	}
+}
+
+// newLocalFileStorage creates the storage of private files kept in PrivateDir
+func newLocalFileStorage(cfg *config.Config) *storage.Local {
+	filesURL := downloadURL(cfg, fileDownloadsPath)
+	return storage.NewLocal(cfg.Storage.PrivateDir, filesURL, filesURL, NewURLSigner(cfg))
+}
+
+// MountStorage serves the uploaded files, and the signed downloads of uploaded and private
+// files, for the storage URLs that are paths on this server
+// With a full URL a CDN or proxy serves the files and nothing is mounted for them; private
+// files kept in S3 are downloaded from S3
+func MountStorage(r *gin.Engine, cfg *config.Config) {
+	if isLocalPath(cfg.Storage.BaseURL) {
+		r.StaticFS(cfg.Storage.BaseURL, gin.Dir(cfg.Storage.Dir, false))
+	}
+	if isLocalPath(cfg.Storage.DownloadURL) {
+		signer := NewURLSigner(cfg)
+		stores := map[string]*storage.Local{uploadDownloadsPath: NewUploadStorage(cfg)}
+		if cfg.Storage.FilesBackend == "" || cfg.Storage.FilesBackend == "local" {
+			stores[fileDownloadsPath] = newLocalFileStorage(cfg)
+		}
+		for subPath, store := range stores {
+			prefix := downloadURL(cfg, subPath)
+			// The signature covers the full path, so it is checked before the prefix is stripped
+			r.GET(prefix+"/*key", gin.WrapH(signer.Require(http.StripPrefix(prefix, store.FileHandler()))))
+		}
+	}
+}
+
+// downloadURL is the URL under which the signed downloads of a storage are served
+func downloadURL(cfg *config.Config, subPath string) string {
+	return strings.TrimSuffix(cfg.Storage.DownloadURL, "/") + subPath
+}
+
+// isLocalPath reports whether a storage URL is a path on this server
+func isLocalPath(url string) bool {
+	return strings.HasPrefix(url, "/") && !strings.HasPrefix(url, "//")
+}
+
+// NewAdminRouter builds the router of the internal admin/ops listener: health endpoints,
+// Prometheus metrics, pprof, the admin-only module routes, the status of the jobs they
+// start and an OpenAPI document of them
+func NewAdminRouter(registry *modules.ModuleRegistry, checker *health.Checker, jobs *scheduler.Scheduler,
+	queue *taskqueue.Queue, middleware ...gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware...)
+	r.Use(registry.AuthMiddlewares()...)
+
+	MountHealth(r, registry, checker)
+	r.GET(metrics.Path, gin.WrapH(metrics.Handler()))
+	mountPprof(r)
+	r.GET(OpenAPIPath, openAPIHandler(r, registry))
+	MountAdminRoutes(r, registry, jobs, queue)
+	MountJobStatus(r, queue)
+
+	return r
+}
+
+// MountAdminRoutes serves the admin-only module routes, the scheduled job listing with the run
+// history of each job and the task queue's dead-letter routes under the API prefix
+// The job listing is narrowed to the jobs of one module with ?module=
+func MountAdminRoutes(r *gin.Engine, registry *modules.ModuleRegistry, jobs *scheduler.Scheduler, queue *taskqueue.Queue) {
+	registry.RegisterAllAdminRoutes(r.Group(apiPrefix))
+
+	auth := middleware.NewAuthMiddleware("")
+	r.GET(JobsPath, auth.RequireAuth(), auth.RequirePermission("jobs", "read"), func(c *gin.Context) {
+		listed := jobs.Jobs(c.Request.Context())
+		if module := strings.ToLower(c.Query("module")); module != "" {
+			filtered := listed[:0]
+			for _, job := range listed {
+				if job.Module == module {
+					filtered = append(filtered, job)
+				}
+			}
+			listed = filtered
+		}
+		c.JSON(http.StatusOK, gin.H{"leader": jobs.IsLeader(), "jobs": listed})
+	})
+	// The job name takes the :id segment of the job status routes of MountJobStatus
+	r.GET(JobsPath+"/:id/runs", auth.RequireAuth(), auth.RequirePermission("jobs", "read"), func(c *gin.Context) {
+		page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		name := c.Param("id")
+		runs, total, err := jobs.Runs(c.Request.Context(), name, page.Limit, page.Offset)
+		if errors.Is(err, scheduler.ErrJobNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+			return
+		}
+		if err != nil {
+			responses.InternalError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, JobRunListResponse{Job: name, Runs: runs, Total: total, Limit: page.Limit, Offset: page.Offset})
+	})
+	MountTaskAdmin(r, queue)
+}
+
+// MountHealth serves the health check and the Kubernetes liveness and readiness probes
+func MountHealth(r *gin.Engine, registry *modules.ModuleRegistry, checker *health.Checker) {
+	// Health check endpoint with module status; 503 while any check fails
+	r.GET("/health", func(c *gin.Context) {
+		report := checker.Run(c.Request.Context())
+		code := http.StatusOK
+		if !report.Serving() {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, gin.H{
+			"status":      report.Status,
+			"checks":      report.Checks,
+			"modules":     ModuleStatuses(registry, report),
+			"description": "Domain-specific adapter architecture",
+		})
+	})
+
+	// Kubernetes probes: liveness runs no checks, readiness runs all of them
+	r.GET(LivenessPath, health.LivenessHandler())
+	r.GET(ReadinessPath, health.ReadinessHandler(checker))
+}
+
+// mountPprof serves the net/http/pprof profiles under PprofPrefix
+func mountPprof(r *gin.Engine) {
+	profiles := r.Group(PprofPrefix)
+	profiles.GET("/", gin.WrapF(pprof.Index))
+	profiles.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+	profiles.GET("/profile", gin.WrapF(pprof.Profile))
+	profiles.POST("/symbol", gin.WrapF(pprof.Symbol))
+	profiles.GET("/symbol", gin.WrapF(pprof.Symbol))
+	profiles.GET("/trace", gin.WrapF(pprof.Trace))
+	// Named profiles: heap, goroutine, allocs, block, mutex, threadcreate
+	profiles.GET("/:profile", func(c *gin.Context) {
+		pprof.Handler(c.Param("profile")).ServeHTTP(c.Writer, c.Request)
+	})
+}
+
+// ModuleNames returns a list of registered module names
+func ModuleNames(registry *modules.ModuleRegistry) []string {
+	var names []string
+	for _, module := range registry.GetModules() {
+		names = append(names, module.Name())
+	}
+	return names
+}
+
+// ModuleStatuses returns the status of all modules
+// Modules with a health check report its status; the others are listed as active
+func ModuleStatuses(registry *modules.ModuleRegistry, report health.Report) map[string]string {
+	statuses := make(map[string]string)
+	for _, module := range registry.GetModules() {
+		statuses[module.Name()] = "active"
+		if result, ok := report.Checks[strings.ToLower(module.Name())]; ok {
+			statuses[module.Name()] = string(result.Status)
+		}
+	}
+	return statuses
+}
+
+// NewGRPCServer builds the gRPC server with the modules' interceptors and the auth
+// interceptor resolving tokens to the sessions of the registry, the standard health service
+// and every module's services; health checks need no authorization
+func NewGRPCServer(port string, registry *modules.ModuleRegistry, healthService *health.GRPCService) *grpcserver.Server {
+	interceptors := append(registry.UnaryInterceptors(), grpcserver.AuthInterceptor(registry.Sessions(), health.ServicePrefix))
+	server := grpcserver.NewServer(port, interceptors...)
+	healthService.Register(server.Registrar())
+	registry.RegisterAllGRPC(server.Registrar())
+	return server
+}
+
+// MountGraphQL serves the GraphQL API aggregating users and orders at GraphQLPath
+func MountGraphQL(r *gin.Engine, db *gorm.DB, opts graph.Options) {
+	handler := gin.WrapH(graph.NewHandler(
+		userRepositories.NewUserRepositoryGen(db),
+		orderRepositories.NewOrderRepositoryGen(db),
+		opts,
+	))
+	r.GET(GraphQLPath, handler)
+	r.POST(GraphQLPath, handler)
+}
+
+// MountGateway serves the grpc-gateway handler under gateway.Prefix
+func MountGateway(r *gin.Engine, handler http.Handler) {
+	r.Any(gateway.Prefix+"/*path", gin.WrapH(handler))
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"clean-arch-gin/internal/adapters/graph"
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/gateway"
+	"clean-arch-gin/internal/infrastructure/health"
+	"clean-arch-gin/internal/infrastructure/jwt"
+	"clean-arch-gin/internal/infrastructure/metrics"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+	"clean-arch-gin/internal/modules"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Components are the parts of the application the routers are composed from
+type Components struct {
+	DB       *gorm.DB
+	Keys     *jwt.KeySet
+	Registry *modules.ModuleRegistry
+	Checker  *health.Checker
+	Jobs     *scheduler.Scheduler
+	Queue    *taskqueue.Queue
+}
+
+// Routers are the routers of the HTTP listeners
+type Routers struct {
+	Public *gin.Engine
+	// Admin serves the operational endpoints and admin routes; nil when ADMIN_ENABLED is off
+	// and they are served by Public
+	Admin *gin.Engine
+}
+
+// Listeners returns the routers by the name of their listener
+func (r *Routers) Listeners() []Listener {
+	listeners := []Listener{{Name: "public", Router: r.Public}}
+	if r.Admin != nil {
+		listeners = append(listeners, Listener{Name: "admin", Router: r.Admin})
+	}
+	return listeners
+}
+
+// Bootstrap composes the HTTP routers of the application as configured: the middleware,
+// the module routes under the versioned groups, the global search, the optional GraphQL,
+// Swagger UI and gRPC gateway endpoints, and the operational endpoints on the admin
+// listener or the public one
+// It is the one way to build the routers; the serve and routes commands, the fx app and
+// the test server all go through it
+func Bootstrap(cfg *config.Config, c Components) (*Routers, error) {
+	if cfg.Server.Mode != "" {
+		gin.SetMode(cfg.Server.Mode)
+	}
+
+	r := NewRouter(c.Registry, Middleware(cfg)...)
+	MountJobStatus(r, c.Queue)
+	MountGlobalSearch(r, c.Registry)
+	MountStorage(r, cfg)
+	MountJWKS(r, c.Keys)
+	if cfg.GraphQL.Enabled {
+		MountGraphQL(r, c.DB, graph.Options{
+			MaxDepth:      cfg.GraphQL.MaxDepth,
+			MaxComplexity: cfg.GraphQL.MaxComplexity,
+		})
+	}
+	if cfg.Server.SwaggerUI {
+		MountSwaggerUI(r)
+	}
+
+	// Transcode JSON requests under /api/v2 to the gRPC services
+	if cfg.GRPC.Enabled && cfg.GRPC.Gateway {
+		handler, err := gateway.NewHandler(context.Background(), "localhost:"+cfg.GRPC.Port)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gRPC gateway: %w", err)
+		}
+		MountGateway(r, handler)
+	}
+
+	routers := &Routers{Public: r}
+	if !cfg.Admin.Enabled {
+		MountHealth(r, c.Registry, c.Checker)
+		MountAdminRoutes(r, c.Registry, c.Jobs, c.Queue)
+	} else {
+		routers.Admin = NewAdminRouter(c.Registry, c.Checker, c.Jobs, c.Queue, Middleware(cfg)...)
+	}
+	inventory := routers.Public
+	if routers.Admin != nil {
+		inventory = routers.Admin
+	}
+	if cfg.Outbox.Enabled {
+		MountOutboxAdmin(inventory, c.DB)
+	}
+	MountRouteInventory(inventory, c.Registry, routers.Listeners()...)
+	return routers, nil
+}
+
+// Middleware returns the middleware both listeners run before the module middleware:
+// metrics, the access log and CORS as configured, panic recovery and the audit of JSON
+// responses for sensitive fields such as password hashes and secrets
+func Middleware(cfg *config.Config) []gin.HandlerFunc {
+	handlers := []gin.HandlerFunc{metrics.Middleware()}
+	if cfg.Server.AccessLog {
+		handlers = append(handlers, gin.Logger())
+	}
+	handlers = append(handlers, gin.Recovery())
+	if cfg.Server.CORS {
+		handlers = append(handlers, middleware.CORS())
+	}
+	handlers = append(handlers, middleware.GuardSensitiveFields())
+	return handlers
+}
@@ -0,0 +1,71 @@
+package app_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestEndToEndOrderFlow registers a user, signs in, places an order and confirms it over HTTP
+func TestEndToEndOrderFlow(t *testing.T) {
+	ts := startTestServer(t)
+
+	var user struct {
+		ID    string `json:"id"`
+		Email string `json:"email"`
+	}
+	sendJSON(t, http.MethodPost, ts.APIURL+"/users", "",
+		`{"email":"erin@example.com","name":"Erin","password":"password123"}`, http.StatusCreated, &user)
+	if user.ID == "" || user.Email != "erin@example.com" {
+		t.Fatalf("registration returned %+v", user)
+	}
+
+	token := login(t, ts, "erin@example.com")
+	if token == "" {
+		t.Fatal("login returned no token")
+	}
+	var me struct {
+		ID string `json:"id"`
+	}
+	sendJSON(t, http.MethodGet, ts.APIURL+"/users/me", token, "", http.StatusOK, &me)
+	if me.ID != user.ID {
+		t.Fatalf("GET /users/me = %q, want the registered user %q", me.ID, user.ID)
+	}
+
+	var created struct {
+		Order struct {
+			ID          string  `json:"id"`
+			Status      string  `json:"status"`
+			TotalAmount float64 `json:"total_amount"`
+		} `json:"order"`
+	}
+	sendJSON(t, http.MethodPost, ts.APIURL+"/orders", token, orderBody, http.StatusCreated, &created)
+	if created.Order.Status != "pending" || created.Order.TotalAmount != 19.98 {
+		t.Fatalf("created order is %s totalling %v, want pending totalling 19.98", created.Order.Status, created.Order.TotalAmount)
+	}
+	order := ts.APIURL + "/orders/" + created.Order.ID
+
+	var confirmed struct {
+		Status string `json:"status"`
+	}
+	sendJSON(t, http.MethodPut, order+"/confirm", token, "", http.StatusOK, &confirmed)
+	if confirmed.Status != "confirmed" {
+		t.Fatalf("confirmed order status = %q, want confirmed", confirmed.Status)
+	}
+
+	var fetched struct {
+		Status string `json:"status"`
+	}
+	sendJSON(t, http.MethodGet, order, token, "", http.StatusOK, &fetched)
+	if fetched.Status != "confirmed" {
+		t.Errorf("GET order status = %q after confirming, want confirmed", fetched.Status)
+	}
+
+	// Another user neither sees nor confirms the order
+	other := login(t, ts, "bob@example.com")
+	if status, body := send(t, http.MethodGet, order, other, ""); status != http.StatusNotFound {
+		t.Errorf("GET order as another user = %d, want 404: %s", status, body)
+	}
+	if status, body := send(t, http.MethodPut, order+"/confirm", "", ""); status != http.StatusUnauthorized {
+		t.Errorf("PUT confirm without a token = %d, want 401: %s", status, body)
+	}
+}
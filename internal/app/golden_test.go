@@ -0,0 +1,200 @@
+package app_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	"clean-arch-gin/internal/testutil/golden"
+
+	"gorm.io/gorm"
+)
+
+// unknownPublicID is a well-formed public ID that no record has
+const unknownPublicID = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+
+// goldenMask lists the response fields that change on every run besides the timestamps
+var goldenMask = golden.Options{Mask: []string{"id", "token", "expires_at", "last_seen_at"}}
+
+// orderBody is a valid checkout of two units of one product
+const orderBody = `{"destination":{"country":"US","region":"CA"},"items":[{"product_id":7,"quantity":2,"price":9.99}]}`
+
+// createdOrder is the part of a new order the golden cases route by
+type createdOrder struct {
+	Order struct {
+		ID    string `json:"id"`
+		Items []struct {
+			ID uint `json:"id"`
+		} `json:"items"`
+	} `json:"order"`
+}
+
+// placeOrder places orderBody as the holder of token and returns the order's URL and the ID
+// of its item; a confirmed order is also confirmed
+func placeOrder(t *testing.T, orders, token string, confirmed bool) (string, uint) {
+	t.Helper()
+	var created createdOrder
+	sendJSON(t, http.MethodPost, orders, token, orderBody, http.StatusCreated, &created)
+	url := orders + "/" + created.Order.ID
+	if confirmed {
+		sendJSON(t, http.MethodPut, url+"/confirm", token, "", http.StatusOK, nil)
+	}
+	return url, created.Order.Items[0].ID
+}
+
+// createUser signs up a user with the seed password and returns their public ID
+func createUser(t *testing.T, users, email, name string) string {
+	t.Helper()
+	var user struct {
+		ID string `json:"id"`
+	}
+	body := `{"email":"` + email + `","name":"` + name + `","password":"password123"}`
+	sendJSON(t, http.MethodPost, users, "", body, http.StatusCreated, &user)
+	return user.ID
+}
+
+// seedAccountToken stores an account token of purpose for the user with the public ID, as
+// mailed in a reset or verification link, and returns it
+func seedAccountToken(t *testing.T, db *gorm.DB, publicID string, purpose userEntities.AccountTokenPurpose) string {
+	t.Helper()
+	var user models.UserModel
+	if err := db.Where("public_id = ?", publicID).First(&user).Error; err != nil {
+		t.Fatalf("failed to find user %s: %v", publicID, err)
+	}
+	token := string(purpose) + "-" + publicID
+	sum := sha256.Sum256([]byte(token))
+	model := models.NewUserAccountTokenModelFromEntity(
+		userEntities.NewAccountToken(user.ID, purpose, hex.EncodeToString(sum[:]), user.Email, time.Hour))
+	if err := db.Create(model).Error; err != nil {
+		t.Fatalf("failed to seed %s token: %v", purpose, err)
+	}
+	return token
+}
+
+// TestGoldenResponses pins the success and error bodies of the public user and order endpoints
+// in testdata; run go test ./internal/app -run Golden -update after an intentional change
+func TestGoldenResponses(t *testing.T) {
+	ts := startTestServer(t)
+	users, orders := ts.APIURL+"/users", ts.APIURL+"/orders"
+
+	carolID := createUser(t, users, "carol@example.com", "Carol")
+	token := login(t, ts, "carol@example.com")
+	erinID := createUser(t, users, "erin@example.com", "Erin")
+	erinToken := login(t, ts, "erin@example.com")
+	frankID := createUser(t, users, "frank@example.com", "Frank")
+	resetToken := seedAccountToken(t, ts.DB, frankID, userEntities.TokenPasswordReset)
+	verifyToken := seedAccountToken(t, ts.DB, frankID, userEntities.TokenEmailVerification)
+	adminToken := login(t, ts, "admin@example.com")
+
+	order, _ := placeOrder(t, orders, token, false)
+	// shipped is confirmed for the shipment cases to ship
+	shipped, shippedItem := placeOrder(t, orders, token, true)
+	shipment := `{"tracking_number":"1Z999","carrier":"UPS","items":[{"order_item_id":` +
+		strconv.FormatUint(uint64(shippedItem), 10) + `,"quantity":2}]}`
+	// delivered is shipped and delivered, with a return requested for the return cases
+	delivered, deliveredItem := placeOrder(t, orders, token, true)
+	sendJSON(t, http.MethodPost, delivered+"/shipments", adminToken,
+		`{"tracking_number":"1Z998","items":[{"order_item_id":`+strconv.FormatUint(uint64(deliveredItem), 10)+`,"quantity":2}]}`,
+		http.StatusCreated, nil)
+	if err := ts.DB.Model(&models.OrderModel{}).Where("id = (SELECT order_id FROM order_items WHERE id = ?)", deliveredItem).
+		Update("status", "delivered").Error; err != nil {
+		t.Fatalf("failed to deliver order: %v", err)
+	}
+	returnBody := `{"reason":"Too small","items":[{"order_item_id":` + strconv.FormatUint(uint64(deliveredItem), 10) + `,"quantity":1}]}`
+	var requested struct {
+		ID uint `json:"id"`
+	}
+	sendJSON(t, http.MethodPost, delivered+"/returns", token, returnBody, http.StatusCreated, &requested)
+	ret := delivered + "/returns/" + strconv.FormatUint(uint64(requested.ID), 10)
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		token  string
+		body   string
+		status int
+	}{
+		{"users_create", http.MethodPost, users, "", `{"email":"dave@example.com","name":"Dave","password":"password123"}`, http.StatusCreated},
+		{"users_create_invalid", http.MethodPost, users, "", `{"email":"not-an-email"}`, http.StatusBadRequest},
+		{"users_create_email_taken", http.MethodPost, users, "", `{"email":"carol@example.com","name":"Carol","password":"password123"}`, http.StatusConflict},
+		{"users_get", http.MethodGet, users + "/" + carolID, "", "", http.StatusOK},
+		{"users_get_invalid_id", http.MethodGet, users + "/nope", "", "", http.StatusBadRequest},
+		{"users_get_not_found", http.MethodGet, users + "/" + unknownPublicID, "", "", http.StatusNotFound},
+		{"users_list", http.MethodGet, users + "?limit=2", "", "", http.StatusOK},
+		{"users_list_invalid_limit", http.MethodGet, users + "?limit=x", "", "", http.StatusBadRequest},
+		{"users_login", http.MethodPost, users + "/auth/login", "", `{"email":"carol@example.com","password":"password123"}`, http.StatusCreated},
+		{"users_login_wrong_password", http.MethodPost, users + "/auth/login", "", `{"email":"carol@example.com","password":"wrong"}`, http.StatusUnauthorized},
+		{"users_me", http.MethodGet, users + "/me", token, "", http.StatusOK},
+		{"users_me_unauthenticated", http.MethodGet, users + "/me", "", "", http.StatusUnauthorized},
+		{"users_me_update", http.MethodPut, users + "/me", token, `{"name":"Caroline"}`, http.StatusOK},
+		{"users_me_update_invalid", http.MethodPut, users + "/me", token, `{"email":"not-an-email"}`, http.StatusBadRequest},
+		{"users_update", http.MethodPut, users + "/" + carolID, token, `{"name":"Carol"}`, http.StatusOK},
+		{"users_update_email_taken", http.MethodPut, users + "/" + carolID, token, `{"email":"alice@example.com"}`, http.StatusConflict},
+		{"users_update_other_user", http.MethodPut, users + "/" + erinID, token, `{"name":"Mallory"}`, http.StatusForbidden},
+		{"users_update_unauthenticated", http.MethodPut, users + "/" + carolID, "", `{"name":"Mallory"}`, http.StatusUnauthorized},
+		{"users_delete_other_user", http.MethodDelete, users + "/" + erinID, token, "", http.StatusForbidden},
+		{"users_delete", http.MethodDelete, users + "/" + erinID, erinToken, "", http.StatusNoContent},
+		{"users_password_reset_confirm", http.MethodPost, users + "/password-reset/confirm", "",
+			`{"token":"` + resetToken + `","password":"password456"}`, http.StatusNoContent},
+		{"users_password_reset_confirm_used_token", http.MethodPost, users + "/password-reset/confirm", "",
+			`{"token":"` + resetToken + `","password":"password789"}`, http.StatusBadRequest},
+		{"users_password_reset_confirm_invalid", http.MethodPost, users + "/password-reset/confirm", "", `{"token":""}`, http.StatusBadRequest},
+		{"users_verify_email", http.MethodPost, users + "/verify-email", "", `{"token":"` + verifyToken + `"}`, http.StatusOK},
+		{"users_verify_email_unknown_token", http.MethodPost, users + "/verify-email", "", `{"token":"nope"}`, http.StatusBadRequest},
+		{"orders_quote", http.MethodPost, orders + "/quote", "", orderBody, http.StatusOK},
+		{"orders_quote_invalid", http.MethodPost, orders + "/quote", "", `{"items":[]}`, http.StatusBadRequest},
+		{"orders_create", http.MethodPost, orders, token, orderBody, http.StatusCreated},
+		{"orders_create_unauthenticated", http.MethodPost, orders, "", orderBody, http.StatusUnauthorized},
+		{"orders_create_invalid", http.MethodPost, orders, token, `{"items":[]}`, http.StatusBadRequest},
+		{"orders_get", http.MethodGet, order, token, "", http.StatusOK},
+		{"orders_get_not_found", http.MethodGet, orders + "/" + unknownPublicID, token, "", http.StatusNotFound},
+		{"orders_invoice_not_invoiceable", http.MethodGet, order + "/invoice.pdf", token, "", http.StatusConflict},
+		{"orders_invoice_not_found", http.MethodGet, orders + "/" + unknownPublicID + "/invoice.pdf", token, "", http.StatusNotFound},
+		{"orders_confirm", http.MethodPut, order + "/confirm", token, "", http.StatusOK},
+		{"orders_confirm_twice", http.MethodPut, order + "/confirm", token, "", http.StatusConflict},
+		{"orders_cancel", http.MethodPut, order + "/cancel", token, "", http.StatusOK},
+		{"orders_cancel_twice", http.MethodPut, order + "/cancel", token, "", http.StatusConflict},
+		{"orders_shipments_create_forbidden", http.MethodPost, shipped + "/shipments", token, shipment, http.StatusForbidden},
+		{"orders_shipments_create_invalid", http.MethodPost, shipped + "/shipments", adminToken, `{"tracking_number":"1Z999","items":[]}`, http.StatusBadRequest},
+		{"orders_shipments_create", http.MethodPost, shipped + "/shipments", adminToken, shipment, http.StatusCreated},
+		{"orders_shipments_create_nothing_left", http.MethodPost, shipped + "/shipments", adminToken, shipment, http.StatusConflict},
+		{"orders_shipments_list", http.MethodGet, shipped + "/shipments", token, "", http.StatusOK},
+		{"orders_returns_request_not_delivered", http.MethodPost, shipped + "/returns", token, returnBody, http.StatusConflict},
+		{"orders_returns_request_invalid", http.MethodPost, delivered + "/returns", token, `{"items":[]}`, http.StatusBadRequest},
+		{"orders_returns_request", http.MethodPost, delivered + "/returns", token, returnBody, http.StatusCreated},
+		{"orders_returns_list", http.MethodGet, delivered + "/returns", token, "", http.StatusOK},
+		{"orders_returns_get", http.MethodGet, ret, token, "", http.StatusOK},
+		{"orders_returns_get_not_found", http.MethodGet, delivered + "/returns/999", token, "", http.StatusNotFound},
+		{"orders_returns_approve_forbidden", http.MethodPut, ret + "/approve", token, "", http.StatusForbidden},
+		{"orders_returns_reject_without_note", http.MethodPut, ret + "/reject", adminToken, `{}`, http.StatusBadRequest},
+		{"orders_returns_approve", http.MethodPut, ret + "/approve", adminToken, `{}`, http.StatusOK},
+		{"orders_returns_approve_twice", http.MethodPut, ret + "/approve", adminToken, `{}`, http.StatusConflict},
+		{"orders_returns_receive", http.MethodPut, ret + "/receive", adminToken, "", http.StatusOK},
+		{"orders_returns_refund", http.MethodPut, ret + "/refund", adminToken, "", http.StatusOK},
+	}
+	// The cases run in order: the confirm and cancel cases walk the same order through its
+	// states, as do the shipment and the return cases theirs; a 204 has no body to pin
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := send(t, tt.method, tt.url, tt.token, tt.body)
+			if status != tt.status {
+				t.Fatalf("%s %s = %d, want %d: %s", tt.method, tt.url, status, tt.status, body)
+			}
+			if status != http.StatusNoContent {
+				golden.AssertJSONWithOptions(t, tt.name, body, goldenMask)
+			}
+		})
+	}
+
+	// The invoice of a confirmed order is a PDF, pinned by its status and type only
+	status, body := send(t, http.MethodGet, shipped+"/invoice.pdf", token, "")
+	if status != http.StatusOK || http.DetectContentType(body) != "application/pdf" {
+		t.Errorf("GET %s/invoice.pdf = %d with %s, want a PDF", shipped, status, http.DetectContentType(body))
+	}
+}
@@ -0,0 +1,80 @@
+package app_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"clean-arch-gin/internal/app"
+)
+
+// startTestServer starts a test server that is closed when the test finishes
+func startTestServer(t *testing.T) *app.TestServer {
+	t.Helper()
+	ts, err := app.NewTestServer()
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// send issues a JSON request, with a bearer token unless token is empty, and returns the
+// status and body
+func send(t *testing.T, method, url, token, body string) (int, []byte) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != "" {
+		reader = bytes.NewBufferString(body)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		t.Fatalf("failed to build %s %s: %v", method, url, err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read %s %s response: %v", method, url, err)
+	}
+	return resp.StatusCode, data
+}
+
+// sendJSON issues a request that must answer with want and decodes the response into out
+func sendJSON(t *testing.T, method, url, token, body string, want int, out interface{}) {
+	t.Helper()
+	status, data := send(t, method, url, token, body)
+	if status != want {
+		t.Fatalf("%s %s = %d, want %d: %s", method, url, status, want, data)
+	}
+	if out == nil {
+		return
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		t.Fatalf("failed to decode %s %s response %s: %v", method, url, data, err)
+	}
+}
+
+// login signs in with the seed password and returns the session token
+func login(t *testing.T, ts *app.TestServer, email string) string {
+	t.Helper()
+	var session struct {
+		Token string `json:"token"`
+	}
+	body := `{"email":"` + email + `","password":"password123"}`
+	sendJSON(t, http.MethodPost, ts.APIURL+"/users/auth/login", "", body, http.StatusCreated, &session)
+	return session.Token
+}
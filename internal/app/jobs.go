@@ -0,0 +1,215 @@
+package app
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// Job streams poll the task row, so progress reported by a worker on any replica reaches them
+const (
+	jobPollInterval      = time.Second
+	jobKeepAliveInterval = 15 * time.Second
+)
+
+// JobStatusResponse reports a long operation queued as a task
+type JobStatusResponse struct {
+	ID       uint             `json:"id"`
+	Type     string           `json:"type"`
+	Status   taskqueue.Status `json:"status"`
+	Progress int              `json:"progress"`
+	// Phase is the step the job is on, and Processed and Total count its items done and to do
+	Phase     string `json:"phase,omitempty"`
+	Processed int    `json:"processed,omitempty"`
+	Total     int    `json:"total,omitempty"`
+	Attempts  int    `json:"attempts"`
+	// Error is the last failure; a pending job with an error is waiting to retry
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	// ResultURL is where the result is fetched once the job succeeded
+	ResultURL string `json:"result_url,omitempty"`
+}
+
+// JobRunListResponse is a page of the run history of a scheduled job, most recent first
+type JobRunListResponse struct {
+	Job    string          `json:"job"`
+	Runs   []scheduler.Run `json:"runs"`
+	Total  int64           `json:"total"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+// toJobStatus converts a task
+func toJobStatus(task *taskqueue.Task) JobStatusResponse {
+	status := JobStatusResponse{
+		ID:         task.ID,
+		Type:       task.Type,
+		Status:     task.Status,
+		Progress:   task.Progress,
+		Phase:      task.Phase,
+		Processed:  task.Processed,
+		Total:      task.Total,
+		Attempts:   task.Attempts,
+		Error:      task.LastError,
+		CreatedAt:  task.CreatedAt,
+		UpdatedAt:  task.UpdatedAt,
+		FinishedAt: task.FinishedAt,
+	}
+	if task.Status == taskqueue.StatusSucceeded && len(task.Result) > 0 {
+		status.ResultURL = jobURL(task.ID) + "/result"
+	}
+	return status
+}
+
+// jobURL is the status URL of a job, as returned by responses.JobAccepted
+func jobURL(id uint) string {
+	return JobsPath + "/" + strconv.FormatUint(uint64(id), 10)
+}
+
+// MountJobStatus serves the status and result of jobs started by long operations, which
+// answer 202 with a job ID. A job is visible to the user who started it and to admins;
+// anyone else gets 404 so job IDs cannot be probed
+func MountJobStatus(r *gin.Engine, queue *taskqueue.Queue) {
+	auth := middleware.NewAuthMiddleware("")
+	jobs := r.Group(JobsPath, auth.RequireAuth())
+
+	// GET /api/v1/jobs/:id
+	jobs.GET("/:id", func(c *gin.Context) {
+		task, ok := visibleJob(c, queue)
+		if !ok {
+			return
+		}
+		c.JSON(http.StatusOK, toJobStatus(task))
+	})
+
+	// GET /api/v1/jobs/:id/events
+	jobs.GET("/:id/events", func(c *gin.Context) {
+		task, ok := visibleJob(c, queue)
+		if !ok {
+			return
+		}
+		streamJob(c, queue, task)
+	})
+
+	// GET /api/v1/jobs/:id/result
+	jobs.GET("/:id/result", func(c *gin.Context) {
+		task, ok := visibleJob(c, queue)
+		if !ok {
+			return
+		}
+		if task.Status != taskqueue.StatusSucceeded {
+			c.JSON(http.StatusConflict, gin.H{"error": "Job has not succeeded", "status": task.Status})
+			return
+		}
+		if len(task.Result) == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job has no result"})
+			return
+		}
+		c.Data(http.StatusOK, "application/json", task.Result)
+	})
+}
+
+// visibleJob loads the :id job if the user may see it, responding otherwise
+func visibleJob(c *gin.Context, queue *taskqueue.Queue) (*taskqueue.Task, bool) {
+	id, ok := taskID(c)
+	if !ok {
+		return nil, false
+	}
+	task, err := queue.Get(c.Request.Context(), id)
+	if err != nil {
+		respondTaskError(c, err)
+		return nil, false
+	}
+	if !middleware.HasRole(c, "admin") && !ownsJob(c, task) {
+		respondTaskError(c, taskqueue.ErrTaskNotFound)
+		return nil, false
+	}
+	return task, true
+}
+
+// ownsJob reports whether the authenticated user started the task
+func ownsJob(c *gin.Context, task *taskqueue.Task) bool {
+	userID, ok := middleware.UserID(c)
+	return ok && task.Owner != "" && task.Owner == strconv.FormatUint(uint64(userID), 10)
+}
+
+// streamJob sends the status of task as Server-Sent Events: a "progress" event right away and
+// whenever its status, phase or counts change, until it succeeds or is dead, or the client
+// leaves
+func streamJob(c *gin.Context, queue *taskqueue.Queue, task *taskqueue.Task) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	last := toJobStatus(task)
+	writeJobEvent(c, last)
+	c.Writer.Flush()
+	if jobFinished(last.Status) {
+		return
+	}
+
+	poll := time.NewTicker(jobPollInterval)
+	defer poll.Stop()
+	keepAlive := time.NewTicker(jobKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	ctx := c.Request.Context()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-poll.C:
+			task, err := queue.Get(ctx, last.ID)
+			if errors.Is(err, taskqueue.ErrTaskNotFound) {
+				return false
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("jobs: failed to poll job %d: %v", last.ID, err)
+				}
+				return ctx.Err() == nil
+			}
+			status := toJobStatus(task)
+			if !jobChanged(last, status) {
+				return true
+			}
+			last = status
+			writeJobEvent(c, status)
+			return !jobFinished(status.Status)
+		case <-keepAlive.C:
+			_, _ = io.WriteString(w, ": keep-alive\n\n")
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
+
+// writeJobEvent sends status as a "progress" event
+func writeJobEvent(c *gin.Context, status JobStatusResponse) {
+	c.Render(-1, sse.Event{Event: "progress", Data: status})
+}
+
+// jobChanged reports whether a client following the job is told something new by next
+func jobChanged(prev, next JobStatusResponse) bool {
+	return prev.Status != next.Status || prev.Progress != next.Progress || prev.Phase != next.Phase ||
+		prev.Processed != next.Processed || prev.Total != next.Total ||
+		prev.Attempts != next.Attempts || prev.Error != next.Error
+}
+
+// jobFinished reports whether a job in status no longer changes on its own
+func jobFinished(status taskqueue.Status) bool {
+	return status == taskqueue.StatusSucceeded || status == taskqueue.StatusDead
+}
@@ -0,0 +1,233 @@
+package app
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/infrastructure/gateway"
+	"clean-arch-gin/internal/infrastructure/health"
+	"clean-arch-gin/internal/infrastructure/metrics"
+	"clean-arch-gin/internal/infrastructure/openapi"
+	"clean-arch-gin/internal/modules"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// OpenAPIPath serves the generated OpenAPI document
+	OpenAPIPath = "/openapi.json"
+	// SwaggerUIPath serves Swagger UI when it is enabled
+	SwaggerUIPath = "/docs"
+	// LivenessPath answers while the process is up
+	LivenessPath = "/health/live"
+	// ReadinessPath answers 200 only while every health check passes
+	ReadinessPath = "/health/ready"
+)
+
+// apiInfo describes the API in the OpenAPI document
+var apiInfo = openapi.Info{
+	Title:       "Clean Architecture Gin API",
+	Version:     "1.0.0",
+	Description: "Modular API built from domain-specific adapters",
+}
+
+// systemRoutes documents the routes registered directly by NewRouter
+var systemRoutes = []openapi.Route{
+	{
+		Method: "GET", Path: "/health", Summary: "Health check with per-dependency and module status",
+		Responses: map[int]interface{}{200: nil, 503: nil},
+	},
+	{Method: "GET", Path: LivenessPath, Summary: "Liveness probe; runs no dependency checks"},
+	{Method: "GET", Path: metrics.Path, Summary: "Prometheus metrics (admin listener only)"},
+	{
+		Method: "GET", Path: ReadinessPath, Summary: "Readiness probe; SERVING only when every check passes",
+		Query: []openapi.Parameter{
+			openapi.QueryParam("service", "string", "Report a single check, e.g. database or users"),
+		},
+		Responses: map[int]interface{}{
+			200: health.Report{}, 404: openapi.ErrorResponse{}, 503: health.Report{},
+		},
+	},
+	{
+		Method: "GET", Path: JobsPath, Summary: "List scheduled jobs with their next and last run (admin only)",
+		Responses: map[int]interface{}{200: nil, 401: openapi.ErrorResponse{}, 403: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "GET", Path: JobsPath + "/:id/runs", Summary: "List the runs of a scheduled job by name, most recent first (admin only)",
+		Query: []openapi.Parameter{
+			openapi.QueryParam("limit", "integer", "Page size (default 10, max 100)"),
+			openapi.QueryParam("offset", "integer", "Number of runs to skip"),
+		},
+		Responses: map[int]interface{}{
+			200: JobRunListResponse{}, 400: openapi.ErrorResponse{}, 401: openapi.ErrorResponse{},
+			403: openapi.ErrorResponse{}, 404: openapi.ErrorResponse{},
+		},
+	},
+	{
+		Method: "GET", Path: JobsPath + "/:id", Auth: true,
+		Summary: "Get the status and progress of a job started by a long operation, e.g. an async import",
+		Responses: map[int]interface{}{
+			200: JobStatusResponse{}, 400: openapi.ErrorResponse{}, 401: openapi.ErrorResponse{}, 404: openapi.ErrorResponse{},
+		},
+	},
+	{
+		Method: "GET", Path: JobsPath + "/:id/events", Auth: true,
+		Summary: "Stream the status and progress of a job as Server-Sent Events (text/event-stream) until it succeeds or is dead",
+		Responses: map[int]interface{}{
+			200: nil, 400: openapi.ErrorResponse{}, 401: openapi.ErrorResponse{}, 404: openapi.ErrorResponse{},
+		},
+	},
+	{
+		Method: "GET", Path: JobsPath + "/:id/result", Auth: true, Summary: "Get the result of a succeeded job",
+		Responses: map[int]interface{}{
+			200: nil, 401: openapi.ErrorResponse{}, 404: openapi.ErrorResponse{}, 409: openapi.ErrorResponse{},
+		},
+	},
+	{
+		Method: "GET", Path: TasksPath + "/dead", Summary: "List dead tasks of the task queue (admin only)",
+		Query: []openapi.Parameter{
+			openapi.QueryParam("type", "string", "Only tasks of this type, e.g. users.import"),
+			openapi.QueryParam("limit", "integer", "Page size (default 10, max 100)"),
+			openapi.QueryParam("offset", "integer", "Number of tasks to skip"),
+		},
+		Responses: map[int]interface{}{200: DeadTaskListResponse{}, 400: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "GET", Path: TasksPath + "/:id", Summary: "Get a task with its payload and error history (admin only)",
+		Responses: map[int]interface{}{200: TaskDTO{}, 400: openapi.ErrorResponse{}, 404: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "POST", Path: TasksPath + "/:id/requeue", Summary: "Requeue a dead task with fresh attempts (admin only)",
+		Responses: map[int]interface{}{200: TaskDTO{}, 404: openapi.ErrorResponse{}, 409: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "DELETE", Path: TasksPath + "/:id", Summary: "Discard a dead task and its error history (admin only)",
+		Responses: map[int]interface{}{204: nil, 404: openapi.ErrorResponse{}, 409: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "POST", Path: TasksPath + "/dead/requeue", Summary: "Requeue a selection of dead tasks (admin only)",
+		Request:   BulkRequest{},
+		Responses: map[int]interface{}{200: BulkResponse{}, 400: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "POST", Path: TasksPath + "/dead/discard", Summary: "Discard a selection of dead tasks (admin only)",
+		Request:   BulkRequest{},
+		Responses: map[int]interface{}{200: BulkResponse{}, 400: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "GET", Path: OutboxPath + "/poisoned", Summary: "List outbox messages set aside as poison (admin only)",
+		Query: []openapi.Parameter{
+			openapi.QueryParam("type", "string", "Only messages of this event type, e.g. order.confirmed"),
+			openapi.QueryParam("limit", "integer", "Page size (default 10, max 100)"),
+			openapi.QueryParam("offset", "integer", "Number of messages to skip"),
+		},
+		Responses: map[int]interface{}{200: PoisonedMessageListResponse{}, 400: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "POST", Path: OutboxPath + "/poisoned/replay", Summary: "Replay a selection of poison outbox messages (admin only)",
+		Request:   BulkRequest{},
+		Responses: map[int]interface{}{200: BulkResponse{}, 400: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "POST", Path: OutboxPath + "/poisoned/discard", Summary: "Discard a selection of poison outbox messages (admin only)",
+		Request:   BulkRequest{},
+		Responses: map[int]interface{}{200: BulkResponse{}, 400: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "GET", Path: OutboxPath + "/:id", Summary: "Get an outbox message with its CloudEvent and last failure (admin only)",
+		Responses: map[int]interface{}{200: OutboxMessageDTO{}, 400: openapi.ErrorResponse{}, 404: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "POST", Path: OutboxPath + "/:id/replay", Summary: "Replay a poison outbox message with fresh attempts (admin only)",
+		Responses: map[int]interface{}{200: OutboxMessageDTO{}, 404: openapi.ErrorResponse{}, 409: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "DELETE", Path: OutboxPath + "/:id", Summary: "Discard a poison outbox message (admin only)",
+		Responses: map[int]interface{}{204: nil, 404: openapi.ErrorResponse{}, 409: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "GET", Path: RoutesPath, Summary: "List every route with its module and middleware (admin only)",
+		Responses: map[int]interface{}{200: nil, 401: openapi.ErrorResponse{}, 403: openapi.ErrorResponse{}},
+	},
+	{
+		Method: "GET", Path: GlobalSearchPath, Auth: true,
+		Summary: "Search the records of every module the caller may search, most relevant first",
+		Query: []openapi.Parameter{
+			openapi.QueryParam("q", "string", "Words to match by prefix, e.g. ali smi"),
+			openapi.QueryParam("limit", "integer", "Number of results (default 10, max 100)"),
+		},
+		Responses: map[int]interface{}{
+			200: GlobalSearchResponse{}, 400: openapi.ErrorResponse{}, 401: openapi.ErrorResponse{}, 500: openapi.ErrorResponse{},
+		},
+	},
+	{Method: "GET", Path: GraphQLPath, Summary: "GraphQL query over the query string"},
+	{Method: "POST", Path: GraphQLPath, Summary: "GraphQL endpoint aggregating users and orders"},
+}
+
+// BuildOpenAPI generates the OpenAPI document for every route registered on r
+// Routes of modules implementing modules.Documented carry their schemas; any other
+// route is still listed so the document never misses an endpoint. The grpc-gateway
+// catch-all is skipped because its surface is defined by the protos in api/proto
+func BuildOpenAPI(r *gin.Engine, registry *modules.ModuleRegistry) *openapi.Document {
+	g := openapi.NewGenerator(apiInfo)
+
+	documented := make(map[string]openapi.Route)
+	tags := moduleTags(registry)
+	for _, route := range systemRoutes {
+		documented[route.Method+" "+route.Path] = route
+	}
+	for _, module := range registry.GetModules() {
+		base := apiPrefix + "/" + strings.ToLower(module.Name())
+		if doc, ok := module.(modules.Documented); ok {
+			for _, route := range doc.APIRoutes() {
+				documented[route.Method+" "+base+route.Path] = route
+			}
+		}
+	}
+
+	for _, info := range r.Routes() {
+		if info.Path == OpenAPIPath || info.Path == SwaggerUIPath || strings.HasPrefix(info.Path, gateway.Prefix+"/") ||
+			strings.HasPrefix(info.Path, PprofPrefix+"/") {
+			continue
+		}
+		route, ok := documented[info.Method+" "+info.Path]
+		if !ok {
+			route = openapi.Route{Method: info.Method}
+		}
+		g.AddRoute(tagFor(info.Path, tags), info.Path, route)
+	}
+
+	return g.Document()
+}
+
+// tagFor groups a route under the module that owns its path
+func tagFor(path string, tags map[string]string) string {
+	for base, tag := range tags {
+		if path == base || strings.HasPrefix(path, base+"/") {
+			return tag
+		}
+	}
+	return "system"
+}
+
+// openAPIHandler serves the document, generated on first request once all routes are registered
+func openAPIHandler(r *gin.Engine, registry *modules.ModuleRegistry) gin.HandlerFunc {
+	var (
+		once sync.Once
+		doc  *openapi.Document
+	)
+	return func(c *gin.Context) {
+		once.Do(func() {
+			doc = BuildOpenAPI(r, registry)
+		})
+		middleware.SkipSensitiveFieldAudit(c)
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+// MountSwaggerUI serves Swagger UI for the generated document
+func MountSwaggerUI(r *gin.Engine) {
+	r.GET(SwaggerUIPath, openapi.SwaggerUIHandler(apiInfo.Title, OpenAPIPath))
+}
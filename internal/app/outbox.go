@@ -0,0 +1,171 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/infrastructure/outbox"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// OutboxPath is the prefix of the outbox admin routes
+const OutboxPath = apiPrefix + "/outbox"
+
+// OutboxMessageDTO is an outbox message with its CloudEvent and delivery state
+type OutboxMessageDTO struct {
+	ID           uint            `json:"id"`
+	EventID      string          `json:"event_id"`
+	EventType    string          `json:"event_type"`
+	Subject      string          `json:"subject,omitempty"`
+	Topic        string          `json:"topic"`
+	PartitionKey string          `json:"partition_key"`
+	OccurredAt   time.Time       `json:"occurred_at"`
+	CreatedAt    time.Time       `json:"created_at"`
+	PublishedAt  *time.Time      `json:"published_at,omitempty"`
+	Attempts     int             `json:"attempts"`
+	LastError    string          `json:"last_error,omitempty"`
+	FailedAt     *time.Time      `json:"failed_at,omitempty"`
+	Payload      json.RawMessage `json:"payload"`
+}
+
+// PoisonedMessageListResponse is a page of poison outbox messages
+type PoisonedMessageListResponse struct {
+	Messages []OutboxMessageDTO `json:"messages"`
+	Total    int64              `json:"total"`
+	Limit    int                `json:"limit"`
+	Offset   int                `json:"offset"`
+}
+
+// toOutboxMessageDTO converts a message; the payload is always a JSON CloudEvent
+func toOutboxMessageDTO(msg *outbox.Message) OutboxMessageDTO {
+	return OutboxMessageDTO{
+		ID:           msg.ID,
+		EventID:      msg.EventID,
+		EventType:    msg.EventType,
+		Subject:      msg.Subject,
+		Topic:        msg.Topic,
+		PartitionKey: msg.PartitionKey,
+		OccurredAt:   msg.OccurredAt,
+		CreatedAt:    msg.CreatedAt,
+		PublishedAt:  msg.PublishedAt,
+		Attempts:     msg.Attempts,
+		LastError:    msg.LastError,
+		FailedAt:     msg.FailedAt,
+		Payload:      json.RawMessage(msg.Payload),
+	}
+}
+
+// MountOutboxAdmin serves the dead-letter routes of the outbox: list the messages set aside
+// as poison, inspect a message's event and failure, replay or discard a poison message or a
+// selection of them
+func MountOutboxAdmin(r *gin.Engine, db *gorm.DB) {
+	auth := middleware.NewAuthMiddleware("")
+	messages := r.Group(OutboxPath, auth.RequireAuth(), auth.RequirePermission("outbox", "manage"))
+	// the partition key orders the messages of an aggregate and is no credential
+	messages.Use(func(c *gin.Context) { middleware.AllowSensitiveFields(c, "partition_key") })
+
+	// GET /api/v1/outbox/poisoned?type=order.confirmed&limit=&offset=
+	messages.GET("/poisoned", func(c *gin.Context) {
+		page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		poisoned, total, err := outbox.ListPoisoned(c.Request.Context(), db, c.Query("type"), page.Limit, page.Offset)
+		if err != nil {
+			responses.InternalError(c, err)
+			return
+		}
+		dtos := make([]OutboxMessageDTO, len(poisoned))
+		for i, msg := range poisoned {
+			dtos[i] = toOutboxMessageDTO(msg)
+		}
+		c.JSON(http.StatusOK, PoisonedMessageListResponse{Messages: dtos, Total: total, Limit: page.Limit, Offset: page.Offset})
+	})
+
+	// POST /api/v1/outbox/poisoned/replay {"ids": [1, 2]}
+	messages.POST("/poisoned/replay", func(c *gin.Context) {
+		applyBulk(c, func(c *gin.Context, id uint) error {
+			_, err := outbox.Replay(c.Request.Context(), db, id)
+			return err
+		})
+	})
+
+	// POST /api/v1/outbox/poisoned/discard {"ids": [1, 2]}
+	messages.POST("/poisoned/discard", func(c *gin.Context) {
+		applyBulk(c, func(c *gin.Context, id uint) error {
+			return outbox.Discard(c.Request.Context(), db, id)
+		})
+	})
+
+	// GET /api/v1/outbox/:id
+	messages.GET("/:id", func(c *gin.Context) {
+		id, ok := outboxMessageID(c)
+		if !ok {
+			return
+		}
+		msg, err := outbox.Get(c.Request.Context(), db, id)
+		if err != nil {
+			respondOutboxError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, toOutboxMessageDTO(msg))
+	})
+
+	// POST /api/v1/outbox/:id/replay
+	messages.POST("/:id/replay", func(c *gin.Context) {
+		id, ok := outboxMessageID(c)
+		if !ok {
+			return
+		}
+		msg, err := outbox.Replay(c.Request.Context(), db, id)
+		if err != nil {
+			respondOutboxError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, toOutboxMessageDTO(msg))
+	})
+
+	// DELETE /api/v1/outbox/:id
+	messages.DELETE("/:id", func(c *gin.Context) {
+		id, ok := outboxMessageID(c)
+		if !ok {
+			return
+		}
+		if err := outbox.Discard(c.Request.Context(), db, id); err != nil {
+			respondOutboxError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+}
+
+// outboxMessageID parses the :id path parameter, responding 400 when it is invalid
+func outboxMessageID(c *gin.Context) (uint, bool) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// respondOutboxError maps outbox errors to HTTP responses
+func respondOutboxError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, outbox.ErrMessageNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, outbox.ErrMessageNotPoisoned):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
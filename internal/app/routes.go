@@ -0,0 +1,143 @@
+package app
+
+import (
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/modules"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoutesPath lists every registered route with its module and middleware (admin only)
+const RoutesPath = "/admin/routes"
+
+// Listener is a router by the name of the listener serving it, e.g. public or admin
+type Listener struct {
+	Name   string
+	Router *gin.Engine
+}
+
+// RouteEntry is a registered route with the module owning it and the handlers it runs
+type RouteEntry struct {
+	Listener string `json:"listener"`
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+	// Module owns the path; system for the routes mounted by the application
+	Module string `json:"module"`
+	// Middleware runs before the handler, in order
+	Middleware []string `json:"middleware"`
+	Handler    string   `json:"handler"`
+}
+
+// RouteInventory lists the routes of every listener, sorted by path and method, with the
+// module owning each path in registry
+func RouteInventory(registry *modules.ModuleRegistry, listeners ...Listener) []RouteEntry {
+	tags := moduleTags(registry)
+
+	var entries []RouteEntry
+	for _, listener := range listeners {
+		chains := handlerChains(listener.Router)
+		routes := listener.Router.Routes()
+		sort.Slice(routes, func(i, j int) bool {
+			if routes[i].Path != routes[j].Path {
+				return routes[i].Path < routes[j].Path
+			}
+			return routes[i].Method < routes[j].Method
+		})
+		for _, route := range routes {
+			entry := RouteEntry{
+				Listener:   listener.Name,
+				Method:     route.Method,
+				Path:       route.Path,
+				Module:     tagFor(route.Path, tags),
+				Middleware: []string{},
+				Handler:    shortFuncName(route.Handler),
+			}
+			if chain := chains[route.Method+" "+route.Path]; len(chain) > 1 {
+				entry.Middleware = chain[:len(chain)-1]
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// MountRouteInventory serves the inventory of listeners on r, generated on first request
+// once all routes are registered
+func MountRouteInventory(r *gin.Engine, registry *modules.ModuleRegistry, listeners ...Listener) {
+	var (
+		once    sync.Once
+		entries []RouteEntry
+	)
+	auth := middleware.NewAuthMiddleware("")
+	r.GET(RoutesPath, auth.RequireAuth(), auth.RequirePermission("routes", "read"), func(c *gin.Context) {
+		once.Do(func() {
+			entries = RouteInventory(registry, listeners...)
+		})
+		c.JSON(http.StatusOK, gin.H{"routes": entries, "total": len(entries)})
+	})
+}
+
+// moduleTags maps the path prefix of every module to its name
+func moduleTags(registry *modules.ModuleRegistry) map[string]string {
+	tags := make(map[string]string)
+	for _, module := range registry.GetModules() {
+		tags[apiPrefix+"/"+strings.ToLower(module.Name())] = module.Name()
+	}
+	return tags
+}
+
+// handlerChains returns the handler names of every route of r by method and path
+// gin only reports the last handler of a route, so the chains are read from its route trees;
+// a gin version laying them out differently yields no chains instead of failing
+func handlerChains(r *gin.Engine) map[string][]string {
+	chains := make(map[string][]string)
+	trees := reflect.ValueOf(r).Elem().FieldByName("trees")
+	if trees.Kind() != reflect.Slice {
+		return chains
+	}
+	for i := 0; i < trees.Len(); i++ {
+		tree := trees.Index(i)
+		method := tree.FieldByName("method")
+		if method.Kind() != reflect.String {
+			return chains
+		}
+		collectChains(chains, method.String(), tree.FieldByName("root"))
+	}
+	return chains
+}
+
+// collectChains adds the chain of node and of its descendants to chains
+func collectChains(chains map[string][]string, method string, node reflect.Value) {
+	if node.Kind() != reflect.Pointer || node.IsNil() {
+		return
+	}
+	node = node.Elem()
+	handlers, fullPath, children := node.FieldByName("handlers"), node.FieldByName("fullPath"), node.FieldByName("children")
+	if handlers.Kind() != reflect.Slice || fullPath.Kind() != reflect.String || children.Kind() != reflect.Slice {
+		return
+	}
+
+	if handlers.Len() > 0 {
+		names := make([]string, handlers.Len())
+		for i := range names {
+			names[i] = shortFuncName(runtime.FuncForPC(handlers.Index(i).Pointer()).Name())
+		}
+		chains[method+" "+fullPath.String()] = names
+	}
+	for i := 0; i < children.Len(); i++ {
+		collectChains(chains, method, children.Index(i))
+	}
+}
+
+// shortFuncName drops the import path from a function name, keeping its package,
+// e.g. middleware.(*AuthMiddleware).RequireAuth.func1
+func shortFuncName(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/domain/shared/authz"
+	"clean-arch-gin/internal/domain/shared/search"
+	"clean-arch-gin/internal/modules"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GlobalSearchPath searches the records of every module implementing modules.Searchable at once
+const GlobalSearchPath = apiPrefix + "/search"
+
+// errInvalidGlobalSearch rejects queries without a word to search for
+var errInvalidGlobalSearch = errors.New("search query needs at least one word of letters or digits")
+
+// GlobalSearchResultDTO is a record found by the global search
+type GlobalSearchResultDTO struct {
+	// Type tags the kind of record, e.g. user or order
+	Type    string `json:"type"`
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Summary string `json:"summary,omitempty"`
+	// Score is the relevance relative to the best result of the same module, which scores 1
+	Score float64 `json:"score"`
+}
+
+// GlobalSearchResponse holds the best results of every module the caller may search, most
+// relevant first
+type GlobalSearchResponse struct {
+	Query   string                  `json:"query"`
+	Results []GlobalSearchResultDTO `json:"results"`
+	Limit   int                     `json:"limit"`
+	// Failed names the modules that could not be searched; their results are missing
+	Failed []string `json:"failed,omitempty"`
+}
+
+// moduleResults are the results of one module, or the reason it failed
+type moduleResults struct {
+	module  string
+	results []modules.SearchResult
+	err     error
+}
+
+// MountGlobalSearch serves the global search on r: every searchable module the caller has
+// the permission of is searched concurrently, and their results merged
+func MountGlobalSearch(r *gin.Engine, registry *modules.ModuleRegistry) {
+	auth := middleware.NewAuthMiddleware("")
+
+	// GET /api/v1/search?q=&limit=
+	r.GET(GlobalSearchPath, auth.RequireAuth(), func(c *gin.Context) {
+		page, err := params.ParsePagination(c.Query("limit"), "")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		query := c.Query("q")
+		if len(search.Terms(query)) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errInvalidGlobalSearch.Error()})
+			return
+		}
+
+		allowed := make(map[string]modules.Searchable)
+		for name, searchable := range registry.Searchables() {
+			object, action := searchable.SearchPermission()
+			if object == "" {
+				allowed[name] = searchable
+				continue
+			}
+			switch err := middleware.Authorize(c, object, action); {
+			case err == nil:
+				allowed[name] = searchable
+			case !errors.Is(err, authz.ErrForbidden):
+				responses.InternalError(c, err)
+				return
+			}
+		}
+
+		found := searchModules(c.Request.Context(), allowed, middleware.CurrentActor(c), query, page.Limit)
+		resp := GlobalSearchResponse{Query: query, Results: mergeResults(found, page.Limit), Limit: page.Limit}
+		for _, f := range found {
+			if f.err != nil {
+				log.Printf("global search: failed to search %s: %v", f.module, f.err)
+				resp.Failed = append(resp.Failed, f.module)
+			}
+		}
+		if len(found) > 0 && len(resp.Failed) == len(found) {
+			responses.InternalError(c, found[0].err)
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+}
+
+// searchModules searches every module concurrently for the limit best results each, since
+// they could all come from one, returning the outcomes by module name
+func searchModules(ctx context.Context, searchables map[string]modules.Searchable, actor authz.Actor,
+	query string, limit int) []moduleResults {
+	found := make([]moduleResults, 0, len(searchables))
+	for name := range searchables {
+		found = append(found, moduleResults{module: name})
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].module < found[j].module })
+
+	var wg sync.WaitGroup
+	for i := range found {
+		wg.Add(1)
+		go func(f *moduleResults) {
+			defer wg.Done()
+			f.results, f.err = searchables[f.module].Search(ctx, actor, query, limit)
+		}(&found[i])
+	}
+	wg.Wait()
+	return found
+}
+
+// mergeResults ranks the results of every module together, keeping the limit best
+// Scores of different modules are not comparable, so each is rescaled by the best of its
+// module; ties keep the order of the modules by name, then each module's own
+func mergeResults(found []moduleResults, limit int) []GlobalSearchResultDTO {
+	merged := []GlobalSearchResultDTO{}
+	for _, f := range found {
+		best := 0.0
+		for _, result := range f.results {
+			if result.Score > best {
+				best = result.Score
+			}
+		}
+		for _, result := range f.results {
+			score := 1.0
+			if best > 0 {
+				score = result.Score / best
+			}
+			merged = append(merged, GlobalSearchResultDTO{
+				Type:    result.Type,
+				ID:      result.ID,
+				Title:   result.Title,
+				Summary: result.Summary,
+				Score:   score,
+			})
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Score > merged[j].Score })
+	if len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged
+}
@@ -0,0 +1,92 @@
+package app_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"clean-arch-gin/internal/adapters/middleware"
+)
+
+// revealedOnPurpose lists the sensitive response fields, as Type.json_key, whose handler
+// calls middleware.AllowSensitiveFields because revealing them is the point of the route
+var revealedOnPurpose = map[string]bool{
+	"APIKeyDTO.key":                  true, // once, when the key is created
+	"DeviceDTO.token":                true, // the push token the device registered
+	"EndpointDTO.secret":             true, // once, when the webhook endpoint is registered
+	"LoginResponse.token":            true, // the token of the new session
+	"OutboxMessageDTO.partition_key": true, // orders the messages of an aggregate, no credential
+}
+
+// TestResponseDTOsCarryNoSecrets walks the response types of every module, the structs named
+// ...DTO or ...Response, and fails on a JSON field the sensitive field guard would reject
+// unless the field is revealed on purpose, so a leak shows up here rather than as a 500
+func TestResponseDTOsCarryNoSecrets(t *testing.T) {
+	var exposed []string
+	fset := token.NewFileSet()
+	err := filepath.WalkDir("..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok || !(strings.HasSuffix(spec.Name.Name, "DTO") || strings.HasSuffix(spec.Name.Name, "Response")) {
+				return true
+			}
+			if fields, ok := spec.Type.(*ast.StructType); ok {
+				for _, key := range sensitiveJSONKeys(fields) {
+					if field := spec.Name.Name + "." + key; !revealedOnPurpose[field] {
+						exposed = append(exposed, field+" in "+filepath.ToSlash(path))
+					}
+				}
+			}
+			return false
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk sources: %v", err)
+	}
+
+	sort.Strings(exposed)
+	for _, field := range exposed {
+		t.Errorf("response field %s is sensitive; map it out of the DTO or allow it in its handler and here", field)
+	}
+}
+
+// sensitiveJSONKeys returns the JSON keys of fields, including those of nested anonymous
+// structs, that the sensitive field guard rejects
+func sensitiveJSONKeys(fields *ast.StructType) []string {
+	var keys []string
+	for _, field := range fields.Fields.List {
+		if nested, ok := field.Type.(*ast.StructType); ok {
+			keys = append(keys, sensitiveJSONKeys(nested)...)
+		}
+		if field.Tag == nil {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		key, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		if len(middleware.ExposedSensitiveFields([]byte(`{`+strconv.Quote(key)+`:null}`), nil)) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,209 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/params"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TasksPath is the prefix of the task queue admin routes on the admin listener
+const TasksPath = apiPrefix + "/tasks"
+
+// TaskDTO is a task with its JSON payload and error history
+type TaskDTO struct {
+	ID          uint                `json:"id"`
+	Type        string              `json:"type"`
+	Status      taskqueue.Status    `json:"status"`
+	Attempts    int                 `json:"attempts"`
+	MaxAttempts int                 `json:"max_attempts"`
+	RunAfter    time.Time           `json:"run_after"`
+	LastError   string              `json:"last_error,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	FinishedAt  *time.Time          `json:"finished_at,omitempty"`
+	Payload     json.RawMessage     `json:"payload,omitempty"`
+	Errors      []taskqueue.Attempt `json:"errors,omitempty"`
+}
+
+// DeadTaskListResponse is a page of dead tasks
+type DeadTaskListResponse struct {
+	Tasks  []TaskDTO `json:"tasks"`
+	Total  int64     `json:"total"`
+	Limit  int       `json:"limit"`
+	Offset int       `json:"offset"`
+}
+
+// BulkRequest selects the dead-lettered items to requeue, replay or discard, up to 100
+type BulkRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1,max=100"`
+}
+
+// BulkResponse reports the items a bulk action applied to and why it failed on the others,
+// by ID
+type BulkResponse struct {
+	Succeeded []uint            `json:"succeeded"`
+	Failed    map[string]string `json:"failed,omitempty"`
+}
+
+// applyBulk binds a BulkRequest and applies action to each of its items in turn; one item
+// failing does not stop the others
+func applyBulk(c *gin.Context, action func(c *gin.Context, id uint) error) {
+	var req BulkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := BulkResponse{Succeeded: make([]uint, 0, len(req.IDs))}
+	for _, id := range req.IDs {
+		if err := action(c, id); err != nil {
+			if response.Failed == nil {
+				response.Failed = make(map[string]string)
+			}
+			response.Failed[strconv.FormatUint(uint64(id), 10)] = err.Error()
+			continue
+		}
+		response.Succeeded = append(response.Succeeded, id)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// toTaskDTO converts a task; the payload is always JSON since Enqueue encodes it
+func toTaskDTO(task *taskqueue.Task) TaskDTO {
+	return TaskDTO{
+		ID:          task.ID,
+		Type:        task.Type,
+		Status:      task.Status,
+		Attempts:    task.Attempts,
+		MaxAttempts: task.MaxAttempts,
+		RunAfter:    task.RunAfter,
+		LastError:   task.LastError,
+		CreatedAt:   task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+		FinishedAt:  task.FinishedAt,
+		Payload:     json.RawMessage(task.Payload),
+	}
+}
+
+// MountTaskAdmin serves the dead-letter routes of the task queue: list dead tasks,
+// inspect a task's payload and error history, requeue or discard a dead task or a selection
+// of them
+func MountTaskAdmin(r *gin.Engine, queue *taskqueue.Queue) {
+	auth := middleware.NewAuthMiddleware("")
+	tasks := r.Group(TasksPath, auth.RequireAuth(), auth.RequirePermission("tasks", "manage"))
+
+	// GET /api/v1/tasks/dead?type=users.import&limit=&offset=
+	tasks.GET("/dead", func(c *gin.Context) {
+		page, err := params.ParsePagination(c.Query("limit"), c.Query("offset"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		dead, total, err := queue.ListDead(c.Request.Context(), c.Query("type"), page.Limit, page.Offset)
+		if err != nil {
+			responses.InternalError(c, err)
+			return
+		}
+		dtos := make([]TaskDTO, len(dead))
+		for i, task := range dead {
+			dtos[i] = toTaskDTO(task)
+		}
+		c.JSON(http.StatusOK, DeadTaskListResponse{Tasks: dtos, Total: total, Limit: page.Limit, Offset: page.Offset})
+	})
+
+	// POST /api/v1/tasks/dead/requeue {"ids": [1, 2]}
+	tasks.POST("/dead/requeue", func(c *gin.Context) {
+		applyBulk(c, func(c *gin.Context, id uint) error {
+			_, err := queue.Requeue(c.Request.Context(), id)
+			return err
+		})
+	})
+
+	// POST /api/v1/tasks/dead/discard {"ids": [1, 2]}
+	tasks.POST("/dead/discard", func(c *gin.Context) {
+		applyBulk(c, func(c *gin.Context, id uint) error {
+			return queue.Discard(c.Request.Context(), id)
+		})
+	})
+
+	// GET /api/v1/tasks/:id
+	tasks.GET("/:id", func(c *gin.Context) {
+		id, ok := taskID(c)
+		if !ok {
+			return
+		}
+		task, err := queue.Get(c.Request.Context(), id)
+		if err != nil {
+			respondTaskError(c, err)
+			return
+		}
+		history, err := queue.Attempts(c.Request.Context(), id)
+		if err != nil {
+			responses.InternalError(c, err)
+			return
+		}
+
+		dto := toTaskDTO(task)
+		dto.Errors = history
+		c.JSON(http.StatusOK, dto)
+	})
+
+	// POST /api/v1/tasks/:id/requeue
+	tasks.POST("/:id/requeue", func(c *gin.Context) {
+		id, ok := taskID(c)
+		if !ok {
+			return
+		}
+		task, err := queue.Requeue(c.Request.Context(), id)
+		if err != nil {
+			respondTaskError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, toTaskDTO(task))
+	})
+
+	// DELETE /api/v1/tasks/:id
+	tasks.DELETE("/:id", func(c *gin.Context) {
+		id, ok := taskID(c)
+		if !ok {
+			return
+		}
+		if err := queue.Discard(c.Request.Context(), id); err != nil {
+			respondTaskError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+}
+
+// taskID parses the :id path parameter, responding 400 when it is invalid
+func taskID(c *gin.Context) (uint, bool) {
+	id, err := params.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// respondTaskError maps task queue errors to HTTP responses
+func respondTaskError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, taskqueue.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, taskqueue.ErrTaskNotDead):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		responses.InternalError(c, err)
+	}
+}
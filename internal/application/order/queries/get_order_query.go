@@ -0,0 +1,70 @@
+package queries
+
+import (
+	"context"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+)
+
+// GetOrderQuery represents a query to get an order by ID
+type GetOrderQuery struct {
+	OrderID uint
+}
+
+// GetOrderQueryHandler handles GetOrderQuery
+type GetOrderQueryHandler struct {
+	orderRepo orderRepositories.OrderRepository
+}
+
+// NewGetOrderQueryHandler creates a new query handler
+func NewGetOrderQueryHandler(orderRepo orderRepositories.OrderRepository) *GetOrderQueryHandler {
+	return &GetOrderQueryHandler{
+		orderRepo: orderRepo,
+	}
+}
+
+// Handle executes the get order query
+func (h *GetOrderQueryHandler) Handle(ctx context.Context, query GetOrderQuery) (*orderEntities.Order, error) {
+	if query.OrderID == 0 {
+		return nil, orderEntities.ErrOrderNotFound
+	}
+
+	return h.orderRepo.GetByID(ctx, query.OrderID)
+}
+
+// GetUserOrdersQuery represents a query to page through a user's orders
+type GetUserOrdersQuery struct {
+	UserID uint
+	Limit  int
+	Offset int
+}
+
+// GetUserOrdersQueryHandler handles GetUserOrdersQuery
+type GetUserOrdersQueryHandler struct {
+	orderRepo orderRepositories.OrderRepository
+}
+
+// NewGetUserOrdersQueryHandler creates a new query handler
+func NewGetUserOrdersQueryHandler(orderRepo orderRepositories.OrderRepository) *GetUserOrdersQueryHandler {
+	return &GetUserOrdersQueryHandler{
+		orderRepo: orderRepo,
+	}
+}
+
+// Handle executes the get user orders query
+func (h *GetUserOrdersQueryHandler) Handle(ctx context.Context, query GetUserOrdersQuery) ([]*orderEntities.Order, error) {
+	if query.UserID == 0 {
+		return nil, orderEntities.ErrInvalidUserID
+	}
+
+	// Apply default values
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	return h.orderRepo.GetByUserID(ctx, query.UserID, query.Limit, query.Offset)
+}
@@ -0,0 +1,59 @@
+package queries
+
+import (
+	"context"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderRepositories "clean-arch-gin/internal/domain/order/repositories"
+)
+
+// SearchOrdersQuery represents a query to search a user's orders by public ID, optionally
+// in one status
+type SearchOrdersQuery struct {
+	Query  string
+	UserID uint
+	Status orderEntities.OrderStatus
+	Limit  int
+	Offset int
+}
+
+// SearchOrdersResult is a page of the orders matching a SearchOrdersQuery and their total
+type SearchOrdersResult struct {
+	Hits  []*orderEntities.OrderSearchHit
+	Total int64
+	Terms []string
+}
+
+// SearchOrdersQueryHandler handles SearchOrdersQuery
+type SearchOrdersQueryHandler struct {
+	searchRepo orderRepositories.OrderSearchRepository
+}
+
+// NewSearchOrdersQueryHandler creates a new query handler
+func NewSearchOrdersQueryHandler(searchRepo orderRepositories.OrderSearchRepository) *SearchOrdersQueryHandler {
+	return &SearchOrdersQueryHandler{
+		searchRepo: searchRepo,
+	}
+}
+
+// Handle executes the search orders query
+func (h *SearchOrdersQueryHandler) Handle(ctx context.Context, query SearchOrdersQuery) (*SearchOrdersResult, error) {
+	criteria, err := orderEntities.NewOrderSearch(query.Query, query.UserID, query.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply default values
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	hits, total, err := h.searchRepo.Search(ctx, *criteria, query.Limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchOrdersResult{Hits: hits, Total: total, Terms: criteria.Terms}, nil
+}
@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"context"
+
 	userEntities "clean-arch-gin/internal/domain/user/entities"
 	userRepositories "clean-arch-gin/internal/domain/user/repositories"
 )
@@ -26,18 +28,14 @@ func NewCreateUserCommandHandler(userRepo userRepositories.UserRepository) *Crea
 }
 
 // Handle executes the create user command
-func (h *CreateUserCommandHandler) Handle(cmd CreateUserCommand) (*userEntities.User, error) {
+func (h *CreateUserCommandHandler) Handle(ctx context.Context, cmd CreateUserCommand) (*userEntities.User, error) {
 	// Business logic validation
 	if err := h.validateCommand(cmd); err != nil {
 		return nil, err
 	}
 
 	// Check if user already exists
-	_, err := h.userRepo.GetByEmail(cmd.Email)
-	if err == nil {
-		return nil, userEntities.ErrEmailExists
-	}
-	if err != userEntities.ErrUserNotFound {
+	if err := userRepositories.CheckEmailAvailable(ctx, h.userRepo, nil, cmd.Email); err != nil {
 		return nil, err
 	}
 
@@ -48,7 +46,7 @@ func (h *CreateUserCommandHandler) Handle(cmd CreateUserCommand) (*userEntities.
 	}
 
 	// Persist the user
-	if err := h.userRepo.Create(user); err != nil {
+	if err := h.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
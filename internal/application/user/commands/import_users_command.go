@@ -0,0 +1,251 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/mail"
+	"sort"
+	"strconv"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+const (
+	// DefaultImportBatchSize is the number of users inserted per statement
+	DefaultImportBatchSize = 500
+	// MaxImportBatchSize caps ImportUsersCommand.BatchSize
+	MaxImportBatchSize = 5000
+	// maxReportedImportErrors caps the row errors kept in the report; the counts stay exact
+	maxReportedImportErrors = 1000
+	// maxImportFieldLength matches the user column sizes
+	maxImportFieldLength = 255
+	// minImportPasswordLength mirrors CreateUserCommand
+	minImportPasswordLength = 8
+)
+
+// ImportRow is one parsed input row
+type ImportRow struct {
+	// Line is the 1-based row number in the file, counting the header
+	Line     int
+	Email    string
+	Name     string
+	Password string
+}
+
+// ImportRowReader yields rows one at a time and returns io.EOF after the last one
+// A *ImportRowError return reports a malformed row; reading continues with the next one
+type ImportRowReader interface {
+	Next() (ImportRow, error)
+}
+
+// UserImportStore persists imported users
+type UserImportStore interface {
+	// ExistingEmails returns which of the emails already belong to a user, including soft-deleted ones
+	ExistingEmails(ctx context.Context, emails []string) (map[string]bool, error)
+	// CreateBatch inserts users and assigns their IDs; a *BatchError from the shared entities
+	// names the users that were not inserted, any other error means none were
+	CreateBatch(ctx context.Context, users []*userEntities.User) error
+}
+
+// ImportUsersCommand represents a command to create users from an uploaded file
+type ImportUsersCommand struct {
+	Rows ImportRowReader
+	// BatchSize is the number of users per insert statement; 0 uses DefaultImportBatchSize
+	BatchSize int
+	// Progress, when set, is called with the number of rows processed after each batch
+	Progress func(processed int)
+}
+
+// ImportRowError reports why a row was not imported
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Email   string `json:"email,omitempty"`
+	Message string `json:"error"`
+}
+
+func (e *ImportRowError) Error() string {
+	return "line " + strconv.Itoa(e.Line) + ": " + e.Message
+}
+
+// Import validation errors, in addition to the user entity errors
+var (
+	ErrImportInvalidEmail = sharedEntities.DomainError{Message: "email is not a valid address"}
+	ErrImportFieldTooLong = sharedEntities.DomainError{Message: "email, name and password must be at most 255 characters"}
+)
+
+// ImportUsersResult is the row-level report of an import
+type ImportUsersResult struct {
+	Total    int              `json:"total"`
+	Imported int              `json:"imported"`
+	Failed   int              `json:"failed"`
+	Errors   []ImportRowError `json:"errors"`
+	// ErrorsTruncated is set when more rows failed than are listed in Errors
+	ErrorsTruncated bool `json:"errors_truncated"`
+}
+
+// ImportUsersCommandHandler handles ImportUsersCommand
+type ImportUsersCommandHandler struct {
+	store UserImportStore
+}
+
+// NewImportUsersCommandHandler creates a new command handler
+func NewImportUsersCommandHandler(store UserImportStore) *ImportUsersCommandHandler {
+	return &ImportUsersCommandHandler{
+		store: store,
+	}
+}
+
+// pendingUser is a validated row waiting for its batch to be written
+type pendingUser struct {
+	line int
+	user *userEntities.User
+}
+
+// Handle streams the rows, validates each and inserts the valid ones in batches
+// Invalid rows and rows of a failed batch are reported and do not stop the import;
+// the returned error is only set when reading the input fails
+func (h *ImportUsersCommandHandler) Handle(ctx context.Context, cmd ImportUsersCommand) (*ImportUsersResult, error) {
+	batchSize := cmd.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultImportBatchSize
+	}
+	if batchSize > MaxImportBatchSize {
+		batchSize = MaxImportBatchSize
+	}
+
+	result := &ImportUsersResult{Errors: []ImportRowError{}}
+	seen := make(map[string]int)
+	batch := make([]pendingUser, 0, batchSize)
+
+	for {
+		row, err := cmd.Rows.Next()
+		if err == io.EOF {
+			break
+		}
+		var rowErr *ImportRowError
+		if errors.As(err, &rowErr) {
+			result.Total++
+			h.fail(result, *rowErr)
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+
+		result.Total++
+		user, err := validateImportRow(row)
+		if err != nil {
+			h.fail(result, ImportRowError{Line: row.Line, Email: row.Email, Message: err.Error()})
+			continue
+		}
+		if first, ok := seen[user.Email]; ok {
+			h.fail(result, ImportRowError{Line: row.Line, Email: row.Email, Message: duplicateInFile(first)})
+			continue
+		}
+		seen[user.Email] = row.Line
+
+		batch = append(batch, pendingUser{line: row.Line, user: user})
+		if len(batch) == batchSize {
+			h.flush(ctx, result, batch)
+			batch = batch[:0]
+			if cmd.Progress != nil {
+				cmd.Progress(result.Total)
+			}
+		}
+	}
+
+	if len(batch) > 0 {
+		h.flush(ctx, result, batch)
+	}
+	// Rows rejected at flush time were reported after later rows
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Line < result.Errors[j].Line
+	})
+	return result, nil
+}
+
+// flush writes a batch, skipping rows whose email is already taken
+func (h *ImportUsersCommandHandler) flush(ctx context.Context, result *ImportUsersResult, batch []pendingUser) {
+	emails := make([]string, len(batch))
+	for i, p := range batch {
+		emails[i] = p.user.Email
+	}
+
+	existing, err := h.store.ExistingEmails(ctx, emails)
+	if err != nil {
+		h.failBatch(result, batch, err)
+		return
+	}
+
+	users := make([]*userEntities.User, 0, len(batch))
+	fresh := make([]pendingUser, 0, len(batch))
+	for _, p := range batch {
+		if existing[p.user.Email] {
+			h.fail(result, ImportRowError{Line: p.line, Email: p.user.Email, Message: userEntities.ErrEmailExists.Error()})
+			continue
+		}
+		users = append(users, p.user)
+		fresh = append(fresh, p)
+	}
+	if len(users) == 0 {
+		return
+	}
+
+	err = h.store.CreateBatch(ctx, users)
+	var batchErr *sharedEntities.BatchError
+	if errors.As(err, &batchErr) {
+		for _, record := range batchErr.Records {
+			p := fresh[record.Index]
+			h.fail(result, ImportRowError{Line: p.line, Email: p.user.Email, Message: record.Err.Error()})
+		}
+		result.Imported += len(users) - len(batchErr.Records)
+		return
+	}
+	if err != nil {
+		h.failBatch(result, fresh, err)
+		return
+	}
+	result.Imported += len(users)
+}
+
+// failBatch reports every row of a batch that was rolled back
+func (h *ImportUsersCommandHandler) failBatch(result *ImportUsersResult, batch []pendingUser, err error) {
+	for _, p := range batch {
+		h.fail(result, ImportRowError{Line: p.line, Email: p.user.Email, Message: "batch rolled back: " + err.Error()})
+	}
+}
+
+// fail counts a failed row and records it while the report has room
+func (h *ImportUsersCommandHandler) fail(result *ImportUsersResult, rowErr ImportRowError) {
+	result.Failed++
+	if len(result.Errors) < maxReportedImportErrors {
+		result.Errors = append(result.Errors, rowErr)
+	} else {
+		result.ErrorsTruncated = true
+	}
+}
+
+// validateImportRow applies the same rules as the create user endpoint
+func validateImportRow(row ImportRow) (*userEntities.User, error) {
+	user, err := userEntities.NewUser(row.Email, row.Name, row.Password)
+	if err != nil {
+		return nil, err
+	}
+	if addr, err := mail.ParseAddress(row.Email); err != nil || addr.Address != row.Email {
+		return nil, ErrImportInvalidEmail
+	}
+	if len(row.Email) > maxImportFieldLength || len(row.Name) > maxImportFieldLength || len(row.Password) > maxImportFieldLength {
+		return nil, ErrImportFieldTooLong
+	}
+	if len(row.Password) < minImportPasswordLength {
+		return nil, userEntities.ErrInvalidPassword
+	}
+	return user, nil
+}
+
+// duplicateInFile describes a repeated email
+func duplicateInFile(firstLine int) string {
+	return "duplicate email, first seen on line " + strconv.Itoa(firstLine)
+}
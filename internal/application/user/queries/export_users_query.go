@@ -0,0 +1,132 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+const (
+	// DefaultExportBatchSize is the number of users read per query
+	DefaultExportBatchSize = 1000
+	// maxExportFilterLength matches the user column sizes
+	maxExportFilterLength = 255
+)
+
+// ExportFilter selects the users to export; empty fields match every user
+// Soft-deleted users are never exported
+type ExportFilter struct {
+	// Email and Name match substrings
+	Email  string              `json:"email,omitempty"`
+	Name   string              `json:"name,omitempty"`
+	Role   userEntities.Role   `json:"role,omitempty"`
+	Status userEntities.Status `json:"status,omitempty"`
+	// CreatedFrom and CreatedTo bound the creation time, inclusive and exclusive
+	CreatedFrom *time.Time `json:"created_from,omitempty"`
+	CreatedTo   *time.Time `json:"created_to,omitempty"`
+}
+
+// Export filter errors
+var (
+	ErrExportFilterTooLong    = sharedEntities.DomainError{Message: "email and name filters must be at most 255 characters"}
+	ErrExportInvalidDateRange = sharedEntities.DomainError{Message: "created_from must be before created_to"}
+)
+
+// Validate checks the filter before an export is queued
+func (f ExportFilter) Validate() error {
+	if len(f.Email) > maxExportFilterLength || len(f.Name) > maxExportFilterLength {
+		return ErrExportFilterTooLong
+	}
+	if f.Role != "" && !f.Role.Valid() {
+		return userEntities.ErrInvalidRole
+	}
+	if f.Status != "" && !f.Status.Valid() {
+		return userEntities.ErrInvalidStatus
+	}
+	if f.CreatedFrom != nil && f.CreatedTo != nil && !f.CreatedFrom.Before(*f.CreatedTo) {
+		return ErrExportInvalidDateRange
+	}
+	return nil
+}
+
+// UserExportSource reads the users to export
+type UserExportSource interface {
+	// Count returns how many users match the filter
+	Count(ctx context.Context, filter ExportFilter) (int64, error)
+	// NextBatch returns up to limit matching users with IDs above afterID, in ID order
+	NextBatch(ctx context.Context, filter ExportFilter, afterID uint, limit int) ([]*userEntities.User, error)
+}
+
+// ExportRowWriter writes exported users in a file format
+type ExportRowWriter interface {
+	Write(user *userEntities.User) error
+}
+
+// ExportUsersQuery represents a query writing the users matching a filter to a file
+type ExportUsersQuery struct {
+	Filter ExportFilter
+	Rows   ExportRowWriter
+	// BatchSize is the number of users per query; 0 uses DefaultExportBatchSize
+	BatchSize int
+	// Progress, when set, is called with the number of users written and the total
+	// after each batch
+	Progress func(written, total int)
+	// AfterID and Written resume an export cut short: the users up to AfterID, Written of
+	// them, were already written
+	AfterID uint
+	Written int
+}
+
+// ExportUsersQueryHandler handles ExportUsersQuery
+type ExportUsersQueryHandler struct {
+	source UserExportSource
+}
+
+// NewExportUsersQueryHandler creates a new query handler
+func NewExportUsersQueryHandler(source UserExportSource) *ExportUsersQueryHandler {
+	return &ExportUsersQueryHandler{
+		source: source,
+	}
+}
+
+// Handle writes the matching users in ID order and returns how many were written, including
+// those of the run it resumes
+// Users are paged by ID rather than offset, so rows created during the export neither
+// shift nor repeat the pages
+func (h *ExportUsersQueryHandler) Handle(ctx context.Context, query ExportUsersQuery) (int, error) {
+	if err := query.Filter.Validate(); err != nil {
+		return 0, err
+	}
+	batchSize := query.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultExportBatchSize
+	}
+
+	total, err := h.source.Count(ctx, query.Filter)
+	if err != nil {
+		return 0, err
+	}
+
+	written, afterID := query.Written, query.AfterID
+	for {
+		users, err := h.source.NextBatch(ctx, query.Filter, afterID, batchSize)
+		if err != nil {
+			return written, err
+		}
+		for _, user := range users {
+			if err := query.Rows.Write(user); err != nil {
+				return written, err
+			}
+			written++
+			afterID = user.ID
+		}
+		if query.Progress != nil && len(users) > 0 {
+			query.Progress(written, int(total))
+		}
+		if len(users) < batchSize {
+			return written, nil
+		}
+	}
+}
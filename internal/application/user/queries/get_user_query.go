@@ -1,6 +1,8 @@
 package queries
 
 import (
+	"context"
+
 	userEntities "clean-arch-gin/internal/domain/user/entities"
 	userRepositories "clean-arch-gin/internal/domain/user/repositories"
 )
@@ -25,12 +27,12 @@ func NewGetUserQueryHandler(userRepo userRepositories.UserRepository) *GetUserQu
 }
 
 // Handle executes the get user query
-func (h *GetUserQueryHandler) Handle(query GetUserQuery) (*userEntities.User, error) {
+func (h *GetUserQueryHandler) Handle(ctx context.Context, query GetUserQuery) (*userEntities.User, error) {
 	if query.UserID == 0 {
 		return nil, userEntities.ErrInvalidEmail // Reusing error for invalid ID
 	}
 
-	user, err := h.userRepo.GetByID(query.UserID)
+	user, err := h.userRepo.GetByID(ctx, query.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +64,7 @@ func NewGetUsersQueryHandler(userRepo userRepositories.UserRepository) *GetUsers
 }
 
 // Handle executes the get users query
-func (h *GetUsersQueryHandler) Handle(query GetUsersQuery) ([]*userEntities.User, error) {
+func (h *GetUsersQueryHandler) Handle(ctx context.Context, query GetUsersQuery) ([]*userEntities.User, error) {
 	// Apply default values
 	if query.Limit <= 0 {
 		query.Limit = 10
@@ -73,7 +75,7 @@ func (h *GetUsersQueryHandler) Handle(query GetUsersQuery) ([]*userEntities.User
 
 	// In a real implementation, you might have more sophisticated
 	// filtering and sorting capabilities
-	users, err := h.userRepo.GetAll(query.Limit, query.Offset)
+	users, err := h.userRepo.GetAll(ctx, query.Limit, query.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -110,11 +112,11 @@ func NewGetUserStatsQueryHandler(userRepo userRepositories.UserRepository) *GetU
 }
 
 // Handle executes the user stats query
-func (h *GetUserStatsQueryHandler) Handle(query UserStatsQuery) (*UserStatsResult, error) {
+func (h *GetUserStatsQueryHandler) Handle(ctx context.Context, query UserStatsQuery) (*UserStatsResult, error) {
 	// In a real implementation, this would execute complex queries
 	// potentially against read-optimized databases or data warehouses
 
-	totalUsers, err := h.userRepo.Count()
+	totalUsers, err := h.userRepo.Count(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"context"
+	"log"
+
+	"clean-arch-gin/internal/app"
+	"clean-arch-gin/internal/di"
+	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/kafka"
+	"clean-arch-gin/internal/infrastructure/leader"
+	"clean-arch-gin/internal/infrastructure/outbox"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+	"clean-arch-gin/internal/modules"
+)
+
+// background holds what a process runs besides serving requests: the module loops, the task
+// workers, the Kafka consumer, the outbox relay, and the scheduled jobs with the leader
+// election they run under
+type background struct {
+	cfg      *config.Config
+	registry *modules.ModuleRegistry
+	// elector is nil without LEADER_ELECTION
+	elector *leader.Elector
+	queue   *taskqueue.Queue
+	// consumer is nil without KAFKA_BROKERS
+	consumer *kafka.Consumer
+	// relay is nil without OUTBOX_ENABLED
+	relay  *outbox.Relay
+	jobs   *scheduler.Scheduler
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// newBackground creates the task queue and the scheduler of application without starting them
+func newBackground(cfg *config.Config, application *di.Application) (*background, error) {
+	db, registry := application.DB, application.Registry
+
+	// Periodic jobs of the modules, run by the elected leader when LEADER_ELECTION is set
+	elector, err := app.NewLeaderElector(cfg, db, application.Redis)
+	if err != nil {
+		return nil, err
+	}
+	// Durable tasks of the modules, processed on every replica running workers
+	queue, err := app.NewTaskQueue(cfg, db, registry)
+	if err != nil {
+		return nil, err
+	}
+	// Topic handlers of the modules, sharing the partitions with the other replicas' workers
+	consumer, err := app.NewKafkaConsumer(cfg, registry)
+	if err != nil {
+		return nil, err
+	}
+	// Domain events recorded in the outbox, relayed to Kafka by one replica at a time
+	relay, err := app.NewOutboxRelay(cfg, db, application.Locks)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := app.NewScheduler(cfg, db, registry, elector, queue, application.Locks)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &background{
+		cfg:      cfg,
+		registry: registry,
+		elector:  elector,
+		queue:    queue,
+		consumer: consumer,
+		relay:    relay,
+		jobs:     jobs,
+		ctx:      ctx,
+		cancel:   cancel,
+	}, nil
+}
+
+// startWorkers starts the module loops (webhook delivery), the task workers, the Kafka
+// consumer and the outbox relay; it fails when the consumer cannot reach the brokers
+func (b *background) startWorkers() error {
+	b.registry.StartAllWorkers(b.ctx)
+	if b.cfg.Tasks.Workers > 0 {
+		b.queue.Start(b.ctx)
+	}
+	if b.consumer != nil {
+		if err := b.consumer.Start(b.ctx); err != nil {
+			return err
+		}
+		log.Printf("📨 Consuming Kafka topics %v in group %s", b.consumer.Topics(), b.cfg.Kafka.GroupID)
+	}
+	if b.relay != nil {
+		b.relay.Start(b.ctx)
+		log.Printf("📤 Relaying the outbox to Kafka topic %s", b.cfg.Outbox.Topic)
+	}
+	return nil
+}
+
+// startJobs starts the scheduled jobs when the scheduler is enabled, only while this process
+// leads when LEADER_ELECTION is set
+func (b *background) startJobs() {
+	if !b.cfg.Scheduler.Enabled {
+		return
+	}
+	if b.elector != nil {
+		b.elector.Start(b.ctx)
+		log.Printf("🗳️ Running scheduled jobs only while %s leads (%s)", b.elector.ID(), b.cfg.Leader.Backend)
+	}
+	b.jobs.Start(b.ctx)
+}
+
+// stop asks the module loops, the task workers, the consumer, the relay and the scheduled jobs
+// to stop
+func (b *background) stop() {
+	b.cancel()
+}
+
+// shutdown waits for the tasks, the messages under way, the batch being relayed and the
+// scheduled jobs to finish and releases the lease
+func (b *background) shutdown(ctx context.Context) {
+	b.cancel()
+	if err := b.queue.Shutdown(ctx); err != nil {
+		log.Printf("Tasks did not finish: %v", err)
+	}
+	if b.consumer != nil {
+		if err := b.consumer.Shutdown(ctx); err != nil {
+			log.Printf("Kafka consumer did not finish: %v", err)
+		}
+	}
+	if b.relay != nil {
+		if err := b.relay.Shutdown(ctx); err != nil {
+			log.Printf("Outbox relay did not finish: %v", err)
+		}
+	}
+	if err := b.jobs.Shutdown(ctx); err != nil {
+		log.Printf("Scheduled jobs did not finish: %v", err)
+	}
+	if b.elector != nil {
+		if err := b.elector.Shutdown(ctx); err != nil {
+			log.Printf("Leader lease not released: %v", err)
+		}
+	}
+}
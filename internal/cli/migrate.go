@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"clean-arch-gin/internal/di"
+
+	"github.com/spf13/cobra"
+)
+
+// newMigrateCommand creates the command migrating the schema of the modules
+func newMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the schema of the modules",
+	}
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Run the migrations of every module",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				// Setup migrates every module
+				_, application, closeApplication, err := initialize(true)
+				if err != nil {
+					return err
+				}
+				defer closeApplication()
+				return printMigrations(cmd.OutOrStdout(), application)
+			},
+		},
+		&cobra.Command{
+			Use:   "down <module>",
+			Short: "Roll back the migrations of a module, dropping its tables",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				_, application, closeApplication, err := initialize(false)
+				if err != nil {
+					return err
+				}
+				defer closeApplication()
+				if err := application.Registry.Rollback(application.DB, args[0]); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Rolled back module %s\n", args[0])
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "Show which modules migrated and which can be rolled back",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				_, application, closeApplication, err := initialize(false)
+				if err != nil {
+					return err
+				}
+				defer closeApplication()
+				return printMigrations(cmd.OutOrStdout(), application)
+			},
+		},
+	)
+	return cmd
+}
+
+// printMigrations writes a table of the migration status of every module
+func printMigrations(w io.Writer, application *di.Application) error {
+	statuses, err := application.Registry.MigrationStatuses(application.DB)
+	if err != nil {
+		return fmt.Errorf("failed to read migration history: %w", err)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODULE\tMIGRATED AT\tREVERSIBLE")
+	for _, status := range statuses {
+		migratedAt := "pending"
+		if status.MigratedAt != nil {
+			migratedAt = status.MigratedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%t\n", status.Module, migratedAt, status.Reversible)
+	}
+	return tw.Flush()
+}
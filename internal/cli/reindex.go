@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"clean-arch-gin/internal/app"
+	"clean-arch-gin/internal/domain/shared/search"
+
+	"github.com/spf13/cobra"
+)
+
+// newReindexCommand creates the command rebuilding the search indexes from the database
+func newReindexCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reindex [index...]",
+		Short: "Rebuild search indexes from the database",
+		Long: "Rebuild the named search indexes, or all of them, from the database, e.g. after changing\n" +
+			"their mappings or while the search engine missed events. Each is loaded into a new index\n" +
+			"that then replaces the old one, so searches keep working meanwhile.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, application, closeApplication, err := initialize(false)
+			if err != nil {
+				return err
+			}
+			defer closeApplication()
+			client, err := app.NewSearchClient(cfg)
+			if err != nil {
+				return err
+			}
+			if client == nil {
+				return errors.New("search is off; set SEARCH_PROVIDER")
+			}
+
+			indexes, err := selectIndexes(application.Registry.SearchIndexes(), args)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			for _, index := range indexes {
+				count, err := client.Reindex(ctx, index)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Reindexed %s: %d documents\n", index.Name, count)
+			}
+			return nil
+		},
+	}
+}
+
+// selectIndexes returns the indexes named by names, or all of them without names
+func selectIndexes(indexes []search.Index, names []string) ([]search.Index, error) {
+	if len(names) == 0 {
+		return indexes, nil
+	}
+	byName := make(map[string]search.Index, len(indexes))
+	for _, index := range indexes {
+		byName[index.Name] = index
+	}
+	selected := make([]search.Index, 0, len(names))
+	for _, name := range names {
+		index, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown search index %q", name)
+		}
+		selected = append(selected, index)
+	}
+	return selected, nil
+}
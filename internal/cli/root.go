@@ -0,0 +1,103 @@
+// Package cli is the server's command line: serve, migrate, seed, worker, routes and reindex
+// share the configuration and the Wire application graph, so ops can run targeted processes
+// from one binary
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"clean-arch-gin/internal/app"
+	"clean-arch-gin/internal/di"
+	"clean-arch-gin/internal/domain/shared/passwords"
+	"clean-arch-gin/internal/domain/shared/publicid"
+	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/fieldcrypt"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+)
+
+// NewRootCommand creates the command with every subcommand
+func NewRootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "clean-arch-gin",
+		Short:         "Large-scale modular Gin server",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	root.AddCommand(
+		newServeCommand(),
+		newMigrateCommand(),
+		newSeedCommand(),
+		newWorkerCommand(),
+		newRoutesCommand(),
+		newReindexCommand(),
+	)
+	return root
+}
+
+// Execute runs the command named by the arguments, logging its error
+func Execute() error {
+	err := NewRootCommand().Execute()
+	if err != nil {
+		log.Printf("❌ %v", err)
+	}
+	return err
+}
+
+// loadConfig loads the environment, from .env when there is one, and sets the process-wide
+// encryption keys and public ID format before anything touches the database
+func loadConfig() (*config.Config, error) {
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using system environment variables")
+	}
+	cfg := config.NewConfig()
+
+	// Personal data columns are encrypted with the configured keys
+	keyring, err := app.NewKeyring(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encryption keys: %w", err)
+	}
+	fieldcrypt.Use(keyring)
+
+	// Users and orders are given public IDs in the configured format
+	if err := publicid.SetFormat(publicid.Format(cfg.PublicIDs.Format)); err != nil {
+		return nil, fmt.Errorf("invalid PUBLIC_ID_FORMAT: %w", err)
+	}
+
+	// Passwords are hashed with the configured work factor
+	if err := passwords.SetCost(cfg.Passwords.HashCost); err != nil {
+		return nil, fmt.Errorf("invalid PASSWORD_HASH_COST: %w", err)
+	}
+	return cfg, nil
+}
+
+// initialize loads the configuration and wires the application; setup also initializes the
+// modules, runs their migrations and creates their search indexes. The cleanup closes the
+// database
+func initialize(setup bool) (*config.Config, *di.Application, func(), error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Database, event bus, query cache, signing keys and every module, wired by Wire; the
+	// event bus logs CloudEvents when EVENTS_LOG_CLOUDEVENTS is set
+	application, closeApplication, err := di.InitializeApplication(cfg)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to initialize application: %w", err)
+	}
+	if setup {
+		if err := app.Setup(application.DB, application.Registry, application.Locks); err != nil {
+			closeApplication()
+			return nil, nil, nil, fmt.Errorf("failed to set up modules: %w", err)
+		}
+		if err := app.EnsureSearchIndexes(context.Background(), cfg, application.Registry); err != nil {
+			closeApplication()
+			return nil, nil, nil, fmt.Errorf("failed to set up search indexes: %w", err)
+		}
+	}
+	return cfg, application, closeApplication, nil
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"clean-arch-gin/internal/app"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cobra"
+)
+
+// newRoutesCommand creates the command listing the HTTP routes the server mounts
+func newRoutesCommand() *cobra.Command {
+	var (
+		module     string
+		middleware bool
+		asJSON     bool
+	)
+	cmd := &cobra.Command{
+		Use:   "routes",
+		Short: "List the HTTP routes of the public router and the admin listener",
+		Long: "List every HTTP route with its method, path and owning module, as served on GET " + app.RoutesPath + ".\n" +
+			"Nothing is served and no migrations run.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, application, closeApplication, err := initialize(false)
+			if err != nil {
+				return err
+			}
+			// Keep gin's route debug output out of the listing
+			cfg.Server.Mode = gin.ReleaseMode
+			defer closeApplication()
+			// Routes are mounted after the modules initialize, without migrating
+			if err := application.Registry.InitializeAll(); err != nil {
+				return fmt.Errorf("failed to initialize modules: %w", err)
+			}
+
+			bg, err := newBackground(cfg, application)
+			if err != nil {
+				return err
+			}
+			routers, err := bootstrap(cfg, application, bg, app.NewHealthChecker(cfg, application.DB, application.Registry, application.Redis))
+			if err != nil {
+				return err
+			}
+
+			var entries []app.RouteEntry
+			for _, entry := range app.RouteInventory(application.Registry, routers.Listeners()...) {
+				if module == "" || strings.EqualFold(entry.Module, module) {
+					entries = append(entries, entry)
+				}
+			}
+			if asJSON {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(entries)
+			}
+			return printRoutes(cmd, entries, middleware)
+		},
+	}
+	cmd.Flags().StringVar(&module, "module", "", "only the routes of this module, or system")
+	cmd.Flags().BoolVarP(&middleware, "middleware", "m", false, "show the middleware of every route")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print the inventory as JSON")
+	return cmd
+}
+
+// printRoutes writes a table of entries, with their middleware when middleware is set
+func printRoutes(cmd *cobra.Command, entries []app.RouteEntry, middleware bool) error {
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	header := "LISTENER\tMETHOD\tPATH\tMODULE\tHANDLER"
+	if middleware {
+		header += "\tMIDDLEWARE"
+	}
+	fmt.Fprintln(tw, header)
+	for _, entry := range entries {
+		line := fmt.Sprintf("%s\t%s\t%s\t%s\t%s", entry.Listener, entry.Method, entry.Path, entry.Module, entry.Handler)
+		if middleware {
+			line += "\t" + strings.Join(entry.Middleware, ", ")
+		}
+		fmt.Fprintln(tw, line)
+	}
+	return tw.Flush()
+}
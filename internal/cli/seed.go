@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"clean-arch-gin/internal/di"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+
+	"github.com/spf13/cobra"
+)
+
+// newSeedCommand creates the command seeding development data
+func newSeedCommand() *cobra.Command {
+	var (
+		users    int
+		password string
+		tenantID uint
+	)
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Seed an admin and sample users, running migrations first",
+		Long: "Seed admin@example.com and user1@example.com to userN@example.com, running migrations first.\n" +
+			"Users are keyed by email, so seeding again overwrites them instead of adding more.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, application, closeApplication, err := initialize(true)
+			if err != nil {
+				return err
+			}
+			defer closeApplication()
+
+			components, err := di.InitializeUserComponents(cfg, application.DB, application.Bus, application.Redis)
+			if err != nil {
+				return fmt.Errorf("failed to initialize users: %w", err)
+			}
+			ctx := tenancy.NewContext(context.Background(), tenantID)
+
+			created, updated := 0, 0
+			for i := 0; i <= users; i++ {
+				email, name, role := fmt.Sprintf("user%d@example.com", i), fmt.Sprintf("User %d", i), userEntities.RoleUser
+				if i == 0 {
+					email, name, role = "admin@example.com", "Admin", userEntities.RoleAdmin
+				}
+				user, err := userEntities.NewUser(email, name, password)
+				if err != nil {
+					return err
+				}
+				user.Role = role
+				inserted, err := components.Repository.Upsert(ctx, user)
+				if err != nil {
+					return fmt.Errorf("failed to seed %s: %w", email, err)
+				}
+				if inserted {
+					created++
+				} else {
+					updated++
+				}
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Seeded tenant %d: %d users created, %d updated\n", tenantID, created, updated)
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&users, "users", 10, "number of sample users besides the admin")
+	cmd.Flags().StringVar(&password, "password", "password", "password of every seeded user")
+	cmd.Flags().UintVar(&tenantID, "tenant", tenantEntities.DefaultTenantID, "tenant to seed")
+	return cmd
+}
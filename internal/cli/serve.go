@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"clean-arch-gin/internal/app"
+	"clean-arch-gin/internal/di"
+	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/grpcserver"
+	"clean-arch-gin/internal/infrastructure/health"
+
+	"github.com/spf13/cobra"
+)
+
+// newServeCommand creates the command serving HTTP, gRPC and the admin listener
+func newServeCommand() *cobra.Command {
+	var workers bool
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve HTTP, gRPC and the admin listener, running migrations first",
+		Long: "Serve HTTP, gRPC and the admin listener until SIGINT or SIGTERM, running migrations first.\n" +
+			"Scheduled jobs run as configured; --workers=false leaves the module loops and the task\n" +
+			"workers to dedicated worker processes.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return serve(workers)
+		},
+	}
+	cmd.Flags().BoolVar(&workers, "workers", true, "run the module loops and the task workers in this process")
+	return cmd
+}
+
+// serve serves until SIGINT or SIGTERM, then drains in order: readiness, the modules and
+// the HTTP and gRPC servers, the admin listener, the background work and the database
+func serve(workers bool) error {
+	cfg, application, closeApplication, err := initialize(true)
+	if err != nil {
+		return err
+	}
+	defer closeApplication()
+	registry := application.Registry
+
+	bg, err := newBackground(cfg, application)
+	if err != nil {
+		return err
+	}
+	if workers {
+		if err := bg.startWorkers(); err != nil {
+			return err
+		}
+	}
+	bg.startJobs()
+
+	// Health checks shared by the HTTP probes and the gRPC health service
+	checker := app.NewHealthChecker(cfg, application.DB, registry, application.Redis)
+
+	routers, err := bootstrap(cfg, application, bg, checker)
+	if err != nil {
+		return err
+	}
+	if cfg.Server.SwaggerUI {
+		log.Printf("📚 Swagger UI available at %s", app.SwaggerUIPath)
+	}
+
+	// Start the gRPC server alongside HTTP
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	var grpcServer *grpcserver.Server
+	if cfg.GRPC.Enabled {
+		healthService := health.NewGRPCService(checker, cfg.Health.GRPCInterval)
+		go healthService.Run(healthCtx)
+
+		grpcServer = app.NewGRPCServer(cfg.GRPC.Port, registry, healthService)
+		go func() {
+			if err := grpcServer.Start(); err != nil {
+				log.Fatal("Failed to start gRPC server:", err)
+			}
+		}()
+	}
+
+	// Operational endpoints and admin routes go to the internal admin listener when enabled
+	var adminServer *http.Server
+	if routers.Admin != nil {
+		adminServer = &http.Server{
+			Addr:    ":" + cfg.Admin.Port,
+			Handler: routers.Admin,
+		}
+		log.Printf("🔧 Admin/ops listener (health, metrics, pprof, admin routes) on port %s", cfg.Admin.Port)
+		go func() {
+			if err := adminServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatal("Failed to start admin server:", err)
+			}
+		}()
+	}
+
+	server := &http.Server{
+		Addr:    ":" + cfg.Server.Port,
+		Handler: routers.Public,
+	}
+
+	log.Printf("🚀 Starting large-scale modular server on port %s", cfg.Server.Port)
+	log.Printf("📦 Registered modules: %v", app.ModuleNames(registry))
+	log.Printf("🏗️ Architecture: Domain-specific adapters with GORM Gen")
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+
+	waitForSignal()
+
+	// Fail readiness first so load balancers stop sending new requests
+	log.Printf("🛑 Shutting down: draining for %s, timeout %s", cfg.Server.DrainDelay, cfg.Server.ShutdownTimeout)
+	checker.Drain()
+	stopHealth()
+	time.Sleep(cfg.Server.DrainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	// Modules end long-lived streams while the server waits for in-flight requests
+	bg.stop()
+	moduleErr := make(chan error, 1)
+	go func() { moduleErr <- registry.ShutdownAll(ctx) }()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("HTTP server did not drain: %v", err)
+	}
+	if grpcServer != nil {
+		grpcServer.Shutdown(ctx)
+	}
+	if err := <-moduleErr; err != nil {
+		log.Printf("Module shutdown incomplete: %v", err)
+	}
+	// The admin listener goes last so probes see the draining status until the end
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			log.Printf("Admin server did not drain: %v", err)
+		}
+	}
+	bg.shutdown(ctx)
+	log.Println("👋 Server stopped")
+	return nil
+}
+
+// bootstrap composes the routers from application and its background work
+func bootstrap(cfg *config.Config, application *di.Application, bg *background, checker *health.Checker) (*app.Routers, error) {
+	return app.Bootstrap(cfg, app.Components{
+		DB:       application.DB,
+		Keys:     application.Keys,
+		Registry: application.Registry,
+		Checker:  checker,
+		Jobs:     bg.jobs,
+		Queue:    bg.queue,
+	})
+}
+
+// waitForSignal blocks until SIGINT (Ctrl+C) or SIGTERM (Kubernetes, docker stop)
+func waitForSignal() {
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	<-signalCtx.Done()
+	stopSignals()
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"log"
+
+	"clean-arch-gin/internal/app"
+
+	"github.com/spf13/cobra"
+)
+
+// newWorkerCommand creates the command running the module loops and the task workers only
+func newWorkerCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "worker",
+		Short: "Run the module loops and the task workers without serving, running migrations first",
+		Long: "Run the module loops (webhook delivery), the task workers and, with KAFKA_BROKERS, the\n" +
+			"Kafka consumer until SIGINT or SIGTERM, running migrations first. Nothing is served and\n" +
+			"no scheduled jobs run, so workers scale apart from the servers, started with\n" +
+			"serve --workers=false.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, application, closeApplication, err := initialize(true)
+			if err != nil {
+				return err
+			}
+			defer closeApplication()
+
+			bg, err := newBackground(cfg, application)
+			if err != nil {
+				return err
+			}
+			if err := bg.startWorkers(); err != nil {
+				return err
+			}
+			log.Printf("⚙️ Running workers of modules %v with %d task workers", app.ModuleNames(application.Registry), cfg.Tasks.Workers)
+
+			waitForSignal()
+
+			log.Printf("🛑 Shutting down workers, timeout %s", cfg.Server.ShutdownTimeout)
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+			defer cancel()
+			bg.stop()
+			if err := application.Registry.ShutdownAll(ctx); err != nil {
+				log.Printf("Module shutdown incomplete: %v", err)
+			}
+			bg.shutdown(ctx)
+			log.Println("👋 Workers stopped")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,346 @@
+// Package fxapp composes the modular server with uber/fx, the runtime alternative to the
+// Wire graph in package di
+// Constructors are registered per concern and every component that runs in the background
+// adds OnStart and OnStop hooks. Fx runs the start hooks in order and the stop hooks in
+// reverse, so the shutdown order of the serve command needs no glue: readiness is drained first,
+// then the modules, the HTTP, gRPC and admin servers, the outbox relay, the Kafka consumer, the
+// task queue, the scheduled jobs, the leader lease and last the Redis client and the database
+package fxapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/app"
+	"clean-arch-gin/internal/di"
+	"clean-arch-gin/internal/domain/shared/locks"
+	"clean-arch-gin/internal/domain/shared/passwords"
+	"clean-arch-gin/internal/domain/shared/publicid"
+	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/eventbus"
+	"clean-arch-gin/internal/infrastructure/fieldcrypt"
+	"clean-arch-gin/internal/infrastructure/grpcserver"
+	"clean-arch-gin/internal/infrastructure/health"
+	"clean-arch-gin/internal/infrastructure/interceptor"
+	"clean-arch-gin/internal/infrastructure/jwt"
+	"clean-arch-gin/internal/infrastructure/kafka"
+	"clean-arch-gin/internal/infrastructure/leader"
+	"clean-arch-gin/internal/infrastructure/outbox"
+	"clean-arch-gin/internal/infrastructure/querycache"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+	"clean-arch-gin/internal/modules"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// Infrastructure provides the database and the Redis client, closed on stop, the distributed
+// locks and the shared infrastructure of the modules
+var Infrastructure = fx.Module("infrastructure",
+	fx.Provide(
+		provideDatabase,
+		provideRedis,
+		app.NewLockManager,
+		di.ProvideLogger,
+		di.ProvideEventBus,
+		di.ProvideDatabaseBreaker,
+		app.NewQueryCache,
+		app.NewDecorators,
+		app.NewKeySet,
+	),
+)
+
+// Modules provides the module registry, migrated and initialized, and hooks every module
+// running background loops or holding resources
+var Modules = fx.Module("modules",
+	fx.Provide(provideRegistry),
+	fx.Invoke(runModules),
+)
+
+// Background provides the task queue, the Kafka consumer, the outbox relay, the leader
+// elector and the scheduled jobs and runs them as configured
+var Background = fx.Module("background",
+	fx.Provide(
+		app.NewLeaderElector,
+		app.NewTaskQueue,
+		app.NewKafkaConsumer,
+		app.NewOutboxRelay,
+		app.NewScheduler,
+	),
+	fx.Invoke(runBackground),
+)
+
+// Servers provides the health checker and the routers and serves HTTP, gRPC and the admin
+// listener as configured
+var Servers = fx.Module("servers",
+	fx.Provide(
+		app.NewHealthChecker,
+		provideRouters,
+	),
+	fx.Invoke(serveAdmin, serveGRPC, serveHTTP),
+)
+
+// Options composes the server for cfg
+// The order of the modules is the order of the start hooks; Modules hooks the module
+// loops after the servers so they are stopped, ending long-lived streams, before the
+// servers drain their requests
+func Options(cfg *config.Config) fx.Option {
+	return fx.Options(
+		fx.Supply(cfg),
+		fx.StopTimeout(cfg.Server.DrainDelay+cfg.Server.ShutdownTimeout),
+		fx.Invoke(configureProcess),
+		Infrastructure,
+		Background,
+		Servers,
+		Modules,
+		fx.Invoke(drainOnStop),
+	)
+}
+
+// configureProcess sets the process-wide encryption keys, public ID format and password hash
+// cost before anything touches the database
+func configureProcess(cfg *config.Config) error {
+	keyring, err := app.NewKeyring(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load encryption keys: %w", err)
+	}
+	fieldcrypt.Use(keyring)
+	if err := publicid.SetFormat(publicid.Format(cfg.PublicIDs.Format)); err != nil {
+		return fmt.Errorf("invalid PUBLIC_ID_FORMAT: %w", err)
+	}
+	if err := passwords.SetCost(cfg.Passwords.HashCost); err != nil {
+		return fmt.Errorf("invalid PASSWORD_HASH_COST: %w", err)
+	}
+	return nil
+}
+
+// provideDatabase connects to the database and closes it on stop
+func provideDatabase(lc fx.Lifecycle, cfg *config.Config) (*gorm.DB, error) {
+	db, closeDB, err := di.ProvideDatabase(cfg)
+	if err != nil {
+		return nil, err
+	}
+	lc.Append(fx.StopHook(closeDB))
+	return db, nil
+}
+
+// provideRedis creates the shared Redis client, nil when no backend uses Redis, and closes it
+// on stop
+func provideRedis(lc fx.Lifecycle, cfg *config.Config) (redis.UniversalClient, error) {
+	client, closeClient, err := app.NewRedisClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	lc.Append(fx.StopHook(closeClient))
+	return client, nil
+}
+
+// provideRegistry creates the registry and sets its modules up, running their migrations
+// under the migrations lock
+func provideRegistry(cfg *config.Config, db *gorm.DB, bus *eventbus.Bus, keys *jwt.KeySet, queryCache *querycache.Cache,
+	decorators interceptor.Stack, redisClient redis.UniversalClient, lockManager locks.LockManager) (*modules.ModuleRegistry, error) {
+	registry, err := app.NewModuleRegistry(cfg, db, bus, keys, queryCache, decorators, redisClient, lockManager)
+	if err != nil {
+		return nil, err
+	}
+	if err := app.Setup(db, registry, lockManager); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// runModules adds a hook per module in registration order, starting its loops when it is a
+// modules.Worker and shutting it down when it is a modules.Shutdowner
+func runModules(lc fx.Lifecycle, registry *modules.ModuleRegistry) {
+	for _, module := range registry.GetModules() {
+		worker, _ := module.(modules.Worker)
+		shutdowner, _ := module.(modules.Shutdowner)
+		if worker == nil && shutdowner == nil {
+			continue
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		name := module.Name()
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				if worker != nil {
+					worker.Start(ctx)
+				}
+				return nil
+			},
+			OnStop: func(stopCtx context.Context) error {
+				cancel()
+				if shutdowner == nil {
+					return nil
+				}
+				if err := shutdowner.Shutdown(stopCtx); err != nil {
+					return fmt.Errorf("failed to shut down module %s: %w", name, err)
+				}
+				return nil
+			},
+		})
+	}
+}
+
+// runBackground starts the task workers, the Kafka consumer, the outbox relay and, when the
+// scheduler is enabled, the leader election and the scheduled jobs; on stop the relay finishes
+// its batch, the consumer the messages under way and the queue its tasks, then the jobs return
+// and the lease is released
+func runBackground(lc fx.Lifecycle, cfg *config.Config, elector *leader.Elector, queue *taskqueue.Queue,
+	consumer *kafka.Consumer, relay *outbox.Relay, jobs *scheduler.Scheduler) {
+	if cfg.Scheduler.Enabled && elector != nil {
+		lc.Append(backgroundHook(elector.Start, elector.Shutdown))
+		log.Printf("🗳️ Running scheduled jobs only while %s leads (%s)", elector.ID(), cfg.Leader.Backend)
+	}
+	if cfg.Scheduler.Enabled {
+		lc.Append(backgroundHook(jobs.Start, jobs.Shutdown))
+	}
+	if cfg.Tasks.Workers > 0 {
+		lc.Append(backgroundHook(queue.Start, queue.Shutdown))
+	}
+	if consumer != nil {
+		lc.Append(consumerHook(consumer))
+	}
+	if relay != nil {
+		lc.Append(backgroundHook(relay.Start, relay.Shutdown))
+	}
+}
+
+// consumerHook joins the consumer group on start, failing when the brokers cannot be reached,
+// and leaves it on stop
+func consumerHook(consumer *kafka.Consumer) fx.Hook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return fx.Hook{
+		OnStart: func(context.Context) error {
+			return consumer.Start(ctx)
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			return consumer.Shutdown(stopCtx)
+		},
+	}
+}
+
+// backgroundHook runs start with a context cancelled on stop, then waits for shutdown
+func backgroundHook(start func(ctx context.Context), shutdown func(ctx context.Context) error) fx.Hook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return fx.Hook{
+		OnStart: func(context.Context) error {
+			start(ctx)
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			return shutdown(stopCtx)
+		},
+	}
+}
+
+// provideRouters composes the public router and, when the admin listener is enabled, the
+// admin router
+func provideRouters(cfg *config.Config, db *gorm.DB, keys *jwt.KeySet, registry *modules.ModuleRegistry,
+	queue *taskqueue.Queue, jobs *scheduler.Scheduler, checker *health.Checker) (*app.Routers, error) {
+	return app.Bootstrap(cfg, app.Components{
+		DB:       db,
+		Keys:     keys,
+		Registry: registry,
+		Checker:  checker,
+		Jobs:     jobs,
+		Queue:    queue,
+	})
+}
+
+// serveAdmin serves the operational endpoints, admin routes and the route inventory of both
+// listeners on the admin port when the admin listener is enabled
+func serveAdmin(lc fx.Lifecycle, cfg *config.Config, routers *app.Routers) {
+	if routers.Admin == nil {
+		return
+	}
+	lc.Append(httpHook(&http.Server{Addr: ":" + cfg.Admin.Port, Handler: routers.Admin}, "admin"))
+}
+
+// serveGRPC serves the modules' gRPC services and the health service when gRPC is enabled
+func serveGRPC(lc fx.Lifecycle, cfg *config.Config, registry *modules.ModuleRegistry, checker *health.Checker) {
+	if !cfg.GRPC.Enabled {
+		return
+	}
+	healthService := health.NewGRPCService(checker, cfg.Health.GRPCInterval)
+	server := app.NewGRPCServer(cfg.GRPC.Port, registry, healthService)
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			lis, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
+			if err != nil {
+				return fmt.Errorf("failed to listen on gRPC port %s: %w", cfg.GRPC.Port, err)
+			}
+			go healthService.Run(healthCtx)
+			go serve(server, lis)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			stopHealth()
+			server.Shutdown(ctx)
+			return nil
+		},
+	})
+}
+
+// serve serves gRPC on lis until the server is shut down
+func serve(server *grpcserver.Server, lis net.Listener) {
+	if err := server.Serve(lis); err != nil {
+		log.Printf("gRPC server stopped: %v", err)
+	}
+}
+
+// serveHTTP serves the public router on the server port
+func serveHTTP(lc fx.Lifecycle, cfg *config.Config, routers *app.Routers) {
+	lc.Append(httpHook(&http.Server{Addr: ":" + cfg.Server.Port, Handler: routers.Public}, "HTTP"))
+}
+
+// httpHook listens on start, so a port in use fails startup, and drains server's requests
+// on stop
+func httpHook(server *http.Server, name string) fx.Hook {
+	return fx.Hook{
+		OnStart: func(context.Context) error {
+			lis, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen for %s on %s: %w", name, server.Addr, err)
+			}
+			log.Printf("🚀 %s server listening on %s", name, lis.Addr())
+			go func() {
+				if err := server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					log.Printf("%s server stopped: %v", name, err)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if err := server.Shutdown(ctx); err != nil {
+				return fmt.Errorf("%s server did not drain: %w", name, err)
+			}
+			return nil
+		},
+	}
+}
+
+// drainOnStop fails readiness first on stop and keeps serving for the drain delay so load
+// balancers stop sending new requests
+func drainOnStop(lc fx.Lifecycle, cfg *config.Config, checker *health.Checker) {
+	lc.Append(fx.StopHook(func(ctx context.Context) error {
+		log.Printf("🛑 Shutting down: draining for %s, timeout %s", cfg.Server.DrainDelay, cfg.Server.ShutdownTimeout)
+		checker.Drain()
+		select {
+		case <-time.After(cfg.Server.DrainDelay):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}))
+}
@@ -0,0 +1,227 @@
+package di
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	orderControllers "clean-arch-gin/internal/adapters/order/controllers"
+	orderRepositories "clean-arch-gin/internal/adapters/order/repositories"
+	"clean-arch-gin/internal/adapters/order/streams"
+	orderUsecases "clean-arch-gin/internal/adapters/order/usecases"
+	userControllers "clean-arch-gin/internal/adapters/user/controllers"
+	userRepositories "clean-arch-gin/internal/adapters/user/repositories"
+	userUsecases "clean-arch-gin/internal/adapters/user/usecases"
+	"clean-arch-gin/internal/app"
+	orderDomainRepositories "clean-arch-gin/internal/domain/order/repositories"
+	orderDomainUsecases "clean-arch-gin/internal/domain/order/usecases"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/locks"
+	userDomainRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userDomainUsecases "clean-arch-gin/internal/domain/user/usecases"
+	"clean-arch-gin/internal/infrastructure/breaker"
+	"clean-arch-gin/internal/infrastructure/cloudevents"
+	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/eventbus"
+	"clean-arch-gin/internal/infrastructure/interceptor"
+	"clean-arch-gin/internal/infrastructure/jwt"
+	"clean-arch-gin/internal/infrastructure/querycache"
+	"clean-arch-gin/internal/infrastructure/retry"
+	"clean-arch-gin/internal/modules"
+
+	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// Application is the modular server's dependency graph: configuration, the database, the
+// logger, the Redis client, the query cache, the event bus, the token signing keys and the
+// module registry
+type Application struct {
+	Config *config.Config
+	DB     *gorm.DB
+	Logger *log.Logger
+	// Redis is nil when no backend is configured to use Redis
+	Redis redis.UniversalClient
+	// Locks is nil when LOCK_BACKEND is none
+	Locks locks.LockManager
+	// Cache is nil when QUERY_CACHE_BACKEND is none
+	Cache    *querycache.Cache
+	Bus      *eventbus.Bus
+	Keys     *jwt.KeySet
+	Registry *modules.ModuleRegistry
+}
+
+// UserComponents are the user module's components, for wiring them without the module,
+// e.g. onto another transport
+type UserComponents struct {
+	Repository userDomainRepositories.UserRepository
+	UseCase    userDomainUsecases.UserUseCase
+	Controller *userControllers.UserController
+	Auth       *middleware.AuthMiddleware
+	// Throttle is nil when LOGIN_THROTTLE_BACKEND is none
+	Throttle *middleware.LoginThrottle
+}
+
+// OrderComponents are the order module's components, for wiring them without the module
+type OrderComponents struct {
+	Repository orderDomainRepositories.OrderRepository
+	Pricing    orderDomainUsecases.PricingUseCase
+	UseCase    orderDomainUsecases.OrderUseCase
+	Controller *orderControllers.OrderController
+}
+
+// InfrastructureSet provides the shared infrastructure the modules are built on
+var InfrastructureSet = wire.NewSet(
+	ProvideDatabase,
+	ProvideLogger,
+	ProvideEventBus,
+	ProvideEventPublisher,
+	ProvideDatabaseBreaker,
+	app.NewRedisClient,
+	app.NewLockManager,
+	app.NewQueryCache,
+	DecoratorSet,
+)
+
+// DecoratorSet provides the interceptors stacked around use cases and repositories as the
+// DECORATE_* flags configure them
+var DecoratorSet = wire.NewSet(
+	app.NewDecorators,
+)
+
+// ApplicationSet provides the Application with every module registered
+var ApplicationSet = wire.NewSet(
+	InfrastructureSet,
+	app.NewKeySet,
+	app.NewModuleRegistry,
+	wire.Struct(new(Application), "*"),
+)
+
+// UserSet provides the user module's components on the GORM Gen stack: the repository
+// behind the database breaker, the query cache and the decorators, the decorated use case,
+// the controller and the middleware of its routes
+var UserSet = wire.NewSet(
+	ProvideUserRepository,
+	ProvideUserUseCase,
+	userControllers.NewUserController,
+	ProvideAuthMiddleware,
+	app.NewLoginThrottle,
+	wire.Struct(new(UserComponents), "*"),
+)
+
+// OrderSet provides the order module's components on the GORM Gen stack: the repositories
+// behind the database breaker, the pricing and order use cases, the status stream and the
+// controller
+var OrderSet = wire.NewSet(
+	ProvideOrderRepository,
+	ProvideShipmentRepository,
+	ProvideInventoryRepository,
+	app.NewTaxCalculator,
+	app.NewShippingStrategy,
+	app.NewBaseCurrencies,
+	app.NewExchangeRateProvider,
+	orderUsecases.NewPricingUseCase,
+	ProvideReservationTTL,
+	orderUsecases.NewOrderUseCase,
+	ProvideStatusStream,
+	orderControllers.NewOrderController,
+	wire.Struct(new(OrderComponents), "*"),
+)
+
+// ProvideDatabase connects to the configured database; the cleanup closes it
+func ProvideDatabase(cfg *config.Config) (*gorm.DB, func(), error) {
+	db, err := database.NewConnection(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return db, func() {
+		if err := database.Close(db); err != nil {
+			log.Printf("Failed to close database: %v", err)
+		}
+	}, nil
+}
+
+// ProvideLogger returns the standard logger the application logs through
+func ProvideLogger() *log.Logger {
+	return log.Default()
+}
+
+// ProvideEventBus creates the in-process event bus shared by all modules, logging every
+// event as a CloudEvent when EVENTS_LOG_CLOUDEVENTS is set
+func ProvideEventBus(cfg *config.Config) *eventbus.Bus {
+	bus := eventbus.New()
+	if cfg.Events.LogCloudEvents {
+		publisher := cloudevents.NewPublisher(app.CloudEventsFormatter(cfg),
+			cloudevents.NewRetryingSink(cloudevents.NewWriterSink(os.Stdout), retry.DefaultPolicy()))
+		bus.Subscribe(eventbus.Wildcard, func(ctx context.Context, event events.DomainEvent) {
+			if err := publisher.Publish(ctx, event); err != nil {
+				log.Printf("Failed to emit %s as CloudEvent: %v", event.EventName(), err)
+			}
+		})
+	}
+	return bus
+}
+
+// ProvideEventPublisher publishes the use cases' events on bus
+func ProvideEventPublisher(bus *eventbus.Bus) events.EventPublisher {
+	return bus
+}
+
+// ProvideDatabaseBreaker creates the circuit breaker repository calls share
+func ProvideDatabaseBreaker(cfg *config.Config) *breaker.CircuitBreaker {
+	return database.NewCircuitBreaker(cfg.Breaker.Database)
+}
+
+// ProvideUserRepository creates the GORM Gen user repository behind dbBreaker, serving
+// profile reads from queryCache when it is not nil, with the repository decorators outermost
+func ProvideUserRepository(db *gorm.DB, dbBreaker *breaker.CircuitBreaker, queryCache *querycache.Cache,
+	decorators interceptor.Stack) userDomainRepositories.UserRepository {
+	userRepo := userRepositories.NewUserRepositoryWithBreaker(userRepositories.NewUserRepositoryGen(db), dbBreaker)
+	if queryCache != nil {
+		userRepo = userRepositories.NewUserRepositoryWithCache(userRepo, queryCache, userRepositories.DefaultUserCachePolicies)
+	}
+	return userRepositories.NewUserRepositoryWithInterceptor(userRepo, decorators.Repositories)
+}
+
+// ProvideUserUseCase creates the user use case behind the use case decorators; the events
+// of a call running in a transaction are published once it commits
+func ProvideUserUseCase(userRepo userDomainRepositories.UserRepository, publisher events.EventPublisher,
+	decorators interceptor.Stack) userDomainUsecases.UserUseCase {
+	return userUsecases.NewUserUseCaseWithInterceptor(
+		userUsecases.NewUserUseCase(userRepo, database.PublishAfterCommit(publisher)), decorators.UseCases)
+}
+
+// ProvideAuthMiddleware creates the middleware authenticating the user routes
+func ProvideAuthMiddleware() *middleware.AuthMiddleware {
+	return middleware.NewAuthMiddleware("")
+}
+
+// ProvideOrderRepository creates the GORM Gen order repository behind dbBreaker
+func ProvideOrderRepository(db *gorm.DB, dbBreaker *breaker.CircuitBreaker) orderDomainRepositories.OrderRepository {
+	return orderRepositories.NewOrderRepositoryWithBreaker(orderRepositories.NewOrderRepositoryGen(db), dbBreaker)
+}
+
+// ProvideShipmentRepository creates the shipment repository behind dbBreaker
+func ProvideShipmentRepository(db *gorm.DB, dbBreaker *breaker.CircuitBreaker) orderDomainRepositories.ShipmentRepository {
+	return orderRepositories.NewShipmentRepositoryWithBreaker(orderRepositories.NewShipmentRepository(db), dbBreaker)
+}
+
+// ProvideInventoryRepository creates the inventory repository behind dbBreaker
+func ProvideInventoryRepository(db *gorm.DB, dbBreaker *breaker.CircuitBreaker) orderDomainRepositories.InventoryRepository {
+	return orderRepositories.NewInventoryRepositoryWithBreaker(orderRepositories.NewInventoryRepository(db), dbBreaker)
+}
+
+// ProvideReservationTTL returns how long confirmed orders hold their stock unpaid
+func ProvideReservationTTL(cfg *config.Config) time.Duration {
+	return cfg.Orders.ReservationTTL
+}
+
+// ProvideStatusStream creates the stream of order status changes on subscriber; the
+// cleanup unsubscribes it
+func ProvideStatusStream(subscriber *eventbus.Bus) (*streams.StatusStream, func()) {
+	return streams.NewStatusStream(subscriber)
+}
@@ -7,14 +7,41 @@ import (
 	"clean-arch-gin/internal/adapters/controllers"
 	"clean-arch-gin/internal/adapters/repositories"
 	"clean-arch-gin/internal/adapters/usecases"
+	"clean-arch-gin/internal/app"
 	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/eventbus"
 
 	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
-// InitializeUserController initializes a user controller with all dependencies
-func InitializeUserController(db *gorm.DB, cfg *config.Config) *controllers.UserController {
+// InitializeApplication builds the modular server from cfg; the cleanup closes the database
+func InitializeApplication(cfg *config.Config) (*Application, func(), error) {
+	wire.Build(ApplicationSet)
+	return nil, nil, nil
+}
+
+// InitializeUserComponents wires the user module's components on db, publishing on bus, with
+// the configured decorators; redisClient, nil when no backend uses Redis, backs the query
+// cache and login throttle configured to use it
+func InitializeUserComponents(cfg *config.Config, db *gorm.DB, bus *eventbus.Bus, redisClient redis.UniversalClient) (*UserComponents, error) {
+	wire.Build(ProvideLogger, ProvideEventPublisher, ProvideDatabaseBreaker, app.NewQueryCache, DecoratorSet, UserSet)
+	return nil, nil
+}
+
+// InitializeOrderComponents wires the order module's components on db, publishing on bus;
+// the cleanup unsubscribes the status stream
+func InitializeOrderComponents(cfg *config.Config, db *gorm.DB, bus *eventbus.Bus) (*OrderComponents, func(), error) {
+	wire.Build(ProvideEventPublisher, ProvideDatabaseBreaker, OrderSet)
+	return nil, nil, nil
+}
+
+// InitializeLegacyUserController initializes a user controller on the legacy repository
+// with all dependencies
+//
+// Deprecated: the user module wires its own controller; compose routers with app.Bootstrap
+func InitializeLegacyUserController(db *gorm.DB, cfg *config.Config) *controllers.UserController {
 	wire.Build(
 		repositories.NewUserRepository,
 		usecases.NewUserUseCase,
@@ -23,19 +50,25 @@ func InitializeUserController(db *gorm.DB, cfg *config.Config) *controllers.User
 	return &controllers.UserController{}
 }
 
-// Application represents the entire application with all dependencies
-type Application struct {
+// LegacyApplication represents the legacy application with all dependencies
+//
+// Deprecated: compose the modular application with InitializeApplication and its routers
+// with app.Bootstrap
+type LegacyApplication struct {
 	UserController *controllers.UserController
 	Config         *config.Config
 }
 
-// InitializeApplication initializes the entire application
-func InitializeApplication(db *gorm.DB, cfg *config.Config) *Application {
+// InitializeLegacyApplication initializes the legacy application
+//
+// Deprecated: compose the modular application with InitializeApplication and its routers
+// with app.Bootstrap
+func InitializeLegacyApplication(db *gorm.DB, cfg *config.Config) *LegacyApplication {
 	wire.Build(
 		repositories.NewUserRepository,
 		usecases.NewUserUseCase,
 		controllers.NewUserController,
-		wire.Struct(new(Application), "*"),
+		wire.Struct(new(LegacyApplication), "*"),
 	)
-	return &Application{}
+	return &LegacyApplication{}
 }
@@ -7,39 +7,176 @@
 package di
 
 import (
-	"clean-arch-gin/internal/adapters/controllers"
+	controllers3 "clean-arch-gin/internal/adapters/controllers"
+	controllers2 "clean-arch-gin/internal/adapters/order/controllers"
+	"clean-arch-gin/internal/adapters/order/usecases"
 	"clean-arch-gin/internal/adapters/repositories"
-	"clean-arch-gin/internal/adapters/usecases"
+	usecases2 "clean-arch-gin/internal/adapters/usecases"
+	"clean-arch-gin/internal/adapters/user/controllers"
+	"clean-arch-gin/internal/app"
 	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/eventbus"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
 // Injectors from wire.go:
 
-// InitializeUserController initializes a user controller with all dependencies
-func InitializeUserController(db *gorm.DB, cfg *config.Config) *controllers.UserController {
-	userRepository := repositories.NewUserRepository(db)
-	userUseCase := usecases.NewUserUseCase(userRepository)
+// InitializeApplication builds the modular server from cfg; the cleanup closes the database
+func InitializeApplication(cfg *config.Config) (*Application, func(), error) {
+	db, cleanup, err := ProvideDatabase(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger := ProvideLogger()
+	universalClient, cleanup2, err := app.NewRedisClient(cfg)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	lockManager, err := app.NewLockManager(cfg, db, universalClient)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	cache, err := app.NewQueryCache(cfg, universalClient)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	bus := ProvideEventBus(cfg)
+	keySet, err := app.NewKeySet(cfg, db)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	stack := app.NewDecorators(cfg, db, logger)
+	moduleRegistry, err := app.NewModuleRegistry(cfg, db, bus, keySet, cache, stack, universalClient, lockManager)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	application := &Application{
+		Config:   cfg,
+		DB:       db,
+		Logger:   logger,
+		Redis:    universalClient,
+		Locks:    lockManager,
+		Cache:    cache,
+		Bus:      bus,
+		Keys:     keySet,
+		Registry: moduleRegistry,
+	}
+	return application, func() {
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+// InitializeUserComponents wires the user module's components on db, publishing on bus, with
+// the configured decorators; redisClient, nil when no backend uses Redis, backs the query
+// cache and login throttle configured to use it
+func InitializeUserComponents(cfg *config.Config, db *gorm.DB, bus *eventbus.Bus, redisClient redis.UniversalClient) (*UserComponents, error) {
+	circuitBreaker := ProvideDatabaseBreaker(cfg)
+	cache, err := app.NewQueryCache(cfg, redisClient)
+	if err != nil {
+		return nil, err
+	}
+	logger := ProvideLogger()
+	stack := app.NewDecorators(cfg, db, logger)
+	userRepository := ProvideUserRepository(db, circuitBreaker, cache, stack)
+	eventPublisher := ProvideEventPublisher(bus)
+	userUseCase := ProvideUserUseCase(userRepository, eventPublisher, stack)
 	userController := controllers.NewUserController(userUseCase)
+	authMiddleware := ProvideAuthMiddleware()
+	loginThrottle, err := app.NewLoginThrottle(cfg, redisClient)
+	if err != nil {
+		return nil, err
+	}
+	userComponents := &UserComponents{
+		Repository: userRepository,
+		UseCase:    userUseCase,
+		Controller: userController,
+		Auth:       authMiddleware,
+		Throttle:   loginThrottle,
+	}
+	return userComponents, nil
+}
+
+// InitializeOrderComponents wires the order module's components on db, publishing on bus;
+// the cleanup unsubscribes the status stream
+func InitializeOrderComponents(cfg *config.Config, db *gorm.DB, bus *eventbus.Bus) (*OrderComponents, func(), error) {
+	circuitBreaker := ProvideDatabaseBreaker(cfg)
+	orderRepository := ProvideOrderRepository(db, circuitBreaker)
+	taxCalculator, err := app.NewTaxCalculator(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	shippingStrategy, err := app.NewShippingStrategy(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	baseCurrencies := app.NewBaseCurrencies(db, circuitBreaker)
+	exchangeRateProvider, err := app.NewExchangeRateProvider(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	pricingUseCase := usecases.NewPricingUseCase(taxCalculator, shippingStrategy, baseCurrencies, exchangeRateProvider)
+	shipmentRepository := ProvideShipmentRepository(db, circuitBreaker)
+	inventoryRepository := ProvideInventoryRepository(db, circuitBreaker)
+	eventPublisher := ProvideEventPublisher(bus)
+	duration := ProvideReservationTTL(cfg)
+	orderUseCase := usecases.NewOrderUseCase(orderRepository, shipmentRepository, inventoryRepository, pricingUseCase, eventPublisher, duration)
+	statusStream, cleanup := ProvideStatusStream(bus)
+	orderController := controllers2.NewOrderController(orderUseCase, pricingUseCase, statusStream)
+	orderComponents := &OrderComponents{
+		Repository: orderRepository,
+		Pricing:    pricingUseCase,
+		UseCase:    orderUseCase,
+		Controller: orderController,
+	}
+	return orderComponents, func() {
+		cleanup()
+	}, nil
+}
+
+// InitializeLegacyUserController initializes a user controller on the legacy repository
+// with all dependencies
+//
+// Deprecated: the user module wires its own controller; compose routers with app.Bootstrap
+func InitializeLegacyUserController(db *gorm.DB, cfg *config.Config) *controllers3.UserController {
+	userRepository := repositories.NewUserRepository(db)
+	userUseCase := usecases2.NewUserUseCase(userRepository)
+	userController := controllers3.NewUserController(userUseCase)
 	return userController
 }
 
-// InitializeApplication initializes the entire application
-func InitializeApplication(db *gorm.DB, cfg *config.Config) *Application {
+// InitializeLegacyApplication initializes the legacy application
+//
+// Deprecated: compose the modular application with InitializeApplication and its routers
+// with app.Bootstrap
+func InitializeLegacyApplication(db *gorm.DB, cfg *config.Config) *LegacyApplication {
 	userRepository := repositories.NewUserRepository(db)
-	userUseCase := usecases.NewUserUseCase(userRepository)
-	userController := controllers.NewUserController(userUseCase)
-	application := &Application{
+	userUseCase := usecases2.NewUserUseCase(userRepository)
+	userController := controllers3.NewUserController(userUseCase)
+	legacyApplication := &LegacyApplication{
 		UserController: userController,
 		Config:         cfg,
 	}
-	return application
+	return legacyApplication
 }
 
 // wire.go:
 
-// Application represents the entire application with all dependencies
-type Application struct {
-	UserController *controllers.UserController
+// LegacyApplication represents the legacy application with all dependencies
+//
+// Deprecated: compose the modular application with InitializeApplication and its routers
+// with app.Bootstrap
+type LegacyApplication struct {
+	UserController *controllers3.UserController
 	Config         *config.Config
 }
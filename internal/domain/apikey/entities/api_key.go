@@ -0,0 +1,153 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/publicid"
+)
+
+const (
+	// KeyPrefix starts every API key so leaked keys are recognisable, e.g. by secret scanners
+	KeyPrefix = "ak_"
+	// DisplayLength is how much of a key, prefix included, is kept to tell keys apart
+	DisplayLength = 11
+	// maxNameLength matches the name column
+	maxNameLength = 100
+)
+
+// Limits caps the requests made with a key
+type Limits struct {
+	// RatePerMinute is how many requests the key may make per minute; 0 is unlimited
+	RatePerMinute int
+	// MonthlyQuota is how many requests the key may make per calendar month, in UTC; 0 is
+	// unlimited
+	MonthlyQuota int64
+}
+
+// Validate rejects negative limits
+func (l Limits) Validate() error {
+	if l.RatePerMinute < 0 || l.MonthlyQuota < 0 {
+		return ErrInvalidLimits
+	}
+	return nil
+}
+
+// APIKey lets a program call the API on behalf of its owner, within its limits
+// Only a hash of the key is kept, so the records cannot be replayed if they leak
+type APIKey struct {
+	ID uint
+	// PublicID identifies the key in routes and DTOs; ID stays internal
+	PublicID string
+	OwnerID  uint
+	Name     string
+	// Display is the start of the key, shown to tell keys apart
+	Display    string
+	KeyHash    string
+	Limits     Limits
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// NewAPIKey creates a key of ownerID named name for the key with keyHash, starting with display
+func NewAPIKey(ownerID uint, name, display, keyHash string, limits Limits) (*APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxNameLength {
+		return nil, ErrInvalidKeyName
+	}
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
+	return &APIKey{
+		PublicID:  publicid.New(),
+		OwnerID:   ownerID,
+		Name:      name,
+		Display:   display,
+		KeyHash:   keyHash,
+		Limits:    limits,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// IsRevoked checks if the key was revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Revoke stops the key from authenticating
+func (k *APIKey) Revoke() {
+	if k.RevokedAt == nil {
+		now := time.Now()
+		k.RevokedAt = &now
+	}
+}
+
+// RateWindow returns the minute of now requests are rate limited in, as its start and end
+func RateWindow(now time.Time) (time.Time, time.Time) {
+	start := now.UTC().Truncate(time.Minute)
+	return start, start.Add(time.Minute)
+}
+
+// QuotaPeriod returns the month of now quotas are counted in, as YYYY-MM, and its end
+func QuotaPeriod(now time.Time) (string, time.Time) {
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start.Format("2006-01"), start.AddDate(0, 1, 0)
+}
+
+// Allowance is the outcome of counting a request against the limits of a key
+type Allowance struct {
+	// Allowed is false when the request was over a limit and was not counted
+	Allowed bool
+	Limits  Limits
+	// RateUsed requests were made in the minute ending at RateReset
+	RateUsed  int
+	RateReset time.Time
+	// QuotaUsed requests were made in the month ending at QuotaReset
+	QuotaUsed  int64
+	QuotaReset time.Time
+}
+
+// RateRemaining is how many more requests the minute allows; -1 when unlimited
+func (a Allowance) RateRemaining() int {
+	if a.Limits.RatePerMinute == 0 {
+		return -1
+	}
+	return max(a.Limits.RatePerMinute-a.RateUsed, 0)
+}
+
+// QuotaRemaining is how many more requests the month allows; -1 when unlimited
+func (a Allowance) QuotaRemaining() int64 {
+	if a.Limits.MonthlyQuota == 0 {
+		return -1
+	}
+	return max(a.Limits.MonthlyQuota-a.QuotaUsed, 0)
+}
+
+// QuotaExceeded reports whether the request was refused for the month rather than the minute
+func (a Allowance) QuotaExceeded() bool {
+	return !a.Allowed && a.Limits.MonthlyQuota > 0 && a.QuotaUsed >= a.Limits.MonthlyQuota
+}
+
+// Usage is the use of a key in a month
+type Usage struct {
+	KeyID uint
+	// KeyPublicID is the public ID of the key
+	KeyPublicID string
+	// Period is the month, as YYYY-MM, which ends at ResetsAt
+	Period   string
+	Used     int64
+	Limits   Limits
+	ResetsAt time.Time
+}
+
+// Domain errors for API keys
+var (
+	ErrInvalidAPIKey  = sharedEntities.DomainError{Message: "invalid API key"}
+	ErrAPIKeyNotFound = sharedEntities.DomainError{Message: "API key not found"}
+	ErrAPIKeyRevoked  = sharedEntities.DomainError{Message: "API key has been revoked"}
+	ErrInvalidKeyName = sharedEntities.DomainError{Message: "API key name must be 1 to 100 characters"}
+	ErrInvalidLimits  = sharedEntities.DomainError{Message: "API key limits cannot be negative"}
+)
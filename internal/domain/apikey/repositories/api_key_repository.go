@@ -0,0 +1,36 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=api_key_repository.go -destination=../../../mocks/api_key_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/domain/apikey/entities"
+)
+
+// APIKeyRepository defines the contract for API key persistence
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	// GetByID returns ErrAPIKeyNotFound for an unknown key
+	GetByID(ctx context.Context, id uint) (*entities.APIKey, error)
+	// GetByPublicID finds a key by the identifier it is exposed by; ErrAPIKeyNotFound for an
+	// unknown key
+	GetByPublicID(ctx context.Context, publicID string) (*entities.APIKey, error)
+	// GetByHash returns ErrAPIKeyNotFound for an unknown key
+	GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+	// ListByOwner returns the keys of ownerID, revoked ones included, newest first
+	ListByOwner(ctx context.Context, ownerID uint) ([]*entities.APIKey, error)
+	// Update saves the limits and revocation of a key
+	Update(ctx context.Context, key *entities.APIKey) error
+	// Touch records that a key was used at usedAt
+	Touch(ctx context.Context, id uint, usedAt time.Time) error
+}
+
+// UsageCounter counts the requests made with each key per minute and per month
+type UsageCounter interface {
+	// Take counts a request of keyID at now unless it is over limits, and returns the counts
+	Take(ctx context.Context, keyID uint, limits entities.Limits, now time.Time) (entities.Allowance, error)
+	// MonthlyUsage returns how many requests keyID made in the month of now
+	MonthlyUsage(ctx context.Context, keyID uint, now time.Time) (int64, error)
+}
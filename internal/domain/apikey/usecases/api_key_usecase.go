@@ -0,0 +1,29 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=api_key_usecase.go -destination=../../../mocks/api_key_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/apikey/entities"
+)
+
+// APIKeyUseCase defines the business logic operations for API keys
+type APIKeyUseCase interface {
+	// CreateKey issues a key with the default limits to ownerID; the key is only returned here
+	CreateKey(ctx context.Context, ownerID uint, name string) (*entities.APIKey, string, error)
+	ListKeys(ctx context.Context, ownerID uint) ([]*entities.APIKey, error)
+	// GetKeyByPublicID finds a key by the identifier it is exposed by in routes
+	GetKeyByPublicID(ctx context.Context, publicID string) (*entities.APIKey, error)
+	// RevokeKey revokes a key of ownerID; the keys of others are not found
+	RevokeKey(ctx context.Context, ownerID, id uint) error
+	// GetUsage returns the use this month of a key of ownerID
+	GetUsage(ctx context.Context, ownerID, id uint) (*entities.Usage, error)
+	// SetLimits changes the limits of any key, for admins
+	SetLimits(ctx context.Context, id uint, limits entities.Limits) (*entities.APIKey, error)
+	// Authenticate resolves a key presented by a client to its record; unknown and revoked
+	// keys are ErrInvalidAPIKey
+	Authenticate(ctx context.Context, key string) (*entities.APIKey, error)
+	// Consume counts a request made with key against its limits
+	Consume(ctx context.Context, key *entities.APIKey) (entities.Allowance, error)
+}
@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"strings"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// Wildcard matches any object or action in a policy; a trailing * in an object, as in
+// users/*, matches every object with that prefix
+const Wildcard = "*"
+
+// Built-in roles; RoleAdmin is granted everything by the default policy
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Policy allows a subject, a role or a user such as user:42, an action on an object
+type Policy struct {
+	Subject string
+	Object  string
+	Action  string
+}
+
+// RoleAssignment makes a subject inherit every permission of a role
+// Assigning a role to a role builds the hierarchy, e.g. admin inheriting support
+type RoleAssignment struct {
+	Subject string
+	Role    string
+}
+
+// Domain errors for policies
+var (
+	ErrInvalidPolicy          = sharedEntities.DomainError{Message: "subject, object and action are required"}
+	ErrInvalidRoleAssignment  = sharedEntities.DomainError{Message: "subject and role are required and must differ"}
+	ErrPolicyExists           = sharedEntities.DomainError{Message: "policy already exists"}
+	ErrPolicyNotFound         = sharedEntities.DomainError{Message: "policy not found"}
+	ErrRoleAssignmentExists   = sharedEntities.DomainError{Message: "role is already assigned"}
+	ErrRoleAssignmentNotFound = sharedEntities.DomainError{Message: "role assignment not found"}
+	ErrProtectedPolicy        = sharedEntities.DomainError{Message: "the admin policy cannot be revoked"}
+)
+
+// DefaultPolicies are stored when there are none, so admins can manage the rest
+var DefaultPolicies = []Policy{
+	{Subject: RoleAdmin, Object: Wildcard, Action: Wildcard},
+}
+
+// NewPolicy creates a policy with validation
+func NewPolicy(subject, object, action string) (*Policy, error) {
+	p := &Policy{
+		Subject: strings.TrimSpace(subject),
+		Object:  strings.TrimSpace(object),
+		Action:  strings.TrimSpace(action),
+	}
+	if p.Subject == "" || p.Object == "" || p.Action == "" {
+		return nil, ErrInvalidPolicy
+	}
+	return p, nil
+}
+
+// IsProtected reports whether the policy is the admin grant that keeps the policies manageable
+func (p *Policy) IsProtected() bool {
+	return *p == DefaultPolicies[0]
+}
+
+// NewRoleAssignment creates a role assignment with validation
+func NewRoleAssignment(subject, role string) (*RoleAssignment, error) {
+	a := &RoleAssignment{
+		Subject: strings.TrimSpace(subject),
+		Role:    strings.TrimSpace(role),
+	}
+	if a.Subject == "" || a.Role == "" || a.Subject == a.Role {
+		return nil, ErrInvalidRoleAssignment
+	}
+	return a, nil
+}
@@ -0,0 +1,23 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=policy_repository.go -destination=../../../mocks/policy_repository_mock.go -package=mocks
+
+import (
+	"clean-arch-gin/internal/domain/authz/entities"
+)
+
+// PolicyRepository defines the contract for policy persistence
+// Changes take effect on the enforcer at once and are persisted with it
+type PolicyRepository interface {
+	ListPolicies() ([]*entities.Policy, error)
+	// AddPolicy returns entities.ErrPolicyExists for a policy already stored
+	AddPolicy(policy *entities.Policy) error
+	// RemovePolicy returns entities.ErrPolicyNotFound for a policy not stored
+	RemovePolicy(policy *entities.Policy) error
+
+	ListRoleAssignments() ([]*entities.RoleAssignment, error)
+	// AddRoleAssignment returns entities.ErrRoleAssignmentExists for an assignment already stored
+	AddRoleAssignment(assignment *entities.RoleAssignment) error
+	// RemoveRoleAssignment returns entities.ErrRoleAssignmentNotFound for an assignment not stored
+	RemoveRoleAssignment(assignment *entities.RoleAssignment) error
+}
@@ -0,0 +1,26 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=policy_usecase.go -destination=../../../mocks/policy_usecase_mock.go -package=mocks
+
+import (
+	"clean-arch-gin/internal/domain/authz/entities"
+	"clean-arch-gin/internal/domain/shared/authz"
+)
+
+// PolicyUseCase defines the management of authorization policies and role hierarchies
+// Changing the rules of a subject requires the write action on policies/<subject>, so
+// an actor can be limited to managing some roles
+type PolicyUseCase interface {
+	ListPolicies() ([]*entities.Policy, error)
+	GrantPolicy(actor authz.Actor, subject, object, action string) (*entities.Policy, error)
+	// RevokePolicy returns entities.ErrProtectedPolicy for the admin grant
+	RevokePolicy(actor authz.Actor, subject, object, action string) error
+
+	ListRoleAssignments() ([]*entities.RoleAssignment, error)
+	AssignRole(actor authz.Actor, subject, role string) (*entities.RoleAssignment, error)
+	UnassignRole(actor authz.Actor, subject, role string) error
+
+	// Check reports whether subject, through its own policies and inherited roles, may
+	// perform action on object
+	Check(subject, object, action string) (bool, error)
+}
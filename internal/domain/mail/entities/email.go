@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/mail"
+)
+
+// EmailStatus is the delivery state of an outbound email
+type EmailStatus string
+
+const (
+	// EmailStatusQueued emails wait for their first attempt
+	EmailStatusQueued EmailStatus = "queued"
+	// EmailStatusRetrying emails failed an attempt and are retried after a backoff
+	EmailStatusRetrying EmailStatus = "retrying"
+	// EmailStatusSent emails were accepted by the provider
+	EmailStatusSent EmailStatus = "sent"
+	// EmailStatusFailed emails failed permanently or ran out of attempts
+	EmailStatusFailed EmailStatus = "failed"
+)
+
+// Valid reports whether s is a known status
+func (s EmailStatus) Valid() bool {
+	switch s {
+	case EmailStatusQueued, EmailStatusRetrying, EmailStatusSent, EmailStatusFailed:
+		return true
+	}
+	return false
+}
+
+// Errors of outbound emails
+var (
+	ErrEmailNotFound      = errors.New("email not found")
+	ErrInvalidEmailStatus = sharedEntities.DomainError{Message: "invalid email status"}
+)
+
+// maxErrorLength matches the size of the last_error column
+const maxErrorLength = 1024
+
+// Email is an outbound email to one recipient, sent by a worker of the task queue
+// The body is only kept until the email is sent, since account emails carry secrets
+type Email struct {
+	ID uint
+	// TaskID is the task sending the email
+	TaskID    uint
+	Recipient string
+	Subject   string
+	Text      string
+	HTML      string
+	Status    EmailStatus
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	SentAt    *time.Time
+}
+
+// NewEmails splits msg into a queued email per recipient, so each has its own status
+func NewEmails(msg mail.Message) []*Email {
+	now := time.Now()
+	emails := make([]*Email, len(msg.To))
+	for i, recipient := range msg.To {
+		emails[i] = &Email{
+			Recipient: recipient,
+			Subject:   msg.Subject,
+			Text:      msg.Text,
+			HTML:      msg.HTML,
+			Status:    EmailStatusQueued,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+	return emails
+}
+
+// Message is the email as sent
+func (e *Email) Message() mail.Message {
+	return mail.Message{To: []string{e.Recipient}, Subject: e.Subject, Text: e.Text, HTML: e.HTML}
+}
+
+// MarkSent records that the provider accepted the email and drops its body
+func (e *Email) MarkSent(attempts int) {
+	now := time.Now()
+	e.Status = EmailStatusSent
+	e.Attempts = attempts
+	e.LastError = ""
+	e.Text = ""
+	e.HTML = ""
+	e.SentAt = &now
+	e.UpdatedAt = now
+}
+
+// MarkFailed records a failed attempt; final ones fail the email, which keeps its body
+// so it can be inspected
+func (e *Email) MarkFailed(attempts int, err error, final bool) {
+	e.Status = EmailStatusRetrying
+	if final {
+		e.Status = EmailStatusFailed
+	}
+	e.Attempts = attempts
+	e.LastError = err.Error()
+	if len(e.LastError) > maxErrorLength {
+		e.LastError = e.LastError[:maxErrorLength]
+	}
+	e.UpdatedAt = time.Now()
+}
@@ -0,0 +1,31 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=email_repository.go -destination=../../../mocks/email_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/mail/entities"
+)
+
+// EmailFilter narrows a listing of outbound emails; zero fields match every email
+type EmailFilter struct {
+	// Recipient matches the exact address, case-insensitively
+	Recipient string
+	Status    entities.EmailStatus
+}
+
+// EmailRepository defines the contract for outbound email persistence
+// Calls join the transaction of their context, so emails are queued with the work mailing them
+type EmailRepository interface {
+	// Create stores an email and assigns its ID
+	Create(ctx context.Context, email *entities.Email) error
+	// SetTask records the task sending the email
+	SetTask(ctx context.Context, id, taskID uint) error
+	// Get returns the email with id; entities.ErrEmailNotFound otherwise
+	Get(ctx context.Context, id uint) (*entities.Email, error)
+	// List returns the emails matching filter, newest first, and their total
+	List(ctx context.Context, filter EmailFilter, limit, offset int) ([]*entities.Email, int64, error)
+	// Update saves the delivery state of an email with its body, dropped once it is sent
+	Update(ctx context.Context, email *entities.Email) error
+}
@@ -0,0 +1,18 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=email_usecase.go -destination=../../../mocks/email_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/mail/entities"
+	"clean-arch-gin/internal/domain/mail/repositories"
+)
+
+// EmailUseCase defines the delivery status lookups support answers inquiries with
+type EmailUseCase interface {
+	GetEmail(ctx context.Context, id uint) (*entities.Email, error)
+	// ListEmails returns the emails matching filter, newest first, and their total; unknown
+	// statuses fail with entities.ErrInvalidEmailStatus
+	ListEmails(ctx context.Context, filter repositories.EmailFilter, limit, offset int) ([]*entities.Email, int64, error)
+}
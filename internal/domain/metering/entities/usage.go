@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// Period is how long usage is aggregated over before it is reported
+const Period = time.Hour
+
+// PeriodStart returns the start of the period t falls in, in UTC
+func PeriodStart(t time.Time) time.Time {
+	return t.UTC().Truncate(Period)
+}
+
+// UsageRecord is the quantity of a metric a tenant used in a period, through an API key or
+// not (APIKeyID 0), and how much of it was reported for billing
+// Usage counted after the period was reported is reported with the next report
+type UsageRecord struct {
+	ID          uint
+	TenantID    uint
+	APIKeyID    uint
+	Metric      string
+	PeriodStart time.Time
+	Quantity    int64
+	Reported    int64
+}
+
+// Unreported is the quantity still to be reported
+func (r *UsageRecord) Unreported() int64 {
+	return r.Quantity - r.Reported
+}
+
+// UsageTotal is the quantity of a metric used through an API key, or without one
+// (APIKeyID 0), over a range of periods
+type UsageTotal struct {
+	APIKeyID uint
+	Metric   string
+	Quantity int64
+}
+
+// UsageSummary is the usage of a tenant from From until To
+type UsageSummary struct {
+	From   time.Time
+	To     time.Time
+	Totals []UsageTotal
+}
+
+// Domain errors for metering
+var (
+	ErrInvalidUsageRange = sharedEntities.DomainError{Message: "usage range must end after it starts and span at most a year"}
+)
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"strconv"
+	"time"
+
+	meteringEntities "clean-arch-gin/internal/domain/metering/entities"
+)
+
+// UsageReportedEventName is the name of UsageReportedEvent
+const UsageReportedEventName = "billing.usage_reported"
+
+// UsageItem is the quantity of a metric a report adds, through an API key or without one
+// (APIKeyID 0)
+type UsageItem struct {
+	APIKeyID uint   `json:"api_key_id,omitempty"`
+	Metric   string `json:"metric"`
+	Quantity int64  `json:"quantity"`
+}
+
+// UsageReportedEvent is published for each tenant and period with usage to bill, for an
+// external billing system to invoice. Quantities add to those reported before for the same
+// period, which happens when usage is counted late
+type UsageReportedEvent struct {
+	TenantID    uint
+	PeriodStart time.Time
+	Items       []UsageItem
+	occurredOn  time.Time
+}
+
+// NewUsageReportedEvent creates the event reporting items used by tenantID in the period
+// starting at periodStart
+func NewUsageReportedEvent(tenantID uint, periodStart time.Time, items []UsageItem) UsageReportedEvent {
+	return UsageReportedEvent{
+		TenantID:    tenantID,
+		PeriodStart: periodStart,
+		Items:       items,
+		occurredOn:  time.Now(),
+	}
+}
+
+// EventName returns the event name
+func (e UsageReportedEvent) EventName() string {
+	return UsageReportedEventName
+}
+
+// OccurredOn returns when the usage was reported
+func (e UsageReportedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+// EventData returns the event payload
+func (e UsageReportedEvent) EventData() interface{} {
+	return map[string]interface{}{
+		"tenant_id":    e.TenantID,
+		"period_start": e.PeriodStart,
+		"period_end":   e.PeriodStart.Add(meteringEntities.Period),
+		"items":        e.Items,
+	}
+}
+
+// EventSubject returns the tenant the usage is billed to
+func (e UsageReportedEvent) EventSubject() string {
+	return "tenants/" + strconv.FormatUint(uint64(e.TenantID), 10)
+}
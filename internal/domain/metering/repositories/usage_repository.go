@@ -0,0 +1,25 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=usage_repository.go -destination=../../../mocks/usage_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/domain/metering/entities"
+)
+
+// UsageRepository defines the contract for usage persistence
+type UsageRepository interface {
+	// Add adds the quantity of each record to the one of its tenant, API key, metric and
+	// period, across replicas
+	Add(ctx context.Context, records []*entities.UsageRecord) error
+	// ListUnreported returns up to limit records of periods starting before before with
+	// usage to report, across tenants, by tenant and period
+	ListUnreported(ctx context.Context, before time.Time, limit int) ([]*entities.UsageRecord, error)
+	// MarkReported adds quantity to the reported usage of a record
+	MarkReported(ctx context.Context, id uint, quantity int64) error
+	// Totals sums the usage of the tenant of ctx in the periods starting from from until
+	// to, by API key and metric; only the usage of apiKeyID when it is not nil
+	Totals(ctx context.Context, from, to time.Time, apiKeyID *uint) ([]entities.UsageTotal, error)
+}
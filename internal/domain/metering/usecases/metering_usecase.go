@@ -0,0 +1,20 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=metering_usecase.go -destination=../../../mocks/metering_usecase_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/domain/metering/entities"
+)
+
+// MeteringUseCase defines the business logic interface for usage metering
+type MeteringUseCase interface {
+	// ReportUsage publishes the usage of the periods ended by now, one event per tenant and
+	// period, and returns how many it published
+	ReportUsage(ctx context.Context, now time.Time) (int, error)
+	// GetUsage sums the usage of the tenant of ctx from from until to, of apiKeyID when it
+	// is not nil
+	GetUsage(ctx context.Context, from, to time.Time, apiKeyID *uint) (*entities.UsageSummary, error)
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// StockLevel is the stock of a product: OnHand units in the warehouse, of which Reserved
+// are held for confirmed orders
+// Products without a stock level are not tracked and never run out
+type StockLevel struct {
+	ProductID uint
+	OnHand    int
+	Reserved  int
+	UpdatedAt time.Time
+}
+
+// Available is how many units can still be reserved
+func (s *StockLevel) Available() int {
+	return s.OnHand - s.Reserved
+}
+
+// ReservationStatus is the state of the units of a product held for a confirmed order
+type ReservationStatus string
+
+const (
+	// ReservationActive holds the units until the order is shipped or the reservation expires
+	ReservationActive ReservationStatus = "active"
+	// ReservationCommitted took the units out of the stock on hand when the order shipped
+	ReservationCommitted ReservationStatus = "committed"
+	// ReservationReleased gave the units back, the order being cancelled or left unpaid
+	ReservationReleased ReservationStatus = "released"
+)
+
+// Inventory errors
+var (
+	ErrInsufficientStock  = sharedEntities.DomainError{Message: "insufficient stock"}
+	ErrInvalidStockLevel  = sharedEntities.DomainError{Message: "stock on hand cannot be negative nor below the reserved units"}
+	ErrStockLevelNotFound = sharedEntities.DomainError{Message: "product stock is not tracked"}
+)
+
+// Quantities sums the quantity ordered of each product, for reserving them at once
+func (o *Order) Quantities() map[uint]int {
+	quantities := make(map[uint]int, len(o.Items))
+	for _, item := range o.Items {
+		quantities[item.ProductID] += item.Quantity
+	}
+	return quantities
+}
@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/money"
+	"clean-arch-gin/internal/domain/shared/pricing"
+)
+
+// Invoice is the bill of a confirmed order
+// Its number comes from a per-tenant sequence without gaps, assigned when it is stored
+type Invoice struct {
+	ID uint
+	// Sequence is the invoice's position in its tenant's numbering, starting at 1
+	Sequence uint
+	OrderID  uint
+	UserID   uint
+	Lines    []*InvoiceLine
+	// Total is the lines' amounts plus TaxAmount and ShippingAmount
+	Total          float64
+	TaxAmount      float64
+	ShippingAmount float64
+	// Currency is the order's, which every amount is in
+	Currency money.Currency
+	IssuedAt time.Time
+}
+
+// InvoiceLine is an order item as billed
+type InvoiceLine struct {
+	ProductID uint
+	Quantity  int
+	UnitPrice float64
+	Amount    float64
+}
+
+// NewInvoice bills an order that was confirmed and not cancelled
+func NewInvoice(order *Order) (*Invoice, error) {
+	switch order.Status {
+	case OrderStatusPending, OrderStatusCancelled:
+		return nil, ErrOrderNotInvoiceable
+	}
+
+	invoice := &Invoice{
+		OrderID:        order.ID,
+		UserID:         order.UserID,
+		Lines:          make([]*InvoiceLine, len(order.Items)),
+		Total:          order.TaxAmount + order.ShippingAmount,
+		TaxAmount:      order.TaxAmount,
+		ShippingAmount: order.ShippingAmount,
+		Currency:       order.Currency,
+		IssuedAt:       time.Now(),
+	}
+	for i, item := range order.Items {
+		amount := pricing.Round(item.Price * float64(item.Quantity))
+		invoice.Lines[i] = &InvoiceLine{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			UnitPrice: item.Price,
+			Amount:    amount,
+		}
+		invoice.Total += amount
+	}
+	invoice.Total = pricing.Round(invoice.Total)
+	return invoice, nil
+}
+
+// Subtotal is the lines' amounts before tax and shipping
+func (i *Invoice) Subtotal() float64 {
+	return pricing.Round(i.Total - i.TaxAmount - i.ShippingAmount)
+}
+
+// Number is the invoice number printed on it, e.g. INV-000042
+func (i *Invoice) Number() string {
+	return fmt.Sprintf("INV-%06d", i.Sequence)
+}
+
+// Domain errors for invoices
+var (
+	ErrOrderNotInvoiceable = sharedEntities.DomainError{Message: "only confirmed orders can be invoiced"}
+	ErrInvoiceNotFound     = sharedEntities.DomainError{Message: "invoice not found"}
+)
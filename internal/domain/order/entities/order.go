@@ -4,6 +4,11 @@ import (
 	"time"
 
 	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/money"
+	"clean-arch-gin/internal/domain/shared/pricing"
+	"clean-arch-gin/internal/domain/shared/publicid"
+	"clean-arch-gin/internal/domain/shared/statemachine"
 )
 
 // OrderStatus represents the status of an order
@@ -12,14 +17,18 @@ type OrderStatus string
 const (
 	OrderStatusPending   OrderStatus = "pending"
 	OrderStatusConfirmed OrderStatus = "confirmed"
-	OrderStatusShipped   OrderStatus = "shipped"
-	OrderStatusDelivered OrderStatus = "delivered"
-	OrderStatusCancelled OrderStatus = "cancelled"
+	// OrderStatusPartiallyShipped has shipments covering some of its items
+	OrderStatusPartiallyShipped OrderStatus = "partially_shipped"
+	OrderStatusShipped          OrderStatus = "shipped"
+	OrderStatusDelivered        OrderStatus = "delivered"
+	OrderStatusCancelled        OrderStatus = "cancelled"
 )
 
 // Order represents the order aggregate root
 type Order struct {
-	ID          uint
+	ID uint
+	// PublicID identifies the order in routes and DTOs; ID stays internal
+	PublicID    string
 	UserID      uint
 	Status      OrderStatus
 	TotalAmount float64
@@ -27,6 +36,15 @@ type Order struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   *time.Time
+
+	// TaxAmount and ShippingAmount are included in TotalAmount; ApplyPricing sets them
+	TaxAmount      float64
+	ShippingAmount float64
+	// Currency is the currency of the order's amounts, its tenant's base currency when placed
+	Currency money.Currency
+
+	// Recorder holds the events raised by transitions until they are published
+	events.Recorder
 }
 
 // OrderItem represents an item within an order
@@ -49,6 +67,7 @@ func NewOrder(userID uint, items []*OrderItem) (*Order, error) {
 	}
 
 	order := &Order{
+		PublicID:  publicid.New(),
 		UserID:    userID,
 		Status:    OrderStatusPending,
 		Items:     items,
@@ -100,48 +119,75 @@ func (o *Order) RemoveItem(itemID uint) error {
 	return ErrOrderItemNotFound
 }
 
+// Order lifecycle transitions
+const (
+	OrderTransitionConfirm       = "confirm"
+	OrderTransitionShipPartially = "ship_partially"
+	OrderTransitionShip          = "ship"
+	OrderTransitionDeliver       = "deliver"
+	OrderTransitionCancel        = "cancel"
+)
+
+// orderLifecycle is the order state machine; every transition raises an OrderStatusChangedEvent
+var orderLifecycle = statemachine.New(
+	func(o *Order) OrderStatus { return o.Status },
+	func(o *Order, to OrderStatus, at time.Time) {
+		o.Status = to
+		o.UpdatedAt = at
+	}).
+	RejectWith(ErrInvalidOrderStatusTransition).
+	Permit(statemachine.Transition[OrderStatus, *Order]{
+		Name: OrderTransitionConfirm, From: []OrderStatus{OrderStatusPending}, To: OrderStatusConfirmed,
+	}).
+	Permit(statemachine.Transition[OrderStatus, *Order]{
+		Name: OrderTransitionShipPartially, From: []OrderStatus{OrderStatusConfirmed}, To: OrderStatusPartiallyShipped,
+	}).
+	Permit(statemachine.Transition[OrderStatus, *Order]{
+		Name: OrderTransitionShip, From: []OrderStatus{OrderStatusConfirmed, OrderStatusPartiallyShipped}, To: OrderStatusShipped,
+	}).
+	Permit(statemachine.Transition[OrderStatus, *Order]{
+		Name: OrderTransitionDeliver, From: []OrderStatus{OrderStatusShipped}, To: OrderStatusDelivered,
+	}).
+	Permit(statemachine.Transition[OrderStatus, *Order]{
+		Name: OrderTransitionCancel,
+		From: []OrderStatus{
+			OrderStatusPending, OrderStatusConfirmed, OrderStatusPartiallyShipped, OrderStatusShipped, OrderStatusDelivered,
+		},
+		To: OrderStatusCancelled,
+		Guard: func(o *Order) error {
+			if o.Status == OrderStatusDelivered {
+				return ErrCannotCancelDeliveredOrder
+			}
+			return nil
+		},
+	}).
+	Emit(func(o *Order, change statemachine.Change[OrderStatus]) events.DomainEvent {
+		return NewOrderStatusChangedEvent(o, change.From)
+	})
+
 // Confirm changes order status to confirmed
 func (o *Order) Confirm() error {
-	if o.Status != OrderStatusPending {
-		return ErrInvalidOrderStatusTransition
-	}
-
-	o.Status = OrderStatusConfirmed
-	o.UpdatedAt = time.Now()
-	return nil
+	return orderLifecycle.Fire(o, OrderTransitionConfirm)
 }
 
-// Ship changes order status to shipped
+// Ship changes order status to shipped; AddShipment ships the order in parts
 func (o *Order) Ship() error {
-	if o.Status != OrderStatusConfirmed {
-		return ErrInvalidOrderStatusTransition
-	}
-
-	o.Status = OrderStatusShipped
-	o.UpdatedAt = time.Now()
-	return nil
+	return orderLifecycle.Fire(o, OrderTransitionShip)
 }
 
 // Deliver changes order status to delivered
 func (o *Order) Deliver() error {
-	if o.Status != OrderStatusShipped {
-		return ErrInvalidOrderStatusTransition
-	}
-
-	o.Status = OrderStatusDelivered
-	o.UpdatedAt = time.Now()
-	return nil
+	return orderLifecycle.Fire(o, OrderTransitionDeliver)
 }
 
-// Cancel cancels the order
+// Cancel cancels an order that has not been delivered nor already cancelled
 func (o *Order) Cancel() error {
-	if o.Status == OrderStatusDelivered {
-		return ErrCannotCancelDeliveredOrder
-	}
+	return orderLifecycle.Fire(o, OrderTransitionCancel)
+}
 
-	o.Status = OrderStatusCancelled
-	o.UpdatedAt = time.Now()
-	return nil
+// AvailableTransitions lists the transitions the order may take from its current status
+func (o *Order) AvailableTransitions() []string {
+	return orderLifecycle.Available(o)
 }
 
 // IsDeleted checks if the order is soft deleted
@@ -156,13 +202,33 @@ func (o *Order) MarkAsDeleted() {
 	o.UpdatedAt = now
 }
 
-// calculateTotal calculates the total amount of the order
-func (o *Order) calculateTotal() {
-	total := 0.0
+// ApplyPricing adds the tax and shipping of the breakdown the pending order was priced with,
+// in the breakdown's currency
+func (o *Order) ApplyPricing(breakdown *PriceBreakdown) error {
+	if o.Status != OrderStatusPending {
+		return ErrOrderNotModifiable
+	}
+
+	o.TaxAmount = breakdown.Tax
+	o.ShippingAmount = breakdown.Shipping
+	o.Currency = breakdown.Currency
+	o.calculateTotal()
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// Subtotal is the price of the order's items before tax and shipping
+func (o *Order) Subtotal() float64 {
+	subtotal := 0.0
 	for _, item := range o.Items {
-		total += item.Price * float64(item.Quantity)
+		subtotal += item.Price * float64(item.Quantity)
 	}
-	o.TotalAmount = total
+	return pricing.Round(subtotal)
+}
+
+// calculateTotal calculates the total amount of the order
+func (o *Order) calculateTotal() {
+	o.TotalAmount = pricing.Round(o.Subtotal() + o.TaxAmount + o.ShippingAmount)
 }
 
 // Domain errors for order
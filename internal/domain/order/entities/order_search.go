@@ -0,0 +1,52 @@
+package entities
+
+import (
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/search"
+)
+
+// Errors of order searches
+var (
+	ErrInvalidOrderSearch       = sharedEntities.DomainError{Message: "order search query needs at least one word of letters or digits"}
+	ErrInvalidOrderSearchStatus = sharedEntities.DomainError{Message: "unknown order status"}
+)
+
+// orderStatuses are the statuses orders can be searched by
+var orderStatuses = map[OrderStatus]bool{
+	OrderStatusPending:          true,
+	OrderStatusConfirmed:        true,
+	OrderStatusPartiallyShipped: true,
+	OrderStatusShipped:          true,
+	OrderStatusDelivered:        true,
+	OrderStatusCancelled:        true,
+}
+
+// OrderSearch selects the orders of a user whose public ID starts with every term, in
+// Status when it is not empty
+type OrderSearch struct {
+	Terms  []string
+	UserID uint
+	Status OrderStatus
+}
+
+// NewOrderSearch creates the search of userID's orders matching query, in status when it
+// is not empty
+func NewOrderSearch(query string, userID uint, status OrderStatus) (*OrderSearch, error) {
+	if userID == 0 {
+		return nil, ErrInvalidUserID
+	}
+	if status != "" && !orderStatuses[status] {
+		return nil, ErrInvalidOrderSearchStatus
+	}
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		return nil, ErrInvalidOrderSearch
+	}
+	return &OrderSearch{Terms: terms, UserID: userID, Status: status}, nil
+}
+
+// OrderSearchHit is an order matching a search with its relevance, higher first
+type OrderSearchHit struct {
+	Order *Order
+	Score float64
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"strconv"
+	"time"
+)
+
+// OrderStatusChangedEventName is the name of OrderStatusChangedEvent
+const OrderStatusChangedEventName = "order.status_changed"
+
+// OrderStatusChangedEvent is raised whenever an order moves to a new status
+type OrderStatusChangedEvent struct {
+	OrderID    uint
+	UserID     uint
+	From       OrderStatus
+	To         OrderStatus
+	occurredOn time.Time
+}
+
+// NewOrderStatusChangedEvent creates the event for a transition that just happened
+func NewOrderStatusChangedEvent(order *Order, from OrderStatus) OrderStatusChangedEvent {
+	return OrderStatusChangedEvent{
+		OrderID:    order.ID,
+		UserID:     order.UserID,
+		From:       from,
+		To:         order.Status,
+		occurredOn: order.UpdatedAt,
+	}
+}
+
+// EventName returns the event name
+func (e OrderStatusChangedEvent) EventName() string {
+	return OrderStatusChangedEventName
+}
+
+// OccurredOn returns when the transition happened
+func (e OrderStatusChangedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+// EventData returns the event payload
+func (e OrderStatusChangedEvent) EventData() interface{} {
+	return map[string]interface{}{
+		"order_id": e.OrderID,
+		"user_id":  e.UserID,
+		"from":     e.From,
+		"to":       e.To,
+	}
+}
+
+// EventSubject returns the order the event is about
+func (e OrderStatusChangedEvent) EventSubject() string {
+	return "orders/" + strconv.FormatUint(uint64(e.OrderID), 10)
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"clean-arch-gin/internal/domain/shared/money"
+	"clean-arch-gin/internal/domain/shared/pricing"
+)
+
+// PriceBreakdown itemises the price of items shipped to a destination: each line with its
+// tax, then shipping. Amounts are rounded to cents
+type PriceBreakdown struct {
+	Lines    []*PriceLine
+	Subtotal float64
+	// TaxLabel names the tax, e.g. "Tax 19%"; empty when none is due
+	TaxLabel       string
+	Tax            float64
+	ShippingMethod string
+	Shipping       float64
+	Total          float64
+	// Currency is the currency of every amount, the base currency of the tenant pricing it
+	Currency money.Currency
+}
+
+// PriceLine is an item of a price breakdown
+type PriceLine struct {
+	ProductID uint
+	Quantity  int
+	UnitPrice float64
+	// Amount is the line's price before tax
+	Amount float64
+	Tax    float64
+}
+
+// NewPriceBreakdown totals lines, priced with their tax in currency, and the shipping rate
+func NewPriceBreakdown(lines []*PriceLine, taxLabel string, shipping pricing.ShippingRate, currency money.Currency) *PriceBreakdown {
+	breakdown := &PriceBreakdown{
+		Currency:       currency,
+		Lines:          lines,
+		TaxLabel:       taxLabel,
+		ShippingMethod: shipping.Method,
+		Shipping:       shipping.Amount,
+	}
+	for _, line := range lines {
+		breakdown.Subtotal += line.Amount
+		breakdown.Tax += line.Tax
+	}
+	breakdown.Subtotal = pricing.Round(breakdown.Subtotal)
+	breakdown.Tax = pricing.Round(breakdown.Tax)
+	breakdown.Total = pricing.Round(breakdown.Subtotal + breakdown.Tax + breakdown.Shipping)
+	return breakdown
+}
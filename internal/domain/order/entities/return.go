@@ -0,0 +1,243 @@
+package entities
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/statemachine"
+)
+
+// ReturnStatus represents the status of a return (RMA)
+type ReturnStatus string
+
+const (
+	// ReturnStatusRequested waits for the shop to approve or reject it
+	ReturnStatusRequested ReturnStatus = "requested"
+	// ReturnStatusApproved waits for the goods to come back
+	ReturnStatusApproved ReturnStatus = "approved"
+	ReturnStatusRejected ReturnStatus = "rejected"
+	// ReturnStatusReceived has its goods back in stock and waits for the refund
+	ReturnStatusReceived ReturnStatus = "received"
+	ReturnStatusRefunded ReturnStatus = "refunded"
+)
+
+// Return lifecycle transitions
+const (
+	ReturnTransitionApprove = "approve"
+	ReturnTransitionReject  = "reject"
+	ReturnTransitionReceive = "receive"
+	ReturnTransitionRefund  = "refund"
+)
+
+// Return is a return merchandise authorization (RMA): some units of a delivered order sent
+// back for a refund
+type Return struct {
+	ID      uint
+	OrderID uint
+	UserID  uint
+	Status  ReturnStatus
+	Reason  string
+	Items   []*ReturnItem
+	// RefundAmount is what the returned units were paid
+	RefundAmount float64
+	// RefundReference is the payment gateway's reference, once refunded
+	RefundReference string
+	// Note is the shop's explanation of its decision
+	Note      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Recorder holds the events raised by transitions until they are published
+	events.Recorder
+}
+
+// ReturnItem is how many units of an order item are returned
+type ReturnItem struct {
+	ID          uint
+	ReturnID    uint
+	OrderItemID uint
+	ProductID   uint
+	Quantity    int
+	Price       float64
+}
+
+// returnLifecycle is the return state machine; every transition raises a ReturnStatusChangedEvent
+var returnLifecycle = statemachine.New(
+	func(r *Return) ReturnStatus { return r.Status },
+	func(r *Return, to ReturnStatus, at time.Time) {
+		r.Status = to
+		r.UpdatedAt = at
+	}).
+	RejectWith(ErrInvalidReturnStatusTransition).
+	Permit(statemachine.Transition[ReturnStatus, *Return]{
+		Name: ReturnTransitionApprove, From: []ReturnStatus{ReturnStatusRequested}, To: ReturnStatusApproved,
+	}).
+	Permit(statemachine.Transition[ReturnStatus, *Return]{
+		Name: ReturnTransitionReject, From: []ReturnStatus{ReturnStatusRequested}, To: ReturnStatusRejected,
+	}).
+	Permit(statemachine.Transition[ReturnStatus, *Return]{
+		Name: ReturnTransitionReceive, From: []ReturnStatus{ReturnStatusApproved}, To: ReturnStatusReceived,
+	}).
+	Permit(statemachine.Transition[ReturnStatus, *Return]{
+		Name: ReturnTransitionRefund, From: []ReturnStatus{ReturnStatusReceived}, To: ReturnStatusRefunded,
+	}).
+	Emit(func(r *Return, change statemachine.Change[ReturnStatus]) events.DomainEvent {
+		return NewReturnStatusChangedEvent(r, change.From)
+	})
+
+// NewReturn requests the return of quantities, keyed by order item ID, of a delivered order
+// returned holds the order's earlier returns; units of rejected ones may be returned again
+func NewReturn(order *Order, returned []*Return, reason string, quantities map[uint]int) (*Return, error) {
+	if order.Status != OrderStatusDelivered {
+		return nil, ErrOrderNotReturnable
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrReturnReasonRequired
+	}
+	if len(quantities) == 0 {
+		return nil, ErrEmptyReturn
+	}
+
+	remaining := order.Returnable(returned)
+	r := &Return{
+		OrderID:   order.ID,
+		UserID:    order.UserID,
+		Status:    ReturnStatusRequested,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	for _, item := range order.Items {
+		quantity, ok := quantities[item.ID]
+		if !ok {
+			continue
+		}
+		if quantity <= 0 {
+			return nil, ErrInvalidReturnQuantity
+		}
+		if quantity > remaining[item.ID] {
+			return nil, ErrReturnExceedsOrder
+		}
+		remaining[item.ID] -= quantity
+		r.Items = append(r.Items, &ReturnItem{
+			OrderItemID: item.ID,
+			ProductID:   item.ProductID,
+			Quantity:    quantity,
+			Price:       item.Price,
+		})
+		r.RefundAmount += item.Price * float64(quantity)
+	}
+	if len(r.Items) != len(quantities) {
+		return nil, ErrReturnItemNotInOrder
+	}
+	sort.Slice(r.Items, func(i, j int) bool { return r.Items[i].OrderItemID < r.Items[j].OrderItemID })
+	return r, nil
+}
+
+// Returnable returns the units per order item ID not claimed by the returns in returned yet
+func (o *Order) Returnable(returned []*Return) map[uint]int {
+	remaining := make(map[uint]int, len(o.Items))
+	for _, item := range o.Items {
+		remaining[item.ID] += item.Quantity
+	}
+	for _, r := range returned {
+		if r.Status == ReturnStatusRejected {
+			continue
+		}
+		for _, item := range r.Items {
+			remaining[item.OrderItemID] -= item.Quantity
+		}
+	}
+	return remaining
+}
+
+// Approve accepts the return; the customer may send the goods back
+func (r *Return) Approve(note string) error {
+	if err := returnLifecycle.Fire(r, ReturnTransitionApprove); err != nil {
+		return err
+	}
+	r.Note = strings.TrimSpace(note)
+	return nil
+}
+
+// Reject refuses the return, explaining why in note
+func (r *Return) Reject(note string) error {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return ErrReturnNoteRequired
+	}
+	if err := returnLifecycle.Fire(r, ReturnTransitionReject); err != nil {
+		return err
+	}
+	r.Note = note
+	return nil
+}
+
+// Receive records that the returned goods are back
+func (r *Return) Receive() error {
+	return returnLifecycle.Fire(r, ReturnTransitionReceive)
+}
+
+// CanRefund reports whether the return is waiting for its refund
+func (r *Return) CanRefund() bool {
+	return returnLifecycle.Can(r, ReturnTransitionRefund)
+}
+
+// Refunded records the payment gateway's reference of the refund issued for the return
+func (r *Return) Refunded(reference string) error {
+	if reference == "" {
+		return ErrRefundReferenceRequired
+	}
+	if err := returnLifecycle.Fire(r, ReturnTransitionRefund); err != nil {
+		return err
+	}
+	r.RefundReference = reference
+	return nil
+}
+
+// Quantities returns the returned units per product
+func (r *Return) Quantities() map[uint]int {
+	quantities := make(map[uint]int, len(r.Items))
+	for _, item := range r.Items {
+		quantities[item.ProductID] += item.Quantity
+	}
+	return quantities
+}
+
+// RMANumber is the number customers quote on the parcel, e.g. RMA-000042
+func (r *Return) RMANumber() string {
+	return FormatRMANumber(r.ID)
+}
+
+// FormatRMANumber formats the RMA number of the return with the given ID
+func FormatRMANumber(id uint) string {
+	number := strconv.FormatUint(uint64(id), 10)
+	if len(number) < 6 {
+		number = strings.Repeat("0", 6-len(number)) + number
+	}
+	return "RMA-" + number
+}
+
+// AvailableTransitions lists the transitions the return may take from its current status
+func (r *Return) AvailableTransitions() []string {
+	return returnLifecycle.Available(r)
+}
+
+// Domain errors for returns
+var (
+	ErrOrderNotReturnable            = sharedEntities.DomainError{Message: "only delivered orders can be returned"}
+	ErrReturnReasonRequired          = sharedEntities.DomainError{Message: "return reason is required"}
+	ErrEmptyReturn                   = sharedEntities.DomainError{Message: "return must contain at least one item"}
+	ErrInvalidReturnQuantity         = sharedEntities.DomainError{Message: "return quantities must be positive"}
+	ErrReturnItemNotInOrder          = sharedEntities.DomainError{Message: "return item is not in the order"}
+	ErrReturnExceedsOrder            = sharedEntities.DomainError{Message: "return exceeds the units not returned yet"}
+	ErrReturnNoteRequired            = sharedEntities.DomainError{Message: "a note explaining the rejection is required"}
+	ErrRefundReferenceRequired       = sharedEntities.DomainError{Message: "refund reference is required"}
+	ErrInvalidReturnStatusTransition = sharedEntities.DomainError{Message: "invalid return status transition"}
+	ErrReturnNotFound                = sharedEntities.DomainError{Message: "return not found"}
+)
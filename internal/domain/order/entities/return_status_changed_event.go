@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"strconv"
+	"time"
+)
+
+// ReturnStatusChangedEventName is the name of ReturnStatusChangedEvent
+const ReturnStatusChangedEventName = "return.status_changed"
+
+// ReturnStatusChangedEvent is raised whenever a return moves to a new status
+type ReturnStatusChangedEvent struct {
+	ReturnID   uint
+	OrderID    uint
+	UserID     uint
+	From       ReturnStatus
+	To         ReturnStatus
+	occurredOn time.Time
+}
+
+// NewReturnStatusChangedEvent creates the event for a transition that just happened
+func NewReturnStatusChangedEvent(r *Return, from ReturnStatus) ReturnStatusChangedEvent {
+	return ReturnStatusChangedEvent{
+		ReturnID:   r.ID,
+		OrderID:    r.OrderID,
+		UserID:     r.UserID,
+		From:       from,
+		To:         r.Status,
+		occurredOn: r.UpdatedAt,
+	}
+}
+
+// EventName returns the event name
+func (e ReturnStatusChangedEvent) EventName() string {
+	return ReturnStatusChangedEventName
+}
+
+// OccurredOn returns when the transition happened
+func (e ReturnStatusChangedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+// EventData returns the event payload
+func (e ReturnStatusChangedEvent) EventData() interface{} {
+	return map[string]interface{}{
+		"return_id": e.ReturnID,
+		"order_id":  e.OrderID,
+		"user_id":   e.UserID,
+		"from":      e.From,
+		"to":        e.To,
+	}
+}
+
+// EventSubject returns the return the event is about
+func (e ReturnStatusChangedEvent) EventSubject() string {
+	return "returns/" + strconv.FormatUint(uint64(e.ReturnID), 10)
+}
@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// Shipment is a parcel sent for part or all of an order
+type Shipment struct {
+	ID             uint
+	OrderID        uint
+	TrackingNumber string
+	Carrier        string
+	Items          []*ShipmentItem
+	CreatedAt      time.Time
+}
+
+// ShipmentItem is how many units of an order item a shipment contains
+type ShipmentItem struct {
+	ID          uint
+	ShipmentID  uint
+	OrderItemID uint
+	Quantity    int
+}
+
+// NewShipment creates a shipment of quantities, keyed by order item ID, for the order
+func NewShipment(orderID uint, trackingNumber, carrier string, quantities map[uint]int) (*Shipment, error) {
+	trackingNumber = strings.TrimSpace(trackingNumber)
+	if trackingNumber == "" {
+		return nil, ErrTrackingNumberRequired
+	}
+	if len(quantities) == 0 {
+		return nil, ErrEmptyShipment
+	}
+
+	shipment := &Shipment{
+		OrderID:        orderID,
+		TrackingNumber: trackingNumber,
+		Carrier:        strings.TrimSpace(carrier),
+		CreatedAt:      time.Now(),
+	}
+	for orderItemID, quantity := range quantities {
+		if quantity <= 0 {
+			return nil, ErrInvalidShipmentQuantity
+		}
+		shipment.Items = append(shipment.Items, &ShipmentItem{OrderItemID: orderItemID, Quantity: quantity})
+	}
+	sort.Slice(shipment.Items, func(i, j int) bool { return shipment.Items[i].OrderItemID < shipment.Items[j].OrderItemID })
+	return shipment, nil
+}
+
+// Quantities returns the shipped units per order item ID
+func (s *Shipment) Quantities() map[uint]int {
+	quantities := make(map[uint]int, len(s.Items))
+	for _, item := range s.Items {
+		quantities[item.OrderItemID] += item.Quantity
+	}
+	return quantities
+}
+
+// AddShipment validates shipment against the order's items and the units already sent in
+// shipped, then moves the order to shipped once every unit is covered or to partially
+// shipped before that
+func (o *Order) AddShipment(shipped []*Shipment, shipment *Shipment) error {
+	if !orderLifecycle.Can(o, OrderTransitionShip) {
+		return ErrInvalidOrderStatusTransition
+	}
+	if shipment.OrderID != o.ID {
+		return ErrShipmentItemNotInOrder
+	}
+
+	remaining := o.Unshipped(shipped)
+	for orderItemID, quantity := range shipment.Quantities() {
+		left, ok := remaining[orderItemID]
+		if !ok {
+			return ErrShipmentItemNotInOrder
+		}
+		if quantity > left {
+			return ErrShipmentExceedsOrder
+		}
+		remaining[orderItemID] = left - quantity
+	}
+
+	for _, left := range remaining {
+		if left > 0 {
+			if o.Status == OrderStatusPartiallyShipped {
+				return nil
+			}
+			return orderLifecycle.Fire(o, OrderTransitionShipPartially)
+		}
+	}
+	return o.Ship()
+}
+
+// Unshipped returns the units per order item ID not covered by shipped yet
+func (o *Order) Unshipped(shipped []*Shipment) map[uint]int {
+	remaining := make(map[uint]int, len(o.Items))
+	for _, item := range o.Items {
+		remaining[item.ID] += item.Quantity
+	}
+	for _, shipment := range shipped {
+		for orderItemID, quantity := range shipment.Quantities() {
+			remaining[orderItemID] -= quantity
+		}
+	}
+	return remaining
+}
+
+// Domain errors for shipments
+var (
+	ErrTrackingNumberRequired  = sharedEntities.DomainError{Message: "tracking number is required"}
+	ErrEmptyShipment           = sharedEntities.DomainError{Message: "shipment must contain at least one item"}
+	ErrInvalidShipmentQuantity = sharedEntities.DomainError{Message: "shipment quantities must be positive"}
+	ErrShipmentItemNotInOrder  = sharedEntities.DomainError{Message: "shipment item is not in the order"}
+	ErrShipmentExceedsOrder    = sharedEntities.DomainError{Message: "shipment exceeds the unshipped quantity"}
+)
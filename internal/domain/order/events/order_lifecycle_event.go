@@ -0,0 +1,82 @@
+package events
+
+import (
+	"strconv"
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+)
+
+// Names of the OrderLifecycleEvents, one per milestone external fulfilment systems act on
+const (
+	OrderConfirmedEventName = "order.confirmed"
+	OrderShippedEventName   = "order.shipped"
+	OrderCancelledEventName = "order.cancelled"
+)
+
+// LifecycleEventNames maps the statuses that raise an OrderLifecycleEvent to its name
+var LifecycleEventNames = map[orderEntities.OrderStatus]string{
+	orderEntities.OrderStatusConfirmed: OrderConfirmedEventName,
+	orderEntities.OrderStatusShipped:   OrderShippedEventName,
+	orderEntities.OrderStatusCancelled: OrderCancelledEventName,
+}
+
+// OrderLifecycleEvent is published when an order reaches a lifecycle milestone, carrying the
+// whole order so webhook subscribers need not call back for it
+type OrderLifecycleEvent struct {
+	name       string
+	Order      *orderEntities.Order
+	occurredOn time.Time
+}
+
+// NewOrderLifecycleEvent creates the event named name for order as it is now
+func NewOrderLifecycleEvent(name string, order *orderEntities.Order) OrderLifecycleEvent {
+	return OrderLifecycleEvent{
+		name:       name,
+		Order:      order,
+		occurredOn: order.UpdatedAt,
+	}
+}
+
+// EventName returns the event name, e.g. order.confirmed
+func (e OrderLifecycleEvent) EventName() string {
+	return e.name
+}
+
+// OccurredOn returns when the order reached the milestone
+func (e OrderLifecycleEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+// EventData returns the order as of the milestone
+func (e OrderLifecycleEvent) EventData() interface{} {
+	items := make([]map[string]interface{}, len(e.Order.Items))
+	for i, item := range e.Order.Items {
+		items[i] = map[string]interface{}{
+			"id":         item.ID,
+			"product_id": item.ProductID,
+			"quantity":   item.Quantity,
+			"price":      item.Price,
+		}
+	}
+
+	return map[string]interface{}{
+		"order": map[string]interface{}{
+			"id":              e.Order.ID,
+			"user_id":         e.Order.UserID,
+			"status":          e.Order.Status,
+			"total_amount":    e.Order.TotalAmount,
+			"tax_amount":      e.Order.TaxAmount,
+			"shipping_amount": e.Order.ShippingAmount,
+			"currency":        e.Order.Currency,
+			"items":           items,
+			"created_at":      e.Order.CreatedAt,
+			"updated_at":      e.Order.UpdatedAt,
+		},
+	}
+}
+
+// EventSubject returns the order the event is about
+func (e OrderLifecycleEvent) EventSubject() string {
+	return "orders/" + strconv.FormatUint(uint64(e.Order.ID), 10)
+}
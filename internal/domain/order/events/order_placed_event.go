@@ -0,0 +1,50 @@
+package events
+
+import (
+	"strconv"
+	"time"
+
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+)
+
+// OrderPlacedEventName is the name of OrderPlacedEvent
+const OrderPlacedEventName = "order.placed"
+
+// OrderPlacedEvent is published when a user places a pending order
+type OrderPlacedEvent struct {
+	OrderID    uint
+	UserID     uint
+	occurredOn time.Time
+}
+
+// NewOrderPlacedEvent creates the event for an order just saved
+func NewOrderPlacedEvent(order *orderEntities.Order) OrderPlacedEvent {
+	return OrderPlacedEvent{
+		OrderID:    order.ID,
+		UserID:     order.UserID,
+		occurredOn: order.CreatedAt,
+	}
+}
+
+// EventName returns the event name
+func (e OrderPlacedEvent) EventName() string {
+	return OrderPlacedEventName
+}
+
+// OccurredOn returns when the order was placed
+func (e OrderPlacedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+// EventData returns the event payload
+func (e OrderPlacedEvent) EventData() interface{} {
+	return map[string]interface{}{
+		"order_id": e.OrderID,
+		"user_id":  e.UserID,
+	}
+}
+
+// EventSubject returns the order the event is about
+func (e OrderPlacedEvent) EventSubject() string {
+	return "orders/" + strconv.FormatUint(uint64(e.OrderID), 10)
+}
@@ -0,0 +1,18 @@
+package events
+
+import (
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+)
+
+// The event is declared with the order entity, whose lifecycle raises it
+
+// OrderStatusChangedEventName is the name of OrderStatusChangedEvent
+const OrderStatusChangedEventName = orderEntities.OrderStatusChangedEventName
+
+// OrderStatusChangedEvent is published whenever an order moves to a new status
+type OrderStatusChangedEvent = orderEntities.OrderStatusChangedEvent
+
+// NewOrderStatusChangedEvent creates the event for a transition that just happened
+func NewOrderStatusChangedEvent(order *orderEntities.Order, from orderEntities.OrderStatus) OrderStatusChangedEvent {
+	return orderEntities.NewOrderStatusChangedEvent(order, from)
+}
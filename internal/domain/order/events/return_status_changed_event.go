@@ -0,0 +1,11 @@
+package events
+
+import (
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+)
+
+// ReturnStatusChangedEventName is the name of ReturnStatusChangedEvent
+const ReturnStatusChangedEventName = orderEntities.ReturnStatusChangedEventName
+
+// ReturnStatusChangedEvent is published whenever a return moves to a new status
+type ReturnStatusChangedEvent = orderEntities.ReturnStatusChangedEvent
@@ -0,0 +1,30 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=inventory_repository.go -destination=../../../mocks/inventory_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/domain/order/entities"
+)
+
+// InventoryRepository defines the contract for stock levels and their reservations
+// Reservations lock the stock rows they change, so concurrent orders cannot oversell
+type InventoryRepository interface {
+	// GetStock returns entities.ErrStockLevelNotFound for untracked products
+	GetStock(ctx context.Context, productID uint) (*entities.StockLevel, error)
+	// SetOnHand tracks the product's stock with onHand units, which must cover the reserved ones
+	SetOnHand(ctx context.Context, productID uint, onHand int) (*entities.StockLevel, error)
+	// Reserve holds the quantity of each product for the order until expiresAt, all or none;
+	// entities.ErrInsufficientStock is returned when a tracked product has too few units
+	Reserve(ctx context.Context, orderID uint, quantities map[uint]int, expiresAt time.Time) error
+	// Release gives the order's active reservations back to the stock
+	Release(ctx context.Context, orderID uint) error
+	// Commit takes the order's active reservations out of the stock on hand
+	Commit(ctx context.Context, orderID uint) error
+	// Restock puts returned units, keyed by product ID, back on hand for tracked products
+	Restock(ctx context.Context, quantities map[uint]int) error
+	// ListExpiredOrderIDs returns up to limit orders with active reservations expired at now
+	ListExpiredOrderIDs(ctx context.Context, now time.Time, limit int) ([]uint, error)
+}
@@ -0,0 +1,19 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=invoice_repository.go -destination=../../../mocks/invoice_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/order/entities"
+)
+
+// InvoiceRepository defines the contract for invoice persistence
+type InvoiceRepository interface {
+	// Create stores the invoice under the next number of the tenant's sequence, taken in the
+	// same transaction so that numbers have no gaps. An order has one invoice: when it
+	// already has one, that invoice is returned instead
+	Create(ctx context.Context, invoice *entities.Invoice) (*entities.Invoice, error)
+	// GetByOrderID returns entities.ErrInvoiceNotFound when the order has no invoice
+	GetByOrderID(ctx context.Context, orderID uint) (*entities.Invoice, error)
+}
@@ -0,0 +1,35 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=order_repository.go -destination=../../../mocks/order_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/domain/order/entities"
+	"clean-arch-gin/internal/domain/shared/pagination"
+)
+
+// OrderRepository defines the contract for order data persistence
+// Orders are always loaded together with their items
+type OrderRepository interface {
+	Create(ctx context.Context, order *entities.Order) error
+	GetByID(ctx context.Context, id uint) (*entities.Order, error)
+	// GetByPublicID finds an order by the identifier it is exposed by
+	GetByPublicID(ctx context.Context, publicID string) (*entities.Order, error)
+	GetByUserID(ctx context.Context, userID uint, limit, offset int) ([]*entities.Order, error)
+	// ListAfter returns up to limit orders after cursor in created_at, id order; a page
+	// resumes from the cursor of the last order of the previous one instead of an offset
+	ListAfter(ctx context.Context, cursor pagination.Cursor, limit int) ([]*entities.Order, error)
+	// GetByUserIDs loads the orders of several users in one query for batched lookups
+	GetByUserIDs(ctx context.Context, userIDs []uint) ([]*entities.Order, error)
+	// GetPendingCreatedBefore loads up to limit pending orders created before the given time, oldest first
+	GetPendingCreatedBefore(ctx context.Context, before time.Time, limit int) ([]*entities.Order, error)
+	Update(ctx context.Context, order *entities.Order) error
+	// Delete soft deletes an order; the other methods only see it with a context from
+	// softdelete.WithDeleted or softdelete.OnlyDeleted
+	Delete(ctx context.Context, id uint) error
+	// PurgeOlderThan permanently deletes the orders soft deleted more than age ago with their
+	// items, shipments and returns; their invoices are kept as accounting records
+	PurgeOlderThan(ctx context.Context, age time.Duration) (int64, error)
+}
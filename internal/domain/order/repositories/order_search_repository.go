@@ -0,0 +1,16 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=order_search_repository.go -destination=../../../mocks/order_search_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/order/entities"
+)
+
+// OrderSearchRepository defines the contract for searching orders
+type OrderSearchRepository interface {
+	// Search returns a page of the orders matching criteria, most relevant and then newest
+	// first, and their total
+	Search(ctx context.Context, criteria entities.OrderSearch, limit, offset int) ([]*entities.OrderSearchHit, int64, error)
+}
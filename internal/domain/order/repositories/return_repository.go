@@ -0,0 +1,24 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=return_repository.go -destination=../../../mocks/return_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/order/entities"
+)
+
+// ReturnRepository defines the contract for order returns (RMAs)
+// Returns are always loaded together with their items
+type ReturnRepository interface {
+	// Create stores the return with its items, locking the order so concurrent returns cannot
+	// claim more units than were ordered: entities.ErrReturnExceedsOrder is returned then
+	Create(ctx context.Context, r *entities.Return) error
+	GetByID(ctx context.Context, id uint) (*entities.Return, error)
+	// ListByOrderID returns the order's returns, oldest first
+	ListByOrderID(ctx context.Context, orderID uint) ([]*entities.Return, error)
+	// Update saves the return's status, note and refund reference if it is still in status
+	// from, so concurrent transitions cannot both apply: entities.ErrInvalidReturnStatusTransition
+	// is returned for the later one
+	Update(ctx context.Context, r *entities.Return, from entities.ReturnStatus) error
+}
@@ -0,0 +1,19 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=shipment_repository.go -destination=../../../mocks/shipment_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/order/entities"
+)
+
+// ShipmentRepository defines the contract for the shipments of orders
+type ShipmentRepository interface {
+	// Create stores the shipment with its items, locking the order so concurrent shipments
+	// cannot send more than was ordered: entities.ErrShipmentExceedsOrder is returned then
+	Create(ctx context.Context, shipment *entities.Shipment) error
+	// ListByOrderID returns the order's shipments with their items, oldest first
+	ListByOrderID(ctx context.Context, orderID uint) ([]*entities.Shipment, error)
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,16 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=inventory_usecase.go -destination=../../../mocks/inventory_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/order/entities"
+)
+
+// InventoryUseCase defines the business logic operations for managing stock levels
+type InventoryUseCase interface {
+	GetStock(ctx context.Context, productID uint) (*entities.StockLevel, error)
+	// SetStock sets the units on hand, e.g. after a stock count; the reserved units stay held
+	SetStock(ctx context.Context, productID uint, onHand int) (*entities.StockLevel, error)
+}
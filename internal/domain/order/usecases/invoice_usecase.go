@@ -0,0 +1,20 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=invoice_usecase.go -destination=../../../mocks/invoice_usecase_mock.go -package=mocks
+
+import (
+	"context"
+	"io"
+
+	"clean-arch-gin/internal/domain/order/entities"
+)
+
+// InvoiceUseCase defines the business logic operations for invoices
+// An order is invoiced once, when it is confirmed; orders confirmed before invoicing existed
+// are invoiced the first time their invoice is asked for
+type InvoiceUseCase interface {
+	// IssueInvoice invoices the order unless it already has an invoice, which is returned then
+	IssueInvoice(ctx context.Context, orderID uint) (*entities.Invoice, error)
+	// RenderInvoicePDF writes the order's invoice as a PDF to w, issuing it if needed
+	RenderInvoicePDF(ctx context.Context, orderID uint, w io.Writer) (*entities.Invoice, error)
+}
@@ -0,0 +1,35 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=order_usecase.go -destination=../../../mocks/order_usecase_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/domain/order/entities"
+	"clean-arch-gin/internal/domain/shared/pricing"
+)
+
+// OrderUseCase defines the business logic operations for orders
+// Status transitions publish an OrderStatusChangedEvent. Confirming reserves the ordered
+// stock, cancelling releases it and the first shipment takes it out of the stock on hand
+type OrderUseCase interface {
+	// CreateOrder places a pending order for items shipped to destination, priced with its
+	// tax and shipping, and returns it with its price breakdown
+	CreateOrder(ctx context.Context, userID uint, destination pricing.Destination, items []*entities.OrderItem) (*entities.Order, *entities.PriceBreakdown, error)
+	GetOrder(ctx context.Context, id uint) (*entities.Order, error)
+	// GetOrderByPublicID finds an order by the identifier it is exposed by in routes
+	GetOrderByPublicID(ctx context.Context, publicID string) (*entities.Order, error)
+	ConfirmOrder(ctx context.Context, id uint) (*entities.Order, error)
+	CancelOrder(ctx context.Context, id uint) (*entities.Order, error)
+	// ShipOrder records a shipment of quantities, keyed by order item ID, and moves the order
+	// to partially shipped, or to shipped once its shipments cover every unit
+	ShipOrder(ctx context.Context, id uint, trackingNumber, carrier string, quantities map[uint]int) (*entities.Shipment, *entities.Order, error)
+	// ListShipments returns the order's shipments, oldest first
+	ListShipments(ctx context.Context, id uint) ([]*entities.Shipment, error)
+	// CancelStaleOrders cancels up to limit orders still pending since before and returns how many it cancelled
+	CancelStaleOrders(ctx context.Context, before time.Time, limit int) (int, error)
+	// CancelUnpaidOrders cancels up to limit confirmed orders whose stock reservation expired
+	// at now, releasing it, and returns how many it cancelled
+	CancelUnpaidOrders(ctx context.Context, now time.Time, limit int) (int, error)
+}
@@ -0,0 +1,23 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=pricing_usecase.go -destination=../../../mocks/pricing_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/order/entities"
+	"clean-arch-gin/internal/domain/shared/money"
+	"clean-arch-gin/internal/domain/shared/pricing"
+)
+
+// PricingUseCase defines the pricing of items at checkout and order creation, in the base
+// currency of the tenant, and the exchange rates amounts are displayed in other currencies at
+type PricingUseCase interface {
+	// PriceItems prices items shipped to destination with the configured tax calculator and
+	// shipping strategy; errors wrap pricing.ErrUnsupportedDestination when either cannot
+	// price the destination
+	PriceItems(ctx context.Context, destination pricing.Destination, items []*entities.OrderItem) (*entities.PriceBreakdown, error)
+	// ExchangeRate is how many units of to one unit of from buys; errors wrap
+	// money.ErrUnsupportedCurrency when either currency has no rate
+	ExchangeRate(ctx context.Context, from, to money.Currency) (float64, error)
+}
@@ -0,0 +1,28 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=return_usecase.go -destination=../../../mocks/return_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/order/entities"
+)
+
+// ReturnUseCase defines the business logic operations for returns (RMAs) of delivered orders
+// A return is requested by the customer, approved or rejected by the shop, received back
+// into stock and then refunded through the payment gateway. Status transitions publish a
+// ReturnStatusChangedEvent
+type ReturnUseCase interface {
+	// RequestReturn opens a return of quantities, keyed by order item ID, of a delivered order
+	RequestReturn(ctx context.Context, orderID uint, reason string, quantities map[uint]int) (*entities.Return, error)
+	// GetReturn returns entities.ErrReturnNotFound unless the return belongs to the order
+	GetReturn(ctx context.Context, orderID, id uint) (*entities.Return, error)
+	ListReturns(ctx context.Context, orderID uint) ([]*entities.Return, error)
+	ApproveReturn(ctx context.Context, orderID, id uint, note string) (*entities.Return, error)
+	RejectReturn(ctx context.Context, orderID, id uint, note string) (*entities.Return, error)
+	// ReceiveReturn records the goods are back and puts them back in stock
+	ReceiveReturn(ctx context.Context, orderID, id uint) (*entities.Return, error)
+	// RefundReturn pays the return's amount back through the payment gateway; retrying
+	// after a failure never pays twice
+	RefundReturn(ctx context.Context, orderID, id uint) (*entities.Return, error)
+}
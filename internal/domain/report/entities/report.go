@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/reports"
+)
+
+// Format identifies the file type a report is rendered as
+type Format string
+
+const (
+	FormatCSV Format = "csv"
+	FormatPDF Format = "pdf"
+)
+
+// ReportURLTTL is how long the download URL of a report works
+const ReportURLTTL = 24 * time.Hour
+
+var (
+	// ErrUnknownReportType is returned for a type no module generates
+	ErrUnknownReportType = sharedEntities.DomainError{Message: "unknown report type"}
+	// ErrUnsupportedFormat is returned for anything other than CSV and PDF
+	ErrUnsupportedFormat = sharedEntities.DomainError{Message: "format must be csv or pdf"}
+)
+
+// Valid reports whether the format is supported
+func (f Format) Valid() bool {
+	return f == FormatCSV || f == FormatPDF
+}
+
+// ContentType is the media type of files of the format
+func (f Format) ContentType() string {
+	if f == FormatPDF {
+		return "application/pdf"
+	}
+	return "text/csv"
+}
+
+// Request is a report an admin asked for
+type Request struct {
+	Type   string
+	Params reports.Params
+	Format Format
+	// RequesterID is notified once the report is ready
+	RequesterID uint
+}
+
+// Report is a generated report stored for download
+type Report struct {
+	Type   string
+	Format Format
+	// Rows is the number of rows of the report's table
+	Rows        int
+	DownloadURL string
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,57 @@
+package events
+
+import (
+	"strconv"
+	"time"
+
+	reportEntities "clean-arch-gin/internal/domain/report/entities"
+)
+
+// ReportReadyEventName is the name of ReportReadyEvent
+const ReportReadyEventName = "report.ready"
+
+// ReportReadyEvent is published once a requested report is stored for download
+type ReportReadyEvent struct {
+	// JobID is the task the report was generated by
+	JobID       uint
+	RequesterID uint
+	Report      reportEntities.Report
+	occurredOn  time.Time
+}
+
+// NewReportReadyEvent creates the event for a report just stored by the job jobID
+func NewReportReadyEvent(jobID, requesterID uint, report reportEntities.Report) ReportReadyEvent {
+	return ReportReadyEvent{
+		JobID:       jobID,
+		RequesterID: requesterID,
+		Report:      report,
+		occurredOn:  time.Now(),
+	}
+}
+
+// EventName returns the event name
+func (e ReportReadyEvent) EventName() string {
+	return ReportReadyEventName
+}
+
+// OccurredOn returns when the report was stored
+func (e ReportReadyEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+// EventData returns the event payload; the download URL is left out, since it works without
+// credentials and events leave the system through webhooks
+func (e ReportReadyEvent) EventData() interface{} {
+	return map[string]interface{}{
+		"job_id":       e.JobID,
+		"requester_id": e.RequesterID,
+		"type":         e.Report.Type,
+		"format":       e.Report.Format,
+		"rows":         e.Report.Rows,
+	}
+}
+
+// EventSubject returns the job the report was generated by
+func (e ReportReadyEvent) EventSubject() string {
+	return "jobs/" + strconv.FormatUint(uint64(e.JobID), 10)
+}
@@ -0,0 +1,59 @@
+// Package authz defines the port through which middleware and use cases check permissions
+package authz
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=authorizer.go -destination=../../../mocks/authorizer_mock.go -package=mocks
+
+import (
+	"strconv"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// ErrForbidden is returned when the policy does not allow an action
+var ErrForbidden = sharedEntities.DomainError{Message: "insufficient permissions"}
+
+// Actor is who performs an action: an authenticated user and the roles they act with
+type Actor struct {
+	// ID is the user's ID, 0 for none
+	ID    uint
+	Roles []string
+}
+
+// UserSubject names the policy subject of a single user, e.g. user:42
+func UserSubject(id uint) string {
+	return "user:" + strconv.FormatUint(uint64(id), 10)
+}
+
+// Subjects are the policy subjects the actor is checked as: the user and each role
+func (a Actor) Subjects() []string {
+	subjects := make([]string, 0, len(a.Roles)+1)
+	if a.ID != 0 {
+		subjects = append(subjects, UserSubject(a.ID))
+	}
+	return append(subjects, a.Roles...)
+}
+
+// Authorizer decides whether actors may perform actions on objects; implemented by the
+// infrastructure layer
+// Objects are slash-separated resource names such as users or users/42, actions verbs
+// such as read or delete
+type Authorizer interface {
+	// Enforce reports whether any of the actor's subjects is allowed action on object
+	Enforce(actor Actor, object, action string) (bool, error)
+}
+
+// Authorize returns ErrForbidden unless actor may perform action on object
+// A nil authorizer allows nothing
+func Authorize(authorizer Authorizer, actor Actor, object, action string) error {
+	if authorizer == nil {
+		return ErrForbidden
+	}
+	allowed, err := authorizer.Enforce(actor, object, action)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+// Package captcha defines the port through which the CAPTCHA challenges guarding
+// abuse-prone public endpoints, such as registration, are verified
+package captcha
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=captcha.go -destination=../../../mocks/captcha_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrFailed is wrapped by the errors of Verify for responses that must be rejected, as
+// opposed to failures to reach the provider
+var ErrFailed = errors.New("captcha verification failed")
+
+// Verifier checks the response token a client got by solving a challenge; implemented by
+// the infrastructure layer for each provider
+type Verifier interface {
+	// Verify checks token, solved by the client at remoteIP; missing, wrong, reused and
+	// expired tokens are rejected with an error wrapping ErrFailed
+	Verify(ctx context.Context, token, remoteIP string) error
+}
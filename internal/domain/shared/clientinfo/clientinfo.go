@@ -0,0 +1,25 @@
+// Package clientinfo carries the address and user agent of the client a request came from
+// through its context, for use cases recording who did something from where
+package clientinfo
+
+import "context"
+
+// Info identifies the client of a request
+type Info struct {
+	IPAddress string
+	UserAgent string
+}
+
+// contextKey keeps the info set by NewContext private to this package
+type contextKey struct{}
+
+// NewContext returns a copy of ctx made on behalf of the client described by info
+func NewContext(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// FromContext returns the client ctx was made on behalf of; zero when it is not known
+func FromContext(ctx context.Context) Info {
+	info, _ := ctx.Value(contextKey{}).(Info)
+	return info
+}
@@ -0,0 +1,30 @@
+// Package documents defines the port through which printable documents, such as invoices,
+// are rendered
+package documents
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=documents.go -destination=../../../mocks/documents_mock.go -package=mocks
+
+import "io"
+
+// Column is a column of a document's table
+type Column struct {
+	Title string
+	// Width is the column's share of the line, in characters
+	Width int
+	// AlignRight aligns the column's cells right, e.g. for amounts
+	AlignRight bool
+}
+
+// Document is a printable document: a title, lines of text above and below a table
+type Document struct {
+	Title   string
+	Header  []string
+	Columns []Column
+	Rows    [][]string
+	Footer  []string
+}
+
+// PDFGenerator renders documents as PDF; implemented by the infrastructure layer
+type PDFGenerator interface {
+	GeneratePDF(w io.Writer, doc Document) error
+}
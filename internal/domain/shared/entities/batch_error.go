@@ -0,0 +1,34 @@
+package entities
+
+import "strconv"
+
+// RecordError reports why one record of a batch write was not written
+type RecordError struct {
+	// Index is the position of the record in the slice given to the batch method
+	Index int
+	Err   error
+}
+
+// BatchError is returned by batch writes when some records were not written; the others were
+type BatchError struct {
+	// Total is the number of records in the batch write
+	Total   int
+	Records []RecordError
+}
+
+func (e *BatchError) Error() string {
+	msg := strconv.Itoa(len(e.Records)) + " of " + strconv.Itoa(e.Total) + " records failed"
+	if len(e.Records) > 0 {
+		msg += ", first at " + strconv.Itoa(e.Records[0].Index) + ": " + e.Records[0].Err.Error()
+	}
+	return msg
+}
+
+// Failed returns the errors of the records that were not written by index
+func (e *BatchError) Failed() map[int]error {
+	failed := make(map[int]error, len(e.Records))
+	for _, record := range e.Records {
+		failed[record.Index] = record.Err
+	}
+	return failed
+}
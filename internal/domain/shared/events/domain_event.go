@@ -0,0 +1,40 @@
+package events
+
+import "time"
+
+// DomainEvent is something that happened in the domain that other parts of the system may react to
+type DomainEvent interface {
+	EventName() string
+	OccurredOn() time.Time
+	EventData() interface{}
+}
+
+// SubjectProvider is implemented by events about one specific resource
+// The subject, e.g. "orders/42", lets consumers filter without decoding the payload
+type SubjectProvider interface {
+	EventSubject() string
+}
+
+// EventRecorder is implemented by aggregates collecting the events they raise until the use
+// case has persisted them and publishes them
+type EventRecorder interface {
+	RecordEvent(event DomainEvent)
+	PullEvents() []DomainEvent
+}
+
+// Recorder implements EventRecorder; embed it in the aggregate root
+type Recorder struct {
+	recorded []DomainEvent
+}
+
+// RecordEvent adds event to the events to publish
+func (r *Recorder) RecordEvent(event DomainEvent) {
+	r.recorded = append(r.recorded, event)
+}
+
+// PullEvents returns the recorded events and forgets them, so each is published once
+func (r *Recorder) PullEvents() []DomainEvent {
+	recorded := r.recorded
+	r.recorded = nil
+	return recorded
+}
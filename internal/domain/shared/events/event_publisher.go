@@ -0,0 +1,18 @@
+package events
+
+import "context"
+
+// EventPublisher publishes domain events; implemented by the infrastructure layer
+// ctx is the context of the change, so handlers act for the same tenant
+type EventPublisher interface {
+	Publish(ctx context.Context, event DomainEvent) error
+}
+
+// EventHandler reacts to a published domain event in the context it was published with
+type EventHandler func(ctx context.Context, event DomainEvent)
+
+// EventSubscriber registers handlers for events by name
+// The returned function removes the subscription
+type EventSubscriber interface {
+	Subscribe(eventName string, handler EventHandler) (unsubscribe func())
+}
@@ -0,0 +1,17 @@
+package events
+
+import "context"
+
+// Projection is a read model built from domain events, which can be rebuilt by replaying the
+// event history into it, e.g. after it was added or its handler was fixed
+type Projection struct {
+	// Name identifies the projection, prefixed with its module, e.g. users.activity
+	Name string
+	// Events names the events the projection is built from
+	Events []string
+	// Reset, when set, clears the read model before a rebuild replays every event into it
+	Reset func(ctx context.Context) error
+	// Handle applies an event to the read model; replayed events carry their data as
+	// recorded, so it decodes what it needs rather than asserting concrete event types
+	Handle EventHandler
+}
@@ -0,0 +1,20 @@
+package events
+
+import "context"
+
+// replayKey marks the context of events replayed from the event history
+type replayKey struct{}
+
+// WithReplay returns a copy of ctx publishing events replayed from the event history rather
+// than raised by a change
+func WithReplay(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replayKey{}, true)
+}
+
+// IsReplay reports whether ctx replays recorded events; handlers with effects outside the
+// read models, such as recording, metering or delivering events, skip them so a replay is
+// not taken for new activity
+func IsReplay(ctx context.Context) bool {
+	replay, _ := ctx.Value(replayKey{}).(bool)
+	return replay
+}
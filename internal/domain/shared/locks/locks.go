@@ -0,0 +1,112 @@
+// Package locks defines the port through which work that must run once at a time across
+// replicas, such as numbering invoices, scheduled jobs and migrations, is serialised
+package locks
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=locks.go -destination=../../../mocks/locks_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrNotObtained is returned by TryObtain when another holder has the lock
+	ErrNotObtained = errors.New("locks: lock held elsewhere")
+	// ErrLost is returned when a held lock expired or its backend dropped it, so another
+	// holder may have it
+	ErrLost = errors.New("locks: lock lost")
+)
+
+// LockManager hands out named locks shared by every replica; implemented by the
+// infrastructure layer
+type LockManager interface {
+	// TryObtain takes the lock on key for ttl, or returns ErrNotObtained at once when another
+	// holder has it
+	TryObtain(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+	// Obtain waits until it takes the lock on key for ttl, or ctx is done
+	Obtain(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+}
+
+// Lock is a held lock; it lapses after its ttl unless extended, so a crashed holder does not
+// keep it
+type Lock interface {
+	// Extend restarts the ttl of the lock, or returns ErrLost when it is no longer held
+	Extend(ctx context.Context) error
+	// Release gives the lock up; releasing a lapsed lock is not an error
+	Release(ctx context.Context) error
+}
+
+// releaseLimit bounds releasing a lock once the work it guarded is done
+const releaseLimit = 5 * time.Second
+
+// WithLock runs fn holding the lock on key, waiting for it as long as ctx allows
+// The lock is extended every third of ttl while fn runs, and the context of fn is cancelled
+// when it is lost. A nil manager runs fn unguarded, for single-replica deployments
+func WithLock(ctx context.Context, manager LockManager, key string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	if manager == nil {
+		return fn(ctx)
+	}
+	lock, err := manager.Obtain(ctx, key, ttl)
+	if err != nil {
+		return fmt.Errorf("failed to lock %s: %w", key, err)
+	}
+	return hold(ctx, lock, key, ttl, fn)
+}
+
+// TryWithLock is WithLock returning ErrNotObtained without running fn when another holder
+// has the lock
+func TryWithLock(ctx context.Context, manager LockManager, key string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	if manager == nil {
+		return fn(ctx)
+	}
+	lock, err := manager.TryObtain(ctx, key, ttl)
+	if err != nil {
+		return err
+	}
+	return hold(ctx, lock, key, ttl, fn)
+}
+
+// hold runs fn while extending lock, then releases it
+func hold(ctx context.Context, lock Lock, key string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	fnCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	stopped := make(chan struct{})
+	extended := make(chan struct{})
+	go func() {
+		defer close(extended)
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopped:
+				return
+			case <-ticker.C:
+				if err := lock.Extend(fnCtx); err != nil {
+					if !errors.Is(err, ErrLost) {
+						err = fmt.Errorf("%w: %v", ErrLost, err)
+					}
+					cancel(fmt.Errorf("%s: %w", key, err))
+					return
+				}
+			}
+		}
+	}()
+
+	err := fn(fnCtx)
+	close(stopped)
+	<-extended
+	// fn cut short by losing the lock failed because of it
+	if cause := context.Cause(fnCtx); errors.Is(cause, ErrLost) && (err == nil || errors.Is(err, context.Canceled)) {
+		err = cause
+	}
+
+	releaseCtx, cancelRelease := context.WithTimeout(context.WithoutCancel(ctx), releaseLimit)
+	defer cancelRelease()
+	if releaseErr := lock.Release(releaseCtx); err == nil && releaseErr != nil {
+		err = fmt.Errorf("failed to unlock %s: %w", key, releaseErr)
+	}
+	return err
+}
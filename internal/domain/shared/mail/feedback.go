@@ -0,0 +1,38 @@
+package mail
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=feedback.go -destination=../../../mocks/mail_feedback_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrInvalidWebhook is returned for webhook requests that are not authentic or cannot be read
+var ErrInvalidWebhook = errors.New("invalid mail webhook")
+
+// FeedbackKind is why mail to an address is no longer sent
+type FeedbackKind string
+
+// Kinds of feedback
+const (
+	// FeedbackBounce is a permanent bounce: the address does not exist or refuses mail
+	FeedbackBounce FeedbackKind = "bounce"
+	// FeedbackComplaint is the recipient reporting mail as spam
+	FeedbackComplaint FeedbackKind = "complaint"
+)
+
+// Feedback reports that Email is undeliverable
+type Feedback struct {
+	Email string
+	Kind  FeedbackKind
+	// Detail is the provider's reason, e.g. the SMTP response of a bounce; may be empty
+	Detail string
+}
+
+// FeedbackSource reads the bounces and complaints a mail provider posts to its webhook
+type FeedbackSource interface {
+	// Parse authenticates a webhook request by its header and body and returns the feedback
+	// it carries, possibly none; ErrInvalidWebhook when it is not authentic
+	Parse(ctx context.Context, header http.Header, body []byte) ([]Feedback, error)
+}
@@ -0,0 +1,45 @@
+// Package mail defines the ports through which transactional mail is rendered and sent
+package mail
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=mail.go -destination=../../../mocks/mail_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrUnknownTemplate is returned by Render for templates that do not exist
+var ErrUnknownTemplate = errors.New("unknown mail template")
+
+// Message is a rendered email; Text is always sent, HTML as its alternative when set
+type Message struct {
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer sends messages from the configured sender; implemented by the infrastructure layer
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Templates of the account flows
+const (
+	TemplatePasswordReset     = "password_reset"
+	TemplateEmailVerification = "email_verification"
+)
+
+// LinkData is the data of the templates mailing a link that works until ExpiresAt
+type LinkData struct {
+	Name      string
+	URL       string
+	ExpiresAt time.Time
+}
+
+// Renderer renders a template in a locale, falling back on less specific locales and then
+// the default one, with its data into a message without recipients
+type Renderer interface {
+	Render(template, locale string, data interface{}) (*Message, error)
+}
@@ -0,0 +1,37 @@
+// Package metering defines the port through which billable operations are counted, and
+// carries the API key a request is billed to through its context
+package metering
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=metering.go -destination=../../../mocks/meter_mock.go -package=mocks
+
+import "context"
+
+// Billable operations counted per tenant and API key
+const (
+	// MetricRequests counts the authenticated API requests served
+	MetricRequests = "api.requests"
+	// MetricOrdersCreated counts the orders placed
+	MetricOrdersCreated = "orders.created"
+	// MetricStorageBytes counts the bytes written to file storage
+	MetricStorageBytes = "storage.bytes"
+)
+
+// Meter counts billable operations for the tenant and API key of ctx; implemented by the
+// metering module. Record must not block nor fail the operation it counts
+type Meter interface {
+	Record(ctx context.Context, metric string, quantity int64)
+}
+
+// contextKey keeps the key set by WithAPIKey private to this package
+type contextKey struct{}
+
+// WithAPIKey returns a copy of ctx billed to the API key keyID
+func WithAPIKey(ctx context.Context, keyID uint) context.Context {
+	return context.WithValue(ctx, contextKey{}, keyID)
+}
+
+// APIKeyFromContext returns the API key ctx is billed to, if any
+func APIKeyFromContext(ctx context.Context) (uint, bool) {
+	keyID, ok := ctx.Value(contextKey{}).(uint)
+	return keyID, ok && keyID != 0
+}
@@ -0,0 +1,88 @@
+// Package money defines amounts in a currency and the port through which they are
+// converted between currencies
+package money
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=money.go -destination=../../../mocks/money_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// DefaultCurrency is the base currency of tenants that set none, and of the amounts stored
+// before currencies were
+const DefaultCurrency Currency = "USD"
+
+// ErrInvalidCurrency is returned for codes that are not three letters
+var ErrInvalidCurrency = sharedEntities.DomainError{Message: "currency must be a three-letter ISO 4217 code"}
+
+// ErrUnsupportedCurrency is wrapped by the errors of exchange rate providers without a rate
+// for a currency, as opposed to failures to reach them
+var ErrUnsupportedCurrency = errors.New("currency not supported")
+
+// Currency is an ISO 4217 currency code, e.g. "EUR"
+type Currency string
+
+// ParseCurrency validates and upper-cases a currency code
+func ParseCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 || strings.IndexFunc(code, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0 {
+		return "", ErrInvalidCurrency
+	}
+	return Currency(code), nil
+}
+
+// zeroDecimal lists the common currencies without minor units
+var zeroDecimal = map[Currency]bool{
+	"CLP": true, "ISK": true, "JPY": true, "KRW": true, "PYG": true, "UGX": true, "VND": true, "XAF": true, "XOF": true,
+}
+
+// Decimals is the number of digits of the currency's minor unit, e.g. 2 for cents
+func (c Currency) Decimals() int {
+	if zeroDecimal[c] {
+		return 0
+	}
+	return 2
+}
+
+// Money is an amount in a currency
+type Money struct {
+	Amount   float64
+	Currency Currency
+}
+
+// New creates an amount rounded to the currency's minor unit
+func New(amount float64, currency Currency) Money {
+	scale := math.Pow10(currency.Decimals())
+	return Money{Amount: math.Round(amount*scale) / scale, Currency: currency}
+}
+
+// ExchangeRateProvider quotes exchange rates; implemented by the infrastructure layer for
+// each rates source
+type ExchangeRateProvider interface {
+	// Rate is how many units of to one unit of from buys; errors wrap ErrUnsupportedCurrency
+	// when either currency has no rate
+	Rate(ctx context.Context, from, to Currency) (float64, error)
+}
+
+// Convert converts m to the currency to at the provider's rate
+func Convert(ctx context.Context, rates ExchangeRateProvider, m Money, to Currency) (Money, error) {
+	if m.Currency == to {
+		return m, nil
+	}
+	rate, err := rates.Rate(ctx, m.Currency, to)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(m.Amount*rate, to), nil
+}
+
+// BaseCurrencies tells the base currency of the tenant a context acts for, which its prices
+// are set and its orders are recorded in
+type BaseCurrencies interface {
+	BaseCurrency(ctx context.Context) (Currency, error)
+}
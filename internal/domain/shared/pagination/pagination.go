@@ -0,0 +1,67 @@
+// Package pagination holds the cursors keyset-paginated listings resume from
+// A cursor is the (created_at, id) of the last row of a page, so the next page is a range
+// scan on that index instead of an OFFSET that reads and discards every earlier row
+package pagination
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// ErrInvalidCursor is returned when decoding a token that was not made by Cursor.Encode
+var ErrInvalidCursor = sharedEntities.DomainError{Message: "invalid pagination cursor"}
+
+// Cursor is the position of a row in created_at, id order
+// The zero Cursor is before every row and starts a listing from the beginning
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+// After returns the cursor of the row created at createdAt with the given ID, where the
+// next page starts
+func After(createdAt time.Time, id uint) Cursor {
+	return Cursor{CreatedAt: createdAt, ID: id}
+}
+
+// IsZero reports whether c is the start of a listing
+func (c Cursor) IsZero() bool {
+	return c.ID == 0 && c.CreatedAt.IsZero()
+}
+
+// Encode returns c as an opaque token for API responses
+func (c Cursor) Encode() string {
+	if c.IsZero() {
+		return ""
+	}
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + "." + strconv.FormatUint(uint64(c.ID), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// Decode parses a token made by Encode; an empty token is the zero Cursor
+func Decode(token string) (Cursor, error) {
+	if token == "" {
+		return Cursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	nanos, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+	createdAt, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	parsedID, err := strconv.ParseUint(id, 10, 0)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{CreatedAt: time.Unix(0, createdAt).UTC(), ID: uint(parsedID)}, nil
+}
@@ -0,0 +1,98 @@
+// Package passwords hashes user passwords for storage and checks them at sign-in
+// Hashes are bcrypt; passwords stored in plain text before hashing was introduced are still
+// accepted once and reported for rehashing, so existing accounts move over as they sign in
+package passwords
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// MaxLength is the longest password bcrypt hashes in full, in bytes
+	MaxLength = 72
+	// MinCost and DefaultCost bound the bcrypt work factor; tests use MinCost to stay fast
+	MinCost     = bcrypt.MinCost
+	DefaultCost = bcrypt.DefaultCost
+	// maxCost keeps a misconfigured cost from making every sign-in take minutes
+	maxCost = 16
+)
+
+var (
+	// ErrTooLong is returned for passwords longer than MaxLength bytes
+	ErrTooLong = errors.New("password is longer than 72 bytes")
+	// ErrInvalidCost is returned for work factors outside MinCost to 16
+	ErrInvalidCost = errors.New("password hash cost must be between 4 and 16")
+)
+
+// cost is the bcrypt work factor of new hashes
+var cost atomic.Int64
+
+// init selects bcrypt's default work factor until SetCost is called
+func init() {
+	cost.Store(int64(DefaultCost))
+}
+
+// SetCost selects the work factor of the hashes made from now on, at startup
+// Hashes made with a lower cost are reported for rehashing when they are verified
+func SetCost(c int) error {
+	if c < MinCost || c > maxCost {
+		return ErrInvalidCost
+	}
+	cost.Store(int64(c))
+	return nil
+}
+
+// Hash returns the bcrypt hash of password
+func Hash(password string) (string, error) {
+	if len(password) > MaxLength {
+		return "", ErrTooLong
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), int(cost.Load()))
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify reports whether password matches the stored hash, and whether the hash should be
+// replaced by a new one: it is a plain-text password from before hashing or has a lower cost
+// than SetCost selected
+func Verify(hash, password string) (ok, rehash bool) {
+	if !IsHash(hash) {
+		ok = hash != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(password)) == 1
+		return ok, ok
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
+		return false, false
+	}
+	c, err := bcrypt.Cost([]byte(hash))
+	return true, err != nil || int64(c) < cost.Load()
+}
+
+// IsHash reports whether stored is a bcrypt hash rather than a plain-text password
+func IsHash(stored string) bool {
+	return len(stored) == 60 &&
+		(strings.HasPrefix(stored, "$2a$") || strings.HasPrefix(stored, "$2b$") || strings.HasPrefix(stored, "$2y$"))
+}
+
+// dummy is a hash no password is checked against for real
+var dummy struct {
+	once sync.Once
+	hash string
+}
+
+// VerifyNone spends the time of a Verify when there is no stored hash, e.g. for an unknown
+// email, so the response time does not reveal which accounts exist
+func VerifyNone(password string) {
+	dummy.once.Do(func() {
+		hash, _ := bcrypt.GenerateFromPassword([]byte("no account"), int(cost.Load()))
+		dummy.hash = string(hash)
+	})
+	_ = bcrypt.CompareHashAndPassword([]byte(dummy.hash), []byte(password))
+}
@@ -0,0 +1,112 @@
+package passwords_test
+
+import (
+	"strings"
+	"testing"
+
+	"clean-arch-gin/internal/domain/shared/passwords"
+)
+
+// withCost selects a work factor for one test and restores the lowest one afterwards
+func withCost(t *testing.T, c int) {
+	t.Helper()
+	if err := passwords.SetCost(c); err != nil {
+		t.Fatalf("SetCost(%d) returned error: %v", c, err)
+	}
+	t.Cleanup(func() { _ = passwords.SetCost(passwords.MinCost) })
+}
+
+// mustHash hashes password and fails the test on error
+func mustHash(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := passwords.Hash(password)
+	if err != nil {
+		t.Fatalf("Hash returned error: %v", err)
+	}
+	return hash
+}
+
+func TestHash(t *testing.T) {
+	withCost(t, passwords.MinCost)
+
+	hash := mustHash(t, "password123")
+	if !passwords.IsHash(hash) {
+		t.Errorf("Hash() = %q, want a bcrypt hash", hash)
+	}
+	if hash == "password123" || strings.Contains(hash, "password123") {
+		t.Errorf("Hash() = %q contains the password", hash)
+	}
+	if other := mustHash(t, "password123"); other == hash {
+		t.Error("Hash() returned the same hash twice, want a fresh salt")
+	}
+	if _, err := passwords.Hash(strings.Repeat("a", passwords.MaxLength)); err != nil {
+		t.Errorf("Hash(%d bytes) returned error: %v", passwords.MaxLength, err)
+	}
+	if _, err := passwords.Hash(strings.Repeat("a", passwords.MaxLength+1)); err != passwords.ErrTooLong {
+		t.Errorf("Hash(%d bytes) error = %v, want %v", passwords.MaxLength+1, err, passwords.ErrTooLong)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	withCost(t, passwords.MinCost)
+	hash := mustHash(t, "password123")
+
+	tests := []struct {
+		name       string
+		stored     string
+		password   string
+		wantOK     bool
+		wantRehash bool
+	}{
+		{"matching hash", hash, "password123", true, false},
+		{"wrong password", hash, "password124", false, false},
+		{"empty password", hash, "", false, false},
+		{"plain-text row", "password123", "password123", true, true},
+		{"plain-text row, wrong password", "password123", "password124", false, false},
+		{"empty row", "", "", false, false},
+		{"hash typed as the password", hash, hash, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, rehash := passwords.Verify(tt.stored, tt.password)
+			if ok != tt.wantOK || rehash != tt.wantRehash {
+				t.Errorf("Verify() = (%v, %v), want (%v, %v)", ok, rehash, tt.wantOK, tt.wantRehash)
+			}
+		})
+	}
+}
+
+func TestVerifyRehashesLowerCost(t *testing.T) {
+	withCost(t, passwords.MinCost)
+	hash := mustHash(t, "password123")
+
+	withCost(t, passwords.MinCost+1)
+	if ok, rehash := passwords.Verify(hash, "password123"); !ok || !rehash {
+		t.Errorf("Verify(lower cost hash) = (%v, %v), want (true, true)", ok, rehash)
+	}
+	if ok, rehash := passwords.Verify(mustHash(t, "password123"), "password123"); !ok || rehash {
+		t.Errorf("Verify(current cost hash) = (%v, %v), want (true, false)", ok, rehash)
+	}
+}
+
+func TestSetCost(t *testing.T) {
+	t.Cleanup(func() { _ = passwords.SetCost(passwords.MinCost) })
+
+	tests := []struct {
+		cost    int
+		wantErr error
+	}{
+		{passwords.MinCost - 1, passwords.ErrInvalidCost},
+		{passwords.MinCost, nil},
+		{passwords.DefaultCost, nil},
+		{16, nil},
+		{17, passwords.ErrInvalidCost},
+	}
+
+	for _, tt := range tests {
+		if err := passwords.SetCost(tt.cost); err != tt.wantErr {
+			t.Errorf("SetCost(%d) error = %v, want %v", tt.cost, err, tt.wantErr)
+		}
+	}
+}
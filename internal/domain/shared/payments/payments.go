@@ -0,0 +1,32 @@
+// Package payments defines the port through which money is paid back to customers, such
+// as the refunds of returned orders
+package payments
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=payments.go -destination=../../../mocks/payments_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"clean-arch-gin/internal/domain/shared/money"
+)
+
+// ErrDeclined is wrapped by the errors of Refund when the gateway refused the refund, as
+// opposed to failures to reach it
+var ErrDeclined = errors.New("refund declined by the payment gateway")
+
+// Refund is money to pay back for an order
+type Refund struct {
+	OrderID  uint
+	Amount   float64
+	Currency money.Currency
+	// IdempotencyKey identifies the refund, so retrying it never pays twice
+	IdempotencyKey string
+	Reason         string
+}
+
+// Gateway issues refunds; implemented by the infrastructure layer for each provider
+type Gateway interface {
+	// Refund pays refund back and returns the gateway's reference for it
+	Refund(ctx context.Context, refund Refund) (reference string, err error)
+}
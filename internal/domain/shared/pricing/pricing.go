@@ -0,0 +1,77 @@
+// Package pricing defines the ports through which prices get their tax and shipping: tax
+// calculators and shipping rate strategies
+package pricing
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=pricing.go -destination=../../../mocks/pricing_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// ErrUnsupportedDestination is wrapped by the errors of calculators and strategies that
+// cannot price a destination, as opposed to failures to reach an external service
+var ErrUnsupportedDestination = errors.New("destination not supported")
+
+// Destination is where goods ship to; tax and shipping rates depend on it
+type Destination struct {
+	// Country is the ISO 3166-1 alpha-2 code, e.g. "US"
+	Country string
+	// Region is the ISO 3166-2 subdivision code without the country, e.g. "CA"; optional
+	Region     string
+	PostalCode string
+}
+
+// Line is a quantity of a product at a unit price
+type Line struct {
+	ProductID uint
+	Quantity  int
+	UnitPrice float64
+}
+
+// Amount is the line's price before tax
+func (l Line) Amount() float64 {
+	return Round(l.UnitPrice * float64(l.Quantity))
+}
+
+// Subtotal sums the lines' amounts
+func Subtotal(lines []Line) float64 {
+	subtotal := 0.0
+	for _, line := range lines {
+		subtotal += line.Amount()
+	}
+	return Round(subtotal)
+}
+
+// Round rounds an amount to cents
+func Round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// Tax is the tax due on lines
+type Tax struct {
+	// Label names the tax on price breakdowns, e.g. "VAT 19%"; empty when none is due
+	Label string
+	// Amounts holds the tax of each line, in the order of the lines
+	Amounts []float64
+}
+
+// TaxCalculator computes the tax due on lines shipped to a destination; implemented by the
+// infrastructure layer, e.g. with a flat rate or an external tax service
+type TaxCalculator interface {
+	CalculateTax(ctx context.Context, destination Destination, lines []Line) (Tax, error)
+}
+
+// ShippingRate is the price of shipping
+type ShippingRate struct {
+	// Method names the rate on price breakdowns, e.g. "Standard"
+	Method string
+	Amount float64
+}
+
+// ShippingStrategy prices the shipping of lines to a destination; implemented by the
+// infrastructure layer
+type ShippingStrategy interface {
+	ShippingRate(ctx context.Context, destination Destination, lines []Line) (ShippingRate, error)
+}
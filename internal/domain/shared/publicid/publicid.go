@@ -0,0 +1,167 @@
+// Package publicid generates the identifiers resources are exposed by in routes and DTOs
+// Sequential database IDs leak how many resources there are and can be guessed, so they
+// stay internal; public IDs are random and time-ordered, which keeps their index compact
+package publicid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// Format selects how new public IDs are generated
+type Format string
+
+const (
+	// FormatULID generates 26-character ULIDs, e.g. 01HZX3M8Q2W0F5N7K9C4D6B1TR
+	FormatULID Format = "ulid"
+	// FormatUUID generates version 7 UUIDs, e.g. 018f4c2e-5b7a-7c3d-9e1f-2a3b4c5d6e7f
+	FormatUUID Format = "uuid"
+)
+
+// MaxLength is the length of the longest public ID, a UUID
+const MaxLength = 36
+
+// ErrInvalidFormat is returned for formats other than ulid and uuid
+var ErrInvalidFormat = sharedEntities.DomainError{Message: "public ID format must be ulid or uuid"}
+
+// crockford is the ULID alphabet, Crockford's base32
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var format atomic.Value
+
+func init() {
+	format.Store(FormatULID)
+}
+
+// SetFormat selects the format of the IDs New generates from now on, at startup
+// IDs generated before keep their format: Valid accepts both
+func SetFormat(f Format) error {
+	switch f {
+	case FormatULID, FormatUUID:
+		format.Store(f)
+		return nil
+	default:
+		return ErrInvalidFormat
+	}
+}
+
+// New generates a public ID in the selected format, ULID by default
+func New() string {
+	if format.Load().(Format) == FormatUUID {
+		return NewUUID()
+	}
+	return NewULID()
+}
+
+// NewULID generates a ULID: 48 bits of Unix milliseconds then 80 random bits
+func NewULID() string {
+	var id [16]byte
+	putMillis(id[:6])
+	random(id[6:])
+
+	// 128 bits are written as 26 base32 characters, the first holding the top 3 bits
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// NewUUID generates a version 7 UUID (RFC 9562): 48 bits of Unix milliseconds then random bits
+func NewUUID() string {
+	var id [16]byte
+	putMillis(id[:6])
+	random(id[6:])
+	id[6] = id[6]&0x0f | 0x70
+	id[8] = id[8]&0x3f | 0x80
+
+	var out [36]byte
+	hex.Encode(out[0:8], id[0:4])
+	out[8] = '-'
+	hex.Encode(out[9:13], id[4:6])
+	out[13] = '-'
+	hex.Encode(out[14:18], id[6:8])
+	out[18] = '-'
+	hex.Encode(out[19:23], id[8:10])
+	out[23] = '-'
+	hex.Encode(out[24:], id[10:])
+	return string(out[:])
+}
+
+// Valid reports whether s is shaped like a ULID or a UUID, so malformed IDs are rejected
+// without a lookup
+func Valid(s string) bool {
+	switch len(s) {
+	case 26:
+		if s[0] > '7' {
+			return false
+		}
+		for i := 0; i < len(s); i++ {
+			if strings.IndexByte(crockford, upper(s[i])) < 0 {
+				return false
+			}
+		}
+		return true
+	case 36:
+		for i := 0; i < len(s); i++ {
+			switch i {
+			case 8, 13, 18, 23:
+				if s[i] != '-' {
+					return false
+				}
+			default:
+				if !isHex(s[i]) {
+					return false
+				}
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// Normalize returns the stored form of a valid public ID: ULIDs upper-case, UUIDs lower-case
+func Normalize(s string) string {
+	if len(s) == 26 {
+		return strings.ToUpper(s)
+	}
+	return strings.ToLower(s)
+}
+
+// putMillis writes the current Unix time in milliseconds as 48 big-endian bits
+func putMillis(b []byte) {
+	ms := uint64(time.Now().UnixMilli())
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+}
+
+// random fills b from the system's secure random source
+func random(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic("publicid: crypto/rand failed: " + err.Error())
+	}
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,26 @@
+// Package push defines the port through which push notifications are sent to devices
+package push
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=push.go -destination=../../../mocks/push_mock.go -package=mocks -mock_names=Sender=MockPushSender
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidToken is returned by Send for tokens the provider no longer accepts, e.g. of an
+// app that was uninstalled; they should be forgotten
+var ErrInvalidToken = errors.New("push token is no longer valid")
+
+// Message is a push notification; Data reaches the app with it
+type Message struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// Sender delivers push notifications to the device a provider issued token to;
+// implemented by the infrastructure layer
+type Sender interface {
+	Send(ctx context.Context, token string, msg Message) error
+}
@@ -0,0 +1,30 @@
+// Package reports defines the port through which modules contribute the reports admins
+// generate in the background
+package reports
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=reports.go -destination=../../../mocks/reports_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/shared/documents"
+)
+
+// Params are the parameters a report is requested with, by name, e.g. from=2024-01-01
+type Params map[string]string
+
+// Progress is told the percentage of a report generated so far
+type Progress func(percent int)
+
+// Generator builds one type of report as a document, which is rendered as CSV or PDF
+type Generator interface {
+	// Type names the report, prefixed with its module, e.g. users.signups
+	Type() string
+	Description() string
+	// Params describes the parameters the report takes, by name
+	Params() map[string]string
+	// Validate rejects the params the report cannot be generated with, as DomainErrors
+	Validate(params Params) error
+	// Generate builds the report for the tenant of ctx from params that passed Validate
+	Generate(ctx context.Context, params Params, progress Progress) (documents.Document, error)
+}
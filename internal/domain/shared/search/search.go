@@ -0,0 +1,92 @@
+// Package search defines the ports of the search engine records are indexed in as domain
+// events change them, and searched through by query handlers
+package search
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=search.go -destination=../../../mocks/search_mock.go -package=mocks -mock_names=Engine=MockSearchEngine
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnavailable is wrapped by the errors of an engine that cannot be reached or refuses
+// calls; searches fall back to the database on it
+var ErrUnavailable = errors.New("search engine unavailable")
+
+// FieldType is how a field of the documents of an index is stored and matched
+type FieldType string
+
+// Field types of mappings
+const (
+	// FieldText is analyzed and matched by the terms of queries, by prefix
+	FieldText FieldType = "text"
+	// FieldKeyword is stored as is and matched by filters
+	FieldKeyword FieldType = "keyword"
+	FieldLong    FieldType = "long"
+	FieldDate    FieldType = "date"
+)
+
+// Mapping types the fields of the documents of an index, by name
+type Mapping map[string]FieldType
+
+// Document is a record as indexed: its ID in the database, the tenant owning it and the
+// fields of its index's mapping
+type Document struct {
+	ID       string
+	TenantID uint
+	Fields   map[string]interface{}
+}
+
+// DocumentSource reads the documents of an index from the database; implemented by the
+// repositories of the module owning it
+type DocumentSource interface {
+	// Document returns the document of the record id, nil when there is none, e.g. once it
+	// is deleted
+	Document(ctx context.Context, id uint) (*Document, error)
+	// Documents yields every document, of every tenant when ctx acts for none, stopping at
+	// the first error yield returns
+	Documents(ctx context.Context, yield func(Document) error) error
+}
+
+// Index describes an index kept by a module: its name, e.g. "users", the mapping of its
+// documents and the source they are read from, rebuilding it
+type Index struct {
+	Name    string
+	Mapping Mapping
+	Source  DocumentSource
+}
+
+// Query searches an index for the documents matching every term by prefix in one of the
+// text fields and every filter exactly, in the tenant of ctx
+type Query struct {
+	Index   string
+	Terms   []string
+	Filters map[string]interface{}
+	Limit   int
+	Offset  int
+}
+
+// Hit is a matching document, most relevant first
+type Hit struct {
+	ID    string
+	Score float64
+}
+
+// Indexer keeps the documents of indexes up to date; implemented by the infrastructure
+// layer
+type Indexer interface {
+	Index(ctx context.Context, index string, doc Document) error
+	// Delete removes a document, succeeding when there is none
+	Delete(ctx context.Context, index, id string) error
+}
+
+// SearchRepository runs queries, returning a page of hits and how many documents match in all
+type SearchRepository interface {
+	Search(ctx context.Context, query Query) ([]Hit, int64, error)
+}
+
+// Engine is a search engine both indexing and searching documents
+type Engine interface {
+	Indexer
+	SearchRepository
+}
@@ -0,0 +1,36 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxTerms caps the words a search query matches; the others are ignored
+const MaxTerms = 8
+
+// Terms splits a search query into the distinct lower case words documents must match, up
+// to MaxTerms; everything but letters and digits separates words, so queries cannot carry
+// the operators of a query language
+func Terms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, word := range Words(query) {
+		word = strings.ToLower(word)
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, word)
+		if len(terms) == MaxTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// Words splits text into its words of letters and digits, as searches match them
+func Words(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,78 @@
+// Package sms defines the ports through which text messages are sent and the replies to
+// them received
+package sms
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=sms.go -destination=../../../mocks/sms_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Errors of senders and reply sources
+var (
+	// ErrOptedOut is returned by Send for numbers that replied STOP to the sender; the
+	// provider refuses to text them until they reply START
+	ErrOptedOut = errors.New("phone number opted out of text messages")
+	// ErrInvalidWebhook is returned for webhook requests that are not authentic or cannot
+	// be read
+	ErrInvalidWebhook = errors.New("invalid sms webhook")
+)
+
+// Sender texts phone numbers in E.164 format from the configured number; implemented by
+// the infrastructure layer
+type Sender interface {
+	Send(ctx context.Context, to, body string) error
+}
+
+// Keyword is what a reply asks of the sender
+type Keyword string
+
+// Keywords of replies
+const (
+	// KeywordNone is any other reply, which changes nothing
+	KeywordNone Keyword = ""
+	// KeywordOptOut stops the texts to the number
+	KeywordOptOut Keyword = "opt_out"
+	// KeywordOptIn resumes the texts to a number that opted out
+	KeywordOptIn Keyword = "opt_in"
+)
+
+// optOutWords and optInWords are the standard keywords carriers and providers honor
+var (
+	optOutWords = []string{"STOP", "STOPALL", "UNSUBSCRIBE", "CANCEL", "END", "QUIT"}
+	optInWords  = []string{"START", "YES", "UNSTOP"}
+)
+
+// ParseKeyword returns the keyword of a reply's body: a reply consisting of one of the
+// standard words, whatever its case and surrounding whitespace or punctuation
+func ParseKeyword(body string) Keyword {
+	word := strings.ToUpper(strings.Trim(body, " \t\r\n.!"))
+	for _, stop := range optOutWords {
+		if word == stop {
+			return KeywordOptOut
+		}
+	}
+	for _, start := range optInWords {
+		if word == start {
+			return KeywordOptIn
+		}
+	}
+	return KeywordNone
+}
+
+// Reply is a text a user sent back from From, in E.164 format
+type Reply struct {
+	From    string
+	Keyword Keyword
+}
+
+// ReplySource reads the replies an SMS provider posts to its webhook
+type ReplySource interface {
+	// Parse authenticates a webhook request to url, the public URL the provider posted to,
+	// by its header and body and returns the replies it carries, possibly none;
+	// ErrInvalidWebhook when it is not authentic
+	Parse(ctx context.Context, url string, header http.Header, body []byte) ([]Reply, error)
+}
@@ -0,0 +1,60 @@
+// Package softdelete carries which soft-deleted rows a query sees through its context
+// Repositories hide soft-deleted rows unless the context asks for them with WithDeleted or
+// OnlyDeleted; updates and deletes are not affected
+package softdelete
+
+import (
+	"context"
+	"time"
+)
+
+// Scope selects the rows a query sees by whether they are soft deleted
+type Scope int
+
+const (
+	// ExcludeDeleted sees only rows that are not deleted, the default
+	ExcludeDeleted Scope = iota
+	// IncludeDeleted sees every row, deleted or not
+	IncludeDeleted
+	// DeletedOnly sees only soft-deleted rows
+	DeletedOnly
+)
+
+// contextKey keeps the scope set by WithDeleted and OnlyDeleted private to this package
+type contextKey struct{}
+
+// WithDeleted returns a copy of ctx whose queries also see soft-deleted rows
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, IncludeDeleted)
+}
+
+// OnlyDeleted returns a copy of ctx whose queries see soft-deleted rows only
+func OnlyDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, DeletedOnly)
+}
+
+// FromContext returns the scope of ctx's queries, ExcludeDeleted when none was set
+func FromContext(ctx context.Context) Scope {
+	scope, _ := ctx.Value(contextKey{}).(Scope)
+	return scope
+}
+
+// Sees reports whether a row deleted at deletedAt, nil when it is not deleted, is in scope
+// Repositories filtering in memory use it in place of a query condition
+func (s Scope) Sees(deletedAt *time.Time) bool {
+	switch s {
+	case IncludeDeleted:
+		return true
+	case DeletedOnly:
+		return deletedAt != nil
+	default:
+		return deletedAt == nil
+	}
+}
+
+// Purger is implemented by repositories of soft-deleting models
+type Purger interface {
+	// PurgeOlderThan permanently deletes the rows soft deleted more than age ago, with the
+	// rows depending on them, and returns how many were purged
+	PurgeOlderThan(ctx context.Context, age time.Duration) (int64, error)
+}
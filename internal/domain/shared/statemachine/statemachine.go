@@ -0,0 +1,149 @@
+// Package statemachine declares the lifecycle of an entity as named transitions between
+// states, with guards vetoing them, hooks reacting to them and domain events raised for them
+package statemachine
+
+import (
+	"fmt"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/events"
+)
+
+// ErrInvalidTransition is returned for a transition not permitted from the entity's state,
+// unless the machine names another error with RejectWith
+var ErrInvalidTransition = sharedEntities.DomainError{Message: "invalid status transition"}
+
+// Transition is a named move to To from any of the From states
+type Transition[S comparable, E any] struct {
+	Name string
+	From []S
+	To   S
+	// Guard, when set, vetoes the transition by returning an error
+	Guard func(entity E) error
+}
+
+// Change is a transition that happened
+type Change[S comparable] struct {
+	Transition string
+	From       S
+	To         S
+	At         time.Time
+}
+
+// Hook reacts to a transition once the entity is in its new state
+type Hook[S comparable, E any] func(entity E, change Change[S])
+
+// Machine is the lifecycle of entities of type E, whose state is of type S
+// Define it once, e.g. in a package variable, and fire transitions on entities with Fire
+type Machine[S comparable, E any] struct {
+	state       func(E) S
+	setState    func(E, S, time.Time)
+	transitions map[string]Transition[S, E]
+	names       []string
+	hooks       []Hook[S, E]
+	event       func(E, Change[S]) events.DomainEvent
+	invalid     error
+}
+
+// New creates a machine reading the entity's state with state and moving it with setState
+func New[S comparable, E any](state func(E) S, setState func(entity E, to S, at time.Time)) *Machine[S, E] {
+	return &Machine[S, E]{
+		state:       state,
+		setState:    setState,
+		transitions: make(map[string]Transition[S, E]),
+		invalid:     ErrInvalidTransition,
+	}
+}
+
+// Permit declares a transition; names are unique per machine
+func (m *Machine[S, E]) Permit(t Transition[S, E]) *Machine[S, E] {
+	if _, ok := m.transitions[t.Name]; ok {
+		panic(fmt.Sprintf("statemachine: transition %q declared twice", t.Name))
+	}
+	m.transitions[t.Name] = t
+	m.names = append(m.names, t.Name)
+	return m
+}
+
+// OnTransition adds a hook run after every transition, in the order added
+func (m *Machine[S, E]) OnTransition(hook Hook[S, E]) *Machine[S, E] {
+	m.hooks = append(m.hooks, hook)
+	return m
+}
+
+// Emit makes every transition record the event built by newEvent on the entity, for the use
+// case to publish once the entity is persisted. E must implement events.EventRecorder
+func (m *Machine[S, E]) Emit(newEvent func(entity E, change Change[S]) events.DomainEvent) *Machine[S, E] {
+	var entity E
+	if _, ok := any(entity).(events.EventRecorder); !ok {
+		panic(fmt.Sprintf("statemachine: %T does not record events", entity))
+	}
+	m.event = newEvent
+	return m
+}
+
+// RejectWith sets the error returned for transitions not permitted from the entity's state
+func (m *Machine[S, E]) RejectWith(err error) *Machine[S, E] {
+	m.invalid = err
+	return m
+}
+
+// Can reports whether the transition named name may be fired on entity now
+func (m *Machine[S, E]) Can(entity E, name string) bool {
+	_, err := m.check(entity, name)
+	return err == nil
+}
+
+// Available lists the transitions that may be fired on entity now, in declaration order
+func (m *Machine[S, E]) Available(entity E) []string {
+	names := make([]string, 0, len(m.names))
+	for _, name := range m.names {
+		if m.Can(entity, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Fire moves entity along the transition named name, runs the hooks and records the event
+// It returns the guard's error, or the machine's rejection when the transition is not
+// permitted from the entity's state, leaving the entity unchanged
+func (m *Machine[S, E]) Fire(entity E, name string) error {
+	t, err := m.check(entity, name)
+	if err != nil {
+		return err
+	}
+
+	change := Change[S]{Transition: name, From: m.state(entity), To: t.To, At: time.Now()}
+	m.setState(entity, t.To, change.At)
+	for _, hook := range m.hooks {
+		hook(entity, change)
+	}
+	if m.event != nil {
+		any(entity).(events.EventRecorder).RecordEvent(m.event(entity, change))
+	}
+	return nil
+}
+
+// check returns the transition named name when it is permitted for entity
+func (m *Machine[S, E]) check(entity E, name string) (Transition[S, E], error) {
+	t, ok := m.transitions[name]
+	if !ok {
+		return t, fmt.Errorf("statemachine: unknown transition %q", name)
+	}
+
+	state := m.state(entity)
+	for _, from := range t.From {
+		if from != state {
+			continue
+		}
+		if t.Guard != nil {
+			if err := t.Guard(entity); err != nil {
+				return t, err
+			}
+		}
+		return t, nil
+	}
+	return t, m.invalid
+}
@@ -0,0 +1,53 @@
+// Package storage defines the port through which uploaded files are kept
+package storage
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=storage.go -destination=../../../mocks/storage_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// Storage keeps files under slash-separated keys such as avatars/42.jpg and serves them
+// at public URLs; implemented by the infrastructure layer
+type Storage interface {
+	// Put writes body under key, replacing any file already there
+	Put(ctx context.Context, key, contentType string, body io.Reader) error
+	// Delete removes the file under key; a missing file is not an error
+	Delete(ctx context.Context, key string) error
+	// URL is where clients fetch the file under key
+	URL(key string) string
+	// SignedURL is a URL to the file under key that works without credentials until expiresAt
+	SignedURL(key string, expiresAt time.Time) (string, error)
+}
+
+// ErrUploadNotFound is returned for a multipart upload that was completed, aborted or expired
+var ErrUploadNotFound = errors.New("storage: multipart upload not found")
+
+// Part is an uploaded part of a multipart upload
+type Part struct {
+	Number int    `json:"number"`
+	ETag   string `json:"etag"`
+}
+
+// MultipartStorage is a Storage that also assembles files from parts uploaded one at a time,
+// so large files are written without being held in full anywhere; an upload left incomplete,
+// e.g. by a worker restart, can be continued from its listed parts
+type MultipartStorage interface {
+	Storage
+	// PartSize is the size to split files into, at least the smallest part accepted for
+	// every part but the last
+	PartSize() int
+	// CreateUpload starts an upload of the file under key and returns its ID
+	CreateUpload(ctx context.Context, key, contentType string) (string, error)
+	// UploadPart uploads body as part number, counting from 1, replacing any earlier one
+	UploadPart(ctx context.Context, key, uploadID string, number int, body []byte) (Part, error)
+	// ListParts returns the parts uploaded so far in number order
+	ListParts(ctx context.Context, key, uploadID string) ([]Part, error)
+	// CompleteUpload assembles parts, in number order, into the file under key
+	CompleteUpload(ctx context.Context, key, uploadID string, parts []Part) error
+	// AbortUpload discards the upload and its parts
+	AbortUpload(ctx context.Context, key, uploadID string) error
+}
@@ -0,0 +1,90 @@
+// Package templates defines the port through which notifications are rendered for each
+// channel they are delivered on
+package templates
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=templates.go -destination=../../../mocks/templates_mock.go -package=mocks
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Channel is a way a notification reaches a user
+type Channel string
+
+// Channels notifications are rendered for
+const (
+	ChannelInApp Channel = "in_app"
+	ChannelEmail Channel = "email"
+	ChannelSMS   Channel = "sms"
+	ChannelPush  Channel = "push"
+)
+
+// Channels lists every channel, in the order previews show them
+var Channels = []Channel{ChannelInApp, ChannelEmail, ChannelSMS, ChannelPush}
+
+// Errors of rendering
+var (
+	ErrUnknownTemplate = errors.New("unknown notification template")
+	ErrNoVariant       = errors.New("notification template has no variant for the channel")
+	// ErrRender wraps the failures of a template with variables it cannot render, e.g. a
+	// date that is not one
+	ErrRender = errors.New("failed to render notification template")
+)
+
+// VariablesError is returned for variables that do not match those a template declares
+type VariablesError struct {
+	Template string
+	Missing  []string
+	Unknown  []string
+}
+
+// Error names the missing and unknown variables
+func (e *VariablesError) Error() string {
+	var problems []string
+	if len(e.Missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Unknown) > 0 {
+		problems = append(problems, "unknown "+strings.Join(e.Unknown, ", "))
+	}
+	return fmt.Sprintf("template %s variables: %s", e.Template, strings.Join(problems, "; "))
+}
+
+// Content is a notification rendered for one channel. In-app and push notifications have a
+// Title and a Body; emails a Title, their subject, a plain text Body and usually HTML; text
+// messages only a Body
+type Content struct {
+	Channel Channel
+	Title   string
+	Body    string
+	HTML    string
+}
+
+// Template describes a notification template
+type Template struct {
+	Name        string
+	Description string
+	// Variables maps each variable the template needs to what it holds
+	Variables map[string]string
+	// Channels are those the template has a variant for
+	Channels []Channel
+	// Example holds variables to preview the template with
+	Example map[string]interface{}
+	// Locales are the default locale and those the template has its own variants in; the
+	// others fall back on them
+	Locales []string
+}
+
+// Engine renders notification templates; implemented by the infrastructure layer
+type Engine interface {
+	// Render renders the channel's variant of the template in locale, falling back on less
+	// specific locales and then the default one, with vars, which must be exactly the
+	// variables the template declares; a *VariablesError otherwise
+	Render(name string, channel Channel, locale string, vars map[string]interface{}) (*Content, error)
+	// Template describes the template with name
+	Template(name string) (Template, bool)
+	// Templates describes every template, by name
+	Templates() []Template
+}
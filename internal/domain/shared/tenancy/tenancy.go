@@ -0,0 +1,26 @@
+// Package tenancy carries the tenant a request or task acts for through its context
+// Repositories scope their queries to that tenant; a context without one is the system
+// acting across tenants, as scheduled jobs and delivery workers do
+package tenancy
+
+import "context"
+
+// contextKey keeps the tenant set by NewContext private to this package
+type contextKey struct{}
+
+// NewContext returns a copy of ctx acting for tenantID
+func NewContext(ctx context.Context, tenantID uint) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenantID)
+}
+
+// FromContext returns the tenant ctx acts for, if any
+func FromContext(ctx context.Context) (uint, bool) {
+	tenantID, ok := ctx.Value(contextKey{}).(uint)
+	return tenantID, ok && tenantID != 0
+}
+
+// WithoutTenant returns a copy of ctx acting across tenants, for work on behalf of no
+// tenant in particular such as webhooks from third parties
+func WithoutTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, uint(0))
+}
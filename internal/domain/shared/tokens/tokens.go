@@ -0,0 +1,35 @@
+// Package tokens defines the port through which bearer tokens are signed as JWTs, so
+// other services can verify them with the published keys
+package tokens
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=tokens.go -destination=../../../mocks/tokens_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrInvalid is wrapped by the errors of Verify for tokens that must be rejected, as
+// opposed to failures to check them
+var ErrInvalid = errors.New("invalid token")
+
+// Claims are the claims carried by a signed token
+type Claims struct {
+	// Subject is the ID of the user the token was issued to
+	Subject  string
+	TenantID uint
+	// ID identifies the token uniquely
+	ID        string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// Signer signs tokens with its current key and verifies those signed with any key it has
+// not retired for longer than the tokens live; implemented by the infrastructure layer
+type Signer interface {
+	Sign(ctx context.Context, claims Claims) (string, error)
+	// Verify checks the signature, issuer and expiry of token and returns its claims; tokens
+	// failing the checks are rejected with an error wrapping ErrInvalid
+	Verify(ctx context.Context, token string) (*Claims, error)
+}
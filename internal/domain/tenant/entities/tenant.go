@@ -0,0 +1,135 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/money"
+)
+
+const (
+	// DefaultTenantID is the tenant of requests naming none, and of the data that existed
+	// before tenants did
+	DefaultTenantID uint = 1
+	// DefaultSlug is the slug of the default tenant
+	DefaultSlug = "default"
+)
+
+// slugPattern is a DNS label, so every slug can also be a subdomain
+var slugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// Tenant is an organization whose data is isolated from every other tenant's
+type Tenant struct {
+	ID uint
+	// Slug names the tenant in the X-Tenant header and as the subdomain it is served on
+	Slug   string
+	Name   string
+	Status Status
+	// BaseCurrency is the currency the tenant's prices are set and its orders recorded in
+	BaseCurrency money.Currency
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// NewTenant creates a new active tenant with validation, in baseCurrency or else the
+// default currency
+func NewTenant(slug, name, baseCurrency string) (*Tenant, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if !slugPattern.MatchString(slug) {
+		return nil, ErrInvalidSlug
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidTenantName
+	}
+	currency := money.DefaultCurrency
+	if baseCurrency != "" {
+		var err error
+		if currency, err = money.ParseCurrency(baseCurrency); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	return &Tenant{
+		Slug:         slug,
+		Name:         name,
+		Status:       StatusActive,
+		BaseCurrency: currency,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// IsDefault reports whether this is the default tenant, which is always served
+func (t *Tenant) IsDefault() bool {
+	return t.ID == DefaultTenantID
+}
+
+// Rename changes the display name
+func (t *Tenant) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrInvalidTenantName
+	}
+	t.Name = name
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetStatus suspends or reactivates the tenant; the default tenant cannot be suspended
+func (t *Tenant) SetStatus(status Status) error {
+	if !status.Valid() {
+		return ErrInvalidTenantStatus
+	}
+	if t.IsDefault() && status != StatusActive {
+		return ErrDefaultTenant
+	}
+	t.Status = status
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetBaseCurrency changes the currency of the tenant's prices and future orders; existing
+// orders keep the currency they were placed in
+func (t *Tenant) SetBaseCurrency(code string) error {
+	currency, err := money.ParseCurrency(code)
+	if err != nil {
+		return err
+	}
+	t.BaseCurrency = currency
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsSuspended reports whether the tenant's requests are refused
+func (t *Tenant) IsSuspended() bool {
+	return t.Status == StatusSuspended
+}
+
+// Status is whether a tenant may be used
+type Status string
+
+// Tenant statuses
+const (
+	StatusActive    Status = "active"
+	StatusSuspended Status = "suspended"
+)
+
+// Valid reports whether the status is known
+func (s Status) Valid() bool {
+	return s == StatusActive || s == StatusSuspended
+}
+
+// Domain errors for tenants
+var (
+	ErrInvalidSlug         = sharedEntities.DomainError{Message: "slug must be 1-63 lowercase letters, digits or hyphens, not starting or ending with a hyphen"}
+	ErrInvalidTenantName   = sharedEntities.DomainError{Message: "tenant name is required"}
+	ErrInvalidTenantStatus = sharedEntities.DomainError{Message: "status must be active or suspended"}
+	ErrTenantNotFound      = sharedEntities.DomainError{Message: "tenant not found"}
+	ErrSlugExists          = sharedEntities.DomainError{Message: "tenant with this slug already exists"}
+	ErrTenantSuspended     = sharedEntities.DomainError{Message: "tenant is suspended"}
+	ErrDefaultTenant       = sharedEntities.DomainError{Message: "the default tenant cannot be suspended or deleted"}
+)
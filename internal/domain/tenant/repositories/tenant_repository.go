@@ -0,0 +1,23 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=tenant_repository.go -destination=../../../mocks/tenant_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/tenant/entities"
+)
+
+// TenantRepository defines the contract for tenant persistence
+// Tenants are looked up by slug on every request naming one, so implementations must
+// answer GetBySlug from an index
+type TenantRepository interface {
+	Create(ctx context.Context, tenant *entities.Tenant) error
+	GetByID(ctx context.Context, id uint) (*entities.Tenant, error)
+	GetBySlug(ctx context.Context, slug string) (*entities.Tenant, error)
+	// List returns a page of tenants in creation order
+	List(ctx context.Context, limit, offset int) ([]*entities.Tenant, error)
+	Count(ctx context.Context) (int64, error)
+	Update(ctx context.Context, tenant *entities.Tenant) error
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,30 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=tenant_usecase.go -destination=../../../mocks/tenant_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/shared/money"
+	"clean-arch-gin/internal/domain/tenant/entities"
+)
+
+// TenantUseCase defines the business logic operations for tenants
+// It also tells the base currency of the tenant a context acts for, as money.BaseCurrencies
+type TenantUseCase interface {
+	// CreateTenant creates a tenant in baseCurrency, or the default currency when empty
+	CreateTenant(ctx context.Context, slug, name, baseCurrency string) (*entities.Tenant, error)
+	GetTenant(ctx context.Context, id uint) (*entities.Tenant, error)
+	ListTenants(ctx context.Context, limit, offset int) ([]*entities.Tenant, int64, error)
+	// UpdateTenant renames the tenant and sets its status and base currency; nil leaves a
+	// field unchanged
+	UpdateTenant(ctx context.Context, id uint, name *string, status *entities.Status, baseCurrency *string) (*entities.Tenant, error)
+	// DeleteTenant deletes a tenant; its data is kept but no longer served
+	DeleteTenant(ctx context.Context, id uint) error
+	// ResolveTenant finds the tenant a request names by slug, returning
+	// entities.ErrTenantSuspended when it may not be served
+	ResolveTenant(ctx context.Context, slug string) (*entities.Tenant, error)
+	// BaseCurrency returns the base currency of the tenant ctx acts for, the default
+	// tenant's when it acts for none
+	BaseCurrency(ctx context.Context) (money.Currency, error)
+}
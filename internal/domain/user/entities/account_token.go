@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// AccountTokenPurpose is the account action an emailed token authorizes
+type AccountTokenPurpose string
+
+// Purposes of account tokens
+const (
+	TokenPasswordReset     AccountTokenPurpose = "password_reset"
+	TokenEmailVerification AccountTokenPurpose = "email_verification"
+)
+
+// AccountToken is a single-use token mailed to a user to reset their password or confirm
+// their email. As with sessions, only a hash of the token is kept
+type AccountToken struct {
+	ID        uint
+	UserID    uint
+	Purpose   AccountTokenPurpose
+	TokenHash string
+	// Email is the address the token was mailed to; a verification only confirms the
+	// user's email while it is still this one
+	Email     string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// ErrInvalidAccountToken is returned for account tokens that are unknown, used, expired
+// or issued for another purpose, alike so the response reveals nothing about them
+var ErrInvalidAccountToken = sharedEntities.DomainError{Message: "invalid or expired token"}
+
+// NewAccountToken creates a token for the user, mailed to email, valid for ttl from now
+func NewAccountToken(userID uint, purpose AccountTokenPurpose, tokenHash, email string, ttl time.Duration) *AccountToken {
+	now := time.Now()
+	return &AccountToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: tokenHash,
+		Email:     email,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"net"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// Activity is an entry in a user's activity feed, recorded from a domain event
+type Activity struct {
+	ID     uint
+	UserID uint
+	// Type names what the user did, the name of the event it was recorded from, e.g. user.logged_in
+	Type    string
+	Summary string
+	// Subject is the resource acted on, e.g. orders/42, or the user, e.g. users/7
+	Subject string
+	// IPAddress and UserAgent are set for activity tied to a request, e.g. signing in
+	IPAddress string
+	UserAgent string
+	// Metadata carries identifiers of the activity, e.g. order_id
+	Metadata   map[string]interface{}
+	OccurredAt time.Time
+}
+
+// ErrInvalidActivity is returned for an activity without a user or a type
+var ErrInvalidActivity = sharedEntities.DomainError{Message: "activity needs a user and a type"}
+
+// NewActivity creates an activity that happened at occurredAt, or now when it is zero
+func NewActivity(userID uint, activityType, summary string, occurredAt time.Time) (*Activity, error) {
+	if userID == 0 || activityType == "" {
+		return nil, ErrInvalidActivity
+	}
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	return &Activity{
+		UserID:     userID,
+		Type:       activityType,
+		Summary:    summary,
+		OccurredAt: occurredAt,
+	}, nil
+}
+
+// MaskedIP returns the network of the IP address, see MaskIP
+func (a *Activity) MaskedIP() string {
+	return MaskIP(a.IPAddress)
+}
+
+// MaskIP returns an IP address with its host part zeroed, the /24 of an IPv4 address or the
+// /48 of an IPv6 one: enough to recognize a network without pinpointing a device
+func MaskIP(address string) string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return ""
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(24, 32)).String()
+	}
+	return ip.Mask(net.CIDRMask(48, 128)).String()
+}
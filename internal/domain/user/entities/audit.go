@@ -0,0 +1,55 @@
+package entities
+
+import "time"
+
+// AuditAction names an admin action on a user account
+type AuditAction string
+
+// Audited admin actions
+const (
+	AuditUserUpdated         AuditAction = "user.updated"
+	AuditUserDeleted         AuditAction = "user.deleted"
+	AuditUserRestored        AuditAction = "user.restored"
+	AuditUserPurged          AuditAction = "user.purged"
+	AuditStatusChanged       AuditAction = "user.status_changed"
+	AuditRoleChanged         AuditAction = "user.role_changed"
+	AuditPasswordResetForced AuditAction = "user.password_reset_forced"
+)
+
+// AuditEntry records an admin action on a user account: who did what to whom, and why
+type AuditEntry struct {
+	ID uint
+	// ActorID is the admin who acted, TargetUserID the account acted on
+	ActorID      uint
+	TargetUserID uint
+	Action       AuditAction
+	// Changes maps each changed field to its old and new value
+	Changes   map[string]AuditChange
+	Reason    string
+	CreatedAt time.Time
+}
+
+// AuditChange is the old and new value of a changed field
+type AuditChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// NewAuditEntry creates the record of an action taken now
+func NewAuditEntry(actorID, targetUserID uint, action AuditAction, reason string) *AuditEntry {
+	return &AuditEntry{
+		ActorID:      actorID,
+		TargetUserID: targetUserID,
+		Action:       action,
+		Changes:      map[string]AuditChange{},
+		Reason:       reason,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// Change records that field went from old to new, skipping fields that did not change
+func (e *AuditEntry) Change(field string, old, new interface{}) {
+	if old != new {
+		e.Changes[field] = AuditChange{From: old, To: new}
+	}
+}
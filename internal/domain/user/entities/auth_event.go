@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// AuthEventType names something that happened to an account's credentials or sessions
+type AuthEventType string
+
+// Recorded authentication events
+const (
+	AuthLoginSucceeded AuthEventType = "login_succeeded"
+	AuthLoginFailed    AuthEventType = "login_failed"
+	AuthLogout         AuthEventType = "logout"
+	// AuthSessionRevoked is one session signed out from another, AuthSessionsRevoked
+	// all of them at once
+	AuthSessionRevoked  AuthEventType = "session_revoked"
+	AuthSessionsRevoked AuthEventType = "sessions_revoked"
+	AuthTokenRefreshed  AuthEventType = "token_refreshed"
+	AuthPasswordChanged AuthEventType = "password_changed"
+	// AuthTwoFactorEnrolled is a second factor added to the account
+	AuthTwoFactorEnrolled AuthEventType = "two_factor_enrolled"
+)
+
+// AuthEventTypes lists the recorded types, for validating filters
+var AuthEventTypes = []AuthEventType{
+	AuthLoginSucceeded, AuthLoginFailed, AuthLogout, AuthSessionRevoked, AuthSessionsRevoked,
+	AuthTokenRefreshed, AuthPasswordChanged, AuthTwoFactorEnrolled,
+}
+
+// Reasons of failed sign-ins
+const (
+	AuthFailureInvalidCredentials = "invalid_credentials"
+	AuthFailureSuspended          = "suspended"
+	// AuthFailurePasswordResetRequired is a correct password an admin required to be reset
+	AuthFailurePasswordResetRequired = "password_reset_required"
+)
+
+// ErrInvalidAuthEventType is returned for a filter naming an unknown event type
+var ErrInvalidAuthEventType = sharedEntities.DomainError{Message: "unknown auth event type"}
+
+// AuthEvent is an entry of the authentication audit trail
+// Failed sign-ins to unknown accounts are recorded too, without a user
+type AuthEvent struct {
+	ID uint
+	// UserID is 0 when the account could not be identified
+	UserID uint
+	// SessionID is the session the event started or ended, if any
+	SessionID uint
+	Type      AuthEventType
+	// Reason explains failures, e.g. AuthFailureInvalidCredentials
+	Reason     string
+	IPAddress  string
+	UserAgent  string
+	OccurredAt time.Time
+}
+
+// AuthEventFilter selects auth events; zero fields match every event
+type AuthEventFilter struct {
+	UserID uint
+	Type   AuthEventType
+	Since  time.Time
+}
+
+// NewAuthEvent creates an event of the user that happens now from the client at ipAddress
+func NewAuthEvent(userID uint, eventType AuthEventType, ipAddress, userAgent string) *AuthEvent {
+	return &AuthEvent{
+		UserID:     userID,
+		Type:       eventType,
+		IPAddress:  ipAddress,
+		UserAgent:  userAgent,
+		OccurredAt: time.Now(),
+	}
+}
+
+// ParseAuthEventType validates an event type from a filter
+func ParseAuthEventType(value string) (AuthEventType, error) {
+	for _, eventType := range AuthEventTypes {
+		if string(eventType) == value {
+			return eventType, nil
+		}
+	}
+	return "", ErrInvalidAuthEventType
+}
+
+// MaskedIP returns the network of the IP address, see MaskIP
+func (e *AuthEvent) MaskedIP() string {
+	return MaskIP(e.IPAddress)
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"strconv"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+const (
+	// MaxAvatarBytes caps the size of an uploaded profile picture
+	MaxAvatarBytes = 5 << 20
+	// MaxAvatarPixels caps the decoded size so small files cannot expand into huge images
+	MaxAvatarPixels = 40_000_000
+	// AvatarSize is the width and height, in pixels, every avatar is stored at
+	AvatarSize = 256
+)
+
+// AvatarContentTypes are the image formats accepted for upload
+var AvatarContentTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// Domain errors for avatars
+var (
+	ErrAvatarTooLarge = sharedEntities.DomainError{
+		Message: "avatar must be at most " + strconv.Itoa(MaxAvatarBytes>>20) + " MB",
+	}
+	ErrUnsupportedAvatarType = sharedEntities.DomainError{Message: "avatar must be a JPEG, PNG or GIF image"}
+	ErrInvalidAvatar         = sharedEntities.DomainError{Message: "avatar is not a readable image"}
+)
+
+// AvatarKey is the storage key of a user's avatar; a new upload replaces the old file
+func AvatarKey(userID uint) string {
+	return "avatars/" + strconv.FormatUint(uint64(userID), 10) + ".jpg"
+}
@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// ConnectionType is a side of a user's connections
+type ConnectionType string
+
+// Sides of a user's connections
+const (
+	// ConnectionFollowers are the users following the user
+	ConnectionFollowers ConnectionType = "followers"
+	// ConnectionFollowing are the users the user follows
+	ConnectionFollowing ConnectionType = "following"
+)
+
+// Valid reports whether the side is known
+func (t ConnectionType) Valid() bool {
+	return t == ConnectionFollowers || t == ConnectionFollowing
+}
+
+// Domain errors for connections
+var (
+	ErrSelfFollow            = sharedEntities.DomainError{Message: "users cannot follow themselves"}
+	ErrInvalidConnectionType = sharedEntities.DomainError{Message: "type must be followers or following"}
+)
+
+// Connection is a user following another, whose shared activity then shows in the
+// follower's timeline
+type Connection struct {
+	ID         uint
+	FollowerID uint
+	FolloweeID uint
+	CreatedAt  time.Time
+}
+
+// NewConnection creates the connection of follower following followee
+func NewConnection(followerID, followeeID uint) (*Connection, error) {
+	if followerID == followeeID {
+		return nil, ErrSelfFollow
+	}
+	return &Connection{
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// ConnectionCounts are how many users follow a user and how many it follows
+// Deleted users are not counted
+type ConnectionCounts struct {
+	Followers int64
+	Following int64
+}
+
+// TimelineEntry is an activity of a followed user in a timeline, with that user
+type TimelineEntry struct {
+	Activity *Activity
+	User     *User
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+const (
+	// MaxDevices caps the devices a user gets push notifications on; registering another
+	// forgets the one registered longest ago
+	MaxDevices = 20
+	// MaxDeviceTokenLength bounds a push token; FCM tokens are about 160 characters
+	MaxDeviceTokenLength = 512
+)
+
+// DevicePlatform is the operating system a device runs
+type DevicePlatform string
+
+// Platforms of devices
+const (
+	DevicePlatformAndroid DevicePlatform = "android"
+	DevicePlatformIOS     DevicePlatform = "ios"
+	DevicePlatformWeb     DevicePlatform = "web"
+)
+
+// Valid reports whether the platform is known
+func (p DevicePlatform) Valid() bool {
+	return p == DevicePlatformAndroid || p == DevicePlatformIOS || p == DevicePlatformWeb
+}
+
+// Domain errors for devices
+var (
+	ErrDeviceNotFound        = sharedEntities.DomainError{Message: "device not found"}
+	ErrInvalidDeviceToken    = sharedEntities.DomainError{Message: "device token is required and at most 512 characters"}
+	ErrInvalidDevicePlatform = sharedEntities.DomainError{Message: "platform must be android, ios or web"}
+)
+
+// Device is an app install push notifications are sent to, addressed by the token the push
+// provider issued it
+// A token belongs to one user at a time: the last one to register it from the device
+type Device struct {
+	ID       uint
+	UserID   uint
+	Token    string
+	Platform DevicePlatform
+	// Name tells the user's devices apart, e.g. "Pixel 8"; may be empty
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewDevice creates a device of the user with validation
+func NewDevice(userID uint, token string, platform DevicePlatform, name string) (*Device, error) {
+	token = strings.TrimSpace(token)
+	if token == "" || len(token) > MaxDeviceTokenLength {
+		return nil, ErrInvalidDeviceToken
+	}
+	if !platform.Valid() {
+		return nil, ErrInvalidDevicePlatform
+	}
+
+	now := time.Now()
+	return &Device{
+		UserID:    userID,
+		Token:     token,
+		Platform:  platform,
+		Name:      strings.TrimSpace(name),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// Notification is a message in a user's in-app inbox, created from a domain event
+type Notification struct {
+	ID     uint
+	UserID uint
+	// Type names what the notification is about, usually the event name, e.g. order.status_changed
+	Type  string
+	Title string
+	Body  string
+	// Data carries identifiers a client needs to link to the subject, e.g. order_id
+	Data      map[string]interface{}
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+// NotificationCounts summarizes a user's inbox
+type NotificationCounts struct {
+	Total  int64
+	Unread int64
+}
+
+// Domain errors for notifications
+var (
+	ErrInvalidNotification  = sharedEntities.DomainError{Message: "notification needs a user, a type and a title"}
+	ErrNotificationNotFound = sharedEntities.DomainError{Message: "notification not found"}
+)
+
+// NewNotification creates an unread notification with validation
+func NewNotification(userID uint, notificationType, title, body string, data map[string]interface{}) (*Notification, error) {
+	if userID == 0 || notificationType == "" || title == "" {
+		return nil, ErrInvalidNotification
+	}
+
+	return &Notification{
+		UserID:    userID,
+		Type:      notificationType,
+		Title:     title,
+		Body:      body,
+		Data:      data,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// IsRead checks if the user has read the notification
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
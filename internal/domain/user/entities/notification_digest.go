@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+// MaxDigestItems caps the notifications a digest lists; it counts the others
+const MaxDigestItems = 50
+
+// DigestItem is a low-priority notification waiting for the user's next digest, as its
+// in-app rendering
+type DigestItem struct {
+	ID        uint
+	UserID    uint
+	Type      string
+	Title     string
+	Body      string
+	CreatedAt time.Time
+}
+
+// NewDigestItem creates a pending digest item with validation
+func NewDigestItem(userID uint, notificationType, title, body string) (*DigestItem, error) {
+	if userID == 0 || notificationType == "" || title == "" {
+		return nil, ErrInvalidNotification
+	}
+
+	return &DigestItem{
+		UserID:    userID,
+		Type:      notificationType,
+		Title:     title,
+		Body:      body,
+		CreatedAt: time.Now(),
+	}, nil
+}
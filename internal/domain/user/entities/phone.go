@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"strings"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// ErrInvalidPhone is returned for phone numbers that are not in international format
+var ErrInvalidPhone = sharedEntities.DomainError{Message: "phone must be an international number such as +14155550123"}
+
+// Bounds of an E.164 number's digits, country code included
+const (
+	minPhoneDigits = 8
+	maxPhoneDigits = 15
+)
+
+// NormalizePhone returns raw in E.164 format, e.g. "+14155550123"
+// Spaces, dashes, dots and parentheses are dropped and a leading 00 is read as +; numbers
+// without a country code are rejected, since the country cannot be guessed
+func NormalizePhone(raw string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(raw))
+
+	switch {
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	case strings.HasPrefix(digits, "00"):
+		digits = digits[2:]
+	default:
+		return "", ErrInvalidPhone
+	}
+	if len(digits) < minPhoneDigits || len(digits) > maxPhoneDigits || digits[0] == '0' {
+		return "", ErrInvalidPhone
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidPhone
+		}
+	}
+	return "+" + digits, nil
+}
@@ -0,0 +1,251 @@
+package entities
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+	_ "time/tzdata" // timezones validate on hosts without a zone database
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// Notification opt-ins known to the preference schema
+const (
+	NotifyEmail     = "email"
+	NotifyPush      = "push"
+	NotifySMS       = "sms"
+	NotifyMarketing = "marketing"
+)
+
+const (
+	// DefaultLocale and DefaultTimezone apply until the user sets their own
+	DefaultLocale   = "en"
+	DefaultTimezone = "UTC"
+	// MaxCustomPreferences caps the namespaced keys per user
+	MaxCustomPreferences = 50
+	// MaxCustomPreferenceBytes caps the JSON value of a namespaced key
+	MaxCustomPreferenceBytes = 1024
+	// MaxNotificationTypes caps the notification types a user toggles
+	MaxNotificationTypes = 50
+	// maxPreferenceKeyLength matches the longest key worth storing
+	maxPreferenceKeyLength = 128
+	// clockLayout is the format of the bounds of quiet hours
+	clockLayout = "15:04"
+)
+
+var (
+	// localePattern accepts BCP 47 style tags such as en, pt-BR or zh-Hant-TW
+	localePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+	// preferenceKeyPattern requires a namespace, e.g. ui.theme or editor.font_size
+	preferenceKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z0-9_-]+)+$`)
+	// reservedNamespaces belong to the typed preferences and cannot hold custom keys
+	reservedNamespaces = []string{"notifications", "notification_types", "quiet_hours", "locale", "timezone"}
+)
+
+// Domain errors for user preferences
+var (
+	ErrInvalidLocale            = sharedEntities.DomainError{Message: "locale must be a language tag such as en or pt-BR"}
+	ErrInvalidTimezone          = sharedEntities.DomainError{Message: "timezone must be an IANA zone such as Europe/Berlin"}
+	ErrTooManyPreferences       = sharedEntities.DomainError{Message: "too many custom preferences"}
+	ErrInvalidQuietHours        = sharedEntities.DomainError{Message: "quiet hours must start and end at different times such as 22:00 and 07:00"}
+	ErrTooManyNotificationTypes = sharedEntities.DomainError{Message: "too many notification types"}
+	ErrUserPreferencesNotFound  = sharedEntities.DomainError{Message: "user preferences not found"}
+)
+
+// QuietHours is the daily period, in the user's timezone, in which notifications do not
+// interrupt them; it wraps past midnight when End is before Start, e.g. 22:00 to 07:00
+type QuietHours struct {
+	Start string
+	End   string
+}
+
+// UserPreferences holds a user's settings: the typed locale, timezone and notification
+// opt-ins, plus free-form namespaced keys for clients, e.g. ui.theme
+type UserPreferences struct {
+	UserID   uint
+	Locale   string
+	Timezone string
+	// Notifications maps every known opt-in to whether the user wants it
+	Notifications map[string]bool
+	// NotificationTypes maps the notification types the user toggled, e.g.
+	// order.status_changed, to whether they want them; types not listed are wanted
+	NotificationTypes map[string]bool
+	// QuietHours is nil when the user set none
+	QuietHours *QuietHours
+	// Custom maps namespaced keys to JSON values
+	Custom    map[string]json.RawMessage
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// PreferencesUpdate is a partial change; nil and absent fields are left as they are
+type PreferencesUpdate struct {
+	Locale        *string
+	Timezone      *string
+	Notifications map[string]bool
+	// NotificationTypes toggles notification types
+	NotificationTypes map[string]bool
+	// QuietHours replaces the quiet hours; empty bounds remove them
+	QuietHours *QuietHours
+	// Custom sets namespaced keys; a JSON null value removes the key
+	Custom map[string]json.RawMessage
+}
+
+// NewDefaultPreferences returns the preferences of a user who has set none
+func NewDefaultPreferences(userID uint) *UserPreferences {
+	return &UserPreferences{
+		UserID:   userID,
+		Locale:   DefaultLocale,
+		Timezone: DefaultTimezone,
+		Notifications: map[string]bool{
+			NotifyEmail:     true,
+			NotifyPush:      true,
+			NotifySMS:       false,
+			NotifyMarketing: false,
+		},
+		NotificationTypes: map[string]bool{},
+		Custom:            map[string]json.RawMessage{},
+	}
+}
+
+// Apply validates the update against the preference schema and applies it
+// Nothing changes when any field is invalid
+func (p *UserPreferences) Apply(update PreferencesUpdate) error {
+	if update.Locale != nil && !localePattern.MatchString(*update.Locale) {
+		return ErrInvalidLocale
+	}
+	if update.Timezone != nil {
+		if _, err := time.LoadLocation(*update.Timezone); err != nil || *update.Timezone == "" || *update.Timezone == "Local" {
+			return ErrInvalidTimezone
+		}
+	}
+	for name := range update.Notifications {
+		if _, ok := p.Notifications[name]; !ok {
+			return invalidPreference("notifications."+name, "is not a known notification opt-in")
+		}
+	}
+
+	if update.QuietHours != nil && *update.QuietHours != (QuietHours{}) && !update.QuietHours.valid() {
+		return ErrInvalidQuietHours
+	}
+	types := make(map[string]bool, len(p.NotificationTypes)+len(update.NotificationTypes))
+	for name, wanted := range p.NotificationTypes {
+		types[name] = wanted
+	}
+	for name, wanted := range update.NotificationTypes {
+		if len(name) > maxPreferenceKeyLength || !preferenceKeyPattern.MatchString(name) {
+			return invalidPreference("notification_types."+name, "must be a notification type such as order.status_changed")
+		}
+		types[name] = wanted
+	}
+	if len(types) > MaxNotificationTypes {
+		return ErrTooManyNotificationTypes
+	}
+
+	custom := make(map[string]json.RawMessage, len(p.Custom)+len(update.Custom))
+	for key, value := range p.Custom {
+		custom[key] = value
+	}
+	for key, value := range update.Custom {
+		if err := validateCustomPreference(key, value); err != nil {
+			return err
+		}
+		if string(value) == "null" {
+			delete(custom, key)
+			continue
+		}
+		custom[key] = value
+	}
+	if len(custom) > MaxCustomPreferences {
+		return ErrTooManyPreferences
+	}
+
+	if update.Locale != nil {
+		p.Locale = *update.Locale
+	}
+	if update.Timezone != nil {
+		p.Timezone = *update.Timezone
+	}
+	for name, optIn := range update.Notifications {
+		p.Notifications[name] = optIn
+	}
+	if update.QuietHours != nil {
+		p.QuietHours = nil
+		if *update.QuietHours != (QuietHours{}) {
+			quiet := *update.QuietHours
+			p.QuietHours = &quiet
+		}
+	}
+	p.NotificationTypes = types
+	p.Custom = custom
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// WantsNotification reports whether the user opted in to a notification kind
+func (p *UserPreferences) WantsNotification(name string) bool {
+	return p.Notifications[name]
+}
+
+// WantsNotificationType reports whether the user wants notifications of notificationType;
+// every type is wanted until toggled off
+func (p *UserPreferences) WantsNotificationType(notificationType string) bool {
+	wanted, ok := p.NotificationTypes[notificationType]
+	return !ok || wanted
+}
+
+// InQuietHours reports whether at falls in the user's quiet hours, read in their timezone
+func (p *UserPreferences) InQuietHours(at time.Time) bool {
+	if p.QuietHours == nil {
+		return false
+	}
+	start, errStart := time.Parse(clockLayout, p.QuietHours.Start)
+	end, errEnd := time.Parse(clockLayout, p.QuietHours.End)
+	if errStart != nil || errEnd != nil {
+		return false
+	}
+	if location, err := time.LoadLocation(p.Timezone); err == nil {
+		at = at.In(location)
+	}
+
+	minute := at.Hour()*60 + at.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	if from < to {
+		return minute >= from && minute < to
+	}
+	return minute >= from || minute < to
+}
+
+// valid reports whether both bounds are times of day and differ
+func (q QuietHours) valid() bool {
+	start, errStart := time.Parse(clockLayout, q.Start)
+	end, errEnd := time.Parse(clockLayout, q.End)
+	return errStart == nil && errEnd == nil && !start.Equal(end)
+}
+
+// validateCustomPreference checks a namespaced key and its JSON value
+func validateCustomPreference(key string, value json.RawMessage) error {
+	if len(key) > maxPreferenceKeyLength || !preferenceKeyPattern.MatchString(key) {
+		return invalidPreference(key, "must be a lowercase namespaced key such as ui.theme")
+	}
+	namespace := key[:strings.IndexByte(key, '.')]
+	for _, reserved := range reservedNamespaces {
+		if namespace == reserved {
+			return invalidPreference(key, "uses the reserved namespace "+reserved)
+		}
+	}
+	if len(value) > MaxCustomPreferenceBytes {
+		return invalidPreference(key, "value is too large")
+	}
+	if !json.Valid(value) {
+		return invalidPreference(key, "value must be JSON")
+	}
+	return nil
+}
+
+// invalidPreference reports why a preference key was rejected
+func invalidPreference(key, reason string) error {
+	return sharedEntities.DomainError{Message: "preference " + key + " " + reason}
+}
@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// Session is a signed-in device: the server-side record of a bearer token issued at login
+// Only a hash of the token is kept, so the records cannot be replayed if they leak
+type Session struct {
+	ID        uint
+	UserID    uint
+	TokenHash string
+	// UserAgent and IPAddress identify the device that signed in
+	UserAgent  string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// Domain errors for sessions
+var (
+	ErrInvalidCredentials = sharedEntities.DomainError{Message: "invalid email or password"}
+	ErrInvalidToken       = sharedEntities.DomainError{Message: "invalid token"}
+	ErrSessionNotFound    = sharedEntities.DomainError{Message: "session not found"}
+	ErrSessionRevoked     = sharedEntities.DomainError{Message: "session has been revoked"}
+	ErrSessionExpired     = sharedEntities.DomainError{Message: "session has expired"}
+)
+
+// NewSession creates a session for the token with tokenHash, valid for ttl from now
+func NewSession(userID uint, tokenHash, userAgent, ipAddress string, ttl time.Duration) *Session {
+	now := time.Now()
+	return &Session{
+		UserID:     userID,
+		TokenHash:  tokenHash,
+		UserAgent:  userAgent,
+		IPAddress:  ipAddress,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+}
+
+// IsRevoked checks if the session was signed out
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// IsExpired checks if the session has outlived its token at now
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// Revoke signs the session out
+func (s *Session) Revoke() {
+	now := time.Now()
+	s.RevokedAt = &now
+}
@@ -4,18 +4,43 @@ import (
 	"time"
 
 	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/passwords"
+	"clean-arch-gin/internal/domain/shared/publicid"
 )
 
 // User represents the pure domain entity
 // No external dependencies - follows Clean Architecture principles
 type User struct {
-	ID        uint
-	Email     string
-	Name      string
+	ID uint
+	// PublicID identifies the user in routes and DTOs; ID stays internal
+	PublicID string
+	Email    string
+	Name     string
+	// Password is the bcrypt hash of the password; rows from before hashing hold it in plain
+	// text until the user signs in
 	Password  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt *time.Time // Pure time pointer, no GORM dependency
+	AvatarURL string // Where the profile picture is served; empty until one is uploaded
+	Role      Role
+	Status    Status
+	// PasswordResetRequired is set by an admin; the user must change their password
+	PasswordResetRequired bool
+	// EmailVerifiedAt is when the user confirmed owning Email; nil until then and again
+	// after it changes
+	EmailVerifiedAt *time.Time
+	// EmailUndeliverableAt is when mail to Email bounced for good or was reported as spam,
+	// for the reason in EmailUndeliverableReason; nil while it is deliverable and again after
+	// it changes
+	EmailUndeliverableAt     *time.Time
+	EmailUndeliverableReason string
+	// Phone is the user's mobile number in E.164 format, texted the notifications they opt
+	// in to; empty until they set one
+	Phone string
+	// SMSOptedOutAt is when the user replied STOP to a text; nil while they may be texted and
+	// again after they reply START or change their phone
+	SMSOptedOutAt *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     *time.Time // Pure time pointer, no GORM dependency
 }
 
 // NewUser creates a new user with validation
@@ -26,14 +51,18 @@ func NewUser(email, name, password string) (*User, error) {
 	if name == "" {
 		return nil, ErrInvalidName
 	}
-	if password == "" {
-		return nil, ErrInvalidPassword
+	hash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
 	}
 
 	return &User{
+		PublicID:  publicid.New(),
 		Email:     email,
 		Name:      name,
-		Password:  password,
+		Password:  hash,
+		Role:      RoleUser,
+		Status:    StatusActive,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}, nil
@@ -51,25 +80,149 @@ func (u *User) MarkAsDeleted() {
 	u.UpdatedAt = now
 }
 
-// UpdateInfo updates user information
+// UpdateInfo updates user information; a new email is unverified and deliverable
 func (u *User) UpdateInfo(name, email string) {
 	if name != "" {
 		u.Name = name
 	}
-	if email != "" {
+	if email != "" && email != u.Email {
 		u.Email = email
+		u.EmailVerifiedAt = nil
+		u.EmailUndeliverableAt = nil
+		u.EmailUndeliverableReason = ""
 	}
 	u.UpdatedAt = time.Now()
 }
 
+// IsEmailVerified reports whether the user confirmed owning their email
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// VerifyEmail records that the user confirmed owning their email
+func (u *User) VerifyEmail() {
+	now := time.Now()
+	u.EmailVerifiedAt = &now
+	u.UpdatedAt = now
+}
+
+// IsEmailDeliverable reports whether mail is still sent to the user's email
+func (u *User) IsEmailDeliverable() bool {
+	return u.EmailUndeliverableAt == nil
+}
+
+// SetPhone sets the user's phone, normalized to E.164, or removes it when phone is empty;
+// a new phone may be texted until it opts out itself
+func (u *User) SetPhone(phone string) error {
+	if phone != "" {
+		normalized, err := NormalizePhone(phone)
+		if err != nil {
+			return err
+		}
+		phone = normalized
+	}
+	if phone != u.Phone {
+		u.Phone = phone
+		u.SMSOptedOutAt = nil
+	}
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// CanBeTexted reports whether the user has a phone that did not opt out of texts
+func (u *User) CanBeTexted() bool {
+	return u.Phone != "" && u.SMSOptedOutAt == nil
+}
+
+// SetAvatar points the profile picture at url
+func (u *User) SetAvatar(url string) {
+	u.AvatarURL = url
+	u.UpdatedAt = time.Now()
+}
+
 // ChangePassword updates the user's password with validation
 func (u *User) ChangePassword(newPassword string) error {
-	if newPassword == "" {
-		return ErrInvalidPassword
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	u.Password = hash
+	u.PasswordResetRequired = false
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// VerifyPassword reports whether password is the user's; when it is but the stored hash is
+// out of date, such as a plain-text password from before hashing, the password is hashed
+// again and rehashed is true so the caller saves the user
+func (u *User) VerifyPassword(password string) (ok, rehashed bool) {
+	ok, rehash := passwords.Verify(u.Password, password)
+	if !ok || !rehash {
+		return ok, false
+	}
+	hash, err := passwords.Hash(password)
+	if err != nil {
+		// The old hash still verifies; the user is rehashed at a later sign-in
+		return true, false
+	}
+	u.Password = hash
+	u.UpdatedAt = time.Now()
+	return true, true
+}
+
+// hashPassword validates a new password and hashes it for storage
+func hashPassword(password string) (string, error) {
+	if password == "" {
+		return "", ErrInvalidPassword
+	}
+	hash, err := passwords.Hash(password)
+	if err == passwords.ErrTooLong {
+		return "", ErrPasswordTooLong
+	}
+	return hash, err
+}
+
+// SetRole assigns one of the known roles
+func (u *User) SetRole(role Role) error {
+	if !role.Valid() {
+		return ErrInvalidRole
 	}
+	u.Role = role
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetStatus suspends or reactivates the account
+func (u *User) SetStatus(status Status) error {
+	if !status.Valid() {
+		return ErrInvalidStatus
+	}
+	u.Status = status
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsSuspended reports whether the account is suspended
+func (u *User) IsSuspended() bool {
+	return u.Status == StatusSuspended
+}
 
-	u.Password = newPassword
+// RequirePasswordReset makes the user change their password before going on
+func (u *User) RequirePasswordReset() {
+	u.PasswordResetRequired = true
 	u.UpdatedAt = time.Now()
+}
+
+// CheckUsable returns why the account may not sign in or act, ErrUserSuspended or
+// ErrPasswordResetRequired, or nil
+func (u *User) CheckUsable() error {
+	if u.IsSuspended() {
+		return ErrUserSuspended
+	}
+	if u.PasswordResetRequired {
+		return ErrPasswordResetRequired
+	}
 	return nil
 }
 
@@ -79,11 +232,50 @@ func (u *User) Activate() {
 	u.UpdatedAt = time.Now()
 }
 
+// Role grants a set of permissions; the auth middleware checks it on admin routes
+type Role string
+
+// Roles a user can be assigned
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+// Valid reports whether the role is known
+func (r Role) Valid() bool {
+	return r == RoleUser || r == RoleAdmin
+}
+
+// Status is whether an account may be used
+type Status string
+
+// Account statuses
+const (
+	StatusActive    Status = "active"
+	StatusSuspended Status = "suspended"
+)
+
+// Valid reports whether the status is known
+func (s Status) Valid() bool {
+	return s == StatusActive || s == StatusSuspended
+}
+
 // Domain errors for user
 var (
 	ErrInvalidEmail    = sharedEntities.DomainError{Message: "email is required"}
 	ErrInvalidName     = sharedEntities.DomainError{Message: "name is required"}
 	ErrInvalidPassword = sharedEntities.DomainError{Message: "password is required"}
+	ErrPasswordTooLong = sharedEntities.DomainError{Message: "password must be at most 72 bytes"}
 	ErrUserNotFound    = sharedEntities.DomainError{Message: "user not found"}
 	ErrEmailExists     = sharedEntities.DomainError{Message: "user with this email already exists"}
+	ErrInvalidRole     = sharedEntities.DomainError{Message: "role must be user or admin"}
+	ErrInvalidStatus   = sharedEntities.DomainError{Message: "status must be active or suspended"}
+	ErrUserSuspended   = sharedEntities.DomainError{Message: "account is suspended"}
+	// ErrPasswordResetRequired is returned once an admin required a new password, until the
+	// user resets it through a mailed link
+	ErrPasswordResetRequired = sharedEntities.DomainError{Message: "password reset required; reset it through the password reset link"}
+	ErrSelfModeration        = sharedEntities.DomainError{Message: "admins cannot suspend, demote or delete themselves"}
+	ErrUserNotDeleted        = sharedEntities.DomainError{Message: "user is not deleted"}
+	// ErrEmailUndeliverable is returned when mailing an email that bounced or got a complaint
+	ErrEmailUndeliverable = sharedEntities.DomainError{Message: "mail to this email is undeliverable; change the email first"}
 )
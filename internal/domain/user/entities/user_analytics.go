@@ -0,0 +1,114 @@
+package entities
+
+import (
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// Bounds of the analytics
+const (
+	// DefaultAnalyticsDays is the span of a range given no start, ending today
+	DefaultAnalyticsDays = 30
+	// MaxAnalyticsDays bounds a range, so a report reads at most a year of rows
+	MaxAnalyticsDays = 366
+	// DefaultRetentionWeeks and MaxRetentionWeeks bound how many weeks cohorts are followed
+	// for; sign-ins older than the audit trail's retention are gone
+	DefaultRetentionWeeks = 8
+	MaxRetentionWeeks     = 12
+)
+
+// AnalyticsDateLayout formats the days of the analytics
+const AnalyticsDateLayout = "2006-01-02"
+
+var (
+	// ErrInvalidAnalyticsRange is returned for a range ending before it starts or too long
+	ErrInvalidAnalyticsRange = sharedEntities.DomainError{Message: "analytics range must not end before it starts nor span over 366 days"}
+	// ErrInvalidRetentionWeeks is returned for a number of weeks out of bounds
+	ErrInvalidRetentionWeeks = sharedEntities.DomainError{Message: "retention weeks must be between 1 and 12"}
+)
+
+// AnalyticsRange is the days analytics cover, from the start of From up to To excluded,
+// both midnight UTC
+type AnalyticsRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// NewAnalyticsRange creates the range of the days from through to, both included; a zero
+// to is today and a zero from DefaultAnalyticsDays before to
+func NewAnalyticsRange(from, to time.Time) (AnalyticsRange, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	end := to.UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	start := end.AddDate(0, 0, -DefaultAnalyticsDays)
+	if !from.IsZero() {
+		start = from.UTC().Truncate(24 * time.Hour)
+	}
+	if !start.Before(end) || start.AddDate(0, 0, MaxAnalyticsDays).Before(end) {
+		return AnalyticsRange{}, ErrInvalidAnalyticsRange
+	}
+	return AnalyticsRange{From: start, To: end}, nil
+}
+
+// Days lists the days of the range in order, as YYYY-MM-DD
+func (r AnalyticsRange) Days() []string {
+	var days []string
+	for day := r.From; day.Before(r.To); day = day.AddDate(0, 0, 1) {
+		days = append(days, day.Format(AnalyticsDateLayout))
+	}
+	return days
+}
+
+// UserTotals counts the users of the tenant as they are now
+type UserTotals struct {
+	// Live users are those not deleted, counted by status and by role
+	Live     int64
+	ByStatus map[Status]int64
+	ByRole   map[Role]int64
+	// Deleted users are soft-deleted and not purged yet
+	Deleted int64
+}
+
+// DailySignups is how many users were created and deleted on a day
+type DailySignups struct {
+	Date      string
+	Signups   int64
+	Deletions int64
+}
+
+// DailyActivity is how many users signed in on a day, how often, and how many sign-ins failed
+type DailyActivity struct {
+	Date          string
+	ActiveUsers   int64
+	SignIns       int64
+	FailedSignIns int64
+}
+
+// UserStats are the totals of users with their signups and deletions on each day of a range
+type UserStats struct {
+	Totals UserTotals
+	Range  AnalyticsRange
+	Days   []DailySignups
+}
+
+// RetentionCohort is the users who signed up in a week, starting on Monday, and how many
+// of them signed in during each week since, the first being the one they signed up in
+type RetentionCohort struct {
+	Week     string
+	Users    int64
+	Retained []int64
+}
+
+// Rates are the shares of the cohort retained each week, 0 for an empty cohort
+func (c RetentionCohort) Rates() []float64 {
+	rates := make([]float64, len(c.Retained))
+	if c.Users == 0 {
+		return rates
+	}
+	for i, retained := range c.Retained {
+		rates[i] = float64(retained) / float64(c.Users)
+	}
+	return rates
+}
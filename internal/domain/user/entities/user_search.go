@@ -0,0 +1,33 @@
+package entities
+
+import (
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/search"
+)
+
+// MaxSearchTerms caps the words a user search matches; the others are ignored
+const MaxSearchTerms = search.MaxTerms
+
+// ErrInvalidSearchQuery is returned for search queries without a word to match
+var ErrInvalidSearchQuery = sharedEntities.DomainError{Message: "search query needs at least one word of letters or digits"}
+
+// UserSearchHit is a user matching a search with its relevance, higher first
+type UserSearchHit struct {
+	User  *User
+	Score float64
+}
+
+// SearchTerms splits a search query into the lower case words users must match, each as
+// the prefix of a word of their name or email (see search.Terms)
+func SearchTerms(query string) ([]string, error) {
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		return nil, ErrInvalidSearchQuery
+	}
+	return terms, nil
+}
+
+// SearchWords splits text into its words of letters and digits, as searches match them
+func SearchWords(text string) []string {
+	return search.Words(text)
+}
@@ -0,0 +1,222 @@
+package events
+
+import (
+	"strconv"
+	"time"
+
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+)
+
+// Names of the user events
+const (
+	UserRegisteredEventName         = "user.registered"
+	UserLoggedInEventName           = "user.logged_in"
+	UserProfileUpdatedEventName     = "user.profile_updated"
+	UserEmailUndeliverableEventName = "user.email_undeliverable"
+	UserSMSConsentChangedEventName  = "user.sms_consent_changed"
+)
+
+// UserRegisteredEvent is published when a user signs up
+type UserRegisteredEvent struct {
+	UserID     uint
+	occurredOn time.Time
+}
+
+// NewUserRegisteredEvent creates the event for a user who was just created
+func NewUserRegisteredEvent(user *userEntities.User) UserRegisteredEvent {
+	return UserRegisteredEvent{
+		UserID:     user.ID,
+		occurredOn: user.CreatedAt,
+	}
+}
+
+// EventName returns the event name
+func (e UserRegisteredEvent) EventName() string {
+	return UserRegisteredEventName
+}
+
+// OccurredOn returns when the user signed up
+func (e UserRegisteredEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+// EventData returns the event payload
+func (e UserRegisteredEvent) EventData() interface{} {
+	return map[string]interface{}{
+		"user_id": e.UserID,
+	}
+}
+
+// EventSubject returns the user the event is about
+func (e UserRegisteredEvent) EventSubject() string {
+	return userSubject(e.UserID)
+}
+
+// UserLoggedInEvent is published by the login flow whenever a user signs in
+type UserLoggedInEvent struct {
+	UserID uint
+	// IPAddress and UserAgent identify where the sign-in came from
+	IPAddress  string
+	UserAgent  string
+	occurredOn time.Time
+}
+
+// NewUserLoggedInEvent creates the event for a sign-in that just happened
+func NewUserLoggedInEvent(userID uint, ipAddress, userAgent string) UserLoggedInEvent {
+	return UserLoggedInEvent{
+		UserID:     userID,
+		IPAddress:  ipAddress,
+		UserAgent:  userAgent,
+		occurredOn: time.Now(),
+	}
+}
+
+// EventName returns the event name
+func (e UserLoggedInEvent) EventName() string {
+	return UserLoggedInEventName
+}
+
+// OccurredOn returns when the user signed in
+func (e UserLoggedInEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+// EventData returns the event payload
+func (e UserLoggedInEvent) EventData() interface{} {
+	return map[string]interface{}{
+		"user_id":    e.UserID,
+		"ip_address": e.IPAddress,
+		"user_agent": e.UserAgent,
+	}
+}
+
+// EventSubject returns the user the event is about
+func (e UserLoggedInEvent) EventSubject() string {
+	return userSubject(e.UserID)
+}
+
+// UserProfileUpdatedEvent is published when a user's profile changes, other than by an admin
+// whose changes go to the audit log
+type UserProfileUpdatedEvent struct {
+	UserID uint
+	// Fields names the changed profile fields, e.g. email or avatar; never their values
+	Fields     []string
+	occurredOn time.Time
+}
+
+// NewUserProfileUpdatedEvent creates the event for a profile change that was just saved
+func NewUserProfileUpdatedEvent(user *userEntities.User, fields []string) UserProfileUpdatedEvent {
+	return UserProfileUpdatedEvent{
+		UserID:     user.ID,
+		Fields:     fields,
+		occurredOn: user.UpdatedAt,
+	}
+}
+
+// EventName returns the event name
+func (e UserProfileUpdatedEvent) EventName() string {
+	return UserProfileUpdatedEventName
+}
+
+// OccurredOn returns when the profile changed
+func (e UserProfileUpdatedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+// EventData returns the event payload
+func (e UserProfileUpdatedEvent) EventData() interface{} {
+	return map[string]interface{}{
+		"user_id": e.UserID,
+		"fields":  e.Fields,
+	}
+}
+
+// EventSubject returns the user the event is about
+func (e UserProfileUpdatedEvent) EventSubject() string {
+	return userSubject(e.UserID)
+}
+
+// UserEmailUndeliverableEvent is published when a user's email is marked undeliverable
+type UserEmailUndeliverableEvent struct {
+	UserID uint
+	// Reason is the kind of feedback, bounce or complaint, and the provider's detail
+	Reason     string
+	occurredOn time.Time
+}
+
+// NewUserEmailUndeliverableEvent creates the event for a user marked undeliverable at at
+func NewUserEmailUndeliverableEvent(userID uint, reason string, at time.Time) UserEmailUndeliverableEvent {
+	return UserEmailUndeliverableEvent{
+		UserID:     userID,
+		Reason:     reason,
+		occurredOn: at,
+	}
+}
+
+// EventName returns the event name
+func (e UserEmailUndeliverableEvent) EventName() string {
+	return UserEmailUndeliverableEventName
+}
+
+// OccurredOn returns when the email was marked undeliverable
+func (e UserEmailUndeliverableEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+// EventData returns the event payload
+func (e UserEmailUndeliverableEvent) EventData() interface{} {
+	return map[string]interface{}{
+		"user_id": e.UserID,
+		"reason":  e.Reason,
+	}
+}
+
+// EventSubject returns the user the event is about
+func (e UserEmailUndeliverableEvent) EventSubject() string {
+	return userSubject(e.UserID)
+}
+
+// UserSMSConsentChangedEvent is published when a user's phone opts out of texts or back in
+type UserSMSConsentChangedEvent struct {
+	UserID     uint
+	OptedOut   bool
+	occurredOn time.Time
+}
+
+// NewUserSMSConsentChangedEvent creates the event for a user whose phone opted out, or in
+// again, at at
+func NewUserSMSConsentChangedEvent(userID uint, optedOut bool, at time.Time) UserSMSConsentChangedEvent {
+	return UserSMSConsentChangedEvent{
+		UserID:     userID,
+		OptedOut:   optedOut,
+		occurredOn: at,
+	}
+}
+
+// EventName returns the event name
+func (e UserSMSConsentChangedEvent) EventName() string {
+	return UserSMSConsentChangedEventName
+}
+
+// OccurredOn returns when the reply was received
+func (e UserSMSConsentChangedEvent) OccurredOn() time.Time {
+	return e.occurredOn
+}
+
+// EventData returns the event payload
+func (e UserSMSConsentChangedEvent) EventData() interface{} {
+	return map[string]interface{}{
+		"user_id":   e.UserID,
+		"opted_out": e.OptedOut,
+	}
+}
+
+// EventSubject returns the user the event is about
+func (e UserSMSConsentChangedEvent) EventSubject() string {
+	return userSubject(e.UserID)
+}
+
+// userSubject is the subject of events about a user, e.g. "users/42"
+func userSubject(userID uint) string {
+	return "users/" + strconv.FormatUint(uint64(userID), 10)
+}
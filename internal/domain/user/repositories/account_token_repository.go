@@ -0,0 +1,21 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=account_token_repository.go -destination=../../../mocks/account_token_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// AccountTokenRepository defines the contract for account token persistence
+type AccountTokenRepository interface {
+	// Create stores a token and assigns its ID
+	Create(ctx context.Context, token *entities.AccountToken) error
+	// Consume marks the unused, unexpired token of purpose with tokenHash used and returns
+	// it; entities.ErrInvalidAccountToken otherwise. A token is consumed once even when
+	// redeemed concurrently
+	Consume(ctx context.Context, purpose entities.AccountTokenPurpose, tokenHash string) (*entities.AccountToken, error)
+	// RevokeAll marks the user's unused tokens of purpose used
+	RevokeAll(ctx context.Context, userID uint, purpose entities.AccountTokenPurpose) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=activity_repository.go -destination=../../../mocks/activity_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// ActivityRepository defines the contract for user activity persistence
+// Activities are only ever appended
+type ActivityRepository interface {
+	Create(ctx context.Context, activity *entities.Activity) error
+	// ListByUser returns a page of the user's activities, most recent first
+	ListByUser(ctx context.Context, userID uint, limit, offset int) ([]*entities.Activity, error)
+	CountByUser(ctx context.Context, userID uint) (int64, error)
+	// ListFollowedBy returns a page of the activities of the types by the users the follower
+	// follows, most recent first
+	ListFollowedBy(ctx context.Context, followerID uint, types []string, limit, offset int) ([]*entities.Activity, error)
+	CountFollowedBy(ctx context.Context, followerID uint, types []string) (int64, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=audit_repository.go -destination=../../../mocks/audit_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// AuditRepository defines the contract for the append-only audit log of admin actions
+type AuditRepository interface {
+	Create(ctx context.Context, entry *entities.AuditEntry) error
+	// ListByTarget returns a page of the actions on a user, newest first
+	ListByTarget(ctx context.Context, userID uint, limit, offset int) ([]*entities.AuditEntry, error)
+	CountByTarget(ctx context.Context, userID uint) (int64, error)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=auth_event_repository.go -destination=../../../mocks/auth_event_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// AuthEventRepository defines the contract for the authentication audit trail
+// Events are only ever appended, and deleted once past retention
+type AuthEventRepository interface {
+	Create(ctx context.Context, event *entities.AuthEvent) error
+	// List returns a page of the events matching filter, most recent first
+	List(ctx context.Context, filter entities.AuthEventFilter, limit, offset int) ([]*entities.AuthEvent, error)
+	Count(ctx context.Context, filter entities.AuthEventFilter) (int64, error)
+}
@@ -0,0 +1,24 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=connection_repository.go -destination=../../../mocks/connection_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// ConnectionRepository defines the contract for persisting who follows whom
+// Lists and counts leave out deleted users
+type ConnectionRepository interface {
+	// Create stores a connection and assigns its ID; storing one that exists does nothing
+	Create(ctx context.Context, connection *entities.Connection) error
+	// Delete removes the connection of follower following followee and reports whether there
+	// was one
+	Delete(ctx context.Context, followerID, followeeID uint) (bool, error)
+	Exists(ctx context.Context, followerID, followeeID uint) (bool, error)
+	Counts(ctx context.Context, userID uint) (entities.ConnectionCounts, error)
+	// List returns a page of the users on the side of the user's connections, most recently
+	// connected first
+	List(ctx context.Context, userID uint, side entities.ConnectionType, limit, offset int) ([]*entities.User, error)
+}
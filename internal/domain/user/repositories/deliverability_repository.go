@@ -0,0 +1,16 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=deliverability_repository.go -destination=../../../mocks/deliverability_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+)
+
+// DeliverabilityRepository defines the contract for recording undeliverable user emails
+type DeliverabilityRepository interface {
+	// MarkUndeliverable flags email undeliverable at at for reason on the users having it, in
+	// every tenant for a context acting for none, and returns the IDs of the users that were
+	// not flagged already
+	MarkUndeliverable(ctx context.Context, email, reason string, at time.Time) ([]uint, error)
+}
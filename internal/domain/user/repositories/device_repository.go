@@ -0,0 +1,22 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=device_repository.go -destination=../../../mocks/device_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// DeviceRepository defines the contract for the devices users get push notifications on
+type DeviceRepository interface {
+	// Register stores the device, taking its token over from any device registered with it
+	// before, and forgets the user's devices registered longest ago beyond keep
+	Register(ctx context.Context, device *entities.Device, keep int) error
+	// ListByUser returns the user's devices, most recently registered first
+	ListByUser(ctx context.Context, userID uint) ([]*entities.Device, error)
+	// Delete returns entities.ErrDeviceNotFound unless the device is the user's
+	Delete(ctx context.Context, userID, id uint) error
+	// DeleteByToken forgets the device with token, if any
+	DeleteByToken(ctx context.Context, token string) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=digest_repository.go -destination=../../../mocks/digest_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// DigestRepository defines the contract for the notifications waiting for a digest
+type DigestRepository interface {
+	Add(ctx context.Context, item *entities.DigestItem) error
+	// PendingUsers returns the users with items waiting, in ID order
+	PendingUsers(ctx context.Context) ([]uint, error)
+	// ListByUser returns the user's pending items, oldest first
+	ListByUser(ctx context.Context, userID uint) ([]*entities.DigestItem, error)
+	// DeleteThrough deletes the user's items up to and including lastID, keeping those added
+	// since they were listed
+	DeleteThrough(ctx context.Context, userID, lastID uint) error
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// CheckEmailAvailable rejects giving user an email another user has, or a new user when
+// user is nil; keeping an unchanged or empty email always passes
+// It is a fast path for a friendly error: the unique index still decides between concurrent
+// changes, which every UserRepository reports as ErrEmailExists too
+func CheckEmailAvailable(ctx context.Context, userRepo UserRepository, user *entities.User, email string) error {
+	if email == "" || (user != nil && email == user.Email) {
+		return nil
+	}
+	existing, err := userRepo.GetByEmail(ctx, email)
+	if err == entities.ErrUserNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user == nil || existing.ID != user.ID {
+		return entities.ErrEmailExists
+	}
+	return nil
+}
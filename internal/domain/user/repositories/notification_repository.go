@@ -0,0 +1,26 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=notification_repository.go -destination=../../../mocks/notification_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/shared/pagination"
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// NotificationRepository defines the contract for user notification persistence
+// Every lookup is scoped to a user, so a notification of another user is simply not found
+// The counts of each inbox are kept up to date by every change, so reading them is cheap
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *entities.Notification) error
+	// ListByUser returns the limit notifications of the user before cursor, newest first;
+	// the zero cursor starts from the newest
+	ListByUser(ctx context.Context, userID uint, unreadOnly bool, cursor pagination.Cursor, limit int) ([]*entities.Notification, error)
+	CountByUser(ctx context.Context, userID uint) (entities.NotificationCounts, error)
+	// MarkAsRead returns entities.ErrNotificationNotFound unless the notification is the user's
+	MarkAsRead(ctx context.Context, userID, id uint) (*entities.Notification, error)
+	// MarkAllAsRead returns how many notifications were unread
+	MarkAllAsRead(ctx context.Context, userID uint) (int64, error)
+	Delete(ctx context.Context, userID, id uint) error
+}
@@ -0,0 +1,17 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=preferences_repository.go -destination=../../../mocks/preferences_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// PreferencesRepository defines the contract for user preferences persistence
+type PreferencesRepository interface {
+	// Get returns entities.ErrUserPreferencesNotFound for a user who never saved any
+	Get(ctx context.Context, userID uint) (*entities.UserPreferences, error)
+	// Save creates or replaces the preferences of prefs.UserID
+	Save(ctx context.Context, prefs *entities.UserPreferences) error
+}
@@ -0,0 +1,31 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=session_repository.go -destination=../../../mocks/session_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// SessionRepository defines the contract for session persistence
+// Sessions are looked up by token hash on every authenticated request, so implementations
+// must answer GetByTokenHash from an index or a key
+type SessionRepository interface {
+	// Create stores a session and assigns its ID
+	Create(ctx context.Context, session *entities.Session) error
+	// GetByTokenHash returns entities.ErrSessionNotFound for unknown tokens; a revoked
+	// session is either returned revoked or not found
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entities.Session, error)
+	// ListByUser returns the user's sessions that are neither revoked nor expired, most
+	// recently used first
+	ListByUser(ctx context.Context, userID uint) ([]*entities.Session, error)
+	// Touch records that the session was used at seenAt
+	Touch(ctx context.Context, id uint, seenAt time.Time) error
+	// Revoke returns entities.ErrSessionNotFound unless the session is the user's and active
+	Revoke(ctx context.Context, userID, id uint) error
+	// RevokeAll revokes the user's active sessions except exceptID, 0 for none, and returns
+	// how many were revoked
+	RevokeAll(ctx context.Context, userID, exceptID uint) (int64, error)
+}
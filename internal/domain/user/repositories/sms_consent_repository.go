@@ -0,0 +1,16 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=sms_consent_repository.go -destination=../../../mocks/sms_consent_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+)
+
+// SMSConsentRepository defines the contract for recording the phones that opted out of texts
+type SMSConsentRepository interface {
+	// SetOptedOut marks phone opted out at at, or opted in again when at is nil, on the users
+	// having it, in every tenant for a context acting for none, and returns the IDs of the
+	// users whose consent changed
+	SetOptedOut(ctx context.Context, phone string, at *time.Time) ([]uint, error)
+}
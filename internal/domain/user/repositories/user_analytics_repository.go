@@ -0,0 +1,27 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=user_analytics_repository.go -destination=../../../mocks/user_analytics_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// UserAnalyticsRepository aggregates users and their sign-ins for the admin analytics;
+// every figure is grouped in the database rather than counted row by row
+type UserAnalyticsRepository interface {
+	// Totals counts the live users by status and role, and the deleted ones
+	Totals(ctx context.Context) (*entities.UserTotals, error)
+	// Signups counts the users created and deleted on each day of r; days with neither
+	// are left out
+	Signups(ctx context.Context, r entities.AnalyticsRange) ([]entities.DailySignups, error)
+	// Activity counts the users signing in, their sign-ins and the failed ones on each day
+	// of r; days without any are left out
+	Activity(ctx context.Context, r entities.AnalyticsRange) ([]entities.DailyActivity, error)
+	// Retention returns the cohorts of the users who signed up in r by week, oldest first,
+	// with how many of them signed in during each of their first weeks weeks, up to the
+	// week of until
+	Retention(ctx context.Context, r entities.AnalyticsRange, weeks int, until time.Time) ([]entities.RetentionCohort, error)
+}
@@ -1,6 +1,12 @@
 package repositories
 
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=user_repository.go -destination=../../../mocks/user_repository_mock.go -package=mocks
+
 import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/pagination"
 	"clean-arch-gin/internal/domain/user/entities"
 )
 
@@ -8,16 +14,45 @@ import (
 // This interface belongs to the domain layer and is implemented by the infrastructure layer
 type UserRepository interface {
 	// Basic CRUD operations
-	Create(user *entities.User) error
-	GetByID(id uint) (*entities.User, error)
-	GetByEmail(email string) (*entities.User, error)
-	GetAll(limit, offset int) ([]*entities.User, error)
-	Update(user *entities.User) error
-	Delete(id uint) error
-	Count() (int64, error)
+	Create(ctx context.Context, user *entities.User) error
+	GetByID(ctx context.Context, id uint) (*entities.User, error)
+	// GetByPublicID finds a user by the identifier it is exposed by, whether or not it is soft
+	// deleted so admins can restore it by that identifier
+	GetByPublicID(ctx context.Context, publicID string) (*entities.User, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]*entities.User, error)
+	GetByEmail(ctx context.Context, email string) (*entities.User, error)
+	GetAll(ctx context.Context, limit, offset int) ([]*entities.User, error)
+	// ListAfter returns up to limit users after cursor in created_at, id order; a page
+	// resumes from the cursor of the last user of the previous one instead of an offset
+	ListAfter(ctx context.Context, cursor pagination.Cursor, limit int) ([]*entities.User, error)
+	Update(ctx context.Context, user *entities.User) error
+	Delete(ctx context.Context, id uint) error
+	Count(ctx context.Context) (int64, error)
+
+	// Batch writes for bulk imports; size users go per statement or transaction. A batch
+	// that fails is retried a user at a time, and the users that still fail are named by a
+	// *BatchError from the shared entities while the others are written
+	CreateInBatches(ctx context.Context, users []*entities.User, size int) error
+	UpdateInBatches(ctx context.Context, users []*entities.User, size int) error
+	// Upsert inserts the user or, when the tenant has one with its email, deleted or not,
+	// overwrites that one's other fields; either way the user gets the stored ID and public ID.
+	// It reports whether the user was inserted, for idempotent syncs keyed by email
+	Upsert(ctx context.Context, user *entities.User) (created bool, err error)
+
+	// Soft-deleted users; the other methods only see them with a context from
+	// softdelete.WithDeleted or softdelete.OnlyDeleted
+	// GetByIDIncludingDeleted finds a user whether or not it is soft deleted
+	GetByIDIncludingDeleted(ctx context.Context, id uint) (*entities.User, error)
+	// Restore undeletes a soft-deleted user
+	Restore(ctx context.Context, id uint) error
+	// Purge permanently deletes a user, deleted or not, with its orders, notifications,
+	// preferences, activity, sessions and auth events; the audit log is kept
+	Purge(ctx context.Context, id uint) error
+	// PurgeOlderThan purges the users soft deleted more than age ago like Purge does
+	PurgeOlderThan(ctx context.Context, age time.Duration) (int64, error)
 
 	// Advanced query methods (enabled by GORM Gen)
-	GetUsersByEmailDomain(domain string) ([]*entities.User, error)
-	GetActiveUsers() ([]*entities.User, error)
-	GetUsersWithFilters(limit, offset int, email, name string) ([]*entities.User, error)
+	GetUsersByEmailDomain(ctx context.Context, domain string) ([]*entities.User, error)
+	GetActiveUsers(ctx context.Context) ([]*entities.User, error)
+	GetUsersWithFilters(ctx context.Context, limit, offset int, email, name string) ([]*entities.User, error)
 }
@@ -0,0 +1,16 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=user_search_repository.go -destination=../../../mocks/user_search_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// UserSearchRepository defines the contract for full-text user search
+type UserSearchRepository interface {
+	// Search returns a page of the users whose name or email has a word starting with every
+	// term, most relevant first, and their total
+	Search(ctx context.Context, terms []string, limit, offset int) ([]*entities.UserSearchHit, int64, error)
+}
@@ -0,0 +1,27 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=account_usecase.go -destination=../../../mocks/account_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// AccountUseCase defines the account recovery and email verification flows, which mail the
+// user a link carrying a single-use token
+type AccountUseCase interface {
+	// RequestPasswordReset mails a password reset link to the account with email; unknown
+	// emails, suspended accounts and undeliverable emails succeed alike without mailing
+	// anything, so the response does not reveal accounts
+	RequestPasswordReset(ctx context.Context, email string) error
+	// ResetPassword sets the password of the token's user, signing them out everywhere and
+	// invalidating their other reset links; bad tokens fail with entities.ErrInvalidAccountToken
+	ResetPassword(ctx context.Context, token, password string) error
+	// SendVerification mails a link confirming the user's current email; it is a no-op for
+	// verified emails and fails with entities.ErrEmailUndeliverable for undeliverable ones
+	SendVerification(ctx context.Context, userID uint) error
+	// VerifyEmail confirms the email the token was mailed to, unless the user changed it
+	// since, and returns the user
+	VerifyEmail(ctx context.Context, token string) (*entities.User, error)
+}
@@ -0,0 +1,17 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=activity_usecase.go -destination=../../../mocks/activity_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// ActivityUseCase defines the business logic operations for user activity feeds
+type ActivityUseCase interface {
+	// Record adds an activity to its user's feed
+	Record(ctx context.Context, activity *entities.Activity) error
+	// ListActivity returns a page of the user's activities, most recent first, and their total
+	ListActivity(ctx context.Context, userID uint, limit, offset int) ([]*entities.Activity, int64, error)
+}
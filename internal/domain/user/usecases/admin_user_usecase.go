@@ -0,0 +1,29 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=admin_user_usecase.go -destination=../../../mocks/admin_user_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/shared/authz"
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// AdminUserUseCase defines the account management operations of admins
+// Every change must be allowed on users/<id> by the policy and is recorded in the audit
+// log under the acting admin; admins cannot suspend, demote or delete their own account
+type AdminUserUseCase interface {
+	UpdateUser(ctx context.Context, actor authz.Actor, id uint, email, name string) (*entities.User, error)
+	DeleteUser(ctx context.Context, actor authz.Actor, id uint, reason string) error
+	// RestoreUser undeletes a soft-deleted user; entities.ErrUserNotDeleted when it is not deleted
+	RestoreUser(ctx context.Context, actor authz.Actor, id uint, reason string) (*entities.User, error)
+	// PurgeUser permanently deletes a user, soft deleted or not, with its data; the audit
+	// log of the account is kept
+	PurgeUser(ctx context.Context, actor authz.Actor, id uint, reason string) error
+	SetStatus(ctx context.Context, actor authz.Actor, id uint, status entities.Status, reason string) (*entities.User, error)
+	SetRole(ctx context.Context, actor authz.Actor, id uint, role entities.Role, reason string) (*entities.User, error)
+	// ForcePasswordReset makes the user change their password before going on
+	ForcePasswordReset(ctx context.Context, actor authz.Actor, id uint, reason string) (*entities.User, error)
+	// AuditLog returns a page of the actions on a user, newest first, and their total
+	AuditLog(ctx context.Context, id uint, limit, offset int) ([]*entities.AuditEntry, int64, error)
+}
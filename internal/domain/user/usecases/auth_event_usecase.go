@@ -0,0 +1,17 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=auth_event_usecase.go -destination=../../../mocks/auth_event_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// AuthEventUseCase defines the business logic operations for reading the authentication
+// audit trail; events are recorded by the use cases they happen in
+type AuthEventUseCase interface {
+	// ListAuthEvents returns a page of the events matching filter, most recent first, and
+	// their total
+	ListAuthEvents(ctx context.Context, filter entities.AuthEventFilter, limit, offset int) ([]*entities.AuthEvent, int64, error)
+}
@@ -0,0 +1,17 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=avatar_usecase.go -destination=../../../mocks/avatar_usecase_mock.go -package=mocks
+
+import (
+	"context"
+	"io"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// AvatarUseCase defines the business logic operations for profile pictures
+type AvatarUseCase interface {
+	// UploadAvatar validates and resizes an uploaded image, stores it as the user's avatar
+	// and returns the user with the new avatar URL
+	UploadAvatar(ctx context.Context, userID uint, image io.Reader) (*entities.User, error)
+}
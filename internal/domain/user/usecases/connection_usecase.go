@@ -0,0 +1,27 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=connection_usecase.go -destination=../../../mocks/connection_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// ConnectionUseCase defines the business logic operations for users following each other
+// and the timelines of the activity of the users they follow
+type ConnectionUseCase interface {
+	// Follow makes the follower follow the followee; following again does nothing
+	Follow(ctx context.Context, followerID, followeeID uint) error
+	// Unfollow makes the follower stop following the followee; it is not an error when it did
+	// not
+	Unfollow(ctx context.Context, followerID, followeeID uint) error
+	// IsFollowing reports whether the follower follows the followee
+	IsFollowing(ctx context.Context, followerID, followeeID uint) (bool, error)
+	// ListConnections returns a page of the users on the side of the user's connections, most
+	// recently connected first, with the user's counts
+	ListConnections(ctx context.Context, userID uint, side entities.ConnectionType, limit, offset int) ([]*entities.User, entities.ConnectionCounts, error)
+	// GetTimeline returns a page of the shared activity of the users the user follows, most
+	// recent first, and its total
+	GetTimeline(ctx context.Context, userID uint, limit, offset int) ([]*entities.TimelineEntry, int64, error)
+}
@@ -0,0 +1,16 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=deliverability_usecase.go -destination=../../../mocks/deliverability_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/shared/mail"
+)
+
+// DeliverabilityUseCase keeps track of the user emails mail providers report undeliverable
+type DeliverabilityUseCase interface {
+	// RecordFeedback marks the email of each bounce and complaint undeliverable on every user
+	// having it, whatever their tenant; emails no user has are ignored
+	RecordFeedback(ctx context.Context, feedback []mail.Feedback) error
+}
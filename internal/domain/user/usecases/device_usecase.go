@@ -0,0 +1,24 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=device_usecase.go -destination=../../../mocks/device_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// DeviceUseCase manages the devices users get push notifications on
+type DeviceUseCase interface {
+	// RegisterDevice registers the push token of one of the user's devices; registering a
+	// token again refreshes it, and the user's oldest devices beyond entities.MaxDevices are
+	// forgotten
+	RegisterDevice(ctx context.Context, userID uint, token string, platform entities.DevicePlatform, name string) (*entities.Device, error)
+	// ListDevices returns the user's devices, most recently registered first
+	ListDevices(ctx context.Context, userID uint) ([]*entities.Device, error)
+	// RemoveDevice forgets one of the user's devices
+	RemoveDevice(ctx context.Context, userID, id uint) error
+	// ForgetToken forgets the device with token, e.g. when the push provider no longer
+	// accepts it
+	ForgetToken(ctx context.Context, token string) error
+}
@@ -0,0 +1,23 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=notification_usecase.go -destination=../../../mocks/notification_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/shared/pagination"
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// NotificationUseCase defines the business logic operations for user notifications
+type NotificationUseCase interface {
+	// Notify adds a notification to a user's inbox
+	Notify(ctx context.Context, userID uint, notificationType, title, body string, data map[string]interface{}) (*entities.Notification, error)
+	// ListNotifications returns the page of the user's notifications after cursor, newest
+	// first, and their inbox counts
+	ListNotifications(ctx context.Context, userID uint, unreadOnly bool, cursor pagination.Cursor, limit int) ([]*entities.Notification, entities.NotificationCounts, error)
+	UnreadCount(ctx context.Context, userID uint) (int64, error)
+	MarkAsRead(ctx context.Context, userID, id uint) (*entities.Notification, error)
+	MarkAllAsRead(ctx context.Context, userID uint) (int64, error)
+	DeleteNotification(ctx context.Context, userID, id uint) error
+}
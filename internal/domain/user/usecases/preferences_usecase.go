@@ -0,0 +1,17 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=preferences_usecase.go -destination=../../../mocks/preferences_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// PreferencesUseCase defines the business logic operations for user preferences
+type PreferencesUseCase interface {
+	// GetPreferences returns the user's preferences, or the defaults if they set none
+	GetPreferences(ctx context.Context, userID uint) (*entities.UserPreferences, error)
+	// UpdatePreferences validates and applies a partial update
+	UpdatePreferences(ctx context.Context, userID uint, update entities.PreferencesUpdate) (*entities.UserPreferences, error)
+}
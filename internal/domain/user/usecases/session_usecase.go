@@ -0,0 +1,34 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=session_usecase.go -destination=../../../mocks/session_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// SessionUseCase defines the business logic operations for server-side sessions
+// Sign-ins, failed or not, and sign-outs are recorded in the authentication audit trail,
+// with the client of clientinfo.FromContext for those not given one
+type SessionUseCase interface {
+	// Login verifies the credentials and starts a session on the device, returning it with
+	// its bearer token; the token is only ever available here
+	Login(ctx context.Context, email, password, userAgent, ipAddress string) (*entities.Session, string, error)
+	// Authenticate resolves a bearer token to its active session; revoked and expired
+	// sessions are rejected with entities.ErrSessionRevoked and entities.ErrSessionExpired
+	Authenticate(ctx context.Context, token string) (*entities.Session, error)
+	// Roles returns the roles the user acts with, the role stored on their account; a user
+	// that no longer exists has none
+	Roles(ctx context.Context, userID uint) ([]string, error)
+	// CheckAccount returns why the user may not act, entities.ErrUserSuspended or
+	// entities.ErrPasswordResetRequired, or nil
+	CheckAccount(ctx context.Context, userID uint) error
+	ListSessions(ctx context.Context, userID uint) ([]*entities.Session, error)
+	// Logout revokes the session the user signs out of
+	Logout(ctx context.Context, userID, sessionID uint) error
+	RevokeSession(ctx context.Context, userID, id uint) error
+	// RevokeAllSessions revokes every session of the user except exceptID, 0 for none, and
+	// returns how many were revoked
+	RevokeAllSessions(ctx context.Context, userID, exceptID uint) (int64, error)
+}
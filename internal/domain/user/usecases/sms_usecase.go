@@ -0,0 +1,23 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=sms_usecase.go -destination=../../../mocks/sms_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/shared/sms"
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// SMSUseCase manages the phones users are texted at and whether they may be
+type SMSUseCase interface {
+	// SetPhone sets the user's phone, normalized to E.164, or removes it when phone is empty;
+	// entities.ErrInvalidPhone for numbers without a country code
+	SetPhone(ctx context.Context, userID uint, phone string) (*entities.User, error)
+	// RecordReplies opts the phone of each STOP reply out of texts and that of each START
+	// reply back in, on every user having it whatever their tenant; other replies and
+	// phones no user has are ignored
+	RecordReplies(ctx context.Context, replies []sms.Reply) error
+	// OptOut opts phone out of texts, e.g. when the provider refuses to text it
+	OptOut(ctx context.Context, phone string) error
+}
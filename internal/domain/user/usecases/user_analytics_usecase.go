@@ -0,0 +1,21 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=user_analytics_usecase.go -destination=../../../mocks/user_analytics_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// UserAnalyticsUseCase defines the admin analytics of the users of a tenant
+type UserAnalyticsUseCase interface {
+	// GetStats returns the totals of users with their signups and deletions on every day
+	// of r, quiet days included
+	GetStats(ctx context.Context, r entities.AnalyticsRange) (*entities.UserStats, error)
+	// GetActivity returns the sign-ins of every day of r, quiet days included
+	GetActivity(ctx context.Context, r entities.AnalyticsRange) ([]entities.DailyActivity, error)
+	// GetRetention returns the weekly cohorts of the users who signed up in r, followed for
+	// weeks weeks, DefaultRetentionWeeks when 0
+	GetRetention(ctx context.Context, r entities.AnalyticsRange, weeks int) ([]entities.RetentionCohort, error)
+}
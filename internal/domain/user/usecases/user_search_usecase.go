@@ -0,0 +1,16 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=user_search_usecase.go -destination=../../../mocks/user_search_usecase_mock.go -package=mocks
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/user/entities"
+)
+
+// UserSearchUseCase defines the business logic operations for searching users
+type UserSearchUseCase interface {
+	// SearchUsers returns a page of the users matching query, most relevant first, their
+	// total and the terms they matched; entities.ErrInvalidSearchQuery without any
+	SearchUsers(ctx context.Context, query string, limit, offset int) ([]*entities.UserSearchHit, int64, []string, error)
+}
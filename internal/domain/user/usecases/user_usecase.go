@@ -1,15 +1,21 @@
 package usecases
 
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=user_usecase.go -destination=../../../mocks/user_usecase_mock.go -package=mocks
+
 import (
+	"context"
+
 	"clean-arch-gin/internal/domain/user/entities"
 )
 
 // UserUseCase defines the business logic operations for users
 // This interface belongs to the domain layer
 type UserUseCase interface {
-	CreateUser(email, name, password string) (*entities.User, error)
-	GetUser(id uint) (*entities.User, error)
-	GetUsers(limit, offset int) ([]*entities.User, error)
-	UpdateUser(id uint, email, name string) (*entities.User, error)
-	DeleteUser(id uint) error
+	CreateUser(ctx context.Context, email, name, password string) (*entities.User, error)
+	GetUser(ctx context.Context, id uint) (*entities.User, error)
+	// GetUserByPublicID finds a user by the identifier it is exposed by in routes
+	GetUserByPublicID(ctx context.Context, publicID string) (*entities.User, error)
+	GetUsers(ctx context.Context, limit, offset int) ([]*entities.User, error)
+	UpdateUser(ctx context.Context, id uint, email, name string) (*entities.User, error)
+	DeleteUser(ctx context.Context, id uint) error
 }
@@ -0,0 +1,196 @@
+package entities
+
+import (
+	"net/url"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// AllEvents subscribes an endpoint to every event
+const AllEvents = "*"
+
+// PayloadFormat selects how events are encoded for an endpoint
+type PayloadFormat string
+
+const (
+	// PayloadFormatJSON sends {"id", "event", "occurred_at", "data"}
+	PayloadFormatJSON PayloadFormat = "json"
+	// PayloadFormatCloudEvents sends CloudEvents 1.0 structured JSON
+	PayloadFormatCloudEvents PayloadFormat = "cloudevents"
+)
+
+// Endpoint is an external URL that receives signed event deliveries
+type Endpoint struct {
+	ID     uint
+	URL    string
+	Secret string
+	// EventTypes lists the event names delivered to the endpoint; empty or AllEvents means every event
+	EventTypes []string
+	// RetrySchedule is the wait before each retry; empty uses the dispatcher default
+	RetrySchedule []time.Duration
+	Format        PayloadFormat
+	Active        bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewEndpoint creates a new active endpoint with validation; an empty format means PayloadFormatJSON
+func NewEndpoint(rawURL, secret string, eventTypes []string, retrySchedule []time.Duration, format PayloadFormat) (*Endpoint, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrInvalidEndpointURL
+	}
+	if secret == "" {
+		return nil, ErrEmptyEndpointSecret
+	}
+	for _, wait := range retrySchedule {
+		if wait <= 0 {
+			return nil, ErrInvalidRetrySchedule
+		}
+	}
+	switch format {
+	case "":
+		format = PayloadFormatJSON
+	case PayloadFormatJSON, PayloadFormatCloudEvents:
+	default:
+		return nil, ErrInvalidPayloadFormat
+	}
+
+	return &Endpoint{
+		URL:           rawURL,
+		Secret:        secret,
+		EventTypes:    eventTypes,
+		RetrySchedule: retrySchedule,
+		Format:        format,
+		Active:        true,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}, nil
+}
+
+// Subscribes reports whether the endpoint should receive eventName
+func (e *Endpoint) Subscribes(eventName string) bool {
+	if !e.Active {
+		return false
+	}
+	if len(e.EventTypes) == 0 {
+		return true
+	}
+	for _, eventType := range e.EventTypes {
+		if eventType == AllEvents || eventType == eventName {
+			return true
+		}
+	}
+	return false
+}
+
+// DeliveryStatus represents the status of a delivery
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// Delivery is one event sent to one endpoint, retried until it succeeds or the schedule runs out
+type Delivery struct {
+	ID uint
+	// TenantID is the tenant the delivery was recorded for, so it is sent within that tenant
+	TenantID   uint
+	EndpointID uint
+	// EventID is shared by every delivery of the same event so receivers can deduplicate
+	EventID       string
+	EventName     string
+	Payload       []byte
+	Status        DeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewDelivery creates a pending delivery due immediately
+func NewDelivery(endpointID uint, eventID, eventName string, payload []byte) *Delivery {
+	now := time.Now()
+	return &Delivery{
+		EndpointID:    endpointID,
+		EventID:       eventID,
+		EventName:     eventName,
+		Payload:       payload,
+		Status:        DeliveryStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// RecordAttempt counts an attempt being made and returns its number
+func (d *Delivery) RecordAttempt() int {
+	d.Attempts++
+	return d.Attempts
+}
+
+// MarkSucceeded records that the last attempt succeeded
+func (d *Delivery) MarkSucceeded() {
+	d.Status = DeliveryStatusSucceeded
+	d.LastError = ""
+	d.UpdatedAt = time.Now()
+}
+
+// MarkRetry schedules the next attempt after a failure or a skipped attempt
+func (d *Delivery) MarkRetry(reason string, next time.Time) {
+	d.LastError = reason
+	d.NextAttemptAt = next
+	d.UpdatedAt = time.Now()
+}
+
+// MarkFailed gives up on the delivery
+func (d *Delivery) MarkFailed(reason string) {
+	d.Status = DeliveryStatusFailed
+	d.LastError = reason
+	d.UpdatedAt = time.Now()
+}
+
+// Redeliver makes a finished delivery pending again with a fresh retry schedule
+// Earlier attempts stay in the log; numbering restarts at 1
+func (d *Delivery) Redeliver() error {
+	if d.Status == DeliveryStatusPending {
+		return ErrDeliveryPending
+	}
+	d.Status = DeliveryStatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = time.Now()
+	d.UpdatedAt = time.Now()
+	return nil
+}
+
+// DeliveryAttempt logs a single HTTP request made for a delivery
+type DeliveryAttempt struct {
+	ID         uint
+	DeliveryID uint
+	Number     int
+	// StatusCode is 0 when no response was received
+	StatusCode  int
+	Error       string
+	Duration    time.Duration
+	AttemptedAt time.Time
+}
+
+// Succeeded reports whether the attempt received a 2xx response
+func (a *DeliveryAttempt) Succeeded() bool {
+	return a.Error == "" && a.StatusCode >= 200 && a.StatusCode < 300
+}
+
+// Domain errors for webhooks
+var (
+	ErrInvalidEndpointURL   = sharedEntities.DomainError{Message: "webhook URL must be an absolute http or https URL"}
+	ErrEmptyEndpointSecret  = sharedEntities.DomainError{Message: "webhook secret cannot be empty"}
+	ErrInvalidRetrySchedule = sharedEntities.DomainError{Message: "webhook retry intervals must be positive"}
+	ErrInvalidPayloadFormat = sharedEntities.DomainError{Message: "webhook format must be json or cloudevents"}
+	ErrEndpointNotFound     = sharedEntities.DomainError{Message: "webhook endpoint not found"}
+	ErrDeliveryNotFound     = sharedEntities.DomainError{Message: "webhook delivery not found"}
+	ErrDeliveryPending      = sharedEntities.DomainError{Message: "webhook delivery is already pending"}
+)
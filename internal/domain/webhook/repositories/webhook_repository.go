@@ -0,0 +1,29 @@
+package repositories
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=webhook_repository.go -destination=../../../mocks/webhook_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/domain/webhook/entities"
+)
+
+// WebhookRepository defines the contract for webhook endpoint, delivery and attempt persistence
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *entities.Endpoint) error
+	GetEndpoint(ctx context.Context, id uint) (*entities.Endpoint, error)
+	ListEndpoints(ctx context.Context) ([]*entities.Endpoint, error)
+
+	CreateDelivery(ctx context.Context, delivery *entities.Delivery) error
+	GetDelivery(ctx context.Context, id uint) (*entities.Delivery, error)
+	// ListDeliveries returns an endpoint's deliveries, newest first
+	ListDeliveries(ctx context.Context, endpointID uint, limit, offset int) ([]*entities.Delivery, error)
+	// ListDueDeliveries returns pending deliveries whose next attempt is due at now, oldest first
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entities.Delivery, error)
+	UpdateDelivery(ctx context.Context, delivery *entities.Delivery) error
+
+	CreateAttempt(ctx context.Context, attempt *entities.DeliveryAttempt) error
+	// ListAttempts returns a delivery's attempts, including those before a redelivery, oldest first
+	ListAttempts(ctx context.Context, deliveryID uint) ([]*entities.DeliveryAttempt, error)
+}
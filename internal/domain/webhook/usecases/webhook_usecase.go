@@ -0,0 +1,21 @@
+package usecases
+
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=webhook_usecase.go -destination=../../../mocks/webhook_usecase_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"clean-arch-gin/internal/domain/webhook/entities"
+)
+
+// WebhookUseCase defines the business logic operations for webhook administration
+type WebhookUseCase interface {
+	// RegisterEndpoint adds an endpoint; an empty secret is replaced by a generated one
+	RegisterEndpoint(ctx context.Context, url, secret string, eventTypes []string, retrySchedule []time.Duration, format entities.PayloadFormat) (*entities.Endpoint, error)
+	ListEndpoints(ctx context.Context) ([]*entities.Endpoint, error)
+	ListDeliveries(ctx context.Context, endpointID uint, limit, offset int) ([]*entities.Delivery, error)
+	GetDelivery(ctx context.Context, id uint) (*entities.Delivery, []*entities.DeliveryAttempt, error)
+	// Redeliver schedules a finished delivery to be sent again right away
+	Redeliver(ctx context.Context, id uint) (*entities.Delivery, error)
+}
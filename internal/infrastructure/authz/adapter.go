@@ -0,0 +1,118 @@
+package authz
+
+import (
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"gorm.io/gorm"
+)
+
+// RuleModel stores one policy (ptype p) or role assignment (ptype g) per row, in the
+// casbin_rule layout shared with the upstream gorm-adapter
+type RuleModel struct {
+	ID    uint   `gorm:"primaryKey;autoIncrement"`
+	PType string `gorm:"column:ptype;size:100;uniqueIndex:idx_casbin_rule"`
+	V0    string `gorm:"size:100;uniqueIndex:idx_casbin_rule"`
+	V1    string `gorm:"size:100;uniqueIndex:idx_casbin_rule"`
+	V2    string `gorm:"size:100;uniqueIndex:idx_casbin_rule"`
+	V3    string `gorm:"size:100;uniqueIndex:idx_casbin_rule"`
+	V4    string `gorm:"size:100;uniqueIndex:idx_casbin_rule"`
+	V5    string `gorm:"size:100;uniqueIndex:idx_casbin_rule"`
+}
+
+// TableName sets the table name for GORM
+func (RuleModel) TableName() string {
+	return "casbin_rule"
+}
+
+// values returns the rule's non-empty fields after the ptype
+func (r *RuleModel) values() []string {
+	values := []string{r.V0, r.V1, r.V2, r.V3, r.V4, r.V5}
+	for len(values) > 0 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+	return values
+}
+
+// newRuleModel creates the row of rule
+func newRuleModel(ptype string, rule []string) *RuleModel {
+	r := &RuleModel{PType: ptype}
+	fields := []*string{&r.V0, &r.V1, &r.V2, &r.V3, &r.V4, &r.V5}
+	for i, value := range rule {
+		if i < len(fields) {
+			*fields[i] = value
+		}
+	}
+	return r
+}
+
+// Adapter persists the Casbin policy in the casbin_rule table; RuleModel must be migrated
+type Adapter struct {
+	db *gorm.DB
+}
+
+// NewAdapter creates an adapter on db
+func NewAdapter(db *gorm.DB) *Adapter {
+	return &Adapter{db: db}
+}
+
+// LoadPolicy loads every rule into m
+func (a *Adapter) LoadPolicy(m model.Model) error {
+	var rules []RuleModel
+	if err := a.db.Order("id").Find(&rules).Error; err != nil {
+		return err
+	}
+	for i := range rules {
+		if err := persist.LoadPolicyArray(append([]string{rules[i].PType}, rules[i].values()...), m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SavePolicy replaces the stored rules with those of m
+func (a *Adapter) SavePolicy(m model.Model) error {
+	var rules []*RuleModel
+	for _, sec := range []string{"p", "g"} {
+		for ptype, assertion := range m[sec] {
+			for _, rule := range assertion.Policy {
+				rules = append(rules, newRuleModel(ptype, rule))
+			}
+		}
+	}
+
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&RuleModel{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(rules).Error
+	})
+}
+
+// AddPolicy stores a rule
+func (a *Adapter) AddPolicy(sec string, ptype string, rule []string) error {
+	return a.db.Create(newRuleModel(ptype, rule)).Error
+}
+
+// RemovePolicy deletes a rule
+func (a *Adapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	return a.db.Where(newRuleModel(ptype, rule), "ptype", "v0", "v1", "v2", "v3", "v4", "v5").
+		Delete(&RuleModel{}).Error
+}
+
+// RemoveFilteredPolicy deletes the rules whose fields from fieldIndex on equal the
+// non-empty fieldValues
+func (a *Adapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	query := a.db.Where("ptype = ?", ptype)
+	columns := []string{"v0", "v1", "v2", "v3", "v4", "v5"}
+	for i, value := range fieldValues {
+		if value != "" && fieldIndex+i < len(columns) {
+			query = query.Where(columns[fieldIndex+i]+" = ?", value)
+		}
+	}
+	return query.Delete(&RuleModel{}).Error
+}
+
+var _ persist.Adapter = (*Adapter)(nil)
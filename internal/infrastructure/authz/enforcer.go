@@ -0,0 +1,186 @@
+// Package authz enforces the authorization policy with Casbin, stored in the database
+package authz
+
+import (
+	"context"
+	"log"
+	"time"
+
+	authzEntities "clean-arch-gin/internal/domain/authz/entities"
+	authzRepositories "clean-arch-gin/internal/domain/authz/repositories"
+	sharedAuthz "clean-arch-gin/internal/domain/shared/authz"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"gorm.io/gorm"
+)
+
+// modelText is the access control model: role-based with role hierarchies (g), objects
+// matched by prefix with a trailing * and * as any action
+const modelText = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && keyMatch(r.obj, p.obj) && (r.act == p.act || p.act == "*")
+`
+
+// Enforcer decides requests against the policy held in memory, persisted through the
+// casbin_rule table
+// Every replica keeps its own copy; changes made elsewhere are picked up by Reload
+type Enforcer struct {
+	enforcer *casbin.SyncedEnforcer
+}
+
+// NewEnforcer creates an enforcer on db with an empty policy; Load reads the stored one
+// once RuleModel is migrated
+func NewEnforcer(db *gorm.DB) (*Enforcer, error) {
+	m, err := model.NewModelFromString(modelText)
+	if err != nil {
+		return nil, err
+	}
+	enforcer, err := casbin.NewSyncedEnforcer(m)
+	if err != nil {
+		return nil, err
+	}
+	enforcer.SetAdapter(NewAdapter(db))
+
+	return &Enforcer{enforcer: enforcer}, nil
+}
+
+// Load reads the stored policy, storing authzEntities.DefaultPolicies first when there is none
+func (e *Enforcer) Load() error {
+	if err := e.enforcer.LoadPolicy(); err != nil {
+		return err
+	}
+	policies, err := e.enforcer.GetPolicy()
+	if err != nil || len(policies) > 0 {
+		return err
+	}
+	for _, policy := range authzEntities.DefaultPolicies {
+		if _, err := e.enforcer.AddPolicy(policy.Subject, policy.Object, policy.Action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reload reads the stored policy every interval until ctx is done, so changes made on
+// other replicas take effect
+func (e *Enforcer) Reload(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.enforcer.LoadPolicy(); err != nil {
+				log.Printf("authz: failed to reload the policy: %v", err)
+			}
+		}
+	}
+}
+
+// Enforce reports whether any of the actor's subjects is allowed action on object
+func (e *Enforcer) Enforce(actor sharedAuthz.Actor, object, action string) (bool, error) {
+	for _, subject := range actor.Subjects() {
+		allowed, err := e.enforcer.Enforce(subject, object, action)
+		if err != nil || allowed {
+			return allowed, err
+		}
+	}
+	return false, nil
+}
+
+// ListPolicies returns the stored policies
+func (e *Enforcer) ListPolicies() ([]*authzEntities.Policy, error) {
+	rules, err := e.enforcer.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]*authzEntities.Policy, 0, len(rules))
+	for _, rule := range rules {
+		if len(rule) >= 3 {
+			policies = append(policies, &authzEntities.Policy{Subject: rule[0], Object: rule[1], Action: rule[2]})
+		}
+	}
+	return policies, nil
+}
+
+// AddPolicy stores a policy
+func (e *Enforcer) AddPolicy(policy *authzEntities.Policy) error {
+	added, err := e.enforcer.AddPolicy(policy.Subject, policy.Object, policy.Action)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return authzEntities.ErrPolicyExists
+	}
+	return nil
+}
+
+// RemovePolicy deletes a policy
+func (e *Enforcer) RemovePolicy(policy *authzEntities.Policy) error {
+	removed, err := e.enforcer.RemovePolicy(policy.Subject, policy.Object, policy.Action)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return authzEntities.ErrPolicyNotFound
+	}
+	return nil
+}
+
+// ListRoleAssignments returns the stored role assignments
+func (e *Enforcer) ListRoleAssignments() ([]*authzEntities.RoleAssignment, error) {
+	rules, err := e.enforcer.GetGroupingPolicy()
+	if err != nil {
+		return nil, err
+	}
+	assignments := make([]*authzEntities.RoleAssignment, 0, len(rules))
+	for _, rule := range rules {
+		if len(rule) >= 2 {
+			assignments = append(assignments, &authzEntities.RoleAssignment{Subject: rule[0], Role: rule[1]})
+		}
+	}
+	return assignments, nil
+}
+
+// AddRoleAssignment stores a role assignment
+func (e *Enforcer) AddRoleAssignment(assignment *authzEntities.RoleAssignment) error {
+	added, err := e.enforcer.AddGroupingPolicy(assignment.Subject, assignment.Role)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return authzEntities.ErrRoleAssignmentExists
+	}
+	return nil
+}
+
+// RemoveRoleAssignment deletes a role assignment
+func (e *Enforcer) RemoveRoleAssignment(assignment *authzEntities.RoleAssignment) error {
+	removed, err := e.enforcer.RemoveGroupingPolicy(assignment.Subject, assignment.Role)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return authzEntities.ErrRoleAssignmentNotFound
+	}
+	return nil
+}
+
+var (
+	_ sharedAuthz.Authorizer             = (*Enforcer)(nil)
+	_ authzRepositories.PolicyRepository = (*Enforcer)(nil)
+)
@@ -0,0 +1,299 @@
+// Package breaker implements a circuit breaker that fails fast while a dependency is down
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// State is the state of a circuit breaker
+type State int
+
+const (
+	// StateClosed lets every call through and counts failures
+	StateClosed State = iota
+	// StateHalfOpen lets a limited number of probe calls through
+	StateHalfOpen
+	// StateOpen rejects every call until the timeout has elapsed
+	StateOpen
+)
+
+// String returns the state name used in logs and metrics
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+const (
+	defaultMaxRequests = 1
+	defaultTimeout     = time.Minute
+	defaultFailures    = 5
+)
+
+var (
+	// ErrOpenState is wrapped by the error returned while the breaker is open
+	ErrOpenState = errors.New("circuit breaker is open")
+	// ErrTooManyRequests is wrapped by the error returned when the half-open probes are all in flight
+	ErrTooManyRequests = errors.New("too many requests while circuit breaker is half-open")
+)
+
+// Counts holds the numbers of calls and their outcomes in the current generation
+// They are cleared on every state change and, while closed, every Interval
+type Counts struct {
+	Requests             uint32
+	TotalSuccesses       uint32
+	TotalFailures        uint32
+	ConsecutiveSuccesses uint32
+	ConsecutiveFailures  uint32
+}
+
+// Settings configures a circuit breaker; zero values take the defaults
+type Settings struct {
+	// Name identifies the dependency in errors and metrics, e.g. "database"
+	Name string
+	// MaxRequests is how many probes half-open lets through, and how many must
+	// succeed to close again (1)
+	MaxRequests uint32
+	// Interval clears the counts periodically while closed; 0 never clears them
+	Interval time.Duration
+	// Timeout is how long the breaker stays open before probing (1m)
+	Timeout time.Duration
+	// ReadyToTrip opens the breaker after a failure (5 consecutive failures)
+	ReadyToTrip func(counts Counts) bool
+	// IsSuccessful decides whether an error counts against the dependency (err == nil);
+	// errors such as "not found" are the dependency working as intended
+	IsSuccessful func(err error) bool
+	// OnStateChange is called on every transition, with the breaker's lock held
+	OnStateChange func(name string, from, to State)
+}
+
+// ConsecutiveFailures is a ReadyToTrip that opens after threshold failures in a row
+func ConsecutiveFailures(threshold uint32) func(Counts) bool {
+	return func(counts Counts) bool {
+		return counts.ConsecutiveFailures >= threshold
+	}
+}
+
+// UnavailableError is returned instead of calling the dependency while the breaker rejects calls
+type UnavailableError struct {
+	Name  string
+	State State
+	// RetryAfter is how long until the breaker lets a probe through
+	RetryAfter time.Duration
+}
+
+// Error describes the rejected dependency
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("%s unavailable: %v", e.Name, e.Unwrap())
+}
+
+// Unwrap returns ErrOpenState or ErrTooManyRequests
+func (e *UnavailableError) Unwrap() error {
+	if e.State == StateHalfOpen {
+		return ErrTooManyRequests
+	}
+	return ErrOpenState
+}
+
+// IsUnavailable reports whether err was returned by a breaker rejecting the call
+func IsUnavailable(err error) bool {
+	var unavailable *UnavailableError
+	return errors.As(err, &unavailable)
+}
+
+// CircuitBreaker stops calling a failing dependency and probes it again after a timeout
+// Closed: calls go through and failures are counted until ReadyToTrip opens it.
+// Open: calls fail fast with an UnavailableError until Timeout has elapsed.
+// Half-open: up to MaxRequests probes go through; all succeeding closes it, any failing reopens it
+type CircuitBreaker struct {
+	settings Settings
+
+	mu         sync.Mutex
+	state      State
+	generation uint64
+	counts     Counts
+	expiry     time.Time
+}
+
+// New creates a closed circuit breaker
+func New(settings Settings) *CircuitBreaker {
+	if settings.MaxRequests == 0 {
+		settings.MaxRequests = defaultMaxRequests
+	}
+	if settings.Timeout <= 0 {
+		settings.Timeout = defaultTimeout
+	}
+	if settings.ReadyToTrip == nil {
+		settings.ReadyToTrip = ConsecutiveFailures(defaultFailures)
+	}
+	if settings.IsSuccessful == nil {
+		settings.IsSuccessful = func(err error) bool { return err == nil }
+	}
+
+	cb := &CircuitBreaker{settings: settings}
+	cb.toNewGeneration(time.Now())
+	metrics.observeState(settings.Name, StateClosed)
+	return cb
+}
+
+// Name returns the name of the guarded dependency
+func (cb *CircuitBreaker) Name() string {
+	return cb.settings.Name
+}
+
+// State returns the current state, moving an expired open breaker to half-open
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	state, _ := cb.currentState(time.Now())
+	return state
+}
+
+// Counts returns the counts of the current generation
+func (cb *CircuitBreaker) Counts() Counts {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.counts
+}
+
+// Execute calls fn unless the breaker rejects it, and records the outcome
+// A panic in fn counts as a failure and is re-raised
+func (cb *CircuitBreaker) Execute(fn func() error) error {
+	done, err := cb.Allow()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			done(false)
+			panic(r)
+		}
+	}()
+
+	err = fn()
+	done(cb.settings.IsSuccessful(err))
+	return err
+}
+
+// Allow is the two-step form of Execute for calls that decide success themselves,
+// such as HTTP requests judged by status code. On success the caller must call done
+// exactly once with the outcome
+func (cb *CircuitBreaker) Allow() (done func(success bool), err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := time.Now()
+	state, generation := cb.currentState(now)
+
+	switch {
+	case state == StateOpen:
+		metrics.observeRejected(cb.settings.Name)
+		return nil, &UnavailableError{Name: cb.settings.Name, State: state, RetryAfter: cb.expiry.Sub(now)}
+	case state == StateHalfOpen && cb.counts.Requests >= cb.settings.MaxRequests:
+		metrics.observeRejected(cb.settings.Name)
+		return nil, &UnavailableError{Name: cb.settings.Name, State: state, RetryAfter: cb.settings.Timeout}
+	}
+
+	cb.counts.Requests++
+	return func(success bool) {
+		cb.done(generation, success)
+	}, nil
+}
+
+// done records the outcome of a call admitted in generation; results of an older generation are ignored
+func (cb *CircuitBreaker) done(generation uint64, success bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := time.Now()
+	metrics.observeResult(cb.settings.Name, success)
+	state, current := cb.currentState(now)
+	if generation != current {
+		return
+	}
+
+	if success {
+		cb.counts.TotalSuccesses++
+		cb.counts.ConsecutiveSuccesses++
+		cb.counts.ConsecutiveFailures = 0
+		if state == StateHalfOpen && cb.counts.ConsecutiveSuccesses >= cb.settings.MaxRequests {
+			cb.setState(StateClosed, now)
+		}
+		return
+	}
+
+	cb.counts.TotalFailures++
+	cb.counts.ConsecutiveFailures++
+	cb.counts.ConsecutiveSuccesses = 0
+	switch state {
+	case StateClosed:
+		if cb.settings.ReadyToTrip(cb.counts) {
+			cb.setState(StateOpen, now)
+		}
+	case StateHalfOpen:
+		cb.setState(StateOpen, now)
+	}
+}
+
+// currentState applies the time-based transitions; cb.mu must be held
+func (cb *CircuitBreaker) currentState(now time.Time) (State, uint64) {
+	switch cb.state {
+	case StateClosed:
+		if !cb.expiry.IsZero() && cb.expiry.Before(now) {
+			cb.toNewGeneration(now)
+		}
+	case StateOpen:
+		if cb.expiry.Before(now) {
+			cb.setState(StateHalfOpen, now)
+		}
+	}
+	return cb.state, cb.generation
+}
+
+// setState moves to state and starts a new generation; cb.mu must be held
+func (cb *CircuitBreaker) setState(state State, now time.Time) {
+	if cb.state == state {
+		return
+	}
+
+	from := cb.state
+	cb.state = state
+	cb.toNewGeneration(now)
+
+	metrics.observeState(cb.settings.Name, state)
+	if cb.settings.OnStateChange != nil {
+		cb.settings.OnStateChange(cb.settings.Name, from, state)
+	}
+}
+
+// toNewGeneration clears the counts and sets the expiry of the current state; cb.mu must be held
+func (cb *CircuitBreaker) toNewGeneration(now time.Time) {
+	cb.generation++
+	cb.counts = Counts{}
+
+	switch cb.state {
+	case StateClosed:
+		if cb.settings.Interval == 0 {
+			cb.expiry = time.Time{}
+		} else {
+			cb.expiry = now.Add(cb.settings.Interval)
+		}
+	case StateOpen:
+		cb.expiry = now.Add(cb.settings.Timeout)
+	default:
+		cb.expiry = time.Time{}
+	}
+}
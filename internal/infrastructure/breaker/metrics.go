@@ -0,0 +1,42 @@
+package breaker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// collectors are the Prometheus metrics shared by every breaker, labelled by name
+type collectors struct {
+	state    *prometheus.GaugeVec
+	requests *prometheus.CounterVec
+}
+
+var metrics = collectors{
+	state: promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "circuit_breaker_state",
+		Help: "Circuit breaker state: 0 closed, 1 half-open, 2 open.",
+	}, []string{"name"}),
+	requests: promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "circuit_breaker_requests_total",
+		Help: "Calls through circuit breakers by outcome: success, failure or rejected.",
+	}, []string{"name", "result"}),
+}
+
+// observeState records the breaker's current state
+func (m collectors) observeState(name string, state State) {
+	m.state.WithLabelValues(name).Set(float64(state))
+}
+
+// observeResult counts a call that went through
+func (m collectors) observeResult(name string, success bool) {
+	result := "failure"
+	if success {
+		result = "success"
+	}
+	m.requests.WithLabelValues(name, result).Inc()
+}
+
+// observeRejected counts a call that failed fast
+func (m collectors) observeRejected(name string) {
+	m.requests.WithLabelValues(name, "rejected").Inc()
+}
@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/infrastructure/health"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// HealthCheck pings Redis; a cluster is healthy when every master answers, as a key of any
+// slot may be asked for
+func HealthCheck(client redis.UniversalClient) health.CheckFunc {
+	return func(ctx context.Context) error {
+		if cluster, ok := client.(*redis.ClusterClient); ok {
+			return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+				return node.Ping(ctx).Err()
+			})
+		}
+		return client.Ping(ctx).Err()
+	}
+}
@@ -0,0 +1,132 @@
+// Package cache provides the Redis client shared by the stores kept in Redis, e.g. the
+// sessions, the login throttle, the API key counters, the query cache and the leader lease,
+// so they pool their connections to one server, sentinel group or cluster
+package cache
+
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
+)
+
+// Deployments of Redis the client connects to
+const (
+	ModeStandalone = "standalone"
+	ModeSentinel   = "sentinel"
+	ModeCluster    = "cluster"
+)
+
+// Options configures the client
+type Options struct {
+	// Mode is ModeStandalone, the default, ModeSentinel or ModeCluster
+	Mode string
+	// Addrs are the server in standalone mode, the sentinels in sentinel mode and the seed
+	// nodes in cluster mode, as host:port
+	Addrs []string
+	// MasterName names the master the sentinels monitor
+	MasterName string
+	Username   string
+	Password   string
+	// DB selects the database; clusters only have database 0
+	DB int
+	// PoolSize caps the connections per node, 10 per CPU when 0; MinIdleConns are kept open
+	PoolSize     int
+	MinIdleConns int
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	// TLS connects over TLS, verifying the servers against the system roots
+	TLS bool
+	// Instrument traces commands and reports pool metrics through the global OpenTelemetry
+	// providers; they record nothing until the application installs an exporting one
+	Instrument bool
+}
+
+// NewClient creates a client of the deployment opts describes. Connections are opened on
+// first use, so an unreachable Redis shows in the health check rather than here
+func NewClient(opts Options) (redis.UniversalClient, error) {
+	if len(opts.Addrs) == 0 {
+		return nil, fmt.Errorf("redis: no address configured")
+	}
+	var tlsConfig *tls.Config
+	if opts.TLS {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	var client redis.UniversalClient
+	switch opts.Mode {
+	case "", ModeStandalone:
+		if len(opts.Addrs) > 1 {
+			return nil, fmt.Errorf("redis: standalone mode takes one address, got %d", len(opts.Addrs))
+		}
+		client = redis.NewClient(&redis.Options{
+			Addr:         opts.Addrs[0],
+			Username:     opts.Username,
+			Password:     opts.Password,
+			DB:           opts.DB,
+			PoolSize:     opts.PoolSize,
+			MinIdleConns: opts.MinIdleConns,
+			DialTimeout:  opts.DialTimeout,
+			ReadTimeout:  opts.ReadTimeout,
+			WriteTimeout: opts.WriteTimeout,
+			TLSConfig:    tlsConfig,
+		})
+	case ModeSentinel:
+		if opts.MasterName == "" {
+			return nil, fmt.Errorf("redis: sentinel mode needs the master name")
+		}
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    opts.MasterName,
+			SentinelAddrs: opts.Addrs,
+			Username:      opts.Username,
+			Password:      opts.Password,
+			DB:            opts.DB,
+			PoolSize:      opts.PoolSize,
+			MinIdleConns:  opts.MinIdleConns,
+			DialTimeout:   opts.DialTimeout,
+			ReadTimeout:   opts.ReadTimeout,
+			WriteTimeout:  opts.WriteTimeout,
+			TLSConfig:     tlsConfig,
+		})
+	case ModeCluster:
+		if opts.DB != 0 {
+			return nil, fmt.Errorf("redis: cluster mode only has database 0, got %d", opts.DB)
+		}
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        opts.Addrs,
+			Username:     opts.Username,
+			Password:     opts.Password,
+			PoolSize:     opts.PoolSize,
+			MinIdleConns: opts.MinIdleConns,
+			DialTimeout:  opts.DialTimeout,
+			ReadTimeout:  opts.ReadTimeout,
+			WriteTimeout: opts.WriteTimeout,
+			TLSConfig:    tlsConfig,
+		})
+	default:
+		return nil, fmt.Errorf("redis: unsupported mode: %s", opts.Mode)
+	}
+
+	if opts.Instrument {
+		if err := instrument(client); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+// instrument adds the OpenTelemetry hooks to client. Spans name the command but leave out
+// its arguments, which hold session tokens and other secrets
+func instrument(client redis.UniversalClient) error {
+	if err := redisotel.InstrumentTracing(client, redisotel.WithDBStatement(false)); err != nil {
+		return fmt.Errorf("redis: failed to instrument tracing: %w", err)
+	}
+	if err := redisotel.InstrumentMetrics(client); err != nil {
+		return fmt.Errorf("redis: failed to instrument metrics: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Package cloudevents renders domain events as CloudEvents 1.0 in the structured JSON format
+// See https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/formats/json-format.md
+package cloudevents
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/events"
+)
+
+const (
+	// SpecVersion is the CloudEvents specification version emitted
+	SpecVersion = "1.0"
+	// ContentType is the media type of a structured-mode CloudEvent
+	ContentType = "application/cloudevents+json"
+	// DefaultSource is used when a Formatter has no Source
+	DefaultSource = "/clean-arch-gin"
+)
+
+// Event is a CloudEvent in structured JSON form
+type Event struct {
+	SpecVersion     string      `json:"specversion"`
+	ID              string      `json:"id"`
+	Source          string      `json:"source"`
+	Type            string      `json:"type"`
+	Subject         string      `json:"subject,omitempty"`
+	Time            time.Time   `json:"time"`
+	DataContentType string      `json:"datacontenttype"`
+	Data            interface{} `json:"data"`
+}
+
+// Formatter converts domain events to CloudEvents
+type Formatter struct {
+	// Source identifies this service as the producer, e.g. "/clean-arch-gin" or "https://api.example.com"
+	Source string
+	// TypePrefix is prepended to event names, e.g. "com.example." gives "com.example.order.status_changed"
+	TypePrefix string
+}
+
+// Format builds the CloudEvent for event
+// id is the CloudEvents id; pass "" to generate one. Deliveries of the same event must share it
+// so consumers can deduplicate on source + id
+func (f Formatter) Format(event events.DomainEvent, id string) Event {
+	if id == "" {
+		id = NewID()
+	}
+	source := f.Source
+	if source == "" {
+		source = DefaultSource
+	}
+
+	ce := Event{
+		SpecVersion:     SpecVersion,
+		ID:              id,
+		Source:          source,
+		Type:            f.TypePrefix + event.EventName(),
+		Time:            event.OccurredOn().UTC(),
+		DataContentType: "application/json",
+		Data:            event.EventData(),
+	}
+	if subjected, ok := event.(events.SubjectProvider); ok {
+		ce.Subject = subjected.EventSubject()
+	}
+	return ce
+}
+
+// Marshal formats event and encodes it as structured JSON
+func (f Formatter) Marshal(event events.DomainEvent, id string) ([]byte, error) {
+	return json.Marshal(f.Format(event, id))
+}
+
+// NewID returns a random 128-bit hex event ID
+func NewID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,79 @@
+package cloudevents
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/infrastructure/retry"
+)
+
+// Sink receives structured-mode CloudEvents, e.g. a broker producer or a log shipper
+type Sink interface {
+	Send(body []byte) error
+}
+
+// Publisher is an events.EventPublisher that emits every event to a Sink as a CloudEvent
+// Subscribe its Publish method to the bus's Wildcard to mirror all domain events
+type Publisher struct {
+	formatter Formatter
+	sink      Sink
+}
+
+// NewPublisher creates a publisher that formats events with formatter and sends them to sink
+func NewPublisher(formatter Formatter, sink Sink) *Publisher {
+	return &Publisher{formatter: formatter, sink: sink}
+}
+
+// Publish formats event as a CloudEvent with a new ID and sends it
+func (p *Publisher) Publish(ctx context.Context, event events.DomainEvent) error {
+	body, err := p.formatter.Marshal(event, "")
+	if err != nil {
+		return err
+	}
+	return p.sink.Send(body)
+}
+
+// WriterSink writes one CloudEvent per line (JSON Lines), e.g. to stdout for a log shipper
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink creates a sink writing to w
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// Send writes body followed by a newline
+func (s *WriterSink) Send(body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.w.Write(body); err != nil {
+		return err
+	}
+	_, err := s.w.Write([]byte("\n"))
+	return err
+}
+
+// RetryingSink retries failed sends with backoff, e.g. while a broker fails over
+type RetryingSink struct {
+	sink   Sink
+	policy retry.Policy
+}
+
+// NewRetryingSink wraps sink so each Send is retried according to policy
+func NewRetryingSink(sink Sink, policy retry.Policy) *RetryingSink {
+	return &RetryingSink{sink: sink, policy: policy}
+}
+
+// Send sends body, retrying transient failures
+func (s *RetryingSink) Send(body []byte) error {
+	return retry.Do(context.Background(), s.policy, func(context.Context) error {
+		return s.sink.Send(body)
+	})
+}
+
+var _ events.EventPublisher = (*Publisher)(nil)
@@ -3,43 +3,734 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Config holds all application configuration
 type Config struct {
 	DB struct {
+		Driver   string
 		Host     string
 		Port     int
 		User     string
 		Password string
 		Name     string
+		// SSLMode is the sslmode of Postgres connections, e.g. disable or require
+		SSLMode  string
+		LogLevel string
+		// ConnectAttempts bounds connecting at startup, retried with backoff from ConnectBackoff
+		ConnectAttempts int
+		ConnectBackoff  time.Duration
 	}
 	Server struct {
-		Port string
+		Port      string
+		Mode      string
+		SwaggerUI bool
+		// AccessLog logs every request; CORS answers preflights and allows any origin
+		AccessLog bool
+		CORS      bool
+		// ShutdownTimeout bounds draining in-flight requests and workers on SIGTERM
+		ShutdownTimeout time.Duration
+		// DrainDelay keeps serving after readiness turns 503 so load balancers notice first
+		DrainDelay time.Duration
+	}
+	Admin struct {
+		Enabled bool
+		Port    string
+	}
+	GRPC struct {
+		Enabled bool
+		Port    string
+		Gateway bool
+	}
+	GraphQL struct {
+		Enabled       bool
+		MaxDepth      int
+		MaxComplexity int
+	}
+	Events struct {
+		Source         string
+		TypePrefix     string
+		LogCloudEvents bool
+	}
+	// Breaker holds the circuit breaker thresholds of each dependency
+	Breaker struct {
+		Database BreakerSettings
+		Webhook  BreakerSettings
+		Search   BreakerSettings
+	}
+	Orders struct {
+		// ReservationTTL is how long a confirmed order holds its stock unpaid before it is
+		// cancelled and the stock released
+		ReservationTTL time.Duration
+		// WebhookEvents is the comma-separated lifecycle events published with the whole order
+		// for webhooks, out of order.confirmed, order.shipped and order.cancelled
+		WebhookEvents string
+	}
+	// Payments issues refunds of returned orders
+	Payments struct {
+		// Gateway is "manual" (refunds are logged and paid out by hand)
+		Gateway string
+	}
+
+	// Pricing adds tax and shipping to the price of orders
+	Pricing struct {
+		// TaxCalculator is "flat" (TaxRate everywhere), "region" (TaxRegionRates, falling
+		// back to TaxRate) or "api" (the external tax service at TaxAPIURL)
+		TaxCalculator string
+		TaxRate       float64
+		// TaxRegionRates maps "COUNTRY-REGION" or "COUNTRY" to a rate, e.g. "US-CA=0.0725,DE=0.19"
+		TaxRegionRates string
+		TaxAPIURL      string
+		TaxAPIKey      string
+		// Shipping is "flat" (ShippingRate per order) or "per_item" (ShippingRate plus
+		// ShippingPerItem for every unit)
+		Shipping        string
+		ShippingRate    float64
+		ShippingPerItem float64
+		// FreeShippingOver waives shipping for subtotals of at least this much; 0 never does
+		FreeShippingOver float64
+	}
+
+	// Currency converts amounts to the currency clients ask to see them in; each tenant sets
+	// the base currency its orders are placed in
+	Currency struct {
+		// ExchangeRates is "none" (amounts are shown in their own currency only), "ecb" (the
+		// European Central Bank's daily reference rates) or "openexchangerates"
+		ExchangeRates          string
+		OpenExchangeRatesAppID string
+		// RatesTTL is how long fetched rates are used before they are fetched again
+		RatesTTL time.Duration
+	}
+
+	// Documents renders printable documents such as invoices
+	Documents struct {
+		// PDFGenerator is "builtin" (plain A4 pages drawn with the PDF standard fonts)
+		PDFGenerator string
+	}
+	// Scheduler runs the modules' periodic jobs (purges, rollups, stale order cancellation)
+	Scheduler struct {
+		Enabled bool
+		// JobTimeout bounds runs of jobs that set no timeout of their own
+		JobTimeout time.Duration
+		// HistoryRetention is how long the history of job runs is kept
+		HistoryRetention time.Duration
+	}
+	// Tasks configures the durable task queue workers
+	Tasks struct {
+		// Workers is how many tasks run concurrently; 0 only enqueues
+		Workers      int
+		PollInterval time.Duration
+		LockTimeout  time.Duration
+		MaxAttempts  int
+		// Retention is how long succeeded tasks are kept
+		Retention time.Duration
+	}
+	// Leader elects the one replica that runs scheduled jobs in multi-replica deployments
+	Leader struct {
+		// Backend is "none" (every replica runs the jobs), "redis" or "database"
+		Backend string
+		// LeaseTTL is how long a dead leader keeps the lease, i.e. the failover time
+		LeaseTTL time.Duration
+		// ID identifies this replica (hostname-pid when empty)
+		ID string
+	}
+	// Kafka runs the topic handlers of the modules in a consumer group
+	Kafka struct {
+		// Brokers are the bootstrap brokers; none leaves the consumer off
+		Brokers []string
+		// GroupID names the consumer group the replicas share partitions in
+		GroupID  string
+		ClientID string
+		// Version is the Kafka protocol version, e.g. 2.8.0
+		Version string
+		// Commit is "interval" (every CommitInterval) or "each" (after every message)
+		Commit         string
+		CommitInterval time.Duration
+		// Oldest starts a new group at the oldest message rather than the next one published
+		Oldest bool
+		// RetryDelays are the delays of the retry topics failed messages go through before
+		// the dead letter topic
+		RetryDelays    []time.Duration
+		HandlerTimeout time.Duration
+	}
+	// Outbox publishes domain events to Kafka through the outbox table
+	Outbox struct {
+		// Enabled records every domain event in the outbox; the relay needs Kafka.Brokers
+		Enabled bool
+		// Topic is the topic events are published to, keyed by their subject
+		Topic        string
+		BatchSize    int
+		PollInterval time.Duration
+		// MaxAttempts is how many failed sends set a message aside as poison
+		MaxAttempts int
+		// Retention is how long published messages are kept
+		Retention time.Duration
+	}
+	// Pools bounds how many webhook deliveries, notification deliveries and exports run at once
+	Pools struct {
+		Webhooks      PoolSettings
+		Notifications PoolSettings
+		Exports       PoolSettings
+	}
+	// Locks keeps invoice numbering, scheduled jobs and migrations to one replica at a time
+	Locks struct {
+		// Backend is "none" (single replica), "redis" or "postgres" (advisory locks on the
+		// database, which must then be Postgres)
+		Backend string
+	}
+	// QueryCache serves read-heavy repository reads, such as public profiles, from a cache
+	QueryCache struct {
+		// Backend is "none", "memory" (per replica) or "redis" (shared by the replicas)
+		Backend string
+		// TTL bounds how stale a cached read may be
+		TTL time.Duration
+	}
+	// Decorators stacks cross-cutting behavior around the use cases and repositories, in the
+	// order logging, metrics, the query cache (repositories) and a transaction (use cases)
+	Decorators struct {
+		// Logging logs every call with its duration and error
+		Logging bool
+		// Metrics exports operation_calls_total and operation_duration_seconds per call
+		Metrics bool
+		// Transactions runs every use case call in a database transaction
+		Transactions bool
+	}
+	// Sessions records the bearer tokens issued at login so they can be listed and revoked
+	Sessions struct {
+		// Backend is "database", "redis" or "none" (login disabled)
+		Backend string
+		// TTL is how long a token stays valid after login
+		TTL time.Duration
+	}
+	// LoginThrottle limits sign-in and password recovery attempts per client IP and per
+	// account, with a growing delay between an account's attempts
+	LoginThrottle struct {
+		// Backend is "memory" (per replica), "redis" (shared by the replicas) or "none"
+		Backend string
+		// IPLimit attempts are allowed per client IP within Window
+		IPLimit int
+		// AccountLimit attempts are allowed per account within Window; after
+		// AccountFreeAttempts each must wait AccountDelay, doubled per further attempt
+		AccountLimit        int
+		AccountFreeAttempts int
+		AccountDelay        time.Duration
+		Window              time.Duration
+	}
+	// APIKeys lets users call the API with keys of their own, each limited per minute and
+	// per calendar month
+	APIKeys struct {
+		// Backend counts the requests of each key: "memory" (per replica), "redis" (shared
+		// by the replicas) or "none" (API keys disabled)
+		Backend string
+		// RateLimit and MonthlyQuota are the limits of new keys, 0 being unlimited; admins
+		// can change them per key
+		RateLimit    int
+		MonthlyQuota int
+	}
+	// Metering counts the billable operations of each tenant and API key and reports them
+	// hourly for billing
+	Metering struct {
+		Enabled bool
+		// FlushInterval is how often each process writes the counts it buffers
+		FlushInterval time.Duration
+	}
+	// Captcha guards registration and password recovery against bots
+	Captcha struct {
+		// Provider is "recaptcha", "hcaptcha" or "none" (no challenge, e.g. in development and tests)
+		Provider string
+		// Secret is the provider's secret key
+		Secret string
+		// MinScore (0 to 1) rejects responses scored lower, for providers returning a score
+		MinScore float64
+	}
+	// Mail sends the password reset and email verification links
+	Mail struct {
+		// Provider is "smtp", "sendgrid", "ses", "log" (messages are logged, for development)
+		// or "none", which turns the flows mailing links off
+		Provider string
+		// From is the sender, e.g. "Shop <noreply@example.com>"
+		From string
+		// LinkURL is the front end the mailed links open, with the token in the token query
+		// parameter: <LinkURL>/reset-password and <LinkURL>/verify-email
+		LinkURL          string
+		PasswordResetTTL time.Duration
+		VerificationTTL  time.Duration
+		// MaxAttempts is how many times the task queue tries to send an email before it fails
+		MaxAttempts int
+		SMTP        struct {
+			Host     string
+			Port     string
+			Username string
+			Password string
+			// TLS is "starttls", "tls" (implicit, usually port 465) or "none"
+			TLS string
+			// PoolSize is how many connections are kept open between sends
+			PoolSize int
+			Timeout  time.Duration
+		}
+		SendGrid struct {
+			APIKey string
+			// WebhookVerificationKey is the public key of the signed event webhook; bounces
+			// and spam reports are received at /api/v1/users/email-feedback/sendgrid when set
+			WebhookVerificationKey string
+		}
+		SES struct {
+			Region          string
+			AccessKeyID     string
+			SecretAccessKey string
+			SessionToken    string
+			// ConfigurationSet is sent with every message, e.g. to publish its events
+			ConfigurationSet string
+			// TopicARNs are the SNS topics bounces and complaints are published to; their
+			// messages are received at /api/v1/users/email-feedback/ses when set
+			TopicARNs []string
+		}
+	}
+	// SMS texts the notifications users opt in to
+	SMS struct {
+		// Provider is "twilio", "log" (texts are logged, for development) or "none", which
+		// turns texting off
+		Provider string
+		Twilio   struct {
+			AccountSID string
+			AuthToken  string
+			// From is the sender number in E.164 format or the SID of a messaging service
+			From string
+			// WebhookURL is the public URL of /api/v1/users/sms-replies/twilio as configured
+			// on the number, when the server sees another one behind a proxy
+			WebhookURL string
+		}
+	}
+	// Push sends the notifications users opt in to to their registered devices
+	Push struct {
+		// Provider is "fcm", "log" (notifications are logged, for development) or "none",
+		// which turns push notifications off
+		Provider string
+		FCM      struct {
+			// CredentialsFile is the JSON key of a service account allowed to send messages
+			CredentialsFile string
+			// ProjectID overrides the Firebase project of the key
+			ProjectID string
+		}
+	}
+	// Search indexes users and orders in Elasticsearch or OpenSearch and searches them there,
+	// falling back to the database while it is unavailable
+	Search struct {
+		// Provider is "elasticsearch", "opensearch" or "none", which searches the database only
+		// The index holds names and emails in plaintext, even when they are encrypted at rest
+		Provider string
+		// URL is the base of the cluster's REST API, e.g. http://localhost:9200
+		URL      string
+		Username string
+		Password string
+		// IndexPrefix is prepended to index names, e.g. to share a cluster between environments
+		IndexPrefix string
+		Timeout     time.Duration
+	}
+	// Authz decides permissions with the policy stored in the database
+	Authz struct {
+		// ReloadInterval is how often the policy is reread to pick up changes made on
+		// other replicas; 0 never rereads it
+		ReloadInterval time.Duration
+	}
+	// Tenancy scopes every request to a tenant, named by the X-Tenant header or a subdomain
+	Tenancy struct {
+		// BaseDomain, e.g. example.com, serves tenants on its subdomains (acme.example.com);
+		// empty resolves tenants by header only
+		BaseDomain string
+	}
+	// Encryption encrypts personal data columns, such as user emails and names, at rest
+	Encryption struct {
+		// Keys are the key encryption keys as comma-separated id:base64 pairs of 32-byte
+		// keys; retired keys are kept until the reencrypt-pii job rewrote their values
+		Keys string
+		// ActiveKey is the ID of the key new values are encrypted with; empty writes plaintext
+		ActiveKey string
+		// IndexKey (base64, at least 16 bytes) keys the blind indexes users are looked up by
+		// email with; changing it rewrites every user at the next startup
+		IndexKey string
+	}
+	// Passwords are stored as bcrypt hashes
+	Passwords struct {
+		// HashCost is the bcrypt work factor of new hashes, 4 to 16; users signing in with
+		// a hash of a lower cost are rehashed
+		HashCost int
+	}
+	// PublicIDs names users and orders in routes and responses in place of their sequential IDs
+	PublicIDs struct {
+		// Format of new public IDs is "ulid" or "uuid" (version 7); existing ones keep theirs
+		Format string
+	}
+	// Storage keeps uploaded files such as avatars, and private files such as exports, on local disk
+	Storage struct {
+		Dir string
+		// BaseURL is where the files are served; a path such as /media is served by this
+		// server, a full URL points at a CDN or proxy in front of Dir
+		BaseURL string
+		// PrivateDir holds files downloadable only through signed, expiring URLs
+		PrivateDir string
+		// DownloadURL is where the signed downloads are served, the private files under
+		// /files and the uploaded ones under /uploads
+		DownloadURL string
+		// SigningKey signs the download URLs
+		SigningKey string
+		// FilesBackend keeps the private files in PrivateDir ("local") or an S3 bucket ("s3"),
+		// which large exports are streamed into as multipart uploads
+		FilesBackend string
+		S3           struct {
+			Bucket string
+			Region string
+			// Endpoint is the URL of an S3-compatible service such as MinIO; empty for AWS
+			Endpoint        string
+			AccessKeyID     string
+			SecretAccessKey string
+			SessionToken    string
+			// PartSize is the size of the parts of multipart uploads, at least 5 MiB
+			PartSize int
+			Timeout  time.Duration
+		}
+	}
+	// Redis is the deployment every Redis backend shares one client of
+	Redis struct {
+		// Mode is standalone, sentinel or cluster
 		Mode string
+		// Addrs are the server, the sentinels or the cluster seed nodes
+		Addrs        []string
+		MasterName   string
+		Username     string
+		Password     string
+		DB           int
+		PoolSize     int
+		MinIdleConns int
+		DialTimeout  time.Duration
+		ReadTimeout  time.Duration
+		WriteTimeout time.Duration
+		TLS          bool
+		// Instrument traces commands and reports pool metrics through OpenTelemetry
+		Instrument bool
+	}
+	Health struct {
+		Timeout      time.Duration
+		GRPCInterval time.Duration
 	}
+	// JWT signs the session tokens issued at login with keys stored in the database; the
+	// public RS256 and ES256 keys are published at /.well-known/jwks.json
 	JWT struct {
 		Secret string
+		// Algorithm of new signing keys: HS256, RS256 or ES256
+		Algorithm string
+		// Issuer is the iss claim of the tokens
+		Issuer string
+		// RotationInterval is how long a key signs before a new one replaces it; replaced
+		// keys verify until the tokens they signed expire. 0 never rotates
+		RotationInterval time.Duration
+		// ReloadInterval is how often keys rotated on other replicas are picked up
+		ReloadInterval time.Duration
 	}
 }
 
+// BreakerSettings configures the circuit breaker of one dependency
+type BreakerSettings struct {
+	// Failures is how many consecutive failures open the breaker
+	Failures int
+	// OpenTimeout is how long the breaker stays open before probing again
+	OpenTimeout time.Duration
+	// HalfOpenRequests is how many probes must succeed to close it again
+	HalfOpenRequests int
+}
+
+// PoolSettings configures the worker pool of one kind of work
+type PoolSettings struct {
+	// Size is how many tasks run at once; 0 leaves the pool out
+	Size int
+	// QueueDepth is how many tasks wait for a worker before Policy applies
+	QueueDepth int
+	// Policy is "block" (submitters wait for room in the queue) or "reject" (new tasks are
+	// turned away while it is full)
+	Policy string
+}
+
 // NewConfig creates a new configuration instance with values from environment variables
 func NewConfig() *Config {
 	cfg := &Config{}
 
 	// Database configuration
+	cfg.DB.Driver = getEnv("DB_DRIVER", "mysql")
 	cfg.DB.Host = getEnv("DB_HOST", "localhost")
 	cfg.DB.Port = getEnvAsInt("DB_PORT", 3306)
 	cfg.DB.User = getEnv("DB_USER", "root")
 	cfg.DB.Password = getEnv("DB_PASSWORD", "password")
 	cfg.DB.Name = getEnv("DB_NAME", "clean_arch_db")
+	cfg.DB.SSLMode = getEnv("DB_SSLMODE", "disable")
+	cfg.DB.LogLevel = getEnv("DB_LOG_LEVEL", "info")
+	cfg.DB.ConnectAttempts = getEnvAsInt("DB_CONNECT_ATTEMPTS", 5)
+	cfg.DB.ConnectBackoff = getEnvAsDuration("DB_CONNECT_BACKOFF", time.Second)
 
 	// Server configuration
 	cfg.Server.Port = getEnv("SERVER_PORT", "8080")
 	cfg.Server.Mode = getEnv("GIN_MODE", "debug")
+	cfg.Server.SwaggerUI = getEnvAsBool("SWAGGER_UI_ENABLED", false)
+	cfg.Server.AccessLog = getEnvAsBool("ACCESS_LOG_ENABLED", true)
+	cfg.Server.CORS = getEnvAsBool("CORS_ENABLED", false)
+	cfg.Server.ShutdownTimeout = getEnvAsDuration("SHUTDOWN_TIMEOUT", 30*time.Second)
+	cfg.Server.DrainDelay = getEnvAsDuration("SHUTDOWN_DRAIN_DELAY", 0)
+
+	// Admin/ops listener (health, metrics, pprof, admin routes) kept off the public port
+	cfg.Admin.Enabled = getEnvAsBool("ADMIN_ENABLED", true)
+	cfg.Admin.Port = getEnv("ADMIN_PORT", "8081")
+
+	// gRPC configuration
+	cfg.GRPC.Enabled = getEnvAsBool("GRPC_ENABLED", true)
+	cfg.GRPC.Port = getEnv("GRPC_PORT", "9090")
+	cfg.GRPC.Gateway = getEnvAsBool("GRPC_GATEWAY_ENABLED", true)
+
+	// GraphQL endpoint and its limits (0 uses the handler defaults)
+	cfg.GraphQL.Enabled = getEnvAsBool("GRAPHQL_ENABLED", true)
+	cfg.GraphQL.MaxDepth = getEnvAsInt("GRAPHQL_MAX_DEPTH", 0)
+	cfg.GraphQL.MaxComplexity = getEnvAsInt("GRAPHQL_MAX_COMPLEXITY", 0)
+
+	// Domain event configuration (CloudEvents attributes)
+	cfg.Events.Source = getEnv("EVENTS_SOURCE", "/clean-arch-gin")
+	cfg.Events.TypePrefix = getEnv("EVENTS_TYPE_PREFIX", "")
+	cfg.Events.LogCloudEvents = getEnvAsBool("EVENTS_LOG_CLOUDEVENTS", false)
+
+	// Circuit breakers per dependency
+	cfg.Breaker.Database = BreakerSettings{
+		Failures:         getEnvAsInt("BREAKER_DB_FAILURES", 5),
+		OpenTimeout:      getEnvAsDuration("BREAKER_DB_OPEN_TIMEOUT", 10*time.Second),
+		HalfOpenRequests: getEnvAsInt("BREAKER_DB_HALF_OPEN_REQUESTS", 1),
+	}
+	cfg.Breaker.Webhook = BreakerSettings{
+		Failures:         getEnvAsInt("BREAKER_WEBHOOK_FAILURES", 5),
+		OpenTimeout:      getEnvAsDuration("BREAKER_WEBHOOK_OPEN_TIMEOUT", time.Minute),
+		HalfOpenRequests: 1,
+	}
+	cfg.Breaker.Search = BreakerSettings{
+		Failures:         getEnvAsInt("BREAKER_SEARCH_FAILURES", 5),
+		OpenTimeout:      getEnvAsDuration("BREAKER_SEARCH_OPEN_TIMEOUT", 30*time.Second),
+		HalfOpenRequests: 1,
+	}
+
+	// Orders
+	cfg.Orders.ReservationTTL = getEnvAsDuration("ORDER_RESERVATION_TTL", 30*time.Minute)
+	cfg.Orders.WebhookEvents = getEnv("ORDER_WEBHOOK_EVENTS", "order.confirmed,order.shipped,order.cancelled")
+
+	// Payments
+	cfg.Payments.Gateway = getEnv("PAYMENT_GATEWAY", "manual")
+
+	// Pricing
+	cfg.Pricing.TaxCalculator = getEnv("TAX_CALCULATOR", "flat")
+	cfg.Pricing.TaxRate = getEnvAsFloat("TAX_RATE", 0)
+	cfg.Pricing.TaxRegionRates = getEnv("TAX_REGION_RATES", "")
+	cfg.Pricing.TaxAPIURL = getEnv("TAX_API_URL", "")
+	cfg.Pricing.TaxAPIKey = getEnv("TAX_API_KEY", "")
+	cfg.Pricing.Shipping = getEnv("SHIPPING_STRATEGY", "flat")
+	cfg.Pricing.ShippingRate = getEnvAsFloat("SHIPPING_RATE", 0)
+	cfg.Pricing.ShippingPerItem = getEnvAsFloat("SHIPPING_PER_ITEM", 0)
+	cfg.Pricing.FreeShippingOver = getEnvAsFloat("FREE_SHIPPING_OVER", 0)
+
+	// Currency
+	cfg.Currency.ExchangeRates = getEnv("EXCHANGE_RATE_PROVIDER", "none")
+	cfg.Currency.OpenExchangeRatesAppID = getEnv("OPENEXCHANGERATES_APP_ID", "")
+	cfg.Currency.RatesTTL = getEnvAsDuration("EXCHANGE_RATE_TTL", time.Hour)
+
+	// Documents
+	cfg.Documents.PDFGenerator = getEnv("PDF_GENERATOR", "builtin")
+
+	// Scheduled jobs
+	cfg.Scheduler.Enabled = getEnvAsBool("SCHEDULER_ENABLED", true)
+	cfg.Scheduler.JobTimeout = getEnvAsDuration("SCHEDULER_JOB_TIMEOUT", 5*time.Minute)
+	cfg.Scheduler.HistoryRetention = getEnvAsDuration("SCHEDULER_HISTORY_RETENTION", 30*24*time.Hour)
+
+	// Durable task queue
+	cfg.Tasks.Workers = getEnvAsInt("TASK_WORKERS", 4)
+	cfg.Tasks.PollInterval = getEnvAsDuration("TASK_POLL_INTERVAL", time.Second)
+	cfg.Tasks.LockTimeout = getEnvAsDuration("TASK_LOCK_TIMEOUT", 5*time.Minute)
+	cfg.Tasks.MaxAttempts = getEnvAsInt("TASK_MAX_ATTEMPTS", 5)
+	cfg.Tasks.Retention = getEnvAsDuration("TASK_RETENTION", 7*24*time.Hour)
+
+	// Leader election for scheduled jobs
+	cfg.Leader.Backend = getEnv("LEADER_ELECTION", "none")
+	cfg.Leader.LeaseTTL = getEnvAsDuration("LEADER_LEASE_TTL", 15*time.Second)
+	cfg.Leader.ID = getEnv("LEADER_ID", "")
+
+	// Distributed locks
+	cfg.Locks.Backend = getEnv("LOCK_BACKEND", "none")
+
+	// Kafka consumer group
+	cfg.Kafka.Brokers = getEnvAsList("KAFKA_BROKERS")
+	cfg.Kafka.GroupID = getEnv("KAFKA_GROUP_ID", "clean-arch-gin")
+	cfg.Kafka.ClientID = getEnv("KAFKA_CLIENT_ID", "")
+	cfg.Kafka.Version = getEnv("KAFKA_VERSION", "")
+	cfg.Kafka.Commit = getEnv("KAFKA_COMMIT", "interval")
+	cfg.Kafka.CommitInterval = getEnvAsDuration("KAFKA_COMMIT_INTERVAL", time.Second)
+	cfg.Kafka.Oldest = getEnvAsBool("KAFKA_OLDEST", false)
+	cfg.Kafka.RetryDelays = getEnvAsDurations("KAFKA_RETRY_DELAYS", []time.Duration{10 * time.Second, time.Minute, 10 * time.Minute})
+	cfg.Kafka.HandlerTimeout = getEnvAsDuration("KAFKA_HANDLER_TIMEOUT", 30*time.Second)
+
+	// Transactional outbox
+	cfg.Outbox.Enabled = getEnvAsBool("OUTBOX_ENABLED", false)
+	cfg.Outbox.Topic = getEnv("OUTBOX_TOPIC", "domain-events")
+	cfg.Outbox.BatchSize = getEnvAsInt("OUTBOX_BATCH_SIZE", 100)
+	cfg.Outbox.PollInterval = getEnvAsDuration("OUTBOX_POLL_INTERVAL", time.Second)
+	cfg.Outbox.MaxAttempts = getEnvAsInt("OUTBOX_MAX_ATTEMPTS", 20)
+	cfg.Outbox.Retention = getEnvAsDuration("OUTBOX_RETENTION", 7*24*time.Hour)
+
+	// Worker pools
+	cfg.Pools.Webhooks = PoolSettings{
+		Size:       getEnvAsInt("WEBHOOK_POOL_SIZE", 8),
+		QueueDepth: getEnvAsInt("WEBHOOK_POOL_QUEUE_DEPTH", 50),
+		Policy:     getEnv("WEBHOOK_POOL_POLICY", "block"),
+	}
+	cfg.Pools.Notifications = PoolSettings{
+		Size:       getEnvAsInt("NOTIFICATION_POOL_SIZE", 4),
+		QueueDepth: getEnvAsInt("NOTIFICATION_POOL_QUEUE_DEPTH", 100),
+		Policy:     getEnv("NOTIFICATION_POOL_POLICY", "reject"),
+	}
+	cfg.Pools.Exports = PoolSettings{
+		Size:       getEnvAsInt("EXPORT_POOL_SIZE", 2),
+		QueueDepth: getEnvAsInt("EXPORT_POOL_QUEUE_DEPTH", 0),
+		Policy:     getEnv("EXPORT_POOL_POLICY", "reject"),
+	}
+
+	// Read-through query cache
+	cfg.QueryCache.Backend = getEnv("QUERY_CACHE_BACKEND", "none")
+	cfg.QueryCache.TTL = getEnvAsDuration("QUERY_CACHE_TTL", time.Minute)
+
+	// Use case and repository decorators
+	cfg.Decorators.Logging = getEnvAsBool("DECORATE_LOGGING", false)
+	cfg.Decorators.Metrics = getEnvAsBool("DECORATE_METRICS", false)
+	cfg.Decorators.Transactions = getEnvAsBool("DECORATE_TRANSACTIONS", false)
+
+	// Server-side sessions
+	cfg.Sessions.Backend = getEnv("SESSION_BACKEND", "database")
+	cfg.Sessions.TTL = getEnvAsDuration("SESSION_TTL", 30*24*time.Hour)
+
+	// Sign-in throttling
+	cfg.LoginThrottle.Backend = getEnv("LOGIN_THROTTLE_BACKEND", "memory")
+	cfg.LoginThrottle.IPLimit = getEnvAsInt("LOGIN_THROTTLE_IP_LIMIT", 50)
+	cfg.LoginThrottle.AccountLimit = getEnvAsInt("LOGIN_THROTTLE_ACCOUNT_LIMIT", 10)
+	cfg.LoginThrottle.AccountFreeAttempts = getEnvAsInt("LOGIN_THROTTLE_ACCOUNT_FREE_ATTEMPTS", 3)
+	cfg.LoginThrottle.AccountDelay = getEnvAsDuration("LOGIN_THROTTLE_ACCOUNT_DELAY", time.Second)
+	cfg.LoginThrottle.Window = getEnvAsDuration("LOGIN_THROTTLE_WINDOW", 15*time.Minute)
+
+	// API keys
+	cfg.APIKeys.Backend = getEnv("API_KEY_COUNTER_BACKEND", "memory")
+	cfg.APIKeys.RateLimit = getEnvAsInt("API_KEY_RATE_LIMIT", 60)
+	cfg.APIKeys.MonthlyQuota = getEnvAsInt("API_KEY_MONTHLY_QUOTA", 100000)
+
+	// Usage metering
+	cfg.Metering.Enabled = getEnvAsBool("METERING_ENABLED", true)
+	cfg.Metering.FlushInterval = getEnvAsDuration("METERING_FLUSH_INTERVAL", 10*time.Second)
+
+	// CAPTCHA on abuse-prone public endpoints
+	cfg.Captcha.Provider = getEnv("CAPTCHA_PROVIDER", "none")
+	cfg.Captcha.Secret = getEnv("CAPTCHA_SECRET", "")
+	cfg.Captcha.MinScore = getEnvAsFloat("CAPTCHA_MIN_SCORE", 0.5)
+
+	// Transactional mail
+	cfg.Mail.Provider = getEnv("MAIL_PROVIDER", "log")
+	cfg.Mail.From = getEnv("MAIL_FROM", "Clean Arch Gin <noreply@example.com>")
+	cfg.Mail.LinkURL = getEnv("MAIL_LINK_URL", "http://localhost:3000")
+	cfg.Mail.PasswordResetTTL = getEnvAsDuration("PASSWORD_RESET_TTL", time.Hour)
+	cfg.Mail.VerificationTTL = getEnvAsDuration("EMAIL_VERIFICATION_TTL", 48*time.Hour)
+	cfg.Mail.MaxAttempts = getEnvAsInt("MAIL_MAX_ATTEMPTS", 8)
+	cfg.Mail.SMTP.Host = getEnv("SMTP_HOST", "")
+	cfg.Mail.SMTP.Port = getEnv("SMTP_PORT", "587")
+	cfg.Mail.SMTP.Username = getEnv("SMTP_USERNAME", "")
+	cfg.Mail.SMTP.Password = getEnv("SMTP_PASSWORD", "")
+	cfg.Mail.SMTP.TLS = getEnv("SMTP_TLS", "starttls")
+	cfg.Mail.SMTP.PoolSize = getEnvAsInt("SMTP_POOL_SIZE", 2)
+	cfg.Mail.SMTP.Timeout = getEnvAsDuration("SMTP_TIMEOUT", 30*time.Second)
+	cfg.Mail.SendGrid.APIKey = getEnv("SENDGRID_API_KEY", "")
+	cfg.Mail.SendGrid.WebhookVerificationKey = getEnv("SENDGRID_WEBHOOK_VERIFICATION_KEY", "")
+	cfg.Mail.SES.Region = getEnv("SES_REGION", getEnv("AWS_REGION", "us-east-1"))
+	cfg.Mail.SES.AccessKeyID = getEnv("AWS_ACCESS_KEY_ID", "")
+	cfg.Mail.SES.SecretAccessKey = getEnv("AWS_SECRET_ACCESS_KEY", "")
+	cfg.Mail.SES.SessionToken = getEnv("AWS_SESSION_TOKEN", "")
+	cfg.Mail.SES.ConfigurationSet = getEnv("SES_CONFIGURATION_SET", "")
+	cfg.Mail.SES.TopicARNs = getEnvAsList("SES_FEEDBACK_TOPIC_ARNS")
+	cfg.SMS.Provider = getEnv("SMS_PROVIDER", "none")
+	cfg.SMS.Twilio.AccountSID = getEnv("TWILIO_ACCOUNT_SID", "")
+	cfg.SMS.Twilio.AuthToken = getEnv("TWILIO_AUTH_TOKEN", "")
+	cfg.SMS.Twilio.From = getEnv("TWILIO_FROM", "")
+	cfg.SMS.Twilio.WebhookURL = getEnv("TWILIO_WEBHOOK_URL", "")
+	cfg.Push.Provider = getEnv("PUSH_PROVIDER", "none")
+	cfg.Push.FCM.CredentialsFile = getEnv("FCM_CREDENTIALS_FILE", "")
+	cfg.Push.FCM.ProjectID = getEnv("FCM_PROJECT_ID", "")
+
+	// Search engine
+	cfg.Search.Provider = getEnv("SEARCH_PROVIDER", "none")
+	cfg.Search.URL = getEnv("SEARCH_URL", "http://localhost:9200")
+	cfg.Search.Username = getEnv("SEARCH_USERNAME", "")
+	cfg.Search.Password = getEnv("SEARCH_PASSWORD", "")
+	cfg.Search.IndexPrefix = getEnv("SEARCH_INDEX_PREFIX", "")
+	cfg.Search.Timeout = getEnvAsDuration("SEARCH_TIMEOUT", 5*time.Second)
+
+	// Authorization policy
+	cfg.Authz.ReloadInterval = getEnvAsDuration("AUTHZ_RELOAD_INTERVAL", time.Minute)
+
+	// Multi-tenancy
+	cfg.Tenancy.BaseDomain = getEnv("TENANT_BASE_DOMAIN", "")
+
+	// Field-level encryption of personal data
+	cfg.Encryption.Keys = getEnv("PII_ENCRYPTION_KEYS", "")
+	cfg.Encryption.ActiveKey = getEnv("PII_ACTIVE_KEY", "")
+	cfg.Encryption.IndexKey = getEnv("PII_INDEX_KEY", "")
+
+	// Public IDs
+	cfg.PublicIDs.Format = getEnv("PUBLIC_ID_FORMAT", "ulid")
+
+	// Password hashing
+	cfg.Passwords.HashCost = getEnvAsInt("PASSWORD_HASH_COST", 10)
+
+	// Uploaded file storage
+	cfg.Storage.Dir = getEnv("STORAGE_DIR", "./data/uploads")
+	cfg.Storage.BaseURL = getEnv("STORAGE_BASE_URL", "/media")
+	cfg.Storage.PrivateDir = getEnv("STORAGE_PRIVATE_DIR", "./data/private")
+	cfg.Storage.DownloadURL = getEnv("STORAGE_DOWNLOAD_URL", "/downloads")
+	cfg.Storage.SigningKey = getEnv("STORAGE_SIGNING_KEY", getEnv("JWT_SECRET", "default-secret-key"))
+	cfg.Storage.FilesBackend = getEnv("STORAGE_FILES_BACKEND", "local")
+	cfg.Storage.S3.Bucket = getEnv("STORAGE_S3_BUCKET", "")
+	cfg.Storage.S3.Region = getEnv("STORAGE_S3_REGION", getEnv("AWS_REGION", "us-east-1"))
+	cfg.Storage.S3.Endpoint = getEnv("STORAGE_S3_ENDPOINT", "")
+	cfg.Storage.S3.AccessKeyID = getEnv("AWS_ACCESS_KEY_ID", "")
+	cfg.Storage.S3.SecretAccessKey = getEnv("AWS_SECRET_ACCESS_KEY", "")
+	cfg.Storage.S3.SessionToken = getEnv("AWS_SESSION_TOKEN", "")
+	cfg.Storage.S3.PartSize = getEnvAsInt("STORAGE_S3_PART_SIZE", 8<<20)
+	cfg.Storage.S3.Timeout = getEnvAsDuration("STORAGE_S3_TIMEOUT", time.Minute)
+
+	// Redis (shared by the redis leader election, lock, session, login throttle, API key
+	// counter and query cache backends)
+	cfg.Redis.Mode = getEnv("REDIS_MODE", "standalone")
+	cfg.Redis.Addrs = getEnvAsList("REDIS_ADDR")
+	if len(cfg.Redis.Addrs) == 0 {
+		cfg.Redis.Addrs = []string{"localhost:6379"}
+	}
+	cfg.Redis.MasterName = getEnv("REDIS_MASTER_NAME", "")
+	cfg.Redis.Username = getEnv("REDIS_USERNAME", "")
+	cfg.Redis.Password = getEnv("REDIS_PASSWORD", "")
+	cfg.Redis.DB = getEnvAsInt("REDIS_DB", 0)
+	cfg.Redis.PoolSize = getEnvAsInt("REDIS_POOL_SIZE", 0)
+	cfg.Redis.MinIdleConns = getEnvAsInt("REDIS_MIN_IDLE_CONNS", 0)
+	cfg.Redis.DialTimeout = getEnvAsDuration("REDIS_DIAL_TIMEOUT", 5*time.Second)
+	cfg.Redis.ReadTimeout = getEnvAsDuration("REDIS_READ_TIMEOUT", 3*time.Second)
+	cfg.Redis.WriteTimeout = getEnvAsDuration("REDIS_WRITE_TIMEOUT", 3*time.Second)
+	cfg.Redis.TLS = getEnvAsBool("REDIS_TLS", false)
+	cfg.Redis.Instrument = getEnvAsBool("REDIS_INSTRUMENT", true)
+
+	// Health checks (per-check timeout and gRPC health status refresh)
+	cfg.Health.Timeout = getEnvAsDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second)
+	cfg.Health.GRPCInterval = getEnvAsDuration("HEALTH_GRPC_INTERVAL", 10*time.Second)
 
 	// JWT configuration
 	cfg.JWT.Secret = getEnv("JWT_SECRET", "default-secret-key")
+	cfg.JWT.Algorithm = getEnv("JWT_ALGORITHM", "HS256")
+	cfg.JWT.Issuer = getEnv("JWT_ISSUER", "clean-arch-gin")
+	cfg.JWT.RotationInterval = getEnvAsDuration("JWT_ROTATION_INTERVAL", 30*24*time.Hour)
+	cfg.JWT.ReloadInterval = getEnvAsDuration("JWT_RELOAD_INTERVAL", time.Minute)
 
 	return cfg
 }
@@ -61,3 +752,65 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvAsFloat gets an environment variable as float with a default fallback
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsBool gets an environment variable as boolean with a default fallback
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsList gets a comma-separated environment variable as its trimmed, non-empty items
+func getEnvAsList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvAsDuration gets an environment variable as duration (e.g. "5s") with a default fallback
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if durationValue, err := time.ParseDuration(value); err == nil {
+			return durationValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsDurations gets a comma-separated environment variable as durations with a default
+// fallback; "none" is no durations
+func getEnvAsDurations(key string, defaultValue []time.Duration) []time.Duration {
+	items := getEnvAsList(key)
+	if len(items) == 0 {
+		return defaultValue
+	}
+	if len(items) == 1 && items[0] == "none" {
+		return nil
+	}
+	durations := make([]time.Duration, 0, len(items))
+	for _, item := range items {
+		duration, err := time.ParseDuration(item)
+		if err != nil {
+			return defaultValue
+		}
+		durations = append(durations, duration)
+	}
+	return durations
+}
@@ -0,0 +1,31 @@
+package database
+
+import (
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+)
+
+// WriteInBatches writes n records size at a time with batch, which must be all or nothing
+// A batch that fails is retried a record at a time with single so each failure is reported
+// against its record in a *BatchError; nil means every record was written
+func WriteInBatches(n, size int, batch func(from, to int) error, single func(i int) error) error {
+	if size <= 0 {
+		size = n
+	}
+
+	var failed []sharedEntities.RecordError
+	for from := 0; from < n; from += size {
+		to := min(from+size, n)
+		if err := batch(from, to); err == nil {
+			continue
+		}
+		for i := from; i < to; i++ {
+			if err := single(i); err != nil {
+				failed = append(failed, sharedEntities.RecordError{Index: i, Err: err})
+			}
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return &sharedEntities.BatchError{Total: n, Records: failed}
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"errors"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/infrastructure/breaker"
+	"clean-arch-gin/internal/infrastructure/config"
+
+	"gorm.io/gorm"
+)
+
+// BreakerName names the database circuit breaker in errors and metrics
+const BreakerName = "database"
+
+// NewCircuitBreaker creates the breaker shared by every repository on the database
+func NewCircuitBreaker(settings config.BreakerSettings) *breaker.CircuitBreaker {
+	return breaker.New(breaker.Settings{
+		Name:         BreakerName,
+		MaxRequests:  uint32(settings.HalfOpenRequests),
+		Timeout:      settings.OpenTimeout,
+		ReadyToTrip:  breaker.ConsecutiveFailures(uint32(settings.Failures)),
+		IsSuccessful: IsHealthyResult,
+	})
+}
+
+// IsHealthyResult reports whether a repository result shows the database working
+// Domain errors and missing records are answers, not failures of the database, and so is
+// a batch write that got some of its records through
+func IsHealthyResult(err error) bool {
+	var batchErr *sharedEntities.BatchError
+	if errors.As(err, &batchErr) && len(batchErr.Records) > 0 {
+		return len(batchErr.Records) < batchErr.Total || IsHealthyResult(batchErr.Records[0].Err)
+	}
+	var domainErr sharedEntities.DomainError
+	return err == nil || errors.As(err, &domainErr) || errors.Is(err, gorm.ErrRecordNotFound)
+}
@@ -1,30 +1,38 @@
 package database
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/retry"
 
+	"github.com/glebarez/sqlite"
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
 // NewConnection creates a new database connection
+// The database often starts alongside the app (docker-compose, Kubernetes), so
+// connecting is retried with backoff up to DB_CONNECT_ATTEMPTS times
 func NewConnection(cfg *config.Config) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		cfg.DB.User,
-		cfg.DB.Password,
-		cfg.DB.Host,
-		cfg.DB.Port,
-		cfg.DB.Name,
-	)
-
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
-	})
+	dialector, err := newDialector(cfg)
+	if err != nil {
+		return nil, err
+	}
 
+	var db *gorm.DB
+	err = retry.Do(context.Background(), connectPolicy(cfg), func(ctx context.Context) error {
+		db, err = open(ctx, dialector, cfg)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -33,6 +41,110 @@ func NewConnection(cfg *config.Config) (*gorm.DB, error) {
 	return db, nil
 }
 
+// connectPolicy retries connecting, except when the server rejects the credentials or database
+func connectPolicy(cfg *config.Config) retry.Policy {
+	return retry.Policy{
+		MaxAttempts:  cfg.DB.ConnectAttempts,
+		InitialDelay: cfg.DB.ConnectBackoff,
+		MaxDelay:     30 * time.Second,
+		Retryable:    isRetryableConnectError,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			log.Printf("Database connection attempt %d failed, retrying in %s: %v", attempt, delay.Round(time.Millisecond), err)
+		},
+	}
+}
+
+// open opens the pool, scoped to the tenant and the soft-deleted rows asked for by each
+// statement's context, and pings it so an unreachable server fails this attempt
+func open(ctx context.Context, dialector gorm.Dialector, cfg *config.Config) (*gorm.DB, error) {
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: logger.Default.LogMode(parseLogLevel(cfg.DB.LogLevel)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Use(TenantScope{}); err != nil {
+		return nil, err
+	}
+	if err := db.Use(SoftDeleteScope{}); err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// isRetryableConnectError reports whether connecting again may succeed
+// Access denied and unknown database errors need a configuration change instead
+func isRetryableConnectError(err error) bool {
+	var mysqlErr *mysqlDriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case 1044, 1045, 1049:
+			return false
+		}
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "28000", "28P01", "3D000":
+			return false
+		}
+	}
+	return true
+}
+
+// newDialector selects the GORM dialector for the configured driver
+// SQLite treats DB_NAME as a file path (or ":memory:") and is meant for local runs and tests
+func newDialector(cfg *config.Config) (gorm.Dialector, error) {
+	switch cfg.DB.Driver {
+	case "", "mysql":
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+			cfg.DB.User,
+			cfg.DB.Password,
+			cfg.DB.Host,
+			cfg.DB.Port,
+			cfg.DB.Name,
+		)
+		return mysql.Open(dsn), nil
+	case "postgres":
+		dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s TimeZone=UTC",
+			cfg.DB.Host,
+			cfg.DB.Port,
+			cfg.DB.User,
+			cfg.DB.Password,
+			cfg.DB.Name,
+			cfg.DB.SSLMode,
+		)
+		return postgres.Open(dsn), nil
+	case "sqlite":
+		return sqlite.Open(cfg.DB.Name), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", cfg.DB.Driver)
+	}
+}
+
+// parseLogLevel maps DB_LOG_LEVEL to a GORM logger level, defaulting to info
+func parseLogLevel(level string) logger.LogLevel {
+	switch level {
+	case "silent":
+		return logger.Silent
+	case "error":
+		return logger.Error
+	case "warn":
+		return logger.Warn
+	default:
+		return logger.Info
+	}
+}
+
 // AutoMigrate runs database migrations for the given models
 func AutoMigrate(db *gorm.DB, models ...interface{}) error {
 	if err := db.AutoMigrate(models...); err != nil {
@@ -41,3 +153,12 @@ func AutoMigrate(db *gorm.DB, models ...interface{}) error {
 	log.Println("Database migration completed successfully")
 	return nil
 }
+
+// Close closes the connection pool once in-flight queries are done
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
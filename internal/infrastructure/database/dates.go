@@ -0,0 +1,52 @@
+package database
+
+import "gorm.io/gorm"
+
+// Period is a span of time rows are grouped by
+type Period string
+
+// Periods DateBucket groups by
+const (
+	PeriodDay Period = "day"
+	// PeriodWeek starts on Monday, as ISO weeks do
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+// DateBucket is the SQL expression of the first day of the period column falls in, as
+// YYYY-MM-DD, for grouping rows by day, week or month in the database
+// Days are those of the time zone timestamps are stored in: UTC on Postgres and SQLite,
+// and the server's on MySQL, which is connected with loc=Local
+func DateBucket(db *gorm.DB, column string, period Period) string {
+	switch db.Dialector.Name() {
+	case "postgres":
+		utc := "(" + column + " AT TIME ZONE 'UTC')"
+		switch period {
+		case PeriodWeek:
+			return "to_char(date_trunc('week', " + utc + "), 'YYYY-MM-DD')"
+		case PeriodMonth:
+			return "to_char(" + utc + ", 'YYYY-MM-01')"
+		default:
+			return "to_char(" + utc + ", 'YYYY-MM-DD')"
+		}
+	case "sqlite":
+		switch period {
+		case PeriodWeek:
+			// The coming Sunday, or the day itself on one, then back to its Monday
+			return "date(" + column + ", 'weekday 0', '-6 days')"
+		case PeriodMonth:
+			return "strftime('%Y-%m-01', " + column + ")"
+		default:
+			return "date(" + column + ")"
+		}
+	default:
+		switch period {
+		case PeriodWeek:
+			return "DATE_FORMAT(DATE_SUB(DATE(" + column + "), INTERVAL WEEKDAY(" + column + ") DAY), '%Y-%m-%d')"
+		case PeriodMonth:
+			return "DATE_FORMAT(" + column + ", '%Y-%m-01')"
+		default:
+			return "DATE_FORMAT(" + column + ", '%Y-%m-%d')"
+		}
+	}
+}
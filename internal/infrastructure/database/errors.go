@@ -0,0 +1,21 @@
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// IsDuplicateKey reports whether err, returned by a statement on db, violates a unique index
+// It reads the driver's error through the dialect of db, so it works whether or not the
+// connection translates errors
+func IsDuplicateKey(db *gorm.DB, err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	translator, ok := db.Dialector.(gorm.ErrorTranslator)
+	return ok && errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey)
+}
@@ -0,0 +1,61 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/testutil/repotest"
+
+	"gorm.io/gorm"
+)
+
+// couponCode is a table with a unique column to violate
+type couponCode struct {
+	ID   uint   `gorm:"primaryKey"`
+	Code string `gorm:"not null;uniqueIndex"`
+}
+
+func TestIsDuplicateKey(t *testing.T) {
+	db := repotest.OpenSQLite(t)
+	if err := db.AutoMigrate(&couponCode{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	if err := db.Create(&couponCode{Code: "SPRING"}).Error; err != nil {
+		t.Fatalf("failed to seed: %v", err)
+	}
+	ctx := context.Background()
+
+	// Errors reported through GORM and straight from the driver, which GORM never translated
+	gormErr := db.Create(&couponCode{Code: "SPRING"}).Error
+	_, driverErr := db.ConnPool.ExecContext(ctx, "INSERT INTO coupon_codes (code) VALUES (?)", "SPRING")
+	_, notNullErr := db.ConnPool.ExecContext(ctx, "INSERT INTO coupon_codes (code) VALUES (NULL)")
+	for _, err := range []error{gormErr, driverErr, notNullErr} {
+		if err == nil {
+			t.Fatal("a statement violating a constraint succeeded")
+		}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"unique violation through GORM", gormErr, true},
+		{"unique violation from the driver", driverErr, true},
+		{"wrapped ErrDuplicatedKey", fmt.Errorf("failed to save: %w", gorm.ErrDuplicatedKey), true},
+		{"not null violation", notNullErr, false},
+		{"record not found", gorm.ErrRecordNotFound, false},
+		{"other error", errors.New("connection reset"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := database.IsDuplicateKey(db, tt.err); got != tt.want {
+				t.Errorf("IsDuplicateKey(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
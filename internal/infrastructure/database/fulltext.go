@@ -0,0 +1,72 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HasFullText reports whether db's dialect has full-text search; SQLite is built without it
+func HasFullText(db *gorm.DB) bool {
+	switch db.Dialector.Name() {
+	case "mysql", "postgres":
+		return true
+	default:
+		return false
+	}
+}
+
+// TSVector is the Postgres text search vector of columns, the expression their full-text
+// index is built on and searches match so they use it. The simple configuration neither
+// stems nor drops stop words, which suits names, and emails are split at @ and dots so
+// their parts are words as on MySQL
+func TSVector(columns []string) string {
+	coalesced := make([]string, len(columns))
+	for i, column := range columns {
+		coalesced[i] = "coalesce(" + column + ", '')"
+	}
+	return "to_tsvector('simple', translate(" + strings.Join(coalesced, " || ' ' || ") + ", '@.', '  '))"
+}
+
+// MatchFullText filters query to the rows whose columns have a word starting with every
+// term, through their full-text index, and returns the expression of the rows' relevance
+// Terms must be plain words of letters and digits: they are not escaped from the full-text
+// query syntax. The dialect must have full-text search (see HasFullText)
+func MatchFullText(query *gorm.DB, columns, terms []string) (*gorm.DB, clause.Expr) {
+	var score clause.Expr
+	switch query.Dialector.Name() {
+	case "postgres":
+		prefixes := make([]string, len(terms))
+		for i, term := range terms {
+			prefixes[i] = term + ":*"
+		}
+		tsquery := strings.Join(prefixes, " & ")
+		query = query.Where(TSVector(columns)+" @@ to_tsquery('simple', ?)", tsquery)
+		score = clause.Expr{SQL: "ts_rank(" + TSVector(columns) + ", to_tsquery('simple', ?))", Vars: []interface{}{tsquery}}
+	default:
+		required := make([]string, len(terms))
+		for i, term := range terms {
+			required[i] = "+" + term + "*"
+		}
+		match := "MATCH (" + strings.Join(columns, ", ") + ") AGAINST (? IN BOOLEAN MODE)"
+		against := strings.Join(required, " ")
+		query = query.Where(match, against)
+		score = clause.Expr{SQL: match, Vars: []interface{}{against}}
+	}
+	return query, score
+}
+
+// createFullTextIndex creates the full-text index of index's columns: a FULLTEXT index on
+// MySQL and a GIN index of their TSVector on Postgres
+func createFullTextIndex(db *gorm.DB, index Index) error {
+	if db.Dialector.Name() == "postgres" {
+		return db.Exec("CREATE INDEX ? ON ? USING GIN ("+TSVector(index.Columns)+")",
+			clause.Column{Name: index.Name}, clause.Table{Name: index.Table}).Error
+	}
+	columns := make([]interface{}, len(index.Columns))
+	for i, column := range index.Columns {
+		columns[i] = clause.Column{Name: column}
+	}
+	return db.Exec("CREATE FULLTEXT INDEX ? ON ? ?", clause.Column{Name: index.Name}, clause.Table{Name: index.Table}, columns).Error
+}
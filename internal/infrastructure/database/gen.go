@@ -1,20 +1,53 @@
 //go:build ignore
 
+// Command gen generates the GORM Gen query code of the models every module declares with
+// modules.QueryDeclarer, connecting to the database of the application configuration
+// (DB_DRIVER mysql, postgres or sqlite). go generate runs it in the query package:
+//
+//	go generate ./internal/infrastructure/database/query
 package main
 
 import (
-	"clean-arch-gin/internal/adapters/shared/models"
+	"flag"
+	"log"
+	"reflect"
+	"sort"
 
-	"gorm.io/driver/mysql"
+	"clean-arch-gin/internal/di"
+	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"github.com/joho/godotenv"
 	"gorm.io/gen"
-	"gorm.io/gorm"
 )
 
-// generateCode generates type-safe database code using GORM Gen
 func main() {
-	// Initialize GORM Gen
+	out := flag.String("out", "internal/infrastructure/database/query", "directory of the generated query package")
+	env := flag.String("env", ".env", "environment file read before the configuration, when it exists")
+	flag.Parse()
+
+	if err := godotenv.Load(*env); err != nil {
+		log.Println("No .env file found, using system environment variables")
+	}
+	cfg := config.NewConfig()
+
+	// The modules, and the database their models are introspected on, come from the same
+	// graph as the server
+	application, closeApplication, err := di.InitializeApplication(cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize application:", err)
+	}
+	defer closeApplication()
+
+	manifests := application.Registry.QueryManifests()
+	names := make([]string, 0, len(manifests))
+	for name := range manifests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
 	g := gen.NewGenerator(gen.Config{
-		OutPath:           "./internal/infrastructure/database/query", // Output directory
+		OutPath:           *out,
 		Mode:              gen.WithoutContext | gen.WithDefaultQuery | gen.WithQueryInterface,
 		FieldNullable:     true,
 		FieldCoverable:    false,
@@ -22,45 +55,31 @@ func main() {
 		FieldWithIndexTag: false,
 		FieldWithTypeTag:  true,
 	})
+	g.UseDB(application.DB)
 
-	// Connect to database for schema introspection
-	// This can use a test database or the actual database
-	db, err := gorm.Open(mysql.Open("user:password@tcp(localhost:3306)/clean_arch_db?charset=utf8mb4&parseTime=True&loc=Local"), &gorm.Config{})
-	if err != nil {
-		panic("failed to connect database for code generation")
+	// Modules sharing a model declare it once between them
+	seen := make(map[reflect.Type]bool)
+	for _, name := range names {
+		manifest := manifests[name]
+		var models []interface{}
+		for _, model := range manifest.Models {
+			if t := reflect.TypeOf(model); !seen[t] {
+				seen[t] = true
+				models = append(models, model)
+			}
+		}
+		log.Printf("📦 Module %s: %d models, %d query interfaces", name, len(manifest.Models), len(manifest.Methods))
+		g.ApplyBasic(models...)
+		applyMethods(g, manifest)
 	}
 
-	// Set the database connection
-	g.UseDB(db)
-
-	// Generate basic CRUD operations for models
-	// This creates type-safe query methods
-	g.ApplyBasic(
-		models.UserModel{},
-		// Add other models here as they're created
-		// models.OrderModel{},
-		// models.ProductModel{},
-	)
-
-	// Generate custom methods for complex queries
-	user := g.GenerateModel("users")
-
-	// Apply custom interface methods for User
-	g.ApplyInterface(func(UserQueryInterface) {}, user)
-
-	// Execute code generation
 	g.Execute()
+	log.Printf("✅ GORM Gen code generated in %s for %s", *out, cfg.DB.Driver)
 }
 
-// UserQueryInterface defines custom query methods for User
-// These will be implemented by GORM Gen
-type UserQueryInterface interface {
-	// Custom query: Find users by email domain
-	FindByEmailDomain(domain string) ([]*models.UserModel, error)
-	// Custom query: Find active users (non-deleted)
-	FindActiveUsers() ([]*models.UserModel, error)
-	// Custom query: Count users by status
-	CountByCreatedDate(date string) (int64, error)
-	// Custom query: Find users with pagination and filters
-	FindWithFilters(limit, offset int, email, name string) ([]*models.UserModel, error)
+// applyMethods generates the custom queries of manifest onto their models
+func applyMethods(g *gen.Generator, manifest database.QueryManifest) {
+	for _, methods := range manifest.Methods {
+		g.ApplyInterface(methods.Interface, methods.Models...)
+	}
 }
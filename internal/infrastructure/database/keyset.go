@@ -0,0 +1,26 @@
+package database
+
+import (
+	"clean-arch-gin/internal/domain/shared/pagination"
+
+	"gorm.io/gorm"
+)
+
+// PageAfter narrows db to the limit rows after cursor in created_at, id order
+// The comparison is spelled out rather than written as a row value so it reads the same on
+// every dialect, and both columns ascend so it is a range scan on a (created_at, id) index
+func PageAfter(db *gorm.DB, cursor pagination.Cursor, limit int) *gorm.DB {
+	if !cursor.IsZero() {
+		db = db.Where("(created_at > ? OR (created_at = ? AND id > ?))", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	}
+	return db.Order("created_at ASC, id ASC").Limit(limit)
+}
+
+// PageBefore narrows db to the limit rows before cursor in created_at, id order, newest first
+// The zero cursor starts from the newest row
+func PageBefore(db *gorm.DB, cursor pagination.Cursor, limit int) *gorm.DB {
+	if !cursor.IsZero() {
+		db = db.Where("(created_at < ? OR (created_at = ? AND id < ?))", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	}
+	return db.Order("created_at DESC, id DESC").Limit(limit)
+}
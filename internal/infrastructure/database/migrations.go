@@ -0,0 +1,41 @@
+package database
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MigrationModel records when a module last migrated the schema
+type MigrationModel struct {
+	Module     string `gorm:"primaryKey;size:64"`
+	MigratedAt time.Time
+}
+
+// TableName keeps the history in schema_migrations
+func (MigrationModel) TableName() string {
+	return "schema_migrations"
+}
+
+// RecordMigration records that module migrated the schema now
+func RecordMigration(db *gorm.DB, module string) error {
+	return db.Save(&MigrationModel{Module: module, MigratedAt: time.Now()}).Error
+}
+
+// ForgetMigration drops the record of module, as if it never migrated
+func ForgetMigration(db *gorm.DB, module string) error {
+	return db.Delete(&MigrationModel{Module: module}).Error
+}
+
+// Migrations returns when each module last migrated the schema
+func Migrations(db *gorm.DB) (map[string]time.Time, error) {
+	var records []MigrationModel
+	if err := db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	migrated := make(map[string]time.Time, len(records))
+	for _, record := range records {
+		migrated[record.Module] = record.MigratedAt
+	}
+	return migrated, nil
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/shared/publicid"
+
+	"gorm.io/gorm"
+)
+
+// AssignPublicIDs gives a public ID to every row of model, deleted or not, written before
+// public IDs existed
+// Rows go in batches of batchSize, each in its own transaction, without touching updated_at.
+// It returns how many rows were assigned one
+func AssignPublicIDs(ctx context.Context, db *gorm.DB, model interface{}, batchSize int) (int64, error) {
+	var total int64
+	for ctx.Err() == nil {
+		var ids []uint
+		err := db.WithContext(ctx).Unscoped().Model(model).
+			Where("public_id IS NULL").
+			Order("id").Limit(batchSize).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return total, err
+		}
+
+		err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, id := range ids {
+				// The IS NULL guard leaves alone rows given one concurrently
+				if err := tx.Unscoped().Model(model).Where("id = ? AND public_id IS NULL", id).
+					UpdateColumn("public_id", publicid.New()).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+		total += int64(len(ids))
+		if len(ids) < batchSize {
+			return total, nil
+		}
+	}
+	return total, ctx.Err()
+}
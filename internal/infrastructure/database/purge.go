@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PurgeSoftDeleted permanently deletes rows of model soft-deleted before the given time
+// Rows go in batches of batchSize, each in its own transaction, so no lock is held for
+// long. cascade, when not nil, deletes dependent rows of each batch first. It returns
+// how many rows of model were deleted
+func PurgeSoftDeleted(ctx context.Context, db *gorm.DB, model interface{}, before time.Time, batchSize int,
+	cascade func(tx *gorm.DB, ids []uint) error) (int64, error) {
+	var total int64
+	for ctx.Err() == nil {
+		var ids []uint
+		err := db.WithContext(ctx).Unscoped().Model(model).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+			Order("id").Limit(batchSize).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return total, err
+		}
+
+		var deleted int64
+		err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if cascade != nil {
+				if err := cascade(tx, ids); err != nil {
+					return err
+				}
+			}
+			result := tx.Unscoped().Where("id IN ?", ids).Delete(model)
+			deleted = result.RowsAffected
+			return result.Error
+		})
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+		if len(ids) < batchSize {
+			return total, nil
+		}
+	}
+	return total, ctx.Err()
+}
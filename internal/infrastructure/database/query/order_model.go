@@ -0,0 +1,141 @@
+package query
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Placeholder for generated OrderModel query methods
+// Every builder method returns a new orderModelDo so conditions compose like the generated code
+type orderModelDo struct {
+	db *gorm.DB
+}
+
+func newOrderModelDo(db *gorm.DB) orderModelDo {
+	// A fresh session keeps conditions from leaking between independent queries
+	return orderModelDo{db: db.Model(&models.OrderModel{}).Session(&gorm.Session{})}
+}
+
+// Placeholder methods - these will be replaced by GORM Gen
+// Create inserts the order with its items, like the generated code saving associations
+func (o orderModelDo) Create(order *models.OrderModel) error {
+	return o.db.Session(&gorm.Session{NewDB: true}).Create(order).Error
+}
+
+// Save updates the order and upserts its items
+func (o orderModelDo) Save(order *models.OrderModel) error {
+	return o.db.Session(&gorm.Session{NewDB: true, FullSaveAssociations: true}).Save(order).Error
+}
+
+func (o orderModelDo) Where(conds ...clause.Expression) orderModelDo {
+	return orderModelDo{db: o.db.Clauses(clause.Where{Exprs: conds})}
+}
+
+// Preload eager loads the given relations in one extra query each
+func (o orderModelDo) Preload(relations ...relation) orderModelDo {
+	db := o.db
+	for _, r := range relations {
+		db = db.Preload(r.name)
+	}
+	return orderModelDo{db: db}
+}
+
+func (o orderModelDo) Order(columns ...orderBy) orderModelDo {
+	db := o.db
+	for _, column := range columns {
+		db = db.Order(column.column)
+	}
+	return orderModelDo{db: db}
+}
+
+func (o orderModelDo) First() (*models.OrderModel, error) {
+	var order models.OrderModel
+	err := o.db.First(&order).Error
+	return &order, err
+}
+
+func (o orderModelDo) Find() ([]*models.OrderModel, error) {
+	var orders []*models.OrderModel
+	err := o.db.Find(&orders).Error
+	return orders, err
+}
+
+func (o orderModelDo) Delete() (int64, error) {
+	result := o.db.Delete(&models.OrderModel{})
+	return result.RowsAffected, result.Error
+}
+
+// WithContext binds the query to ctx, moving it into the transaction ctx runs in, if any
+func (o orderModelDo) WithContext(ctx context.Context) orderModelDo {
+	if tx, ok := database.TxFromContext(ctx); ok {
+		return orderModelDo{db: newOrderModelDo(tx).db.WithContext(ctx)}
+	}
+	return orderModelDo{db: o.db.WithContext(ctx)}
+}
+
+func (o orderModelDo) Limit(limit int) orderModelDo {
+	return orderModelDo{db: o.db.Limit(limit)}
+}
+
+func (o orderModelDo) Offset(offset int) orderModelDo {
+	return orderModelDo{db: o.db.Offset(offset)}
+}
+
+// Add field properties to orderModelDo
+func (o orderModelDo) ID() field        { return ID }
+func (o orderModelDo) PublicID() field  { return PublicID }
+func (o orderModelDo) UserID() field    { return UserID }
+func (o orderModelDo) Status() field    { return Status }
+func (o orderModelDo) CreatedAt() field { return CreatedAt }
+func (o orderModelDo) Items() relation  { return relation{name: "Items"} }
+
+// Placeholder for generated OrderItemModel query methods
+type orderItemModelDo struct {
+	db *gorm.DB
+}
+
+func newOrderItemModelDo(db *gorm.DB) orderItemModelDo {
+	return orderItemModelDo{db: db.Model(&models.OrderItemModel{}).Session(&gorm.Session{})}
+}
+
+func (i orderItemModelDo) Where(conds ...clause.Expression) orderItemModelDo {
+	return orderItemModelDo{db: i.db.Clauses(clause.Where{Exprs: conds})}
+}
+
+func (i orderItemModelDo) Find() ([]*models.OrderItemModel, error) {
+	var items []*models.OrderItemModel
+	err := i.db.Find(&items).Error
+	return items, err
+}
+
+func (i orderItemModelDo) Delete() (int64, error) {
+	result := i.db.Delete(&models.OrderItemModel{})
+	return result.RowsAffected, result.Error
+}
+
+// WithContext binds the query to ctx, moving it into the transaction ctx runs in, if any
+func (i orderItemModelDo) WithContext(ctx context.Context) orderItemModelDo {
+	if tx, ok := database.TxFromContext(ctx); ok {
+		return orderItemModelDo{db: newOrderItemModelDo(tx).db.WithContext(ctx)}
+	}
+	return orderItemModelDo{db: i.db.WithContext(ctx)}
+}
+
+// Add field properties to orderItemModelDo
+func (i orderItemModelDo) ID() field      { return ID }
+func (i orderItemModelDo) OrderID() field { return OrderID }
+
+// Placeholder relation type mirroring the generated association fields
+type relation struct {
+	name string
+}
+
+// Placeholder ordering mirroring the generated field.Desc and field.Asc
+type orderBy struct {
+	column string
+}
@@ -1,44 +1,89 @@
 // Package query contains GORM Gen generated code
-// This file will be replaced by generated code when running: go run internal/infrastructure/database/gen.go
+// This file will be replaced by generated code from the modules' query manifests (see
+// modules.QueryDeclarer); generating connects to the configured database
 package query
 
+//go:generate go run ../gen.go -out . -env ../../../../.env
+
 import (
+	"context"
+
 	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/infrastructure/database"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Query struct contains all generated query methods
 // This is a placeholder - will be generated by GORM Gen
 type Query struct {
-	db        *gorm.DB
-	UserModel userModelDo
+	db             *gorm.DB
+	UserModel      userModelDo
+	OrderModel     orderModelDo
+	OrderItemModel orderItemModelDo
 }
 
 // Use initializes and returns the Query struct
 func Use(db *gorm.DB) *Query {
 	return &Query{
-		db:        db,
-		UserModel: newUserModelDo(db),
+		db:             db,
+		UserModel:      newUserModelDo(db),
+		OrderModel:     newOrderModelDo(db),
+		OrderItemModel: newOrderItemModelDo(db),
 	}
 }
 
+// WithContext returns a Query running in the transaction ctx runs in, if any, so its
+// Transaction nests in it
+func (q *Query) WithContext(ctx context.Context) *Query {
+	if tx, ok := database.TxFromContext(ctx); ok {
+		return Use(tx)
+	}
+	return q
+}
+
+// Transaction runs fc with a Query whose statements share one transaction, committed when
+// fc returns nil
+func (q *Query) Transaction(fc func(tx *Query) error) error {
+	return q.db.Transaction(func(tx *gorm.DB) error {
+		return fc(Use(tx))
+	})
+}
+
 // Placeholder for generated UserModel query methods
+// Every builder method returns a new userModelDo so conditions compose like the generated code
 type userModelDo struct {
 	db *gorm.DB
 }
 
 func newUserModelDo(db *gorm.DB) userModelDo {
-	return userModelDo{db: db}
+	// A fresh session keeps conditions from leaking between independent queries
+	return userModelDo{db: db.Model(&models.UserModel{}).Session(&gorm.Session{})}
 }
 
 // Placeholder methods - these will be replaced by GORM Gen
 func (u userModelDo) Create(user *models.UserModel) error {
-	return u.db.Create(user).Error
+	return u.db.Session(&gorm.Session{NewDB: true}).Create(user).Error
+}
+
+// Clauses adds clauses such as an ON CONFLICT to the statement; only Upsert keeps them
+func (u userModelDo) Clauses(conds ...clause.Expression) userModelDo {
+	return userModelDo{db: u.db.Clauses(conds...)}
 }
 
-func (u userModelDo) Where(conds ...interface{}) userModelDo {
-	return u
+// Upsert inserts the user with the clauses added so far, like the generated
+// Clauses(...).Create
+func (u userModelDo) Upsert(user *models.UserModel) error {
+	return u.db.Model(user).Create(user).Error
+}
+
+func (u userModelDo) CreateInBatches(users []*models.UserModel, batchSize int) error {
+	return u.db.Session(&gorm.Session{NewDB: true}).CreateInBatches(users, batchSize).Error
+}
+
+func (u userModelDo) Where(conds ...clause.Expression) userModelDo {
+	return userModelDo{db: u.db.Clauses(clause.Where{Exprs: conds})}
 }
 
 func (u userModelDo) First() (*models.UserModel, error) {
@@ -53,8 +98,15 @@ func (u userModelDo) Find() ([]*models.UserModel, error) {
 	return users, err
 }
 
+// Updates writes into a model of its own: GORM copies the updated values into the model,
+// which would otherwise add the primary key of one update to every later one
 func (u userModelDo) Updates(values interface{}) (int64, error) {
-	result := u.db.Updates(values)
+	result := u.db.Model(&models.UserModel{}).Updates(values)
+	return result.RowsAffected, result.Error
+}
+
+func (u userModelDo) Update(column field, value interface{}) (int64, error) {
+	result := u.db.Model(&models.UserModel{}).Update(column.column, value)
 	return result.RowsAffected, result.Error
 }
 
@@ -65,41 +117,119 @@ func (u userModelDo) Delete() (int64, error) {
 
 func (u userModelDo) Count() (int64, error) {
 	var count int64
-	err := u.db.Model(&models.UserModel{}).Count(&count).Error
+	err := u.db.Count(&count).Error
 	return count, err
 }
 
+// WithContext binds the query to ctx, moving it into the transaction ctx runs in, if any
+func (u userModelDo) WithContext(ctx context.Context) userModelDo {
+	if tx, ok := database.TxFromContext(ctx); ok {
+		return userModelDo{db: newUserModelDo(tx).db.WithContext(ctx)}
+	}
+	return userModelDo{db: u.db.WithContext(ctx)}
+}
+
+func (u userModelDo) Unscoped() userModelDo {
+	return userModelDo{db: u.db.Unscoped()}
+}
+
 func (u userModelDo) Limit(limit int) userModelDo {
-	return u
+	return userModelDo{db: u.db.Limit(limit)}
 }
 
 func (u userModelDo) Offset(offset int) userModelDo {
-	return u
+	return userModelDo{db: u.db.Offset(offset)}
 }
 
-func (u userModelDo) Select(columns ...interface{}) userModelDo {
-	return u
+func (u userModelDo) Order(columns ...orderBy) userModelDo {
+	db := u.db
+	for _, column := range columns {
+		db = db.Order(column.column)
+	}
+	return userModelDo{db: db}
 }
 
-// Placeholder field methods for type-safe queries
-type field struct{}
+func (u userModelDo) Select(columns ...field) userModelDo {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.column
+	}
+	return userModelDo{db: u.db.Select(names)}
+}
+
+// Placeholder field type mirroring the generated field expressions
+type field struct {
+	column string
+}
+
+func (f field) Eq(value interface{}) clause.Expression {
+	return clause.Eq{Column: clause.Column{Name: f.column}, Value: value}
+}
+
+func (f field) Like(value interface{}) clause.Expression {
+	return clause.Like{Column: clause.Column{Name: f.column}, Value: value}
+}
+
+func (f field) In(values ...interface{}) clause.Expression {
+	return clause.IN{Column: clause.Column{Name: f.column}, Values: values}
+}
+
+func (f field) Lt(value interface{}) clause.Expression {
+	return clause.Lt{Column: clause.Column{Name: f.column}, Value: value}
+}
+
+func (f field) Gt(value interface{}) clause.Expression {
+	return clause.Gt{Column: clause.Column{Name: f.column}, Value: value}
+}
+
+func (f field) Desc() orderBy {
+	return orderBy{column: f.column + " DESC"}
+}
+
+func (f field) Asc() orderBy {
+	return orderBy{column: f.column + " ASC"}
+}
+
+func (f field) IsNull() clause.Expression {
+	return clause.Eq{Column: clause.Column{Name: f.column}, Value: nil}
+}
+
+func (f field) IsNotNull() clause.Expression {
+	return clause.Neq{Column: clause.Column{Name: f.column}, Value: nil}
+}
 
-func (f field) Eq(value interface{}) interface{}   { return nil }
-func (f field) Like(value interface{}) interface{} { return nil }
-func (f field) IsNull() interface{}                { return nil }
+// Or and And mirror the generated field.Or and field.And for grouping conditions
+func Or(exprs ...clause.Expression) clause.Expression {
+	return clause.Or(exprs...)
+}
+
+func And(exprs ...clause.Expression) clause.Expression {
+	return clause.And(exprs...)
+}
 
 // Placeholder field properties
 var (
-	ID        = field{}
-	Email     = field{}
-	Name      = field{}
-	DeletedAt = field{}
-	ALL       = "*"
+	ID        = field{column: "id"}
+	PublicID  = field{column: "public_id"}
+	Email     = field{column: "email"}
+	EmailHash = field{column: "email_hash"}
+	TenantID  = field{column: "tenant_id"}
+	Name      = field{column: "name"}
+	DeletedAt = field{column: "deleted_at"}
+	UserID    = field{column: "user_id"}
+	Status    = field{column: "status"}
+	CreatedAt = field{column: "created_at"}
+	OrderID   = field{column: "order_id"}
+	ALL       = field{column: "*"}
 )
 
 // Add field properties to userModelDo
 func (u userModelDo) ID() field        { return ID }
 func (u userModelDo) Email() field     { return Email }
+func (u userModelDo) EmailHash() field { return EmailHash }
+func (u userModelDo) TenantID() field  { return TenantID }
+func (u userModelDo) PublicID() field  { return PublicID }
 func (u userModelDo) Name() field      { return Name }
 func (u userModelDo) DeletedAt() field { return DeletedAt }
-func (u userModelDo) ALL() string      { return ALL }
+func (u userModelDo) CreatedAt() field { return CreatedAt }
+func (u userModelDo) ALL() field       { return ALL }
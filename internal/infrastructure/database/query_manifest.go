@@ -0,0 +1,17 @@
+package database
+
+// QueryManifest lists the models a module generates GORM Gen query code for
+type QueryManifest struct {
+	// Models get the type-safe basic queries, e.g. models.UserModel{}
+	Models []interface{}
+	// Methods add custom queries to some of the Models
+	Methods []QueryMethods
+}
+
+// QueryMethods generates the methods of an interface onto models; every method's comment
+// is the SQL template GORM Gen implements it with, e.g. SELECT * FROM @@table WHERE id=@id
+type QueryMethods struct {
+	// Interface is func(I) {} for the interface I declaring the methods
+	Interface interface{}
+	Models    []interface{}
+}
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Index is an index queries rely on that AutoMigrate does not derive from the models, such
+// as a composite index for a filter or a partial one
+type Index struct {
+	Table   string
+	Name    string
+	Columns []string
+	Unique  bool
+	// Where makes the index partial, e.g. "deleted_at IS NULL"; MySQL has no partial indexes
+	// and gets the full one
+	Where string
+	// FullText makes it the full-text index MatchFullText searches the columns through; it is
+	// not partial, and SQLite cannot hold one
+	FullText bool
+}
+
+// Check is a CHECK constraint on a table
+type Check struct {
+	Table string
+	Name  string
+	Expr  string
+}
+
+// Schema is what a module declares on top of its models
+type Schema struct {
+	Indexes []Index
+	Checks  []Check
+}
+
+// EnsureSchema creates the declared indexes and checks that are missing and returns a drift
+// warning for every one the live schema defines differently or cannot hold
+// Drifted indexes are left alone: rebuilding one can lock a large table, so that is for a
+// planned migration. Their columns and uniqueness are compared, not partial predicates
+func EnsureSchema(db *gorm.DB, schema Schema) ([]string, error) {
+	var warnings []string
+	migrator := db.Migrator()
+
+	for _, index := range schema.Indexes {
+		if index.FullText && !HasFullText(db) {
+			warnings = append(warnings, fmt.Sprintf("full-text index %s on %s cannot be created on %s", index.Name, index.Table, db.Dialector.Name()))
+			continue
+		}
+		if !migrator.HasIndex(index.Table, index.Name) {
+			if err := createIndex(db, index); err != nil {
+				return warnings, fmt.Errorf("failed to create index %s on %s: %w", index.Name, index.Table, err)
+			}
+			continue
+		}
+		if index.FullText {
+			// Postgres builds it on an expression, not the columns compared for drift
+			continue
+		}
+		if warning := indexDrift(db, index); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	for _, check := range schema.Checks {
+		if migrator.HasConstraint(check.Table, check.Name) {
+			continue
+		}
+		if db.Dialector.Name() == "sqlite" {
+			// SQLite only takes constraints in CREATE TABLE
+			warnings = append(warnings, fmt.Sprintf("check %s on %s is missing and cannot be added on sqlite", check.Name, check.Table))
+			continue
+		}
+		err := db.Exec("ALTER TABLE ? ADD CONSTRAINT ? CHECK ("+check.Expr+")",
+			clause.Table{Name: check.Table}, clause.Column{Name: check.Name}).Error
+		if err != nil {
+			return warnings, fmt.Errorf("failed to add check %s on %s: %w", check.Name, check.Table, err)
+		}
+	}
+	return warnings, nil
+}
+
+// createIndex creates index, partial where the dialect supports it
+func createIndex(db *gorm.DB, index Index) error {
+	if index.FullText {
+		return createFullTextIndex(db, index)
+	}
+	columns := make([]interface{}, len(index.Columns))
+	for i, column := range index.Columns {
+		columns[i] = clause.Column{Name: column}
+	}
+
+	sql := "CREATE INDEX ? ON ? ?"
+	if index.Unique {
+		sql = "CREATE UNIQUE INDEX ? ON ? ?"
+	}
+	if index.Where != "" && db.Dialector.Name() != "mysql" {
+		sql += " WHERE " + index.Where
+	}
+	return db.Exec(sql, clause.Column{Name: index.Name}, clause.Table{Name: index.Table}, columns).Error
+}
+
+// indexDrift describes how the live index differs from the declared one, empty when it
+// matches
+func indexDrift(db *gorm.DB, index Index) string {
+	columns, unique, err := liveIndex(db, index)
+	if err != nil {
+		return fmt.Sprintf("index %s on %s could not be inspected: %v", index.Name, index.Table, err)
+	}
+
+	var drift []string
+	if !slices.Equal(columns, index.Columns) {
+		drift = append(drift, fmt.Sprintf("columns %v, declared %v", columns, index.Columns))
+	}
+	if unique != index.Unique {
+		drift = append(drift, fmt.Sprintf("unique %v, declared %v", unique, index.Unique))
+	}
+	if len(drift) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("index %s on %s has %s", index.Name, index.Table, strings.Join(drift, " and "))
+}
+
+// liveIndex reads the columns of an existing index, in order, and whether it is unique
+// GORM's SQLite migrator cannot list indexes, so SQLite's index pragmas are read instead
+func liveIndex(db *gorm.DB, index Index) ([]string, bool, error) {
+	if db.Dialector.Name() == "sqlite" {
+		var columns []string
+		if err := db.Raw("SELECT name FROM pragma_index_info(?) ORDER BY seqno", index.Name).Scan(&columns).Error; err != nil {
+			return nil, false, err
+		}
+		var unique bool
+		err := db.Raw("SELECT \"unique\" FROM pragma_index_list(?) WHERE name = ?", index.Table, index.Name).Scan(&unique).Error
+		return columns, unique, err
+	}
+
+	live, err := db.Migrator().GetIndexes(index.Table)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, existing := range live {
+		if existing.Name() == index.Name {
+			unique, _ := existing.Unique()
+			return existing.Columns(), unique, nil
+		}
+	}
+	return nil, false, errors.New("not listed by the database")
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"reflect"
+
+	"clean-arch-gin/internal/domain/shared/softdelete"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// deletedAtType is the type of the field marking a model as soft deleting
+var deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+
+// SoftDeleteScope lets queries on soft-deleting models see deleted rows when the statement's
+// context asks for them (see softdelete.WithDeleted and softdelete.OnlyDeleted)
+// Updates and deletes keep GORM's behaviour, so a scoped context never hard deletes
+type SoftDeleteScope struct{}
+
+// Name returns the plugin name
+func (SoftDeleteScope) Name() string {
+	return "soft_delete_scope"
+}
+
+// Initialize registers the callbacks on db
+func (SoftDeleteScope) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Query().Before("gorm:query").Register("soft_delete_scope:query", scopeDeleted); err != nil {
+		return err
+	}
+	return callbacks.Row().Before("gorm:row").Register("soft_delete_scope:row", scopeDeleted)
+}
+
+// deletedAtOf returns the soft delete field of the statement's model, if it has one
+func deletedAtOf(db *gorm.DB) *schema.Field {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil
+	}
+	for _, field := range db.Statement.Schema.Fields {
+		if field.FieldType == deletedAtType {
+			return field
+		}
+	}
+	return nil
+}
+
+// scopeDeleted lifts the soft delete condition of the statement for the deleted rows its
+// context asks for
+func scopeDeleted(db *gorm.DB) {
+	scope := softdelete.FromContext(db.Statement.Context)
+	if scope == softdelete.ExcludeDeleted || db.Statement.Unscoped {
+		return
+	}
+	field := deletedAtOf(db)
+	if field == nil {
+		return
+	}
+	db.Statement.Unscoped = true
+	if scope == softdelete.DeletedOnly {
+		db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+			clause.Neq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: nil},
+		}})
+	}
+}
@@ -0,0 +1,99 @@
+package database
+
+import (
+	"reflect"
+
+	"clean-arch-gin/internal/domain/shared/tenancy"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// tenantField is the field marking a model as owned by a tenant
+const tenantField = "TenantID"
+
+// TenantScope isolates tenants on every model with a TenantID field: when the statement's
+// context acts for a tenant (see tenancy.NewContext), created rows are assigned to it and
+// queries, updates and deletes only see its rows. The tenant of a row is never updated
+// Statements without a tenant in their context, and raw SQL, are not scoped
+type TenantScope struct{}
+
+// Name returns the plugin name
+func (TenantScope) Name() string {
+	return "tenant_scope"
+}
+
+// Initialize registers the callbacks on db
+func (TenantScope) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("tenant_scope:create", assignTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tenant_scope:query", scopeTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("tenant_scope:row", scopeTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenant_scope:update", scopeTenantUpdate); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("tenant_scope:delete", scopeTenant)
+}
+
+// tenantOf returns the tenant of the statement's context and the model's tenant field
+func tenantOf(db *gorm.DB) (uint, *schema.Field, bool) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return 0, nil, false
+	}
+	field := db.Statement.Schema.LookUpField(tenantField)
+	if field == nil {
+		return 0, nil, false
+	}
+	tenantID, ok := tenancy.FromContext(db.Statement.Context)
+	return tenantID, field, ok
+}
+
+// assignTenant sets the tenant of every created row
+func assignTenant(db *gorm.DB) {
+	tenantID, field, ok := tenantOf(db)
+	if !ok {
+		return
+	}
+	ctx := db.Statement.Context
+	switch value := db.Statement.ReflectValue; value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := field.Set(ctx, reflect.Indirect(value.Index(i)), tenantID); err != nil {
+				db.AddError(err)
+				return
+			}
+		}
+	case reflect.Struct:
+		db.AddError(field.Set(ctx, value, tenantID))
+	}
+}
+
+// scopeTenant restricts the statement to the rows of the tenant
+func scopeTenant(db *gorm.DB) {
+	tenantID, field, ok := tenantOf(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: tenantID},
+	}})
+}
+
+// scopeTenantUpdate restricts the update to the rows of the tenant and keeps their tenant,
+// even when a whole model is saved without it
+func scopeTenantUpdate(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+	if field := db.Statement.Schema.LookUpField(tenantField); field != nil {
+		db.Statement.Omits = append(db.Statement.Omits, field.DBName)
+	}
+	scopeTenant(db)
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"log"
+
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/infrastructure/interceptor"
+
+	"gorm.io/gorm"
+)
+
+// txKey is the context key of the transaction a call runs in
+type txKey struct{}
+
+// txState is a transaction opened by Transactional with what runs once it commits
+type txState struct {
+	tx          *gorm.DB
+	afterCommit []func(ctx context.Context)
+}
+
+// Conn returns the transaction ctx runs in, or db outside one, bound to ctx
+// Repositories reading their connection through Conn join the transaction of a use case
+// decorated by Transactional
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// TxFromContext returns the transaction ctx runs in, if any
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	state, _ := ctx.Value(txKey{}).(*txState)
+	if state == nil {
+		return nil, false
+	}
+	return state.tx, true
+}
+
+// Transactional runs every operation in a transaction on db, committed when it returns nil
+// and rolled back otherwise; operations called inside one join it
+func Transactional(db *gorm.DB) interceptor.Interceptor {
+	return func(ctx context.Context, op string, call interceptor.Call) error {
+		if _, ok := TxFromContext(ctx); ok {
+			return call(ctx)
+		}
+
+		state := &txState{}
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			state.tx = tx
+			return call(context.WithValue(ctx, txKey{}, state))
+		})
+		if err != nil {
+			return err
+		}
+		// The hooks see the committed changes outside the transaction
+		afterCtx := context.WithValue(ctx, txKey{}, (*txState)(nil))
+		for _, fn := range state.afterCommit {
+			fn(afterCtx)
+		}
+		return nil
+	}
+}
+
+// AfterCommit defers fn until the transaction ctx runs in commits, dropping it on rollback,
+// and reports whether it did; outside a transaction it leaves fn to the caller
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) bool {
+	state, _ := ctx.Value(txKey{}).(*txState)
+	if state == nil {
+		return false
+	}
+	state.afterCommit = append(state.afterCommit, fn)
+	return true
+}
+
+// afterCommitPublisher holds back the events published in a transaction until it commits
+type afterCommitPublisher struct {
+	publisher events.EventPublisher
+}
+
+// PublishAfterCommit wraps publisher so events published inside a transaction are published
+// once it commits, and never when it rolls back, so handlers do not act on changes that are
+// not there or wait on its locks
+func PublishAfterCommit(publisher events.EventPublisher) events.EventPublisher {
+	if publisher == nil {
+		return nil
+	}
+	return &afterCommitPublisher{publisher: publisher}
+}
+
+// Publish publishes event now outside a transaction and once it commits inside one
+func (p *afterCommitPublisher) Publish(ctx context.Context, event events.DomainEvent) error {
+	deferred := AfterCommit(ctx, func(ctx context.Context) {
+		if err := p.publisher.Publish(ctx, event); err != nil {
+			log.Printf("Failed to publish %s after commit: %v", event.EventName(), err)
+		}
+	})
+	if deferred {
+		return nil
+	}
+	return p.publisher.Publish(ctx, event)
+}
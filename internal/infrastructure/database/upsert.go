@@ -0,0 +1,21 @@
+package database
+
+import (
+	"gorm.io/gorm/clause"
+)
+
+// OnConflictUpdate makes an insert update columns of the row already holding its key
+// instead of failing
+// GORM renders it as ON CONFLICT (key) DO UPDATE on Postgres and SQLite and as ON DUPLICATE
+// KEY UPDATE on MySQL, which matches on any unique index, so key must be the only unique
+// index an upserted row can collide on besides the primary key
+func OnConflictUpdate(key, columns []string) clause.OnConflict {
+	conflictColumns := make([]clause.Column, len(key))
+	for i, name := range key {
+		conflictColumns[i] = clause.Column{Name: name}
+	}
+	return clause.OnConflict{
+		Columns:   conflictColumns,
+		DoUpdates: clause.AssignmentColumns(columns),
+	}
+}
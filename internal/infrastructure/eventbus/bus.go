@@ -0,0 +1,82 @@
+// Package eventbus provides the in-process event bus connecting publishers and subscribers
+package eventbus
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"clean-arch-gin/internal/domain/shared/events"
+)
+
+// Wildcard subscribes a handler to every event
+const Wildcard = "*"
+
+// Bus is a synchronous in-memory event bus
+// Handlers run on the publisher's goroutine and must not block; hand work off to a
+// goroutine or queue when it is slow
+type Bus struct {
+	mu       sync.RWMutex
+	nextID   int
+	handlers map[string]map[int]events.EventHandler
+}
+
+// New creates an empty event bus
+func New() *Bus {
+	return &Bus{
+		handlers: make(map[string]map[int]events.EventHandler),
+	}
+}
+
+// Publish delivers the event with ctx to the handlers subscribed to its name and to Wildcard
+// A panicking handler is logged and does not affect the others
+func (b *Bus) Publish(ctx context.Context, event events.DomainEvent) error {
+	b.mu.RLock()
+	handlers := make([]events.EventHandler, 0, len(b.handlers[event.EventName()])+len(b.handlers[Wildcard]))
+	for _, handler := range b.handlers[event.EventName()] {
+		handlers = append(handlers, handler)
+	}
+	for _, handler := range b.handlers[Wildcard] {
+		handlers = append(handlers, handler)
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		dispatch(ctx, event, handler)
+	}
+	return nil
+}
+
+// Subscribe registers handler for eventName (or Wildcard) and returns its unsubscribe function
+func (b *Bus) Subscribe(eventName string, handler events.EventHandler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	if b.handlers[eventName] == nil {
+		b.handlers[eventName] = make(map[int]events.EventHandler)
+	}
+	b.handlers[eventName][id] = handler
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers[eventName], id)
+	}
+}
+
+// dispatch invokes one handler, recovering from panics
+func dispatch(ctx context.Context, event events.DomainEvent, handler events.EventHandler) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("event handler for %s panicked: %v", event.EventName(), r)
+		}
+	}()
+	handler(ctx, event)
+}
+
+var (
+	_ events.EventPublisher  = (*Bus)(nil)
+	_ events.EventSubscriber = (*Bus)(nil)
+)
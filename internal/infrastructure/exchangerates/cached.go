@@ -0,0 +1,111 @@
+// Package exchangerates implements the exchange rate providers: the European Central Bank's
+// daily reference rates and Open Exchange Rates, behind a cache
+package exchangerates
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/money"
+)
+
+// Table is the rates of every currency a source quotes against its base currency
+type Table struct {
+	Base money.Currency
+	// Rates holds how many units of each currency one unit of Base buys
+	Rates map[money.Currency]float64
+}
+
+// rate is how many units of currency one unit of Base buys
+func (t Table) rate(currency money.Currency) (float64, bool) {
+	if currency == t.Base {
+		return 1, true
+	}
+	rate, ok := t.Rates[currency]
+	return rate, ok && rate > 0
+}
+
+// Source fetches the current rates table of a provider
+type Source interface {
+	Fetch(ctx context.Context) (Table, error)
+}
+
+// Cached quotes rates from a source's table, fetched at most once per TTL, implementing
+// money.ExchangeRateProvider. Rates between two quoted currencies are crossed through the
+// base. When refreshing fails, the last table keeps being served, so a provider outage
+// only makes displayed prices staler
+type Cached struct {
+	source Source
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	table     *Table
+	fetchedAt time.Time
+}
+
+var _ money.ExchangeRateProvider = (*Cached)(nil)
+
+// NewCached creates a provider caching source's table for ttl
+func NewCached(source Source, ttl time.Duration) *Cached {
+	return &Cached{source: source, ttl: ttl}
+}
+
+// Rate quotes the rate from one currency to another
+func (c *Cached) Rate(ctx context.Context, from, to money.Currency) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	table, err := c.current(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	fromRate, ok := table.rate(from)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", money.ErrUnsupportedCurrency, from)
+	}
+	toRate, ok := table.rate(to)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", money.ErrUnsupportedCurrency, to)
+	}
+	return toRate / fromRate, nil
+}
+
+// current returns the cached table, refreshing it once it is older than the TTL
+// Concurrent callers wait for a single refresh
+func (c *Cached) current(ctx context.Context) (*Table, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.table != nil && time.Since(c.fetchedAt) < c.ttl {
+		return c.table, nil
+	}
+	table, err := c.source.Fetch(ctx)
+	if err != nil {
+		if c.table == nil {
+			return nil, fmt.Errorf("exchange rates unavailable: %w", err)
+		}
+		log.Printf("exchangerates: refresh failed, serving rates from %s: %v", c.fetchedAt.Format(time.RFC3339), err)
+		return c.table, nil
+	}
+	c.table = &table
+	c.fetchedAt = time.Now()
+	return c.table, nil
+}
+
+// None is the provider of deployments without exchange rates: amounts are only shown in the
+// currency they are recorded in
+type None struct{}
+
+var _ money.ExchangeRateProvider = None{}
+
+// Rate converts a currency only to itself
+func (None) Rate(ctx context.Context, from, to money.Currency) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	return 0, fmt.Errorf("%w: no exchange rates are configured", money.ErrUnsupportedCurrency)
+}
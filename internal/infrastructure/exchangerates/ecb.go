@@ -0,0 +1,69 @@
+package exchangerates
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/money"
+)
+
+// ECBURL is the European Central Bank's daily euro foreign exchange reference rates
+const ECBURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// requestTimeout bounds a call to a rates source
+const requestTimeout = 10 * time.Second
+
+// ecbEnvelope is the reference rates document, with the rates nested in two levels of Cube
+type ecbEnvelope struct {
+	Rates []struct {
+		Currency string  `xml:"currency,attr"`
+		Rate     float64 `xml:"rate,attr"`
+	} `xml:"Cube>Cube>Cube"`
+}
+
+// ECB fetches the European Central Bank's reference rates, quoted against the euro and
+// published once per working day; no account is needed
+type ECB struct {
+	client *http.Client
+	url    string
+}
+
+var _ Source = (*ECB)(nil)
+
+// NewECB creates a source for the reference rates at url, ECBURL in production
+func NewECB(url string) *ECB {
+	return &ECB{client: &http.Client{Timeout: requestTimeout}, url: url}
+}
+
+// Fetch downloads the latest reference rates
+func (e *ECB) Fetch(ctx context.Context) (Table, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.url, nil)
+	if err != nil {
+		return Table{}, err
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return Table{}, fmt.Errorf("ECB unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Table{}, fmt.Errorf("ECB answered %s", resp.Status)
+	}
+
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return Table{}, fmt.Errorf("invalid ECB rates: %w", err)
+	}
+	if len(envelope.Rates) == 0 {
+		return Table{}, fmt.Errorf("invalid ECB rates: none published")
+	}
+
+	table := Table{Base: "EUR", Rates: make(map[money.Currency]float64, len(envelope.Rates))}
+	for _, rate := range envelope.Rates {
+		table.Rates[money.Currency(rate.Currency)] = rate.Rate
+	}
+	return table, nil
+}
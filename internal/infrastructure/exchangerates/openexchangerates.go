@@ -0,0 +1,67 @@
+package exchangerates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"clean-arch-gin/internal/domain/shared/money"
+)
+
+// OpenExchangeRatesURL is the latest rates endpoint of Open Exchange Rates
+const OpenExchangeRatesURL = "https://openexchangerates.org/api/latest.json"
+
+// oxrResponse is the latest rates, quoted against Base (USD on the free plan)
+type oxrResponse struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// OpenExchangeRates fetches the latest rates of Open Exchange Rates with an app ID; they
+// cover more currencies than the ECB's and are refreshed hourly
+type OpenExchangeRates struct {
+	client *http.Client
+	url    string
+	appID  string
+}
+
+var _ Source = (*OpenExchangeRates)(nil)
+
+// NewOpenExchangeRates creates a source for the endpoint at baseURL, OpenExchangeRatesURL
+// in production, authenticated with appID
+func NewOpenExchangeRates(baseURL, appID string) *OpenExchangeRates {
+	return &OpenExchangeRates{client: &http.Client{Timeout: requestTimeout}, url: baseURL, appID: appID}
+}
+
+// Fetch downloads the latest rates
+func (o *OpenExchangeRates) Fetch(ctx context.Context) (Table, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.url+"?app_id="+url.QueryEscape(o.appID), nil)
+	if err != nil {
+		return Table{}, err
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return Table{}, fmt.Errorf("Open Exchange Rates unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Table{}, fmt.Errorf("Open Exchange Rates answered %s", resp.Status)
+	}
+
+	var result oxrResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return Table{}, fmt.Errorf("invalid Open Exchange Rates response: %w", err)
+	}
+	base, err := money.ParseCurrency(result.Base)
+	if err != nil || len(result.Rates) == 0 {
+		return Table{}, fmt.Errorf("invalid Open Exchange Rates response: base %q with %d rates", result.Base, len(result.Rates))
+	}
+
+	table := Table{Base: base, Rates: make(map[money.Currency]float64, len(result.Rates))}
+	for currency, rate := range result.Rates {
+		table.Rates[money.Currency(currency)] = rate
+	}
+	return table, nil
+}
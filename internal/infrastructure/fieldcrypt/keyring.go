@@ -0,0 +1,250 @@
+// Package fieldcrypt encrypts sensitive columns at rest with envelope encryption
+// Every value is sealed with its own data key, which is wrapped by a key encryption key
+// named in the ciphertext so keys can be rotated while older values stay readable
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// prefix marks encrypted values: enc:v1:<key ID>:<wrapped data key>:<sealed value>
+	prefix = "enc:v1:"
+	// keySize is the size of the key encryption keys and data keys (AES-256)
+	keySize = 32
+	// minIndexKeySize is the smallest blind index key accepted
+	minIndexKeySize = 16
+)
+
+var (
+	// ErrUnknownKey is returned for values encrypted with a key missing from the keyring
+	ErrUnknownKey = errors.New("fieldcrypt: value encrypted with an unknown key")
+	// ErrMalformed is returned for values carrying the encrypted prefix that cannot be parsed
+	ErrMalformed = errors.New("fieldcrypt: malformed encrypted value")
+
+	// keyIDPattern keeps key IDs safe to embed in values and LIKE patterns
+	keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9-]{1,32}$`)
+	encoding     = base64.RawURLEncoding
+)
+
+// Keyring holds the key encryption keys by ID, the active one new values are encrypted
+// with, and the key of the blind indexes
+// A keyring without an active key writes plaintext but still decrypts values encrypted
+// with its keys; the zero keyring encrypts nothing and indexes with an empty key
+type Keyring struct {
+	keys     map[string]cipher.AEAD
+	activeID string
+	indexKey []byte
+	// indexPrefix starts every blind index, identifying the key it was computed with
+	indexPrefix string
+}
+
+// NewKeyring creates a keyring of 32-byte keys by ID encrypting with activeID, which may be
+// empty to stop encrypting, and computing blind indexes with indexKey
+// indexKey must never change once indexes were written unless every row is rewritten
+func NewKeyring(keys map[string][]byte, activeID string, indexKey []byte) (*Keyring, error) {
+	k := &Keyring{keys: make(map[string]cipher.AEAD, len(keys)), activeID: activeID}
+	for id, key := range keys {
+		if !keyIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("fieldcrypt: key ID %q must be 1-32 letters, digits or hyphens", id)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("fieldcrypt: key %q: %w", id, err)
+		}
+		k.keys[id] = aead
+	}
+	if _, ok := k.keys[activeID]; activeID != "" && !ok {
+		return nil, fmt.Errorf("fieldcrypt: active key %q is not in the keyring", activeID)
+	}
+	if len(keys) > 0 && len(indexKey) < minIndexKeySize {
+		return nil, fmt.Errorf("fieldcrypt: the index key must be at least %d bytes", minIndexKeySize)
+	}
+	k.setIndexKey(indexKey)
+	return k, nil
+}
+
+// ParseKeys parses comma-separated id:base64 pairs, e.g. "2024a:<key>,2025a:<key>"
+func ParseKeys(spec string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("fieldcrypt: key %q is not id:base64", pair)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("fieldcrypt: key %q is not valid base64: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return keys, nil
+}
+
+// Enabled reports whether the keyring holds keys, i.e. whether columns may hold ciphertext
+func (k *Keyring) Enabled() bool {
+	return len(k.keys) > 0
+}
+
+// ActiveKeyID returns the ID of the key new values are encrypted with, "" for plaintext
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeID
+}
+
+// Encrypt seals plaintext under a fresh data key wrapped with the active key
+// associatedData binds the value to where it is stored, e.g. the column, so it cannot be
+// moved elsewhere; it returns plaintext unchanged without an active key
+func (k *Keyring) Encrypt(plaintext, associatedData string) (string, error) {
+	if k.activeID == "" {
+		return plaintext, nil
+	}
+
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(dataAEAD, []byte(plaintext), []byte(associatedData))
+	if err != nil {
+		return "", err
+	}
+	// The wrapped data key is bound to its key ID so it cannot be relabelled
+	wrapped, err := seal(k.keys[k.activeID], dataKey, []byte(k.activeID))
+	if err != nil {
+		return "", err
+	}
+	return prefix + k.activeID + ":" + encoding.EncodeToString(wrapped) + ":" + encoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value returned by Encrypt with the same associatedData
+// Values without the encrypted prefix, e.g. written before encryption was enabled, are
+// returned unchanged
+func (k *Keyring) Decrypt(value, associatedData string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 3 {
+		return "", ErrMalformed
+	}
+	keyAEAD, ok := k.keys[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownKey, parts[0])
+	}
+	wrapped, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrMalformed
+	}
+	sealed, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return "", ErrMalformed
+	}
+
+	dataKey, err := open(keyAEAD, wrapped, []byte(parts[0]))
+	if err != nil {
+		return "", err
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(dataAEAD, sealed, []byte(associatedData))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// BlindIndex returns a keyed hash of value, case and surrounding spaces ignored, to look
+// up and enforce uniqueness of an encrypted column by equality
+// It starts with IndexPrefix, so indexes computed with another key can be found
+func (k *Keyring) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(value))))
+	return k.indexPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// IndexPrefix starts every blind index computed with the keyring's index key
+func (k *Keyring) IndexPrefix() string {
+	return k.indexPrefix
+}
+
+// StaleCiphertext returns a SQL condition matching the values of column not written with
+// the active key: older keys and plaintext while encrypting, ciphertext otherwise
+func (k *Keyring) StaleCiphertext(column string) (string, string) {
+	if k.activeID == "" {
+		return column + " LIKE ?", prefix + "%"
+	}
+	return column + " NOT LIKE ?", prefix + k.activeID + ":%"
+}
+
+// StaleIndex returns a SQL condition matching the blind indexes in column not computed
+// with the index key, including missing ones
+func (k *Keyring) StaleIndex(column string) (string, string) {
+	return column + " IS NULL OR " + column + " NOT LIKE ?", k.indexPrefix + "%"
+}
+
+// setIndexKey sets the index key and the fingerprint prefixed to its indexes
+func (k *Keyring) setIndexKey(indexKey []byte) {
+	k.indexKey = indexKey
+	mac := hmac.New(sha256.New, indexKey)
+	mac.Write([]byte("fieldcrypt blind index"))
+	k.indexPrefix = hex.EncodeToString(mac.Sum(nil)[:4]) + ":"
+}
+
+// IsEncrypted reports whether value was returned by Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// newAEAD creates an AES-256-GCM cipher
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", keySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext behind a random nonce
+func seal(aead cipher.AEAD, plaintext, associatedData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, associatedData), nil
+}
+
+// open decrypts the output of seal
+func open(aead cipher.AEAD, sealed, associatedData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("fieldcrypt: failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,85 @@
+package fieldcrypt
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"gorm.io/gorm/schema"
+)
+
+// SerializerName tags the string fields encrypted by Serializer: `gorm:"serializer:encrypted"`
+const SerializerName = "encrypted"
+
+// current is the keyring used by Serializer and the package functions
+var current atomic.Pointer[Keyring]
+
+func init() {
+	Use(nil)
+	schema.RegisterSerializer(SerializerName, Serializer{})
+}
+
+// Use sets the keyring of Serializer, nil for the zero keyring, before the database is used
+func Use(k *Keyring) {
+	if k == nil {
+		k = &Keyring{}
+		k.setIndexKey(nil)
+	}
+	current.Store(k)
+}
+
+// Current returns the keyring set by Use
+func Current() *Keyring {
+	return current.Load()
+}
+
+// Enabled reports whether encrypted columns may hold ciphertext, in which case they can
+// only be searched once decrypted
+func Enabled() bool {
+	return Current().Enabled()
+}
+
+// BlindIndex returns the blind index of value with the current keyring
+func BlindIndex(value string) string {
+	return Current().BlindIndex(value)
+}
+
+// Serializer transparently encrypts string fields on write and decrypts them on read
+// The ciphertext is bound to the table and column, e.g. users.email
+type Serializer struct{}
+
+// Scan decrypts the column value into the field
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("fieldcrypt: cannot decrypt %s from %T", field.DBName, dbValue)
+	}
+
+	plaintext, err := Current().Decrypt(value, associatedData(field))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt %s: %w", field.DBName, err)
+	}
+	field.ReflectValueOf(ctx, dst).SetString(plaintext)
+	return nil
+}
+
+// Value encrypts the field value with the active key
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("fieldcrypt: cannot encrypt %s of type %T", field.DBName, fieldValue)
+	}
+	return Current().Encrypt(value, associatedData(field))
+}
+
+// associatedData binds a value to its column
+func associatedData(field *schema.Field) string {
+	return field.Schema.Table + "." + field.DBName
+}
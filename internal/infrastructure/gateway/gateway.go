@@ -0,0 +1,83 @@
+// Package gateway serves the gRPC services as JSON over HTTP using grpc-gateway
+// Requests are proxied to the gRPC listener so the same interceptors (auth, logging)
+// apply to both surfaces
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	userv1 "clean-arch-gin/internal/gen/proto/user/v1"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Prefix is the path prefix of the transcoded routes (see api/proto/*/v1/*_http.yaml)
+const Prefix = "/api/v2"
+
+// TenantHeader names the tenant of a request, forwarded to the gRPC server as metadata;
+// the gateway does not see the subdomain a request was made on
+const TenantHeader = "X-Tenant"
+
+// registrations lists the generated gateway handlers for every gRPC service
+var registrations = []func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error{
+	userv1.RegisterUserServiceHandlerFromEndpoint,
+}
+
+// NewHandler creates an HTTP handler that transcodes JSON requests to the gRPC server at endpoint
+func NewHandler(ctx context.Context, endpoint string) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			// Match the snake_case field names of the HTTP DTOs
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+		runtime.WithErrorHandler(ErrorHandler),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+	)
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	for _, register := range registrations {
+		if err := register(ctx, mux, endpoint, opts); err != nil {
+			return nil, fmt.Errorf("failed to register gateway handler: %w", err)
+		}
+	}
+
+	return mux, nil
+}
+
+// headerMatcher forwards the X-Tenant header as x-tenant metadata on top of the default headers
+func headerMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, TenantHeader) {
+		return strings.ToLower(key), true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// ErrorHandler writes gRPC errors with the {"error": "..."} body used by the Gin controllers
+// Status codes follow the standard gRPC to HTTP mapping, so NotFound becomes 404,
+// AlreadyExists 409, InvalidArgument 400 and Unauthenticated 401
+func ErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+
+	body, marshalErr := marshaler.Marshal(map[string]string{"error": st.Message()})
+	if marshalErr != nil {
+		http.Error(w, `{"error":"failed to marshal error"}`, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(runtime.HTTPStatusFromCode(st.Code()))
+	_, _ = w.Write(body)
+}
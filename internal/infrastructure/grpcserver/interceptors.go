@@ -0,0 +1,123 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// contextKey keeps the values set by the auth interceptor private to this package
+type contextKey string
+
+const (
+	userIDKey    contextKey = "userID"
+	sessionIDKey contextKey = "sessionID"
+)
+
+// SessionAuthenticator resolves a bearer token to its server-side session in the tenant of
+// ctx, verifying it as a JWT first when tokens are signed
+type SessionAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*userEntities.Session, error)
+}
+
+// AccountChecker returns why a user may not act, such as a suspended account, or nil; a
+// SessionAuthenticator implementing it turns such users away
+type AccountChecker interface {
+	CheckAccount(ctx context.Context, userID uint) error
+}
+
+// UserIDFromContext returns the user ID set by AuthInterceptor
+func UserIDFromContext(ctx context.Context) (uint, bool) {
+	userID, ok := ctx.Value(userIDKey).(uint)
+	return userID, ok
+}
+
+// SessionIDFromContext returns the ID of the session set by AuthInterceptor
+func SessionIDFromContext(ctx context.Context) (uint, bool) {
+	sessionID, ok := ctx.Value(sessionIDKey).(uint)
+	return sessionID, ok
+}
+
+// LoggingInterceptor logs every call in the same spirit as gin.Logger
+func LoggingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		log.Printf("[GRPC] %-16s | %13v | %s", status.Code(err), time.Since(start), info.FullMethod)
+		return resp, err
+	}
+}
+
+// RecoveryInterceptor turns panics into Internal errors like gin.Recovery
+func RecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("[GRPC] panic recovered in %s: %v\n%s", info.FullMethod, r, debug.Stack())
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// AuthInterceptor mirrors middleware.AuthMiddleware.RequireAuth for gRPC calls
+// The bearer token is read from the "authorization" metadata and resolved to its session by
+// sessions, so revoked and expired sessions are rejected as over HTTP; without sessions no
+// call is authenticated. Methods whose full name starts with one of publicPrefixes (e.g.
+// "/grpc.health.v1.Health/") skip the check. When sessions is an AccountChecker, users it
+// turns away, e.g. suspended ones, are PermissionDenied
+func AuthInterceptor(sessions SessionAuthenticator, publicPrefixes ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		for _, prefix := range publicPrefixes {
+			if strings.HasPrefix(info.FullMethod, prefix) {
+				return handler(ctx, req)
+			}
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		tokens := md.Get("authorization")
+		if len(tokens) == 0 || tokens[0] == "" {
+			return nil, status.Error(codes.Unauthenticated, "Authorization header required")
+		}
+		token, ok := strings.CutPrefix(tokens[0], "Bearer ")
+		if !ok || token == "" || sessions == nil {
+			return nil, status.Error(codes.Unauthenticated, "Invalid token")
+		}
+
+		session, err := sessions.Authenticate(ctx, token)
+		if err != nil {
+			return nil, authStatus(info.FullMethod, err, codes.Unauthenticated)
+		}
+		if accounts, ok := sessions.(AccountChecker); ok {
+			if err := accounts.CheckAccount(ctx, session.UserID); err != nil {
+				return nil, authStatus(info.FullMethod, err, codes.PermissionDenied)
+			}
+		}
+		ctx = context.WithValue(ctx, userIDKey, session.UserID)
+		ctx = context.WithValue(ctx, sessionIDKey, session.ID)
+
+		return handler(ctx, req)
+	}
+}
+
+// authStatus reports a domain error of AuthInterceptor with code, and any other as Internal
+func authStatus(method string, err error, code codes.Code) error {
+	var domainErr sharedEntities.DomainError
+	if errors.As(err, &domainErr) {
+		return status.Error(code, domainErr.Error())
+	}
+	log.Printf("[GRPC] failed to authenticate a call to %s: %v", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
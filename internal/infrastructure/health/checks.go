@@ -0,0 +1,27 @@
+package health
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// DatabaseCheck pings the database connection pool
+func DatabaseCheck(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// TableCheck reads at most one row of model's table, catching missing migrations
+// and permission problems that a ping does not
+func TableCheck(db *gorm.DB, model interface{}) CheckFunc {
+	return func(ctx context.Context) error {
+		var found []int
+		return db.WithContext(ctx).Unscoped().Model(model).Select("1").Limit(1).Find(&found).Error
+	}
+}
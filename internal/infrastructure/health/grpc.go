@@ -0,0 +1,79 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ServicePrefix is the method prefix of the health service, which needs no authorization
+const ServicePrefix = "/grpc.health.v1.Health/"
+
+// DefaultInterval is how often GRPCService refreshes the statuses when none is given
+const DefaultInterval = 10 * time.Second
+
+// GRPCService serves grpc.health.v1.Health from the checker's results
+// The empty service name carries the overall status and every check is also
+// exposed under its own name, so probes can target a single dependency
+type GRPCService struct {
+	checker  *Checker
+	server   *grpchealth.Server
+	interval time.Duration
+}
+
+// NewGRPCService creates the health service; statuses start as NOT_SERVING until the first refresh
+func NewGRPCService(checker *Checker, interval time.Duration) *GRPCService {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	server := grpchealth.NewServer()
+	server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	for _, name := range checker.Names() {
+		server.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return &GRPCService{checker: checker, server: server, interval: interval}
+}
+
+// Register registers the health service on s
+func (g *GRPCService) Register(s grpc.ServiceRegistrar) {
+	healthpb.RegisterHealthServer(s, g.server)
+}
+
+// Refresh runs the checks once and publishes the results to Check and Watch callers
+func (g *GRPCService) Refresh(ctx context.Context) Report {
+	report := g.checker.Run(ctx)
+	for name, result := range report.Checks {
+		g.server.SetServingStatus(name, servingStatus(result.Status))
+	}
+	g.server.SetServingStatus("", servingStatus(report.Status))
+	return report
+}
+
+// Run refreshes the statuses every interval until ctx is cancelled, then marks every
+// service NOT_SERVING so clients watching the stream stop routing to this instance
+func (g *GRPCService) Run(ctx context.Context) {
+	g.Refresh(ctx)
+
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			g.server.Shutdown()
+			return
+		case <-ticker.C:
+			g.Refresh(ctx)
+		}
+	}
+}
+
+// servingStatus converts a Status to its protocol value
+func servingStatus(status Status) healthpb.HealthCheckResponse_ServingStatus {
+	if status == StatusServing {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
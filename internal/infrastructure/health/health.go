@@ -0,0 +1,155 @@
+// Package health runs dependency checks and reports them over HTTP and the gRPC Health Checking Protocol
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status is the overall or per-check serving status, named after grpc.health.v1
+type Status string
+
+const (
+	StatusServing    Status = "SERVING"
+	StatusNotServing Status = "NOT_SERVING"
+)
+
+// DefaultTimeout bounds each check when the Checker is created without one
+const DefaultTimeout = 2 * time.Second
+
+// CheckFunc reports a dependency as unhealthy by returning an error
+// It must return promptly once ctx is done
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult is the outcome of one check
+type CheckResult struct {
+	Status     Status `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// Report is the outcome of every check; Status is SERVING only when all checks are
+type Report struct {
+	Status Status `json:"status"`
+	// Draining is set once shutdown has begun; the status is then NOT_SERVING regardless of the checks
+	Draining  bool                   `json:"draining,omitempty"`
+	Checks    map[string]CheckResult `json:"checks"`
+	CheckedAt time.Time              `json:"checked_at"`
+}
+
+// Serving reports whether every check passed
+func (r Report) Serving() bool {
+	return r.Status == StatusServing
+}
+
+// Checker holds the named checks of the process
+type Checker struct {
+	mu       sync.RWMutex
+	checks   map[string]CheckFunc
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+// NewChecker creates a checker bounding each check by timeout (DefaultTimeout when zero)
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{
+		checks:  make(map[string]CheckFunc),
+		timeout: timeout,
+	}
+}
+
+// Register adds a check under name, replacing any check with the same name
+func (c *Checker) Register(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// Drain marks the process as shutting down so readiness fails and load balancers
+// stop routing new requests here while in-flight ones finish
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Names returns the registered check names in sorted order
+func (c *Checker) Names() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	names := make([]string, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run executes every check concurrently and builds the report
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := make(map[string]CheckFunc, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.RUnlock()
+
+	report := Report{
+		Status:    StatusServing,
+		Checks:    make(map[string]CheckResult, len(checks)),
+		CheckedAt: time.Now().UTC(),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check CheckFunc) {
+			defer wg.Done()
+			result := c.run(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status != StatusServing {
+				report.Status = StatusNotServing
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	if c.draining.Load() {
+		report.Status = StatusNotServing
+		report.Draining = true
+	}
+	return report
+}
+
+// run executes one check under the timeout; a check that overruns is reported as failed
+func (c *Checker) run(ctx context.Context, check CheckFunc) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{Status: StatusServing, DurationMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = StatusNotServing
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LivenessHandler answers 200 while the process can serve requests; it runs no checks
+// so a failing dependency never gets the pod restarted
+func LivenessHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": StatusServing})
+	}
+}
+
+// ReadinessHandler runs every check and answers 200 when all pass, 503 otherwise
+// The ?service= query parameter limits the answer to one check, like the gRPC protocol
+func ReadinessHandler(checker *Checker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := checker.Run(c.Request.Context())
+
+		if service := c.Query("service"); service != "" {
+			result, ok := report.Checks[service]
+			if !ok {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Unknown health check: " + service})
+				return
+			}
+			c.JSON(httpStatus(result.Status), result)
+			return
+		}
+
+		c.JSON(httpStatus(report.Status), report)
+	}
+}
+
+// httpStatus maps a Status to the HTTP code probes act on
+func httpStatus(status Status) int {
+	if status == StatusServing {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
@@ -0,0 +1,144 @@
+// Package i18n holds the message catalogs shared by everything that speaks to users in
+// their language, and resolves locales to the chain of catalogs they fall back on
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// DefaultLocale is the locale every chain ends with; its catalog holds every key
+const DefaultLocale = "en"
+
+// Every catalog is a locales/<locale>.json object mapping keys to messages, which may hold
+// fmt verbs for the arguments they are translated with
+const catalogSuffix = ".json"
+
+//go:embed locales/*.json
+var embedded embed.FS
+
+// Catalog translates keys into the messages of a locale
+type Catalog struct {
+	messages map[string]map[string]string
+	locales  []string
+}
+
+// NewCatalog loads and checks the embedded catalogs
+func NewCatalog() (*Catalog, error) {
+	return newCatalog(embedded)
+}
+
+// newCatalog loads the catalogs in fsys's locales/; the catalogs other than the default
+// locale's may only hold keys the default one has
+func newCatalog(fsys fs.FS) (*Catalog, error) {
+	files, err := fs.Glob(fsys, "locales/*"+catalogSuffix)
+	if err != nil {
+		return nil, err
+	}
+	c := &Catalog{messages: make(map[string]map[string]string)}
+	for _, file := range files {
+		name := strings.TrimSuffix(path.Base(file), catalogSuffix)
+		locale := Canonical(name)
+		if locale != name {
+			return nil, fmt.Errorf("catalog %s: name it %s%s", path.Base(file), locale, catalogSuffix)
+		}
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("catalog %s: %w", locale, err)
+		}
+		c.messages[locale] = messages
+		c.locales = append(c.locales, locale)
+	}
+	defaults, ok := c.messages[DefaultLocale]
+	if !ok {
+		return nil, fmt.Errorf("no catalog for the default locale %s", DefaultLocale)
+	}
+	for _, locale := range c.locales {
+		var unknown []string
+		for key := range c.messages[locale] {
+			if _, ok := defaults[key]; !ok {
+				unknown = append(unknown, key)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return nil, fmt.Errorf("catalog %s: keys missing from %s: %v", locale, DefaultLocale, unknown)
+		}
+	}
+	sort.Strings(c.locales)
+	return c, nil
+}
+
+// Locales lists the locales with a catalog
+func (c *Catalog) Locales() []string {
+	return append([]string(nil), c.locales...)
+}
+
+// Has reports whether the default catalog, and so every chain, has key
+func (c *Catalog) Has(key string) bool {
+	_, ok := c.messages[DefaultLocale][key]
+	return ok
+}
+
+// Translate returns the message of key in the first catalog of locale's chain holding it,
+// formatted with args; the key itself when no catalog does
+func (c *Catalog) Translate(locale, key string, args ...interface{}) string {
+	for _, candidate := range Fallbacks(locale) {
+		message, ok := c.messages[candidate][key]
+		if !ok {
+			continue
+		}
+		if len(args) == 0 {
+			return message
+		}
+		return fmt.Sprintf(message, args...)
+	}
+	return key
+}
+
+// Fallbacks is the chain of locales tried for locale, most specific first and ending with
+// the default locale, e.g. pt-BR, pt, en
+func Fallbacks(locale string) []string {
+	var chain []string
+	for tag := Canonical(locale); tag != ""; {
+		chain = append(chain, tag)
+		i := strings.LastIndex(tag, "-")
+		if i < 0 {
+			break
+		}
+		tag = tag[:i]
+	}
+	if len(chain) == 0 || chain[len(chain)-1] != DefaultLocale {
+		chain = append(chain, DefaultLocale)
+	}
+	return chain
+}
+
+// Canonical spells a language tag the way catalogs and template directories are named:
+// the language in lower case, a script in title case and a region in upper case, e.g.
+// zh-Hant-TW
+func Canonical(locale string) string {
+	subtags := strings.Split(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-")
+	for i, subtag := range subtags {
+		switch {
+		case i == 0:
+			subtags[i] = strings.ToLower(subtag)
+		case len(subtag) == 4:
+			subtags[i] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		case len(subtag) == 2:
+			subtags[i] = strings.ToUpper(subtag)
+		default:
+			subtags[i] = strings.ToLower(subtag)
+		}
+	}
+	return strings.Join(subtags, "-")
+}
@@ -0,0 +1,84 @@
+// Package interceptor decorates the calls of use cases and repositories with cross-cutting
+// behavior, such as logging, metrics and transactions, stacked per environment
+package interceptor
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Call is an intercepted call, run with the context the interceptors pass on
+type Call func(ctx context.Context) error
+
+// Interceptor runs call, the operation op such as "UserUseCase.CreateUser", and returns its
+// error; it may act before and after it or replace its context
+type Interceptor func(ctx context.Context, op string, call Call) error
+
+// Stack holds the interceptors stacked around each layer; a nil one leaves the layer as is
+type Stack struct {
+	UseCases     Interceptor
+	Repositories Interceptor
+}
+
+// Chain stacks interceptors, the first outermost; nil ones are skipped and Chain returns
+// nil when none is left
+func Chain(interceptors ...Interceptor) Interceptor {
+	var chain []Interceptor
+	for _, interceptor := range interceptors {
+		if interceptor != nil {
+			chain = append(chain, interceptor)
+		}
+	}
+	switch len(chain) {
+	case 0:
+		return nil
+	case 1:
+		return chain[0]
+	}
+	return func(ctx context.Context, op string, call Call) error {
+		return run(ctx, op, call, chain)
+	}
+}
+
+// run runs call inside chain
+func run(ctx context.Context, op string, call Call, chain []Interceptor) error {
+	if len(chain) == 0 {
+		return call(ctx)
+	}
+	return chain[0](ctx, op, func(ctx context.Context) error {
+		return run(ctx, op, call, chain[1:])
+	})
+}
+
+// Run runs call through interceptor, or straight away when interceptor is nil
+func Run(ctx context.Context, interceptor Interceptor, op string, call Call) error {
+	if interceptor == nil {
+		return call(ctx)
+	}
+	return interceptor(ctx, op, call)
+}
+
+// Logging logs every operation with how long it took and the error it failed with
+func Logging(logger *log.Logger) Interceptor {
+	return func(ctx context.Context, op string, call Call) error {
+		start := time.Now()
+		err := call(ctx)
+		if err != nil {
+			logger.Printf("%s failed after %s: %v", op, time.Since(start), err)
+		} else {
+			logger.Printf("%s took %s", op, time.Since(start))
+		}
+		return err
+	}
+}
+
+// Metrics records the count and duration of operations by outcome
+func Metrics() Interceptor {
+	return func(ctx context.Context, op string, call Call) error {
+		start := time.Now()
+		err := call(ctx)
+		observeCall(op, err, time.Since(start))
+		return err
+	}
+}
@@ -0,0 +1,31 @@
+package interceptor
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	callsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "operation_calls_total",
+		Help: "Use case and repository calls by operation and outcome: ok or error.",
+	}, []string{"operation", "outcome"})
+
+	callDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "operation_duration_seconds",
+		Help:    "Use case and repository call duration by operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+)
+
+// observeCall records the outcome of a finished call
+func observeCall(op string, err error, elapsed time.Duration) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	callsTotal.WithLabelValues(op, outcome).Inc()
+	callDuration.WithLabelValues(op).Observe(elapsed.Seconds())
+}
@@ -0,0 +1,262 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"clean-arch-gin/internal/domain/shared/tokens"
+)
+
+// Signing algorithms; HS256 keys are secret and never published in the JWKS
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+	ES256 = "ES256"
+)
+
+const (
+	// rsaKeyBits is the size of generated RS256 keys
+	rsaKeyBits = 2048
+	// hmacKeySize is the size of generated HS256 secrets
+	hmacKeySize = 32
+	// es256Size is the size of each of the r and s halves of an ES256 signature
+	es256Size = 32
+)
+
+var (
+	// ErrMalformed is returned for tokens that are not compact JWS with JSON claims
+	ErrMalformed = fmt.Errorf("%w: malformed", tokens.ErrInvalid)
+	// ErrUnknownKey is returned for tokens whose kid names no key of the key set
+	ErrUnknownKey = fmt.Errorf("%w: signed with an unknown key", tokens.ErrInvalid)
+	// ErrSignature is returned for tokens whose signature does not verify
+	ErrSignature = fmt.Errorf("%w: bad signature", tokens.ErrInvalid)
+	// ErrExpired is returned for tokens past their expiry
+	ErrExpired = fmt.Errorf("%w: expired", tokens.ErrInvalid)
+	// ErrIssuer is returned for tokens issued by someone else
+	ErrIssuer = fmt.Errorf("%w: unexpected issuer", tokens.ErrInvalid)
+
+	encoding = base64.RawURLEncoding
+)
+
+// Key is a signing key named by the kid header of the tokens it signs
+type Key struct {
+	ID        string
+	Algorithm string
+	// secret is the HS256 key; signer the RS256 or ES256 private key
+	secret []byte
+	signer crypto.Signer
+}
+
+// header is the JOSE header of a token
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Type      string `json:"typ"`
+}
+
+// JWK is the public half of an RS256 or ES256 key as published in the JWKS (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	// N and E are the RSA modulus and exponent
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Curve, X and Y are the EC curve and point
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// GenerateKey creates a key for algorithm with a random kid
+func GenerateKey(algorithm string) (*Key, error) {
+	id := make([]byte, 12)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	key := &Key{ID: encoding.EncodeToString(id), Algorithm: algorithm}
+
+	var err error
+	switch algorithm {
+	case HS256:
+		key.secret = make([]byte, hmacKeySize)
+		_, err = rand.Read(key.secret)
+	case RS256:
+		key.signer, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case ES256:
+		key.signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, fmt.Errorf("jwt: unsupported algorithm %q", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// marshal encodes the private key: the HS256 secret or the PKCS #8 DER, in base64
+func (k *Key) marshal() (string, error) {
+	if k.Algorithm == HS256 {
+		return base64.StdEncoding.EncodeToString(k.secret), nil
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(k.signer)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(der), nil
+}
+
+// parseKey decodes a key encoded by marshal
+func parseKey(id, algorithm, encoded string) (*Key, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	key := &Key{ID: id, Algorithm: algorithm}
+	if algorithm == HS256 {
+		key.secret = raw
+		return key, nil
+	}
+
+	private, err := x509.ParsePKCS8PrivateKey(raw)
+	if err != nil {
+		return nil, err
+	}
+	switch private := private.(type) {
+	case *rsa.PrivateKey:
+		if algorithm != RS256 {
+			return nil, fmt.Errorf("jwt: key %s is RSA, not %s", id, algorithm)
+		}
+		key.signer = private
+	case *ecdsa.PrivateKey:
+		if algorithm != ES256 || private.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("jwt: key %s is not a P-256 key for %s", id, algorithm)
+		}
+		key.signer = private
+	default:
+		return nil, fmt.Errorf("jwt: key %s has unsupported type %T", id, private)
+	}
+	return key, nil
+}
+
+// PublicJWK returns the public key to publish; HS256 keys have none
+func (k *Key) PublicJWK() (JWK, bool) {
+	if k.signer == nil {
+		return JWK{}, false
+	}
+	jwk := JWK{Use: "sig", KeyID: k.ID, Algorithm: k.Algorithm}
+	switch public := k.signer.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = encoding.EncodeToString(public.N.Bytes())
+		jwk.E = encoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case *ecdsa.PublicKey:
+		jwk.KeyType = "EC"
+		jwk.Curve = "P-256"
+		jwk.X = encoding.EncodeToString(public.X.FillBytes(make([]byte, es256Size)))
+		jwk.Y = encoding.EncodeToString(public.Y.FillBytes(make([]byte, es256Size)))
+	default:
+		return JWK{}, false
+	}
+	return jwk, true
+}
+
+// encode signs the claims as a compact JWS
+func (k *Key) encode(claims interface{}) (string, error) {
+	head, err := json.Marshal(header{Algorithm: k.Algorithm, KeyID: k.ID, Type: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	input := encoding.EncodeToString(head) + "." + encoding.EncodeToString(body)
+	signature, err := k.sign([]byte(input))
+	if err != nil {
+		return "", err
+	}
+	return input + "." + encoding.EncodeToString(signature), nil
+}
+
+// parse splits a compact JWS into its header, signing input, claims and signature
+func parse(token string) (header, string, []byte, []byte, error) {
+	var head header
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return head, "", nil, nil, ErrMalformed
+	}
+	rawHead, err := encoding.DecodeString(parts[0])
+	if err != nil || json.Unmarshal(rawHead, &head) != nil {
+		return head, "", nil, nil, ErrMalformed
+	}
+	body, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return head, "", nil, nil, ErrMalformed
+	}
+	signature, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return head, "", nil, nil, ErrMalformed
+	}
+	return head, parts[0] + "." + parts[1], body, signature, nil
+}
+
+// sign signs the JWS signing input
+func (k *Key) sign(input []byte) ([]byte, error) {
+	digest := sha256.Sum256(input)
+	switch k.Algorithm {
+	case HS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(input)
+		return mac.Sum(nil), nil
+	case RS256:
+		return rsa.SignPKCS1v15(rand.Reader, k.signer.(*rsa.PrivateKey), crypto.SHA256, digest[:])
+	case ES256:
+		r, s, err := ecdsa.Sign(rand.Reader, k.signer.(*ecdsa.PrivateKey), digest[:])
+		if err != nil {
+			return nil, err
+		}
+		signature := make([]byte, 2*es256Size)
+		r.FillBytes(signature[:es256Size])
+		s.FillBytes(signature[es256Size:])
+		return signature, nil
+	}
+	return nil, fmt.Errorf("jwt: unsupported algorithm %q", k.Algorithm)
+}
+
+// verify checks a signature made by sign
+func (k *Key) verify(input, signature []byte) error {
+	digest := sha256.Sum256(input)
+	valid := false
+	switch k.Algorithm {
+	case HS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(input)
+		valid = hmac.Equal(signature, mac.Sum(nil))
+	case RS256:
+		valid = rsa.VerifyPKCS1v15(&k.signer.(*rsa.PrivateKey).PublicKey, crypto.SHA256, digest[:], signature) == nil
+	case ES256:
+		if len(signature) == 2*es256Size {
+			r := new(big.Int).SetBytes(signature[:es256Size])
+			s := new(big.Int).SetBytes(signature[es256Size:])
+			valid = ecdsa.Verify(&k.signer.(*ecdsa.PrivateKey).PublicKey, digest[:], r, s)
+		}
+	}
+	if !valid {
+		return ErrSignature
+	}
+	return nil
+}
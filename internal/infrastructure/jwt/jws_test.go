@@ -0,0 +1,79 @@
+package jwt_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"clean-arch-gin/internal/infrastructure/jwt"
+)
+
+// b64 encodes parts of a compact JWS
+var b64 = base64.RawURLEncoding
+
+// kid returns the kid header of token
+func kid(t *testing.T, token string) string {
+	t.Helper()
+	var head struct {
+		KeyID string `json:"kid"`
+	}
+	raw, err := b64.DecodeString(strings.Split(token, ".")[0])
+	if err != nil || json.Unmarshal(raw, &head) != nil {
+		t.Fatalf("decode header of %s", token)
+	}
+	return head.KeyID
+}
+
+// withHeader returns the signing input of token under a header naming alg and kid
+func withHeader(token, alg, kid string) string {
+	head, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	return b64.EncodeToString(head) + "." + strings.Split(token, ".")[1]
+}
+
+func TestVerifyRejectsForgedTokens(t *testing.T) {
+	ks := newKeySet(t, openKeyDB(t), jwt.RS256)
+	token := sign(t, ks, validClaims())
+	parts := strings.Split(token, ".")
+	keyID := kid(t, token)
+
+	var jwks struct {
+		Keys []jwt.JWK `json:"keys"`
+	}
+	if err := json.Unmarshal(ks.JWKS(), &jwks); err != nil || len(jwks.Keys) != 1 {
+		t.Fatalf("JWKS = %s, want the signing key", ks.JWKS())
+	}
+
+	unsigned := withHeader(token, "none", keyID)
+	// An HS256 signature keyed with the published public key, the classic algorithm confusion
+	swappedInput := withHeader(token, jwt.HS256, keyID)
+	mac := hmac.New(sha256.New, []byte(jwks.Keys[0].N))
+	mac.Write([]byte(swappedInput))
+	tamperedClaims, _ := json.Marshal(map[string]interface{}{"iss": testIssuer, "sub": "1", "jti": "x", "exp": 1 << 40})
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"alg none", unsigned + ".", jwt.ErrSignature},
+		{"alg none keeping the signature", unsigned + "." + parts[2], jwt.ErrSignature},
+		{"alg swapped to HS256", swappedInput + "." + b64.EncodeToString(mac.Sum(nil)), jwt.ErrSignature},
+		{"claims tampered", parts[0] + "." + b64.EncodeToString(tamperedClaims) + "." + parts[2], jwt.ErrSignature},
+		{"signature stripped", parts[0] + "." + parts[1] + ".", jwt.ErrSignature},
+		{"kid swapped", withHeader(token, jwt.RS256, "nobody") + "." + parts[2], jwt.ErrUnknownKey},
+		{"two parts", parts[0] + "." + parts[1], jwt.ErrMalformed},
+		{"header not base64", "%%." + parts[1] + "." + parts[2], jwt.ErrMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ks.Verify(context.Background(), tt.token); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
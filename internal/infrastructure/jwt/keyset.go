@@ -0,0 +1,338 @@
+// Package jwt signs the bearer tokens issued at login as JWTs with keys kept in the
+// database, rotated on a schedule and published as a JWKS for other services
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/tokens"
+
+	"gorm.io/gorm"
+)
+
+// reloadOnMissAfter limits how often a token naming an unknown kid rereads the keys, which
+// happens when another replica rotated them
+const reloadOnMissAfter = time.Second
+
+// SigningKeyModel stores a signing key
+// The private key is encrypted at rest like personal data when encryption is configured
+type SigningKeyModel struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement"`
+	KID       string `gorm:"column:kid;not null;size:64;uniqueIndex"`
+	Algorithm string `gorm:"not null;size:16"`
+	// PrivateKey is the HS256 secret or the PKCS #8 DER of the key, in base64
+	PrivateKey string    `gorm:"not null;size:4096;serializer:encrypted"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	// RetiredAt is when a newer key took over signing; the key verifies until ExpiresAt
+	RetiredAt *time.Time
+	// ExpiresAt is when a retired key has outlived every token it signed and is deleted
+	ExpiresAt *time.Time `gorm:"index"`
+}
+
+// TableName sets the table name for GORM
+func (SigningKeyModel) TableName() string {
+	return "jwt_signing_keys"
+}
+
+// Options configures a key set
+type Options struct {
+	// Algorithm is used for new keys: HS256, RS256 or ES256; keys made with another
+	// algorithm before a change keep verifying until they expire
+	Algorithm string
+	// Issuer is set on signed tokens and required on verified ones
+	Issuer string
+	// RotationInterval is the age at which the signing key is replaced; 0 never rotates it
+	RotationInterval time.Duration
+	// TokenTTL is the longest lifetime of a signed token, which a replaced key keeps
+	// verifying for
+	TokenTTL time.Duration
+	// ReloadInterval is how often keys rotated by another replica are picked up; a replaced
+	// key verifies that much longer, since replicas sign with it until then
+	ReloadInterval time.Duration
+}
+
+// KeySet signs tokens with the newest active key and verifies them with any key that has
+// not expired, implementing tokens.Signer
+// Every replica keeps its own copy of the keys; rotations made elsewhere are picked up by
+// Reload, or when a token names a key it does not know yet
+type KeySet struct {
+	db   *gorm.DB
+	opts Options
+
+	mu       sync.RWMutex
+	keys     map[string]*Key
+	active   *Key
+	jwks     []byte
+	loadedAt time.Time
+}
+
+var _ tokens.Signer = (*KeySet)(nil)
+
+// NewKeySet creates a key set on db without keys; Load reads them once SigningKeyModel is
+// migrated
+func NewKeySet(db *gorm.DB, opts Options) (*KeySet, error) {
+	switch opts.Algorithm {
+	case HS256, RS256, ES256:
+	default:
+		return nil, fmt.Errorf("jwt: unsupported algorithm %q", opts.Algorithm)
+	}
+	return &KeySet{db: db, opts: opts, keys: make(map[string]*Key)}, nil
+}
+
+// Load reads the keys that have not expired, generating the first one when none signs
+func (ks *KeySet) Load(ctx context.Context) error {
+	var models []SigningKeyModel
+	if err := ks.db.WithContext(ctx).Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		Order("id").Find(&models).Error; err != nil {
+		return err
+	}
+	if !hasActive(models) {
+		model, err := ks.create(ks.db.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		models = append(models, *model)
+	}
+
+	keys := make(map[string]*Key, len(models))
+	var active *Key
+	jwks := struct {
+		Keys []JWK `json:"keys"`
+	}{Keys: []JWK{}}
+	for _, model := range models {
+		key, err := parseKey(model.KID, model.Algorithm, model.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("failed to load signing key %s: %w", model.KID, err)
+		}
+		keys[key.ID] = key
+		// Models are in creation order, so the newest active key signs
+		if model.RetiredAt == nil {
+			active = key
+		}
+		if jwk, ok := key.PublicJWK(); ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+	document, err := json.Marshal(jwks)
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.keys, ks.active, ks.jwks, ks.loadedAt = keys, active, document, time.Now()
+	return nil
+}
+
+// Reload rereads the keys every interval until ctx is cancelled
+func (ks *KeySet) Reload(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ks.Load(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Failed to reload the signing keys: %v", err)
+			}
+		}
+	}
+}
+
+// Rotate makes a new key sign from now on; the keys it replaces keep verifying the tokens
+// they signed until those expire
+func (ks *KeySet) Rotate(ctx context.Context) error {
+	err := ks.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		expiresAt := now.Add(ks.opts.TokenTTL + ks.opts.ReloadInterval)
+		if err := tx.Model(&SigningKeyModel{}).Where("retired_at IS NULL").
+			Updates(map[string]interface{}{"retired_at": now, "expires_at": expiresAt}).Error; err != nil {
+			return err
+		}
+		_, err := ks.create(tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return ks.Load(ctx)
+}
+
+// RotateIfDue rotates the keys once the signing key is RotationInterval old and deletes
+// the expired ones; it reports whether it rotated
+func (ks *KeySet) RotateIfDue(ctx context.Context) (bool, error) {
+	if err := ks.db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&SigningKeyModel{}).Error; err != nil {
+		return false, err
+	}
+	if ks.opts.RotationInterval <= 0 {
+		return false, nil
+	}
+
+	var newest SigningKeyModel
+	err := ks.db.WithContext(ctx).Where("retired_at IS NULL").Order("id DESC").First(&newest).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return false, err
+	}
+	if err == nil && time.Since(newest.CreatedAt) < ks.opts.RotationInterval {
+		return false, nil
+	}
+	return true, ks.Rotate(ctx)
+}
+
+// KeyInfo describes a stored key without its private part
+type KeyInfo struct {
+	KID       string     `json:"kid"`
+	Algorithm string     `json:"alg"`
+	CreatedAt time.Time  `json:"created_at"`
+	RetiredAt *time.Time `json:"retired_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Keys describes the stored keys, newest first
+func (ks *KeySet) Keys(ctx context.Context) ([]KeyInfo, error) {
+	var models []SigningKeyModel
+	if err := ks.db.WithContext(ctx).Omit("private_key").Order("id DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	infos := make([]KeyInfo, len(models))
+	for i, model := range models {
+		infos[i] = KeyInfo{
+			KID:       model.KID,
+			Algorithm: model.Algorithm,
+			CreatedAt: model.CreatedAt,
+			RetiredAt: model.RetiredAt,
+			ExpiresAt: model.ExpiresAt,
+		}
+	}
+	return infos, nil
+}
+
+// JWKS returns the JSON Web Key Set of the public keys verifying tokens, RS256 and ES256 only
+func (ks *KeySet) JWKS() []byte {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	return ks.jwks
+}
+
+// claims is the JSON form of tokens.Claims
+type claims struct {
+	Issuer    string `json:"iss,omitempty"`
+	Subject   string `json:"sub"`
+	TenantID  uint   `json:"tid,omitempty"`
+	ID        string `json:"jti"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Sign signs the claims with the active key
+func (ks *KeySet) Sign(ctx context.Context, c tokens.Claims) (string, error) {
+	ks.mu.RLock()
+	key := ks.active
+	ks.mu.RUnlock()
+	if key == nil {
+		return "", fmt.Errorf("jwt: no signing key loaded")
+	}
+
+	return key.encode(claims{
+		Issuer:    ks.opts.Issuer,
+		Subject:   c.Subject,
+		TenantID:  c.TenantID,
+		ID:        c.ID,
+		IssuedAt:  c.IssuedAt.Unix(),
+		ExpiresAt: c.ExpiresAt.Unix(),
+	})
+}
+
+// Verify checks the token's signature with the key it names, its issuer and expiry
+func (ks *KeySet) Verify(ctx context.Context, token string) (*tokens.Claims, error) {
+	head, input, body, signature, err := parse(token)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ks.key(ctx, head.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	// The algorithm is the key's, never the one the token claims
+	if head.Algorithm != key.Algorithm {
+		return nil, ErrSignature
+	}
+	if err := key.verify([]byte(input), signature); err != nil {
+		return nil, err
+	}
+
+	var c claims
+	if err := json.Unmarshal(body, &c); err != nil {
+		return nil, ErrMalformed
+	}
+	if c.Issuer != ks.opts.Issuer {
+		return nil, ErrIssuer
+	}
+	if !time.Now().Before(time.Unix(c.ExpiresAt, 0)) {
+		return nil, ErrExpired
+	}
+	return &tokens.Claims{
+		Subject:   c.Subject,
+		TenantID:  c.TenantID,
+		ID:        c.ID,
+		IssuedAt:  time.Unix(c.IssuedAt, 0),
+		ExpiresAt: time.Unix(c.ExpiresAt, 0),
+	}, nil
+}
+
+// key returns the key named kid, rereading the keys once when it is unknown
+func (ks *KeySet) key(ctx context.Context, kid string) (*Key, error) {
+	ks.mu.RLock()
+	key, ok := ks.keys[kid]
+	stale := time.Since(ks.loadedAt) >= reloadOnMissAfter
+	ks.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+	if !stale {
+		return nil, ErrUnknownKey
+	}
+
+	if err := ks.Load(ctx); err != nil {
+		return nil, err
+	}
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	if key, ok := ks.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+// create generates and stores a new active key
+func (ks *KeySet) create(db *gorm.DB) (*SigningKeyModel, error) {
+	key, err := GenerateKey(ks.opts.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := key.marshal()
+	if err != nil {
+		return nil, err
+	}
+	model := &SigningKeyModel{KID: key.ID, Algorithm: key.Algorithm, PrivateKey: encoded}
+	if err := db.Create(model).Error; err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// hasActive reports whether any of the keys still signs
+func hasActive(models []SigningKeyModel) bool {
+	for _, model := range models {
+		if model.RetiredAt == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package jwt_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/tokens"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/jwt"
+	"clean-arch-gin/internal/testutil/repotest"
+
+	"gorm.io/gorm"
+)
+
+// testIssuer is the issuer of the key sets under test
+const testIssuer = "https://auth.example.com"
+
+// openKeyDB opens a database with the signing key table
+func openKeyDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := repotest.OpenSQLite(t)
+	if err := database.AutoMigrate(db, &jwt.SigningKeyModel{}); err != nil {
+		t.Fatalf("migrate signing keys: %v", err)
+	}
+	return db
+}
+
+// newKeySet loads a key set on db signing with algorithm for testIssuer
+func newKeySet(t *testing.T, db *gorm.DB, algorithm string) *jwt.KeySet {
+	t.Helper()
+	ks, err := jwt.NewKeySet(db, jwt.Options{Algorithm: algorithm, Issuer: testIssuer, TokenTTL: time.Hour})
+	if err != nil {
+		t.Fatalf("new key set: %v", err)
+	}
+	if err := ks.Load(context.Background()); err != nil {
+		t.Fatalf("load keys: %v", err)
+	}
+	return ks
+}
+
+// validClaims are claims of a token issued now for an hour
+func validClaims() tokens.Claims {
+	now := time.Now()
+	return tokens.Claims{Subject: "42", TenantID: 3, ID: "token-1", IssuedAt: now, ExpiresAt: now.Add(time.Hour)}
+}
+
+// sign signs claims with ks, failing the test on error
+func sign(t *testing.T, ks *jwt.KeySet, claims tokens.Claims) string {
+	t.Helper()
+	token, err := ks.Sign(context.Background(), claims)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return token
+}
+
+func TestKeySetRoundTrip(t *testing.T) {
+	for _, algorithm := range []string{jwt.HS256, jwt.RS256, jwt.ES256} {
+		t.Run(algorithm, func(t *testing.T) {
+			ctx := context.Background()
+			db := openKeyDB(t)
+			ks := newKeySet(t, db, algorithm)
+			want := validClaims()
+			token := sign(t, ks, want)
+
+			got, err := ks.Verify(ctx, token)
+			if err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+			if got.Subject != want.Subject || got.TenantID != want.TenantID || got.ID != want.ID ||
+				got.ExpiresAt.Unix() != want.ExpiresAt.Unix() || got.IssuedAt.Unix() != want.IssuedAt.Unix() {
+				t.Errorf("claims = %+v, want %+v", got, want)
+			}
+
+			// Another replica loads the same keys from the database
+			if _, err := newKeySet(t, db, algorithm).Verify(ctx, token); err != nil {
+				t.Errorf("verify on another replica: %v", err)
+			}
+		})
+	}
+}
+
+func TestKeySetRejects(t *testing.T) {
+	ctx := context.Background()
+	db := openKeyDB(t)
+	ks := newKeySet(t, db, jwt.RS256)
+
+	expired := validClaims()
+	expired.IssuedAt, expired.ExpiresAt = time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour)
+	otherIssuer, err := jwt.NewKeySet(db, jwt.Options{Algorithm: jwt.RS256, Issuer: "https://other.example.com"})
+	if err != nil {
+		t.Fatalf("new key set: %v", err)
+	}
+	if err := otherIssuer.Load(ctx); err != nil {
+		t.Fatalf("load keys: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"expired", sign(t, ks, expired), jwt.ErrExpired},
+		{"wrong issuer", sign(t, otherIssuer, validClaims()), jwt.ErrIssuer},
+		{"unknown kid", sign(t, newKeySet(t, openKeyDB(t), jwt.RS256), validClaims()), jwt.ErrUnknownKey},
+		{"not a JWS", "not-a-token", jwt.ErrMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ks.Verify(ctx, tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !errors.Is(err, tokens.ErrInvalid) {
+				t.Errorf("err = %v, want it to wrap tokens.ErrInvalid", err)
+			}
+		})
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	ctx := context.Background()
+	db := openKeyDB(t)
+	ks := newKeySet(t, db, jwt.ES256)
+	before := sign(t, ks, validClaims())
+
+	if err := ks.Rotate(ctx); err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	after := sign(t, ks, validClaims())
+	if kid(t, before) == kid(t, after) {
+		t.Fatalf("kid %s still signs after the rotation", kid(t, before))
+	}
+	for name, token := range map[string]string{"retired key": before, "new key": after} {
+		if _, err := ks.Verify(ctx, token); err != nil {
+			t.Errorf("verify token of the %s: %v", name, err)
+		}
+	}
+
+	var jwks struct {
+		Keys []jwt.JWK `json:"keys"`
+	}
+	if err := json.Unmarshal(ks.JWKS(), &jwks); err != nil {
+		t.Fatalf("decode JWKS: %v", err)
+	}
+	if len(jwks.Keys) != 2 {
+		t.Errorf("JWKS publishes %d keys, want the retired and the new one", len(jwks.Keys))
+	}
+
+	// Once the retired key outlived its tokens it is deleted and its tokens are refused
+	if err := db.Model(&jwt.SigningKeyModel{}).Where("kid = ?", kid(t, before)).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatalf("expire retired key: %v", err)
+	}
+	if rotated, err := ks.RotateIfDue(ctx); err != nil || rotated {
+		t.Fatalf("RotateIfDue = %v, %v; want no rotation", rotated, err)
+	}
+	if err := ks.Load(ctx); err != nil {
+		t.Fatalf("load keys: %v", err)
+	}
+	if _, err := ks.Verify(ctx, before); !errors.Is(err, jwt.ErrUnknownKey) {
+		t.Errorf("verify token of the expired key: err = %v, want %v", err, jwt.ErrUnknownKey)
+	}
+	infos, err := ks.Keys(ctx)
+	if err != nil {
+		t.Fatalf("keys: %v", err)
+	}
+	if len(infos) != 1 || infos[0].KID != kid(t, after) {
+		t.Errorf("keys = %+v, want only %s", infos, kid(t, after))
+	}
+}
+
+func TestKeySetRotateIfDue(t *testing.T) {
+	ctx := context.Background()
+	ks, err := jwt.NewKeySet(openKeyDB(t), jwt.Options{Algorithm: jwt.HS256, Issuer: testIssuer, RotationInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("new key set: %v", err)
+	}
+	if err := ks.Load(ctx); err != nil {
+		t.Fatalf("load keys: %v", err)
+	}
+	if rotated, err := ks.RotateIfDue(ctx); err != nil || rotated {
+		t.Errorf("RotateIfDue on a new key = %v, %v; want no rotation", rotated, err)
+	}
+	if string(ks.JWKS()) != `{"keys":[]}` {
+		t.Errorf("JWKS = %s, want HS256 keys kept secret", ks.JWKS())
+	}
+}
@@ -0,0 +1,286 @@
+// Package kafka consumes Kafka topics in a consumer group on behalf of the modules
+// Messages of a partition are handled one at a time, in order; a message whose handler fails
+// is moved to a retry topic, handled again after a delay and moved to a dead letter topic once
+// its retries run out, so one bad message never holds up its partition
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"clean-arch-gin/internal/infrastructure/retry"
+
+	"github.com/IBM/sarama"
+)
+
+// Commit strategies
+const (
+	// CommitInterval commits the offsets of handled messages every CommitInterval and when
+	// a partition is handed over; a crash redelivers up to an interval of messages
+	CommitInterval = "interval"
+	// CommitEach commits the offset of every message once it is handled, before the next
+	CommitEach = "each"
+)
+
+// Defaults applied to zero Options fields
+const (
+	defaultCommitInterval = time.Second
+	defaultHandlerTimeout = 30 * time.Second
+	// rejoinDelay spaces attempts at rejoining the group after an error
+	rejoinDelay = 5 * time.Second
+)
+
+// ErrDuplicateTopic is returned when registering a handler for a topic twice
+var ErrDuplicateTopic = errors.New("kafka: topic already has a handler")
+
+// Message is a record read from a topic
+type Message struct {
+	// Topic is the topic the message was first published to, also when it is retried
+	Topic string
+	// Partition and Offset locate the message in the topic it was read from, a retry topic
+	// for a retried message
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Timestamp time.Time
+	// Attempt counts the deliveries of the message to its handler, from 1
+	Attempt int
+}
+
+// Handler handles one message; returning an error retries it through the retry topics
+// unless the error is wrapped with retry.Permanent, which sends it to the dead letter topic
+// at once. Messages are delivered at least once, so handlers must be idempotent
+type Handler func(ctx context.Context, msg *Message) error
+
+// Options configures a Consumer
+type Options struct {
+	// Brokers are the bootstrap brokers
+	Brokers []string
+	// GroupID names the consumer group the replicas share partitions in
+	GroupID string
+	// ClientID identifies this replica to the brokers
+	ClientID string
+	// Version is the Kafka protocol version to speak (sarama.DefaultVersion)
+	Version string
+	// Commit is CommitInterval (the default) or CommitEach
+	Commit string
+	// CommitInterval is how often CommitInterval commits (1s)
+	CommitInterval time.Duration
+	// Oldest starts a group without committed offsets at the oldest message rather than
+	// the next one published
+	Oldest bool
+	// RetryDelays are the delays of the retry topics a failed message goes through in turn;
+	// none sends failed messages to the dead letter topic at once
+	RetryDelays []time.Duration
+	// HandlerTimeout bounds a delivery (30s); a delivery under way when the consumer stops or
+	// its partition is handed over still gets to finish within it
+	HandlerTimeout time.Duration
+}
+
+// Consumer runs the registered handlers in a consumer group
+// Group membership, partition assignment and rebalancing are left to the brokers; every
+// assigned partition of a topic and of its retry topics is consumed on a goroutine of its own
+type Consumer struct {
+	opts   Options
+	config *sarama.Config
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+
+	started bool
+	group   sarama.ConsumerGroup
+	// producer publishes failed messages to the retry and dead letter topics
+	producer sarama.SyncProducer
+	done     chan struct{}
+}
+
+// New creates a consumer; zero Options fields use the defaults. It connects once Start is
+// called
+func New(opts Options) (*Consumer, error) {
+	if len(opts.Brokers) == 0 {
+		return nil, errors.New("kafka: no brokers")
+	}
+	if opts.GroupID == "" {
+		return nil, errors.New("kafka: no consumer group")
+	}
+	if opts.CommitInterval <= 0 {
+		opts.CommitInterval = defaultCommitInterval
+	}
+	if opts.HandlerTimeout <= 0 {
+		opts.HandlerTimeout = defaultHandlerTimeout
+	}
+
+	config, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	switch opts.Commit {
+	case "", CommitInterval:
+		config.Consumer.Offsets.AutoCommit.Interval = opts.CommitInterval
+	case CommitEach:
+		config.Consumer.Offsets.AutoCommit.Enable = false
+	default:
+		return nil, fmt.Errorf("kafka: unsupported commit strategy: %s", opts.Commit)
+	}
+	if opts.Oldest {
+		config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	}
+	config.Consumer.Return.Errors = true
+
+	return &Consumer{opts: opts, config: config, handlers: make(map[string]Handler), done: make(chan struct{})}, nil
+}
+
+// newConfig creates the client configuration shared by consumers and producers: the protocol
+// version, the client ID and producers waiting for every in-sync replica
+func newConfig(opts Options) (*sarama.Config, error) {
+	config := sarama.NewConfig()
+	if opts.Version != "" {
+		version, err := sarama.ParseKafkaVersion(opts.Version)
+		if err != nil {
+			return nil, fmt.Errorf("kafka: %w", err)
+		}
+		config.Version = version
+	}
+	if opts.ClientID != "" {
+		config.ClientID = opts.ClientID
+	}
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+	return config, nil
+}
+
+// Register adds the handler of topic; handlers registered after Start are ignored
+func (c *Consumer) Register(topic string, handler Handler) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.handlers[topic]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateTopic, topic)
+	}
+	c.handlers[topic] = handler
+	return nil
+}
+
+// Topics lists the topics with a handler, sorted
+func (c *Consumer) Topics() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	topics := make([]string, 0, len(c.handlers))
+	for topic := range c.handlers {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// Start joins the group and consumes the registered topics and their retry topics until ctx
+// is cancelled; it does not block, and Shutdown waits for the deliveries under way and the
+// last commit. Without handlers it does nothing
+func (c *Consumer) Start(ctx context.Context) error {
+	topics := c.Topics()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.started || len(topics) == 0 {
+		return nil
+	}
+
+	producer, err := sarama.NewSyncProducer(c.opts.Brokers, c.config)
+	if err != nil {
+		return fmt.Errorf("kafka: failed to connect the producer: %w", err)
+	}
+	group, err := sarama.NewConsumerGroup(c.opts.Brokers, c.opts.GroupID, c.config)
+	if err != nil {
+		producer.Close()
+		return fmt.Errorf("kafka: failed to join group %s: %w", c.opts.GroupID, err)
+	}
+	c.producer, c.group, c.started = producer, group, true
+
+	subscribed := append([]string(nil), topics...)
+	for _, topic := range topics {
+		for tier := range c.opts.RetryDelays {
+			subscribed = append(subscribed, c.retryTopic(topic, tier+1))
+		}
+	}
+	go c.logErrors()
+	go c.consume(ctx, subscribed)
+	return nil
+}
+
+// consume stays in the group, rejoining after every rebalance, until ctx is cancelled
+func (c *Consumer) consume(ctx context.Context, topics []string) {
+	defer close(c.done)
+	handler := &groupHandler{consumer: c}
+	for {
+		err := c.group.Consume(ctx, topics, handler)
+		if errors.Is(err, sarama.ErrClosedConsumerGroup) || ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Printf("kafka: group %s: %v", c.opts.GroupID, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(rejoinDelay):
+			}
+		}
+	}
+}
+
+// logErrors logs the errors of the group, e.g. failed commits, until it is closed
+func (c *Consumer) logErrors() {
+	for err := range c.group.Errors() {
+		log.Printf("kafka: group %s: %v", c.opts.GroupID, err)
+	}
+}
+
+// Shutdown waits for the group to be left, after the deliveries under way finish and their
+// offsets are committed, or ctx to be done. Cancel the context passed to Start first
+func (c *Consumer) Shutdown(ctx context.Context) error {
+	c.mu.RLock()
+	started := c.started
+	c.mu.RUnlock()
+	if !started {
+		return nil
+	}
+
+	select {
+	case <-c.done:
+	case <-ctx.Done():
+		return fmt.Errorf("kafka: deliveries still under way: %w", ctx.Err())
+	}
+	err := c.group.Close()
+	if closeErr := c.producer.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// handler returns the handler of topic
+func (c *Consumer) handler(topic string) (Handler, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	handler, ok := c.handlers[topic]
+	return handler, ok
+}
+
+// handle delivers msg to the handler of its topic within the handler timeout; the delivery is
+// not cut short by ctx being done, only waited for
+func (c *Consumer) handle(ctx context.Context, msg *Message) error {
+	handler, ok := c.handler(msg.Topic)
+	if !ok {
+		return retry.Permanent(fmt.Errorf("no handler for topic %s", msg.Topic))
+	}
+	handlerCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.opts.HandlerTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := handler(handlerCtx, msg)
+	observeDelivery(msg.Topic, err, time.Since(start))
+	return err
+}
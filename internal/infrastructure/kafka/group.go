@@ -0,0 +1,200 @@
+package kafka
+
+import (
+	"context"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"clean-arch-gin/internal/infrastructure/retry"
+
+	"github.com/IBM/sarama"
+)
+
+// Headers a failed message is republished with
+const (
+	// topicHeader keeps the topic the message was first published to
+	topicHeader = "x-original-topic"
+	// attemptHeader counts the deliveries the message has had
+	attemptHeader = "x-attempt"
+	// errorHeader keeps the error of the last delivery
+	errorHeader = "x-error"
+	// maxErrorLength bounds the error kept in errorHeader
+	maxErrorLength = 1024
+)
+
+// publishRetry spaces attempts at republishing a failed message, which go on until they
+// succeed or the partition is handed over
+var publishRetry = retry.Policy{MaxAttempts: math.MaxInt32, MaxDelay: 10 * time.Second}
+
+// retryTopic names the retry topic of topic at tier, from 1; topics are per group so groups
+// consuming the same topic retry apart
+func (c *Consumer) retryTopic(topic string, tier int) string {
+	return topic + "." + c.opts.GroupID + ".retry." + strconv.Itoa(tier)
+}
+
+// DeadLetterTopic names the topic the messages of topic that failed every retry are moved to
+func (c *Consumer) DeadLetterTopic(topic string) string {
+	return topic + "." + c.opts.GroupID + ".dlq"
+}
+
+// retryTier is the tier of the retry topic a message was read from, 0 for a registered topic
+func (c *Consumer) retryTier(msg *sarama.ConsumerMessage) int {
+	original := header(msg, topicHeader)
+	if original == "" {
+		return 0
+	}
+	tier, err := strconv.Atoi(strings.TrimPrefix(msg.Topic, original+"."+c.opts.GroupID+".retry."))
+	if err != nil || tier < 1 || tier > len(c.opts.RetryDelays) {
+		return 0
+	}
+	return tier
+}
+
+// groupHandler consumes the partitions assigned to this replica
+type groupHandler struct {
+	consumer *Consumer
+}
+
+// Setup logs the partitions assigned in a new generation of the group
+func (h *groupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	log.Printf("kafka: group %s generation %d assigned %v", h.consumer.opts.GroupID, session.GenerationID(), session.Claims())
+	return nil
+}
+
+// Cleanup has nothing to release; offsets are committed by the session
+func (h *groupHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim handles the messages of one partition in order until the partition is handed
+// over or the consumer stops; the message under way is finished first
+func (h *groupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	ctx := session.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			if !h.process(ctx, msg) {
+				return nil
+			}
+			session.MarkMessage(msg, "")
+			if h.consumer.opts.Commit == CommitEach {
+				session.Commit()
+			}
+		}
+	}
+}
+
+// process delivers msg, waiting out its retry delay first when it comes from a retry topic,
+// and moves it on when the delivery fails; it reports whether msg is done with, false when
+// ctx was done first and msg is left to be redelivered
+func (h *groupHandler) process(ctx context.Context, msg *sarama.ConsumerMessage) bool {
+	c := h.consumer
+	tier := c.retryTier(msg)
+	if tier > 0 {
+		wait := time.Until(msg.Timestamp.Add(c.opts.RetryDelays[tier-1]))
+		if wait > 0 {
+			select {
+			case <-ctx.Done():
+				return false
+			case <-time.After(wait):
+			}
+		}
+	}
+
+	message := newMessage(msg, tier)
+	err := c.handle(ctx, message)
+	if err == nil {
+		return true
+	}
+
+	next := c.DeadLetterTopic(message.Topic)
+	if !retry.IsPermanent(err) && tier < len(c.opts.RetryDelays) {
+		next = c.retryTopic(message.Topic, tier+1)
+	}
+	log.Printf("kafka: message %s/%d/%d failed attempt %d, moving it to %s: %v",
+		msg.Topic, msg.Partition, msg.Offset, message.Attempt, next, err)
+	return c.republish(ctx, next, msg, message, err)
+}
+
+// republish publishes msg to topic with its retry headers, retrying until it succeeds or ctx
+// is done; the key is kept so the retries of a key stay in one partition
+func (c *Consumer) republish(ctx context.Context, topic string, msg *sarama.ConsumerMessage, message *Message, cause error) bool {
+	reason := cause.Error()
+	if len(reason) > maxErrorLength {
+		reason = reason[:maxErrorLength]
+	}
+	headers := []sarama.RecordHeader{
+		{Key: []byte(topicHeader), Value: []byte(message.Topic)},
+		{Key: []byte(attemptHeader), Value: []byte(strconv.Itoa(message.Attempt))},
+		{Key: []byte(errorHeader), Value: []byte(reason)},
+	}
+	for _, h := range msg.Headers {
+		switch string(h.Key) {
+		case topicHeader, attemptHeader, errorHeader:
+		default:
+			headers = append(headers, *h)
+		}
+	}
+	// The timestamp is when the retry delay starts
+	out := &sarama.ProducerMessage{Topic: topic, Value: sarama.ByteEncoder(msg.Value), Headers: headers, Timestamp: time.Now()}
+	if msg.Key != nil {
+		out.Key = sarama.ByteEncoder(msg.Key)
+	}
+
+	policy := publishRetry
+	policy.OnRetry = func(attempt int, err error, delay time.Duration) {
+		log.Printf("kafka: failed to publish to %s, retrying in %s: %v", topic, delay.Round(time.Millisecond), err)
+	}
+	err := retry.Do(ctx, policy, func(context.Context) error {
+		_, _, err := c.producer.SendMessage(out)
+		return err
+	})
+	if err == nil {
+		observeMoved(message.Topic, topic == c.DeadLetterTopic(message.Topic))
+	}
+	return err == nil
+}
+
+// newMessage converts msg, read from the retry topic at tier or a registered topic at 0
+func newMessage(msg *sarama.ConsumerMessage, tier int) *Message {
+	message := &Message{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   make(map[string]string, len(msg.Headers)),
+		Timestamp: msg.Timestamp,
+		Attempt:   1,
+	}
+	for _, h := range msg.Headers {
+		message.Headers[string(h.Key)] = string(h.Value)
+	}
+	if tier > 0 {
+		message.Topic = message.Headers[topicHeader]
+		if attempt, err := strconv.Atoi(message.Headers[attemptHeader]); err == nil {
+			message.Attempt = attempt + 1
+		}
+	}
+	return message
+}
+
+// header returns the value of the header key of msg, empty when it has none
+func header(msg *sarama.ConsumerMessage, key string) string {
+	for _, h := range msg.Headers {
+		if string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+var _ sarama.ConsumerGroupHandler = (*groupHandler)(nil)
@@ -0,0 +1,45 @@
+package kafka
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	deliveriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_deliveries_total",
+		Help: "Kafka messages delivered to their handler by topic and outcome: succeeded or failed.",
+	}, []string{"topic", "outcome"})
+
+	deliveryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kafka_delivery_duration_seconds",
+		Help:    "Kafka message handler duration by topic.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"topic"})
+
+	movedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_moved_messages_total",
+		Help: "Failed Kafka messages moved by topic and destination: retry or dead_letter.",
+	}, []string{"topic", "destination"})
+)
+
+// observeDelivery records the outcome of a delivery
+func observeDelivery(topic string, err error, duration time.Duration) {
+	outcome := "succeeded"
+	if err != nil {
+		outcome = "failed"
+	}
+	deliveriesTotal.WithLabelValues(topic, outcome).Inc()
+	deliveryDuration.WithLabelValues(topic).Observe(duration.Seconds())
+}
+
+// observeMoved records a failed message moved to a retry or the dead letter topic
+func observeMoved(topic string, deadLetter bool) {
+	destination := "retry"
+	if deadLetter {
+		destination = "dead_letter"
+	}
+	movedTotal.WithLabelValues(topic, destination).Inc()
+}
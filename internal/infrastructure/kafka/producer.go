@@ -0,0 +1,98 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+
+	"clean-arch-gin/internal/infrastructure/retry"
+
+	"github.com/IBM/sarama"
+)
+
+// Record is a message to publish
+type Record struct {
+	Topic string
+	// Key picks the partition; records with the same key keep their order
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// Producer publishes records, waiting for every in-sync replica to have them
+type Producer struct {
+	producer sarama.SyncProducer
+}
+
+// NewProducer connects a producer to opts.Brokers; only the connection Options are used
+// One request is in flight per broker, so the records of a partition are written in order
+// also when sends are retried
+func NewProducer(opts Options) (*Producer, error) {
+	if len(opts.Brokers) == 0 {
+		return nil, errors.New("kafka: no brokers")
+	}
+	config, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	config.Net.MaxOpenRequests = 1
+
+	producer, err := sarama.NewSyncProducer(opts.Brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: failed to connect the producer: %w", err)
+	}
+	return &Producer{producer: producer}, nil
+}
+
+// SendAll publishes records and reports how many of them, from the first, were published
+// before the first that failed. Errors the record itself causes, e.g. exceeding the size
+// limit, are wrapped with retry.Permanent
+func (p *Producer) SendAll(records []Record) (int, error) {
+	messages := make([]*sarama.ProducerMessage, len(records))
+	for i, record := range records {
+		messages[i] = &sarama.ProducerMessage{Topic: record.Topic, Value: sarama.ByteEncoder(record.Value)}
+		if record.Key != nil {
+			messages[i].Key = sarama.ByteEncoder(record.Key)
+		}
+		for key, value := range record.Headers {
+			messages[i].Headers = append(messages[i].Headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+		}
+	}
+
+	err := p.producer.SendMessages(messages)
+	if err == nil {
+		return len(records), nil
+	}
+	var failures sarama.ProducerErrors
+	if !errors.As(err, &failures) {
+		return 0, err
+	}
+
+	// Records after the first failure may have been published too; they are sent again
+	first, firstErr := len(records), error(nil)
+	for _, failure := range failures {
+		for i, message := range messages[:first] {
+			if message == failure.Msg {
+				first, firstErr = i, failure.Err
+				break
+			}
+		}
+	}
+	if firstErr == nil {
+		return 0, err
+	}
+	if isRecordError(firstErr) {
+		firstErr = retry.Permanent(firstErr)
+	}
+	return first, fmt.Errorf("kafka: failed to publish to %s: %w", records[first].Topic, firstErr)
+}
+
+// Close flushes and closes the producer
+func (p *Producer) Close() error {
+	return p.producer.Close()
+}
+
+// isRecordError reports whether err is caused by the record, so sending it again fails too
+func isRecordError(err error) bool {
+	return errors.Is(err, sarama.ErrMessageSizeTooLarge) || errors.Is(err, sarama.ErrInvalidMessage) ||
+		errors.Is(err, sarama.ErrInvalidMessageSize) || errors.Is(err, sarama.ErrMessageSetSizeTooLarge)
+}
@@ -0,0 +1,56 @@
+package leader
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LeaseModel stores a leader lease; ExpiresAt is in Unix milliseconds so every renewal
+// changes the row and is counted as affected
+type LeaseModel struct {
+	Name      string `gorm:"primaryKey;size:128"`
+	Holder    string `gorm:"not null;size:255;default:''"`
+	ExpiresAt int64  `gorm:"not null;default:0"`
+}
+
+// TableName sets the table name for GORM
+func (LeaseModel) TableName() string {
+	return "leader_leases"
+}
+
+// DatabaseLock keeps the lease in the leader_leases table, for deployments without Redis
+// Expiry is judged by each replica's clock, so keep clock skew well below the TTL
+type DatabaseLock struct {
+	db *gorm.DB
+}
+
+// NewDatabaseLock creates a lock on db; LeaseModel must be migrated
+func NewDatabaseLock(db *gorm.DB) *DatabaseLock {
+	return &DatabaseLock{db: db}
+}
+
+// Acquire takes the lease if it is free or expired, or extends it if holder has it
+func (l *DatabaseLock) Acquire(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	db := l.db.WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&LeaseModel{Name: key}).Error; err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	result := db.Model(&LeaseModel{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", key, holder, now.UnixMilli()).
+		Updates(map[string]interface{}{"holder": holder, "expires_at": now.Add(ttl).UnixMilli()})
+	return result.RowsAffected == 1, result.Error
+}
+
+// Release frees the lease if holder has it
+func (l *DatabaseLock) Release(ctx context.Context, key, holder string) error {
+	return l.db.WithContext(ctx).Model(&LeaseModel{}).
+		Where("name = ? AND holder = ?", key, holder).
+		Updates(map[string]interface{}{"holder": "", "expires_at": 0}).Error
+}
+
+var _ Lock = (*DatabaseLock)(nil)
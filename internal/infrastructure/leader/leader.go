@@ -0,0 +1,177 @@
+// Package leader elects one replica to run cluster-wide work, such as scheduled jobs
+package leader
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Defaults applied to zero Options fields
+const (
+	defaultTTL          = 15 * time.Second
+	defaultReleaseLimit = 5 * time.Second
+)
+
+var isLeaderGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "leader_election_is_leader",
+	Help: "1 while this instance holds the leader lease, else 0.",
+}, []string{"key"})
+
+// Lock is a lease shared by every replica; whoever holds it is the leader
+type Lock interface {
+	// Acquire takes the lease for holder, or extends it when holder already has it,
+	// for ttl; it reports whether holder has the lease afterwards
+	Acquire(ctx context.Context, key, holder string, ttl time.Duration) (bool, error)
+	// Release gives the lease up if holder has it, so another replica takes over at once
+	Release(ctx context.Context, key, holder string) error
+}
+
+// Options configures an Elector
+type Options struct {
+	// Key names the lease; replicas competing for the same work share it
+	Key string
+	// ID identifies this replica (hostname-pid when empty)
+	ID string
+	// TTL is how long the lease lasts without renewal, and so how long failover takes (15s)
+	TTL time.Duration
+	// RenewInterval is how often the leader renews and followers retry (TTL/3)
+	RenewInterval time.Duration
+}
+
+// Elector keeps trying to hold the lease and reports whether this replica is the leader
+// The leader renews the lease well before it expires; when a renewal fails it steps
+// down at once, so two replicas never both believe they lead while the lock backend
+// is healthy. When the leader dies its lease expires and a follower takes over
+type Elector struct {
+	lock Lock
+	opts Options
+
+	leader  atomic.Bool
+	started atomic.Bool
+	done    chan struct{}
+}
+
+// NewElector creates an elector on lock; zero Options fields use the defaults
+func NewElector(lock Lock, opts Options) *Elector {
+	if opts.TTL <= 0 {
+		opts.TTL = defaultTTL
+	}
+	if opts.RenewInterval <= 0 || opts.RenewInterval >= opts.TTL {
+		opts.RenewInterval = opts.TTL / 3
+	}
+	if opts.ID == "" {
+		opts.ID = DefaultID()
+	}
+	return &Elector{lock: lock, opts: opts, done: make(chan struct{})}
+}
+
+// DefaultID identifies this process by hostname (the pod name on Kubernetes) and pid
+func DefaultID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return host + "-" + strconv.Itoa(os.Getpid())
+}
+
+// ID returns the identity this replica holds the lease under
+func (e *Elector) ID() string {
+	return e.opts.ID
+}
+
+// IsLeader reports whether this replica currently holds the lease
+func (e *Elector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Start campaigns for the lease until ctx is cancelled, then releases it
+// It does not block; Shutdown waits for the lease to be released
+func (e *Elector) Start(ctx context.Context) {
+	if !e.started.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer close(e.done)
+		e.run(ctx)
+	}()
+}
+
+// run renews or acquires the lease every RenewInterval
+func (e *Elector) run(ctx context.Context) {
+	ticker := time.NewTicker(e.opts.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		e.campaign(ctx)
+
+		select {
+		case <-ctx.Done():
+			e.release()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// campaign makes one attempt to acquire or renew the lease
+func (e *Elector) campaign(ctx context.Context) {
+	attemptCtx, cancel := context.WithTimeout(ctx, e.opts.RenewInterval)
+	defer cancel()
+
+	held, err := e.lock.Acquire(attemptCtx, e.opts.Key, e.opts.ID, e.opts.TTL)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("leader: failed to acquire %s: %v", e.opts.Key, err)
+		}
+		held = false
+	}
+	e.setLeader(held)
+}
+
+// release gives the lease up on shutdown so a follower takes over without waiting for the TTL
+func (e *Elector) release() {
+	if !e.leader.Load() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultReleaseLimit)
+	defer cancel()
+	if err := e.lock.Release(ctx, e.opts.Key, e.opts.ID); err != nil {
+		log.Printf("leader: failed to release %s: %v", e.opts.Key, err)
+	}
+	e.setLeader(false)
+}
+
+// setLeader records a change of leadership; only the campaign goroutine calls it
+func (e *Elector) setLeader(held bool) {
+	if e.leader.Swap(held) == held {
+		return
+	}
+	if held {
+		log.Printf("leader: %s is now the leader of %s", e.opts.ID, e.opts.Key)
+		isLeaderGauge.WithLabelValues(e.opts.Key).Set(1)
+	} else {
+		log.Printf("leader: %s is no longer the leader of %s", e.opts.ID, e.opts.Key)
+		isLeaderGauge.WithLabelValues(e.opts.Key).Set(0)
+	}
+}
+
+// Shutdown waits for the campaign started by Start to release the lease, or ctx to be done
+// Cancel the context passed to Start first
+func (e *Elector) Shutdown(ctx context.Context) error {
+	if !e.started.Load() {
+		return nil
+	}
+	select {
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,176 @@
+package leader_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/leader"
+	"clean-arch-gin/internal/testutil/repotest"
+)
+
+// testKey names the lease under test
+const testKey = "scheduler"
+
+// newDatabaseLock creates a lock on SQLite with the lease table migrated
+func newDatabaseLock(t *testing.T) *leader.DatabaseLock {
+	t.Helper()
+	db := repotest.OpenSQLite(t)
+	if err := database.AutoMigrate(db, &leader.LeaseModel{}); err != nil {
+		t.Fatalf("migrate leases: %v", err)
+	}
+	return leader.NewDatabaseLock(db)
+}
+
+// flakyLock wraps a Lock whose Acquire fails while broken is set
+type flakyLock struct {
+	leader.Lock
+	broken atomic.Bool
+}
+
+// Acquire fails while the lock is broken, else acquires the wrapped lock
+func (l *flakyLock) Acquire(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	if l.broken.Load() {
+		return false, errors.New("connection refused")
+	}
+	return l.Lock.Acquire(ctx, key, holder, ttl)
+}
+
+// campaign starts e until the test ends or stop is called, which waits for the lease release
+func campaign(t *testing.T, e *leader.Elector) (stop func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	e.Start(ctx)
+	stop = func() {
+		cancel()
+		shutdownCtx, done := context.WithTimeout(context.Background(), 5*time.Second)
+		defer done()
+		if err := e.Shutdown(shutdownCtx); err != nil {
+			t.Errorf("Shutdown() error = %v", err)
+		}
+	}
+	t.Cleanup(stop)
+	return stop
+}
+
+// waitFor polls cond until it holds, failing the test after a few seconds
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDatabaseLockAcquire(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup runs against the lock before b tries to acquire it
+		setup func(ctx context.Context, lock *leader.DatabaseLock) error
+		want  bool
+	}{
+		{"free", func(context.Context, *leader.DatabaseLock) error { return nil }, true},
+		{"held by another replica", func(ctx context.Context, lock *leader.DatabaseLock) error {
+			_, err := lock.Acquire(ctx, testKey, "a", time.Minute)
+			return err
+		}, false},
+		{"renewed by its holder", func(ctx context.Context, lock *leader.DatabaseLock) error {
+			_, err := lock.Acquire(ctx, testKey, "b", time.Minute)
+			return err
+		}, true},
+		{"expired", func(ctx context.Context, lock *leader.DatabaseLock) error {
+			_, err := lock.Acquire(ctx, testKey, "a", -time.Second)
+			return err
+		}, true},
+		{"released", func(ctx context.Context, lock *leader.DatabaseLock) error {
+			if _, err := lock.Acquire(ctx, testKey, "a", time.Minute); err != nil {
+				return err
+			}
+			return lock.Release(ctx, testKey, "a")
+		}, true},
+		{"released by another replica", func(ctx context.Context, lock *leader.DatabaseLock) error {
+			if _, err := lock.Acquire(ctx, testKey, "a", time.Minute); err != nil {
+				return err
+			}
+			return lock.Release(ctx, testKey, "c")
+		}, false},
+		{"other key held", func(ctx context.Context, lock *leader.DatabaseLock) error {
+			_, err := lock.Acquire(ctx, "outbox", "a", time.Minute)
+			return err
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			lock := newDatabaseLock(t)
+			if err := tt.setup(ctx, lock); err != nil {
+				t.Fatalf("setup: %v", err)
+			}
+			got, err := lock.Acquire(ctx, testKey, "b", time.Minute)
+			if err != nil {
+				t.Fatalf("Acquire() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Acquire() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElectorFailover(t *testing.T) {
+	lock := newDatabaseLock(t)
+	opts := leader.Options{Key: testKey, TTL: 300 * time.Millisecond, RenewInterval: 50 * time.Millisecond}
+	opts.ID = "a"
+	a := leader.NewElector(lock, opts)
+	opts.ID = "b"
+	b := leader.NewElector(lock, opts)
+
+	stopA := campaign(t, a)
+	waitFor(t, "a to lead", a.IsLeader)
+	campaign(t, b)
+	// b keeps campaigning for longer than the TTL while a renews the lease
+	time.Sleep(2 * opts.TTL)
+	if !a.IsLeader() || b.IsLeader() {
+		t.Fatalf("a leads = %v, b leads = %v; want only a", a.IsLeader(), b.IsLeader())
+	}
+
+	stopA()
+	if a.IsLeader() {
+		t.Error("a still leads after Shutdown")
+	}
+	// The lease was released, so b takes over within a renewal rather than a TTL
+	waitFor(t, "b to lead", b.IsLeader)
+}
+
+func TestElectorStepsDownWhenRenewalFails(t *testing.T) {
+	lock := &flakyLock{Lock: newDatabaseLock(t)}
+	e := leader.NewElector(lock, leader.Options{Key: testKey, ID: "a", TTL: 300 * time.Millisecond, RenewInterval: 50 * time.Millisecond})
+	campaign(t, e)
+	waitFor(t, "a to lead", e.IsLeader)
+
+	lock.broken.Store(true)
+	waitFor(t, "a to step down", func() bool { return !e.IsLeader() })
+	lock.broken.Store(false)
+	// a still holds the unexpired lease, so it leads again as soon as the lock recovers
+	waitFor(t, "a to lead again", e.IsLeader)
+}
+
+func TestNewElectorDefaults(t *testing.T) {
+	e := leader.NewElector(newDatabaseLock(t), leader.Options{Key: testKey})
+	if e.ID() != leader.DefaultID() {
+		t.Errorf("ID() = %q, want %q", e.ID(), leader.DefaultID())
+	}
+	if e.IsLeader() {
+		t.Error("IsLeader() before Start = true, want false")
+	}
+	if err := e.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() before Start error = %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package leader
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// acquireScript sets the lease if it is free, or extends it if holder already has it
+var acquireScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return 1
+end
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// releaseScript deletes the lease only if holder still has it
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisLock keeps the lease in a Redis key that expires after the TTL
+type RedisLock struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisLock creates a lock storing leases under prefix+key
+func NewRedisLock(client redis.UniversalClient, prefix string) *RedisLock {
+	return &RedisLock{client: client, prefix: prefix}
+}
+
+// Acquire sets or extends the lease key for holder
+func (l *RedisLock) Acquire(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	held, err := acquireScript.Run(ctx, l.client, []string{l.prefix + key}, holder, ttl.Milliseconds()).Int()
+	return held == 1, err
+}
+
+// Release deletes the lease key if holder has it
+func (l *RedisLock) Release(ctx context.Context, key, holder string) error {
+	return releaseScript.Run(ctx, l.client, []string{l.prefix + key}, holder).Err()
+}
+
+var _ Lock = (*RedisLock)(nil)
@@ -0,0 +1,94 @@
+package locks
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	sharedLocks "clean-arch-gin/internal/domain/shared/locks"
+)
+
+// PostgresLockManager takes session-level advisory locks, each on a connection of its own
+// held until the lock is released. The ttl is not needed: Postgres drops the lock with the
+// session of a replica that crashed holding it. Every lock held takes a connection off the
+// pool, so keep them to work that is rare or short
+type PostgresLockManager struct {
+	db *sql.DB
+}
+
+var _ sharedLocks.LockManager = (*PostgresLockManager)(nil)
+
+// NewPostgresLockManager creates a lock manager on the Postgres database db
+func NewPostgresLockManager(db *sql.DB) *PostgresLockManager {
+	return &PostgresLockManager{db: db}
+}
+
+// TryObtain takes the advisory lock of key unless another session has it
+func (m *PostgresLockManager) TryObtain(ctx context.Context, key string, ttl time.Duration) (sharedLocks.Lock, error) {
+	return m.obtain(ctx, key, "SELECT pg_try_advisory_lock($1)")
+}
+
+// Obtain waits for the advisory lock of key; Postgres queues the waiting sessions
+func (m *PostgresLockManager) Obtain(ctx context.Context, key string, ttl time.Duration) (sharedLocks.Lock, error) {
+	return m.obtain(ctx, key, "SELECT true FROM pg_advisory_lock($1)")
+}
+
+// obtain runs query, reporting whether it took the lock, on a connection kept for the lock
+func (m *PostgresLockManager) obtain(ctx context.Context, key, query string) (sharedLocks.Lock, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lock := &postgresLock{conn: conn, key: key, id: advisoryID(key)}
+	var obtained bool
+	if err := conn.QueryRowContext(ctx, query, lock.id).Scan(&obtained); err != nil {
+		// The lock may have been taken as the query was cancelled
+		lock.discard()
+		return nil, err
+	}
+	if !obtained {
+		conn.Close()
+		return nil, fmt.Errorf("%w: %s", sharedLocks.ErrNotObtained, key)
+	}
+	return lock, nil
+}
+
+// advisoryID maps key onto the 64-bit space of advisory locks
+func advisoryID(key string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte("clean-arch-gin:" + key))
+	return int64(hash.Sum64())
+}
+
+// postgresLock is an advisory lock held by the session of conn
+type postgresLock struct {
+	conn *sql.Conn
+	key  string
+	id   int64
+}
+
+// Extend checks the session holding the lock is still alive
+func (l *postgresLock) Extend(ctx context.Context) error {
+	if err := l.conn.PingContext(ctx); err != nil {
+		return fmt.Errorf("%w: %s: %v", sharedLocks.ErrLost, l.key, err)
+	}
+	return nil
+}
+
+// Release unlocks the lock and returns the connection to the pool
+func (l *postgresLock) Release(ctx context.Context) error {
+	if _, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.id); err != nil {
+		l.discard()
+		return err
+	}
+	return l.conn.Close()
+}
+
+// discard closes the session rather than pooling it, which drops any lock it holds
+func (l *postgresLock) discard() {
+	l.conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	l.conn.Close()
+}
@@ -0,0 +1,91 @@
+// Package locks implements the locks port
+package locks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sharedLocks "clean-arch-gin/internal/domain/shared/locks"
+
+	"github.com/go-redsync/redsync/v4"
+	goredis "github.com/go-redsync/redsync/v4/redis/goredis/v9"
+	"github.com/redis/go-redis/v9"
+)
+
+// retryDelay is how long Obtain waits between attempts at a Redis lock held elsewhere
+const retryDelay = 100 * time.Millisecond
+
+// RedisLockManager keeps locks in Redis keys expiring after their ttl, with redsync
+// A lock outlives a replica that crashed holding it by at most its ttl
+type RedisLockManager struct {
+	sync   *redsync.Redsync
+	prefix string
+}
+
+var _ sharedLocks.LockManager = (*RedisLockManager)(nil)
+
+// NewRedisLockManager creates a lock manager storing locks under prefix+key
+func NewRedisLockManager(client redis.UniversalClient, prefix string) *RedisLockManager {
+	return &RedisLockManager{sync: redsync.New(goredis.NewPool(client)), prefix: prefix}
+}
+
+// TryObtain sets the key of the lock unless another holder has it
+func (m *RedisLockManager) TryObtain(ctx context.Context, key string, ttl time.Duration) (sharedLocks.Lock, error) {
+	mutex := m.sync.NewMutex(m.prefix+key, redsync.WithExpiry(ttl), redsync.WithTries(1))
+	if err := mutex.TryLockContext(ctx); err != nil {
+		if isTaken(err) || errors.Is(err, redsync.ErrFailed) {
+			return nil, fmt.Errorf("%w: %s", sharedLocks.ErrNotObtained, key)
+		}
+		return nil, err
+	}
+	return &redisLock{mutex: mutex}, nil
+}
+
+// Obtain retries TryObtain until it takes the lock or ctx is done
+func (m *RedisLockManager) Obtain(ctx context.Context, key string, ttl time.Duration) (sharedLocks.Lock, error) {
+	for {
+		lock, err := m.TryObtain(ctx, key, ttl)
+		if !errors.Is(err, sharedLocks.ErrNotObtained) {
+			return lock, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// redisLock is a lock held in Redis
+type redisLock struct {
+	mutex *redsync.Mutex
+}
+
+// Extend restarts the expiry of the key if it still holds this lock
+func (l *redisLock) Extend(ctx context.Context) error {
+	extended, err := l.mutex.ExtendContext(ctx)
+	switch {
+	case extended:
+		return nil
+	case isTaken(err) || errors.Is(err, redsync.ErrExtendFailed):
+		return fmt.Errorf("%w: %s", sharedLocks.ErrLost, l.mutex.Name())
+	default:
+		return err
+	}
+}
+
+// Release deletes the key if it still holds this lock
+func (l *redisLock) Release(ctx context.Context) error {
+	if _, err := l.mutex.UnlockContext(ctx); !isTaken(err) {
+		return err
+	}
+	return nil
+}
+
+// isTaken reports whether redsync failed because the key is missing or set by another holder
+func isTaken(err error) bool {
+	var taken *redsync.ErrTaken
+	return errors.As(err, &taken)
+}
@@ -0,0 +1,26 @@
+package mail
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"clean-arch-gin/internal/domain/shared/mail"
+)
+
+// Log writes messages to the log instead of sending them, so links mailed in development
+// can be followed from the server output
+type Log struct{}
+
+var _ mail.Mailer = Log{}
+
+// NewLog creates the log mailer
+func NewLog() Log {
+	return Log{}
+}
+
+// Send logs the recipients, subject and plain text of msg
+func (Log) Send(ctx context.Context, msg mail.Message) error {
+	log.Printf("mail: to %s: %s\n%s", strings.Join(msg.To, ", "), msg.Subject, msg.Text)
+	return nil
+}
@@ -0,0 +1,118 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	netmail "net/mail"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/mail"
+)
+
+// SendGridURL is the v3 mail send endpoint
+const SendGridURL = "https://api.sendgrid.com/v3/mail/send"
+
+// apiTimeout bounds a call to a mail provider's API
+const apiTimeout = 10 * time.Second
+
+// sendGridAddress is an address in a SendGrid request
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// sendGridRequest is the body posted to the mail send endpoint
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+// SendGrid sends messages with the SendGrid v3 API, implementing mail.Mailer
+type SendGrid struct {
+	client *http.Client
+	url    string
+	apiKey string
+	from   sendGridAddress
+}
+
+var _ mail.Mailer = (*SendGrid)(nil)
+
+// NewSendGrid creates a mailer sending from from with an API key allowed to send mail
+func NewSendGrid(apiKey, from string) (*SendGrid, error) {
+	if apiKey == "" {
+		return nil, errors.New("SendGrid API key is required")
+	}
+	address, err := netmail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", from, err)
+	}
+	return &SendGrid{
+		client: &http.Client{Timeout: apiTimeout},
+		url:    SendGridURL,
+		apiKey: apiKey,
+		from:   sendGridAddress{Email: address.Address, Name: address.Name},
+	}, nil
+}
+
+// Send posts msg to SendGrid, which accepts it for delivery with 202 Accepted; all
+// recipients get the one message, seeing each other as with SMTP
+func (s *SendGrid) Send(ctx context.Context, msg mail.Message) error {
+	if len(msg.To) == 0 {
+		return errors.New("message has no recipients")
+	}
+	body := sendGridRequest{
+		Personalizations: []sendGridPersonalization{{To: make([]sendGridAddress, len(msg.To))}},
+		From:             s.from,
+		Subject:          msg.Subject,
+		Content:          []sendGridContent{{Type: "text/plain", Value: msg.Text}},
+	}
+	for i, to := range msg.To {
+		body.Personalizations[0].To[i] = sendGridAddress{Email: to}
+	}
+	if msg.HTML != "" {
+		body.Content = append(body.Content, sendGridContent{Type: "text/html", Value: msg.HTML})
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("SendGrid unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return apiError("SendGrid", resp)
+	}
+	return nil
+}
+
+// apiError describes a provider's error response with the start of its body, which names
+// what was wrong with the request
+func apiError(provider string, resp *http.Response) error {
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("%s answered %s: %s", provider, resp.Status, bytes.TrimSpace(detail))
+}
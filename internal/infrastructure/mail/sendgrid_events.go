@@ -0,0 +1,93 @@
+package mail
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"clean-arch-gin/internal/domain/shared/mail"
+)
+
+// Headers of a signed SendGrid event webhook request
+const (
+	SendGridSignatureHeader = "X-Twilio-Email-Event-Webhook-Signature"
+	SendGridTimestampHeader = "X-Twilio-Email-Event-Webhook-Timestamp"
+)
+
+// sendGridEvent is an event posted by the SendGrid event webhook; only the fields of
+// bounces and spam reports are read
+type sendGridEvent struct {
+	Email string `json:"email"`
+	Event string `json:"event"`
+	// Type tells a bounce from a block, a temporary refusal
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// SendGridEvents reads bounces and spam reports from the SendGrid event webhook,
+// implementing mail.FeedbackSource
+// Requests must be signed: enable the webhook's signature verification and configure the
+// verification key it shows
+type SendGridEvents struct {
+	key *ecdsa.PublicKey
+}
+
+var _ mail.FeedbackSource = (*SendGridEvents)(nil)
+
+// NewSendGridEvents creates a source verifying requests with the webhook's verification
+// key, the base64 public key SendGrid shows for it
+func NewSendGridEvents(verificationKey string) (*SendGridEvents, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(verificationKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SendGrid verification key: %w", err)
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SendGrid verification key: %w", err)
+	}
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("invalid SendGrid verification key: not an ECDSA key")
+	}
+	return &SendGridEvents{key: ecdsaKey}, nil
+}
+
+// Parse verifies the ECDSA signature over the timestamp header and the body, then returns
+// the permanent bounces and spam reports among the events. Blocks, SendGrid's temporary
+// bounces, and the other events are left out
+func (s *SendGridEvents) Parse(ctx context.Context, header http.Header, body []byte) ([]mail.Feedback, error) {
+	signature, err := base64.StdEncoding.DecodeString(header.Get(SendGridSignatureHeader))
+	if err != nil || len(signature) == 0 {
+		return nil, fmt.Errorf("%w: missing signature", mail.ErrInvalidWebhook)
+	}
+	timestamp := header.Get(SendGridTimestampHeader)
+	if timestamp == "" {
+		return nil, fmt.Errorf("%w: missing timestamp", mail.ErrInvalidWebhook)
+	}
+	digest := sha256.Sum256(append([]byte(timestamp), body...))
+	if !ecdsa.VerifyASN1(s.key, digest[:], signature) {
+		return nil, fmt.Errorf("%w: signature mismatch", mail.ErrInvalidWebhook)
+	}
+
+	var events []sendGridEvent
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, fmt.Errorf("%w: %v", mail.ErrInvalidWebhook, err)
+	}
+	var feedback []mail.Feedback
+	for _, event := range events {
+		switch {
+		case event.Event == "bounce" && event.Type != "blocked":
+			feedback = append(feedback, mail.Feedback{Email: event.Email, Kind: mail.FeedbackBounce, Detail: event.Reason})
+		case event.Event == "spamreport":
+			feedback = append(feedback, mail.Feedback{Email: event.Email, Kind: mail.FeedbackComplaint})
+		}
+	}
+	return feedback, nil
+}
@@ -0,0 +1,120 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	netmail "net/mail"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/mail"
+	"clean-arch-gin/internal/infrastructure/sigv4"
+)
+
+// AWSCredentials authenticate requests to AWS APIs; SessionToken is only set for
+// temporary credentials
+type AWSCredentials = sigv4.Credentials
+
+// SESConfig configures an SES mailer
+type SESConfig struct {
+	Region      string
+	Credentials AWSCredentials
+	// ConfigurationSet names the SES configuration set messages are sent with, e.g. the one
+	// publishing bounces and complaints to SNS; none when empty
+	ConfigurationSet string
+}
+
+type sesContent struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+type sesBody struct {
+	Text *sesContent `json:"Text,omitempty"`
+	HTML *sesContent `json:"Html,omitempty"`
+}
+
+// sesRequest is the body of an SES v2 SendEmail call
+type sesRequest struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	Content struct {
+		Simple struct {
+			Subject sesContent `json:"Subject"`
+			Body    sesBody    `json:"Body"`
+		} `json:"Simple"`
+	} `json:"Content"`
+	ConfigurationSetName string `json:"ConfigurationSetName,omitempty"`
+}
+
+// SES sends messages with the Amazon SES v2 API, implementing mail.Mailer
+// Requests are signed with the configured credentials, which need ses:SendEmail
+type SES struct {
+	client *http.Client
+	url    string
+	cfg    SESConfig
+	from   string
+}
+
+var _ mail.Mailer = (*SES)(nil)
+
+// NewSES creates a mailer sending from from, an identity verified in SES
+func NewSES(cfg SESConfig, from string) (*SES, error) {
+	if cfg.Region == "" {
+		return nil, errors.New("SES region is required")
+	}
+	if cfg.Credentials.AccessKeyID == "" || cfg.Credentials.SecretAccessKey == "" {
+		return nil, errors.New("AWS access key ID and secret access key are required for SES")
+	}
+	address, err := netmail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", from, err)
+	}
+	return &SES{
+		client: &http.Client{Timeout: apiTimeout},
+		url:    "https://email." + cfg.Region + ".amazonaws.com/v2/email/outbound-emails",
+		cfg:    cfg,
+		from:   address.String(),
+	}, nil
+}
+
+// Send calls SendEmail with msg; all recipients get the one message
+func (s *SES) Send(ctx context.Context, msg mail.Message) error {
+	if len(msg.To) == 0 {
+		return errors.New("message has no recipients")
+	}
+	var body sesRequest
+	body.FromEmailAddress = s.from
+	body.Destination.ToAddresses = msg.To
+	body.Content.Simple.Subject = sesContent{Data: msg.Subject, Charset: "UTF-8"}
+	body.Content.Simple.Body.Text = &sesContent{Data: msg.Text, Charset: "UTF-8"}
+	if msg.HTML != "" {
+		body.Content.Simple.Body.HTML = &sesContent{Data: msg.HTML, Charset: "UTF-8"}
+	}
+	body.ConfigurationSetName = s.cfg.ConfigurationSet
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	sigv4.Sign(req, sigv4.HashHex(payload), s.cfg.Credentials, "ses", s.cfg.Region, time.Now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("SES unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return apiError("SES", resp)
+	}
+	return nil
+}
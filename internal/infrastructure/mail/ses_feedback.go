@@ -0,0 +1,270 @@
+package mail
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"clean-arch-gin/internal/domain/shared/mail"
+)
+
+// snsHost matches the hosts SNS signing certificates and subscription confirmations are
+// served from
+var snsHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// snsMessage is a message SNS posts to an HTTPS subscription
+type snsMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL"`
+}
+
+// sesRecipient is a recipient of a bounce or complaint notification
+type sesRecipient struct {
+	EmailAddress   string `json:"emailAddress"`
+	DiagnosticCode string `json:"diagnosticCode"`
+}
+
+// sesNotification is an SES bounce or complaint, sent as a notification (notificationType)
+// or published by a configuration set (eventType)
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Bounce           struct {
+		BounceType        string         `json:"bounceType"`
+		BounceSubType     string         `json:"bounceSubType"`
+		BouncedRecipients []sesRecipient `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint struct {
+		ComplainedRecipients  []sesRecipient `json:"complainedRecipients"`
+		ComplaintFeedbackType string         `json:"complaintFeedbackType"`
+	} `json:"complaint"`
+}
+
+// SESFeedback reads SES bounces and complaints from the SNS topics they are published to,
+// implementing mail.FeedbackSource
+// Messages must be signed by SNS and come from one of the configured topics, since anyone
+// can have SNS sign messages of their own topics. Subscriptions to those topics are
+// confirmed as they are made
+type SESFeedback struct {
+	client *http.Client
+	topics map[string]bool
+	// certs caches the signing certificates' public keys by URL
+	certs sync.Map
+}
+
+var _ mail.FeedbackSource = (*SESFeedback)(nil)
+
+// NewSESFeedback creates a source accepting the messages of the SNS topics with topicARNs
+func NewSESFeedback(topicARNs []string) (*SESFeedback, error) {
+	topics := make(map[string]bool, len(topicARNs))
+	for _, arn := range topicARNs {
+		if arn = strings.TrimSpace(arn); arn != "" {
+			topics[arn] = true
+		}
+	}
+	if len(topics) == 0 {
+		return nil, errors.New("at least one SNS topic ARN is required")
+	}
+	return &SESFeedback{client: &http.Client{Timeout: apiTimeout}, topics: topics}, nil
+}
+
+// Parse verifies an SNS message and returns the permanent bounces and complaints of a
+// notification; subscription confirmations are confirmed and carry none. Transient bounces
+// are left out
+func (s *SESFeedback) Parse(ctx context.Context, header http.Header, body []byte) ([]mail.Feedback, error) {
+	var msg snsMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("%w: %v", mail.ErrInvalidWebhook, err)
+	}
+	if !s.topics[msg.TopicArn] {
+		return nil, fmt.Errorf("%w: unknown topic %q", mail.ErrInvalidWebhook, msg.TopicArn)
+	}
+	if err := s.verify(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	switch msg.Type {
+	case "SubscriptionConfirmation":
+		return nil, s.confirm(ctx, msg.SubscribeURL)
+	case "UnsubscribeConfirmation":
+		return nil, nil
+	case "Notification":
+		return parseSESNotification(msg.Message)
+	default:
+		return nil, fmt.Errorf("%w: unknown message type %q", mail.ErrInvalidWebhook, msg.Type)
+	}
+}
+
+// verify checks the message's signature with the certificate it names, which must be
+// served by SNS
+func (s *SESFeedback) verify(ctx context.Context, msg snsMessage) error {
+	var hash crypto.Hash
+	switch msg.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return fmt.Errorf("%w: unknown signature version %q", mail.ErrInvalidWebhook, msg.SignatureVersion)
+	}
+	signature, err := base64.StdEncoding.DecodeString(msg.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature", mail.ErrInvalidWebhook)
+	}
+	if !isSNSURL(msg.SigningCertURL) {
+		return fmt.Errorf("%w: signing certificate not served by SNS", mail.ErrInvalidWebhook)
+	}
+	key, err := s.certificateKey(ctx, msg.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	signed := []byte(snsStringToSign(msg))
+	var digest []byte
+	if hash == crypto.SHA1 {
+		sum := sha1.Sum(signed)
+		digest = sum[:]
+	} else {
+		sum := sha256.Sum256(signed)
+		digest = sum[:]
+	}
+	if err := rsa.VerifyPKCS1v15(key, hash, digest, signature); err != nil {
+		return fmt.Errorf("%w: signature mismatch", mail.ErrInvalidWebhook)
+	}
+	return nil
+}
+
+// certificateKey returns the public key of the certificate at certURL, fetched once;
+// failing to fetch it is not the sender's fault, so SNS is left to retry
+func (s *SESFeedback) certificateKey(ctx context.Context, certURL string) (*rsa.PublicKey, error) {
+	if key, ok := s.certs.Load(certURL); ok {
+		return key.(*rsa.PublicKey), nil
+	}
+	data, err := s.get(ctx, certURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetching SNS signing certificate: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("SNS signing certificate is not PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing SNS signing certificate: %w", err)
+	}
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("SNS signing certificate has no RSA key")
+	}
+	s.certs.Store(certURL, key)
+	return key, nil
+}
+
+// confirm completes a subscription by visiting its SubscribeURL
+func (s *SESFeedback) confirm(ctx context.Context, subscribeURL string) error {
+	if !isSNSURL(subscribeURL) {
+		return fmt.Errorf("%w: subscribe URL not served by SNS", mail.ErrInvalidWebhook)
+	}
+	if _, err := s.get(ctx, subscribeURL); err != nil {
+		return fmt.Errorf("confirming SNS subscription: %w", err)
+	}
+	return nil
+}
+
+// get returns the body of an OK response to a GET of target
+func (s *SESFeedback) get(ctx context.Context, target string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SNS answered %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+}
+
+// isSNSURL reports whether raw is an HTTPS URL of an SNS host
+func isSNSURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme == "https" && snsHost.MatchString(u.Hostname())
+}
+
+// snsStringToSign is the canonical form of msg that SNS signs: the fields of its type as
+// alternating lines of name and value
+func snsStringToSign(msg snsMessage) string {
+	var fields []string
+	if msg.Type == "Notification" {
+		fields = []string{"Message", msg.Message, "MessageId", msg.MessageID}
+		if msg.Subject != "" {
+			fields = append(fields, "Subject", msg.Subject)
+		}
+		fields = append(fields, "Timestamp", msg.Timestamp, "TopicArn", msg.TopicArn, "Type", msg.Type)
+	} else {
+		fields = []string{"Message", msg.Message, "MessageId", msg.MessageID, "SubscribeURL", msg.SubscribeURL,
+			"Timestamp", msg.Timestamp, "Token", msg.Token, "TopicArn", msg.TopicArn, "Type", msg.Type}
+	}
+	return strings.Join(fields, "\n") + "\n"
+}
+
+// parseSESNotification returns the permanent bounces and complaints of an SES notification;
+// other notifications, such as deliveries, carry none
+func parseSESNotification(message string) ([]mail.Feedback, error) {
+	var notification sesNotification
+	if err := json.Unmarshal([]byte(message), &notification); err != nil {
+		return nil, fmt.Errorf("%w: %v", mail.ErrInvalidWebhook, err)
+	}
+	kind := notification.NotificationType
+	if kind == "" {
+		kind = notification.EventType
+	}
+
+	var feedback []mail.Feedback
+	switch kind {
+	case "Bounce":
+		if notification.Bounce.BounceType != "Permanent" {
+			return nil, nil
+		}
+		for _, recipient := range notification.Bounce.BouncedRecipients {
+			detail := recipient.DiagnosticCode
+			if detail == "" {
+				detail = notification.Bounce.BounceSubType
+			}
+			feedback = append(feedback, mail.Feedback{Email: recipient.EmailAddress, Kind: mail.FeedbackBounce, Detail: detail})
+		}
+	case "Complaint":
+		for _, recipient := range notification.Complaint.ComplainedRecipients {
+			feedback = append(feedback, mail.Feedback{
+				Email: recipient.EmailAddress, Kind: mail.FeedbackComplaint, Detail: notification.Complaint.ComplaintFeedbackType,
+			})
+		}
+	}
+	return feedback, nil
+}
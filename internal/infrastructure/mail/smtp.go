@@ -0,0 +1,216 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/mail"
+)
+
+// TLS modes of an SMTP connection
+const (
+	// TLSStartTLS upgrades a plain connection, usually on port 587; servers without
+	// STARTTLS are refused
+	TLSStartTLS = "starttls"
+	// TLSImplicit connects with TLS from the start, usually on port 465
+	TLSImplicit = "tls"
+	// TLSNone sends in the clear, only for local relays and test servers
+	TLSNone = "none"
+)
+
+// SMTPConfig configures the connections of an SMTP mailer
+type SMTPConfig struct {
+	Host string
+	Port string
+	// Username and Password authenticate with PLAIN; no authentication when Username is empty
+	Username string
+	Password string
+	TLS      string
+	// PoolSize is how many connections are kept open between sends; 0 closes every one
+	PoolSize int
+	// Timeout bounds dialing and each send
+	Timeout time.Duration
+}
+
+// SMTP sends messages through an SMTP server, implementing mail.Mailer
+// Connections are reused across sends, reset between messages; one the server dropped is
+// replaced by a new one
+type SMTP struct {
+	cfg  SMTPConfig
+	from string
+	// sender is the bare address of from, for the envelope
+	sender string
+	idle   chan *smtpConn
+}
+
+var _ mail.Mailer = (*SMTP)(nil)
+
+// smtpConn is a pooled SMTP session with its connection, whose deadline is set per send
+type smtpConn struct {
+	client *smtp.Client
+	conn   net.Conn
+}
+
+// NewSMTP creates a mailer sending from from, e.g. "Shop <noreply@example.com>"
+func NewSMTP(cfg SMTPConfig, from string) (*SMTP, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("SMTP host is required")
+	}
+	switch cfg.TLS {
+	case TLSStartTLS, TLSImplicit, TLSNone:
+	default:
+		return nil, fmt.Errorf("unknown SMTP TLS mode %q (want %s, %s or %s)", cfg.TLS, TLSStartTLS, TLSImplicit, TLSNone)
+	}
+	address, err := netmail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", from, err)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	if cfg.PoolSize < 0 {
+		cfg.PoolSize = 0
+	}
+	return &SMTP{
+		cfg:    cfg,
+		from:   address.String(),
+		sender: address.Address,
+		idle:   make(chan *smtpConn, cfg.PoolSize),
+	}, nil
+}
+
+// Send delivers msg to its recipients
+func (s *SMTP) Send(ctx context.Context, msg mail.Message) error {
+	if len(msg.To) == 0 {
+		return errors.New("mail has no recipients")
+	}
+	body, err := encode(s.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	c, err := s.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.deliver(c, msg.To, body); err != nil {
+		c.client.Close()
+		return err
+	}
+	s.release(c)
+	return nil
+}
+
+// Close closes the idle connections
+func (s *SMTP) Close() error {
+	for {
+		select {
+		case c := <-s.idle:
+			c.conn.SetDeadline(time.Now().Add(s.cfg.Timeout))
+			c.client.Quit()
+		default:
+			return nil
+		}
+	}
+}
+
+// deliver runs one mail transaction on c
+func (s *SMTP) deliver(c *smtpConn, to []string, body []byte) error {
+	if err := c.client.Mail(s.sender); err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		if err := c.client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	w, err := c.client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// acquire returns an idle connection still accepted by the server, or a new one, with its
+// deadline set for a send
+func (s *SMTP) acquire(ctx context.Context) (*smtpConn, error) {
+	for {
+		select {
+		case c := <-s.idle:
+			s.setDeadline(ctx, c.conn)
+			if err := c.client.Reset(); err == nil {
+				return c, nil
+			}
+			c.client.Close()
+		default:
+			return s.dial(ctx)
+		}
+	}
+}
+
+// release returns c to the pool, or quits it when the pool is full
+func (s *SMTP) release(c *smtpConn) {
+	select {
+	case s.idle <- c:
+	default:
+		c.client.Quit()
+	}
+}
+
+// dial connects and greets the server, then secures and authenticates the session as
+// configured
+func (s *SMTP) dial(ctx context.Context) (*smtpConn, error) {
+	addr := net.JoinHostPort(s.cfg.Host, s.cfg.Port)
+	dialer := &net.Dialer{Timeout: s.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+	tlsConfig := &tls.Config{ServerName: s.cfg.Host, MinVersion: tls.VersionTLS12}
+	if s.cfg.TLS == TLSImplicit {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	s.setDeadline(ctx, conn)
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to greet SMTP server %s: %w", addr, err)
+	}
+	if s.cfg.TLS == TLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, fmt.Errorf("SMTP server %s does not support STARTTLS", addr)
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to start TLS with SMTP server %s: %w", addr, err)
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to authenticate with SMTP server %s: %w", addr, err)
+		}
+	}
+	return &smtpConn{client: client, conn: conn}, nil
+}
+
+// setDeadline bounds the next exchange on conn by the timeout and the deadline of ctx
+func (s *SMTP) setDeadline(ctx context.Context, conn net.Conn) {
+	deadline := time.Now().Add(s.cfg.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	conn.SetDeadline(deadline)
+}
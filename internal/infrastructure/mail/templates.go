@@ -0,0 +1,175 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+
+	"clean-arch-gin/internal/domain/shared/mail"
+	"clean-arch-gin/internal/infrastructure/i18n"
+)
+
+// Every template has a plain text variant, <name>.txt.tmpl, defining its subject as
+// "subject", and optionally an HTML one, <name>.html.tmpl
+// Those files speak the default locale; templates/<locale>/, named after a locale of the
+// catalog, overrides any of them in that locale. Templates translate with t "key" args...
+const (
+	textSuffix = ".txt.tmpl"
+	htmlSuffix = ".html.tmpl"
+	root       = "templates"
+)
+
+//go:embed templates
+var embedded embed.FS
+
+// Templates renders the embedded templates, implementing mail.Renderer
+// The HTML variants are escaped by html/template, so data can carry user input such as names
+type Templates struct {
+	// text and html hold the templates of every locale of the catalog, by locale and name
+	text map[string]map[string]*texttemplate.Template
+	html map[string]map[string]*htmltemplate.Template
+}
+
+var _ mail.Renderer = (*Templates)(nil)
+
+// NewTemplates parses the embedded templates in every locale of catalog
+func NewTemplates(catalog *i18n.Catalog) (*Templates, error) {
+	t := &Templates{
+		text: make(map[string]map[string]*texttemplate.Template),
+		html: make(map[string]map[string]*htmltemplate.Template),
+	}
+	files, err := fs.Glob(embedded, root+"/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOverrides(catalog); err != nil {
+		return nil, err
+	}
+	for _, locale := range append([]string{i18n.DefaultLocale}, catalog.Locales()...) {
+		if _, ok := t.text[locale]; ok {
+			continue
+		}
+		locale := locale
+		t.text[locale] = make(map[string]*texttemplate.Template)
+		t.html[locale] = make(map[string]*htmltemplate.Template)
+		funcs := texttemplate.FuncMap{
+			"t": func(key string, args ...interface{}) string {
+				return catalog.Translate(locale, key, args...)
+			},
+		}
+		for _, file := range files {
+			base := path.Base(file)
+			file = localized(locale, base)
+			switch {
+			case strings.HasSuffix(base, textSuffix):
+				tmpl, err := texttemplate.New(base).Option("missingkey=error").Funcs(funcs).ParseFS(embedded, file)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse mail template %s: %w", file, err)
+				}
+				if tmpl.Lookup("subject") == nil {
+					return nil, fmt.Errorf("mail template %s defines no subject", file)
+				}
+				t.text[locale][strings.TrimSuffix(base, textSuffix)] = tmpl
+			case strings.HasSuffix(base, htmlSuffix):
+				tmpl, err := htmltemplate.New(base).Option("missingkey=error").Funcs(htmltemplate.FuncMap(funcs)).ParseFS(embedded, file)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse mail template %s: %w", file, err)
+				}
+				t.html[locale][strings.TrimSuffix(base, htmlSuffix)] = tmpl
+			}
+		}
+	}
+	for name := range t.html[i18n.DefaultLocale] {
+		if _, ok := t.text[i18n.DefaultLocale][name]; !ok {
+			return nil, fmt.Errorf("mail template %s has no plain text variant", name)
+		}
+	}
+	return t, nil
+}
+
+// checkOverrides fails for locale directories not named after a locale of catalog other
+// than the default, and for files overriding no template
+func checkOverrides(catalog *i18n.Catalog) error {
+	known := make(map[string]bool)
+	for _, locale := range catalog.Locales() {
+		known[locale] = locale != i18n.DefaultLocale
+	}
+	entries, err := fs.ReadDir(embedded, root)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if !known[entry.Name()] {
+			return fmt.Errorf("mail templates %s/ is not a locale of the catalog other than %s", entry.Name(), i18n.DefaultLocale)
+		}
+		overrides, err := fs.ReadDir(embedded, path.Join(root, entry.Name()))
+		if err != nil {
+			return err
+		}
+		for _, override := range overrides {
+			if _, err := fs.Stat(embedded, path.Join(root, override.Name())); err != nil {
+				return fmt.Errorf("mail template %s/%s overrides no template", entry.Name(), override.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// localized is the path of the file in the first locale of locale's chain that overrides
+// it, or of the default one
+func localized(locale, file string) string {
+	for _, candidate := range i18n.Fallbacks(locale) {
+		if candidate == i18n.DefaultLocale {
+			break
+		}
+		override := path.Join(root, candidate, file)
+		if _, err := fs.Stat(embedded, override); err == nil {
+			return override
+		}
+	}
+	return path.Join(root, file)
+}
+
+// Render renders the subject, the text and, when the template has one, the HTML of name in
+// the first locale of locale's chain the catalog has
+func (t *Templates) Render(name, locale string, data interface{}) (*mail.Message, error) {
+	for _, candidate := range i18n.Fallbacks(locale) {
+		if _, ok := t.text[candidate]; ok {
+			locale = candidate
+			break
+		}
+	}
+	text, ok := t.text[locale][name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", mail.ErrUnknownTemplate, name)
+	}
+
+	var subject, body bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render the subject of %s: %w", name, err)
+	}
+	if err := text.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	msg := &mail.Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    body.String(),
+	}
+
+	if html, ok := t.html[locale][name]; ok {
+		var buf bytes.Buffer
+		if err := html.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render the HTML of %s: %w", name, err)
+		}
+		msg.HTML = buf.String()
+	}
+	return msg, nil
+}
@@ -0,0 +1,263 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Security scheme names of authenticated routes, which take either
+const (
+	bearerAuth = "bearerAuth"
+	apiKeyAuth = "apiKeyAuth"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Generator accumulates operations and schemas into a Document
+type Generator struct {
+	doc *Document
+}
+
+// NewGenerator creates a generator for an API described by info
+func NewGenerator(info Info) *Generator {
+	return &Generator{
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    info,
+			Paths:   make(map[string]PathItem),
+			Components: Components{
+				Schemas: make(map[string]*Schema),
+				SecuritySchemes: map[string]SecurityScheme{
+					bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+					apiKeyAuth: {Type: "apiKey", In: "header", Name: "X-API-Key"},
+				},
+			},
+		},
+	}
+}
+
+// AddRoute adds a documented route registered at fullPath (Gin syntax) under the given tag
+func (g *Generator) AddRoute(tag, fullPath string, route Route) {
+	path, params := convertPath(fullPath, route.PublicID)
+	op := &Operation{
+		Summary:     route.Summary,
+		OperationID: operationID(route.Method, path),
+		Parameters:  append(params, route.Query...),
+		Responses:   make(map[string]Response),
+	}
+	if tag != "" {
+		op.Tags = []string{tag}
+	}
+	if route.Auth {
+		op.Security = []map[string][]string{{bearerAuth: {}}, {apiKeyAuth: {}}}
+	}
+
+	if route.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  jsonContent(g.SchemaFor(route.Request)),
+		}
+	}
+
+	for status, body := range route.Responses {
+		resp := Response{Description: http.StatusText(status)}
+		if body != nil {
+			resp.Content = jsonContent(g.SchemaFor(body))
+		}
+		op.Responses[strconv.Itoa(status)] = resp
+	}
+	if len(op.Responses) == 0 {
+		op.Responses["200"] = Response{Description: http.StatusText(http.StatusOK)}
+	}
+
+	item, ok := g.doc.Paths[path]
+	if !ok {
+		item = make(PathItem)
+		g.doc.Paths[path] = item
+	}
+	item[strings.ToLower(route.Method)] = op
+}
+
+// Document returns the generated document
+func (g *Generator) Document() *Document {
+	return g.doc
+}
+
+// SchemaFor returns the schema for the type of v, registering named structs as components
+func (g *Generator) SchemaFor(v interface{}) *Schema {
+	return g.schemaForType(reflect.TypeOf(v))
+}
+
+func (g *Generator) schemaForType(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	if t.Kind() == reflect.Ptr {
+		s := g.schemaForType(t.Elem())
+		if s.Ref == "" {
+			s.Nullable = true
+		}
+		return s
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	if t == rawMessageType {
+		// Embedded JSON of any shape
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaForType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaForType(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema builds an object schema; named types become $ref components
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	name := t.Name()
+	if name != "" {
+		if _, ok := g.doc.Components.Schemas[name]; ok {
+			return &Schema{Ref: "#/components/schemas/" + name}
+		}
+		// Reserve the name first so recursive types terminate
+		g.doc.Components.Schemas[name] = &Schema{Type: "object"}
+	}
+
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(schema, t)
+	sort.Strings(schema.Required)
+
+	if name == "" {
+		return schema
+	}
+	g.doc.Components.Schemas[name] = schema
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// addFields adds the exported JSON fields of t, flattening embedded structs like encoding/json
+func (g *Generator) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts := parseTag(field.Tag.Get("json"))
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(schema, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := g.schemaForType(field.Type)
+		binding := field.Tag.Get("binding")
+		if max, ok := bindingMax(binding); ok && prop.Type == "string" {
+			prop.MaxLength = &max
+		}
+		if strings.Contains(binding, "email") && prop.Type == "string" {
+			prop.Format = "email"
+		}
+		schema.Properties[name] = prop
+
+		if hasOption(binding, "required") && !hasOption(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// convertPath turns "/users/:id" into "/users/{id}" and returns the path parameters
+// IDs are integers, except for an id that is a public ID
+func convertPath(ginPath string, publicID bool) (string, []Parameter) {
+	var params []Parameter
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if len(segment) < 2 || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		name := segment[1:]
+		segments[i] = "{" + name + "}"
+
+		schema := &Schema{Type: "string"}
+		if publicID && name == "id" {
+			schema = &Schema{Type: "string", Description: "ULID or UUID"}
+		} else if name == "id" || strings.HasSuffix(name, "Id") || strings.HasSuffix(name, "ID") {
+			schema = &Schema{Type: "integer", Format: "int32"}
+		}
+		params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID derives a stable identifier such as "get_api_v1_users_id"
+func operationID(method, path string) string {
+	replacer := strings.NewReplacer("/", "_", "{", "", "}", "", "-", "_", ".", "_")
+	return strings.ToLower(method) + strings.TrimRight(replacer.Replace(path), "_")
+}
+
+// jsonContent wraps a schema in an application/json media type
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+// parseTag splits a struct tag value into its name and options
+func parseTag(tag string) (string, string) {
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, opts
+}
+
+// hasOption reports whether a comma-separated tag contains option
+func hasOption(tag, option string) bool {
+	for _, opt := range strings.Split(tag, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// bindingMax extracts the max=N validation from a binding tag
+func bindingMax(binding string) (int, bool) {
+	for _, opt := range strings.Split(binding, ",") {
+		if value, ok := strings.CutPrefix(opt, "max="); ok {
+			if n, err := strconv.Atoi(value); err == nil {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
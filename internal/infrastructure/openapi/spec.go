@@ -0,0 +1,125 @@
+// Package openapi builds an OpenAPI 3 document from typed route definitions
+// Modules describe their routes with Route values; request and response schemas
+// are derived from the DTO types by reflection so docs never drift from the code
+package openapi
+
+// Version is the OpenAPI specification version emitted by the generator
+const Version = "3.0.3"
+
+// Document is the subset of the OpenAPI 3 object model emitted by the generator
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem maps lower-case HTTP methods to operations
+type PathItem map[string]*Operation
+
+// Operation describes a single route
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter describes a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody describes a JSON request body
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a response for one status code
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType wraps the schema of a body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a JSON schema as used by OpenAPI 3.0
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+}
+
+// Components holds reusable schemas and security schemes
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas,omitempty"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how clients authenticate
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	// In and Name locate the key of apiKey schemes
+	In   string `json:"in,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Route documents one HTTP route
+// Path is relative to the router group the route is registered on, using Gin syntax (":id")
+type Route struct {
+	Method  string
+	Path    string
+	Summary string
+	// Auth marks routes that require a bearer token
+	Auth bool
+	// PublicID marks routes whose :id is a public ID (ULID or UUID) rather than an integer
+	PublicID bool
+	// Query lists the accepted query parameters, and header parameters made with HeaderParam
+	Query []Parameter
+	// Request is a zero value of the JSON request body type, or nil
+	Request interface{}
+	// Responses maps status codes to a zero value of the response body type (nil for no body)
+	Responses map[int]interface{}
+}
+
+// ErrorResponse is the error body returned by every controller
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// HeaderParam is a shorthand for a string request header, required or not
+func HeaderParam(name, description string, required bool) Parameter {
+	return Parameter{Name: name, In: "header", Description: description, Required: required, Schema: &Schema{Type: "string"}}
+}
+
+// QueryParam is a shorthand for an optional query parameter of the given JSON type
+func QueryParam(name, typ, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: typ}}
+}
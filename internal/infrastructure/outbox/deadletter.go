@@ -0,0 +1,87 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrMessageNotFound is returned for an unknown message
+	ErrMessageNotFound = errors.New("outbox: message not found")
+	// ErrMessageNotPoisoned is returned when replaying or discarding a message that was not
+	// set aside
+	ErrMessageNotPoisoned = errors.New("outbox: only poison messages can be replayed or discarded")
+)
+
+// Get loads a message by ID
+func Get(ctx context.Context, db *gorm.DB, id uint) (*Message, error) {
+	var model MessageModel
+	if err := db.WithContext(ctx).First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
+	}
+	return model.toMessage(), nil
+}
+
+// ListPoisoned returns the messages set aside as poison, most recently failed first,
+// optionally of one event type, and how many there are in total
+func ListPoisoned(ctx context.Context, db *gorm.DB, eventType string, limit, offset int) ([]*Message, int64, error) {
+	query := db.WithContext(ctx).Model(&MessageModel{}).Where("failed_at IS NOT NULL")
+	if eventType != "" {
+		query = query.Where("event_type = ?", eventType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var models []MessageModel
+	if err := query.Order("failed_at DESC, id DESC").Limit(limit).Offset(offset).Find(&models).Error; err != nil {
+		return nil, 0, err
+	}
+	msgs := make([]*Message, len(models))
+	for i := range models {
+		msgs[i] = models[i].toMessage()
+	}
+	return msgs, total, nil
+}
+
+// Replay gives a poison message a fresh set of attempts; the relay publishes it next, ahead
+// of the messages written after it. Its last error is kept until it is sent
+func Replay(ctx context.Context, db *gorm.DB, id uint) (*Message, error) {
+	result := db.WithContext(ctx).Model(&MessageModel{}).
+		Where("id = ? AND failed_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"failed_at": nil, "attempts": 0})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, notPoisoned(ctx, db, id)
+	}
+	return Get(ctx, db, id)
+}
+
+// Discard deletes a poison message, giving up on publishing it
+func Discard(ctx context.Context, db *gorm.DB, id uint) error {
+	result := db.WithContext(ctx).Where("id = ? AND failed_at IS NOT NULL", id).Delete(&MessageModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return notPoisoned(ctx, db, id)
+	}
+	return nil
+}
+
+// notPoisoned explains why a message could not be changed: it is missing or not poison
+func notPoisoned(ctx context.Context, db *gorm.DB, id uint) error {
+	if _, err := Get(ctx, db, id); err != nil {
+		return err
+	}
+	return ErrMessageNotPoisoned
+}
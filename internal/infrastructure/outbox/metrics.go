@@ -0,0 +1,76 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	outboxPublishedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "outbox_published_total",
+		Help: "Outbox messages published to the broker by event type.",
+	}, []string{"type"})
+
+	outboxFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "outbox_failures_total",
+		Help: "Failed sends of outbox messages by event type and result: retried or poisoned.",
+	}, []string{"type", "result"})
+
+	outboxPending = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "outbox_pending_messages",
+		Help: "Outbox messages waiting to be published, poison ones excluded.",
+	})
+
+	outboxLag = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "outbox_lag_seconds",
+		Help: "Age of the oldest outbox message waiting to be published; 0 when none waits.",
+	})
+
+	outboxPublishLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "outbox_publish_latency_seconds",
+		Help:    "Time from an outbox message being written to it being published.",
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 14),
+	})
+
+	outboxBatchDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "outbox_batch_duration_seconds",
+		Help:    "Duration of relaying one batch of outbox messages.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+// observeBacklog sets the pending count and the lag of the oldest message in batch, the
+// next messages to publish
+func observeBacklog(pending int64, batch []MessageModel) {
+	outboxPending.Set(float64(pending))
+	if len(batch) == 0 {
+		outboxLag.Set(0)
+		return
+	}
+	outboxLag.Set(time.Since(batch[0].CreatedAt).Seconds())
+}
+
+// observePublished records the published messages
+func observePublished(msgs []*Message) {
+	now := time.Now()
+	for _, msg := range msgs {
+		outboxPublishedTotal.WithLabelValues(msg.EventType).Inc()
+		outboxPublishLatency.Observe(now.Sub(msg.CreatedAt).Seconds())
+	}
+}
+
+// observeFailure counts a failed send of msg
+func observeFailure(msg *Message, poisoned bool) {
+	result := "retried"
+	if poisoned {
+		result = "poisoned"
+	}
+	outboxFailuresTotal.WithLabelValues(msg.EventType, result).Inc()
+}
+
+// observeBatch records the duration of a relayed batch
+func observeBatch(duration time.Duration) {
+	outboxBatchDuration.Observe(duration.Seconds())
+}
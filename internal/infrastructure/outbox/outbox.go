@@ -0,0 +1,224 @@
+// Package outbox delivers domain events to the message broker through the outbox table
+// Events are written to the table with the change raising them, in its transaction when it
+// has one, and a relay publishes the unpublished rows in order, so an event is published at
+// least once if and only if its change is kept
+package outbox
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/infrastructure/cloudevents"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/eventbus"
+	"clean-arch-gin/internal/infrastructure/kafka"
+
+	"gorm.io/gorm"
+)
+
+// maxErrorLength matches the size of the last_error column
+const maxErrorLength = 1024
+
+// MessageModel is the GORM model of the outbox_messages table
+type MessageModel struct {
+	ID       uint   `gorm:"primaryKey;autoIncrement"`
+	TenantID uint   `gorm:"not null;default:1;index"`
+	EventID  string `gorm:"not null;size:64;uniqueIndex"`
+	// EventType is the name of the domain event
+	EventType string `gorm:"not null;size:128;index"`
+	// Subject is the resource the event is about, e.g. orders/42
+	Subject string `gorm:"size:255;index"`
+	Topic   string `gorm:"not null;size:255"`
+	// PartitionKey orders the messages of a subject in the topic
+	PartitionKey string `gorm:"size:255"`
+	// Payload is the event as a structured-mode CloudEvent
+	Payload     []byte     `gorm:"not null"`
+	OccurredAt  time.Time  `gorm:"not null;index"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime"`
+	PublishedAt *time.Time `gorm:"index"`
+	Attempts    int        `gorm:"not null;default:0"`
+	LastError   string     `gorm:"size:1024"`
+	// FailedAt sets a poison message aside: the relay no longer tries it
+	FailedAt *time.Time `gorm:"index"`
+}
+
+// TableName sets the table name for GORM
+func (MessageModel) TableName() string {
+	return "outbox_messages"
+}
+
+// Message is a domain event in the outbox and its delivery state
+type Message struct {
+	ID           uint       `json:"id"`
+	TenantID     uint       `json:"-"`
+	EventID      string     `json:"event_id"`
+	EventType    string     `json:"event_type"`
+	Subject      string     `json:"subject,omitempty"`
+	Topic        string     `json:"topic"`
+	PartitionKey string     `json:"partition_key"`
+	Payload      []byte     `json:"-"`
+	OccurredAt   time.Time  `json:"occurred_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	PublishedAt  *time.Time `json:"published_at,omitempty"`
+	Attempts     int        `json:"attempts"`
+	LastError    string     `json:"last_error,omitempty"`
+	FailedAt     *time.Time `json:"failed_at,omitempty"`
+}
+
+// toMessage converts the model to a Message
+func (m *MessageModel) toMessage() *Message {
+	return &Message{
+		ID:           m.ID,
+		TenantID:     m.TenantID,
+		EventID:      m.EventID,
+		EventType:    m.EventType,
+		Subject:      m.Subject,
+		Topic:        m.Topic,
+		PartitionKey: m.PartitionKey,
+		Payload:      m.Payload,
+		OccurredAt:   m.OccurredAt,
+		CreatedAt:    m.CreatedAt,
+		PublishedAt:  m.PublishedAt,
+		Attempts:     m.Attempts,
+		LastError:    m.LastError,
+		FailedAt:     m.FailedAt,
+	}
+}
+
+// Writer records domain events in the outbox
+type Writer struct {
+	db        *gorm.DB
+	formatter cloudevents.Formatter
+	topic     string
+}
+
+var _ events.EventPublisher = (*Writer)(nil)
+
+// NewWriter creates a writer recording events on db as CloudEvents bound for topic
+func NewWriter(db *gorm.DB, formatter cloudevents.Formatter, topic string) *Writer {
+	return &Writer{db: db, formatter: formatter, topic: topic}
+}
+
+// Publish records event in the transaction of ctx, or on its own outside one, for the tenant
+// of ctx. Events of a subject share its partition key, the others that of their type
+func (w *Writer) Publish(ctx context.Context, event events.DomainEvent) error {
+	id := cloudevents.NewID()
+	payload, err := w.formatter.Marshal(event, id)
+	if err != nil {
+		return err
+	}
+	model := &MessageModel{
+		EventID:      id,
+		EventType:    event.EventName(),
+		Topic:        w.topic,
+		PartitionKey: event.EventName(),
+		Payload:      payload,
+		OccurredAt:   event.OccurredOn(),
+	}
+	if subjected, ok := event.(events.SubjectProvider); ok && subjected.EventSubject() != "" {
+		model.Subject = subjected.EventSubject()
+		model.PartitionKey = model.Subject
+	}
+	return database.Conn(ctx, w.db).Create(model).Error
+}
+
+// Subscribe records every event published on bus with w. Handlers run where the event is
+// published, so events published inside a transaction are written in it; events held back
+// until it commits (database.PublishAfterCommit) are written right after. Replayed events are
+// already recorded and are skipped
+func Subscribe(bus *eventbus.Bus, w *Writer) func() {
+	return bus.Subscribe(eventbus.Wildcard, func(ctx context.Context, event events.DomainEvent) {
+		if events.IsReplay(ctx) {
+			return
+		}
+		if err := w.Publish(ctx, event); err != nil {
+			log.Printf("outbox: failed to record %s: %v", event.EventName(), err)
+		}
+	})
+}
+
+// Sink publishes outbox messages to the broker
+type Sink interface {
+	// Send publishes msgs in order and reports how many of them, from the first, were
+	// published before one failed; errors wrapped with retry.Permanent set that message aside
+	Send(ctx context.Context, msgs []*Message) (int, error)
+}
+
+// kafkaSink publishes outbox messages as Kafka records
+type kafkaSink struct {
+	opts kafka.Options
+
+	mu       sync.Mutex
+	producer *kafka.Producer
+}
+
+// NewKafkaSink creates a sink publishing to the brokers of opts, connecting on the first send
+// so the relay waits out brokers that are down. Records are keyed by the partition key of
+// their message and carry the CloudEvents id and type as headers
+func NewKafkaSink(opts kafka.Options) Sink {
+	return &kafkaSink{opts: opts}
+}
+
+// Send publishes msgs as one batch
+func (s *kafkaSink) Send(ctx context.Context, msgs []*Message) (int, error) {
+	producer, err := s.connect()
+	if err != nil {
+		return 0, err
+	}
+	records := make([]kafka.Record, len(msgs))
+	for i, msg := range msgs {
+		records[i] = kafka.Record{
+			Topic: msg.Topic,
+			Key:   []byte(msg.PartitionKey),
+			Value: msg.Payload,
+			Headers: map[string]string{
+				"content-type": cloudevents.ContentType,
+				"ce_id":        msg.EventID,
+				"ce_type":      msg.EventType,
+			},
+		}
+	}
+	return producer.SendAll(records)
+}
+
+// connect returns the producer, connecting it the first time
+func (s *kafkaSink) connect() (*kafka.Producer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.producer == nil {
+		producer, err := kafka.NewProducer(s.opts)
+		if err != nil {
+			return nil, err
+		}
+		s.producer = producer
+	}
+	return s.producer, nil
+}
+
+// Close closes the producer once connected
+func (s *kafkaSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.producer == nil {
+		return nil
+	}
+	return s.producer.Close()
+}
+
+// PurgePublished deletes the messages published before the given time and returns how many;
+// poison messages are kept until they are dealt with
+func PurgePublished(ctx context.Context, db *gorm.DB, before time.Time) (int64, error) {
+	result := db.WithContext(ctx).Where("published_at < ?", before).Delete(&MessageModel{})
+	return result.RowsAffected, result.Error
+}
+
+// truncate limits an error message to the last_error column
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength]
+	}
+	return s
+}
@@ -0,0 +1,226 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/locks"
+	"clean-arch-gin/internal/infrastructure/retry"
+
+	"gorm.io/gorm"
+)
+
+// Defaults applied to zero Options fields
+const (
+	defaultBatchSize    = 100
+	defaultPollInterval = time.Second
+	defaultMaxAttempts  = 20
+	// lockTTL is how long a replica that died relaying keeps the others from relaying
+	lockTTL = 30 * time.Second
+	// lockKey names the lock held by the replica relaying a batch
+	lockKey = "outbox:relay"
+)
+
+// Options configures a Relay
+type Options struct {
+	// BatchSize is how many messages are read and published at a time (100)
+	BatchSize int
+	// PollInterval is how often an idle relay looks for new messages (1s)
+	PollInterval time.Duration
+	// MaxAttempts is how many failed sends set a message aside as poison (20); a message the
+	// broker rejects for good, e.g. as too large, is set aside at once
+	MaxAttempts int
+	// Backoff spaces the sends after a failure (retry.DefaultPolicy delays, capped at 1m)
+	Backoff retry.Policy
+	// Locks, when set, holds a lock while a batch is relayed, so replicas relay one at a time
+	// and messages are published in order; nil leaves relaying to a single replica
+	Locks locks.LockManager
+}
+
+// Relay publishes the messages of the outbox to a sink in the order they were written
+// Messages are sent in batches and marked published once the sink has them; a message that
+// fails is retried before any later one, so its subject's order holds, until it is set aside
+// as poison after MaxAttempts
+type Relay struct {
+	db   *gorm.DB
+	sink Sink
+	opts Options
+
+	mu      sync.Mutex
+	started bool
+	running sync.WaitGroup
+}
+
+// New creates a relay from db to sink; zero Options fields use the defaults and MessageModel
+// must be migrated
+func New(db *gorm.DB, sink Sink, opts Options) *Relay {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultMaxAttempts
+	}
+	if opts.Backoff.InitialDelay <= 0 {
+		opts.Backoff.InitialDelay = time.Second
+	}
+	if opts.Backoff.MaxDelay <= 0 {
+		opts.Backoff.MaxDelay = time.Minute
+	}
+	return &Relay{db: db, sink: sink, opts: opts}
+}
+
+// Start relays until ctx is cancelled; it does not block
+func (r *Relay) Start(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.started {
+		return
+	}
+	r.started = true
+
+	r.running.Add(1)
+	go r.loop(ctx)
+}
+
+// Shutdown waits for the batch under way to be sent and marked, or ctx to be done, then
+// closes the sink when it is an io.Closer. Cancel the context passed to Start first; messages
+// sent but not marked are sent again
+func (r *Relay) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return fmt.Errorf("outbox: relay still running: %w", ctx.Err())
+	}
+	if closer, ok := r.sink.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// loop relays batches back to back while they come full, then waits for PollInterval, or
+// for the backoff after a failure
+func (r *Relay) loop(ctx context.Context) {
+	defer r.running.Done()
+
+	failures := 0
+	for ctx.Err() == nil {
+		var relayed int
+		err := locks.TryWithLock(ctx, r.opts.Locks, lockKey, lockTTL, func(ctx context.Context) error {
+			var err error
+			relayed, err = r.relay(ctx)
+			return err
+		})
+
+		wait := r.opts.PollInterval
+		switch {
+		case errors.Is(err, locks.ErrNotObtained):
+			failures = 0
+		case err != nil:
+			if ctx.Err() != nil {
+				return
+			}
+			failures++
+			wait = r.opts.Backoff.Delay(failures)
+			log.Printf("outbox: relay failed, retrying in %s: %v", wait.Round(time.Millisecond), err)
+		case relayed == r.opts.BatchSize:
+			failures = 0
+			continue
+		default:
+			failures = 0
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// relay sends the next batch of unpublished messages and records the outcome: the messages
+// the sink has are marked published, and the first it failed on gets the failure. It returns
+// how many messages it read
+func (r *Relay) relay(ctx context.Context) (int, error) {
+	started := time.Now()
+	db := r.db.WithContext(ctx)
+
+	var models []MessageModel
+	if err := unpublished(db).Order("id").Limit(r.opts.BatchSize).Find(&models).Error; err != nil {
+		return 0, err
+	}
+	// Only a full batch leaves messages beyond it to count
+	pending := int64(len(models))
+	if len(models) == r.opts.BatchSize {
+		if err := unpublished(db).Count(&pending).Error; err != nil {
+			log.Printf("outbox: failed to count pending messages: %v", err)
+		}
+	}
+	observeBacklog(pending, models)
+	if len(models) == 0 {
+		return 0, nil
+	}
+
+	msgs := make([]*Message, len(models))
+	for i := range models {
+		msgs[i] = models[i].toMessage()
+	}
+	sent, sendErr := r.sink.Send(ctx, msgs)
+
+	// The outcome is written even when ctx is cancelled, so what was sent is not sent again
+	db = r.db.WithContext(context.WithoutCancel(ctx))
+	if sent > 0 {
+		ids := make([]uint, sent)
+		for i, msg := range msgs[:sent] {
+			ids[i] = msg.ID
+		}
+		if err := db.Model(&MessageModel{}).Where("id IN ?", ids).Update("published_at", time.Now()).Error; err != nil {
+			return len(models), err
+		}
+		observePublished(msgs[:sent])
+	}
+	observeBatch(time.Since(started))
+	if sendErr == nil || sent >= len(msgs) {
+		return len(models), nil
+	}
+	return len(models), r.fail(db, msgs[sent], sendErr)
+}
+
+// fail records a failed send of msg, setting it aside as poison when the failure is permanent
+// or its attempts have run out, and returns the failure unless it was set aside
+func (r *Relay) fail(db *gorm.DB, msg *Message, sendErr error) error {
+	attempts := msg.Attempts + 1
+	updates := map[string]interface{}{"attempts": attempts, "last_error": truncate(sendErr.Error())}
+	poison := retry.IsPermanent(sendErr) || attempts >= r.opts.MaxAttempts
+	if poison {
+		updates["failed_at"] = time.Now()
+	}
+	if err := db.Model(&MessageModel{}).Where("id = ?", msg.ID).Updates(updates).Error; err != nil {
+		return err
+	}
+	observeFailure(msg, poison)
+	if poison {
+		log.Printf("outbox: message %d (%s) set aside after %d attempts: %v", msg.ID, msg.EventType, attempts, sendErr)
+		return nil
+	}
+	return fmt.Errorf("message %d (%s): %w", msg.ID, msg.EventType, sendErr)
+}
+
+// unpublished scopes tx to the messages waiting to be published
+func unpublished(tx *gorm.DB) *gorm.DB {
+	return tx.Model(&MessageModel{}).Where("published_at IS NULL AND failed_at IS NULL")
+}
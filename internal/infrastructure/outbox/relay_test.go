@@ -0,0 +1,349 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/locks"
+	"clean-arch-gin/internal/infrastructure/cloudevents"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/eventbus"
+	"clean-arch-gin/internal/infrastructure/outbox"
+	"clean-arch-gin/internal/infrastructure/retry"
+	"clean-arch-gin/internal/mocks"
+	"clean-arch-gin/internal/testutil/repotest"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// testTopic is the topic the writer under test records events for
+const testTopic = "domain-events"
+
+// openOutbox opens a database with the outbox table and a writer on it
+func openOutbox(t *testing.T) (*gorm.DB, *outbox.Writer) {
+	t.Helper()
+	db := repotest.OpenSQLite(t)
+	if err := database.AutoMigrate(db, &outbox.MessageModel{}); err != nil {
+		t.Fatalf("migrate outbox: %v", err)
+	}
+	return db, outbox.NewWriter(db, cloudevents.Formatter{Source: "/test"}, testTopic)
+}
+
+// event returns a domain event named name about subject, occurring at occurred
+func event(name, subject string, occurred time.Time) events.DomainEvent {
+	data, _ := json.Marshal(map[string]string{"subject": subject})
+	return &outbox.StoredEvent{Name: name, Subject: subject, Occurred: occurred, Data: data}
+}
+
+// write records n events of order 1 with w, failing the test on error
+func write(t *testing.T, w *outbox.Writer, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := w.Publish(context.Background(), event("order.updated", "orders/1", time.Now())); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+}
+
+// fakeSink records the messages it is sent, failing those fail returns an error for
+type fakeSink struct {
+	fail func(msg *outbox.Message) error
+
+	mu   sync.Mutex
+	sent []uint
+}
+
+// Send records msgs in order up to the first that fails
+func (s *fakeSink) Send(ctx context.Context, msgs []*outbox.Message) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, msg := range msgs {
+		if s.fail != nil {
+			if err := s.fail(msg); err != nil {
+				return i, err
+			}
+		}
+		s.sent = append(s.sent, msg.ID)
+	}
+	return len(msgs), nil
+}
+
+// ids returns the IDs of the messages sent so far
+func (s *fakeSink) ids() []uint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]uint(nil), s.sent...)
+}
+
+// failing fails the message with id the first times sends, then lets it through
+func failing(id uint, times int, err error) func(msg *outbox.Message) error {
+	var mu sync.Mutex
+	return func(msg *outbox.Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if msg.ID != id || times == 0 {
+			return nil
+		}
+		times--
+		return err
+	}
+}
+
+// relayUntilDone runs a relay from db to sink until no message waits to be published
+func relayUntilDone(t *testing.T, db *gorm.DB, sink outbox.Sink, opts outbox.Options) {
+	t.Helper()
+	opts.PollInterval = 10 * time.Millisecond
+	opts.Backoff = retry.Policy{InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, Jitter: -1}
+	relay := outbox.New(db, sink, opts)
+	ctx, cancel := context.WithCancel(context.Background())
+	relay.Start(ctx)
+	defer func() {
+		cancel()
+		if err := relay.Shutdown(context.Background()); err != nil {
+			t.Errorf("Shutdown() error = %v", err)
+		}
+	}()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		var waiting int64
+		if err := db.Model(&outbox.MessageModel{}).Where("published_at IS NULL AND failed_at IS NULL").Count(&waiting).Error; err != nil {
+			t.Fatalf("count waiting messages: %v", err)
+		}
+		if waiting == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d messages still waiting", waiting)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// message loads the message with id, failing the test on error
+func message(t *testing.T, db *gorm.DB, id uint) *outbox.Message {
+	t.Helper()
+	msg, err := outbox.Get(context.Background(), db, id)
+	if err != nil {
+		t.Fatalf("Get(%d) error = %v", id, err)
+	}
+	return msg
+}
+
+func TestWriterPublish(t *testing.T) {
+	ctx := context.Background()
+	db, w := openOutbox(t)
+	occurred := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	if err := w.Publish(ctx, event("order.created", "orders/7", occurred)); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if err := w.Publish(ctx, event("catalog.reindexed", "", occurred)); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	tests := []struct {
+		id               uint
+		wantType         string
+		wantPartitionKey string
+	}{
+		{1, "order.created", "orders/7"},
+		{2, "catalog.reindexed", "catalog.reindexed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantType, func(t *testing.T) {
+			msg := message(t, db, tt.id)
+			if msg.EventType != tt.wantType || msg.PartitionKey != tt.wantPartitionKey || msg.Topic != testTopic {
+				t.Errorf("message = %s keyed %q on %s, want %s keyed %q on %s",
+					msg.EventType, msg.PartitionKey, msg.Topic, tt.wantType, tt.wantPartitionKey, testTopic)
+			}
+			var ce cloudevents.Event
+			if err := json.Unmarshal(msg.Payload, &ce); err != nil || ce.ID != msg.EventID || ce.Type != tt.wantType || !ce.Time.Equal(occurred) {
+				t.Errorf("payload = %s, %v; want the CloudEvent %s of %s", msg.Payload, err, msg.EventID, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestWriterJoinsTheTransaction(t *testing.T) {
+	db, w := openOutbox(t)
+	bus := eventbus.New()
+	defer outbox.Subscribe(bus, w)()
+	rollback := errors.New("rolled back")
+
+	err := database.Transactional(db)(context.Background(), "order.Cancel", func(ctx context.Context) error {
+		bus.Publish(ctx, event("order.cancelled", "orders/1", time.Now()))
+		return rollback
+	})
+	if err != rollback {
+		t.Fatalf("transaction error = %v, want %v", err, rollback)
+	}
+	err = database.Transactional(db)(context.Background(), "order.Place", func(ctx context.Context) error {
+		return bus.Publish(ctx, event("order.placed", "orders/2", time.Now()))
+	})
+	if err != nil {
+		t.Fatalf("transaction error = %v", err)
+	}
+	bus.Publish(events.WithReplay(context.Background()), event("order.placed", "orders/3", time.Now()))
+
+	var types []string
+	if err := db.Model(&outbox.MessageModel{}).Order("id").Pluck("event_type", &types).Error; err != nil {
+		t.Fatalf("list messages: %v", err)
+	}
+	if len(types) != 1 || types[0] != "order.placed" {
+		t.Errorf("recorded %v, want only the committed order.placed", types)
+	}
+}
+
+func TestRelay(t *testing.T) {
+	sendFailed := errors.New("broker unavailable")
+	tests := []struct {
+		name string
+		// fail fails sends of some messages, nil sends them all
+		fail        func(msg *outbox.Message) error
+		maxAttempts int
+		wantSent    []uint
+		// wantPoisoned is set when message 2 is set aside
+		wantPoisoned bool
+		// wantAttempts are the failed sends recorded on message 2
+		wantAttempts int
+	}{
+		{
+			name:     "in order across batches",
+			wantSent: []uint{1, 2, 3, 4, 5},
+		},
+		{
+			name:         "a failed message is retried before the later ones",
+			fail:         failing(2, 2, sendFailed),
+			wantSent:     []uint{1, 2, 3, 4, 5},
+			wantAttempts: 2,
+		},
+		{
+			name:         "poison once out of attempts",
+			fail:         failing(2, 100, sendFailed),
+			maxAttempts:  3,
+			wantSent:     []uint{1, 3, 4, 5},
+			wantPoisoned: true,
+			wantAttempts: 3,
+		},
+		{
+			name:         "poison at once on a permanent failure",
+			fail:         failing(2, 100, retry.Permanent(errors.New("message too large"))),
+			wantSent:     []uint{1, 3, 4, 5},
+			wantPoisoned: true,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, w := openOutbox(t)
+			write(t, w, 5)
+			sink := &fakeSink{fail: tt.fail}
+			relayUntilDone(t, db, sink, outbox.Options{BatchSize: 2, MaxAttempts: tt.maxAttempts})
+
+			if got := sink.ids(); len(got) != len(tt.wantSent) {
+				t.Fatalf("sent %v, want %v", got, tt.wantSent)
+			} else {
+				for i := range got {
+					if got[i] != tt.wantSent[i] {
+						t.Fatalf("sent %v, want %v", got, tt.wantSent)
+					}
+				}
+			}
+			for _, id := range tt.wantSent {
+				if msg := message(t, db, id); msg.PublishedAt == nil {
+					t.Errorf("message %d sent but not marked published", id)
+				}
+			}
+			msg := message(t, db, 2)
+			if msg.Attempts != tt.wantAttempts || (tt.wantAttempts > 0) != (msg.LastError != "") {
+				t.Errorf("message 2 = %d attempts, error %q; want %d attempts", msg.Attempts, msg.LastError, tt.wantAttempts)
+			}
+			if poisoned := msg.FailedAt != nil; poisoned != tt.wantPoisoned || poisoned && msg.PublishedAt != nil {
+				t.Errorf("message 2 failed at %v, published at %v; want poisoned = %v", msg.FailedAt, msg.PublishedAt, tt.wantPoisoned)
+			}
+		})
+	}
+}
+
+func TestRelayWaitsForTheLock(t *testing.T) {
+	db, w := openOutbox(t)
+	write(t, w, 3)
+	lockManager := mocks.NewMockLockManager(gomock.NewController(t))
+	lockManager.EXPECT().TryObtain(gomock.Any(), "outbox:relay", gomock.Any()).Return(nil, locks.ErrNotObtained).MinTimes(1)
+	sink := &fakeSink{}
+
+	relay := outbox.New(db, sink, outbox.Options{PollInterval: 10 * time.Millisecond, Locks: lockManager})
+	ctx, cancel := context.WithCancel(context.Background())
+	relay.Start(ctx)
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	if err := relay.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if sent := sink.ids(); len(sent) != 0 {
+		t.Errorf("sent %v while another replica relays, want nothing", sent)
+	}
+}
+
+func TestPoisonMessages(t *testing.T) {
+	ctx := context.Background()
+	db, w := openOutbox(t)
+	write(t, w, 3)
+	relayUntilDone(t, db, &fakeSink{fail: func(msg *outbox.Message) error {
+		if msg.ID == 3 {
+			return nil
+		}
+		return retry.Permanent(errors.New("rejected"))
+	}}, outbox.Options{})
+
+	poisoned, total, err := outbox.ListPoisoned(ctx, db, "order.updated", 10, 0)
+	if err != nil || total != 2 || len(poisoned) != 2 {
+		t.Fatalf("ListPoisoned() = %d of %d, %v; want 2", len(poisoned), total, err)
+	}
+	if _, total, _ := outbox.ListPoisoned(ctx, db, "order.created", 10, 0); total != 0 {
+		t.Errorf("ListPoisoned() of another type = %d, want 0", total)
+	}
+
+	replayed, err := outbox.Replay(ctx, db, 1)
+	if err != nil || replayed.FailedAt != nil || replayed.Attempts != 0 || replayed.LastError == "" {
+		t.Fatalf("Replay() = %+v, %v; want fresh attempts and the last error kept", replayed, err)
+	}
+	sink := &fakeSink{}
+	relayUntilDone(t, db, sink, outbox.Options{})
+	if sent := sink.ids(); len(sent) != 1 || sent[0] != 1 {
+		t.Errorf("sent %v after the replay, want message 1", sent)
+	}
+	if err := outbox.Discard(ctx, db, 2); err != nil {
+		t.Fatalf("Discard() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr error
+	}{
+		{"replay a published message", func() error { _, err := outbox.Replay(ctx, db, 3); return err }, outbox.ErrMessageNotPoisoned},
+		{"discard a published message", func() error { return outbox.Discard(ctx, db, 3) }, outbox.ErrMessageNotPoisoned},
+		{"replay a discarded message", func() error { _, err := outbox.Replay(ctx, db, 2); return err }, outbox.ErrMessageNotFound},
+		{"discard a discarded message", func() error { return outbox.Discard(ctx, db, 2) }, outbox.ErrMessageNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != tt.wantErr {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	purged, err := outbox.PurgePublished(ctx, db, time.Now().Add(time.Minute))
+	if err != nil || purged != 2 {
+		t.Errorf("PurgePublished() = %d, %v; want the 2 published messages", purged, err)
+	}
+}
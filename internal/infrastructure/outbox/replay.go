@@ -0,0 +1,151 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+
+	"gorm.io/gorm"
+)
+
+// replayBatchSize is how many messages a replay reads at a time
+const replayBatchSize = 500
+
+// StoredEvent is a domain event read back from the outbox: its name, subject and time as
+// they were recorded, and its data as the JSON of the CloudEvent
+type StoredEvent struct {
+	// ID is the ID of the outbox message the event was read from
+	ID       uint
+	Name     string
+	Subject  string
+	Occurred time.Time
+	Data     json.RawMessage
+}
+
+var (
+	_ events.DomainEvent     = (*StoredEvent)(nil)
+	_ events.SubjectProvider = (*StoredEvent)(nil)
+)
+
+// EventName returns the name the event was published under
+func (e *StoredEvent) EventName() string {
+	return e.Name
+}
+
+// OccurredOn returns when the event happened
+func (e *StoredEvent) OccurredOn() time.Time {
+	return e.Occurred
+}
+
+// EventData returns the data of the event as recorded
+func (e *StoredEvent) EventData() interface{} {
+	return e.Data
+}
+
+// EventSubject returns the resource the event is about, if any
+func (e *StoredEvent) EventSubject() string {
+	return e.Subject
+}
+
+// Decode unmarshals the data of the event into v
+func (e *StoredEvent) Decode(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// Filter selects the recorded events to replay; zero fields select every event
+type Filter struct {
+	// Types names the events to replay
+	Types []string
+	// Aggregate is a subject, e.g. orders/42, or a kind of subject, e.g. orders
+	Aggregate string
+	// From and To bound when the events occurred, From included and To excluded
+	From *time.Time
+	To   *time.Time
+}
+
+// apply scopes tx to the messages of the events f selects
+func (f Filter) apply(tx *gorm.DB) *gorm.DB {
+	if len(f.Types) > 0 {
+		tx = tx.Where("event_type IN ?", f.Types)
+	}
+	if f.Aggregate != "" {
+		if strings.Contains(f.Aggregate, "/") {
+			tx = tx.Where("subject = ?", f.Aggregate)
+		} else {
+			tx = tx.Where("subject LIKE ?", f.Aggregate+"/%")
+		}
+	}
+	if f.From != nil {
+		tx = tx.Where("occurred_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		tx = tx.Where("occurred_at < ?", *f.To)
+	}
+	return tx
+}
+
+// ReplayEvents reads the recorded events filter selects, in the order they were written, and
+// hands each to handle in the context of its tenant, marked with events.WithReplay. Messages
+// are kept until PurgePublished removes them, so the events replayed are those of the last
+// OUTBOX_RETENTION, published or not. progress, when not nil, is told how many events were
+// handled out of how many there are. It returns how many events were handled
+func ReplayEvents(ctx context.Context, db *gorm.DB, filter Filter, handle events.EventHandler, progress func(done, total int)) (int, error) {
+	var total int64
+	if err := filter.apply(db.WithContext(ctx).Model(&MessageModel{})).Count(&total).Error; err != nil {
+		return 0, err
+	}
+	if progress != nil {
+		progress(0, int(total))
+	}
+
+	done := 0
+	var after uint
+	for {
+		var models []MessageModel
+		err := filter.apply(db.WithContext(ctx).Model(&MessageModel{})).
+			Where("id > ?", after).Order("id").Limit(replayBatchSize).Find(&models).Error
+		if err != nil {
+			return done, err
+		}
+		for i := range models {
+			if err := ctx.Err(); err != nil {
+				return done, err
+			}
+			event, err := models[i].toStoredEvent()
+			if err != nil {
+				return done, err
+			}
+			handle(events.WithReplay(tenancy.NewContext(ctx, models[i].TenantID)), event)
+			done++
+			if progress != nil {
+				progress(done, int(total))
+			}
+		}
+		if len(models) < replayBatchSize {
+			return done, nil
+		}
+		after = models[len(models)-1].ID
+	}
+}
+
+// toStoredEvent reads the domain event back from the CloudEvent of the message
+func (m *MessageModel) toStoredEvent() (*StoredEvent, error) {
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(m.Payload, &envelope); err != nil {
+		return nil, fmt.Errorf("outbox: message %d is not a CloudEvent: %w", m.ID, err)
+	}
+	return &StoredEvent{
+		ID:       m.ID,
+		Name:     m.EventType,
+		Subject:  m.Subject,
+		Occurred: m.OccurredAt,
+		Data:     envelope.Data,
+	}, nil
+}
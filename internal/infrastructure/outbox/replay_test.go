@@ -0,0 +1,101 @@
+package outbox_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	"clean-arch-gin/internal/infrastructure/outbox"
+)
+
+func TestReplayEvents(t *testing.T) {
+	ctx := context.Background()
+	db, w := openOutbox(t)
+	base := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	recorded := []events.DomainEvent{
+		event("order.created", "orders/1", base),
+		event("order.created", "orders/12", base.Add(time.Hour)),
+		event("order.cancelled", "orders/1", base.Add(2*time.Hour)),
+		event("user.registered", "users/1", base.Add(3*time.Hour)),
+		event("catalog.reindexed", "", base.Add(4*time.Hour)),
+	}
+	for i, e := range recorded {
+		publishCtx := tenancy.NewContext(ctx, 1)
+		// The user event is recorded for another tenant
+		if i == 3 {
+			publishCtx = tenancy.NewContext(ctx, 2)
+		}
+		if err := w.Publish(publishCtx, e); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	from, to := base.Add(time.Hour), base.Add(3*time.Hour)
+
+	tests := []struct {
+		name    string
+		filter  outbox.Filter
+		wantIDs []uint
+	}{
+		{"every event", outbox.Filter{}, []uint{1, 2, 3, 4, 5}},
+		{"by type", outbox.Filter{Types: []string{"order.created", "user.registered"}}, []uint{1, 2, 4}},
+		{"by subject", outbox.Filter{Aggregate: "orders/1"}, []uint{1, 3}},
+		{"by kind of subject", outbox.Filter{Aggregate: "orders"}, []uint{1, 2, 3}},
+		{"by time, To excluded", outbox.Filter{From: &from, To: &to}, []uint{2, 3}},
+		{"nothing selected", outbox.Filter{Types: []string{"order.shipped"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotIDs []uint
+			var lastDone, lastTotal int
+			handled, err := outbox.ReplayEvents(ctx, db, tt.filter, func(ctx context.Context, event events.DomainEvent) {
+				stored := event.(*outbox.StoredEvent)
+				gotIDs = append(gotIDs, stored.ID)
+				if !events.IsReplay(ctx) {
+					t.Errorf("event %d handled without the replay mark", stored.ID)
+				}
+				wantTenant := uint(1)
+				if stored.ID == 4 {
+					wantTenant = 2
+				}
+				if tenantID, _ := tenancy.FromContext(ctx); tenantID != wantTenant {
+					t.Errorf("event %d handled in tenant %d, want %d", stored.ID, tenantID, wantTenant)
+				}
+				var data map[string]string
+				if err := stored.Decode(&data); err != nil || data["subject"] != stored.EventSubject() {
+					t.Errorf("event %d data = %s, %v; want the recorded data", stored.ID, stored.Data, err)
+				}
+			}, func(done, total int) {
+				lastDone, lastTotal = done, total
+			})
+			if err != nil {
+				t.Fatalf("ReplayEvents() error = %v", err)
+			}
+			if handled != len(tt.wantIDs) || lastDone != handled || lastTotal != len(tt.wantIDs) {
+				t.Errorf("handled %d, progress %d of %d; want %d", handled, lastDone, lastTotal, len(tt.wantIDs))
+			}
+			if len(gotIDs) != len(tt.wantIDs) {
+				t.Fatalf("replayed %v, want %v", gotIDs, tt.wantIDs)
+			}
+			for i := range gotIDs {
+				if gotIDs[i] != tt.wantIDs[i] {
+					t.Fatalf("replayed %v, want %v", gotIDs, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestReplayEventsStopsWhenCancelled(t *testing.T) {
+	db, w := openOutbox(t)
+	write(t, w, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	handled, err := outbox.ReplayEvents(ctx, db, outbox.Filter{}, func(context.Context, events.DomainEvent) {
+		cancel()
+	}, nil)
+	if err != context.Canceled || handled != 1 {
+		t.Errorf("ReplayEvents() = %d, %v; want 1, %v", handled, err, context.Canceled)
+	}
+}
@@ -0,0 +1,27 @@
+// Package payments implements the payment gateways
+package payments
+
+import (
+	"context"
+	"log"
+
+	"clean-arch-gin/internal/domain/shared/payments"
+)
+
+// Manual is the gateway for shops paying refunds back by hand, e.g. by bank transfer: the
+// refund is logged for the finance team and its idempotency key is the reference
+type Manual struct{}
+
+var _ payments.Gateway = Manual{}
+
+// NewManual creates the manual gateway
+func NewManual() Manual {
+	return Manual{}
+}
+
+// Refund logs the refund to pay out by hand
+func (Manual) Refund(ctx context.Context, refund payments.Refund) (string, error) {
+	log.Printf("payments: refund %.2f %s for order %d to pay out manually (%s)", refund.Amount, refund.Currency, refund.OrderID,
+		refund.IdempotencyKey)
+	return refund.IdempotencyKey, nil
+}
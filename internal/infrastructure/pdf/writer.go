@@ -0,0 +1,179 @@
+// Package pdf renders documents as PDF without external dependencies, using the PDF
+// standard fonts: the title in Helvetica Bold, everything else in Courier so that table
+// columns line up
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"clean-arch-gin/internal/domain/shared/documents"
+)
+
+// A4 portrait page layout, in points
+const (
+	pageWidth    = 595.0
+	pageHeight   = 842.0
+	margin       = 50.0
+	titleSize    = 18.0
+	bodySize     = 9.0
+	lineHeight   = 13.0
+	courierWidth = 0.6 // Courier advance width per character, relative to the font size
+)
+
+// Generator renders documents as A4 PDF files, implementing documents.PDFGenerator
+type Generator struct{}
+
+var _ documents.PDFGenerator = Generator{}
+
+// NewGenerator creates a PDF generator
+func NewGenerator() Generator {
+	return Generator{}
+}
+
+// GeneratePDF writes doc as a PDF to w, breaking the table across pages as needed
+// Characters outside Latin-1 are replaced with '?'
+func (Generator) GeneratePDF(w io.Writer, doc documents.Document) error {
+	var pages []*bytes.Buffer
+	var page *bytes.Buffer
+	y := 0.0
+
+	newPage := func() {
+		page = &bytes.Buffer{}
+		pages = append(pages, page)
+		y = pageHeight - margin
+	}
+	text := func(font string, size, x float64, s string) {
+		fmt.Fprintf(page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(s))
+	}
+	line := func(s string) {
+		if y < margin+lineHeight {
+			newPage()
+		}
+		text("F2", bodySize, margin, s)
+		y -= lineHeight
+	}
+
+	newPage()
+	text("F1", titleSize, margin, doc.Title)
+	y -= titleSize * 2
+	for _, s := range doc.Header {
+		line(s)
+	}
+
+	if len(doc.Columns) > 0 {
+		y -= lineHeight
+		header := formatRow(doc.Columns, nil)
+		rule := strings.Repeat("-", len(header))
+		line(header)
+		line(rule)
+		for _, row := range doc.Rows {
+			if y < margin+lineHeight {
+				newPage()
+				line(header)
+				line(rule)
+			}
+			line(formatRow(doc.Columns, row))
+		}
+		line(rule)
+	}
+
+	y -= lineHeight
+	for _, s := range doc.Footer {
+		line(s)
+	}
+
+	return writeFile(w, pages)
+}
+
+// formatRow lays cells out in the columns' fixed widths; nil cells print the column titles
+func formatRow(columns []documents.Column, cells []string) string {
+	var b strings.Builder
+	for i, column := range columns {
+		cell := column.Title
+		if cells != nil {
+			cell = ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+		}
+		if len(cell) > column.Width {
+			cell = cell[:column.Width]
+		}
+		padding := strings.Repeat(" ", column.Width-len(cell))
+		if i > 0 {
+			b.WriteString("  ")
+		}
+		if column.AlignRight {
+			b.WriteString(padding + cell)
+		} else {
+			b.WriteString(cell + padding)
+		}
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// maxColumns is how many Courier characters fit on a line: (pageWidth - 2*margin) / (bodySize * courierWidth)
+const maxColumns = 91
+
+// escape encodes s as the body of a PDF literal string in WinAnsi
+func escape(s string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		if n == maxColumns {
+			break
+		}
+		n++
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 255:
+			b.WriteByte('?')
+		case r > 126:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// writeFile writes the PDF objects for the pages' content streams and the cross-reference table
+func writeFile(w io.Writer, pages []*bytes.Buffer) error {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// 1 catalog, 2 page tree, 3-4 fonts, then a page and its content per page
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	for i, page := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
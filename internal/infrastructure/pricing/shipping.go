@@ -0,0 +1,67 @@
+package pricing
+
+import (
+	"context"
+
+	"clean-arch-gin/internal/domain/shared/pricing"
+)
+
+// FlatShipping charges the same rate for any shipment
+type FlatShipping struct {
+	amount float64
+}
+
+var _ pricing.ShippingStrategy = FlatShipping{}
+
+// NewFlatShipping creates a strategy charging amount per shipment
+func NewFlatShipping(amount float64) FlatShipping {
+	return FlatShipping{amount: amount}
+}
+
+// ShippingRate returns the flat rate
+func (f FlatShipping) ShippingRate(ctx context.Context, destination pricing.Destination, lines []pricing.Line) (pricing.ShippingRate, error) {
+	return pricing.ShippingRate{Method: "Standard", Amount: pricing.Round(f.amount)}, nil
+}
+
+// PerItemShipping charges a base rate plus a rate per unit shipped
+type PerItemShipping struct {
+	base    float64
+	perItem float64
+}
+
+var _ pricing.ShippingStrategy = PerItemShipping{}
+
+// NewPerItemShipping creates a strategy charging base plus perItem for every unit
+func NewPerItemShipping(base, perItem float64) PerItemShipping {
+	return PerItemShipping{base: base, perItem: perItem}
+}
+
+// ShippingRate returns the base rate plus the units' rate
+func (p PerItemShipping) ShippingRate(ctx context.Context, destination pricing.Destination, lines []pricing.Line) (pricing.ShippingRate, error) {
+	units := 0
+	for _, line := range lines {
+		units += line.Quantity
+	}
+	return pricing.ShippingRate{Method: "Standard", Amount: pricing.Round(p.base + p.perItem*float64(units))}, nil
+}
+
+// FreeShippingOver waives the rate of another strategy for subtotals of at least a threshold
+type FreeShippingOver struct {
+	threshold float64
+	next      pricing.ShippingStrategy
+}
+
+var _ pricing.ShippingStrategy = FreeShippingOver{}
+
+// NewFreeShippingOver creates a strategy shipping for free from threshold, and as next below it
+func NewFreeShippingOver(threshold float64, next pricing.ShippingStrategy) FreeShippingOver {
+	return FreeShippingOver{threshold: threshold, next: next}
+}
+
+// ShippingRate waives shipping for large enough subtotals
+func (f FreeShippingOver) ShippingRate(ctx context.Context, destination pricing.Destination, lines []pricing.Line) (pricing.ShippingRate, error) {
+	if pricing.Subtotal(lines) >= f.threshold {
+		return pricing.ShippingRate{Method: "Free shipping"}, nil
+	}
+	return f.next.ShippingRate(ctx, destination, lines)
+}
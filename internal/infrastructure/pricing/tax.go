@@ -0,0 +1,97 @@
+// Package pricing implements the tax calculators and shipping rate strategies
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"clean-arch-gin/internal/domain/shared/pricing"
+)
+
+// FlatRate taxes every line at the same rate, wherever it ships
+type FlatRate struct {
+	rate float64
+}
+
+var _ pricing.TaxCalculator = FlatRate{}
+
+// NewFlatRate creates a calculator taxing at rate, e.g. 0.2 for 20%
+func NewFlatRate(rate float64) FlatRate {
+	return FlatRate{rate: rate}
+}
+
+// CalculateTax taxes the lines at the flat rate
+func (f FlatRate) CalculateTax(ctx context.Context, destination pricing.Destination, lines []pricing.Line) (pricing.Tax, error) {
+	return taxAt(f.rate, lines), nil
+}
+
+// RegionTable taxes lines at the rate of the region, or else the country, they ship to
+type RegionTable struct {
+	rates    map[string]float64
+	fallback float64
+}
+
+var _ pricing.TaxCalculator = RegionTable{}
+
+// NewRegionTable creates a calculator with rates keyed by "COUNTRY-REGION" or "COUNTRY",
+// e.g. "US-CA" and "DE"; destinations in neither are taxed at fallback
+func NewRegionTable(rates map[string]float64, fallback float64) RegionTable {
+	normalized := make(map[string]float64, len(rates))
+	for key, rate := range rates {
+		normalized[strings.ToUpper(key)] = rate
+	}
+	return RegionTable{rates: normalized, fallback: fallback}
+}
+
+// CalculateTax taxes the lines at the destination's rate
+func (t RegionTable) CalculateTax(ctx context.Context, destination pricing.Destination, lines []pricing.Line) (pricing.Tax, error) {
+	country := strings.ToUpper(destination.Country)
+	if destination.Region != "" {
+		if rate, ok := t.rates[country+"-"+strings.ToUpper(destination.Region)]; ok {
+			return taxAt(rate, lines), nil
+		}
+	}
+	if rate, ok := t.rates[country]; ok {
+		return taxAt(rate, lines), nil
+	}
+	return taxAt(t.fallback, lines), nil
+}
+
+// ParseRates parses a rate table such as "US-CA=0.0725,US=0.05,DE=0.19"
+func ParseRates(s string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid tax rate %q: want KEY=RATE", entry)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("invalid tax rate %q", entry)
+		}
+		rates[strings.TrimSpace(key)] = rate
+	}
+	return rates, nil
+}
+
+// taxAt taxes each line at rate, labelled with the rate as a percentage
+func taxAt(rate float64, lines []pricing.Line) pricing.Tax {
+	tax := pricing.Tax{Amounts: make([]float64, len(lines))}
+	if rate == 0 {
+		return tax
+	}
+
+	// Rounded to hundredths of a percent, so 0.0725 reads 7.25% despite float error
+	tax.Label = "Tax " + strconv.FormatFloat(math.Round(rate*10000)/100, 'f', -1, 64) + "%"
+	for i, line := range lines {
+		tax.Amounts[i] = pricing.Round(line.Amount() * rate)
+	}
+	return tax
+}
@@ -0,0 +1,106 @@
+package pricing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/pricing"
+)
+
+// requestTimeout bounds a call to the tax service
+const requestTimeout = 5 * time.Second
+
+// taxRequest is the body posted to the tax service
+type taxRequest struct {
+	Destination taxDestination `json:"destination"`
+	Lines       []taxLine      `json:"lines"`
+}
+
+type taxDestination struct {
+	Country    string `json:"country"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+}
+
+type taxLine struct {
+	ProductID uint    `json:"product_id"`
+	Quantity  int     `json:"quantity"`
+	Amount    float64 `json:"amount"`
+}
+
+// taxResponse is the tax service's answer: the tax of each line, in the order posted
+type taxResponse struct {
+	Label   string    `json:"label"`
+	Amounts []float64 `json:"amounts"`
+}
+
+// API asks an external tax service for the tax of each line, implementing pricing.TaxCalculator
+// The service is posted the destination and lines as JSON and answers with their tax amounts;
+// it answers 422 Unprocessable Entity for destinations it does not cover
+type API struct {
+	client *http.Client
+	url    string
+	apiKey string
+}
+
+var _ pricing.TaxCalculator = (*API)(nil)
+
+// NewAPI creates a calculator for the tax service at url, authenticated with apiKey when set
+func NewAPI(url, apiKey string) *API {
+	return &API{
+		client: &http.Client{Timeout: requestTimeout},
+		url:    url,
+		apiKey: apiKey,
+	}
+}
+
+// CalculateTax asks the service for the lines' tax
+func (a *API) CalculateTax(ctx context.Context, destination pricing.Destination, lines []pricing.Line) (pricing.Tax, error) {
+	body := taxRequest{
+		Destination: taxDestination{Country: destination.Country, Region: destination.Region, PostalCode: destination.PostalCode},
+		Lines:       make([]taxLine, len(lines)),
+	}
+	for i, line := range lines {
+		body.Lines[i] = taxLine{ProductID: line.ProductID, Quantity: line.Quantity, Amount: line.Amount()}
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return pricing.Tax{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(payload))
+	if err != nil {
+		return pricing.Tax{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if a.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.apiKey)
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return pricing.Tax{}, fmt.Errorf("tax service unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnprocessableEntity:
+		return pricing.Tax{}, fmt.Errorf("%w: tax service does not cover %s", pricing.ErrUnsupportedDestination, destination.Country)
+	case resp.StatusCode != http.StatusOK:
+		return pricing.Tax{}, fmt.Errorf("tax service answered %s", resp.Status)
+	}
+
+	var result taxResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return pricing.Tax{}, fmt.Errorf("invalid tax service response: %w", err)
+	}
+	if len(result.Amounts) != len(lines) {
+		return pricing.Tax{}, fmt.Errorf("invalid tax service response: %d amounts for %d lines", len(result.Amounts), len(lines))
+	}
+	for i, amount := range result.Amounts {
+		result.Amounts[i] = pricing.Round(amount)
+	}
+	return pricing.Tax{Label: result.Label, Amounts: result.Amounts}, nil
+}
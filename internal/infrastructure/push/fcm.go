@@ -0,0 +1,259 @@
+// Package push implements the push port: senders delivering notifications to devices
+// through a provider
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/push"
+)
+
+// FCMURL is the base of the FCM HTTP v1 API
+const FCMURL = "https://fcm.googleapis.com/v1/projects/"
+
+// fcmScope is the OAuth scope allowed to send messages
+const fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// apiTimeout bounds a call to a push provider's API
+const apiTimeout = 10 * time.Second
+
+// accessTokenMargin renews an access token ahead of its expiry, so requests in flight do
+// not carry an expired one
+const accessTokenMargin = time.Minute
+
+// serviceAccount is the part of a Google service account key file FCM needs
+type serviceAccount struct {
+	Type         string `json:"type"`
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// fcmNotification is the part of a message the device displays
+type fcmNotification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body"`
+}
+
+// fcmMessage is the message of a send request
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+// fcmError is the body of an FCM API error; details carry the FCM error code
+type fcmError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// FCM sends push notifications with the Firebase Cloud Messaging HTTP v1 API, implementing
+// push.Sender
+// It authenticates as a service account allowed to send messages, exchanging a signed
+// assertion for an access token it reuses until shortly before it expires
+type FCM struct {
+	client  *http.Client
+	sendURL string
+	account serviceAccount
+	key     *rsa.PrivateKey
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+var _ push.Sender = (*FCM)(nil)
+
+// NewFCM creates a sender from the JSON key file of a service account; projectID overrides
+// the key's project when not empty
+func NewFCM(credentials []byte, projectID string) (*FCM, error) {
+	var account serviceAccount
+	if err := json.Unmarshal(credentials, &account); err != nil {
+		return nil, fmt.Errorf("invalid FCM credentials: %w", err)
+	}
+	if account.Type != "service_account" || account.ClientEmail == "" || account.TokenURI == "" {
+		return nil, errors.New("invalid FCM credentials: not a service account key")
+	}
+	block, _ := pem.Decode([]byte(account.PrivateKey))
+	if block == nil {
+		return nil, errors.New("invalid FCM credentials: no private key")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FCM credentials: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("invalid FCM credentials: not an RSA key")
+	}
+	if projectID != "" {
+		account.ProjectID = projectID
+	}
+	if account.ProjectID == "" {
+		return nil, errors.New("FCM project ID is required")
+	}
+
+	return &FCM{
+		client:  &http.Client{Timeout: apiTimeout},
+		sendURL: FCMURL + url.PathEscape(account.ProjectID) + "/messages:send",
+		account: account,
+		key:     key,
+	}, nil
+}
+
+// Send posts msg for the device with token; tokens FCM reports unregistered, issued to
+// another project or malformed fail with push.ErrInvalidToken
+func (f *FCM) Send(ctx context.Context, token string, msg push.Message) error {
+	accessToken, err := f.token(ctx)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(map[string]fcmMessage{"message": {
+		Token:        token,
+		Notification: fcmNotification{Title: msg.Title, Body: msg.Body},
+		Data:         msg.Data,
+	}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.sendURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("FCM unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		f.resetToken()
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+	var apiErr fcmError
+	if json.Unmarshal(detail, &apiErr) == nil && invalidToken(apiErr) {
+		return fmt.Errorf("%w: %s", push.ErrInvalidToken, apiErr.Error.Message)
+	}
+	return fmt.Errorf("FCM answered %s: %s", resp.Status, bytes.TrimSpace(detail))
+}
+
+// invalidToken reports whether apiErr is about the token rather than the message or the
+// sender
+func invalidToken(apiErr fcmError) bool {
+	for _, detail := range apiErr.Error.Details {
+		switch detail.ErrorCode {
+		case "UNREGISTERED", "SENDER_ID_MISMATCH":
+			return true
+		}
+	}
+	return apiErr.Error.Status == "INVALID_ARGUMENT" && strings.Contains(apiErr.Error.Message, "registration token")
+}
+
+// token returns the cached access token, or exchanges a new assertion for one
+func (f *FCM) token(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.accessToken != "" && time.Now().Before(f.expiresAt) {
+		return f.accessToken, nil
+	}
+
+	assertion, err := f.assertion(time.Now())
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Google OAuth unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("Google OAuth answered %s: %s", resp.Status, bytes.TrimSpace(detail))
+	}
+
+	var granted struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&granted); err != nil {
+		return "", fmt.Errorf("invalid Google OAuth response: %w", err)
+	}
+	f.accessToken = granted.AccessToken
+	f.expiresAt = time.Now().Add(time.Duration(granted.ExpiresIn)*time.Second - accessTokenMargin)
+	return f.accessToken, nil
+}
+
+// resetToken drops the cached access token, e.g. after FCM refused it
+func (f *FCM) resetToken() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.accessToken = ""
+}
+
+// assertion signs the JWT the service account is authenticated with, valid for an hour
+// from now
+func (f *FCM) assertion(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": f.account.PrivateKeyID})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   f.account.ClientEmail,
+		"scope": fcmScope,
+		"aud":   f.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, f.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,24 @@
+package push
+
+import (
+	"context"
+	"log"
+
+	"clean-arch-gin/internal/domain/shared/push"
+)
+
+// Log writes push notifications to the log instead of sending them, for development
+type Log struct{}
+
+var _ push.Sender = Log{}
+
+// NewLog creates the log sender
+func NewLog() Log {
+	return Log{}
+}
+
+// Send logs the device token and the title and body of msg
+func (Log) Send(ctx context.Context, token string, msg push.Message) error {
+	log.Printf("push: to %s: %s\n%s", token, msg.Title, msg.Body)
+	return nil
+}
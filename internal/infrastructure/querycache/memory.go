@@ -0,0 +1,102 @@
+package querycache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryEntry is a value kept by MemoryStore
+type memoryEntry struct {
+	value     []byte
+	subject   string
+	expiresAt time.Time
+}
+
+// MemoryStore keeps the entries in process memory, so each replica caches its own and
+// serves entries written on other replicas up to their TTL
+type MemoryStore struct {
+	mu       sync.Mutex
+	entries  map[string]memoryEntry
+	subjects map[string]map[string]bool
+	// pruned is when expired entries were last dropped
+	pruned time.Time
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// pruneInterval is how often Set drops the expired entries
+const pruneInterval = time.Minute
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries:  make(map[string]memoryEntry),
+		subjects: make(map[string]map[string]bool),
+		pruned:   time.Now(),
+	}
+}
+
+// Get returns the entry under key unless it expired
+func (s *MemoryStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cached, ok := s.entries[key]
+	if !ok || !time.Now().Before(cached.expiresAt) {
+		return nil, false, nil
+	}
+	return cached.value, true, nil
+}
+
+// Set stores value under key for ttl, tagged with subject
+func (s *MemoryStore) Set(ctx context.Context, key, subject string, value []byte, ttl time.Duration) error {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.pruned) >= pruneInterval {
+		for k, cached := range s.entries {
+			if !now.Before(cached.expiresAt) {
+				s.remove(k)
+			}
+		}
+		s.pruned = now
+	}
+
+	s.remove(key)
+	s.entries[key] = memoryEntry{value: value, subject: subject, expiresAt: now.Add(ttl)}
+	if subject != "" {
+		if s.subjects[subject] == nil {
+			s.subjects[subject] = make(map[string]bool)
+		}
+		s.subjects[subject][key] = true
+	}
+	return nil
+}
+
+// Invalidate drops the entries tagged with subject
+func (s *MemoryStore) Invalidate(ctx context.Context, subject string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.subjects[subject] {
+		s.remove(key)
+	}
+	return nil
+}
+
+// remove drops the entry under key and its tag; s.mu must be held
+func (s *MemoryStore) remove(key string) {
+	cached, ok := s.entries[key]
+	if !ok {
+		return
+	}
+	delete(s.entries, key)
+	if keys := s.subjects[cached.subject]; keys != nil {
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(s.subjects, cached.subject)
+		}
+	}
+}
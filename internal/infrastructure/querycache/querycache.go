@@ -0,0 +1,166 @@
+// Package querycache caches the results of repository reads for read-heavy endpoints such
+// as public profiles
+// Each cached method has a Policy naming its TTL, the template of its keys and the events
+// that invalidate it. Entries are tagged with the subject of what they hold, e.g.
+// "users/42", so a write or an event about it drops every entry of that resource
+package querycache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/softdelete"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	"clean-arch-gin/internal/infrastructure/database"
+)
+
+// Store keeps the cached entries and the subjects they are tagged with
+type Store interface {
+	// Get returns the entry under key, false when there is none or it expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for ttl, tagged with subject
+	Set(ctx context.Context, key, subject string, value []byte, ttl time.Duration) error
+	// Invalidate drops the entries tagged with subject
+	Invalidate(ctx context.Context, subject string) error
+}
+
+// Policy configures the caching of one repository method
+type Policy struct {
+	// TTL bounds how stale an entry may be served; the cache's default when zero
+	TTL time.Duration
+	// Key is the key template, e.g. "users:id:{id}"; each {name} is replaced by that param
+	Key string
+	// InvalidatedBy names the events that drop the entries of their subject, for writes
+	// that do not go through the cached repository
+	InvalidatedBy []string
+}
+
+// Params are the values a key template is filled in with
+type Params map[string]interface{}
+
+// key fills in the template of p with params
+func (p Policy) key(params Params) string {
+	pairs := make([]string, 0, 2*len(params))
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(pairs...).Replace(p.Key)
+}
+
+// entry is what a Store holds: the value and the tenant it was read for
+type entry struct {
+	// Tenant is 0 when the value was read across tenants
+	Tenant uint            `json:"tenant"`
+	Value  json.RawMessage `json:"value"`
+}
+
+// Cache reads through a Store
+// A Store failing never fails a read: it is logged and the database is read instead
+type Cache struct {
+	store Store
+	ttl   time.Duration
+}
+
+// New creates a cache on store whose policies without a TTL keep entries for ttl
+func New(store Store, ttl time.Duration) *Cache {
+	return &Cache{store: store, ttl: ttl}
+}
+
+// Fetch decodes the entry of policy for params into dest, or calls load to fill dest and
+// caches it tagged with the subject load returns
+// A value read for a tenant is only served to that tenant, and reads of soft-deleted rows
+// and reads in a transaction, which may see its uncommitted writes, always call load
+func (c *Cache) Fetch(ctx context.Context, policy Policy, params Params, dest interface{}, load func() (subject string, err error)) error {
+	if _, inTx := database.TxFromContext(ctx); inTx || softdelete.FromContext(ctx) != softdelete.ExcludeDeleted {
+		_, err := load()
+		return err
+	}
+
+	key := policy.key(params)
+	tenant, _ := tenancy.FromContext(ctx)
+	if data, ok, err := c.store.Get(ctx, key); err != nil {
+		log.Printf("querycache: failed to get %s: %v", key, err)
+	} else if ok {
+		var cached entry
+		// Values read across tenants may belong to any tenant, so only the system sees them
+		if err := json.Unmarshal(data, &cached); err == nil && (tenant == 0 || cached.Tenant == tenant) {
+			if err := json.Unmarshal(cached.Value, dest); err == nil {
+				return nil
+			}
+		}
+	}
+
+	subject, err := load()
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(dest)
+	if err != nil {
+		log.Printf("querycache: failed to encode %s: %v", key, err)
+		return nil
+	}
+	data, err := json.Marshal(entry{Tenant: tenant, Value: value})
+	if err != nil {
+		log.Printf("querycache: failed to encode %s: %v", key, err)
+		return nil
+	}
+	ttl := policy.TTL
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+	if err := c.store.Set(ctx, key, subject, data, ttl); err != nil {
+		log.Printf("querycache: failed to set %s: %v", key, err)
+	}
+	return nil
+}
+
+// Invalidate drops the entries of subjects; a failure is logged, leaving them to expire
+// In a transaction they are dropped again once it commits, in case another request cached
+// the rows in the meantime
+func (c *Cache) Invalidate(ctx context.Context, subjects ...string) {
+	c.invalidate(ctx, subjects)
+	database.AfterCommit(ctx, func(ctx context.Context) {
+		c.invalidate(ctx, subjects)
+	})
+}
+
+// invalidate drops the entries of subjects now
+func (c *Cache) invalidate(ctx context.Context, subjects []string) {
+	for _, subject := range subjects {
+		if err := c.store.Invalidate(ctx, subject); err != nil {
+			log.Printf("querycache: failed to invalidate %s: %v", subject, err)
+		}
+	}
+}
+
+// InvalidateOn subscribes to the events the policies are invalidated by and drops the
+// entries of each event's subject; events without one are ignored
+// The returned function removes the subscriptions
+func (c *Cache) InvalidateOn(subscriber events.EventSubscriber, policies ...Policy) (unsubscribe func()) {
+	handler := func(ctx context.Context, event events.DomainEvent) {
+		if provider, ok := event.(events.SubjectProvider); ok {
+			c.Invalidate(ctx, provider.EventSubject())
+		}
+	}
+
+	subscribed := make(map[string]bool)
+	var unsubscribes []func()
+	for _, policy := range policies {
+		for _, name := range policy.InvalidatedBy {
+			if !subscribed[name] {
+				subscribed[name] = true
+				unsubscribes = append(unsubscribes, subscriber.Subscribe(name, handler))
+			}
+		}
+	}
+	return func() {
+		for _, unsubscribe := range unsubscribes {
+			unsubscribe()
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package querycache
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore keeps the entries in Redis, so every replica shares them and a write on one
+// invalidates them for all
+// The keys tagged with a subject are a set under subject:<subject>, expiring with the
+// longest-lived of them
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// NewRedisStore creates a store keeping entries under prefix+key
+func NewRedisStore(client redis.UniversalClient, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Get returns the entry under key
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set stores value under key for ttl and adds key to the set of subject
+func (s *RedisStore) Set(ctx context.Context, key, subject string, value []byte, ttl time.Duration) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, s.prefix+key, value, ttl)
+		if subject != "" {
+			subjectKey := s.subjectKey(subject)
+			pipe.SAdd(ctx, subjectKey, key)
+			pipe.ExpireGT(ctx, subjectKey, ttl)
+			// ExpireGT leaves a set without a TTL alone
+			pipe.ExpireNX(ctx, subjectKey, ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+// Invalidate deletes the entries in the set of subject and the set
+func (s *RedisStore) Invalidate(ctx context.Context, subject string) error {
+	subjectKey := s.subjectKey(subject)
+	keys, err := s.client.SMembers(ctx, subjectKey).Result()
+	if err != nil {
+		return err
+	}
+	for i, key := range keys {
+		keys[i] = s.prefix + key
+	}
+	return s.client.Del(ctx, append(keys, subjectKey)...).Err()
+}
+
+// subjectKey is the key of the set of keys tagged with subject
+func (s *RedisStore) subjectKey(subject string) string {
+	return s.prefix + "subject:" + subject
+}
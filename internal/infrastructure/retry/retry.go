@@ -0,0 +1,141 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Policy configures how Do retries an operation
+// Zero fields take the values of DefaultPolicy
+type Policy struct {
+	// MaxAttempts is the total number of attempts including the first (3)
+	MaxAttempts int
+	// InitialDelay is the wait before the first retry (100ms)
+	InitialDelay time.Duration
+	// MaxDelay caps the wait between attempts (10s)
+	MaxDelay time.Duration
+	// Multiplier grows the wait after every attempt (2)
+	Multiplier float64
+	// Jitter randomizes each wait by up to this fraction, e.g. 0.2 is ±20% (0.2)
+	// Negative disables jitter
+	Jitter float64
+	// Retryable classifies errors; nil retries every error not marked Permanent
+	Retryable func(err error) bool
+	// OnRetry is called before waiting to retry, e.g. to log the failed attempt
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// DefaultPolicy returns the policy used for zero Policy fields
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:  3,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     10 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// withDefaults fills zero fields from DefaultPolicy
+func (p Policy) withDefaults() Policy {
+	d := DefaultPolicy()
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = d.MaxAttempts
+	}
+	if p.InitialDelay <= 0 {
+		p.InitialDelay = d.InitialDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = d.MaxDelay
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = d.Multiplier
+	}
+	if p.Jitter == 0 {
+		p.Jitter = d.Jitter
+	}
+	return p
+}
+
+// Delay returns the wait after the given failed attempt (1-based), jitter included
+func (p Policy) Delay(attempt int) time.Duration {
+	p = p.withDefaults()
+
+	delay := float64(p.InitialDelay) * math.Pow(p.Multiplier, float64(attempt-1))
+	if delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// Do calls fn until it succeeds, returns a non-retryable error, runs out of attempts
+// or ctx is done. The last error of fn is returned; when ctx ends the wait it is
+// joined with ctx.Err() so errors.Is works for both
+func Do(ctx context.Context, policy Policy, fn func(ctx context.Context) error) error {
+	policy = policy.withDefaults()
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		if attempt >= policy.MaxAttempts || !policy.retryable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		delay := policy.Delay(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, delay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable applies the classifier; context errors are never retried
+func (p Policy) retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// permanentError marks an error that must not be retried
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so Do returns it (unwrapped) without retrying
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
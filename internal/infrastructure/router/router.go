@@ -1,3 +1,7 @@
+// Package router is the legacy flat router serving the users without the module registry
+//
+// Deprecated: compose the routers with app.Bootstrap, which serves the same user routes
+// through the user module
 package router
 
 import (
@@ -11,6 +15,8 @@ import (
 )
 
 // NewRouter creates and configures the HTTP router using dependency injection
+//
+// Deprecated: use app.Bootstrap
 func NewRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	// Set Gin mode
 	gin.SetMode(cfg.Server.Mode)
@@ -24,7 +30,7 @@ func NewRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	r.Use(middleware.CORS())
 
 	// Initialize dependencies using Wire
-	app := di.InitializeApplication(db, cfg)
+	app := di.InitializeLegacyApplication(db, cfg)
 
 	// Setup routes
 	setupRoutes(r, app.UserController)
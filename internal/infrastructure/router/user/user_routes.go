@@ -1,15 +1,45 @@
 package user
 
 import (
-	"clean-arch-gin/internal/adapters/controllers"
 	"clean-arch-gin/internal/adapters/middleware"
+	userControllers "clean-arch-gin/internal/adapters/user/controllers"
+	"clean-arch-gin/internal/domain/shared/captcha"
 
 	"github.com/gin-gonic/gin"
 )
 
 // UserRouteConfig holds dependencies for user routes
 type UserRouteConfig struct {
-	UserController *controllers.UserController
+	UserController *userControllers.UserController
+	// PreferencesController serves the preferences; the placeholders answer when it is nil
+	PreferencesController *userControllers.PreferencesController
+	// AdminController serves account management; the routes are left out when it is nil
+	AdminController *userControllers.AdminUserController
+	// ActivityController serves the activity feeds; the routes are left out when it is nil
+	ActivityController *userControllers.ActivityController
+	// ConnectionController serves follows and timelines; the placeholders answer when it is
+	// nil
+	ConnectionController *userControllers.ConnectionController
+	// AuthEventController serves the authentication audit trail; the routes are left out
+	// when it is nil
+	AuthEventController *userControllers.AuthEventController
+	// AvatarController serves avatar uploads; the routes are left out when it is nil
+	AvatarController *userControllers.AvatarController
+	// SessionController serves login and the session list; the login placeholder answers
+	// and the other routes are left out when it is nil
+	SessionController *userControllers.SessionController
+	// NotificationController serves the inbox; the placeholders answer when it is nil
+	NotificationController *userControllers.NotificationController
+	// ImportController serves the bulk import; the placeholder answers when it is nil
+	ImportController *userControllers.UserImportController
+	// ExportController serves the bulk export; the placeholder answers when it is nil
+	ExportController *userControllers.UserExportController
+	// AnalyticsController serves the user analytics; the placeholders answer when it is nil
+	AnalyticsController *userControllers.UserAnalyticsController
+	// LoginThrottle limits sign-in and password recovery attempts; nil lets them all through
+	LoginThrottle *middleware.LoginThrottle
+	// Captcha guards registration and password recovery; nil requires no challenge
+	Captcha        captcha.Verifier
 	AuthMiddleware *middleware.AuthMiddleware
 }
 
@@ -32,10 +62,15 @@ func registerPublicRoutes(rg *gin.RouterGroup, config UserRouteConfig) {
 		// Authentication routes
 		auth := public.Group("/auth")
 		{
-			auth.POST("/register", config.UserController.CreateUser)
-			auth.POST("/login", handleLogin)                    // Placeholder
-			auth.POST("/forgot-password", handleForgotPassword) // Placeholder
-			auth.POST("/reset-password", handleResetPassword)   // Placeholder
+			auth.POST("/register", middleware.RequireCaptcha(config.Captcha), config.UserController.CreateUser)
+			if config.SessionController != nil {
+				auth.POST("/login", config.LoginThrottle.Limit("login"), config.SessionController.Login)
+			} else {
+				auth.POST("/login", config.LoginThrottle.Limit("login"), handleLogin) // Placeholder
+			}
+			auth.POST("/forgot-password", config.LoginThrottle.Limit("forgot-password"), middleware.RequireCaptcha(config.Captcha),
+				handleForgotPassword) // Placeholder
+			auth.POST("/reset-password", middleware.RequireCaptcha(config.Captcha), handleResetPassword) // Placeholder
 		}
 
 		// Public user information
@@ -54,26 +89,62 @@ func registerProtectedRoutes(rg *gin.RouterGroup, config UserRouteConfig) {
 		// Current user routes
 		me := protected.Group("/me")
 		{
-			me.GET("", handleGetCurrentUser) // Placeholder
-			me.PUT("", config.UserController.UpdateUser)
-			me.DELETE("", config.UserController.DeleteUser)
-			me.GET("/profile", handleGetProfile)    // Placeholder
-			me.PUT("/profile", handleUpdateProfile) // Placeholder
+			me.GET("", config.UserController.GetCurrentUser)
+			me.PUT("", config.UserController.UpdateCurrentUser)
+			me.GET("/profile", config.UserController.GetCurrentUser)
+			me.PUT("/profile", config.UserController.UpdateCurrentUser)
+			if config.AvatarController != nil {
+				me.PUT("/avatar", config.AvatarController.UploadAvatar)
+				me.PUT("/profile/avatar", config.AvatarController.UploadAvatar)
+			}
+			if config.ActivityController != nil {
+				me.GET("/activity", config.ActivityController.GetOwnActivity)
+			}
+			if config.ConnectionController != nil {
+				me.GET("/timeline", config.ConnectionController.GetOwnTimeline)
+			}
+			if config.SessionController != nil {
+				me.GET("/sessions", config.SessionController.ListSessions)
+				me.DELETE("/sessions", config.SessionController.RevokeAllSessions)
+				me.DELETE("/sessions/:id", config.SessionController.RevokeSession)
+			}
+			if config.AuthEventController != nil {
+				me.GET("/auth-events", config.AuthEventController.GetOwnAuthEvents)
+			}
+		}
+
+		// Sign-out of the session the request is made with
+		if config.SessionController != nil {
+			protected.POST("/auth/logout", config.SessionController.Logout)
 		}
 
 		// User preferences
 		preferences := protected.Group("/me/preferences")
 		{
-			preferences.GET("", handleGetPreferences)    // Placeholder
-			preferences.PUT("", handleUpdatePreferences) // Placeholder
+			if config.PreferencesController != nil {
+				preferences.GET("", config.PreferencesController.GetPreferences)
+				preferences.PUT("", config.PreferencesController.UpdatePreferences)
+			} else {
+				preferences.GET("", handleGetPreferences)    // Placeholder
+				preferences.PUT("", handleUpdatePreferences) // Placeholder
+			}
 		}
 
 		// User notifications
 		notifications := protected.Group("/me/notifications")
 		{
-			notifications.GET("", handleGetNotifications)          // Placeholder
-			notifications.PUT("/:id/read", handleMarkAsRead)       // Placeholder
-			notifications.DELETE("/:id", handleDeleteNotification) // Placeholder
+			if config.NotificationController != nil {
+				notifications.GET("", config.NotificationController.GetNotifications)
+				notifications.GET("/unread-count", config.NotificationController.GetUnreadCount)
+				notifications.PUT("/read-all", config.NotificationController.MarkAllAsRead)
+				notifications.PUT("/read", config.NotificationController.MarkAllAsRead)
+				notifications.PUT("/:id/read", config.NotificationController.MarkAsRead)
+				notifications.DELETE("/:id", config.NotificationController.DeleteNotification)
+			} else {
+				notifications.GET("", handleGetNotifications)          // Placeholder
+				notifications.PUT("/:id/read", handleMarkAsRead)       // Placeholder
+				notifications.DELETE("/:id", handleDeleteNotification) // Placeholder
+			}
 		}
 	}
 }
@@ -81,34 +152,60 @@ func registerProtectedRoutes(rg *gin.RouterGroup, config UserRouteConfig) {
 // registerAdminRoutes sets up admin-only user routes
 func registerAdminRoutes(rg *gin.RouterGroup, config UserRouteConfig) {
 	admin := rg.Group("/admin/users")
-	// Apply authentication and admin role middleware
+	// Apply authentication and permission middleware
 	if config.AuthMiddleware != nil {
 		admin.Use(config.AuthMiddleware.RequireAuth())
-		admin.Use(config.AuthMiddleware.RequireRole("admin"))
+		admin.Use(config.AuthMiddleware.RequirePermission("users", "manage"))
 	}
 	{
 		// User management
 		admin.GET("", config.UserController.GetUsers)
 		admin.GET("/:id", config.UserController.GetUser)
-		admin.PUT("/:id", handleAdminUpdateUser)     // Placeholder
-		admin.DELETE("/:id", handleAdminDeleteUser)  // Placeholder
-		admin.PUT("/:id/status", handleUpdateStatus) // Placeholder
-		admin.PUT("/:id/role", handleUpdateRole)     // Placeholder
+		if config.AdminController != nil {
+			admin.PUT("/:id", config.AdminController.UpdateUser)
+			admin.DELETE("/:id", config.AdminController.DeleteUser)
+			admin.POST("/:id/restore", config.AdminController.RestoreUser)
+			admin.DELETE("/:id/purge", config.AdminController.PurgeUser)
+			admin.PUT("/:id/status", config.AdminController.UpdateStatus)
+			admin.PUT("/:id/role", config.AdminController.UpdateRole)
+			admin.POST("/:id/password-reset", config.AdminController.ForcePasswordReset)
+			admin.GET("/:id/audit", config.AdminController.GetAuditLog)
+		}
+		if config.ActivityController != nil {
+			admin.GET("/:id/activity", config.ActivityController.GetUserActivity)
+		}
+		if config.AuthEventController != nil {
+			admin.GET("/auth-events", config.AuthEventController.GetAuthEvents)
+		}
 
 		// Bulk operations
 		bulk := admin.Group("/bulk")
 		{
-			bulk.POST("/export", handleBulkExport)   // Placeholder
-			bulk.POST("/import", handleBulkImport)   // Placeholder
+			if config.ExportController != nil {
+				bulk.POST("/export", config.ExportController.ExportUsers)
+			} else {
+				bulk.POST("/export", handleBulkExport) // Placeholder
+			}
+			if config.ImportController != nil {
+				bulk.POST("/import", config.ImportController.ImportUsers)
+			} else {
+				bulk.POST("/import", handleBulkImport) // Placeholder
+			}
 			bulk.DELETE("/delete", handleBulkDelete) // Placeholder
 		}
 
 		// User analytics
 		analytics := admin.Group("/analytics")
 		{
-			analytics.GET("/stats", handleUserStats)       // Placeholder
-			analytics.GET("/activity", handleUserActivity) // Placeholder
-			analytics.GET("/reports", handleUserReports)   // Placeholder
+			if config.AnalyticsController != nil {
+				analytics.GET("/stats", config.AnalyticsController.GetStats)
+				analytics.GET("/activity", config.AnalyticsController.GetActivity)
+				analytics.GET("/reports", config.AnalyticsController.GetReports)
+			} else {
+				analytics.GET("/stats", handleUserStats)       // Placeholder
+				analytics.GET("/activity", handleUserActivity) // Placeholder
+				analytics.GET("/reports", handleUserReports)   // Placeholder
+			}
 		}
 	}
 }
@@ -123,8 +220,19 @@ func RegisterV2Routes(rg *gin.RouterGroup, config UserRouteConfig) {
 		v2Users.GET("", handleGetUsersV2)    // Placeholder
 
 		// New V2 features
-		v2Users.GET("/:id/timeline", handleUserTimeline)       // Placeholder
-		v2Users.GET("/:id/connections", handleUserConnections) // Placeholder
+		if config.ConnectionController != nil {
+			connections := v2Users.Group("/:id")
+			if config.AuthMiddleware != nil {
+				connections.Use(config.AuthMiddleware.RequireAuth())
+			}
+			connections.GET("/timeline", config.ConnectionController.GetUserTimeline)
+			connections.GET("/connections", config.ConnectionController.ListConnections)
+			connections.POST("/follow", config.ConnectionController.Follow)
+			connections.DELETE("/follow", config.ConnectionController.Unfollow)
+		} else {
+			v2Users.GET("/:id/timeline", handleUserTimeline)       // Placeholder
+			v2Users.GET("/:id/connections", handleUserConnections) // Placeholder
+		}
 	}
 }
 
@@ -145,18 +253,6 @@ func handleGetPublicProfile(c *gin.Context) {
 	c.JSON(200, gin.H{"message": "Get public profile endpoint"})
 }
 
-func handleGetCurrentUser(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Get current user endpoint"})
-}
-
-func handleGetProfile(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Get profile endpoint"})
-}
-
-func handleUpdateProfile(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Update profile endpoint"})
-}
-
 func handleGetPreferences(c *gin.Context) {
 	c.JSON(200, gin.H{"message": "Get preferences endpoint"})
 }
@@ -177,22 +273,6 @@ func handleDeleteNotification(c *gin.Context) {
 	c.JSON(200, gin.H{"message": "Delete notification endpoint"})
 }
 
-func handleAdminUpdateUser(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Admin update user endpoint"})
-}
-
-func handleAdminDeleteUser(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Admin delete user endpoint"})
-}
-
-func handleUpdateStatus(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Update status endpoint"})
-}
-
-func handleUpdateRole(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Update role endpoint"})
-}
-
 func handleBulkExport(c *gin.Context) {
 	c.JSON(200, gin.H{"message": "Bulk export endpoint"})
 }
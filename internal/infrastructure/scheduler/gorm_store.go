@@ -0,0 +1,168 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JobRunModel stores the last run of a scheduled job
+type JobRunModel struct {
+	Name       string    `gorm:"primaryKey;size:128"`
+	Status     string    `gorm:"not null;size:32"`
+	StartedAt  time.Time `gorm:"not null"`
+	FinishedAt *time.Time
+	DurationMs int64     `gorm:"not null;default:0"`
+	Error      string    `gorm:"size:1024"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName sets the table name for GORM
+func (JobRunModel) TableName() string {
+	return "scheduled_job_runs"
+}
+
+// JobRunHistoryModel stores one run of a scheduled job
+type JobRunHistoryModel struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	Name       string    `gorm:"not null;size:128;index:idx_job_run_history_name,priority:1"`
+	Status     string    `gorm:"not null;size:32"`
+	StartedAt  time.Time `gorm:"not null;index:idx_job_run_history_name,priority:2;index"`
+	FinishedAt *time.Time
+	DurationMs int64  `gorm:"not null;default:0"`
+	Error      string `gorm:"size:1024"`
+}
+
+// TableName sets the table name for GORM
+func (JobRunHistoryModel) TableName() string {
+	return "scheduled_job_run_history"
+}
+
+// toRun converts the model to a Run
+func (m *JobRunHistoryModel) toRun() Run {
+	return Run{
+		ID:         m.ID,
+		StartedAt:  m.StartedAt,
+		FinishedAt: m.FinishedAt,
+		Duration:   time.Duration(m.DurationMs) * time.Millisecond,
+		Status:     Status(m.Status),
+		Error:      m.Error,
+	}
+}
+
+// maxErrorLength matches the size of the error column
+const maxErrorLength = 1024
+
+// GormStore persists the last run of every job in the scheduled_job_runs table and all runs
+// in scheduled_job_run_history
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore creates a store on db; JobRunModel and JobRunHistoryModel must be migrated
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{db: db}
+}
+
+// LoadRuns returns the last run of every job that has run before
+func (s *GormStore) LoadRuns(ctx context.Context) (map[string]Run, error) {
+	var rows []JobRunModel
+	if err := s.db.WithContext(ctx).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	runs := make(map[string]Run, len(rows))
+	for _, row := range rows {
+		runs[row.Name] = Run{
+			StartedAt:  row.StartedAt,
+			FinishedAt: row.FinishedAt,
+			Duration:   time.Duration(row.DurationMs) * time.Millisecond,
+			Status:     Status(row.Status),
+			Error:      row.Error,
+		}
+	}
+	return runs, nil
+}
+
+// SaveRun replaces the last run of the named job
+func (s *GormStore) SaveRun(ctx context.Context, name string, run Run) error {
+	row := JobRunModel{
+		Name:       name,
+		Status:     string(run.Status),
+		StartedAt:  run.StartedAt,
+		FinishedAt: run.FinishedAt,
+		DurationMs: run.Duration.Milliseconds(),
+		Error:      truncate(run.Error),
+	}
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&row).Error
+}
+
+// AppendRun adds a run of the named job to its history
+func (s *GormStore) AppendRun(ctx context.Context, name string, run Run) (uint, error) {
+	row := JobRunHistoryModel{
+		Name:       name,
+		Status:     string(run.Status),
+		StartedAt:  run.StartedAt,
+		FinishedAt: run.FinishedAt,
+		DurationMs: run.Duration.Milliseconds(),
+		Error:      truncate(run.Error),
+	}
+	if err := s.db.WithContext(ctx).Create(&row).Error; err != nil {
+		return 0, err
+	}
+	return row.ID, nil
+}
+
+// UpdateRun records the outcome of a run in the history
+func (s *GormStore) UpdateRun(ctx context.Context, name string, run Run) error {
+	return s.db.WithContext(ctx).Model(&JobRunHistoryModel{}).
+		Where("id = ? AND name = ?", run.ID, name).
+		Updates(map[string]interface{}{
+			"status":      string(run.Status),
+			"finished_at": run.FinishedAt,
+			"duration_ms": run.Duration.Milliseconds(),
+			"error":       truncate(run.Error),
+		}).Error
+}
+
+// ListRuns returns the history of the named job, most recent first
+func (s *GormStore) ListRuns(ctx context.Context, name string, limit, offset int) ([]Run, int64, error) {
+	query := s.db.WithContext(ctx).Model(&JobRunHistoryModel{}).Where("name = ?", name)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []JobRunHistoryModel
+	if err := query.Order("started_at DESC, id DESC").Limit(limit).Offset(offset).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	runs := make([]Run, len(rows))
+	for i := range rows {
+		runs[i] = rows[i].toRun()
+	}
+	return runs, total, nil
+}
+
+// PurgeRuns deletes the runs started before the given time from the history and returns how
+// many; the last run of every job is kept in scheduled_job_runs
+func (s *GormStore) PurgeRuns(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Where("started_at < ?", before).Delete(&JobRunHistoryModel{})
+	return result.RowsAffected, result.Error
+}
+
+// truncate limits an error message to the error columns
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength]
+	}
+	return s
+}
+
+var (
+	_ Store   = (*GormStore)(nil)
+	_ History = (*GormStore)(nil)
+)
@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	jobRunsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduled_job_runs_total",
+		Help: "Scheduled job runs by job and status: succeeded, failed or timed_out.",
+	}, []string{"job", "status"})
+
+	jobRunDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "scheduled_job_duration_seconds",
+		Help:    "Scheduled job run duration by job.",
+		Buckets: []float64{0.01, 0.1, 0.5, 1, 5, 15, 60, 300, 900},
+	}, []string{"job"})
+)
+
+// observeRun records the outcome of a finished run
+func observeRun(name string, run Run) {
+	jobRunsTotal.WithLabelValues(name, string(run.Status)).Inc()
+	jobRunDuration.WithLabelValues(name).Observe(run.Duration.Seconds())
+}
@@ -0,0 +1,432 @@
+// Package scheduler runs periodic background jobs on cron expressions
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/locks"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// defaultTimeout bounds jobs that set no Timeout when Options.DefaultTimeout is zero
+	defaultTimeout = 5 * time.Minute
+	// idleWait is how long the loop sleeps with no jobs registered
+	idleWait = time.Hour
+	// lockTTL is how long a replica that died running a job keeps the others from running it
+	lockTTL = 30 * time.Second
+	// lockPrefix prefixes the name of a job in the key of its lock
+	lockPrefix = "scheduler:"
+)
+
+// Status is the outcome of a job run
+type Status string
+
+const (
+	// StatusRunning marks a run that has started and not finished, e.g. cut short by a crash
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusTimedOut  Status = "timed_out"
+)
+
+// Job is a unit of periodic work
+type Job struct {
+	// Name identifies the job in logs, metrics, persisted runs and the admin listing
+	Name string
+	// Module names the module declaring the job, set when the module registry registers it;
+	// empty for jobs of the application itself
+	Module string
+	// Schedule is a standard 5-field cron expression or a descriptor such as @hourly or @every 10m
+	Schedule string
+	// Timeout bounds a single run; zero uses Options.DefaultTimeout
+	Timeout time.Duration
+	// Run does the work; it must return promptly once ctx is done
+	Run func(ctx context.Context) error
+}
+
+// Run records one execution of a job
+type Run struct {
+	// ID identifies the run in the job's history; 0 without one
+	ID         uint          `json:"id,omitempty"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt *time.Time    `json:"finished_at,omitempty"`
+	Duration   time.Duration `json:"duration_ns"`
+	Status     Status        `json:"status"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// JobInfo describes a registered job for the admin listing
+type JobInfo struct {
+	Name     string    `json:"name"`
+	Module   string    `json:"module,omitempty"`
+	Schedule string    `json:"schedule"`
+	Timeout  string    `json:"timeout"`
+	Running  bool      `json:"running"`
+	NextRun  time.Time `json:"next_run"`
+	LastRun  *Run      `json:"last_run,omitempty"`
+	// Skipped counts ticks dropped because the previous run was still going, here or on
+	// another replica
+	Skipped uint64 `json:"skipped"`
+}
+
+// Store persists the last run of every job so the status survives restarts
+type Store interface {
+	LoadRuns(ctx context.Context) (map[string]Run, error)
+	SaveRun(ctx context.Context, name string, run Run) error
+}
+
+// History keeps every run of the jobs rather than the last one only; a Store implementing it
+// records runs as they start and finish
+type History interface {
+	// AppendRun records a run that started and returns its ID
+	AppendRun(ctx context.Context, name string, run Run) (uint, error)
+	// UpdateRun records the outcome of the run with run.ID
+	UpdateRun(ctx context.Context, name string, run Run) error
+	// ListRuns returns the runs of the named job, most recent first, and how many there are
+	ListRuns(ctx context.Context, name string, limit, offset int) ([]Run, int64, error)
+}
+
+// Leader reports whether this replica should run the jobs, e.g. a *leader.Elector
+type Leader interface {
+	IsLeader() bool
+}
+
+// Options configures a Scheduler
+type Options struct {
+	// DefaultTimeout bounds jobs that set no Timeout (5m)
+	DefaultTimeout time.Duration
+	// Leader, when set, limits runs to the replica it elects so every tick runs once
+	// cluster-wide; nil runs every tick on this replica
+	Leader Leader
+	// Locks, when set, holds a lock per job while it runs, so a job never runs on two replicas
+	// at once, e.g. while leadership changes hands; nil leaves that to Leader
+	Locks locks.LockManager
+}
+
+var (
+	// ErrDuplicateJob is returned when registering a job name twice
+	ErrDuplicateJob = errors.New("scheduler: job already registered")
+	// ErrJobNotFound is returned for a job that is not registered
+	ErrJobNotFound = errors.New("scheduler: job not found")
+)
+
+// Scheduler runs registered jobs on their schedules
+// A job never overlaps with itself: a tick that fires while the previous run is
+// still going is skipped
+type Scheduler struct {
+	store Store
+	// history is store when it keeps every run
+	history History
+	opts    Options
+
+	mu      sync.Mutex
+	entries []*entry
+	wake    chan struct{}
+	running sync.WaitGroup
+}
+
+// entry is a registered job and its runtime state, guarded by Scheduler.mu
+type entry struct {
+	job      Job
+	schedule cron.Schedule
+	next     time.Time
+	last     *Run
+	active   bool
+	skipped  uint64
+}
+
+// New creates a scheduler persisting runs to store, and every run when it is a History; a nil
+// store keeps the last runs in memory only
+func New(store Store, opts Options) *Scheduler {
+	if opts.DefaultTimeout <= 0 {
+		opts.DefaultTimeout = defaultTimeout
+	}
+	history, _ := store.(History)
+	return &Scheduler{
+		store:   store,
+		history: history,
+		opts:    opts,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Register adds a job; it fails for an invalid schedule or a name already in use
+// Jobs registered after Start are picked up on the next tick
+func (s *Scheduler) Register(job Job) error {
+	schedule, err := cron.ParseStandard(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("scheduler: invalid schedule %q for job %s: %w", job.Schedule, job.Name, err)
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = s.opts.DefaultTimeout
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.entries {
+		if e.job.Name == job.Name {
+			return fmt.Errorf("%w: %s", ErrDuplicateJob, job.Name)
+		}
+	}
+	s.entries = append(s.entries, &entry{job: job, schedule: schedule, next: schedule.Next(time.Now())})
+	s.signal()
+	return nil
+}
+
+// Start loads the persisted runs and schedules the jobs until ctx is cancelled
+// It does not block; running jobs see ctx cancelled and Shutdown waits for them
+func (s *Scheduler) Start(ctx context.Context) {
+	if s.store != nil {
+		runs, err := s.store.LoadRuns(ctx)
+		if err != nil {
+			log.Printf("scheduler: failed to load job runs: %v", err)
+		}
+		s.mu.Lock()
+		for _, e := range s.entries {
+			if run, ok := runs[e.job.Name]; ok {
+				e.last = &run
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	go s.loop(ctx)
+}
+
+// loop sleeps until the earliest next run and starts every job that is due
+func (s *Scheduler) loop(ctx context.Context) {
+	for {
+		s.mu.Lock()
+		now := time.Now()
+		wait := time.Duration(-1)
+		for _, e := range s.entries {
+			if !e.next.After(now) {
+				s.trigger(ctx, e)
+				e.next = e.schedule.Next(now)
+			}
+			if d := e.next.Sub(now); wait < 0 || d < wait {
+				wait = d
+			}
+		}
+		s.mu.Unlock()
+
+		// With nothing registered, sleep until Register signals
+		if wait < 0 {
+			wait = idleWait
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// trigger starts a run of e unless this replica is not the leader or the previous
+// run is still going; s.mu must be held
+func (s *Scheduler) trigger(ctx context.Context, e *entry) {
+	if !s.IsLeader() {
+		return
+	}
+	if e.active {
+		e.skipped++
+		log.Printf("scheduler: skipping %s, previous run still in progress", e.job.Name)
+		return
+	}
+	e.active = true
+	s.running.Add(1)
+	go s.run(ctx, e)
+}
+
+// run executes one run of e holding its lock, skipping it when another replica holds it
+func (s *Scheduler) run(ctx context.Context, e *entry) {
+	defer s.running.Done()
+
+	err := locks.TryWithLock(ctx, s.opts.Locks, lockPrefix+e.job.Name, lockTTL, func(ctx context.Context) error {
+		s.execute(ctx, e)
+		return nil
+	})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e.active = false
+	switch {
+	case errors.Is(err, locks.ErrNotObtained):
+		e.skipped++
+		log.Printf("scheduler: skipping %s, running on another replica", e.job.Name)
+	case err != nil:
+		log.Printf("scheduler: job %s: %v", e.job.Name, err)
+	}
+}
+
+// execute runs e within its timeout and records the outcome
+func (s *Scheduler) execute(ctx context.Context, e *entry) {
+	run := Run{StartedAt: time.Now(), Status: StatusRunning}
+	s.record(e, &run)
+
+	jobCtx, cancel := context.WithTimeout(ctx, e.job.Timeout)
+	err := e.job.Run(jobCtx)
+	timedOut := errors.Is(jobCtx.Err(), context.DeadlineExceeded)
+	cancel()
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.Duration = finished.Sub(run.StartedAt)
+	switch {
+	case err == nil:
+		run.Status = StatusSucceeded
+	case timedOut:
+		run.Status = StatusTimedOut
+		run.Error = err.Error()
+	default:
+		run.Status = StatusFailed
+		run.Error = err.Error()
+	}
+	if err != nil {
+		log.Printf("scheduler: job %s %s after %s: %v", e.job.Name, run.Status, run.Duration.Round(time.Millisecond), err)
+	}
+	observeRun(e.job.Name, run)
+	s.record(e, &run)
+}
+
+// record keeps run as the last run of e and persists it, adding a run that starts to the
+// history, which sets its ID, and updating one that finished there
+// The writes outlive ctx so the outcome of a run cut short by shutdown is kept
+func (s *Scheduler) record(e *entry, run *Run) {
+	ctx := context.Background()
+	if s.history != nil {
+		var err error
+		if run.ID == 0 {
+			run.ID, err = s.history.AppendRun(ctx, e.job.Name, *run)
+		} else {
+			err = s.history.UpdateRun(ctx, e.job.Name, *run)
+		}
+		if err != nil {
+			log.Printf("scheduler: failed to record run of %s in its history: %v", e.job.Name, err)
+		}
+	}
+
+	last := *run
+	s.mu.Lock()
+	e.last = &last
+	s.mu.Unlock()
+
+	if s.store == nil {
+		return
+	}
+	if err := s.store.SaveRun(ctx, e.job.Name, last); err != nil {
+		log.Printf("scheduler: failed to save run of %s: %v", e.job.Name, err)
+	}
+}
+
+// signal wakes the loop to recompute the next tick
+func (s *Scheduler) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// IsLeader reports whether this replica runs the jobs
+func (s *Scheduler) IsLeader() bool {
+	return s.opts.Leader == nil || s.opts.Leader.IsLeader()
+}
+
+// Jobs lists the registered jobs sorted by name
+// Last runs are read from the store when there is one, so every replica lists the
+// runs made by whichever replica led at the time
+func (s *Scheduler) Jobs(ctx context.Context) []JobInfo {
+	var runs map[string]Run
+	if s.store != nil {
+		var err error
+		if runs, err = s.store.LoadRuns(ctx); err != nil {
+			log.Printf("scheduler: failed to load job runs: %v", err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]JobInfo, 0, len(s.entries))
+	for _, e := range s.entries {
+		info := JobInfo{
+			Name:     e.job.Name,
+			Module:   e.job.Module,
+			Schedule: e.job.Schedule,
+			Timeout:  e.job.Timeout.String(),
+			Running:  e.active,
+			NextRun:  e.next,
+			Skipped:  e.skipped,
+		}
+		if run, ok := runs[e.job.Name]; ok {
+			info.LastRun = &run
+		} else if e.last != nil {
+			last := *e.last
+			info.LastRun = &last
+		}
+		jobs = append(jobs, info)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+	return jobs
+}
+
+// Runs returns the runs of the named job, most recent first, and how many there are; without
+// a History only the last run is known
+func (s *Scheduler) Runs(ctx context.Context, name string, limit, offset int) ([]Run, int64, error) {
+	s.mu.Lock()
+	var found *entry
+	for _, e := range s.entries {
+		if e.job.Name == name {
+			found = e
+			break
+		}
+	}
+	var last *Run
+	if found != nil && found.last != nil {
+		run := *found.last
+		last = &run
+	}
+	s.mu.Unlock()
+	if found == nil {
+		return nil, 0, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+
+	if s.history != nil {
+		return s.history.ListRuns(ctx, name, limit, offset)
+	}
+	if last == nil {
+		return []Run{}, 0, nil
+	}
+	if offset > 0 {
+		return []Run{}, 1, nil
+	}
+	return []Run{*last}, 1, nil
+}
+
+// Shutdown waits for running jobs to return or ctx to be done, whichever comes first
+// Cancel the context passed to Start first so the jobs are asked to stop
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("scheduler: jobs still running: %w", ctx.Err())
+	}
+}
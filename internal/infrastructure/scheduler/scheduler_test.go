@@ -0,0 +1,345 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/locks"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+	"clean-arch-gin/internal/mocks"
+	"clean-arch-gin/internal/testutil/repotest"
+
+	"go.uber.org/mock/gomock"
+)
+
+// everySecond is the shortest schedule cron supports
+const everySecond = "@every 1s"
+
+// fixedLeader is a Leader that always answers the same
+type fixedLeader bool
+
+// IsLeader reports l
+func (l fixedLeader) IsLeader() bool {
+	return bool(l)
+}
+
+// newGormStore creates a store on SQLite with the run tables migrated
+func newGormStore(t *testing.T) *scheduler.GormStore {
+	t.Helper()
+	db := repotest.OpenSQLite(t)
+	if err := database.AutoMigrate(db, &scheduler.JobRunModel{}, &scheduler.JobRunHistoryModel{}); err != nil {
+		t.Fatalf("migrate job runs: %v", err)
+	}
+	return scheduler.NewGormStore(db)
+}
+
+// start starts s until the test ends, then waits for its jobs
+func start(t *testing.T, s *scheduler.Scheduler) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+	t.Cleanup(func() {
+		cancel()
+		shutdownCtx, done := context.WithTimeout(context.Background(), 5*time.Second)
+		defer done()
+		if err := s.Shutdown(shutdownCtx); err != nil {
+			t.Errorf("Shutdown() error = %v", err)
+		}
+	})
+}
+
+// job returns the listing of the named job
+func job(t *testing.T, s *scheduler.Scheduler, name string) scheduler.JobInfo {
+	t.Helper()
+	for _, info := range s.Jobs(context.Background()) {
+		if info.Name == name {
+			return info
+		}
+	}
+	t.Fatalf("job %s not listed", name)
+	return scheduler.JobInfo{}
+}
+
+// waitFor polls cond until it holds, failing the test after a few ticks
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(4 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	s := scheduler.New(nil, scheduler.Options{DefaultTimeout: time.Minute})
+	if err := s.Register(scheduler.Job{Name: "purge", Schedule: "@hourly", Run: func(context.Context) error { return nil }}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		job     scheduler.Job
+		wantErr string
+	}{
+		{"cron expression", scheduler.Job{Name: "report", Schedule: "0 3 * * *"}, ""},
+		{"descriptor", scheduler.Job{Name: "sweep", Schedule: "@every 10m"}, ""},
+		{"invalid schedule", scheduler.Job{Name: "broken", Schedule: "every day"}, "invalid schedule"},
+		{"seconds field", scheduler.Job{Name: "fast", Schedule: "* * * * * *"}, "invalid schedule"},
+		{"name in use", scheduler.Job{Name: "purge", Schedule: "@daily"}, scheduler.ErrDuplicateJob.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Register(tt.job)
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Register() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if info := job(t, s, "purge"); info.Timeout != time.Minute.String() || !info.NextRun.After(time.Now()) {
+		t.Errorf("purge = %+v, want the default timeout and a next run", info)
+	}
+	if _, _, err := s.Runs(context.Background(), "missing", 10, 0); !errors.Is(err, scheduler.ErrJobNotFound) {
+		t.Errorf("Runs() of an unknown job error = %v, want %v", err, scheduler.ErrJobNotFound)
+	}
+}
+
+func TestSchedulerRunsJobs(t *testing.T) {
+	failure := errors.New("upstream down")
+	tests := []struct {
+		name    string
+		leader  scheduler.Leader
+		heldBy  bool
+		timeout time.Duration
+		run     func(ctx context.Context) error
+		// wantStatus is empty when the job must not run
+		wantStatus scheduler.Status
+		wantError  string
+		// wantSkipped is set when ticks are skipped for the lock
+		wantSkipped bool
+	}{
+		{
+			name:       "succeeds without a leader",
+			run:        func(context.Context) error { return nil },
+			wantStatus: scheduler.StatusSucceeded,
+		},
+		{
+			name:       "succeeds on the leader",
+			leader:     fixedLeader(true),
+			run:        func(context.Context) error { return nil },
+			wantStatus: scheduler.StatusSucceeded,
+		},
+		{
+			name:       "fails",
+			run:        func(context.Context) error { return failure },
+			wantStatus: scheduler.StatusFailed,
+			wantError:  failure.Error(),
+		},
+		{
+			name:    "times out",
+			timeout: 10 * time.Millisecond,
+			run: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			wantStatus: scheduler.StatusTimedOut,
+			wantError:  context.DeadlineExceeded.Error(),
+		},
+		{
+			name:   "does not run on a follower",
+			leader: fixedLeader(false),
+			run:    func(context.Context) error { return nil },
+		},
+		{
+			name:        "skipped while another replica holds the lock",
+			heldBy:      true,
+			run:         func(context.Context) error { return nil },
+			wantSkipped: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := scheduler.Options{Leader: tt.leader}
+			if tt.heldBy {
+				lockManager := mocks.NewMockLockManager(gomock.NewController(t))
+				lockManager.EXPECT().TryObtain(gomock.Any(), "scheduler:job", gomock.Any()).Return(nil, locks.ErrNotObtained).AnyTimes()
+				opts.Locks = lockManager
+			}
+			store := newGormStore(t)
+			s := scheduler.New(store, opts)
+			var runs atomic.Int32
+			err := s.Register(scheduler.Job{Name: "job", Schedule: everySecond, Timeout: tt.timeout, Run: func(ctx context.Context) error {
+				runs.Add(1)
+				return tt.run(ctx)
+			}})
+			if err != nil {
+				t.Fatalf("Register() error = %v", err)
+			}
+			start(t, s)
+
+			if tt.wantStatus == "" {
+				if tt.wantSkipped {
+					waitFor(t, "a skipped tick", func() bool { return job(t, s, "job").Skipped > 0 })
+				} else {
+					time.Sleep(1500 * time.Millisecond)
+				}
+				if n := runs.Load(); n != 0 {
+					t.Errorf("job ran %d times, want none", n)
+				}
+				if info := job(t, s, "job"); info.LastRun != nil {
+					t.Errorf("LastRun = %+v, want none", info.LastRun)
+				}
+				return
+			}
+
+			waitFor(t, "a finished run", func() bool {
+				last := job(t, s, "job").LastRun
+				return last != nil && last.Status != scheduler.StatusRunning
+			})
+			last := job(t, s, "job").LastRun
+			if last.Status != tt.wantStatus || last.Error != tt.wantError || last.FinishedAt == nil {
+				t.Errorf("LastRun = %+v, want status %s and error %q", last, tt.wantStatus, tt.wantError)
+			}
+			history, total, err := store.ListRuns(context.Background(), "job", 10, 0)
+			if err != nil || total == 0 || history[0].Status == "" {
+				t.Errorf("ListRuns() = %+v, %d, %v; want the run recorded", history, total, err)
+			}
+		})
+	}
+}
+
+func TestSchedulerSkipsOverlappingRuns(t *testing.T) {
+	s := scheduler.New(nil, scheduler.Options{})
+	release := make(chan struct{})
+	var runs atomic.Int32
+	err := s.Register(scheduler.Job{Name: "slow", Schedule: everySecond, Run: func(ctx context.Context) error {
+		runs.Add(1)
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil
+	}})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	start(t, s)
+
+	waitFor(t, "the run to start", func() bool { return job(t, s, "slow").Running })
+	waitFor(t, "a skipped tick", func() bool { return job(t, s, "slow").Skipped > 0 })
+	if n := runs.Load(); n != 1 {
+		t.Errorf("job ran %d times while the first run was going, want 1", n)
+	}
+
+	close(release)
+	waitFor(t, "the run to finish", func() bool {
+		last := job(t, s, "slow").LastRun
+		return last != nil && last.Status == scheduler.StatusSucceeded
+	})
+	history, total, err := s.Runs(context.Background(), "slow", 10, 0)
+	if err != nil || total != 1 || len(history) != 1 {
+		t.Errorf("Runs() without a history = %+v, %d, %v; want the last run only", history, total, err)
+	}
+}
+
+func TestSchedulerLoadsPersistedRuns(t *testing.T) {
+	ctx := context.Background()
+	store := newGormStore(t)
+	finished := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	saved := scheduler.Run{StartedAt: finished.Add(-time.Second), FinishedAt: &finished, Duration: time.Second, Status: scheduler.StatusFailed, Error: "boom"}
+	if err := store.SaveRun(ctx, "report", saved); err != nil {
+		t.Fatalf("SaveRun() error = %v", err)
+	}
+
+	s := scheduler.New(store, scheduler.Options{Leader: fixedLeader(false)})
+	if err := s.Register(scheduler.Job{Name: "report", Schedule: "@daily", Run: func(context.Context) error { return nil }}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	start(t, s)
+
+	last := job(t, s, "report").LastRun
+	if last == nil || last.Status != saved.Status || last.Error != saved.Error || last.Duration != saved.Duration {
+		t.Errorf("LastRun = %+v, want the persisted %+v", last, saved)
+	}
+}
+
+func TestGormStoreHistory(t *testing.T) {
+	ctx := context.Background()
+	store := newGormStore(t)
+	base := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	var ids []uint
+	for i := 0; i < 3; i++ {
+		id, err := store.AppendRun(ctx, "report", scheduler.Run{StartedAt: base.Add(time.Duration(i) * time.Hour), Status: scheduler.StatusRunning})
+		if err != nil {
+			t.Fatalf("AppendRun() error = %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if _, err := store.AppendRun(ctx, "other", scheduler.Run{StartedAt: base, Status: scheduler.StatusSucceeded}); err != nil {
+		t.Fatalf("AppendRun() error = %v", err)
+	}
+	finished := base.Add(2*time.Hour + time.Minute)
+	longError := strings.Repeat("x", 2000)
+	err := store.UpdateRun(ctx, "report", scheduler.Run{ID: ids[2], FinishedAt: &finished, Duration: time.Minute, Status: scheduler.StatusFailed, Error: longError})
+	if err != nil {
+		t.Fatalf("UpdateRun() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		limit     int
+		offset    int
+		wantIDs   []uint
+		wantTotal int64
+	}{
+		{"most recent first", 10, 0, []uint{ids[2], ids[1], ids[0]}, 3},
+		{"first page", 2, 0, []uint{ids[2], ids[1]}, 3},
+		{"second page", 2, 2, []uint{ids[0]}, 3},
+		{"past the end", 2, 4, nil, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs, total, err := store.ListRuns(ctx, "report", tt.limit, tt.offset)
+			if err != nil {
+				t.Fatalf("ListRuns() error = %v", err)
+			}
+			var gotIDs []uint
+			for _, run := range runs {
+				gotIDs = append(gotIDs, run.ID)
+			}
+			if total != tt.wantTotal || len(gotIDs) != len(tt.wantIDs) {
+				t.Fatalf("ListRuns() = %v, %d; want %v, %d", gotIDs, total, tt.wantIDs, tt.wantTotal)
+			}
+			for i := range gotIDs {
+				if gotIDs[i] != tt.wantIDs[i] {
+					t.Errorf("ListRuns() = %v, want %v", gotIDs, tt.wantIDs)
+					break
+				}
+			}
+		})
+	}
+
+	runs, _, _ := store.ListRuns(ctx, "report", 1, 0)
+	if run := runs[0]; run.Status != scheduler.StatusFailed || run.Duration != time.Minute || len(run.Error) != 1024 {
+		t.Errorf("updated run = %s in %s with a %d byte error, want failed in 1m with the error truncated", run.Status, run.Duration, len(run.Error))
+	}
+
+	purged, err := store.PurgeRuns(ctx, base.Add(90*time.Minute))
+	if err != nil || purged != 3 {
+		t.Errorf("PurgeRuns() = %d, %v; want the 3 runs started before", purged, err)
+	}
+	if _, total, _ := store.ListRuns(ctx, "report", 10, 0); total != 1 {
+		t.Errorf("%d runs of report left, want 1", total)
+	}
+}
@@ -0,0 +1,72 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"clean-arch-gin/internal/domain/shared/search"
+	"clean-arch-gin/internal/infrastructure/breaker"
+	"clean-arch-gin/internal/infrastructure/config"
+)
+
+// BreakerName names the search engine's circuit breaker in errors and metrics
+const BreakerName = "search"
+
+// NewCircuitBreaker creates the breaker guarding the search engine; only its being
+// unavailable counts as a failure, not the queries it rejects
+func NewCircuitBreaker(settings config.BreakerSettings) *breaker.CircuitBreaker {
+	return breaker.New(breaker.Settings{
+		Name:        BreakerName,
+		MaxRequests: uint32(settings.HalfOpenRequests),
+		Timeout:     settings.OpenTimeout,
+		ReadyToTrip: breaker.ConsecutiveFailures(uint32(settings.Failures)),
+		IsSuccessful: func(err error) bool {
+			return !errors.Is(err, search.ErrUnavailable)
+		},
+	})
+}
+
+// engineBreaker guards an Engine with a circuit breaker
+type engineBreaker struct {
+	engine search.Engine
+	cb     *breaker.CircuitBreaker
+}
+
+// NewEngineWithBreaker wraps engine so calls go through cb; calls it rejects fail with
+// search.ErrUnavailable
+func NewEngineWithBreaker(engine search.Engine, cb *breaker.CircuitBreaker) search.Engine {
+	return &engineBreaker{engine: engine, cb: cb}
+}
+
+// execute runs fn through the breaker
+func (e *engineBreaker) execute(fn func() error) error {
+	err := e.cb.Execute(fn)
+	if breaker.IsUnavailable(err) {
+		return fmt.Errorf("%w: %v", search.ErrUnavailable, err)
+	}
+	return err
+}
+
+// Index indexes doc through the breaker
+func (e *engineBreaker) Index(ctx context.Context, index string, doc search.Document) error {
+	return e.execute(func() error {
+		return e.engine.Index(ctx, index, doc)
+	})
+}
+
+// Delete deletes the document through the breaker
+func (e *engineBreaker) Delete(ctx context.Context, index, id string) error {
+	return e.execute(func() error {
+		return e.engine.Delete(ctx, index, id)
+	})
+}
+
+// Search searches through the breaker
+func (e *engineBreaker) Search(ctx context.Context, query search.Query) (hits []search.Hit, total int64, err error) {
+	err = e.execute(func() error {
+		hits, total, err = e.engine.Search(ctx, query)
+		return err
+	})
+	return hits, total, err
+}
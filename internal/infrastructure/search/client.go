@@ -0,0 +1,275 @@
+// Package search implements the search ports on Elasticsearch and OpenSearch, whose REST
+// APIs agree on everything used here, and manages their indexes
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/search"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+)
+
+// Every index is reached through an alias named after it, pointing at a concrete index
+// <alias>-<timestamp> so reindexing can swap in a rebuilt one; documents carry the fields
+// below besides those of their mapping
+const (
+	// idField holds the document's ID, sorting hits of equal score
+	idField = "id"
+	// tenantField holds the tenant owning the document
+	tenantField = "tenant_id"
+	// textField collects the text fields, which queries match
+	textField = "search_text"
+)
+
+// defaultTimeout bounds a call to the engine unless configured otherwise
+const defaultTimeout = 5 * time.Second
+
+// Config locates the cluster
+type Config struct {
+	// URL is the base of the REST API, e.g. http://localhost:9200
+	URL      string
+	Username string
+	Password string
+	// IndexPrefix is prepended to the names of indexes, e.g. to share a cluster between
+	// environments
+	IndexPrefix string
+	Timeout     time.Duration
+}
+
+// Client speaks to an Elasticsearch or OpenSearch cluster, implementing search.Engine
+type Client struct {
+	http   *http.Client
+	url    string
+	config Config
+}
+
+var _ search.Engine = (*Client)(nil)
+
+// New creates a client of the cluster at config.URL
+func New(config Config) (*Client, error) {
+	if config.URL == "" {
+		return nil, errors.New("search engine URL is required")
+	}
+	if _, err := url.ParseRequestURI(config.URL); err != nil {
+		return nil, fmt.Errorf("invalid search engine URL: %w", err)
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Client{
+		http:   &http.Client{Timeout: timeout},
+		url:    strings.TrimSuffix(config.URL, "/"),
+		config: config,
+	}, nil
+}
+
+// alias is the name index is reached by
+func (c *Client) alias(index string) string {
+	return c.config.IndexPrefix + index
+}
+
+// Index creates or replaces doc in index
+func (c *Client) Index(ctx context.Context, index string, doc search.Document) error {
+	path := "/" + url.PathEscape(c.alias(index)) + "/_doc/" + url.PathEscape(doc.ID)
+	return c.do(ctx, http.MethodPut, path, source(doc), nil)
+}
+
+// Delete removes the document id from index, succeeding when there is none
+func (c *Client) Delete(ctx context.Context, index, id string) error {
+	path := "/" + url.PathEscape(c.alias(index)) + "/_doc/" + url.PathEscape(id)
+	err := c.do(ctx, http.MethodDelete, path, nil, nil)
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.status == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// searchResponse is the part of a search response read
+type searchResponse struct {
+	Hits struct {
+		Total json.RawMessage `json:"total"`
+		Hits  []struct {
+			ID    string  `json:"_id"`
+			Score float64 `json:"_score"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// Search matches every term of query by prefix in the text fields, in the tenant of ctx
+// when it acts for one
+func (c *Client) Search(ctx context.Context, query search.Query) ([]search.Hit, int64, error) {
+	filters := []interface{}{}
+	if tenantID, ok := tenancy.FromContext(ctx); ok {
+		filters = append(filters, term(tenantField, tenantID))
+	}
+	for field, value := range query.Filters {
+		filters = append(filters, term(field, value))
+	}
+	must := []interface{}{}
+	if len(query.Terms) > 0 {
+		must = append(must, map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":    strings.Join(query.Terms, " "),
+				"type":     "bool_prefix",
+				"operator": "and",
+				"fields":   []string{textField},
+			},
+		})
+	}
+	body := map[string]interface{}{
+		"from":             query.Offset,
+		"size":             query.Limit,
+		"track_total_hits": true,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{"must": must, "filter": filters},
+		},
+		"sort": []interface{}{"_score", map[string]string{idField: "asc"}},
+	}
+
+	var resp searchResponse
+	path := "/" + url.PathEscape(c.alias(query.Index)) + "/_search"
+	if err := c.do(ctx, http.MethodPost, path, body, &resp); err != nil {
+		return nil, 0, err
+	}
+	total, err := parseTotal(resp.Hits.Total)
+	if err != nil {
+		return nil, 0, err
+	}
+	hits := make([]search.Hit, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		hits = append(hits, search.Hit{ID: hit.ID, Score: hit.Score})
+	}
+	return hits, total, nil
+}
+
+// parseTotal reads the total of a search response, an object since Elasticsearch 7 and a
+// number before
+func parseTotal(raw json.RawMessage) (int64, error) {
+	var total struct {
+		Value int64 `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &total); err == nil {
+		return total.Value, nil
+	}
+	var count int64
+	if err := json.Unmarshal(raw, &count); err != nil {
+		return 0, fmt.Errorf("failed to read search total: %w", err)
+	}
+	return count, nil
+}
+
+// term is a filter matching field exactly
+func term(field string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{"term": map[string]interface{}{field: value}}
+}
+
+// source is the body doc is indexed with
+func source(doc search.Document) map[string]interface{} {
+	body := make(map[string]interface{}, len(doc.Fields)+2)
+	for field, value := range doc.Fields {
+		body[field] = value
+	}
+	body[idField] = doc.ID
+	body[tenantField] = doc.TenantID
+	return body
+}
+
+// apiError is an error response of the engine
+type apiError struct {
+	status int
+	reason string
+}
+
+// Error describes the response
+func (e *apiError) Error() string {
+	return fmt.Sprintf("search engine responded %d: %s", e.status, e.reason)
+}
+
+// Unwrap returns search.ErrUnavailable for server errors and throttled calls
+func (e *apiError) Unwrap() error {
+	if e.status >= http.StatusInternalServerError || e.status == http.StatusTooManyRequests {
+		return search.ErrUnavailable
+	}
+	return nil
+}
+
+// do calls the API with body encoded as JSON, or as is when it is a []byte of NDJSON, and
+// decodes the response into out when it is not nil
+func (c *Client) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	contentType := "application/json"
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		reader = bytes.NewReader(b)
+		contentType = "application/x-ndjson"
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, reader)
+	if err != nil {
+		return err
+	}
+	if reader != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.config.Username != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", search.ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %v", search.ErrUnavailable, err)
+	}
+	if resp.StatusCode >= 300 {
+		return &apiError{status: resp.StatusCode, reason: errorReason(data)}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to read search engine response: %w", err)
+	}
+	return nil
+}
+
+// errorReason is the reason of an error response, or its body when it has none
+func errorReason(data []byte) string {
+	var resp struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(data, &resp); err == nil && len(resp.Error) > 0 {
+		var detail struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		}
+		if err := json.Unmarshal(resp.Error, &detail); err == nil && detail.Reason != "" {
+			return detail.Type + ": " + detail.Reason
+		}
+		if reason, err := strconv.Unquote(string(resp.Error)); err == nil {
+			return reason
+		}
+	}
+	return strings.TrimSpace(string(data))
+}
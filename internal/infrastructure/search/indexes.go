@@ -0,0 +1,177 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/search"
+)
+
+// bulkSize is how many documents a reindex sends per bulk request
+const bulkSize = 500
+
+// mappings is the mapping of the concrete indexes behind index
+func mappings(index search.Index) map[string]interface{} {
+	properties := map[string]interface{}{
+		idField:     map[string]string{"type": string(search.FieldKeyword)},
+		tenantField: map[string]string{"type": string(search.FieldLong)},
+		textField:   map[string]string{"type": string(search.FieldText)},
+	}
+	for field, fieldType := range index.Mapping {
+		property := map[string]interface{}{"type": string(fieldType)}
+		if fieldType == search.FieldText {
+			property["copy_to"] = textField
+		}
+		properties[field] = property
+	}
+	return map[string]interface{}{"properties": properties}
+}
+
+// EnsureIndexes creates the indexes that do not exist yet and adds the fields their
+// mappings gained to those that do; changing the type of a field takes a reindex
+func (c *Client) EnsureIndexes(ctx context.Context, indexes []search.Index) error {
+	for _, index := range indexes {
+		alias := c.alias(index.Name)
+		err := c.do(ctx, http.MethodHead, "/"+url.PathEscape(alias), nil, nil)
+		var apiErr *apiError
+		switch {
+		case errors.As(err, &apiErr) && apiErr.status == http.StatusNotFound:
+			if _, err := c.createIndex(ctx, index, true); err != nil {
+				return fmt.Errorf("failed to create search index %s: %w", alias, err)
+			}
+		case err != nil:
+			return fmt.Errorf("failed to look up search index %s: %w", alias, err)
+		default:
+			path := "/" + url.PathEscape(alias) + "/_mapping"
+			if err := c.do(ctx, http.MethodPut, path, mappings(index), nil); err != nil {
+				return fmt.Errorf("failed to update the mapping of search index %s, reindex it: %w", alias, err)
+			}
+		}
+	}
+	return nil
+}
+
+// createIndex creates a concrete index for index, behind its alias when aliased, and
+// returns its name
+func (c *Client) createIndex(ctx context.Context, index search.Index, aliased bool) (string, error) {
+	alias := c.alias(index.Name)
+	name := fmt.Sprintf("%s-%s", alias, time.Now().UTC().Format("20060102150405.000000000"))
+	body := map[string]interface{}{"mappings": mappings(index)}
+	if aliased {
+		body["aliases"] = map[string]interface{}{alias: map[string]interface{}{}}
+	}
+	return name, c.do(ctx, http.MethodPut, "/"+url.PathEscape(name), body, nil)
+}
+
+// Reindex rebuilds index from its source in a new concrete index, then swaps the alias over
+// to it and deletes the previous one, returning how many documents it holds; documents
+// indexed by events while it runs land in the previous index and wait for their next change
+func (c *Client) Reindex(ctx context.Context, index search.Index) (int, error) {
+	alias := c.alias(index.Name)
+	name, err := c.createIndex(ctx, index, false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create search index for %s: %w", alias, err)
+	}
+	drop := func() {
+		_ = c.do(context.Background(), http.MethodDelete, "/"+url.PathEscape(name), nil, nil)
+	}
+
+	count := 0
+	var batch bytes.Buffer
+	flush := func() error {
+		if batch.Len() == 0 {
+			return nil
+		}
+		defer batch.Reset()
+		return c.bulk(ctx, append([]byte(nil), batch.Bytes()...))
+	}
+	err = index.Source.Documents(ctx, func(doc search.Document) error {
+		action, _ := json.Marshal(map[string]interface{}{"index": map[string]string{"_index": name, "_id": doc.ID}})
+		body, err := json.Marshal(source(doc))
+		if err != nil {
+			return err
+		}
+		batch.Write(action)
+		batch.WriteByte('\n')
+		batch.Write(body)
+		batch.WriteByte('\n')
+		count++
+		if count%bulkSize == 0 {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err == nil {
+		err = c.do(ctx, http.MethodPost, "/"+url.PathEscape(name)+"/_refresh", nil, nil)
+	}
+	if err == nil {
+		err = c.swap(ctx, alias, name)
+	}
+	if err != nil {
+		drop()
+		return 0, fmt.Errorf("failed to reindex %s: %w", alias, err)
+	}
+	return count, nil
+}
+
+// swap points alias at name alone, deleting the indexes it pointed at, or the index named
+// alias itself that indexing before the alias existed created, in one atomic step
+func (c *Client) swap(ctx context.Context, alias, name string) error {
+	var previous map[string]json.RawMessage
+	err := c.do(ctx, http.MethodGet, "/"+url.PathEscape(alias)+"/_alias", nil, &previous)
+	var apiErr *apiError
+	if err != nil && !(errors.As(err, &apiErr) && apiErr.status == http.StatusNotFound) {
+		return err
+	}
+	old := make([]string, 0, len(previous))
+	for index := range previous {
+		old = append(old, index)
+	}
+	sort.Strings(old)
+
+	actions := make([]interface{}, 0, len(old)+1)
+	for _, index := range old {
+		actions = append(actions, map[string]interface{}{"remove_index": map[string]string{"index": index}})
+	}
+	actions = append(actions, map[string]interface{}{"add": map[string]string{"index": name, "alias": alias}})
+	return c.do(ctx, http.MethodPost, "/_aliases", map[string]interface{}{"actions": actions}, nil)
+}
+
+// bulkResponse is the part of a bulk response read
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string          `json:"_id"`
+		Status int             `json:"status"`
+		Error  json.RawMessage `json:"error"`
+	} `json:"items"`
+}
+
+// bulk sends the NDJSON actions of body, failing when any of them failed
+func (c *Client) bulk(ctx context.Context, body []byte) error {
+	var resp bulkResponse
+	if err := c.do(ctx, http.MethodPost, "/_bulk", body, &resp); err != nil {
+		return err
+	}
+	if !resp.Errors {
+		return nil
+	}
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Status >= 300 {
+				return fmt.Errorf("failed to index document %s: %s", result.ID, errorReason([]byte(`{"error":`+string(result.Error)+`}`)))
+			}
+		}
+	}
+	return errors.New("bulk indexing failed")
+}
@@ -0,0 +1,174 @@
+// Package sigv4 signs requests to AWS APIs with Signature Version 4
+package sigv4
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	algorithm  = "AWS4-HMAC-SHA256"
+	dateLayout = "20060102T150405Z"
+	// UnsignedPayload stands for the payload hash of requests whose body is not signed, such
+	// as presigned URLs and streamed uploads over TLS
+	UnsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// Credentials authenticate requests to AWS APIs; SessionToken is only set for temporary
+// credentials
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// Sign signs req, whose body hashes to payloadHash (see HashHex and UnsignedPayload), for
+// service in region at now. The host, content type, date and x-amz-* headers are signed
+func Sign(req *http.Request, payloadHash string, credentials Credentials, service, region string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(dateLayout)
+	req.Header.Set("X-Amz-Date", amzDate)
+	if credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "content-type" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	scope := scope(now, service, region)
+	canonicalRequest := strings.Join([]string{
+		req.Method, canonicalPath(req.URL), canonicalQuery(req.URL.Query()), canonicalHeaders.String(),
+		signedHeaders, payloadHash,
+	}, "\n")
+	req.Header.Set("Authorization", algorithm+" Credential="+credentials.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature(credentials, now, service, region, canonicalRequest))
+}
+
+// Presign returns u signed in its query string for method, valid from now for expires, as
+// used for download links that work without credentials. Only the host header is signed
+func Presign(method string, u *url.URL, credentials Credentials, service, region string, now time.Time, expires time.Duration) string {
+	now = now.UTC()
+	scope := scope(now, service, region)
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", algorithm)
+	query.Set("X-Amz-Credential", credentials.AccessKeyID+"/"+scope)
+	query.Set("X-Amz-Date", now.Format(dateLayout))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires/time.Second)))
+	query.Set("X-Amz-SignedHeaders", "host")
+	if credentials.SessionToken != "" {
+		query.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+
+	canonicalRequest := strings.Join([]string{
+		method, canonicalPath(u), canonicalQuery(query), "host:" + u.Host + "\n", "host", UnsignedPayload,
+	}, "\n")
+	signed := *u
+	signed.RawQuery = canonicalQuery(query) + "&X-Amz-Signature=" +
+		signature(credentials, now, service, region, canonicalRequest)
+	return signed.String()
+}
+
+// HashHex is the hex-encoded SHA-256 of data, the payload hash of a signed body
+func HashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// EscapePath escapes each segment of a slash-separated path the way AWS canonicalizes it,
+// e.g. for S3 keys
+func EscapePath(path string) string {
+	return escape(path, false)
+}
+
+// scope is the credential scope of a signature made at now
+func scope(now time.Time, service, region string) string {
+	return now.Format("20060102") + "/" + region + "/" + service + "/aws4_request"
+}
+
+// signature signs canonicalRequest with a key derived from the secret for the day of now
+func signature(credentials Credentials, now time.Time, service, region, canonicalRequest string) string {
+	scope := scope(now, service, region)
+	stringToSign := algorithm + "\n" + now.Format(dateLayout) + "\n" + scope + "\n" + HashHex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), now.Format("20060102"))
+	for _, part := range []string{region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalPath is the escaped path of u, / when empty
+func canonicalPath(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// canonicalQuery encodes query sorted by name, values escaped as AWS expects; a name without
+// a value, e.g. ?uploads, is name=
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var pairs []string
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, escape(name, true)+"="+escape(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// escape percent-encodes everything but unreserved characters, and slashes unless
+// encodeSlash is set
+func escape(s string, encodeSlash bool) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,24 @@
+package sms
+
+import (
+	"context"
+	"log"
+
+	"clean-arch-gin/internal/domain/shared/sms"
+)
+
+// Log writes text messages to the log instead of sending them, for development
+type Log struct{}
+
+var _ sms.Sender = Log{}
+
+// NewLog creates the log sender
+func NewLog() Log {
+	return Log{}
+}
+
+// Send logs the recipient and body of the text
+func (Log) Send(ctx context.Context, to, body string) error {
+	log.Printf("sms: to %s: %s", to, body)
+	return nil
+}
@@ -0,0 +1,166 @@
+// Package sms implements the SMS ports: senders texting through a provider and the sources
+// of the replies it posts back
+package sms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/sms"
+)
+
+// TwilioAPIURL is the base of the Twilio REST API
+const TwilioAPIURL = "https://api.twilio.com/2010-04-01"
+
+// TwilioSignatureHeader carries the signature of a Twilio webhook request
+const TwilioSignatureHeader = "X-Twilio-Signature"
+
+// twilioOptedOut is the error code of messages to numbers that replied STOP
+const twilioOptedOut = 21610
+
+// apiTimeout bounds a call to an SMS provider's API
+const apiTimeout = 10 * time.Second
+
+// TwilioConfig holds the account credentials and the sender of texts
+type TwilioConfig struct {
+	AccountSID string
+	AuthToken  string
+	// From is the number texts are sent from in E.164 format, or the SID of a messaging
+	// service (MG...) picking one from its pool
+	From string
+	// WebhookURL is the public URL Twilio posts replies to, as configured on the number,
+	// when the server sees another one behind a proxy; empty uses the request's URL
+	WebhookURL string
+}
+
+// twilioError is the body of a Twilio API error
+type twilioError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Twilio sends texts with the Twilio Messages API and reads the replies posted to the
+// messaging webhook of its number, implementing sms.Sender and sms.ReplySource
+type Twilio struct {
+	client *http.Client
+	url    string
+	config TwilioConfig
+}
+
+var (
+	_ sms.Sender      = (*Twilio)(nil)
+	_ sms.ReplySource = (*Twilio)(nil)
+)
+
+// NewTwilio creates a sender texting from the account of config
+func NewTwilio(config TwilioConfig) (*Twilio, error) {
+	if config.AccountSID == "" || config.AuthToken == "" {
+		return nil, errors.New("Twilio account SID and auth token are required")
+	}
+	if config.From == "" {
+		return nil, errors.New("Twilio sender number or messaging service is required")
+	}
+	return &Twilio{
+		client: &http.Client{Timeout: apiTimeout},
+		url:    TwilioAPIURL + "/Accounts/" + url.PathEscape(config.AccountSID) + "/Messages.json",
+		config: config,
+	}, nil
+}
+
+// Send posts the text to Twilio, which queues it for delivery with 201 Created; numbers
+// that opted out fail with sms.ErrOptedOut
+func (t *Twilio) Send(ctx context.Context, to, body string) error {
+	form := url.Values{"To": {to}, "Body": {body}}
+	if strings.HasPrefix(t.config.From, "MG") {
+		form.Set("MessagingServiceSid", t.config.From)
+	} else {
+		form.Set("From", t.config.From)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(t.config.AccountSID, t.config.AuthToken)
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Twilio unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	var apiErr twilioError
+	if json.Unmarshal(detail, &apiErr) == nil && apiErr.Code == twilioOptedOut {
+		return fmt.Errorf("%w: %s", sms.ErrOptedOut, apiErr.Message)
+	}
+	return fmt.Errorf("Twilio answered %s: %s", resp.Status, bytes.TrimSpace(detail))
+}
+
+// Parse verifies the request's signature, an HMAC-SHA1 keyed with the auth token over the
+// URL followed by the form parameters sorted by name, then returns the reply it carries
+// Twilio's own reading of the keyword (OptOutType, with Advanced Opt-Out) is preferred to
+// the body's
+func (t *Twilio) Parse(ctx context.Context, requestURL string, header http.Header, body []byte) ([]sms.Reply, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", sms.ErrInvalidWebhook, err)
+	}
+	if t.config.WebhookURL != "" {
+		requestURL = t.config.WebhookURL
+	}
+	signature, err := base64.StdEncoding.DecodeString(header.Get(TwilioSignatureHeader))
+	if err != nil || len(signature) == 0 {
+		return nil, fmt.Errorf("%w: missing signature", sms.ErrInvalidWebhook)
+	}
+	if subtle.ConstantTimeCompare(signature, t.sign(requestURL, form)) != 1 {
+		return nil, fmt.Errorf("%w: signature mismatch", sms.ErrInvalidWebhook)
+	}
+
+	from := form.Get("From")
+	if from == "" {
+		return nil, nil
+	}
+	keyword := sms.ParseKeyword(form.Get("OptOutType"))
+	if keyword == sms.KeywordNone {
+		keyword = sms.ParseKeyword(form.Get("Body"))
+	}
+	return []sms.Reply{{From: from, Keyword: keyword}}, nil
+}
+
+// sign computes the signature Twilio sends with a request to requestURL posting form
+func (t *Twilio) sign(requestURL string, form url.Values) []byte {
+	names := make([]string, 0, len(form))
+	for name := range form {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	mac := hmac.New(sha1.New, []byte(t.config.AuthToken))
+	mac.Write([]byte(requestURL))
+	for _, name := range names {
+		values := append([]string(nil), form[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			mac.Write([]byte(name))
+			mac.Write([]byte(value))
+		}
+	}
+	return mac.Sum(nil)
+}
@@ -0,0 +1,152 @@
+// Package storage implements the storage port
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	sharedStorage "clean-arch-gin/internal/domain/shared/storage"
+	"clean-arch-gin/internal/infrastructure/urlsign"
+)
+
+var (
+	// ErrInvalidKey is returned for keys that are empty or escape the storage root
+	ErrInvalidKey = errors.New("storage: invalid key")
+	// ErrNoSignedURLs is returned by SignedURL on a storage without signed downloads
+	ErrNoSignedURLs = errors.New("storage: signed downloads are not configured")
+)
+
+// Local keeps files in a directory on disk, served under baseURL by the HTTP router
+// Suited to single-replica deployments and development; replicas need a shared volume
+type Local struct {
+	dir     string
+	baseURL string
+	// downloadURL is where FileHandler is mounted behind signer, for the URLs returned by
+	// SignedURL; empty without signed downloads
+	downloadURL string
+	signer      *urlsign.Signer
+}
+
+var _ sharedStorage.Storage = (*Local)(nil)
+
+// NewLocal creates a storage rooted at dir whose files are served under baseURL,
+// e.g. /media or https://cdn.example.com/media, and downloaded through URLs under
+// downloadURL signed by signer; signer may be nil when there are no signed downloads
+func NewLocal(dir, baseURL, downloadURL string, signer *urlsign.Signer) *Local {
+	return &Local{
+		dir:         dir,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		downloadURL: strings.TrimSuffix(downloadURL, "/"),
+		signer:      signer,
+	}
+}
+
+// Dir is the directory the files are kept in
+func (s *Local) Dir() string {
+	return s.dir
+}
+
+// Put writes body to a temporary file renamed over key, so readers never see a partial file
+func (s *Local) Put(ctx context.Context, key, contentType string, body io.Reader) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Delete removes the file under key
+func (s *Local) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// URL joins baseURL and key
+func (s *Local) URL(key string) string {
+	return s.baseURL + "/" + strings.TrimPrefix(key, "/")
+}
+
+// SignedURL is the URL of key under downloadURL, signed to work until expiresAt
+func (s *Local) SignedURL(key string, expiresAt time.Time) (string, error) {
+	if s.signer == nil || s.downloadURL == "" {
+		return "", ErrNoSignedURLs
+	}
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+	return s.signer.Sign(s.downloadURL+"/"+key, expiresAt)
+}
+
+// FileHandler serves the file named by the request path as an attachment; anything else
+// is 404. It checks no signature: mount it with the download path stripped behind
+// urlsign.Signer.Require, e.g. signer.Require(http.StripPrefix("/downloads/files", s.FileHandler()))
+func (s *Local) FileHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/")
+		name, err := s.path(key)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		file, err := os.Open(name)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer file.Close()
+		info, err := file.Stat()
+		if err != nil || info.IsDir() {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Disposition", `attachment; filename="`+path.Base(key)+`"`)
+		w.Header().Set("Cache-Control", "private, no-store")
+		http.ServeContent(w, r, path.Base(key), info.ModTime(), file)
+	})
+}
+
+// path maps key to a file inside dir, rejecting keys that would leave it
+func (s *Local) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned == "/" || cleaned != "/"+key {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(cleaned)), nil
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	sharedStorage "clean-arch-gin/internal/domain/shared/storage"
+)
+
+// UploadState is how far a multipart upload got: the parts uploaded so far, kept by the
+// uploader, e.g. as a task checkpoint, to continue the upload after a restart
+type UploadState struct {
+	Key      string               `json:"key"`
+	UploadID string               `json:"upload_id"`
+	Parts    []sharedStorage.Part `json:"parts"`
+}
+
+// MultipartWriter writes a file as a multipart upload, holding no more than about a part in
+// memory. Writes are buffered until FlushPart uploads them, so the caller decides where
+// parts end, e.g. only between the rows it can resume from
+type MultipartWriter struct {
+	files    sharedStorage.MultipartStorage
+	partSize int
+	state    UploadState
+	buf      bytes.Buffer
+}
+
+// NewMultipartWriter starts an upload of the file under key in parts of the part size of files
+func NewMultipartWriter(ctx context.Context, files sharedStorage.MultipartStorage, key, contentType string) (*MultipartWriter, error) {
+	uploadID, err := files.CreateUpload(ctx, key, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return newMultipartWriter(files, UploadState{Key: key, UploadID: uploadID}), nil
+}
+
+// ResumeMultipartWriter continues the upload of state, whose parts must all still be there;
+// parts uploaded after state was kept are replaced by the next ones written. It returns
+// ErrUploadNotFound, wrapped, when the upload is gone or lost a part, for the caller to
+// start over
+func ResumeMultipartWriter(ctx context.Context, files sharedStorage.MultipartStorage, state UploadState) (*MultipartWriter, error) {
+	uploaded, err := files.ListParts(ctx, state.Key, state.UploadID)
+	if err != nil {
+		return nil, err
+	}
+	etags := make(map[int]string, len(uploaded))
+	for _, part := range uploaded {
+		etags[part.Number] = part.ETag
+	}
+	for _, part := range state.Parts {
+		if etags[part.Number] != part.ETag {
+			return nil, fmt.Errorf("%w: part %d of %s differs", sharedStorage.ErrUploadNotFound, part.Number, state.Key)
+		}
+	}
+	return newMultipartWriter(files, state), nil
+}
+
+// newMultipartWriter creates a writer continuing state
+func newMultipartWriter(files sharedStorage.MultipartStorage, state UploadState) *MultipartWriter {
+	state.Parts = append([]sharedStorage.Part(nil), state.Parts...)
+	return &MultipartWriter{files: files, partSize: files.PartSize(), state: state}
+}
+
+// Write buffers p for the next part
+func (w *MultipartWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+// FlushPart uploads the buffered bytes as the next part once they reach the part size, and
+// reports whether it did
+func (w *MultipartWriter) FlushPart(ctx context.Context) (bool, error) {
+	if w.buf.Len() < w.partSize {
+		return false, nil
+	}
+	return true, w.upload(ctx)
+}
+
+// State is how far the upload got, as of the last part uploaded
+func (w *MultipartWriter) State() UploadState {
+	state := w.state
+	state.Parts = append([]sharedStorage.Part(nil), w.state.Parts...)
+	return state
+}
+
+// Complete uploads the rest of the buffer as the last part and assembles the file
+func (w *MultipartWriter) Complete(ctx context.Context) error {
+	if w.buf.Len() > 0 || len(w.state.Parts) == 0 {
+		if err := w.upload(ctx); err != nil {
+			return err
+		}
+	}
+	return w.files.CompleteUpload(ctx, w.state.Key, w.state.UploadID, w.state.Parts)
+}
+
+// Abort discards the upload and its parts
+func (w *MultipartWriter) Abort(ctx context.Context) error {
+	w.buf.Reset()
+	return w.files.AbortUpload(ctx, w.state.Key, w.state.UploadID)
+}
+
+// upload uploads the buffer as the next part
+func (w *MultipartWriter) upload(ctx context.Context) error {
+	part, err := w.files.UploadPart(ctx, w.state.Key, w.state.UploadID, len(w.state.Parts)+1, w.buf.Bytes())
+	if err != nil {
+		return err
+	}
+	w.state.Parts = append(w.state.Parts, part)
+	w.buf.Reset()
+	return nil
+}
@@ -0,0 +1,361 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	sharedStorage "clean-arch-gin/internal/domain/shared/storage"
+	"clean-arch-gin/internal/infrastructure/sigv4"
+)
+
+const (
+	// S3MinPartSize is the smallest part S3 accepts but for the last one
+	S3MinPartSize = 5 << 20
+	// s3MaxSignedURLTTL is the longest a presigned URL may work
+	s3MaxSignedURLTTL = 7 * 24 * time.Hour
+	// defaultS3PartSize holds 10,000 parts, S3's limit, of an 80 GiB file
+	defaultS3PartSize = 8 << 20
+	// defaultS3Timeout bounds each request, including the upload of a part
+	defaultS3Timeout = time.Minute
+)
+
+// S3Config configures an S3 storage
+type S3Config struct {
+	Bucket      string
+	Region      string
+	Credentials sigv4.Credentials
+	// Endpoint is the URL of an S3-compatible service such as MinIO, whose buckets are
+	// addressed by path; empty for AWS, whose buckets are addressed by host
+	Endpoint string
+	// PartSize is the size of the parts of multipart uploads, at least S3MinPartSize (8 MiB)
+	PartSize int
+	// Timeout bounds each request (1m)
+	Timeout time.Duration
+}
+
+// S3 keeps files in an S3 bucket over the REST API, signed with the configured credentials,
+// which need s3:PutObject, s3:GetObject, s3:DeleteObject, s3:ListMultipartUploadParts and
+// s3:AbortMultipartUpload on the bucket. Signed URLs are presigned GETs served by S3 itself
+type S3 struct {
+	client *http.Client
+	cfg    S3Config
+	// base is the URL of the bucket, keys are appended to
+	base *url.URL
+}
+
+var _ sharedStorage.MultipartStorage = (*S3)(nil)
+
+// NewS3 creates a storage on the bucket of cfg
+func NewS3(cfg S3Config) (*S3, error) {
+	if cfg.Bucket == "" || cfg.Region == "" {
+		return nil, errors.New("S3 bucket and region are required")
+	}
+	if cfg.Credentials.AccessKeyID == "" || cfg.Credentials.SecretAccessKey == "" {
+		return nil, errors.New("AWS access key ID and secret access key are required for S3")
+	}
+	if cfg.PartSize <= 0 {
+		cfg.PartSize = defaultS3PartSize
+	}
+	if cfg.PartSize < S3MinPartSize {
+		return nil, fmt.Errorf("S3 part size must be at least %d bytes", S3MinPartSize)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultS3Timeout
+	}
+
+	var base *url.URL
+	if cfg.Endpoint == "" {
+		base = &url.URL{Scheme: "https", Host: cfg.Bucket + ".s3." + cfg.Region + ".amazonaws.com", Path: "/"}
+	} else {
+		endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+		if err != nil || endpoint.Host == "" {
+			return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+		}
+		base = endpoint
+		base.Path = strings.TrimSuffix(endpoint.Path, "/") + "/" + cfg.Bucket + "/"
+	}
+	return &S3{client: &http.Client{Timeout: cfg.Timeout}, cfg: cfg, base: base}, nil
+}
+
+// Put uploads body under key in one request; files are streamed, other readers are read
+// into memory first. Large files are better written as multipart uploads
+func (s *S3) Put(ctx context.Context, key, contentType string, body io.Reader) error {
+	u, err := s.objectURL(key, nil)
+	if err != nil {
+		return err
+	}
+	payloadHash := sigv4.UnsignedPayload
+	var length int64
+	if file, ok := body.(*os.File); ok {
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		offset, err := file.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+		length = info.Size() - offset
+	} else {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		body, length, payloadHash = bytes.NewReader(data), int64(len(data)), sigv4.HashHex(data)
+	}
+
+	// The caller closes body; an empty one is sent without a body so it is not chunked
+	var reqBody io.Reader
+	if length > 0 {
+		reqBody = io.NopCloser(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = length
+	req.Header.Set("Content-Type", contentType)
+	resp, err := s.do(req, payloadHash)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Delete removes the object under key; S3 does not fail on missing objects
+func (s *S3) Delete(ctx context.Context, key string) error {
+	return s.send(ctx, http.MethodDelete, key, nil, nil, nil)
+}
+
+// URL is the unsigned URL of the object under key, which only works on a public bucket
+func (s *S3) URL(key string) string {
+	u, err := s.objectURL(key, nil)
+	if err != nil {
+		return ""
+	}
+	return u.String()
+}
+
+// SignedURL is a presigned GET of key, downloaded as an attachment, until expiresAt, at
+// most a week away
+func (s *S3) SignedURL(key string, expiresAt time.Time) (string, error) {
+	now := time.Now()
+	ttl := expiresAt.Sub(now).Round(time.Second)
+	if ttl <= 0 || ttl > s3MaxSignedURLTTL {
+		return "", errors.New("S3 signed URLs must expire within a week")
+	}
+	u, err := s.objectURL(key, url.Values{
+		"response-content-disposition": {`attachment; filename="` + path.Base(key) + `"`},
+	})
+	if err != nil {
+		return "", err
+	}
+	return sigv4.Presign(http.MethodGet, u, s.cfg.Credentials, "s3", s.cfg.Region, now, ttl), nil
+}
+
+// PartSize is the configured part size
+func (s *S3) PartSize() int {
+	return s.cfg.PartSize
+}
+
+// CreateUpload initiates a multipart upload
+func (s *S3) CreateUpload(ctx context.Context, key, contentType string) (string, error) {
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	header := http.Header{"Content-Type": {contentType}}
+	if err := s.send(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, header, &result); err != nil {
+		return "", err
+	}
+	if result.UploadID == "" {
+		return "", errors.New("S3 answered without an upload ID")
+	}
+	return result.UploadID, nil
+}
+
+// UploadPart uploads one part, signing its hash
+func (s *S3) UploadPart(ctx context.Context, key, uploadID string, number int, body []byte) (sharedStorage.Part, error) {
+	u, err := s.objectURL(key, url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}})
+	if err != nil {
+		return sharedStorage.Part{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return sharedStorage.Part{}, err
+	}
+	resp, err := s.do(req, sigv4.HashHex(body))
+	if err != nil {
+		return sharedStorage.Part{}, err
+	}
+	resp.Body.Close()
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		return sharedStorage.Part{}, errors.New("S3 answered a part without an ETag")
+	}
+	return sharedStorage.Part{Number: number, ETag: etag}, nil
+}
+
+// s3Part is a part as listed and completed by S3
+type s3Part struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// ListParts pages through the parts of the upload
+func (s *S3) ListParts(ctx context.Context, key, uploadID string) ([]sharedStorage.Part, error) {
+	var parts []sharedStorage.Part
+	marker := ""
+	for {
+		query := url.Values{"uploadId": {uploadID}}
+		if marker != "" {
+			query.Set("part-number-marker", marker)
+		}
+		var result struct {
+			IsTruncated          bool     `xml:"IsTruncated"`
+			NextPartNumberMarker string   `xml:"NextPartNumberMarker"`
+			Parts                []s3Part `xml:"Part"`
+		}
+		if err := s.send(ctx, http.MethodGet, key, query, nil, &result); err != nil {
+			return nil, err
+		}
+		for _, part := range result.Parts {
+			parts = append(parts, sharedStorage.Part{Number: part.PartNumber, ETag: part.ETag})
+		}
+		if !result.IsTruncated || result.NextPartNumberMarker == "" {
+			return parts, nil
+		}
+		marker = result.NextPartNumberMarker
+	}
+}
+
+// CompleteUpload assembles the parts into the object
+func (s *S3) CompleteUpload(ctx context.Context, key, uploadID string, parts []sharedStorage.Part) error {
+	var body struct {
+		XMLName xml.Name `xml:"CompleteMultipartUpload"`
+		Parts   []s3Part `xml:"Part"`
+	}
+	for _, part := range parts {
+		body.Parts = append(body.Parts, s3Part{PartNumber: part.Number, ETag: part.ETag})
+	}
+	payload, err := xml.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	u, err := s.objectURL(key, url.Values{"uploadId": {uploadID}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/xml")
+	resp, err := s.do(req, sigv4.HashHex(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// S3 may answer 200 and still fail, with an error in place of the result
+	result, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return err
+	}
+	return errorIn(resp, result)
+}
+
+// AbortUpload discards the upload; an upload already gone is not an error
+func (s *S3) AbortUpload(ctx context.Context, key, uploadID string) error {
+	err := s.send(ctx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil, nil)
+	if errors.Is(err, sharedStorage.ErrUploadNotFound) {
+		return nil
+	}
+	return err
+}
+
+// send makes a request without a body to key and decodes the XML answer into result unless
+// it is nil
+func (s *S3) send(ctx context.Context, method, key string, query url.Values, header http.Header, result interface{}) error {
+	u, err := s.objectURL(key, query)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	resp, err := s.do(req, sigv4.HashHex(nil))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if result == nil {
+		return nil
+	}
+	return xml.NewDecoder(resp.Body).Decode(result)
+}
+
+// do signs and sends req, turning error answers into errors
+func (s *S3) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	sigv4.Sign(req, payloadHash, s.cfg.Credentials, "s3", s.cfg.Region, time.Now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("S3 unreachable: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return nil, errorIn(resp, detail)
+	}
+	return resp, nil
+}
+
+// errorIn returns the S3 error in body, if any; NoSuchUpload is ErrUploadNotFound
+func errorIn(resp *http.Response, body []byte) error {
+	var s3Err struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}
+	if xml.Unmarshal(body, &s3Err) != nil || s3Err.Code == "" {
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("S3 answered %s: %s", resp.Status, bytes.TrimSpace(body))
+		}
+		return nil
+	}
+	if s3Err.Code == "NoSuchUpload" {
+		return sharedStorage.ErrUploadNotFound
+	}
+	return fmt.Errorf("S3 answered %s: %s: %s", resp.Status, s3Err.Code, s3Err.Message)
+}
+
+// objectURL is the URL of key in the bucket with query
+func (s *S3) objectURL(key string, query url.Values) (*url.URL, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned == "/" || cleaned != "/"+key {
+		return nil, ErrInvalidKey
+	}
+	u := *s.base
+	u.Path = s.base.Path + key
+	u.RawPath = sigv4.EscapePath(u.Path)
+	u.RawQuery = ""
+	if query != nil {
+		u.RawQuery = strings.ReplaceAll(query.Encode(), "+", "%20")
+	}
+	return &u, nil
+}
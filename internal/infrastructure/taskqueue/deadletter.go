@@ -0,0 +1,134 @@
+package taskqueue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrTaskNotDead is returned when requeuing or discarding a task that is not dead
+var ErrTaskNotDead = errors.New("taskqueue: only dead tasks can be requeued or discarded")
+
+// TaskAttemptModel records a failed attempt of a task, kept as its error history
+type TaskAttemptModel struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	TaskID     uint      `gorm:"not null;index"`
+	Attempt    int       `gorm:"not null"`
+	Error      string    `gorm:"size:1024"`
+	WorkerID   string    `gorm:"size:255"`
+	StartedAt  time.Time `gorm:"not null"`
+	DurationMs int64     `gorm:"not null;default:0"`
+}
+
+// TableName sets the table name for GORM
+func (TaskAttemptModel) TableName() string {
+	return "task_attempts"
+}
+
+// Attempt is one failed run of a task
+type Attempt struct {
+	Attempt   int           `json:"attempt"`
+	Error     string        `json:"error"`
+	WorkerID  string        `json:"worker_id"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration_ns"`
+}
+
+// ListDead returns dead tasks, most recently failed first, optionally of one type,
+// and how many there are in total
+func (q *Queue) ListDead(ctx context.Context, taskType string, limit, offset int) ([]*Task, int64, error) {
+	query := q.db.WithContext(ctx).Model(&TaskModel{}).Where("status = ?", StatusDead)
+	if taskType != "" {
+		query = query.Where("type = ?", taskType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var models []TaskModel
+	if err := query.Order("finished_at DESC, id DESC").Limit(limit).Offset(offset).Find(&models).Error; err != nil {
+		return nil, 0, err
+	}
+	tasks := make([]*Task, len(models))
+	for i := range models {
+		tasks[i] = models[i].toTask()
+	}
+	return tasks, total, nil
+}
+
+// Attempts returns the error history of a task, oldest first
+func (q *Queue) Attempts(ctx context.Context, id uint) ([]Attempt, error) {
+	var models []TaskAttemptModel
+	if err := q.db.WithContext(ctx).Where("task_id = ?", id).Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	attempts := make([]Attempt, len(models))
+	for i, m := range models {
+		attempts[i] = Attempt{
+			Attempt:   m.Attempt,
+			Error:     m.Error,
+			WorkerID:  m.WorkerID,
+			StartedAt: m.StartedAt,
+			Duration:  time.Duration(m.DurationMs) * time.Millisecond,
+		}
+	}
+	return attempts, nil
+}
+
+// Requeue gives a dead task a fresh set of attempts, due now
+// Its error history is kept, so later failures are appended to it
+func (q *Queue) Requeue(ctx context.Context, id uint) (*Task, error) {
+	result := q.db.WithContext(ctx).Model(&TaskModel{}).
+		Where("id = ? AND status = ?", id, StatusDead).
+		Updates(map[string]interface{}{
+			"status":      StatusPending,
+			"attempts":    0,
+			"progress":    0,
+			"phase":       "",
+			"processed":   0,
+			"total":       0,
+			"run_after":   time.Now(),
+			"finished_at": nil,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, notDead(q.db.WithContext(ctx), id)
+	}
+
+	q.signal()
+	return q.Get(ctx, id)
+}
+
+// Discard deletes a dead task and its error history
+func (q *Queue) Discard(ctx context.Context, id uint) error {
+	return q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND status = ?", id, StatusDead).Delete(&TaskModel{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return notDead(tx, id)
+		}
+		return tx.Where("task_id = ?", id).Delete(&TaskAttemptModel{}).Error
+	})
+}
+
+// notDead explains why a task could not be changed: it is missing or not dead
+// It reads through db, so within a transaction it does not wait for a second connection
+func notDead(db *gorm.DB, id uint) error {
+	var count int64
+	if err := db.Model(&TaskModel{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrTaskNotFound
+	}
+	return ErrTaskNotDead
+}
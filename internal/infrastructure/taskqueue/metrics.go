@@ -0,0 +1,41 @@
+package taskqueue
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	tasksProcessedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tasks_processed_total",
+		Help: "Task queue runs by task type and result: success or failure.",
+	}, []string{"type", "result"})
+
+	tasksDeadTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tasks_dead_total",
+		Help: "Tasks moved to the dead state by task type.",
+	}, []string{"type"})
+
+	taskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "task_duration_seconds",
+		Help:    "Task queue run duration by task type.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"type"})
+)
+
+// observeTask records a finished run
+func observeTask(taskType string, err error, duration time.Duration) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	tasksProcessedTotal.WithLabelValues(taskType, result).Inc()
+	taskDuration.WithLabelValues(taskType).Observe(duration.Seconds())
+}
+
+// observeDead counts a task that exhausted its attempts or failed permanently
+func observeDead(taskType string) {
+	tasksDeadTotal.WithLabelValues(taskType).Inc()
+}
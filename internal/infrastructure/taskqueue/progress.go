@@ -0,0 +1,196 @@
+package taskqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// itemsInterval spaces the writes of item counts that leave the percentage and phase as they
+// were, so ReportItems can be called for every item
+const itemsInterval = time.Second
+
+var (
+	// errNotInTask is returned by SetResult and SaveCheckpoint outside a handler
+	errNotInTask = errors.New("taskqueue: not called from a task handler")
+	// ErrTaskLost is returned by SaveCheckpoint once this worker no longer holds the task
+	ErrTaskLost = errors.New("taskqueue: task is no longer held by this worker")
+)
+
+// runKey carries the state of the running task in the handler's context
+type runKey struct{}
+
+// runState is what a handler reports while it runs: its progress and checkpoints, written
+// as it goes, and its result, written by finish once it succeeds
+type runState struct {
+	q    *Queue
+	task *Task
+
+	mu       sync.Mutex
+	progress int
+	phase    string
+	// processed and total are the item counts of phase, written at written
+	processed int
+	total     int
+	written   time.Time
+	result    []byte
+	// checkpointed is set once the run saved a checkpoint
+	checkpointed bool
+}
+
+// withRun attaches the state of task to the handler's context
+func withRun(ctx context.Context, q *Queue, task *Task) (context.Context, *runState) {
+	state := &runState{q: q, task: task, progress: task.Progress, phase: task.Phase,
+		processed: task.Processed, total: task.Total}
+	return context.WithValue(ctx, runKey{}, state), state
+}
+
+// ReportProgress records that percent of the running task's work is done, clamped to 0-99
+// since only success completes a task. It is best effort: outside a handler, or once this
+// worker has lost the task, nothing is written, and write failures are only logged
+func ReportProgress(ctx context.Context, percent int) {
+	state, ok := ctx.Value(runKey{}).(*runState)
+	if !ok {
+		return
+	}
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 99 {
+		percent = 99
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if percent == state.progress {
+		return
+	}
+	state.progress = percent
+	state.write(ctx, map[string]interface{}{"progress": percent})
+}
+
+// ReportPhase records the step the running task is on, e.g. "counting" or "uploading"; the
+// item counts start over with it. It is best effort, like ReportProgress
+func ReportPhase(ctx context.Context, phase string) {
+	state, ok := ctx.Value(runKey{}).(*runState)
+	if !ok {
+		return
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if phase == state.phase && state.processed == 0 && state.total == 0 {
+		return
+	}
+	state.phase, state.processed, state.total = phase, 0, 0
+	state.write(ctx, map[string]interface{}{"phase": phase, "processed": 0, "total": 0})
+}
+
+// ReportItems records that processed of total items of the current phase are done, and the
+// percentage they make; a total of 0 is unknown and leaves the percentage as it is. Counts
+// that leave the percentage unchanged are written at most every second, so it can be called
+// for every item. It is best effort, like ReportProgress
+func ReportItems(ctx context.Context, processed, total int) {
+	state, ok := ctx.Value(runKey{}).(*runState)
+	if !ok {
+		return
+	}
+	if total < 0 {
+		total = 0
+	}
+	if processed < 0 {
+		processed = 0
+	}
+	if total > 0 && processed > total {
+		processed = total
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if processed == state.processed && total == state.total {
+		return
+	}
+	percent := state.progress
+	if total > 0 {
+		percent = min(processed*100/total, 99)
+	}
+	// The last item is always written, so the counts end on the total
+	last := total > 0 && processed == total
+	if percent == state.progress && !last && time.Since(state.written) < itemsInterval {
+		return
+	}
+	state.progress, state.processed, state.total = percent, processed, total
+	state.write(ctx, map[string]interface{}{"progress": percent, "processed": processed, "total": total})
+}
+
+// write records updates of the progress columns while this worker holds the task; failures
+// are only logged. Call it with s.mu held
+func (s *runState) write(ctx context.Context, updates map[string]interface{}) {
+	s.written = time.Now()
+	q, task := s.q, s.task
+	err := q.db.WithContext(ctx).Model(&TaskModel{}).
+		Where("id = ? AND status = ? AND locked_by = ?", task.ID, StatusRunning, q.opts.WorkerID).
+		Updates(updates).Error
+	if err != nil && ctx.Err() == nil {
+		log.Printf("taskqueue: failed to record progress of task %d: %v", task.ID, err)
+	}
+}
+
+// SetResult keeps v, encoded as JSON, as the outcome of the running task
+// It is saved when the handler returns nil and discarded when the run fails
+func SetResult(ctx context.Context, v interface{}) error {
+	state, ok := ctx.Value(runKey{}).(*runState)
+	if !ok {
+		return errNotInTask
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	state.mu.Lock()
+	state.result = body
+	state.mu.Unlock()
+	return nil
+}
+
+// SaveCheckpoint records v, encoded as JSON, as how far the running task got, so a retry
+// after a failure or a worker restart resumes from it through Task.DecodeCheckpoint rather
+// than starting over. Unlike progress it is written before returning, and a run that saves
+// one does not use up an attempt when it fails; it is cleared once the task finishes
+func SaveCheckpoint(ctx context.Context, v interface{}) error {
+	state, ok := ctx.Value(runKey{}).(*runState)
+	if !ok {
+		return errNotInTask
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	q, task := state.q, state.task
+	result := q.db.WithContext(ctx).Model(&TaskModel{}).
+		Where("id = ? AND status = ? AND locked_by = ?", task.ID, StatusRunning, q.opts.WorkerID).
+		Update("checkpoint", body)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTaskLost
+	}
+	task.Checkpoint = body
+	state.checkpointed = true
+	return nil
+}
+
+// outcome returns the result set by the handler and whether it saved a checkpoint
+func (s *runState) outcome() ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.result, s.checkpointed
+}
@@ -0,0 +1,193 @@
+package taskqueue
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"clean-arch-gin/internal/infrastructure/retry"
+
+	"gorm.io/gorm"
+)
+
+// claimBatch is how many due tasks a worker considers per claim
+const claimBatch = 10
+
+// TaskModel is the GORM model of the tasks table
+type TaskModel struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement"`
+	TenantID    uint       `gorm:"not null;default:1;index"`
+	Type        string     `gorm:"not null;size:128;index"`
+	Payload     []byte     `gorm:"not null"`
+	Status      string     `gorm:"not null;size:16;index:idx_tasks_due,priority:1"`
+	Attempts    int        `gorm:"not null;default:0"`
+	MaxAttempts int        `gorm:"not null"`
+	RunAfter    time.Time  `gorm:"not null;index:idx_tasks_due,priority:2"`
+	LockedBy    string     `gorm:"size:255"`
+	LockedUntil *time.Time `gorm:"index"`
+	LastError   string     `gorm:"size:1024"`
+	Owner       string     `gorm:"size:255"`
+	Progress    int        `gorm:"not null;default:0"`
+	Phase       string     `gorm:"size:64"`
+	Processed   int        `gorm:"not null;default:0"`
+	Total       int        `gorm:"not null;default:0"`
+	Result      []byte
+	Checkpoint  []byte
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	FinishedAt  *time.Time
+}
+
+// TableName sets the table name for GORM
+func (TaskModel) TableName() string {
+	return "tasks"
+}
+
+// toTask converts the model to a Task
+func (m *TaskModel) toTask() *Task {
+	return &Task{
+		ID:          m.ID,
+		TenantID:    m.TenantID,
+		Type:        m.Type,
+		Payload:     m.Payload,
+		Status:      Status(m.Status),
+		Attempts:    m.Attempts,
+		MaxAttempts: m.MaxAttempts,
+		RunAfter:    m.RunAfter,
+		LastError:   m.LastError,
+		Owner:       m.Owner,
+		Progress:    m.Progress,
+		Phase:       m.Phase,
+		Processed:   m.Processed,
+		Total:       m.Total,
+		Result:      m.Result,
+		Checkpoint:  m.Checkpoint,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		FinishedAt:  m.FinishedAt,
+	}
+}
+
+// claim locks one due task of a registered type for this worker, or returns nil
+// Due tasks are pending ones past RunAfter and running ones whose lock expired. Each
+// candidate is taken with a conditional update, so concurrent workers on any replica
+// never claim the same task
+func (q *Queue) claim(ctx context.Context) (*Task, error) {
+	types := q.types()
+	if len(types) == 0 {
+		return nil, nil
+	}
+
+	db := q.db.WithContext(ctx)
+	now := time.Now()
+	due := func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("type IN ?", types).Where(
+			q.db.Where("status = ? AND run_after <= ?", StatusPending, now).
+				Or("status = ? AND locked_until < ?", StatusRunning, now),
+		)
+	}
+
+	var candidates []TaskModel
+	if err := due(db.Model(&TaskModel{})).Select("id").Order("run_after, id").Limit(claimBatch).Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	lockedUntil := now.Add(q.opts.LockTimeout)
+	for _, candidate := range candidates {
+		result := due(db.Model(&TaskModel{}).Where("id = ?", candidate.ID)).Updates(map[string]interface{}{
+			"status":       StatusRunning,
+			"locked_by":    q.opts.WorkerID,
+			"locked_until": lockedUntil,
+			"attempts":     gorm.Expr("attempts + 1"),
+		})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue // taken by another worker
+		}
+
+		var model TaskModel
+		if err := db.First(&model, candidate.ID).Error; err != nil {
+			return nil, err
+		}
+		return model.toTask(), nil
+	}
+	return nil, nil
+}
+
+// finish records the outcome of a run: succeeded with its result, retried after a backoff,
+// or dead. Failed runs are appended to the task's error history; a retried run that saved a
+// checkpoint gets its attempt back and keeps its progress, since the retry resumes from it.
+// Nothing is written once this worker has lost the lock, since the task then belongs to
+// another worker
+func (q *Queue) finish(ctx context.Context, task *Task, started time.Time, result []byte, checkpointed bool, runErr error) error {
+	now := time.Now()
+	updates := map[string]interface{}{"locked_by": "", "locked_until": nil}
+
+	switch {
+	case runErr == nil:
+		updates["status"] = StatusSucceeded
+		updates["finished_at"] = now
+		updates["last_error"] = ""
+		updates["progress"] = 100
+		updates["result"] = result
+		updates["checkpoint"] = nil
+	case retry.IsPermanent(runErr) || task.Attempts >= task.MaxAttempts && !checkpointed:
+		log.Printf("taskqueue: task %d (%s) is dead after %d attempts: %v", task.ID, task.Type, task.Attempts, runErr)
+		updates["status"] = StatusDead
+		updates["finished_at"] = now
+		updates["last_error"] = truncate(runErr.Error())
+		updates["checkpoint"] = nil
+		observeDead(task.Type)
+	case checkpointed:
+		updates["status"] = StatusPending
+		updates["run_after"] = now.Add(q.opts.Backoff.Delay(task.Attempts))
+		updates["last_error"] = truncate(runErr.Error())
+		updates["attempts"] = gorm.Expr("attempts - 1")
+	default:
+		updates["status"] = StatusPending
+		updates["run_after"] = now.Add(q.opts.Backoff.Delay(task.Attempts))
+		updates["last_error"] = truncate(runErr.Error())
+		if task.Checkpoint == nil {
+			// the retry starts over
+			updates["progress"] = 0
+			updates["phase"] = ""
+			updates["processed"] = 0
+			updates["total"] = 0
+		}
+	}
+
+	return q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&TaskModel{}).
+			Where("id = ? AND status = ? AND locked_by = ?", task.ID, StatusRunning, q.opts.WorkerID).
+			Updates(updates)
+		if result.Error != nil || result.RowsAffected == 0 || runErr == nil {
+			return result.Error
+		}
+		return tx.Create(&TaskAttemptModel{
+			TaskID:     task.ID,
+			Attempt:    task.Attempts,
+			Error:      truncate(runErr.Error()),
+			WorkerID:   q.opts.WorkerID,
+			StartedAt:  started,
+			DurationMs: now.Sub(started).Milliseconds(),
+		}).Error
+	})
+}
+
+// PurgeFinished deletes succeeded tasks finished before the given time, with their error
+// history, and returns how many; dead tasks are kept until requeued or discarded
+func (q *Queue) PurgeFinished(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		finished := q.db.Model(&TaskModel{}).Select("id").Where("status = ? AND finished_at < ?", StatusSucceeded, before)
+		if err := tx.Where("task_id IN (?)", finished).Delete(&TaskAttemptModel{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("status = ? AND finished_at < ?", StatusSucceeded, before).Delete(&TaskModel{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
@@ -0,0 +1,355 @@
+// Package taskqueue is a durable task queue kept in the database
+// Tasks survive restarts: they are rows in the tasks table claimed by worker pollers,
+// retried with exponential backoff and moved to the dead state once attempts run out
+package taskqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	"clean-arch-gin/internal/infrastructure/retry"
+
+	"gorm.io/gorm"
+)
+
+// Status is the state of a task
+type Status string
+
+const (
+	// StatusPending tasks wait for RunAfter and a free worker
+	StatusPending Status = "pending"
+	// StatusRunning tasks are claimed by a worker until LockedUntil
+	StatusRunning Status = "running"
+	// StatusSucceeded tasks are done
+	StatusSucceeded Status = "succeeded"
+	// StatusDead tasks failed permanently or ran out of attempts
+	StatusDead Status = "dead"
+)
+
+// Defaults applied to zero Options fields
+const (
+	defaultWorkers      = 4
+	defaultPollInterval = time.Second
+	defaultLockTimeout  = 5 * time.Minute
+	defaultMaxAttempts  = 5
+	// maxErrorLength matches the size of the error column
+	maxErrorLength = 1024
+)
+
+// ErrTaskNotFound is returned by Get for an unknown task
+var ErrTaskNotFound = errors.New("taskqueue: task not found")
+
+// Task is a unit of work and its delivery state
+type Task struct {
+	ID          uint       `json:"id"`
+	Type        string     `json:"type"`
+	Payload     []byte     `json:"-"`
+	Status      Status     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	MaxAttempts int        `json:"max_attempts"`
+	RunAfter    time.Time  `json:"run_after"`
+	LastError   string     `json:"last_error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	// Owner is the user who started the task, the only one besides admins who may follow it
+	Owner string `json:"owner,omitempty"`
+	// Progress is the percentage of the work done, reported by the handler with ReportProgress
+	Progress int `json:"progress"`
+	// Phase is the step the task is on, and Processed and Total count the items of that step
+	// done and to do (0 when unknown), reported with ReportPhase and ReportItems
+	Phase     string `json:"phase,omitempty"`
+	Processed int    `json:"processed,omitempty"`
+	Total     int    `json:"total,omitempty"`
+	// Result is the JSON outcome of a succeeded task, set by the handler with SetResult
+	Result []byte `json:"-"`
+	// Checkpoint is how far an earlier run got, saved by the handler with SaveCheckpoint
+	Checkpoint []byte `json:"-"`
+	// TenantID is the tenant the task was enqueued for; its handler runs within that tenant
+	TenantID uint `json:"-"`
+}
+
+// Decode unmarshals the JSON payload into v
+func (t *Task) Decode(v interface{}) error {
+	return json.Unmarshal(t.Payload, v)
+}
+
+// DecodeCheckpoint unmarshals the checkpoint saved by an earlier run into v and reports
+// whether there was one
+func (t *Task) DecodeCheckpoint(v interface{}) (bool, error) {
+	if len(t.Checkpoint) == 0 {
+		return false, nil
+	}
+	return true, json.Unmarshal(t.Checkpoint, v)
+}
+
+// Handler processes one task; returning an error schedules a retry unless the error is
+// wrapped with retry.Permanent or the task is out of attempts. It must honour ctx, and
+// may report its progress with ReportProgress, ReportPhase and ReportItems, its outcome with SetResult and how far it got
+// with SaveCheckpoint
+type Handler func(ctx context.Context, task *Task) error
+
+// Options configures a Queue
+type Options struct {
+	// Workers is how many tasks are processed concurrently (4)
+	Workers int
+	// PollInterval is how often idle workers look for due tasks (1s)
+	PollInterval time.Duration
+	// LockTimeout bounds a run; a task still running after it is reclaimed, e.g. after a crash (5m)
+	LockTimeout time.Duration
+	// MaxAttempts is the default number of attempts before a task is dead (5)
+	MaxAttempts int
+	// Backoff spaces retries (retry.DefaultPolicy delays, starting at 10s and capped at 1h)
+	Backoff retry.Policy
+	// WorkerID identifies this process in the locked_by column
+	WorkerID string
+}
+
+// Queue enqueues tasks and runs the registered handlers on worker pollers
+type Queue struct {
+	db   *gorm.DB
+	opts Options
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+
+	wake    chan struct{}
+	started bool
+	running sync.WaitGroup
+}
+
+// New creates a queue on db; zero Options fields use the defaults and TaskModel must be migrated
+func New(db *gorm.DB, opts Options) *Queue {
+	if opts.Workers <= 0 {
+		opts.Workers = defaultWorkers
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	if opts.LockTimeout <= 0 {
+		opts.LockTimeout = defaultLockTimeout
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultMaxAttempts
+	}
+	if opts.Backoff.InitialDelay <= 0 {
+		opts.Backoff.InitialDelay = 10 * time.Second
+	}
+	if opts.Backoff.MaxDelay <= 0 {
+		opts.Backoff.MaxDelay = time.Hour
+	}
+
+	return &Queue{
+		db:       db,
+		opts:     opts,
+		handlers: make(map[string]Handler),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Register sets the handler of a task type, named "<module>.<task>" by convention
+// Workers only claim tasks of registered types, so replicas running older code leave
+// new types alone
+func (q *Queue) Register(taskType string, handler Handler) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.handlers[taskType]; ok {
+		return fmt.Errorf("taskqueue: handler already registered for %s", taskType)
+	}
+	q.handlers[taskType] = handler
+	return nil
+}
+
+// EnqueueOption customizes an enqueued task
+type EnqueueOption func(*TaskModel)
+
+// RunAt delays the task until t
+func RunAt(t time.Time) EnqueueOption {
+	return func(m *TaskModel) { m.RunAfter = t }
+}
+
+// MaxAttempts overrides the queue's default number of attempts
+func MaxAttempts(n int) EnqueueOption {
+	return func(m *TaskModel) {
+		if n > 0 {
+			m.MaxAttempts = n
+		}
+	}
+}
+
+// Owner records the user who started the task, so only they can follow its progress
+func Owner(subject string) EnqueueOption {
+	return func(m *TaskModel) { m.Owner = subject }
+}
+
+// Enqueue persists a task of taskType with payload encoded as JSON, for the tenant of ctx
+func (q *Queue) Enqueue(ctx context.Context, taskType string, payload interface{}, opts ...EnqueueOption) (*Task, error) {
+	return q.EnqueueTx(q.db.WithContext(ctx), taskType, payload, opts...)
+}
+
+// EnqueueTx persists a task within tx, so it is only queued if the surrounding work commits
+func (q *Queue) EnqueueTx(tx *gorm.DB, taskType string, payload interface{}, opts ...EnqueueOption) (*Task, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("taskqueue: failed to encode %s payload: %w", taskType, err)
+	}
+
+	model := &TaskModel{
+		Type:        taskType,
+		Payload:     body,
+		Status:      string(StatusPending),
+		MaxAttempts: q.opts.MaxAttempts,
+		RunAfter:    time.Now(),
+	}
+	for _, opt := range opts {
+		opt(model)
+	}
+	if err := tx.Create(model).Error; err != nil {
+		return nil, err
+	}
+
+	q.signal()
+	return model.toTask(), nil
+}
+
+// Get loads a task by ID
+func (q *Queue) Get(ctx context.Context, id uint) (*Task, error) {
+	var model TaskModel
+	if err := q.db.WithContext(ctx).First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+	return model.toTask(), nil
+}
+
+// Start runs the worker pollers until ctx is cancelled; it does not block
+func (q *Queue) Start(ctx context.Context) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.started {
+		return
+	}
+	q.started = true
+
+	for i := 0; i < q.opts.Workers; i++ {
+		q.running.Add(1)
+		go q.work(ctx)
+	}
+}
+
+// Shutdown waits for the workers to finish their current task, or ctx to be done
+// Cancel the context passed to Start first; unfinished tasks are reclaimed after LockTimeout
+func (q *Queue) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		q.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("taskqueue: workers still running: %w", ctx.Err())
+	}
+}
+
+// work claims and processes due tasks, sleeping for PollInterval when there are none
+func (q *Queue) work(ctx context.Context) {
+	defer q.running.Done()
+
+	for ctx.Err() == nil {
+		task, err := q.claim(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("taskqueue: failed to claim a task: %v", err)
+		}
+		if task != nil {
+			q.process(ctx, task)
+			continue
+		}
+
+		timer := time.NewTimer(q.opts.PollInterval)
+		select {
+		case <-ctx.Done():
+		case <-q.wake:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// signal wakes one idle worker after a task is enqueued
+func (q *Queue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// types lists the registered task types
+func (q *Queue) types() []string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	types := make([]string, 0, len(q.handlers))
+	for taskType := range q.handlers {
+		types = append(types, taskType)
+	}
+	return types
+}
+
+// handler returns the handler of a task type
+func (q *Queue) handler(taskType string) Handler {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.handlers[taskType]
+}
+
+// process runs the handler of a claimed task and records the outcome
+// The outcome is written even when ctx is cancelled mid-run, so a task cut short by
+// shutdown is retried rather than left to be reclaimed
+func (q *Queue) process(ctx context.Context, task *Task) {
+	started := time.Now()
+	runCtx, cancel := context.WithTimeout(tenancy.NewContext(ctx, task.TenantID), q.opts.LockTimeout)
+	runCtx, state := withRun(runCtx, q, task)
+	err := q.run(runCtx, task)
+	cancel()
+	observeTask(task.Type, err, time.Since(started))
+
+	result, checkpointed := state.outcome()
+	if err := q.finish(context.WithoutCancel(ctx), task, started, result, checkpointed, err); err != nil {
+		log.Printf("taskqueue: failed to record outcome of task %d: %v", task.ID, err)
+	}
+}
+
+// run calls the handler, turning a panic into a permanent failure
+func (q *Queue) run(ctx context.Context, task *Task) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = retry.Permanent(fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	handler := q.handler(task.Type)
+	if handler == nil {
+		return retry.Permanent(fmt.Errorf("no handler registered for %s", task.Type))
+	}
+	return handler(ctx, task)
+}
+
+// truncate limits an error message to the error column
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength]
+	}
+	return s
+}
@@ -0,0 +1,397 @@
+package taskqueue_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/retry"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+	"clean-arch-gin/internal/testutil/repotest"
+
+	"gorm.io/gorm"
+)
+
+// testType is the task type the tests register
+const testType = "test.task"
+
+// newQueue creates a queue on SQLite that polls and retries within milliseconds unless
+// backoff is set
+func newQueue(t *testing.T, backoff time.Duration) (*taskqueue.Queue, *gorm.DB) {
+	t.Helper()
+	db := repotest.OpenSQLite(t)
+	if err := database.AutoMigrate(db, &taskqueue.TaskModel{}, &taskqueue.TaskAttemptModel{}); err != nil {
+		t.Fatalf("migrate tasks: %v", err)
+	}
+	if backoff <= 0 {
+		backoff = time.Millisecond
+	}
+	return taskqueue.New(db, taskqueue.Options{
+		Workers:      2,
+		PollInterval: 10 * time.Millisecond,
+		Backoff:      retry.Policy{InitialDelay: backoff, MaxDelay: backoff, Jitter: -1},
+		WorkerID:     "worker-1",
+	}), db
+}
+
+// start runs the workers of q until the test ends
+func start(t *testing.T, q *taskqueue.Queue) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	q.Start(ctx)
+	t.Cleanup(func() {
+		cancel()
+		shutdownCtx, done := context.WithTimeout(context.Background(), 5*time.Second)
+		defer done()
+		if err := q.Shutdown(shutdownCtx); err != nil {
+			t.Errorf("Shutdown() error = %v", err)
+		}
+	})
+}
+
+// enqueue enqueues a task of testType, failing the test on error
+func enqueue(t *testing.T, q *taskqueue.Queue, opts ...taskqueue.EnqueueOption) *taskqueue.Task {
+	t.Helper()
+	task, err := q.Enqueue(context.Background(), testType, map[string]string{"report": "sales"}, opts...)
+	if err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	return task
+}
+
+// waitForStatus polls the task until it has status, failing the test after a few seconds
+func waitForStatus(t *testing.T, q *taskqueue.Queue, id uint, status taskqueue.Status) *taskqueue.Task {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		task, err := q.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if task.Status == status {
+			return task
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("task %d is %s after %d attempts, want %s", id, task.Status, task.Attempts, status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// failingTimes returns a handler that fails its first n runs and then succeeds
+func failingTimes(n int32) taskqueue.Handler {
+	var runs atomic.Int32
+	return func(ctx context.Context, task *taskqueue.Task) error {
+		if runs.Add(1) <= n {
+			return fmt.Errorf("run %d failed", runs.Load())
+		}
+		return nil
+	}
+}
+
+func TestQueueRunsTasks(t *testing.T) {
+	tests := []struct {
+		name         string
+		handler      taskqueue.Handler
+		maxAttempts  int
+		wantStatus   taskqueue.Status
+		wantAttempts int
+		// wantHistory is how many failed attempts are recorded
+		wantHistory int
+		wantError   string
+		wantResult  string
+	}{
+		{
+			name: "succeeds with a result",
+			handler: func(ctx context.Context, task *taskqueue.Task) error {
+				var payload map[string]string
+				if err := task.Decode(&payload); err != nil {
+					return err
+				}
+				if tenantID, ok := tenancy.FromContext(ctx); !ok || tenantID != task.TenantID {
+					return retry.Permanent(fmt.Errorf("ran in tenant %d, want %d", tenantID, task.TenantID))
+				}
+				return taskqueue.SetResult(ctx, payload)
+			},
+			wantStatus:   taskqueue.StatusSucceeded,
+			wantAttempts: 1,
+			wantResult:   `{"report":"sales"}`,
+		},
+		{
+			name:         "succeeds on a retry",
+			handler:      failingTimes(2),
+			wantStatus:   taskqueue.StatusSucceeded,
+			wantAttempts: 3,
+			wantHistory:  2,
+		},
+		{
+			name:         "dead once out of attempts",
+			handler:      failingTimes(10),
+			maxAttempts:  3,
+			wantStatus:   taskqueue.StatusDead,
+			wantAttempts: 3,
+			wantHistory:  3,
+			wantError:    "run 3 failed",
+		},
+		{
+			name: "dead on a permanent error",
+			handler: func(context.Context, *taskqueue.Task) error {
+				return retry.Permanent(errors.New("report not found"))
+			},
+			wantStatus:   taskqueue.StatusDead,
+			wantAttempts: 1,
+			wantHistory:  1,
+			wantError:    "report not found",
+		},
+		{
+			name: "dead on a panic",
+			handler: func(context.Context, *taskqueue.Task) error {
+				panic("nil map")
+			},
+			wantStatus:   taskqueue.StatusDead,
+			wantAttempts: 1,
+			wantHistory:  1,
+			wantError:    "panic: nil map",
+		},
+		{
+			name: "resumes from a checkpoint without using up an attempt",
+			handler: func(ctx context.Context, task *taskqueue.Task) error {
+				var done int
+				if resumed, err := task.DecodeCheckpoint(&done); err != nil || resumed {
+					return taskqueue.SetResult(ctx, done)
+				}
+				if err := taskqueue.SaveCheckpoint(ctx, 50); err != nil {
+					return err
+				}
+				return errors.New("connection reset")
+			},
+			maxAttempts:  1,
+			wantStatus:   taskqueue.StatusSucceeded,
+			wantAttempts: 1,
+			wantHistory:  1,
+			wantResult:   "50",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := newQueue(t, 0)
+			if err := q.Register(testType, tt.handler); err != nil {
+				t.Fatalf("Register() error = %v", err)
+			}
+			start(t, q)
+			task := enqueue(t, q, taskqueue.MaxAttempts(tt.maxAttempts))
+
+			got := waitForStatus(t, q, task.ID, tt.wantStatus)
+			if got.Attempts != tt.wantAttempts || got.LastError != tt.wantError || got.FinishedAt == nil {
+				t.Errorf("task = %d attempts, error %q, finished %v; want %d attempts, error %q, finished",
+					got.Attempts, got.LastError, got.FinishedAt, tt.wantAttempts, tt.wantError)
+			}
+			if string(got.Result) != tt.wantResult || got.Checkpoint != nil {
+				t.Errorf("result = %s, checkpoint = %s; want result %s and no checkpoint", got.Result, got.Checkpoint, tt.wantResult)
+			}
+			if tt.wantStatus == taskqueue.StatusSucceeded && got.Progress != 100 {
+				t.Errorf("progress = %d, want 100", got.Progress)
+			}
+			history, err := q.Attempts(context.Background(), task.ID)
+			if err != nil || len(history) != tt.wantHistory {
+				t.Errorf("Attempts() = %+v, %v; want %d failed attempts", history, err, tt.wantHistory)
+			}
+			if len(history) > 0 && history[0].WorkerID != "worker-1" {
+				t.Errorf("attempt run by %q, want worker-1", history[0].WorkerID)
+			}
+		})
+	}
+}
+
+func TestQueueBacksOffAfterAFailure(t *testing.T) {
+	q, _ := newQueue(t, time.Hour)
+	if err := q.Register(testType, func(ctx context.Context, task *taskqueue.Task) error {
+		taskqueue.ReportProgress(ctx, 40)
+		return errors.New("upstream down")
+	}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	start(t, q)
+	task := enqueue(t, q)
+
+	deadline := time.Now().Add(3 * time.Second)
+	for task.Attempts == 0 || task.Status != taskqueue.StatusPending {
+		if time.Now().After(deadline) {
+			t.Fatalf("task is %s after %d attempts, want pending after 1", task.Status, task.Attempts)
+		}
+		time.Sleep(10 * time.Millisecond)
+		task = waitForStatus(t, q, task.ID, taskqueue.StatusPending)
+	}
+	if task.Attempts != 1 || task.LastError != "upstream down" {
+		t.Errorf("task = %d attempts, error %q; want 1 attempt, error %q", task.Attempts, task.LastError, "upstream down")
+	}
+	if wait := time.Until(task.RunAfter); wait < 59*time.Minute {
+		t.Errorf("retry due in %s, want the 1h backoff", wait)
+	}
+	if task.Progress != 0 {
+		t.Errorf("progress = %d, want it reset for a retry that starts over", task.Progress)
+	}
+}
+
+func TestQueueClaims(t *testing.T) {
+	lockExpired := time.Now().Add(-time.Minute)
+	lockHeld := time.Now().Add(time.Minute)
+	tests := []struct {
+		name string
+		task taskqueue.TaskModel
+		want bool
+	}{
+		{"due", taskqueue.TaskModel{Type: testType, Status: string(taskqueue.StatusPending), RunAfter: time.Now()}, true},
+		{"scheduled later", taskqueue.TaskModel{Type: testType, Status: string(taskqueue.StatusPending), RunAfter: time.Now().Add(time.Hour)}, false},
+		{"unregistered type", taskqueue.TaskModel{Type: "other.task", Status: string(taskqueue.StatusPending), RunAfter: time.Now()}, false},
+		{"held by a worker that crashed", taskqueue.TaskModel{Type: testType, Status: string(taskqueue.StatusRunning), Attempts: 1,
+			LockedBy: "worker-2", LockedUntil: &lockExpired}, true},
+		{"held by a live worker", taskqueue.TaskModel{Type: testType, Status: string(taskqueue.StatusRunning), Attempts: 1,
+			LockedBy: "worker-2", LockedUntil: &lockHeld}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, db := newQueue(t, 0)
+			var runs atomic.Int32
+			if err := q.Register(testType, func(context.Context, *taskqueue.Task) error {
+				runs.Add(1)
+				return nil
+			}); err != nil {
+				t.Fatalf("Register() error = %v", err)
+			}
+			tt.task.Payload, tt.task.MaxAttempts = []byte("{}"), 3
+			if err := db.Create(&tt.task).Error; err != nil {
+				t.Fatalf("insert task: %v", err)
+			}
+			start(t, q)
+
+			if tt.want {
+				waitForStatus(t, q, tt.task.ID, taskqueue.StatusSucceeded)
+				return
+			}
+			time.Sleep(100 * time.Millisecond)
+			if n := runs.Load(); n != 0 {
+				t.Errorf("task ran %d times, want none", n)
+			}
+			if got, _ := q.Get(context.Background(), tt.task.ID); got.Status != taskqueue.Status(tt.task.Status) {
+				t.Errorf("status = %s, want %s", got.Status, tt.task.Status)
+			}
+		})
+	}
+}
+
+func TestQueueReportsProgress(t *testing.T) {
+	q, _ := newQueue(t, 0)
+	reported := make(chan struct{})
+	release := make(chan struct{})
+	if err := q.Register(testType, func(ctx context.Context, task *taskqueue.Task) error {
+		taskqueue.ReportProgress(ctx, 150)
+		taskqueue.ReportPhase(ctx, "exporting")
+		taskqueue.ReportItems(ctx, 30, 40)
+		close(reported)
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	start(t, q)
+	task := enqueue(t, q, taskqueue.Owner("42"))
+
+	<-reported
+	running, err := q.Get(context.Background(), task.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if running.Progress != 75 || running.Phase != "exporting" || running.Processed != 30 || running.Total != 40 || running.Owner != "42" {
+		t.Errorf("running task = %d%% %s %d/%d of %q, want 75%% exporting 30/40 of 42",
+			running.Progress, running.Phase, running.Processed, running.Total, running.Owner)
+	}
+	close(release)
+	waitForStatus(t, q, task.ID, taskqueue.StatusSucceeded)
+
+	// Outside a handler there is no task to report on
+	taskqueue.ReportProgress(context.Background(), 10)
+	if err := taskqueue.SetResult(context.Background(), 1); err == nil {
+		t.Error("SetResult() outside a handler error = nil, want an error")
+	}
+}
+
+func TestDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	q, _ := newQueue(t, 0)
+	var failing atomic.Bool
+	failing.Store(true)
+	if err := q.Register(testType, func(context.Context, *taskqueue.Task) error {
+		if failing.Load() {
+			return retry.Permanent(errors.New("bad payload"))
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	start(t, q)
+	requeued, discarded := enqueue(t, q), enqueue(t, q)
+	waitForStatus(t, q, requeued.ID, taskqueue.StatusDead)
+	waitForStatus(t, q, discarded.ID, taskqueue.StatusDead)
+
+	dead, total, err := q.ListDead(ctx, testType, 10, 0)
+	if err != nil || total != 2 || len(dead) != 2 {
+		t.Fatalf("ListDead() = %d of %d, %v; want 2", len(dead), total, err)
+	}
+	if _, total, _ := q.ListDead(ctx, "other.task", 10, 0); total != 0 {
+		t.Errorf("ListDead() of another type = %d, want 0", total)
+	}
+
+	failing.Store(false)
+	if _, err := q.Requeue(ctx, requeued.ID); err != nil {
+		t.Fatalf("Requeue() error = %v", err)
+	}
+	task := waitForStatus(t, q, requeued.ID, taskqueue.StatusSucceeded)
+	if task.Attempts != 1 {
+		t.Errorf("requeued task took %d attempts, want a fresh first one", task.Attempts)
+	}
+	if history, _ := q.Attempts(ctx, requeued.ID); len(history) != 1 {
+		t.Errorf("requeued task has %d failed attempts, want the one before the requeue kept", len(history))
+	}
+
+	if err := q.Discard(ctx, discarded.ID); err != nil {
+		t.Fatalf("Discard() error = %v", err)
+	}
+	if history, _ := q.Attempts(ctx, discarded.ID); len(history) != 0 {
+		t.Errorf("discarded task has %d failed attempts left, want none", len(history))
+	}
+
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr error
+	}{
+		{"requeue a task that succeeded", func() error { _, err := q.Requeue(ctx, requeued.ID); return err }, taskqueue.ErrTaskNotDead},
+		{"discard a task that succeeded", func() error { return q.Discard(ctx, requeued.ID) }, taskqueue.ErrTaskNotDead},
+		{"requeue a discarded task", func() error { _, err := q.Requeue(ctx, discarded.ID); return err }, taskqueue.ErrTaskNotFound},
+		{"discard a discarded task", func() error { return q.Discard(ctx, discarded.ID) }, taskqueue.ErrTaskNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	purged, err := q.PurgeFinished(ctx, time.Now().Add(time.Minute))
+	if err != nil || purged != 1 {
+		t.Errorf("PurgeFinished() = %d, %v; want the succeeded task", purged, err)
+	}
+	if err := q.Register(testType, nil); err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("Register() twice error = %v, want already registered", err)
+	}
+}
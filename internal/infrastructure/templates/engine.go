@@ -0,0 +1,429 @@
+// Package templates renders the embedded notification templates for each channel
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"text/template/parse"
+	"time"
+	"unicode/utf8"
+
+	"clean-arch-gin/internal/domain/shared/templates"
+	"clean-arch-gin/internal/infrastructure/i18n"
+)
+
+// Every template is a directory of notifications/ with a template.json manifest declaring
+// its variables and example, an in_app.tmpl variant and optionally the variants of the other
+// channels. The in-app, push and email variants define their title (the subject of emails)
+// as "title"; emails have a plain text variant and optionally an HTML one
+// Those files speak the default locale; a subdirectory named after a locale of the catalog,
+// e.g. de or pt-BR, overrides any of them in that locale. Templates translate with
+// t "key" args..., in the locale they are rendered in
+const (
+	root         = "notifications"
+	manifestFile = "template.json"
+	emailText    = "email.txt.tmpl"
+	emailHTML    = "email.html.tmpl"
+)
+
+// variantFiles names the plain text file of each channel's variant
+var variantFiles = map[templates.Channel]string{
+	templates.ChannelInApp: "in_app.tmpl",
+	templates.ChannelEmail: emailText,
+	templates.ChannelSMS:   "sms.tmpl",
+	templates.ChannelPush:  "push.tmpl",
+}
+
+// Limits of the short channels; longer renderings are cut with an ellipsis
+const (
+	SMSMaxLength       = 160
+	PushTitleMaxLength = 65
+	PushBodyMaxLength  = 240
+)
+
+//go:embed notifications
+var embedded embed.FS
+
+// funcs are the functions templates may call, besides t
+var funcs = map[string]interface{}{
+	// datetime formats a time, or an RFC 3339 string as example variables hold them, in UTC
+	"datetime": func(value interface{}) (string, error) {
+		switch v := value.(type) {
+		case time.Time:
+			return v.UTC().Format("Mon, 02 Jan 2006 15:04 MST"), nil
+		case string:
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return "", err
+			}
+			return t.UTC().Format("Mon, 02 Jan 2006 15:04 MST"), nil
+		default:
+			return "", fmt.Errorf("datetime of %T", value)
+		}
+	},
+	"upper": func(value interface{}) string {
+		return strings.ToUpper(fmt.Sprint(value))
+	},
+}
+
+// localeFuncs are funcs plus t, translating with catalog in locale
+func localeFuncs(catalog *i18n.Catalog, locale string) map[string]interface{} {
+	bound := map[string]interface{}{
+		"t": func(key string, args ...interface{}) string {
+			return catalog.Translate(locale, key, args...)
+		},
+	}
+	for name, fn := range funcs {
+		bound[name] = fn
+	}
+	return bound
+}
+
+// manifest is a template's template.json
+type manifest struct {
+	Description string                 `json:"description"`
+	Variables   map[string]string      `json:"variables"`
+	Example     map[string]interface{} `json:"example"`
+}
+
+// variant is the parsed variant of a channel; html is only set for emails with an HTML part
+type variant struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// notificationTemplate is a parsed template with its variants in every locale of the catalog
+type notificationTemplate struct {
+	templates.Template
+	variants map[string]map[templates.Channel]variant
+}
+
+// Engine renders the embedded templates, implementing templates.Engine
+// Templates are checked as they are loaded: they may only use the variables they declare
+// and the keys the catalog has, and must render their example on every channel in every
+// locale. HTML variants are escaped by html/template
+type Engine struct {
+	templates map[string]*notificationTemplate
+	names     []string
+}
+
+var _ templates.Engine = (*Engine)(nil)
+
+// NewEngine parses and checks the embedded templates, translating with catalog
+func NewEngine(catalog *i18n.Catalog) (*Engine, error) {
+	return newEngine(embedded, catalog)
+}
+
+// newEngine loads the templates in the directories of fsys's notifications/
+func newEngine(fsys fs.FS, catalog *i18n.Catalog) (*Engine, error) {
+	entries, err := fs.ReadDir(fsys, root)
+	if err != nil {
+		return nil, err
+	}
+	e := &Engine{templates: make(map[string]*notificationTemplate)}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		tmpl, err := load(fsys, entry.Name(), catalog)
+		if err != nil {
+			return nil, fmt.Errorf("notification template %s: %w", entry.Name(), err)
+		}
+		e.templates[tmpl.Name] = tmpl
+		e.names = append(e.names, tmpl.Name)
+	}
+	sort.Strings(e.names)
+	for _, name := range e.names {
+		tmpl := e.templates[name]
+		for locale := range tmpl.variants {
+			for _, channel := range tmpl.Channels {
+				if _, err := e.Render(name, channel, locale, tmpl.Example); err != nil {
+					return nil, fmt.Errorf("notification template %s does not render its example in %s: %w", name, locale, err)
+				}
+			}
+		}
+	}
+	return e, nil
+}
+
+// load parses the manifest and the variants of the template in dir in every locale
+func load(fsys fs.FS, dir string, catalog *i18n.Catalog) (*notificationTemplate, error) {
+	data, err := fs.ReadFile(fsys, path.Join(root, dir, manifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", manifestFile, err)
+	}
+	if err := checkVariables(dir, m.Variables, m.Example); err != nil {
+		return nil, fmt.Errorf("example: %w", err)
+	}
+
+	tmpl := &notificationTemplate{
+		Template: templates.Template{
+			Name:        dir,
+			Description: m.Description,
+			Variables:   m.Variables,
+			Example:     m.Example,
+			Locales:     []string{i18n.DefaultLocale},
+		},
+		variants: make(map[string]map[templates.Channel]variant),
+	}
+	for _, channel := range templates.Channels {
+		if fileExists(fsys, path.Join(root, dir, variantFiles[channel])) {
+			tmpl.Channels = append(tmpl.Channels, channel)
+		}
+	}
+	if !fileExists(fsys, path.Join(root, dir, variantFiles[templates.ChannelInApp])) {
+		return nil, fmt.Errorf("no %s", variantFiles[templates.ChannelInApp])
+	}
+	overrides, err := localeOverrides(fsys, dir, catalog)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.Locales = append(tmpl.Locales, overrides...)
+
+	locales := append([]string{i18n.DefaultLocale}, catalog.Locales()...)
+	for _, locale := range locales {
+		if _, ok := tmpl.variants[locale]; ok {
+			continue
+		}
+		variants := make(map[templates.Channel]variant)
+		for _, channel := range tmpl.Channels {
+			v, err := parseVariant(fsys, dir, channel, locale, m.Variables, catalog)
+			if err != nil {
+				return nil, err
+			}
+			variants[channel] = v
+		}
+		tmpl.variants[locale] = variants
+	}
+	return tmpl, nil
+}
+
+// localeOverrides checks and lists the locale subdirectories of dir: each must be named
+// after a locale of the catalog other than the default, and may only override files the
+// template has in the default locale
+func localeOverrides(fsys fs.FS, dir string, catalog *i18n.Catalog) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, path.Join(root, dir))
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool)
+	for _, locale := range catalog.Locales() {
+		known[locale] = locale != i18n.DefaultLocale
+	}
+	var locales []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		locale := entry.Name()
+		if !known[locale] {
+			return nil, fmt.Errorf("%s/ is not a locale of the catalog other than %s", locale, i18n.DefaultLocale)
+		}
+		files, err := fs.ReadDir(fsys, path.Join(root, dir, locale))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if !fileExists(fsys, path.Join(root, dir, file.Name())) || file.Name() == manifestFile {
+				return nil, fmt.Errorf("%s/%s overrides no file of the template", locale, file.Name())
+			}
+		}
+		locales = append(locales, locale)
+	}
+	return locales, nil
+}
+
+// parseVariant parses the channel's variant in locale from the files found first along the
+// locale's fallback chain
+func parseVariant(fsys fs.FS, dir string, channel templates.Channel, locale string, declared map[string]string,
+	catalog *i18n.Catalog) (variant, error) {
+	bound := localeFuncs(catalog, locale)
+	file := localized(fsys, dir, locale, variantFiles[channel])
+	text, err := texttemplate.New(path.Base(file)).Option("missingkey=error").Funcs(bound).ParseFS(fsys, file)
+	if err != nil {
+		return variant{}, err
+	}
+	v := variant{text: text}
+	if channel != templates.ChannelSMS && text.Lookup("title") == nil {
+		return variant{}, fmt.Errorf("%s defines no title", file)
+	}
+	if err := checkTrees(textTrees(text), declared, catalog.Has); err != nil {
+		return variant{}, fmt.Errorf("%s: %w", file, err)
+	}
+	if channel == templates.ChannelEmail && fileExists(fsys, path.Join(root, dir, emailHTML)) {
+		html := localized(fsys, dir, locale, emailHTML)
+		if v.html, err = htmltemplate.New(emailHTML).Option("missingkey=error").Funcs(bound).ParseFS(fsys, html); err != nil {
+			return variant{}, err
+		}
+		if err := checkTrees(htmlTrees(v.html), declared, catalog.Has); err != nil {
+			return variant{}, fmt.Errorf("%s: %w", html, err)
+		}
+	}
+	return v, nil
+}
+
+// localized is the path of the template's file in the first locale of locale's chain that
+// overrides it, or of the default one
+func localized(fsys fs.FS, dir, locale, file string) string {
+	for _, candidate := range i18n.Fallbacks(locale) {
+		if candidate == i18n.DefaultLocale {
+			break
+		}
+		if override := path.Join(root, dir, candidate, file); fileExists(fsys, override) {
+			return override
+		}
+	}
+	return path.Join(root, dir, file)
+}
+
+// Render renders the channel's variant of name in the first locale of locale's chain the
+// catalog has, with vars
+func (e *Engine) Render(name string, channel templates.Channel, locale string, vars map[string]interface{}) (*templates.Content, error) {
+	tmpl, ok := e.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", templates.ErrUnknownTemplate, name)
+	}
+	if err := checkVariables(name, tmpl.Variables, vars); err != nil {
+		return nil, err
+	}
+	v, ok := tmpl.variantsIn(locale)[channel]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s has no %s variant", templates.ErrNoVariant, name, channel)
+	}
+
+	content := &templates.Content{Channel: channel}
+	var err error
+	if channel != templates.ChannelSMS {
+		if content.Title, err = execute(v.text, "title", vars); err != nil {
+			return nil, fmt.Errorf("%w %s: %v", templates.ErrRender, name, err)
+		}
+	}
+	if content.Body, err = execute(v.text, "", vars); err != nil {
+		return nil, fmt.Errorf("%w %s: %v", templates.ErrRender, name, err)
+	}
+	if v.html != nil {
+		var buf bytes.Buffer
+		if err := v.html.Execute(&buf, vars); err != nil {
+			return nil, fmt.Errorf("%w %s: %v", templates.ErrRender, name, err)
+		}
+		content.HTML = buf.String()
+	}
+
+	switch channel {
+	case templates.ChannelSMS:
+		content.Body = truncate(strings.Join(strings.Fields(content.Body), " "), SMSMaxLength)
+	case templates.ChannelPush:
+		content.Title = truncate(content.Title, PushTitleMaxLength)
+		content.Body = truncate(content.Body, PushBodyMaxLength)
+	}
+	return content, nil
+}
+
+// variantsIn returns the variants of the first locale of locale's chain the template was
+// parsed in; the chain ends with the default locale, which always was
+func (t *notificationTemplate) variantsIn(locale string) map[templates.Channel]variant {
+	for _, candidate := range i18n.Fallbacks(locale) {
+		if variants, ok := t.variants[candidate]; ok {
+			return variants
+		}
+	}
+	return t.variants[i18n.DefaultLocale]
+}
+
+// Template describes the template with name
+func (e *Engine) Template(name string) (templates.Template, bool) {
+	tmpl, ok := e.templates[name]
+	if !ok {
+		return templates.Template{}, false
+	}
+	return tmpl.Template, true
+}
+
+// Templates describes every template, by name
+func (e *Engine) Templates() []templates.Template {
+	described := make([]templates.Template, len(e.names))
+	for i, name := range e.names {
+		described[i] = e.templates[name].Template
+	}
+	return described
+}
+
+// execute renders the named template of t, t itself for "", trimmed
+func execute(t *texttemplate.Template, name string, vars map[string]interface{}) (string, error) {
+	var buf bytes.Buffer
+	var err error
+	if name == "" {
+		err = t.Execute(&buf, vars)
+	} else {
+		err = t.ExecuteTemplate(&buf, name, vars)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// checkVariables compares vars to the declared variables
+func checkVariables(name string, declared map[string]string, vars map[string]interface{}) error {
+	verr := &templates.VariablesError{Template: name}
+	for variable := range declared {
+		if _, ok := vars[variable]; !ok {
+			verr.Missing = append(verr.Missing, variable)
+		}
+	}
+	for variable := range vars {
+		if _, ok := declared[variable]; !ok {
+			verr.Unknown = append(verr.Unknown, variable)
+		}
+	}
+	if len(verr.Missing) == 0 && len(verr.Unknown) == 0 {
+		return nil
+	}
+	sort.Strings(verr.Missing)
+	sort.Strings(verr.Unknown)
+	return verr
+}
+
+// truncate cuts s to max runes, ending it with an ellipsis when it is cut
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:max-1])) + "…"
+}
+
+// textTrees are the parse trees of t and the templates it defines
+func textTrees(t *texttemplate.Template) []*parse.Tree {
+	var trees []*parse.Tree
+	for _, associated := range t.Templates() {
+		trees = append(trees, associated.Tree)
+	}
+	return trees
+}
+
+// htmlTrees are the parse trees of t and the templates it defines
+func htmlTrees(t *htmltemplate.Template) []*parse.Tree {
+	var trees []*parse.Tree
+	for _, associated := range t.Templates() {
+		trees = append(trees, associated.Tree)
+	}
+	return trees
+}
+
+func fileExists(fsys fs.FS, name string) bool {
+	_, err := fs.Stat(fsys, name)
+	return err == nil
+}
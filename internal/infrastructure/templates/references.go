@@ -0,0 +1,103 @@
+package templates
+
+import (
+	"fmt"
+	"sort"
+	"text/template/parse"
+)
+
+// references are the variables and the literal message keys, those of t "key", trees use
+type references struct {
+	variables map[string]bool
+	keys      map[string]bool
+}
+
+// checkTrees fails when the trees reference variables that are not declared or translate
+// keys for which known is false. Fields of the top-level dot and of $ are variables; within
+// with and range the dot is something else
+func checkTrees(trees []*parse.Tree, declared map[string]string, known func(key string) bool) error {
+	referenced := &references{variables: make(map[string]bool), keys: make(map[string]bool)}
+	for _, tree := range trees {
+		if tree != nil && tree.Root != nil {
+			collect(tree.Root, true, referenced)
+		}
+	}
+	var undeclared, unknown []string
+	for name := range referenced.variables {
+		if _, ok := declared[name]; !ok {
+			undeclared = append(undeclared, name)
+		}
+	}
+	if len(undeclared) > 0 {
+		sort.Strings(undeclared)
+		return fmt.Errorf("undeclared variables %v", undeclared)
+	}
+	for key := range referenced.keys {
+		if !known(key) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown message keys %v", unknown)
+	}
+	return nil
+}
+
+// collect adds the variables and keys node references to referenced; topLevel tells
+// whether the dot is still the variables
+func collect(node parse.Node, topLevel bool, referenced *references) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collect(child, topLevel, referenced)
+		}
+	case *parse.ActionNode:
+		collect(n.Pipe, topLevel, referenced)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collect(cmd, topLevel, referenced)
+		}
+	case *parse.CommandNode:
+		if len(n.Args) > 1 {
+			if ident, ok := n.Args[0].(*parse.IdentifierNode); ok && ident.Ident == "t" {
+				if key, ok := n.Args[1].(*parse.StringNode); ok {
+					referenced.keys[key.Text] = true
+				}
+			}
+		}
+		for _, arg := range n.Args {
+			collect(arg, topLevel, referenced)
+		}
+	case *parse.FieldNode:
+		if topLevel {
+			referenced.variables[n.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			referenced.variables[n.Ident[1]] = true
+		}
+	case *parse.ChainNode:
+		collect(n.Node, topLevel, referenced)
+	case *parse.IfNode:
+		collect(n.Pipe, topLevel, referenced)
+		collect(n.List, topLevel, referenced)
+		collect(n.ElseList, topLevel, referenced)
+	case *parse.WithNode:
+		collect(n.Pipe, topLevel, referenced)
+		collect(n.List, false, referenced)
+		collect(n.ElseList, topLevel, referenced)
+	case *parse.RangeNode:
+		collect(n.Pipe, topLevel, referenced)
+		collect(n.List, false, referenced)
+		collect(n.ElseList, topLevel, referenced)
+	case *parse.TemplateNode:
+		collect(n.Pipe, topLevel, referenced)
+	}
+}
@@ -0,0 +1,66 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLimiter keeps the attempts in process memory, so each replica counts its own
+type MemoryLimiter struct {
+	opts Options
+
+	mu       sync.Mutex
+	attempts map[string][]time.Time
+	// pruned is when keys without recent attempts were last dropped
+	pruned time.Time
+}
+
+var _ Limiter = (*MemoryLimiter)(nil)
+
+// NewMemoryLimiter creates a limiter counting attempts in memory
+func NewMemoryLimiter(opts Options) *MemoryLimiter {
+	return &MemoryLimiter{opts: opts.withDefaults(), attempts: make(map[string][]time.Time), pruned: time.Now()}
+}
+
+// Attempt records an attempt of key unless it must wait
+func (l *MemoryLimiter) Attempt(ctx context.Context, key string) (time.Duration, error) {
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.pruned) >= l.opts.Window {
+		for k, times := range l.attempts {
+			if len(recent(times, now.Add(-l.opts.Window))) == 0 {
+				delete(l.attempts, k)
+			}
+		}
+		l.pruned = now
+	}
+
+	times := recent(l.attempts[key], now.Add(-l.opts.Window))
+	if len(times) > 0 {
+		if wait := l.opts.wait(len(times), times[0], times[len(times)-1], now); wait > 0 {
+			l.attempts[key] = times
+			return wait, nil
+		}
+	}
+	l.attempts[key] = append(times, now)
+	return 0, nil
+}
+
+// Reset forgets the attempts of key
+func (l *MemoryLimiter) Reset(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.attempts, key)
+	return nil
+}
+
+// recent drops the attempts made at or before since, keeping times in order
+func recent(times []time.Time, since time.Time) []time.Time {
+	for len(times) > 0 && !times[0].After(since) {
+		times = times[1:]
+	}
+	return times
+}
@@ -0,0 +1,70 @@
+package throttle
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// attemptScript applies Options.wait to the attempts kept as a sorted set of millisecond
+// timestamps and records the attempt when it may go through, atomically
+var attemptScript = redis.NewScript(`
+local now, window, limit = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3])
+local free, base, max = tonumber(ARGV[4]), tonumber(ARGV[5]), tonumber(ARGV[6])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+local attempts = redis.call("ZCARD", KEYS[1])
+if attempts >= limit then
+	local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+	return tonumber(oldest[2]) + window - now
+end
+if attempts >= free and base > 0 then
+	local delay = math.min(base * 2 ^ (attempts - free), max)
+	local newest = redis.call("ZRANGE", KEYS[1], -1, -1, "WITHSCORES")
+	local wait = tonumber(newest[2]) + delay - now
+	if wait > 0 then
+		return wait
+	end
+end
+redis.call("ZADD", KEYS[1], now, ARGV[7])
+redis.call("PEXPIRE", KEYS[1], window)
+return 0
+`)
+
+// RedisLimiter keeps the attempts in Redis, so every replica shares the same counts
+type RedisLimiter struct {
+	client redis.UniversalClient
+	prefix string
+	opts   Options
+}
+
+var _ Limiter = (*RedisLimiter)(nil)
+
+// NewRedisLimiter creates a limiter keeping the attempts of key under prefix+key
+func NewRedisLimiter(client redis.UniversalClient, prefix string, opts Options) *RedisLimiter {
+	return &RedisLimiter{client: client, prefix: prefix, opts: opts.withDefaults()}
+}
+
+// Attempt records an attempt of key unless it must wait
+func (l *RedisLimiter) Attempt(ctx context.Context, key string) (time.Duration, error) {
+	// Attempts made in the same millisecond need distinct members
+	member := make([]byte, 8)
+	if _, err := rand.Read(member); err != nil {
+		return 0, err
+	}
+	wait, err := attemptScript.Run(ctx, l.client, []string{l.prefix + key},
+		time.Now().UnixMilli(), l.opts.Window.Milliseconds(), l.opts.Limit,
+		l.opts.FreeAttempts, l.opts.BaseDelay.Milliseconds(), l.opts.MaxDelay.Milliseconds(),
+		hex.EncodeToString(member)).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
+// Reset forgets the attempts of key
+func (l *RedisLimiter) Reset(ctx context.Context, key string) error {
+	return l.client.Del(ctx, l.prefix+key).Err()
+}
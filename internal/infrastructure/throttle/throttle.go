@@ -0,0 +1,58 @@
+// Package throttle limits how often a key, such as a client IP or an account, may attempt
+// an operation, with a sliding window and a growing delay between attempts
+package throttle
+
+import (
+	"context"
+	"time"
+)
+
+// Options configures a limiter
+type Options struct {
+	// Limit is how many attempts a key may make within Window
+	Limit  int
+	Window time.Duration
+	// FreeAttempts are let through without delay; after them each attempt must wait
+	// BaseDelay, doubled per further attempt in the window, up to MaxDelay. A zero
+	// BaseDelay only applies Limit
+	FreeAttempts int
+	BaseDelay    time.Duration
+	// MaxDelay caps the delay (Window when zero)
+	MaxDelay time.Duration
+}
+
+// Limiter records attempts per key and rejects those over the limits
+type Limiter interface {
+	// Attempt records an attempt of key and returns 0, or returns how long key must wait
+	// before its next attempt is let through without recording it
+	Attempt(ctx context.Context, key string) (time.Duration, error)
+	// Reset forgets the attempts of key, e.g. after a successful sign-in
+	Reset(ctx context.Context, key string) error
+}
+
+// withDefaults fills in the zero fields of opts
+func (opts Options) withDefaults() Options {
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = opts.Window
+	}
+	return opts
+}
+
+// wait returns how long a key with attempts in the window, oldest and newest first made
+// at oldest and newest, must wait at now; 0 lets the attempt through
+func (opts Options) wait(attempts int, oldest, newest, now time.Time) time.Duration {
+	if attempts >= opts.Limit {
+		return oldest.Add(opts.Window).Sub(now)
+	}
+	if attempts < opts.FreeAttempts || opts.BaseDelay <= 0 {
+		return 0
+	}
+	delay := opts.MaxDelay
+	if shift := attempts - opts.FreeAttempts; shift < 32 && opts.BaseDelay<<shift < opts.MaxDelay {
+		delay = opts.BaseDelay << shift
+	}
+	if wait := newest.Add(delay).Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
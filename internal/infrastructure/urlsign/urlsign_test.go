@@ -0,0 +1,113 @@
+package urlsign_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/infrastructure/urlsign"
+)
+
+// signed signs rawURL with signer to expire at expiresAt, failing the test on error
+func signed(t *testing.T, signer *urlsign.Signer, rawURL string, expiresAt time.Time) *url.URL {
+	t.Helper()
+	signedURL, err := signer.Sign(rawURL, expiresAt)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	u, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatalf("parse %s: %v", signedURL, err)
+	}
+	return u
+}
+
+func TestVerify(t *testing.T) {
+	signer := urlsign.New([]byte("secret"))
+	valid := signed(t, signer, "https://cdn.example.com/files/avatars/1.png?size=64", time.Now().Add(time.Hour))
+	expired := signed(t, signer, "/files/avatars/1.png", time.Now().Add(-time.Minute))
+	otherSecret := signed(t, urlsign.New([]byte("other")), "/files/avatars/1.png", time.Now().Add(time.Hour))
+
+	// with returns the query of valid with key set to value
+	with := func(key, value string) url.Values {
+		query := valid.Query()
+		query.Set(key, value)
+		return query
+	}
+	// without returns the query of valid without key
+	without := func(key string) url.Values {
+		query := valid.Query()
+		query.Del(key)
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		signer  *urlsign.Signer
+		path    string
+		query   url.Values
+		wantErr error
+	}{
+		{"signed", signer, valid.Path, valid.Query(), nil},
+		{"other parameters changed", signer, valid.Path, with("size", "1024"), nil},
+		{"other path", signer, "/files/avatars/2.png", valid.Query(), urlsign.ErrInvalidSignature},
+		{"same key under another mount", signer, "/private" + valid.Path, valid.Query(), urlsign.ErrInvalidSignature},
+		{"expiry extended", signer, valid.Path, with(urlsign.ExpiresParam, "9999999999"), urlsign.ErrInvalidSignature},
+		{"expiry not a number", signer, valid.Path, with(urlsign.ExpiresParam, "soon"), urlsign.ErrInvalidSignature},
+		{"signature tampered", signer, valid.Path, with(urlsign.SignatureParam, strings.Repeat("0", 64)), urlsign.ErrInvalidSignature},
+		{"no signature", signer, valid.Path, without(urlsign.SignatureParam), urlsign.ErrInvalidSignature},
+		{"expired", signer, expired.Path, expired.Query(), urlsign.ErrExpired},
+		{"signed with another secret", signer, otherSecret.Path, otherSecret.Query(), urlsign.ErrInvalidSignature},
+		{"signer without a secret", urlsign.New(nil), valid.Path, valid.Query(), urlsign.ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.signer.Verify(tt.path, tt.query); err != tt.wantErr {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSignKeepsTheURL(t *testing.T) {
+	u := signed(t, urlsign.New([]byte("secret")), "https://cdn.example.com/files/a.pdf?download=1", time.Unix(1700000000, 0))
+	if u.Host != "cdn.example.com" || u.Path != "/files/a.pdf" {
+		t.Errorf("signed URL = %s, want the same host and path", u)
+	}
+	if query := u.Query(); query.Get("download") != "1" || query.Get(urlsign.ExpiresParam) != "1700000000" {
+		t.Errorf("signed query = %s, want download=1 and the expiry", u.RawQuery)
+	}
+	if _, err := urlsign.New(nil).Sign("/files/a.pdf", time.Now()); err != urlsign.ErrInvalidSignature {
+		t.Errorf("Sign() without a secret error = %v, want %v", err, urlsign.ErrInvalidSignature)
+	}
+}
+
+func TestRequire(t *testing.T) {
+	signer := urlsign.New([]byte("secret"))
+	handler := signer.Require(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("file"))
+	}))
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantBody   string
+	}{
+		{"signed", signed(t, signer, "/files/a.pdf", time.Now().Add(time.Hour)).String(), http.StatusOK, "file"},
+		{"unsigned", "/files/a.pdf", http.StatusForbidden, "invalid signature\n"},
+		{"expired", signed(t, signer, "/files/a.pdf", time.Now().Add(-time.Minute)).String(), http.StatusForbidden, "link expired\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			if rec.Code != tt.wantStatus || rec.Body.String() != tt.wantBody {
+				t.Errorf("GET %s = %d %q, want %d %q", tt.url, rec.Code, rec.Body.String(), tt.wantStatus, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package workerpool
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	poolWorkers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "workerpool_workers",
+		Help: "Workers of each worker pool.",
+	}, []string{"pool"})
+
+	poolBusyWorkers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "workerpool_busy_workers",
+		Help: "Workers running a task; at workerpool_workers the pool is saturated.",
+	}, []string{"pool"})
+
+	poolQueueCapacity = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "workerpool_queue_capacity",
+		Help: "How many tasks the queue of each worker pool holds.",
+	}, []string{"pool"})
+
+	poolQueueLength = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "workerpool_queue_length",
+		Help: "Tasks waiting for a worker.",
+	}, []string{"pool"})
+
+	poolRejectedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "workerpool_rejected_total",
+		Help: "Tasks rejected because the queue was full.",
+	}, []string{"pool"})
+
+	poolTasksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "workerpool_tasks_total",
+		Help: "Tasks run by each worker pool by result: completed or panicked.",
+	}, []string{"pool", "result"})
+
+	poolQueueWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "workerpool_queue_wait_seconds",
+		Help:    "Time tasks waited in the queue for a worker.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+	}, []string{"pool"})
+)
+
+// observeCapacity records the workers and queue size of a new pool
+func observeCapacity(pool string, workers, queueDepth int) {
+	poolWorkers.WithLabelValues(pool).Set(float64(workers))
+	poolQueueCapacity.WithLabelValues(pool).Set(float64(queueDepth))
+}
+
+// observeQueue records the length of the queue after a task was queued
+func observeQueue(pool string, length int) {
+	poolQueueLength.WithLabelValues(pool).Set(float64(length))
+}
+
+// observeRejected counts a rejected task
+func observeRejected(pool string) {
+	poolRejectedTotal.WithLabelValues(pool).Inc()
+}
+
+// observeStart records a task taken off the queue after waiting for wait
+func observeStart(pool string, length int, wait time.Duration) {
+	poolQueueLength.WithLabelValues(pool).Set(float64(length))
+	poolBusyWorkers.WithLabelValues(pool).Inc()
+	poolQueueWait.WithLabelValues(pool).Observe(wait.Seconds())
+}
+
+// observeDone records a finished task
+func observeDone(pool string, panicked bool) {
+	result := "completed"
+	if panicked {
+		result = "panicked"
+	}
+	poolBusyWorkers.WithLabelValues(pool).Dec()
+	poolTasksTotal.WithLabelValues(pool, result).Inc()
+}
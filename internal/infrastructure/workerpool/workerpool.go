@@ -0,0 +1,191 @@
+// Package workerpool runs tasks on a fixed number of goroutines fed by a bounded queue
+// A full queue either blocks the submitter until a worker frees a slot or rejects the task
+// at once, so bursts are absorbed up to a point and then pushed back on instead of piling up
+// goroutines
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Policy is what a full queue does to a new task
+type Policy string
+
+// Policies of a full queue
+const (
+	// Block makes the submitter wait for a slot in the queue, or its context to be done
+	Block Policy = "block"
+	// Reject fails the submission at once with ErrRejected
+	Reject Policy = "reject"
+)
+
+var (
+	// ErrRejected is returned when a task is submitted to a full queue with the Reject policy
+	ErrRejected = errors.New("workerpool: queue full")
+	// ErrClosed is returned when a task is submitted to a pool that was shut down
+	ErrClosed = errors.New("workerpool: shut down")
+)
+
+// ParsePolicy parses a policy name; empty is Block
+func ParsePolicy(name string) (Policy, error) {
+	switch Policy(name) {
+	case "", Block:
+		return Block, nil
+	case Reject:
+		return Reject, nil
+	default:
+		return "", fmt.Errorf("workerpool: unsupported policy: %s", name)
+	}
+}
+
+// Options configures a Pool
+type Options struct {
+	// Size is how many tasks run at once (1)
+	Size int
+	// QueueDepth is how many tasks wait for a worker; 0 hands tasks straight to idle workers
+	QueueDepth int
+	// Policy is what a full queue does to a new task (Block)
+	Policy Policy
+}
+
+// Pool runs submitted tasks on Size workers, in the order they were queued
+type Pool struct {
+	name    string
+	policy  Policy
+	tasks   chan queued
+	running sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// queued is a task waiting for a worker
+type queued struct {
+	run      func()
+	queuedAt time.Time
+}
+
+// New creates a pool and starts its workers; zero Options fields use the defaults. name
+// labels its metrics and logs
+func New(name string, opts Options) *Pool {
+	if opts.Size <= 0 {
+		opts.Size = 1
+	}
+	if opts.QueueDepth < 0 {
+		opts.QueueDepth = 0
+	}
+	if opts.Policy == "" {
+		opts.Policy = Block
+	}
+
+	p := &Pool{name: name, policy: opts.Policy, tasks: make(chan queued, opts.QueueDepth)}
+	observeCapacity(name, opts.Size, opts.QueueDepth)
+	p.running.Add(opts.Size)
+	for i := 0; i < opts.Size; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Name returns the name of the pool
+func (p *Pool) Name() string {
+	return p.name
+}
+
+// Submit queues task to run on a worker. When the queue is full the Reject policy returns
+// ErrRejected and the Block policy waits for a slot, returning ctx's error if it is done
+// first; a pool that was shut down returns ErrClosed
+func (p *Pool) Submit(ctx context.Context, task func()) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrClosed
+	}
+
+	item := queued{run: task, queuedAt: time.Now()}
+	if p.policy == Reject {
+		select {
+		case p.tasks <- item:
+		default:
+			observeRejected(p.name)
+			return ErrRejected
+		}
+	} else {
+		select {
+		case p.tasks <- item:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	observeQueue(p.name, len(p.tasks))
+	return nil
+}
+
+// Do runs fn on a worker with ctx and waits for it to return; the errors of Submit are
+// returned without running it, and a panic of fn as an error
+func (p *Pool) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	result := make(chan error, 1)
+	err := p.Submit(ctx, func() {
+		returned := false
+		defer func() {
+			if !returned {
+				result <- fmt.Errorf("workerpool %s: task panicked", p.name)
+			}
+		}()
+		result <- fn(ctx)
+		returned = true
+	})
+	if err != nil {
+		return err
+	}
+	return <-result
+}
+
+// Shutdown stops taking tasks and waits for the queued ones to run, or ctx to be done
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("workerpool %s: tasks still running: %w", p.name, ctx.Err())
+	}
+}
+
+// work runs queued tasks until the queue is closed and drained
+func (p *Pool) work() {
+	defer p.running.Done()
+	for item := range p.tasks {
+		observeStart(p.name, len(p.tasks), time.Since(item.queuedAt))
+		p.run(item.run)
+	}
+}
+
+// run runs task, recovering a panic so the worker survives it
+func (p *Pool) run(task func()) {
+	panicked := true
+	defer func() {
+		if panicked {
+			log.Printf("workerpool %s: task panicked: %v", p.name, recover())
+		}
+		observeDone(p.name, panicked)
+	}()
+	task()
+	panicked = false
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: account_token_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=account_token_repository.go -destination=../../../mocks/account_token_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountTokenRepository is a mock of AccountTokenRepository interface.
+type MockAccountTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountTokenRepositoryMockRecorder
+}
+
+// MockAccountTokenRepositoryMockRecorder is the mock recorder for MockAccountTokenRepository.
+type MockAccountTokenRepositoryMockRecorder struct {
+	mock *MockAccountTokenRepository
+}
+
+// NewMockAccountTokenRepository creates a new mock instance.
+func NewMockAccountTokenRepository(ctrl *gomock.Controller) *MockAccountTokenRepository {
+	mock := &MockAccountTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountTokenRepository) EXPECT() *MockAccountTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockAccountTokenRepository) Consume(ctx context.Context, purpose entities.AccountTokenPurpose, tokenHash string) (*entities.AccountToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, purpose, tokenHash)
+	ret0, _ := ret[0].(*entities.AccountToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockAccountTokenRepositoryMockRecorder) Consume(ctx, purpose, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockAccountTokenRepository)(nil).Consume), ctx, purpose, tokenHash)
+}
+
+// Create mocks base method.
+func (m *MockAccountTokenRepository) Create(ctx context.Context, token *entities.AccountToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAccountTokenRepositoryMockRecorder) Create(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccountTokenRepository)(nil).Create), ctx, token)
+}
+
+// RevokeAll mocks base method.
+func (m *MockAccountTokenRepository) RevokeAll(ctx context.Context, userID uint, purpose entities.AccountTokenPurpose) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, userID, purpose)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockAccountTokenRepositoryMockRecorder) RevokeAll(ctx, userID, purpose any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockAccountTokenRepository)(nil).RevokeAll), ctx, userID, purpose)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: account_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=account_usecase.go -destination=../../../mocks/account_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountUseCase is a mock of AccountUseCase interface.
+type MockAccountUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountUseCaseMockRecorder
+}
+
+// MockAccountUseCaseMockRecorder is the mock recorder for MockAccountUseCase.
+type MockAccountUseCaseMockRecorder struct {
+	mock *MockAccountUseCase
+}
+
+// NewMockAccountUseCase creates a new mock instance.
+func NewMockAccountUseCase(ctrl *gomock.Controller) *MockAccountUseCase {
+	mock := &MockAccountUseCase{ctrl: ctrl}
+	mock.recorder = &MockAccountUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountUseCase) EXPECT() *MockAccountUseCaseMockRecorder {
+	return m.recorder
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockAccountUseCase) RequestPasswordReset(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockAccountUseCaseMockRecorder) RequestPasswordReset(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAccountUseCase)(nil).RequestPasswordReset), ctx, email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAccountUseCase) ResetPassword(ctx context.Context, token, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAccountUseCaseMockRecorder) ResetPassword(ctx, token, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAccountUseCase)(nil).ResetPassword), ctx, token, password)
+}
+
+// SendVerification mocks base method.
+func (m *MockAccountUseCase) SendVerification(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerification", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendVerification indicates an expected call of SendVerification.
+func (mr *MockAccountUseCaseMockRecorder) SendVerification(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerification", reflect.TypeOf((*MockAccountUseCase)(nil).SendVerification), ctx, userID)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockAccountUseCase) VerifyEmail(ctx context.Context, token string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockAccountUseCaseMockRecorder) VerifyEmail(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockAccountUseCase)(nil).VerifyEmail), ctx, token)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: activity_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=activity_repository.go -destination=../../../mocks/activity_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockActivityRepository is a mock of ActivityRepository interface.
+type MockActivityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityRepositoryMockRecorder
+}
+
+// MockActivityRepositoryMockRecorder is the mock recorder for MockActivityRepository.
+type MockActivityRepositoryMockRecorder struct {
+	mock *MockActivityRepository
+}
+
+// NewMockActivityRepository creates a new mock instance.
+func NewMockActivityRepository(ctrl *gomock.Controller) *MockActivityRepository {
+	mock := &MockActivityRepository{ctrl: ctrl}
+	mock.recorder = &MockActivityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityRepository) EXPECT() *MockActivityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByUser mocks base method.
+func (m *MockActivityRepository) CountByUser(ctx context.Context, userID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUser", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUser indicates an expected call of CountByUser.
+func (mr *MockActivityRepositoryMockRecorder) CountByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUser", reflect.TypeOf((*MockActivityRepository)(nil).CountByUser), ctx, userID)
+}
+
+// CountFollowedBy mocks base method.
+func (m *MockActivityRepository) CountFollowedBy(ctx context.Context, followerID uint, types []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFollowedBy", ctx, followerID, types)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFollowedBy indicates an expected call of CountFollowedBy.
+func (mr *MockActivityRepositoryMockRecorder) CountFollowedBy(ctx, followerID, types any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFollowedBy", reflect.TypeOf((*MockActivityRepository)(nil).CountFollowedBy), ctx, followerID, types)
+}
+
+// Create mocks base method.
+func (m *MockActivityRepository) Create(ctx context.Context, activity *entities.Activity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, activity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockActivityRepositoryMockRecorder) Create(ctx, activity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockActivityRepository)(nil).Create), ctx, activity)
+}
+
+// ListByUser mocks base method.
+func (m *MockActivityRepository) ListByUser(ctx context.Context, userID uint, limit, offset int) ([]*entities.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*entities.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockActivityRepositoryMockRecorder) ListByUser(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockActivityRepository)(nil).ListByUser), ctx, userID, limit, offset)
+}
+
+// ListFollowedBy mocks base method.
+func (m *MockActivityRepository) ListFollowedBy(ctx context.Context, followerID uint, types []string, limit, offset int) ([]*entities.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowedBy", ctx, followerID, types, limit, offset)
+	ret0, _ := ret[0].([]*entities.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowedBy indicates an expected call of ListFollowedBy.
+func (mr *MockActivityRepositoryMockRecorder) ListFollowedBy(ctx, followerID, types, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowedBy", reflect.TypeOf((*MockActivityRepository)(nil).ListFollowedBy), ctx, followerID, types, limit, offset)
+}
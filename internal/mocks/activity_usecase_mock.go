@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: activity_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=activity_usecase.go -destination=../../../mocks/activity_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockActivityUseCase is a mock of ActivityUseCase interface.
+type MockActivityUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityUseCaseMockRecorder
+}
+
+// MockActivityUseCaseMockRecorder is the mock recorder for MockActivityUseCase.
+type MockActivityUseCaseMockRecorder struct {
+	mock *MockActivityUseCase
+}
+
+// NewMockActivityUseCase creates a new mock instance.
+func NewMockActivityUseCase(ctrl *gomock.Controller) *MockActivityUseCase {
+	mock := &MockActivityUseCase{ctrl: ctrl}
+	mock.recorder = &MockActivityUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityUseCase) EXPECT() *MockActivityUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ListActivity mocks base method.
+func (m *MockActivityUseCase) ListActivity(ctx context.Context, userID uint, limit, offset int) ([]*entities.Activity, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActivity", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*entities.Activity)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListActivity indicates an expected call of ListActivity.
+func (mr *MockActivityUseCaseMockRecorder) ListActivity(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivity", reflect.TypeOf((*MockActivityUseCase)(nil).ListActivity), ctx, userID, limit, offset)
+}
+
+// Record mocks base method.
+func (m *MockActivityUseCase) Record(ctx context.Context, activity *entities.Activity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, activity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockActivityUseCaseMockRecorder) Record(ctx, activity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockActivityUseCase)(nil).Record), ctx, activity)
+}
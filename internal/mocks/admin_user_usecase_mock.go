@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: admin_user_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=admin_user_usecase.go -destination=../../../mocks/admin_user_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	authz "clean-arch-gin/internal/domain/shared/authz"
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAdminUserUseCase is a mock of AdminUserUseCase interface.
+type MockAdminUserUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminUserUseCaseMockRecorder
+}
+
+// MockAdminUserUseCaseMockRecorder is the mock recorder for MockAdminUserUseCase.
+type MockAdminUserUseCaseMockRecorder struct {
+	mock *MockAdminUserUseCase
+}
+
+// NewMockAdminUserUseCase creates a new mock instance.
+func NewMockAdminUserUseCase(ctrl *gomock.Controller) *MockAdminUserUseCase {
+	mock := &MockAdminUserUseCase{ctrl: ctrl}
+	mock.recorder = &MockAdminUserUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminUserUseCase) EXPECT() *MockAdminUserUseCaseMockRecorder {
+	return m.recorder
+}
+
+// AuditLog mocks base method.
+func (m *MockAdminUserUseCase) AuditLog(ctx context.Context, id uint, limit, offset int) ([]*entities.AuditEntry, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditLog", ctx, id, limit, offset)
+	ret0, _ := ret[0].([]*entities.AuditEntry)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AuditLog indicates an expected call of AuditLog.
+func (mr *MockAdminUserUseCaseMockRecorder) AuditLog(ctx, id, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLog", reflect.TypeOf((*MockAdminUserUseCase)(nil).AuditLog), ctx, id, limit, offset)
+}
+
+// DeleteUser mocks base method.
+func (m *MockAdminUserUseCase) DeleteUser(ctx context.Context, actor authz.Actor, id uint, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, actor, id, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockAdminUserUseCaseMockRecorder) DeleteUser(ctx, actor, id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockAdminUserUseCase)(nil).DeleteUser), ctx, actor, id, reason)
+}
+
+// ForcePasswordReset mocks base method.
+func (m *MockAdminUserUseCase) ForcePasswordReset(ctx context.Context, actor authz.Actor, id uint, reason string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForcePasswordReset", ctx, actor, id, reason)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForcePasswordReset indicates an expected call of ForcePasswordReset.
+func (mr *MockAdminUserUseCaseMockRecorder) ForcePasswordReset(ctx, actor, id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForcePasswordReset", reflect.TypeOf((*MockAdminUserUseCase)(nil).ForcePasswordReset), ctx, actor, id, reason)
+}
+
+// PurgeUser mocks base method.
+func (m *MockAdminUserUseCase) PurgeUser(ctx context.Context, actor authz.Actor, id uint, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUser", ctx, actor, id, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeUser indicates an expected call of PurgeUser.
+func (mr *MockAdminUserUseCaseMockRecorder) PurgeUser(ctx, actor, id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUser", reflect.TypeOf((*MockAdminUserUseCase)(nil).PurgeUser), ctx, actor, id, reason)
+}
+
+// RestoreUser mocks base method.
+func (m *MockAdminUserUseCase) RestoreUser(ctx context.Context, actor authz.Actor, id uint, reason string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, actor, id, reason)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockAdminUserUseCaseMockRecorder) RestoreUser(ctx, actor, id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockAdminUserUseCase)(nil).RestoreUser), ctx, actor, id, reason)
+}
+
+// SetRole mocks base method.
+func (m *MockAdminUserUseCase) SetRole(ctx context.Context, actor authz.Actor, id uint, role entities.Role, reason string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, actor, id, role, reason)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockAdminUserUseCaseMockRecorder) SetRole(ctx, actor, id, role, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockAdminUserUseCase)(nil).SetRole), ctx, actor, id, role, reason)
+}
+
+// SetStatus mocks base method.
+func (m *MockAdminUserUseCase) SetStatus(ctx context.Context, actor authz.Actor, id uint, status entities.Status, reason string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, actor, id, status, reason)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockAdminUserUseCaseMockRecorder) SetStatus(ctx, actor, id, status, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockAdminUserUseCase)(nil).SetStatus), ctx, actor, id, status, reason)
+}
+
+// UpdateUser mocks base method.
+func (m *MockAdminUserUseCase) UpdateUser(ctx context.Context, actor authz.Actor, id uint, email, name string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, actor, id, email, name)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockAdminUserUseCaseMockRecorder) UpdateUser(ctx, actor, id, email, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockAdminUserUseCase)(nil).UpdateUser), ctx, actor, id, email, name)
+}
@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auth_event_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=auth_event_repository.go -destination=../../../mocks/auth_event_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthEventRepository is a mock of AuthEventRepository interface.
+type MockAuthEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthEventRepositoryMockRecorder
+}
+
+// MockAuthEventRepositoryMockRecorder is the mock recorder for MockAuthEventRepository.
+type MockAuthEventRepositoryMockRecorder struct {
+	mock *MockAuthEventRepository
+}
+
+// NewMockAuthEventRepository creates a new mock instance.
+func NewMockAuthEventRepository(ctrl *gomock.Controller) *MockAuthEventRepository {
+	mock := &MockAuthEventRepository{ctrl: ctrl}
+	mock.recorder = &MockAuthEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthEventRepository) EXPECT() *MockAuthEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockAuthEventRepository) Count(ctx context.Context, filter entities.AuthEventFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockAuthEventRepositoryMockRecorder) Count(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockAuthEventRepository)(nil).Count), ctx, filter)
+}
+
+// Create mocks base method.
+func (m *MockAuthEventRepository) Create(ctx context.Context, event *entities.AuthEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuthEventRepositoryMockRecorder) Create(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuthEventRepository)(nil).Create), ctx, event)
+}
+
+// List mocks base method.
+func (m *MockAuthEventRepository) List(ctx context.Context, filter entities.AuthEventFilter, limit, offset int) ([]*entities.AuthEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]*entities.AuthEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAuthEventRepositoryMockRecorder) List(ctx, filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuthEventRepository)(nil).List), ctx, filter, limit, offset)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auth_event_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=auth_event_usecase.go -destination=../../../mocks/auth_event_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthEventUseCase is a mock of AuthEventUseCase interface.
+type MockAuthEventUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthEventUseCaseMockRecorder
+}
+
+// MockAuthEventUseCaseMockRecorder is the mock recorder for MockAuthEventUseCase.
+type MockAuthEventUseCaseMockRecorder struct {
+	mock *MockAuthEventUseCase
+}
+
+// NewMockAuthEventUseCase creates a new mock instance.
+func NewMockAuthEventUseCase(ctrl *gomock.Controller) *MockAuthEventUseCase {
+	mock := &MockAuthEventUseCase{ctrl: ctrl}
+	mock.recorder = &MockAuthEventUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthEventUseCase) EXPECT() *MockAuthEventUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ListAuthEvents mocks base method.
+func (m *MockAuthEventUseCase) ListAuthEvents(ctx context.Context, filter entities.AuthEventFilter, limit, offset int) ([]*entities.AuthEvent, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuthEvents", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]*entities.AuthEvent)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAuthEvents indicates an expected call of ListAuthEvents.
+func (mr *MockAuthEventUseCaseMockRecorder) ListAuthEvents(ctx, filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuthEvents", reflect.TypeOf((*MockAuthEventUseCase)(nil).ListAuthEvents), ctx, filter, limit, offset)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: avatar_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=avatar_usecase.go -destination=../../../mocks/avatar_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAvatarUseCase is a mock of AvatarUseCase interface.
+type MockAvatarUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAvatarUseCaseMockRecorder
+}
+
+// MockAvatarUseCaseMockRecorder is the mock recorder for MockAvatarUseCase.
+type MockAvatarUseCaseMockRecorder struct {
+	mock *MockAvatarUseCase
+}
+
+// NewMockAvatarUseCase creates a new mock instance.
+func NewMockAvatarUseCase(ctrl *gomock.Controller) *MockAvatarUseCase {
+	mock := &MockAvatarUseCase{ctrl: ctrl}
+	mock.recorder = &MockAvatarUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAvatarUseCase) EXPECT() *MockAvatarUseCaseMockRecorder {
+	return m.recorder
+}
+
+// UploadAvatar mocks base method.
+func (m *MockAvatarUseCase) UploadAvatar(ctx context.Context, userID uint, image io.Reader) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAvatar", ctx, userID, image)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAvatar indicates an expected call of UploadAvatar.
+func (mr *MockAvatarUseCaseMockRecorder) UploadAvatar(ctx, userID, image any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAvatar", reflect.TypeOf((*MockAvatarUseCase)(nil).UploadAvatar), ctx, userID, image)
+}
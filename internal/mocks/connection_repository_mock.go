@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: connection_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=connection_repository.go -destination=../../../mocks/connection_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConnectionRepository is a mock of ConnectionRepository interface.
+type MockConnectionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockConnectionRepositoryMockRecorder
+}
+
+// MockConnectionRepositoryMockRecorder is the mock recorder for MockConnectionRepository.
+type MockConnectionRepositoryMockRecorder struct {
+	mock *MockConnectionRepository
+}
+
+// NewMockConnectionRepository creates a new mock instance.
+func NewMockConnectionRepository(ctrl *gomock.Controller) *MockConnectionRepository {
+	mock := &MockConnectionRepository{ctrl: ctrl}
+	mock.recorder = &MockConnectionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConnectionRepository) EXPECT() *MockConnectionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Counts mocks base method.
+func (m *MockConnectionRepository) Counts(ctx context.Context, userID uint) (entities.ConnectionCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Counts", ctx, userID)
+	ret0, _ := ret[0].(entities.ConnectionCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Counts indicates an expected call of Counts.
+func (mr *MockConnectionRepositoryMockRecorder) Counts(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Counts", reflect.TypeOf((*MockConnectionRepository)(nil).Counts), ctx, userID)
+}
+
+// Create mocks base method.
+func (m *MockConnectionRepository) Create(ctx context.Context, connection *entities.Connection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, connection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockConnectionRepositoryMockRecorder) Create(ctx, connection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockConnectionRepository)(nil).Create), ctx, connection)
+}
+
+// Delete mocks base method.
+func (m *MockConnectionRepository) Delete(ctx context.Context, followerID, followeeID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockConnectionRepositoryMockRecorder) Delete(ctx, followerID, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockConnectionRepository)(nil).Delete), ctx, followerID, followeeID)
+}
+
+// Exists mocks base method.
+func (m *MockConnectionRepository) Exists(ctx context.Context, followerID, followeeID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockConnectionRepositoryMockRecorder) Exists(ctx, followerID, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockConnectionRepository)(nil).Exists), ctx, followerID, followeeID)
+}
+
+// List mocks base method.
+func (m *MockConnectionRepository) List(ctx context.Context, userID uint, side entities.ConnectionType, limit, offset int) ([]*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID, side, limit, offset)
+	ret0, _ := ret[0].([]*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockConnectionRepositoryMockRecorder) List(ctx, userID, side, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockConnectionRepository)(nil).List), ctx, userID, side, limit, offset)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: connection_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=connection_usecase.go -destination=../../../mocks/connection_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConnectionUseCase is a mock of ConnectionUseCase interface.
+type MockConnectionUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockConnectionUseCaseMockRecorder
+}
+
+// MockConnectionUseCaseMockRecorder is the mock recorder for MockConnectionUseCase.
+type MockConnectionUseCaseMockRecorder struct {
+	mock *MockConnectionUseCase
+}
+
+// NewMockConnectionUseCase creates a new mock instance.
+func NewMockConnectionUseCase(ctrl *gomock.Controller) *MockConnectionUseCase {
+	mock := &MockConnectionUseCase{ctrl: ctrl}
+	mock.recorder = &MockConnectionUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConnectionUseCase) EXPECT() *MockConnectionUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Follow mocks base method.
+func (m *MockConnectionUseCase) Follow(ctx context.Context, followerID, followeeID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follow", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Follow indicates an expected call of Follow.
+func (mr *MockConnectionUseCaseMockRecorder) Follow(ctx, followerID, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follow", reflect.TypeOf((*MockConnectionUseCase)(nil).Follow), ctx, followerID, followeeID)
+}
+
+// GetTimeline mocks base method.
+func (m *MockConnectionUseCase) GetTimeline(ctx context.Context, userID uint, limit, offset int) ([]*entities.TimelineEntry, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeline", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*entities.TimelineEntry)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTimeline indicates an expected call of GetTimeline.
+func (mr *MockConnectionUseCaseMockRecorder) GetTimeline(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeline", reflect.TypeOf((*MockConnectionUseCase)(nil).GetTimeline), ctx, userID, limit, offset)
+}
+
+// IsFollowing mocks base method.
+func (m *MockConnectionUseCase) IsFollowing(ctx context.Context, followerID, followeeID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFollowing", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsFollowing indicates an expected call of IsFollowing.
+func (mr *MockConnectionUseCaseMockRecorder) IsFollowing(ctx, followerID, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFollowing", reflect.TypeOf((*MockConnectionUseCase)(nil).IsFollowing), ctx, followerID, followeeID)
+}
+
+// ListConnections mocks base method.
+func (m *MockConnectionUseCase) ListConnections(ctx context.Context, userID uint, side entities.ConnectionType, limit, offset int) ([]*entities.User, entities.ConnectionCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConnections", ctx, userID, side, limit, offset)
+	ret0, _ := ret[0].([]*entities.User)
+	ret1, _ := ret[1].(entities.ConnectionCounts)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListConnections indicates an expected call of ListConnections.
+func (mr *MockConnectionUseCaseMockRecorder) ListConnections(ctx, userID, side, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConnections", reflect.TypeOf((*MockConnectionUseCase)(nil).ListConnections), ctx, userID, side, limit, offset)
+}
+
+// Unfollow mocks base method.
+func (m *MockConnectionUseCase) Unfollow(ctx context.Context, followerID, followeeID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfollow", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unfollow indicates an expected call of Unfollow.
+func (mr *MockConnectionUseCaseMockRecorder) Unfollow(ctx, followerID, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfollow", reflect.TypeOf((*MockConnectionUseCase)(nil).Unfollow), ctx, followerID, followeeID)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: deliverability_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=deliverability_repository.go -destination=../../../mocks/deliverability_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeliverabilityRepository is a mock of DeliverabilityRepository interface.
+type MockDeliverabilityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliverabilityRepositoryMockRecorder
+}
+
+// MockDeliverabilityRepositoryMockRecorder is the mock recorder for MockDeliverabilityRepository.
+type MockDeliverabilityRepositoryMockRecorder struct {
+	mock *MockDeliverabilityRepository
+}
+
+// NewMockDeliverabilityRepository creates a new mock instance.
+func NewMockDeliverabilityRepository(ctrl *gomock.Controller) *MockDeliverabilityRepository {
+	mock := &MockDeliverabilityRepository{ctrl: ctrl}
+	mock.recorder = &MockDeliverabilityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliverabilityRepository) EXPECT() *MockDeliverabilityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// MarkUndeliverable mocks base method.
+func (m *MockDeliverabilityRepository) MarkUndeliverable(ctx context.Context, email, reason string, at time.Time) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUndeliverable", ctx, email, reason, at)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUndeliverable indicates an expected call of MarkUndeliverable.
+func (mr *MockDeliverabilityRepositoryMockRecorder) MarkUndeliverable(ctx, email, reason, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUndeliverable", reflect.TypeOf((*MockDeliverabilityRepository)(nil).MarkUndeliverable), ctx, email, reason, at)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: deliverability_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=deliverability_usecase.go -destination=../../../mocks/deliverability_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	mail "clean-arch-gin/internal/domain/shared/mail"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeliverabilityUseCase is a mock of DeliverabilityUseCase interface.
+type MockDeliverabilityUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliverabilityUseCaseMockRecorder
+}
+
+// MockDeliverabilityUseCaseMockRecorder is the mock recorder for MockDeliverabilityUseCase.
+type MockDeliverabilityUseCaseMockRecorder struct {
+	mock *MockDeliverabilityUseCase
+}
+
+// NewMockDeliverabilityUseCase creates a new mock instance.
+func NewMockDeliverabilityUseCase(ctrl *gomock.Controller) *MockDeliverabilityUseCase {
+	mock := &MockDeliverabilityUseCase{ctrl: ctrl}
+	mock.recorder = &MockDeliverabilityUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliverabilityUseCase) EXPECT() *MockDeliverabilityUseCaseMockRecorder {
+	return m.recorder
+}
+
+// RecordFeedback mocks base method.
+func (m *MockDeliverabilityUseCase) RecordFeedback(ctx context.Context, feedback []mail.Feedback) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFeedback", ctx, feedback)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFeedback indicates an expected call of RecordFeedback.
+func (mr *MockDeliverabilityUseCaseMockRecorder) RecordFeedback(ctx, feedback any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFeedback", reflect.TypeOf((*MockDeliverabilityUseCase)(nil).RecordFeedback), ctx, feedback)
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: device_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=device_usecase.go -destination=../../../mocks/device_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeviceUseCase is a mock of DeviceUseCase interface.
+type MockDeviceUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceUseCaseMockRecorder
+}
+
+// MockDeviceUseCaseMockRecorder is the mock recorder for MockDeviceUseCase.
+type MockDeviceUseCaseMockRecorder struct {
+	mock *MockDeviceUseCase
+}
+
+// NewMockDeviceUseCase creates a new mock instance.
+func NewMockDeviceUseCase(ctrl *gomock.Controller) *MockDeviceUseCase {
+	mock := &MockDeviceUseCase{ctrl: ctrl}
+	mock.recorder = &MockDeviceUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceUseCase) EXPECT() *MockDeviceUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ForgetToken mocks base method.
+func (m *MockDeviceUseCase) ForgetToken(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgetToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgetToken indicates an expected call of ForgetToken.
+func (mr *MockDeviceUseCaseMockRecorder) ForgetToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgetToken", reflect.TypeOf((*MockDeviceUseCase)(nil).ForgetToken), ctx, token)
+}
+
+// ListDevices mocks base method.
+func (m *MockDeviceUseCase) ListDevices(ctx context.Context, userID uint) ([]*entities.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDevices", ctx, userID)
+	ret0, _ := ret[0].([]*entities.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDevices indicates an expected call of ListDevices.
+func (mr *MockDeviceUseCaseMockRecorder) ListDevices(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDevices", reflect.TypeOf((*MockDeviceUseCase)(nil).ListDevices), ctx, userID)
+}
+
+// RegisterDevice mocks base method.
+func (m *MockDeviceUseCase) RegisterDevice(ctx context.Context, userID uint, token string, platform entities.DevicePlatform, name string) (*entities.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterDevice", ctx, userID, token, platform, name)
+	ret0, _ := ret[0].(*entities.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterDevice indicates an expected call of RegisterDevice.
+func (mr *MockDeviceUseCaseMockRecorder) RegisterDevice(ctx, userID, token, platform, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterDevice", reflect.TypeOf((*MockDeviceUseCase)(nil).RegisterDevice), ctx, userID, token, platform, name)
+}
+
+// RemoveDevice mocks base method.
+func (m *MockDeviceUseCase) RemoveDevice(ctx context.Context, userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDevice", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDevice indicates an expected call of RemoveDevice.
+func (mr *MockDeviceUseCaseMockRecorder) RemoveDevice(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDevice", reflect.TypeOf((*MockDeviceUseCase)(nil).RemoveDevice), ctx, userID, id)
+}
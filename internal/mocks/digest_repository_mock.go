@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: digest_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=digest_repository.go -destination=../../../mocks/digest_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDigestRepository is a mock of DigestRepository interface.
+type MockDigestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestRepositoryMockRecorder
+}
+
+// MockDigestRepositoryMockRecorder is the mock recorder for MockDigestRepository.
+type MockDigestRepositoryMockRecorder struct {
+	mock *MockDigestRepository
+}
+
+// NewMockDigestRepository creates a new mock instance.
+func NewMockDigestRepository(ctrl *gomock.Controller) *MockDigestRepository {
+	mock := &MockDigestRepository{ctrl: ctrl}
+	mock.recorder = &MockDigestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigestRepository) EXPECT() *MockDigestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockDigestRepository) Add(ctx context.Context, item *entities.DigestItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockDigestRepositoryMockRecorder) Add(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockDigestRepository)(nil).Add), ctx, item)
+}
+
+// DeleteThrough mocks base method.
+func (m *MockDigestRepository) DeleteThrough(ctx context.Context, userID, lastID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteThrough", ctx, userID, lastID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteThrough indicates an expected call of DeleteThrough.
+func (mr *MockDigestRepositoryMockRecorder) DeleteThrough(ctx, userID, lastID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteThrough", reflect.TypeOf((*MockDigestRepository)(nil).DeleteThrough), ctx, userID, lastID)
+}
+
+// ListByUser mocks base method.
+func (m *MockDigestRepository) ListByUser(ctx context.Context, userID uint) ([]*entities.DigestItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID)
+	ret0, _ := ret[0].([]*entities.DigestItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockDigestRepositoryMockRecorder) ListByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockDigestRepository)(nil).ListByUser), ctx, userID)
+}
+
+// PendingUsers mocks base method.
+func (m *MockDigestRepository) PendingUsers(ctx context.Context) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingUsers", ctx)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingUsers indicates an expected call of PendingUsers.
+func (mr *MockDigestRepositoryMockRecorder) PendingUsers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingUsers", reflect.TypeOf((*MockDigestRepository)(nil).PendingUsers), ctx)
+}
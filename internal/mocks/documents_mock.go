@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: documents.go
+//
+// Generated by this command:
+//
+//	mockgen -source=documents.go -destination=../../../mocks/documents_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	documents "clean-arch-gin/internal/domain/shared/documents"
+	io "io"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPDFGenerator is a mock of PDFGenerator interface.
+type MockPDFGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockPDFGeneratorMockRecorder
+}
+
+// MockPDFGeneratorMockRecorder is the mock recorder for MockPDFGenerator.
+type MockPDFGeneratorMockRecorder struct {
+	mock *MockPDFGenerator
+}
+
+// NewMockPDFGenerator creates a new mock instance.
+func NewMockPDFGenerator(ctrl *gomock.Controller) *MockPDFGenerator {
+	mock := &MockPDFGenerator{ctrl: ctrl}
+	mock.recorder = &MockPDFGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPDFGenerator) EXPECT() *MockPDFGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GeneratePDF mocks base method.
+func (m *MockPDFGenerator) GeneratePDF(w io.Writer, doc documents.Document) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GeneratePDF", w, doc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GeneratePDF indicates an expected call of GeneratePDF.
+func (mr *MockPDFGeneratorMockRecorder) GeneratePDF(w, doc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePDF", reflect.TypeOf((*MockPDFGenerator)(nil).GeneratePDF), w, doc)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: email_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=email_repository.go -destination=../../../mocks/email_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/mail/entities"
+	repositories "clean-arch-gin/internal/domain/mail/repositories"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEmailRepository is a mock of EmailRepository interface.
+type MockEmailRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailRepositoryMockRecorder
+}
+
+// MockEmailRepositoryMockRecorder is the mock recorder for MockEmailRepository.
+type MockEmailRepositoryMockRecorder struct {
+	mock *MockEmailRepository
+}
+
+// NewMockEmailRepository creates a new mock instance.
+func NewMockEmailRepository(ctrl *gomock.Controller) *MockEmailRepository {
+	mock := &MockEmailRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailRepository) EXPECT() *MockEmailRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockEmailRepository) Create(ctx context.Context, email *entities.Email) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEmailRepositoryMockRecorder) Create(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEmailRepository)(nil).Create), ctx, email)
+}
+
+// Get mocks base method.
+func (m *MockEmailRepository) Get(ctx context.Context, id uint) (*entities.Email, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entities.Email)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockEmailRepositoryMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockEmailRepository)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockEmailRepository) List(ctx context.Context, filter repositories.EmailFilter, limit, offset int) ([]*entities.Email, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]*entities.Email)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockEmailRepositoryMockRecorder) List(ctx, filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockEmailRepository)(nil).List), ctx, filter, limit, offset)
+}
+
+// SetTask mocks base method.
+func (m *MockEmailRepository) SetTask(ctx context.Context, id, taskID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTask", ctx, id, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTask indicates an expected call of SetTask.
+func (mr *MockEmailRepositoryMockRecorder) SetTask(ctx, id, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTask", reflect.TypeOf((*MockEmailRepository)(nil).SetTask), ctx, id, taskID)
+}
+
+// Update mocks base method.
+func (m *MockEmailRepository) Update(ctx context.Context, email *entities.Email) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEmailRepositoryMockRecorder) Update(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEmailRepository)(nil).Update), ctx, email)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: email_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=email_usecase.go -destination=../../../mocks/email_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/mail/entities"
+	repositories "clean-arch-gin/internal/domain/mail/repositories"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEmailUseCase is a mock of EmailUseCase interface.
+type MockEmailUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailUseCaseMockRecorder
+}
+
+// MockEmailUseCaseMockRecorder is the mock recorder for MockEmailUseCase.
+type MockEmailUseCaseMockRecorder struct {
+	mock *MockEmailUseCase
+}
+
+// NewMockEmailUseCase creates a new mock instance.
+func NewMockEmailUseCase(ctrl *gomock.Controller) *MockEmailUseCase {
+	mock := &MockEmailUseCase{ctrl: ctrl}
+	mock.recorder = &MockEmailUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailUseCase) EXPECT() *MockEmailUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetEmail mocks base method.
+func (m *MockEmailUseCase) GetEmail(ctx context.Context, id uint) (*entities.Email, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmail", ctx, id)
+	ret0, _ := ret[0].(*entities.Email)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmail indicates an expected call of GetEmail.
+func (mr *MockEmailUseCaseMockRecorder) GetEmail(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmail", reflect.TypeOf((*MockEmailUseCase)(nil).GetEmail), ctx, id)
+}
+
+// ListEmails mocks base method.
+func (m *MockEmailUseCase) ListEmails(ctx context.Context, filter repositories.EmailFilter, limit, offset int) ([]*entities.Email, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmails", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]*entities.Email)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListEmails indicates an expected call of ListEmails.
+func (mr *MockEmailUseCaseMockRecorder) ListEmails(ctx, filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmails", reflect.TypeOf((*MockEmailUseCase)(nil).ListEmails), ctx, filter, limit, offset)
+}
@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: inventory_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=inventory_repository.go -destination=../../../mocks/inventory_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/order/entities"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInventoryRepository is a mock of InventoryRepository interface.
+type MockInventoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryRepositoryMockRecorder
+}
+
+// MockInventoryRepositoryMockRecorder is the mock recorder for MockInventoryRepository.
+type MockInventoryRepositoryMockRecorder struct {
+	mock *MockInventoryRepository
+}
+
+// NewMockInventoryRepository creates a new mock instance.
+func NewMockInventoryRepository(ctrl *gomock.Controller) *MockInventoryRepository {
+	mock := &MockInventoryRepository{ctrl: ctrl}
+	mock.recorder = &MockInventoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryRepository) EXPECT() *MockInventoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Commit mocks base method.
+func (m *MockInventoryRepository) Commit(ctx context.Context, orderID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockInventoryRepositoryMockRecorder) Commit(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockInventoryRepository)(nil).Commit), ctx, orderID)
+}
+
+// GetStock mocks base method.
+func (m *MockInventoryRepository) GetStock(ctx context.Context, productID uint) (*entities.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStock", ctx, productID)
+	ret0, _ := ret[0].(*entities.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStock indicates an expected call of GetStock.
+func (mr *MockInventoryRepositoryMockRecorder) GetStock(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStock", reflect.TypeOf((*MockInventoryRepository)(nil).GetStock), ctx, productID)
+}
+
+// ListExpiredOrderIDs mocks base method.
+func (m *MockInventoryRepository) ListExpiredOrderIDs(ctx context.Context, now time.Time, limit int) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredOrderIDs", ctx, now, limit)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredOrderIDs indicates an expected call of ListExpiredOrderIDs.
+func (mr *MockInventoryRepositoryMockRecorder) ListExpiredOrderIDs(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredOrderIDs", reflect.TypeOf((*MockInventoryRepository)(nil).ListExpiredOrderIDs), ctx, now, limit)
+}
+
+// Release mocks base method.
+func (m *MockInventoryRepository) Release(ctx context.Context, orderID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockInventoryRepositoryMockRecorder) Release(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockInventoryRepository)(nil).Release), ctx, orderID)
+}
+
+// Reserve mocks base method.
+func (m *MockInventoryRepository) Reserve(ctx context.Context, orderID uint, quantities map[uint]int, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, orderID, quantities, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockInventoryRepositoryMockRecorder) Reserve(ctx, orderID, quantities, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockInventoryRepository)(nil).Reserve), ctx, orderID, quantities, expiresAt)
+}
+
+// Restock mocks base method.
+func (m *MockInventoryRepository) Restock(ctx context.Context, quantities map[uint]int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restock", ctx, quantities)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restock indicates an expected call of Restock.
+func (mr *MockInventoryRepositoryMockRecorder) Restock(ctx, quantities any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restock", reflect.TypeOf((*MockInventoryRepository)(nil).Restock), ctx, quantities)
+}
+
+// SetOnHand mocks base method.
+func (m *MockInventoryRepository) SetOnHand(ctx context.Context, productID uint, onHand int) (*entities.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOnHand", ctx, productID, onHand)
+	ret0, _ := ret[0].(*entities.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOnHand indicates an expected call of SetOnHand.
+func (mr *MockInventoryRepositoryMockRecorder) SetOnHand(ctx, productID, onHand any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOnHand", reflect.TypeOf((*MockInventoryRepository)(nil).SetOnHand), ctx, productID, onHand)
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: inventory_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=inventory_usecase.go -destination=../../../mocks/inventory_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/order/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInventoryUseCase is a mock of InventoryUseCase interface.
+type MockInventoryUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryUseCaseMockRecorder
+}
+
+// MockInventoryUseCaseMockRecorder is the mock recorder for MockInventoryUseCase.
+type MockInventoryUseCaseMockRecorder struct {
+	mock *MockInventoryUseCase
+}
+
+// NewMockInventoryUseCase creates a new mock instance.
+func NewMockInventoryUseCase(ctrl *gomock.Controller) *MockInventoryUseCase {
+	mock := &MockInventoryUseCase{ctrl: ctrl}
+	mock.recorder = &MockInventoryUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryUseCase) EXPECT() *MockInventoryUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetStock mocks base method.
+func (m *MockInventoryUseCase) GetStock(ctx context.Context, productID uint) (*entities.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStock", ctx, productID)
+	ret0, _ := ret[0].(*entities.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStock indicates an expected call of GetStock.
+func (mr *MockInventoryUseCaseMockRecorder) GetStock(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStock", reflect.TypeOf((*MockInventoryUseCase)(nil).GetStock), ctx, productID)
+}
+
+// SetStock mocks base method.
+func (m *MockInventoryUseCase) SetStock(ctx context.Context, productID uint, onHand int) (*entities.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStock", ctx, productID, onHand)
+	ret0, _ := ret[0].(*entities.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStock indicates an expected call of SetStock.
+func (mr *MockInventoryUseCaseMockRecorder) SetStock(ctx, productID, onHand any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStock", reflect.TypeOf((*MockInventoryUseCase)(nil).SetStock), ctx, productID, onHand)
+}
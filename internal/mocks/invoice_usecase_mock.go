@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: invoice_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=invoice_usecase.go -destination=../../../mocks/invoice_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/order/entities"
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInvoiceUseCase is a mock of InvoiceUseCase interface.
+type MockInvoiceUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceUseCaseMockRecorder
+}
+
+// MockInvoiceUseCaseMockRecorder is the mock recorder for MockInvoiceUseCase.
+type MockInvoiceUseCaseMockRecorder struct {
+	mock *MockInvoiceUseCase
+}
+
+// NewMockInvoiceUseCase creates a new mock instance.
+func NewMockInvoiceUseCase(ctrl *gomock.Controller) *MockInvoiceUseCase {
+	mock := &MockInvoiceUseCase{ctrl: ctrl}
+	mock.recorder = &MockInvoiceUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceUseCase) EXPECT() *MockInvoiceUseCaseMockRecorder {
+	return m.recorder
+}
+
+// IssueInvoice mocks base method.
+func (m *MockInvoiceUseCase) IssueInvoice(ctx context.Context, orderID uint) (*entities.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueInvoice", ctx, orderID)
+	ret0, _ := ret[0].(*entities.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueInvoice indicates an expected call of IssueInvoice.
+func (mr *MockInvoiceUseCaseMockRecorder) IssueInvoice(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueInvoice", reflect.TypeOf((*MockInvoiceUseCase)(nil).IssueInvoice), ctx, orderID)
+}
+
+// RenderInvoicePDF mocks base method.
+func (m *MockInvoiceUseCase) RenderInvoicePDF(ctx context.Context, orderID uint, w io.Writer) (*entities.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderInvoicePDF", ctx, orderID, w)
+	ret0, _ := ret[0].(*entities.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderInvoicePDF indicates an expected call of RenderInvoicePDF.
+func (mr *MockInvoiceUseCaseMockRecorder) RenderInvoicePDF(ctx, orderID, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderInvoicePDF", reflect.TypeOf((*MockInvoiceUseCase)(nil).RenderInvoicePDF), ctx, orderID, w)
+}
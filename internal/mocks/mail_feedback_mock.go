@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: feedback.go
+//
+// Generated by this command:
+//
+//	mockgen -source=feedback.go -destination=../../../mocks/mail_feedback_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	mail "clean-arch-gin/internal/domain/shared/mail"
+	context "context"
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedbackSource is a mock of FeedbackSource interface.
+type MockFeedbackSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedbackSourceMockRecorder
+}
+
+// MockFeedbackSourceMockRecorder is the mock recorder for MockFeedbackSource.
+type MockFeedbackSourceMockRecorder struct {
+	mock *MockFeedbackSource
+}
+
+// NewMockFeedbackSource creates a new mock instance.
+func NewMockFeedbackSource(ctrl *gomock.Controller) *MockFeedbackSource {
+	mock := &MockFeedbackSource{ctrl: ctrl}
+	mock.recorder = &MockFeedbackSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedbackSource) EXPECT() *MockFeedbackSourceMockRecorder {
+	return m.recorder
+}
+
+// Parse mocks base method.
+func (m *MockFeedbackSource) Parse(ctx context.Context, header http.Header, body []byte) ([]mail.Feedback, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", ctx, header, body)
+	ret0, _ := ret[0].([]mail.Feedback)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockFeedbackSourceMockRecorder) Parse(ctx, header, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockFeedbackSource)(nil).Parse), ctx, header, body)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: metering.go
+//
+// Generated by this command:
+//
+//	mockgen -source=metering.go -destination=../../../mocks/meter_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMeter is a mock of Meter interface.
+type MockMeter struct {
+	ctrl     *gomock.Controller
+	recorder *MockMeterMockRecorder
+}
+
+// MockMeterMockRecorder is the mock recorder for MockMeter.
+type MockMeterMockRecorder struct {
+	mock *MockMeter
+}
+
+// NewMockMeter creates a new mock instance.
+func NewMockMeter(ctrl *gomock.Controller) *MockMeter {
+	mock := &MockMeter{ctrl: ctrl}
+	mock.recorder = &MockMeterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMeter) EXPECT() *MockMeterMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockMeter) Record(ctx context.Context, metric string, quantity int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, metric, quantity)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockMeterMockRecorder) Record(ctx, metric, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockMeter)(nil).Record), ctx, metric, quantity)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: metering_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=metering_usecase.go -destination=../../../mocks/metering_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/metering/entities"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMeteringUseCase is a mock of MeteringUseCase interface.
+type MockMeteringUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMeteringUseCaseMockRecorder
+}
+
+// MockMeteringUseCaseMockRecorder is the mock recorder for MockMeteringUseCase.
+type MockMeteringUseCaseMockRecorder struct {
+	mock *MockMeteringUseCase
+}
+
+// NewMockMeteringUseCase creates a new mock instance.
+func NewMockMeteringUseCase(ctrl *gomock.Controller) *MockMeteringUseCase {
+	mock := &MockMeteringUseCase{ctrl: ctrl}
+	mock.recorder = &MockMeteringUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMeteringUseCase) EXPECT() *MockMeteringUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetUsage mocks base method.
+func (m *MockMeteringUseCase) GetUsage(ctx context.Context, from, to time.Time, apiKeyID *uint) (*entities.UsageSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", ctx, from, to, apiKeyID)
+	ret0, _ := ret[0].(*entities.UsageSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockMeteringUseCaseMockRecorder) GetUsage(ctx, from, to, apiKeyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockMeteringUseCase)(nil).GetUsage), ctx, from, to, apiKeyID)
+}
+
+// ReportUsage mocks base method.
+func (m *MockMeteringUseCase) ReportUsage(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportUsage", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportUsage indicates an expected call of ReportUsage.
+func (mr *MockMeteringUseCaseMockRecorder) ReportUsage(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportUsage", reflect.TypeOf((*MockMeteringUseCase)(nil).ReportUsage), ctx, now)
+}
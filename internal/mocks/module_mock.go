@@ -0,0 +1,855 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: module.go
+//
+// Generated by this command:
+//
+//	mockgen -source=module.go -destination=../mocks/module_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	authz "clean-arch-gin/internal/domain/shared/authz"
+	events "clean-arch-gin/internal/domain/shared/events"
+	reports "clean-arch-gin/internal/domain/shared/reports"
+	search "clean-arch-gin/internal/domain/shared/search"
+	database "clean-arch-gin/internal/infrastructure/database"
+	grpcserver "clean-arch-gin/internal/infrastructure/grpcserver"
+	kafka "clean-arch-gin/internal/infrastructure/kafka"
+	openapi "clean-arch-gin/internal/infrastructure/openapi"
+	scheduler "clean-arch-gin/internal/infrastructure/scheduler"
+	taskqueue "clean-arch-gin/internal/infrastructure/taskqueue"
+	modules "clean-arch-gin/internal/modules"
+	context "context"
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+	gorm "gorm.io/gorm"
+)
+
+// MockModule is a mock of Module interface.
+type MockModule struct {
+	ctrl     *gomock.Controller
+	recorder *MockModuleMockRecorder
+}
+
+// MockModuleMockRecorder is the mock recorder for MockModule.
+type MockModuleMockRecorder struct {
+	mock *MockModule
+}
+
+// NewMockModule creates a new mock instance.
+func NewMockModule(ctrl *gomock.Controller) *MockModule {
+	mock := &MockModule{ctrl: ctrl}
+	mock.recorder = &MockModuleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModule) EXPECT() *MockModuleMockRecorder {
+	return m.recorder
+}
+
+// Initialize mocks base method.
+func (m *MockModule) Initialize() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Initialize")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Initialize indicates an expected call of Initialize.
+func (mr *MockModuleMockRecorder) Initialize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Initialize", reflect.TypeOf((*MockModule)(nil).Initialize))
+}
+
+// Migrate mocks base method.
+func (m *MockModule) Migrate(db *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migrate", db)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Migrate indicates an expected call of Migrate.
+func (mr *MockModuleMockRecorder) Migrate(db any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockModule)(nil).Migrate), db)
+}
+
+// Name mocks base method.
+func (m *MockModule) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockModuleMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockModule)(nil).Name))
+}
+
+// RegisterRoutes mocks base method.
+func (m *MockModule) RegisterRoutes(rg *gin.RouterGroup) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterRoutes", rg)
+}
+
+// RegisterRoutes indicates an expected call of RegisterRoutes.
+func (mr *MockModuleMockRecorder) RegisterRoutes(rg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRoutes", reflect.TypeOf((*MockModule)(nil).RegisterRoutes), rg)
+}
+
+// MockSchemaDeclarer is a mock of SchemaDeclarer interface.
+type MockSchemaDeclarer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchemaDeclarerMockRecorder
+}
+
+// MockSchemaDeclarerMockRecorder is the mock recorder for MockSchemaDeclarer.
+type MockSchemaDeclarerMockRecorder struct {
+	mock *MockSchemaDeclarer
+}
+
+// NewMockSchemaDeclarer creates a new mock instance.
+func NewMockSchemaDeclarer(ctrl *gomock.Controller) *MockSchemaDeclarer {
+	mock := &MockSchemaDeclarer{ctrl: ctrl}
+	mock.recorder = &MockSchemaDeclarerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchemaDeclarer) EXPECT() *MockSchemaDeclarerMockRecorder {
+	return m.recorder
+}
+
+// Schema mocks base method.
+func (m *MockSchemaDeclarer) Schema() database.Schema {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schema")
+	ret0, _ := ret[0].(database.Schema)
+	return ret0
+}
+
+// Schema indicates an expected call of Schema.
+func (mr *MockSchemaDeclarerMockRecorder) Schema() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schema", reflect.TypeOf((*MockSchemaDeclarer)(nil).Schema))
+}
+
+// MockQueryDeclarer is a mock of QueryDeclarer interface.
+type MockQueryDeclarer struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueryDeclarerMockRecorder
+}
+
+// MockQueryDeclarerMockRecorder is the mock recorder for MockQueryDeclarer.
+type MockQueryDeclarerMockRecorder struct {
+	mock *MockQueryDeclarer
+}
+
+// NewMockQueryDeclarer creates a new mock instance.
+func NewMockQueryDeclarer(ctrl *gomock.Controller) *MockQueryDeclarer {
+	mock := &MockQueryDeclarer{ctrl: ctrl}
+	mock.recorder = &MockQueryDeclarerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueryDeclarer) EXPECT() *MockQueryDeclarerMockRecorder {
+	return m.recorder
+}
+
+// Queries mocks base method.
+func (m *MockQueryDeclarer) Queries() database.QueryManifest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Queries")
+	ret0, _ := ret[0].(database.QueryManifest)
+	return ret0
+}
+
+// Queries indicates an expected call of Queries.
+func (mr *MockQueryDeclarerMockRecorder) Queries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Queries", reflect.TypeOf((*MockQueryDeclarer)(nil).Queries))
+}
+
+// MockReverter is a mock of Reverter interface.
+type MockReverter struct {
+	ctrl     *gomock.Controller
+	recorder *MockReverterMockRecorder
+}
+
+// MockReverterMockRecorder is the mock recorder for MockReverter.
+type MockReverterMockRecorder struct {
+	mock *MockReverter
+}
+
+// NewMockReverter creates a new mock instance.
+func NewMockReverter(ctrl *gomock.Controller) *MockReverter {
+	mock := &MockReverter{ctrl: ctrl}
+	mock.recorder = &MockReverterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReverter) EXPECT() *MockReverterMockRecorder {
+	return m.recorder
+}
+
+// Rollback mocks base method.
+func (m *MockReverter) Rollback(db *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", db)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockReverterMockRecorder) Rollback(db any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockReverter)(nil).Rollback), db)
+}
+
+// MockDocumented is a mock of Documented interface.
+type MockDocumented struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentedMockRecorder
+}
+
+// MockDocumentedMockRecorder is the mock recorder for MockDocumented.
+type MockDocumentedMockRecorder struct {
+	mock *MockDocumented
+}
+
+// NewMockDocumented creates a new mock instance.
+func NewMockDocumented(ctrl *gomock.Controller) *MockDocumented {
+	mock := &MockDocumented{ctrl: ctrl}
+	mock.recorder = &MockDocumentedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumented) EXPECT() *MockDocumentedMockRecorder {
+	return m.recorder
+}
+
+// APIRoutes mocks base method.
+func (m *MockDocumented) APIRoutes() []openapi.Route {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIRoutes")
+	ret0, _ := ret[0].([]openapi.Route)
+	return ret0
+}
+
+// APIRoutes indicates an expected call of APIRoutes.
+func (mr *MockDocumentedMockRecorder) APIRoutes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIRoutes", reflect.TypeOf((*MockDocumented)(nil).APIRoutes))
+}
+
+// MockAdminRouter is a mock of AdminRouter interface.
+type MockAdminRouter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminRouterMockRecorder
+}
+
+// MockAdminRouterMockRecorder is the mock recorder for MockAdminRouter.
+type MockAdminRouterMockRecorder struct {
+	mock *MockAdminRouter
+}
+
+// NewMockAdminRouter creates a new mock instance.
+func NewMockAdminRouter(ctrl *gomock.Controller) *MockAdminRouter {
+	mock := &MockAdminRouter{ctrl: ctrl}
+	mock.recorder = &MockAdminRouterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminRouter) EXPECT() *MockAdminRouterMockRecorder {
+	return m.recorder
+}
+
+// RegisterAdminRoutes mocks base method.
+func (m *MockAdminRouter) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterAdminRoutes", rg)
+}
+
+// RegisterAdminRoutes indicates an expected call of RegisterAdminRoutes.
+func (mr *MockAdminRouterMockRecorder) RegisterAdminRoutes(rg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterAdminRoutes", reflect.TypeOf((*MockAdminRouter)(nil).RegisterAdminRoutes), rg)
+}
+
+// MockAuthenticator is a mock of Authenticator interface.
+type MockAuthenticator struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthenticatorMockRecorder
+}
+
+// MockAuthenticatorMockRecorder is the mock recorder for MockAuthenticator.
+type MockAuthenticatorMockRecorder struct {
+	mock *MockAuthenticator
+}
+
+// NewMockAuthenticator creates a new mock instance.
+func NewMockAuthenticator(ctrl *gomock.Controller) *MockAuthenticator {
+	mock := &MockAuthenticator{ctrl: ctrl}
+	mock.recorder = &MockAuthenticatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthenticator) EXPECT() *MockAuthenticatorMockRecorder {
+	return m.recorder
+}
+
+// AuthMiddleware mocks base method.
+func (m *MockAuthenticator) AuthMiddleware() gin.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthMiddleware")
+	ret0, _ := ret[0].(gin.HandlerFunc)
+	return ret0
+}
+
+// AuthMiddleware indicates an expected call of AuthMiddleware.
+func (mr *MockAuthenticatorMockRecorder) AuthMiddleware() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthMiddleware", reflect.TypeOf((*MockAuthenticator)(nil).AuthMiddleware))
+}
+
+// MockGRPCService is a mock of GRPCService interface.
+type MockGRPCService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGRPCServiceMockRecorder
+}
+
+// MockGRPCServiceMockRecorder is the mock recorder for MockGRPCService.
+type MockGRPCServiceMockRecorder struct {
+	mock *MockGRPCService
+}
+
+// NewMockGRPCService creates a new mock instance.
+func NewMockGRPCService(ctrl *gomock.Controller) *MockGRPCService {
+	mock := &MockGRPCService{ctrl: ctrl}
+	mock.recorder = &MockGRPCServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGRPCService) EXPECT() *MockGRPCServiceMockRecorder {
+	return m.recorder
+}
+
+// RegisterGRPC mocks base method.
+func (m *MockGRPCService) RegisterGRPC(s grpc.ServiceRegistrar) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterGRPC", s)
+}
+
+// RegisterGRPC indicates an expected call of RegisterGRPC.
+func (mr *MockGRPCServiceMockRecorder) RegisterGRPC(s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterGRPC", reflect.TypeOf((*MockGRPCService)(nil).RegisterGRPC), s)
+}
+
+// MockGRPCInterceptor is a mock of GRPCInterceptor interface.
+type MockGRPCInterceptor struct {
+	ctrl     *gomock.Controller
+	recorder *MockGRPCInterceptorMockRecorder
+}
+
+// MockGRPCInterceptorMockRecorder is the mock recorder for MockGRPCInterceptor.
+type MockGRPCInterceptorMockRecorder struct {
+	mock *MockGRPCInterceptor
+}
+
+// NewMockGRPCInterceptor creates a new mock instance.
+func NewMockGRPCInterceptor(ctrl *gomock.Controller) *MockGRPCInterceptor {
+	mock := &MockGRPCInterceptor{ctrl: ctrl}
+	mock.recorder = &MockGRPCInterceptorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGRPCInterceptor) EXPECT() *MockGRPCInterceptorMockRecorder {
+	return m.recorder
+}
+
+// UnaryInterceptor mocks base method.
+func (m *MockGRPCInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnaryInterceptor")
+	ret0, _ := ret[0].(grpc.UnaryServerInterceptor)
+	return ret0
+}
+
+// UnaryInterceptor indicates an expected call of UnaryInterceptor.
+func (mr *MockGRPCInterceptorMockRecorder) UnaryInterceptor() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnaryInterceptor", reflect.TypeOf((*MockGRPCInterceptor)(nil).UnaryInterceptor))
+}
+
+// MockSessionProvider is a mock of SessionProvider interface.
+type MockSessionProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionProviderMockRecorder
+}
+
+// MockSessionProviderMockRecorder is the mock recorder for MockSessionProvider.
+type MockSessionProviderMockRecorder struct {
+	mock *MockSessionProvider
+}
+
+// NewMockSessionProvider creates a new mock instance.
+func NewMockSessionProvider(ctrl *gomock.Controller) *MockSessionProvider {
+	mock := &MockSessionProvider{ctrl: ctrl}
+	mock.recorder = &MockSessionProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionProvider) EXPECT() *MockSessionProviderMockRecorder {
+	return m.recorder
+}
+
+// Sessions mocks base method.
+func (m *MockSessionProvider) Sessions() grpcserver.SessionAuthenticator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sessions")
+	ret0, _ := ret[0].(grpcserver.SessionAuthenticator)
+	return ret0
+}
+
+// Sessions indicates an expected call of Sessions.
+func (mr *MockSessionProviderMockRecorder) Sessions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sessions", reflect.TypeOf((*MockSessionProvider)(nil).Sessions))
+}
+
+// MockWorker is a mock of Worker interface.
+type MockWorker struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkerMockRecorder
+}
+
+// MockWorkerMockRecorder is the mock recorder for MockWorker.
+type MockWorkerMockRecorder struct {
+	mock *MockWorker
+}
+
+// NewMockWorker creates a new mock instance.
+func NewMockWorker(ctrl *gomock.Controller) *MockWorker {
+	mock := &MockWorker{ctrl: ctrl}
+	mock.recorder = &MockWorkerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorker) EXPECT() *MockWorkerMockRecorder {
+	return m.recorder
+}
+
+// Start mocks base method.
+func (m *MockWorker) Start(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Start", ctx)
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockWorkerMockRecorder) Start(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockWorker)(nil).Start), ctx)
+}
+
+// MockShutdowner is a mock of Shutdowner interface.
+type MockShutdowner struct {
+	ctrl     *gomock.Controller
+	recorder *MockShutdownerMockRecorder
+}
+
+// MockShutdownerMockRecorder is the mock recorder for MockShutdowner.
+type MockShutdownerMockRecorder struct {
+	mock *MockShutdowner
+}
+
+// NewMockShutdowner creates a new mock instance.
+func NewMockShutdowner(ctrl *gomock.Controller) *MockShutdowner {
+	mock := &MockShutdowner{ctrl: ctrl}
+	mock.recorder = &MockShutdownerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShutdowner) EXPECT() *MockShutdownerMockRecorder {
+	return m.recorder
+}
+
+// Shutdown mocks base method.
+func (m *MockShutdowner) Shutdown(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockShutdownerMockRecorder) Shutdown(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockShutdowner)(nil).Shutdown), ctx)
+}
+
+// MockHealthChecker is a mock of HealthChecker interface.
+type MockHealthChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthCheckerMockRecorder
+}
+
+// MockHealthCheckerMockRecorder is the mock recorder for MockHealthChecker.
+type MockHealthCheckerMockRecorder struct {
+	mock *MockHealthChecker
+}
+
+// NewMockHealthChecker creates a new mock instance.
+func NewMockHealthChecker(ctrl *gomock.Controller) *MockHealthChecker {
+	mock := &MockHealthChecker{ctrl: ctrl}
+	mock.recorder = &MockHealthCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthChecker) EXPECT() *MockHealthCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckHealth mocks base method.
+func (m *MockHealthChecker) CheckHealth(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckHealth", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckHealth indicates an expected call of CheckHealth.
+func (mr *MockHealthCheckerMockRecorder) CheckHealth(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckHealth", reflect.TypeOf((*MockHealthChecker)(nil).CheckHealth), ctx)
+}
+
+// MockScheduled is a mock of Scheduled interface.
+type MockScheduled struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduledMockRecorder
+}
+
+// MockScheduledMockRecorder is the mock recorder for MockScheduled.
+type MockScheduledMockRecorder struct {
+	mock *MockScheduled
+}
+
+// NewMockScheduled creates a new mock instance.
+func NewMockScheduled(ctrl *gomock.Controller) *MockScheduled {
+	mock := &MockScheduled{ctrl: ctrl}
+	mock.recorder = &MockScheduledMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduled) EXPECT() *MockScheduledMockRecorder {
+	return m.recorder
+}
+
+// ScheduledJobs mocks base method.
+func (m *MockScheduled) ScheduledJobs() []scheduler.Job {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduledJobs")
+	ret0, _ := ret[0].([]scheduler.Job)
+	return ret0
+}
+
+// ScheduledJobs indicates an expected call of ScheduledJobs.
+func (mr *MockScheduledMockRecorder) ScheduledJobs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduledJobs", reflect.TypeOf((*MockScheduled)(nil).ScheduledJobs))
+}
+
+// MockTaskProcessor is a mock of TaskProcessor interface.
+type MockTaskProcessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskProcessorMockRecorder
+}
+
+// MockTaskProcessorMockRecorder is the mock recorder for MockTaskProcessor.
+type MockTaskProcessorMockRecorder struct {
+	mock *MockTaskProcessor
+}
+
+// NewMockTaskProcessor creates a new mock instance.
+func NewMockTaskProcessor(ctrl *gomock.Controller) *MockTaskProcessor {
+	mock := &MockTaskProcessor{ctrl: ctrl}
+	mock.recorder = &MockTaskProcessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskProcessor) EXPECT() *MockTaskProcessorMockRecorder {
+	return m.recorder
+}
+
+// TaskHandlers mocks base method.
+func (m *MockTaskProcessor) TaskHandlers() map[string]taskqueue.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TaskHandlers")
+	ret0, _ := ret[0].(map[string]taskqueue.Handler)
+	return ret0
+}
+
+// TaskHandlers indicates an expected call of TaskHandlers.
+func (mr *MockTaskProcessorMockRecorder) TaskHandlers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskHandlers", reflect.TypeOf((*MockTaskProcessor)(nil).TaskHandlers))
+}
+
+// MockTaskProducer is a mock of TaskProducer interface.
+type MockTaskProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskProducerMockRecorder
+}
+
+// MockTaskProducerMockRecorder is the mock recorder for MockTaskProducer.
+type MockTaskProducerMockRecorder struct {
+	mock *MockTaskProducer
+}
+
+// NewMockTaskProducer creates a new mock instance.
+func NewMockTaskProducer(ctrl *gomock.Controller) *MockTaskProducer {
+	mock := &MockTaskProducer{ctrl: ctrl}
+	mock.recorder = &MockTaskProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskProducer) EXPECT() *MockTaskProducerMockRecorder {
+	return m.recorder
+}
+
+// SetTaskQueue mocks base method.
+func (m *MockTaskProducer) SetTaskQueue(q *taskqueue.Queue) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTaskQueue", q)
+}
+
+// SetTaskQueue indicates an expected call of SetTaskQueue.
+func (mr *MockTaskProducerMockRecorder) SetTaskQueue(q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskQueue", reflect.TypeOf((*MockTaskProducer)(nil).SetTaskQueue), q)
+}
+
+// MockTopicConsumer is a mock of TopicConsumer interface.
+type MockTopicConsumer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTopicConsumerMockRecorder
+}
+
+// MockTopicConsumerMockRecorder is the mock recorder for MockTopicConsumer.
+type MockTopicConsumerMockRecorder struct {
+	mock *MockTopicConsumer
+}
+
+// NewMockTopicConsumer creates a new mock instance.
+func NewMockTopicConsumer(ctrl *gomock.Controller) *MockTopicConsumer {
+	mock := &MockTopicConsumer{ctrl: ctrl}
+	mock.recorder = &MockTopicConsumerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTopicConsumer) EXPECT() *MockTopicConsumerMockRecorder {
+	return m.recorder
+}
+
+// TopicHandlers mocks base method.
+func (m *MockTopicConsumer) TopicHandlers() map[string]kafka.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopicHandlers")
+	ret0, _ := ret[0].(map[string]kafka.Handler)
+	return ret0
+}
+
+// TopicHandlers indicates an expected call of TopicHandlers.
+func (mr *MockTopicConsumerMockRecorder) TopicHandlers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopicHandlers", reflect.TypeOf((*MockTopicConsumer)(nil).TopicHandlers))
+}
+
+// MockSearchIndexer is a mock of SearchIndexer interface.
+type MockSearchIndexer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchIndexerMockRecorder
+}
+
+// MockSearchIndexerMockRecorder is the mock recorder for MockSearchIndexer.
+type MockSearchIndexerMockRecorder struct {
+	mock *MockSearchIndexer
+}
+
+// NewMockSearchIndexer creates a new mock instance.
+func NewMockSearchIndexer(ctrl *gomock.Controller) *MockSearchIndexer {
+	mock := &MockSearchIndexer{ctrl: ctrl}
+	mock.recorder = &MockSearchIndexerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchIndexer) EXPECT() *MockSearchIndexerMockRecorder {
+	return m.recorder
+}
+
+// SearchIndexes mocks base method.
+func (m *MockSearchIndexer) SearchIndexes() []search.Index {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchIndexes")
+	ret0, _ := ret[0].([]search.Index)
+	return ret0
+}
+
+// SearchIndexes indicates an expected call of SearchIndexes.
+func (mr *MockSearchIndexerMockRecorder) SearchIndexes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchIndexes", reflect.TypeOf((*MockSearchIndexer)(nil).SearchIndexes))
+}
+
+// MockSearchable is a mock of Searchable interface.
+type MockSearchable struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchableMockRecorder
+}
+
+// MockSearchableMockRecorder is the mock recorder for MockSearchable.
+type MockSearchableMockRecorder struct {
+	mock *MockSearchable
+}
+
+// NewMockSearchable creates a new mock instance.
+func NewMockSearchable(ctrl *gomock.Controller) *MockSearchable {
+	mock := &MockSearchable{ctrl: ctrl}
+	mock.recorder = &MockSearchableMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchable) EXPECT() *MockSearchableMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchable) Search(ctx context.Context, actor authz.Actor, query string, limit int) ([]modules.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, actor, query, limit)
+	ret0, _ := ret[0].([]modules.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchableMockRecorder) Search(ctx, actor, query, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchable)(nil).Search), ctx, actor, query, limit)
+}
+
+// SearchPermission mocks base method.
+func (m *MockSearchable) SearchPermission() (string, string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPermission")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	return ret0, ret1
+}
+
+// SearchPermission indicates an expected call of SearchPermission.
+func (mr *MockSearchableMockRecorder) SearchPermission() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPermission", reflect.TypeOf((*MockSearchable)(nil).SearchPermission))
+}
+
+// MockReportProvider is a mock of ReportProvider interface.
+type MockReportProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportProviderMockRecorder
+}
+
+// MockReportProviderMockRecorder is the mock recorder for MockReportProvider.
+type MockReportProviderMockRecorder struct {
+	mock *MockReportProvider
+}
+
+// NewMockReportProvider creates a new mock instance.
+func NewMockReportProvider(ctrl *gomock.Controller) *MockReportProvider {
+	mock := &MockReportProvider{ctrl: ctrl}
+	mock.recorder = &MockReportProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportProvider) EXPECT() *MockReportProviderMockRecorder {
+	return m.recorder
+}
+
+// ReportGenerators mocks base method.
+func (m *MockReportProvider) ReportGenerators() []reports.Generator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportGenerators")
+	ret0, _ := ret[0].([]reports.Generator)
+	return ret0
+}
+
+// ReportGenerators indicates an expected call of ReportGenerators.
+func (mr *MockReportProviderMockRecorder) ReportGenerators() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportGenerators", reflect.TypeOf((*MockReportProvider)(nil).ReportGenerators))
+}
+
+// MockProjector is a mock of Projector interface.
+type MockProjector struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectorMockRecorder
+}
+
+// MockProjectorMockRecorder is the mock recorder for MockProjector.
+type MockProjectorMockRecorder struct {
+	mock *MockProjector
+}
+
+// NewMockProjector creates a new mock instance.
+func NewMockProjector(ctrl *gomock.Controller) *MockProjector {
+	mock := &MockProjector{ctrl: ctrl}
+	mock.recorder = &MockProjectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjector) EXPECT() *MockProjectorMockRecorder {
+	return m.recorder
+}
+
+// Projections mocks base method.
+func (m *MockProjector) Projections() []events.Projection {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Projections")
+	ret0, _ := ret[0].([]events.Projection)
+	return ret0
+}
+
+// Projections indicates an expected call of Projections.
+func (mr *MockProjectorMockRecorder) Projections() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Projections", reflect.TypeOf((*MockProjector)(nil).Projections))
+}
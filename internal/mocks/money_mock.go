@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: money.go
+//
+// Generated by this command:
+//
+//	mockgen -source=money.go -destination=../../../mocks/money_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	money "clean-arch-gin/internal/domain/shared/money"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExchangeRateProvider is a mock of ExchangeRateProvider interface.
+type MockExchangeRateProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateProviderMockRecorder
+}
+
+// MockExchangeRateProviderMockRecorder is the mock recorder for MockExchangeRateProvider.
+type MockExchangeRateProviderMockRecorder struct {
+	mock *MockExchangeRateProvider
+}
+
+// NewMockExchangeRateProvider creates a new mock instance.
+func NewMockExchangeRateProvider(ctrl *gomock.Controller) *MockExchangeRateProvider {
+	mock := &MockExchangeRateProvider{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRateProvider) EXPECT() *MockExchangeRateProviderMockRecorder {
+	return m.recorder
+}
+
+// Rate mocks base method.
+func (m *MockExchangeRateProvider) Rate(ctx context.Context, from, to money.Currency) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", ctx, from, to)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockExchangeRateProviderMockRecorder) Rate(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockExchangeRateProvider)(nil).Rate), ctx, from, to)
+}
+
+// MockBaseCurrencies is a mock of BaseCurrencies interface.
+type MockBaseCurrencies struct {
+	ctrl     *gomock.Controller
+	recorder *MockBaseCurrenciesMockRecorder
+}
+
+// MockBaseCurrenciesMockRecorder is the mock recorder for MockBaseCurrencies.
+type MockBaseCurrenciesMockRecorder struct {
+	mock *MockBaseCurrencies
+}
+
+// NewMockBaseCurrencies creates a new mock instance.
+func NewMockBaseCurrencies(ctrl *gomock.Controller) *MockBaseCurrencies {
+	mock := &MockBaseCurrencies{ctrl: ctrl}
+	mock.recorder = &MockBaseCurrenciesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBaseCurrencies) EXPECT() *MockBaseCurrenciesMockRecorder {
+	return m.recorder
+}
+
+// BaseCurrency mocks base method.
+func (m *MockBaseCurrencies) BaseCurrency(ctx context.Context) (money.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseCurrency", ctx)
+	ret0, _ := ret[0].(money.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BaseCurrency indicates an expected call of BaseCurrency.
+func (mr *MockBaseCurrenciesMockRecorder) BaseCurrency(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseCurrency", reflect.TypeOf((*MockBaseCurrencies)(nil).BaseCurrency), ctx)
+}
@@ -0,0 +1,324 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handlers.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handlers.go -destination=../../../mocks/order_handlers_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderHandlers is a mock of OrderHandlers interface.
+type MockOrderHandlers struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderHandlersMockRecorder
+}
+
+// MockOrderHandlersMockRecorder is the mock recorder for MockOrderHandlers.
+type MockOrderHandlersMockRecorder struct {
+	mock *MockOrderHandlers
+}
+
+// NewMockOrderHandlers creates a new mock instance.
+func NewMockOrderHandlers(ctrl *gomock.Controller) *MockOrderHandlers {
+	mock := &MockOrderHandlers{ctrl: ctrl}
+	mock.recorder = &MockOrderHandlersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderHandlers) EXPECT() *MockOrderHandlersMockRecorder {
+	return m.recorder
+}
+
+// CancelOrder mocks base method.
+func (m *MockOrderHandlers) CancelOrder(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CancelOrder", c)
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderHandlersMockRecorder) CancelOrder(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderHandlers)(nil).CancelOrder), c)
+}
+
+// ConfirmOrder mocks base method.
+func (m *MockOrderHandlers) ConfirmOrder(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ConfirmOrder", c)
+}
+
+// ConfirmOrder indicates an expected call of ConfirmOrder.
+func (mr *MockOrderHandlersMockRecorder) ConfirmOrder(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmOrder", reflect.TypeOf((*MockOrderHandlers)(nil).ConfirmOrder), c)
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrderHandlers) CreateOrder(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateOrder", c)
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderHandlersMockRecorder) CreateOrder(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderHandlers)(nil).CreateOrder), c)
+}
+
+// CreateShipment mocks base method.
+func (m *MockOrderHandlers) CreateShipment(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateShipment", c)
+}
+
+// CreateShipment indicates an expected call of CreateShipment.
+func (mr *MockOrderHandlersMockRecorder) CreateShipment(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipment", reflect.TypeOf((*MockOrderHandlers)(nil).CreateShipment), c)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrderHandlers) GetOrder(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetOrder", c)
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderHandlersMockRecorder) GetOrder(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderHandlers)(nil).GetOrder), c)
+}
+
+// ListShipments mocks base method.
+func (m *MockOrderHandlers) ListShipments(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListShipments", c)
+}
+
+// ListShipments indicates an expected call of ListShipments.
+func (mr *MockOrderHandlersMockRecorder) ListShipments(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShipments", reflect.TypeOf((*MockOrderHandlers)(nil).ListShipments), c)
+}
+
+// QuoteOrder mocks base method.
+func (m *MockOrderHandlers) QuoteOrder(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "QuoteOrder", c)
+}
+
+// QuoteOrder indicates an expected call of QuoteOrder.
+func (mr *MockOrderHandlersMockRecorder) QuoteOrder(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteOrder", reflect.TypeOf((*MockOrderHandlers)(nil).QuoteOrder), c)
+}
+
+// StreamOrderEvents mocks base method.
+func (m *MockOrderHandlers) StreamOrderEvents(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StreamOrderEvents", c)
+}
+
+// StreamOrderEvents indicates an expected call of StreamOrderEvents.
+func (mr *MockOrderHandlersMockRecorder) StreamOrderEvents(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamOrderEvents", reflect.TypeOf((*MockOrderHandlers)(nil).StreamOrderEvents), c)
+}
+
+// MockReturnHandlers is a mock of ReturnHandlers interface.
+type MockReturnHandlers struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnHandlersMockRecorder
+}
+
+// MockReturnHandlersMockRecorder is the mock recorder for MockReturnHandlers.
+type MockReturnHandlersMockRecorder struct {
+	mock *MockReturnHandlers
+}
+
+// NewMockReturnHandlers creates a new mock instance.
+func NewMockReturnHandlers(ctrl *gomock.Controller) *MockReturnHandlers {
+	mock := &MockReturnHandlers{ctrl: ctrl}
+	mock.recorder = &MockReturnHandlersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnHandlers) EXPECT() *MockReturnHandlersMockRecorder {
+	return m.recorder
+}
+
+// ApproveReturn mocks base method.
+func (m *MockReturnHandlers) ApproveReturn(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ApproveReturn", c)
+}
+
+// ApproveReturn indicates an expected call of ApproveReturn.
+func (mr *MockReturnHandlersMockRecorder) ApproveReturn(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveReturn", reflect.TypeOf((*MockReturnHandlers)(nil).ApproveReturn), c)
+}
+
+// GetReturn mocks base method.
+func (m *MockReturnHandlers) GetReturn(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetReturn", c)
+}
+
+// GetReturn indicates an expected call of GetReturn.
+func (mr *MockReturnHandlersMockRecorder) GetReturn(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturn", reflect.TypeOf((*MockReturnHandlers)(nil).GetReturn), c)
+}
+
+// ListReturns mocks base method.
+func (m *MockReturnHandlers) ListReturns(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListReturns", c)
+}
+
+// ListReturns indicates an expected call of ListReturns.
+func (mr *MockReturnHandlersMockRecorder) ListReturns(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReturns", reflect.TypeOf((*MockReturnHandlers)(nil).ListReturns), c)
+}
+
+// ReceiveReturn mocks base method.
+func (m *MockReturnHandlers) ReceiveReturn(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReceiveReturn", c)
+}
+
+// ReceiveReturn indicates an expected call of ReceiveReturn.
+func (mr *MockReturnHandlersMockRecorder) ReceiveReturn(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveReturn", reflect.TypeOf((*MockReturnHandlers)(nil).ReceiveReturn), c)
+}
+
+// RefundReturn mocks base method.
+func (m *MockReturnHandlers) RefundReturn(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RefundReturn", c)
+}
+
+// RefundReturn indicates an expected call of RefundReturn.
+func (mr *MockReturnHandlersMockRecorder) RefundReturn(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundReturn", reflect.TypeOf((*MockReturnHandlers)(nil).RefundReturn), c)
+}
+
+// RejectReturn mocks base method.
+func (m *MockReturnHandlers) RejectReturn(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RejectReturn", c)
+}
+
+// RejectReturn indicates an expected call of RejectReturn.
+func (mr *MockReturnHandlersMockRecorder) RejectReturn(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectReturn", reflect.TypeOf((*MockReturnHandlers)(nil).RejectReturn), c)
+}
+
+// RequestReturn mocks base method.
+func (m *MockReturnHandlers) RequestReturn(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RequestReturn", c)
+}
+
+// RequestReturn indicates an expected call of RequestReturn.
+func (mr *MockReturnHandlersMockRecorder) RequestReturn(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestReturn", reflect.TypeOf((*MockReturnHandlers)(nil).RequestReturn), c)
+}
+
+// MockInventoryHandlers is a mock of InventoryHandlers interface.
+type MockInventoryHandlers struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryHandlersMockRecorder
+}
+
+// MockInventoryHandlersMockRecorder is the mock recorder for MockInventoryHandlers.
+type MockInventoryHandlersMockRecorder struct {
+	mock *MockInventoryHandlers
+}
+
+// NewMockInventoryHandlers creates a new mock instance.
+func NewMockInventoryHandlers(ctrl *gomock.Controller) *MockInventoryHandlers {
+	mock := &MockInventoryHandlers{ctrl: ctrl}
+	mock.recorder = &MockInventoryHandlersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryHandlers) EXPECT() *MockInventoryHandlersMockRecorder {
+	return m.recorder
+}
+
+// GetStock mocks base method.
+func (m *MockInventoryHandlers) GetStock(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetStock", c)
+}
+
+// GetStock indicates an expected call of GetStock.
+func (mr *MockInventoryHandlersMockRecorder) GetStock(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStock", reflect.TypeOf((*MockInventoryHandlers)(nil).GetStock), c)
+}
+
+// SetStock mocks base method.
+func (m *MockInventoryHandlers) SetStock(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetStock", c)
+}
+
+// SetStock indicates an expected call of SetStock.
+func (mr *MockInventoryHandlersMockRecorder) SetStock(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStock", reflect.TypeOf((*MockInventoryHandlers)(nil).SetStock), c)
+}
+
+// MockInvoiceHandlers is a mock of InvoiceHandlers interface.
+type MockInvoiceHandlers struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceHandlersMockRecorder
+}
+
+// MockInvoiceHandlersMockRecorder is the mock recorder for MockInvoiceHandlers.
+type MockInvoiceHandlersMockRecorder struct {
+	mock *MockInvoiceHandlers
+}
+
+// NewMockInvoiceHandlers creates a new mock instance.
+func NewMockInvoiceHandlers(ctrl *gomock.Controller) *MockInvoiceHandlers {
+	mock := &MockInvoiceHandlers{ctrl: ctrl}
+	mock.recorder = &MockInvoiceHandlersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceHandlers) EXPECT() *MockInvoiceHandlersMockRecorder {
+	return m.recorder
+}
+
+// GetInvoicePDF mocks base method.
+func (m *MockInvoiceHandlers) GetInvoicePDF(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetInvoicePDF", c)
+}
+
+// GetInvoicePDF indicates an expected call of GetInvoicePDF.
+func (mr *MockInvoiceHandlersMockRecorder) GetInvoicePDF(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoicePDF", reflect.TypeOf((*MockInvoiceHandlers)(nil).GetInvoicePDF), c)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: order_search_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=order_search_repository.go -destination=../../../mocks/order_search_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/order/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderSearchRepository is a mock of OrderSearchRepository interface.
+type MockOrderSearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderSearchRepositoryMockRecorder
+}
+
+// MockOrderSearchRepositoryMockRecorder is the mock recorder for MockOrderSearchRepository.
+type MockOrderSearchRepositoryMockRecorder struct {
+	mock *MockOrderSearchRepository
+}
+
+// NewMockOrderSearchRepository creates a new mock instance.
+func NewMockOrderSearchRepository(ctrl *gomock.Controller) *MockOrderSearchRepository {
+	mock := &MockOrderSearchRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderSearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderSearchRepository) EXPECT() *MockOrderSearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockOrderSearchRepository) Search(ctx context.Context, criteria entities.OrderSearch, limit, offset int) ([]*entities.OrderSearchHit, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, criteria, limit, offset)
+	ret0, _ := ret[0].([]*entities.OrderSearchHit)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockOrderSearchRepositoryMockRecorder) Search(ctx, criteria, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockOrderSearchRepository)(nil).Search), ctx, criteria, limit, offset)
+}
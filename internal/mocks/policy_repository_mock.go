@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: policy_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=policy_repository.go -destination=../../../mocks/policy_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/authz/entities"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPolicyRepository is a mock of PolicyRepository interface.
+type MockPolicyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyRepositoryMockRecorder
+}
+
+// MockPolicyRepositoryMockRecorder is the mock recorder for MockPolicyRepository.
+type MockPolicyRepositoryMockRecorder struct {
+	mock *MockPolicyRepository
+}
+
+// NewMockPolicyRepository creates a new mock instance.
+func NewMockPolicyRepository(ctrl *gomock.Controller) *MockPolicyRepository {
+	mock := &MockPolicyRepository{ctrl: ctrl}
+	mock.recorder = &MockPolicyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyRepository) EXPECT() *MockPolicyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddPolicy mocks base method.
+func (m *MockPolicyRepository) AddPolicy(policy *entities.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPolicy", policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPolicy indicates an expected call of AddPolicy.
+func (mr *MockPolicyRepositoryMockRecorder) AddPolicy(policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPolicy", reflect.TypeOf((*MockPolicyRepository)(nil).AddPolicy), policy)
+}
+
+// AddRoleAssignment mocks base method.
+func (m *MockPolicyRepository) AddRoleAssignment(assignment *entities.RoleAssignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRoleAssignment", assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRoleAssignment indicates an expected call of AddRoleAssignment.
+func (mr *MockPolicyRepositoryMockRecorder) AddRoleAssignment(assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoleAssignment", reflect.TypeOf((*MockPolicyRepository)(nil).AddRoleAssignment), assignment)
+}
+
+// ListPolicies mocks base method.
+func (m *MockPolicyRepository) ListPolicies() ([]*entities.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPolicies")
+	ret0, _ := ret[0].([]*entities.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPolicies indicates an expected call of ListPolicies.
+func (mr *MockPolicyRepositoryMockRecorder) ListPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPolicies", reflect.TypeOf((*MockPolicyRepository)(nil).ListPolicies))
+}
+
+// ListRoleAssignments mocks base method.
+func (m *MockPolicyRepository) ListRoleAssignments() ([]*entities.RoleAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoleAssignments")
+	ret0, _ := ret[0].([]*entities.RoleAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoleAssignments indicates an expected call of ListRoleAssignments.
+func (mr *MockPolicyRepositoryMockRecorder) ListRoleAssignments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoleAssignments", reflect.TypeOf((*MockPolicyRepository)(nil).ListRoleAssignments))
+}
+
+// RemovePolicy mocks base method.
+func (m *MockPolicyRepository) RemovePolicy(policy *entities.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePolicy", policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePolicy indicates an expected call of RemovePolicy.
+func (mr *MockPolicyRepositoryMockRecorder) RemovePolicy(policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePolicy", reflect.TypeOf((*MockPolicyRepository)(nil).RemovePolicy), policy)
+}
+
+// RemoveRoleAssignment mocks base method.
+func (m *MockPolicyRepository) RemoveRoleAssignment(assignment *entities.RoleAssignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRoleAssignment", assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveRoleAssignment indicates an expected call of RemoveRoleAssignment.
+func (mr *MockPolicyRepositoryMockRecorder) RemoveRoleAssignment(assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRoleAssignment", reflect.TypeOf((*MockPolicyRepository)(nil).RemoveRoleAssignment), assignment)
+}
@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: policy_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=policy_usecase.go -destination=../../../mocks/policy_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/authz/entities"
+	authz "clean-arch-gin/internal/domain/shared/authz"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPolicyUseCase is a mock of PolicyUseCase interface.
+type MockPolicyUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyUseCaseMockRecorder
+}
+
+// MockPolicyUseCaseMockRecorder is the mock recorder for MockPolicyUseCase.
+type MockPolicyUseCaseMockRecorder struct {
+	mock *MockPolicyUseCase
+}
+
+// NewMockPolicyUseCase creates a new mock instance.
+func NewMockPolicyUseCase(ctrl *gomock.Controller) *MockPolicyUseCase {
+	mock := &MockPolicyUseCase{ctrl: ctrl}
+	mock.recorder = &MockPolicyUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyUseCase) EXPECT() *MockPolicyUseCaseMockRecorder {
+	return m.recorder
+}
+
+// AssignRole mocks base method.
+func (m *MockPolicyUseCase) AssignRole(actor authz.Actor, subject, role string) (*entities.RoleAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRole", actor, subject, role)
+	ret0, _ := ret[0].(*entities.RoleAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignRole indicates an expected call of AssignRole.
+func (mr *MockPolicyUseCaseMockRecorder) AssignRole(actor, subject, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRole", reflect.TypeOf((*MockPolicyUseCase)(nil).AssignRole), actor, subject, role)
+}
+
+// Check mocks base method.
+func (m *MockPolicyUseCase) Check(subject, object, action string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", subject, object, action)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockPolicyUseCaseMockRecorder) Check(subject, object, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockPolicyUseCase)(nil).Check), subject, object, action)
+}
+
+// GrantPolicy mocks base method.
+func (m *MockPolicyUseCase) GrantPolicy(actor authz.Actor, subject, object, action string) (*entities.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantPolicy", actor, subject, object, action)
+	ret0, _ := ret[0].(*entities.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantPolicy indicates an expected call of GrantPolicy.
+func (mr *MockPolicyUseCaseMockRecorder) GrantPolicy(actor, subject, object, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantPolicy", reflect.TypeOf((*MockPolicyUseCase)(nil).GrantPolicy), actor, subject, object, action)
+}
+
+// ListPolicies mocks base method.
+func (m *MockPolicyUseCase) ListPolicies() ([]*entities.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPolicies")
+	ret0, _ := ret[0].([]*entities.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPolicies indicates an expected call of ListPolicies.
+func (mr *MockPolicyUseCaseMockRecorder) ListPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPolicies", reflect.TypeOf((*MockPolicyUseCase)(nil).ListPolicies))
+}
+
+// ListRoleAssignments mocks base method.
+func (m *MockPolicyUseCase) ListRoleAssignments() ([]*entities.RoleAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoleAssignments")
+	ret0, _ := ret[0].([]*entities.RoleAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoleAssignments indicates an expected call of ListRoleAssignments.
+func (mr *MockPolicyUseCaseMockRecorder) ListRoleAssignments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoleAssignments", reflect.TypeOf((*MockPolicyUseCase)(nil).ListRoleAssignments))
+}
+
+// RevokePolicy mocks base method.
+func (m *MockPolicyUseCase) RevokePolicy(actor authz.Actor, subject, object, action string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokePolicy", actor, subject, object, action)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokePolicy indicates an expected call of RevokePolicy.
+func (mr *MockPolicyUseCaseMockRecorder) RevokePolicy(actor, subject, object, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokePolicy", reflect.TypeOf((*MockPolicyUseCase)(nil).RevokePolicy), actor, subject, object, action)
+}
+
+// UnassignRole mocks base method.
+func (m *MockPolicyUseCase) UnassignRole(actor authz.Actor, subject, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignRole", actor, subject, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignRole indicates an expected call of UnassignRole.
+func (mr *MockPolicyUseCaseMockRecorder) UnassignRole(actor, subject, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignRole", reflect.TypeOf((*MockPolicyUseCase)(nil).UnassignRole), actor, subject, role)
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: preferences_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=preferences_repository.go -destination=../../../mocks/preferences_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPreferencesRepository is a mock of PreferencesRepository interface.
+type MockPreferencesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferencesRepositoryMockRecorder
+}
+
+// MockPreferencesRepositoryMockRecorder is the mock recorder for MockPreferencesRepository.
+type MockPreferencesRepositoryMockRecorder struct {
+	mock *MockPreferencesRepository
+}
+
+// NewMockPreferencesRepository creates a new mock instance.
+func NewMockPreferencesRepository(ctrl *gomock.Controller) *MockPreferencesRepository {
+	mock := &MockPreferencesRepository{ctrl: ctrl}
+	mock.recorder = &MockPreferencesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferencesRepository) EXPECT() *MockPreferencesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockPreferencesRepository) Get(ctx context.Context, userID uint) (*entities.UserPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID)
+	ret0, _ := ret[0].(*entities.UserPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPreferencesRepositoryMockRecorder) Get(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPreferencesRepository)(nil).Get), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockPreferencesRepository) Save(ctx context.Context, prefs *entities.UserPreferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, prefs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPreferencesRepositoryMockRecorder) Save(ctx, prefs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPreferencesRepository)(nil).Save), ctx, prefs)
+}
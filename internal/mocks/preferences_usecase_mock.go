@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: preferences_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=preferences_usecase.go -destination=../../../mocks/preferences_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPreferencesUseCase is a mock of PreferencesUseCase interface.
+type MockPreferencesUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferencesUseCaseMockRecorder
+}
+
+// MockPreferencesUseCaseMockRecorder is the mock recorder for MockPreferencesUseCase.
+type MockPreferencesUseCaseMockRecorder struct {
+	mock *MockPreferencesUseCase
+}
+
+// NewMockPreferencesUseCase creates a new mock instance.
+func NewMockPreferencesUseCase(ctrl *gomock.Controller) *MockPreferencesUseCase {
+	mock := &MockPreferencesUseCase{ctrl: ctrl}
+	mock.recorder = &MockPreferencesUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferencesUseCase) EXPECT() *MockPreferencesUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetPreferences mocks base method.
+func (m *MockPreferencesUseCase) GetPreferences(ctx context.Context, userID uint) (*entities.UserPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userID)
+	ret0, _ := ret[0].(*entities.UserPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockPreferencesUseCaseMockRecorder) GetPreferences(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockPreferencesUseCase)(nil).GetPreferences), ctx, userID)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockPreferencesUseCase) UpdatePreferences(ctx context.Context, userID uint, update entities.PreferencesUpdate) (*entities.UserPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", ctx, userID, update)
+	ret0, _ := ret[0].(*entities.UserPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockPreferencesUseCaseMockRecorder) UpdatePreferences(ctx, userID, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockPreferencesUseCase)(nil).UpdatePreferences), ctx, userID, update)
+}
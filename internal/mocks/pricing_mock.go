@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pricing.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pricing.go -destination=../../../mocks/pricing_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	pricing "clean-arch-gin/internal/domain/shared/pricing"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaxCalculator is a mock of TaxCalculator interface.
+type MockTaxCalculator struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxCalculatorMockRecorder
+}
+
+// MockTaxCalculatorMockRecorder is the mock recorder for MockTaxCalculator.
+type MockTaxCalculatorMockRecorder struct {
+	mock *MockTaxCalculator
+}
+
+// NewMockTaxCalculator creates a new mock instance.
+func NewMockTaxCalculator(ctrl *gomock.Controller) *MockTaxCalculator {
+	mock := &MockTaxCalculator{ctrl: ctrl}
+	mock.recorder = &MockTaxCalculatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxCalculator) EXPECT() *MockTaxCalculatorMockRecorder {
+	return m.recorder
+}
+
+// CalculateTax mocks base method.
+func (m *MockTaxCalculator) CalculateTax(ctx context.Context, destination pricing.Destination, lines []pricing.Line) (pricing.Tax, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateTax", ctx, destination, lines)
+	ret0, _ := ret[0].(pricing.Tax)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateTax indicates an expected call of CalculateTax.
+func (mr *MockTaxCalculatorMockRecorder) CalculateTax(ctx, destination, lines any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateTax", reflect.TypeOf((*MockTaxCalculator)(nil).CalculateTax), ctx, destination, lines)
+}
+
+// MockShippingStrategy is a mock of ShippingStrategy interface.
+type MockShippingStrategy struct {
+	ctrl     *gomock.Controller
+	recorder *MockShippingStrategyMockRecorder
+}
+
+// MockShippingStrategyMockRecorder is the mock recorder for MockShippingStrategy.
+type MockShippingStrategyMockRecorder struct {
+	mock *MockShippingStrategy
+}
+
+// NewMockShippingStrategy creates a new mock instance.
+func NewMockShippingStrategy(ctrl *gomock.Controller) *MockShippingStrategy {
+	mock := &MockShippingStrategy{ctrl: ctrl}
+	mock.recorder = &MockShippingStrategyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShippingStrategy) EXPECT() *MockShippingStrategyMockRecorder {
+	return m.recorder
+}
+
+// ShippingRate mocks base method.
+func (m *MockShippingStrategy) ShippingRate(ctx context.Context, destination pricing.Destination, lines []pricing.Line) (pricing.ShippingRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShippingRate", ctx, destination, lines)
+	ret0, _ := ret[0].(pricing.ShippingRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShippingRate indicates an expected call of ShippingRate.
+func (mr *MockShippingStrategyMockRecorder) ShippingRate(ctx, destination, lines any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShippingRate", reflect.TypeOf((*MockShippingStrategy)(nil).ShippingRate), ctx, destination, lines)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pricing_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pricing_usecase.go -destination=../../../mocks/pricing_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/order/entities"
+	money "clean-arch-gin/internal/domain/shared/money"
+	pricing "clean-arch-gin/internal/domain/shared/pricing"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPricingUseCase is a mock of PricingUseCase interface.
+type MockPricingUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPricingUseCaseMockRecorder
+}
+
+// MockPricingUseCaseMockRecorder is the mock recorder for MockPricingUseCase.
+type MockPricingUseCaseMockRecorder struct {
+	mock *MockPricingUseCase
+}
+
+// NewMockPricingUseCase creates a new mock instance.
+func NewMockPricingUseCase(ctrl *gomock.Controller) *MockPricingUseCase {
+	mock := &MockPricingUseCase{ctrl: ctrl}
+	mock.recorder = &MockPricingUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPricingUseCase) EXPECT() *MockPricingUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ExchangeRate mocks base method.
+func (m *MockPricingUseCase) ExchangeRate(ctx context.Context, from, to money.Currency) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExchangeRate", ctx, from, to)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExchangeRate indicates an expected call of ExchangeRate.
+func (mr *MockPricingUseCaseMockRecorder) ExchangeRate(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeRate", reflect.TypeOf((*MockPricingUseCase)(nil).ExchangeRate), ctx, from, to)
+}
+
+// PriceItems mocks base method.
+func (m *MockPricingUseCase) PriceItems(ctx context.Context, destination pricing.Destination, items []*entities.OrderItem) (*entities.PriceBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PriceItems", ctx, destination, items)
+	ret0, _ := ret[0].(*entities.PriceBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PriceItems indicates an expected call of PriceItems.
+func (mr *MockPricingUseCaseMockRecorder) PriceItems(ctx, destination, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PriceItems", reflect.TypeOf((*MockPricingUseCase)(nil).PriceItems), ctx, destination, items)
+}
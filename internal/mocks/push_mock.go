@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: push.go
+//
+// Generated by this command:
+//
+//	mockgen -source=push.go -destination=../../../mocks/push_mock.go -package=mocks -mock_names=Sender=MockPushSender
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	push "clean-arch-gin/internal/domain/shared/push"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPushSender is a mock of Sender interface.
+type MockPushSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockPushSenderMockRecorder
+}
+
+// MockPushSenderMockRecorder is the mock recorder for MockPushSender.
+type MockPushSenderMockRecorder struct {
+	mock *MockPushSender
+}
+
+// NewMockPushSender creates a new mock instance.
+func NewMockPushSender(ctrl *gomock.Controller) *MockPushSender {
+	mock := &MockPushSender{ctrl: ctrl}
+	mock.recorder = &MockPushSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPushSender) EXPECT() *MockPushSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockPushSender) Send(ctx context.Context, token string, msg push.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, token, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockPushSenderMockRecorder) Send(ctx, token, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockPushSender)(nil).Send), ctx, token, msg)
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: return_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=return_usecase.go -destination=../../../mocks/return_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/order/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReturnUseCase is a mock of ReturnUseCase interface.
+type MockReturnUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnUseCaseMockRecorder
+}
+
+// MockReturnUseCaseMockRecorder is the mock recorder for MockReturnUseCase.
+type MockReturnUseCaseMockRecorder struct {
+	mock *MockReturnUseCase
+}
+
+// NewMockReturnUseCase creates a new mock instance.
+func NewMockReturnUseCase(ctrl *gomock.Controller) *MockReturnUseCase {
+	mock := &MockReturnUseCase{ctrl: ctrl}
+	mock.recorder = &MockReturnUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnUseCase) EXPECT() *MockReturnUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ApproveReturn mocks base method.
+func (m *MockReturnUseCase) ApproveReturn(ctx context.Context, orderID, id uint, note string) (*entities.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveReturn", ctx, orderID, id, note)
+	ret0, _ := ret[0].(*entities.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveReturn indicates an expected call of ApproveReturn.
+func (mr *MockReturnUseCaseMockRecorder) ApproveReturn(ctx, orderID, id, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveReturn", reflect.TypeOf((*MockReturnUseCase)(nil).ApproveReturn), ctx, orderID, id, note)
+}
+
+// GetReturn mocks base method.
+func (m *MockReturnUseCase) GetReturn(ctx context.Context, orderID, id uint) (*entities.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturn", ctx, orderID, id)
+	ret0, _ := ret[0].(*entities.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturn indicates an expected call of GetReturn.
+func (mr *MockReturnUseCaseMockRecorder) GetReturn(ctx, orderID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturn", reflect.TypeOf((*MockReturnUseCase)(nil).GetReturn), ctx, orderID, id)
+}
+
+// ListReturns mocks base method.
+func (m *MockReturnUseCase) ListReturns(ctx context.Context, orderID uint) ([]*entities.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReturns", ctx, orderID)
+	ret0, _ := ret[0].([]*entities.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReturns indicates an expected call of ListReturns.
+func (mr *MockReturnUseCaseMockRecorder) ListReturns(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReturns", reflect.TypeOf((*MockReturnUseCase)(nil).ListReturns), ctx, orderID)
+}
+
+// ReceiveReturn mocks base method.
+func (m *MockReturnUseCase) ReceiveReturn(ctx context.Context, orderID, id uint) (*entities.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveReturn", ctx, orderID, id)
+	ret0, _ := ret[0].(*entities.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveReturn indicates an expected call of ReceiveReturn.
+func (mr *MockReturnUseCaseMockRecorder) ReceiveReturn(ctx, orderID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveReturn", reflect.TypeOf((*MockReturnUseCase)(nil).ReceiveReturn), ctx, orderID, id)
+}
+
+// RefundReturn mocks base method.
+func (m *MockReturnUseCase) RefundReturn(ctx context.Context, orderID, id uint) (*entities.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundReturn", ctx, orderID, id)
+	ret0, _ := ret[0].(*entities.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundReturn indicates an expected call of RefundReturn.
+func (mr *MockReturnUseCaseMockRecorder) RefundReturn(ctx, orderID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundReturn", reflect.TypeOf((*MockReturnUseCase)(nil).RefundReturn), ctx, orderID, id)
+}
+
+// RejectReturn mocks base method.
+func (m *MockReturnUseCase) RejectReturn(ctx context.Context, orderID, id uint, note string) (*entities.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectReturn", ctx, orderID, id, note)
+	ret0, _ := ret[0].(*entities.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectReturn indicates an expected call of RejectReturn.
+func (mr *MockReturnUseCaseMockRecorder) RejectReturn(ctx, orderID, id, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectReturn", reflect.TypeOf((*MockReturnUseCase)(nil).RejectReturn), ctx, orderID, id, note)
+}
+
+// RequestReturn mocks base method.
+func (m *MockReturnUseCase) RequestReturn(ctx context.Context, orderID uint, reason string, quantities map[uint]int) (*entities.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestReturn", ctx, orderID, reason, quantities)
+	ret0, _ := ret[0].(*entities.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestReturn indicates an expected call of RequestReturn.
+func (mr *MockReturnUseCaseMockRecorder) RequestReturn(ctx, orderID, reason, quantities any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestReturn", reflect.TypeOf((*MockReturnUseCase)(nil).RequestReturn), ctx, orderID, reason, quantities)
+}
@@ -0,0 +1,226 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search.go
+//
+// Generated by this command:
+//
+//	mockgen -source=search.go -destination=../../../mocks/search_mock.go -package=mocks -mock_names=Engine=MockSearchEngine
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	search "clean-arch-gin/internal/domain/shared/search"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDocumentSource is a mock of DocumentSource interface.
+type MockDocumentSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentSourceMockRecorder
+}
+
+// MockDocumentSourceMockRecorder is the mock recorder for MockDocumentSource.
+type MockDocumentSourceMockRecorder struct {
+	mock *MockDocumentSource
+}
+
+// NewMockDocumentSource creates a new mock instance.
+func NewMockDocumentSource(ctrl *gomock.Controller) *MockDocumentSource {
+	mock := &MockDocumentSource{ctrl: ctrl}
+	mock.recorder = &MockDocumentSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentSource) EXPECT() *MockDocumentSourceMockRecorder {
+	return m.recorder
+}
+
+// Document mocks base method.
+func (m *MockDocumentSource) Document(ctx context.Context, id uint) (*search.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Document", ctx, id)
+	ret0, _ := ret[0].(*search.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Document indicates an expected call of Document.
+func (mr *MockDocumentSourceMockRecorder) Document(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Document", reflect.TypeOf((*MockDocumentSource)(nil).Document), ctx, id)
+}
+
+// Documents mocks base method.
+func (m *MockDocumentSource) Documents(ctx context.Context, yield func(search.Document) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Documents", ctx, yield)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Documents indicates an expected call of Documents.
+func (mr *MockDocumentSourceMockRecorder) Documents(ctx, yield any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Documents", reflect.TypeOf((*MockDocumentSource)(nil).Documents), ctx, yield)
+}
+
+// MockIndexer is a mock of Indexer interface.
+type MockIndexer struct {
+	ctrl     *gomock.Controller
+	recorder *MockIndexerMockRecorder
+}
+
+// MockIndexerMockRecorder is the mock recorder for MockIndexer.
+type MockIndexerMockRecorder struct {
+	mock *MockIndexer
+}
+
+// NewMockIndexer creates a new mock instance.
+func NewMockIndexer(ctrl *gomock.Controller) *MockIndexer {
+	mock := &MockIndexer{ctrl: ctrl}
+	mock.recorder = &MockIndexerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIndexer) EXPECT() *MockIndexerMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIndexer) Delete(ctx context.Context, index, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, index, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIndexerMockRecorder) Delete(ctx, index, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIndexer)(nil).Delete), ctx, index, id)
+}
+
+// Index mocks base method.
+func (m *MockIndexer) Index(ctx context.Context, index string, doc search.Document) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", ctx, index, doc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockIndexerMockRecorder) Index(ctx, index, doc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockIndexer)(nil).Index), ctx, index, doc)
+}
+
+// MockSearchRepository is a mock of SearchRepository interface.
+type MockSearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchRepositoryMockRecorder
+}
+
+// MockSearchRepositoryMockRecorder is the mock recorder for MockSearchRepository.
+type MockSearchRepositoryMockRecorder struct {
+	mock *MockSearchRepository
+}
+
+// NewMockSearchRepository creates a new mock instance.
+func NewMockSearchRepository(ctrl *gomock.Controller) *MockSearchRepository {
+	mock := &MockSearchRepository{ctrl: ctrl}
+	mock.recorder = &MockSearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchRepository) EXPECT() *MockSearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchRepository) Search(ctx context.Context, query search.Query) ([]search.Hit, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query)
+	ret0, _ := ret[0].([]search.Hit)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchRepositoryMockRecorder) Search(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchRepository)(nil).Search), ctx, query)
+}
+
+// MockSearchEngine is a mock of Engine interface.
+type MockSearchEngine struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchEngineMockRecorder
+}
+
+// MockSearchEngineMockRecorder is the mock recorder for MockSearchEngine.
+type MockSearchEngineMockRecorder struct {
+	mock *MockSearchEngine
+}
+
+// NewMockSearchEngine creates a new mock instance.
+func NewMockSearchEngine(ctrl *gomock.Controller) *MockSearchEngine {
+	mock := &MockSearchEngine{ctrl: ctrl}
+	mock.recorder = &MockSearchEngineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchEngine) EXPECT() *MockSearchEngineMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSearchEngine) Delete(ctx context.Context, index, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, index, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSearchEngineMockRecorder) Delete(ctx, index, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSearchEngine)(nil).Delete), ctx, index, id)
+}
+
+// Index mocks base method.
+func (m *MockSearchEngine) Index(ctx context.Context, index string, doc search.Document) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", ctx, index, doc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockSearchEngineMockRecorder) Index(ctx, index, doc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockSearchEngine)(nil).Index), ctx, index, doc)
+}
+
+// Search mocks base method.
+func (m *MockSearchEngine) Search(ctx context.Context, query search.Query) ([]search.Hit, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query)
+	ret0, _ := ret[0].([]search.Hit)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchEngineMockRecorder) Search(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchEngine)(nil).Search), ctx, query)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sms_consent_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sms_consent_repository.go -destination=../../../mocks/sms_consent_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSMSConsentRepository is a mock of SMSConsentRepository interface.
+type MockSMSConsentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSMSConsentRepositoryMockRecorder
+}
+
+// MockSMSConsentRepositoryMockRecorder is the mock recorder for MockSMSConsentRepository.
+type MockSMSConsentRepositoryMockRecorder struct {
+	mock *MockSMSConsentRepository
+}
+
+// NewMockSMSConsentRepository creates a new mock instance.
+func NewMockSMSConsentRepository(ctrl *gomock.Controller) *MockSMSConsentRepository {
+	mock := &MockSMSConsentRepository{ctrl: ctrl}
+	mock.recorder = &MockSMSConsentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSMSConsentRepository) EXPECT() *MockSMSConsentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SetOptedOut mocks base method.
+func (m *MockSMSConsentRepository) SetOptedOut(ctx context.Context, phone string, at *time.Time) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOptedOut", ctx, phone, at)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOptedOut indicates an expected call of SetOptedOut.
+func (mr *MockSMSConsentRepositoryMockRecorder) SetOptedOut(ctx, phone, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOptedOut", reflect.TypeOf((*MockSMSConsentRepository)(nil).SetOptedOut), ctx, phone, at)
+}
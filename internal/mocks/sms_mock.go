@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sms.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sms.go -destination=../../../mocks/sms_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	sms "clean-arch-gin/internal/domain/shared/sms"
+	context "context"
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSender is a mock of Sender interface.
+type MockSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockSenderMockRecorder
+}
+
+// MockSenderMockRecorder is the mock recorder for MockSender.
+type MockSenderMockRecorder struct {
+	mock *MockSender
+}
+
+// NewMockSender creates a new mock instance.
+func NewMockSender(ctrl *gomock.Controller) *MockSender {
+	mock := &MockSender{ctrl: ctrl}
+	mock.recorder = &MockSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSender) EXPECT() *MockSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockSender) Send(ctx context.Context, to, body string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, to, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockSenderMockRecorder) Send(ctx, to, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockSender)(nil).Send), ctx, to, body)
+}
+
+// MockReplySource is a mock of ReplySource interface.
+type MockReplySource struct {
+	ctrl     *gomock.Controller
+	recorder *MockReplySourceMockRecorder
+}
+
+// MockReplySourceMockRecorder is the mock recorder for MockReplySource.
+type MockReplySourceMockRecorder struct {
+	mock *MockReplySource
+}
+
+// NewMockReplySource creates a new mock instance.
+func NewMockReplySource(ctrl *gomock.Controller) *MockReplySource {
+	mock := &MockReplySource{ctrl: ctrl}
+	mock.recorder = &MockReplySourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReplySource) EXPECT() *MockReplySourceMockRecorder {
+	return m.recorder
+}
+
+// Parse mocks base method.
+func (m *MockReplySource) Parse(ctx context.Context, url string, header http.Header, body []byte) ([]sms.Reply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", ctx, url, header, body)
+	ret0, _ := ret[0].([]sms.Reply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockReplySourceMockRecorder) Parse(ctx, url, header, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockReplySource)(nil).Parse), ctx, url, header, body)
+}
@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sms_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sms_usecase.go -destination=../../../mocks/sms_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	sms "clean-arch-gin/internal/domain/shared/sms"
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSMSUseCase is a mock of SMSUseCase interface.
+type MockSMSUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSMSUseCaseMockRecorder
+}
+
+// MockSMSUseCaseMockRecorder is the mock recorder for MockSMSUseCase.
+type MockSMSUseCaseMockRecorder struct {
+	mock *MockSMSUseCase
+}
+
+// NewMockSMSUseCase creates a new mock instance.
+func NewMockSMSUseCase(ctrl *gomock.Controller) *MockSMSUseCase {
+	mock := &MockSMSUseCase{ctrl: ctrl}
+	mock.recorder = &MockSMSUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSMSUseCase) EXPECT() *MockSMSUseCaseMockRecorder {
+	return m.recorder
+}
+
+// OptOut mocks base method.
+func (m *MockSMSUseCase) OptOut(ctx context.Context, phone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OptOut", ctx, phone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OptOut indicates an expected call of OptOut.
+func (mr *MockSMSUseCaseMockRecorder) OptOut(ctx, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OptOut", reflect.TypeOf((*MockSMSUseCase)(nil).OptOut), ctx, phone)
+}
+
+// RecordReplies mocks base method.
+func (m *MockSMSUseCase) RecordReplies(ctx context.Context, replies []sms.Reply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordReplies", ctx, replies)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordReplies indicates an expected call of RecordReplies.
+func (mr *MockSMSUseCaseMockRecorder) RecordReplies(ctx, replies any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordReplies", reflect.TypeOf((*MockSMSUseCase)(nil).RecordReplies), ctx, replies)
+}
+
+// SetPhone mocks base method.
+func (m *MockSMSUseCase) SetPhone(ctx context.Context, userID uint, phone string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPhone", ctx, userID, phone)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPhone indicates an expected call of SetPhone.
+func (mr *MockSMSUseCaseMockRecorder) SetPhone(ctx, userID, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPhone", reflect.TypeOf((*MockSMSUseCase)(nil).SetPhone), ctx, userID, phone)
+}
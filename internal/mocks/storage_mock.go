@@ -0,0 +1,266 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=storage.go -destination=../../../mocks/storage_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	storage "clean-arch-gin/internal/domain/shared/storage"
+	context "context"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorage is a mock of Storage interface.
+type MockStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageMockRecorder
+}
+
+// MockStorageMockRecorder is the mock recorder for MockStorage.
+type MockStorageMockRecorder struct {
+	mock *MockStorage
+}
+
+// NewMockStorage creates a new mock instance.
+func NewMockStorage(ctrl *gomock.Controller) *MockStorage {
+	mock := &MockStorage{ctrl: ctrl}
+	mock.recorder = &MockStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockStorage) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStorageMockRecorder) Delete(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStorage)(nil).Delete), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockStorage) Put(ctx context.Context, key, contentType string, body io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, contentType, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockStorageMockRecorder) Put(ctx, key, contentType, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStorage)(nil).Put), ctx, key, contentType, body)
+}
+
+// SignedURL mocks base method.
+func (m *MockStorage) SignedURL(key string, expiresAt time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignedURL", key, expiresAt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignedURL indicates an expected call of SignedURL.
+func (mr *MockStorageMockRecorder) SignedURL(key, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignedURL", reflect.TypeOf((*MockStorage)(nil).SignedURL), key, expiresAt)
+}
+
+// URL mocks base method.
+func (m *MockStorage) URL(key string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URL", key)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// URL indicates an expected call of URL.
+func (mr *MockStorageMockRecorder) URL(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URL", reflect.TypeOf((*MockStorage)(nil).URL), key)
+}
+
+// MockMultipartStorage is a mock of MultipartStorage interface.
+type MockMultipartStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockMultipartStorageMockRecorder
+}
+
+// MockMultipartStorageMockRecorder is the mock recorder for MockMultipartStorage.
+type MockMultipartStorageMockRecorder struct {
+	mock *MockMultipartStorage
+}
+
+// NewMockMultipartStorage creates a new mock instance.
+func NewMockMultipartStorage(ctrl *gomock.Controller) *MockMultipartStorage {
+	mock := &MockMultipartStorage{ctrl: ctrl}
+	mock.recorder = &MockMultipartStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMultipartStorage) EXPECT() *MockMultipartStorageMockRecorder {
+	return m.recorder
+}
+
+// AbortUpload mocks base method.
+func (m *MockMultipartStorage) AbortUpload(ctx context.Context, key, uploadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortUpload", ctx, key, uploadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortUpload indicates an expected call of AbortUpload.
+func (mr *MockMultipartStorageMockRecorder) AbortUpload(ctx, key, uploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortUpload", reflect.TypeOf((*MockMultipartStorage)(nil).AbortUpload), ctx, key, uploadID)
+}
+
+// CompleteUpload mocks base method.
+func (m *MockMultipartStorage) CompleteUpload(ctx context.Context, key, uploadID string, parts []storage.Part) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteUpload", ctx, key, uploadID, parts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteUpload indicates an expected call of CompleteUpload.
+func (mr *MockMultipartStorageMockRecorder) CompleteUpload(ctx, key, uploadID, parts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUpload", reflect.TypeOf((*MockMultipartStorage)(nil).CompleteUpload), ctx, key, uploadID, parts)
+}
+
+// CreateUpload mocks base method.
+func (m *MockMultipartStorage) CreateUpload(ctx context.Context, key, contentType string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", ctx, key, contentType)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockMultipartStorageMockRecorder) CreateUpload(ctx, key, contentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockMultipartStorage)(nil).CreateUpload), ctx, key, contentType)
+}
+
+// Delete mocks base method.
+func (m *MockMultipartStorage) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMultipartStorageMockRecorder) Delete(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMultipartStorage)(nil).Delete), ctx, key)
+}
+
+// ListParts mocks base method.
+func (m *MockMultipartStorage) ListParts(ctx context.Context, key, uploadID string) ([]storage.Part, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListParts", ctx, key, uploadID)
+	ret0, _ := ret[0].([]storage.Part)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListParts indicates an expected call of ListParts.
+func (mr *MockMultipartStorageMockRecorder) ListParts(ctx, key, uploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParts", reflect.TypeOf((*MockMultipartStorage)(nil).ListParts), ctx, key, uploadID)
+}
+
+// PartSize mocks base method.
+func (m *MockMultipartStorage) PartSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PartSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// PartSize indicates an expected call of PartSize.
+func (mr *MockMultipartStorageMockRecorder) PartSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartSize", reflect.TypeOf((*MockMultipartStorage)(nil).PartSize))
+}
+
+// Put mocks base method.
+func (m *MockMultipartStorage) Put(ctx context.Context, key, contentType string, body io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, contentType, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockMultipartStorageMockRecorder) Put(ctx, key, contentType, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockMultipartStorage)(nil).Put), ctx, key, contentType, body)
+}
+
+// SignedURL mocks base method.
+func (m *MockMultipartStorage) SignedURL(key string, expiresAt time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignedURL", key, expiresAt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignedURL indicates an expected call of SignedURL.
+func (mr *MockMultipartStorageMockRecorder) SignedURL(key, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignedURL", reflect.TypeOf((*MockMultipartStorage)(nil).SignedURL), key, expiresAt)
+}
+
+// URL mocks base method.
+func (m *MockMultipartStorage) URL(key string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URL", key)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// URL indicates an expected call of URL.
+func (mr *MockMultipartStorageMockRecorder) URL(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URL", reflect.TypeOf((*MockMultipartStorage)(nil).URL), key)
+}
+
+// UploadPart mocks base method.
+func (m *MockMultipartStorage) UploadPart(ctx context.Context, key, uploadID string, number int, body []byte) (storage.Part, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadPart", ctx, key, uploadID, number, body)
+	ret0, _ := ret[0].(storage.Part)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadPart indicates an expected call of UploadPart.
+func (mr *MockMultipartStorageMockRecorder) UploadPart(ctx, key, uploadID, number, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPart", reflect.TypeOf((*MockMultipartStorage)(nil).UploadPart), ctx, key, uploadID, number, body)
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: templates.go
+//
+// Generated by this command:
+//
+//	mockgen -source=templates.go -destination=../../../mocks/templates_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	templates "clean-arch-gin/internal/domain/shared/templates"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEngine is a mock of Engine interface.
+type MockEngine struct {
+	ctrl     *gomock.Controller
+	recorder *MockEngineMockRecorder
+}
+
+// MockEngineMockRecorder is the mock recorder for MockEngine.
+type MockEngineMockRecorder struct {
+	mock *MockEngine
+}
+
+// NewMockEngine creates a new mock instance.
+func NewMockEngine(ctrl *gomock.Controller) *MockEngine {
+	mock := &MockEngine{ctrl: ctrl}
+	mock.recorder = &MockEngineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEngine) EXPECT() *MockEngineMockRecorder {
+	return m.recorder
+}
+
+// Render mocks base method.
+func (m *MockEngine) Render(name string, channel templates.Channel, locale string, vars map[string]any) (*templates.Content, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", name, channel, locale, vars)
+	ret0, _ := ret[0].(*templates.Content)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockEngineMockRecorder) Render(name, channel, locale, vars any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockEngine)(nil).Render), name, channel, locale, vars)
+}
+
+// Template mocks base method.
+func (m *MockEngine) Template(name string) (templates.Template, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Template", name)
+	ret0, _ := ret[0].(templates.Template)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Template indicates an expected call of Template.
+func (mr *MockEngineMockRecorder) Template(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Template", reflect.TypeOf((*MockEngine)(nil).Template), name)
+}
+
+// Templates mocks base method.
+func (m *MockEngine) Templates() []templates.Template {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Templates")
+	ret0, _ := ret[0].([]templates.Template)
+	return ret0
+}
+
+// Templates indicates an expected call of Templates.
+func (mr *MockEngineMockRecorder) Templates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Templates", reflect.TypeOf((*MockEngine)(nil).Templates))
+}
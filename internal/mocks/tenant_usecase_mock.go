@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: tenant_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=tenant_usecase.go -destination=../../../mocks/tenant_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	money "clean-arch-gin/internal/domain/shared/money"
+	entities "clean-arch-gin/internal/domain/tenant/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTenantUseCase is a mock of TenantUseCase interface.
+type MockTenantUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantUseCaseMockRecorder
+}
+
+// MockTenantUseCaseMockRecorder is the mock recorder for MockTenantUseCase.
+type MockTenantUseCaseMockRecorder struct {
+	mock *MockTenantUseCase
+}
+
+// NewMockTenantUseCase creates a new mock instance.
+func NewMockTenantUseCase(ctrl *gomock.Controller) *MockTenantUseCase {
+	mock := &MockTenantUseCase{ctrl: ctrl}
+	mock.recorder = &MockTenantUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantUseCase) EXPECT() *MockTenantUseCaseMockRecorder {
+	return m.recorder
+}
+
+// BaseCurrency mocks base method.
+func (m *MockTenantUseCase) BaseCurrency(ctx context.Context) (money.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseCurrency", ctx)
+	ret0, _ := ret[0].(money.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BaseCurrency indicates an expected call of BaseCurrency.
+func (mr *MockTenantUseCaseMockRecorder) BaseCurrency(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseCurrency", reflect.TypeOf((*MockTenantUseCase)(nil).BaseCurrency), ctx)
+}
+
+// CreateTenant mocks base method.
+func (m *MockTenantUseCase) CreateTenant(ctx context.Context, slug, name, baseCurrency string) (*entities.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTenant", ctx, slug, name, baseCurrency)
+	ret0, _ := ret[0].(*entities.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTenant indicates an expected call of CreateTenant.
+func (mr *MockTenantUseCaseMockRecorder) CreateTenant(ctx, slug, name, baseCurrency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTenant", reflect.TypeOf((*MockTenantUseCase)(nil).CreateTenant), ctx, slug, name, baseCurrency)
+}
+
+// DeleteTenant mocks base method.
+func (m *MockTenantUseCase) DeleteTenant(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTenant", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTenant indicates an expected call of DeleteTenant.
+func (mr *MockTenantUseCaseMockRecorder) DeleteTenant(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTenant", reflect.TypeOf((*MockTenantUseCase)(nil).DeleteTenant), ctx, id)
+}
+
+// GetTenant mocks base method.
+func (m *MockTenantUseCase) GetTenant(ctx context.Context, id uint) (*entities.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenant", ctx, id)
+	ret0, _ := ret[0].(*entities.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenant indicates an expected call of GetTenant.
+func (mr *MockTenantUseCaseMockRecorder) GetTenant(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenant", reflect.TypeOf((*MockTenantUseCase)(nil).GetTenant), ctx, id)
+}
+
+// ListTenants mocks base method.
+func (m *MockTenantUseCase) ListTenants(ctx context.Context, limit, offset int) ([]*entities.Tenant, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTenants", ctx, limit, offset)
+	ret0, _ := ret[0].([]*entities.Tenant)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTenants indicates an expected call of ListTenants.
+func (mr *MockTenantUseCaseMockRecorder) ListTenants(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTenants", reflect.TypeOf((*MockTenantUseCase)(nil).ListTenants), ctx, limit, offset)
+}
+
+// ResolveTenant mocks base method.
+func (m *MockTenantUseCase) ResolveTenant(ctx context.Context, slug string) (*entities.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveTenant", ctx, slug)
+	ret0, _ := ret[0].(*entities.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveTenant indicates an expected call of ResolveTenant.
+func (mr *MockTenantUseCaseMockRecorder) ResolveTenant(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveTenant", reflect.TypeOf((*MockTenantUseCase)(nil).ResolveTenant), ctx, slug)
+}
+
+// UpdateTenant mocks base method.
+func (m *MockTenantUseCase) UpdateTenant(ctx context.Context, id uint, name *string, status *entities.Status, baseCurrency *string) (*entities.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTenant", ctx, id, name, status, baseCurrency)
+	ret0, _ := ret[0].(*entities.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTenant indicates an expected call of UpdateTenant.
+func (mr *MockTenantUseCaseMockRecorder) UpdateTenant(ctx, id, name, status, baseCurrency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTenant", reflect.TypeOf((*MockTenantUseCase)(nil).UpdateTenant), ctx, id, name, status, baseCurrency)
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usage_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=usage_repository.go -destination=../../../mocks/usage_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/metering/entities"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUsageRepository is a mock of UsageRepository interface.
+type MockUsageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsageRepositoryMockRecorder
+}
+
+// MockUsageRepositoryMockRecorder is the mock recorder for MockUsageRepository.
+type MockUsageRepositoryMockRecorder struct {
+	mock *MockUsageRepository
+}
+
+// NewMockUsageRepository creates a new mock instance.
+func NewMockUsageRepository(ctrl *gomock.Controller) *MockUsageRepository {
+	mock := &MockUsageRepository{ctrl: ctrl}
+	mock.recorder = &MockUsageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsageRepository) EXPECT() *MockUsageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockUsageRepository) Add(ctx context.Context, records []*entities.UsageRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, records)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockUsageRepositoryMockRecorder) Add(ctx, records any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockUsageRepository)(nil).Add), ctx, records)
+}
+
+// ListUnreported mocks base method.
+func (m *MockUsageRepository) ListUnreported(ctx context.Context, before time.Time, limit int) ([]*entities.UsageRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnreported", ctx, before, limit)
+	ret0, _ := ret[0].([]*entities.UsageRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnreported indicates an expected call of ListUnreported.
+func (mr *MockUsageRepositoryMockRecorder) ListUnreported(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnreported", reflect.TypeOf((*MockUsageRepository)(nil).ListUnreported), ctx, before, limit)
+}
+
+// MarkReported mocks base method.
+func (m *MockUsageRepository) MarkReported(ctx context.Context, id uint, quantity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReported", ctx, id, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReported indicates an expected call of MarkReported.
+func (mr *MockUsageRepositoryMockRecorder) MarkReported(ctx, id, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReported", reflect.TypeOf((*MockUsageRepository)(nil).MarkReported), ctx, id, quantity)
+}
+
+// Totals mocks base method.
+func (m *MockUsageRepository) Totals(ctx context.Context, from, to time.Time, apiKeyID *uint) ([]entities.UsageTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Totals", ctx, from, to, apiKeyID)
+	ret0, _ := ret[0].([]entities.UsageTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Totals indicates an expected call of Totals.
+func (mr *MockUsageRepositoryMockRecorder) Totals(ctx, from, to, apiKeyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Totals", reflect.TypeOf((*MockUsageRepository)(nil).Totals), ctx, from, to, apiKeyID)
+}
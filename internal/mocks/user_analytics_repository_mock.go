@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_analytics_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=user_analytics_repository.go -destination=../../../mocks/user_analytics_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserAnalyticsRepository is a mock of UserAnalyticsRepository interface.
+type MockUserAnalyticsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserAnalyticsRepositoryMockRecorder
+}
+
+// MockUserAnalyticsRepositoryMockRecorder is the mock recorder for MockUserAnalyticsRepository.
+type MockUserAnalyticsRepositoryMockRecorder struct {
+	mock *MockUserAnalyticsRepository
+}
+
+// NewMockUserAnalyticsRepository creates a new mock instance.
+func NewMockUserAnalyticsRepository(ctrl *gomock.Controller) *MockUserAnalyticsRepository {
+	mock := &MockUserAnalyticsRepository{ctrl: ctrl}
+	mock.recorder = &MockUserAnalyticsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserAnalyticsRepository) EXPECT() *MockUserAnalyticsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Activity mocks base method.
+func (m *MockUserAnalyticsRepository) Activity(ctx context.Context, r entities.AnalyticsRange) ([]entities.DailyActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Activity", ctx, r)
+	ret0, _ := ret[0].([]entities.DailyActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Activity indicates an expected call of Activity.
+func (mr *MockUserAnalyticsRepositoryMockRecorder) Activity(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activity", reflect.TypeOf((*MockUserAnalyticsRepository)(nil).Activity), ctx, r)
+}
+
+// Retention mocks base method.
+func (m *MockUserAnalyticsRepository) Retention(ctx context.Context, r entities.AnalyticsRange, weeks int, until time.Time) ([]entities.RetentionCohort, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retention", ctx, r, weeks, until)
+	ret0, _ := ret[0].([]entities.RetentionCohort)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Retention indicates an expected call of Retention.
+func (mr *MockUserAnalyticsRepositoryMockRecorder) Retention(ctx, r, weeks, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retention", reflect.TypeOf((*MockUserAnalyticsRepository)(nil).Retention), ctx, r, weeks, until)
+}
+
+// Signups mocks base method.
+func (m *MockUserAnalyticsRepository) Signups(ctx context.Context, r entities.AnalyticsRange) ([]entities.DailySignups, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Signups", ctx, r)
+	ret0, _ := ret[0].([]entities.DailySignups)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Signups indicates an expected call of Signups.
+func (mr *MockUserAnalyticsRepositoryMockRecorder) Signups(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signups", reflect.TypeOf((*MockUserAnalyticsRepository)(nil).Signups), ctx, r)
+}
+
+// Totals mocks base method.
+func (m *MockUserAnalyticsRepository) Totals(ctx context.Context) (*entities.UserTotals, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Totals", ctx)
+	ret0, _ := ret[0].(*entities.UserTotals)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Totals indicates an expected call of Totals.
+func (mr *MockUserAnalyticsRepositoryMockRecorder) Totals(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Totals", reflect.TypeOf((*MockUserAnalyticsRepository)(nil).Totals), ctx)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_analytics_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=user_analytics_usecase.go -destination=../../../mocks/user_analytics_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserAnalyticsUseCase is a mock of UserAnalyticsUseCase interface.
+type MockUserAnalyticsUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserAnalyticsUseCaseMockRecorder
+}
+
+// MockUserAnalyticsUseCaseMockRecorder is the mock recorder for MockUserAnalyticsUseCase.
+type MockUserAnalyticsUseCaseMockRecorder struct {
+	mock *MockUserAnalyticsUseCase
+}
+
+// NewMockUserAnalyticsUseCase creates a new mock instance.
+func NewMockUserAnalyticsUseCase(ctrl *gomock.Controller) *MockUserAnalyticsUseCase {
+	mock := &MockUserAnalyticsUseCase{ctrl: ctrl}
+	mock.recorder = &MockUserAnalyticsUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserAnalyticsUseCase) EXPECT() *MockUserAnalyticsUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetActivity mocks base method.
+func (m *MockUserAnalyticsUseCase) GetActivity(ctx context.Context, r entities.AnalyticsRange) ([]entities.DailyActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivity", ctx, r)
+	ret0, _ := ret[0].([]entities.DailyActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivity indicates an expected call of GetActivity.
+func (mr *MockUserAnalyticsUseCaseMockRecorder) GetActivity(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivity", reflect.TypeOf((*MockUserAnalyticsUseCase)(nil).GetActivity), ctx, r)
+}
+
+// GetRetention mocks base method.
+func (m *MockUserAnalyticsUseCase) GetRetention(ctx context.Context, r entities.AnalyticsRange, weeks int) ([]entities.RetentionCohort, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRetention", ctx, r, weeks)
+	ret0, _ := ret[0].([]entities.RetentionCohort)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRetention indicates an expected call of GetRetention.
+func (mr *MockUserAnalyticsUseCaseMockRecorder) GetRetention(ctx, r, weeks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetention", reflect.TypeOf((*MockUserAnalyticsUseCase)(nil).GetRetention), ctx, r, weeks)
+}
+
+// GetStats mocks base method.
+func (m *MockUserAnalyticsUseCase) GetStats(ctx context.Context, r entities.AnalyticsRange) (*entities.UserStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, r)
+	ret0, _ := ret[0].(*entities.UserStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockUserAnalyticsUseCaseMockRecorder) GetStats(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockUserAnalyticsUseCase)(nil).GetStats), ctx, r)
+}
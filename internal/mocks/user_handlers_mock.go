@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handlers.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handlers.go -destination=../../../mocks/user_handlers_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserHandlers is a mock of UserHandlers interface.
+type MockUserHandlers struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserHandlersMockRecorder
+}
+
+// MockUserHandlersMockRecorder is the mock recorder for MockUserHandlers.
+type MockUserHandlersMockRecorder struct {
+	mock *MockUserHandlers
+}
+
+// NewMockUserHandlers creates a new mock instance.
+func NewMockUserHandlers(ctrl *gomock.Controller) *MockUserHandlers {
+	mock := &MockUserHandlers{ctrl: ctrl}
+	mock.recorder = &MockUserHandlersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserHandlers) EXPECT() *MockUserHandlersMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockUserHandlers) CreateUser(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateUser", c)
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockUserHandlersMockRecorder) CreateUser(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserHandlers)(nil).CreateUser), c)
+}
+
+// DeleteUser mocks base method.
+func (m *MockUserHandlers) DeleteUser(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteUser", c)
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserHandlersMockRecorder) DeleteUser(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserHandlers)(nil).DeleteUser), c)
+}
+
+// GetCurrentUser mocks base method.
+func (m *MockUserHandlers) GetCurrentUser(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetCurrentUser", c)
+}
+
+// GetCurrentUser indicates an expected call of GetCurrentUser.
+func (mr *MockUserHandlersMockRecorder) GetCurrentUser(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUser", reflect.TypeOf((*MockUserHandlers)(nil).GetCurrentUser), c)
+}
+
+// GetUser mocks base method.
+func (m *MockUserHandlers) GetUser(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUser", c)
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserHandlersMockRecorder) GetUser(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserHandlers)(nil).GetUser), c)
+}
+
+// GetUsers mocks base method.
+func (m *MockUserHandlers) GetUsers(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUsers", c)
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockUserHandlersMockRecorder) GetUsers(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUserHandlers)(nil).GetUsers), c)
+}
+
+// RequireActiveAccount mocks base method.
+func (m *MockUserHandlers) RequireActiveAccount() gin.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequireActiveAccount")
+	ret0, _ := ret[0].(gin.HandlerFunc)
+	return ret0
+}
+
+// RequireActiveAccount indicates an expected call of RequireActiveAccount.
+func (mr *MockUserHandlersMockRecorder) RequireActiveAccount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequireActiveAccount", reflect.TypeOf((*MockUserHandlers)(nil).RequireActiveAccount))
+}
+
+// UpdateCurrentUser mocks base method.
+func (m *MockUserHandlers) UpdateCurrentUser(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateCurrentUser", c)
+}
+
+// UpdateCurrentUser indicates an expected call of UpdateCurrentUser.
+func (mr *MockUserHandlersMockRecorder) UpdateCurrentUser(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentUser", reflect.TypeOf((*MockUserHandlers)(nil).UpdateCurrentUser), c)
+}
+
+// UpdateUser mocks base method.
+func (m *MockUserHandlers) UpdateUser(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateUser", c)
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUserHandlersMockRecorder) UpdateUser(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserHandlers)(nil).UpdateUser), c)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_search_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=user_search_repository.go -destination=../../../mocks/user_search_repository_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserSearchRepository is a mock of UserSearchRepository interface.
+type MockUserSearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSearchRepositoryMockRecorder
+}
+
+// MockUserSearchRepositoryMockRecorder is the mock recorder for MockUserSearchRepository.
+type MockUserSearchRepositoryMockRecorder struct {
+	mock *MockUserSearchRepository
+}
+
+// NewMockUserSearchRepository creates a new mock instance.
+func NewMockUserSearchRepository(ctrl *gomock.Controller) *MockUserSearchRepository {
+	mock := &MockUserSearchRepository{ctrl: ctrl}
+	mock.recorder = &MockUserSearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSearchRepository) EXPECT() *MockUserSearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockUserSearchRepository) Search(ctx context.Context, terms []string, limit, offset int) ([]*entities.UserSearchHit, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, terms, limit, offset)
+	ret0, _ := ret[0].([]*entities.UserSearchHit)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockUserSearchRepositoryMockRecorder) Search(ctx, terms, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUserSearchRepository)(nil).Search), ctx, terms, limit, offset)
+}
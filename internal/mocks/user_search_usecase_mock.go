@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_search_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=user_search_usecase.go -destination=../../../mocks/user_search_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/user/entities"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserSearchUseCase is a mock of UserSearchUseCase interface.
+type MockUserSearchUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSearchUseCaseMockRecorder
+}
+
+// MockUserSearchUseCaseMockRecorder is the mock recorder for MockUserSearchUseCase.
+type MockUserSearchUseCaseMockRecorder struct {
+	mock *MockUserSearchUseCase
+}
+
+// NewMockUserSearchUseCase creates a new mock instance.
+func NewMockUserSearchUseCase(ctrl *gomock.Controller) *MockUserSearchUseCase {
+	mock := &MockUserSearchUseCase{ctrl: ctrl}
+	mock.recorder = &MockUserSearchUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSearchUseCase) EXPECT() *MockUserSearchUseCaseMockRecorder {
+	return m.recorder
+}
+
+// SearchUsers mocks base method.
+func (m *MockUserSearchUseCase) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*entities.UserSearchHit, int64, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUsers", ctx, query, limit, offset)
+	ret0, _ := ret[0].([]*entities.UserSearchHit)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].([]string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockUserSearchUseCaseMockRecorder) SearchUsers(ctx, query, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockUserSearchUseCase)(nil).SearchUsers), ctx, query, limit, offset)
+}
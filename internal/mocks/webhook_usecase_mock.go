@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook_usecase.go -destination=../../../mocks/webhook_usecase_mock.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "clean-arch-gin/internal/domain/webhook/entities"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookUseCase is a mock of WebhookUseCase interface.
+type MockWebhookUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookUseCaseMockRecorder
+}
+
+// MockWebhookUseCaseMockRecorder is the mock recorder for MockWebhookUseCase.
+type MockWebhookUseCaseMockRecorder struct {
+	mock *MockWebhookUseCase
+}
+
+// NewMockWebhookUseCase creates a new mock instance.
+func NewMockWebhookUseCase(ctrl *gomock.Controller) *MockWebhookUseCase {
+	mock := &MockWebhookUseCase{ctrl: ctrl}
+	mock.recorder = &MockWebhookUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookUseCase) EXPECT() *MockWebhookUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetDelivery mocks base method.
+func (m *MockWebhookUseCase) GetDelivery(ctx context.Context, id uint) (*entities.Delivery, []*entities.DeliveryAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", ctx, id)
+	ret0, _ := ret[0].(*entities.Delivery)
+	ret1, _ := ret[1].([]*entities.DeliveryAttempt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockWebhookUseCaseMockRecorder) GetDelivery(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockWebhookUseCase)(nil).GetDelivery), ctx, id)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookUseCase) ListDeliveries(ctx context.Context, endpointID uint, limit, offset int) ([]*entities.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, endpointID, limit, offset)
+	ret0, _ := ret[0].([]*entities.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookUseCaseMockRecorder) ListDeliveries(ctx, endpointID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookUseCase)(nil).ListDeliveries), ctx, endpointID, limit, offset)
+}
+
+// ListEndpoints mocks base method.
+func (m *MockWebhookUseCase) ListEndpoints(ctx context.Context) ([]*entities.Endpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEndpoints", ctx)
+	ret0, _ := ret[0].([]*entities.Endpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEndpoints indicates an expected call of ListEndpoints.
+func (mr *MockWebhookUseCaseMockRecorder) ListEndpoints(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEndpoints", reflect.TypeOf((*MockWebhookUseCase)(nil).ListEndpoints), ctx)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookUseCase) Redeliver(ctx context.Context, id uint) (*entities.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, id)
+	ret0, _ := ret[0].(*entities.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookUseCaseMockRecorder) Redeliver(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookUseCase)(nil).Redeliver), ctx, id)
+}
+
+// RegisterEndpoint mocks base method.
+func (m *MockWebhookUseCase) RegisterEndpoint(ctx context.Context, url, secret string, eventTypes []string, retrySchedule []time.Duration, format entities.PayloadFormat) (*entities.Endpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterEndpoint", ctx, url, secret, eventTypes, retrySchedule, format)
+	ret0, _ := ret[0].(*entities.Endpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterEndpoint indicates an expected call of RegisterEndpoint.
+func (mr *MockWebhookUseCaseMockRecorder) RegisterEndpoint(ctx, url, secret, eventTypes, retrySchedule, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEndpoint", reflect.TypeOf((*MockWebhookUseCase)(nil).RegisterEndpoint), ctx, url, secret, eventTypes, retrySchedule, format)
+}
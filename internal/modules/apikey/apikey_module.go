@@ -0,0 +1,155 @@
+package apikey
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"clean-arch-gin/internal/adapters/apikey/controllers"
+	apikeyRepositories "clean-arch-gin/internal/adapters/apikey/repositories"
+	apikeyUsecases "clean-arch-gin/internal/adapters/apikey/usecases"
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/models"
+	apikeyEntities "clean-arch-gin/internal/domain/apikey/entities"
+	apikeyDomainRepositories "clean-arch-gin/internal/domain/apikey/repositories"
+	apikeyDomainUsecases "clean-arch-gin/internal/domain/apikey/usecases"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/openapi"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// backfillBatchSize is how many keys are assigned a public ID per transaction
+const backfillBatchSize = 100
+
+// APIKeyModule lets users call the API with keys of their own, each limited per minute and
+// per month
+type APIKeyModule struct {
+	apiKeyUseCase apikeyDomainUsecases.APIKeyUseCase
+	controller    *controllers.APIKeyController
+	auth          *middleware.AuthMiddleware
+}
+
+// NewAPIKeyModule creates an API key module counting the requests of keys with counter and
+// issuing them with defaults as their limits
+func NewAPIKeyModule(db *gorm.DB, counter apikeyDomainRepositories.UsageCounter, defaults apikeyEntities.Limits) *APIKeyModule {
+	apiKeyUseCase := apikeyUsecases.NewAPIKeyUseCase(apikeyRepositories.NewAPIKeyRepository(db), counter, defaults)
+	return &APIKeyModule{
+		apiKeyUseCase: apiKeyUseCase,
+		controller:    controllers.NewAPIKeyController(apiKeyUseCase),
+		auth:          middleware.NewAuthMiddleware(""),
+	}
+}
+
+// Name returns the module name
+func (m *APIKeyModule) Name() string {
+	return "api-keys"
+}
+
+// RegisterRoutes registers the routes users manage their keys through; keys are addressed by
+// public ID
+func (m *APIKeyModule) RegisterRoutes(rg *gin.RouterGroup) {
+	protected := rg.Group("", m.auth.RequireAuth(), middleware.ResolvePublicID("id", m.keyID))
+	{
+		protected.POST("", m.controller.CreateKey)         // POST /api/v1/api-keys
+		protected.GET("", m.controller.ListKeys)           // GET /api/v1/api-keys
+		protected.DELETE("/:id", m.controller.RevokeKey)   // DELETE /api/v1/api-keys/:id
+		protected.GET("/:id/usage", m.controller.GetUsage) // GET /api/v1/api-keys/:id/usage
+	}
+}
+
+// RegisterAdminRoutes registers the routes admins change the limits of keys through
+func (m *APIKeyModule) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	admin := rg.Group("", m.auth.RequireAuth(), m.auth.RequirePermission("api-keys", "manage"),
+		middleware.ResolvePublicID("id", m.keyID))
+	{
+		admin.PUT("/:id/limits", m.controller.SetLimits) // PUT /api/v1/api-keys/:id/limits
+	}
+}
+
+// keyID resolves the public ID of a key in a route to its internal ID
+func (m *APIKeyModule) keyID(ctx context.Context, publicID string) (uint, error) {
+	key, err := m.apiKeyUseCase.GetKeyByPublicID(ctx, publicID)
+	if err != nil {
+		return 0, err
+	}
+	return key.ID, nil
+}
+
+// APIRoutes documents the routes registered by RegisterRoutes and RegisterAdminRoutes
+func (m *APIKeyModule) APIRoutes() []openapi.Route {
+	errorResponse := openapi.ErrorResponse{}
+
+	routes := []openapi.Route{
+		{
+			Method: "POST", Path: "", Auth: true,
+			Summary: "Create an API key, sent as X-API-Key to act for you; the key is only returned here and " +
+				"cannot be created with another key",
+			Request: controllers.CreateKeyRequest{},
+			Responses: map[int]interface{}{
+				201: controllers.APIKeyDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "", Summary: "List your API keys, revoked ones included, newest first", Auth: true,
+			Responses: map[int]interface{}{
+				200: controllers.APIKeyListResponse{}, 401: errorResponse,
+			},
+		},
+		{
+			Method: "DELETE", Path: "/:id", Summary: "Revoke one of your API keys", Auth: true,
+			Responses: map[int]interface{}{
+				204: nil, 400: errorResponse, 401: errorResponse, 404: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/:id/usage", Auth: true,
+			Summary: "Get the requests one of your API keys made this month against its quota",
+			Responses: map[int]interface{}{
+				200: controllers.UsageResponse{}, 400: errorResponse, 401: errorResponse, 404: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/:id/limits", Auth: true,
+			Summary: "Change the requests per minute and per month an API key may make, 0 being unlimited (admin)",
+			Request: controllers.SetLimitsRequest{},
+			Responses: map[int]interface{}{
+				200: controllers.APIKeyDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+				404: errorResponse,
+			},
+		},
+	}
+	for i := range routes {
+		routes[i].PublicID = strings.Contains(routes[i].Path, ":id")
+	}
+	return routes
+}
+
+// AuthMiddleware authenticates requests carrying an API key and enforces its limits
+func (m *APIKeyModule) AuthMiddleware() gin.HandlerFunc {
+	return middleware.APIKeyAuth(m.apiKeyUseCase)
+}
+
+// Migrate runs database migrations for API key module
+// Keys missing a public ID are assigned one
+func (m *APIKeyModule) Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.APIKeyModel{}); err != nil {
+		return err
+	}
+	assigned, err := database.AssignPublicIDs(context.Background(), db, &models.APIKeyModel{}, backfillBatchSize)
+	if assigned > 0 {
+		log.Printf("api-keys: assigned a public ID to %d keys", assigned)
+	}
+	return err
+}
+
+// Rollback drops the API key table
+func (m *APIKeyModule) Rollback(db *gorm.DB) error {
+	return db.Migrator().DropTable(&models.APIKeyModel{})
+}
+
+// Initialize performs API key module initialization
+func (m *APIKeyModule) Initialize() error {
+	return nil
+}
@@ -0,0 +1,145 @@
+package authz
+
+import (
+	"context"
+	"time"
+
+	authzControllers "clean-arch-gin/internal/adapters/authz/controllers"
+	authzUsecases "clean-arch-gin/internal/adapters/authz/usecases"
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/infrastructure/authz"
+	"clean-arch-gin/internal/infrastructure/openapi"
+	"clean-arch-gin/internal/modules"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AuthzModule decides permissions with the policy engine and serves its management API
+type AuthzModule struct {
+	enforcer   *authz.Enforcer
+	controller *authzControllers.PolicyController
+	auth       *middleware.AuthMiddleware
+	// reloadInterval is how often the policy is reread for changes made on other
+	// replicas; 0 never rereads it
+	reloadInterval time.Duration
+}
+
+// NewAuthzModule creates the module enforcing the policy of enforcer on every route
+// guarded by RequirePermission
+func NewAuthzModule(enforcer *authz.Enforcer, reloadInterval time.Duration) modules.Module {
+	return &AuthzModule{
+		enforcer:       enforcer,
+		controller:     authzControllers.NewPolicyController(authzUsecases.NewPolicyUseCase(enforcer, enforcer)),
+		auth:           middleware.NewAuthMiddleware(""),
+		reloadInterval: reloadInterval,
+	}
+}
+
+// Name returns the module name
+func (m *AuthzModule) Name() string {
+	return "authz"
+}
+
+// RegisterRoutes registers no public routes; policy management is admin-only
+func (m *AuthzModule) RegisterRoutes(rg *gin.RouterGroup) {}
+
+// RegisterAdminRoutes registers the policy management routes, served to the default tenant's
+// admins only since the policies apply to every tenant
+func (m *AuthzModule) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	admin := rg.Group("", middleware.RequireDefaultTenant(), m.auth.RequireAuth(), m.auth.RequirePermission("policies", "manage"))
+	{
+		admin.GET("/policies", m.controller.ListPolicies)    // GET /api/v1/authz/policies
+		admin.POST("/policies", m.controller.GrantPolicy)    // POST /api/v1/authz/policies
+		admin.DELETE("/policies", m.controller.RevokePolicy) // DELETE /api/v1/authz/policies
+		admin.POST("/roles", m.controller.AssignRole)        // POST /api/v1/authz/roles
+		admin.DELETE("/roles", m.controller.UnassignRole)    // DELETE /api/v1/authz/roles
+		admin.GET("/check", m.controller.Check)              // GET /api/v1/authz/check?subject=&object=&action=
+	}
+}
+
+// APIRoutes documents the routes registered by RegisterAdminRoutes
+func (m *AuthzModule) APIRoutes() []openapi.Route {
+	errorResponse := openapi.ErrorResponse{}
+
+	return []openapi.Route{
+		{
+			Method: "GET", Path: "/policies", Auth: true, Summary: "List the policies and role assignments (admin)",
+			Responses: map[int]interface{}{
+				200: authzControllers.PolicyListResponse{}, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/policies", Auth: true,
+			Summary: "Allow a role or user an action on an object; requires write on policies/<subject> (admin)",
+			Request: authzControllers.PolicyRequest{},
+			Responses: map[int]interface{}{
+				201: authzControllers.PolicyDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 409: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "DELETE", Path: "/policies", Auth: true, Summary: "Revoke a policy; the admin grant cannot be revoked (admin)",
+			Request: authzControllers.PolicyRequest{},
+			Responses: map[int]interface{}{
+				204: nil, 400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/roles", Auth: true,
+			Summary: "Make a user or role inherit a role's permissions, building the role hierarchy (admin)",
+			Request: authzControllers.RoleAssignmentRequest{},
+			Responses: map[int]interface{}{
+				201: authzControllers.RoleAssignmentDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 409: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "DELETE", Path: "/roles", Auth: true, Summary: "Remove a role assignment (admin)",
+			Request: authzControllers.RoleAssignmentRequest{},
+			Responses: map[int]interface{}{
+				204: nil, 400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/check", Auth: true, Summary: "Decide whether a subject may perform an action on an object (admin)",
+			Query: []openapi.Parameter{
+				openapi.QueryParam("subject", "string", "Role or user, e.g. user:42"),
+				openapi.QueryParam("object", "string", "Resource, e.g. users/42"),
+				openapi.QueryParam("action", "string", "Action, e.g. delete"),
+			},
+			Responses: map[int]interface{}{
+				200: authzControllers.CheckResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+	}
+}
+
+// Migrate creates the policy table and loads the policy, storing the default one when
+// there is none
+func (m *AuthzModule) Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&authz.RuleModel{}); err != nil {
+		return err
+	}
+	return m.enforcer.Load()
+}
+
+// Rollback drops the policy rules
+func (m *AuthzModule) Rollback(db *gorm.DB) error {
+	return db.Migrator().DropTable(&authz.RuleModel{})
+}
+
+// Initialize performs authz module initialization
+func (m *AuthzModule) Initialize() error {
+	return nil
+}
+
+// AuthMiddleware lets RequirePermission decide with the policy engine
+func (m *AuthzModule) AuthMiddleware() gin.HandlerFunc {
+	return middleware.UseAuthorizer(m.enforcer)
+}
+
+// Start rereads the policy every reload interval until ctx is cancelled
+func (m *AuthzModule) Start(ctx context.Context) {
+	if m.reloadInterval > 0 {
+		go m.enforcer.Reload(ctx, m.reloadInterval)
+	}
+}
@@ -0,0 +1,108 @@
+package events
+
+import (
+	"log"
+
+	eventControllers "clean-arch-gin/internal/adapters/events/controllers"
+	eventTasks "clean-arch-gin/internal/adapters/events/tasks"
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/infrastructure/eventbus"
+	"clean-arch-gin/internal/infrastructure/openapi"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// EventsModule replays the domain events recorded in the outbox in the background, through
+// the event bus or into the projections other modules contribute, e.g. to build a read model
+// or feed a subscriber added after the events happened
+type EventsModule struct {
+	replayTask taskqueue.Handler
+	controller *eventControllers.ReplayController
+	auth       *middleware.AuthMiddleware
+}
+
+// NewEventsModule creates an events module replaying the outbox on db through bus or into
+// projections, typically the registry's Projections. A name used twice keeps its first
+// projection
+func NewEventsModule(db *gorm.DB, bus *eventbus.Bus, projections []events.Projection) *EventsModule {
+	byName := make(map[string]events.Projection, len(projections))
+	for _, projection := range projections {
+		if _, ok := byName[projection.Name]; ok {
+			log.Printf("events: ignoring a second projection named %s", projection.Name)
+			continue
+		}
+		byName[projection.Name] = projection
+	}
+	return &EventsModule{
+		replayTask: eventTasks.NewReplayHandler(db, bus, byName),
+		controller: eventControllers.NewReplayController(byName),
+		auth:       middleware.NewAuthMiddleware(""),
+	}
+}
+
+// Name returns the module name
+func (m *EventsModule) Name() string {
+	return "events"
+}
+
+// RegisterRoutes registers no public routes; replays are admin-only
+func (m *EventsModule) RegisterRoutes(rg *gin.RouterGroup) {}
+
+// RegisterAdminRoutes registers the routes admins replay events through; their progress and
+// results are served by the jobs API
+func (m *EventsModule) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	admin := rg.Group("", m.auth.RequireAuth(), m.auth.RequirePermission("events", "replay"))
+	{
+		admin.GET("/projections", m.controller.ListProjections) // GET /api/v1/events/projections
+		admin.POST("/replay", m.controller.Replay)              // POST /api/v1/events/replay
+	}
+}
+
+// APIRoutes documents the routes registered by RegisterAdminRoutes
+func (m *EventsModule) APIRoutes() []openapi.Route {
+	errorResponse := openapi.ErrorResponse{}
+
+	return []openapi.Route{
+		{
+			Method: "GET", Path: "/projections", Auth: true,
+			Summary: "List the projections that can be rebuilt from the recorded events (admin)",
+			Responses: map[int]interface{}{
+				200: eventControllers.ProjectionsResponse{}, 401: errorResponse, 403: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/replay", Auth: true,
+			Summary: "Replay recorded events, filtered by type, aggregate and time range, through the event bus " +
+				"or into a projection, optionally reset first (admin); track it through the job",
+			Request: eventControllers.ReplayRequest{},
+			Responses: map[int]interface{}{
+				202: responses.JobAcceptedResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+				500: errorResponse, 503: errorResponse,
+			},
+		},
+	}
+}
+
+// TaskHandlers runs the requested replays
+func (m *EventsModule) TaskHandlers() map[string]taskqueue.Handler {
+	return map[string]taskqueue.Handler{eventTasks.ReplayTaskType: m.replayTask}
+}
+
+// SetTaskQueue lets admins request replays
+func (m *EventsModule) SetTaskQueue(q *taskqueue.Queue) {
+	m.controller.SetQueue(q)
+}
+
+// Migrate creates no tables; the events are read from the outbox
+func (m *EventsModule) Migrate(db *gorm.DB) error {
+	return nil
+}
+
+// Initialize performs events module initialization
+func (m *EventsModule) Initialize() error {
+	return nil
+}
@@ -0,0 +1,143 @@
+package keys
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/infrastructure/jwt"
+	"clean-arch-gin/internal/infrastructure/openapi"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+	"clean-arch-gin/internal/modules"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// KeysModule keeps the keys signing the session tokens: it loads them, rotates them on
+// schedule and serves their management API. The public keys are published by app.MountJWKS
+type KeysModule struct {
+	keys *jwt.KeySet
+	auth *middleware.AuthMiddleware
+	// reloadInterval is how often keys rotated on other replicas are picked up; 0 never
+	reloadInterval time.Duration
+}
+
+// KeyListResponse lists the signing keys, newest first
+type KeyListResponse struct {
+	Keys []jwt.KeyInfo `json:"keys"`
+}
+
+// NewKeysModule creates the module managing keys
+func NewKeysModule(keys *jwt.KeySet, reloadInterval time.Duration) modules.Module {
+	return &KeysModule{
+		keys:           keys,
+		auth:           middleware.NewAuthMiddleware(""),
+		reloadInterval: reloadInterval,
+	}
+}
+
+// Name returns the module name
+func (m *KeysModule) Name() string {
+	return "keys"
+}
+
+// RegisterRoutes registers no public routes; the JWKS is served at the server root
+func (m *KeysModule) RegisterRoutes(rg *gin.RouterGroup) {}
+
+// RegisterAdminRoutes registers the key management routes, served to the default
+// tenant's admins only since the keys sign the tokens of every tenant
+func (m *KeysModule) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	admin := rg.Group("", middleware.RequireDefaultTenant(), m.auth.RequireAuth(), m.auth.RequirePermission("keys", "manage"))
+	{
+		admin.GET("", m.listKeys)       // GET /api/v1/keys
+		admin.POST("/rotate", m.rotate) // POST /api/v1/keys/rotate
+	}
+}
+
+// APIRoutes documents the routes registered by RegisterAdminRoutes
+func (m *KeysModule) APIRoutes() []openapi.Route {
+	errorResponse := openapi.ErrorResponse{}
+
+	return []openapi.Route{
+		{
+			Method: "GET", Path: "", Auth: true,
+			Summary: "List the token signing keys without their private parts (admin of the default tenant)",
+			Responses: map[int]interface{}{
+				200: KeyListResponse{}, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/rotate", Auth: true,
+			Summary: "Sign with a new key from now on; the replaced keys verify until their tokens expire (admin of the default tenant)",
+			Responses: map[int]interface{}{
+				200: KeyListResponse{}, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+	}
+}
+
+// Migrate creates the signing key table and loads the keys, generating the first one
+func (m *KeysModule) Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&jwt.SigningKeyModel{}); err != nil {
+		return err
+	}
+	return m.keys.Load(context.Background())
+}
+
+// Rollback drops the signing keys
+func (m *KeysModule) Rollback(db *gorm.DB) error {
+	return db.Migrator().DropTable(&jwt.SigningKeyModel{})
+}
+
+// Initialize performs keys module initialization
+func (m *KeysModule) Initialize() error {
+	return nil
+}
+
+// Start rereads the keys every reload interval until ctx is cancelled
+func (m *KeysModule) Start(ctx context.Context) {
+	if m.reloadInterval > 0 {
+		go m.keys.Reload(ctx, m.reloadInterval)
+	}
+}
+
+// ScheduledJobs rotates the signing key once it is due and deletes the expired keys, hourly
+func (m *KeysModule) ScheduledJobs() []scheduler.Job {
+	return []scheduler.Job{
+		{
+			Name:     "rotate",
+			Schedule: "@hourly",
+			Timeout:  time.Minute,
+			Run: func(ctx context.Context) error {
+				rotated, err := m.keys.RotateIfDue(ctx)
+				if rotated && err == nil {
+					log.Printf("keys: rotated the token signing key")
+				}
+				return err
+			},
+		},
+	}
+}
+
+// listKeys lists the signing keys
+func (m *KeysModule) listKeys(c *gin.Context) {
+	keys, err := m.keys.Keys(c.Request.Context())
+	if err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, KeyListResponse{Keys: keys})
+}
+
+// rotate replaces the signing key and lists the keys
+func (m *KeysModule) rotate(c *gin.Context) {
+	if err := m.keys.Rotate(c.Request.Context()); err != nil {
+		responses.InternalError(c, err)
+		return
+	}
+	m.listKeys(c)
+}
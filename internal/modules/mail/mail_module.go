@@ -0,0 +1,121 @@
+package mail
+
+import (
+	"clean-arch-gin/internal/adapters/mail/controllers"
+	mailJobs "clean-arch-gin/internal/adapters/mail/jobs"
+	"clean-arch-gin/internal/adapters/mail/outbox"
+	mailRepositories "clean-arch-gin/internal/adapters/mail/repositories"
+	mailTasks "clean-arch-gin/internal/adapters/mail/tasks"
+	mailUsecases "clean-arch-gin/internal/adapters/mail/usecases"
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/domain/shared/mail"
+	"clean-arch-gin/internal/infrastructure/openapi"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// MailModule sends outbound email through the task queue and serves its delivery status
+type MailModule struct {
+	outbox     *outbox.Mailer
+	sendTask   taskqueue.Handler
+	controller *controllers.EmailController
+	auth       *middleware.AuthMiddleware
+	db         *gorm.DB
+}
+
+// NewMailModule creates a mail module sending with sender, a provider's mailer, from the
+// workers of the task queue; an email is tried maxAttempts times, zero for the queue's default
+// Other modules mail through Mailer so their mail is queued with their work
+func NewMailModule(db *gorm.DB, sender mail.Mailer, maxAttempts int) *MailModule {
+	emailRepo := mailRepositories.NewEmailRepository(db)
+	return &MailModule{
+		outbox:     outbox.NewMailer(db, emailRepo, maxAttempts),
+		sendTask:   mailTasks.NewSendHandler(emailRepo, sender),
+		controller: controllers.NewEmailController(mailUsecases.NewEmailUseCase(emailRepo)),
+		auth:       middleware.NewAuthMiddleware(""),
+		db:         db,
+	}
+}
+
+// Mailer queues emails; they are sent once the task queue is set
+func (m *MailModule) Mailer() mail.Mailer {
+	return m.outbox
+}
+
+// Name returns the module name
+func (m *MailModule) Name() string {
+	return "mail"
+}
+
+// RegisterRoutes registers no public routes; delivery status is admin-only
+func (m *MailModule) RegisterRoutes(rg *gin.RouterGroup) {}
+
+// RegisterAdminRoutes registers the delivery status routes support answers inquiries with
+func (m *MailModule) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	admin := rg.Group("", m.auth.RequireAuth(), m.auth.RequirePermission("mail", "read"))
+	{
+		admin.GET("/emails", m.controller.ListEmails)   // GET /api/v1/mail/emails?recipient=&status=
+		admin.GET("/emails/:id", m.controller.GetEmail) // GET /api/v1/mail/emails/:id
+	}
+}
+
+// APIRoutes documents the routes registered by RegisterAdminRoutes
+func (m *MailModule) APIRoutes() []openapi.Route {
+	errorResponse := openapi.ErrorResponse{}
+
+	return []openapi.Route{
+		{
+			Method: "GET", Path: "/emails", Auth: true,
+			Summary: "List outbound emails with their delivery status, newest first (admin); bodies are never returned",
+			Query: []openapi.Parameter{
+				openapi.QueryParam("recipient", "string", "Exact recipient address, case-insensitive"),
+				openapi.QueryParam("status", "string", "queued, retrying, sent or failed"),
+				openapi.QueryParam("limit", "integer", "Page size (default 10, max 100)"),
+				openapi.QueryParam("offset", "integer", "Number of emails to skip"),
+			},
+			Responses: map[int]interface{}{
+				200: controllers.EmailListResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/emails/:id", Auth: true, Summary: "Get the delivery status of an outbound email (admin)",
+			Responses: map[int]interface{}{
+				200: controllers.EmailDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+	}
+}
+
+// TaskHandlers sends the queued emails
+func (m *MailModule) TaskHandlers() map[string]taskqueue.Handler {
+	return map[string]taskqueue.Handler{mailTasks.SendTaskType: m.sendTask}
+}
+
+// SetTaskQueue lets Mailer queue emails
+func (m *MailModule) SetTaskQueue(q *taskqueue.Queue) {
+	m.outbox.SetQueue(q)
+}
+
+// ScheduledJobs purges the delivery status of long sent emails
+func (m *MailModule) ScheduledJobs() []scheduler.Job {
+	return []scheduler.Job{mailJobs.NewPurgeSentJob(m.db, mailJobs.SentEmailRetention)}
+}
+
+// Migrate creates the outbound emails table
+func (m *MailModule) Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(&models.OutboundEmailModel{})
+}
+
+// Rollback drops the outbound emails
+func (m *MailModule) Rollback(db *gorm.DB) error {
+	return db.Migrator().DropTable(&models.OutboundEmailModel{})
+}
+
+// Initialize performs mail module initialization
+func (m *MailModule) Initialize() error {
+	return nil
+}
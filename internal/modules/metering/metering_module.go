@@ -0,0 +1,163 @@
+package metering
+
+import (
+	"context"
+	"time"
+
+	meteringControllers "clean-arch-gin/internal/adapters/metering/controllers"
+	meteringJobs "clean-arch-gin/internal/adapters/metering/jobs"
+	"clean-arch-gin/internal/adapters/metering/meter"
+	meteringRepositories "clean-arch-gin/internal/adapters/metering/repositories"
+	meteringUsecases "clean-arch-gin/internal/adapters/metering/usecases"
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/models"
+	meteringDomainUsecases "clean-arch-gin/internal/domain/metering/usecases"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/metering"
+	"clean-arch-gin/internal/infrastructure/eventbus"
+	"clean-arch-gin/internal/infrastructure/openapi"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// defaultFlushInterval is how often counts are written when no interval is configured
+const defaultFlushInterval = 10 * time.Second
+
+// MeteringModule counts the billable operations of each tenant and API key, and reports them
+// hourly as billing.usage_reported events, delivered to the webhook endpoints subscribed to
+// them, for an external billing system to invoice
+type MeteringModule struct {
+	meter           *meter.BufferedMeter
+	flushInterval   time.Duration
+	meteringUseCase meteringDomainUsecases.MeteringUseCase
+	controller      *meteringControllers.MeteringController
+	auth            *middleware.AuthMiddleware
+	// stop ends the flush loop started by Initialize, which closes done once the last
+	// counts are written
+	stop context.CancelFunc
+	done chan struct{}
+}
+
+// NewMeteringModule creates a metering module writing its counts every flushInterval and
+// reporting them on bus. Each event named in meteredEvents counts one of its metric, e.g.
+// order.placed counting orders.created; replayed events were counted when they happened
+func NewMeteringModule(db *gorm.DB, bus *eventbus.Bus, flushInterval time.Duration, meteredEvents map[string]string) *MeteringModule {
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+	usageRepo := meteringRepositories.NewUsageRepository(db)
+	bufferedMeter := meter.NewBufferedMeter(usageRepo)
+	for name, metric := range meteredEvents {
+		metric := metric
+		bus.Subscribe(name, func(ctx context.Context, event events.DomainEvent) {
+			if events.IsReplay(ctx) {
+				return
+			}
+			bufferedMeter.Record(ctx, metric, 1)
+		})
+	}
+
+	meteringUseCase := meteringUsecases.NewMeteringUseCase(usageRepo, bus)
+	return &MeteringModule{
+		meter:           bufferedMeter,
+		flushInterval:   flushInterval,
+		meteringUseCase: meteringUseCase,
+		controller:      meteringControllers.NewMeteringController(meteringUseCase),
+		auth:            middleware.NewAuthMiddleware(""),
+	}
+}
+
+// Meter returns the meter other modules count their billable operations with
+func (m *MeteringModule) Meter() metering.Meter {
+	return m.meter
+}
+
+// Name returns the module name
+func (m *MeteringModule) Name() string {
+	return "metering"
+}
+
+// RegisterRoutes registers no public routes; usage is admin-only
+func (m *MeteringModule) RegisterRoutes(rg *gin.RouterGroup) {}
+
+// RegisterAdminRoutes registers the route admins read the usage of their tenant through
+func (m *MeteringModule) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	admin := rg.Group("", m.auth.RequireAuth(), m.auth.RequirePermission("metering", "read"))
+	{
+		admin.GET("/usage", m.controller.GetUsage) // GET /api/v1/metering/usage
+	}
+}
+
+// APIRoutes documents the routes registered by RegisterAdminRoutes
+func (m *MeteringModule) APIRoutes() []openapi.Route {
+	errorResponse := openapi.ErrorResponse{}
+
+	return []openapi.Route{
+		{
+			Method: "GET", Path: "/usage", Auth: true,
+			Summary: "Sum the billable usage of the tenant by API key and metric (api.requests, orders.created, " +
+				"storage.bytes) over whole hours (admin)",
+			Query: []openapi.Parameter{
+				openapi.QueryParam("from", "string", "First day, YYYY-MM-DD (default the first of the month, UTC)"),
+				openapi.QueryParam("to", "string", "Last day, YYYY-MM-DD (default today, UTC)"),
+				openapi.QueryParam("api_key_id", "integer", "Only the usage of this API key"),
+			},
+			Responses: map[int]interface{}{
+				200: meteringControllers.UsageResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+			},
+		},
+	}
+}
+
+// AuthMiddleware counts the authenticated requests; it must be registered after the modules
+// resolving the tenant and API key of requests
+func (m *MeteringModule) AuthMiddleware() gin.HandlerFunc {
+	return middleware.MeterRequests(m.meter)
+}
+
+// ScheduledJobs reports the usage hourly
+func (m *MeteringModule) ScheduledJobs() []scheduler.Job {
+	return []scheduler.Job{meteringJobs.NewReportUsageJob(m.meteringUseCase)}
+}
+
+// Migrate runs database migrations for metering module
+func (m *MeteringModule) Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(&models.UsageRecordModel{})
+}
+
+// Rollback drops the usage table
+func (m *MeteringModule) Rollback(db *gorm.DB) error {
+	return db.Migrator().DropTable(&models.UsageRecordModel{})
+}
+
+// Initialize starts writing the counts every flush interval; every process counts, whether
+// it serves requests or runs tasks, so the loop does not wait for the module workers
+func (m *MeteringModule) Initialize() error {
+	if m.stop != nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.stop, m.done = cancel, make(chan struct{})
+	go func() {
+		defer close(m.done)
+		m.meter.Run(ctx, m.flushInterval)
+	}()
+	return nil
+}
+
+// Shutdown stops the flush loop and waits for it to write the last counts
+// Operations counted after that, such as requests still draining, are lost
+func (m *MeteringModule) Shutdown(ctx context.Context) error {
+	if m.stop == nil {
+		return nil
+	}
+	m.stop()
+	select {
+	case <-m.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -1,10 +1,29 @@
 package modules
 
+//go:generate go run -mod=mod go.uber.org/mock/mockgen -source=module.go -destination=../mocks/module_mock.go -package=mocks
+
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"strings"
+	"time"
+
+	"clean-arch-gin/internal/domain/shared/authz"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/reports"
+	"clean-arch-gin/internal/domain/shared/search"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/grpcserver"
+	"clean-arch-gin/internal/infrastructure/health"
+	"clean-arch-gin/internal/infrastructure/kafka"
+	"clean-arch-gin/internal/infrastructure/openapi"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
@@ -16,6 +35,151 @@ type Module interface {
 	Initialize() error
 }
 
+// SchemaDeclarer is implemented by modules whose queries rely on indexes or constraints
+// AutoMigrate does not derive from their models, such as composite indexes for filters,
+// partial indexes and checks; MigrateAll creates the missing ones after Migrate and logs a
+// warning for those the live schema defines differently
+type SchemaDeclarer interface {
+	Schema() database.Schema
+}
+
+// QueryDeclarer is implemented by modules whose models get GORM Gen query code; the
+// generator (go generate ./internal/infrastructure/database/query) reads the manifests of
+// every registered module
+type QueryDeclarer interface {
+	Queries() database.QueryManifest
+}
+
+// Reverter is implemented by modules that can undo Migrate, for resetting a development
+// database one module at a time; Rollback drops the tables the module owns with their data
+type Reverter interface {
+	Rollback(db *gorm.DB) error
+}
+
+// Documented is implemented by modules that describe their routes for the OpenAPI document
+// Route paths are relative to the module's router group, exactly as passed to RegisterRoutes
+type Documented interface {
+	APIRoutes() []openapi.Route
+}
+
+// AdminRouter is implemented by modules with admin-only routes
+// They are mounted under the same /api/v1/<module> prefix, on the admin listener
+// when it is enabled so they are never reachable through the public port
+type AdminRouter interface {
+	RegisterAdminRoutes(rg *gin.RouterGroup)
+}
+
+// Authenticator is implemented by modules that resolve the credentials of requests, such
+// as session tokens, or the policy they are authorized by; the middleware runs in front
+// of every route of both routers, in registration order, and must not abort on missing
+// or rejected credentials, leaving the decision to the routes' own auth middleware
+// Requests that cannot be served at all, such as those naming an unknown tenant, may be
+// aborted
+type Authenticator interface {
+	AuthMiddleware() gin.HandlerFunc
+}
+
+// GRPCService is implemented by modules that expose gRPC services
+type GRPCService interface {
+	RegisterGRPC(s grpc.ServiceRegistrar)
+}
+
+// GRPCInterceptor is implemented by modules that scope gRPC calls, e.g. to a tenant
+// The interceptors run in registration order, ahead of the auth interceptor
+type GRPCInterceptor interface {
+	UnaryInterceptor() grpc.UnaryServerInterceptor
+}
+
+// SessionProvider is implemented by modules that resolve the bearer tokens issued at login
+// to their sessions, for the gRPC auth interceptor
+type SessionProvider interface {
+	Sessions() grpcserver.SessionAuthenticator
+}
+
+// Worker is implemented by modules that run background loops, such as webhook dispatch
+// Start must not block; the loops stop when ctx is cancelled
+type Worker interface {
+	Start(ctx context.Context)
+}
+
+// Shutdowner is implemented by modules that hold resources to release on shutdown,
+// such as long-lived streams or the goroutines started by Worker
+// Shutdown must return once its work is drained or ctx is done, whichever comes first
+type Shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// HealthChecker is implemented by modules that can verify their own dependencies
+// CheckHealth returns an error while the module cannot serve requests; it must honour ctx
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// Scheduled is implemented by modules with periodic jobs, such as purging soft-deleted rows
+// Job names are prefixed with the lowercase module name, e.g. users.purge-deleted
+type Scheduled interface {
+	ScheduledJobs() []scheduler.Job
+}
+
+// TaskProcessor is implemented by modules that handle durable tasks from the task queue
+// Task types are named "<module>.<task>", e.g. users.import, and are enqueued by that name
+type TaskProcessor interface {
+	TaskHandlers() map[string]taskqueue.Handler
+}
+
+// TaskProducer is implemented by modules that enqueue tasks, e.g. to run a long
+// operation in the background; RegisterTaskHandlers hands them the queue
+type TaskProducer interface {
+	SetTaskQueue(q *taskqueue.Queue)
+}
+
+// TopicConsumer is implemented by modules handling messages from Kafka topics, e.g. events
+// published by other services; the handlers run in the consumer group of the replicas
+type TopicConsumer interface {
+	TopicHandlers() map[string]kafka.Handler
+}
+
+// SearchIndexer is implemented by modules keeping records in the search engine; the indexes
+// are created at startup and rebuilt from the database by the reindex command
+type SearchIndexer interface {
+	SearchIndexes() []search.Index
+}
+
+// SearchResult is a record found by the global search
+type SearchResult struct {
+	// Type tags the kind of record, e.g. user or order
+	Type string
+	// ID is the record's public ID
+	ID      string
+	Title   string
+	Summary string
+	// Score ranks the results of one module, higher first; scores of different modules
+	// are not comparable
+	Score float64
+}
+
+// Searchable is implemented by modules whose records the global search finds
+// SearchPermission is the policy object and action a caller needs to be shown the module's
+// records, an empty object for any authenticated caller; Search returns at most limit
+// records matching query that actor may see, most relevant first
+type Searchable interface {
+	SearchPermission() (object, action string)
+	Search(ctx context.Context, actor authz.Actor, query string, limit int) ([]SearchResult, error)
+}
+
+// ReportProvider is implemented by modules contributing reports admins can generate in the
+// background; report types are prefixed with the lowercase module name, e.g. users.signups
+type ReportProvider interface {
+	ReportGenerators() []reports.Generator
+}
+
+// Projector is implemented by modules keeping read models built from domain events, which
+// admins can rebuild by replaying the recorded events; projection names are prefixed with
+// the lowercase module name, e.g. users.activity
+type Projector interface {
+	Projections() []events.Projection
+}
+
 // ModuleRegistry manages all application modules
 type ModuleRegistry struct {
 	modules []Module
@@ -51,16 +215,282 @@ func (r *ModuleRegistry) RegisterAllRoutes(rg *gin.RouterGroup) {
 	}
 }
 
-// MigrateAll runs database migrations for all modules
+// RegisterAllAdminRoutes registers the admin routes of every module implementing AdminRouter
+func (r *ModuleRegistry) RegisterAllAdminRoutes(rg *gin.RouterGroup) {
+	for _, module := range r.modules {
+		if admin, ok := module.(AdminRouter); ok {
+			admin.RegisterAdminRoutes(rg.Group("/" + strings.ToLower(module.Name())))
+		}
+	}
+}
+
+// AuthMiddlewares returns the middleware of every module implementing Authenticator
+func (r *ModuleRegistry) AuthMiddlewares() []gin.HandlerFunc {
+	var handlers []gin.HandlerFunc
+	for _, module := range r.modules {
+		if auth, ok := module.(Authenticator); ok {
+			handlers = append(handlers, auth.AuthMiddleware())
+		}
+	}
+	return handlers
+}
+
+// RegisterAllGRPC registers the gRPC services of every module implementing GRPCService
+func (r *ModuleRegistry) RegisterAllGRPC(s grpc.ServiceRegistrar) {
+	for _, module := range r.modules {
+		if svc, ok := module.(GRPCService); ok {
+			svc.RegisterGRPC(s)
+		}
+	}
+}
+
+// UnaryInterceptors returns the interceptor of every module implementing GRPCInterceptor
+func (r *ModuleRegistry) UnaryInterceptors() []grpc.UnaryServerInterceptor {
+	var interceptors []grpc.UnaryServerInterceptor
+	for _, module := range r.modules {
+		if i, ok := module.(GRPCInterceptor); ok {
+			interceptors = append(interceptors, i.UnaryInterceptor())
+		}
+	}
+	return interceptors
+}
+
+// Sessions returns the session resolver of the first module implementing SessionProvider
+// that has one, or nil
+func (r *ModuleRegistry) Sessions() grpcserver.SessionAuthenticator {
+	for _, module := range r.modules {
+		if provider, ok := module.(SessionProvider); ok {
+			if sessions := provider.Sessions(); sessions != nil {
+				return sessions
+			}
+		}
+	}
+	return nil
+}
+
+// StartAllWorkers starts the background loops of every module implementing Worker
+func (r *ModuleRegistry) StartAllWorkers(ctx context.Context) {
+	for _, module := range r.modules {
+		if worker, ok := module.(Worker); ok {
+			worker.Start(ctx)
+		}
+	}
+}
+
+// ShutdownAll shuts down every module implementing Shutdowner in reverse registration order
+// Every module is given the chance to shut down; the errors are joined
+func (r *ModuleRegistry) ShutdownAll(ctx context.Context) error {
+	var errs []error
+	for i := len(r.modules) - 1; i >= 0; i-- {
+		if s, ok := r.modules[i].(Shutdowner); ok {
+			if err := s.Shutdown(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("failed to shut down module %s: %w", r.modules[i].Name(), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RegisterHealthChecks adds a check named after every module implementing HealthChecker
+func (r *ModuleRegistry) RegisterHealthChecks(checker *health.Checker) {
+	for _, module := range r.modules {
+		if hc, ok := module.(HealthChecker); ok {
+			checker.Register(strings.ToLower(module.Name()), hc.CheckHealth)
+		}
+	}
+}
+
+// RegisterScheduledJobs registers the jobs of every module implementing Scheduled, named and
+// listed under the module
+func (r *ModuleRegistry) RegisterScheduledJobs(s *scheduler.Scheduler) error {
+	for _, module := range r.modules {
+		scheduled, ok := module.(Scheduled)
+		if !ok {
+			continue
+		}
+		for _, job := range scheduled.ScheduledJobs() {
+			job.Module = strings.ToLower(module.Name())
+			job.Name = job.Module + "." + job.Name
+			if err := s.Register(job); err != nil {
+				return fmt.Errorf("failed to schedule jobs of module %s: %w", module.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// RegisterTaskHandlers registers the task handlers of every module implementing TaskProcessor
+// and gives the queue to every module implementing TaskProducer
+func (r *ModuleRegistry) RegisterTaskHandlers(q *taskqueue.Queue) error {
+	for _, module := range r.modules {
+		if producer, ok := module.(TaskProducer); ok {
+			producer.SetTaskQueue(q)
+		}
+		processor, ok := module.(TaskProcessor)
+		if !ok {
+			continue
+		}
+		for taskType, handler := range processor.TaskHandlers() {
+			if err := q.Register(taskType, handler); err != nil {
+				return fmt.Errorf("failed to register tasks of module %s: %w", module.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// RegisterTopicHandlers registers the topic handlers of every module implementing
+// TopicConsumer
+func (r *ModuleRegistry) RegisterTopicHandlers(c *kafka.Consumer) error {
+	for _, module := range r.modules {
+		consumer, ok := module.(TopicConsumer)
+		if !ok {
+			continue
+		}
+		for topic, handler := range consumer.TopicHandlers() {
+			if err := c.Register(topic, handler); err != nil {
+				return fmt.Errorf("failed to register topics of module %s: %w", module.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// MigrateAll runs database migrations for all modules, then ensures the schema declared by
+// every module implementing SchemaDeclarer; each module migrated is recorded in
+// schema_migrations
 func (r *ModuleRegistry) MigrateAll(db *gorm.DB) error {
+	if err := db.AutoMigrate(&database.MigrationModel{}); err != nil {
+		return fmt.Errorf("failed to migrate the migration history: %w", err)
+	}
 	for _, module := range r.modules {
 		if err := module.Migrate(db); err != nil {
 			return fmt.Errorf("failed to migrate module %s: %w", module.Name(), err)
 		}
+		declarer, ok := module.(SchemaDeclarer)
+		if !ok {
+			continue
+		}
+		warnings, err := database.EnsureSchema(db, declarer.Schema())
+		if err != nil {
+			return fmt.Errorf("failed to migrate module %s: %w", module.Name(), err)
+		}
+		for _, warning := range warnings {
+			log.Printf("schema drift in module %s: %s", module.Name(), warning)
+		}
+	}
+	for _, module := range r.modules {
+		if err := database.RecordMigration(db, module.Name()); err != nil {
+			return fmt.Errorf("failed to record the migration of module %s: %w", module.Name(), err)
+		}
 	}
 	return nil
 }
 
+// MigrationStatus is whether and when a module migrated the schema
+type MigrationStatus struct {
+	Module string
+	// MigratedAt is nil when the module never migrated
+	MigratedAt *time.Time
+	// Reversible tells whether the module implements Reverter
+	Reversible bool
+}
+
+// MigrationStatuses returns the migration status of every module in registration order
+func (r *ModuleRegistry) MigrationStatuses(db *gorm.DB) ([]MigrationStatus, error) {
+	migrated := map[string]time.Time{}
+	if db.Migrator().HasTable(&database.MigrationModel{}) {
+		var err error
+		if migrated, err = database.Migrations(db); err != nil {
+			return nil, err
+		}
+	}
+	statuses := make([]MigrationStatus, len(r.modules))
+	for i, module := range r.modules {
+		_, reversible := module.(Reverter)
+		statuses[i] = MigrationStatus{Module: module.Name(), Reversible: reversible}
+		if at, ok := migrated[module.Name()]; ok {
+			statuses[i].MigratedAt = &at
+		}
+	}
+	return statuses, nil
+}
+
+// Rollback undoes the migrations of the module named name and forgets it migrated
+func (r *ModuleRegistry) Rollback(db *gorm.DB, name string) error {
+	module := r.GetModuleByName(name)
+	if module == nil {
+		return fmt.Errorf("unknown module %s", name)
+	}
+	reverter, ok := module.(Reverter)
+	if !ok {
+		return fmt.Errorf("module %s cannot be rolled back", module.Name())
+	}
+	if err := reverter.Rollback(db); err != nil {
+		return fmt.Errorf("failed to roll back module %s: %w", module.Name(), err)
+	}
+	if db.Migrator().HasTable(&database.MigrationModel{}) {
+		return database.ForgetMigration(db, module.Name())
+	}
+	return nil
+}
+
+// QueryManifests returns the query manifest of every module implementing QueryDeclarer by
+// module name
+func (r *ModuleRegistry) QueryManifests() map[string]database.QueryManifest {
+	manifests := make(map[string]database.QueryManifest)
+	for _, module := range r.modules {
+		if declarer, ok := module.(QueryDeclarer); ok {
+			manifests[module.Name()] = declarer.Queries()
+		}
+	}
+	return manifests
+}
+
+// SearchIndexes returns the indexes of every module implementing SearchIndexer
+func (r *ModuleRegistry) SearchIndexes() []search.Index {
+	var indexes []search.Index
+	for _, module := range r.modules {
+		if indexer, ok := module.(SearchIndexer); ok {
+			indexes = append(indexes, indexer.SearchIndexes()...)
+		}
+	}
+	return indexes
+}
+
+// Searchables returns every module implementing Searchable by lowercase module name
+func (r *ModuleRegistry) Searchables() map[string]Searchable {
+	searchables := make(map[string]Searchable)
+	for _, module := range r.modules {
+		if searchable, ok := module.(Searchable); ok {
+			searchables[strings.ToLower(module.Name())] = searchable
+		}
+	}
+	return searchables
+}
+
+// ReportGenerators returns the generators of every module implementing ReportProvider
+func (r *ModuleRegistry) ReportGenerators() []reports.Generator {
+	var generators []reports.Generator
+	for _, module := range r.modules {
+		if provider, ok := module.(ReportProvider); ok {
+			generators = append(generators, provider.ReportGenerators()...)
+		}
+	}
+	return generators
+}
+
+// Projections returns the projections of every module implementing Projector
+func (r *ModuleRegistry) Projections() []events.Projection {
+	var projections []events.Projection
+	for _, module := range r.modules {
+		if projector, ok := module.(Projector); ok {
+			projections = append(projections, projector.Projections()...)
+		}
+	}
+	return projections
+}
+
 // GetModules returns all registered modules
 func (r *ModuleRegistry) GetModules() []Module {
 	return r.modules
@@ -1,6 +1,46 @@
 package order
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	orderControllers "clean-arch-gin/internal/adapters/order/controllers"
+	orderJobs "clean-arch-gin/internal/adapters/order/jobs"
+	"clean-arch-gin/internal/adapters/order/lifecycle"
+	orderRepositories "clean-arch-gin/internal/adapters/order/repositories"
+	"clean-arch-gin/internal/adapters/order/streams"
+	orderUsecases "clean-arch-gin/internal/adapters/order/usecases"
+	"clean-arch-gin/internal/adapters/shared/indexing"
+	"clean-arch-gin/internal/adapters/shared/models"
+	tenantRepositories "clean-arch-gin/internal/adapters/tenant/repositories"
+	tenantUsecases "clean-arch-gin/internal/adapters/tenant/usecases"
+	orderQueries "clean-arch-gin/internal/application/order/queries"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	orderEvents "clean-arch-gin/internal/domain/order/events"
+	orderDomainRepositories "clean-arch-gin/internal/domain/order/repositories"
+	orderDomainUsecases "clean-arch-gin/internal/domain/order/usecases"
+	"clean-arch-gin/internal/domain/shared/authz"
+	"clean-arch-gin/internal/domain/shared/documents"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/locks"
+	"clean-arch-gin/internal/domain/shared/money"
+	"clean-arch-gin/internal/domain/shared/payments"
+	"clean-arch-gin/internal/domain/shared/pricing"
+	"clean-arch-gin/internal/domain/shared/search"
+	"clean-arch-gin/internal/infrastructure/breaker"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/eventbus"
+	"clean-arch-gin/internal/infrastructure/exchangerates"
+	"clean-arch-gin/internal/infrastructure/health"
+	"clean-arch-gin/internal/infrastructure/openapi"
+	paymentGateways "clean-arch-gin/internal/infrastructure/payments"
+	"clean-arch-gin/internal/infrastructure/pdf"
+	pricingStrategies "clean-arch-gin/internal/infrastructure/pricing"
+	"clean-arch-gin/internal/infrastructure/scheduler"
 	"clean-arch-gin/internal/modules"
 
 	"github.com/gin-gonic/gin"
@@ -9,13 +49,177 @@ import (
 
 // OrderModule encapsulates all order-related functionality
 type OrderModule struct {
-	db *gorm.DB
+	controller          orderControllers.OrderHandlers
+	returnController    orderControllers.ReturnHandlers
+	inventoryController orderControllers.InventoryHandlers
+	invoiceController   orderControllers.InvoiceHandlers
+	useCase             orderDomainUsecases.OrderUseCase
+	// searchHandler and searchController are nil on modules built from handlers
+	searchHandler    *orderQueries.SearchOrdersQueryHandler
+	searchController *orderControllers.OrderSearchController
+	// searchIndexes are kept in the search engine; none without one
+	searchIndexes []search.Index
+	// orderRepo purges long soft-deleted orders
+	orderRepo    orderDomainRepositories.OrderRepository
+	statusStream *streams.StatusStream
+	unsubscribe  func()
+	auth         *middleware.AuthMiddleware
+	db           *gorm.DB
+}
+
+// Pricing holds what orders and carts are priced with
+type Pricing struct {
+	Tax      pricing.TaxCalculator
+	Shipping pricing.ShippingStrategy
+	// Currencies tells the base currency of each tenant, which its orders are placed in
+	Currencies money.BaseCurrencies
+	// Rates converts amounts to the currency a client asks to see them in
+	Rates money.ExchangeRateProvider
+}
+
+// Handlers are the handlers the order routes are registered against
+type Handlers struct {
+	Orders    orderControllers.OrderHandlers
+	Returns   orderControllers.ReturnHandlers
+	Inventory orderControllers.InventoryHandlers
+	Invoices  orderControllers.InvoiceHandlers
+}
+
+// NewOrderModule creates a new order module with all dependencies, on the GORM Gen repository
+// Status transitions are published on the bus, which also feeds the SSE status stream,
+// confirmed orders hold their stock for reservationTTL until paid and are invoiced, with the
+// invoices rendered by pdfGenerator, and returns are refunded through refunds. Orders and carts
+// are priced with prices. The lifecycleEvents, e.g. order.confirmed, are published with the
+// whole order for webhooks. Orders are indexed in searchEngine and searched there when it is
+// not nil. Repository calls go through dbBreaker when it is not nil, and invoices are numbered
+// under lockManager when it is not nil
+func NewOrderModule(db *gorm.DB, bus *eventbus.Bus, refunds payments.Gateway, pdfGenerator documents.PDFGenerator,
+	prices Pricing, lifecycleEvents []string, reservationTTL time.Duration, searchEngine search.Engine, dbBreaker *breaker.CircuitBreaker,
+	lockManager locks.LockManager) modules.Module {
+	return newOrderModule(db, orderRepositories.NewOrderRepositoryGen(db), bus, refunds, pdfGenerator,
+		orderUsecases.NewPricingUseCase(prices.Tax, prices.Shipping, prices.Currencies, prices.Rates), lifecycleEvents,
+		reservationTTL, searchEngine, dbBreaker, lockManager)
+}
+
+// NewOrderModuleLegacy creates an order module with traditional GORM
+// Keep this for backward compatibility or comparison
+func NewOrderModuleLegacy(db *gorm.DB, bus *eventbus.Bus) modules.Module {
+	pricingUseCase := orderUsecases.NewPricingUseCase(pricingStrategies.NewFlatRate(0), pricingStrategies.NewFlatShipping(0),
+		tenantUsecases.NewTenantUseCase(tenantRepositories.NewTenantRepository(db)), exchangerates.None{})
+	lifecycleEvents := []string{orderEvents.OrderConfirmedEventName, orderEvents.OrderShippedEventName, orderEvents.OrderCancelledEventName}
+	return newOrderModule(db, orderRepositories.NewOrderRepository(db), bus, paymentGateways.NewManual(), pdf.NewGenerator(),
+		pricingUseCase, lifecycleEvents, orderJobs.ReservationTTL, nil, nil, nil)
+}
+
+// NewOrderModuleWithHandlers creates an order module registering its routes against handlers,
+// e.g. fakes in route tests, resolving the public IDs of orders through orderUseCase
+func NewOrderModuleWithHandlers(orderUseCase orderDomainUsecases.OrderUseCase, handlers Handlers) modules.Module {
+	return &OrderModule{
+		controller:          handlers.Orders,
+		returnController:    handlers.Returns,
+		inventoryController: handlers.Inventory,
+		invoiceController:   handlers.Invoices,
+		useCase:             orderUseCase,
+		unsubscribe:         func() {},
+		auth:                middleware.NewAuthMiddleware(""),
+	}
 }
 
-// NewOrderModule creates a new order module
-func NewOrderModule(db *gorm.DB) modules.Module {
+// newOrderModule wires the order module onto orderRepo
+func newOrderModule(db *gorm.DB, orderRepo orderDomainRepositories.OrderRepository, bus *eventbus.Bus,
+	refunds payments.Gateway, pdfGenerator documents.PDFGenerator, pricingUseCase orderDomainUsecases.PricingUseCase,
+	lifecycleEvents []string, reservationTTL time.Duration, searchEngine search.Engine, dbBreaker *breaker.CircuitBreaker,
+	lockManager locks.LockManager) modules.Module {
+	shipmentRepo := orderRepositories.NewShipmentRepository(db)
+	returnRepo := orderRepositories.NewReturnRepository(db)
+	inventoryRepo := orderRepositories.NewInventoryRepository(db)
+	invoiceRepo := orderRepositories.NewInvoiceRepository(db)
+	if dbBreaker != nil {
+		orderRepo = orderRepositories.NewOrderRepositoryWithBreaker(orderRepo, dbBreaker)
+		shipmentRepo = orderRepositories.NewShipmentRepositoryWithBreaker(shipmentRepo, dbBreaker)
+		returnRepo = orderRepositories.NewReturnRepositoryWithBreaker(returnRepo, dbBreaker)
+		inventoryRepo = orderRepositories.NewInventoryRepositoryWithBreaker(inventoryRepo, dbBreaker)
+		invoiceRepo = orderRepositories.NewInvoiceRepositoryWithBreaker(invoiceRepo, dbBreaker)
+	}
+	orderUseCase := orderUsecases.NewOrderUseCase(orderRepo, shipmentRepo, inventoryRepo, pricingUseCase, bus, reservationTTL)
+	invoiceUseCase := orderUsecases.NewInvoiceUseCase(orderRepo, invoiceRepo, pdfGenerator, lockManager)
+	statusStream, unsubscribeStream := streams.NewStatusStream(bus)
+	unsubscribeInvoicing := bus.Subscribe(orderEvents.OrderStatusChangedEventName, issueInvoiceOnConfirmation(invoiceUseCase))
+	unsubscribeLifecycle := lifecycle.NewPublisher(orderUseCase, bus, lifecycleEvents).Subscribe(bus)
+	searchHandler := newSearchHandler(db, dbBreaker, searchEngine)
+	searchIndexes, unsubscribeSearch := newSearchIndexing(db, bus, searchEngine)
+	unsubscribe := func() {
+		unsubscribeStream()
+		unsubscribeInvoicing()
+		unsubscribeLifecycle()
+		unsubscribeSearch()
+	}
+
 	return &OrderModule{
-		db: db,
+		controller: orderControllers.NewOrderController(orderUseCase, pricingUseCase, statusStream),
+		returnController: orderControllers.NewReturnController(
+			orderUsecases.NewReturnUseCase(orderRepo, returnRepo, inventoryRepo, refunds, bus), orderUseCase),
+		inventoryController: orderControllers.NewInventoryController(orderUsecases.NewInventoryUseCase(inventoryRepo)),
+		invoiceController:   orderControllers.NewInvoiceController(invoiceUseCase, orderUseCase),
+		searchHandler:       searchHandler,
+		searchController:    orderControllers.NewOrderSearchController(searchHandler),
+		searchIndexes:       searchIndexes,
+		useCase:             orderUseCase,
+		orderRepo:           orderRepo,
+		statusStream:        statusStream,
+		unsubscribe:         unsubscribe,
+		auth:                middleware.NewAuthMiddleware(""),
+		db:                  db,
+	}
+}
+
+// newSearchHandler wires the order search onto the database; with an engine orders are
+// searched there, and in the database while it is unavailable
+func newSearchHandler(db *gorm.DB, dbBreaker *breaker.CircuitBreaker, engine search.Engine) *orderQueries.SearchOrdersQueryHandler {
+	searchRepo := orderRepositories.NewOrderSearchRepository(db)
+	if dbBreaker != nil {
+		searchRepo = orderRepositories.NewOrderSearchRepositoryWithBreaker(searchRepo, dbBreaker)
+	}
+	if engine != nil {
+		searchRepo = orderRepositories.NewOrderSearchRepositoryWithEngine(engine, db, searchRepo)
+	}
+	return orderQueries.NewSearchOrdersQueryHandler(searchRepo)
+}
+
+// newSearchIndexing describes the orders index and keeps it up to date in engine from the
+// domain events on bus, returning the unsubscribe function; no index without an engine
+func newSearchIndexing(db *gorm.DB, bus *eventbus.Bus, engine search.Engine) ([]search.Index, func()) {
+	if engine == nil {
+		return nil, func() {}
+	}
+	index := orderRepositories.NewOrderSearchIndex(db)
+	subscriber := indexing.NewSubscriber(engine, index)
+	subscriber.Register(orderEvents.OrderPlacedEventName, func(event events.DomainEvent) []uint {
+		if placed, ok := event.(orderEvents.OrderPlacedEvent); ok {
+			return []uint{placed.OrderID}
+		}
+		return nil
+	})
+	subscriber.Register(orderEvents.OrderStatusChangedEventName, func(event events.DomainEvent) []uint {
+		if changed, ok := event.(orderEvents.OrderStatusChangedEvent); ok {
+			return []uint{changed.OrderID}
+		}
+		return nil
+	})
+	return []search.Index{index}, subscriber.Subscribe(bus)
+}
+
+// issueInvoiceOnConfirmation invoices orders as they are confirmed
+// A failure is logged: the order stays confirmed and is invoiced when its invoice is first asked for
+func issueInvoiceOnConfirmation(invoices orderDomainUsecases.InvoiceUseCase) events.EventHandler {
+	return func(ctx context.Context, event events.DomainEvent) {
+		changed, ok := event.(orderEvents.OrderStatusChangedEvent)
+		if !ok || changed.To != orderEntities.OrderStatusConfirmed {
+			return
+		}
+		if _, err := invoices.IssueInvoice(ctx, changed.OrderID); err != nil {
+			log.Printf("failed to invoice order %d: %v", changed.OrderID, err)
+		}
 	}
 }
 
@@ -25,25 +229,288 @@ func (m *OrderModule) Name() string {
 }
 
 // RegisterRoutes registers all order-related routes
+// Orders are addressed by public ID, resolved to the internal one ahead of the controllers
 func (m *OrderModule) RegisterRoutes(rg *gin.RouterGroup) {
+	orders := rg.Group("", middleware.ResolvePublicID("id", m.orderID))
+
 	// Basic order routes
-	rg.POST("", m.createOrder)             // POST /api/v1/orders
-	rg.GET("/:id", m.getOrder)             // GET /api/v1/orders/:id
-	rg.GET("", m.getUserOrders)            // GET /api/v1/orders
-	rg.PUT("/:id/confirm", m.confirmOrder) // PUT /api/v1/orders/:id/confirm
-	rg.PUT("/:id/cancel", m.cancelOrder)   // PUT /api/v1/orders/:id/cancel
+	orders.POST("", m.auth.RequireAuth(), m.controller.CreateOrder) // POST /api/v1/orders
+	orders.POST("/quote", m.controller.QuoteOrder)                  // POST /api/v1/orders/quote
+	orders.GET("/:id", m.auth.RequireAuth(), m.controller.GetOrder) // GET /api/v1/orders/:id
+	if m.searchController != nil {
+		orders.GET("/search", m.auth.RequireAuth(), m.searchController.SearchOrders) // GET /api/v1/orders/search?q=
+	}
+	orders.GET("", m.getUserOrders)                                             // GET /api/v1/orders
+	orders.PUT("/:id/confirm", m.auth.RequireAuth(), m.controller.ConfirmOrder) // PUT /api/v1/orders/:id/confirm
+	orders.PUT("/:id/cancel", m.auth.RequireAuth(), m.controller.CancelOrder)   // PUT /api/v1/orders/:id/cancel
+
+	// Shipments with their tracking numbers
+	orders.GET("/:id/shipments", m.auth.RequireAuth(), m.controller.ListShipments) // GET /api/v1/orders/:id/shipments
+
+	// Returns (RMAs) of delivered orders
+	orders.POST("/:id/returns", m.auth.RequireAuth(), m.returnController.RequestReturn)      // POST /api/v1/orders/:id/returns
+	orders.GET("/:id/returns", m.auth.RequireAuth(), m.returnController.ListReturns)         // GET /api/v1/orders/:id/returns
+	orders.GET("/:id/returns/:returnId", m.auth.RequireAuth(), m.returnController.GetReturn) // GET /api/v1/orders/:id/returns/:returnId
+
+	// Invoice issued on confirmation, as a PDF
+	orders.GET("/:id/invoice.pdf", m.auth.RequireAuth(), m.invoiceController.GetInvoicePDF) // GET /api/v1/orders/:id/invoice.pdf
+
+	// Server-Sent Events stream of status transitions
+	orders.GET("/:id/events", m.auth.RequireAuth(), m.controller.StreamOrderEvents) // GET /api/v1/orders/:id/events
 
 	// Order items sub-routes
-	rg.GET("/:id/items", m.getOrderItems)              // GET /api/v1/orders/:id/items
-	rg.POST("/:id/items", m.addOrderItem)              // POST /api/v1/orders/:id/items
-	rg.DELETE("/:id/items/:itemId", m.removeOrderItem) // DELETE /api/v1/orders/:id/items/:itemId
+	orders.GET("/:id/items", m.getOrderItems)              // GET /api/v1/orders/:id/items
+	orders.POST("/:id/items", m.addOrderItem)              // POST /api/v1/orders/:id/items
+	orders.DELETE("/:id/items/:itemId", m.removeOrderItem) // DELETE /api/v1/orders/:id/items/:itemId
+}
+
+// RegisterAdminRoutes registers the fulfilment, returns and stock management routes
+func (m *OrderModule) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	orders := rg.Group("", middleware.ResolvePublicID("id", m.orderID))
+	orders.POST("/:id/shipments", m.auth.RequireAuth(), m.auth.RequirePermission("orders", "ship"), m.controller.CreateShipment) // POST /api/v1/orders/:id/shipments
+
+	returns := orders.Group("/:id/returns/:returnId", m.auth.RequireAuth(), m.auth.RequirePermission("returns", "manage"))
+	returns.PUT("/approve", m.returnController.ApproveReturn) // PUT /api/v1/orders/:id/returns/:returnId/approve
+	returns.PUT("/reject", m.returnController.RejectReturn)   // PUT /api/v1/orders/:id/returns/:returnId/reject
+	returns.PUT("/receive", m.returnController.ReceiveReturn) // PUT /api/v1/orders/:id/returns/:returnId/receive
+	returns.PUT("/refund", m.returnController.RefundReturn)   // PUT /api/v1/orders/:id/returns/:returnId/refund
+
+	inventory := rg.Group("/inventory", m.auth.RequireAuth(), m.auth.RequirePermission("inventory", "manage"))
+	inventory.GET("/:productId", m.inventoryController.GetStock) // GET /api/v1/orders/inventory/:productId
+	inventory.PUT("/:productId", m.inventoryController.SetStock) // PUT /api/v1/orders/inventory/:productId
+}
+
+// orderID resolves the public ID of an order in a route to its internal ID
+func (m *OrderModule) orderID(ctx context.Context, publicID string) (uint, error) {
+	order, err := m.useCase.GetOrderByPublicID(ctx, publicID)
+	if err != nil {
+		return 0, err
+	}
+	return order.ID, nil
+}
+
+// APIRoutes documents the routes registered by RegisterRoutes and RegisterAdminRoutes
+func (m *OrderModule) APIRoutes() []openapi.Route {
+	errorResponse := openapi.ErrorResponse{}
+	currencyParam := openapi.QueryParam("currency", "string",
+		"ISO 4217 code to show the amounts in, converted at the current exchange rate; 422 when no rate is known")
+
+	routes := []openapi.Route{
+		{
+			Method: "POST", Path: "", Auth: true,
+			Summary: "Place an order for the authenticated user, priced with tax and shipping to its destination; 422 when the destination cannot be priced",
+			Request: orderControllers.CheckoutRequest{},
+			Responses: map[int]interface{}{
+				201: orderControllers.CreateOrderResponse{}, 400: errorResponse, 401: errorResponse, 422: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/quote",
+			Summary: "Price a cart at checkout in the tenant's base currency: each item with its tax, then shipping to the destination",
+			Request: orderControllers.CheckoutRequest{},
+			Query:   []openapi.Parameter{currencyParam},
+			Responses: map[int]interface{}{
+				200: orderControllers.PriceBreakdownDTO{}, 400: errorResponse, 422: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/:id", Auth: true, Summary: "Get an order by ID; others' orders are not found unless the policy allows reading orders",
+			Query: []openapi.Parameter{currencyParam},
+			Responses: map[int]interface{}{
+				200: orderControllers.OrderDTO{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 422: errorResponse, 500: errorResponse,
+			},
+		},
+		{Method: "GET", Path: "", Summary: "List the current user's orders"},
+		{
+			Method: "GET", Path: "/search", Auth: true,
+			Summary: "Search the current user's orders by the words of their public ID, most relevant and then newest first",
+			Query: []openapi.Parameter{
+				openapi.QueryParam("q", "string", "Words the public ID must have a word starting with, e.g. 01hx"),
+				openapi.QueryParam("status", "string", "Only orders in this status, e.g. shipped"),
+				openapi.QueryParam("limit", "integer", "Page size (default 10, max 100)"),
+				openapi.QueryParam("offset", "integer", "Number of orders to skip"),
+			},
+			Responses: map[int]interface{}{
+				200: orderControllers.OrderSearchResponse{}, 400: errorResponse, 401: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/:id/confirm", Auth: true,
+			Summary: "Confirm a pending order, reserving its stock until it is paid; 409 when a product is out of stock",
+			Responses: map[int]interface{}{
+				200: orderControllers.OrderDTO{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 409: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/:id/cancel", Auth: true, Summary: "Cancel an order, releasing its reserved stock",
+			Responses: map[int]interface{}{
+				200: orderControllers.OrderDTO{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 409: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/:id/events", Auth: true, Summary: "Stream status transitions as Server-Sent Events (text/event-stream)",
+			Query: []openapi.Parameter{
+				openapi.QueryParam("lastEventId", "string", "Resume after this event ID (alternative to the Last-Event-ID header)"),
+			},
+			Responses: map[int]interface{}{
+				200: nil, 400: errorResponse, 401: errorResponse, 404: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/:id/shipments", Auth: true, Summary: "List an order's shipments with their tracking numbers",
+			Responses: map[int]interface{}{
+				200: orderControllers.ShipmentListResponse{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/:id/shipments", Auth: true,
+			Summary: "Admin: ship some or all of a confirmed order's items; the order becomes partially_shipped until every unit is shipped",
+			Request: orderControllers.CreateShipmentRequest{},
+			Responses: map[int]interface{}{
+				201: orderControllers.CreateShipmentResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+				404: errorResponse, 409: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/:id/invoice.pdf", Auth: true,
+			Summary: "Download an order's invoice as a PDF (application/pdf); 409 until the order is confirmed and once cancelled uninvoiced",
+			Responses: map[int]interface{}{
+				200: nil, 400: errorResponse, 401: errorResponse, 404: errorResponse, 409: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/:id/returns", Auth: true, Summary: "Request the return (RMA) of some of a delivered order's items",
+			Request: orderControllers.RequestReturnRequest{},
+			Responses: map[int]interface{}{
+				201: orderControllers.ReturnDTO{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 409: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/:id/returns", Auth: true, Summary: "List an order's returns",
+			Responses: map[int]interface{}{
+				200: orderControllers.ReturnListResponse{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/:id/returns/:returnId", Auth: true, Summary: "Get a return of an order",
+			Responses: map[int]interface{}{
+				200: orderControllers.ReturnDTO{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		returnRoute("approve", "Admin: approve a requested return, with an optional note", orderControllers.ReturnDecisionRequest{}),
+		returnRoute("reject", "Admin: reject a requested return with a note explaining why", orderControllers.ReturnDecisionRequest{}),
+		returnRoute("receive", "Admin: record an approved return's goods are back, putting them back in stock", nil),
+		returnRoute("refund", "Admin: refund a received return through the payment gateway; 422 when the gateway declines", nil),
+		{Method: "GET", Path: "/:id/items", Summary: "List order items"},
+		{Method: "POST", Path: "/:id/items", Summary: "Add an item to an order"},
+		{Method: "DELETE", Path: "/:id/items/:itemId", Summary: "Remove an item from an order"},
+		{
+			Method: "GET", Path: "/inventory/:productId", Auth: true, Summary: "Admin: get a product's stock on hand, reserved and available",
+			Responses: map[int]interface{}{
+				200: orderControllers.StockLevelDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/inventory/:productId", Auth: true,
+			Summary: "Admin: set a product's stock on hand, tracking it from now on; it cannot go below the reserved units",
+			Request: orderControllers.SetStockRequest{},
+			Responses: map[int]interface{}{
+				200: orderControllers.StockLevelDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+	}
+	for i := range routes {
+		routes[i].PublicID = strings.Contains(routes[i].Path, ":id")
+	}
+	return routes
+}
+
+// returnRoute documents an admin transition of a return
+func returnRoute(transition, summary string, request interface{}) openapi.Route {
+	errorResponse := openapi.ErrorResponse{}
+	return openapi.Route{
+		Method: "PUT", Path: "/:id/returns/:returnId/" + transition, Auth: true, Summary: summary,
+		Request: request,
+		Responses: map[int]interface{}{
+			200: orderControllers.ReturnDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+			404: errorResponse, 409: errorResponse, 422: errorResponse, 500: errorResponse,
+		},
+	}
 }
 
 // Migrate runs database migrations for order module
+// Orders missing a public ID are assigned one
 func (m *OrderModule) Migrate(db *gorm.DB) error {
-	// Here you would auto-migrate order models
-	// return db.AutoMigrate(&models.OrderModel{}, &models.OrderItemModel{})
-	return nil
+	if err := db.AutoMigrate(&models.OrderModel{}, &models.OrderItemModel{}, &models.ShipmentModel{}, &models.ShipmentItemModel{},
+		&models.ReturnModel{}, &models.ReturnItemModel{},
+		&models.InvoiceModel{}, &models.InvoiceLineModel{}, &models.InvoiceSequenceModel{},
+		&models.StockLevelModel{}, &models.StockReservationModel{}); err != nil {
+		return err
+	}
+	return orderJobs.BackfillPublicIDs(context.Background(), db)
+}
+
+// Rollback drops the order tables, the dependent ones first
+func (m *OrderModule) Rollback(db *gorm.DB) error {
+	return db.Migrator().DropTable(&models.StockReservationModel{}, &models.StockLevelModel{},
+		&models.InvoiceSequenceModel{}, &models.InvoiceLineModel{}, &models.InvoiceModel{},
+		&models.ReturnItemModel{}, &models.ReturnModel{},
+		&models.ShipmentItemModel{}, &models.ShipmentModel{}, &models.OrderItemModel{}, &models.OrderModel{})
+}
+
+// Schema declares the indexes behind listing a user's orders newest first and sweeping
+// pending ones by age, both over live orders only, and the checks on amounts and quantities
+func (m *OrderModule) Schema() database.Schema {
+	return database.Schema{
+		Indexes: []database.Index{
+			{Table: "orders", Name: "idx_orders_user_created_live", Columns: []string{"user_id", "created_at", "id"}, Where: "deleted_at IS NULL"},
+			{Table: "orders", Name: "idx_orders_status_created_live", Columns: []string{"status", "created_at"}, Where: "deleted_at IS NULL"},
+		},
+		Checks: []database.Check{
+			{Table: "orders", Name: "chk_orders_amounts", Expr: "total_amount >= 0 AND tax_amount >= 0 AND shipping_amount >= 0"},
+			{Table: "order_items", Name: "chk_order_items_quantity", Expr: "quantity > 0"},
+		},
+	}
+}
+
+// Queries declares the order and order item models for GORM Gen
+func (m *OrderModule) Queries() database.QueryManifest {
+	return database.QueryManifest{
+		Models: []interface{}{models.OrderModel{}, models.OrderItemModel{}},
+	}
+}
+
+// SearchPermission lets any authenticated caller find orders, which Search limits to their own
+func (m *OrderModule) SearchPermission() (string, string) {
+	return "", ""
+}
+
+// Search finds the actor's orders whose public ID has words starting with those of query;
+// none on modules built from handlers
+func (m *OrderModule) Search(ctx context.Context, actor authz.Actor, query string, limit int) ([]modules.SearchResult, error) {
+	if m.searchHandler == nil || actor.ID == 0 {
+		return nil, nil
+	}
+	result, err := m.searchHandler.Handle(ctx, orderQueries.SearchOrdersQuery{Query: query, UserID: actor.ID, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]modules.SearchResult, len(result.Hits))
+	for i, hit := range result.Hits {
+		results[i] = modules.SearchResult{
+			Type:    "order",
+			ID:      hit.Order.PublicID,
+			Title:   "Order " + hit.Order.PublicID,
+			Summary: fmt.Sprintf("%s, %.2f %s", hit.Order.Status, hit.Order.TotalAmount, hit.Order.Currency),
+			Score:   hit.Score,
+		}
+	}
+	return results, nil
+}
+
+// SearchIndexes describes the orders index when orders are kept in the search engine
+func (m *OrderModule) SearchIndexes() []search.Index {
+	return m.searchIndexes
 }
 
 // Initialize performs order module initialization
@@ -52,25 +519,34 @@ func (m *OrderModule) Initialize() error {
 	return nil
 }
 
-// Placeholder handler methods (would be implemented with proper controllers)
-func (m *OrderModule) createOrder(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Create order endpoint"})
-}
-
-func (m *OrderModule) getOrder(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Get order endpoint"})
+// ScheduledJobs purges long soft-deleted orders and cancels orders left pending or unpaid
+func (m *OrderModule) ScheduledJobs() []scheduler.Job {
+	return []scheduler.Job{
+		orderJobs.NewPurgeDeletedJob(m.orderRepo, orderJobs.PurgeDeletedAfter),
+		orderJobs.NewCancelStaleJob(m.useCase, orderJobs.StaleOrderAfter),
+		orderJobs.NewCancelUnpaidJob(m.useCase),
+	}
 }
 
-func (m *OrderModule) getUserOrders(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Get user orders endpoint"})
+// CheckHealth verifies the order tables are readable
+func (m *OrderModule) CheckHealth(ctx context.Context) error {
+	return health.TableCheck(m.db, &models.OrderModel{})(ctx)
 }
 
-func (m *OrderModule) confirmOrder(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Confirm order endpoint"})
+// Shutdown stops invoicing confirmed orders, publishing lifecycle events and feeding the status
+// stream, and ends the open
+// SSE connections, which would otherwise hold the HTTP server's drain until its timeout
+func (m *OrderModule) Shutdown(ctx context.Context) error {
+	m.unsubscribe()
+	if m.statusStream != nil {
+		m.statusStream.Close()
+	}
+	return nil
 }
 
-func (m *OrderModule) cancelOrder(c *gin.Context) {
-	c.JSON(200, gin.H{"message": "Cancel order endpoint"})
+// Placeholder handler methods (would be implemented with proper controllers)
+func (m *OrderModule) getUserOrders(c *gin.Context) {
+	c.JSON(200, gin.H{"message": "Get user orders endpoint"})
 }
 
 func (m *OrderModule) getOrderItems(c *gin.Context) {
@@ -0,0 +1,117 @@
+package report
+
+import (
+	"log"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	reportControllers "clean-arch-gin/internal/adapters/report/controllers"
+	"clean-arch-gin/internal/adapters/report/renderers"
+	reportTasks "clean-arch-gin/internal/adapters/report/tasks"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	"clean-arch-gin/internal/domain/shared/documents"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/reports"
+	"clean-arch-gin/internal/domain/shared/storage"
+	"clean-arch-gin/internal/infrastructure/eventbus"
+	"clean-arch-gin/internal/infrastructure/openapi"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ReportModule generates the reports other modules contribute in the background, as CSV or
+// PDF files kept in file storage
+type ReportModule struct {
+	generateTask taskqueue.Handler
+	controller   *reportControllers.ReportController
+	auth         *middleware.AuthMiddleware
+}
+
+// NewReportModule creates a report module generating the reports of generators, typically
+// the registry's ReportGenerators, storing them in files and rendering PDFs with pdfGenerator
+// Requesters are notified through the ReportReadyEvent published on bus when it is not nil.
+// A type generated twice keeps its first generator
+func NewReportModule(generators []reports.Generator, files storage.Storage, pdfGenerator documents.PDFGenerator,
+	bus *eventbus.Bus) *ReportModule {
+	var publisher events.EventPublisher
+	if bus != nil {
+		publisher = bus
+	}
+	byType := make(map[string]reports.Generator, len(generators))
+	for _, generator := range generators {
+		if _, ok := byType[generator.Type()]; ok {
+			log.Printf("reports: ignoring a second generator of %s", generator.Type())
+			continue
+		}
+		byType[generator.Type()] = generator
+	}
+	return &ReportModule{
+		generateTask: reportTasks.NewGenerateHandler(byType, renderers.NewRenderer(pdfGenerator), files, publisher),
+		controller:   reportControllers.NewReportController(byType),
+		auth:         middleware.NewAuthMiddleware(""),
+	}
+}
+
+// Name returns the module name
+func (m *ReportModule) Name() string {
+	return "reports"
+}
+
+// RegisterRoutes registers no public routes; reports are admin-only
+func (m *ReportModule) RegisterRoutes(rg *gin.RouterGroup) {}
+
+// RegisterAdminRoutes registers the routes admins request reports through; their progress
+// and results are served by the jobs API
+func (m *ReportModule) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	admin := rg.Group("", m.auth.RequireAuth(), m.auth.RequirePermission("reports", "generate"))
+	{
+		admin.GET("/types", m.controller.ListTypes) // GET /api/v1/reports/types
+		admin.POST("", m.controller.RequestReport)  // POST /api/v1/reports
+	}
+}
+
+// APIRoutes documents the routes registered by RegisterAdminRoutes
+func (m *ReportModule) APIRoutes() []openapi.Route {
+	errorResponse := openapi.ErrorResponse{}
+
+	return []openapi.Route{
+		{
+			Method: "GET", Path: "/types", Auth: true,
+			Summary: "List the reports that can be requested with their parameters (admin)",
+			Responses: map[int]interface{}{
+				200: reportControllers.ReportTypesResponse{}, 401: errorResponse, 403: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "", Auth: true,
+			Summary: "Request a report as CSV or PDF (admin); track it through the job, whose result, also sent " +
+				"as a notification, is a signed download URL",
+			Request: reportControllers.RequestReportRequest{},
+			Responses: map[int]interface{}{
+				202: responses.JobAcceptedResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+				500: errorResponse, 503: errorResponse,
+			},
+		},
+	}
+}
+
+// TaskHandlers generates the requested reports
+func (m *ReportModule) TaskHandlers() map[string]taskqueue.Handler {
+	return map[string]taskqueue.Handler{reportTasks.GenerateTaskType: m.generateTask}
+}
+
+// SetTaskQueue lets admins request reports
+func (m *ReportModule) SetTaskQueue(q *taskqueue.Queue) {
+	m.controller.SetQueue(q)
+}
+
+// Migrate creates no tables; reports live in the task queue and file storage
+func (m *ReportModule) Migrate(db *gorm.DB) error {
+	return nil
+}
+
+// Initialize performs report module initialization
+func (m *ReportModule) Initialize() error {
+	return nil
+}
@@ -0,0 +1,145 @@
+package tenant
+
+import (
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/models"
+	tenantControllers "clean-arch-gin/internal/adapters/tenant/controllers"
+	tenantGRPC "clean-arch-gin/internal/adapters/tenant/grpc"
+	tenantRepositories "clean-arch-gin/internal/adapters/tenant/repositories"
+	tenantUsecases "clean-arch-gin/internal/adapters/tenant/usecases"
+	tenantEntities "clean-arch-gin/internal/domain/tenant/entities"
+	tenantDomainUsecases "clean-arch-gin/internal/domain/tenant/usecases"
+	"clean-arch-gin/internal/infrastructure/breaker"
+	"clean-arch-gin/internal/infrastructure/openapi"
+	"clean-arch-gin/internal/modules"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+// TenantModule scopes every request to a tenant and serves the tenant management API
+// It must be registered first so the tenant is resolved before sessions are looked up
+type TenantModule struct {
+	tenantUseCase tenantDomainUsecases.TenantUseCase
+	controller    *tenantControllers.TenantController
+	auth          *middleware.AuthMiddleware
+	// baseDomain serves tenants on its subdomains; empty resolves them by header only
+	baseDomain string
+}
+
+// NewTenantModule creates a new tenant module
+// Repository calls go through dbBreaker when it is not nil
+func NewTenantModule(db *gorm.DB, baseDomain string, dbBreaker *breaker.CircuitBreaker) modules.Module {
+	tenantRepo := tenantRepositories.NewTenantRepository(db)
+	if dbBreaker != nil {
+		tenantRepo = tenantRepositories.NewTenantRepositoryWithBreaker(tenantRepo, dbBreaker)
+	}
+	tenantUseCase := tenantUsecases.NewTenantUseCase(tenantRepo)
+
+	return &TenantModule{
+		tenantUseCase: tenantUseCase,
+		controller:    tenantControllers.NewTenantController(tenantUseCase),
+		auth:          middleware.NewAuthMiddleware(""),
+		baseDomain:    baseDomain,
+	}
+}
+
+// Name returns the module name
+func (m *TenantModule) Name() string {
+	return "tenants"
+}
+
+// RegisterRoutes registers no public routes; tenant management is admin-only
+func (m *TenantModule) RegisterRoutes(rg *gin.RouterGroup) {}
+
+// RegisterAdminRoutes registers the tenant management routes, served to the default
+// tenant's admins only
+func (m *TenantModule) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	admin := rg.Group("", middleware.RequireDefaultTenant(), m.auth.RequireAuth(), m.auth.RequirePermission("tenants", "manage"))
+	{
+		admin.GET("", m.controller.ListTenants)         // GET /api/v1/tenants
+		admin.POST("", m.controller.CreateTenant)       // POST /api/v1/tenants
+		admin.GET("/:id", m.controller.GetTenant)       // GET /api/v1/tenants/:id
+		admin.PUT("/:id", m.controller.UpdateTenant)    // PUT /api/v1/tenants/:id
+		admin.DELETE("/:id", m.controller.DeleteTenant) // DELETE /api/v1/tenants/:id
+	}
+}
+
+// APIRoutes documents the routes registered by RegisterAdminRoutes
+func (m *TenantModule) APIRoutes() []openapi.Route {
+	errorResponse := openapi.ErrorResponse{}
+
+	return []openapi.Route{
+		{
+			Method: "GET", Path: "", Auth: true, Summary: "List the tenants (admin of the default tenant)",
+			Query: []openapi.Parameter{
+				openapi.QueryParam("limit", "integer", "Page size (default 10, max 100)"),
+				openapi.QueryParam("offset", "integer", "Number of tenants to skip"),
+			},
+			Responses: map[int]interface{}{
+				200: tenantControllers.TenantListResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "", Auth: true,
+			Summary: "Create a tenant, served under its slug in the X-Tenant header or as a subdomain, with the base currency its orders are placed in (admin of the default tenant)",
+			Request: tenantControllers.CreateTenantRequest{},
+			Responses: map[int]interface{}{
+				201: tenantControllers.TenantDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 409: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/:id", Auth: true, Summary: "Get a tenant (admin of the default tenant)",
+			Responses: map[int]interface{}{
+				200: tenantControllers.TenantDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/:id", Auth: true,
+			Summary: "Rename, suspend or reactivate a tenant or change its base currency; requests to a suspended tenant get 403 (admin of the default tenant)",
+			Request: tenantControllers.UpdateTenantRequest{},
+			Responses: map[int]interface{}{
+				200: tenantControllers.TenantDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "DELETE", Path: "/:id", Auth: true,
+			Summary: "Delete a tenant; its data is kept but no longer served. The default tenant cannot be deleted (admin of the default tenant)",
+			Responses: map[int]interface{}{
+				204: nil, 400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+	}
+}
+
+// Migrate creates the tenants table with the default tenant, which owns the data that
+// existed before tenants did
+func (m *TenantModule) Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.TenantModel{}); err != nil {
+		return err
+	}
+	return db.Where(models.TenantModel{ID: tenantEntities.DefaultTenantID}).
+		Attrs(models.TenantModel{Slug: tenantEntities.DefaultSlug, Name: "Default", Status: string(tenantEntities.StatusActive)}).
+		FirstOrCreate(&models.TenantModel{}).Error
+}
+
+// Rollback drops the tenants, the default one included
+func (m *TenantModule) Rollback(db *gorm.DB) error {
+	return db.Migrator().DropTable(&models.TenantModel{})
+}
+
+// Initialize performs tenant module initialization
+func (m *TenantModule) Initialize() error {
+	return nil
+}
+
+// AuthMiddleware scopes every request to the tenant it names
+func (m *TenantModule) AuthMiddleware() gin.HandlerFunc {
+	return middleware.ResolveTenant(m.tenantUseCase, m.baseDomain)
+}
+
+// UnaryInterceptor scopes every gRPC call to the tenant named by its x-tenant metadata
+func (m *TenantModule) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return tenantGRPC.TenantInterceptor(m.tenantUseCase)
+}
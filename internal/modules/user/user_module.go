@@ -1,32 +1,264 @@
 package user
 
 import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/indexing"
 	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/adapters/shared/responses"
+	userActivity "clean-arch-gin/internal/adapters/user/activity"
 	userControllers "clean-arch-gin/internal/adapters/user/controllers"
+	userGRPC "clean-arch-gin/internal/adapters/user/grpc"
+	userJobs "clean-arch-gin/internal/adapters/user/jobs"
+	userNotifications "clean-arch-gin/internal/adapters/user/notifications"
+	userReports "clean-arch-gin/internal/adapters/user/reports"
 	userRepositories "clean-arch-gin/internal/adapters/user/repositories"
+	userTasks "clean-arch-gin/internal/adapters/user/tasks"
 	userUsecases "clean-arch-gin/internal/adapters/user/usecases"
+	userCommands "clean-arch-gin/internal/application/user/commands"
+	userQueries "clean-arch-gin/internal/application/user/queries"
+	"clean-arch-gin/internal/domain/shared/authz"
+	"clean-arch-gin/internal/domain/shared/captcha"
+	"clean-arch-gin/internal/domain/shared/events"
+	"clean-arch-gin/internal/domain/shared/mail"
+	"clean-arch-gin/internal/domain/shared/push"
+	"clean-arch-gin/internal/domain/shared/reports"
+	"clean-arch-gin/internal/domain/shared/search"
+	"clean-arch-gin/internal/domain/shared/sms"
+	"clean-arch-gin/internal/domain/shared/storage"
+	"clean-arch-gin/internal/domain/shared/templates"
+	"clean-arch-gin/internal/domain/shared/tokens"
+	userEvents "clean-arch-gin/internal/domain/user/events"
+	userDomainRepositories "clean-arch-gin/internal/domain/user/repositories"
+	userDomainUsecases "clean-arch-gin/internal/domain/user/usecases"
+	userv1 "clean-arch-gin/internal/gen/proto/user/v1"
+	"clean-arch-gin/internal/infrastructure/breaker"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/eventbus"
+	"clean-arch-gin/internal/infrastructure/fieldcrypt"
+	"clean-arch-gin/internal/infrastructure/grpcserver"
+	"clean-arch-gin/internal/infrastructure/health"
+	"clean-arch-gin/internal/infrastructure/interceptor"
+	"clean-arch-gin/internal/infrastructure/openapi"
+	"clean-arch-gin/internal/infrastructure/querycache"
+	"clean-arch-gin/internal/infrastructure/scheduler"
+	"clean-arch-gin/internal/infrastructure/taskqueue"
+	"clean-arch-gin/internal/infrastructure/workerpool"
 	"clean-arch-gin/internal/modules"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
 // UserModule encapsulates all user-related functionality
 type UserModule struct {
-	controller *userControllers.UserController
-	db         *gorm.DB
+	controller userControllers.UserHandlers
+	// userUseCase resolves the public IDs of users in routes
+	userUseCase userDomainUsecases.UserUseCase
+	// userRepo purges long soft-deleted users
+	userRepo userDomainRepositories.UserRepository
+	// importHandler and importController are nil without a database, e.g. on the in-memory repository
+	importHandler    *userCommands.ImportUsersCommandHandler
+	importController *userControllers.UserImportController
+	// exportTask and exportController are nil without a database or file storage
+	exportTask       taskqueue.Handler
+	exportController *userControllers.UserExportController
+	// preferencesController and notificationController are nil without a database
+	preferencesController  *userControllers.PreferencesController
+	notificationController *userControllers.NotificationController
+	// templateController previews the notification templates; nil without them
+	templateController *userControllers.NotificationTemplateController
+	// adminController and activityController are nil without a database
+	adminController    *userControllers.AdminUserController
+	activityController *userControllers.ActivityController
+	// connectionController serves follows and timelines; nil without a database
+	connectionController *userControllers.ConnectionController
+	// avatarController is nil without storage
+	avatarController *userControllers.AvatarController
+	// authEventController is nil without a database
+	authEventController *userControllers.AuthEventController
+	// analyticsUseCase and analyticsController are nil without a database
+	analyticsUseCase    userDomainUsecases.UserAnalyticsUseCase
+	analyticsController *userControllers.UserAnalyticsController
+	// accountController is nil without a database or a mailer
+	accountController *userControllers.AccountController
+	// feedbackController is nil without a database or feedback sources
+	feedbackController *userControllers.EmailFeedbackController
+	// smsController is nil without a database; its reply webhook is left out without reply
+	// sources
+	smsController *userControllers.SMSController
+	smsReplies    bool
+	// deviceController is nil without a database
+	deviceController *userControllers.DeviceController
+	// searchUseCase and searchController are nil without a database
+	searchUseCase    userDomainUsecases.UserSearchUseCase
+	searchController *userControllers.UserSearchController
+	// searchIndexes are kept in the search engine; none without one or a database
+	searchIndexes []search.Index
+	// digest is nil without a database, notification templates or a mailer
+	digest *userNotifications.Digest
+	// sessionUseCase and sessionController are nil without a session store
+	sessionUseCase    userDomainUsecases.SessionUseCase
+	sessionController *userControllers.SessionController
+	// unsubscribe stops the event subscriptions of the notification dispatcher, the
+	// activity recorder, the query cache and the search index
+	unsubscribe func()
+	// pools are drained on shutdown
+	pools      Pools
+	grpcServer *userGRPC.UserGRPCServer
+	auth       *middleware.AuthMiddleware
+	// throttle limits sign-in attempts; nil lets them all through
+	throttle *middleware.LoginThrottle
+	// captcha guards registration; nil requires no challenge
+	captcha captcha.Verifier
+	db      *gorm.DB
+}
+
+// AccountMail configures the password reset and email verification flows, which are left
+// out without a Mailer, and the webhooks of Feedback
+type AccountMail struct {
+	Mailer    mail.Mailer
+	Templates mail.Renderer
+	Links     userUsecases.AccountLinks
+	// Feedback reads the bounces and complaints posted to /email-feedback/<provider>, by
+	// provider name; the emails they report are marked undeliverable and no longer mailed
+	Feedback map[string]mail.FeedbackSource
+}
+
+// TextMessages configures the notifications texted to users, which are left out without a
+// Sender, and the webhooks of Replies
+type TextMessages struct {
+	Sender sms.Sender
+	// Replies reads the replies posted to /sms-replies/<provider>, by provider name; STOP
+	// opts the phone out of texts and START back in
+	Replies map[string]sms.ReplySource
+}
+
+// Pools bound the concurrency of notification delivery and exports; the module drains them
+// when it shuts down
+type Pools struct {
+	// Notifications delivers notifications outside the inbox; nil delivers them on the
+	// publisher's goroutine
+	Notifications *workerpool.Pool
+	// Exports runs exports; nil runs one per task worker taking one
+	Exports *workerpool.Pool
 }
 
 // NewUserModule creates a new user module with all dependencies
 // Now using GORM Gen for better performance and type safety
-func NewUserModule(db *gorm.DB) modules.Module {
+// Repository calls go through dbBreaker when it is not nil, domain events on bus become
+// notifications in the users' inboxes and entries of their activity feeds, avatars are kept in uploads and exports in files,
+// the private storage downloaded through signed URLs, login records tokens valid for sessionTTL in sessions, signed as
+// JWTs by signer when it is not nil, sign-in attempts are limited by throttle when it is not nil, registering
+// and password recovery require a CAPTCHA accepted by captchaVerifier when it is not nil, password reset and
+// verification links are mailed as accountMail configures, notifications are rendered from notificationTemplates,
+// without which events notify nobody, texted as textMessages configures and pushed to users' devices by pushSender
+// when it is not nil, and account management is allowed per user
+// by authorizer. Users are indexed in searchEngine and searched there when it is not nil. Profile reads are served from queryCache when it is not nil, and the user use
+// case and repository are decorated by decorators, and notifications and exports run on pools
+func NewUserModule(db *gorm.DB, bus *eventbus.Bus, uploads, files storage.Storage, sessions userDomainRepositories.SessionRepository,
+	signer tokens.Signer, throttle *middleware.LoginThrottle, captchaVerifier captcha.Verifier, accountMail AccountMail,
+	notificationTemplates templates.Engine, textMessages TextMessages, pushSender push.Sender, searchEngine search.Engine, sessionTTL time.Duration, authorizer authz.Authorizer, dbBreaker *breaker.CircuitBreaker, queryCache *querycache.Cache, decorators interceptor.Stack, pools Pools) modules.Module {
 	// Initialize user module dependencies with GORM Gen
 	userRepo := userRepositories.NewUserRepositoryGen(db) // Using GORM Gen repository
-	userUseCase := userUsecases.NewUserUseCase(userRepo)
+	if dbBreaker != nil {
+		userRepo = userRepositories.NewUserRepositoryWithBreaker(userRepo, dbBreaker)
+	}
+	unsubscribeCache := func() {}
+	if queryCache != nil {
+		// Outside the breaker, so cached profiles are still served while it is open
+		userRepo = userRepositories.NewUserRepositoryWithCache(userRepo, queryCache, userRepositories.DefaultUserCachePolicies)
+		if bus != nil {
+			unsubscribeCache = queryCache.InvalidateOn(bus, userRepositories.DefaultUserCachePolicies.Policies()...)
+		}
+	}
+	userRepo = userRepositories.NewUserRepositoryWithInterceptor(userRepo, decorators.Repositories)
+	var publisher events.EventPublisher
+	if bus != nil {
+		publisher = bus
+	}
+	// Events of a use case call running in a transaction wait for it to commit
+	userUseCase := userUsecases.NewUserUseCaseWithInterceptor(
+		userUsecases.NewUserUseCase(userRepo, database.PublishAfterCommit(publisher)), decorators.UseCases)
 	userController := userControllers.NewUserController(userUseCase)
 
+	importHandler, importController := newImport(db)
+	preferencesUseCase := newPreferences(db, userRepo, dbBreaker)
+	smsUseCase, smsController := newSMS(db, userUseCase, userRepo, publisher, dbBreaker, textMessages.Replies)
+	deviceUseCase, deviceController := newDevices(db, dbBreaker)
+	var channels []userNotifications.Channel
+	if smsUseCase != nil && textMessages.Sender != nil {
+		channels = append(channels, userNotifications.NewSMSChannel(userUseCase, smsUseCase, textMessages.Sender))
+	}
+	if deviceUseCase != nil && pushSender != nil {
+		channels = append(channels, userNotifications.NewPushChannel(deviceUseCase, pushSender))
+	}
+	dispatcher, notificationController, unsubscribeNotifications := newNotifications(db, bus, dbBreaker, preferencesUseCase, notificationTemplates, channels...)
+	digest := newDigest(db, dbBreaker, userUseCase, preferencesUseCase, notificationTemplates, accountMail.Mailer)
+	if dispatcher != nil && digest != nil {
+		dispatcher.SetDigest(digest)
+	}
+	if dispatcher != nil && pools.Notifications != nil {
+		dispatcher.SetPool(pools.Notifications)
+	}
+	activityController, unsubscribeActivity := newActivity(db, bus, dbBreaker)
+	var notifier userTasks.Notifier
+	if dispatcher != nil {
+		notifier = dispatcher
+	}
+	exportTask, exportController := newExport(db, files, notifier, pools.Exports)
+	authEventRepo, authEventController := newAuthEvents(db, dbBreaker)
+	sessionUseCase, sessionController := newSessions(userRepo, sessions, authEventRepo, publisher, signer, sessionTTL)
+	accountController, unsubscribeAccount := newAccount(db, bus, userRepo, sessions, authEventRepo, publisher, dbBreaker, accountMail)
+	searchUseCase, searchController := newSearch(db, dbBreaker, userRepo, searchEngine)
+	searchIndexes, unsubscribeSearch := newSearchIndexing(db, bus, searchEngine)
+	analyticsUseCase, analyticsController := newAnalytics(db, dbBreaker)
 	return &UserModule{
-		controller: userController,
+		controller:             userController,
+		userUseCase:            userUseCase,
+		userRepo:               userRepo,
+		importHandler:          importHandler,
+		importController:       importController,
+		exportTask:             exportTask,
+		exportController:       exportController,
+		preferencesController:  newPreferencesController(preferencesUseCase),
+		adminController:        newAdminController(db, userRepo, uploads, authorizer, dbBreaker),
+		activityController:     activityController,
+		connectionController:   newConnectionController(db, dbBreaker, userRepo),
+		notificationController: notificationController,
+		templateController:     newNotificationTemplateController(notificationTemplates),
+		avatarController:       newAvatarController(userRepo, uploads, publisher),
+		authEventController:    authEventController,
+		analyticsUseCase:       analyticsUseCase,
+		analyticsController:    analyticsController,
+		accountController:      accountController,
+		feedbackController:     newEmailFeedback(db, publisher, dbBreaker, accountMail.Feedback),
+		smsController:          smsController,
+		smsReplies:             len(textMessages.Replies) > 0,
+		deviceController:       deviceController,
+		searchUseCase:          searchUseCase,
+		searchController:       searchController,
+		searchIndexes:          searchIndexes,
+		digest:                 digest,
+		sessionUseCase:         sessionUseCase,
+		sessionController:      sessionController,
+		unsubscribe: func() {
+			unsubscribeNotifications()
+			unsubscribeActivity()
+			unsubscribeCache()
+			unsubscribeAccount()
+			unsubscribeSearch()
+		},
+		pools:      pools,
+		grpcServer: userGRPC.NewUserGRPCServer(userUseCase),
+		auth:       middleware.NewAuthMiddleware(""),
+		throttle:   throttle,
+		captcha:    captchaVerifier,
 		db:         db,
 	}
 }
@@ -36,13 +268,420 @@ func NewUserModule(db *gorm.DB) modules.Module {
 func NewUserModuleLegacy(db *gorm.DB) modules.Module {
 	// Initialize user module dependencies with traditional GORM
 	userRepo := userRepositories.NewUserRepository(db) // Traditional GORM repository
-	userUseCase := userUsecases.NewUserUseCase(userRepo)
+	userUseCase := userUsecases.NewUserUseCase(userRepo, nil)
 	userController := userControllers.NewUserController(userUseCase)
 
+	importHandler, importController := newImport(db)
+	_, notificationController, unsubscribe := newNotifications(db, nil, nil, nil, nil)
+	searchUseCase, searchController := newSearch(db, nil, nil, nil)
+	analyticsUseCase, analyticsController := newAnalytics(db, nil)
 	return &UserModule{
-		controller: userController,
-		db:         db,
+		controller:             userController,
+		userUseCase:            userUseCase,
+		userRepo:               userRepo,
+		importHandler:          importHandler,
+		importController:       importController,
+		preferencesController:  newPreferencesController(newPreferences(db, userRepo, nil)),
+		adminController:        newAdminController(db, userRepo, nil, nil, nil),
+		activityController:     newActivityController(db),
+		connectionController:   newConnectionController(db, nil, userRepo),
+		analyticsUseCase:       analyticsUseCase,
+		analyticsController:    analyticsController,
+		notificationController: notificationController,
+		searchUseCase:          searchUseCase,
+		searchController:       searchController,
+		unsubscribe:            unsubscribe,
+		grpcServer:             userGRPC.NewUserGRPCServer(userUseCase),
+		auth:                   middleware.NewAuthMiddleware(""),
+		db:                     db,
+	}
+}
+
+// NewUserModuleWithRepository creates a user module on top of any repository implementation
+// Used with the in-memory repository for load tests and database-free local runs
+func NewUserModuleWithRepository(db *gorm.DB, userRepo userDomainRepositories.UserRepository) modules.Module {
+	userUseCase := userUsecases.NewUserUseCase(userRepo, nil)
+	userController := userControllers.NewUserController(userUseCase)
+
+	importHandler, importController := newImport(db)
+	_, notificationController, unsubscribe := newNotifications(db, nil, nil, nil, nil)
+	searchUseCase, searchController := newSearch(db, nil, nil, nil)
+	analyticsUseCase, analyticsController := newAnalytics(db, nil)
+	return &UserModule{
+		controller:             userController,
+		userUseCase:            userUseCase,
+		userRepo:               userRepo,
+		importHandler:          importHandler,
+		importController:       importController,
+		preferencesController:  newPreferencesController(newPreferences(db, userRepo, nil)),
+		adminController:        newAdminController(db, userRepo, nil, nil, nil),
+		activityController:     newActivityController(db),
+		connectionController:   newConnectionController(db, nil, userRepo),
+		analyticsUseCase:       analyticsUseCase,
+		analyticsController:    analyticsController,
+		notificationController: notificationController,
+		searchUseCase:          searchUseCase,
+		searchController:       searchController,
+		unsubscribe:            unsubscribe,
+		grpcServer:             userGRPC.NewUserGRPCServer(userUseCase),
+		auth:                   middleware.NewAuthMiddleware(""),
+		db:                     db,
+	}
+}
+
+// NewUserModuleWithHandlers creates a user module registering its routes against handlers,
+// e.g. fakes in route tests, resolving the public IDs of users through userUseCase
+// The routes of the optional controllers are left out, as on a module without a database
+func NewUserModuleWithHandlers(userUseCase userDomainUsecases.UserUseCase, handlers userControllers.UserHandlers) modules.Module {
+	return &UserModule{
+		controller:  handlers,
+		userUseCase: userUseCase,
+		unsubscribe: func() {},
+		grpcServer:  userGRPC.NewUserGRPCServer(userUseCase),
+		auth:        middleware.NewAuthMiddleware(""),
+	}
+}
+
+// newImport wires the bulk import onto the database, or returns nils without one
+func newImport(db *gorm.DB) (*userCommands.ImportUsersCommandHandler, *userControllers.UserImportController) {
+	if db == nil {
+		return nil, nil
+	}
+	importHandler := userCommands.NewImportUsersCommandHandler(userRepositories.NewUserImportStore(db))
+	return importHandler, userControllers.NewUserImportController(importHandler)
+}
+
+// newPreferences wires the preferences onto the database, or returns nil without one
+func newPreferences(db *gorm.DB, userRepo userDomainRepositories.UserRepository, dbBreaker *breaker.CircuitBreaker) userDomainUsecases.PreferencesUseCase {
+	if db == nil {
+		return nil
+	}
+	preferencesRepo := userRepositories.NewPreferencesRepository(db)
+	if dbBreaker != nil {
+		preferencesRepo = userRepositories.NewPreferencesRepositoryWithBreaker(preferencesRepo, dbBreaker)
+	}
+	return userUsecases.NewPreferencesUseCase(userRepo, preferencesRepo)
+}
+
+// newPreferencesController serves preferencesUseCase, or returns nil without one
+func newPreferencesController(preferencesUseCase userDomainUsecases.PreferencesUseCase) *userControllers.PreferencesController {
+	if preferencesUseCase == nil {
+		return nil
 	}
+	return userControllers.NewPreferencesController(preferencesUseCase)
+}
+
+// newAdminController wires account management and its audit log onto the database, purged
+// users losing their avatar in uploads and actors checked by authorizer, or returns nil without a database
+func newAdminController(db *gorm.DB, userRepo userDomainRepositories.UserRepository, uploads storage.Storage,
+	authorizer authz.Authorizer, dbBreaker *breaker.CircuitBreaker) *userControllers.AdminUserController {
+	if db == nil {
+		return nil
+	}
+	auditRepo := userRepositories.NewAuditRepository(db)
+	if dbBreaker != nil {
+		auditRepo = userRepositories.NewAuditRepositoryWithBreaker(auditRepo, dbBreaker)
+	}
+	return userControllers.NewAdminUserController(userUsecases.NewAdminUserUseCase(userRepo, auditRepo, uploads, authorizer,
+		database.Transactional(db)))
+}
+
+// newAvatarController wires avatar uploads onto store, or returns nil without one
+func newAvatarController(userRepo userDomainRepositories.UserRepository, store storage.Storage, publisher events.EventPublisher) *userControllers.AvatarController {
+	if store == nil {
+		return nil
+	}
+	return userControllers.NewAvatarController(userUsecases.NewAvatarUseCase(userRepo, store, publisher))
+}
+
+// newSessions wires login and session management onto sessionRepo, recorded in authEventRepo
+// when it is not nil, or returns nils without a session store
+func newSessions(userRepo userDomainRepositories.UserRepository, sessionRepo userDomainRepositories.SessionRepository, authEventRepo userDomainRepositories.AuthEventRepository,
+	publisher events.EventPublisher, signer tokens.Signer, ttl time.Duration) (userDomainUsecases.SessionUseCase, *userControllers.SessionController) {
+	if sessionRepo == nil {
+		return nil, nil
+	}
+	sessionUseCase := userUsecases.NewSessionUseCase(userRepo, sessionRepo, authEventRepo, publisher, signer, ttl)
+	return sessionUseCase, userControllers.NewSessionController(sessionUseCase)
+}
+
+// newAccount wires password resets and email verification onto the database and the mailer
+// of accountMail, mailing users in their locale, and, with a bus, mails verification links to users who sign up or change
+// their email; without a database or a mailer there is neither
+func newAccount(db *gorm.DB, bus *eventbus.Bus, userRepo userDomainRepositories.UserRepository, sessionRepo userDomainRepositories.SessionRepository,
+	authEventRepo userDomainRepositories.AuthEventRepository, publisher events.EventPublisher, dbBreaker *breaker.CircuitBreaker,
+	accountMail AccountMail) (*userControllers.AccountController, func()) {
+	if db == nil || accountMail.Mailer == nil {
+		return nil, func() {}
+	}
+	tokenRepo := userRepositories.NewAccountTokenRepository(db)
+	if dbBreaker != nil {
+		tokenRepo = userRepositories.NewAccountTokenRepositoryWithBreaker(tokenRepo, dbBreaker)
+	}
+	preferencesRepo := userRepositories.NewPreferencesRepository(db)
+	if dbBreaker != nil {
+		preferencesRepo = userRepositories.NewPreferencesRepositoryWithBreaker(preferencesRepo, dbBreaker)
+	}
+	accountUseCase := userUsecases.NewAccountUseCase(userRepo, tokenRepo, sessionRepo, authEventRepo, preferencesRepo, publisher,
+		accountMail.Mailer, accountMail.Templates, accountMail.Links)
+
+	unsubscribe := func() {}
+	if bus != nil {
+		unsubscribe = subscribeVerification(bus, accountUseCase)
+	}
+	return userControllers.NewAccountController(accountUseCase), unsubscribe
+}
+
+// newEmailFeedback wires the webhooks of sources onto the database; without a database or
+// sources there are none
+func newEmailFeedback(db *gorm.DB, publisher events.EventPublisher, dbBreaker *breaker.CircuitBreaker,
+	sources map[string]mail.FeedbackSource) *userControllers.EmailFeedbackController {
+	if db == nil || len(sources) == 0 {
+		return nil
+	}
+	repo := userRepositories.NewDeliverabilityRepository(db)
+	if dbBreaker != nil {
+		repo = userRepositories.NewDeliverabilityRepositoryWithBreaker(repo, dbBreaker)
+	}
+	return userControllers.NewEmailFeedbackController(userUsecases.NewDeliverabilityUseCase(repo, publisher), sources)
+}
+
+// newSMS wires the phones users are texted at and the webhooks of the reply sources onto
+// the database, or returns nils without one
+func newSMS(db *gorm.DB, userUseCase userDomainUsecases.UserUseCase, userRepo userDomainRepositories.UserRepository, publisher events.EventPublisher,
+	dbBreaker *breaker.CircuitBreaker, sources map[string]sms.ReplySource) (userDomainUsecases.SMSUseCase, *userControllers.SMSController) {
+	if db == nil {
+		return nil, nil
+	}
+	consentRepo := userRepositories.NewSMSConsentRepository(db)
+	if dbBreaker != nil {
+		consentRepo = userRepositories.NewSMSConsentRepositoryWithBreaker(consentRepo, dbBreaker)
+	}
+	smsUseCase := userUsecases.NewSMSUseCase(userRepo, consentRepo, publisher)
+	return smsUseCase, userControllers.NewSMSController(userUseCase, smsUseCase, sources)
+}
+
+// newDevices wires the devices users get push notifications on onto the database, or
+// returns nils without one
+func newDevices(db *gorm.DB, dbBreaker *breaker.CircuitBreaker) (userDomainUsecases.DeviceUseCase, *userControllers.DeviceController) {
+	if db == nil {
+		return nil, nil
+	}
+	deviceRepo := userRepositories.NewDeviceRepository(db)
+	if dbBreaker != nil {
+		deviceRepo = userRepositories.NewDeviceRepositoryWithBreaker(deviceRepo, dbBreaker)
+	}
+	deviceUseCase := userUsecases.NewDeviceUseCase(deviceRepo)
+	return deviceUseCase, userControllers.NewDeviceController(deviceUseCase)
+}
+
+// newSearch wires the user search onto the database, or returns nils without one; with an
+// engine users are searched there, reading the hits from userRepo, and in the database
+// while it is unavailable
+func newSearch(db *gorm.DB, dbBreaker *breaker.CircuitBreaker, userRepo userDomainRepositories.UserRepository,
+	engine search.Engine) (userDomainUsecases.UserSearchUseCase, *userControllers.UserSearchController) {
+	if db == nil {
+		return nil, nil
+	}
+	searchRepo := userRepositories.NewUserSearchRepository(db)
+	if dbBreaker != nil {
+		searchRepo = userRepositories.NewUserSearchRepositoryWithBreaker(searchRepo, dbBreaker)
+	}
+	if engine != nil && userRepo != nil {
+		searchRepo = userRepositories.NewUserSearchRepositoryWithEngine(engine, userRepo, searchRepo)
+	}
+	searchUseCase := userUsecases.NewUserSearchUseCase(searchRepo)
+	return searchUseCase, userControllers.NewUserSearchController(searchUseCase)
+}
+
+// newSearchIndexing describes the users index and keeps it up to date in engine from the
+// domain events on bus, returning the unsubscribe function; no index without a database or
+// an engine
+func newSearchIndexing(db *gorm.DB, bus *eventbus.Bus, engine search.Engine) ([]search.Index, func()) {
+	if db == nil || engine == nil {
+		return nil, func() {}
+	}
+	index := userRepositories.NewUserSearchIndex(db)
+	if bus == nil {
+		return []search.Index{index}, func() {}
+	}
+	subscriber := indexing.NewSubscriber(engine, index)
+	subscriber.Register(userEvents.UserRegisteredEventName, func(event events.DomainEvent) []uint {
+		if registered, ok := event.(userEvents.UserRegisteredEvent); ok {
+			return []uint{registered.UserID}
+		}
+		return nil
+	})
+	subscriber.Register(userEvents.UserProfileUpdatedEventName, func(event events.DomainEvent) []uint {
+		if updated, ok := event.(userEvents.UserProfileUpdatedEvent); ok {
+			return []uint{updated.UserID}
+		}
+		return nil
+	})
+	return []search.Index{index}, subscriber.Subscribe(bus)
+}
+
+// subscribeVerification mails a verification link to users who sign up or change their
+// email and returns the unsubscribe function; failures are logged, the change is saved
+func subscribeVerification(bus *eventbus.Bus, accountUseCase userDomainUsecases.AccountUseCase) func() {
+	send := func(ctx context.Context, userID uint) {
+		if err := accountUseCase.SendVerification(ctx, userID); err != nil {
+			log.Printf("failed to mail a verification link to user %d: %v", userID, err)
+		}
+	}
+	unsubscribeRegistered := bus.Subscribe(userEvents.UserRegisteredEventName, func(ctx context.Context, event events.DomainEvent) {
+		if registered, ok := event.(userEvents.UserRegisteredEvent); ok {
+			send(ctx, registered.UserID)
+		}
+	})
+	unsubscribeUpdated := bus.Subscribe(userEvents.UserProfileUpdatedEventName, func(ctx context.Context, event events.DomainEvent) {
+		updated, ok := event.(userEvents.UserProfileUpdatedEvent)
+		if !ok {
+			return
+		}
+		for _, field := range updated.Fields {
+			if field == "email" {
+				send(ctx, updated.UserID)
+				return
+			}
+		}
+	})
+	return func() {
+		unsubscribeRegistered()
+		unsubscribeUpdated()
+	}
+}
+
+// newAnalytics wires the admin analytics onto the database, or returns nils without one
+func newAnalytics(db *gorm.DB, dbBreaker *breaker.CircuitBreaker) (userDomainUsecases.UserAnalyticsUseCase, *userControllers.UserAnalyticsController) {
+	if db == nil {
+		return nil, nil
+	}
+	analyticsRepo := userRepositories.NewUserAnalyticsRepository(db)
+	if dbBreaker != nil {
+		analyticsRepo = userRepositories.NewUserAnalyticsRepositoryWithBreaker(analyticsRepo, dbBreaker)
+	}
+	analyticsUseCase := userUsecases.NewUserAnalyticsUseCase(analyticsRepo)
+	return analyticsUseCase, userControllers.NewUserAnalyticsController(analyticsUseCase)
+}
+
+// newAuthEvents wires the authentication audit trail onto the database, or returns nils
+// without one
+func newAuthEvents(db *gorm.DB, dbBreaker *breaker.CircuitBreaker) (userDomainRepositories.AuthEventRepository, *userControllers.AuthEventController) {
+	if db == nil {
+		return nil, nil
+	}
+	authEventRepo := userRepositories.NewAuthEventRepository(db)
+	if dbBreaker != nil {
+		authEventRepo = userRepositories.NewAuthEventRepositoryWithBreaker(authEventRepo, dbBreaker)
+	}
+	return authEventRepo, userControllers.NewAuthEventController(userUsecases.NewAuthEventUseCase(authEventRepo))
+}
+
+// newNotifications wires the notification inbox onto the database and, with templates, the
+// dispatcher rendering notifications into it and delivering them on channels, subscribed to
+// the domain events on bus when there is one; without a database there is neither
+func newNotifications(db *gorm.DB, bus *eventbus.Bus, dbBreaker *breaker.CircuitBreaker,
+	preferences userDomainUsecases.PreferencesUseCase, engine templates.Engine, channels ...userNotifications.Channel) (*userNotifications.Dispatcher, *userControllers.NotificationController, func()) {
+	if db == nil {
+		return nil, nil, func() {}
+	}
+	notificationRepo := userRepositories.NewNotificationRepository(db)
+	if dbBreaker != nil {
+		notificationRepo = userRepositories.NewNotificationRepositoryWithBreaker(notificationRepo, dbBreaker)
+	}
+	notificationUseCase := userUsecases.NewNotificationUseCase(notificationRepo)
+	notificationController := userControllers.NewNotificationController(notificationUseCase)
+	if engine == nil {
+		return nil, notificationController, func() {}
+	}
+
+	dispatcher := userNotifications.NewDispatcher(notificationUseCase, preferences, engine, channels...)
+	unsubscribe := func() {}
+	if bus != nil {
+		unsubscribe = dispatcher.Subscribe(bus)
+	}
+	return dispatcher, notificationController, unsubscribe
+}
+
+// newDigest wires the digests of low-priority notifications onto the database, mailed with
+// mailer; nil without a database, templates or a mailer
+func newDigest(db *gorm.DB, dbBreaker *breaker.CircuitBreaker, users userDomainUsecases.UserUseCase,
+	preferences userDomainUsecases.PreferencesUseCase, engine templates.Engine, mailer mail.Mailer) *userNotifications.Digest {
+	if db == nil || engine == nil || mailer == nil {
+		return nil
+	}
+	digestRepo := userRepositories.NewDigestRepository(db)
+	if dbBreaker != nil {
+		digestRepo = userRepositories.NewDigestRepositoryWithBreaker(digestRepo, dbBreaker)
+	}
+	return userNotifications.NewDigest(digestRepo, users, preferences, engine, mailer)
+}
+
+// newNotificationTemplateController previews the notification templates, or returns nil
+// without them
+func newNotificationTemplateController(engine templates.Engine) *userControllers.NotificationTemplateController {
+	if engine == nil {
+		return nil
+	}
+	return userControllers.NewNotificationTemplateController(engine)
+}
+
+// newActivity wires the activity feeds onto the database and, with a bus, the recorder
+// filling them from domain events; without a database there is neither
+func newActivity(db *gorm.DB, bus *eventbus.Bus, dbBreaker *breaker.CircuitBreaker) (*userControllers.ActivityController, func()) {
+	if db == nil {
+		return nil, func() {}
+	}
+	activityRepo := userRepositories.NewActivityRepository(db)
+	if dbBreaker != nil {
+		activityRepo = userRepositories.NewActivityRepositoryWithBreaker(activityRepo, dbBreaker)
+	}
+	activityUseCase := userUsecases.NewActivityUseCase(activityRepo)
+
+	unsubscribe := func() {}
+	if bus != nil {
+		unsubscribe = userActivity.NewRecorder(activityUseCase).Subscribe(bus)
+	}
+	return userControllers.NewActivityController(activityUseCase), unsubscribe
+}
+
+// newActivityController serves the activity feeds without recording, or returns nil without
+// a database
+func newActivityController(db *gorm.DB) *userControllers.ActivityController {
+	activityController, _ := newActivity(db, nil, nil)
+	return activityController
+}
+
+// timelineActivityTypes are the activities shared with followers; sign-ins and orders stay
+// in their user's own feed
+var timelineActivityTypes = []string{userEvents.UserProfileUpdatedEventName}
+
+// newConnectionController wires follows and timelines onto the database, or returns nil
+// without one
+func newConnectionController(db *gorm.DB, dbBreaker *breaker.CircuitBreaker, userRepo userDomainRepositories.UserRepository) *userControllers.ConnectionController {
+	if db == nil {
+		return nil
+	}
+	connectionRepo := userRepositories.NewConnectionRepository(db)
+	activityRepo := userRepositories.NewActivityRepository(db)
+	if dbBreaker != nil {
+		connectionRepo = userRepositories.NewConnectionRepositoryWithBreaker(connectionRepo, dbBreaker)
+		activityRepo = userRepositories.NewActivityRepositoryWithBreaker(activityRepo, dbBreaker)
+	}
+	connectionUseCase := userUsecases.NewConnectionUseCase(connectionRepo, activityRepo, userRepo, timelineActivityTypes)
+	return userControllers.NewConnectionController(connectionUseCase)
+}
+
+// newExport wires the bulk export onto the database and files, notifying requesters through
+// notifier when it is not nil and running on pool when it is not nil, or returns nils without
+// a database or file storage
+func newExport(db *gorm.DB, files storage.Storage, notifier userTasks.Notifier, pool *workerpool.Pool) (taskqueue.Handler, *userControllers.UserExportController) {
+	if db == nil || files == nil {
+		return nil, nil
+	}
+	exportHandler := userQueries.NewExportUsersQueryHandler(userRepositories.NewUserExportStore(db))
+	return userTasks.NewExportHandler(exportHandler, files, notifier, pool), userControllers.NewUserExportController()
 }
 
 // Name returns the module name
@@ -51,23 +690,837 @@ func (m *UserModule) Name() string {
 }
 
 // RegisterRoutes registers all user-related routes
+// Users are addressed by public ID, resolved to the internal one ahead of the controllers
 func (m *UserModule) RegisterRoutes(rg *gin.RouterGroup) {
-	// Basic CRUD routes
-	rg.POST("", m.controller.CreateUser)       // POST /api/v1/users
-	rg.GET("/:id", m.controller.GetUser)       // GET /api/v1/users/:id
-	rg.GET("", m.controller.GetUsers)          // GET /api/v1/users
-	rg.PUT("/:id", m.controller.UpdateUser)    // PUT /api/v1/users/:id
-	rg.DELETE("/:id", m.controller.DeleteUser) // DELETE /api/v1/users/:id
+	userID := middleware.ResolvePublicID("id", m.userID)
+
+	// Basic CRUD routes; users change and delete their own account, admins any
+	rg.POST("", middleware.RequireCaptcha(m.captcha), m.controller.CreateUser) // POST /api/v1/users
+	rg.GET("/:id", userID, m.controller.GetUser)                               // GET /api/v1/users/:id
+	rg.GET("", m.controller.GetUsers)                                          // GET /api/v1/users
+	owned := rg.Group("/:id", m.auth.RequireAuth(), m.controller.RequireActiveAccount(), userID)
+	owned.PUT("", m.controller.UpdateUser)    // PUT /api/v1/users/:id
+	owned.DELETE("", m.controller.DeleteUser) // DELETE /api/v1/users/:id
+
+	// Sign-in; the token of a session is revoked by logging out
+	if m.sessionController != nil {
+		rg.POST("/auth/login", m.throttle.Limit("login"), m.sessionController.Login) // POST /api/v1/users/auth/login
+		rg.POST("/auth/logout", m.auth.RequireAuth(), m.sessionController.Logout)    // POST /api/v1/users/auth/logout
+	}
+
+	// Password recovery and email verification through mailed links
+	if m.accountController != nil {
+		rg.POST("/password-reset", middleware.RequireCaptcha(m.captcha), m.throttle.Limit("password-reset"),
+			m.accountController.RequestPasswordReset) // POST /api/v1/users/password-reset
+		rg.POST("/password-reset/confirm", m.accountController.ResetPassword) // POST /api/v1/users/password-reset/confirm
+		rg.POST("/verify-email", m.accountController.VerifyEmail)             // POST /api/v1/users/verify-email
+	}
+
+	// Bounce and complaint webhooks of mail providers, authenticated by their signatures
+	if m.feedbackController != nil {
+		rg.POST("/email-feedback/:provider", m.feedbackController.Receive) // POST /api/v1/users/email-feedback/:provider
+	}
+
+	// Replies to texts, authenticated by the providers' signatures
+	if m.smsController != nil && m.smsReplies {
+		rg.POST("/sms-replies/:provider", m.smsController.ReceiveReplies) // POST /api/v1/users/sms-replies/:provider
+	}
+
+	// Current user routes; the user comes from the auth context, never from the path, and
+	// suspended accounts are turned away
+	me := rg.Group("/me", m.auth.RequireAuth(), m.controller.RequireActiveAccount())
+	me.GET("", m.controller.GetCurrentUser)            // GET /api/v1/users/me
+	me.PUT("", m.controller.UpdateCurrentUser)         // PUT /api/v1/users/me
+	me.GET("/profile", m.controller.GetCurrentUser)    // GET /api/v1/users/me/profile
+	me.PUT("/profile", m.controller.UpdateCurrentUser) // PUT /api/v1/users/me/profile
+	if m.preferencesController != nil {
+		me.GET("/preferences", m.preferencesController.GetPreferences)    // GET /api/v1/users/me/preferences
+		me.PUT("/preferences", m.preferencesController.UpdatePreferences) // PUT /api/v1/users/me/preferences
+	}
+	if m.smsController != nil {
+		me.GET("/phone", m.smsController.GetPhone) // GET /api/v1/users/me/phone
+		me.PUT("/phone", m.smsController.SetPhone) // PUT /api/v1/users/me/phone
+	}
+	if m.deviceController != nil {
+		me.GET("/devices", m.deviceController.ListDevices)         // GET /api/v1/users/me/devices
+		me.POST("/devices", m.deviceController.RegisterDevice)     // POST /api/v1/users/me/devices
+		me.DELETE("/devices/:id", m.deviceController.RemoveDevice) // DELETE /api/v1/users/me/devices/:id
+	}
+	if m.avatarController != nil {
+		me.PUT("/avatar", m.avatarController.UploadAvatar)         // PUT /api/v1/users/me/avatar
+		me.PUT("/profile/avatar", m.avatarController.UploadAvatar) // PUT /api/v1/users/me/profile/avatar
+	}
+	if m.activityController != nil {
+		me.GET("/activity", m.activityController.GetOwnActivity) // GET /api/v1/users/me/activity
+	}
+	if m.connectionController != nil {
+		me.GET("/timeline", m.connectionController.GetOwnTimeline) // GET /api/v1/users/me/timeline
+	}
+	if m.accountController != nil {
+		me.POST("/verification", m.accountController.SendVerification) // POST /api/v1/users/me/verification
+	}
+	if m.sessionController != nil {
+		me.GET("/sessions", m.sessionController.ListSessions)         // GET /api/v1/users/me/sessions
+		me.DELETE("/sessions", m.sessionController.RevokeAllSessions) // DELETE /api/v1/users/me/sessions?keep_current=true
+		me.DELETE("/sessions/:id", m.sessionController.RevokeSession) // DELETE /api/v1/users/me/sessions/:id
+	}
+	if m.authEventController != nil {
+		me.GET("/auth-events", m.authEventController.GetOwnAuthEvents) // GET /api/v1/users/me/auth-events
+	}
+	if m.notificationController != nil {
+		notifications := me.Group("/notifications")
+		notifications.GET("", m.notificationController.GetNotifications)            // GET /api/v1/users/me/notifications
+		notifications.GET("/unread-count", m.notificationController.GetUnreadCount) // GET /api/v1/users/me/notifications/unread-count
+		notifications.PUT("/read-all", m.notificationController.MarkAllAsRead)      // PUT /api/v1/users/me/notifications/read-all
+		notifications.PUT("/read", m.notificationController.MarkAllAsRead)          // PUT /api/v1/users/me/notifications/read, kept for older clients
+		notifications.PUT("/:id/read", m.notificationController.MarkAsRead)         // PUT /api/v1/users/me/notifications/:id/read
+		notifications.DELETE("/:id", m.notificationController.DeleteNotification)   // DELETE /api/v1/users/me/notifications/:id
+	}
+
+	// Follows between users and their timelines; followers act as themselves, so suspended
+	// accounts are turned away
+	if m.connectionController != nil {
+		connections := rg.Group("/:id", m.auth.RequireAuth(), m.controller.RequireActiveAccount(), userID)
+		connections.GET("/follow", m.connectionController.GetFollowStatus)      // GET /api/v1/users/:id/follow
+		connections.POST("/follow", m.connectionController.Follow)              // POST /api/v1/users/:id/follow
+		connections.DELETE("/follow", m.connectionController.Unfollow)          // DELETE /api/v1/users/:id/follow
+		connections.GET("/connections", m.connectionController.ListConnections) // GET /api/v1/users/:id/connections?type=followers|following
+		connections.GET("/timeline", m.connectionController.GetUserTimeline)    // GET /api/v1/users/:id/timeline
+	}
 
 	// GORM Gen specific routes (advanced queries)
 	rg.GET("/domain/:domain", m.getUsersByDomain) // GET /api/v1/users/domain/example.com
 	rg.GET("/active", m.getActiveUsers)           // GET /api/v1/users/active
-	rg.GET("/search", m.searchUsers)              // GET /api/v1/users/search?email=&name=
+
+	// Full-text search over names and emails
+	if m.searchController != nil {
+		rg.GET("/search", m.auth.RequireAuth(), m.searchController.SearchUsers) // GET /api/v1/users/search?q=
+	}
+}
+
+// RegisterAdminRoutes registers the admin-only user routes
+func (m *UserModule) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	// Account management; every change is audited
+	if m.adminController != nil {
+		// Every :id of the group names a user, deleted ones included so they can be restored
+		admin := rg.Group("/admin", m.auth.RequireAuth(), m.auth.RequirePermission("users", "manage"),
+			middleware.ResolvePublicID("id", m.userID))
+		admin.PUT("/:id", m.adminController.UpdateUser)                         // PUT /api/v1/users/admin/:id
+		admin.DELETE("/:id", m.adminController.DeleteUser)                      // DELETE /api/v1/users/admin/:id
+		admin.POST("/:id/restore", m.adminController.RestoreUser)               // POST /api/v1/users/admin/:id/restore
+		admin.DELETE("/:id/purge", m.adminController.PurgeUser)                 // DELETE /api/v1/users/admin/:id/purge
+		admin.PUT("/:id/status", m.adminController.UpdateStatus)                // PUT /api/v1/users/admin/:id/status
+		admin.PUT("/:id/role", m.adminController.UpdateRole)                    // PUT /api/v1/users/admin/:id/role
+		admin.POST("/:id/password-reset", m.adminController.ForcePasswordReset) // POST /api/v1/users/admin/:id/password-reset
+		admin.GET("/:id/audit", m.adminController.GetAuditLog)                  // GET /api/v1/users/admin/:id/audit
+		if m.activityController != nil {
+			admin.GET("/:id/activity", m.activityController.GetUserActivity) // GET /api/v1/users/admin/:id/activity
+		}
+		if m.authEventController != nil {
+			admin.GET("/auth-events", m.authEventController.GetAuthEvents) // GET /api/v1/users/admin/auth-events?user_id=
+		}
+	}
+
+	// Analytics of signups, sign-ins and retention
+	if m.analyticsController != nil {
+		analytics := rg.Group("/analytics", m.auth.RequireAuth(), m.auth.RequirePermission("users", "analytics"))
+		analytics.GET("/stats", m.analyticsController.GetStats)       // GET /api/v1/users/analytics/stats?from=&to=
+		analytics.GET("/activity", m.analyticsController.GetActivity) // GET /api/v1/users/analytics/activity?from=&to=
+		analytics.GET("/reports", m.analyticsController.GetReports)   // GET /api/v1/users/analytics/reports?from=&to=&weeks=
+	}
+
+	// Bulk operations
+	if m.importController != nil || m.exportController != nil {
+		bulk := rg.Group("/bulk", m.auth.RequireAuth(), m.auth.RequirePermission("users", "bulk"))
+		if m.importController != nil {
+			bulk.POST("/import", m.importController.ImportUsers) // POST /api/v1/users/bulk/import
+		}
+		if m.exportController != nil {
+			bulk.POST("/export", m.exportController.ExportUsers) // POST /api/v1/users/bulk/export
+		}
+	}
+
+	// Notification templates, rendered without sending anything
+	if m.templateController != nil {
+		notificationTemplates := rg.Group("/notifications/templates", m.auth.RequireAuth(), m.auth.RequirePermission("notifications", "preview"))
+		notificationTemplates.GET("", m.templateController.ListTemplates)                  // GET /api/v1/users/notifications/templates
+		notificationTemplates.POST("/:name/preview", m.templateController.PreviewTemplate) // POST /api/v1/users/notifications/templates/:name/preview
+	}
+}
+
+// userID resolves the public ID of a user in a route to its internal ID
+func (m *UserModule) userID(ctx context.Context, publicID string) (uint, error) {
+	user, err := m.userUseCase.GetUserByPublicID(ctx, publicID)
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+// APIRoutes documents the routes registered by RegisterRoutes
+func (m *UserModule) APIRoutes() []openapi.Route {
+	errorResponse := openapi.ErrorResponse{}
+	pagination := []openapi.Parameter{
+		openapi.QueryParam("limit", "integer", "Page size (default 10, max 100)"),
+		openapi.QueryParam("offset", "integer", "Number of users to skip"),
+	}
+	authEventFilters := []openapi.Parameter{
+		openapi.QueryParam("type", "string", "Only events of this type, e.g. login_failed"),
+		openapi.QueryParam("since", "string", "Only events at or after this RFC 3339 time"),
+	}
+
+	analyticsRange := []openapi.Parameter{
+		openapi.QueryParam("from", "string", "First day, YYYY-MM-DD (default 30 days before to)"),
+		openapi.QueryParam("to", "string", "Last day, YYYY-MM-DD (default today, UTC)"),
+	}
+
+	routes := []openapi.Route{
+		{
+			Method: "POST", Path: "", Summary: "Create a user",
+			Query: []openapi.Parameter{
+				openapi.HeaderParam(middleware.CaptchaHeader, "Response token of the solved CAPTCHA, when one is configured", false),
+			},
+			Request: userControllers.CreateUserRequest{},
+			Responses: map[int]interface{}{
+				201: userControllers.UserDTO{}, 400: errorResponse, 409: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/:id", Summary: "Get a user by ID",
+			Responses: map[int]interface{}{
+				200: userControllers.UserDTO{}, 400: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "", Summary: "List users", Query: pagination,
+			Responses: map[int]interface{}{
+				200: userControllers.UserListResponse{}, 400: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/:id", Auth: true, Summary: "Update your own user, or any with the users manage permission",
+			Request: userControllers.UpdateUserRequest{},
+			Responses: map[int]interface{}{
+				200: userControllers.UserDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse, 409: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "DELETE", Path: "/:id", Auth: true, Summary: "Soft delete your own user, or any with the users manage permission",
+			Responses: map[int]interface{}{
+				204: nil, 400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/auth/login",
+			Summary: "Sign in with email and password, starting a session; the bearer token is only returned here",
+			Request: userControllers.LoginRequest{},
+			Responses: map[int]interface{}{
+				201: userControllers.LoginResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 429: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/auth/logout", Auth: true, Summary: "Sign out, revoking the session the request is made with",
+			Responses: map[int]interface{}{
+				204: nil, 400: errorResponse, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/password-reset",
+			Summary: "Mail a password reset link; the response is the same whether or not the email belongs to an account",
+			Query: []openapi.Parameter{
+				openapi.HeaderParam(middleware.CaptchaHeader, "Response token of the solved CAPTCHA, when one is configured", false),
+			},
+			Request: userControllers.PasswordResetRequest{},
+			Responses: map[int]interface{}{
+				202: nil, 400: errorResponse, 429: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/password-reset/confirm",
+			Summary: "Set a new password with the token of a reset link, signing the user out everywhere",
+			Request: userControllers.ResetPasswordRequest{},
+			Responses: map[int]interface{}{
+				204: nil, 400: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/verify-email", Summary: "Confirm an email with the token of a verification link",
+			Request: userControllers.VerifyEmailRequest{},
+			Responses: map[int]interface{}{
+				200: userControllers.UserDTO{}, 400: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/email-feedback/:provider",
+			Summary: "Mail provider webhook (sendgrid or ses) reporting bounces and complaints; their emails are marked undeliverable",
+			Responses: map[int]interface{}{
+				204: nil, 401: errorResponse, 404: errorResponse, 413: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/sms-replies/:provider",
+			Summary: "SMS provider webhook (twilio) receiving replies to texts; STOP opts the phone out of texts and START back in",
+			Responses: map[int]interface{}{
+				200: nil, 401: errorResponse, 404: errorResponse, 413: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/me", Auth: true, Summary: "Get the authenticated user",
+			Responses: map[int]interface{}{
+				200: userControllers.UserDTO{}, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/me", Auth: true, Summary: "Update the authenticated user",
+			Request: userControllers.UpdateUserRequest{},
+			Responses: map[int]interface{}{
+				200: userControllers.UserDTO{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 409: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/me/profile", Auth: true, Summary: "Get the authenticated user's profile",
+			Responses: map[int]interface{}{
+				200: userControllers.UserDTO{}, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/me/profile", Auth: true, Summary: "Update the authenticated user's profile",
+			Request: userControllers.UpdateUserRequest{},
+			Responses: map[int]interface{}{
+				200: userControllers.UserDTO{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 409: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/me/avatar", Auth: true,
+			Summary: "Upload the authenticated user's avatar as the multipart \"avatar\" field (JPEG, PNG or GIF, max 5 MB)",
+			Responses: map[int]interface{}{
+				200: userControllers.UserDTO{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 413: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/me/profile/avatar", Auth: true, Summary: "Upload the authenticated user's avatar",
+			Responses: map[int]interface{}{
+				200: userControllers.UserDTO{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 413: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/me/phone", Auth: true, Summary: "Get the phone the authenticated user is texted at",
+			Responses: map[int]interface{}{
+				200: userControllers.PhoneDTO{}, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/me/phone", Auth: true,
+			Summary: "Set the phone to text, in international format (normalized to E.164); an empty phone removes it",
+			Request: userControllers.SetPhoneRequest{},
+			Responses: map[int]interface{}{
+				200: userControllers.PhoneDTO{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/me/devices", Auth: true, Summary: "List the devices the authenticated user gets push notifications on",
+			Responses: map[int]interface{}{
+				200: userControllers.DeviceListResponse{}, 401: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/me/devices", Auth: true,
+			Summary: "Register the push token of an app install (android, ios or web); tokens are registered again on every app start",
+			Request: userControllers.RegisterDeviceRequest{},
+			Responses: map[int]interface{}{
+				201: userControllers.DeviceDTO{}, 400: errorResponse, 401: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "DELETE", Path: "/me/devices/:id", Auth: true, Summary: "Stop push notifications to one of the authenticated user's devices",
+			Responses: map[int]interface{}{
+				204: nil, 400: errorResponse, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/me/preferences", Auth: true,
+			Summary: "Get the authenticated user's preferences, or the defaults if none were saved",
+			Responses: map[int]interface{}{
+				200: userControllers.PreferencesDTO{}, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/me/preferences", Auth: true,
+			Summary: "Update preferences: locale, timezone, notification opt-ins and namespaced custom keys (null removes one)",
+			Request: userControllers.UpdatePreferencesRequest{},
+			Responses: map[int]interface{}{
+				200: userControllers.PreferencesDTO{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/me/activity", Auth: true,
+			Summary: "List the authenticated user's sign-ins, profile changes and order actions, most recent first; IP addresses are reduced to their network",
+			Query:   pagination,
+			Responses: map[int]interface{}{
+				200: userControllers.OwnActivityListResponse{}, 400: errorResponse, 401: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/me/sessions", Auth: true,
+			Summary: "List the authenticated user's signed-in devices, flagging the current one; IP addresses are reduced to their network",
+			Responses: map[int]interface{}{
+				200: userControllers.SessionListResponse{}, 401: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "DELETE", Path: "/me/sessions", Auth: true,
+			Summary: "Sign out all of the authenticated user's sessions",
+			Query: []openapi.Parameter{
+				openapi.QueryParam("keep_current", "boolean", "Keep the session the request is made with (default false)"),
+			},
+			Responses: map[int]interface{}{
+				200: userControllers.RevokeSessionsResponse{}, 400: errorResponse, 401: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "DELETE", Path: "/me/sessions/:id", Auth: true, Summary: "Sign out one of the authenticated user's sessions",
+			Responses: map[int]interface{}{
+				204: nil, 400: errorResponse, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/me/verification", Auth: true,
+			Summary: "Mail the authenticated user a link confirming their email; nothing is mailed once it is verified",
+			Responses: map[int]interface{}{
+				202: nil, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/me/auth-events", Auth: true,
+			Summary: "List the authenticated user's recent sign-ins, failed or not, and sign-outs, most recent first; IP addresses are reduced to their network",
+			Query:   append(authEventFilters, pagination...),
+			Responses: map[int]interface{}{
+				200: userControllers.OwnAuthEventListResponse{}, 400: errorResponse, 401: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/me/notifications", Auth: true,
+			Summary: "List the authenticated user's notifications, newest first, with total and unread counts",
+			Query: []openapi.Parameter{
+				openapi.QueryParam("unread", "boolean", "Only unread notifications"),
+				openapi.QueryParam("limit", "integer", "Page size (default 10, max 100)"),
+				openapi.QueryParam("cursor", "string", "next_cursor of the previous page"),
+			},
+			Responses: map[int]interface{}{
+				200: userControllers.NotificationListResponse{}, 400: errorResponse, 401: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/me/notifications/unread-count", Auth: true, Summary: "Count unread notifications",
+			Responses: map[int]interface{}{200: userControllers.UnreadCountResponse{}, 401: errorResponse, 500: errorResponse},
+		},
+		{
+			Method: "PUT", Path: "/me/notifications/read-all", Auth: true, Summary: "Mark all notifications as read",
+			Responses: map[int]interface{}{200: userControllers.MarkAllReadResponse{}, 401: errorResponse, 500: errorResponse},
+		},
+		{
+			Method: "PUT", Path: "/me/notifications/read", Auth: true, Summary: "Mark all notifications as read (alias of read-all)",
+			Responses: map[int]interface{}{200: userControllers.MarkAllReadResponse{}, 401: errorResponse, 500: errorResponse},
+		},
+		{
+			Method: "PUT", Path: "/me/notifications/:id/read", Auth: true, Summary: "Mark a notification as read",
+			Responses: map[int]interface{}{
+				200: userControllers.NotificationDTO{}, 400: errorResponse, 401: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "DELETE", Path: "/me/notifications/:id", Auth: true, Summary: "Delete a notification",
+			Responses: map[int]interface{}{204: nil, 400: errorResponse, 401: errorResponse, 404: errorResponse, 500: errorResponse},
+		},
+		{
+			Method: "PUT", Path: "/admin/:id", Auth: true, Summary: "Admin: update a user's name or email (audited)",
+			Request: userControllers.UpdateUserRequest{},
+			Responses: map[int]interface{}{
+				200: userControllers.AdminUserDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+				404: errorResponse, 409: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "DELETE", Path: "/admin/:id", Auth: true, Summary: "Admin: soft delete a user (audited)",
+			Request: userControllers.AdminActionRequest{},
+			Responses: map[int]interface{}{
+				204: nil, 400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/admin/:id/restore", Auth: true, Summary: "Admin: restore a soft-deleted user (audited)",
+			Request: userControllers.AdminActionRequest{},
+			Responses: map[int]interface{}{
+				200: userControllers.AdminUserDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+				404: errorResponse, 409: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "DELETE", Path: "/admin/:id/purge", Auth: true,
+			Summary: "Admin: permanently delete a user, deleted or not, with its orders, notifications, preferences, activity, connections, sessions, auth events and avatar; the audit log is kept",
+			Request: userControllers.AdminActionRequest{},
+			Responses: map[int]interface{}{
+				204: nil, 400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/admin/:id/status", Auth: true, Summary: "Admin: suspend or reactivate an account (audited)",
+			Request: userControllers.UpdateStatusRequest{},
+			Responses: map[int]interface{}{
+				200: userControllers.AdminUserDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+				404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "PUT", Path: "/admin/:id/role", Auth: true, Summary: "Admin: assign a role (audited)",
+			Request: userControllers.UpdateRoleRequest{},
+			Responses: map[int]interface{}{
+				200: userControllers.AdminUserDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+				404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/admin/:id/password-reset", Auth: true,
+			Summary: "Admin: require the user to change their password (audited)",
+			Request: userControllers.AdminActionRequest{},
+			Responses: map[int]interface{}{
+				200: userControllers.AdminUserDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+				404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/admin/:id/audit", Auth: true, Summary: "Admin: list the audited actions on a user, newest first",
+			Query: pagination,
+			Responses: map[int]interface{}{
+				200: userControllers.AuditLogResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/admin/:id/activity", Auth: true,
+			Summary: "Admin: list a user's activity, most recent first, with IP addresses and user agents",
+			Query:   pagination,
+			Responses: map[int]interface{}{
+				200: userControllers.ActivityListResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/admin/auth-events", Auth: true,
+			Summary: "Admin: list sign-ins, failed or not, and sign-outs, most recent first, with IP addresses and user agents",
+			Query: append(append([]openapi.Parameter{
+				openapi.QueryParam("user_id", "integer", "Only the events of this user"),
+			}, authEventFilters...), pagination...),
+			Responses: map[int]interface{}{
+				200: userControllers.AuthEventListResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/analytics/stats", Auth: true,
+			Summary: "Admin: count users, live and deleted, by status and role, with the signups and deletions of every day",
+			Query:   analyticsRange,
+			Responses: map[int]interface{}{
+				200: userControllers.UserStatsResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/analytics/activity", Auth: true,
+			Summary: "Admin: count the users signing in, their sign-ins and the failed ones on every day",
+			Query:   analyticsRange,
+			Responses: map[int]interface{}{
+				200: userControllers.UserActivityResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/analytics/reports", Auth: true,
+			Summary: "Admin: weekly retention cohorts of the users who signed up in the range, by sign-ins in each week since",
+			Query: append([]openapi.Parameter{
+				openapi.QueryParam("weeks", "integer", "Weeks to follow each cohort for (default 8, max 12)"),
+			}, analyticsRange...),
+			Responses: map[int]interface{}{
+				200: userControllers.UserReportResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+		{Method: "GET", Path: "/domain/:domain", Summary: "List users by email domain"},
+		{Method: "GET", Path: "/active", Summary: "List active users"},
+		{
+			Method: "GET", Path: "/search", Auth: true,
+			Summary: "Search users by the words of their name and email, most relevant first, with the matches highlighted",
+			Query: append([]openapi.Parameter{
+				openapi.QueryParam("q", "string", "Words users must have a word starting with, e.g. ali smi"),
+			}, pagination...),
+			Responses: map[int]interface{}{
+				200: userControllers.UserSearchResponse{}, 400: errorResponse, 401: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/:id/follow", Auth: true, Summary: "Tell whether the authenticated user follows a user",
+			Responses: map[int]interface{}{
+				200: userControllers.FollowStatusResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+				404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/:id/follow", Auth: true,
+			Summary: "Follow a user, whose shared activity then shows in the authenticated user's timeline; following again does nothing",
+			Responses: map[int]interface{}{
+				204: nil, 400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "DELETE", Path: "/:id/follow", Auth: true, Summary: "Stop following a user",
+			Responses: map[int]interface{}{
+				204: nil, 400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/:id/connections", Auth: true,
+			Summary: "List the users following a user, or the users it follows, most recently connected first, with both counts",
+			Query: append([]openapi.Parameter{
+				openapi.QueryParam("type", "string", "followers (default) or following"),
+			}, pagination...),
+			Responses: map[int]interface{}{
+				200: userControllers.ConnectionListResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+				404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/:id/timeline", Auth: true,
+			Summary: "List the profile changes of the users a user follows, most recent first",
+			Query:   pagination,
+			Responses: map[int]interface{}{
+				200: userControllers.TimelineResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+				404: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/me/timeline", Auth: true,
+			Summary: "List the profile changes of the users the authenticated user follows, most recent first",
+			Query:   pagination,
+			Responses: map[int]interface{}{
+				200: userControllers.TimelineResponse{}, 400: errorResponse, 401: errorResponse, 403: errorResponse, 500: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/bulk/import", Auth: true,
+			Summary: "Import users from a multipart CSV or XLSX upload (admin); the \"file\" field needs email, name and password columns",
+			Query: []openapi.Parameter{
+				openapi.QueryParam("batch_size", "integer", "Users inserted per transaction (default 500, max 5000)"),
+				openapi.QueryParam("async", "boolean", "Queue the import and return a job ID to poll at /api/v1/jobs/{id}"),
+			},
+			Responses: map[int]interface{}{
+				200: userCommands.ImportUsersResult{}, 202: responses.JobAcceptedResponse{},
+				400: errorResponse, 401: errorResponse, 403: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/bulk/export", Auth: true,
+			Summary: "Queue an export of the users matching the filters as CSV, JSON or XLSX (admin); the requester is notified with a signed download URL, also the job's result",
+			Request: userControllers.ExportUsersRequest{},
+			Responses: map[int]interface{}{
+				202: responses.JobAcceptedResponse{},
+				400: errorResponse, 401: errorResponse, 403: errorResponse, 503: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/notifications/templates", Auth: true,
+			Summary: "List the notification templates with their variables, channels and example (admin)",
+			Responses: map[int]interface{}{
+				200: userControllers.NotificationTemplateListResponse{},
+				401: errorResponse, 403: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/notifications/templates/:name/preview", Auth: true,
+			Summary: "Render a notification template for one or every channel without sending it (admin); the variables default to the template's example and the locale to en",
+			Request: userControllers.PreviewTemplateRequest{},
+			Responses: map[int]interface{}{
+				200: userControllers.PreviewTemplateResponse{},
+				400: errorResponse, 401: errorResponse, 403: errorResponse, 404: errorResponse,
+			},
+		},
+	}
+	// The :id of the /me routes names a session or a notification, not a user
+	for i := range routes {
+		routes[i].PublicID = strings.HasPrefix(routes[i].Path, "/:id") || strings.HasPrefix(routes[i].Path, "/admin/:id")
+	}
+	return routes
+}
+
+// RegisterGRPC registers the user gRPC service backed by the same use case as the HTTP routes
+func (m *UserModule) RegisterGRPC(s grpc.ServiceRegistrar) {
+	userv1.RegisterUserServiceServer(s, m.grpcServer)
 }
 
 // Migrate runs database migrations for user module
+// Emails used to be unique across the whole table, then per tenant; both indexes are
+// dropped now that encrypted emails are kept unique by their blind index, which is
+// computed for the users missing one. Users missing a public ID are assigned one, and
+// inboxes missing their counts are counted
 func (m *UserModule) Migrate(db *gorm.DB) error {
-	return db.AutoMigrate(&models.UserModel{})
+	migrator := db.Migrator()
+	for _, index := range []string{"idx_users_email", "idx_users_tenant_email"} {
+		if migrator.HasIndex(&models.UserModel{}, index) {
+			if err := migrator.DropIndex(&models.UserModel{}, index); err != nil {
+				return err
+			}
+		}
+	}
+	if err := db.AutoMigrate(&models.UserModel{}, &models.UserDailyStatsModel{}, &models.UserPreferencesModel{},
+		&models.NotificationModel{}, &models.UserAuditModel{}, &models.UserActivityModel{}, &models.UserSessionModel{},
+		&models.UserAuthEventModel{}, &models.UserAccountTokenModel{}, &models.UserDeviceModel{},
+		&models.NotificationDigestItemModel{}, &models.NotificationCounterModel{}, &models.UserConnectionModel{}); err != nil {
+		return err
+	}
+	if err := userJobs.BackfillEmailIndex(context.Background(), db); err != nil {
+		return err
+	}
+	if err := userJobs.BackfillNotificationCounters(context.Background(), db); err != nil {
+		return err
+	}
+	return userJobs.BackfillPublicIDs(context.Background(), db)
+}
+
+// Rollback drops the user tables, the dependent ones first; the orders of the users must be
+// rolled back before
+func (m *UserModule) Rollback(db *gorm.DB) error {
+	return db.Migrator().DropTable(&models.UserConnectionModel{}, &models.NotificationCounterModel{}, &models.NotificationDigestItemModel{}, &models.UserDeviceModel{}, &models.UserAccountTokenModel{}, &models.UserAuthEventModel{}, &models.UserSessionModel{}, &models.UserActivityModel{},
+		&models.UserAuditModel{}, &models.NotificationModel{}, &models.UserPreferencesModel{}, &models.UserDailyStatsModel{},
+		&models.UserModel{})
+}
+
+// Schema declares the indexes behind paging a tenant's users and filtering them by role and
+// status, both over live users only, the sign-ins the analytics count, and the full-text index
+// users are searched by, unless their names and emails are encrypted
+func (m *UserModule) Schema() database.Schema {
+	schema := database.Schema{
+		Indexes: []database.Index{
+			{Table: "users", Name: "idx_users_tenant_created_live", Columns: []string{"tenant_id", "created_at", "id"}, Where: "deleted_at IS NULL"},
+			{Table: "users", Name: "idx_users_tenant_role_status_live", Columns: []string{"tenant_id", "role", "status"}, Where: "deleted_at IS NULL"},
+			// Covers the sign-ins the analytics count by day and week
+			{Table: "user_auth_events", Name: "idx_user_auth_events_tenant_type_occurred", Columns: []string{"tenant_id", "type", "occurred_at", "user_id"}},
+		},
+	}
+	if !fieldcrypt.Enabled() {
+		// Ciphertext has no words to index; encrypted users are searched by scanning them
+		schema.Indexes = append(schema.Indexes,
+			database.Index{Table: "users", Name: "idx_users_search", Columns: userRepositories.UserSearchColumns, FullText: true})
+	}
+	return schema
+}
+
+// Queries declares the user model and its custom queries for GORM Gen
+func (m *UserModule) Queries() database.QueryManifest {
+	return database.QueryManifest{
+		Models: []interface{}{models.UserModel{}},
+		Methods: []database.QueryMethods{
+			{Interface: func(userRepositories.UserQueries) {}, Models: []interface{}{models.UserModel{}}},
+		},
+	}
+}
+
+// ScheduledJobs purges long soft-deleted users, expired sessions, account tokens and old auth events,
+// rolls up daily user stats, re-encrypts users with the active encryption key and mails the
+// notification digests
+// There are none without a database, e.g. on the in-memory repository
+func (m *UserModule) ScheduledJobs() []scheduler.Job {
+	if m.db == nil {
+		return nil
+	}
+	jobs := []scheduler.Job{
+		userJobs.NewPurgeDeletedJob(m.userRepo, userJobs.PurgeDeletedAfter),
+		userJobs.NewPurgeSessionsJob(m.db),
+		userJobs.NewPurgeAuthEventsJob(m.db, userJobs.AuthEventRetention),
+		userJobs.NewStatsRollupJob(m.db),
+		userJobs.NewReencryptJob(m.db),
+	}
+	if m.accountController != nil {
+		jobs = append(jobs, userJobs.NewPurgeAccountTokensJob(m.db))
+	}
+	if m.digest != nil {
+		jobs = append(jobs, userJobs.NewDigestJob(m.digest))
+	}
+	return jobs
+}
+
+// TaskHandlers runs async bulk imports
+func (m *UserModule) TaskHandlers() map[string]taskqueue.Handler {
+	handlers := make(map[string]taskqueue.Handler)
+	if m.importHandler != nil {
+		handlers[userTasks.ImportTaskType] = userTasks.NewImportHandler(m.importHandler)
+	}
+	if m.exportTask != nil {
+		handlers[userTasks.ExportTaskType] = m.exportTask
+	}
+	return handlers
+}
+
+// SetTaskQueue lets the import endpoint queue uploads with ?async=true and the export
+// endpoint queue exports
+func (m *UserModule) SetTaskQueue(q *taskqueue.Queue) {
+	if m.importController != nil {
+		m.importController.SetQueue(q)
+	}
+	if m.exportController != nil {
+		m.exportController.SetQueue(q)
+	}
+}
+
+// AuthMiddleware resolves the session tokens issued at login and the roles stored on the
+// accounts of the users authenticated, turning away suspended accounts and those awaiting a
+// password reset; without a session store nothing is resolved
+func (m *UserModule) AuthMiddleware() gin.HandlerFunc {
+	if m.sessionUseCase == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return middleware.SessionAuth(m.sessionUseCase, m.sessionUseCase, m.sessionUseCase)
+}
+
+// Sessions resolves the session tokens of gRPC calls; nil without a session store
+func (m *UserModule) Sessions() grpcserver.SessionAuthenticator {
+	if m.sessionUseCase == nil {
+		return nil
+	}
+	return m.sessionUseCase
+}
+
+// SearchPermission lets any authenticated caller find users, as the search route does
+func (m *UserModule) SearchPermission() (string, string) {
+	return "", ""
+}
+
+// ReportGenerators contributes the reports on the user analytics; none without a database
+func (m *UserModule) ReportGenerators() []reports.Generator {
+	if m.analyticsUseCase == nil {
+		return nil
+	}
+	return userReports.NewGenerators(m.analyticsUseCase)
+}
+
+// Search finds the users whose name or email has words starting with those of query;
+// none without a database
+func (m *UserModule) Search(ctx context.Context, actor authz.Actor, query string, limit int) ([]modules.SearchResult, error) {
+	if m.searchUseCase == nil {
+		return nil, nil
+	}
+	hits, _, _, err := m.searchUseCase.SearchUsers(ctx, query, limit, 0)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]modules.SearchResult, len(hits))
+	for i, hit := range hits {
+		results[i] = modules.SearchResult{
+			Type:    "user",
+			ID:      hit.User.PublicID,
+			Title:   hit.User.Name,
+			Summary: hit.User.Email,
+			Score:   hit.Score,
+		}
+	}
+	return results, nil
+}
+
+// SearchIndexes describes the users index when users are kept in the search engine
+func (m *UserModule) SearchIndexes() []search.Index {
+	return m.searchIndexes
 }
 
 // Initialize performs any module-specific initialization
@@ -80,8 +1533,32 @@ func (m *UserModule) Initialize() error {
 	return nil
 }
 
+// CheckHealth verifies the users table is readable
+// Without a database (in-memory repository) there is nothing to check
+func (m *UserModule) CheckHealth(ctx context.Context) error {
+	if m.db == nil {
+		return nil
+	}
+	return health.TableCheck(m.db, &models.UserModel{})(ctx)
+}
+
 // Additional route handlers that leverage GORM Gen advanced features
 
+// Shutdown stops turning domain events into notifications, then waits for the notifications
+// and exports under way
+func (m *UserModule) Shutdown(ctx context.Context) error {
+	m.unsubscribe()
+	for _, pool := range []*workerpool.Pool{m.pools.Notifications, m.pools.Exports} {
+		if pool == nil {
+			continue
+		}
+		if err := pool.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // getUsersByDomain demonstrates GORM Gen's advanced querying
 func (m *UserModule) getUsersByDomain(c *gin.Context) {
 	domain := c.Param("domain")
@@ -102,19 +1579,3 @@ func (m *UserModule) getActiveUsers(c *gin.Context) {
 		"note":    "This uses GORM Gen's type-safe filtering methods",
 	})
 }
-
-// searchUsers demonstrates GORM Gen's dynamic query building
-func (m *UserModule) searchUsers(c *gin.Context) {
-	email := c.Query("email")
-	name := c.Query("name")
-
-	// This would use the GORM Gen repository's dynamic query building
-	c.JSON(200, gin.H{
-		"message": "Search users",
-		"filters": gin.H{
-			"email": email,
-			"name":  name,
-		},
-		"note": "This uses GORM Gen's dynamic query building",
-	})
-}
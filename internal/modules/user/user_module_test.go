@@ -0,0 +1,34 @@
+package user_test
+
+import (
+	"net/http"
+	"testing"
+
+	userRepositories "clean-arch-gin/internal/adapters/user/repositories"
+	"clean-arch-gin/internal/modules/user"
+	"clean-arch-gin/internal/testutil/httptestutil"
+)
+
+// TestUserRoutesAddressUsersByPublicID registers the module on the in-memory repository and
+// checks that users are created and fetched by public ID
+func TestUserRoutesAddressUsersByPublicID(t *testing.T) {
+	r := httptestutil.NewModuleRouter(t, user.NewUserModuleWithRepository(nil, userRepositories.NewUserRepositoryMemory()))
+
+	rec := httptestutil.DoJSON(t, r, http.MethodPost, "/api/v1/users",
+		map[string]string{"email": "alice@example.com", "name": "Alice", "password": "password123"})
+	httptestutil.AssertStatus(t, rec, http.StatusCreated)
+	created := httptestutil.AssertJSONFields(t, rec, "id")
+	id, _ := created["id"].(string)
+
+	rec = httptestutil.DoJSON(t, r, http.MethodGet, "/api/v1/users/"+id, nil)
+	httptestutil.AssertStatus(t, rec, http.StatusOK)
+	if body := httptestutil.AssertJSONFields(t, rec, "email"); body["email"] != "alice@example.com" {
+		t.Errorf("GET user email = %v, want alice@example.com", body["email"])
+	}
+
+	rec = httptestutil.DoJSON(t, r, http.MethodGet, "/api/v1/users/1", nil)
+	httptestutil.AssertError(t, rec, http.StatusBadRequest, "Invalid ID")
+
+	rec = httptestutil.DoJSON(t, r, http.MethodGet, "/api/v1/users/me", nil)
+	httptestutil.AssertStatus(t, rec, http.StatusUnauthorized)
+}
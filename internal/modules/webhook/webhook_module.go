@@ -0,0 +1,169 @@
+package webhook
+
+import (
+	"context"
+	"sync/atomic"
+
+	"clean-arch-gin/internal/adapters/middleware"
+	"clean-arch-gin/internal/adapters/shared/models"
+	webhookControllers "clean-arch-gin/internal/adapters/webhook/controllers"
+	"clean-arch-gin/internal/adapters/webhook/delivery"
+	webhookRepositories "clean-arch-gin/internal/adapters/webhook/repositories"
+	webhookUsecases "clean-arch-gin/internal/adapters/webhook/usecases"
+	"clean-arch-gin/internal/infrastructure/eventbus"
+	"clean-arch-gin/internal/infrastructure/health"
+	"clean-arch-gin/internal/infrastructure/openapi"
+	"clean-arch-gin/internal/infrastructure/workerpool"
+	"clean-arch-gin/internal/modules"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// WebhookModule delivers domain events to registered webhook endpoints
+type WebhookModule struct {
+	controller *webhookControllers.WebhookController
+	dispatcher *delivery.Dispatcher
+	auth       *middleware.AuthMiddleware
+	db         *gorm.DB
+	// pool sends deliveries when set, and is drained on shutdown
+	pool *workerpool.Pool
+	// done is closed when the delivery loop started by Start returns
+	started atomic.Bool
+	done    chan struct{}
+}
+
+// NewWebhookModule creates a new webhook module
+// Every event published on the bus is recorded for the endpoints subscribed to it; the
+// module drains opts.Pool when it shuts down
+func NewWebhookModule(db *gorm.DB, bus *eventbus.Bus, opts delivery.Options) modules.Module {
+	webhookRepo := webhookRepositories.NewWebhookRepository(db)
+	dispatcher := delivery.NewDispatcher(webhookRepo, opts)
+	bus.Subscribe(eventbus.Wildcard, dispatcher.HandleEvent)
+
+	return &WebhookModule{
+		controller: webhookControllers.NewWebhookController(webhookUsecases.NewWebhookUseCase(webhookRepo, dispatcher)),
+		dispatcher: dispatcher,
+		auth:       middleware.NewAuthMiddleware(""),
+		db:         db,
+		pool:       opts.Pool,
+		done:       make(chan struct{}),
+	}
+}
+
+// Name returns the module name
+func (m *WebhookModule) Name() string {
+	return "webhooks"
+}
+
+// RegisterRoutes registers no public routes; webhook administration is admin-only
+func (m *WebhookModule) RegisterRoutes(rg *gin.RouterGroup) {}
+
+// RegisterAdminRoutes registers the webhook admin routes
+func (m *WebhookModule) RegisterAdminRoutes(rg *gin.RouterGroup) {
+	admin := rg.Group("", m.auth.RequireAuth(), m.auth.RequirePermission("webhooks", "manage"))
+	{
+		admin.POST("/endpoints", m.controller.RegisterEndpoint)             // POST /api/v1/webhooks/endpoints
+		admin.GET("/endpoints", m.controller.ListEndpoints)                 // GET /api/v1/webhooks/endpoints
+		admin.GET("/endpoints/:id/deliveries", m.controller.ListDeliveries) // GET /api/v1/webhooks/endpoints/:id/deliveries
+		admin.GET("/deliveries/:id", m.controller.GetDelivery)              // GET /api/v1/webhooks/deliveries/:id
+		admin.POST("/deliveries/:id/redeliver", m.controller.Redeliver)     // POST /api/v1/webhooks/deliveries/:id/redeliver
+	}
+}
+
+// APIRoutes documents the routes registered by RegisterRoutes
+func (m *WebhookModule) APIRoutes() []openapi.Route {
+	errorResponse := openapi.ErrorResponse{}
+
+	return []openapi.Route{
+		{
+			Method: "POST", Path: "/endpoints", Summary: "Register a webhook endpoint (admin); the signing secret is only returned here", Auth: true,
+			Request: webhookControllers.RegisterEndpointRequest{},
+			Responses: map[int]interface{}{
+				201: webhookControllers.EndpointDTO{}, 400: errorResponse, 401: errorResponse, 403: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/endpoints", Summary: "List webhook endpoints (admin)", Auth: true,
+			Responses: map[int]interface{}{
+				200: nil, 401: errorResponse, 403: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/endpoints/:id/deliveries", Summary: "List an endpoint's deliveries, newest first (admin)", Auth: true,
+			Query: []openapi.Parameter{
+				openapi.QueryParam("limit", "integer", "Maximum number of deliveries to return"),
+				openapi.QueryParam("offset", "integer", "Number of deliveries to skip"),
+			},
+			Responses: map[int]interface{}{
+				200: webhookControllers.DeliveryListResponse{}, 400: errorResponse, 404: errorResponse,
+			},
+		},
+		{
+			Method: "GET", Path: "/deliveries/:id", Summary: "Get a delivery with its payload and attempt log (admin)", Auth: true,
+			Responses: map[int]interface{}{
+				200: webhookControllers.DeliveryDetailDTO{}, 400: errorResponse, 404: errorResponse,
+			},
+		},
+		{
+			Method: "POST", Path: "/deliveries/:id/redeliver", Summary: "Send a succeeded or failed delivery again (admin)", Auth: true,
+			Responses: map[int]interface{}{
+				202: webhookControllers.DeliveryDTO{}, 400: errorResponse, 404: errorResponse, 409: errorResponse,
+			},
+		},
+	}
+}
+
+// Migrate runs database migrations for webhook module
+func (m *WebhookModule) Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&models.WebhookEndpointModel{},
+		&models.WebhookDeliveryModel{},
+		&models.WebhookDeliveryAttemptModel{},
+	)
+}
+
+// Rollback drops the webhook tables, the dependent ones first
+func (m *WebhookModule) Rollback(db *gorm.DB) error {
+	return db.Migrator().DropTable(
+		&models.WebhookDeliveryAttemptModel{},
+		&models.WebhookDeliveryModel{},
+		&models.WebhookEndpointModel{},
+	)
+}
+
+// Initialize performs webhook module initialization
+func (m *WebhookModule) Initialize() error {
+	return nil
+}
+
+// CheckHealth verifies the delivery queue is readable
+func (m *WebhookModule) CheckHealth(ctx context.Context) error {
+	return health.TableCheck(m.db, &models.WebhookDeliveryModel{})(ctx)
+}
+
+// Start runs the delivery loop until ctx is cancelled
+func (m *WebhookModule) Start(ctx context.Context) {
+	m.started.Store(true)
+	go func() {
+		defer close(m.done)
+		m.dispatcher.Run(ctx)
+	}()
+}
+
+// Shutdown waits for the delivery loop to finish its in-flight deliveries, then stops the pool
+// The loop stops once the context passed to Start is cancelled; deliveries it
+// did not reach stay pending and are sent by the next instance to poll
+func (m *WebhookModule) Shutdown(ctx context.Context) error {
+	if m.started.Load() {
+		select {
+		case <-m.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if m.pool == nil {
+		return nil
+	}
+	return m.pool.Shutdown(ctx)
+}
@@ -0,0 +1,198 @@
+// Package factory builds valid domain entities and persisted models for tests
+// Every builder starts from a valid default and applies the given options in order
+package factory
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	orderEntities "clean-arch-gin/internal/domain/order/entities"
+	"clean-arch-gin/internal/domain/shared/passwords"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+
+	"gorm.io/gorm"
+)
+
+// init hashes the passwords of generated users at the lowest cost, which keeps tests building
+// many users fast
+func init() {
+	if err := passwords.SetCost(passwords.MinCost); err != nil {
+		panic(err)
+	}
+}
+
+// sequence hands out unique numbers so generated emails and IDs never collide
+var sequence uint64
+
+// Next returns the next value of the shared factory sequence
+func Next() uint64 {
+	return atomic.AddUint64(&sequence, 1)
+}
+
+// UserOption overrides a field of a generated user
+type UserOption func(*userEntities.User)
+
+// WithUserID sets the user ID
+func WithUserID(id uint) UserOption {
+	return func(u *userEntities.User) { u.ID = id }
+}
+
+// WithEmail sets the user email
+func WithEmail(email string) UserOption {
+	return func(u *userEntities.User) { u.Email = email }
+}
+
+// WithName sets the user name
+func WithName(name string) UserOption {
+	return func(u *userEntities.User) { u.Name = name }
+}
+
+// WithPassword sets the user password, hashed like a real one
+func WithPassword(password string) UserOption {
+	return func(u *userEntities.User) {
+		if err := u.ChangePassword(password); err != nil {
+			panic(fmt.Sprintf("factory: cannot set password: %v", err))
+		}
+	}
+}
+
+// WithPlainTextPassword stores the password unhashed, like rows from before hashing
+func WithPlainTextPassword(password string) UserOption {
+	return func(u *userEntities.User) { u.Password = password }
+}
+
+// WithCreatedAt sets the creation timestamp
+func WithCreatedAt(createdAt time.Time) UserOption {
+	return func(u *userEntities.User) { u.CreatedAt = createdAt }
+}
+
+// Deleted marks the user as soft deleted
+func Deleted() UserOption {
+	return func(u *userEntities.User) { u.MarkAsDeleted() }
+}
+
+// WithDeletedAt marks the user as soft deleted at the given time
+func WithDeletedAt(deletedAt time.Time) UserOption {
+	return func(u *userEntities.User) { u.DeletedAt = &deletedAt }
+}
+
+// Suspended suspends the user account
+func Suspended() UserOption {
+	return func(u *userEntities.User) {
+		if err := u.SetStatus(userEntities.StatusSuspended); err != nil {
+			panic(fmt.Sprintf("factory: cannot suspend user: %v", err))
+		}
+	}
+}
+
+// User builds a valid user entity with a unique email
+func User(opts ...UserOption) *userEntities.User {
+	n := Next()
+	user, err := userEntities.NewUser(
+		fmt.Sprintf("user%d@example.com", n),
+		fmt.Sprintf("User %d", n),
+		"password123",
+	)
+	if err != nil {
+		panic(fmt.Sprintf("factory: default user is invalid: %v", err))
+	}
+
+	for _, opt := range opts {
+		opt(user)
+	}
+	return user
+}
+
+// UserModel builds a GORM user model from a generated user entity
+func UserModel(opts ...UserOption) *models.UserModel {
+	return models.NewUserModelFromEntity(User(opts...))
+}
+
+// CreateUser persists a generated user and returns it with its assigned ID
+func CreateUser(t testing.TB, db *gorm.DB, opts ...UserOption) *userEntities.User {
+	t.Helper()
+
+	model := UserModel(opts...)
+	if err := db.Create(model).Error; err != nil {
+		t.Fatalf("factory: failed to create user: %v", err)
+	}
+	return model.ToDomainEntity()
+}
+
+// OrderOption overrides a field of a generated order
+type OrderOption func(*orderEntities.Order)
+
+// WithOrderID sets the order ID
+func WithOrderID(id uint) OrderOption {
+	return func(o *orderEntities.Order) { o.ID = id }
+}
+
+// WithOrderUserID sets the user owning the order
+func WithOrderUserID(userID uint) OrderOption {
+	return func(o *orderEntities.Order) { o.UserID = userID }
+}
+
+// WithStatus sets the order status
+func WithStatus(status orderEntities.OrderStatus) OrderOption {
+	return func(o *orderEntities.Order) { o.Status = status }
+}
+
+// WithItems replaces the order items and recalculates the total
+func WithItems(items ...*orderEntities.OrderItem) OrderOption {
+	return func(o *orderEntities.Order) {
+		o.Items = items
+		o.TotalAmount = 0
+		for _, item := range items {
+			o.TotalAmount += item.Price * float64(item.Quantity)
+		}
+	}
+}
+
+// Order builds a valid pending order with a single item
+func Order(opts ...OrderOption) *orderEntities.Order {
+	order, err := orderEntities.NewOrder(uint(Next()), []*orderEntities.OrderItem{OrderItem()})
+	if err != nil {
+		panic(fmt.Sprintf("factory: default order is invalid: %v", err))
+	}
+
+	for _, opt := range opts {
+		opt(order)
+	}
+	return order
+}
+
+// OrderItemOption overrides a field of a generated order item
+type OrderItemOption func(*orderEntities.OrderItem)
+
+// WithProductID sets the product of the item
+func WithProductID(productID uint) OrderItemOption {
+	return func(i *orderEntities.OrderItem) { i.ProductID = productID }
+}
+
+// WithQuantity sets the item quantity
+func WithQuantity(quantity int) OrderItemOption {
+	return func(i *orderEntities.OrderItem) { i.Quantity = quantity }
+}
+
+// WithPrice sets the item unit price
+func WithPrice(price float64) OrderItemOption {
+	return func(i *orderEntities.OrderItem) { i.Price = price }
+}
+
+// OrderItem builds a valid order item for a unique product
+func OrderItem(opts ...OrderItemOption) *orderEntities.OrderItem {
+	item := &orderEntities.OrderItem{
+		ProductID: uint(Next()),
+		Quantity:  1,
+		Price:     9.99,
+		CreatedAt: time.Now(),
+	}
+
+	for _, opt := range opts {
+		opt(item)
+	}
+	return item
+}
@@ -0,0 +1,139 @@
+// Package golden compares API responses against checked-in golden JSON files
+// Run the tests with -update to rewrite the golden files after an intentional change
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// Masked replaces the value of volatile fields so golden files stay stable between runs
+const Masked = "<masked>"
+
+// DefaultVolatileFields are masked in every comparison because they change on every run
+var DefaultVolatileFields = []string{"created_at", "updated_at", "deleted_at"}
+
+// Options controls how a response is normalized before comparison
+type Options struct {
+	// Dir is the directory holding golden files, relative to the test package
+	Dir string
+	// Mask lists additional JSON keys whose values are replaced with Masked
+	Mask []string
+}
+
+// AssertJSON compares body with testdata/<name>.golden.json
+func AssertJSON(t testing.TB, name string, body []byte) {
+	t.Helper()
+	AssertJSONWithOptions(t, name, body, Options{})
+}
+
+// AssertJSONWithOptions compares body with the named golden file using the given options
+func AssertJSONWithOptions(t testing.TB, name string, body []byte, opts Options) {
+	t.Helper()
+
+	if opts.Dir == "" {
+		opts.Dir = "testdata"
+	}
+	path := filepath.Join(opts.Dir, name+".golden.json")
+
+	got, err := Normalize(body, append(DefaultVolatileFields, opts.Mask...)...)
+	if err != nil {
+		t.Fatalf("golden: response for %s is not valid JSON: %v\n%s", name, err, body)
+	}
+
+	if *update {
+		if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+			t.Fatalf("golden: failed to create %s: %v", opts.Dir, err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("golden: failed to write %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("golden: failed to read %s (run with -update to create it): %v", path, err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(want), bytes.TrimSpace(got)) {
+		t.Errorf("golden: response for %s does not match %s\n%s", name, path, Diff(string(want), string(got)))
+	}
+}
+
+// Normalize re-encodes JSON with sorted keys and indentation, masking the given fields at any depth
+func Normalize(body []byte, mask ...string) ([]byte, error) {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, err
+	}
+
+	masked := make(map[string]bool, len(mask))
+	for _, key := range mask {
+		masked[key] = true
+	}
+
+	// HTML escaping is off so Masked and markup in messages stay readable in the files
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(maskValue(value, masked)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// maskValue walks decoded JSON and replaces non-null values of masked keys
+func maskValue(value interface{}, masked map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if masked[key] && child != nil {
+				v[key] = Masked
+				continue
+			}
+			v[key] = maskValue(child, masked)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = maskValue(child, masked)
+		}
+	}
+	return value
+}
+
+// Diff renders a line-by-line comparison of want and got
+func Diff(want, got string) string {
+	wantLines := strings.Split(strings.TrimSpace(want), "\n")
+	gotLines := strings.Split(strings.TrimSpace(got), "\n")
+
+	var b strings.Builder
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w == g {
+			fmt.Fprintf(&b, "  %s\n", w)
+			continue
+		}
+		if i < len(wantLines) {
+			fmt.Fprintf(&b, "- %s\n", w)
+		}
+		if i < len(gotLines) {
+			fmt.Fprintf(&b, "+ %s\n", g)
+		}
+	}
+	return b.String()
+}
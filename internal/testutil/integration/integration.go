@@ -0,0 +1,88 @@
+// Package integration provides container-backed databases for repository integration tests
+// Containers are started with testcontainers-go, so Docker must be available on the host; the
+// tests using them carry the integration build tag and run with just test-integration
+package integration
+
+import (
+	"testing"
+
+	userRepositories "clean-arch-gin/internal/adapters/user/repositories"
+	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/infrastructure/eventbus"
+	"clean-arch-gin/internal/modules"
+	orderModule "clean-arch-gin/internal/modules/order"
+	userModule "clean-arch-gin/internal/modules/user"
+	"clean-arch-gin/internal/testutil/repotest"
+
+	"github.com/testcontainers/testcontainers-go"
+	"gorm.io/gorm"
+)
+
+// Database starts a throwaway database with migrations applied and returns a connection to it
+type Database func(t *testing.T) *gorm.DB
+
+// Databases lists every database engine the conformance suites run against
+var Databases = map[string]Database{
+	"mysql":    StartMySQL,
+	"postgres": StartPostgres,
+}
+
+// UserRepositories lists every UserRepository implementation covered by the conformance suite
+var UserRepositories = []repotest.UserRepositoryImplementation{
+	{Name: "gorm", New: userRepositories.NewUserRepository},
+	{Name: "gorm_gen", New: userRepositories.NewUserRepositoryGen},
+}
+
+// RunUserRepositoryConformance runs the user repository suite and the cross-implementation
+// contract for every implementation on every database
+func RunUserRepositoryConformance(t *testing.T) {
+	for dbName, start := range Databases {
+		t.Run(dbName, func(t *testing.T) {
+			db := start(t)
+			for _, impl := range UserRepositories {
+				t.Run(impl.Name, func(t *testing.T) {
+					repotest.RunUserRepositorySuite(t, db, impl.New)
+				})
+			}
+			t.Run("contract", func(t *testing.T) {
+				repotest.RunUserRepositoryContract(t, db, UserRepositories)
+			})
+		})
+	}
+}
+
+// skipUnlessDockerAvailable skips integration tests in short mode or when Docker is unreachable
+func skipUnlessDockerAvailable(t *testing.T) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping container-backed integration test in short mode")
+	}
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+}
+
+// connect opens the database of cfg through database.NewConnection, so the tenant and soft
+// delete scopes are registered as in the application, and migrates it the way the user and
+// order modules do at startup. The connection is closed when the test finishes
+func connect(t *testing.T, cfg *config.Config) *gorm.DB {
+	t.Helper()
+	cfg.DB.LogLevel = "silent"
+
+	db, err := database.NewConnection(cfg)
+	if err != nil {
+		t.Fatalf("failed to connect to %s: %v", cfg.DB.Driver, err)
+	}
+	t.Cleanup(func() {
+		if err := database.Close(db); err != nil {
+			t.Logf("failed to close %s: %v", cfg.DB.Driver, err)
+		}
+	})
+
+	registry := modules.NewModuleRegistry()
+	registry.Register(userModule.NewUserModuleLegacy(db))
+	registry.Register(orderModule.NewOrderModuleLegacy(db, eventbus.New()))
+	if err := registry.MigrateAll(db); err != nil {
+		t.Fatalf("failed to migrate %s: %v", cfg.DB.Driver, err)
+	}
+	return db
+}
@@ -0,0 +1,57 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"clean-arch-gin/internal/infrastructure/config"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/mysql"
+	"gorm.io/gorm"
+)
+
+// mysqlImage matches the image used by docker-compose.yaml
+const mysqlImage = "mysql:8.0"
+
+// StartMySQL starts a MySQL container, migrates it like the application and returns a
+// connection to it
+// The container is terminated automatically when the test finishes
+func StartMySQL(t *testing.T) *gorm.DB {
+	t.Helper()
+	skipUnlessDockerAvailable(t)
+
+	ctx := context.Background()
+	container, err := mysql.RunContainer(ctx,
+		testcontainers.WithImage(mysqlImage),
+		mysql.WithDatabase("clean_arch_test"),
+		mysql.WithUsername("test"),
+		mysql.WithPassword("test"),
+	)
+	if err != nil {
+		t.Fatalf("failed to start mysql container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Logf("failed to terminate mysql container: %v", err)
+		}
+	})
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		t.Fatalf("failed to get the mysql container host: %v", err)
+	}
+	port, err := container.MappedPort(ctx, "3306/tcp")
+	if err != nil {
+		t.Fatalf("failed to get the mysql container port: %v", err)
+	}
+
+	cfg := config.NewConfig()
+	cfg.DB.Driver = "mysql"
+	cfg.DB.Host = host
+	cfg.DB.Port = port.Int()
+	cfg.DB.User = "test"
+	cfg.DB.Password = "test"
+	cfg.DB.Name = "clean_arch_test"
+	return connect(t, cfg)
+}
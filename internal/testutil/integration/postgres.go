@@ -0,0 +1,68 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"clean-arch-gin/internal/infrastructure/config"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/gorm"
+)
+
+// postgresImage is the Postgres release the conformance suite runs against
+const postgresImage = "postgres:15-alpine"
+
+// StartPostgres starts a Postgres container, migrates it like the application and returns a
+// connection to it
+// The container is terminated automatically when the test finishes
+func StartPostgres(t *testing.T) *gorm.DB {
+	t.Helper()
+	skipUnlessDockerAvailable(t)
+
+	ctx := context.Background()
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        postgresImage,
+			ExposedPorts: []string{"5432/tcp"},
+			Env: map[string]string{
+				"POSTGRES_DB":       "clean_arch_test",
+				"POSTGRES_USER":     "test",
+				"POSTGRES_PASSWORD": "test",
+			},
+			// The server restarts once after running its init scripts
+			WaitingFor: wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).WithStartupTimeout(time.Minute),
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to start postgres container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Logf("failed to terminate postgres container: %v", err)
+		}
+	})
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		t.Fatalf("failed to get the postgres container host: %v", err)
+	}
+	port, err := container.MappedPort(ctx, "5432/tcp")
+	if err != nil {
+		t.Fatalf("failed to get the postgres container port: %v", err)
+	}
+
+	cfg := config.NewConfig()
+	cfg.DB.Driver = "postgres"
+	cfg.DB.Host = host
+	cfg.DB.Port = port.Int()
+	cfg.DB.User = "test"
+	cfg.DB.Password = "test"
+	cfg.DB.Name = "clean_arch_test"
+	cfg.DB.SSLMode = "disable"
+	return connect(t, cfg)
+}
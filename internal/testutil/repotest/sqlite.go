@@ -0,0 +1,51 @@
+package repotest
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/infrastructure/config"
+	"clean-arch-gin/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// userTables are the users table and the tables whose rows are purged along with a user
+var userTables = []interface{}{
+	&models.UserModel{}, &models.UserPreferencesModel{}, &models.NotificationModel{}, &models.UserActivityModel{},
+	&models.UserSessionModel{}, &models.UserAuthEventModel{}, &models.UserAccountTokenModel{}, &models.UserDeviceModel{},
+	&models.NotificationDigestItemModel{}, &models.NotificationCounterModel{}, &models.UserConnectionModel{},
+	&models.OrderModel{}, &models.OrderItemModel{}, &models.ShipmentModel{}, &models.ShipmentItemModel{},
+	&models.ReturnModel{}, &models.ReturnItemModel{},
+}
+
+// sqliteSeq gives every OpenSQLite call its own in-memory database
+var sqliteSeq uint64
+
+// OpenSQLite opens a private in-memory SQLite database through database.NewConnection, so the
+// tenant and soft delete scopes are registered, and migrates the user tables
+// The connection is closed when the test finishes
+func OpenSQLite(tb testing.TB) *gorm.DB {
+	tb.Helper()
+
+	cfg := config.NewConfig()
+	cfg.DB.Driver = "sqlite"
+	cfg.DB.Name = fmt.Sprintf("file:repotest%d?mode=memory&cache=shared", atomic.AddUint64(&sqliteSeq, 1))
+	cfg.DB.LogLevel = "silent"
+
+	db, err := database.NewConnection(cfg)
+	if err != nil {
+		tb.Fatalf("failed to open sqlite: %v", err)
+	}
+	tb.Cleanup(func() {
+		if err := database.Close(db); err != nil {
+			tb.Logf("failed to close sqlite: %v", err)
+		}
+	})
+	if err := database.AutoMigrate(db, userTables...); err != nil {
+		tb.Fatalf("failed to migrate sqlite: %v", err)
+	}
+	return db
+}
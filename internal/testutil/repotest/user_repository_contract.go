@@ -0,0 +1,455 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	sharedEntities "clean-arch-gin/internal/domain/shared/entities"
+	"clean-arch-gin/internal/domain/shared/pagination"
+	"clean-arch-gin/internal/domain/shared/softdelete"
+	"clean-arch-gin/internal/domain/shared/tenancy"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/infrastructure/database"
+	"clean-arch-gin/internal/testutil/factory"
+
+	"gorm.io/gorm"
+)
+
+// UserRepositoryImplementation names a UserRepository constructor covered by the contract
+type UserRepositoryImplementation struct {
+	Name string
+	New  UserRepositoryConstructor
+}
+
+// userContractCase seeds the database through repo and asserts one behavior every implementation must share
+type userContractCase struct {
+	name string
+	run  func(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository)
+}
+
+// userContractCases is the table of behaviors every repository must agree on; cases go through
+// the repository only, so implementations without a database are covered too
+var userContractCases = []userContractCase{
+	{"MissingRecordsReturnErrUserNotFound", contractMissingRecords},
+	{"DeleteIsSoft", contractDeleteIsSoft},
+	{"DeletedUsersAreHiddenFromQueries", contractDeletedUsersHidden},
+	{"DeleteMissingIsNoop", contractDeleteMissingIsNoop},
+	{"SoftDeleteScopesSelectDeletedUsers", contractSoftDeleteScopes},
+	{"PurgeOlderThanKeepsRecentlyDeleted", contractPurgeOlderThan},
+	{"EmailDomainMatchesSuffix", contractEmailDomain},
+	{"FiltersMatchSubstrings", contractFilters},
+	{"FiltersPaginate", contractFiltersPaginate},
+	{"ListAfterPagesThroughTies", contractListAfter},
+	{"BatchWritesReportFailedRecords", contractBatchWrites},
+	{"UpsertIsKeyedByEmail", contractUpsert},
+	{"TenantsAreIsolated", contractTenantsIsolated},
+}
+
+// RunUserRepositoryContract runs the contract table against every implementation
+// Each case starts from an empty users table
+func RunUserRepositoryContract(t *testing.T, db *gorm.DB, impls []UserRepositoryImplementation) {
+	for _, impl := range impls {
+		t.Run(impl.Name, func(t *testing.T) {
+			for _, tc := range userContractCases {
+				t.Run(tc.name, func(t *testing.T) {
+					ResetUsers(t, db)
+					tc.run(t, db, impl.New(db))
+				})
+			}
+		})
+	}
+}
+
+func contractMissingRecords(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository) {
+	if _, err := repo.GetByID(context.Background(), 4242); err != userEntities.ErrUserNotFound {
+		t.Errorf("GetByID error = %v, want %v", err, userEntities.ErrUserNotFound)
+	}
+	if _, err := repo.GetByEmail(context.Background(), "nobody@example.com"); err != userEntities.ErrUserNotFound {
+		t.Errorf("GetByEmail error = %v, want %v", err, userEntities.ErrUserNotFound)
+	}
+
+	users, err := repo.GetAll(context.Background(), 10, 0)
+	if err != nil {
+		t.Fatalf("GetAll returned error: %v", err)
+	}
+	if len(users) != 0 {
+		t.Errorf("GetAll on empty table returned %d users", len(users))
+	}
+}
+
+func contractDeleteIsSoft(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository) {
+	user := mustCreateUser(t, repo, "alice@example.com", "Alice")
+
+	if err := repo.Delete(context.Background(), user.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	// The user must still exist with its deletion time set
+	found, err := repo.GetByIDIncludingDeleted(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("deleted user was removed permanently: %v", err)
+	}
+	if !found.IsDeleted() {
+		t.Error("deleted user has no deletion time")
+	}
+}
+
+func contractDeletedUsersHidden(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository) {
+	mustCreateUser(t, repo, "alice@example.com", "Alice")
+	deleted := mustCreateUser(t, repo, "bob@example.com", "Bob")
+	if err := repo.Delete(context.Background(), deleted.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	if _, err := repo.GetByID(context.Background(), deleted.ID); err != userEntities.ErrUserNotFound {
+		t.Errorf("GetByID(deleted) error = %v, want %v", err, userEntities.ErrUserNotFound)
+	}
+	if _, err := repo.GetByEmail(context.Background(), deleted.Email); err != userEntities.ErrUserNotFound {
+		t.Errorf("GetByEmail(deleted) error = %v, want %v", err, userEntities.ErrUserNotFound)
+	}
+
+	count, err := repo.Count(context.Background())
+	if err != nil {
+		t.Fatalf("Count returned error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Count = %d, want 1", count)
+	}
+
+	lists := map[string]func() ([]*userEntities.User, error){
+		"GetAll":         func() ([]*userEntities.User, error) { return repo.GetAll(context.Background(), 10, 0) },
+		"GetActiveUsers": func() ([]*userEntities.User, error) { return repo.GetActiveUsers(context.Background()) },
+		"GetUsersByEmailDomain": func() ([]*userEntities.User, error) {
+			return repo.GetUsersByEmailDomain(context.Background(), "example.com")
+		},
+		"GetUsersWithFilters": func() ([]*userEntities.User, error) {
+			return repo.GetUsersWithFilters(context.Background(), 10, 0, "", "")
+		},
+	}
+	for name, list := range lists {
+		users, err := list()
+		if err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+		if len(users) != 1 || users[0].Email != "alice@example.com" {
+			t.Errorf("%s returned %d users, want only alice@example.com", name, len(users))
+		}
+	}
+}
+
+func contractDeleteMissingIsNoop(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository) {
+	if err := repo.Delete(context.Background(), 4242); err != nil {
+		t.Errorf("Delete(missing) error = %v, want nil", err)
+	}
+}
+
+func contractEmailDomain(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository) {
+	mustCreateUser(t, repo, "alice@example.com", "Alice")
+	mustCreateUser(t, repo, "bob@example.org", "Bob")
+	mustCreateUser(t, repo, "carol@sub.example.com", "Carol")
+
+	users, err := repo.GetUsersByEmailDomain(context.Background(), "@example.com")
+	if err != nil {
+		t.Fatalf("GetUsersByEmailDomain returned error: %v", err)
+	}
+	assertEmails(t, users, "alice@example.com")
+
+	users, err = repo.GetUsersByEmailDomain(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("GetUsersByEmailDomain returned error: %v", err)
+	}
+	assertEmails(t, users, "alice@example.com", "carol@sub.example.com")
+}
+
+func contractFilters(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository) {
+	mustCreateUser(t, repo, "alice@example.com", "Alice Smith")
+	mustCreateUser(t, repo, "bob@example.org", "Bob Smith")
+	mustCreateUser(t, repo, "carol@example.com", "Carol Jones")
+
+	tests := []struct {
+		email, name string
+		want        []string
+	}{
+		{"", "", []string{"alice@example.com", "bob@example.org", "carol@example.com"}},
+		{"example.com", "", []string{"alice@example.com", "carol@example.com"}},
+		{"", "Smith", []string{"alice@example.com", "bob@example.org"}},
+		{"example.com", "Smith", []string{"alice@example.com"}},
+		{"nomatch", "", nil},
+	}
+	for _, tt := range tests {
+		users, err := repo.GetUsersWithFilters(context.Background(), 10, 0, tt.email, tt.name)
+		if err != nil {
+			t.Fatalf("GetUsersWithFilters(%q, %q) returned error: %v", tt.email, tt.name, err)
+		}
+		assertEmails(t, users, tt.want...)
+	}
+}
+
+func contractFiltersPaginate(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository) {
+	for i := 0; i < 5; i++ {
+		user := factory.User(factory.WithName("Paged"))
+		if err := repo.Create(context.Background(), user); err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+	}
+
+	firstPage, err := repo.GetUsersWithFilters(context.Background(), 2, 0, "", "Paged")
+	if err != nil {
+		t.Fatalf("GetUsersWithFilters returned error: %v", err)
+	}
+	lastPage, err := repo.GetUsersWithFilters(context.Background(), 2, 4, "", "Paged")
+	if err != nil {
+		t.Fatalf("GetUsersWithFilters returned error: %v", err)
+	}
+	if len(firstPage) != 2 || len(lastPage) != 1 {
+		t.Errorf("pages have %d and %d users, want 2 and 1", len(firstPage), len(lastPage))
+	}
+}
+
+// contractListAfter gives several users the same creation time so pages must break ties by ID
+func contractListAfter(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository) {
+	tie := time.Now().Add(-time.Hour).Truncate(time.Second)
+	var created []uint
+	for i := 0; i < 5; i++ {
+		opts := []factory.UserOption{factory.WithEmail(fmt.Sprintf("user%d@example.com", i)), factory.WithName("Paged")}
+		if i >= 1 && i <= 3 {
+			opts = append(opts, factory.WithCreatedAt(tie))
+		}
+		user := factory.User(opts...)
+		if err := repo.Create(context.Background(), user); err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+		created = append(created, user.ID)
+	}
+	// The tied users were created earliest, so they come first
+	want := append(append(append([]uint{}, created[1:4]...), created[0]), created[4])
+
+	var got []uint
+	cursor := pagination.Cursor{}
+	for page := 0; page < len(want); page++ {
+		users, err := repo.ListAfter(context.Background(), cursor, 2)
+		if err != nil {
+			t.Fatalf("ListAfter returned error: %v", err)
+		}
+		if len(users) == 0 {
+			break
+		}
+		for _, user := range users {
+			got = append(got, user.ID)
+		}
+		last := users[len(users)-1]
+		cursor = pagination.After(last.CreatedAt, last.ID)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ListAfter pages returned IDs %v, want %v", got, want)
+	}
+}
+
+// contractBatchWrites puts a duplicate email in a batch so only that user should fail
+func contractBatchWrites(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository) {
+	mustCreateUser(t, repo, "taken@example.com", "Taken")
+	users := []*userEntities.User{
+		factory.User(factory.WithEmail("a@example.com")),
+		factory.User(factory.WithEmail("taken@example.com")),
+		factory.User(factory.WithEmail("b@example.com")),
+	}
+
+	err := repo.CreateInBatches(context.Background(), users, 2)
+	var batchErr *sharedEntities.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("CreateInBatches error = %v, want a *BatchError", err)
+	}
+	if len(batchErr.Records) != 1 || batchErr.Records[0].Index != 1 {
+		t.Fatalf("CreateInBatches failed records = %+v, want only index 1", batchErr.Records)
+	}
+	if users[0].ID == 0 || users[2].ID == 0 {
+		t.Fatalf("CreateInBatches left IDs %d and %d unassigned", users[0].ID, users[2].ID)
+	}
+
+	users[0].Name, users[2].Name = "Renamed A", "Renamed B"
+	if err := repo.UpdateInBatches(context.Background(), []*userEntities.User{users[0], users[2]}, 2); err != nil {
+		t.Fatalf("UpdateInBatches returned error: %v", err)
+	}
+	found, err := repo.GetByID(context.Background(), users[2].ID)
+	if err != nil {
+		t.Fatalf("GetByID returned error: %v", err)
+	}
+	if found.Name != "Renamed B" {
+		t.Errorf("name after UpdateInBatches = %q, want %q", found.Name, "Renamed B")
+	}
+}
+
+func contractUpsert(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository) {
+	user := factory.User(factory.WithEmail("sync@example.com"), factory.WithName("First"))
+	created, err := repo.Upsert(context.Background(), user)
+	if err != nil {
+		t.Fatalf("Upsert returned error: %v", err)
+	}
+	if !created || user.ID == 0 {
+		t.Fatalf("Upsert of a new email = created %v with ID %d, want created with an ID", created, user.ID)
+	}
+
+	again := factory.User(factory.WithEmail("sync@example.com"), factory.WithName("Second"))
+	created, err = repo.Upsert(context.Background(), again)
+	if err != nil {
+		t.Fatalf("Upsert returned error: %v", err)
+	}
+	if created || again.ID != user.ID || again.PublicID != user.PublicID {
+		t.Errorf("Upsert of a taken email = created %v with ID %d and public ID %q, want updated %d and %q",
+			created, again.ID, again.PublicID, user.ID, user.PublicID)
+	}
+	found, err := repo.GetByID(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("GetByID returned error: %v", err)
+	}
+	if found.Name != "Second" {
+		t.Errorf("name after Upsert = %q, want %q", found.Name, "Second")
+	}
+	if count, _ := repo.Count(context.Background()); count != 1 {
+		t.Errorf("Count after two upserts = %d, want 1", count)
+	}
+}
+
+// contractSoftDeleteScopes requires db to be opened with database.SoftDeleteScope, as
+// database.NewConnection does; it is registered here otherwise
+func contractSoftDeleteScopes(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository) {
+	if _, ok := db.Config.Plugins[database.SoftDeleteScope{}.Name()]; !ok {
+		if err := db.Use(database.SoftDeleteScope{}); err != nil {
+			t.Fatalf("failed to register soft delete scope: %v", err)
+		}
+	}
+	mustCreateUser(t, repo, "alice@example.com", "Alice")
+	deleted := mustCreateUser(t, repo, "bob@example.com", "Bob")
+	if err := repo.Delete(context.Background(), deleted.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	withDeleted := softdelete.WithDeleted(context.Background())
+	if _, err := repo.GetByID(withDeleted, deleted.ID); err != nil {
+		t.Errorf("GetByID(deleted) with deleted error = %v, want nil", err)
+	}
+	users, err := repo.GetAll(withDeleted, 10, 0)
+	if err != nil {
+		t.Fatalf("GetAll returned error: %v", err)
+	}
+	assertEmails(t, users, "alice@example.com", "bob@example.com")
+
+	users, err = repo.GetAll(softdelete.OnlyDeleted(context.Background()), 10, 0)
+	if err != nil {
+		t.Fatalf("GetAll returned error: %v", err)
+	}
+	assertEmails(t, users, "bob@example.com")
+}
+
+func contractPurgeOlderThan(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository) {
+	old := factory.User(factory.WithEmail("alice@example.com"), factory.WithDeletedAt(time.Now().Add(-48*time.Hour)))
+	if err := repo.Create(context.Background(), old); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	recent := mustCreateUser(t, repo, "bob@example.com", "Bob")
+	if err := repo.Delete(context.Background(), recent.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	purged, err := repo.PurgeOlderThan(context.Background(), 24*time.Hour)
+	if err != nil {
+		t.Fatalf("PurgeOlderThan returned error: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("PurgeOlderThan purged %d users, want 1", purged)
+	}
+	if _, err := repo.GetByIDIncludingDeleted(context.Background(), old.ID); err != userEntities.ErrUserNotFound {
+		t.Errorf("GetByIDIncludingDeleted(purged) error = %v, want %v", err, userEntities.ErrUserNotFound)
+	}
+	if _, err := repo.GetByIDIncludingDeleted(context.Background(), recent.ID); err != nil {
+		t.Errorf("GetByIDIncludingDeleted(recently deleted) error = %v, want nil", err)
+	}
+}
+
+// contractTenantsIsolated requires db to be opened with database.TenantScope, as
+// database.NewConnection does; it is registered here otherwise
+func contractTenantsIsolated(t *testing.T, db *gorm.DB, repo userRepositories.UserRepository) {
+	if _, ok := db.Config.Plugins[database.TenantScope{}.Name()]; !ok {
+		if err := db.Use(database.TenantScope{}); err != nil {
+			t.Fatalf("failed to register tenant scope: %v", err)
+		}
+	}
+	acme := tenancy.NewContext(context.Background(), 1)
+	globex := tenancy.NewContext(context.Background(), 2)
+
+	alice := factory.User(factory.WithEmail("alice@example.com"), factory.WithName("Alice"))
+	if err := repo.Create(acme, alice); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	// The same email may be used in another tenant
+	other := factory.User(factory.WithEmail("alice@example.com"), factory.WithName("Other Alice"))
+	if err := repo.Create(globex, other); err != nil {
+		t.Fatalf("Create in another tenant returned error: %v", err)
+	}
+
+	if _, err := repo.GetByID(globex, alice.ID); err != userEntities.ErrUserNotFound {
+		t.Errorf("GetByID from another tenant error = %v, want %v", err, userEntities.ErrUserNotFound)
+	}
+	found, err := repo.GetByEmail(globex, "alice@example.com")
+	if err != nil {
+		t.Fatalf("GetByEmail returned error: %v", err)
+	}
+	if found.ID != other.ID {
+		t.Errorf("GetByEmail returned user %d, want %d", found.ID, other.ID)
+	}
+	count, err := repo.Count(acme)
+	if err != nil {
+		t.Fatalf("Count returned error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Count = %d, want 1", count)
+	}
+
+	if err := repo.Delete(globex, alice.ID); err != nil {
+		t.Fatalf("Delete from another tenant returned error: %v", err)
+	}
+	if _, err := repo.GetByID(acme, alice.ID); err != nil {
+		t.Errorf("GetByID after Delete from another tenant error = %v, want nil", err)
+	}
+
+	// Without a tenant in the context, every tenant's users are seen
+	users, err := repo.GetAll(context.Background(), 10, 0)
+	if err != nil {
+		t.Fatalf("GetAll returned error: %v", err)
+	}
+	if len(users) != 2 {
+		t.Errorf("GetAll without a tenant returned %d users, want 2", len(users))
+	}
+}
+
+// assertEmails checks that users contains exactly the given emails, in any order
+func assertEmails(t *testing.T, users []*userEntities.User, want ...string) {
+	t.Helper()
+
+	got := make(map[string]bool, len(users))
+	for _, user := range users {
+		got[user.Email] = true
+	}
+	if len(got) != len(want) {
+		t.Errorf("got emails %v, want %v", keys(got), want)
+		return
+	}
+	for _, email := range want {
+		if !got[email] {
+			t.Errorf("got emails %v, want %v", keys(got), want)
+			return
+		}
+	}
+}
+
+func keys(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
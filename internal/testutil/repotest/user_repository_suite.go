@@ -0,0 +1,241 @@
+// Package repotest contains conformance suites shared by every repository implementation
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"clean-arch-gin/internal/adapters/shared/models"
+	"clean-arch-gin/internal/domain/shared/publicid"
+	userEntities "clean-arch-gin/internal/domain/user/entities"
+	userRepositories "clean-arch-gin/internal/domain/user/repositories"
+	"clean-arch-gin/internal/testutil/factory"
+
+	"gorm.io/gorm"
+)
+
+// UserRepositoryConstructor builds a UserRepository on top of a database connection
+type UserRepositoryConstructor func(db *gorm.DB) userRepositories.UserRepository
+
+// RunUserRepositorySuite runs the user repository conformance suite against the given implementation
+// The users table is emptied before every case so cases never depend on each other
+func RunUserRepositorySuite(t *testing.T, db *gorm.DB, newRepo UserRepositoryConstructor) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, repo userRepositories.UserRepository)
+	}{
+		{"CreateAssignsID", testCreateAssignsID},
+		{"GetByID", testGetByID},
+		{"GetByIDsSkipsMissingAndDeleted", testGetByIDs},
+		{"GetByPublicIDIncludesDeleted", testGetByPublicID},
+		{"GetByEmail", testGetByEmail},
+		{"GetAllPaginates", testGetAllPaginates},
+		{"CreateTakenEmailIsErrEmailExists", testCreateTakenEmail},
+		{"Update", testUpdate},
+		{"UpdateTakenEmailIsErrEmailExists", testUpdateTakenEmail},
+		{"Delete", testDelete},
+		{"Count", testCount},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ResetUsers(t, db)
+			tc.run(t, newRepo(db))
+		})
+	}
+}
+
+// ResetUsers permanently removes every row from the users table
+func ResetUsers(t testing.TB, db *gorm.DB) {
+	t.Helper()
+	err := db.Session(&gorm.Session{AllowGlobalUpdate: true}).Unscoped().Delete(&models.UserModel{}).Error
+	if err != nil {
+		t.Fatalf("failed to reset users table: %v", err)
+	}
+}
+
+// mustCreateUser persists a valid user and fails the test on error
+func mustCreateUser(t *testing.T, repo userRepositories.UserRepository, email, name string) *userEntities.User {
+	t.Helper()
+	user := factory.User(factory.WithEmail(email), factory.WithName(name))
+	if err := repo.Create(context.Background(), user); err != nil {
+		t.Fatalf("failed to create user %s: %v", email, err)
+	}
+	return user
+}
+
+func testCreateAssignsID(t *testing.T, repo userRepositories.UserRepository) {
+	user := mustCreateUser(t, repo, "alice@example.com", "Alice")
+	if user.ID == 0 {
+		t.Fatal("expected Create to assign an ID")
+	}
+}
+
+func testGetByID(t *testing.T, repo userRepositories.UserRepository) {
+	created := mustCreateUser(t, repo, "alice@example.com", "Alice")
+
+	found, err := repo.GetByID(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("GetByID returned error: %v", err)
+	}
+	if found.Email != created.Email || found.Name != created.Name {
+		t.Errorf("GetByID = %+v, want email %q and name %q", found, created.Email, created.Name)
+	}
+
+	if _, err := repo.GetByID(context.Background(), created.ID+1000); err != userEntities.ErrUserNotFound {
+		t.Errorf("GetByID(missing) error = %v, want %v", err, userEntities.ErrUserNotFound)
+	}
+}
+
+func testGetByIDs(t *testing.T, repo userRepositories.UserRepository) {
+	alice := mustCreateUser(t, repo, "alice@example.com", "Alice")
+	bob := mustCreateUser(t, repo, "bob@example.com", "Bob")
+	carol := mustCreateUser(t, repo, "carol@example.com", "Carol")
+	if err := repo.Delete(context.Background(), carol.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	found, err := repo.GetByIDs(context.Background(), []uint{alice.ID, bob.ID, carol.ID, bob.ID + 1000})
+	if err != nil {
+		t.Fatalf("GetByIDs returned error: %v", err)
+	}
+	assertEmails(t, found, "alice@example.com", "bob@example.com")
+
+	empty, err := repo.GetByIDs(context.Background(), nil)
+	if err != nil || len(empty) != 0 {
+		t.Errorf("GetByIDs(nil) = %v, %v; want no users and no error", empty, err)
+	}
+}
+
+func testGetByPublicID(t *testing.T, repo userRepositories.UserRepository) {
+	created := mustCreateUser(t, repo, "alice@example.com", "Alice")
+	if err := repo.Delete(context.Background(), created.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	found, err := repo.GetByPublicID(context.Background(), created.PublicID)
+	if err != nil {
+		t.Fatalf("GetByPublicID returned error: %v", err)
+	}
+	if found.ID != created.ID || found.PublicID != created.PublicID {
+		t.Errorf("GetByPublicID = %+v, want ID %d and public ID %q", found, created.ID, created.PublicID)
+	}
+
+	if _, err := repo.GetByPublicID(context.Background(), publicid.New()); err != userEntities.ErrUserNotFound {
+		t.Errorf("GetByPublicID(missing) error = %v, want %v", err, userEntities.ErrUserNotFound)
+	}
+}
+
+func testGetByEmail(t *testing.T, repo userRepositories.UserRepository) {
+	mustCreateUser(t, repo, "alice@example.com", "Alice")
+	mustCreateUser(t, repo, "bob@example.com", "Bob")
+
+	found, err := repo.GetByEmail(context.Background(), "bob@example.com")
+	if err != nil {
+		t.Fatalf("GetByEmail returned error: %v", err)
+	}
+	if found.Name != "Bob" {
+		t.Errorf("GetByEmail name = %q, want %q", found.Name, "Bob")
+	}
+
+	if _, err := repo.GetByEmail(context.Background(), "missing@example.com"); err != userEntities.ErrUserNotFound {
+		t.Errorf("GetByEmail(missing) error = %v, want %v", err, userEntities.ErrUserNotFound)
+	}
+}
+
+func testGetAllPaginates(t *testing.T, repo userRepositories.UserRepository) {
+	mustCreateUser(t, repo, "alice@example.com", "Alice")
+	mustCreateUser(t, repo, "bob@example.com", "Bob")
+	mustCreateUser(t, repo, "carol@example.com", "Carol")
+
+	users, err := repo.GetAll(context.Background(), 2, 0)
+	if err != nil {
+		t.Fatalf("GetAll returned error: %v", err)
+	}
+	if len(users) != 2 {
+		t.Errorf("GetAll(2, 0) returned %d users, want 2", len(users))
+	}
+
+	users, err = repo.GetAll(context.Background(), 2, 2)
+	if err != nil {
+		t.Fatalf("GetAll returned error: %v", err)
+	}
+	if len(users) != 1 {
+		t.Errorf("GetAll(2, 2) returned %d users, want 1", len(users))
+	}
+}
+
+func testUpdate(t *testing.T, repo userRepositories.UserRepository) {
+	user := mustCreateUser(t, repo, "alice@example.com", "Alice")
+
+	user.UpdateInfo("Alice Smith", "alice.smith@example.com")
+	if err := repo.Update(context.Background(), user); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+
+	found, err := repo.GetByID(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("GetByID returned error: %v", err)
+	}
+	if found.Name != "Alice Smith" || found.Email != "alice.smith@example.com" {
+		t.Errorf("after Update got %+v", found)
+	}
+}
+
+// testCreateTakenEmail checks the unique email index surfaces as ErrEmailExists, as it does
+// when a concurrent sign-up passed the use case's availability check first
+func testCreateTakenEmail(t *testing.T, repo userRepositories.UserRepository) {
+	mustCreateUser(t, repo, "alice@example.com", "Alice")
+
+	duplicate := factory.User(factory.WithEmail("alice@example.com"), factory.WithName("Another Alice"))
+	if err := repo.Create(context.Background(), duplicate); err != userEntities.ErrEmailExists {
+		t.Fatalf("Create(taken email) error = %v, want %v", err, userEntities.ErrEmailExists)
+	}
+	if n, err := repo.Count(context.Background()); err != nil || n != 1 {
+		t.Errorf("Count() = %d, %v after the rejected Create, want 1", n, err)
+	}
+}
+
+// testUpdateTakenEmail checks the unique email index surfaces as ErrEmailExists on Update and
+// leaves the stored user unchanged
+func testUpdateTakenEmail(t *testing.T, repo userRepositories.UserRepository) {
+	mustCreateUser(t, repo, "alice@example.com", "Alice")
+	bob := mustCreateUser(t, repo, "bob@example.com", "Bob")
+
+	bob.UpdateInfo("Bob", "alice@example.com")
+	if err := repo.Update(context.Background(), bob); err != userEntities.ErrEmailExists {
+		t.Fatalf("Update(taken email) error = %v, want %v", err, userEntities.ErrEmailExists)
+	}
+
+	found, err := repo.GetByID(context.Background(), bob.ID)
+	if err != nil {
+		t.Fatalf("GetByID returned error: %v", err)
+	}
+	if found.Email != "bob@example.com" {
+		t.Errorf("email after the rejected Update = %q, want %q", found.Email, "bob@example.com")
+	}
+}
+
+func testDelete(t *testing.T, repo userRepositories.UserRepository) {
+	user := mustCreateUser(t, repo, "alice@example.com", "Alice")
+
+	if err := repo.Delete(context.Background(), user.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if _, err := repo.GetByID(context.Background(), user.ID); err != userEntities.ErrUserNotFound {
+		t.Errorf("GetByID(deleted) error = %v, want %v", err, userEntities.ErrUserNotFound)
+	}
+}
+
+func testCount(t *testing.T, repo userRepositories.UserRepository) {
+	mustCreateUser(t, repo, "alice@example.com", "Alice")
+	mustCreateUser(t, repo, "bob@example.com", "Bob")
+
+	count, err := repo.Count(context.Background())
+	if err != nil {
+		t.Fatalf("Count returned error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Count = %d, want 2", count)
+	}
+}
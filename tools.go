@@ -0,0 +1,8 @@
+//go:build tools
+
+// Package tools pins the code generators run with go run, so go.mod tracks their versions
+package tools
+
+import (
+	_ "gorm.io/gen"
+)